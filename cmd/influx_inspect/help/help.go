@@ -39,6 +39,7 @@ The commands are:
     help                 display this help message
     report               displays a shard level cardinality report
     report-disk          displays a shard level disk usage report
+    report-seriesfile    reports space series file garbage collection can reclaim
     reporttsi            reports series cardinality in one or more TSI indexes.
     verify               verifies integrity of TSM files
     verify-seriesfile    verifies integrity of the Series file
@@ -17,6 +17,7 @@ import (
 	"github.com/influxdata/influxdb/cmd/influx_inspect/help"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/report"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/reportdisk"
+	"github.com/influxdata/influxdb/cmd/influx_inspect/reportseriesfile"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/reporttsi"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/verify/seriesfile"
 	"github.com/influxdata/influxdb/cmd/influx_inspect/verify/tombstone"
@@ -104,6 +105,11 @@ func (m *Main) Run(args ...string) error {
 		if err := name.Run(args...); err != nil {
 			return fmt.Errorf("report: %s", err)
 		}
+	case "report-seriesfile":
+		name := reportseriesfile.NewCommand()
+		if err := name.Run(args...); err != nil {
+			return fmt.Errorf("report-seriesfile: %s", err)
+		}
 	case "reporttsi":
 		name := reporttsi.NewCommand()
 		if err := name.Run(args...); err != nil {
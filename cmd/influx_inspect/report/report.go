@@ -29,6 +29,7 @@ type Command struct {
 	dir             string
 	pattern         string
 	detailed, exact bool
+	encodings       bool
 }
 
 // NewCommand returns a new instance of Command.
@@ -45,6 +46,7 @@ func (cmd *Command) Run(args ...string) error {
 	fs.StringVar(&cmd.pattern, "pattern", "", "Include only files matching a pattern")
 	fs.BoolVar(&cmd.detailed, "detailed", false, "Report detailed cardinality estimates")
 	fs.BoolVar(&cmd.exact, "exact", false, "Report exact counts")
+	fs.BoolVar(&cmd.encodings, "encodings", false, "Report the size of blocks by encoding")
 
 	fs.SetOutput(cmd.Stdout)
 	fs.Usage = cmd.printUsage
@@ -74,6 +76,8 @@ func (cmd *Command) Run(args ...string) error {
 
 	dbCardinalities := map[string]counter{}
 
+	encodings := map[string]*encodingStats{}
+
 	start := time.Now()
 
 	tw := tabwriter.NewWriter(cmd.Stdout, 8, 2, 1, ' ', 0)
@@ -143,6 +147,12 @@ func (cmd *Command) Run(args ...string) error {
 				}
 			}
 		}
+		if cmd.encodings {
+			if err := addEncodingStats(encodings, reader); err != nil {
+				fmt.Fprintf(cmd.Stderr, "error: %s: %v. Skipping encodings.\n", file.Name(), err)
+			}
+		}
+
 		minT, maxT := reader.TimeRange()
 		if minT < minTime {
 			minTime = minT
@@ -205,6 +215,10 @@ func (cmd *Command) Run(args ...string) error {
 		}
 	}
 
+	if cmd.encodings {
+		printEncodingStats(encodings)
+	}
+
 	fmt.Printf("Completed in %s\n", time.Since(start))
 	return nil
 }
@@ -233,11 +247,76 @@ Usage: influx_inspect report [flags]
     -detailed
             Report detailed cardinality estimates.
             Defaults to "false".
+    -encodings
+            Report the number and size of blocks by encoding, and the bytes saved
+            by blocks written with version 2 encodings.  Note: this reads every block.
+            Defaults to "false".
 `
 
 	fmt.Fprintf(cmd.Stdout, usage)
 }
 
+// encodingStats are the totals of the blocks of an encoding.
+type encodingStats struct {
+	blocks  int
+	bytes   int64
+	v1Bytes int64 // size of the blocks with version 1 encodings
+}
+
+// addEncodingStats adds each block of r to the stats of its encoding.
+func addEncodingStats(stats map[string]*encodingStats, r *tsm1.TSMReader) error {
+	iter := r.BlockIterator()
+	for iter.Next() {
+		_, _, _, _, _, buf, err := iter.Read()
+		if err != nil {
+			return err
+		}
+		name, err := tsm1.BlockEncodingName(buf)
+		if err != nil {
+			return err
+		}
+		v1Size, err := tsm1.BlockSizeV1(buf)
+		if err != nil {
+			return err
+		}
+
+		s := stats[name]
+		if s == nil {
+			s = &encodingStats{}
+			stats[name] = s
+		}
+		s.blocks++
+		s.bytes += int64(len(buf))
+		s.v1Bytes += int64(v1Size)
+	}
+	return iter.Err()
+}
+
+// printEncodingStats prints the stats of each encoding and the bytes saved by
+// version 2 encodings.
+func printEncodingStats(stats map[string]*encodingStats) {
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bytes, v1Bytes int64
+	fmt.Printf("\n  Encodings:\n")
+	for _, name := range names {
+		s := stats[name]
+		fmt.Printf("    - %s: %d blocks, %d bytes\n", name, s.blocks, s.bytes)
+		bytes += s.bytes
+		v1Bytes += s.v1Bytes
+	}
+
+	var pct float64
+	if v1Bytes > 0 {
+		pct = float64(v1Bytes-bytes) / float64(v1Bytes) * 100
+	}
+	fmt.Printf("  Saved by version 2 encodings: %d bytes (%.1f%%)\n", v1Bytes-bytes, pct)
+}
+
 // counter abstracts a a method of counting keys.
 type counter interface {
 	Add(key []byte)
@@ -0,0 +1,211 @@
+// Package reportseriesfile reports the space series file garbage collection
+// can reclaim.
+package reportseriesfile
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+// Command represents the program execution for "influx_inspect report-seriesfile".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+
+	dir string
+	db  string
+}
+
+// NewCommand returns a new instance of Command.
+func NewCommand() *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// Run executes the command.
+func (cmd *Command) Run(args ...string) error {
+	fs := flag.NewFlagSet("report-seriesfile", flag.ExitOnError)
+	fs.StringVar(&cmd.dir, "dir", filepath.Join(os.Getenv("HOME"), ".influxdb", "data"), "Data directory.")
+	fs.StringVar(&cmd.db, "db", "", "Only report on this database inside of the data directory.")
+
+	fs.SetOutput(cmd.Stdout)
+	fs.Usage = cmd.printUsage
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dbs := []string{cmd.db}
+	if cmd.db == "" {
+		fis, err := os.ReadDir(cmd.dir)
+		if err != nil {
+			return err
+		}
+		dbs = dbs[:0]
+		for _, fi := range fis {
+			if fi.IsDir() {
+				dbs = append(dbs, fi.Name())
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(cmd.Stdout, 8, 8, 1, '\t', 0)
+	fmt.Fprintln(tw, "Database\tSize\tUnreferenced Series\tReclaimable Entries\tReclaimable Bytes")
+	var notes []string
+	for _, db := range dbs {
+		sfilePath := filepath.Join(cmd.dir, db, tsdb.SeriesFileDirectory)
+		if _, err := os.Stat(sfilePath); os.IsNotExist(err) {
+			continue
+		}
+
+		r, err := reportDatabase(filepath.Join(cmd.dir, db), sfilePath)
+		if err != nil {
+			return fmt.Errorf("%s: %s", db, err)
+		}
+
+		unreferenced := "-"
+		if r.unknown == "" {
+			unreferenced = strconv.FormatInt(r.stats.SeriesN, 10)
+		} else {
+			notes = append(notes, fmt.Sprintf("%s: unreferenced series not counted: %s", db, r.unknown))
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%d\n", db, r.size, unreferenced, r.stats.EntryN, r.stats.Bytes)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		fmt.Fprintln(cmd.Stdout, note)
+	}
+	return nil
+}
+
+// report is the reclaimable space in a database's series file.
+type report struct {
+	size  int64
+	stats tsdb.SeriesGCStats
+
+	// unknown explains why the unreferenced series could not be found.
+	unknown string
+}
+
+// reportDatabase returns the space reclaimable from the series file at
+// sfilePath by deleting the series no shard of the database references and
+// compacting its segments.
+func reportDatabase(dbPath, sfilePath string) (*report, error) {
+	sfile := tsdb.NewSeriesFile(sfilePath)
+	if err := sfile.Open(); err != nil {
+		return nil, err
+	}
+	defer sfile.Close()
+
+	r := &report{}
+	var err error
+	if r.size, err = sfile.FileSize(); err != nil {
+		return nil, err
+	}
+
+	referenced, err := referencedSeriesIDs(dbPath, sfile)
+	if errors.Is(err, errNotTSI) {
+		r.unknown = err.Error()
+	} else if err != nil {
+		return nil, err
+	}
+
+	var unreferenced []uint64
+	var extra *tsdb.SeriesIDSet
+	if referenced != nil {
+		if unreferenced, err = sfile.UnreferencedSeriesIDs(referenced); err != nil {
+			return nil, err
+		}
+		extra = tsdb.NewSeriesIDSet(unreferenced...)
+	}
+
+	if r.stats, err = sfile.Reclaimable(extra); err != nil {
+		return nil, err
+	}
+	r.stats.SeriesN = int64(len(unreferenced))
+	return r, nil
+}
+
+var errNotTSI = errors.New("shard does not use the tsi1 index")
+
+// referencedSeriesIDs returns the union of the series in the tsi1 indexes of
+// the database's shards. Shards using the inmem index have no index on disk,
+// so errNotTSI is returned if there are any.
+func referencedSeriesIDs(dbPath string, sfile *tsdb.SeriesFile) (*tsdb.SeriesIDSet, error) {
+	var paths []string
+	rps, err := os.ReadDir(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, rp := range rps {
+		if !rp.IsDir() || rp.Name() == tsdb.SeriesFileDirectory {
+			continue
+		}
+		shards, err := os.ReadDir(filepath.Join(dbPath, rp.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, sh := range shards {
+			if _, err := strconv.ParseUint(sh.Name(), 10, 64); err != nil || !sh.IsDir() {
+				continue
+			}
+			paths = append(paths, filepath.Join(dbPath, rp.Name(), sh.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	referenced := tsdb.NewSeriesIDSet()
+	for _, path := range paths {
+		indexPath := filepath.Join(path, "index")
+		if ok, err := tsi1.IsIndexDir(indexPath); os.IsNotExist(err) || (err == nil && !ok) {
+			return nil, fmt.Errorf("%w: %s", errNotTSI, path)
+		} else if err != nil {
+			return nil, err
+		}
+
+		idx := tsi1.NewIndex(sfile, "", tsi1.WithPath(indexPath), tsi1.DisableCompactions())
+		if err := idx.Open(); err != nil {
+			return nil, err
+		}
+		referenced.Merge(idx.SeriesIDSet())
+		if err := idx.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return referenced, nil
+}
+
+func (cmd *Command) printUsage() {
+	usage := `Reports the space series file garbage collection can reclaim.
+
+Usage: influx_inspect report-seriesfile [flags]
+
+    -dir <path>
+            Root data path.
+            Defaults to "%[1]s/.influxdb/data".
+    -db <name>
+            Only report on this database inside of the data directory.
+
+Unreferenced series are those no shard of the database references. They can
+only be counted when every shard uses the tsi1 index. Reclaimable entries and
+bytes are those of deleted and unreferenced series outside the active segment
+of each series file partition.
+`
+
+	fmt.Fprintf(cmd.Stdout, usage, os.Getenv("HOME"))
+}
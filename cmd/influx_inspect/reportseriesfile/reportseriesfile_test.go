@@ -0,0 +1,57 @@
+package reportseriesfile_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/cmd/influx_inspect/reportseriesfile"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/index/tsi1"
+)
+
+func TestCommand_Run(t *testing.T) {
+	dir := t.TempDir()
+	sfile := tsdb.NewSeriesFile(filepath.Join(dir, "db0", tsdb.SeriesFileDirectory))
+	if err := sfile.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer sfile.Close()
+
+	// One series referenced by a shard index, and one only in the series file.
+	idx := tsi1.NewIndex(sfile, "db0", tsi1.WithPath(filepath.Join(dir, "db0", "rp0", "1", "index")))
+	if err := idx.Open(); err != nil {
+		t.Fatal(err)
+	}
+	name, tags := []byte("cpu"), models.NewTags(map[string]string{"host": "a"})
+	if err := idx.CreateSeriesListIfNotExists([][]byte{models.MakeKey(name, tags)}, [][]byte{name}, []models.Tags{tags}); err != nil {
+		t.Fatal(err)
+	} else if err := idx.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sfile.CreateSeriesListIfNotExists([][]byte{[]byte("mem")}, []models.Tags{nil}); err != nil {
+		t.Fatal(err)
+	} else if err := sfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	cmd := reportseriesfile.NewCommand()
+	cmd.Stdout = &buf
+	if err := cmd.Run("-dir", dir); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) != 5 || fields[0] != "db0" {
+		t.Fatalf("unexpected row: %q", lines[1])
+	} else if fields[2] != "1" {
+		t.Fatalf("unexpected unreferenced series: %s", fields[2])
+	}
+}
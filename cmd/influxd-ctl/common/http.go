@@ -227,6 +227,209 @@ func (c *HTTPClient) TruncateShards(delay time.Duration) error {
 	return parseStatusNoContent(resp)
 }
 
+func (c *HTTPClient) RebuildIndex(shard uint64, db string) error {
+	data := url.Values{}
+	if shard != 0 {
+		data.Set("shard", strconv.FormatUint(shard, 10))
+	} else {
+		data.Set("db", db)
+	}
+	resp, err := c.PostForm("/rebuild-index", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowCompactions(shard uint64, v interface{}) error {
+	path := "/show-compactions"
+	if shard != 0 {
+		path += "?shard=" + strconv.FormatUint(shard, 10)
+	}
+	resp, err := c.Get(path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
+func (c *HTTPClient) Compact(shard uint64, action string) error {
+	data := url.Values{"shard": {strconv.FormatUint(shard, 10)}, "action": {action}}
+	resp, err := c.PostForm("/compact", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) CreateRollupRule(db, rp, name string, after, interval time.Duration) error {
+	data := url.Values{"db": {db}, "rp": {rp}, "name": {name}, "after": {after.String()}, "interval": {interval.String()}}
+	resp, err := c.PostForm("/create-rollup-rule", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) DropRollupRule(db, rp, name string) error {
+	data := url.Values{"db": {db}, "rp": {rp}, "name": {name}}
+	resp, err := c.PostForm("/drop-rollup-rule", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowRollupRules(v interface{}) error {
+	resp, err := c.Get("/show-rollup-rules")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
+func (c *HTTPClient) Reshard(db, rp string, duration time.Duration) error {
+	data := url.Values{"db": {db}, "rp": {rp}, "duration": {duration.String()}}
+	resp, err := c.PostForm("/reshard", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) DropReshard(db, rp string) error {
+	data := url.Values{"db": {db}, "rp": {rp}}
+	resp, err := c.PostForm("/drop-reshard", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowReshards(v interface{}) error {
+	resp, err := c.Get("/show-reshards")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
+func (c *HTTPClient) SetReplication(db, rp, mode string) error {
+	data := url.Values{"db": {db}, "rp": {rp}, "mode": {mode}}
+	resp, err := c.PostForm("/set-replication", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) SetShardLeader(id uint64, addr string) error {
+	data := url.Values{"shard": {strconv.FormatUint(id, 10)}, "node": {addr}}
+	resp, err := c.PostForm("/set-shard-leader", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowReplication(v interface{}) error {
+	resp, err := c.Get("/show-replication")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
+func (c *HTTPClient) CreateMeasurementSchema(db, name, fields, requiredTags, allowedTags, mode string) error {
+	data := url.Values{"db": {db}, "name": {name}, "fields": {fields}, "required-tags": {requiredTags}, "allowed-tags": {allowedTags}, "mode": {mode}}
+	resp, err := c.PostForm("/create-measurement-schema", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) DropMeasurementSchema(db, name string) error {
+	data := url.Values{"db": {db}, "name": {name}}
+	resp, err := c.PostForm("/drop-measurement-schema", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowMeasurementSchemas(v interface{}) error {
+	resp, err := c.Get("/show-measurement-schemas")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
+func (c *HTTPClient) SetCardinalityLimits(db string, maxSeriesPerMeasurement, maxValuesPerTag int64, measurements, tagKeys string) error {
+	data := url.Values{
+		"db":                         {db},
+		"max-series-per-measurement": {strconv.FormatInt(maxSeriesPerMeasurement, 10)},
+		"max-values-per-tag":         {strconv.FormatInt(maxValuesPerTag, 10)},
+		"measurements":               {measurements},
+		"tag-keys":                   {tagKeys},
+	}
+	resp, err := c.PostForm("/set-cardinality-limits", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowCardinalityLimits(v interface{}) error {
+	resp, err := c.Get("/show-cardinality-limits")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
+func (c *HTTPClient) SetDiskQuota(db, rp string, soft, hard int64) error {
+	data := url.Values{
+		"db":   {db},
+		"rp":   {rp},
+		"soft": {strconv.FormatInt(soft, 10)},
+		"hard": {strconv.FormatInt(hard, 10)},
+	}
+	resp, err := c.PostForm("/set-disk-quota", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusNoContent(resp)
+}
+
+func (c *HTTPClient) ShowDiskQuotas(v interface{}) error {
+	resp, err := c.Get("/show-disk-quotas")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return parseStatusOK(resp, v)
+}
+
 func (c *HTTPClient) Status(addr string, v interface{}) error {
 	resp, err := c.GetWithAddr(addr, "/status")
 	if err != nil {
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// WriteDiskQuotas writes the disk quotas and their usage on each data node
+// as a table.
+func WriteDiskQuotas(w io.Writer, quotas []meta.ClusterDiskQuotaInfo) {
+	fmt.Fprintln(w, "Disk Quotas")
+	fmt.Fprintln(w, "===========")
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Database", "Retention Policy", "Soft Limit", "Hard Limit", "Node", "TCPAddr", "Used", "Status"}, "\t"))
+	for _, q := range quotas {
+		if len(q.Usage) == 0 {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t-\t-\t-\t-\n", q.Database, q.RetentionPolicy, formatLimit(q.SoftLimit), formatLimit(q.HardLimit))
+			continue
+		}
+		for _, u := range q.Usage {
+			status := "ok"
+			if q.HardLimit > 0 && u.Bytes > q.HardLimit {
+				status = "hard limit exceeded"
+			} else if q.SoftLimit > 0 && u.Bytes > q.SoftLimit {
+				status = "soft limit exceeded"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%d\t%s\n", q.Database, q.RetentionPolicy,
+				formatLimit(q.SoftLimit), formatLimit(q.HardLimit), u.NodeID, u.TCPAddr, u.Bytes, status)
+		}
+	}
+	tw.Flush()
+}
+
+// formatLimit formats a quota limit in bytes, or "-" if not set.
+func formatLimit(n int64) string {
+	if n == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,114 @@
+package compact
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl compact".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	shard  uint64
+	pause  bool
+	resume bool
+	full   bool
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	if cmd.shard == 0 {
+		return errors.New("-shard is required")
+	}
+
+	var action string
+	n := 0
+	for _, f := range []struct {
+		set    bool
+		action string
+	}{{cmd.pause, "pause"}, {cmd.resume, "resume"}, {cmd.full, "full"}} {
+		if f.set {
+			action = f.action
+			n++
+		}
+	}
+	if n != 1 {
+		return errors.New("exactly one of -pause, -resume or -full is required")
+	}
+	err = cmd.compact(action)
+	return common.OperationExitedError(err)
+}
+
+// pauses, resumes or schedules a full compaction of a shard.
+func (cmd *Command) compact(action string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.Compact(cmd.shard, action); err != nil {
+		return err
+	}
+	switch action {
+	case "pause":
+		fmt.Fprintf(cmd.Stdout, "Paused compactions of shard %d\n", cmd.shard)
+	case "resume":
+		fmt.Fprintf(cmd.Stdout, "Resumed compactions of shard %d\n", cmd.shard)
+	case "full":
+		fmt.Fprintf(cmd.Stdout, "Scheduled a full compaction of shard %d\n", cmd.shard)
+	}
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Uint64Var(&cmd.shard, "shard", 0, "ID of the shard")
+	fs.BoolVar(&cmd.pause, "pause", false, "pause compactions of the shard")
+	fs.BoolVar(&cmd.resume, "resume", false, "resume compactions of the shard")
+	fs.BoolVar(&cmd.full, "full", false, "schedule a full compaction of the shard")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] compact -shard ID (-pause | -resume | -full)
+    Controls the compactions of a shard on each data node that owns it.
+    Pausing aborts running compactions and stops new ones until resumed,
+    including across restarts. Snapshots of the cache continue while paused.
+    A full compaction starts regardless of the compact-full-windows setting.
+
+Options:
+  -shard uint
+    	ID of the shard
+  -pause
+    	pause compactions of the shard
+  -resume
+    	resume compactions of the shard
+  -full
+    	schedule a full compaction of the shard
+`
@@ -79,7 +79,8 @@ func (cmd *Command) parseFlags(args []string) ([]string, error) {
 
 const usage = `
 Usage: influxd-ctl copy-shard <srcAddr> <destAddr> <shardID>
-    Copies a shard from src to dest
+    Copies a shard from src to dest. If dest holds a quarantined copy of
+    the shard, it is replaced and the quarantine is cleared.
 
 Arguments:
     <srcAddr> is the TCP bind address of the source data node.
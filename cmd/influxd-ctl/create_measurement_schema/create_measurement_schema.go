@@ -0,0 +1,106 @@
+package create_measurement_schema
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl create-measurement-schema".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	database     string
+	fields       string
+	requiredTags string
+	allowedTags  string
+	mode         string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("missing measurement name")
+	} else if len(args) > 1 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[1:])
+	}
+	if cmd.database == "" {
+		return errors.New("-db is required")
+	}
+	err = cmd.createMeasurementSchema(args[0])
+	return common.OperationExitedError(err)
+}
+
+// creates a measurement schema.
+func (cmd *Command) createMeasurementSchema(name string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.CreateMeasurementSchema(cmd.database, name, cmd.fields, cmd.requiredTags, cmd.allowedTags, cmd.mode); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Created measurement schema %s on %s\n", name, cmd.database)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.database, "db", "", "database of the measurement")
+	fs.StringVar(&cmd.fields, "fields", "", "comma separated name:type fields")
+	fs.StringVar(&cmd.requiredTags, "required-tags", "", "comma separated tag keys every point must have")
+	fs.StringVar(&cmd.allowedTags, "allowed-tags", "", "comma separated tag keys points may have besides the required ones")
+	fs.StringVar(&cmd.mode, "mode", "strict", "strict or coerce")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] create-measurement-schema -db DB [-fields FIELDS] [-required-tags TAGS] [-allowed-tags TAGS] [-mode MODE] <measurement>
+    Creates the schema of a measurement. Points written to the measurement
+    that don't conform to the schema are rejected, and reported in the error
+    of the write.
+
+    FIELDS is a list of name:type, where type is float, integer, unsigned,
+    string or boolean. When fields are given, points may only have these
+    fields. In coerce mode, numeric values are converted to the type of
+    their field when that can be done without loss; in strict mode they
+    are rejected.
+
+    Points must have every tag of the required tags and, when allowed tags
+    are given, no other tags than the required and allowed ones.
+
+Options:
+  -allowed-tags string
+    	comma separated tag keys points may have besides the required ones
+  -db string
+    	database of the measurement
+  -fields string
+    	comma separated name:type fields
+  -mode string
+    	strict or coerce (default "strict")
+  -required-tags string
+    	comma separated tag keys every point must have
+`
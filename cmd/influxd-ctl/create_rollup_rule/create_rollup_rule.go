@@ -0,0 +1,97 @@
+package create_rollup_rule
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl create-rollup-rule".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	database        string
+	retentionPolicy string
+	after           time.Duration
+	interval        time.Duration
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("missing rule name")
+	} else if len(args) > 1 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[1:])
+	}
+	if cmd.database == "" || cmd.retentionPolicy == "" {
+		return errors.New("-db and -rp are required")
+	}
+	err = cmd.createRollupRule(args[0])
+	return common.OperationExitedError(err)
+}
+
+// creates a rollup rule.
+func (cmd *Command) createRollupRule(name string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.CreateRollupRule(cmd.database, cmd.retentionPolicy, name, cmd.after, cmd.interval); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Created rollup rule %s on %s.%s\n", name, cmd.database, cmd.retentionPolicy)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.database, "db", "", "database of the retention policy")
+	fs.StringVar(&cmd.retentionPolicy, "rp", "", "retention policy to roll up")
+	fs.DurationVar(&cmd.after, "after", 0, "age of a shard group's end time before it is rolled up")
+	fs.DurationVar(&cmd.interval, "interval", 0, "downsampling interval")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] create-rollup-rule -db DB -rp RP -after AGE -interval INTERVAL <name>
+    Creates a rollup rule on a retention policy. Shards whose group ended more
+    than AGE ago are downsampled in place to one point per INTERVAL. Each field
+    keeps the mean of the window; the min, max and count are written to the
+    <field>_min, <field>_max and <field>_count fields, which min(), max() and
+    count() read. sum() and mean() weight the means by the counts and other
+    aggregates read the means.
+
+Options:
+  -after duration
+    	age of a shard group's end time before it is rolled up
+  -db string
+    	database of the retention policy
+  -interval duration
+    	downsampling interval
+  -rp string
+    	retention policy to roll up
+`
@@ -0,0 +1,80 @@
+package drop_measurement_schema
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl drop-measurement-schema".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	database string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("missing measurement name")
+	} else if len(args) > 1 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[1:])
+	}
+	if cmd.database == "" {
+		return errors.New("-db is required")
+	}
+	err = cmd.dropMeasurementSchema(args[0])
+	return common.OperationExitedError(err)
+}
+
+// drops a measurement schema.
+func (cmd *Command) dropMeasurementSchema(name string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.DropMeasurementSchema(cmd.database, name); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Dropped measurement schema %s from %s\n", name, cmd.database)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.database, "db", "", "database of the measurement")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] drop-measurement-schema -db DB <measurement>
+    Drops the schema of a measurement. Points written to the measurement
+    are no longer checked.
+
+Options:
+  -db string
+    	database of the measurement
+`
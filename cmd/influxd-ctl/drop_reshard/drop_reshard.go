@@ -0,0 +1,71 @@
+package drop_reshard
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl drop-reshard".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) < 2 {
+		return errors.New("missing database or retention policy")
+	} else if len(args) > 2 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[2:])
+	}
+	err = cmd.dropReshard(args[0], args[1])
+	return common.OperationExitedError(err)
+}
+
+// stops resharding a retention policy.
+func (cmd *Command) dropReshard(db, rp string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.DropReshard(db, rp); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Dropped resharding of %s.%s\n", db, rp)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] drop-reshard <db> <rp>
+    Stops resharding a retention policy. Shard groups already resharded are
+    kept, the others are left as they are and the new shard groups not yet
+    copied are deleted.
+`
@@ -0,0 +1,84 @@
+package drop_rollup_rule
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl drop-rollup-rule".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	database        string
+	retentionPolicy string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("missing rule name")
+	} else if len(args) > 1 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[1:])
+	}
+	if cmd.database == "" || cmd.retentionPolicy == "" {
+		return errors.New("-db and -rp are required")
+	}
+	err = cmd.dropRollupRule(args[0])
+	return common.OperationExitedError(err)
+}
+
+// drops a rollup rule.
+func (cmd *Command) dropRollupRule(name string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.DropRollupRule(cmd.database, cmd.retentionPolicy, name); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Dropped rollup rule %s from %s.%s\n", name, cmd.database, cmd.retentionPolicy)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.database, "db", "", "database of the retention policy")
+	fs.StringVar(&cmd.retentionPolicy, "rp", "", "retention policy of the rule")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] drop-rollup-rule -db DB -rp RP <name>
+    Drops a rollup rule from a retention policy. Shards already rolled up
+    are left as they are.
+
+Options:
+  -db string
+    	database of the retention policy
+  -rp string
+    	retention policy of the rule
+`
@@ -32,13 +32,37 @@ Usage: influxd-ctl [options] <command> [options] [<args>]
 Available commands are:
    add-data            Add a data node
    add-meta            Add a meta node
+   compact             Pause, resume or force compactions of a shard
    copy-shard          Copy a shard between data nodes
+   create-measurement-schema
+                       Create the schema of a measurement
+   create-rollup-rule  Create a rollup rule on a retention policy
+   drop-measurement-schema
+                       Drop the schema of a measurement
+   drop-reshard        Stop resharding a retention policy
+   drop-rollup-rule    Drop a rollup rule from a retention policy
    join                Join a meta or data node
    leave               Remove a meta or data node
+   rebuild-index       Rebuild the index of shards while they stay online
    remove-data         Remove a data node
    remove-meta         Remove a meta node
    remove-shard        Remove a shard from a data node
+   reshard             Reshard a retention policy to another shard duration
+   set-cardinality-limits
+                       Set the cardinality limits of a database
+   set-disk-quota      Set the disk quota of a database or retention policy
+   set-replication     Set how the writes to a retention policy are replicated
+   set-shard-leader    Set the leader of a shard replicated by WAL shipping
    show                Show cluster members
+   show-cardinality-limits
+                       Show cardinality limits
+   show-compactions    Show running and queued compactions
+   show-disk-quotas    Show disk quotas and their usage on each data node
+   show-measurement-schemas
+                       Show measurement schemas
+   show-replication    Show the replication of shards replicated by WAL shipping
+   show-reshards       Show retention policies being resharded
+   show-rollup-rules   Show rollup rules
    show-shards         Shows the shards in a cluster
    update-data         Update a data node
    token               Generates a signed JWT token
@@ -13,14 +13,33 @@ import (
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/add_data"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/add_meta"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/compact"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/copy_shard"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/create_measurement_schema"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/create_rollup_rule"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/drop_measurement_schema"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/drop_reshard"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/drop_rollup_rule"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/help"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/join"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/leave"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/rebuild_index"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/remove_data"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/remove_meta"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/remove_shard"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/reshard"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/set_cardinality_limits"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/set_disk_quota"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/set_replication"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/set_shard_leader"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/show"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_cardinality_limits"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_compactions"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_disk_quotas"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_measurement_schemas"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_replication"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_reshards"
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_rollup_rules"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/show_shards"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/token"
 	"github.com/influxdata/influxdb/cmd/influxd-ctl/truncate_shards"
@@ -109,11 +128,106 @@ func (m *Main) Run(args ...string) error {
 		if err := cmd.Run(args...); err != nil {
 			return fmt.Errorf("remove-shard: %s", err)
 		}
+	case "rebuild-index":
+		cmd := rebuild_index.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("rebuild-index: %s", err)
+		}
+	case "compact":
+		cmd := compact.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("compact: %s", err)
+		}
+	case "show-compactions":
+		cmd := show_compactions.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-compactions: %s", err)
+		}
 	case "show":
 		cmd := show.NewCommand(cOpts)
 		if err := cmd.Run(args...); err != nil {
 			return fmt.Errorf("show: %s", err)
 		}
+	case "create-measurement-schema":
+		cmd := create_measurement_schema.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("create-measurement-schema: %s", err)
+		}
+	case "drop-measurement-schema":
+		cmd := drop_measurement_schema.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("drop-measurement-schema: %s", err)
+		}
+	case "show-measurement-schemas":
+		cmd := show_measurement_schemas.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-measurement-schemas: %s", err)
+		}
+	case "set-cardinality-limits":
+		cmd := set_cardinality_limits.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("set-cardinality-limits: %s", err)
+		}
+	case "show-cardinality-limits":
+		cmd := show_cardinality_limits.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-cardinality-limits: %s", err)
+		}
+	case "set-disk-quota":
+		cmd := set_disk_quota.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("set-disk-quota: %s", err)
+		}
+	case "show-disk-quotas":
+		cmd := show_disk_quotas.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-disk-quotas: %s", err)
+		}
+	case "create-rollup-rule":
+		cmd := create_rollup_rule.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("create-rollup-rule: %s", err)
+		}
+	case "drop-rollup-rule":
+		cmd := drop_rollup_rule.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("drop-rollup-rule: %s", err)
+		}
+	case "show-rollup-rules":
+		cmd := show_rollup_rules.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-rollup-rules: %s", err)
+		}
+	case "reshard":
+		cmd := reshard.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("reshard: %s", err)
+		}
+	case "drop-reshard":
+		cmd := drop_reshard.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("drop-reshard: %s", err)
+		}
+	case "show-reshards":
+		cmd := show_reshards.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-reshards: %s", err)
+		}
+	case "set-replication":
+		cmd := set_replication.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("set-replication: %s", err)
+		}
+	case "set-shard-leader":
+		cmd := set_shard_leader.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("set-shard-leader: %s", err)
+		}
+	case "show-replication":
+		cmd := show_replication.NewCommand(cOpts)
+		if err := cmd.Run(args...); err != nil {
+			return fmt.Errorf("show-replication: %s", err)
+		}
 	case "show-shards":
 		cmd := show_shards.NewCommand(cOpts)
 		if err := cmd.Run(args...); err != nil {
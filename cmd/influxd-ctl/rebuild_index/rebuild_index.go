@@ -0,0 +1,88 @@
+package rebuild_index
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl rebuild-index".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	shard    uint64
+	database string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	if (cmd.shard == 0) == (cmd.database == "") {
+		return errors.New("exactly one of -shard or -database is required")
+	}
+	err = cmd.rebuildIndex()
+	return common.OperationExitedError(err)
+}
+
+// starts rebuilding the index of a shard or of every shard in a database.
+func (cmd *Command) rebuildIndex() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.RebuildIndex(cmd.shard, cmd.database); err != nil {
+		return err
+	}
+	if cmd.shard != 0 {
+		fmt.Fprintf(cmd.Stdout, "Started rebuilding the index of shard %d\n", cmd.shard)
+	} else {
+		fmt.Fprintf(cmd.Stdout, "Started rebuilding the index of shards in %s\n", cmd.database)
+	}
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Uint64Var(&cmd.shard, "shard", 0, "ID of the shard to rebuild")
+	fs.StringVar(&cmd.database, "database", "", "database whose shards to rebuild")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] rebuild-index (-shard ID | -database DB)
+    Rebuilds the tsi1 index of a shard, or of every shard in a database, on
+    each data node that owns it while the shard stays online. Shards using
+    the inmem index are converted to tsi1. Progress and errors are shown by
+    'influxd-ctl show-shards -v'.
+
+Options:
+  -shard uint
+    	ID of the shard to rebuild
+  -database string
+    	database whose shards to rebuild
+`
@@ -0,0 +1,96 @@
+package reshard
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxql"
+)
+
+// Command represents the program execution for "influxd-ctl reshard".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	duration string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) < 2 {
+		return errors.New("missing database or retention policy")
+	} else if len(args) > 2 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[2:])
+	}
+	if cmd.duration == "" {
+		return errors.New("-duration is required")
+	}
+	duration, err := influxql.ParseDuration(cmd.duration)
+	if err != nil {
+		return err
+	}
+	err = cmd.reshard(args[0], args[1], duration)
+	return common.OperationExitedError(err)
+}
+
+// reshards a retention policy.
+func (cmd *Command) reshard(db, rp string, duration time.Duration) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.Reshard(db, rp, duration); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Resharding %s.%s to shard groups of %s\n", db, rp, duration)
+	return nil
+}
+
+// parseFlags parses the command line flags. Flags may follow the database
+// and retention policy.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.duration, "duration", "", "shard group duration to reshard to")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		} else if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+const usage = `
+Usage: influxd-ctl [options] reshard <db> <rp> -duration DURATION
+    Reshards a retention policy to shard groups of the given duration. The
+    shard groups of each past window of the duration are merged into a new
+    shard group by the data nodes, which replaces them once every node copied
+    its shards. New shard groups are created with the duration.
+
+Options:
+  -duration string
+    	shard group duration to reshard to, e.g. 7d
+`
@@ -0,0 +1,101 @@
+package set_cardinality_limits
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl set-cardinality-limits".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	database                string
+	maxSeriesPerMeasurement int64
+	maxValuesPerTag         int64
+	measurements            string
+	tagKeys                 string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	if cmd.database == "" {
+		return errors.New("-db is required")
+	}
+	err = cmd.setCardinalityLimits()
+	return common.OperationExitedError(err)
+}
+
+// sets the cardinality limits of a database.
+func (cmd *Command) setCardinalityLimits() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.SetCardinalityLimits(cmd.database, cmd.maxSeriesPerMeasurement, cmd.maxValuesPerTag, cmd.measurements, cmd.tagKeys); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Set cardinality limits on %s\n", cmd.database)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.database, "db", "", "database of the limits")
+	fs.Int64Var(&cmd.maxSeriesPerMeasurement, "max-series-per-measurement", 0, "maximum number of series per measurement")
+	fs.Int64Var(&cmd.maxValuesPerTag, "max-values-per-tag", 0, "maximum number of values per tag key")
+	fs.StringVar(&cmd.measurements, "measurements", "", "comma separated measurement=limit series limits")
+	fs.StringVar(&cmd.tagKeys, "tag-keys", "", "comma separated key=limit tag value limits")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] set-cardinality-limits -db DB [-max-series-per-measurement N] [-max-values-per-tag N] [-measurements LIMITS] [-tag-keys LIMITS]
+    Sets the cardinality limits of a database, which override the
+    max-series-per-measurement and max-values-per-tag settings of the data
+    nodes. Limits apply to each shard. Points creating a series beyond a
+    limit are dropped, and reported in the error of the write.
+
+    LIMITS is a list of name=limit, such as cpu=10000 or pod_id=100, setting
+    the limit of specific measurements or tag keys. A zero limit inherits the
+    setting of the data nodes, and a negative limit disables it. Without
+    limits, the limits of the database are removed.
+
+Options:
+  -db string
+    	database of the limits
+  -max-series-per-measurement int
+    	maximum number of series per measurement
+  -max-values-per-tag int
+    	maximum number of values per tag key
+  -measurements string
+    	comma separated measurement=limit series limits
+  -tag-keys string
+    	comma separated key=limit tag value limits
+`
@@ -0,0 +1,126 @@
+package set_disk_quota
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/toml"
+)
+
+// Command represents the program execution for "influxd-ctl set-disk-quota".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	database        string
+	retentionPolicy string
+	soft            string
+	hard            string
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	if cmd.database == "" {
+		return errors.New("-db is required")
+	}
+	soft, err := parseSize(cmd.soft)
+	if err != nil {
+		return fmt.Errorf("-soft: %s", err)
+	}
+	hard, err := parseSize(cmd.hard)
+	if err != nil {
+		return fmt.Errorf("-hard: %s", err)
+	}
+	err = cmd.setDiskQuota(soft, hard)
+	return common.OperationExitedError(err)
+}
+
+// sets the disk quota of a database or retention policy.
+func (cmd *Command) setDiskQuota(soft, hard int64) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.SetDiskQuota(cmd.database, cmd.retentionPolicy, soft, hard); err != nil {
+		return err
+	}
+	target := cmd.database
+	if cmd.retentionPolicy != "" {
+		target = fmt.Sprintf("%s.%s", cmd.database, cmd.retentionPolicy)
+	}
+	if soft == 0 && hard == 0 {
+		fmt.Fprintf(cmd.Stdout, "Removed disk quota of %s\n", target)
+	} else {
+		fmt.Fprintf(cmd.Stdout, "Set disk quota on %s\n", target)
+	}
+	return nil
+}
+
+// parseSize parses a size in bytes, with an optional k, m or g suffix.
+func parseSize(s string) (int64, error) {
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	var size toml.Size
+	if err := size.UnmarshalText([]byte(s)); err != nil {
+		return 0, err
+	}
+	return int64(size), nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.StringVar(&cmd.database, "db", "", "database of the quota")
+	fs.StringVar(&cmd.retentionPolicy, "rp", "", "retention policy of the quota")
+	fs.StringVar(&cmd.soft, "soft", "", "soft limit, such as 500m or 10g")
+	fs.StringVar(&cmd.hard, "hard", "", "hard limit, such as 500m or 10g")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] set-disk-quota -db DB [-rp RP] [-soft SIZE] [-hard SIZE]
+    Sets the disk quota of a database, or of one of its retention policies.
+    Quotas apply to the disk used by the shards on each data node. Writes to
+    the shards of a database or retention policy over its hard limit are
+    rejected with 507 Insufficient Storage until enough data is dropped or
+    expires. Crossing the soft limit is logged and reported in the diskQuota
+    measurement of the _internal database.
+
+    SIZE is a number of bytes with an optional k, m or g suffix. A zero limit
+    is not enforced. Without limits, the quota is removed.
+
+Options:
+  -db string
+    	database of the quota
+  -hard string
+    	hard limit, such as 500m or 10g
+  -rp string
+    	retention policy of the quota
+  -soft string
+    	soft limit, such as 500m or 10g
+`
@@ -0,0 +1,71 @@
+package set_replication
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl set-replication".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) < 3 {
+		return errors.New("missing database, retention policy or mode")
+	} else if len(args) > 3 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[3:])
+	}
+	err = cmd.setReplication(args[0], args[1], args[2])
+	return common.OperationExitedError(err)
+}
+
+// sets the replication mode of a retention policy.
+func (cmd *Command) setReplication(db, rp, mode string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.SetReplication(db, rp, mode); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Set replication of %s.%s to %s\n", db, rp, mode)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] set-replication <db> <rp> <mode>
+    Sets how the writes to a retention policy are replicated. With "fanout",
+    writes are sent to every owner of a shard. With "wal", writes are sent to
+    the leader of the shard only, which ships its WAL to the other owners.
+`
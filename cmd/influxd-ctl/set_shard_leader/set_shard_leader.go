@@ -0,0 +1,75 @@
+package set_shard_leader
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+)
+
+// Command represents the program execution for "influxd-ctl set-shard-leader".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) < 2 {
+		return errors.New("missing shard id or data node address")
+	} else if len(args) > 2 {
+		return fmt.Errorf("unexpected extra arguments: %v", args[2:])
+	}
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid shard id: %s", args[0])
+	}
+	err = cmd.setShardLeader(id, args[1])
+	return common.OperationExitedError(err)
+}
+
+// sets the leader of a shard.
+func (cmd *Command) setShardLeader(id uint64, addr string) error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	if err := client.SetShardLeader(id, addr); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.Stdout, "Set leader of shard %d to %s\n", id, addr)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] set-shard-leader <shard-id> <data-node-tcp-addr>
+    Sets the leader of a shard of a retention policy replicated by WAL
+    shipping. The data node must own the shard.
+`
@@ -0,0 +1,78 @@
+package show_cardinality_limits
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-cardinality-limits".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showCardinalityLimits()
+	return common.OperationExitedError(err)
+}
+
+// show cardinality limits.
+func (cmd *Command) showCardinalityLimits() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var limits []meta.ClusterCardinalityLimitsInfo
+	if err := client.ShowCardinalityLimits(&limits); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.Stdout, "Cardinality Limits")
+	fmt.Fprintln(cmd.Stdout, "==================")
+	tw := tabwriter.NewWriter(cmd.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Database", "Max Series Per Measurement", "Max Values Per Tag", "Measurements", "Tag Keys"}, "\t"))
+	for _, li := range limits {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", li.Database, li.MaxSeriesPerMeasurement, li.MaxValuesPerTag,
+			meta.FormatCardinalityLimits(li.Measurements), meta.FormatCardinalityLimits(li.TagKeys))
+	}
+	tw.Flush()
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-cardinality-limits
+    Shows the cardinality limits of all databases
+`
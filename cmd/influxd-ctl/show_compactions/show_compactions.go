@@ -0,0 +1,99 @@
+package show_compactions
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-compactions".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+
+	shard uint64
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showCompactions()
+	return common.OperationExitedError(err)
+}
+
+// show the running and planned compactions of the cluster's shards.
+func (cmd *Command) showCompactions() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var compactions []meta.CompactionInfo
+	if err := client.ShowCompactions(cmd.shard, &compactions); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.Stdout, "Compactions")
+	fmt.Fprintln(cmd.Stdout, "===========")
+	tw := tabwriter.NewWriter(cmd.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Node", "TCP Address", "Shard", "State", "Level", "Strategy", "Files", "Bytes", "Progress", "Started"}, "\t"))
+	for _, ci := range compactions {
+		if ci.State == "paused" {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t\t\t\t\t\t\n", ci.NodeID, ci.TCPAddr, ci.ShardID, ci.State)
+			continue
+		}
+		var progress, started string
+		if ci.State == "running" {
+			progress = fmt.Sprintf("%.0f%%", ci.Progress*100)
+			started = ci.StartedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%d\t%s\t%d\t%d\t%s\t%s\n", ci.NodeID, ci.TCPAddr, ci.ShardID, ci.State,
+			ci.Level, ci.Strategy, ci.Files, ci.Bytes, progress, started)
+	}
+	tw.Flush()
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Uint64Var(&cmd.shard, "shard", 0, "only show compactions of the shard with this ID")
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-compactions [-shard ID]
+    Shows the running and queued TSM compactions of shards on every data
+    node, with their level, files, size and progress. Full and optimize
+    compactions of cold shards waiting for a compact-full-windows window are
+    shown as deferred. Shards whose compactions are paused are shown as
+    paused.
+
+Options:
+  -shard uint
+    	only show compactions of the shard with this ID
+`
@@ -0,0 +1,69 @@
+package show_disk_quotas
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-disk-quotas".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showDiskQuotas()
+	return common.OperationExitedError(err)
+}
+
+// show disk quotas.
+func (cmd *Command) showDiskQuotas() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var quotas []meta.ClusterDiskQuotaInfo
+	if err := client.ShowDiskQuotas(&quotas); err != nil {
+		return err
+	}
+	common.WriteDiskQuotas(cmd.Stdout, quotas)
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-disk-quotas
+    Shows the disk quotas of databases and retention policies, and the disk
+    used under each quota on every data node
+`
@@ -0,0 +1,78 @@
+package show_measurement_schemas
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-measurement-schemas".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showMeasurementSchemas()
+	return common.OperationExitedError(err)
+}
+
+// show measurement schemas.
+func (cmd *Command) showMeasurementSchemas() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var schemas []meta.ClusterMeasurementSchemaInfo
+	if err := client.ShowMeasurementSchemas(&schemas); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.Stdout, "Measurement Schemas")
+	fmt.Fprintln(cmd.Stdout, "===================")
+	tw := tabwriter.NewWriter(cmd.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Database", "Measurement", "Fields", "Required Tags", "Allowed Tags", "Mode"}, "\t"))
+	for _, si := range schemas {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", si.Database, si.Name,
+			strings.Join(si.Fields, ","), strings.Join(si.RequiredTags, ","), strings.Join(si.AllowedTags, ","), si.Mode)
+	}
+	tw.Flush()
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-measurement-schemas
+    Shows the measurement schemas of all databases
+`
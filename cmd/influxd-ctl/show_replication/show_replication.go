@@ -0,0 +1,84 @@
+package show_replication
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-replication".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showReplication()
+	return common.OperationExitedError(err)
+}
+
+// show the replicas of the shards replicated by WAL shipping.
+func (cmd *Command) showReplication() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var replicas []meta.ReplicaInfo
+	if err := client.ShowReplication(&replicas); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.Stdout, "Replicas")
+	fmt.Fprintln(cmd.Stdout, "========")
+	tw := tabwriter.NewWriter(cmd.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Shard", "Database", "Retention Policy", "Node", "Leader", "Position", "Lag Bytes", "Last Contact"}, "\t"))
+	for _, ri := range replicas {
+		lastContact := ""
+		if !ri.LastContact.IsZero() {
+			lastContact = ri.LastContact.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%s\t%d\t%s\n", ri.ShardID, ri.Database, ri.RetentionPolicy, ri.TCPAddr,
+			ri.Leader, ri.Position, ri.LagBytes, lastContact)
+	}
+	tw.Flush()
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-replication
+    Shows the followers of the shards replicated by WAL shipping, with the
+    position of the leader's WAL they applied and how far behind they are
+`
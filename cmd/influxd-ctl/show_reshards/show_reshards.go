@@ -0,0 +1,84 @@
+package show_reshards
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-reshards".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showReshards()
+	return common.OperationExitedError(err)
+}
+
+// show the shard groups being resharded.
+func (cmd *Command) showReshards() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var reshards []meta.ClusterReshardInfo
+	if err := client.ShowReshards(&reshards); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.Stdout, "Reshards")
+	fmt.Fprintln(cmd.Stdout, "========")
+	tw := tabwriter.NewWriter(cmd.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Database", "Retention Policy", "Duration", "Shard Group", "Start", "End", "Sources", "Copied"}, "\t"))
+	for _, ri := range reshards {
+		sources := make([]string, len(ri.Sources))
+		for i, id := range ri.Sources {
+			sources[i] = fmt.Sprint(id)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%d/%d\n", ri.Database, ri.RetentionPolicy, ri.Duration, ri.ShardGroup,
+			ri.StartTime.Format(time.RFC3339), ri.EndTime.Format(time.RFC3339), strings.Join(sources, ","), ri.Copied, ri.Copies)
+	}
+	tw.Flush()
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-reshards
+    Shows the shard groups being resharded, with the shard groups they
+    replace and how many shard copies are done
+`
@@ -0,0 +1,77 @@
+package show_rollup_rules
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/influxdata/influxdb/cmd/influxd-ctl/common"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// Command represents the program execution for "influxd-ctl show-rollup-rules".
+type Command struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	cOpts  *common.Options
+}
+
+// NewCommand return a new instance of Command.
+func NewCommand(cOpts *common.Options) *Command {
+	return &Command{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		cOpts:  cOpts,
+	}
+}
+
+// Run executes the program.
+func (cmd *Command) Run(args ...string) error {
+	args, err := cmd.parseFlags(args)
+	if err != nil {
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected extra arguments: %v", args)
+	}
+	err = cmd.showRollupRules()
+	return common.OperationExitedError(err)
+}
+
+// show rollup rules.
+func (cmd *Command) showRollupRules() error {
+	client := common.NewHTTPClient(cmd.cOpts)
+	defer client.Close()
+	var rules []meta.ClusterRollupRuleInfo
+	if err := client.ShowRollupRules(&rules); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.Stdout, "Rollup Rules")
+	fmt.Fprintln(cmd.Stdout, "============")
+	tw := tabwriter.NewWriter(cmd.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"Database", "Retention Policy", "Name", "After", "Interval"}, "\t"))
+	for _, ri := range rules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", ri.Database, ri.RetentionPolicy, ri.Name, ri.After, ri.Interval)
+	}
+	tw.Flush()
+	return nil
+}
+
+// parseFlags parses the command line flags.
+func (cmd *Command) parseFlags(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprintln(cmd.Stderr, strings.TrimSpace(usage)) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return fs.Args(), nil
+}
+
+const usage = `
+Usage: influxd-ctl [options] show-rollup-rules
+    Shows the rollup rules of all retention policies
+`
@@ -64,6 +64,15 @@ func (cmd *Command) showShards() error {
 			common.FormatRFC3339(si.ExpireTime), cmd.formatOwners(si.Owners))
 	}
 	tw.Flush()
+
+	var quotas []meta.ClusterDiskQuotaInfo
+	if err := client.ShowDiskQuotas(&quotas); err != nil {
+		return err
+	}
+	if len(quotas) > 0 {
+		fmt.Fprintln(cmd.Stdout)
+		common.WriteDiskQuotas(cmd.Stdout, quotas)
+	}
 	return nil
 }
 
@@ -80,6 +89,12 @@ func (cmd *Command) formatOwners(owners []*meta.ShardOwnerInfo) string {
 		var fields []string
 		fields = append(fields, fmt.Sprintf("ID:%d", oi.ID))
 		fields = append(fields, fmt.Sprintf("TCPAddr:%s", oi.TCPAddr))
+		if oi.Quarantined {
+			fields = append(fields, "Quarantined:true")
+		}
+		if oi.Tier != "" {
+			fields = append(fields, fmt.Sprintf("Tier:%s", oi.Tier))
+		}
 		if cmd.verbose {
 			fields = append(fields, fmt.Sprintf("State:%s", oi.State))
 			fields = append(fields, fmt.Sprintf("LastModified:%s", oi.LastModified.UTC().Format(time.RFC3339Nano)))
@@ -89,6 +104,21 @@ func (cmd *Command) formatOwners(owners []*meta.ShardOwnerInfo) string {
 			} else {
 				fields = append(fields, "Err:nil")
 			}
+			if oi.LastVerified.IsZero() {
+				fields = append(fields, "LastVerified:never")
+			} else {
+				fields = append(fields, fmt.Sprintf("LastVerified:%s", oi.LastVerified.UTC().Format(time.RFC3339Nano)))
+			}
+			if oi.VerifyErr != "" {
+				fields = append(fields, fmt.Sprintf("VerifyErr:%s", oi.VerifyErr))
+			}
+			if oi.IndexRebuild != "" {
+				fields = append(fields, fmt.Sprintf("IndexRebuild:%s", oi.IndexRebuild))
+				fields = append(fields, fmt.Sprintf("IndexRebuildSeries:%d", oi.IndexRebuildSeries))
+			}
+			if oi.IndexRebuildErr != "" {
+				fields = append(fields, fmt.Sprintf("IndexRebuildErr:%s", oi.IndexRebuildErr))
+			}
 		}
 		info := fmt.Sprintf("{%s}", strings.Join(fields, " "))
 		infos = append(infos, info)
@@ -109,7 +139,8 @@ func (cmd *Command) parseFlags(args []string) ([]string, error) {
 
 const usage = `
 Usage: influxd-ctl show-shards [options]
-    Lists shards with the cluster
+    Lists shards with the cluster, followed by the disk used under the disk
+    quotas on each data node if any quota is set
 
 Options:
   -v	displays detailed shard info
@@ -55,6 +55,11 @@ type Command struct {
 	start    time.Time
 	end      time.Time
 
+	// consistent backs up a snapshot of the database, taken at a single
+	// point in time across its shards.
+	consistent bool
+	snapshotID uint64
+
 	portable         bool
 	manifest         backup_util.Manifest
 	portableFileBase string
@@ -160,6 +165,7 @@ func (cmd *Command) parseFlags(args []string) (err error) {
 	fs.StringVar(&endArg, "end", "", "")
 	fs.BoolVar(&cmd.portable, "portable", false, "")
 	fs.BoolVar(&cmd.continueOnError, "skip-errors", false, "")
+	fs.BoolVar(&cmd.consistent, "consistent", false, "")
 
 	fs.SetOutput(cmd.Stderr)
 	fs.Usage = cmd.printUsage
@@ -208,6 +214,16 @@ func (cmd *Command) parseFlags(args []string) (err error) {
 		}
 	}
 
+	if cmd.consistent {
+		if cmd.database == "" {
+			return errors.New("-consistent requires -db")
+		} else if cmd.shardID != "" {
+			return errors.New("-consistent cannot be used with -shard")
+		} else if !cmd.isBackup {
+			return errors.New("-consistent cannot be used with -start/-end")
+		}
+	}
+
 	// Ensure that only one arg is specified.
 	if fs.NArg() != 1 {
 		return errors.New("exactly one backup path is required")
@@ -297,6 +313,7 @@ func (cmd *Command) backupShard(db, rp, sid string, verifyLocation bool) (err er
 		Since:                 cmd.since,
 		ExportStart:           cmd.start,
 		ExportEnd:             cmd.end,
+		SnapshotID:            cmd.snapshotID,
 	}
 
 	// TODO: verify shard backup data
@@ -374,6 +391,15 @@ func (cmd *Command) backupShard(db, rp, sid string, verifyLocation bool) (err er
 func (cmd *Command) backupDatabase() error {
 	cmd.StdoutLogger.Printf("backing up db=%s", cmd.database)
 
+	if cmd.consistent {
+		response, err := cmd.createSnapshot()
+		if err != nil {
+			return err
+		}
+		defer cmd.releaseSnapshot()
+		return cmd.backupResponsePaths(response)
+	}
+
 	req := &snapshotter.Request{
 		Type:           snapshotter.RequestDatabaseInfo,
 		BackupDatabase: cmd.database,
@@ -397,6 +423,26 @@ func (cmd *Command) backupRetentionPolicy() error {
 			cmd.retentionPolicy, cmd.start.Format(time.RFC3339), cmd.end.Format(time.RFC3339))
 	}
 
+	if cmd.consistent {
+		response, err := cmd.createSnapshot()
+		if err != nil {
+			return err
+		}
+		defer cmd.releaseSnapshot()
+
+		// Only back up the shards of the retention policy.
+		paths := response.Paths[:0]
+		for _, path := range response.Paths {
+			if _, rp, _, err := backup_util.DBRetentionAndShardFromPath(path); err != nil {
+				return err
+			} else if rp == cmd.retentionPolicy {
+				paths = append(paths, path)
+			}
+		}
+		response.Paths = paths
+		return cmd.backupResponsePaths(response)
+	}
+
 	req := &snapshotter.Request{
 		Type:                  snapshotter.RequestRetentionPolicyInfo,
 		BackupDatabase:        cmd.database,
@@ -411,6 +457,36 @@ func (cmd *Command) backupRetentionPolicy() error {
 	return cmd.backupResponsePaths(response)
 }
 
+// createSnapshot will request a point-in-time snapshot of the database from the
+// server. The shards are then backed up from the snapshot until it is released.
+func (cmd *Command) createSnapshot() (*snapshotter.Response, error) {
+	req := &snapshotter.Request{
+		Type:           snapshotter.RequestDatabaseSnapshot,
+		BackupDatabase: cmd.database,
+	}
+
+	response, err := cmd.requestInfo(req)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot db=%s: %w", cmd.database, err)
+	}
+	cmd.snapshotID = response.SnapshotID
+	cmd.StdoutLogger.Printf("created snapshot %d of db=%s with %d shards", cmd.snapshotID, cmd.database, len(response.Paths))
+	return response, nil
+}
+
+// releaseSnapshot will release the snapshot created by createSnapshot on the server.
+func (cmd *Command) releaseSnapshot() {
+	req := &snapshotter.Request{
+		Type:       snapshotter.RequestDatabaseSnapshotRelease,
+		SnapshotID: cmd.snapshotID,
+	}
+
+	if _, err := cmd.requestInfo(req); err != nil {
+		cmd.StderrLogger.Printf("unable to release snapshot %d: %v", cmd.snapshotID, err)
+	}
+	cmd.snapshotID = 0
+}
+
 // backupResponsePaths will backup all shards identified by shard paths in the response struct
 func (cmd *Command) backupResponsePaths(response *snapshotter.Response) error {
 
@@ -669,6 +745,10 @@ Usage: influxd backup [options] PATH
             Recommend using '-start <timestamp>' instead.
     -skip-errors 
             Optional flag to continue backing up the remaining shards when the current shard fails to backup. 
+    -consistent
+            Optional flag to back up a snapshot of the database taken at a single point in time across its
+            shards. Writes to the database are paused while the snapshot is taken. Requires '-db' and is not
+            compatible with '-shard' or '-start'/'-end'.
 `)
 
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/influxdata/influxdb/cmd"
 	"github.com/influxdata/influxdb/cmd/influxd/help"
 	"github.com/influxdata/influxdb/cmd/influxd/run"
+	"go.uber.org/zap"
 )
 
 // These variables are populated via the Go linker.
@@ -81,6 +82,18 @@ func (m *Main) Run(args ...string) error {
 			return fmt.Errorf("run: %s", err)
 		}
 
+		// Reload the configuration on SIGHUP until the server shuts down.
+		reloadCh := make(chan os.Signal, 1)
+		signal.Notify(reloadCh, syscall.SIGHUP)
+		go func() {
+			for range reloadCh {
+				cmd.Logger.Info("SIGHUP received, reloading configuration")
+				if err := cmd.Reload(); err != nil {
+					cmd.Logger.Error("Unable to reload configuration", zap.Error(err))
+				}
+			}
+		}()
+
 		signalCh := make(chan os.Signal, 1)
 		signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
 		cmd.Logger.Info("Listening for signals")
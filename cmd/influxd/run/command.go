@@ -37,9 +37,10 @@ type Command struct {
 	Commit    string
 	BuildTime string
 
-	closing chan struct{}
-	pidfile string
-	Closed  chan struct{}
+	closing    chan struct{}
+	pidfile    string
+	configPath string
+	Closed     chan struct{}
 
 	Stdin  io.Reader
 	Stdout io.Writer
@@ -158,6 +159,7 @@ func (cmd *Command) Run(args ...string) error {
 		return fmt.Errorf("open server: %s", err)
 	}
 	cmd.Server = s
+	cmd.configPath = options.GetConfigPath()
 
 	// Begin monitoring the server's error channel.
 	go cmd.monitorServerErrors()
@@ -176,6 +178,30 @@ func (cmd *Command) Close() error {
 	return nil
 }
 
+// Reload parses the config file again and applies the settings which can be
+// changed while the server is running, such as the transform rules and the
+// graphite templates.
+func (cmd *Command) Reload() error {
+	if cmd.Server == nil {
+		return nil
+	}
+
+	config, err := cmd.ParseConfig(cmd.configPath)
+	if err != nil {
+		return fmt.Errorf("parse config: %s", err)
+	}
+
+	if err := config.ApplyEnvOverrides(cmd.Getenv); err != nil {
+		return fmt.Errorf("apply env config: %v", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	return cmd.Server.Reload(config)
+}
+
 func (cmd *Command) monitorServerErrors() {
 	logger := log.New(cmd.Stderr, "", log.LstdFlags)
 	for {
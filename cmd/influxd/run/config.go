@@ -15,6 +15,7 @@ import (
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/monitor"
 	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/pkg/s3"
 	"github.com/influxdata/influxdb/pkg/tlsconfig"
 	"github.com/influxdata/influxdb/services/ae"
 	"github.com/influxdata/influxdb/services/collectd"
@@ -22,11 +23,20 @@ import (
 	"github.com/influxdata/influxdb/services/graphite"
 	"github.com/influxdata/influxdb/services/hh"
 	"github.com/influxdata/influxdb/services/httpd"
+	"github.com/influxdata/influxdb/services/kafka"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/services/opentsdb"
 	"github.com/influxdata/influxdb/services/precreator"
+	"github.com/influxdata/influxdb/services/quota"
+	"github.com/influxdata/influxdb/services/replication"
+	"github.com/influxdata/influxdb/services/reshard"
 	"github.com/influxdata/influxdb/services/retention"
+	"github.com/influxdata/influxdb/services/rollup"
+	"github.com/influxdata/influxdb/services/scrubber"
+	"github.com/influxdata/influxdb/services/seriesgc"
 	"github.com/influxdata/influxdb/services/subscriber"
+	"github.com/influxdata/influxdb/services/tiering"
+	itransform "github.com/influxdata/influxdb/services/transform"
 	"github.com/influxdata/influxdb/services/udp"
 	itoml "github.com/influxdata/influxdb/toml"
 	"github.com/influxdata/influxdb/tsdb"
@@ -58,10 +68,22 @@ type Config struct {
 	CollectdInputs []collectd.Config `toml:"collectd"`
 	OpenTSDBInputs []opentsdb.Config `toml:"opentsdb"`
 	UDPInputs      []udp.Config      `toml:"udp"`
+	KafkaInputs    []kafka.Config    `toml:"kafka"`
+
+	// Transforms are the rules applied to the points of every write.
+	Transforms []itransform.Config `toml:"transform"`
 
 	ContinuousQuery continuous_querier.Config `toml:"continuous_queries"`
 	HintedHandoff   hh.Config                 `toml:"hinted-handoff"`
 	AntiEntropy     ae.Config                 `toml:"anti-entropy"`
+	Scrubber        scrubber.Config           `toml:"scrubber"`
+	Tiering         tiering.Config            `toml:"tiering"`
+	S3              s3.Config                 `toml:"s3"`
+	Rollup          rollup.Config             `toml:"rollup"`
+	Reshard         reshard.Config            `toml:"reshard"`
+	Replication     replication.Config        `toml:"replication"`
+	SeriesGC        seriesgc.Config           `toml:"series-gc"`
+	DiskQuota       quota.Config              `toml:"disk-quota"`
 
 	// Server reporting
 	ReportingDisabled bool `toml:"reporting-disabled"`
@@ -97,11 +119,20 @@ func NewConfig() *Config {
 	c.CollectdInputs = []collectd.Config{collectd.NewConfig()}
 	c.OpenTSDBInputs = []opentsdb.Config{opentsdb.NewConfig()}
 	c.UDPInputs = []udp.Config{udp.NewConfig()}
+	c.KafkaInputs = []kafka.Config{kafka.NewConfig()}
 
 	c.ContinuousQuery = continuous_querier.NewConfig()
 	c.Retention = retention.NewConfig()
 	c.HintedHandoff = hh.NewConfig()
 	c.AntiEntropy = ae.NewConfig()
+	c.Scrubber = scrubber.NewConfig()
+	c.Tiering = tiering.NewConfig()
+	c.S3 = s3.NewConfig()
+	c.Rollup = rollup.NewConfig()
+	c.Reshard = reshard.NewConfig()
+	c.Replication = replication.NewConfig()
+	c.SeriesGC = seriesgc.NewConfig()
+	c.DiskQuota = quota.NewConfig()
 	c.BindAddress = DefaultBindAddress
 	c.GossipFrequency = itoml.Duration(DefaultGossipFrequency)
 
@@ -175,6 +206,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Coordinator.Validate(); err != nil {
+		return err
+	}
+
 	if err := c.Monitor.Validate(); err != nil {
 		return err
 	}
@@ -203,6 +238,44 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Scrubber.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.S3.Validate(); err != nil {
+		return err
+	} else if c.S3.Enabled && c.Data.OffloadCacheDir == "" {
+		return fmt.Errorf("s3 requires Data.OffloadCacheDir to be set")
+	}
+
+	if err := c.Tiering.Validate(); err != nil {
+		return err
+	} else if c.Tiering.Enabled && c.Tiering.ColdTier() && c.Data.ColdDir == "" {
+		return fmt.Errorf("tiering cold-after requires Data.ColdDir to be set")
+	} else if c.Tiering.Enabled && c.Tiering.RemoteTier() && !c.S3.Enabled {
+		return fmt.Errorf("tiering offload-after requires s3 to be enabled")
+	}
+
+	if err := c.Rollup.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Reshard.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Replication.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.SeriesGC.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.DiskQuota.Validate(); err != nil {
+		return err
+	}
+
 	for _, graphite := range c.GraphiteInputs {
 		if err := graphite.Validate(); err != nil {
 			return fmt.Errorf("invalid graphite config: %v", err)
@@ -215,6 +288,16 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, kafka := range c.KafkaInputs {
+		if err := kafka.Validate(); err != nil {
+			return fmt.Errorf("invalid kafka config: %v", err)
+		}
+	}
+
+	if err := itransform.Configs(c.Transforms).Validate(); err != nil {
+		return fmt.Errorf("invalid transform config: %v", err)
+	}
+
 	if err := c.TLS.Validate(); err != nil {
 		return err
 	}
@@ -255,6 +338,15 @@ func (c *Config) diagnosticsClients() map[string]diagnostics.Client {
 		"config-cqs": c.ContinuousQuery,
 		"config-hh":  c.HintedHandoff,
 		"config-ae":  c.AntiEntropy,
+
+		"config-scrubber":    c.Scrubber,
+		"config-tiering":     c.Tiering,
+		"config-s3":          c.S3,
+		"config-rollup":      c.Rollup,
+		"config-reshard":     c.Reshard,
+		"config-replication": c.Replication,
+		"config-seriesgc":    c.SeriesGC,
+		"config-quota":       c.DiskQuota,
 	}
 
 	// Config settings that can be repeated and can be disabled.
@@ -270,6 +362,12 @@ func (c *Config) diagnosticsClients() map[string]diagnostics.Client {
 	if u := udp.Configs(c.UDPInputs); u.Enabled() {
 		m["config-udp"] = u
 	}
+	if k := kafka.Configs(c.KafkaInputs); k.Enabled() {
+		m["config-kafka"] = k
+	}
+	if t := itransform.Configs(c.Transforms); t.Enabled() {
+		m["config-transform"] = t
+	}
 
 	return m
 }
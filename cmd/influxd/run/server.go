@@ -3,6 +3,7 @@ package run
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -17,6 +18,7 @@ import (
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/monitor"
+	"github.com/influxdata/influxdb/pkg/s3"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/services/ae"
 	"github.com/influxdata/influxdb/services/announcer"
@@ -25,13 +27,22 @@ import (
 	"github.com/influxdata/influxdb/services/graphite"
 	"github.com/influxdata/influxdb/services/hh"
 	"github.com/influxdata/influxdb/services/httpd"
+	"github.com/influxdata/influxdb/services/kafka"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/services/opentsdb"
 	"github.com/influxdata/influxdb/services/precreator"
+	"github.com/influxdata/influxdb/services/quota"
+	"github.com/influxdata/influxdb/services/replication"
+	"github.com/influxdata/influxdb/services/reshard"
 	"github.com/influxdata/influxdb/services/retention"
+	"github.com/influxdata/influxdb/services/rollup"
+	"github.com/influxdata/influxdb/services/scrubber"
+	"github.com/influxdata/influxdb/services/seriesgc"
 	"github.com/influxdata/influxdb/services/snapshotter"
 	"github.com/influxdata/influxdb/services/storage"
 	"github.com/influxdata/influxdb/services/subscriber"
+	"github.com/influxdata/influxdb/services/tiering"
+	"github.com/influxdata/influxdb/services/transform"
 	"github.com/influxdata/influxdb/services/udp"
 	"github.com/influxdata/influxdb/storage/reads"
 	"github.com/influxdata/influxdb/tcp"
@@ -81,8 +92,10 @@ type Server struct {
 	MetaExecutor  *coordinator.MetaExecutor
 	PointsWriter  *coordinator.PointsWriter
 	ShardWriter   *coordinator.ShardWriter
+	NodeWriter    *coordinator.NodeWriter
 	HintedHandoff *hh.Service
 	Subscriber    *subscriber.Service
+	Transform     *transform.Pipeline
 
 	Services []Service
 
@@ -178,6 +191,16 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 	// Copy TSDB configuration.
 	s.TSDBStore.EngineOptions.EngineVersion = c.Data.Engine
 	s.TSDBStore.EngineOptions.IndexVersion = c.Data.Index
+	s.TSDBStore.EngineOptions.CardinalityLimits = s.cardinalityLimits
+
+	// Offloaded shards are stored in an S3-compatible bucket.
+	if c.S3.Enabled {
+		client, err := s3.NewClient(c.S3)
+		if err != nil {
+			return nil, fmt.Errorf("s3: %s", err)
+		}
+		s.TSDBStore.ObjectStore = client
+	}
 
 	// Create TLS client config
 	tlsClientConfig := c.Coordinator.TLSClientConfig()
@@ -187,6 +210,18 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 		time.Duration(c.Coordinator.PoolMaxIdleTime), c.Coordinator.PoolMaxIdleStreams)
 	s.ShardWriter.TLSConfig = tlsClientConfig
 
+	// Set the node writer batching the writes to each remote node
+	if c.Coordinator.BatchNodeWrites {
+		compression, err := coordinator.ParseCompression(c.Coordinator.WriteCompression)
+		if err != nil {
+			return nil, err
+		}
+		s.NodeWriter = coordinator.NewNodeWriter(time.Duration(c.Coordinator.WriteTimeout), time.Duration(c.Coordinator.DialTimeout),
+			compression, c.Coordinator.MaxPipelinedWrites)
+		s.NodeWriter.TLSConfig = tlsClientConfig
+		s.NodeWriter.ShardWriter = s.ShardWriter
+	}
+
 	// Create the hinted handoff service
 	s.HintedHandoff = hh.NewService(c.HintedHandoff, s.ShardWriter)
 	s.HintedHandoff.Monitor = s.Monitor
@@ -194,6 +229,12 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 	// Create the Subscriber service
 	s.Subscriber = subscriber.NewService(c.Subscriber)
 
+	// Create the write transformation pipeline
+	s.Transform, err = transform.NewPipeline(c.Transforms)
+	if err != nil {
+		return nil, fmt.Errorf("transform: %s", err)
+	}
+
 	// Initialize points writer.
 	s.PointsWriter = coordinator.NewPointsWriter()
 	s.PointsWriter.AllowOutOfOrderWrites = c.Coordinator.AllowOutOfOrderWrites
@@ -202,6 +243,10 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 	s.PointsWriter.ShardWriter = s.ShardWriter
 	s.PointsWriter.HintedHandoff = s.HintedHandoff
 	s.PointsWriter.Subscriber = s.Subscriber
+	s.PointsWriter.Transformer = s.Transform
+	if s.NodeWriter != nil {
+		s.PointsWriter.NodeWriter = s.NodeWriter
+	}
 
 	// Initialize meta executor.
 	s.MetaExecutor = coordinator.NewMetaExecutor(time.Duration(c.Coordinator.ShardReaderTimeout), time.Duration(c.Coordinator.DialTimeout),
@@ -225,6 +270,7 @@ func NewServer(c *Config, buildInfo *BuildInfo) (*Server, error) {
 		},
 		StrictErrorHandling: s.TSDBStore.EngineOptions.Config.StrictErrorHandling,
 		Monitor:             s.Monitor,
+		CompactionLister:    s.ClusterStore,
 		PointsWriter:        s.PointsWriter,
 		MaxSelectPointN:     c.Coordinator.MaxSelectPointN,
 		MaxSelectSeriesN:    c.Coordinator.MaxSelectSeriesN,
@@ -252,6 +298,7 @@ func (s *Server) Statistics(tags map[string]string) []models.Statistic {
 	statistics = append(statistics, s.PointsWriter.Statistics(tags)...)
 	statistics = append(statistics, s.HintedHandoff.Statistics(tags)...)
 	statistics = append(statistics, s.Subscriber.Statistics(tags)...)
+	statistics = append(statistics, s.Transform.Statistics(tags)...)
 	for _, srv := range s.Services {
 		if m, ok := srv.(monitor.Reporter); ok {
 			statistics = append(statistics, m.Statistics(tags)...)
@@ -313,6 +360,77 @@ func (s *Server) appendAntiEntropyService(c ae.Config) {
 	s.Services = append(s.Services, srv)
 }
 
+func (s *Server) appendScrubberService(c scrubber.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := scrubber.NewService(c)
+	srv.MetaClient = s.MetaClient
+	srv.TSDBStore = s.TSDBStore
+	s.Services = append(s.Services, srv)
+}
+
+func (s *Server) appendSeriesGCService(c seriesgc.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := seriesgc.NewService(c)
+	srv.TSDBStore = s.TSDBStore
+	s.Services = append(s.Services, srv)
+}
+
+func (s *Server) appendDiskQuotaService(c quota.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := quota.NewService(c)
+	srv.MetaClient = s.MetaClient
+	srv.TSDBStore = s.TSDBStore
+	s.Services = append(s.Services, srv)
+}
+
+func (s *Server) appendTieringService(c tiering.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := tiering.NewService(c)
+	srv.MetaClient = s.MetaClient
+	srv.TSDBStore = s.TSDBStore
+	s.Services = append(s.Services, srv)
+}
+
+func (s *Server) appendRollupService(c rollup.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := rollup.NewService(c)
+	srv.MetaClient = s.MetaClient
+	srv.TSDBStore = s.TSDBStore
+	s.Services = append(s.Services, srv)
+}
+
+func (s *Server) appendReshardService(c reshard.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := reshard.NewService(c)
+	srv.MetaClient = s.MetaClient
+	srv.TSDBStore = s.TSDBStore
+	srv.ShardBackuper = s.CoordinatorService
+	s.Services = append(s.Services, srv)
+}
+
+func (s *Server) appendReplicationService(c replication.Config) {
+	if !c.Enabled {
+		return
+	}
+	srv := replication.NewService(c)
+	srv.MetaClient = s.MetaClient
+	srv.TSDBStore = s.TSDBStore
+	srv.ShardWriter = s.ShardWriter
+	s.Services = append(s.Services, srv)
+}
+
 func (s *Server) appendHTTPDService(c httpd.Config) {
 	if !c.Enabled {
 		return
@@ -329,6 +447,7 @@ func (s *Server) appendHTTPDService(c httpd.Config) {
 	srv.Handler.BuildType = "OSS"
 	ss := storage.NewClusterStore(s.ClusterStore, s.MetaClient, s.MetaExecutor)
 	srv.Handler.Store = ss
+	srv.Handler.CardinalityReporter = s.ClusterStore
 	if s.config.HTTPD.FluxEnabled {
 		srv.Handler.Controller = control.NewController(s.MetaClient, reads.NewReader(ss), authorizer, c.AuthEnabled, s.Logger)
 	}
@@ -336,6 +455,32 @@ func (s *Server) appendHTTPDService(c httpd.Config) {
 	s.Services = append(s.Services, srv)
 }
 
+// cardinalityLimits returns the cardinality limits of database set in meta.
+func (s *Server) cardinalityLimits(database string) *tsdb.CardinalityLimits {
+	di := s.MetaClient.Database(database)
+	if di == nil || di.CardinalityLimits == nil {
+		return nil
+	}
+
+	toInts := func(m map[string]int64) map[string]int {
+		if len(m) == 0 {
+			return nil
+		}
+		other := make(map[string]int, len(m))
+		for k, v := range m {
+			other[k] = int(v)
+		}
+		return other
+	}
+	li := di.CardinalityLimits
+	return &tsdb.CardinalityLimits{
+		MaxSeriesPerMeasurement: int(li.MaxSeriesPerMeasurement),
+		MaxValuesPerTag:         int(li.MaxValuesPerTag),
+		Measurements:            toInts(li.Measurements),
+		TagKeys:                 toInts(li.TagKeys),
+	}
+}
+
 func (s *Server) appendCollectdService(c collectd.Config) {
 	if !c.Enabled {
 		return
@@ -396,6 +541,20 @@ func (s *Server) appendUDPService(c udp.Config) {
 	s.Services = append(s.Services, srv)
 }
 
+func (s *Server) appendKafkaService(c kafka.Config) error {
+	if !c.Enabled {
+		return nil
+	}
+	srv, err := kafka.NewService(c)
+	if err != nil {
+		return err
+	}
+	srv.PointsWriter = s.PointsWriter
+	srv.MetaClient = s.MetaClient
+	s.Services = append(s.Services, srv)
+	return nil
+}
+
 func (s *Server) appendContinuousQueryService(c continuous_querier.Config) {
 	if !c.Enabled {
 		return
@@ -442,6 +601,13 @@ func (s *Server) Open() error {
 	s.appendAnnouncerService(s.config.Meta)
 	s.appendRetentionPolicyService(s.config.Retention)
 	s.appendAntiEntropyService(s.config.AntiEntropy)
+	s.appendScrubberService(s.config.Scrubber)
+	s.appendTieringService(s.config.Tiering)
+	s.appendRollupService(s.config.Rollup)
+	s.appendReshardService(s.config.Reshard)
+	s.appendReplicationService(s.config.Replication)
+	s.appendSeriesGCService(s.config.SeriesGC)
+	s.appendDiskQuotaService(s.config.DiskQuota)
 	for _, i := range s.config.GraphiteInputs {
 		if err := s.appendGraphiteService(i); err != nil {
 			return err
@@ -458,8 +624,16 @@ func (s *Server) Open() error {
 	for _, i := range s.config.UDPInputs {
 		s.appendUDPService(i)
 	}
+	for _, i := range s.config.KafkaInputs {
+		if err := s.appendKafkaService(i); err != nil {
+			return err
+		}
+	}
 
 	s.ShardWriter.MetaClient = s.MetaClient
+	if s.NodeWriter != nil {
+		s.NodeWriter.MetaClient = s.MetaClient
+	}
 	s.HintedHandoff.MetaClient = s.MetaClient
 	s.Subscriber.MetaClient = s.MetaClient
 	s.PointsWriter.MetaClient = s.MetaClient
@@ -485,6 +659,7 @@ func (s *Server) Open() error {
 	s.PointsWriter.WithLogger(s.Logger)
 	s.HintedHandoff.WithLogger(s.Logger)
 	s.Subscriber.WithLogger(s.Logger)
+	s.Transform.WithLogger(s.Logger)
 	for _, svc := range s.Services {
 		svc.WithLogger(s.Logger)
 	}
@@ -551,6 +726,10 @@ func (s *Server) Close() error {
 		s.ShardWriter.Close()
 	}
 
+	if s.NodeWriter != nil {
+		s.NodeWriter.Close()
+	}
+
 	if s.PointsWriter != nil {
 		s.PointsWriter.Close()
 	}
@@ -613,6 +792,7 @@ func (s *Server) Reset() error {
 	s.MetaExecutor = svr.MetaExecutor
 	s.PointsWriter = svr.PointsWriter
 	s.ShardWriter = svr.ShardWriter
+	s.NodeWriter = svr.NodeWriter
 	s.HintedHandoff = svr.HintedHandoff
 	s.Subscriber = svr.Subscriber
 	s.Services = svr.Services
@@ -779,6 +959,35 @@ func (s *Server) stopProfile() error {
 	return nil
 }
 
+// Reload applies the settings of c which can be changed while the server is
+// running. Currently these are the transform rules, and the templates, tags
+// and separator of each graphite input, which is matched to its config by
+// protocol and bind address. A failure to reload one doesn't stop the others
+// from being reloaded, and the errors are returned joined.
+func (s *Server) Reload(c *Config) error {
+	var errs []error
+	if err := s.Transform.Reload(c.Transforms); err != nil {
+		errs = append(errs, fmt.Errorf("reload transform: %s", err))
+	}
+
+	for _, service := range s.Services {
+		srv, ok := service.(*graphite.Service)
+		if !ok {
+			continue
+		}
+		for _, gc := range c.GraphiteInputs {
+			if !gc.Enabled || !srv.Matches(gc) {
+				continue
+			}
+			if err := srv.Reload(gc); err != nil {
+				errs = append(errs, fmt.Errorf("reload graphite: %s", err))
+			}
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (s *Server) LogQueriesOnTermination() bool {
 	if s != nil && s.config != nil {
 		return s.config.Coordinator.TerminationQueryLog
@@ -4,6 +4,7 @@ package coordinator
 
 import (
 	"crypto/tls"
+	"errors"
 	"time"
 
 	"github.com/influxdata/influxdb/monitor/diagnostics"
@@ -47,6 +48,17 @@ const (
 	// DefaultMaxSelectBucketsN is the maximum number of group by time buckets a SELECT can create.
 	// A value of 0 will make the maximum number of buckets unlimited.
 	DefaultMaxSelectBucketsN = 0
+
+	// DefaultBatchNodeWrites is whether the points of all the shards of a write owned by a
+	// remote data node are sent in a single request.
+	DefaultBatchNodeWrites = true
+
+	// DefaultWriteCompression is the compression of the requests writing to a remote data node.
+	DefaultWriteCompression = "snappy"
+
+	// DefaultMaxPipelinedWrites is the maximum number of write requests sent to a remote data
+	// node without waiting for their response.
+	DefaultMaxPipelinedWrites = 4
 )
 
 // Config represents the configuration for the coordinator service.
@@ -70,6 +82,9 @@ type Config struct {
 	MaxSelectSeriesN      int           `toml:"max-select-series"`
 	MaxSelectBucketsN     int           `toml:"max-select-buckets"`
 	TerminationQueryLog   bool          `toml:"termination-query-log"`
+	BatchNodeWrites       bool          `toml:"batch-node-writes"`
+	WriteCompression      string        `toml:"write-compression"`
+	MaxPipelinedWrites    int           `toml:"max-pipelined-writes"`
 
 	// TLS is a base tls config to use for tls clients.
 	TLS *tls.Config `toml:"-"`
@@ -90,7 +105,21 @@ func NewConfig() Config {
 		MaxSelectSeriesN:     DefaultMaxSelectSeriesN,
 		MaxSelectBucketsN:    DefaultMaxSelectBucketsN,
 		TerminationQueryLog:  false,
+		BatchNodeWrites:      DefaultBatchNodeWrites,
+		WriteCompression:     DefaultWriteCompression,
+		MaxPipelinedWrites:   DefaultMaxPipelinedWrites,
+	}
+}
+
+// Validate returns an error if the config is invalid.
+func (c Config) Validate() error {
+	if _, err := ParseCompression(c.WriteCompression); err != nil {
+		return errors.New("write-compression must be none, snappy or zstd")
+	}
+	if c.MaxPipelinedWrites < 1 {
+		return errors.New("max-pipelined-writes must be positive")
 	}
+	return nil
 }
 
 // TLSConfig returns a TLS config.
@@ -121,5 +150,8 @@ func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 		"max-select-series":         c.MaxSelectSeriesN,
 		"max-select-buckets":        c.MaxSelectBucketsN,
 		"termination-query-log":     c.TerminationQueryLog,
+		"batch-node-writes":         c.BatchNodeWrites,
+		"write-compression":         c.WriteCompression,
+		"max-pipelined-writes":      c.MaxPipelinedWrites,
 	}), nil
 }
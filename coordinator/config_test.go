@@ -13,6 +13,8 @@ func TestConfig_Parse(t *testing.T) {
 	var c coordinator.Config
 	if _, err := toml.Decode(`
 write-timeout = "20s"
+write-compression = "zstd"
+max-pipelined-writes = 8
 `, &c); err != nil {
 		t.Fatal(err)
 	}
@@ -20,5 +22,29 @@ write-timeout = "20s"
 	// Validate configuration.
 	if time.Duration(c.WriteTimeout) != 20*time.Second {
 		t.Fatalf("unexpected write timeout s: %s", c.WriteTimeout)
+	} else if c.WriteCompression != "zstd" {
+		t.Fatalf("unexpected write compression: %s", c.WriteCompression)
+	} else if c.MaxPipelinedWrites != 8 {
+		t.Fatalf("unexpected max pipelined writes: %d", c.MaxPipelinedWrites)
+	} else if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := coordinator.NewConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.WriteCompression = "lz4"
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for unknown compression")
+	}
+
+	c = coordinator.NewConfig()
+	c.MaxPipelinedWrites = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for max-pipelined-writes")
 	}
 }
@@ -940,6 +940,90 @@ func (m *StoreReadGroupResponse) GetErr() string {
 	return ""
 }
 
+type StoreReadWindowAggregateRequest struct {
+	ShardIDs             []uint64 `protobuf:"varint,1,rep,name=ShardIDs" json:"ShardIDs,omitempty"`
+	Request              []byte   `protobuf:"bytes,2,req,name=Request" json:"Request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreReadWindowAggregateRequest) Reset()         { *m = StoreReadWindowAggregateRequest{} }
+func (m *StoreReadWindowAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*StoreReadWindowAggregateRequest) ProtoMessage()    {}
+func (*StoreReadWindowAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{20}
+}
+func (m *StoreReadWindowAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreReadWindowAggregateRequest.Unmarshal(m, b)
+}
+func (m *StoreReadWindowAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreReadWindowAggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *StoreReadWindowAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreReadWindowAggregateRequest.Merge(m, src)
+}
+func (m *StoreReadWindowAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_StoreReadWindowAggregateRequest.Size(m)
+}
+func (m *StoreReadWindowAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreReadWindowAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreReadWindowAggregateRequest proto.InternalMessageInfo
+
+func (m *StoreReadWindowAggregateRequest) GetShardIDs() []uint64 {
+	if m != nil {
+		return m.ShardIDs
+	}
+	return nil
+}
+
+func (m *StoreReadWindowAggregateRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type StoreReadWindowAggregateResponse struct {
+	Err                  *string  `protobuf:"bytes,1,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreReadWindowAggregateResponse) Reset()         { *m = StoreReadWindowAggregateResponse{} }
+func (m *StoreReadWindowAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*StoreReadWindowAggregateResponse) ProtoMessage()    {}
+func (*StoreReadWindowAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{21}
+}
+func (m *StoreReadWindowAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreReadWindowAggregateResponse.Unmarshal(m, b)
+}
+func (m *StoreReadWindowAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreReadWindowAggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *StoreReadWindowAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreReadWindowAggregateResponse.Merge(m, src)
+}
+func (m *StoreReadWindowAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_StoreReadWindowAggregateResponse.Size(m)
+}
+func (m *StoreReadWindowAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreReadWindowAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreReadWindowAggregateResponse proto.InternalMessageInfo
+
+func (m *StoreReadWindowAggregateResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
 type CreateIteratorRequest struct {
 	ShardIDs             []uint64 `protobuf:"varint,1,rep,name=ShardIDs" json:"ShardIDs,omitempty"`
 	Measurement          []byte   `protobuf:"bytes,2,req,name=Measurement" json:"Measurement,omitempty"`
@@ -954,7 +1038,7 @@ func (m *CreateIteratorRequest) Reset()         { *m = CreateIteratorRequest{} }
 func (m *CreateIteratorRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateIteratorRequest) ProtoMessage()    {}
 func (*CreateIteratorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{20}
+	return fileDescriptor_7438786364df21e1, []int{22}
 }
 func (m *CreateIteratorRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateIteratorRequest.Unmarshal(m, b)
@@ -1015,7 +1099,7 @@ func (m *CreateIteratorResponse) Reset()         { *m = CreateIteratorResponse{}
 func (m *CreateIteratorResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateIteratorResponse) ProtoMessage()    {}
 func (*CreateIteratorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{21}
+	return fileDescriptor_7438786364df21e1, []int{23}
 }
 func (m *CreateIteratorResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateIteratorResponse.Unmarshal(m, b)
@@ -1068,7 +1152,7 @@ func (m *IteratorStats) Reset()         { *m = IteratorStats{} }
 func (m *IteratorStats) String() string { return proto.CompactTextString(m) }
 func (*IteratorStats) ProtoMessage()    {}
 func (*IteratorStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{22}
+	return fileDescriptor_7438786364df21e1, []int{24}
 }
 func (m *IteratorStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IteratorStats.Unmarshal(m, b)
@@ -1115,7 +1199,7 @@ func (m *IteratorCostRequest) Reset()         { *m = IteratorCostRequest{} }
 func (m *IteratorCostRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorCostRequest) ProtoMessage()    {}
 func (*IteratorCostRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{23}
+	return fileDescriptor_7438786364df21e1, []int{25}
 }
 func (m *IteratorCostRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IteratorCostRequest.Unmarshal(m, b)
@@ -1168,7 +1252,7 @@ func (m *IteratorCostResponse) Reset()         { *m = IteratorCostResponse{} }
 func (m *IteratorCostResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorCostResponse) ProtoMessage()    {}
 func (*IteratorCostResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{24}
+	return fileDescriptor_7438786364df21e1, []int{26}
 }
 func (m *IteratorCostResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IteratorCostResponse.Unmarshal(m, b)
@@ -1218,7 +1302,7 @@ func (m *IteratorCost) Reset()         { *m = IteratorCost{} }
 func (m *IteratorCost) String() string { return proto.CompactTextString(m) }
 func (*IteratorCost) ProtoMessage()    {}
 func (*IteratorCost) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{25}
+	return fileDescriptor_7438786364df21e1, []int{27}
 }
 func (m *IteratorCost) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IteratorCost.Unmarshal(m, b)
@@ -1292,7 +1376,7 @@ func (m *FieldDimensionsRequest) Reset()         { *m = FieldDimensionsRequest{}
 func (m *FieldDimensionsRequest) String() string { return proto.CompactTextString(m) }
 func (*FieldDimensionsRequest) ProtoMessage()    {}
 func (*FieldDimensionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{26}
+	return fileDescriptor_7438786364df21e1, []int{28}
 }
 func (m *FieldDimensionsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FieldDimensionsRequest.Unmarshal(m, b)
@@ -1339,7 +1423,7 @@ func (m *FieldDimensionsResponse) Reset()         { *m = FieldDimensionsResponse
 func (m *FieldDimensionsResponse) String() string { return proto.CompactTextString(m) }
 func (*FieldDimensionsResponse) ProtoMessage()    {}
 func (*FieldDimensionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{27}
+	return fileDescriptor_7438786364df21e1, []int{29}
 }
 func (m *FieldDimensionsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FieldDimensionsResponse.Unmarshal(m, b)
@@ -1393,7 +1477,7 @@ func (m *MapTypeRequest) Reset()         { *m = MapTypeRequest{} }
 func (m *MapTypeRequest) String() string { return proto.CompactTextString(m) }
 func (*MapTypeRequest) ProtoMessage()    {}
 func (*MapTypeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{28}
+	return fileDescriptor_7438786364df21e1, []int{30}
 }
 func (m *MapTypeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MapTypeRequest.Unmarshal(m, b)
@@ -1446,7 +1530,7 @@ func (m *MapTypeResponse) Reset()         { *m = MapTypeResponse{} }
 func (m *MapTypeResponse) String() string { return proto.CompactTextString(m) }
 func (*MapTypeResponse) ProtoMessage()    {}
 func (*MapTypeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{29}
+	return fileDescriptor_7438786364df21e1, []int{31}
 }
 func (m *MapTypeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MapTypeResponse.Unmarshal(m, b)
@@ -1492,7 +1576,7 @@ func (m *ExpandSourcesRequest) Reset()         { *m = ExpandSourcesRequest{} }
 func (m *ExpandSourcesRequest) String() string { return proto.CompactTextString(m) }
 func (*ExpandSourcesRequest) ProtoMessage()    {}
 func (*ExpandSourcesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{30}
+	return fileDescriptor_7438786364df21e1, []int{32}
 }
 func (m *ExpandSourcesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpandSourcesRequest.Unmarshal(m, b)
@@ -1538,7 +1622,7 @@ func (m *ExpandSourcesResponse) Reset()         { *m = ExpandSourcesResponse{} }
 func (m *ExpandSourcesResponse) String() string { return proto.CompactTextString(m) }
 func (*ExpandSourcesResponse) ProtoMessage()    {}
 func (*ExpandSourcesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{31}
+	return fileDescriptor_7438786364df21e1, []int{33}
 }
 func (m *ExpandSourcesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpandSourcesResponse.Unmarshal(m, b)
@@ -1584,7 +1668,7 @@ func (m *BackupShardRequest) Reset()         { *m = BackupShardRequest{} }
 func (m *BackupShardRequest) String() string { return proto.CompactTextString(m) }
 func (*BackupShardRequest) ProtoMessage()    {}
 func (*BackupShardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{32}
+	return fileDescriptor_7438786364df21e1, []int{34}
 }
 func (m *BackupShardRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BackupShardRequest.Unmarshal(m, b)
@@ -1629,7 +1713,7 @@ func (m *BackupShardResponse) Reset()         { *m = BackupShardResponse{} }
 func (m *BackupShardResponse) String() string { return proto.CompactTextString(m) }
 func (*BackupShardResponse) ProtoMessage()    {}
 func (*BackupShardResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{33}
+	return fileDescriptor_7438786364df21e1, []int{35}
 }
 func (m *BackupShardResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BackupShardResponse.Unmarshal(m, b)
@@ -1671,7 +1755,7 @@ func (m *CopyShardRequest) Reset()         { *m = CopyShardRequest{} }
 func (m *CopyShardRequest) String() string { return proto.CompactTextString(m) }
 func (*CopyShardRequest) ProtoMessage()    {}
 func (*CopyShardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{34}
+	return fileDescriptor_7438786364df21e1, []int{36}
 }
 func (m *CopyShardRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CopyShardRequest.Unmarshal(m, b)
@@ -1737,7 +1821,7 @@ func (m *CopyShardResponse) Reset()         { *m = CopyShardResponse{} }
 func (m *CopyShardResponse) String() string { return proto.CompactTextString(m) }
 func (*CopyShardResponse) ProtoMessage()    {}
 func (*CopyShardResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{35}
+	return fileDescriptor_7438786364df21e1, []int{37}
 }
 func (m *CopyShardResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CopyShardResponse.Unmarshal(m, b)
@@ -1775,7 +1859,7 @@ func (m *RemoveShardRequest) Reset()         { *m = RemoveShardRequest{} }
 func (m *RemoveShardRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveShardRequest) ProtoMessage()    {}
 func (*RemoveShardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{36}
+	return fileDescriptor_7438786364df21e1, []int{38}
 }
 func (m *RemoveShardRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveShardRequest.Unmarshal(m, b)
@@ -1813,7 +1897,7 @@ func (m *RemoveShardResponse) Reset()         { *m = RemoveShardResponse{} }
 func (m *RemoveShardResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoveShardResponse) ProtoMessage()    {}
 func (*RemoveShardResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{37}
+	return fileDescriptor_7438786364df21e1, []int{39}
 }
 func (m *RemoveShardResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveShardResponse.Unmarshal(m, b)
@@ -1852,7 +1936,7 @@ func (m *ListShardsResponse) Reset()         { *m = ListShardsResponse{} }
 func (m *ListShardsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListShardsResponse) ProtoMessage()    {}
 func (*ListShardsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{38}
+	return fileDescriptor_7438786364df21e1, []int{40}
 }
 func (m *ListShardsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListShardsResponse.Unmarshal(m, b)
@@ -1898,7 +1982,7 @@ func (m *JoinClusterRequest) Reset()         { *m = JoinClusterRequest{} }
 func (m *JoinClusterRequest) String() string { return proto.CompactTextString(m) }
 func (*JoinClusterRequest) ProtoMessage()    {}
 func (*JoinClusterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{39}
+	return fileDescriptor_7438786364df21e1, []int{41}
 }
 func (m *JoinClusterRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinClusterRequest.Unmarshal(m, b)
@@ -1944,7 +2028,7 @@ func (m *JoinClusterResponse) Reset()         { *m = JoinClusterResponse{} }
 func (m *JoinClusterResponse) String() string { return proto.CompactTextString(m) }
 func (*JoinClusterResponse) ProtoMessage()    {}
 func (*JoinClusterResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{40}
+	return fileDescriptor_7438786364df21e1, []int{42}
 }
 func (m *JoinClusterResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinClusterResponse.Unmarshal(m, b)
@@ -1991,7 +2075,7 @@ func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
 func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()    {}
 func (*NodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{41}
+	return fileDescriptor_7438786364df21e1, []int{43}
 }
 func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeInfo.Unmarshal(m, b)
@@ -2043,7 +2127,7 @@ func (m *LeaveClusterResponse) Reset()         { *m = LeaveClusterResponse{} }
 func (m *LeaveClusterResponse) String() string { return proto.CompactTextString(m) }
 func (*LeaveClusterResponse) ProtoMessage()    {}
 func (*LeaveClusterResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{42}
+	return fileDescriptor_7438786364df21e1, []int{44}
 }
 func (m *LeaveClusterResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LeaveClusterResponse.Unmarshal(m, b)
@@ -2081,7 +2165,7 @@ func (m *RemoveHintedHandoffRequest) Reset()         { *m = RemoveHintedHandoffR
 func (m *RemoveHintedHandoffRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveHintedHandoffRequest) ProtoMessage()    {}
 func (*RemoveHintedHandoffRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{43}
+	return fileDescriptor_7438786364df21e1, []int{45}
 }
 func (m *RemoveHintedHandoffRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveHintedHandoffRequest.Unmarshal(m, b)
@@ -2119,7 +2203,7 @@ func (m *RemoveHintedHandoffResponse) Reset()         { *m = RemoveHintedHandoff
 func (m *RemoveHintedHandoffResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoveHintedHandoffResponse) ProtoMessage()    {}
 func (*RemoveHintedHandoffResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7438786364df21e1, []int{44}
+	return fileDescriptor_7438786364df21e1, []int{46}
 }
 func (m *RemoveHintedHandoffResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoveHintedHandoffResponse.Unmarshal(m, b)
@@ -2146,6 +2230,766 @@ func (m *RemoveHintedHandoffResponse) GetErr() string {
 	return ""
 }
 
+type CardinalityReportRequest struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	N                    *int64   `protobuf:"varint,2,opt,name=N" json:"N,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CardinalityReportRequest) Reset()         { *m = CardinalityReportRequest{} }
+func (m *CardinalityReportRequest) String() string { return proto.CompactTextString(m) }
+func (*CardinalityReportRequest) ProtoMessage()    {}
+func (*CardinalityReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{47}
+}
+func (m *CardinalityReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CardinalityReportRequest.Unmarshal(m, b)
+}
+func (m *CardinalityReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CardinalityReportRequest.Marshal(b, m, deterministic)
+}
+func (m *CardinalityReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CardinalityReportRequest.Merge(m, src)
+}
+func (m *CardinalityReportRequest) XXX_Size() int {
+	return xxx_messageInfo_CardinalityReportRequest.Size(m)
+}
+func (m *CardinalityReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CardinalityReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CardinalityReportRequest proto.InternalMessageInfo
+
+func (m *CardinalityReportRequest) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *CardinalityReportRequest) GetN() int64 {
+	if m != nil && m.N != nil {
+		return *m.N
+	}
+	return 0
+}
+
+type CardinalityReportResponse struct {
+	Report               []byte   `protobuf:"bytes,1,opt,name=Report" json:"Report,omitempty"`
+	Err                  *string  `protobuf:"bytes,2,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CardinalityReportResponse) Reset()         { *m = CardinalityReportResponse{} }
+func (m *CardinalityReportResponse) String() string { return proto.CompactTextString(m) }
+func (*CardinalityReportResponse) ProtoMessage()    {}
+func (*CardinalityReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{48}
+}
+func (m *CardinalityReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CardinalityReportResponse.Unmarshal(m, b)
+}
+func (m *CardinalityReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CardinalityReportResponse.Marshal(b, m, deterministic)
+}
+func (m *CardinalityReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CardinalityReportResponse.Merge(m, src)
+}
+func (m *CardinalityReportResponse) XXX_Size() int {
+	return xxx_messageInfo_CardinalityReportResponse.Size(m)
+}
+func (m *CardinalityReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CardinalityReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CardinalityReportResponse proto.InternalMessageInfo
+
+func (m *CardinalityReportResponse) GetReport() []byte {
+	if m != nil {
+		return m.Report
+	}
+	return nil
+}
+
+func (m *CardinalityReportResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type WriteNodeRequest struct {
+	RequestID            *uint64  `protobuf:"varint,1,req,name=RequestID" json:"RequestID,omitempty"`
+	Compression          *int32   `protobuf:"varint,2,opt,name=Compression" json:"Compression,omitempty"`
+	Shards               []byte   `protobuf:"bytes,3,req,name=Shards" json:"Shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteNodeRequest) Reset()         { *m = WriteNodeRequest{} }
+func (m *WriteNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteNodeRequest) ProtoMessage()    {}
+func (*WriteNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{49}
+}
+func (m *WriteNodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteNodeRequest.Unmarshal(m, b)
+}
+func (m *WriteNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteNodeRequest.Marshal(b, m, deterministic)
+}
+func (m *WriteNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteNodeRequest.Merge(m, src)
+}
+func (m *WriteNodeRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteNodeRequest.Size(m)
+}
+func (m *WriteNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteNodeRequest proto.InternalMessageInfo
+
+func (m *WriteNodeRequest) GetRequestID() uint64 {
+	if m != nil && m.RequestID != nil {
+		return *m.RequestID
+	}
+	return 0
+}
+
+func (m *WriteNodeRequest) GetCompression() int32 {
+	if m != nil && m.Compression != nil {
+		return *m.Compression
+	}
+	return 0
+}
+
+func (m *WriteNodeRequest) GetShards() []byte {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+type WriteNodeShards struct {
+	Shards               []*WriteShardRequest `protobuf:"bytes,1,rep,name=Shards" json:"Shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WriteNodeShards) Reset()         { *m = WriteNodeShards{} }
+func (m *WriteNodeShards) String() string { return proto.CompactTextString(m) }
+func (*WriteNodeShards) ProtoMessage()    {}
+func (*WriteNodeShards) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{50}
+}
+func (m *WriteNodeShards) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteNodeShards.Unmarshal(m, b)
+}
+func (m *WriteNodeShards) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteNodeShards.Marshal(b, m, deterministic)
+}
+func (m *WriteNodeShards) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteNodeShards.Merge(m, src)
+}
+func (m *WriteNodeShards) XXX_Size() int {
+	return xxx_messageInfo_WriteNodeShards.Size(m)
+}
+func (m *WriteNodeShards) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteNodeShards.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteNodeShards proto.InternalMessageInfo
+
+func (m *WriteNodeShards) GetShards() []*WriteShardRequest {
+	if m != nil {
+		return m.Shards
+	}
+	return nil
+}
+
+type WriteNodeResponse struct {
+	RequestID            *uint64               `protobuf:"varint,1,req,name=RequestID" json:"RequestID,omitempty"`
+	Results              []*WriteShardResponse `protobuf:"bytes,2,rep,name=Results" json:"Results,omitempty"`
+	Err                  *string               `protobuf:"bytes,3,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *WriteNodeResponse) Reset()         { *m = WriteNodeResponse{} }
+func (m *WriteNodeResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteNodeResponse) ProtoMessage()    {}
+func (*WriteNodeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{51}
+}
+func (m *WriteNodeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteNodeResponse.Unmarshal(m, b)
+}
+func (m *WriteNodeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteNodeResponse.Marshal(b, m, deterministic)
+}
+func (m *WriteNodeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteNodeResponse.Merge(m, src)
+}
+func (m *WriteNodeResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteNodeResponse.Size(m)
+}
+func (m *WriteNodeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteNodeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteNodeResponse proto.InternalMessageInfo
+
+func (m *WriteNodeResponse) GetRequestID() uint64 {
+	if m != nil && m.RequestID != nil {
+		return *m.RequestID
+	}
+	return 0
+}
+
+func (m *WriteNodeResponse) GetResults() []*WriteShardResponse {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *WriteNodeResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type NodeVersionResponse struct {
+	Version              *uint64  `protobuf:"varint,1,req,name=Version" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeVersionResponse) Reset()         { *m = NodeVersionResponse{} }
+func (m *NodeVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeVersionResponse) ProtoMessage()    {}
+func (*NodeVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{52}
+}
+func (m *NodeVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeVersionResponse.Unmarshal(m, b)
+}
+func (m *NodeVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeVersionResponse.Marshal(b, m, deterministic)
+}
+func (m *NodeVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeVersionResponse.Merge(m, src)
+}
+func (m *NodeVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeVersionResponse.Size(m)
+}
+func (m *NodeVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeVersionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeVersionResponse proto.InternalMessageInfo
+
+func (m *NodeVersionResponse) GetVersion() uint64 {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return 0
+}
+
+type RebuildIndexRequest struct {
+	ShardIDs             []uint64 `protobuf:"varint,1,rep,name=ShardIDs" json:"ShardIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildIndexRequest) Reset()         { *m = RebuildIndexRequest{} }
+func (m *RebuildIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*RebuildIndexRequest) ProtoMessage()    {}
+func (*RebuildIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{53}
+}
+func (m *RebuildIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildIndexRequest.Unmarshal(m, b)
+}
+func (m *RebuildIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildIndexRequest.Marshal(b, m, deterministic)
+}
+func (m *RebuildIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildIndexRequest.Merge(m, src)
+}
+func (m *RebuildIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_RebuildIndexRequest.Size(m)
+}
+func (m *RebuildIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildIndexRequest proto.InternalMessageInfo
+
+func (m *RebuildIndexRequest) GetShardIDs() []uint64 {
+	if m != nil {
+		return m.ShardIDs
+	}
+	return nil
+}
+
+type RebuildIndexResponse struct {
+	Err                  *string  `protobuf:"bytes,1,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildIndexResponse) Reset()         { *m = RebuildIndexResponse{} }
+func (m *RebuildIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*RebuildIndexResponse) ProtoMessage()    {}
+func (*RebuildIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{54}
+}
+func (m *RebuildIndexResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RebuildIndexResponse.Unmarshal(m, b)
+}
+func (m *RebuildIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RebuildIndexResponse.Marshal(b, m, deterministic)
+}
+func (m *RebuildIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildIndexResponse.Merge(m, src)
+}
+func (m *RebuildIndexResponse) XXX_Size() int {
+	return xxx_messageInfo_RebuildIndexResponse.Size(m)
+}
+func (m *RebuildIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildIndexResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildIndexResponse proto.InternalMessageInfo
+
+func (m *RebuildIndexResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type ListCompactionsResponse struct {
+	Compactions          []byte   `protobuf:"bytes,1,req,name=Compactions" json:"Compactions,omitempty"`
+	Err                  *string  `protobuf:"bytes,2,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCompactionsResponse) Reset()         { *m = ListCompactionsResponse{} }
+func (m *ListCompactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCompactionsResponse) ProtoMessage()    {}
+func (*ListCompactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{55}
+}
+func (m *ListCompactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCompactionsResponse.Unmarshal(m, b)
+}
+func (m *ListCompactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCompactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCompactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCompactionsResponse.Merge(m, src)
+}
+func (m *ListCompactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCompactionsResponse.Size(m)
+}
+func (m *ListCompactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCompactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCompactionsResponse proto.InternalMessageInfo
+
+func (m *ListCompactionsResponse) GetCompactions() []byte {
+	if m != nil {
+		return m.Compactions
+	}
+	return nil
+}
+
+func (m *ListCompactionsResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type CompactShardRequest struct {
+	ShardID              *uint64  `protobuf:"varint,1,req,name=ShardID" json:"ShardID,omitempty"`
+	Action               *string  `protobuf:"bytes,2,req,name=Action" json:"Action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactShardRequest) Reset()         { *m = CompactShardRequest{} }
+func (m *CompactShardRequest) String() string { return proto.CompactTextString(m) }
+func (*CompactShardRequest) ProtoMessage()    {}
+func (*CompactShardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{56}
+}
+func (m *CompactShardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactShardRequest.Unmarshal(m, b)
+}
+func (m *CompactShardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactShardRequest.Marshal(b, m, deterministic)
+}
+func (m *CompactShardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactShardRequest.Merge(m, src)
+}
+func (m *CompactShardRequest) XXX_Size() int {
+	return xxx_messageInfo_CompactShardRequest.Size(m)
+}
+func (m *CompactShardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactShardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactShardRequest proto.InternalMessageInfo
+
+func (m *CompactShardRequest) GetShardID() uint64 {
+	if m != nil && m.ShardID != nil {
+		return *m.ShardID
+	}
+	return 0
+}
+
+func (m *CompactShardRequest) GetAction() string {
+	if m != nil && m.Action != nil {
+		return *m.Action
+	}
+	return ""
+}
+
+type CompactShardResponse struct {
+	Err                  *string  `protobuf:"bytes,1,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactShardResponse) Reset()         { *m = CompactShardResponse{} }
+func (m *CompactShardResponse) String() string { return proto.CompactTextString(m) }
+func (*CompactShardResponse) ProtoMessage()    {}
+func (*CompactShardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{57}
+}
+func (m *CompactShardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactShardResponse.Unmarshal(m, b)
+}
+func (m *CompactShardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactShardResponse.Marshal(b, m, deterministic)
+}
+func (m *CompactShardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactShardResponse.Merge(m, src)
+}
+func (m *CompactShardResponse) XXX_Size() int {
+	return xxx_messageInfo_CompactShardResponse.Size(m)
+}
+func (m *CompactShardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactShardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactShardResponse proto.InternalMessageInfo
+
+func (m *CompactShardResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type ReplicateWALRequest struct {
+	ShardID              *uint64  `protobuf:"varint,1,req,name=ShardID" json:"ShardID,omitempty"`
+	LeaderID             *uint64  `protobuf:"varint,2,req,name=LeaderID" json:"LeaderID,omitempty"`
+	StartSegment         *int64   `protobuf:"varint,3,opt,name=StartSegment" json:"StartSegment,omitempty"`
+	StartOffset          *int64   `protobuf:"varint,4,opt,name=StartOffset" json:"StartOffset,omitempty"`
+	NextSegment          *int64   `protobuf:"varint,5,opt,name=NextSegment" json:"NextSegment,omitempty"`
+	NextOffset           *int64   `protobuf:"varint,6,opt,name=NextOffset" json:"NextOffset,omitempty"`
+	Data                 []byte   `protobuf:"bytes,7,opt,name=Data" json:"Data,omitempty"`
+	LagBytes             *int64   `protobuf:"varint,8,opt,name=LagBytes" json:"LagBytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplicateWALRequest) Reset()         { *m = ReplicateWALRequest{} }
+func (m *ReplicateWALRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplicateWALRequest) ProtoMessage()    {}
+func (*ReplicateWALRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{58}
+}
+func (m *ReplicateWALRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicateWALRequest.Unmarshal(m, b)
+}
+func (m *ReplicateWALRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicateWALRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplicateWALRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicateWALRequest.Merge(m, src)
+}
+func (m *ReplicateWALRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplicateWALRequest.Size(m)
+}
+func (m *ReplicateWALRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicateWALRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicateWALRequest proto.InternalMessageInfo
+
+func (m *ReplicateWALRequest) GetShardID() uint64 {
+	if m != nil && m.ShardID != nil {
+		return *m.ShardID
+	}
+	return 0
+}
+
+func (m *ReplicateWALRequest) GetLeaderID() uint64 {
+	if m != nil && m.LeaderID != nil {
+		return *m.LeaderID
+	}
+	return 0
+}
+
+func (m *ReplicateWALRequest) GetStartSegment() int64 {
+	if m != nil && m.StartSegment != nil {
+		return *m.StartSegment
+	}
+	return 0
+}
+
+func (m *ReplicateWALRequest) GetStartOffset() int64 {
+	if m != nil && m.StartOffset != nil {
+		return *m.StartOffset
+	}
+	return 0
+}
+
+func (m *ReplicateWALRequest) GetNextSegment() int64 {
+	if m != nil && m.NextSegment != nil {
+		return *m.NextSegment
+	}
+	return 0
+}
+
+func (m *ReplicateWALRequest) GetNextOffset() int64 {
+	if m != nil && m.NextOffset != nil {
+		return *m.NextOffset
+	}
+	return 0
+}
+
+func (m *ReplicateWALRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReplicateWALRequest) GetLagBytes() int64 {
+	if m != nil && m.LagBytes != nil {
+		return *m.LagBytes
+	}
+	return 0
+}
+
+type ReplicateWALResponse struct {
+	AppliedSegment       *int64   `protobuf:"varint,1,opt,name=AppliedSegment" json:"AppliedSegment,omitempty"`
+	AppliedOffset        *int64   `protobuf:"varint,2,opt,name=AppliedOffset" json:"AppliedOffset,omitempty"`
+	Err                  *string  `protobuf:"bytes,3,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplicateWALResponse) Reset()         { *m = ReplicateWALResponse{} }
+func (m *ReplicateWALResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplicateWALResponse) ProtoMessage()    {}
+func (*ReplicateWALResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{59}
+}
+func (m *ReplicateWALResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicateWALResponse.Unmarshal(m, b)
+}
+func (m *ReplicateWALResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicateWALResponse.Marshal(b, m, deterministic)
+}
+func (m *ReplicateWALResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicateWALResponse.Merge(m, src)
+}
+func (m *ReplicateWALResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplicateWALResponse.Size(m)
+}
+func (m *ReplicateWALResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicateWALResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicateWALResponse proto.InternalMessageInfo
+
+func (m *ReplicateWALResponse) GetAppliedSegment() int64 {
+	if m != nil && m.AppliedSegment != nil {
+		return *m.AppliedSegment
+	}
+	return 0
+}
+
+func (m *ReplicateWALResponse) GetAppliedOffset() int64 {
+	if m != nil && m.AppliedOffset != nil {
+		return *m.AppliedOffset
+	}
+	return 0
+}
+
+func (m *ReplicateWALResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type ReplicationStatusResponse struct {
+	Replicas             []byte   `protobuf:"bytes,1,req,name=Replicas" json:"Replicas,omitempty"`
+	Err                  *string  `protobuf:"bytes,2,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplicationStatusResponse) Reset()         { *m = ReplicationStatusResponse{} }
+func (m *ReplicationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplicationStatusResponse) ProtoMessage()    {}
+func (*ReplicationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{60}
+}
+func (m *ReplicationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplicationStatusResponse.Unmarshal(m, b)
+}
+func (m *ReplicationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplicationStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ReplicationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplicationStatusResponse.Merge(m, src)
+}
+func (m *ReplicationStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplicationStatusResponse.Size(m)
+}
+func (m *ReplicationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplicationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplicationStatusResponse proto.InternalMessageInfo
+
+func (m *ReplicationStatusResponse) GetReplicas() []byte {
+	if m != nil {
+		return m.Replicas
+	}
+	return nil
+}
+
+func (m *ReplicationStatusResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type DiskUsageRequest struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskUsageRequest) Reset()         { *m = DiskUsageRequest{} }
+func (m *DiskUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*DiskUsageRequest) ProtoMessage()    {}
+func (*DiskUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{61}
+}
+func (m *DiskUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskUsageRequest.Unmarshal(m, b)
+}
+func (m *DiskUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *DiskUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskUsageRequest.Merge(m, src)
+}
+func (m *DiskUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_DiskUsageRequest.Size(m)
+}
+func (m *DiskUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskUsageRequest proto.InternalMessageInfo
+
+func (m *DiskUsageRequest) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+type DiskUsageResponse struct {
+	Usage                []byte   `protobuf:"bytes,1,opt,name=Usage" json:"Usage,omitempty"`
+	Err                  *string  `protobuf:"bytes,2,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskUsageResponse) Reset()         { *m = DiskUsageResponse{} }
+func (m *DiskUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*DiskUsageResponse) ProtoMessage()    {}
+func (*DiskUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7438786364df21e1, []int{62}
+}
+func (m *DiskUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskUsageResponse.Unmarshal(m, b)
+}
+func (m *DiskUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *DiskUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskUsageResponse.Merge(m, src)
+}
+func (m *DiskUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_DiskUsageResponse.Size(m)
+}
+func (m *DiskUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskUsageResponse proto.InternalMessageInfo
+
+func (m *DiskUsageResponse) GetUsage() []byte {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+func (m *DiskUsageResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*WriteShardRequest)(nil), "internal.WriteShardRequest")
 	proto.RegisterType((*WriteShardResponse)(nil), "internal.WriteShardResponse")
@@ -2167,6 +3011,8 @@ func init() {
 	proto.RegisterType((*StoreReadFilterResponse)(nil), "internal.StoreReadFilterResponse")
 	proto.RegisterType((*StoreReadGroupRequest)(nil), "internal.StoreReadGroupRequest")
 	proto.RegisterType((*StoreReadGroupResponse)(nil), "internal.StoreReadGroupResponse")
+	proto.RegisterType((*StoreReadWindowAggregateRequest)(nil), "internal.StoreReadWindowAggregateRequest")
+	proto.RegisterType((*StoreReadWindowAggregateResponse)(nil), "internal.StoreReadWindowAggregateResponse")
 	proto.RegisterType((*CreateIteratorRequest)(nil), "internal.CreateIteratorRequest")
 	proto.RegisterType((*CreateIteratorResponse)(nil), "internal.CreateIteratorResponse")
 	proto.RegisterType((*IteratorStats)(nil), "internal.IteratorStats")
@@ -2192,80 +3038,122 @@ func init() {
 	proto.RegisterType((*LeaveClusterResponse)(nil), "internal.LeaveClusterResponse")
 	proto.RegisterType((*RemoveHintedHandoffRequest)(nil), "internal.RemoveHintedHandoffRequest")
 	proto.RegisterType((*RemoveHintedHandoffResponse)(nil), "internal.RemoveHintedHandoffResponse")
+	proto.RegisterType((*CardinalityReportRequest)(nil), "internal.CardinalityReportRequest")
+	proto.RegisterType((*CardinalityReportResponse)(nil), "internal.CardinalityReportResponse")
+	proto.RegisterType((*WriteNodeRequest)(nil), "internal.WriteNodeRequest")
+	proto.RegisterType((*WriteNodeShards)(nil), "internal.WriteNodeShards")
+	proto.RegisterType((*WriteNodeResponse)(nil), "internal.WriteNodeResponse")
+	proto.RegisterType((*NodeVersionResponse)(nil), "internal.NodeVersionResponse")
+	proto.RegisterType((*RebuildIndexRequest)(nil), "internal.RebuildIndexRequest")
+	proto.RegisterType((*RebuildIndexResponse)(nil), "internal.RebuildIndexResponse")
+	proto.RegisterType((*ListCompactionsResponse)(nil), "internal.ListCompactionsResponse")
+	proto.RegisterType((*CompactShardRequest)(nil), "internal.CompactShardRequest")
+	proto.RegisterType((*CompactShardResponse)(nil), "internal.CompactShardResponse")
+	proto.RegisterType((*ReplicateWALRequest)(nil), "internal.ReplicateWALRequest")
+	proto.RegisterType((*ReplicateWALResponse)(nil), "internal.ReplicateWALResponse")
+	proto.RegisterType((*ReplicationStatusResponse)(nil), "internal.ReplicationStatusResponse")
+	proto.RegisterType((*DiskUsageRequest)(nil), "internal.DiskUsageRequest")
+	proto.RegisterType((*DiskUsageResponse)(nil), "internal.DiskUsageResponse")
 }
 
 func init() { proto.RegisterFile("internal/data.proto", fileDescriptor_7438786364df21e1) }
 
 var fileDescriptor_7438786364df21e1 = []byte{
-	// 1113 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x7d, 0x6f, 0x23, 0xb5,
-	0x13, 0xd6, 0xe6, 0xa5, 0xd7, 0xcc, 0xe5, 0xd7, 0x97, 0x6d, 0x9a, 0xae, 0xae, 0xd5, 0x4f, 0x95,
-	0x25, 0x20, 0x3a, 0x44, 0x4f, 0x02, 0x24, 0x84, 0x10, 0x48, 0xd7, 0xb4, 0x25, 0x3d, 0xda, 0x5c,
-	0xe5, 0x0d, 0xc7, 0x7f, 0x48, 0x26, 0x3b, 0x6d, 0x57, 0x4d, 0x76, 0xc3, 0xda, 0x5b, 0xb5, 0x48,
-	0x7c, 0x00, 0xe0, 0x8b, 0xf1, 0xb1, 0x90, 0xdf, 0xf6, 0x25, 0xd9, 0xa0, 0x1c, 0x94, 0xff, 0xfc,
-	0x3c, 0xb6, 0x67, 0x1e, 0x8f, 0xc7, 0x33, 0x86, 0x9d, 0x30, 0x12, 0x98, 0x44, 0x6c, 0xf2, 0x2a,
-	0x60, 0x82, 0x1d, 0xcd, 0x92, 0x58, 0xc4, 0xee, 0xba, 0x25, 0xc9, 0x1f, 0x0e, 0x6c, 0xff, 0x90,
-	0x84, 0x02, 0xfd, 0x5b, 0x96, 0x04, 0x14, 0x7f, 0x4e, 0x91, 0x0b, 0xd7, 0x83, 0x67, 0x0a, 0x9f,
-	0x9f, 0x78, 0xce, 0x61, 0xad, 0xd7, 0xa0, 0x16, 0xba, 0x5d, 0x58, 0xbb, 0x8a, 0xc3, 0x48, 0x70,
-	0xaf, 0x76, 0x58, 0xef, 0xb5, 0xa9, 0x41, 0xee, 0x0b, 0x58, 0x3f, 0x61, 0x82, 0xfd, 0xc4, 0x38,
-	0x7a, 0xf5, 0x43, 0xa7, 0xd7, 0xa2, 0x19, 0x76, 0x7b, 0xb0, 0x49, 0x51, 0x60, 0x24, 0xc2, 0x38,
-	0xba, 0x8a, 0x27, 0xe1, 0xf8, 0xd1, 0x6b, 0xa8, 0x25, 0xf3, 0x34, 0x39, 0x06, 0xb7, 0x28, 0x86,
-	0xcf, 0xe2, 0x88, 0xa3, 0xeb, 0x42, 0xa3, 0x1f, 0x07, 0xa8, 0xa4, 0x34, 0xa9, 0x1a, 0x4b, 0x85,
-	0x97, 0xc8, 0x39, 0xbb, 0x41, 0xaf, 0xa6, 0x6c, 0x59, 0x48, 0x7c, 0xd8, 0x3b, 0x7d, 0xc0, 0x71,
-	0x2a, 0xd0, 0x17, 0x4c, 0xe0, 0x14, 0x23, 0x61, 0x8f, 0x75, 0x00, 0xad, 0x8c, 0x53, 0xd6, 0x5a,
-	0x34, 0x27, 0x4a, 0x47, 0xa8, 0xa9, 0xc9, 0x0c, 0x93, 0x01, 0x78, 0x8b, 0x46, 0xff, 0x91, 0xbc,
-	0xaf, 0x60, 0x7f, 0xc4, 0xf8, 0xdd, 0x25, 0x8b, 0xd8, 0x0d, 0x26, 0xef, 0x27, 0x91, 0x0c, 0xe0,
-	0xa0, 0x7a, 0xb3, 0x91, 0xd2, 0x85, 0x35, 0x8a, 0x3c, 0x9d, 0xe8, 0xad, 0x6d, 0x6a, 0x90, 0xbb,
-	0x05, 0xf5, 0xd3, 0x24, 0x31, 0x52, 0xe4, 0x90, 0xfc, 0x0a, 0x7b, 0x97, 0xc8, 0x78, 0x9a, 0x28,
-	0x03, 0x43, 0x36, 0x45, 0x6e, 0x25, 0x14, 0xe3, 0xe0, 0x94, 0xe3, 0x50, 0x75, 0x95, 0xb5, 0xca,
-	0xab, 0x94, 0x07, 0xe9, 0xc7, 0x51, 0x10, 0x4a, 0xca, 0x64, 0x44, 0x4e, 0x90, 0x63, 0xf0, 0x16,
-	0xdd, 0x9b, 0x43, 0x74, 0xa0, 0xa9, 0x08, 0xcf, 0x51, 0x19, 0xa6, 0x41, 0xc5, 0x11, 0xde, 0xc0,
-	0xc6, 0x88, 0xdd, 0x7c, 0x87, 0x8f, 0x45, 0xe5, 0x26, 0x4f, 0xf5, 0xe6, 0x06, 0xcd, 0x70, 0x59,
-	0x4f, 0x6d, 0x5e, 0xcf, 0xd7, 0xb0, 0x99, 0xd9, 0x32, 0x32, 0x3c, 0x78, 0x66, 0x28, 0xcf, 0x39,
-	0x74, 0x7a, 0x6d, 0x6a, 0x61, 0x85, 0x94, 0x0b, 0xd8, 0x1a, 0xb1, 0x9b, 0x77, 0x6c, 0x92, 0xe2,
-	0x13, 0x88, 0xe9, 0xc3, 0x76, 0xc1, 0x9a, 0x91, 0x73, 0x00, 0xad, 0x8c, 0x34, 0x82, 0x72, 0xa2,
-	0x42, 0xd2, 0x67, 0xb0, 0xeb, 0x63, 0x12, 0x22, 0xf7, 0xef, 0x50, 0x8c, 0x6f, 0x57, 0xba, 0x5e,
-	0xf2, 0x23, 0x74, 0xe7, 0x37, 0xe5, 0x99, 0xa5, 0x39, 0x9b, 0x59, 0x1a, 0x49, 0x6b, 0x23, 0xdf,
-	0xcc, 0xd4, 0xd4, 0x4c, 0x86, 0xad, 0xa8, 0x7a, 0x2e, 0xea, 0x4b, 0xd8, 0x2f, 0x5c, 0xfb, 0x7b,
-	0x49, 0x0b, 0xe0, 0xa0, 0x7a, 0xeb, 0x93, 0x0a, 0x1c, 0x42, 0xd7, 0x17, 0x71, 0x82, 0x14, 0x59,
-	0x70, 0x16, 0x4e, 0x04, 0x26, 0xab, 0x5c, 0xa7, 0x07, 0xcf, 0xcc, 0x32, 0xe3, 0xc2, 0x42, 0xf2,
-	0x31, 0xec, 0x2d, 0xd8, 0x33, 0x82, 0x8d, 0x73, 0x27, 0x77, 0x7e, 0x09, 0xbb, 0xd9, 0xe2, 0x6f,
-	0x93, 0x38, 0x9d, 0xfd, 0x3b, 0xdf, 0x2f, 0x0b, 0x67, 0x31, 0xe6, 0x96, 0xba, 0xfe, 0xcd, 0x81,
-	0xdd, 0x7e, 0x82, 0x4c, 0xe0, 0xb9, 0xc0, 0x84, 0x89, 0x78, 0xa5, 0x73, 0x1f, 0xc2, 0xf3, 0xc2,
-	0x9d, 0x18, 0xff, 0x45, 0x4a, 0x7a, 0x7a, 0x3b, 0x13, 0x5e, 0x5d, 0xcd, 0xc8, 0xa1, 0xdc, 0xe3,
-	0xcf, 0x58, 0xd4, 0x8f, 0x23, 0x81, 0x0f, 0x42, 0x35, 0x82, 0x36, 0x2d, 0x52, 0x64, 0x0a, 0xdd,
-	0x79, 0x29, 0xcb, 0x74, 0xcb, 0xda, 0x3b, 0x7a, 0x9c, 0xe9, 0x7a, 0xdd, 0xa4, 0x6a, 0xec, 0x7e,
-	0x02, 0x4d, 0x59, 0x19, 0xb9, 0xba, 0xd7, 0xe7, 0x9f, 0xee, 0x1d, 0xd9, 0x66, 0x77, 0x64, 0x0d,
-	0xaa, 0x69, 0xaa, 0x57, 0x91, 0xd7, 0xf0, 0xbf, 0x12, 0xaf, 0x9a, 0x9f, 0x7a, 0x04, 0x43, 0xe5,
-	0xa9, 0x4e, 0x2d, 0xcc, 0x9a, 0xdf, 0x50, 0x3d, 0xb4, 0xba, 0x69, 0x7e, 0x43, 0x82, 0xb0, 0x63,
-	0x4d, 0xf4, 0x63, 0x2e, 0xfe, 0xa3, 0xd0, 0x91, 0x11, 0x74, 0xca, 0x6e, 0x96, 0x86, 0xe5, 0xa5,
-	0x6c, 0x49, 0x2a, 0x23, 0x64, 0x04, 0xba, 0x8b, 0x11, 0x50, 0xfb, 0xd5, 0x1a, 0xf2, 0xa7, 0x03,
-	0xed, 0x22, 0x2d, 0x2b, 0xcd, 0x30, 0x9d, 0x2a, 0xa5, 0xdc, 0x44, 0x20, 0x27, 0xec, 0xac, 0x8a,
-	0x88, 0x09, 0x43, 0x4e, 0xb8, 0x04, 0xda, 0x7d, 0x36, 0xbe, 0xc5, 0xc0, 0x14, 0xaa, 0xba, 0x5a,
-	0x50, 0xe2, 0x64, 0x58, 0x86, 0xe9, 0xf4, 0x2c, 0x9c, 0x20, 0x57, 0xd7, 0x5f, 0xa7, 0x19, 0x76,
-	0xff, 0x0f, 0x70, 0x3c, 0x89, 0xc7, 0x77, 0x5c, 0x26, 0xad, 0xd7, 0x54, 0xb3, 0x05, 0x46, 0x7a,
-	0x57, 0xc8, 0x0f, 0x7f, 0x41, 0x6f, 0x4d, 0x7b, 0xcf, 0x08, 0xf2, 0x0e, 0xba, 0x67, 0x21, 0x4e,
-	0x82, 0x93, 0x70, 0x8a, 0x11, 0x0f, 0xe3, 0x88, 0x3f, 0xc9, 0x55, 0x90, 0x31, 0xec, 0x2d, 0xd8,
-	0xcd, 0xcb, 0x8e, 0x9a, 0xe2, 0xb6, 0xec, 0x68, 0x24, 0x0f, 0x92, 0xaf, 0x56, 0x7f, 0xa5, 0x16,
-	0x2d, 0x30, 0x15, 0xa5, 0x27, 0x80, 0x8d, 0x4b, 0x36, 0x93, 0x19, 0xfc, 0x34, 0xf9, 0xd3, 0x81,
-	0xa6, 0xd2, 0xa2, 0x32, 0xa8, 0x45, 0x35, 0x20, 0x5f, 0xc0, 0x66, 0xe6, 0x25, 0xff, 0xbf, 0xa8,
-	0x37, 0xe4, 0x14, 0xde, 0x50, 0x55, 0x8b, 0xeb, 0x9c, 0x3e, 0xcc, 0x58, 0x14, 0xf8, 0x71, 0x9a,
-	0x8c, 0x57, 0x6b, 0x73, 0xf2, 0x25, 0xe9, 0xd5, 0xb6, 0x36, 0x19, 0x48, 0xfa, 0xb0, 0x3b, 0x67,
-	0x2d, 0xef, 0xba, 0x76, 0x8b, 0x53, 0xda, 0x52, 0x21, 0xe9, 0x04, 0xdc, 0x63, 0x36, 0xbe, 0x4b,
-	0x67, 0x2b, 0xfe, 0x5d, 0x3b, 0xd0, 0xf4, 0xc3, 0x68, 0x8c, 0x26, 0x6d, 0x35, 0x20, 0x1f, 0xc1,
-	0x4e, 0xc9, 0xca, 0xd2, 0x1a, 0xf9, 0xbb, 0x03, 0x5b, 0xfd, 0x78, 0xf6, 0x58, 0xf2, 0xe6, 0x42,
-	0x63, 0x20, 0x5f, 0x9a, 0x6e, 0x57, 0x6a, 0xfc, 0x77, 0x1f, 0x49, 0x5d, 0x42, 0xd4, 0xbf, 0x49,
-	0x5f, 0x8b, 0x41, 0x45, 0xd5, 0x8d, 0x25, 0xaa, 0x9b, 0x45, 0xd5, 0x1f, 0xc0, 0x76, 0x41, 0xcb,
-	0x52, 0xcd, 0x47, 0xe0, 0x52, 0x9c, 0xc6, 0xf7, 0x2b, 0x7e, 0xef, 0x65, 0x30, 0x4a, 0xeb, 0x97,
-	0x1a, 0xfe, 0x06, 0xdc, 0x8b, 0x90, 0x0b, 0x5d, 0x14, 0x4a, 0x4d, 0xd8, 0xd6, 0x0d, 0xdd, 0x84,
-	0x75, 0xd1, 0x58, 0xbc, 0xbb, 0x21, 0xb8, 0x6f, 0xe2, 0x30, 0xea, 0x4f, 0x52, 0x5e, 0x68, 0xb2,
-	0x2a, 0xab, 0x05, 0xf3, 0x31, 0xb9, 0xc7, 0x44, 0xe7, 0x53, 0x8b, 0x16, 0x29, 0xe9, 0xe1, 0xfb,
-	0x59, 0xc0, 0x84, 0x8e, 0xec, 0x3a, 0x35, 0x88, 0xbc, 0x85, 0x9d, 0x92, 0x3d, 0x23, 0xe8, 0x43,
-	0x68, 0x0c, 0xf5, 0xdf, 0x5c, 0x16, 0x42, 0x37, 0x2f, 0x84, 0x92, 0x3d, 0x8f, 0xae, 0x63, 0xaa,
-	0xe6, 0x2b, 0x04, 0x0e, 0x60, 0xdd, 0xae, 0x71, 0x37, 0xa0, 0x96, 0x85, 0xaa, 0x76, 0x7e, 0x22,
-	0x2f, 0xfd, 0x75, 0x10, 0xd8, 0xe5, 0x6a, 0xac, 0xbe, 0x8b, 0xfd, 0x2b, 0x45, 0xeb, 0x47, 0x6d,
-	0x21, 0xe9, 0x41, 0xe7, 0x02, 0xd9, 0x3d, 0xce, 0x6b, 0x5b, 0x0c, 0xea, 0xe7, 0xf0, 0x42, 0x47,
-	0x7f, 0x20, 0x75, 0x06, 0x03, 0x16, 0x05, 0xf1, 0xf5, 0xb5, 0x0d, 0x4e, 0x17, 0xd6, 0x94, 0x22,
-	0xab, 0xc4, 0x20, 0xf2, 0x0a, 0xf6, 0x2b, 0x77, 0x2d, 0x73, 0xf3, 0x57, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xf2, 0xfe, 0x34, 0x16, 0x13, 0x0e, 0x00, 0x00,
+	// 1530 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xdb, 0xc6,
+	0x12, 0x06, 0x75, 0xf1, 0x65, 0xac, 0xf8, 0x42, 0xcb, 0x32, 0x63, 0x1b, 0xe7, 0x08, 0xc4, 0x39,
+	0xad, 0x90, 0xa2, 0x0e, 0x9a, 0x04, 0x2d, 0x8a, 0xa0, 0x05, 0x6c, 0xc9, 0x8e, 0x95, 0xda, 0x4a,
+	0x40, 0x3a, 0xf1, 0x5b, 0x81, 0x8d, 0x38, 0x56, 0x58, 0x4b, 0x24, 0x4b, 0xae, 0x5c, 0xbb, 0x45,
+	0x9f, 0xfa, 0xd4, 0xf6, 0x8f, 0xf5, 0x67, 0x15, 0x7b, 0x23, 0x97, 0x12, 0x95, 0x28, 0x8d, 0xfb,
+	0xc6, 0xf9, 0x76, 0x77, 0xe6, 0xdb, 0xd9, 0xd9, 0x99, 0x59, 0xc2, 0xa6, 0x1f, 0x50, 0x8c, 0x03,
+	0x32, 0x7c, 0xe8, 0x11, 0x4a, 0xf6, 0xa3, 0x38, 0xa4, 0xa1, 0xb9, 0xa4, 0x40, 0xfb, 0x4f, 0x03,
+	0x36, 0x2e, 0x62, 0x9f, 0xa2, 0xfb, 0x96, 0xc4, 0x9e, 0x83, 0x3f, 0x8e, 0x31, 0xa1, 0xa6, 0x05,
+	0x8b, 0x5c, 0xee, 0x76, 0x2c, 0xa3, 0x59, 0x6a, 0x55, 0x1c, 0x25, 0x9a, 0x0d, 0x58, 0x78, 0x19,
+	0xfa, 0x01, 0x4d, 0xac, 0x52, 0xb3, 0xdc, 0xaa, 0x39, 0x52, 0x32, 0x77, 0x60, 0xa9, 0x43, 0x28,
+	0x79, 0x43, 0x12, 0xb4, 0xca, 0x4d, 0xa3, 0xb5, 0xec, 0xa4, 0xb2, 0xd9, 0x82, 0x35, 0x07, 0x29,
+	0x06, 0xd4, 0x0f, 0x83, 0x97, 0xe1, 0xd0, 0xef, 0xdf, 0x5a, 0x15, 0x3e, 0x65, 0x12, 0xb6, 0x0f,
+	0xc1, 0xd4, 0xc9, 0x24, 0x51, 0x18, 0x24, 0x68, 0x9a, 0x50, 0x69, 0x87, 0x1e, 0x72, 0x2a, 0x55,
+	0x87, 0x7f, 0x33, 0x86, 0x67, 0x98, 0x24, 0x64, 0x80, 0x56, 0x89, 0xeb, 0x52, 0xa2, 0xed, 0xc2,
+	0xf6, 0xd1, 0x0d, 0xf6, 0xc7, 0x14, 0x5d, 0x4a, 0x28, 0x8e, 0x30, 0xa0, 0x6a, 0x5b, 0x7b, 0xb0,
+	0x9c, 0x62, 0x5c, 0xdb, 0xb2, 0x93, 0x01, 0xb9, 0x2d, 0x94, 0xf8, 0x60, 0x2a, 0xdb, 0x27, 0x60,
+	0x4d, 0x2b, 0xfd, 0x47, 0xf4, 0x9e, 0xc2, 0xee, 0x39, 0x49, 0xae, 0xce, 0x48, 0x40, 0x06, 0x18,
+	0x7f, 0x18, 0x45, 0xfb, 0x04, 0xf6, 0x8a, 0x17, 0x4b, 0x2a, 0x0d, 0x58, 0x70, 0x30, 0x19, 0x0f,
+	0xc5, 0xd2, 0x9a, 0x23, 0x25, 0x73, 0x1d, 0xca, 0x47, 0x71, 0x2c, 0xa9, 0xb0, 0x4f, 0xfb, 0x57,
+	0xd8, 0x3e, 0x43, 0x92, 0x8c, 0x63, 0xae, 0xa0, 0x47, 0x46, 0x98, 0x28, 0x0a, 0xba, 0x1f, 0x8c,
+	0x66, 0xe9, 0x7d, 0x47, 0x59, 0x2a, 0x3c, 0x4a, 0xb6, 0x91, 0x76, 0x18, 0x78, 0x3e, 0x83, 0x64,
+	0x44, 0x64, 0x80, 0x7d, 0x08, 0xd6, 0xb4, 0x79, 0xb9, 0x89, 0x3a, 0x54, 0x39, 0x60, 0x19, 0x3c,
+	0xc2, 0x84, 0x50, 0xb0, 0x85, 0xe7, 0xb0, 0x7a, 0x4e, 0x06, 0xdf, 0xe1, 0xad, 0xce, 0x5c, 0xc6,
+	0xa9, 0x58, 0x5c, 0x71, 0x52, 0x39, 0xcf, 0xa7, 0x34, 0xc9, 0xe7, 0x1b, 0x58, 0x4b, 0x75, 0x49,
+	0x1a, 0x16, 0x2c, 0x4a, 0xc8, 0x32, 0x9a, 0x46, 0xab, 0xe6, 0x28, 0xb1, 0x80, 0xca, 0x29, 0xac,
+	0x9f, 0x93, 0xc1, 0x6b, 0x32, 0x1c, 0xe3, 0x1d, 0x90, 0x69, 0xc3, 0x86, 0xa6, 0x4d, 0xd2, 0xd9,
+	0x83, 0xe5, 0x14, 0x94, 0x84, 0x32, 0xa0, 0x80, 0xd2, 0x63, 0xd8, 0x72, 0x31, 0xf6, 0x31, 0x71,
+	0xaf, 0x90, 0xf6, 0xdf, 0xce, 0x75, 0xbc, 0xf6, 0xf7, 0xd0, 0x98, 0x5c, 0x94, 0x45, 0x96, 0xc0,
+	0x54, 0x64, 0x09, 0x89, 0x69, 0x3b, 0x77, 0xe5, 0x48, 0x89, 0x8f, 0xa4, 0xb2, 0x22, 0x55, 0xce,
+	0x48, 0x7d, 0x0d, 0xbb, 0xda, 0xb1, 0x7f, 0x10, 0x35, 0x0f, 0xf6, 0x8a, 0x97, 0xde, 0x29, 0xc1,
+	0x1e, 0x34, 0x5c, 0x1a, 0xc6, 0xe8, 0x20, 0xf1, 0x8e, 0xfd, 0x21, 0xc5, 0x78, 0x9e, 0xe3, 0xb4,
+	0x60, 0x51, 0x4e, 0x93, 0x26, 0x94, 0x68, 0x7f, 0x06, 0xdb, 0x53, 0xfa, 0x24, 0x61, 0x69, 0xdc,
+	0xc8, 0x8c, 0x9f, 0xc1, 0x56, 0x3a, 0xf9, 0x59, 0x1c, 0x8e, 0xa3, 0x8f, 0xb3, 0xfd, 0x00, 0x1a,
+	0x93, 0xea, 0x66, 0x9a, 0xbe, 0x80, 0xff, 0xa6, 0x73, 0x2f, 0xfc, 0xc0, 0x0b, 0x7f, 0x3a, 0x18,
+	0x0c, 0x62, 0x1c, 0x10, 0x8a, 0x1f, 0x47, 0xe2, 0x09, 0x34, 0x67, 0x2b, 0x9e, 0x49, 0xe7, 0x77,
+	0x03, 0xb6, 0xda, 0x31, 0x12, 0x8a, 0x5d, 0x8a, 0x31, 0xa1, 0xe1, 0x5c, 0xc7, 0xd0, 0x84, 0x15,
+	0x2d, 0x44, 0x24, 0x13, 0x1d, 0x62, 0x96, 0x5e, 0x44, 0xd4, 0x2a, 0xf3, 0x11, 0xf6, 0xc9, 0xd6,
+	0xb8, 0x11, 0x09, 0xda, 0x61, 0x40, 0xf1, 0x86, 0xf2, 0xba, 0x54, 0x73, 0x74, 0xc8, 0x1e, 0x41,
+	0x63, 0x92, 0xca, 0x2c, 0xde, 0xac, 0x14, 0x9c, 0xdf, 0x46, 0xa2, 0x7c, 0x54, 0x1d, 0xfe, 0x6d,
+	0x7e, 0x0e, 0x55, 0x96, 0xa8, 0x13, 0x1e, 0x66, 0x2b, 0x8f, 0xb6, 0xf7, 0x55, 0xed, 0xdd, 0x57,
+	0x0a, 0xf9, 0xb0, 0x23, 0x66, 0xd9, 0x07, 0x70, 0x2f, 0x87, 0xf3, 0x5a, 0xcc, 0xef, 0x64, 0x8f,
+	0x5b, 0x2a, 0x3b, 0x4a, 0x4c, 0x6b, 0x71, 0x8f, 0xdf, 0xfb, 0xb2, 0xac, 0xc5, 0x3d, 0x1b, 0x61,
+	0x53, 0xa9, 0x68, 0x87, 0x09, 0xfd, 0x97, 0x5c, 0x67, 0x9f, 0x43, 0x3d, 0x6f, 0x66, 0xa6, 0x5b,
+	0x1e, 0xb0, 0x0a, 0xc9, 0x63, 0x83, 0x79, 0xa0, 0x31, 0xed, 0x01, 0xbe, 0x9e, 0xcf, 0xb1, 0xff,
+	0x32, 0xa0, 0xa6, 0xc3, 0x2c, 0xf1, 0xf5, 0xc6, 0x23, 0xce, 0x34, 0x91, 0x1e, 0xc8, 0x00, 0x35,
+	0xca, 0x3d, 0x22, 0xdd, 0x90, 0x01, 0xa6, 0x0d, 0xb5, 0x36, 0xe9, 0xbf, 0x45, 0x4f, 0xe6, 0xcd,
+	0x32, 0x9f, 0x90, 0xc3, 0x98, 0x5b, 0x7a, 0xe3, 0xd1, 0xb1, 0x3f, 0xc4, 0x84, 0x1f, 0x7f, 0xd9,
+	0x49, 0x65, 0xf3, 0x3f, 0x00, 0x87, 0xc3, 0xb0, 0x7f, 0x95, 0xb0, 0xf0, 0xb5, 0xaa, 0x7c, 0x54,
+	0x43, 0x98, 0x75, 0x2e, 0xb9, 0xfe, 0xcf, 0x68, 0x2d, 0x08, 0xeb, 0x29, 0x60, 0xbf, 0x86, 0xc6,
+	0xb1, 0x8f, 0x43, 0xaf, 0xe3, 0x8f, 0x30, 0x48, 0xfc, 0x30, 0x48, 0xee, 0xe4, 0x28, 0xec, 0x3e,
+	0x6c, 0x4f, 0xe9, 0xcd, 0xb2, 0x20, 0x1f, 0x4a, 0x54, 0x16, 0x14, 0x12, 0xdb, 0x48, 0x36, 0x9b,
+	0xb7, 0x6e, 0xcb, 0x8e, 0x86, 0x14, 0x64, 0x42, 0x0f, 0x56, 0xcf, 0x48, 0xc4, 0x22, 0xf8, 0x6e,
+	0xe2, 0xa7, 0x0e, 0x55, 0xce, 0x85, 0x47, 0xd0, 0xb2, 0x23, 0x04, 0xfb, 0x2b, 0x58, 0x4b, 0xad,
+	0x64, 0xed, 0x14, 0x93, 0x55, 0x3b, 0xc5, 0xbe, 0x0b, 0x2b, 0x6e, 0xfd, 0xe8, 0x26, 0x22, 0x81,
+	0xe7, 0x86, 0xe3, 0xb8, 0x3f, 0x5f, 0xd5, 0x65, 0x37, 0x49, 0xcc, 0x56, 0x59, 0x4a, 0x8a, 0x76,
+	0x1b, 0xb6, 0x26, 0xb4, 0x65, 0x4d, 0x80, 0x5a, 0x62, 0xe4, 0x96, 0x14, 0x50, 0xea, 0x80, 0x79,
+	0x48, 0xfa, 0x57, 0xe3, 0x68, 0xce, 0x56, 0xba, 0x0e, 0x55, 0xd7, 0x0f, 0xfa, 0x28, 0xc3, 0x56,
+	0x08, 0xf6, 0xa7, 0xb0, 0x99, 0xd3, 0x32, 0x33, 0x47, 0xfe, 0x61, 0xc0, 0x7a, 0x3b, 0x8c, 0x6e,
+	0x73, 0xd6, 0x4c, 0xa8, 0x9c, 0xb0, 0x9b, 0x26, 0xaa, 0x27, 0xff, 0x7e, 0x57, 0x5f, 0x2b, 0x52,
+	0x08, 0x6f, 0xe3, 0xc4, 0xb1, 0x48, 0x49, 0x67, 0x5d, 0x99, 0xc1, 0xba, 0xaa, 0xb3, 0xfe, 0x3f,
+	0x6c, 0x68, 0x5c, 0x66, 0x72, 0xde, 0x07, 0xd3, 0xc1, 0x51, 0x78, 0x3d, 0xe7, 0x6b, 0x83, 0x39,
+	0x23, 0x37, 0x7f, 0xa6, 0xe2, 0x6f, 0xc1, 0x3c, 0xf5, 0x13, 0xca, 0xa7, 0xe5, 0x7b, 0x02, 0x95,
+	0x37, 0x44, 0x4f, 0xc0, 0xa5, 0x82, 0xb3, 0xeb, 0x81, 0xf9, 0x3c, 0xf4, 0x83, 0xf6, 0x70, 0x9c,
+	0x68, 0x35, 0x9f, 0x47, 0x35, 0x25, 0x2e, 0xc6, 0xd7, 0x18, 0x8b, 0x78, 0x5a, 0x76, 0x74, 0x88,
+	0x59, 0x78, 0x15, 0x79, 0x84, 0x0a, 0xcf, 0x2e, 0x39, 0x52, 0xb2, 0x5f, 0xc0, 0x66, 0x4e, 0x9f,
+	0x24, 0xf4, 0x09, 0x54, 0x7a, 0xe2, 0xa9, 0xc0, 0x12, 0xa1, 0x99, 0x25, 0x42, 0x86, 0x76, 0x83,
+	0xcb, 0xd0, 0xe1, 0xe3, 0x05, 0x04, 0x4f, 0x60, 0x49, 0xcd, 0x31, 0x57, 0xa1, 0x94, 0xba, 0xaa,
+	0xd4, 0xed, 0xb0, 0x43, 0x3f, 0xf0, 0x3c, 0x35, 0x9d, 0x7f, 0xf3, 0xee, 0xb5, 0xfd, 0x92, 0xc3,
+	0xe2, 0x52, 0x2b, 0xd1, 0x6e, 0x41, 0xfd, 0x14, 0xc9, 0x35, 0x4e, 0x72, 0x9b, 0x76, 0xea, 0x13,
+	0xd8, 0x11, 0xde, 0x3f, 0x61, 0x3c, 0xbd, 0x13, 0x12, 0x78, 0xe1, 0xe5, 0xa5, 0x72, 0x4e, 0x03,
+	0x16, 0x38, 0x23, 0xc5, 0x44, 0x4a, 0xf6, 0x43, 0xd8, 0x2d, 0x5c, 0x35, 0xd3, 0x4c, 0x07, 0xac,
+	0x36, 0x89, 0x3d, 0x3f, 0x20, 0x43, 0x9f, 0xde, 0x3a, 0x18, 0x85, 0x31, 0x9d, 0xe7, 0x2d, 0x52,
+	0x03, 0x43, 0x55, 0x3e, 0xa3, 0x67, 0x1f, 0xc1, 0xfd, 0x02, 0x2d, 0xfa, 0xbb, 0x88, 0x21, 0xb2,
+	0x73, 0x96, 0x52, 0x81, 0x9f, 0x7f, 0x80, 0x75, 0xfe, 0x02, 0x65, 0x9b, 0xd1, 0xde, 0x64, 0xf2,
+	0x33, 0xdd, 0x6c, 0x06, 0xb0, 0x20, 0x69, 0x87, 0xa3, 0x28, 0xc6, 0x24, 0x51, 0xdd, 0x7c, 0xd5,
+	0xd1, 0x21, 0x2d, 0x0c, 0xcb, 0x7a, 0x18, 0xda, 0xc7, 0xb0, 0x96, 0xda, 0x12, 0x90, 0xf9, 0x58,
+	0x8b, 0xd8, 0x72, 0x6b, 0xe5, 0xd1, 0x6e, 0x16, 0x22, 0x53, 0xaf, 0xf4, 0x54, 0xcf, 0x2f, 0xb0,
+	0x91, 0xea, 0xd1, 0xdf, 0x0b, 0xef, 0x20, 0xfd, 0x25, 0x2c, 0x8a, 0xa7, 0xa1, 0x28, 0x06, 0x2b,
+	0x8f, 0xf6, 0x8a, 0x0d, 0x09, 0x65, 0x8e, 0x9a, 0x5c, 0x50, 0x27, 0x1e, 0xc2, 0x26, 0xb3, 0xfb,
+	0x1a, 0x63, 0xb6, 0x57, 0x3d, 0x71, 0x4a, 0x48, 0xdd, 0x69, 0x29, 0xda, 0x5f, 0xb0, 0x3b, 0xfd,
+	0x66, 0xec, 0x0f, 0xbd, 0x6e, 0xe0, 0xe1, 0xcd, 0x1c, 0x89, 0x9b, 0x85, 0x6c, 0x7e, 0xc9, 0x3b,
+	0x5a, 0xe8, 0x6d, 0x96, 0x07, 0x98, 0xf7, 0x49, 0x9f, 0xe6, 0x4a, 0xa3, 0x3c, 0x27, 0x09, 0xcb,
+	0x8c, 0xa0, 0x43, 0x05, 0xd1, 0xf0, 0x0c, 0x36, 0xe5, 0x84, 0xf9, 0x7f, 0x8f, 0x1c, 0xf4, 0xe5,
+	0xab, 0x8e, 0xe7, 0x53, 0x21, 0xb1, 0x1d, 0xe4, 0x15, 0xcd, 0xdc, 0xc1, 0x6f, 0x25, 0xe6, 0x9f,
+	0x68, 0xe8, 0xf7, 0x09, 0xc5, 0x8b, 0x83, 0xd3, 0xf7, 0xdb, 0xdc, 0x81, 0xa5, 0x53, 0x24, 0x1e,
+	0xc6, 0xdd, 0x0e, 0xb7, 0x5a, 0x71, 0x52, 0x99, 0x35, 0x40, 0x2e, 0x25, 0x31, 0x75, 0x71, 0xc0,
+	0x0b, 0xb3, 0x6c, 0x80, 0x74, 0x8c, 0xb7, 0xc0, 0x4c, 0x7e, 0x71, 0x79, 0x99, 0x20, 0x95, 0x3d,
+	0x90, 0x0e, 0xb1, 0x19, 0x3d, 0xbc, 0x49, 0x95, 0x88, 0xcc, 0xaf, 0x43, 0xac, 0xbf, 0x60, 0xa2,
+	0x54, 0x21, 0x3a, 0x21, 0x0d, 0x61, 0x29, 0x8a, 0xdd, 0x5b, 0x6b, 0x91, 0x5f, 0x3f, 0xfe, 0xcd,
+	0x79, 0x93, 0xc1, 0xe1, 0x2d, 0xc5, 0xc4, 0x5a, 0x12, 0x8d, 0x97, 0x92, 0xed, 0x6b, 0xa8, 0xe7,
+	0x9d, 0x90, 0x26, 0xd0, 0xd5, 0x83, 0x28, 0x1a, 0xfa, 0xe8, 0x29, 0x32, 0xa2, 0x23, 0x9c, 0x40,
+	0xcd, 0xff, 0xc1, 0x3d, 0x89, 0x48, 0x4a, 0x22, 0x4f, 0xe4, 0xc1, 0x82, 0x68, 0xee, 0xc2, 0x7d,
+	0x65, 0xd7, 0x0f, 0x03, 0xd6, 0x80, 0x8f, 0xb3, 0x08, 0xda, 0x81, 0x25, 0x39, 0xa8, 0xc2, 0x27,
+	0x95, 0x0b, 0x62, 0x67, 0x1f, 0xd6, 0x3b, 0x7e, 0x72, 0xf5, 0x8a, 0xfd, 0xf5, 0x99, 0xe7, 0x81,
+	0xfb, 0x14, 0x36, 0xb4, 0xf9, 0xd9, 0xbf, 0x10, 0x0e, 0xc8, 0xbc, 0x25, 0x84, 0x69, 0x63, 0x7f,
+	0x0f, 0x00, 0x16, 0xa7, 0x30, 0xaf, 0xe6, 0x13, 0x00, 0x00,
 }
@@ -12,7 +12,9 @@ type MetaClient interface {
 	CreateContinuousQuery(database, name, query string) error
 	CreateDatabase(name string) (*meta.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicy(name string, spec *meta.RetentionPolicySpec) (*meta.DatabaseInfo, error)
+	CreateMeasurementSchema(database string, schema *meta.MeasurementSchemaInfo) error
 	CreateRetentionPolicy(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	CreateRollupRule(database, rp, name string, after, interval time.Duration) error
 	CreateSubscription(database, rp, name, mode string, destinations []string) error
 	CreateUser(name, password string, admin bool) (meta.User, error)
 	Database(name string) *meta.DatabaseInfo
@@ -24,7 +26,9 @@ type MetaClient interface {
 	DropShard(id uint64) error
 	DropContinuousQuery(database, name string) error
 	DropDatabase(name string) error
+	DropMeasurementSchema(database, name string) error
 	DropRetentionPolicy(database, name string) error
+	DropRollupRule(database, rp, name string) error
 	DropSubscription(database, rp, name string) error
 	DropUser(name string) error
 	MetaNodes() []meta.NodeInfo
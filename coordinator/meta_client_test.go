@@ -12,7 +12,9 @@ type MetaClient struct {
 	CreateContinuousQueryFn             func(database, name, query string) error
 	CreateDatabaseFn                    func(name string) (*meta.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicyFn func(name string, spec *meta.RetentionPolicySpec) (*meta.DatabaseInfo, error)
+	CreateMeasurementSchemaFn           func(database string, schema *meta.MeasurementSchemaInfo) error
 	CreateRetentionPolicyFn             func(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	CreateRollupRuleFn                  func(database, rp, name string, after, interval time.Duration) error
 	CreateSubscriptionFn                func(database, rp, name, mode string, destinations []string) error
 	CreateUserFn                        func(name, password string, admin bool) (meta.User, error)
 	DatabaseFn                          func(name string) *meta.DatabaseInfo
@@ -23,7 +25,9 @@ type MetaClient struct {
 	DeleteMetaNodeFn                    func(id uint64) error
 	DropContinuousQueryFn               func(database, name string) error
 	DropDatabaseFn                      func(name string) error
+	DropMeasurementSchemaFn             func(database, name string) error
 	DropRetentionPolicyFn               func(database, name string) error
+	DropRollupRuleFn                    func(database, rp, name string) error
 	DropSubscriptionFn                  func(database, rp, name string) error
 	DropShardFn                         func(id uint64) error
 	DropUserFn                          func(name string) error
@@ -53,10 +57,18 @@ func (c *MetaClient) CreateDatabaseWithRetentionPolicy(name string, spec *meta.R
 	return c.CreateDatabaseWithRetentionPolicyFn(name, spec)
 }
 
+func (c *MetaClient) CreateMeasurementSchema(database string, schema *meta.MeasurementSchemaInfo) error {
+	return c.CreateMeasurementSchemaFn(database, schema)
+}
+
 func (c *MetaClient) CreateRetentionPolicy(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error) {
 	return c.CreateRetentionPolicyFn(database, spec, makeDefault)
 }
 
+func (c *MetaClient) CreateRollupRule(database, rp, name string, after, interval time.Duration) error {
+	return c.CreateRollupRuleFn(database, rp, name, after, interval)
+}
+
 func (c *MetaClient) DropShard(id uint64) error {
 	return c.DropShardFn(id)
 }
@@ -101,10 +113,18 @@ func (c *MetaClient) DropDatabase(name string) error {
 	return c.DropDatabaseFn(name)
 }
 
+func (c *MetaClient) DropMeasurementSchema(database, name string) error {
+	return c.DropMeasurementSchemaFn(database, name)
+}
+
 func (c *MetaClient) DropRetentionPolicy(database, name string) error {
 	return c.DropRetentionPolicyFn(database, name)
 }
 
+func (c *MetaClient) DropRollupRule(database, rp, name string) error {
+	return c.DropRollupRuleFn(database, rp, name)
+}
+
 func (c *MetaClient) DropSubscription(database, rp, name string) error {
 	return c.DropSubscriptionFn(database, rp, name)
 }
@@ -40,6 +40,16 @@ type MetaExecutor struct {
 	}
 
 	TLSConfig *tls.Config
+
+	// The NodeVersion of the nodes, by ID, probed again once expired to
+	// pick up upgrades.
+	versionMu sync.Mutex
+	versions  map[uint64]probedNodeVersion
+}
+
+type probedNodeVersion struct {
+	version uint64
+	expires time.Time
 }
 
 // NewMetaExecutor returns a new initialized *MetaExecutor.
@@ -324,6 +334,83 @@ func (e *MetaExecutor) MeasurementsSketches(nodeID uint64, database string) (est
 	return resp.Sketch, resp.TSSketch, nil
 }
 
+func (e *MetaExecutor) CardinalityReport(nodeID uint64, database string, n int) (*tsdb.CardinalityReport, error) {
+	conn, err := e.dial(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Write request.
+	if err := EncodeTLVT(conn, cardinalityReportRequestMessage, &CardinalityReportRequest{
+		Database: database,
+		N:        n,
+	}, e.timeout); err != nil {
+		MarkUnusable(conn)
+		return nil, err
+	}
+
+	// Read the response.
+	var resp CardinalityReportResponse
+	if _, err := DecodeTLVT(conn, &resp, e.timeout); err != nil {
+		MarkUnusable(conn)
+		return nil, err
+	} else if resp.Err != nil {
+		return nil, resp.Err
+	}
+	return resp.Report, nil
+}
+
+func (e *MetaExecutor) DiskUsage(nodeID uint64, database string) (*tsdb.DiskUsage, error) {
+	conn, err := e.dial(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Write request.
+	if err := EncodeTLVT(conn, diskUsageRequestMessage, &DiskUsageRequest{
+		Database: database,
+	}, e.timeout); err != nil {
+		MarkUnusable(conn)
+		return nil, err
+	}
+
+	// Read the response.
+	var resp DiskUsageResponse
+	if _, err := DecodeTLVT(conn, &resp, e.timeout); err != nil {
+		MarkUnusable(conn)
+		return nil, err
+	} else if resp.Err != nil {
+		return nil, resp.Err
+	}
+	return resp.Usage, nil
+}
+
+func (e *MetaExecutor) ListCompactions(nodeID uint64) ([]*meta.CompactionInfo, error) {
+	conn, err := e.dial(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Write request.
+	if err := WriteTypeT(conn, listCompactionsRequestMessage, e.timeout); err != nil {
+		MarkUnusable(conn)
+		return nil, err
+	}
+
+	// Read the response.
+	var resp ListCompactionsResponse
+	if _, err := DecodeTLVT(conn, &resp, e.timeout); err != nil {
+		MarkUnusable(conn)
+		return nil, err
+	} else if resp.Err != nil {
+		return nil, resp.Err
+	}
+	return resp.Compactions, nil
+}
+
 func (e *MetaExecutor) FieldDimensions(nodeID uint64, shardIDs []uint64, m *influxql.Measurement) (fields map[string]influxql.DataType, dimensions map[string]struct{}, err error) {
 	conn, err := e.dial(nodeID)
 	if err != nil {
@@ -512,6 +599,83 @@ func (e *MetaExecutor) ReadGroup(nodeID uint64, shardIDs []uint64, ctx context.C
 	return reads.NewGroupResultSetStreamReader(NewStoreStreamReceiver(conn)), nil
 }
 
+// ReadWindowAggregate reads the window aggregates of req from the shards of
+// node nodeID. The values of nodes older than windowAggregateNodeVersion are
+// read with a ReadFilterRequest and aggregated locally.
+func (e *MetaExecutor) ReadWindowAggregate(nodeID uint64, shardIDs []uint64, ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	if version, err := e.nodeVersion(nodeID); err != nil {
+		return nil, err
+	} else if version < windowAggregateNodeVersion {
+		rs, err := e.ReadFilter(nodeID, shardIDs, ctx, reads.WindowAggregateFilterRequest(req))
+		if err != nil {
+			return nil, err
+		}
+		return reads.NewWindowAggregateFilteredResultSet(req, rs)
+	}
+
+	conn, err := e.dial(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	MarkUnusable(conn)
+
+	if err := func() error {
+		// Write request.
+		if err := EncodeTLVT(conn, storeReadWindowAggregateRequestMessage, &StoreReadWindowAggregateRequest{
+			ShardIDs: shardIDs,
+			Request:  *req,
+		}, e.timeout); err != nil {
+			return err
+		}
+
+		// Read the response.
+		var resp StoreReadWindowAggregateResponse
+		if _, err := DecodeTLVT(conn, &resp, e.timeout); err != nil {
+			return err
+		} else if resp.Err != nil {
+			return resp.Err
+		}
+
+		return nil
+	}(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return reads.NewResultSetStreamReader(NewStoreStreamReceiver(conn)), nil
+}
+
+// nodeVersion returns the NodeVersion of node nodeID.
+func (e *MetaExecutor) nodeVersion(nodeID uint64) (uint64, error) {
+	e.versionMu.Lock()
+	v, ok := e.versions[nodeID]
+	e.versionMu.Unlock()
+	if ok && time.Now().Before(v.expires) {
+		return v.version, nil
+	}
+
+	conn, err := e.dial(nodeID)
+	if err != nil {
+		return 0, err
+	}
+	version, err := probeNodeVersion(conn, e.dialTimeout)
+	if err != nil || version == 0 {
+		MarkUnusable(conn)
+	}
+	conn.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	e.versionMu.Lock()
+	defer e.versionMu.Unlock()
+	if e.versions == nil {
+		e.versions = make(map[uint64]probedNodeVersion)
+	}
+	e.versions[nodeID] = probedNodeVersion{version: version, expires: time.Now().Add(legacyNodeTTL)}
+	return version, nil
+}
+
 // dial returns a connection to a single node in the cluster.
 func (e *MetaExecutor) dial(nodeID uint64) (net.Conn, error) {
 	// If we don't have a connection pool for that addr yet, create one
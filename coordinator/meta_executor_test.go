@@ -2,7 +2,9 @@ package coordinator
 
 import (
 	"fmt"
+	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,6 +22,8 @@ func Test_ExecuteStatement(t *testing.T) {
 	mock.expect("DROP RETENTION POLICY rp0 on foo")
 	mock.expect("DROP DATABASE foo")
 	mock.expect("DROP DATABASE foo")
+	mock.expect("DELETE FIELD debug FROM cpu, /^mem/ WHERE host = 'a'")
+	mock.expect("DELETE FIELD debug FROM cpu, /^mem/ WHERE host = 'a'")
 
 	e := NewMetaExecutor(time.Duration(0), time.Second, time.Minute, 1)
 	e.MetaClient = newMockMetaClient(numOfNodes)
@@ -32,12 +36,63 @@ func Test_ExecuteStatement(t *testing.T) {
 	if err := e.ExecuteStatement(mustParseStatement("DROP DATABASE foo"), "foo"); err != nil {
 		t.Fatal(err)
 	}
+	if err := e.ExecuteStatement(mustParseStatement("DELETE FIELD debug FROM cpu, /^mem/ WHERE host = 'a'"), "foo"); err != nil {
+		t.Fatal(err)
+	}
 
 	if err := mock.done(); err != nil {
 		t.Fatal(err)
 	}
 }
 
+// Ensure a node which doesn't answer the version request is detected as
+// legacy, and the result is cached rather than probed for each request.
+func TestMetaExecutor_NodeVersion_Legacy(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// Accept connections and read them without replying, as an older node
+	// does with message types it doesn't know.
+	var accepted int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&accepted, 1)
+			go func() {
+				defer conn.Close()
+				var buf [1024]byte
+				for {
+					if _, err := conn.Read(buf[:]); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	e := NewMetaExecutor(time.Second, 100*time.Millisecond, time.Minute, 1)
+	mc := newMockMetaClient(2)
+	mc.nodes[1].TCPAddr = ln.Addr().String()
+	e.MetaClient = mc
+
+	for i := 0; i < 2; i++ {
+		if version, err := e.nodeVersion(2); err != nil {
+			t.Fatal(err)
+		} else if version >= windowAggregateNodeVersion {
+			t.Fatalf("unexpected version: %d", version)
+		}
+	}
+	if n := atomic.LoadInt32(&accepted); n != 1 {
+		t.Fatalf("unexpected connection count: %d", n)
+	}
+}
+
 type mockExecutor struct {
 	mu               sync.Mutex
 	expectStatements []influxql.Statement
@@ -0,0 +1,423 @@
+package coordinator
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// legacyNodeTTL is how long a node that doesn't support WriteNodeRequest is
+// written to shard by shard before being probed again, to pick up upgrades.
+const legacyNodeTTL = time.Minute
+
+// errLegacyNode is returned when probing a node that doesn't support
+// WriteNodeRequest.
+var errLegacyNode = errors.New("node does not support write node requests")
+
+// ShardWrite holds the points of a shard written by a WriteNodeRequest.
+type ShardWrite struct {
+	ShardID         uint64
+	Database        string
+	RetentionPolicy string
+	Points          []models.Point
+}
+
+// NodeWriter writes the points of several shards owned by a node in a single
+// WriteNodeRequest. The requests to a node are pipelined on one connection.
+// Nodes running a version without WriteNodeRequest, and nodes whose version
+// is still being probed, are written to shard by shard with the ShardWriter.
+type NodeWriter struct {
+	mu     sync.Mutex
+	nodes  map[uint64]*nodeState
+	closed bool
+
+	requestID   uint64
+	timeout     time.Duration
+	dialTimeout time.Duration
+	compression Compression
+	maxInFlight int
+
+	MetaClient interface {
+		DataNode(id uint64) (ni *meta.NodeInfo, err error)
+	}
+
+	ShardWriter interface {
+		WriteShard(shardID, ownerID uint64, points []models.Point) error
+	}
+
+	TLSConfig *tls.Config
+}
+
+// NewNodeWriter returns a new instance of NodeWriter. At most maxInFlight
+// requests are sent to a node without waiting for their response.
+func NewNodeWriter(timeout, dialTimeout time.Duration, compression Compression, maxInFlight int) *NodeWriter {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+	return &NodeWriter{
+		nodes:       make(map[uint64]*nodeState),
+		timeout:     timeout,
+		dialTimeout: dialTimeout,
+		compression: compression,
+		maxInFlight: maxInFlight,
+	}
+}
+
+// nodeState holds the connection to a node, or when the node was found not
+// to support WriteNodeRequest.
+type nodeState struct {
+	mu          sync.Mutex
+	conn        *nodeConn
+	legacyUntil time.Time
+	dialing     bool
+}
+
+// WriteNode writes the points of writes to the shards of node nodeID. It
+// returns the error of each write, nil if it succeeded, so that only the
+// shards which failed are retried.
+func (w *NodeWriter) WriteNode(nodeID uint64, writes []*ShardWrite) []error {
+	errs := make([]error, len(writes))
+	setErrs := func(err error) {
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+
+	c, err := w.conn(nodeID)
+	if err == errLegacyNode {
+		w.writeShards(nodeID, writes, errs)
+		return errs
+	} else if err != nil {
+		setErrs(err)
+		return errs
+	}
+
+	req := &WriteNodeRequest{
+		RequestID:   atomic.AddUint64(&w.requestID, 1),
+		Compression: w.compression,
+		Shards:      make([]*WriteShardRequest, len(writes)),
+	}
+	for i, wr := range writes {
+		pts := make([][]byte, 0, len(wr.Points))
+		for _, p := range wr.Points {
+			b, err := p.MarshalBinary()
+			if err != nil {
+				continue
+			}
+			pts = append(pts, b)
+		}
+
+		var sr WriteShardRequest
+		sr.SetShardID(wr.ShardID)
+		sr.SetDatabase(wr.Database)
+		sr.SetRetentionPolicy(wr.RetentionPolicy)
+		sr.SetBinaryPoints(pts)
+		req.Shards[i] = &sr
+	}
+
+	resp, err := c.write(req, w.timeout)
+	if err != nil {
+		setErrs(err)
+		return errs
+	} else if resp.Err != nil {
+		setErrs(resp.Err)
+		return errs
+	} else if len(resp.Results) != len(writes) {
+		setErrs(fmt.Errorf("write node %d: got %d results for %d shards", nodeID, len(resp.Results), len(writes)))
+		return errs
+	}
+
+	for i, result := range resp.Results {
+		if result.Code() != 0 {
+			errs[i] = fmt.Errorf("error code %d: %s", result.Code(), result.Message())
+		}
+	}
+	return errs
+}
+
+// writeShards writes each of writes to node nodeID with a WriteShardRequest.
+func (w *NodeWriter) writeShards(nodeID uint64, writes []*ShardWrite, errs []error) {
+	var wg sync.WaitGroup
+	for i, wr := range writes {
+		wg.Add(1)
+		go func(i int, wr *ShardWrite) {
+			defer wg.Done()
+			errs[i] = w.ShardWriter.WriteShard(wr.ShardID, nodeID, wr.Points)
+		}(i, wr)
+	}
+	wg.Wait()
+}
+
+// conn returns the connection to node nodeID. It returns errLegacyNode if the
+// node doesn't support WriteNodeRequest or isn't connected yet, in which case
+// the node is dialed in the background.
+func (w *NodeWriter) conn(nodeID uint64) (*nodeConn, error) {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil, ErrClientClosed
+	}
+	n := w.nodes[nodeID]
+	if n == nil {
+		n = &nodeState{}
+		w.nodes[nodeID] = n
+	}
+	w.mu.Unlock()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn != nil && n.conn.error() == nil {
+		return n.conn, nil
+	} else if !n.dialing && !time.Now().Before(n.legacyUntil) {
+		// Probing a legacy node waits for dialTimeout, so don't hold up
+		// the write: it goes shard by shard until the node is connected.
+		n.dialing = true
+		go w.connect(nodeID, n)
+	}
+	return nil, errLegacyNode
+}
+
+// connect dials node nodeID and sets the connection of n, or when the node
+// can be probed again if it doesn't support WriteNodeRequest.
+func (w *NodeWriter) connect(nodeID uint64, n *nodeState) {
+	conn, err := w.dial(nodeID)
+
+	n.mu.Lock()
+	n.dialing = false
+	if err == errLegacyNode {
+		n.legacyUntil = time.Now().Add(legacyNodeTTL)
+	} else if err == nil {
+		n.conn = newNodeConn(conn, w.maxInFlight)
+	}
+	c := n.conn
+	n.mu.Unlock()
+
+	// Close sets closed before failing the connections of the nodes, so a
+	// connection it didn't see is failed here.
+	w.mu.Lock()
+	closed := w.closed
+	w.mu.Unlock()
+	if closed && c != nil {
+		c.fail(ErrClientClosed)
+	}
+}
+
+// dial connects to node nodeID and checks that it supports WriteNodeRequest.
+// Older nodes ignore the version request, which has no value, and keep
+// waiting for the next message, so they are detected by the timeout.
+func (w *NodeWriter) dial(nodeID uint64) (net.Conn, error) {
+	factory := &connFactory{nodeID: nodeID, clientPool: w, timeout: w.dialTimeout, tlsConfig: w.TLSConfig}
+	factory.metaClient = w.MetaClient
+
+	conn, err := factory.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := probeNodeVersion(conn, w.dialTimeout)
+	if err == nil && version < 1 {
+		err = errLegacyNode
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// probeNodeVersion returns the NodeVersion of the node conn is connected to.
+// Nodes older than version 1 ignore the version request, which has no value,
+// and keep waiting for the next message, so version 0 is returned when no
+// response is received within timeout. conn must not be reused then.
+func probeNodeVersion(conn net.Conn, timeout time.Duration) (uint64, error) {
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+	if err := WriteType(conn, nodeVersionRequestMessage); err != nil {
+		return 0, err
+	}
+
+	var typ [1]byte
+	if _, err := io.ReadFull(conn, typ[:]); err != nil {
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			return 0, nil
+		}
+		return 0, err
+	} else if typ[0] != nodeVersionResponseMessage {
+		return 0, fmt.Errorf("unexpected message type %d", typ[0])
+	}
+
+	var resp NodeVersionResponse
+	buf, err := ReadLV(conn)
+	if err != nil {
+		return 0, err
+	} else if err := resp.UnmarshalBinary(buf); err != nil {
+		return 0, err
+	}
+	return resp.Version, conn.SetDeadline(time.Time{})
+}
+
+// size returns the number of connections of the writer.
+func (w *NodeWriter) size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.nodes)
+}
+
+// Close closes the connections of the writer.
+func (w *NodeWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrClientClosed
+	}
+	w.closed = true
+	for _, n := range w.nodes {
+		n.mu.Lock()
+		if n.conn != nil {
+			n.conn.fail(ErrClientClosed)
+		}
+		n.mu.Unlock()
+	}
+	return nil
+}
+
+// nodeConn is a connection to a node on which several WriteNodeRequests can
+// be in flight. The node answers the requests in order, so the connection
+// is closed as soon as a response is missing.
+type nodeConn struct {
+	conn net.Conn
+	sem  chan struct{}
+
+	// wmu serializes the writes of requests.
+	wmu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[uint64]chan *WriteNodeResponse
+	err     error
+	done    chan struct{}
+}
+
+// newNodeConn returns a nodeConn on conn and starts reading its responses.
+func newNodeConn(conn net.Conn, maxInFlight int) *nodeConn {
+	c := &nodeConn{
+		conn:    conn,
+		sem:     make(chan struct{}, maxInFlight),
+		pending: make(map[uint64]chan *WriteNodeResponse),
+		done:    make(chan struct{}),
+	}
+	go c.readResponses()
+	return c
+}
+
+// write sends req and waits for its response.
+func (c *nodeConn) write(req *WriteNodeRequest, timeout time.Duration) (*WriteNodeResponse, error) {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+		defer func() { <-c.sem }()
+	case <-c.done:
+		return nil, c.error()
+	case <-deadline:
+		return nil, ErrTimeout
+	}
+
+	buf, err := req.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *WriteNodeResponse, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.err
+	}
+	c.pending[req.RequestID] = ch
+	c.mu.Unlock()
+
+	c.wmu.Lock()
+	err = WriteTLVT(c.conn, writeNodeRequestMessage, buf, timeout)
+	c.wmu.Unlock()
+	if err != nil {
+		c.fail(err)
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-c.done:
+		select {
+		case resp := <-ch:
+			return resp, nil
+		default:
+			return nil, c.error()
+		}
+	case <-deadline:
+		c.fail(ErrTimeout)
+		return nil, ErrTimeout
+	}
+}
+
+// readResponses hands the responses read from the connection to the
+// requests waiting for them, until the connection fails.
+func (c *nodeConn) readResponses() {
+	for {
+		typ, buf, err := ReadTLV(c.conn)
+		if err == nil && typ != writeNodeResponseMessage {
+			err = fmt.Errorf("unexpected message type %d", typ)
+		}
+		var resp WriteNodeResponse
+		if err == nil {
+			err = resp.UnmarshalBinary(buf)
+		}
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		c.mu.Lock()
+		ch := c.pending[resp.RequestID]
+		delete(c.pending, resp.RequestID)
+		c.mu.Unlock()
+
+		if ch != nil {
+			ch <- &resp
+		}
+	}
+}
+
+// fail closes the connection and fails the requests in flight with err.
+func (c *nodeConn) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+	c.conn.Close()
+}
+
+// error returns the error the connection failed with, nil if it didn't.
+func (c *nodeConn) error() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
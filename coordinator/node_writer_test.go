@@ -0,0 +1,156 @@
+package coordinator_test
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/models"
+)
+
+// Ensure the node writer writes the shards of a node in one request and
+// returns the error of each shard.
+func TestNodeWriter_WriteNode(t *testing.T) {
+	ts := newTestWriteService(func(shardID uint64, points []models.Point) error {
+		if shardID == 2 {
+			return fmt.Errorf("failed to write")
+		}
+		return nil
+	})
+	s := coordinator.NewService(coordinator.Config{})
+	s.Listener = ts.muxln
+	s.DefaultListener = ts.defln
+	s.MetaClient = &metaClient{addr: ts.ln.Addr().String()}
+	s.TSDBStore = &ts.TSDBStore
+	s.Server = &server{}
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	defer ts.Close()
+
+	var shardWrites, connected int32
+	w := coordinator.NewNodeWriter(10*time.Second, time.Second, coordinator.CompressionZstd, 2)
+	w.MetaClient = &metaClient{addr: ts.ln.Addr().String()}
+	w.ShardWriter = &fakeShardWriter{
+		ShardWriteFn: func(shardID, nodeID uint64, points []models.Point) error {
+			if atomic.LoadInt32(&connected) != 0 {
+				t.Errorf("unexpected shard write: %d", shardID)
+			}
+			atomic.AddInt32(&shardWrites, 1)
+			return nil
+		},
+	}
+	defer w.Close()
+
+	points := []models.Point{models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "server01"}), map[string]interface{}{"value": int64(100)}, time.Now())}
+	writes := []*coordinator.ShardWrite{
+		{ShardID: 1, Database: "db0", RetentionPolicy: "rp0", Points: points},
+		{ShardID: 2, Database: "db0", RetentionPolicy: "rp0", Points: points},
+		{ShardID: 3, Database: "db0", RetentionPolicy: "rp0", Points: points},
+	}
+
+	// Writes go shard by shard until the node's version has been probed.
+	for i := 0; ; i++ {
+		n := atomic.LoadInt32(&shardWrites)
+		if errs := w.WriteNode(2, writes[:1]); errs[0] != nil {
+			t.Fatal(errs[0])
+		} else if atomic.LoadInt32(&shardWrites) == n {
+			break
+		} else if i == 100 {
+			t.Fatal("node not connected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	atomic.StoreInt32(&connected, 1)
+
+	// Write concurrently so that requests are pipelined on the connection.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs := w.WriteNode(2, writes)
+			if len(errs) != 3 {
+				t.Errorf("unexpected error count: %d", len(errs))
+			} else if errs[0] != nil || errs[2] != nil {
+				t.Errorf("unexpected errors: %v", errs)
+			} else if errs[1] == nil || errs[1].Error() != "error code 1: write shard 2: failed to write" {
+				t.Errorf("unexpected shard 2 error: %v", errs[1])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Ensure the node writer falls back to writing shard by shard to a node
+// which doesn't answer the version request, without waiting for the probe.
+func TestNodeWriter_WriteNode_Legacy(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// Accept connections and read them without replying, as an older node
+	// does with message types it doesn't know.
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var buf [1024]byte
+				for {
+					if _, err := conn.Read(buf[:]); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	var mu sync.Mutex
+	written := make(map[uint64]int)
+
+	w := coordinator.NewNodeWriter(10*time.Second, time.Second, coordinator.CompressionSnappy, 1)
+	w.MetaClient = &metaClient{addr: ln.Addr().String()}
+	w.ShardWriter = &fakeShardWriter{
+		ShardWriteFn: func(shardID, nodeID uint64, points []models.Point) error {
+			mu.Lock()
+			defer mu.Unlock()
+			written[shardID] += len(points)
+			return nil
+		},
+	}
+	defer w.Close()
+
+	points := []models.Point{models.MustNewPoint("cpu", nil, map[string]interface{}{"value": 1.0}, time.Now())}
+	writes := []*coordinator.ShardWrite{
+		{ShardID: 1, Points: points},
+		{ShardID: 2, Points: points},
+	}
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		for _, err := range w.WriteNode(2, writes) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if d := time.Since(start); d >= time.Second {
+		t.Fatalf("writes waited for the version probe: %s", d)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if written[1] != 2 || written[2] != 2 {
+		t.Fatalf("unexpected shard writes: %v", written)
+	}
+}
@@ -27,6 +27,7 @@ const (
 	statWriteOK             = "writeOk"
 	statWritePartial        = "writePartial"
 	statWriteDrop           = "writeDrop"
+	statWriteRejected       = "writeRejected"
 	statWriteTimeout        = "writeTimeout"
 	statWriteErr            = "writeError"
 	statSubWriteOK          = "subWriteOk"
@@ -74,9 +75,24 @@ type PointsWriter struct {
 		Empty(shardID, ownerID uint64) bool
 	}
 
+	// NodeWriter, when set, writes the points of all the shards of a write
+	// owned by a remote node in a single request, instead of one request per
+	// shard with the ShardWriter.
+	NodeWriter interface {
+		WriteNode(nodeID uint64, writes []*ShardWrite) []error
+	}
+
 	Subscriber interface {
 		Points() chan<- *WritePointsRequest
 	}
+
+	// Transformer rewrites the points of a write before they are mapped to
+	// shards. It returns the index of the original point of each point, or
+	// nil if the points are returned as is.
+	Transformer interface {
+		Transform(database string, points []models.Point) ([]models.Point, []int)
+	}
+
 	subPoints []chan<- *WritePointsRequest
 
 	stats *WriteStatistics
@@ -112,10 +128,11 @@ func NewPointsWriter() *PointsWriter {
 
 // ShardMapping contains a mapping of shards to points.
 type ShardMapping struct {
-	n       int
-	Points  map[uint64][]models.Point  // The points associated with a shard ID
-	Shards  map[uint64]*meta.ShardInfo // The shards that have been mapped, keyed by shard ID
-	Dropped []models.Point             // Points that were dropped
+	n        int
+	Points   map[uint64][]models.Point  // The points associated with a shard ID
+	Shards   map[uint64]*meta.ShardInfo // The shards that have been mapped, keyed by shard ID
+	Dropped  []models.Point             // Points that were dropped
+	Rejected []SchemaError              // Points that did not conform to their measurement schema
 }
 
 // NewShardMapping creates an empty ShardMapping.
@@ -179,6 +196,7 @@ type WriteStatistics struct {
 	WriteOK             int64
 	WritePartial        int64
 	WriteDropped        int64
+	WriteRejected       int64
 	WriteTimeout        int64
 	WriteErr            int64
 	SubWriteOK          int64
@@ -199,6 +217,7 @@ func (w *PointsWriter) Statistics(tags map[string]string) []models.Statistic {
 			statWriteOK:             atomic.LoadInt64(&w.stats.WriteOK),
 			statWritePartial:        atomic.LoadInt64(&w.stats.WritePartial),
 			statWriteDrop:           atomic.LoadInt64(&w.stats.WriteDropped),
+			statWriteRejected:       atomic.LoadInt64(&w.stats.WriteRejected),
 			statWriteTimeout:        atomic.LoadInt64(&w.stats.WriteTimeout),
 			statWriteErr:            atomic.LoadInt64(&w.stats.WriteErr),
 			statSubWriteOK:          atomic.LoadInt64(&w.stats.SubWriteOK),
@@ -210,6 +229,13 @@ func (w *PointsWriter) Statistics(tags map[string]string) []models.Statistic {
 // MapShards maps the points contained in wp to a ShardMapping.  If a point
 // maps to a shard group or shard that does not currently exist, it will be
 // created before returning the mapping.
+//
+// Points that do not conform to the schema of their measurement are not
+// mapped and are returned in Rejected. wp.Points is then replaced by the
+// points that conform, converted to the types of the schema in coerce mode.
+//
+// Points in a window being resharded are also mapped to the new group of the
+// window, so that the group holds them once it replaces the old groups.
 func (w *PointsWriter) MapShards(wp *WritePointsRequest) (*ShardMapping, error) {
 	rp, err := w.MetaClient.RetentionPolicy(wp.Database, wp.RetentionPolicy)
 	if err != nil {
@@ -218,6 +244,12 @@ func (w *PointsWriter) MapShards(wp *WritePointsRequest) (*ShardMapping, error)
 		return nil, influxdb.ErrRetentionPolicyNotFound(wp.RetentionPolicy)
 	}
 
+	var rejected []SchemaError
+	if di := w.MetaClient.Database(wp.Database); di != nil && len(di.MeasurementSchemas) > 0 {
+		wp.Points, rejected = conformPoints(di, wp.Points)
+		atomic.AddInt64(&w.stats.WriteRejected, int64(len(rejected)))
+	}
+
 	// Holds all the shard groups and shards that are required for writes.
 	list := sgList{items: make(meta.ShardGroupInfos, 0, 8)}
 	min := time.Unix(0, models.MinNanoTime)
@@ -235,7 +267,11 @@ func (w *PointsWriter) MapShards(wp *WritePointsRequest) (*ShardMapping, error)
 		// No shard groups overlap with the point's time, so we will create
 		// a new shard group for this point.
 		sg, err := w.MetaClient.CreateShardGroup(wp.Database, wp.RetentionPolicy, p.Time())
-		if err != nil {
+		if err == meta.ErrShardGroupResharding && rp.Reshard != nil && rp.Reshard.ShardGroupAt(p.Time()) != nil {
+			// The window has no old group, the point is only written to
+			// the new group.
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
@@ -246,9 +282,20 @@ func (w *PointsWriter) MapShards(wp *WritePointsRequest) (*ShardMapping, error)
 	}
 
 	mapping := NewShardMapping(len(wp.Points))
+	mapping.Rejected = rejected
 	for _, p := range wp.Points {
-		sg := list.ShardGroupAt(p.Time())
-		if sg == nil {
+		var groups []*meta.ShardGroupInfo
+		if p.Time().Before(min) {
+			// The point is outside the scope of the RP.
+		} else if sg := list.ShardGroupAt(p.Time()); sg != nil {
+			groups = append(groups, sg)
+		}
+		if rp.Reshard != nil && !p.Time().Before(min) {
+			if sg := rp.Reshard.ShardGroupAt(p.Time()); sg != nil {
+				groups = append(groups, sg)
+			}
+		}
+		if len(groups) == 0 {
 			// We didn't create a shard group because the point was outside the
 			// scope of the RP.
 			mapping.Dropped = append(mapping.Dropped, p)
@@ -256,12 +303,55 @@ func (w *PointsWriter) MapShards(wp *WritePointsRequest) (*ShardMapping, error)
 			continue
 		}
 
-		sh := sg.ShardFor(p)
-		mapping.MapPoint(&sh, p)
+		for _, sg := range groups {
+			sh := sg.ShardFor(p)
+			if rp.WALReplicated() {
+				sh = leaderShard(sh)
+			}
+			mapping.MapPoint(&sh, p)
+		}
 	}
 	return mapping, nil
 }
 
+// leaderShard returns a copy of sh owned only by its leader. Shards of
+// retention policies replicated by WAL shipping are written to their leader,
+// which ships its WAL to the other owners.
+func leaderShard(sh meta.ShardInfo) meta.ShardInfo {
+	leader := sh.LeaderID()
+	for _, owner := range sh.Owners {
+		if owner.NodeID == leader {
+			sh.Owners = []meta.ShardOwner{owner}
+			break
+		}
+	}
+	return sh
+}
+
+// conformPoints returns the points that conform to the schemas of their
+// measurements in the database, and the errors of the others.
+func conformPoints(di *meta.DatabaseInfo, points []models.Point) ([]models.Point, []SchemaError) {
+	var (
+		conforming = make([]models.Point, 0, len(points))
+		errs       []SchemaError
+	)
+	for i, p := range points {
+		schema := di.MeasurementSchema(string(p.Name()))
+		if schema == nil {
+			conforming = append(conforming, p)
+			continue
+		}
+
+		cp, err := conformPoint(schema, p)
+		if err != nil {
+			errs = append(errs, SchemaError{Index: i, Measurement: string(p.Name()), Err: err})
+			continue
+		}
+		conforming = append(conforming, cp)
+	}
+	return conforming, errs
+}
+
 // sgList is a wrapper around a meta.ShardGroupInfos where we can also check
 // if a given time is covered by any of the shard groups in the list.
 type sgList struct {
@@ -397,10 +487,30 @@ func (w *PointsWriter) WritePointsPrivilegedWithContext(ctx context.Context, dat
 		retentionPolicy = db.DefaultRetentionPolicy
 	}
 
-	shardMappings, err := w.MapShards(&WritePointsRequest{Database: database, RetentionPolicy: retentionPolicy, Points: points})
+	var index []int
+	if w.Transformer != nil && len(points) > 0 {
+		points, index = w.Transformer.Transform(database, points)
+		if len(points) == 0 {
+			// Every point was dropped by the transformation rules.
+			return nil
+		}
+	}
+
+	wp := &WritePointsRequest{Database: database, RetentionPolicy: retentionPolicy, Points: points}
+	shardMappings, err := w.MapShards(wp)
 	if err != nil {
 		return err
 	}
+	points = wp.Points
+	if index != nil {
+		// Report the points rejected by their index in the write.
+		for i := range shardMappings.Rejected {
+			shardMappings.Rejected[i].Index = index[shardMappings.Rejected[i].Index]
+		}
+	}
+
+	// Send the points of the shards owned by each remote node in one request.
+	nodes := w.writeNodes(database, retentionPolicy, shardMappings)
 
 	// Write each shard in it's own goroutine and return as soon as one fails.
 	ch := make(chan error, len(shardMappings.Points))
@@ -410,7 +520,7 @@ func (w *PointsWriter) WritePointsPrivilegedWithContext(ctx context.Context, dat
 			ctx = context.WithValue(ctx, tsdb.StatPointsWritten, &numPoints)
 			ctx = context.WithValue(ctx, tsdb.StatValuesWritten, &numValues)
 
-			err := w.writeToShardWithContext(ctx, shard, database, retentionPolicy, consistencyLevel, points)
+			err := w.writeToShardWithContext(ctx, shard, database, retentionPolicy, consistencyLevel, points, nodes)
 			if err == tsdb.ErrShardDeletion {
 				err = tsdb.PartialWriteError{Reason: fmt.Sprintf("shard %d is pending deletion", shard.ID), Dropped: len(points)}
 			}
@@ -450,7 +560,13 @@ func (w *PointsWriter) WritePointsPrivilegedWithContext(ctx context.Context, dat
 		atomic.AddInt64(&w.stats.SubWriteDrop, dropped)
 	}
 
-	if err == nil && len(shardMappings.Dropped) > 0 {
+	if err == nil && len(shardMappings.Rejected) > 0 {
+		reason := schemaErrorsReason(shardMappings.Rejected)
+		if len(shardMappings.Dropped) > 0 {
+			reason = "points beyond retention policy; " + reason
+		}
+		err = tsdb.PartialWriteError{Reason: reason, Dropped: len(shardMappings.Dropped) + len(shardMappings.Rejected)}
+	} else if err == nil && len(shardMappings.Dropped) > 0 {
 		err = tsdb.PartialWriteError{Reason: "points beyond retention policy", Dropped: len(shardMappings.Dropped)}
 	}
 	for range shardMappings.Points {
@@ -469,10 +585,13 @@ func (w *PointsWriter) WritePointsPrivilegedWithContext(ctx context.Context, dat
 // writeToShards writes points to a shard and ensures a write consistency level has been met.
 // If the write partially succeeds, ErrPartialWrite is returned.
 func (w *PointsWriter) writeToShard(shard *meta.ShardInfo, database, retentionPolicy string, consistency models.ConsistencyLevel, points []models.Point) error {
-	return w.writeToShardWithContext(context.Background(), shard, database, retentionPolicy, consistency, points)
+	return w.writeToShardWithContext(context.Background(), shard, database, retentionPolicy, consistency, points, nil)
 }
 
-func (w *PointsWriter) writeToShardWithContext(ctx context.Context, shard *meta.ShardInfo, database, retentionPolicy string, consistency models.ConsistencyLevel, points []models.Point) error {
+// writeToShardWithContext writes points to a shard and ensures a write
+// consistency level has been met. The writes to the remote owners of the
+// shard in nodes are waited for instead of being sent.
+func (w *PointsWriter) writeToShardWithContext(ctx context.Context, shard *meta.ShardInfo, database, retentionPolicy string, consistency models.ConsistencyLevel, points []models.Point, nodes *nodeWrites) error {
 	// The required number of writes to achieve the requested consistency level
 	required := len(shard.Owners)
 	switch consistency {
@@ -532,20 +651,27 @@ func (w *PointsWriter) writeToShardWithContext(ctx context.Context, shard *meta.
 				return
 			}
 
-			if !w.AllowOutOfOrderWrites && !w.HintedHandoff.Empty(shardID, owner.NodeID) {
-				atomic.AddInt64(&w.stats.PointWriteReqHH, int64(len(points)))
-				hherr := w.HintedHandoff.WriteShard(shardID, owner.NodeID, points)
-				if hherr != nil {
-					w.Logger.Warn("Write shard failed with hinted handoff", zap.Uint64("node_id", owner.NodeID), zap.Uint64("shard_id", shardID), zap.Error(hherr))
-					ch <- &AsyncWriteResult{owner, hherr}
+			var err error
+			if result := nodes.result(shardID, owner.NodeID); result != nil {
+				atomic.AddInt64(&w.stats.PointWriteReqRemote, int64(len(points)))
+				<-result.done
+				err = result.err
+			} else {
+				if !w.AllowOutOfOrderWrites && !w.HintedHandoff.Empty(shardID, owner.NodeID) {
+					atomic.AddInt64(&w.stats.PointWriteReqHH, int64(len(points)))
+					hherr := w.HintedHandoff.WriteShard(shardID, owner.NodeID, points)
+					if hherr != nil {
+						w.Logger.Warn("Write shard failed with hinted handoff", zap.Uint64("node_id", owner.NodeID), zap.Uint64("shard_id", shardID), zap.Error(hherr))
+						ch <- &AsyncWriteResult{owner, hherr}
+						return
+					}
+					ch <- &AsyncWriteResult{owner, hh.ErrHintedHandoffQueueNotEmpty}
 					return
 				}
-				ch <- &AsyncWriteResult{owner, hh.ErrHintedHandoffQueueNotEmpty}
-				return
-			}
 
-			atomic.AddInt64(&w.stats.PointWriteReqRemote, int64(len(points)))
-			err := w.ShardWriter.WriteShard(shardID, owner.NodeID, points)
+				atomic.AddInt64(&w.stats.PointWriteReqRemote, int64(len(points)))
+				err = w.ShardWriter.WriteShard(shardID, owner.NodeID, points)
+			}
 			if err != nil && hh.IsRetryable(err) {
 				// The remote write failed so queue it via hinted handoff
 				atomic.AddInt64(&w.stats.PointWriteReqHH, int64(len(points)))
@@ -620,3 +746,73 @@ func (w *PointsWriter) writeToShardWithContext(ctx context.Context, shard *meta.
 
 	return ErrWriteFailed
 }
+
+// nodeWrites holds the results of the writes to the shards of each remote
+// node, sent in a single request per node.
+type nodeWrites struct {
+	results map[shardOwner]*nodeWriteResult
+}
+
+// shardOwner identifies the copy of a shard on a node.
+type shardOwner struct {
+	shardID uint64
+	nodeID  uint64
+}
+
+// nodeWriteResult is the result of the write to a shard of a node, set
+// before done is closed.
+type nodeWriteResult struct {
+	done chan struct{}
+	err  error
+}
+
+// result returns the result of the write to the shard of the node, nil if the
+// shard is not written to the node by a node request.
+func (n *nodeWrites) result(shardID, nodeID uint64) *nodeWriteResult {
+	if n == nil {
+		return nil
+	}
+	return n.results[shardOwner{shardID: shardID, nodeID: nodeID}]
+}
+
+// writeNodes sends the points of the shards of shardMappings to their remote
+// owners, with one request per node. The copies of shards with hinted handoff
+// data queued for the node are left out to be queued too, unless out of order
+// writes are allowed. It returns nil without a NodeWriter.
+func (w *PointsWriter) writeNodes(database, retentionPolicy string, shardMappings *ShardMapping) *nodeWrites {
+	if w.NodeWriter == nil {
+		return nil
+	}
+
+	localID := w.MetaClient.NodeID()
+	nodes := &nodeWrites{results: make(map[shardOwner]*nodeWriteResult)}
+	writes := make(map[uint64][]*ShardWrite)
+	for shardID, points := range shardMappings.Points {
+		for _, owner := range shardMappings.Shards[shardID].Owners {
+			if owner.NodeID == localID {
+				continue
+			} else if !w.AllowOutOfOrderWrites && !w.HintedHandoff.Empty(shardID, owner.NodeID) {
+				continue
+			}
+			writes[owner.NodeID] = append(writes[owner.NodeID], &ShardWrite{
+				ShardID:         shardID,
+				Database:        database,
+				RetentionPolicy: retentionPolicy,
+				Points:          points,
+			})
+			nodes.results[shardOwner{shardID: shardID, nodeID: owner.NodeID}] = &nodeWriteResult{done: make(chan struct{})}
+		}
+	}
+
+	for nodeID, writes := range writes {
+		go func(nodeID uint64, writes []*ShardWrite) {
+			errs := w.NodeWriter.WriteNode(nodeID, writes)
+			for i, wr := range writes {
+				result := nodes.results[shardOwner{shardID: wr.ShardID, nodeID: nodeID}]
+				result.err = errs[i]
+				close(result.done)
+			}
+		}(nodeID, writes)
+	}
+	return nodes
+}
@@ -3,6 +3,7 @@ package coordinator_test
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,7 +12,9 @@ import (
 	"github.com/influxdata/influxdb/coordinator"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/services/transform"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
 )
 
 // TODO(benbjohnson): Rewrite tests to use cluster_test.MetaClient.
@@ -50,6 +53,46 @@ func TestPointsWriter_MapShards_One(t *testing.T) {
 	}
 }
 
+// Ensures the points writer maps points of a WAL replicated retention policy
+// to the leader of their shard only.
+func TestPointsWriter_MapShards_WALReplicated(t *testing.T) {
+	ms := PointsWriterMetaClient{}
+	rp := NewRetentionPolicy("myp", time.Hour, 3)
+	rp.ReplicationMode = meta.ReplicationModeWAL
+	rp.ShardGroups[0].Shards[0].Leader = 2
+
+	ms.NodeIDFn = func() uint64 { return 1 }
+	ms.RetentionPolicyFn = func(db, retentionPolicy string) (*meta.RetentionPolicyInfo, error) {
+		return rp, nil
+	}
+
+	ms.CreateShardGroupIfNotExistsFn = func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+		return &rp.ShardGroups[0], nil
+	}
+
+	c := coordinator.PointsWriter{MetaClient: ms}
+	pr := &coordinator.WritePointsRequest{
+		Database:        "mydb",
+		RetentionPolicy: "myrp",
+	}
+	pr.AddPoint("cpu", 1.0, time.Now(), nil)
+
+	shardMappings, err := c.MapShards(pr)
+	if err != nil {
+		t.Fatalf("unexpected an error: %v", err)
+	}
+
+	sh := shardMappings.Shards[rp.ShardGroups[0].Shards[0].ID]
+	if got, exp := len(sh.Owners), 1; got != exp {
+		t.Fatalf("owners len mismatch: got %v, exp %v", got, exp)
+	} else if got, exp := sh.Owners[0].NodeID, uint64(2); got != exp {
+		t.Fatalf("owner mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := len(rp.ShardGroups[0].Shards[0].Owners), 3; got != exp {
+		t.Fatalf("shard owners modified: got %v, exp %v", got, exp)
+	}
+}
+
 // Ensures the points writer maps to a new shard group when the shard duration
 // is changed.
 func TestPointsWriter_MapShards_AlterShardDuration(t *testing.T) {
@@ -202,6 +245,59 @@ func TestPointsWriter_MapShards_Multiple(t *testing.T) {
 	}
 }
 
+// Ensures the points writer maps points in a window being resharded to both
+// the old and the new groups of the window.
+func TestPointsWriter_MapShards_Reshard(t *testing.T) {
+	ms := PointsWriterMetaClient{}
+	start := time.Now().Add(-4 * time.Hour).Truncate(2 * time.Hour)
+	rp := &meta.RetentionPolicyInfo{
+		Name:               "myrp",
+		ShardGroupDuration: 2 * time.Hour,
+		ShardGroups: []meta.ShardGroupInfo{
+			{ID: 1, StartTime: start, EndTime: start.Add(time.Hour), Shards: []meta.ShardInfo{{ID: 1}}},
+		},
+		Reshard: &meta.ReshardInfo{
+			Duration: 2 * time.Hour,
+			ShardGroups: []meta.ShardGroupInfo{
+				{ID: 2, StartTime: start, EndTime: start.Add(2 * time.Hour), Shards: []meta.ShardInfo{{ID: 10}}},
+			},
+		},
+	}
+
+	ms.RetentionPolicyFn = func(db, retentionPolicy string) (*meta.RetentionPolicyInfo, error) {
+		return rp, nil
+	}
+	ms.CreateShardGroupIfNotExistsFn = func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+		if rp.ShardGroups[0].Contains(timestamp) {
+			return &rp.ShardGroups[0], nil
+		}
+		return nil, meta.ErrShardGroupResharding
+	}
+
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	pr := &coordinator.WritePointsRequest{
+		Database:        "mydb",
+		RetentionPolicy: "myrp",
+	}
+	// The second hour of the window has no old group.
+	pr.AddPoint("cpu", 1.0, start.Add(10*time.Minute), nil)
+	pr.AddPoint("cpu", 2.0, start.Add(90*time.Minute), nil)
+
+	shardMappings, err := c.MapShards(pr)
+	if err != nil {
+		t.Fatalf("unexpected an error: %v", err)
+	}
+
+	if got, exp := len(shardMappings.Points[1]), 1; got != exp {
+		t.Fatalf("old shard points mismatch: got %v, exp %v", got, exp)
+	} else if got, exp := len(shardMappings.Points[10]), 2; got != exp {
+		t.Fatalf("new shard points mismatch: got %v, exp %v", got, exp)
+	} else if got, exp := len(shardMappings.Dropped), 0; got != exp {
+		t.Fatalf("dropped points mismatch: got %v, exp %v", got, exp)
+	}
+}
+
 // Ensures the points writer does not map points beyond the retention policy.
 func TestPointsWriter_MapShards_Invalid(t *testing.T) {
 	ms := PointsWriterMetaClient{}
@@ -243,6 +339,222 @@ func TestPointsWriter_MapShards_Invalid(t *testing.T) {
 	}
 }
 
+// Ensures the points writer only maps points conforming to their measurement schema.
+func TestPointsWriter_MapShards_Schema(t *testing.T) {
+	ms := PointsWriterMetaClient{}
+	rp := NewRetentionPolicy("myp", time.Hour, 3)
+
+	ms.RetentionPolicyFn = func(db, retentionPolicy string) (*meta.RetentionPolicyInfo, error) {
+		return rp, nil
+	}
+	ms.CreateShardGroupIfNotExistsFn = func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+		return &rp.ShardGroups[0], nil
+	}
+	ms.DatabaseFn = func(database string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name: database,
+			MeasurementSchemas: []meta.MeasurementSchemaInfo{
+				{
+					Name:         "cpu",
+					Fields:       []meta.FieldSchemaInfo{{Name: "value", Type: influxql.Float}},
+					RequiredTags: []string{"host"},
+					AllowedTags:  []string{"region"},
+					Mode:         meta.MeasurementSchemaModeStrict,
+				},
+				{
+					Name:   "mem",
+					Fields: []meta.FieldSchemaInfo{{Name: "used", Type: influxql.Integer}},
+					Mode:   meta.MeasurementSchemaModeCoerce,
+				},
+			},
+		}
+	}
+
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	defer c.Close()
+
+	points, err := models.ParsePointsString(`cpu,host=a value=1
+cpu,host=a value=1i
+cpu value=1
+cpu,host=a,dc=x value=1
+cpu,host=a,region=eu value=1,other=2
+mem used=3
+mem used=3.5
+disk free=4i`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr := &coordinator.WritePointsRequest{Database: "mydb", RetentionPolicy: "myrp", Points: points}
+
+	shardMappings, err := c.MapShards(pr)
+	if err != nil {
+		t.Fatalf("unexpected an error: %v", err)
+	}
+
+	var got []string
+	for _, p := range pr.Points {
+		got = append(got, strings.TrimSuffix(p.String(), fmt.Sprintf(" %d", p.UnixNano())))
+	}
+	exp := []string{"cpu,host=a value=1", "mem used=3i", "disk free=4i"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points:\ngot %v\nexp %v", got, exp)
+	}
+	if got, exp := len(shardMappings.Points[rp.ShardGroups[0].Shards[0].ID]), 3; got != exp {
+		t.Fatalf("MapShards() len mismatch. got %v, exp %v", got, exp)
+	}
+
+	var errs []string
+	for _, err := range shardMappings.Rejected {
+		errs = append(errs, err.Error())
+	}
+	expErrs := []string{
+		`point 2: measurement "cpu": field "value" is integer, schema requires float`,
+		`point 3: measurement "cpu": missing required tag "host"`,
+		`point 4: measurement "cpu": tag "dc" is not allowed`,
+		`point 5: measurement "cpu": field "other" is not in the schema`,
+		`point 7: measurement "mem": field "used" is float and can't be converted to integer`,
+	}
+	if !reflect.DeepEqual(errs, expErrs) {
+		t.Fatalf("unexpected errors:\ngot %q\nexp %q", errs, expErrs)
+	}
+}
+
+// Ensures points rejected by their measurement schema are reported in a partial write error.
+func TestPointsWriter_WritePoints_Schema(t *testing.T) {
+	ms := PointsWriterMetaClient{}
+	rp := NewRetentionPolicy("myp", time.Hour, 1)
+
+	ms.NodeIDFn = func() uint64 { return 1 }
+	ms.RetentionPolicyFn = func(db, retentionPolicy string) (*meta.RetentionPolicyInfo, error) {
+		return rp, nil
+	}
+	ms.CreateShardGroupIfNotExistsFn = func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+		return &rp.ShardGroups[0], nil
+	}
+	ms.DatabaseFn = func(database string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{
+			Name: database,
+			MeasurementSchemas: []meta.MeasurementSchemaInfo{{
+				Name:   "cpu",
+				Fields: []meta.FieldSchemaInfo{{Name: "value", Type: influxql.Float}},
+				Mode:   meta.MeasurementSchemaModeStrict,
+			}},
+		}
+	}
+
+	var mu sync.Mutex
+	var written int
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	c.TSDBStore = &fakeStore{
+		WriteFn: func(shardID uint64, points []models.Point) error {
+			mu.Lock()
+			defer mu.Unlock()
+			written += len(points)
+			return nil
+		},
+	}
+	c.Open()
+	defer c.Close()
+
+	now := time.Now()
+	points := []models.Point{
+		models.MustNewPoint("cpu", nil, models.Fields{"value": 1.0}, now),
+		models.MustNewPoint("cpu", nil, models.Fields{"value": int64(1)}, now),
+	}
+	err := c.WritePointsPrivileged("mydb", "myrp", models.ConsistencyLevelOne, points)
+	werr, ok := err.(tsdb.PartialWriteError)
+	if !ok {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if werr.Dropped != 1 || werr.Reason != `measurement schema violations: point 2: measurement "cpu": field "value" is integer, schema requires float` {
+		t.Fatalf("unexpected partial write: %v", werr)
+	}
+
+	// Rejected points are numbered as in the write when transformation
+	// rules dropped points before them.
+	pipeline, err := transform.NewPipeline(transform.Configs{
+		{Name: "drop-debug", Action: transform.ActionDropSeries, Measurement: "debug"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Transformer = pipeline
+	points = append([]models.Point{models.MustNewPoint("debug", nil, models.Fields{"value": 1.0}, now)}, points...)
+	err = c.WritePointsPrivileged("mydb", "myrp", models.ConsistencyLevelOne, points)
+	if werr, ok := err.(tsdb.PartialWriteError); !ok {
+		t.Fatalf("unexpected error: %v", err)
+	} else if werr.Dropped != 1 || werr.Reason != `measurement schema violations: point 3: measurement "cpu": field "value" is integer, schema requires float` {
+		t.Fatalf("unexpected partial write: %v", werr)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if written != 2 {
+		t.Fatalf("unexpected points written: %d", written)
+	}
+}
+
+func TestPointsWriter_WritePoints_Transform(t *testing.T) {
+	ms := PointsWriterMetaClient{}
+	rp := NewRetentionPolicy("myp", time.Hour, 1)
+
+	ms.NodeIDFn = func() uint64 { return 1 }
+	ms.RetentionPolicyFn = func(db, retentionPolicy string) (*meta.RetentionPolicyInfo, error) {
+		return rp, nil
+	}
+	ms.CreateShardGroupIfNotExistsFn = func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+		return &rp.ShardGroups[0], nil
+	}
+
+	pipeline, err := transform.NewPipeline(transform.Configs{
+		{Name: "drop-pod", Action: transform.ActionDropTag, Key: "pod"},
+		{Name: "drop-debug", Action: transform.ActionDropSeries, Measurement: "debug"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var written []string
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	c.Transformer = pipeline
+	c.TSDBStore = &fakeStore{
+		WriteFn: func(shardID uint64, points []models.Point) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, p := range points {
+				written = append(written, string(p.Key()))
+			}
+			return nil
+		},
+	}
+	c.Open()
+	defer c.Close()
+
+	now := time.Now()
+	points := []models.Point{
+		models.MustNewPoint("cpu", models.NewTags(map[string]string{"host": "a", "pod": "x"}), models.Fields{"value": 1.0}, now),
+		models.MustNewPoint("debug", nil, models.Fields{"value": 1.0}, now),
+	}
+	if err := c.WritePointsPrivileged("mydb", "myrp", models.ConsistencyLevelOne, points); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write whose points are all dropped succeeds without writing.
+	if err := c.WritePointsPrivileged("mydb", "myrp", models.ConsistencyLevelOne, points[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(written, []string{"cpu,host=a"}) {
+		t.Fatalf("unexpected points written: %v", written)
+	}
+}
+
 func TestPointsWriter_WritePoints(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -563,6 +875,73 @@ func TestBufferedPointsWriter(t *testing.T) {
 	}
 }
 
+// Ensure the points writer sends the shards of a remote node in one request
+// and only queues the shards which failed in hinted handoff.
+func TestPointsWriter_WritePoints_NodeWriter(t *testing.T) {
+	ms := NewPointsWriterMetaClient()
+	ms.NodeIDFn = func() uint64 { return 1 }
+
+	pr := &coordinator.WritePointsRequest{Database: "mydb", RetentionPolicy: "myrp"}
+	pr.AddPoint("cpu", 1.0, time.Now(), nil)
+	pr.AddPoint("cpu", 2.0, time.Now().Add(time.Hour), nil)
+
+	var mu sync.Mutex
+	var failedShardID uint64
+	calls := make(map[uint64]int)
+	nw := &fakeNodeWriter{
+		WriteNodeFn: func(nodeID uint64, writes []*coordinator.ShardWrite) []error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[nodeID] += len(writes)
+			errs := make([]error, len(writes))
+			if nodeID == 3 {
+				failedShardID = writes[0].ShardID
+				errs[0] = fmt.Errorf("failed to write")
+			}
+			return errs
+		},
+	}
+
+	var queued []string
+	hh := &fakeHintedHandoff{
+		ShardWriteFn: func(shardID, nodeID uint64, points []models.Point) error {
+			mu.Lock()
+			defer mu.Unlock()
+			queued = append(queued, fmt.Sprintf("%d:%d", shardID, nodeID))
+			return nil
+		},
+		EmptyFn: func(shardID, nodeID uint64) bool { return true },
+	}
+
+	c := coordinator.NewPointsWriter()
+	c.MetaClient = ms
+	c.NodeWriter = nw
+	c.ShardWriter = &fakeShardWriter{
+		ShardWriteFn: func(shardID, nodeID uint64, points []models.Point) error {
+			t.Errorf("unexpected shard write: %d", shardID)
+			return nil
+		},
+	}
+	c.TSDBStore = &fakeStore{
+		WriteFn: func(shardID uint64, points []models.Point) error { return nil },
+	}
+	c.HintedHandoff = hh
+	c.Open()
+	defer c.Close()
+
+	if err := c.WritePointsPrivileged(pr.Database, pr.RetentionPolicy, models.ConsistencyLevelAll, pr.Points); err != coordinator.ErrPartialWrite {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(calls, map[uint64]int{2: 2, 3: 2}) {
+		t.Fatalf("unexpected node writes: %v", calls)
+	} else if exp := []string{fmt.Sprintf("%d:3", failedShardID)}; !reflect.DeepEqual(queued, exp) {
+		t.Fatalf("unexpected hinted handoff writes: got %v, exp %v", queued, exp)
+	}
+}
+
 var shardID uint64
 
 type fakeShardWriter struct {
@@ -573,6 +952,14 @@ func (f *fakeShardWriter) WriteShard(shardID, nodeID uint64, points []models.Poi
 	return f.ShardWriteFn(shardID, nodeID, points)
 }
 
+type fakeNodeWriter struct {
+	WriteNodeFn func(nodeID uint64, writes []*coordinator.ShardWrite) []error
+}
+
+func (f *fakeNodeWriter) WriteNode(nodeID uint64, writes []*coordinator.ShardWrite) []error {
+	return f.WriteNodeFn(nodeID, writes)
+}
+
 type fakeHintedHandoff struct {
 	ShardWriteFn func(shardID, nodeID uint64, points []models.Point) error
 	EmptyFn      func(shardID, nodeID uint64) bool
@@ -647,6 +1034,9 @@ func (m PointsWriterMetaClient) CreateShardGroup(database, policy string, timest
 }
 
 func (m PointsWriterMetaClient) Database(database string) *meta.DatabaseInfo {
+	if m.DatabaseFn == nil {
+		return nil
+	}
 	return m.DatabaseFn(database)
 }
 
@@ -4,11 +4,13 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
 	"github.com/influxdata/influxdb/coordinator/internal"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/estimator"
@@ -20,6 +22,7 @@ import (
 	"github.com/influxdata/influxdb/tcp"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxql"
+	"github.com/klauspost/compress/zstd"
 )
 
 //go:generate protoc --gogo_out=. internal/data.proto
@@ -133,6 +136,179 @@ func (w *WriteShardResponse) UnmarshalBinary(buf []byte) error {
 	return nil
 }
 
+// Compression is the compression of the shards of a WriteNodeRequest.
+type Compression int32
+
+// The compressions of a WriteNodeRequest.
+const (
+	CompressionNone Compression = iota
+	CompressionSnappy
+	CompressionZstd
+)
+
+// compressionNames maps the names of the compressions to their value.
+var compressionNames = map[string]Compression{
+	"none":   CompressionNone,
+	"snappy": CompressionSnappy,
+	"zstd":   CompressionZstd,
+}
+
+// ParseCompression returns the compression of name.
+func ParseCompression(name string) (Compression, error) {
+	c, ok := compressionNames[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown compression %q", name)
+	}
+	return c, nil
+}
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxMessageSize))
+)
+
+// compress returns buf compressed with c.
+func (c Compression) compress(buf []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return buf, nil
+	case CompressionSnappy:
+		return snappy.Encode(nil, buf), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(buf, nil), nil
+	}
+	return nil, fmt.Errorf("unknown compression %d", c)
+}
+
+// decompress returns buf decompressed with c.
+func (c Compression) decompress(buf []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return buf, nil
+	case CompressionSnappy:
+		if n, err := snappy.DecodedLen(buf); err != nil {
+			return nil, err
+		} else if n >= MaxMessageSize {
+			return nil, fmt.Errorf("max message size of %d exceeded: %d", MaxMessageSize, n)
+		}
+		return snappy.Decode(nil, buf)
+	case CompressionZstd:
+		return zstdDecoder.DecodeAll(buf, nil)
+	}
+	return nil, fmt.Errorf("unknown compression %d", c)
+}
+
+// WriteNodeRequest represents a request to write the points of several shards
+// owned by a node. Several requests can be in flight on a connection, and the
+// responses are matched to them by RequestID.
+type WriteNodeRequest struct {
+	RequestID   uint64
+	Compression Compression
+	Shards      []*WriteShardRequest
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *WriteNodeRequest) MarshalBinary() ([]byte, error) {
+	var shards internal.WriteNodeShards
+	for _, sr := range r.Shards {
+		shards.Shards = append(shards.Shards, &sr.pb)
+	}
+	buf, err := proto.Marshal(&shards)
+	if err != nil {
+		return nil, err
+	}
+	if buf, err = r.Compression.compress(buf); err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&internal.WriteNodeRequest{
+		RequestID:   proto.Uint64(r.RequestID),
+		Compression: proto.Int32(int32(r.Compression)),
+		Shards:      buf,
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *WriteNodeRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.WriteNodeRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.RequestID = pb.GetRequestID()
+	r.Compression = Compression(pb.GetCompression())
+
+	buf, err := r.Compression.decompress(pb.GetShards())
+	if err != nil {
+		return err
+	}
+	var shards internal.WriteNodeShards
+	if err := proto.Unmarshal(buf, &shards); err != nil {
+		return err
+	}
+	r.Shards = make([]*WriteShardRequest, len(shards.Shards))
+	for i, sr := range shards.Shards {
+		r.Shards[i] = &WriteShardRequest{pb: *sr}
+	}
+	return nil
+}
+
+// WriteNodeResponse represents the response to a WriteNodeRequest. Results
+// hold the response of each shard of the request, in the same order.
+type WriteNodeResponse struct {
+	RequestID uint64
+	Results   []*WriteShardResponse
+	Err       error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *WriteNodeResponse) MarshalBinary() ([]byte, error) {
+	pb := internal.WriteNodeResponse{RequestID: proto.Uint64(r.RequestID)}
+	for _, res := range r.Results {
+		pb.Results = append(pb.Results, &res.pb)
+	}
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *WriteNodeResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.WriteNodeResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.RequestID = pb.GetRequestID()
+	r.Results = make([]*WriteShardResponse, len(pb.Results))
+	for i, res := range pb.Results {
+		r.Results[i] = &WriteShardResponse{pb: *res}
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
+// NodeVersionResponse represents the response to a node version request.
+type NodeVersionResponse struct {
+	Version uint64
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *NodeVersionResponse) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&internal.NodeVersionResponse{Version: proto.Uint64(r.Version)})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *NodeVersionResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.NodeVersionResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.Version = pb.GetVersion()
+	return nil
+}
+
 // ExecuteStatementRequest represents a request to execute a statement on a node.
 type ExecuteStatementRequest struct {
 	pb internal.ExecuteStatementRequest
@@ -743,6 +919,63 @@ func (r *StoreReadGroupResponse) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// StoreReadWindowAggregateRequest represents a request to read window aggregates.
+type StoreReadWindowAggregateRequest struct {
+	ShardIDs []uint64
+	Request  datatypes.ReadWindowAggregateRequest
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *StoreReadWindowAggregateRequest) MarshalBinary() ([]byte, error) {
+	buf, err := r.Request.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&internal.StoreReadWindowAggregateRequest{
+		ShardIDs: r.ShardIDs,
+		Request:  buf,
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *StoreReadWindowAggregateRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.StoreReadWindowAggregateRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.ShardIDs = pb.GetShardIDs()
+	if err := r.Request.Unmarshal(pb.GetRequest()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// StoreReadWindowAggregateResponse represents a response from remote read window aggregate.
+type StoreReadWindowAggregateResponse struct {
+	Err error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *StoreReadWindowAggregateResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.StoreReadWindowAggregateResponse
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *StoreReadWindowAggregateResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.StoreReadWindowAggregateResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
 // CreateIteratorRequest represents a request to create a remote iterator.
 type CreateIteratorRequest struct {
 	ShardIDs    []uint64
@@ -1430,6 +1663,72 @@ func (r *RemoveHintedHandoffResponse) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// CardinalityReportRequest represents a request to retrieve the cardinality
+// report of a database.
+type CardinalityReportRequest struct {
+	Database string
+	N        int
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *CardinalityReportRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&internal.CardinalityReportRequest{
+		Database: proto.String(r.Database),
+		N:        proto.Int64(int64(r.N)),
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *CardinalityReportRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.CardinalityReportRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.Database = pb.GetDatabase()
+	r.N = int(pb.GetN())
+	return nil
+}
+
+// CardinalityReportResponse represents a response from a cardinality report.
+type CardinalityReportResponse struct {
+	Report *tsdb.CardinalityReport
+	Err    error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *CardinalityReportResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.CardinalityReportResponse
+	if r.Report != nil {
+		buf, err := json.Marshal(r.Report)
+		if err != nil {
+			return nil, err
+		}
+		pb.Report = buf
+	}
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *CardinalityReportResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.CardinalityReportResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if buf := pb.GetReport(); len(buf) > 0 {
+		r.Report = &tsdb.CardinalityReport{}
+		if err := json.Unmarshal(buf, r.Report); err != nil {
+			return err
+		}
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
 // Client provides an API for the rpc service.
 type Client struct {
 	tlsConfig *tls.Config
@@ -1626,3 +1925,446 @@ func (c *Client) RemoveHintedHandoff(address string, nodeID uint64) error {
 	}
 	return resp.Err
 }
+
+// RebuildIndex starts rebuilding the index of the given shards on the data
+// node at address. It returns once the rebuild has started.
+func (c *Client) RebuildIndex(address string, shardIDs []uint64) error {
+	conn, err := c.dial(address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Send request.
+	req := RebuildIndexRequest{
+		ShardIDs: shardIDs,
+	}
+	err = EncodeTLV(conn, rebuildIndexRequestMessage, &req)
+	if err != nil {
+		return err
+	}
+
+	// Read the response.
+	_, buf, err := ReadTLV(conn)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal response.
+	var resp RebuildIndexResponse
+	if err = resp.UnmarshalBinary(buf); err != nil {
+		return err
+	}
+	return resp.Err
+}
+
+// ListCompactions returns the running and planned compactions of the shards
+// on the data node at address.
+func (c *Client) ListCompactions(address string) ([]*meta.CompactionInfo, error) {
+	conn, err := c.dial(address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Send request.
+	err = WriteType(conn, listCompactionsRequestMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the response.
+	_, buf, err := ReadTLV(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var resp ListCompactionsResponse
+	if err = resp.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return resp.Compactions, resp.Err
+}
+
+// CompactShard pauses, resumes or schedules a full compaction of a shard on
+// the data node at address. action is one of CompactShardPause,
+// CompactShardResume or CompactShardFull.
+func (c *Client) CompactShard(address string, shardID uint64, action string) error {
+	conn, err := c.dial(address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Send request.
+	req := CompactShardRequest{
+		ShardID: shardID,
+		Action:  action,
+	}
+	err = EncodeTLV(conn, compactShardRequestMessage, &req)
+	if err != nil {
+		return err
+	}
+
+	// Read the response.
+	_, buf, err := ReadTLV(conn)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal response.
+	var resp CompactShardResponse
+	if err = resp.UnmarshalBinary(buf); err != nil {
+		return err
+	}
+	return resp.Err
+}
+
+// ReplicationStatus returns the replication of the shards on the data node at
+// address from the WAL of their leaders.
+func (c *Client) ReplicationStatus(address string) ([]*meta.ReplicaInfo, error) {
+	conn, err := c.dial(address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Send request.
+	err = WriteType(conn, replicationStatusRequestMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the response.
+	_, buf, err := ReadTLV(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var resp ReplicationStatusResponse
+	if err = resp.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return resp.Replicas, resp.Err
+}
+
+// RebuildIndexRequest represents a request to rebuild the index of shards.
+type RebuildIndexRequest struct {
+	ShardIDs []uint64
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *RebuildIndexRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&internal.RebuildIndexRequest{
+		ShardIDs: r.ShardIDs,
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *RebuildIndexRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.RebuildIndexRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.ShardIDs = pb.GetShardIDs()
+	return nil
+}
+
+// RebuildIndexResponse represents a response to a request to rebuild the
+// index of shards.
+type RebuildIndexResponse struct {
+	Err error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *RebuildIndexResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.RebuildIndexResponse
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *RebuildIndexResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.RebuildIndexResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
+// ListCompactionsResponse represents a response to list compactions.
+type ListCompactionsResponse struct {
+	Compactions []*meta.CompactionInfo
+	Err         error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *ListCompactionsResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.ListCompactionsResponse
+	buf, err := json.Marshal(r.Compactions)
+	if err != nil {
+		return nil, err
+	}
+	pb.Compactions = buf
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *ListCompactionsResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.ListCompactionsResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(pb.GetCompactions(), &r.Compactions); err != nil {
+		return err
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
+// Actions of a CompactShardRequest.
+const (
+	// CompactShardPause pauses compactions of the shard.
+	CompactShardPause = "pause"
+
+	// CompactShardResume resumes paused compactions of the shard.
+	CompactShardResume = "resume"
+
+	// CompactShardFull schedules a full compaction of the shard.
+	CompactShardFull = "full"
+)
+
+// CompactShardRequest represents a request to control the compactions of a shard.
+type CompactShardRequest struct {
+	ShardID uint64
+	Action  string
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *CompactShardRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&internal.CompactShardRequest{
+		ShardID: proto.Uint64(r.ShardID),
+		Action:  proto.String(r.Action),
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *CompactShardRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.CompactShardRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.ShardID = pb.GetShardID()
+	r.Action = pb.GetAction()
+	return nil
+}
+
+// CompactShardResponse represents a response to a request to control the
+// compactions of a shard.
+type CompactShardResponse struct {
+	Err error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *CompactShardResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.CompactShardResponse
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *CompactShardResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.CompactShardResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
+// ReplicateWALRequest represents a request to apply entries of the WAL of a
+// shard's leader to a copy of the shard.
+type ReplicateWALRequest struct {
+	ShardID  uint64
+	LeaderID uint64
+	Start    tsdb.WALPosition
+	Next     tsdb.WALPosition
+	Data     []byte
+	LagBytes int64
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *ReplicateWALRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&internal.ReplicateWALRequest{
+		ShardID:      proto.Uint64(r.ShardID),
+		LeaderID:     proto.Uint64(r.LeaderID),
+		StartSegment: proto.Int64(int64(r.Start.Segment)),
+		StartOffset:  proto.Int64(r.Start.Offset),
+		NextSegment:  proto.Int64(int64(r.Next.Segment)),
+		NextOffset:   proto.Int64(r.Next.Offset),
+		Data:         r.Data,
+		LagBytes:     proto.Int64(r.LagBytes),
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *ReplicateWALRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.ReplicateWALRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.ShardID = pb.GetShardID()
+	r.LeaderID = pb.GetLeaderID()
+	r.Start = tsdb.WALPosition{Segment: int(pb.GetStartSegment()), Offset: pb.GetStartOffset()}
+	r.Next = tsdb.WALPosition{Segment: int(pb.GetNextSegment()), Offset: pb.GetNextOffset()}
+	r.Data = pb.GetData()
+	r.LagBytes = pb.GetLagBytes()
+	return nil
+}
+
+// ReplicateWALResponse represents a response to a request to apply WAL
+// entries, with the position of the leader's WAL applied to the shard.
+type ReplicateWALResponse struct {
+	Applied tsdb.WALPosition
+	Err     error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *ReplicateWALResponse) MarshalBinary() ([]byte, error) {
+	pb := internal.ReplicateWALResponse{
+		AppliedSegment: proto.Int64(int64(r.Applied.Segment)),
+		AppliedOffset:  proto.Int64(r.Applied.Offset),
+	}
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *ReplicateWALResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.ReplicateWALResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.Applied = tsdb.WALPosition{Segment: int(pb.GetAppliedSegment()), Offset: pb.GetAppliedOffset()}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
+// ReplicationStatusResponse represents a response to a request for the
+// replication of the shards on a data node.
+type ReplicationStatusResponse struct {
+	Replicas []*meta.ReplicaInfo
+	Err      error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *ReplicationStatusResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.ReplicationStatusResponse
+	buf, err := json.Marshal(r.Replicas)
+	if err != nil {
+		return nil, err
+	}
+	pb.Replicas = buf
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *ReplicationStatusResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.ReplicationStatusResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(pb.GetReplicas(), &r.Replicas); err != nil {
+		return err
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
+
+// DiskUsageRequest represents a request to retrieve the disk used by the
+// shards of a database on a node.
+type DiskUsageRequest struct {
+	Database string
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *DiskUsageRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&internal.DiskUsageRequest{
+		Database: proto.String(r.Database),
+	})
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *DiskUsageRequest) UnmarshalBinary(data []byte) error {
+	var pb internal.DiskUsageRequest
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	r.Database = pb.GetDatabase()
+	return nil
+}
+
+// DiskUsageResponse represents a response from a disk usage request.
+type DiskUsageResponse struct {
+	Usage *tsdb.DiskUsage
+	Err   error
+}
+
+// MarshalBinary encodes r to a binary format.
+func (r *DiskUsageResponse) MarshalBinary() ([]byte, error) {
+	var pb internal.DiskUsageResponse
+	if r.Usage != nil {
+		buf, err := json.Marshal(r.Usage)
+		if err != nil {
+			return nil, err
+		}
+		pb.Usage = buf
+	}
+	if r.Err != nil {
+		pb.Err = proto.String(r.Err.Error())
+	}
+	return proto.Marshal(&pb)
+}
+
+// UnmarshalBinary decodes data into r.
+func (r *DiskUsageResponse) UnmarshalBinary(data []byte) error {
+	var pb internal.DiskUsageResponse
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if buf := pb.GetUsage(); len(buf) > 0 {
+		r.Usage = &tsdb.DiskUsage{}
+		if err := json.Unmarshal(buf, r.Usage); err != nil {
+			return err
+		}
+	}
+	if pb.Err != nil {
+		r.Err = errors.New(pb.GetErr())
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
 )
 
 func TestWriteShardRequestBinary(t *testing.T) {
@@ -117,6 +118,146 @@ func TestWriteShardResponseBinary(t *testing.T) {
 	}
 }
 
+func TestCardinalityReportResponseBinary(t *testing.T) {
+	exp := &CardinalityReportResponse{Report: &tsdb.CardinalityReport{
+		Database:      "db0",
+		Time:          time.Unix(10, 0).UTC(),
+		SeriesN:       3,
+		MeasurementsN: 1,
+		Measurements:  []tsdb.MeasurementCardinality{{Measurement: "cpu", SeriesN: 3, Growth: 1.5}},
+		TagKeys:       []tsdb.TagKeyCardinality{{Measurement: "cpu", Key: "host", ValuesN: 3}},
+	}}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got CardinalityReportResponse
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got.Report, exp.Report) {
+		t.Fatalf("unexpected report: %+v", got.Report)
+	}
+}
+
+func TestDiskUsageResponseBinary(t *testing.T) {
+	exp := &DiskUsageResponse{Usage: &tsdb.DiskUsage{
+		Database:          "db0",
+		Bytes:             300,
+		RetentionPolicies: map[string]int64{"rp0": 100, "rp1": 200},
+	}}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got DiskUsageResponse
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got.Usage, exp.Usage) {
+		t.Fatalf("unexpected usage: %+v", got.Usage)
+	}
+}
+
+func TestRebuildIndexRequestBinary(t *testing.T) {
+	exp := &RebuildIndexRequest{ShardIDs: []uint64{1, 5, 9}}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got RebuildIndexRequest
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got.ShardIDs, exp.ShardIDs) {
+		t.Fatalf("unexpected shard ids: %v", got.ShardIDs)
+	}
+}
+
+func TestListCompactionsResponseBinary(t *testing.T) {
+	exp := &ListCompactionsResponse{Compactions: []*meta.CompactionInfo{
+		{NodeID: 1, TCPAddr: "localhost:8088", ShardID: 5, Level: 4, Strategy: "full", State: "running", Files: 3, Bytes: 1024, Progress: 0.5, StartedAt: time.Unix(10, 0).UTC()},
+		{NodeID: 1, TCPAddr: "localhost:8088", ShardID: 6, Level: 1, Strategy: "level", State: "queued", Files: 8, Bytes: 2048},
+	}}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got ListCompactionsResponse
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got.Compactions, exp.Compactions) {
+		t.Fatalf("unexpected compactions: %+v", got.Compactions)
+	}
+}
+
+func TestCompactShardRequestBinary(t *testing.T) {
+	exp := &CompactShardRequest{ShardID: 7, Action: CompactShardPause}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got CompactShardRequest
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if got != *exp {
+		t.Fatalf("unexpected request: %+v", got)
+	}
+}
+
+func TestWriteNodeRequestBinary(t *testing.T) {
+	for _, c := range []Compression{CompressionNone, CompressionSnappy, CompressionZstd} {
+		var sr WriteShardRequest
+		sr.SetShardID(2)
+		sr.SetDatabase("db0")
+		sr.SetRetentionPolicy("rp0")
+		sr.AddPoint("cpu", 1.0, time.Unix(0, 0), map[string]string{"host": "serverA"})
+
+		exp := &WriteNodeRequest{RequestID: 10, Compression: c, Shards: []*WriteShardRequest{&sr}}
+		b, err := exp.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got WriteNodeRequest
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("compression %d: %s", c, err)
+		} else if got.RequestID != 10 || got.Compression != c {
+			t.Fatalf("compression %d: unexpected request: %+v", c, got)
+		} else if len(got.Shards) != 1 {
+			t.Fatalf("compression %d: unexpected shard count: %d", c, len(got.Shards))
+		} else if got.Shards[0].ShardID() != 2 || got.Shards[0].Database() != "db0" || got.Shards[0].RetentionPolicy() != "rp0" {
+			t.Fatalf("compression %d: unexpected shard: %+v", c, got.Shards[0])
+		} else if pts := got.Shards[0].Points(); len(pts) != 1 || pts[0].String() != sr.Points()[0].String() {
+			t.Fatalf("compression %d: unexpected points: %v", c, pts)
+		}
+	}
+}
+
+func TestWriteNodeResponseBinary(t *testing.T) {
+	ok, failed := &WriteShardResponse{}, &WriteShardResponse{}
+	ok.SetCode(0)
+	failed.SetCode(1)
+	failed.SetMessage("failed to write")
+
+	exp := &WriteNodeResponse{RequestID: 3, Results: []*WriteShardResponse{ok, failed}}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got WriteNodeResponse
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if got.RequestID != 3 || got.Err != nil || len(got.Results) != 2 {
+		t.Fatalf("unexpected response: %+v", got)
+	} else if got.Results[0].Code() != 0 || got.Results[1].Code() != 1 || got.Results[1].Message() != "failed to write" {
+		t.Fatalf("unexpected results: %v, %v", got.Results[0], got.Results[1])
+	}
+}
+
 func TestClient_JoinCluster(t *testing.T) {
 	dataNode := &meta.NodeInfo{
 		ID:      1,
@@ -239,3 +380,25 @@ func TestClient_LeaveCluster(t *testing.T) {
 		t.Errorf("timeout while waiting for the goroutine")
 	}
 }
+
+func TestReplicateWALRequestBinary(t *testing.T) {
+	exp := &ReplicateWALRequest{
+		ShardID:  3,
+		LeaderID: 2,
+		Start:    tsdb.WALPosition{Segment: 4, Offset: 100},
+		Next:     tsdb.WALPosition{Segment: 5, Offset: 20},
+		Data:     []byte("entries"),
+		LagBytes: 1024,
+	}
+	b, err := exp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got ReplicateWALRequest
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(&got, exp) {
+		t.Fatalf("unexpected request: %+v", got)
+	}
+}
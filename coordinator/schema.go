@@ -0,0 +1,137 @@
+package coordinator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxql"
+)
+
+// maxSchemaErrors is the maximum number of schema errors reported for a write.
+const maxSchemaErrors = 10
+
+// SchemaError is returned for a point that does not conform to the schema of
+// its measurement.
+type SchemaError struct {
+	// Index is the position of the point in the write, starting at 0.
+	Index int
+
+	Measurement string
+	Err         error
+}
+
+// Error returns the string representation of the error. The point is
+// numbered from 1, which is its line in a line protocol write without blank
+// lines or comments.
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("point %d: measurement %q: %s", e.Index+1, e.Measurement, e.Err)
+}
+
+// schemaErrorsReason returns the reason of a partial write for the schema errors.
+func schemaErrorsReason(errs []SchemaError) string {
+	msgs := make([]string, 0, maxSchemaErrors+1)
+	for i, err := range errs {
+		if i == maxSchemaErrors {
+			msgs = append(msgs, fmt.Sprintf("and %d more", len(errs)-i))
+			break
+		}
+		msgs = append(msgs, err.Error())
+	}
+	return "measurement schema violations: " + strings.Join(msgs, "; ")
+}
+
+// conformPoint returns p if it conforms to the schema. In coerce mode, a
+// point with numeric field values of another type than their field is
+// returned with the values converted, if that can be done without loss.
+func conformPoint(schema *meta.MeasurementSchemaInfo, p models.Point) (models.Point, error) {
+	tags := p.Tags()
+	for _, k := range schema.RequiredTags {
+		if tags.Get([]byte(k)) == nil {
+			return nil, fmt.Errorf("missing required tag %q", k)
+		}
+	}
+	for _, t := range tags {
+		if !schema.TagAllowed(string(t.Key)) {
+			return nil, fmt.Errorf("tag %q is not allowed", t.Key)
+		}
+	}
+
+	if len(schema.Fields) == 0 {
+		return p, nil
+	}
+
+	fields, err := p.Fields()
+	if err != nil {
+		return nil, err
+	}
+
+	var coerced bool
+	for k, v := range fields {
+		f := schema.Field(k)
+		if f == nil {
+			return nil, fmt.Errorf("field %q is not in the schema", k)
+		}
+
+		typ := influxql.InspectDataType(v)
+		if typ == f.Type {
+			continue
+		} else if schema.Mode != meta.MeasurementSchemaModeCoerce {
+			return nil, fmt.Errorf("field %q is %s, schema requires %s", k, typ, f.Type)
+		}
+
+		cv, ok := coerceValue(v, f.Type)
+		if !ok {
+			return nil, fmt.Errorf("field %q is %s and can't be converted to %s", k, typ, f.Type)
+		}
+		fields[k] = cv
+		coerced = true
+	}
+
+	if !coerced {
+		return p, nil
+	}
+	return models.NewPoint(string(p.Name()), tags, fields, p.Time())
+}
+
+// coerceValue converts a numeric value to typ, if that can be done without loss.
+func coerceValue(v interface{}, typ influxql.DataType) (interface{}, bool) {
+	switch typ {
+	case influxql.Float:
+		switch v := v.(type) {
+		case int64:
+			if f := float64(v); int64(f) == v {
+				return f, true
+			}
+		case uint64:
+			if f := float64(v); uint64(f) == v {
+				return f, true
+			}
+		}
+	case influxql.Integer:
+		switch v := v.(type) {
+		case float64:
+			if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+				return int64(v), true
+			}
+		case uint64:
+			if v <= math.MaxInt64 {
+				return int64(v), true
+			}
+		}
+	case influxql.Unsigned:
+		switch v := v.(type) {
+		case float64:
+			if v == math.Trunc(v) && v >= 0 && v < math.MaxUint64 {
+				return uint64(v), true
+			}
+		case int64:
+			if v >= 0 {
+				return uint64(v), true
+			}
+		}
+	}
+	return nil, false
+}
@@ -50,6 +50,7 @@ const (
 	statCopyShardReq        = "copyShardReq"
 	statRemoveShardReq      = "removeShardReq"
 	statListShardsReq       = "listShardsReq"
+	statWriteNodeReq        = "writeNodeReq"
 )
 
 const (
@@ -118,8 +119,47 @@ const (
 
 	removeHintedHandoffRequestMessage
 	removeHintedHandoffResponseMessage
+
+	storeReadWindowAggregateRequestMessage
+	storeReadWindowAggregateResponseMessage
+
+	cardinalityReportRequestMessage
+	cardinalityReportResponseMessage
+
+	writeNodeRequestMessage
+	writeNodeResponseMessage
+
+	nodeVersionRequestMessage
+	nodeVersionResponseMessage
+
+	rebuildIndexRequestMessage
+	rebuildIndexResponseMessage
+
+	listCompactionsRequestMessage
+	listCompactionsResponseMessage
+
+	compactShardRequestMessage
+	compactShardResponseMessage
+
+	replicateWALRequestMessage
+	replicateWALResponseMessage
+
+	replicationStatusRequestMessage
+	replicationStatusResponseMessage
+
+	diskUsageRequestMessage
+	diskUsageResponseMessage
 )
 
+// NodeVersion is the version of the RPC served by this node, returned to the
+// nodes probing it before sending messages older nodes don't know. Version 1
+// added WriteNodeRequest and StoreReadWindowAggregateRequest.
+const NodeVersion = 1
+
+// windowAggregateNodeVersion is the first NodeVersion serving
+// StoreReadWindowAggregateRequest.
+const windowAggregateNodeVersion = 1
+
 // ShardIDsKey is the shardIDs context key when handling read request.
 const ShardIDsKey ContextKey = iota + 1
 
@@ -227,6 +267,7 @@ type Statistics struct {
 	CopyShardReq        int64
 	RemoveShardReq      int64
 	ListShardsReq       int64
+	WriteNodeReq        int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -247,6 +288,7 @@ func (s *Service) Statistics(tags map[string]string) []models.Statistic {
 			statCopyShardReq:        atomic.LoadInt64(&s.stats.CopyShardReq),
 			statRemoveShardReq:      atomic.LoadInt64(&s.stats.RemoveShardReq),
 			statListShardsReq:       atomic.LoadInt64(&s.stats.ListShardsReq),
+			statWriteNodeReq:        atomic.LoadInt64(&s.stats.WriteNodeReq),
 		},
 	}}
 }
@@ -344,6 +386,19 @@ func (s *Service) handleConn(conn net.Conn) {
 				s.Logger.Error("Process write shard error", zap.Error(err))
 			}
 			s.writeShardResponse(conn, err)
+		case writeNodeRequestMessage:
+			buf, err := ReadLV(conn)
+			if err != nil {
+				s.Logger.Error("Unable to read length-value", zap.Error(err))
+				return
+			}
+			atomic.AddInt64(&s.stats.WriteNodeReq, 1)
+			s.writeNodeResponse(conn, s.processWriteNodeRequest(buf))
+		case nodeVersionRequestMessage:
+			if err := EncodeTLV(conn, nodeVersionResponseMessage, &NodeVersionResponse{Version: NodeVersion}); err != nil {
+				s.Logger.Error("Error writing NodeVersion response", zap.Error(err))
+				return
+			}
 		case executeStatementRequestMessage:
 			buf, err := ReadLV(conn)
 			if err != nil {
@@ -367,12 +422,29 @@ func (s *Service) handleConn(conn net.Conn) {
 			s.processSeriesSketchesRequest(conn)
 		case measurementsSketchesRequestMessage:
 			s.processMeasurementsSketchesRequest(conn)
+		case cardinalityReportRequestMessage:
+			s.processCardinalityReportRequest(conn)
+		case rebuildIndexRequestMessage:
+			s.processRebuildIndexRequest(conn)
+		case listCompactionsRequestMessage:
+			s.processListCompactionsRequest(conn)
+		case compactShardRequestMessage:
+			s.processCompactShardRequest(conn)
+		case replicateWALRequestMessage:
+			s.processReplicateWALRequest(conn)
+		case replicationStatusRequestMessage:
+			s.processReplicationStatusRequest(conn)
+		case diskUsageRequestMessage:
+			s.processDiskUsageRequest(conn)
 		case storeReadFilterRequestMessage:
 			s.processStoreReadFilterRequest(conn)
 			return
 		case storeReadGroupRequestMessage:
 			s.processStoreReadGroupRequest(conn)
 			return
+		case storeReadWindowAggregateRequestMessage:
+			s.processStoreReadWindowAggregateRequest(conn)
+			return
 		case createIteratorRequestMessage:
 			atomic.AddInt64(&s.stats.CreateIteratorReq, 1)
 			s.processCreateIteratorRequest(conn)
@@ -439,10 +511,14 @@ func (s *Service) processExecuteStatementRequest(buf []byte) error {
 
 func (s *Service) executeStatement(stmt influxql.Statement, database string) error {
 	switch t := stmt.(type) {
+	case *influxql.DeleteFieldStatement:
+		return s.TSDBStore.DeleteFields(database, t.Sources, t.Fields, t.Condition)
 	case *influxql.DeleteSeriesStatement:
 		return s.TSDBStore.DeleteSeries(database, t.Sources, t.Condition)
 	case *influxql.DropDatabaseStatement:
 		return s.TSDBStore.DeleteDatabase(t.Name)
+	case *influxql.DropFieldStatement:
+		return s.TSDBStore.DeleteFields(database, t.Sources, t.Fields, nil)
 	case *influxql.DropMeasurementStatement:
 		return s.TSDBStore.DeleteMeasurement(database, t.Name)
 	case *influxql.DropSeriesStatement:
@@ -485,7 +561,12 @@ func (s *Service) processWriteShardRequest(buf []byte) error {
 	if err := req.UnmarshalBinary(buf); err != nil {
 		return err
 	}
+	return s.writeShard(&req)
+}
 
+// writeShard writes the points of req to its shard, creating the shard if
+// it doesn't exist yet.
+func (s *Service) writeShard(req *WriteShardRequest) error {
 	points := req.Points()
 	atomic.AddInt64(&s.stats.WriteShardPointsReq, int64(len(points)))
 	err := s.TSDBStore.WriteToShard(req.ShardID(), points)
@@ -523,6 +604,58 @@ func (s *Service) processWriteShardRequest(buf []byte) error {
 	return nil
 }
 
+// processWriteNodeRequest writes the points of each shard of a WriteNodeRequest
+// concurrently and returns the result of each.
+func (s *Service) processWriteNodeRequest(buf []byte) *WriteNodeResponse {
+	var req WriteNodeRequest
+	if err := req.UnmarshalBinary(buf); err != nil {
+		return &WriteNodeResponse{RequestID: req.RequestID, Err: err}
+	}
+
+	resp := &WriteNodeResponse{
+		RequestID: req.RequestID,
+		Results:   make([]*WriteShardResponse, len(req.Shards)),
+	}
+	var wg sync.WaitGroup
+	for i, sr := range req.Shards {
+		wg.Add(1)
+		go func(i int, sr *WriteShardRequest) {
+			defer wg.Done()
+			atomic.AddInt64(&s.stats.WriteShardReq, 1)
+
+			var result WriteShardResponse
+			if err := s.writeShard(sr); err != nil {
+				s.Logger.Error("Process write shard error", zap.Error(err))
+				result.SetCode(1)
+				result.SetMessage(err.Error())
+			} else {
+				result.SetCode(0)
+			}
+			resp.Results[i] = &result
+		}(i, sr)
+	}
+	wg.Wait()
+	return resp
+}
+
+func (s *Service) writeNodeResponse(w io.Writer, resp *WriteNodeResponse) {
+	if resp.Err != nil {
+		s.Logger.Error("Process write node error", zap.Error(resp.Err))
+	}
+
+	// Marshal response to binary.
+	buf, err := resp.MarshalBinary()
+	if err != nil {
+		s.Logger.Error("Error marshalling WriteNode response", zap.Error(err))
+		return
+	}
+
+	// Write to connection.
+	if err := WriteTLV(w, writeNodeResponseMessage, buf); err != nil {
+		s.Logger.Error("Error writing WriteNode response", zap.Error(err))
+	}
+}
+
 func (s *Service) writeShardResponse(w io.Writer, e error) {
 	// Build response.
 	var resp WriteShardResponse
@@ -716,6 +849,237 @@ func (s *Service) processMeasurementsSketchesRequest(conn net.Conn) {
 	}
 }
 
+func (s *Service) processCardinalityReportRequest(conn net.Conn) {
+	report, err := func() (*tsdb.CardinalityReport, error) {
+		// Parse request.
+		var req CardinalityReportRequest
+		if err := DecodeLV(conn, &req); err != nil {
+			return nil, err
+		}
+		// Return the cardinality report of this node.
+		return s.TSDBStore.CardinalityReport(context.Background(), req.Database, req.N)
+	}()
+	if err != nil {
+		s.Logger.Error("Error reading CardinalityReport request", zap.Error(err))
+		EncodeTLV(conn, cardinalityReportResponseMessage, &CardinalityReportResponse{Err: err})
+		return
+	}
+
+	// Encode success response.
+	if err := EncodeTLV(conn, cardinalityReportResponseMessage, &CardinalityReportResponse{Report: report}); err != nil {
+		s.Logger.Error("Error writing CardinalityReport response", zap.Error(err))
+		return
+	}
+}
+
+func (s *Service) processRebuildIndexRequest(conn net.Conn) {
+	err := func() error {
+		// Parse request.
+		var req RebuildIndexRequest
+		if err := DecodeLV(conn, &req); err != nil {
+			return err
+		}
+		for _, id := range req.ShardIDs {
+			if sh := s.TSDBStore.Shard(id); sh == nil {
+				return fmt.Errorf("shard %d: %w", id, tsdb.ErrShardNotFound)
+			} else if sh.IndexRebuildStatus().State == tsdb.IndexRebuildRunning {
+				return fmt.Errorf("shard %d: %w", id, tsdb.ErrIndexRebuildInProgress)
+			}
+		}
+
+		// Rebuild one shard at a time in the background. Progress is
+		// reported through ListShards.
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.rebuildIndexes(req.ShardIDs)
+		}()
+		return nil
+	}()
+	if err != nil {
+		s.Logger.Error("Error processing RebuildIndex request", zap.Error(err))
+	}
+
+	// Encode response.
+	if err := EncodeTLV(conn, rebuildIndexResponseMessage, &RebuildIndexResponse{Err: err}); err != nil {
+		s.Logger.Error("Error writing RebuildIndex response", zap.Error(err))
+		return
+	}
+}
+
+// rebuildIndexes rebuilds the index of each shard in turn, stopping early if
+// the service is closed.
+func (s *Service) rebuildIndexes(ids []uint64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for _, id := range ids {
+		if err := s.TSDBStore.RebuildShardIndex(ctx, id); err != nil {
+			s.Logger.Warn("Unable to rebuild shard index", zap.Uint64("shard_id", id), zap.Error(err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *Service) processListCompactionsRequest(conn net.Conn) {
+	compactions := shardCompactions(s.TSDBStore, s.MetaClient.NodeID(), s.Server.TCPAddr())
+
+	// Encode response.
+	if err := EncodeTLV(conn, listCompactionsResponseMessage, &ListCompactionsResponse{Compactions: compactions}); err != nil {
+		s.Logger.Error("Error writing ListCompactions response", zap.Error(err))
+		return
+	}
+}
+
+// shardCompactions returns the running and planned compactions of the shards
+// in store, which belong to the data node nodeID at tcpAddr.
+func shardCompactions(store interface {
+	ShardIDs() []uint64
+	Shard(id uint64) *tsdb.Shard
+}, nodeID uint64, tcpAddr string) []*meta.CompactionInfo {
+	var compactions []*meta.CompactionInfo
+	for _, id := range store.ShardIDs() {
+		sh := store.Shard(id)
+		if sh == nil {
+			continue
+		}
+		for _, c := range sh.Compactions() {
+			compactions = append(compactions, &meta.CompactionInfo{
+				NodeID:    nodeID,
+				TCPAddr:   tcpAddr,
+				ShardID:   c.ShardID,
+				Level:     c.Level,
+				Strategy:  c.Strategy,
+				State:     c.State,
+				Files:     c.Files,
+				Bytes:     c.Bytes,
+				Progress:  c.Progress,
+				StartedAt: c.StartedAt,
+			})
+		}
+	}
+	return compactions
+}
+
+func (s *Service) processCompactShardRequest(conn net.Conn) {
+	err := func() error {
+		// Parse request.
+		var req CompactShardRequest
+		if err := DecodeLV(conn, &req); err != nil {
+			return err
+		}
+		sh := s.TSDBStore.Shard(req.ShardID)
+		if sh == nil {
+			return fmt.Errorf("shard %d: %w", req.ShardID, tsdb.ErrShardNotFound)
+		}
+
+		switch req.Action {
+		case CompactShardPause:
+			return sh.SetCompactionsPaused(true)
+		case CompactShardResume:
+			return sh.SetCompactionsPaused(false)
+		case CompactShardFull:
+			return sh.ScheduleFullCompaction()
+		default:
+			return fmt.Errorf("unknown compact action %q", req.Action)
+		}
+	}()
+	if err != nil {
+		s.Logger.Error("Error processing CompactShard request", zap.Error(err))
+	}
+
+	// Encode response.
+	if err := EncodeTLV(conn, compactShardResponseMessage, &CompactShardResponse{Err: err}); err != nil {
+		s.Logger.Error("Error writing CompactShard response", zap.Error(err))
+		return
+	}
+}
+
+func (s *Service) processReplicateWALRequest(conn net.Conn) {
+	applied, err := func() (tsdb.WALPosition, error) {
+		// Parse request.
+		var req ReplicateWALRequest
+		if err := DecodeLV(conn, &req); err != nil {
+			return tsdb.WALPosition{}, err
+		}
+		applied, err := s.TSDBStore.ApplyWAL(req.ShardID, req.LeaderID, req.Start, req.Next, req.LagBytes, req.Data)
+		if err == tsdb.ErrShardNotFound {
+			err = fmt.Errorf("shard %d: %w", req.ShardID, err)
+		}
+		return applied, err
+	}()
+	if err != nil {
+		s.Logger.Error("Error processing ReplicateWAL request", zap.Error(err))
+	}
+
+	// Encode response.
+	if err := EncodeTLV(conn, replicateWALResponseMessage, &ReplicateWALResponse{Applied: applied, Err: err}); err != nil {
+		s.Logger.Error("Error writing ReplicateWAL response", zap.Error(err))
+		return
+	}
+}
+
+func (s *Service) processReplicationStatusRequest(conn net.Conn) {
+	var replicas []*meta.ReplicaInfo
+	for _, id := range s.TSDBStore.ShardIDs() {
+		sh := s.TSDBStore.Shard(id)
+		if sh == nil {
+			continue
+		}
+		r := sh.ReplicaStatus()
+		if r == nil {
+			continue
+		}
+		replicas = append(replicas, &meta.ReplicaInfo{
+			NodeID:      s.MetaClient.NodeID(),
+			TCPAddr:     s.Server.TCPAddr(),
+			ShardID:     id,
+			Leader:      r.Leader,
+			Position:    r.Applied.String(),
+			LagBytes:    r.LagBytes,
+			LastContact: r.LastContact,
+		})
+	}
+
+	// Encode response.
+	if err := EncodeTLV(conn, replicationStatusResponseMessage, &ReplicationStatusResponse{Replicas: replicas}); err != nil {
+		s.Logger.Error("Error writing ReplicationStatus response", zap.Error(err))
+		return
+	}
+}
+
+func (s *Service) processDiskUsageRequest(conn net.Conn) {
+	usage, err := func() (*tsdb.DiskUsage, error) {
+		// Parse request.
+		var req DiskUsageRequest
+		if err := DecodeLV(conn, &req); err != nil {
+			return nil, err
+		}
+		// Return the disk usage of this node.
+		return s.TSDBStore.DiskUsage(context.Background(), req.Database)
+	}()
+	if err != nil {
+		s.Logger.Error("Error reading DiskUsage request", zap.Error(err))
+		EncodeTLV(conn, diskUsageResponseMessage, &DiskUsageResponse{Err: err})
+		return
+	}
+
+	// Encode success response.
+	if err := EncodeTLV(conn, diskUsageResponseMessage, &DiskUsageResponse{Usage: usage}); err != nil {
+		s.Logger.Error("Error writing DiskUsage response", zap.Error(err))
+		return
+	}
+}
+
 func (s *Service) processStoreReadFilterRequest(conn net.Conn) {
 	rs, err := func() (reads.ResultSet, error) {
 		// Parse request.
@@ -816,6 +1180,55 @@ func (s *Service) processStoreReadGroupRequest(conn net.Conn) {
 	}
 }
 
+func (s *Service) processStoreReadWindowAggregateRequest(conn net.Conn) {
+	rs, err := func() (reads.ResultSet, error) {
+		// Parse request.
+		var req StoreReadWindowAggregateRequest
+		if err := DecodeLV(conn, &req); err != nil {
+			return nil, err
+		}
+		// Read window aggregates from storage store.
+		ctx := context.WithValue(context.Background(), ShardIDsKey, req.ShardIDs)
+		return s.Store.ReadWindowAggregate(ctx, &req.Request)
+	}()
+	if err != nil {
+		s.Logger.Error("Error reading StoreReadWindowAggregate request", zap.Error(err))
+		EncodeTLV(conn, storeReadWindowAggregateResponseMessage, &StoreReadWindowAggregateResponse{Err: err})
+		return
+	}
+
+	// Encode success response.
+	if err := EncodeTLV(conn, storeReadWindowAggregateResponseMessage, &StoreReadWindowAggregateResponse{}); err != nil {
+		s.Logger.Error("Error writing StoreReadWindowAggregate response", zap.Error(err))
+		return
+	}
+
+	// Exit if no result set was produced.
+	if rs == nil {
+		return
+	}
+	defer rs.Close()
+
+	// Stream result set to connection.
+	stream := NewStoreStreamSender(conn)
+	w := reads.NewResponseWriter(stream, 0)
+	if err := w.WriteResultSet(rs); err != nil {
+		s.Logger.Error("Error writing StoreReadWindowAggregate response", zap.Error(err))
+		return
+	}
+	w.Flush()
+
+	if err := w.Err(); err != nil {
+		s.Logger.Error("Error writing StoreReadWindowAggregate response", zap.Error(err))
+		return
+	}
+
+	if err := stream.Close(); err != nil {
+		s.Logger.Error("Error writing StoreReadWindowAggregate response", zap.Error(err))
+		return
+	}
+}
+
 func (s *Service) processCreateIteratorRequest(conn net.Conn) {
 	var t *tracing.Trace
 	var span *tracing.Span
@@ -1145,7 +1558,7 @@ func (s *Service) processCopyShardRequest(conn net.Conn) {
 		}
 
 		// Begin streaming backup from remote server.
-		r, err := s.backupRemoteShard(req.Host, req.ShardID, req.Since)
+		r, err := s.BackupRemoteShard(req.Host, req.ShardID, req.Since)
 		if err != nil {
 			return err
 		}
@@ -1175,8 +1588,8 @@ func (s *Service) processCopyShardRequest(conn net.Conn) {
 	}
 }
 
-// backupRemoteShard connects to a coordinator service on a remote host and streams a shard.
-func (s *Service) backupRemoteShard(host string, shardID uint64, since time.Time) (io.ReadCloser, error) {
+// BackupRemoteShard connects to a coordinator service on a remote host and streams a shard.
+func (s *Service) BackupRemoteShard(host string, shardID uint64, since time.Time) (io.ReadCloser, error) {
 	tlsConfig := s.config.TLSClientConfig()
 	conn, err := tcp.DialTLSTimeout("tcp", host, tlsConfig, time.Duration(s.config.DialTimeout))
 	if err != nil {
@@ -1254,6 +1667,17 @@ func (s *Service) processListShardsRequest(conn net.Conn) {
 				} else {
 					owner.Size = size
 				}
+				status := sh.VerifyStatus()
+				owner.LastVerified = status.VerifiedAt
+				if status.Err != nil {
+					owner.VerifyErr = status.Err.Error()
+				}
+				rebuild := sh.IndexRebuildStatus()
+				owner.IndexRebuild = rebuild.State
+				owner.IndexRebuildSeries = rebuild.SeriesN
+				if rebuild.Err != nil {
+					owner.IndexRebuildErr = rebuild.Err.Error()
+				}
 			} else {
 				owner.Err = "not found"
 			}
@@ -1563,6 +1987,16 @@ func WriteTLV(w io.Writer, typ byte, buf []byte) error {
 	return nil
 }
 
+// WriteTypeT writes the type in a TLV record with timeout to w.
+func WriteTypeT(w net.Conn, typ byte, t time.Duration) error {
+	if t > 0 {
+		if err := w.SetWriteDeadline(time.Now().Add(t)); err != nil {
+			return err
+		}
+	}
+	return WriteType(w, typ)
+}
+
 // WriteType writes the type in a TLV record to w.
 func WriteType(w io.Writer, typ byte) error {
 	if _, err := w.Write([]byte{typ}); err != nil {
@@ -338,26 +338,10 @@ func (e *ClusterShardMapper) mapShards(a *ClusterShardMapping, sources influxql.
 					// If zero, all nodes are used.
 					for _, g := range groups {
 						for _, si := range g.Shards {
-							// Always assign to local node if it has the shard.
-							// Otherwise randomly select a remote node.
-							var nodeID uint64
-							if si.OwnedBy(a.LocalID) {
-								nodeID = a.LocalID
-							} else if len(si.Owners) > 0 {
-								// The selected node has higher priority.
-								for _, owner := range si.Owners {
-									if _, ok := shardsByNodeID[owner.NodeID]; ok {
-										nodeID = owner.NodeID
-										break
-									}
-								}
-								// Otherwise randomly select.
-								if nodeID == 0 {
-									nodeID = si.Owners[rand.Intn(len(si.Owners))].NodeID
-								}
-							} else {
+							nodeID := selectShardOwner(si, a.LocalID, shardsByNodeID)
+							if nodeID == 0 {
 								// This should not occur but if the shard has no owners then
-								// we don't want this to panic by trying to randomly select a node.
+								// there is nothing to read from.
 								continue
 							}
 							if _, ok := shardsByNodeID[nodeID]; !ok {
@@ -643,6 +627,7 @@ func (a *ClusterShardMapping) Close() error {
 type Store interface {
 	ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error)
 	ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error)
+	ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error)
 }
 
 // ClusterStoreMapper implements a StoreMapper for cluster store.
@@ -697,26 +682,10 @@ func (e *ClusterStoreMapper) mapShards(a *ClusterStoreMapping, groups []meta.Sha
 		// If zero, all nodes are used.
 		for _, g := range groups {
 			for _, si := range g.Shards {
-				// Always assign to local node if it has the shard.
-				// Otherwise randomly select a remote node.
-				var nodeID uint64
-				if si.OwnedBy(a.LocalID) {
-					nodeID = a.LocalID
-				} else if len(si.Owners) > 0 {
-					// The selected node has higher priority.
-					for _, owner := range si.Owners {
-						if _, ok := shardsByNodeID[owner.NodeID]; ok {
-							nodeID = owner.NodeID
-							break
-						}
-					}
-					// Otherwise randomly select.
-					if nodeID == 0 {
-						nodeID = si.Owners[rand.Intn(len(si.Owners))].NodeID
-					}
-				} else {
+				nodeID := selectShardOwner(si, a.LocalID, shardsByNodeID)
+				if nodeID == 0 {
 					// This should not occur but if the shard has no owners then
-					// we don't want this to panic by trying to randomly select a node.
+					// there is nothing to read from.
 					continue
 				}
 				if _, ok := shardsByNodeID[nodeID]; !ok {
@@ -811,6 +780,108 @@ func (a *ClusterStoreMapping) ReadFilter(ctx context.Context, req *datatypes.Rea
 	return reads.NewMergedResultSet(rss), nil
 }
 
+func (a *ClusterStoreMapping) ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	if len(a.LocalShardIDs) == 0 && len(a.RemoteShardGroups) == 0 {
+		return nil, nil
+	}
+
+	// Local shards are aggregated by a single result set.
+	if len(a.RemoteShardGroups) == 0 {
+		if a.CursorFn == nil {
+			return nil, nil
+		}
+		cur, err := a.CursorFn(ctx, a.LocalShardIDs)
+		if err != nil {
+			return nil, err
+		} else if cur == nil {
+			return nil, nil
+		}
+		return reads.NewWindowAggregateResultSet(ctx, req, cur)
+	}
+
+	agg, err := reads.WindowAggregateType(req)
+	if err != nil {
+		return nil, err
+	}
+	if agg != datatypes.AggregateTypeMean {
+		return a.readWindowAggregate(ctx, req)
+	}
+
+	// The mean of each window cannot be combined from the partial means
+	// of each node, so the sums and counts are combined instead.
+	sumReq, countReq := *req, *req
+	sumReq.Aggregate = []*datatypes.Aggregate{{Type: datatypes.AggregateTypeSum}}
+	countReq.Aggregate = []*datatypes.Aggregate{{Type: datatypes.AggregateTypeCount}}
+
+	sum, err := a.readWindowAggregate(ctx, &sumReq)
+	if err != nil {
+		return nil, err
+	}
+	count, err := a.readWindowAggregate(ctx, &countReq)
+	if err != nil {
+		if sum != nil {
+			sum.Close()
+		}
+		return nil, err
+	}
+	return reads.NewWindowMeanResultSet(sum, count), nil
+}
+
+// readWindowAggregate reads the window aggregates from the local and remote
+// shards and combines the partial aggregates of each series.
+func (a *ClusterStoreMapping) readWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	var mu sync.Mutex
+	var g errgroup.Group
+	rss := make([]reads.ResultSet, 0, len(a.RemoteShardGroups)+1)
+
+	g.Go(func() error {
+		if len(a.LocalShardIDs) == 0 || a.CursorFn == nil {
+			return nil
+		}
+		cur, err := a.CursorFn(ctx, a.LocalShardIDs)
+		if err != nil {
+			return err
+		} else if cur == nil {
+			return nil
+		}
+
+		rs, err := reads.NewWindowAggregateResultSet(ctx, req, cur)
+		if err != nil {
+			cur.Close()
+			return err
+		}
+		mu.Lock()
+		rss = append(rss, rs)
+		mu.Unlock()
+		return nil
+	})
+
+	for _, sg := range a.RemoteShardGroups {
+		sg := sg
+		g.Go(func() error {
+			results, err := sg.ReadWindowAggregate(ctx, req)
+			if err != nil {
+				return err
+			}
+			if len(results) > 0 {
+				mu.Lock()
+				rss = append(rss, results...)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		for _, rs := range rss {
+			rs.Close()
+		}
+		return nil, err
+	}
+
+	return reads.NewWindowAggregateMergedResultSet(req, rss)
+}
+
 func (a *ClusterStoreMapping) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error) {
 	if len(a.LocalShardIDs) == 0 && len(a.RemoteShardGroups) == 0 {
 		return nil, nil
@@ -906,7 +977,7 @@ func (a *remoteShardGroup) shuffleShards() map[uint64]shardInfos {
 			}
 		}
 		if nodeID == 0 {
-			for _, owner := range si.Owners {
+			for _, owner := range preferredShardOwners(si) {
 				if _, ok := a.dirty.Load(owner.NodeID); !ok {
 					nodeID = owner.NodeID
 					break
@@ -1108,6 +1179,44 @@ func (a *remoteShardGroup) ReadFilter(ctx context.Context, req *datatypes.ReadFi
 	return nil, err
 }
 
+func (a *remoteShardGroup) ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) ([]reads.ResultSet, error) {
+	rs, err := a.executor.ReadWindowAggregate(a.nodeID, a.shards.shardIDs(), ctx, req)
+	if err == nil {
+		return []reads.ResultSet{rs}, nil
+	}
+	if !a.retry {
+		return nil, err
+	}
+	a.dirty.Store(a.nodeID, struct{}{})
+	for shardsByNodeID := a.shuffleShards(); shardsByNodeID != nil; shardsByNodeID = a.shuffleShards() {
+		var mu sync.Mutex
+		var g errgroup.Group
+		rss := make([]reads.ResultSet, 0, len(shardsByNodeID))
+		for nodeID, shards := range shardsByNodeID {
+			nodeID, shards := nodeID, shards
+			g.Go(func() error {
+				input, err := a.executor.ReadWindowAggregate(nodeID, shards.shardIDs(), ctx, req)
+				if err != nil {
+					a.dirty.Store(nodeID, struct{}{})
+					return err
+				}
+				mu.Lock()
+				rss = append(rss, input)
+				mu.Unlock()
+				return nil
+			})
+		}
+		err = g.Wait()
+		if err == nil {
+			return rss, nil
+		}
+		for _, rs := range rss {
+			rs.Close()
+		}
+	}
+	return nil, err
+}
+
 func (a *remoteShardGroup) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest) ([]reads.GroupResultSet, error) {
 	rs, err := a.executor.ReadGroup(a.nodeID, a.shards.shardIDs(), ctx, req)
 	if err == nil {
@@ -1156,6 +1265,44 @@ type FieldDimensionsEntry struct {
 	Dimensions map[string]struct{}
 }
 
+// selectShardOwner returns the node a shard should be read from. The local node
+// is used if it has the shard, then any node already selected for this query,
+// and otherwise a random owner. Quarantined copies are only read when no other
+// owner is available. Returns zero if the shard has no owners.
+func selectShardOwner(si meta.ShardInfo, localID uint64, selected map[uint64]shardInfos) uint64 {
+	owners := si.HealthyOwners()
+	if len(owners) == 0 {
+		owners = si.Owners
+	}
+	if len(owners) == 0 {
+		return 0
+	}
+
+	for _, owner := range owners {
+		if owner.NodeID == localID {
+			return localID
+		}
+	}
+	// The selected node has higher priority.
+	for _, owner := range owners {
+		if _, ok := selected[owner.NodeID]; ok {
+			return owner.NodeID
+		}
+	}
+	return owners[rand.Intn(len(owners))].NodeID
+}
+
+// preferredShardOwners returns the owners of a shard with quarantined copies last.
+func preferredShardOwners(si meta.ShardInfo) []meta.ShardOwner {
+	owners := si.HealthyOwners()
+	for _, owner := range si.Owners {
+		if owner.Quarantined {
+			owners = append(owners, owner)
+		}
+	}
+	return owners
+}
+
 type shardInfos []meta.ShardInfo
 
 func (a shardInfos) shardIDs() []uint64 {
@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tcp"
+	"github.com/influxdata/influxdb/tsdb"
 )
 
 // ShardWriter writes a set of points to a shard.
@@ -107,6 +108,43 @@ func (w *ShardWriter) WriteShardBinary(shardID, ownerID uint64, points [][]byte)
 	return nil
 }
 
+// ReplicateWAL sends entries of the WAL of a shard's leader to the copy of the
+// shard on node ownerID, and returns the position of the leader's WAL applied
+// to that copy.
+func (w *ShardWriter) ReplicateWAL(ownerID uint64, req *ReplicateWALRequest) (tsdb.WALPosition, error) {
+	conn, err := w.dial(ownerID)
+	if err != nil {
+		return tsdb.WALPosition{}, err
+	}
+	defer conn.Close()
+
+	// Marshal into protocol buffers.
+	buf, err := req.MarshalBinary()
+	if err != nil {
+		return tsdb.WALPosition{}, err
+	}
+
+	// Write request.
+	if err := WriteTLVT(conn, replicateWALRequestMessage, buf, w.timeout); err != nil {
+		MarkUnusable(conn)
+		return tsdb.WALPosition{}, err
+	}
+
+	// Read the response.
+	_, buf, err = ReadTLVT(conn, w.timeout)
+	if err != nil {
+		MarkUnusable(conn)
+		return tsdb.WALPosition{}, err
+	}
+
+	// Unmarshal response.
+	var resp ReplicateWALResponse
+	if err := resp.UnmarshalBinary(buf); err != nil {
+		return tsdb.WALPosition{}, err
+	}
+	return resp.Applied, resp.Err
+}
+
 // dial returns a connection to a single node in the cluster.
 func (w *ShardWriter) dial(nodeID uint64) (net.Conn, error) {
 	// If we don't have a connection pool for that addr yet, create one
@@ -49,6 +49,11 @@ type StatementExecutor struct {
 	// Holds monitoring data for SHOW STATS and SHOW DIAGNOSTICS.
 	Monitor *monitor.Monitor
 
+	// Lists the compactions of the shards on every data node for SHOW COMPACTIONS.
+	CompactionLister interface {
+		ListCompactions() ([]*meta.CompactionInfo, error)
+	}
+
 	// Used for rewriting points back into system for SELECT INTO statements.
 	PointsWriter interface {
 		WritePointsInto(*IntoWriteRequest) error
@@ -89,11 +94,21 @@ func (e *StatementExecutor) ExecuteStatement(ctx *query.ExecutionContext, stmt i
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeCreateDatabaseStatement(stmt)
+	case *influxql.CreateMeasurementSchemaStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeCreateMeasurementSchemaStatement(stmt)
 	case *influxql.CreateRetentionPolicyStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeCreateRetentionPolicyStatement(stmt)
+	case *influxql.CreateRollupRuleStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeCreateRollupRuleStatement(stmt)
 	case *influxql.CreateSubscriptionStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
@@ -104,6 +119,8 @@ func (e *StatementExecutor) ExecuteStatement(ctx *query.ExecutionContext, stmt i
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeCreateUserStatement(stmt)
+	case *influxql.DeleteFieldStatement:
+		err = e.executeDeleteFieldStatement(stmt, ctx.Database)
 	case *influxql.DeleteSeriesStatement:
 		err = e.executeDeleteSeriesStatement(stmt, ctx.Database)
 	case *influxql.DropContinuousQueryStatement:
@@ -116,11 +133,21 @@ func (e *StatementExecutor) ExecuteStatement(ctx *query.ExecutionContext, stmt i
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropDatabaseStatement(stmt)
+	case *influxql.DropFieldStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropFieldStatement(stmt, ctx.Database)
 	case *influxql.DropMeasurementStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropMeasurementStatement(stmt, ctx.Database)
+	case *influxql.DropMeasurementSchemaStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropMeasurementSchemaStatement(stmt)
 	case *influxql.DropSeriesStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
@@ -131,6 +158,11 @@ func (e *StatementExecutor) ExecuteStatement(ctx *query.ExecutionContext, stmt i
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeDropRetentionPolicyStatement(stmt)
+	case *influxql.DropRollupRuleStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeDropRollupRuleStatement(stmt)
 	case *influxql.DropShardStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
@@ -172,6 +204,8 @@ func (e *StatementExecutor) ExecuteStatement(ctx *query.ExecutionContext, stmt i
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeRevokeAdminStatement(stmt)
+	case *influxql.ShowCardinalityReportStatement:
+		rows, err = e.executeShowCardinalityReportStatement(ctx, stmt)
 	case *influxql.ShowContinuousQueriesStatement:
 		rows, err = e.executeShowContinuousQueriesStatement(ctx, stmt)
 	case *influxql.ShowDatabasesStatement:
@@ -184,14 +218,20 @@ func (e *StatementExecutor) ExecuteStatement(ctx *query.ExecutionContext, stmt i
 		return e.executeShowMeasurementsStatement(ctx, stmt)
 	case *influxql.ShowMeasurementCardinalityStatement:
 		rows, err = e.executeShowMeasurementCardinalityStatement(ctx, stmt)
+	case *influxql.ShowMeasurementSchemasStatement:
+		rows, err = e.executeShowMeasurementSchemasStatement(stmt)
 	case *influxql.ShowRetentionPoliciesStatement:
 		rows, err = e.executeShowRetentionPoliciesStatement(stmt)
+	case *influxql.ShowRollupRulesStatement:
+		rows, err = e.executeShowRollupRulesStatement(stmt)
 	case *influxql.ShowSeriesCardinalityStatement:
 		rows, err = e.executeShowSeriesCardinalityStatement(ctx, stmt)
 	case *influxql.ShowServersStatement:
 		rows, err = e.executeShowServersStatement(stmt)
 	case *influxql.ShowShardsStatement:
 		rows, err = e.executeShowShardsStatement(stmt)
+	case *influxql.ShowCompactionsStatement:
+		rows, err = e.executeShowCompactionsStatement(stmt)
 	case *influxql.ShowShardGroupsStatement:
 		rows, err = e.executeShowShardGroupsStatement(stmt)
 	case *influxql.ShowStatsStatement:
@@ -296,6 +336,23 @@ func (e *StatementExecutor) executeCreateDatabaseStatement(stmt *influxql.Create
 	return err
 }
 
+func (e *StatementExecutor) executeCreateMeasurementSchemaStatement(stmt *influxql.CreateMeasurementSchemaStatement) error {
+	if stmt.Database == "" {
+		return ErrDatabaseNameRequired
+	}
+
+	schema := &meta.MeasurementSchemaInfo{
+		Name:         stmt.Name,
+		RequiredTags: stmt.RequiredTags,
+		AllowedTags:  stmt.AllowedTags,
+		Mode:         stmt.Mode,
+	}
+	for _, f := range stmt.Fields {
+		schema.Fields = append(schema.Fields, meta.FieldSchemaInfo{Name: f.Name, Type: f.Type})
+	}
+	return e.MetaClient.CreateMeasurementSchema(stmt.Database, schema)
+}
+
 func (e *StatementExecutor) executeCreateRetentionPolicyStatement(stmt *influxql.CreateRetentionPolicyStatement) error {
 	if !meta.ValidName(stmt.Name) {
 		// TODO This should probably be in `(*meta.Data).CreateRetentionPolicy`
@@ -315,6 +372,10 @@ func (e *StatementExecutor) executeCreateRetentionPolicyStatement(stmt *influxql
 	return err
 }
 
+func (e *StatementExecutor) executeCreateRollupRuleStatement(q *influxql.CreateRollupRuleStatement) error {
+	return e.MetaClient.CreateRollupRule(q.Database, q.RetentionPolicy, q.Name, q.After, q.Interval)
+}
+
 func (e *StatementExecutor) executeCreateSubscriptionStatement(q *influxql.CreateSubscriptionStatement) error {
 	return e.MetaClient.CreateSubscription(q.Database, q.RetentionPolicy, q.Name, q.Mode, q.Destinations)
 }
@@ -324,6 +385,18 @@ func (e *StatementExecutor) executeCreateUserStatement(q *influxql.CreateUserSta
 	return err
 }
 
+func (e *StatementExecutor) executeDeleteFieldStatement(stmt *influxql.DeleteFieldStatement, database string) error {
+	if dbi := e.MetaClient.Database(database); dbi == nil {
+		return query.ErrDatabaseNotFound(database)
+	}
+
+	// Convert "now()" to current time.
+	stmt.Condition = influxql.Reduce(stmt.Condition, &influxql.NowValuer{Now: time.Now().UTC()})
+
+	// Delete the values of the fields.
+	return e.TSDBStore.DeleteFields(database, stmt.Sources, stmt.Fields, stmt.Condition)
+}
+
 func (e *StatementExecutor) executeDeleteSeriesStatement(stmt *influxql.DeleteSeriesStatement, database string) error {
 	if dbi := e.MetaClient.Database(database); dbi == nil {
 		return query.ErrDatabaseNotFound(database)
@@ -357,6 +430,15 @@ func (e *StatementExecutor) executeDropDatabaseStatement(stmt *influxql.DropData
 	return e.MetaClient.DropDatabase(stmt.Name)
 }
 
+func (e *StatementExecutor) executeDropFieldStatement(stmt *influxql.DropFieldStatement, database string) error {
+	if dbi := e.MetaClient.Database(database); dbi == nil {
+		return query.ErrDatabaseNotFound(database)
+	}
+
+	// Delete all values of the fields, which removes them from the measurements.
+	return e.TSDBStore.DeleteFields(database, stmt.Sources, stmt.Fields, nil)
+}
+
 func (e *StatementExecutor) executeDropMeasurementStatement(stmt *influxql.DropMeasurementStatement, database string) error {
 	if dbi := e.MetaClient.Database(database); dbi == nil {
 		return query.ErrDatabaseNotFound(database)
@@ -366,6 +448,13 @@ func (e *StatementExecutor) executeDropMeasurementStatement(stmt *influxql.DropM
 	return e.TSDBStore.DeleteMeasurement(database, stmt.Name)
 }
 
+func (e *StatementExecutor) executeDropMeasurementSchemaStatement(stmt *influxql.DropMeasurementSchemaStatement) error {
+	if stmt.Database == "" {
+		return ErrDatabaseNameRequired
+	}
+	return e.MetaClient.DropMeasurementSchema(stmt.Database, stmt.Name)
+}
+
 func (e *StatementExecutor) executeDropSeriesStatement(stmt *influxql.DropSeriesStatement, database string) error {
 	if dbi := e.MetaClient.Database(database); dbi == nil {
 		return query.ErrDatabaseNotFound(database)
@@ -408,6 +497,10 @@ func (e *StatementExecutor) executeDropRetentionPolicyStatement(stmt *influxql.D
 	return e.MetaClient.DropRetentionPolicy(stmt.Database, stmt.Name)
 }
 
+func (e *StatementExecutor) executeDropRollupRuleStatement(q *influxql.DropRollupRuleStatement) error {
+	return e.MetaClient.DropRollupRule(q.Database, q.RetentionPolicy, q.Name)
+}
+
 func (e *StatementExecutor) executeDropSubscriptionStatement(q *influxql.DropSubscriptionStatement) error {
 	return e.MetaClient.DropSubscription(q.Database, q.RetentionPolicy, q.Name)
 }
@@ -650,6 +743,34 @@ func (e *StatementExecutor) createIterators(ctx context.Context, stmt *influxql.
 	return cur, nil
 }
 
+func (e *StatementExecutor) executeShowCardinalityReportStatement(ctx *query.ExecutionContext, stmt *influxql.ShowCardinalityReportStatement) (models.Rows, error) {
+	if stmt.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	} else if e.MetaClient.Database(stmt.Database) == nil {
+		return nil, influxdb.ErrDatabaseNotFound(stmt.Database)
+	}
+
+	report, err := e.TSDBStore.CardinalityReport(ctx.Context, stmt.Database, stmt.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := &models.Row{
+		Name:    "totals",
+		Columns: []string{"series", "measurements"},
+		Values:  [][]interface{}{{report.SeriesN, report.MeasurementsN}},
+	}
+	measurements := &models.Row{Name: "measurements", Columns: []string{"measurement", "series", "growthPerHour"}}
+	for _, m := range report.Measurements {
+		measurements.Values = append(measurements.Values, []interface{}{m.Measurement, m.SeriesN, m.Growth})
+	}
+	tagKeys := &models.Row{Name: "tag keys", Columns: []string{"measurement", "tagKey", "values", "growthPerHour"}}
+	for _, k := range report.TagKeys {
+		tagKeys.Values = append(tagKeys.Values, []interface{}{k.Measurement, k.Key, k.ValuesN, k.Growth})
+	}
+	return []*models.Row{totals, measurements, tagKeys}, nil
+}
+
 func (e *StatementExecutor) executeShowContinuousQueriesStatement(ctx *query.ExecutionContext, stmt *influxql.ShowContinuousQueriesStatement) (models.Rows, error) {
 	dis := e.MetaClient.Databases()
 	a := ctx.ExecutionOptions.CoarseAuthorizer
@@ -673,10 +794,31 @@ func (e *StatementExecutor) executeShowDatabasesStatement(ctx *query.ExecutionCo
 	a := ctx.ExecutionOptions.CoarseAuthorizer
 
 	row := &models.Row{Name: "databases", Columns: []string{"name"}}
+	var quotas []*meta.DiskQuotaInfo
+	var hasQuota bool
 	for _, di := range dis {
 		// Only include databases that the user is authorized to read or write.
 		if a.AuthorizeDatabase(influxql.ReadPrivilege, di.Name) || a.AuthorizeDatabase(influxql.WritePrivilege, di.Name) {
 			row.Values = append(row.Values, []interface{}{di.Name})
+			quotas = append(quotas, di.DiskQuota)
+			hasQuota = hasQuota || di.DiskQuota != nil
+		}
+	}
+
+	// Show the disk used by the databases with a quota on the data node using
+	// the most, as the quotas apply to each node.
+	if hasQuota {
+		row.Columns = append(row.Columns, "disk_bytes", "soft_quota", "hard_quota")
+		for i, q := range quotas {
+			if q == nil {
+				row.Values[i] = append(row.Values[i], nil, nil, nil)
+				continue
+			}
+			usage, err := e.TSDBStore.DiskUsage(ctx.Context, row.Values[i][0].(string))
+			if err != nil {
+				return nil, err
+			}
+			row.Values[i] = append(row.Values[i], usage.Bytes, q.SoftLimit, q.HardLimit)
 		}
 	}
 	return []*models.Row{row}, nil
@@ -848,6 +990,27 @@ func (e *StatementExecutor) executeShowMeasurementCardinalityStatement(ctx *quer
 	}}, nil
 }
 
+func (e *StatementExecutor) executeShowMeasurementSchemasStatement(q *influxql.ShowMeasurementSchemasStatement) (models.Rows, error) {
+	if q.Database == "" {
+		return nil, ErrDatabaseNameRequired
+	}
+
+	di := e.MetaClient.Database(q.Database)
+	if di == nil {
+		return nil, influxdb.ErrDatabaseNotFound(q.Database)
+	}
+
+	row := &models.Row{Columns: []string{"name", "fields", "requiredTags", "allowedTags", "mode"}}
+	for _, si := range di.MeasurementSchemas {
+		fields := make([]string, len(si.Fields))
+		for i, f := range si.Fields {
+			fields[i] = f.String()
+		}
+		row.Values = append(row.Values, []interface{}{si.Name, strings.Join(fields, ","), strings.Join(si.RequiredTags, ","), strings.Join(si.AllowedTags, ","), si.Mode})
+	}
+	return []*models.Row{row}, nil
+}
+
 func (e *StatementExecutor) executeShowRetentionPoliciesStatement(q *influxql.ShowRetentionPoliciesStatement) (models.Rows, error) {
 	if q.Database == "" {
 		return nil, ErrDatabaseNameRequired
@@ -888,7 +1051,7 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 
 	rows := []*models.Row{}
 	for _, di := range dis {
-		row := &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners"}, Name: di.Name}
+		row := &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners", "owner_tiers"}, Name: di.Name}
 		for _, rpi := range di.RetentionPolicies {
 			for _, sgi := range rpi.ShardGroups {
 				// Shards associated with deleted shard groups are effectively deleted.
@@ -899,8 +1062,12 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 
 				for _, si := range sgi.Shards {
 					ownerIDs := make([]uint64, len(si.Owners))
+					var ownerTiers []string
 					for i, owner := range si.Owners {
 						ownerIDs[i] = owner.NodeID
+						if owner.Tier != "" {
+							ownerTiers = append(ownerTiers, fmt.Sprintf("%d:%s", owner.NodeID, owner.Tier))
+						}
 					}
 					expiry := ""
 					if rpi.Duration != 0 {
@@ -915,6 +1082,7 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 						sgi.EndTime.UTC().Format(time.RFC3339),
 						expiry,
 						joinUint64(ownerIDs),
+						strings.Join(ownerTiers, ","),
 					})
 				}
 			}
@@ -940,6 +1108,34 @@ func (e *StatementExecutor) executeShowSeriesCardinalityStatement(ctx *query.Exe
 	}}, nil
 }
 
+func (e *StatementExecutor) executeShowCompactionsStatement(stmt *influxql.ShowCompactionsStatement) (models.Rows, error) {
+	compactions, err := e.CompactionLister.ListCompactions()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &models.Row{Columns: []string{"node_id", "tcp_addr", "shard_id", "state", "level", "strategy", "files", "bytes", "progress", "started_at"}, Name: "compactions"}
+	for _, ci := range compactions {
+		var startedAt string
+		if !ci.StartedAt.IsZero() {
+			startedAt = ci.StartedAt.UTC().Format(time.RFC3339)
+		}
+		row.Values = append(row.Values, []interface{}{
+			ci.NodeID,
+			ci.TCPAddr,
+			ci.ShardID,
+			ci.State,
+			ci.Level,
+			ci.Strategy,
+			ci.Files,
+			ci.Bytes,
+			ci.Progress,
+			startedAt,
+		})
+	}
+	return []*models.Row{row}, nil
+}
+
 func (e *StatementExecutor) executeShowShardGroupsStatement(stmt *influxql.ShowShardGroupsStatement) (models.Rows, error) {
 	dis := e.MetaClient.Databases()
 
@@ -1024,6 +1220,24 @@ func (e *StatementExecutor) executeShowSubscriptionsStatement(stmt *influxql.Sho
 	return rows, nil
 }
 
+func (e *StatementExecutor) executeShowRollupRulesStatement(stmt *influxql.ShowRollupRulesStatement) (models.Rows, error) {
+	dis := e.MetaClient.Databases()
+
+	rows := []*models.Row{}
+	for _, di := range dis {
+		row := &models.Row{Columns: []string{"retention_policy", "name", "after", "interval"}, Name: di.Name}
+		for _, rpi := range di.RetentionPolicies {
+			for _, ri := range rpi.RollupRules {
+				row.Values = append(row.Values, []interface{}{rpi.Name, ri.Name, ri.After.String(), ri.Interval.String()})
+			}
+		}
+		if len(row.Values) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
 func (e *StatementExecutor) executeShowTagKeys(ctx *query.ExecutionContext, q *influxql.ShowTagKeysStatement) error {
 	if q.Database == "" {
 		return ErrDatabaseNameRequired
@@ -1400,6 +1614,22 @@ func (e *StatementExecutor) NormalizeStatement(stmt influxql.Statement, defaultD
 			if node.Database == "" {
 				node.Database = defaultDatabase
 			}
+		case *influxql.CreateMeasurementSchemaStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.DropMeasurementSchemaStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowMeasurementSchemasStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.ShowCardinalityReportStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
 		case *influxql.ShowMeasurementsStatement:
 			if node.Database == "" {
 				node.Database = defaultDatabase
@@ -1422,7 +1652,8 @@ func (e *StatementExecutor) NormalizeStatement(stmt influxql.Statement, defaultD
 			}
 		case *influxql.Measurement:
 			switch stmt.(type) {
-			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement:
+			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement,
+				*influxql.DropFieldStatement, *influxql.DeleteFieldStatement:
 				// DB and RP not supported by these statements so don't rewrite into invalid
 				// statements
 			case *influxql.ShowTagValuesStatement:
@@ -1486,6 +1717,7 @@ type TSDBStore interface {
 
 	CreateShard(database, policy string, shardID uint64, enabled bool) error
 	WriteToShard(shardID uint64, points []models.Point) error
+	ApplyWAL(shardID, leaderID uint64, start, next tsdb.WALPosition, lagBytes int64, b []byte) (tsdb.WALPosition, error)
 
 	RestoreShard(id uint64, r io.Reader) error
 	BackupShard(id uint64, since time.Time, w io.Writer) error
@@ -1494,8 +1726,11 @@ type TSDBStore interface {
 	DeleteMeasurement(database, name string) error
 	DeleteRetentionPolicy(database, name string) error
 	DeleteSeries(database string, sources []influxql.Source, condition influxql.Expr) error
+	DeleteFields(database string, sources []influxql.Source, fields []string, condition influxql.Expr) error
 	DeleteShard(id uint64) error
 
+	RebuildShardIndex(ctx context.Context, id uint64) error
+
 	MeasurementNames(ctx context.Context, auth query.FineAuthorizer, database string, retentionPolicy string, cond influxql.Expr) ([][]byte, error)
 	TagKeys(ctx context.Context, auth query.FineAuthorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagKeys, error)
 	TagValues(ctx context.Context, auth query.FineAuthorizer, shardIDs []uint64, cond influxql.Expr) ([]tsdb.TagValues, error)
@@ -1504,6 +1739,9 @@ type TSDBStore interface {
 	MeasurementsCardinality(ctx context.Context, database string) (int64, error)
 	SeriesSketches(ctx context.Context, database string) (estimator.Sketch, estimator.Sketch, error)
 	MeasurementsSketches(ctx context.Context, database string) (estimator.Sketch, estimator.Sketch, error)
+
+	CardinalityReport(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error)
+	DiskUsage(ctx context.Context, database string) (*tsdb.DiskUsage, error)
 }
 
 var _ TSDBStore = ClusterTSDBStore{}
@@ -1564,6 +1802,18 @@ func (s ClusterTSDBStore) DeleteSeries(database string, sources []influxql.Sourc
 	return g.Wait()
 }
 
+func (s ClusterTSDBStore) DeleteFields(database string, sources []influxql.Source, fields []string, condition influxql.Expr) error {
+	var g errgroup.Group
+	g.Go(func() error {
+		return s.Store.DeleteFields(database, sources, fields, condition)
+	})
+	g.Go(func() error {
+		stmt := &influxql.DeleteFieldStatement{Fields: fields, Sources: sources, Condition: condition}
+		return s.MetaExecutor.ExecuteStatement(stmt, database)
+	})
+	return g.Wait()
+}
+
 func (s ClusterTSDBStore) DeleteShard(id uint64) error {
 	var g errgroup.Group
 	g.Go(func() error {
@@ -1748,6 +1998,130 @@ func (s ClusterTSDBStore) MeasurementsCardinality(ctx context.Context, database
 	return int64(ss.Count() - ts.Count()), nil
 }
 
+// CardinalityReport returns the cardinality report of database merged across
+// the data nodes. As the series of a measurement are replicated across the
+// nodes, each measurement and tag key keeps its largest count on any node.
+func (s ClusterTSDBStore) CardinalityReport(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error) {
+	if n <= 0 {
+		n = tsdb.DefaultCardinalityReportN
+	}
+
+	fn := func() (interface{}, error) {
+		return s.Store.CardinalityReport(ctx, database, n)
+	}
+	rfn := func(nodeID uint64) (interface{}, error) {
+		return s.MetaExecutor.CardinalityReport(nodeID, database, n)
+	}
+	results, err := s.MetaExecutor.ExecuteQuery(fn, rfn)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements := make(map[string]tsdb.MeasurementCardinality)
+	tagKeys := make(map[[2]string]tsdb.TagKeyCardinality)
+	for _, result := range results {
+		r, ok := result.(*tsdb.CardinalityReport)
+		if !ok || r == nil {
+			continue
+		}
+		for _, m := range r.Measurements {
+			if prev, ok := measurements[m.Measurement]; !ok || m.SeriesN > prev.SeriesN {
+				measurements[m.Measurement] = m
+			}
+		}
+		for _, k := range r.TagKeys {
+			id := [2]string{k.Measurement, k.Key}
+			if prev, ok := tagKeys[id]; !ok || k.ValuesN > prev.ValuesN {
+				tagKeys[id] = k
+			}
+		}
+	}
+
+	report := &tsdb.CardinalityReport{Database: database, Time: time.Now().UTC()}
+	for _, m := range measurements {
+		report.Measurements = append(report.Measurements, m)
+	}
+	for _, k := range tagKeys {
+		report.TagKeys = append(report.TagKeys, k)
+	}
+	report.Top(n)
+
+	if report.SeriesN, err = s.SeriesCardinality(ctx, database); err != nil {
+		return nil, err
+	}
+	if report.MeasurementsN, err = s.MeasurementsCardinality(ctx, database); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// DiskUsage returns the disk used by the shards of database on the data node
+// using the most, as the disk quotas apply to each node. Each retention policy
+// keeps its largest usage on any node.
+func (s ClusterTSDBStore) DiskUsage(ctx context.Context, database string) (*tsdb.DiskUsage, error) {
+	fn := func() (interface{}, error) {
+		return s.Store.DiskUsage(ctx, database)
+	}
+	rfn := func(nodeID uint64) (interface{}, error) {
+		return s.MetaExecutor.DiskUsage(nodeID, database)
+	}
+	results, err := s.MetaExecutor.ExecuteQuery(fn, rfn)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &tsdb.DiskUsage{Database: database, RetentionPolicies: make(map[string]int64)}
+	for _, result := range results {
+		u, ok := result.(*tsdb.DiskUsage)
+		if !ok || u == nil {
+			continue
+		}
+		if u.Bytes > usage.Bytes {
+			usage.Bytes = u.Bytes
+		}
+		for rp, n := range u.RetentionPolicies {
+			if n > usage.RetentionPolicies[rp] {
+				usage.RetentionPolicies[rp] = n
+			}
+		}
+	}
+	return usage, nil
+}
+
+// ListCompactions returns the running and planned compactions of the shards
+// on every data node, ordered by node and shard.
+func (s ClusterTSDBStore) ListCompactions() ([]*meta.CompactionInfo, error) {
+	fn := func() (interface{}, error) {
+		nodeID := s.MetaExecutor.MetaClient.NodeID()
+		ni, err := s.MetaExecutor.MetaClient.DataNode(nodeID)
+		if err != nil {
+			return nil, err
+		}
+		return shardCompactions(s.Store, nodeID, ni.TCPAddr), nil
+	}
+	rfn := func(nodeID uint64) (interface{}, error) {
+		return s.MetaExecutor.ListCompactions(nodeID)
+	}
+	results, err := s.MetaExecutor.ExecuteQuery(fn, rfn)
+	if err != nil {
+		return nil, err
+	}
+
+	var compactions []*meta.CompactionInfo
+	for _, result := range results {
+		if infos, ok := result.([]*meta.CompactionInfo); ok {
+			compactions = append(compactions, infos...)
+		}
+	}
+	sort.SliceStable(compactions, func(i, j int) bool {
+		if compactions[i].NodeID != compactions[j].NodeID {
+			return compactions[i].NodeID < compactions[j].NodeID
+		}
+		return compactions[i].ShardID < compactions[j].ShardID
+	})
+	return compactions, nil
+}
+
 // joinUint64 returns a comma-delimited string of uint64 numbers.
 func joinUint64(a []uint64) string {
 	var buf bytes.Buffer
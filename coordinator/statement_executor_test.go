@@ -9,6 +9,7 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -434,6 +435,52 @@ func TestQueryExecutor_ExecuteQuery_ShowDatabases(t *testing.T) {
 	}
 }
 
+func TestQueryExecutor_ExecuteQuery_ShowDatabases_DiskQuota(t *testing.T) {
+	qe := query.NewExecutor()
+	qe.StatementExecutor = &coordinator.StatementExecutor{
+		MetaClient: &internal.MetaClientMock{
+			DatabasesFn: func() []meta.DatabaseInfo {
+				return []meta.DatabaseInfo{
+					{Name: "db1", DiskQuota: &meta.DiskQuotaInfo{SoftLimit: 100, HardLimit: 200}},
+					{Name: "db2"},
+				}
+			},
+		},
+		TSDBStore: &internal.TSDBStoreMock{
+			DiskUsageFn: func(ctx context.Context, database string) (*tsdb.DiskUsage, error) {
+				if database != "db1" {
+					t.Fatalf("unexpected database: %s", database)
+				}
+				return &tsdb.DiskUsage{Database: database, Bytes: 150}, nil
+			},
+		},
+	}
+
+	q, err := influxql.ParseQuery("SHOW DATABASES")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt := query.ExecutionOptions{CoarseAuthorizer: query.OpenCoarseAuthorizer}
+	results := ReadAllResults(qe.ExecuteQuery(q, opt, make(chan struct{})))
+	exp := []*query.Result{
+		{
+			StatementID: 0,
+			Series: []*models.Row{{
+				Name:    "databases",
+				Columns: []string{"name", "disk_bytes", "soft_quota", "hard_quota"},
+				Values: [][]interface{}{
+					{"db1", int64(150), int64(100), int64(200)},
+					{"db2", nil, nil, nil},
+				},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(results, exp) {
+		t.Fatalf("unexpected results: exp %s, got %s", spew.Sdump(exp), spew.Sdump(results))
+	}
+}
+
 func TestQueryExecutor_ExecuteQuery_ShowContinuousQueries(t *testing.T) {
 	qe := query.NewExecutor()
 	qe.StatementExecutor = &coordinator.StatementExecutor{
@@ -514,6 +561,220 @@ func TestQueryExecutor_ExecuteQuery_ShowContinuousQueries(t *testing.T) {
 	}
 }
 
+// Ensure rollup rules are created, listed and dropped through the meta client.
+func TestQueryExecutor_ExecuteQuery_RollupRules(t *testing.T) {
+	e := NewQueryExecutor()
+
+	var rules []meta.RollupRuleInfo
+	e.MetaClient.CreateRollupRuleFn = func(database, rp, name string, after, interval time.Duration) error {
+		if database != "db0" || rp != "rp0" {
+			t.Fatalf("unexpected retention policy: %s.%s", database, rp)
+		}
+		rules = append(rules, meta.RollupRuleInfo{Name: name, After: after, Interval: interval})
+		return nil
+	}
+	e.MetaClient.DropRollupRuleFn = func(database, rp, name string) error {
+		if database != "db0" || rp != "rp0" || name != "hourly" {
+			t.Fatalf("unexpected rule: %s.%s.%s", database, rp, name)
+		}
+		rules = nil
+		return nil
+	}
+	e.MetaClient.DatabasesFn = func() []meta.DatabaseInfo {
+		return []meta.DatabaseInfo{
+			{Name: "db0", RetentionPolicies: []meta.RetentionPolicyInfo{{Name: "rp0", RollupRules: rules}}},
+			{Name: "db1", RetentionPolicies: []meta.RetentionPolicyInfo{{Name: "rp0"}}},
+		}
+	}
+
+	if res := <-e.ExecuteQuery(`CREATE ROLLUP RULE hourly ON db0.rp0 AFTER 30d INTERVAL 1h`, "", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	exp := []meta.RollupRuleInfo{{Name: "hourly", After: 30 * 24 * time.Hour, Interval: time.Hour}}
+	if !reflect.DeepEqual(rules, exp) {
+		t.Fatalf("unexpected rules: %s", spew.Sdump(rules))
+	}
+
+	res := <-e.ExecuteQuery(`SHOW ROLLUP RULES`, "", 0)
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	expRows := models.Rows{{
+		Name:    "db0",
+		Columns: []string{"retention_policy", "name", "after", "interval"},
+		Values:  [][]interface{}{{"rp0", "hourly", "720h0m0s", "1h0m0s"}},
+	}}
+	if !reflect.DeepEqual(res.Series, expRows) {
+		t.Fatalf("unexpected rows: %s", spew.Sdump(res.Series))
+	}
+
+	if res := <-e.ExecuteQuery(`DROP ROLLUP RULE hourly ON db0.rp0`, "", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	} else if rules != nil {
+		t.Fatal("expected rule to be dropped")
+	}
+}
+
+// Ensure measurement schemas are created, listed and dropped on the database
+// of the query by default.
+func TestQueryExecutor_ExecuteQuery_MeasurementSchemas(t *testing.T) {
+	e := NewQueryExecutor()
+
+	var schemas []meta.MeasurementSchemaInfo
+	e.MetaClient.CreateMeasurementSchemaFn = func(database string, schema *meta.MeasurementSchemaInfo) error {
+		if database != "db0" {
+			t.Fatalf("unexpected database: %s", database)
+		}
+		schemas = append(schemas, *schema)
+		return nil
+	}
+	e.MetaClient.DropMeasurementSchemaFn = func(database, name string) error {
+		if database != "db1" || name != "cpu" {
+			t.Fatalf("unexpected schema: %s.%s", database, name)
+		}
+		schemas = nil
+		return nil
+	}
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		return &meta.DatabaseInfo{Name: name, MeasurementSchemas: schemas}
+	}
+
+	if res := <-e.ExecuteQuery(`CREATE MEASUREMENT SCHEMA cpu FIELD KEYS (value FLOAT, n INTEGER) REQUIRED TAG KEYS (host) MODE COERCE`, "db0", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	exp := []meta.MeasurementSchemaInfo{{
+		Name:         "cpu",
+		Fields:       []meta.FieldSchemaInfo{{Name: "value", Type: influxql.Float}, {Name: "n", Type: influxql.Integer}},
+		RequiredTags: []string{"host"},
+		Mode:         meta.MeasurementSchemaModeCoerce,
+	}}
+	if !reflect.DeepEqual(schemas, exp) {
+		t.Fatalf("unexpected schemas: %s", spew.Sdump(schemas))
+	}
+
+	res := <-e.ExecuteQuery(`SHOW MEASUREMENT SCHEMAS`, "db0", 0)
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	expRows := models.Rows{{
+		Columns: []string{"name", "fields", "requiredTags", "allowedTags", "mode"},
+		Values:  [][]interface{}{{"cpu", "value:float,n:integer", "host", "", "coerce"}},
+	}}
+	if !reflect.DeepEqual(res.Series, expRows) {
+		t.Fatalf("unexpected rows: %s", spew.Sdump(res.Series))
+	}
+
+	if res := <-e.ExecuteQuery(`DROP MEASUREMENT SCHEMA cpu ON db1`, "db0", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	} else if schemas != nil {
+		t.Fatal("expected schema to be dropped")
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ShowCardinalityReport(t *testing.T) {
+	e := NewQueryExecutor()
+	e.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		if name != "db0" {
+			return nil
+		}
+		return DefaultMetaClientDatabaseFn(name)
+	}
+	e.TSDBStore.CardinalityReportFn = func(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error) {
+		if database != "db0" || n != 5 {
+			t.Fatalf("unexpected report request: %s, %d", database, n)
+		}
+		return &tsdb.CardinalityReport{
+			Database:      database,
+			SeriesN:       120,
+			MeasurementsN: 2,
+			Measurements:  []tsdb.MeasurementCardinality{{Measurement: "cpu", SeriesN: 100, Growth: 2.5}},
+			TagKeys:       []tsdb.TagKeyCardinality{{Measurement: "cpu", Key: "host", ValuesN: 100, Growth: 2.5}},
+		}, nil
+	}
+
+	res := <-e.ExecuteQuery(`SHOW CARDINALITY REPORT LIMIT 5`, "db0", 0)
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	exp := models.Rows{
+		{Name: "totals", Columns: []string{"series", "measurements"}, Values: [][]interface{}{{int64(120), int64(2)}}},
+		{Name: "measurements", Columns: []string{"measurement", "series", "growthPerHour"}, Values: [][]interface{}{{"cpu", int64(100), 2.5}}},
+		{Name: "tag keys", Columns: []string{"measurement", "tagKey", "values", "growthPerHour"}, Values: [][]interface{}{{"cpu", "host", int64(100), 2.5}}},
+	}
+	if !reflect.DeepEqual(res.Series, exp) {
+		t.Fatalf("unexpected rows: %s", spew.Sdump(res.Series))
+	}
+
+	if res := <-e.ExecuteQuery(`SHOW CARDINALITY REPORT ON nodb`, "db0", 0); res.Err == nil || res.Err.Error() != "database not found: nodb" {
+		t.Fatalf("unexpected error: %v", res.Err)
+	}
+}
+
+// Ensure DELETE FIELD and DROP FIELD delete the values of the fields.
+func TestQueryExecutor_ExecuteQuery_DeleteFields(t *testing.T) {
+	e := DefaultQueryExecutor()
+
+	var calls []string
+	e.TSDBStore.DeleteFieldsFn = func(database string, sources []influxql.Source, fields []string, condition influxql.Expr) error {
+		if database != "db0" {
+			t.Fatalf("unexpected database: %s", database)
+		}
+		var cond string
+		if condition != nil {
+			if strings.Contains(condition.String(), "now()") {
+				t.Fatalf("expected now() to be reduced: %s", condition)
+			}
+			cond = " WHERE time"
+		}
+		calls = append(calls, fmt.Sprintf("%s FROM %s%s", strings.Join(fields, ", "), influxql.Sources(sources), cond))
+		return nil
+	}
+
+	if res := <-e.ExecuteQuery(`DELETE FIELD debug FROM cpu WHERE time < now() - 1h`, "db0", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	if res := <-e.ExecuteQuery(`DROP FIELD debug, x FROM cpu, /^mem/`, "db0", 0); res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	if exp := []string{"debug FROM cpu WHERE time", "debug, x FROM cpu, /^mem/"}; !reflect.DeepEqual(calls, exp) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
+
+func TestQueryExecutor_ExecuteQuery_ShowCompactions(t *testing.T) {
+	e := DefaultQueryExecutor()
+	started := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	e.StatementExecutor.CompactionLister = compactionListerFunc(func() ([]*meta.CompactionInfo, error) {
+		return []*meta.CompactionInfo{
+			{NodeID: 1, TCPAddr: "node1:8088", ShardID: 2, Level: 1, Strategy: "level", State: "running", Files: 4, Bytes: 1024, Progress: 0.5, StartedAt: started},
+			{NodeID: 2, TCPAddr: "node2:8088", ShardID: 3, Level: 4, Strategy: "full", State: "deferred", Files: 8, Bytes: 4096},
+		}, nil
+	})
+
+	res := <-e.ExecuteQuery(`SHOW COMPACTIONS`, "", 0)
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	exp := models.Rows{{
+		Name:    "compactions",
+		Columns: []string{"node_id", "tcp_addr", "shard_id", "state", "level", "strategy", "files", "bytes", "progress", "started_at"},
+		Values: [][]interface{}{
+			{uint64(1), "node1:8088", uint64(2), "running", 1, "level", 4, int64(1024), 0.5, "2000-01-01T00:00:00Z"},
+			{uint64(2), "node2:8088", uint64(3), "deferred", 4, "full", 8, int64(4096), float64(0), ""},
+		},
+	}}
+	if !reflect.DeepEqual(res.Series, exp) {
+		t.Fatalf("unexpected rows: %s", spew.Sdump(res.Series))
+	}
+}
+
+// compactionListerFunc is a function listing the compactions of a cluster.
+type compactionListerFunc func() ([]*meta.CompactionInfo, error)
+
+func (fn compactionListerFunc) ListCompactions() ([]*meta.CompactionInfo, error) {
+	return fn()
+}
+
 // QueryExecutor is a test wrapper for coordinator.QueryExecutor.
 type QueryExecutor struct {
 	*query.Executor
@@ -19,6 +19,8 @@ const (
 	ReadGroupPhysKind     = "ReadGroupPhysKind"
 	ReadTagKeysPhysKind   = "ReadTagKeysPhysKind"
 	ReadTagValuesPhysKind = "ReadTagValuesPhysKind"
+
+	ReadWindowAggregatePhysKind = "ReadWindowAggregatePhysKind"
 )
 
 type ReadGroupPhysSpec struct {
@@ -46,6 +48,40 @@ func (s *ReadGroupPhysSpec) Copy() plan.ProcedureSpec {
 	return ns
 }
 
+// ReadWindowAggregatePhysSpec reads the aggregate of each fixed window of
+// every series in the range.
+type ReadWindowAggregatePhysSpec struct {
+	plan.DefaultCost
+	ReadRangePhysSpec
+
+	WindowEvery int64
+	Offset      int64
+	Aggregates  []plan.ProcedureKind
+	CreateEmpty bool
+
+	// TimeColumn is the window bound, either _start or _stop, used as the
+	// time of each aggregate when the windows of a series are combined
+	// into a single table. If empty, each window is a separate table.
+	TimeColumn string
+}
+
+func (s *ReadWindowAggregatePhysSpec) Kind() plan.ProcedureKind {
+	return ReadWindowAggregatePhysKind
+}
+
+func (s *ReadWindowAggregatePhysSpec) Copy() plan.ProcedureSpec {
+	ns := new(ReadWindowAggregatePhysSpec)
+	ns.ReadRangePhysSpec = *s.ReadRangePhysSpec.Copy().(*ReadRangePhysSpec)
+
+	ns.WindowEvery = s.WindowEvery
+	ns.Offset = s.Offset
+	ns.Aggregates = make([]plan.ProcedureKind, len(s.Aggregates))
+	copy(ns.Aggregates, s.Aggregates)
+	ns.CreateEmpty = s.CreateEmpty
+	ns.TimeColumn = s.TimeColumn
+	return ns
+}
+
 type ReadRangePhysSpec struct {
 	plan.DefaultCost
 
@@ -1,12 +1,15 @@
 package influxdb
 
 import (
+	"math"
+
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 )
 
 func init() {
@@ -17,7 +20,11 @@ func init() {
 		PushDownReadTagKeysRule{},
 		PushDownReadTagValuesRule{},
 		SortedPivotRule{},
+		PushDownWindowAggregateByTimeRule{},
 	)
+	for _, kind := range windowAggregateKinds {
+		plan.RegisterPhysicalRules(PushDownWindowAggregateRule{Kind: kind})
+	}
 }
 
 // PushDownGroupRule pushes down a group operation to storage
@@ -58,6 +65,162 @@ func (rule PushDownGroupRule) Rewrite(node plan.Node) (plan.Node, bool, error) {
 	}), true, nil
 }
 
+// windowAggregateKinds are the aggregates storage is able to compute for
+// each window of a series.
+var windowAggregateKinds = []plan.ProcedureKind{
+	universe.CountKind,
+	universe.SumKind,
+	universe.MeanKind,
+	universe.MinKind,
+	universe.MaxKind,
+	universe.FirstKind,
+	universe.LastKind,
+}
+
+// PushDownWindowAggregateRule pushes down an aggregate of fixed windows to
+// storage. It matches 'ReadRange |> window() |> <aggregate>()' for a
+// single aggregate kind.
+type PushDownWindowAggregateRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule(" + string(rule.Kind) + ")"
+}
+
+func (rule PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.Kind, plan.Pat(universe.WindowKind, plan.Pat(ReadRangePhysKind)))
+}
+
+func (rule PushDownWindowAggregateRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	windowNode := pn.Predecessors()[0]
+	windowSpec := windowNode.ProcedureSpec().(*universe.WindowProcedureSpec)
+	fromNode := windowNode.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadRangePhysSpec)
+
+	// Storage only aggregates the _value column.
+	switch spec := pn.ProcedureSpec().(type) {
+	case *universe.CountProcedureSpec:
+		if !isValueColumns(spec.Columns) {
+			return pn, false, nil
+		}
+	case *universe.SumProcedureSpec:
+		if !isValueColumns(spec.Columns) {
+			return pn, false, nil
+		}
+	case *universe.MeanProcedureSpec:
+		if !isValueColumns(spec.Columns) {
+			return pn, false, nil
+		}
+	case *universe.MinProcedureSpec:
+		if spec.Column != execute.DefaultValueColLabel {
+			return pn, false, nil
+		}
+	case *universe.MaxProcedureSpec:
+		if spec.Column != execute.DefaultValueColLabel {
+			return pn, false, nil
+		}
+	case *universe.FirstProcedureSpec:
+		if spec.Column != execute.DefaultValueColLabel {
+			return pn, false, nil
+		}
+	case *universe.LastProcedureSpec:
+		if spec.Column != execute.DefaultValueColLabel {
+			return pn, false, nil
+		}
+	default:
+		return pn, false, nil
+	}
+
+	// Storage only supports contiguous windows of a fixed duration which
+	// use the default columns.
+	w := windowSpec.Window
+	switch {
+	case w.Every.Months() != 0 || w.Offset.Months() != 0:
+		return pn, false, nil
+	case !w.Every.IsPositive() || w.Every.Equal(infinityDuration):
+		return pn, false, nil
+	case !w.Period.Equal(w.Every):
+		return pn, false, nil
+	case windowSpec.TimeColumn != execute.DefaultTimeColLabel,
+		windowSpec.StartColumn != execute.DefaultStartColLabel,
+		windowSpec.StopColumn != execute.DefaultStopColLabel:
+		return pn, false, nil
+	}
+
+	return plan.CreatePhysicalNode("ReadWindowAggregate", &ReadWindowAggregatePhysSpec{
+		ReadRangePhysSpec: *fromSpec.Copy().(*ReadRangePhysSpec),
+		WindowEvery:       int64(w.Every.Duration()),
+		Offset:            int64(w.Offset.Duration()),
+		Aggregates:        []plan.ProcedureKind{rule.Kind},
+		CreateEmpty:       windowSpec.CreateEmpty,
+	}), true, nil
+}
+
+// infinityDuration is the duration of the Flux inf value, which is used
+// to merge windowed tables back together.
+var infinityDuration = values.ConvertDuration(math.MaxInt64)
+
+func isValueColumns(columns []string) bool {
+	return len(columns) == 1 && columns[0] == execute.DefaultValueColLabel
+}
+
+// PushDownWindowAggregateByTimeRule matches the remainder of aggregateWindow,
+// 'ReadWindowAggregate |> duplicate(column: "_stop", as: "_time") |> window(every: inf)',
+// which assigns each aggregate the time of its window and combines the
+// windows of each series into a single table.
+type PushDownWindowAggregateByTimeRule struct{}
+
+func (PushDownWindowAggregateByTimeRule) Name() string {
+	return "PushDownWindowAggregateByTimeRule"
+}
+
+func (PushDownWindowAggregateByTimeRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.WindowKind,
+		plan.Pat(universe.SchemaMutationKind,
+			plan.Pat(ReadWindowAggregatePhysKind)))
+}
+
+func (PushDownWindowAggregateByTimeRule) Rewrite(pn plan.Node) (plan.Node, bool, error) {
+	windowSpec := pn.ProcedureSpec().(*universe.WindowProcedureSpec)
+	duplicateNode := pn.Predecessors()[0]
+	duplicateSpec := duplicateNode.ProcedureSpec().(*universe.SchemaMutationProcedureSpec)
+	fromNode := duplicateNode.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*ReadWindowAggregatePhysSpec)
+
+	if fromSpec.TimeColumn != "" {
+		return pn, false, nil
+	}
+
+	// The window must merge all of the windows back into a single table.
+	w := windowSpec.Window
+	switch {
+	case !w.Every.Equal(infinityDuration) || !w.Period.Equal(infinityDuration):
+		return pn, false, nil
+	case !w.Offset.IsZero() || windowSpec.CreateEmpty:
+		return pn, false, nil
+	case windowSpec.TimeColumn != execute.DefaultTimeColLabel,
+		windowSpec.StartColumn != execute.DefaultStartColLabel,
+		windowSpec.StopColumn != execute.DefaultStopColLabel:
+		return pn, false, nil
+	}
+
+	// The schema mutation must copy one of the window bounds to _time.
+	if len(duplicateSpec.Mutations) != 1 {
+		return pn, false, nil
+	}
+	dup, ok := duplicateSpec.Mutations[0].(*universe.DuplicateOpSpec)
+	if !ok || dup.As != execute.DefaultTimeColLabel {
+		return pn, false, nil
+	} else if dup.Column != execute.DefaultStartColLabel && dup.Column != execute.DefaultStopColLabel {
+		return pn, false, nil
+	}
+
+	newSpec := fromSpec.Copy().(*ReadWindowAggregatePhysSpec)
+	newSpec.TimeColumn = dup.Column
+	return plan.CreatePhysicalNode("ReadWindowAggregateByTime", newSpec), true, nil
+}
+
 // PushDownRangeRule pushes down a range filter to storage
 type PushDownRangeRule struct{}
 
@@ -1,6 +1,7 @@
 package influxdb_test
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
 	"github.com/influxdata/influxdb/flux/stdlib/influxdata/influxdb"
 )
 
@@ -1284,3 +1286,152 @@ func TestReadTagValuesRule(t *testing.T) {
 		})
 	}
 }
+
+func TestPushDownWindowAggregateRule(t *testing.T) {
+	readRange := influxdb.ReadRangePhysSpec{
+		Bucket: "my-bucket",
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+
+	window := func(every, period time.Duration) *universe.WindowProcedureSpec {
+		return &universe.WindowProcedureSpec{
+			Window: plan.WindowSpec{
+				Every:  flux.ConvertDuration(every),
+				Period: flux.ConvertDuration(period),
+			},
+			TimeColumn:  execute.DefaultTimeColLabel,
+			StartColumn: execute.DefaultStartColLabel,
+			StopColumn:  execute.DefaultStopColLabel,
+		}
+	}
+
+	countSpec := &universe.CountProcedureSpec{
+		AggregateConfig: execute.AggregateConfig{Columns: []string{execute.DefaultValueColLabel}},
+	}
+	lastSpec := &universe.LastProcedureSpec{
+		SelectorConfig: execute.SelectorConfig{Column: execute.DefaultValueColLabel},
+	}
+
+	otherCountSpec := &universe.CountProcedureSpec{
+		AggregateConfig: execute.AggregateConfig{Columns: []string{"_other"}},
+	}
+
+	month, err := values.ParseDuration("1mo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monthWindow := window(0, 0)
+	monthWindow.Window.Every, monthWindow.Window.Period = month, month
+
+	readWindowAggregate := func(kind plan.ProcedureKind) *influxdb.ReadWindowAggregatePhysSpec {
+		return &influxdb.ReadWindowAggregatePhysSpec{
+			ReadRangePhysSpec: readRange,
+			WindowEvery:       int64(time.Minute),
+			Aggregates:        []plan.ProcedureKind{kind},
+		}
+	}
+
+	simple := func(w *universe.WindowProcedureSpec, kind plan.ProcedureKind, spec plan.PhysicalProcedureSpec) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.Node{
+				plan.CreatePhysicalNode("ReadRange", &readRange),
+				plan.CreatePhysicalNode("window", w),
+				plan.CreatePhysicalNode(plan.NodeID(kind), spec),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+			},
+		}
+	}
+
+	// WindowProcedureSpec.Copy does not preserve the column names, so the
+	// cases which leave the plan unchanged spell out the expected plan.
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "count",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.CountKind}},
+			Before: simple(window(time.Minute, time.Minute), universe.CountKind, countSpec),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", readWindowAggregate(universe.CountKind)),
+				},
+			},
+		},
+		{
+			Name:   "last",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.LastKind}},
+			Before: simple(window(time.Minute, time.Minute), universe.LastKind, lastSpec),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregate", readWindowAggregate(universe.LastKind)),
+				},
+			},
+		},
+		{
+			Name:   "period differs from every",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.CountKind}},
+			Before: simple(window(time.Minute, 2*time.Minute), universe.CountKind, countSpec),
+			After:  simple(window(time.Minute, 2*time.Minute), universe.CountKind, countSpec),
+		},
+		{
+			Name:   "calendar months",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.CountKind}},
+			Before: simple(monthWindow, universe.CountKind, countSpec),
+			After:  simple(monthWindow, universe.CountKind, countSpec),
+		},
+		{
+			Name:   "other column",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.CountKind}},
+			Before: simple(window(time.Minute, time.Minute), universe.CountKind, otherCountSpec),
+			After:  simple(window(time.Minute, time.Minute), universe.CountKind, otherCountSpec),
+		},
+		{
+			Name: "aggregate window",
+			// ReadRange -> window -> count -> duplicate -> window(every: inf)  =>  ReadWindowAggregateByTime
+			Rules: []plan.Rule{
+				influxdb.PushDownWindowAggregateRule{Kind: universe.CountKind},
+				influxdb.PushDownWindowAggregateByTimeRule{},
+			},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadRange", &readRange),
+					plan.CreatePhysicalNode("window", window(time.Minute, time.Minute)),
+					plan.CreatePhysicalNode("count", countSpec),
+					plan.CreatePhysicalNode("duplicate", &universe.SchemaMutationProcedureSpec{
+						Mutations: []universe.SchemaMutation{
+							&universe.DuplicateOpSpec{Column: execute.DefaultStopColLabel, As: execute.DefaultTimeColLabel},
+						},
+					}),
+					plan.CreatePhysicalNode("window2", window(math.MaxInt64, math.MaxInt64)),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+					{2, 3},
+					{3, 4},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.Node{
+					plan.CreatePhysicalNode("ReadWindowAggregateByTime", func() *influxdb.ReadWindowAggregatePhysSpec {
+						spec := readWindowAggregate(universe.CountKind)
+						spec.TimeColumn = execute.DefaultStopColLabel
+						return spec
+					}()),
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tsdb/cursors"
@@ -77,6 +78,16 @@ type ReadGroupSpec struct {
 	AggregateMethod string
 }
 
+type ReadWindowAggregateSpec struct {
+	ReadFilterSpec
+
+	WindowEvery int64
+	Offset      int64
+	Aggregates  []plan.ProcedureKind
+	CreateEmpty bool
+	TimeColumn  string
+}
+
 type ReadTagKeysSpec struct {
 	ReadFilterSpec
 }
@@ -89,6 +100,7 @@ type ReadTagValuesSpec struct {
 type Reader interface {
 	ReadFilter(ctx context.Context, spec ReadFilterSpec, alloc *memory.Allocator) (TableIterator, error)
 	ReadGroup(ctx context.Context, spec ReadGroupSpec, alloc *memory.Allocator) (TableIterator, error)
+	ReadWindowAggregate(ctx context.Context, spec ReadWindowAggregateSpec, alloc *memory.Allocator) (TableIterator, error)
 
 	ReadTagKeys(ctx context.Context, spec ReadTagKeysSpec, alloc *memory.Allocator) (TableIterator, error)
 	ReadTagValues(ctx context.Context, spec ReadTagValuesSpec, alloc *memory.Allocator) (TableIterator, error)
@@ -0,0 +1,5 @@
+module github.com/influxdata/influxql
+
+go 1.18
+
+require github.com/gogo/protobuf v1.3.2
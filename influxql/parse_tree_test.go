@@ -0,0 +1,55 @@
+package influxql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxql"
+)
+
+func TestParseTree_Clone(t *testing.T) {
+	// Clone the default language parse tree and add a new syntax node.
+	language := influxql.Language.Clone()
+	language.Group(influxql.CREATE).Handle(influxql.STATS, func(p *influxql.Parser) (influxql.Statement, error) {
+		return &influxql.ShowStatsStatement{}, nil
+	})
+
+	// Create a parser with CREATE STATS and parse the statement.
+	parser := influxql.NewParser(strings.NewReader(`CREATE STATS`))
+	stmt, err := language.Parse(parser)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(stmt, &influxql.ShowStatsStatement{}) {
+		t.Fatalf("unexpected statement returned from parser: %s", stmt)
+	}
+
+	// Recreate the parser and try parsing with the original parsing. This should fail.
+	parser = influxql.NewParser(strings.NewReader(`CREATE STATS`))
+	if _, err := parser.ParseStatement(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseTree_HandleIdent(t *testing.T) {
+	// Add a statement introduced by an identifier to a clone of the language.
+	language := influxql.Language.Clone()
+	language.Group(influxql.CREATE).HandleIdent("summary", func(p *influxql.Parser) (influxql.Statement, error) {
+		return &influxql.ShowStatsStatement{}, nil
+	})
+
+	// The identifier matches case-insensitively.
+	parser := influxql.NewParser(strings.NewReader(`CREATE Summary`))
+	stmt, err := language.Parse(parser)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(stmt, &influxql.ShowStatsStatement{}) {
+		t.Fatalf("unexpected statement returned from parser: %s", stmt)
+	}
+
+	// Other identifiers report the word among the expected tokens.
+	parser = influxql.NewParser(strings.NewReader(`CREATE foo`))
+	if _, err := language.Parse(parser); err == nil || !strings.Contains(err.Error(), "SUMMARY") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -13,7 +13,9 @@ type MetaClientMock struct {
 	CreateContinuousQueryFn             func(database, name, query string) error
 	CreateDatabaseFn                    func(name string) (*meta.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicyFn func(name string, spec *meta.RetentionPolicySpec) (*meta.DatabaseInfo, error)
+	CreateMeasurementSchemaFn           func(database string, schema *meta.MeasurementSchemaInfo) error
 	CreateRetentionPolicyFn             func(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	CreateRollupRuleFn                  func(database, rp, name string, after, interval time.Duration) error
 	CreateShardGroupFn                  func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error)
 	CreateSubscriptionFn                func(database, rp, name, mode string, destinations []string) error
 	CreateUserFn                        func(name, password string, admin bool) (meta.User, error)
@@ -26,14 +28,16 @@ type MetaClientMock struct {
 	DeleteDataNodeFn func(id uint64) error
 	DeleteMetaNodeFn func(id uint64) error
 
-	DataFn                func() meta.Data
-	DeleteShardGroupFn    func(database string, policy string, id uint64) error
-	DropContinuousQueryFn func(database, name string) error
-	DropDatabaseFn        func(name string) error
-	DropRetentionPolicyFn func(database, name string) error
-	DropSubscriptionFn    func(database, rp, name string) error
-	DropShardFn           func(id uint64) error
-	DropUserFn            func(name string) error
+	DataFn                  func() meta.Data
+	DeleteShardGroupFn      func(database string, policy string, id uint64) error
+	DropContinuousQueryFn   func(database, name string) error
+	DropDatabaseFn          func(name string) error
+	DropMeasurementSchemaFn func(database, name string) error
+	DropRetentionPolicyFn   func(database, name string) error
+	DropRollupRuleFn        func(database, rp, name string) error
+	DropSubscriptionFn      func(database, rp, name string) error
+	DropShardFn             func(id uint64) error
+	DropUserFn              func(name string) error
 
 	MetaNodesFn func() []meta.NodeInfo
 	NodeIDFn    func() uint64
@@ -47,20 +51,24 @@ type MetaClientMock struct {
 
 	RetentionPolicyFn func(database, name string) (rpi *meta.RetentionPolicyInfo, err error)
 
-	AuthenticateFn           func(username, password string) (ui meta.User, err error)
-	AdminUserExistsFn        func() bool
-	SetAdminPrivilegeFn      func(username string, admin bool) error
-	SetDataFn                func(*meta.Data) error
-	SetPrivilegeFn           func(username, database string, p influxql.Privilege) error
-	ShardGroupsByTimeRangeFn func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
-	ShardOwnerFn             func(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
-	TruncateShardGroupsFn    func(t time.Time) error
-	UpdateRetentionPolicyFn  func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
-	UpdateUserFn             func(name, password string) error
-	UserPrivilegeFn          func(username, database string) (*influxql.Privilege, error)
-	UserPrivilegesFn         func(username string) (map[string]influxql.Privilege, error)
-	UserFn                   func(username string) (meta.User, error)
-	UsersFn                  func() []meta.UserInfo
+	AuthenticateFn            func(username, password string) (ui meta.User, err error)
+	AdminUserExistsFn         func() bool
+	SetAdminPrivilegeFn       func(username string, admin bool) error
+	SetDataFn                 func(*meta.Data) error
+	SetPrivilegeFn            func(username, database string, p influxql.Privilege) error
+	SetReshardCopiedFn        func(database, rp string, id, nodeID uint64) error
+	SetShardLeaderFn          func(id, nodeID, prev uint64) error
+	SetShardOwnerQuarantineFn func(id, nodeID uint64, quarantined bool) error
+	SetShardOwnerTierFn       func(id, nodeID uint64, tier string) error
+	ShardGroupsByTimeRangeFn  func(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error)
+	ShardOwnerFn              func(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
+	TruncateShardGroupsFn     func(t time.Time) error
+	UpdateRetentionPolicyFn   func(database, name string, rpu *meta.RetentionPolicyUpdate, makeDefault bool) error
+	UpdateUserFn              func(name, password string) error
+	UserPrivilegeFn           func(username, database string) (*influxql.Privilege, error)
+	UserPrivilegesFn          func(username string) (map[string]influxql.Privilege, error)
+	UserFn                    func(username string) (meta.User, error)
+	UsersFn                   func() []meta.UserInfo
 }
 
 func (c *MetaClientMock) Close() error {
@@ -79,10 +87,18 @@ func (c *MetaClientMock) CreateDatabaseWithRetentionPolicy(name string, spec *me
 	return c.CreateDatabaseWithRetentionPolicyFn(name, spec)
 }
 
+func (c *MetaClientMock) CreateMeasurementSchema(database string, schema *meta.MeasurementSchemaInfo) error {
+	return c.CreateMeasurementSchemaFn(database, schema)
+}
+
 func (c *MetaClientMock) CreateRetentionPolicy(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error) {
 	return c.CreateRetentionPolicyFn(database, spec, makeDefault)
 }
 
+func (c *MetaClientMock) CreateRollupRule(database, rp, name string, after, interval time.Duration) error {
+	return c.CreateRollupRuleFn(database, rp, name, after, interval)
+}
+
 func (c *MetaClientMock) CreateShardGroup(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
 	return c.CreateShardGroupFn(database, policy, timestamp)
 }
@@ -131,10 +147,18 @@ func (c *MetaClientMock) DropDatabase(name string) error {
 	return c.DropDatabaseFn(name)
 }
 
+func (c *MetaClientMock) DropMeasurementSchema(database, name string) error {
+	return c.DropMeasurementSchemaFn(database, name)
+}
+
 func (c *MetaClientMock) DropRetentionPolicy(database, name string) error {
 	return c.DropRetentionPolicyFn(database, name)
 }
 
+func (c *MetaClientMock) DropRollupRule(database, rp, name string) error {
+	return c.DropRollupRuleFn(database, rp, name)
+}
+
 func (c *MetaClientMock) DropShard(id uint64) error {
 	return c.DropShardFn(id)
 }
@@ -171,6 +195,22 @@ func (c *MetaClientMock) SetPrivilege(username, database string, p influxql.Priv
 	return c.SetPrivilegeFn(username, database, p)
 }
 
+func (c *MetaClientMock) SetReshardCopied(database, rp string, id, nodeID uint64) error {
+	return c.SetReshardCopiedFn(database, rp, id, nodeID)
+}
+
+func (c *MetaClientMock) SetShardLeader(id, nodeID, prev uint64) error {
+	return c.SetShardLeaderFn(id, nodeID, prev)
+}
+
+func (c *MetaClientMock) SetShardOwnerQuarantine(id, nodeID uint64, quarantined bool) error {
+	return c.SetShardOwnerQuarantineFn(id, nodeID, quarantined)
+}
+
+func (c *MetaClientMock) SetShardOwnerTier(id, nodeID uint64, tier string) error {
+	return c.SetShardOwnerTierFn(id, nodeID, tier)
+}
+
 func (c *MetaClientMock) ShardGroupsByTimeRange(database, policy string, min, max time.Time) (a []meta.ShardGroupInfo, err error) {
 	return c.ShardGroupsByTimeRangeFn(database, policy, min, max)
 }
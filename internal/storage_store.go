@@ -19,8 +19,9 @@ import (
 // It's currently a partial implementation as one of a store's exported methods
 // returns an unexported type.
 type StorageStoreMock struct {
-	ReadFilterFn func(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error)
-	ReadGroupFn  func(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error)
+	ReadFilterFn          func(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error)
+	ReadGroupFn           func(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error)
+	ReadWindowAggregateFn func(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error)
 
 	TagKeysFn    func(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error)
 	TagValuesFn  func(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error)
@@ -44,6 +45,9 @@ func NewStorageStoreMock() *StorageStoreMock {
 	store.ReadGroupFn = func(context.Context, *datatypes.ReadGroupRequest) (reads.GroupResultSet, error) {
 		return nil, errors.New("implement me")
 	}
+	store.ReadWindowAggregateFn = func(context.Context, *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+		return store.ResultSet, nil
+	}
 	store.TagKeysFn = func(context.Context, *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
 		return nil, errors.New("implement me")
 	}
@@ -61,6 +65,10 @@ func (s *StorageStoreMock) ReadGroup(ctx context.Context, req *datatypes.ReadGro
 	return s.ReadGroupFn(ctx, req)
 }
 
+func (s *StorageStoreMock) ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	return s.ReadWindowAggregateFn(ctx, req)
+}
+
 func (s *StorageStoreMock) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
 	return s.TagKeysFn(ctx, req)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/estimator"
+	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxql"
@@ -15,10 +16,15 @@ import (
 
 // TSDBStoreMock is a mockable implementation of tsdb.Store.
 type TSDBStoreMock struct {
+	ApplyWALFn                func(shardID, leaderID uint64, start, next tsdb.WALPosition, lagBytes int64, b []byte) (tsdb.WALPosition, error)
 	BackupShardFn             func(id uint64, since time.Time, w io.Writer) error
+	BackupShardSnapshotFn     func(snapshotID, shardID uint64, since time.Time, w io.Writer) error
 	BackupSeriesFileFn        func(database string, w io.Writer) error
+	CardinalityReportFn       func(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error)
 	ExportShardFn             func(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error
 	CloseFn                   func() error
+	CollectSeriesFn           func(ctx context.Context, database string) (tsdb.SeriesGCStats, error)
+	CreateDatabaseSnapshotFn  func(database string) (*tsdb.DatabaseSnapshot, error)
 	CreateShardFn             func(database, policy string, shardID uint64, enabled bool) error
 	CreateShardSnapshotFn     func(id uint64) (string, error)
 	DatabasesFn               func() []string
@@ -26,19 +32,28 @@ type TSDBStoreMock struct {
 	DeleteMeasurementFn       func(database, name string) error
 	DeleteRetentionPolicyFn   func(database, name string) error
 	DeleteSeriesFn            func(database string, sources []influxql.Source, condition influxql.Expr) error
+	DeleteFieldsFn            func(database string, sources []influxql.Source, fields []string, condition influxql.Expr) error
 	DeleteShardFn             func(id uint64) error
 	DiskSizeFn                func() (int64, error)
+	DiskUsageFn               func(ctx context.Context, database string) (*tsdb.DiskUsage, error)
 	ExpandSourcesFn           func(sources influxql.Sources) (influxql.Sources, error)
 	ImportShardFn             func(id uint64, r io.Reader) error
 	MeasurementSeriesCountsFn func(database string) (measuments int, series int)
+	MergeShardFn              func(id uint64, basePath string, r io.Reader) error
 	MeasurementsCardinalityFn func(database string) (int64, error)
 	MeasurementsSketchesFn    func(ctx context.Context, database string) (estimator.Sketch, estimator.Sketch, error)
 	MeasurementNamesFn        func(auth query.FineAuthorizer, database string, retentionPolicy string, cond influxql.Expr) ([][]byte, error)
+	MoveShardFn               func(ctx context.Context, id uint64, tier string, rate limiter.Rate) error
+	OffloadShardFn            func(ctx context.Context, id uint64) error
 	OpenFn                    func() error
 	PathFn                    func() string
+	RebuildShardIndexFn       func(ctx context.Context, id uint64) error
+	ReleaseDatabaseSnapshotFn func(id uint64) error
 	RestoreShardFn            func(id uint64, r io.Reader) error
+	RollupShardFn             func(ctx context.Context, id uint64, interval time.Duration) error
 	SeriesCardinalityFn       func(database string) (int64, error)
 	SeriesSketchesFn          func(ctx context.Context, database string) (estimator.Sketch, estimator.Sketch, error)
+	SetDiskQuotaExceededFn    func(errs []tsdb.DiskQuotaExceededError)
 	SetShardEnabledFn         func(shardID uint64, enabled bool) error
 	ShardFn                   func(id uint64) *tsdb.Shard
 	ShardGroupFn              func(ids []uint64) tsdb.ShardGroup
@@ -53,19 +68,34 @@ type TSDBStoreMock struct {
 	WriteToShardFn            func(shardID uint64, points []models.Point) error
 }
 
+func (s *TSDBStoreMock) ApplyWAL(shardID, leaderID uint64, start, next tsdb.WALPosition, lagBytes int64, b []byte) (tsdb.WALPosition, error) {
+	return s.ApplyWALFn(shardID, leaderID, start, next, lagBytes, b)
+}
 func (s *TSDBStoreMock) BackupShard(id uint64, since time.Time, w io.Writer) error {
 	return s.BackupShardFn(id, since, w)
 }
+func (s *TSDBStoreMock) BackupShardSnapshot(snapshotID, shardID uint64, since time.Time, w io.Writer) error {
+	return s.BackupShardSnapshotFn(snapshotID, shardID, since, w)
+}
 func (s *TSDBStoreMock) BackupSeriesFile(database string, w io.Writer) error {
 	return s.BackupSeriesFileFn(database, w)
 }
 func (s *TSDBStoreMock) ExportShard(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error {
 	return s.ExportShardFn(id, ExportStart, ExportEnd, w)
 }
+func (s *TSDBStoreMock) CardinalityReport(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error) {
+	return s.CardinalityReportFn(ctx, database, n)
+}
 func (s *TSDBStoreMock) Close() error { return s.CloseFn() }
+func (s *TSDBStoreMock) CollectSeries(ctx context.Context, database string) (tsdb.SeriesGCStats, error) {
+	return s.CollectSeriesFn(ctx, database)
+}
 func (s *TSDBStoreMock) CreateShard(database string, retentionPolicy string, shardID uint64, enabled bool) error {
 	return s.CreateShardFn(database, retentionPolicy, shardID, enabled)
 }
+func (s *TSDBStoreMock) CreateDatabaseSnapshot(database string) (*tsdb.DatabaseSnapshot, error) {
+	return s.CreateDatabaseSnapshotFn(database)
+}
 func (s *TSDBStoreMock) CreateShardSnapshot(id uint64) (string, error) {
 	return s.CreateShardSnapshotFn(id)
 }
@@ -84,12 +114,18 @@ func (s *TSDBStoreMock) DeleteRetentionPolicy(database string, name string) erro
 func (s *TSDBStoreMock) DeleteSeries(database string, sources []influxql.Source, condition influxql.Expr) error {
 	return s.DeleteSeriesFn(database, sources, condition)
 }
+func (s *TSDBStoreMock) DeleteFields(database string, sources []influxql.Source, fields []string, condition influxql.Expr) error {
+	return s.DeleteFieldsFn(database, sources, fields, condition)
+}
 func (s *TSDBStoreMock) DeleteShard(shardID uint64) error {
 	return s.DeleteShardFn(shardID)
 }
 func (s *TSDBStoreMock) DiskSize() (int64, error) {
 	return s.DiskSizeFn()
 }
+func (s *TSDBStoreMock) DiskUsage(ctx context.Context, database string) (*tsdb.DiskUsage, error) {
+	return s.DiskUsageFn(ctx, database)
+}
 func (s *TSDBStoreMock) ExpandSources(sources influxql.Sources) (influxql.Sources, error) {
 	return s.ExpandSourcesFn(sources)
 }
@@ -99,6 +135,9 @@ func (s *TSDBStoreMock) ImportShard(id uint64, r io.Reader) error {
 func (s *TSDBStoreMock) MeasurementNames(ctx context.Context, auth query.FineAuthorizer, database string, retentionPolicy string, cond influxql.Expr) ([][]byte, error) {
 	return s.MeasurementNamesFn(auth, database, retentionPolicy, cond)
 }
+func (s *TSDBStoreMock) MergeShard(id uint64, basePath string, r io.Reader) error {
+	return s.MergeShardFn(id, basePath, r)
+}
 func (s *TSDBStoreMock) MeasurementSeriesCounts(database string) (measuments int, series int) {
 	return s.MeasurementSeriesCountsFn(database)
 }
@@ -108,21 +147,39 @@ func (s *TSDBStoreMock) MeasurementsCardinality(ctx context.Context, database st
 func (s *TSDBStoreMock) MeasurementsSketches(ctx context.Context, database string) (estimator.Sketch, estimator.Sketch, error) {
 	return s.MeasurementsSketchesFn(ctx, database)
 }
+func (s *TSDBStoreMock) MoveShard(ctx context.Context, id uint64, tier string, rate limiter.Rate) error {
+	return s.MoveShardFn(ctx, id, tier, rate)
+}
+func (s *TSDBStoreMock) OffloadShard(ctx context.Context, id uint64) error {
+	return s.OffloadShardFn(ctx, id)
+}
 func (s *TSDBStoreMock) Open() error {
 	return s.OpenFn()
 }
 func (s *TSDBStoreMock) Path() string {
 	return s.PathFn()
 }
+func (s *TSDBStoreMock) RebuildShardIndex(ctx context.Context, id uint64) error {
+	return s.RebuildShardIndexFn(ctx, id)
+}
+func (s *TSDBStoreMock) ReleaseDatabaseSnapshot(id uint64) error {
+	return s.ReleaseDatabaseSnapshotFn(id)
+}
 func (s *TSDBStoreMock) RestoreShard(id uint64, r io.Reader) error {
 	return s.RestoreShardFn(id, r)
 }
+func (s *TSDBStoreMock) RollupShard(ctx context.Context, id uint64, interval time.Duration) error {
+	return s.RollupShardFn(ctx, id, interval)
+}
 func (s *TSDBStoreMock) SeriesCardinality(ctx context.Context, database string) (int64, error) {
 	return s.SeriesCardinalityFn(database)
 }
 func (s *TSDBStoreMock) SeriesSketches(ctx context.Context, database string) (estimator.Sketch, estimator.Sketch, error) {
 	return s.SeriesSketchesFn(ctx, database)
 }
+func (s *TSDBStoreMock) SetDiskQuotaExceeded(errs []tsdb.DiskQuotaExceededError) {
+	s.SetDiskQuotaExceededFn(errs)
+}
 func (s *TSDBStoreMock) SetShardEnabled(shardID uint64, enabled bool) error {
 	return s.SetShardEnabledFn(shardID, enabled)
 }
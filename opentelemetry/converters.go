@@ -0,0 +1,372 @@
+// Package opentelemetry converts OpenTelemetry (OTLP) metrics to points.
+//
+// Each data point of a metric is written as a point of the measurement named
+// after the metric, tagged with the attributes of the data point and the
+// promoted attributes of its resource. Values are always float fields:
+//
+//	gauge                  gauge
+//	monotonic sum          counter
+//	non-monotonic sum      gauge
+//	histogram              count, sum, min, max and one field per bucket
+//	exponential histogram  count, sum, min, max and one field per bucket
+//	summary                count, sum and one field per quantile
+//
+// Histogram bucket fields are named after their upper bound, with "+Inf"
+// for the last bucket, and hold the cumulative count of observations less
+// than or equal to it, as Prometheus "le" buckets do. Buckets of exponential
+// histograms are converted the same way, from the most negative bucket
+// through the zero bucket to the positive buckets. Summary quantile fields
+// are named after the quantile.
+//
+// Temporality is not recorded: delta sums and histograms are written as
+// they are received. Exemplars are decoded but not written.
+package opentelemetry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/opentelemetry/otlp"
+)
+
+const (
+	// gaugeField is the field of gauges and non-monotonic sums.
+	gaugeField = "gauge"
+
+	// counterField is the field of monotonic sums.
+	counterField = "counter"
+
+	countField = "count"
+	sumField   = "sum"
+	minField   = "min"
+	maxField   = "max"
+
+	// infBucket is the field of the last bucket of a histogram.
+	infBucket = "+Inf"
+)
+
+// DefaultPromotedResourceAttributes are the resource attributes written as
+// tags by default. They identify the service which produced a metric.
+var DefaultPromotedResourceAttributes = []string{
+	"service.name",
+	"service.namespace",
+	"service.instance.id",
+}
+
+// A RejectedPointsError is returned when some data points of a request can't
+// be written. The other data points are still converted.
+type RejectedPointsError struct {
+	Rejected int64
+	Reason   string
+}
+
+// Error returns a descriptive error of the data points rejected.
+func (e RejectedPointsError) Error() string {
+	return fmt.Sprintf("rejected %d OTLP data points: %s", e.Rejected, e.Reason)
+}
+
+// MetricsRequestToPoints converts the data points of an OTLP metrics export
+// request into points. The resource attributes listed in promote are added
+// as tags to every point of the resource.
+//
+// Data points without a value, with an unsupported float value or of a
+// metric without a name are rejected, and a RejectedPointsError is returned
+// along with the other points.
+func MetricsRequestToPoints(req *otlp.ExportMetricsServiceRequest, promote []string, now time.Time) ([]models.Point, error) {
+	c := converter{now: now}
+	for _, rm := range req.ResourceMetrics {
+		resourceTags := make(map[string]string, len(promote))
+		for _, kv := range rm.GetResource().GetAttributes() {
+			if containsString(promote, kv.Key) {
+				if v := attributeString(kv.Value); v != "" {
+					resourceTags[kv.Key] = v
+				}
+			}
+		}
+
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				c.metric(m, resourceTags)
+			}
+		}
+	}
+
+	if c.rejected > 0 {
+		return c.points, RejectedPointsError{Rejected: c.rejected, Reason: c.reason}
+	}
+	return c.points, nil
+}
+
+type converter struct {
+	now      time.Time
+	points   []models.Point
+	rejected int64
+	reason   string
+}
+
+// reject records that n data points were rejected. The first reason is kept.
+func (c *converter) reject(n int, reason string) {
+	c.rejected += int64(n)
+	if c.reason == "" {
+		c.reason = reason
+	}
+}
+
+func (c *converter) metric(m *otlp.Metric, resourceTags map[string]string) {
+	if m.Name == "" {
+		c.reject(dataPointCount(m), "metric name is required")
+		return
+	}
+
+	switch data := m.Data.(type) {
+	case *otlp.Metric_Gauge:
+		for _, dp := range data.Gauge.DataPoints {
+			c.number(m.Name, gaugeField, dp, resourceTags)
+		}
+	case *otlp.Metric_Sum:
+		field := gaugeField
+		if data.Sum.IsMonotonic {
+			field = counterField
+		}
+		for _, dp := range data.Sum.DataPoints {
+			c.number(m.Name, field, dp, resourceTags)
+		}
+	case *otlp.Metric_Histogram:
+		for _, dp := range data.Histogram.DataPoints {
+			c.histogram(m.Name, dp, resourceTags)
+		}
+	case *otlp.Metric_ExponentialHistogram:
+		for _, dp := range data.ExponentialHistogram.DataPoints {
+			c.exponentialHistogram(m.Name, dp, resourceTags)
+		}
+	case *otlp.Metric_Summary:
+		for _, dp := range data.Summary.DataPoints {
+			c.summary(m.Name, dp, resourceTags)
+		}
+	}
+}
+
+func (c *converter) number(name, field string, dp *otlp.NumberDataPoint, resourceTags map[string]string) {
+	if noRecordedValue(dp.Flags) {
+		return
+	}
+
+	var v float64
+	switch value := dp.Value.(type) {
+	case *otlp.NumberDataPoint_AsDouble:
+		v = value.AsDouble
+	case *otlp.NumberDataPoint_AsInt:
+		v = float64(value.AsInt)
+	default:
+		c.reject(1, "data point has no value")
+		return
+	}
+	c.add(name, dp.Attributes, resourceTags, map[string]interface{}{field: v}, dp.TimeUnixNano)
+}
+
+func (c *converter) histogram(name string, dp *otlp.HistogramDataPoint, resourceTags map[string]string) {
+	if noRecordedValue(dp.Flags) {
+		return
+	}
+	if len(dp.BucketCounts) > 0 && len(dp.BucketCounts) != len(dp.ExplicitBounds)+1 {
+		c.reject(1, "histogram bucket counts don't match its bounds")
+		return
+	}
+
+	fields := map[string]interface{}{countField: float64(dp.Count)}
+	if s, ok := dp.SumValue.(*otlp.HistogramDataPoint_Sum); ok {
+		fields[sumField] = s.Sum
+	}
+	if m, ok := dp.MinValue.(*otlp.HistogramDataPoint_Min); ok {
+		fields[minField] = m.Min
+	}
+	if m, ok := dp.MaxValue.(*otlp.HistogramDataPoint_Max); ok {
+		fields[maxField] = m.Max
+	}
+
+	var cumulative uint64
+	for i, n := range dp.BucketCounts {
+		cumulative += n
+		bucket := infBucket
+		if i < len(dp.ExplicitBounds) {
+			bucket = formatFloat(dp.ExplicitBounds[i])
+		}
+		fields[bucket] = float64(cumulative)
+	}
+	c.add(name, dp.Attributes, resourceTags, fields, dp.TimeUnixNano)
+}
+
+func (c *converter) exponentialHistogram(name string, dp *otlp.ExponentialHistogramDataPoint, resourceTags map[string]string) {
+	if noRecordedValue(dp.Flags) {
+		return
+	}
+
+	fields := map[string]interface{}{countField: float64(dp.Count)}
+	if s, ok := dp.SumValue.(*otlp.ExponentialHistogramDataPoint_Sum); ok {
+		fields[sumField] = s.Sum
+	}
+	if m, ok := dp.MinValue.(*otlp.ExponentialHistogramDataPoint_Min); ok {
+		fields[minField] = m.Min
+	}
+	if m, ok := dp.MaxValue.(*otlp.ExponentialHistogramDataPoint_Max); ok {
+		fields[maxField] = m.Max
+	}
+
+	// The bucket at index i covers (base^i, base^(i+1)], or the negation
+	// of that range for negative buckets.
+	base := math.Pow(2, math.Pow(2, -float64(dp.Scale)))
+	var cumulative uint64
+	if neg := dp.Negative; neg != nil {
+		for i := len(neg.BucketCounts) - 1; i >= 0; i-- {
+			cumulative += neg.BucketCounts[i]
+			upper := -math.Pow(base, float64(int(neg.Offset)+i))
+			fields[formatFloat(upper)] = float64(cumulative)
+		}
+	}
+	cumulative += dp.ZeroCount
+	fields[formatFloat(dp.ZeroThreshold)] = float64(cumulative)
+	if pos := dp.Positive; pos != nil {
+		for i, n := range pos.BucketCounts {
+			cumulative += n
+			upper := math.Pow(base, float64(int(pos.Offset)+i+1))
+			fields[formatFloat(upper)] = float64(cumulative)
+		}
+	}
+	fields[infBucket] = float64(dp.Count)
+	c.add(name, dp.Attributes, resourceTags, fields, dp.TimeUnixNano)
+}
+
+func (c *converter) summary(name string, dp *otlp.SummaryDataPoint, resourceTags map[string]string) {
+	if noRecordedValue(dp.Flags) {
+		return
+	}
+
+	fields := map[string]interface{}{
+		countField: float64(dp.Count),
+		sumField:   dp.Sum,
+	}
+	for _, q := range dp.QuantileValues {
+		fields[formatFloat(q.Quantile)] = q.Value
+	}
+	c.add(name, dp.Attributes, resourceTags, fields, dp.TimeUnixNano)
+}
+
+// add adds a point for a data point, unless one of its values can't be
+// stored.
+func (c *converter) add(name string, attributes []*otlp.KeyValue, resourceTags map[string]string, fields map[string]interface{}, ts uint64) {
+	for _, v := range fields {
+		if f := v.(float64); math.IsNaN(f) || math.IsInf(f, 0) {
+			c.reject(1, "NaN and infinite values are not supported")
+			return
+		}
+	}
+
+	tags := make(map[string]string, len(attributes)+len(resourceTags))
+	for k, v := range resourceTags {
+		tags[k] = v
+	}
+	for _, kv := range attributes {
+		if v := attributeString(kv.Value); v != "" {
+			tags[kv.Key] = v
+		}
+	}
+
+	t := c.now
+	if ts != 0 {
+		t = time.Unix(0, int64(ts))
+	}
+
+	p, err := models.NewPoint(name, models.NewTags(tags), fields, t)
+	if err != nil {
+		c.reject(1, err.Error())
+		return
+	}
+	c.points = append(c.points, p)
+}
+
+// noRecordedValue reports whether flags mark a data point without a value,
+// such as one which marks the end of a series.
+func noRecordedValue(flags uint32) bool {
+	return flags&uint32(otlp.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK) != 0
+}
+
+// dataPointCount returns the number of data points of m.
+func dataPointCount(m *otlp.Metric) int {
+	switch data := m.Data.(type) {
+	case *otlp.Metric_Gauge:
+		return len(data.Gauge.DataPoints)
+	case *otlp.Metric_Sum:
+		return len(data.Sum.DataPoints)
+	case *otlp.Metric_Histogram:
+		return len(data.Histogram.DataPoints)
+	case *otlp.Metric_ExponentialHistogram:
+		return len(data.ExponentialHistogram.DataPoints)
+	case *otlp.Metric_Summary:
+		return len(data.Summary.DataPoints)
+	}
+	return 0
+}
+
+// attributeString returns the value of an attribute as a tag value. Arrays
+// and maps are encoded as JSON and bytes as base64.
+func attributeString(v *otlp.AnyValue) string {
+	switch v := v.GetValue().(type) {
+	case *otlp.AnyValue_StringValue:
+		return v.StringValue
+	case *otlp.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *otlp.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *otlp.AnyValue_DoubleValue:
+		return formatFloat(v.DoubleValue)
+	case *otlp.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	case *otlp.AnyValue_ArrayValue, *otlp.AnyValue_KvlistValue:
+		b, _ := json.Marshal(attributeValue(&otlp.AnyValue{Value: v}))
+		return string(b)
+	}
+	return ""
+}
+
+// attributeValue returns the Go value of an attribute.
+func attributeValue(v *otlp.AnyValue) interface{} {
+	switch v := v.GetValue().(type) {
+	case *otlp.AnyValue_ArrayValue:
+		a := make([]interface{}, 0, len(v.ArrayValue.GetValues()))
+		for _, e := range v.ArrayValue.GetValues() {
+			a = append(a, attributeValue(e))
+		}
+		return a
+	case *otlp.AnyValue_KvlistValue:
+		m := make(map[string]interface{}, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			m[kv.Key] = attributeValue(kv.Value)
+		}
+		return m
+	case *otlp.AnyValue_BoolValue:
+		return v.BoolValue
+	case *otlp.AnyValue_IntValue:
+		return v.IntValue
+	case *otlp.AnyValue_DoubleValue:
+		return v.DoubleValue
+	}
+	return attributeString(v)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func containsString(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
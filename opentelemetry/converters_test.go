@@ -0,0 +1,129 @@
+package opentelemetry_test
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/opentelemetry"
+	"github.com/influxdata/influxdb/opentelemetry/otlp"
+)
+
+func TestMetricsRequestToPoints(t *testing.T) {
+	ts := uint64(time.Unix(10, 0).UnixNano())
+	attrs := []*otlp.KeyValue{
+		{Key: "host", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "a"}}},
+		{Key: "cpu", Value: &otlp.AnyValue{Value: &otlp.AnyValue_IntValue{IntValue: 1}}},
+	}
+
+	req := &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlp.ResourceMetrics{{
+			Resource: &otlp.Resource{Attributes: []*otlp.KeyValue{
+				{Key: "service.name", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "api"}}},
+				{Key: "os.type", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "linux"}}},
+			}},
+			ScopeMetrics: []*otlp.ScopeMetrics{{
+				Metrics: []*otlp.Metric{
+					{
+						Name: "temperature",
+						Data: &otlp.Metric_Gauge{Gauge: &otlp.Gauge{DataPoints: []*otlp.NumberDataPoint{
+							{Attributes: attrs, TimeUnixNano: ts, Value: &otlp.NumberDataPoint_AsDouble{AsDouble: 21.5}},
+						}}},
+					},
+					{
+						Name: "requests",
+						Data: &otlp.Metric_Sum{Sum: &otlp.Sum{IsMonotonic: true, DataPoints: []*otlp.NumberDataPoint{
+							{TimeUnixNano: ts, Value: &otlp.NumberDataPoint_AsInt{AsInt: 7}},
+						}}},
+					},
+					{
+						Name: "latency",
+						Data: &otlp.Metric_Histogram{Histogram: &otlp.Histogram{DataPoints: []*otlp.HistogramDataPoint{{
+							TimeUnixNano:   ts,
+							Count:          6,
+							SumValue:       &otlp.HistogramDataPoint_Sum{Sum: 9},
+							BucketCounts:   []uint64{1, 2, 3},
+							ExplicitBounds: []float64{0.5, 1},
+						}}}},
+					},
+					{
+						Name: "size",
+						Data: &otlp.Metric_ExponentialHistogram{ExponentialHistogram: &otlp.ExponentialHistogram{DataPoints: []*otlp.ExponentialHistogramDataPoint{{
+							TimeUnixNano: ts,
+							Count:        6,
+							Scale:        0,
+							ZeroCount:    1,
+							Positive:     &otlp.ExponentialHistogramDataPoint_Buckets{Offset: 1, BucketCounts: []uint64{2, 1}},
+							Negative:     &otlp.ExponentialHistogramDataPoint_Buckets{Offset: 0, BucketCounts: []uint64{2}},
+						}}}},
+					},
+					{
+						Name: "rtt",
+						Data: &otlp.Metric_Summary{Summary: &otlp.Summary{DataPoints: []*otlp.SummaryDataPoint{{
+							TimeUnixNano:   ts,
+							Count:          4,
+							Sum:            10,
+							QuantileValues: []*otlp.SummaryDataPoint_ValueAtQuantile{{Quantile: 0.5, Value: 2}},
+						}}}},
+					},
+				},
+			}},
+		}},
+	}
+
+	points, err := opentelemetry.MetricsRequestToPoints(req, opentelemetry.DefaultPromotedResourceAttributes, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, p := range points {
+		got = append(got, p.String())
+	}
+	sort.Strings(got)
+
+	exp := []string{
+		"latency,service.name=api +Inf=6,0.5=1,1=3,count=6,sum=9 10000000000",
+		"requests,service.name=api counter=7 10000000000",
+		"rtt,service.name=api 0.5=2,count=4,sum=10 10000000000",
+		"size,service.name=api +Inf=6,-1=2,0=3,4=5,8=6,count=6 10000000000",
+		"temperature,cpu=1,host=a,service.name=api gauge=21.5 10000000000",
+	}
+	if strings.Join(got, "\n") != strings.Join(exp, "\n") {
+		t.Fatalf("unexpected points:\ngot %s\nexp %s", strings.Join(got, "\n"), strings.Join(exp, "\n"))
+	}
+}
+
+func TestMetricsRequestToPoints_Rejected(t *testing.T) {
+	req := &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlp.ResourceMetrics{{
+			ScopeMetrics: []*otlp.ScopeMetrics{{
+				Metrics: []*otlp.Metric{
+					{
+						Name: "ok",
+						Data: &otlp.Metric_Gauge{Gauge: &otlp.Gauge{DataPoints: []*otlp.NumberDataPoint{
+							{Value: &otlp.NumberDataPoint_AsDouble{AsDouble: 1}},
+							{Value: &otlp.NumberDataPoint_AsDouble{AsDouble: math.NaN()}},
+							{Flags: uint32(otlp.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK)},
+						}}},
+					},
+					{
+						Data: &otlp.Metric_Gauge{Gauge: &otlp.Gauge{DataPoints: []*otlp.NumberDataPoint{
+							{Value: &otlp.NumberDataPoint_AsDouble{AsDouble: 1}},
+						}}},
+					},
+				},
+			}},
+		}},
+	}
+
+	points, err := opentelemetry.MetricsRequestToPoints(req, nil, time.Unix(0, 0))
+	if len(points) != 1 {
+		t.Fatalf("unexpected number of points: %d", len(points))
+	}
+	if e, ok := err.(opentelemetry.RejectedPointsError); !ok || e.Rejected != 2 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogo/protobuf/jsonpb"
+)
+
+// The JSON names of the exemplar fields encoded differently than those of a
+// number data point, by original and lower camel case name.
+var exemplarJSONNames = map[string]string{
+	"filtered_attributes": "filteredAttributes",
+	"span_id":             "spanId",
+	"trace_id":            "traceId",
+}
+
+// MarshalJSONPB encodes the exemplar as OTLP/JSON, whose trace and span IDs
+// are hex encoded rather than base64.
+func (m *Exemplar) MarshalJSONPB(jm *jsonpb.Marshaler) ([]byte, error) {
+	// The other fields of an exemplar are those of a number data point.
+	dp := &NumberDataPoint{Attributes: m.FilteredAttributes, TimeUnixNano: m.TimeUnixNano}
+	switch v := m.Value.(type) {
+	case *Exemplar_AsDouble:
+		dp.Value = &NumberDataPoint_AsDouble{AsDouble: v.AsDouble}
+	case *Exemplar_AsInt:
+		dp.Value = &NumberDataPoint_AsInt{AsInt: v.AsInt}
+	}
+
+	var buf bytes.Buffer
+	if err := jm.Marshal(&buf, dp); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		return nil, err
+	}
+
+	name := func(orig string) string {
+		if jm.OrigName {
+			return orig
+		}
+		return exemplarJSONNames[orig]
+	}
+	if attrs, ok := fields["attributes"]; ok {
+		delete(fields, "attributes")
+		fields[name("filtered_attributes")] = attrs
+	}
+	for orig, id := range map[string][]byte{"span_id": m.SpanId, "trace_id": m.TraceId} {
+		if len(id) > 0 || jm.EmitDefaults {
+			fields[name(orig)], _ = json.Marshal(hex.EncodeToString(id))
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSONPB decodes an exemplar of OTLP/JSON, whose trace and span IDs
+// are hex encoded rather than base64.
+func (m *Exemplar) UnmarshalJSONPB(u *jsonpb.Unmarshaler, b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	// Number data point fields that exemplars don't have.
+	for _, k := range []string{"attributes", "startTimeUnixNano", "start_time_unix_nano", "flags", "exemplars"} {
+		if _, ok := fields[k]; ok && !u.AllowUnknownFields {
+			return fmt.Errorf("unknown field %q in otlp.Exemplar", k)
+		}
+		delete(fields, k)
+	}
+
+	*m = Exemplar{}
+	for orig, camel := range exemplarJSONNames {
+		v, ok := fields[camel]
+		if !ok {
+			v, ok = fields[orig]
+		}
+		delete(fields, camel)
+		delete(fields, orig)
+		if !ok {
+			continue
+		}
+
+		if orig == "filtered_attributes" {
+			fields["attributes"] = v
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			return fmt.Errorf("invalid exemplar %s: %s", camel, err)
+		}
+		id, err := hex.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("invalid exemplar %s %q: %s", camel, s, err)
+		}
+		if orig == "span_id" {
+			m.SpanId = id
+		} else {
+			m.TraceId = id
+		}
+	}
+
+	// The other fields of an exemplar are those of a number data point.
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	var dp NumberDataPoint
+	if err := u.Unmarshal(bytes.NewReader(b), &dp); err != nil {
+		return err
+	}
+
+	m.FilteredAttributes, m.TimeUnixNano = dp.Attributes, dp.TimeUnixNano
+	switch v := dp.Value.(type) {
+	case *NumberDataPoint_AsDouble:
+		m.Value = &Exemplar_AsDouble{AsDouble: v.AsDouble}
+	case *NumberDataPoint_AsInt:
+		m.Value = &Exemplar_AsInt{AsInt: v.AsInt}
+	}
+	return nil
+}
@@ -0,0 +1,3 @@
+package otlp
+
+//go:generate protoc -I. --gogofaster_out=. metrics.proto
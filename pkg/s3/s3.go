@@ -0,0 +1,452 @@
+// Package s3 implements a minimal client for S3-compatible object storage.
+//
+// Only the object operations needed to store and retrieve immutable blobs are
+// supported. Objects of unknown size are streamed with a multipart upload.
+// Requests use path-style addressing and are signed with AWS Signature
+// Version 4 when credentials are configured.
+package s3 // import "github.com/influxdata/influxdb/pkg/s3"
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+const (
+	// DefaultRegion is the region used for signing when none is configured.
+	DefaultRegion = "us-east-1"
+
+	// DefaultPartSize is the default size of the parts of a multipart upload.
+	DefaultPartSize = 64 << 20
+
+	// MinPartSize is the smallest size S3 accepts for all but the last part
+	// of a multipart upload.
+	MinPartSize = 5 << 20
+
+	// maxParts is the most parts a multipart upload may have.
+	maxParts = 10000
+
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateFormat   = "20060102T150405Z"
+)
+
+// Config represents the configuration for connecting to a bucket.
+type Config struct {
+	Enabled         bool   `toml:"enabled"`
+	Endpoint        string `toml:"endpoint"`
+	Bucket          string `toml:"bucket"`
+	Region          string `toml:"region"`
+	Prefix          string `toml:"prefix"`
+	AccessKeyID     string `toml:"access-key-id"`
+	SecretAccessKey string `toml:"secret-access-key"`
+}
+
+// NewConfig returns an instance of Config with defaults.
+func NewConfig() Config {
+	return Config{Region: DefaultRegion}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Endpoint == "" {
+		return errors.New("s3 endpoint must be specified")
+	} else if u, err := url.Parse(c.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid s3 endpoint %q", c.Endpoint)
+	}
+	if c.Bucket == "" {
+		return errors.New("s3 bucket must be specified")
+	}
+	if (c.AccessKeyID == "") != (c.SecretAccessKey == "") {
+		return errors.New("s3 access-key-id and secret-access-key must be set together")
+	}
+	return nil
+}
+
+// Diagnostics returns a diagnostics representation of a subset of the Config.
+// Credentials are not included.
+func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
+	if !c.Enabled {
+		return diagnostics.RowFromMap(map[string]interface{}{
+			"enabled": false,
+		}), nil
+	}
+
+	return diagnostics.RowFromMap(map[string]interface{}{
+		"enabled":  true,
+		"endpoint": c.Endpoint,
+		"bucket":   c.Bucket,
+		"region":   c.Region,
+		"prefix":   c.Prefix,
+	}), nil
+}
+
+// Client reads and writes objects in a single bucket.
+type Client struct {
+	config     Config
+	endpoint   *url.URL
+	HTTPClient *http.Client
+
+	// PartSize is the size of the parts of a multipart upload. It is raised
+	// to MinPartSize if smaller. An upload of at most PartSize bytes is sent
+	// in a single request.
+	PartSize int
+}
+
+// NewClient returns a client for the bucket described by c.
+func NewClient(c Config) (*Client, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if c.Region == "" {
+		c.Region = DefaultRegion
+	}
+	return &Client{
+		config:     c,
+		endpoint:   u,
+		HTTPClient: http.DefaultClient,
+		PartSize:   DefaultPartSize,
+	}, nil
+}
+
+// Put uploads an object of the given size from r, replacing any object
+// stored under the same key.
+func (c *Client) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get returns a reader for the object's contents. The caller must close it.
+func (c *Client) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Size returns the size of the object in bytes.
+func (c *Client) Size(ctx context.Context, key string) (int64, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.ContentLength, nil
+}
+
+// Delete removes an object. Deleting an object that does not exist is not
+// an error.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Upload streams the contents of r to an object, replacing any object stored
+// under the same key, and returns its size. Objects larger than PartSize are
+// uploaded in parts, so only one part is buffered in memory at a time. A
+// failed multipart upload is aborted.
+func (c *Client) Upload(ctx context.Context, key string, r io.Reader) (int64, error) {
+	partSize := c.PartSize
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+
+	// Objects that fit in a single part are sent with a single request.
+	buf := make([]byte, partSize)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return int64(n), c.Put(ctx, key, bytes.NewReader(buf[:n]), int64(n))
+	} else if err != nil {
+		return 0, err
+	}
+
+	uploadID, err := c.createMultipartUpload(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	size, err := c.uploadParts(ctx, key, uploadID, r, buf)
+	if err != nil {
+		// Abort with a fresh context, as ctx may be the cause of the failure.
+		abortCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if abortErr := c.abortMultipartUpload(abortCtx, key, uploadID); abortErr != nil {
+			return 0, fmt.Errorf("%s (abort upload: %s)", err, abortErr)
+		}
+		return 0, err
+	}
+	return size, nil
+}
+
+// completedPart identifies an uploaded part of a multipart upload.
+type completedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// uploadParts uploads r as the parts of a multipart upload, starting with the
+// full part already read into buf, and completes the upload.
+func (c *Client) uploadParts(ctx context.Context, key, uploadID string, r io.Reader, buf []byte) (int64, error) {
+	var parts []completedPart
+	var size int64
+	for n := len(buf); ; {
+		if len(parts) == maxParts {
+			return 0, fmt.Errorf("s3 upload %s: more than %d parts of %d bytes", key, maxParts, len(buf))
+		}
+		etag, err := c.uploadPart(ctx, key, uploadID, len(parts)+1, buf[:n])
+		if err != nil {
+			return 0, err
+		}
+		parts = append(parts, completedPart{PartNumber: len(parts) + 1, ETag: etag})
+		size += int64(n)
+
+		// Only the last part may be short.
+		if n < len(buf) {
+			break
+		}
+		if n, err = io.ReadFull(r, buf); err == io.EOF {
+			break
+		} else if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+	}
+
+	if err := c.completeMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// createMultipartUpload starts a multipart upload and returns its ID.
+func (c *Client) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("s3 create upload %s: %s", key, err)
+	} else if result.UploadID == "" {
+		return "", fmt.Errorf("s3 create upload %s: missing upload id", key)
+	}
+	return result.UploadID, nil
+}
+
+// uploadPart uploads a part of a multipart upload and returns its ETag.
+func (c *Client) uploadPart(ctx context.Context, key, uploadID string, partNumber int, data []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {uploadID}}
+	req, err := c.newRequest(ctx, http.MethodPut, key, query, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// completeMultipartUpload assembles the uploaded parts into the object.
+func (c *Client) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 may report a failure with a 200 status once it has started
+	// assembling the object.
+	var result struct {
+		XMLName xml.Name
+		Message string
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("s3 complete upload %s: %s", key, err)
+	} else if result.XMLName.Local == "Error" {
+		return fmt.Errorf("s3 complete upload %s: %s", key, result.Message)
+	}
+	return nil
+}
+
+// abortMultipartUpload discards the uploaded parts of a multipart upload.
+func (c *Client) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// newRequest returns a request for the object stored under key.
+func (c *Client) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := *c.endpoint
+	u.Path = path.Join("/", u.Path, c.config.Bucket, c.config.Prefix, key)
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and sends the request, converting error responses into errors.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	c.sign(req)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	case resp.StatusCode/100 != 2:
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the request.
+// The payload is not included in the signature. Requests are left unsigned
+// if no credentials are configured.
+func (c *Client) sign(req *http.Request) {
+	now := time.Now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if c.config.AccessKeyID == "" {
+		return
+	}
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": unsignedPayload,
+		"x-amz-date":           amzDate,
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := date + "/" + c.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.config.SecretAccessKey), date)
+	key = hmacSHA256(key, c.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	h := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,174 @@
+package s3_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/influxdata/influxdb/pkg/s3"
+	"github.com/influxdata/influxdb/pkg/s3/s3test"
+)
+
+func TestClient_PutGetDelete(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+
+	c := srv.Client("bucket")
+	ctx := context.Background()
+
+	if err := c.Put(ctx, "db/rp/1.tar", strings.NewReader("hello"), 5); err != nil {
+		t.Fatal(err)
+	}
+	if keys := srv.Keys(); len(keys) != 1 || keys[0] != "bucket/db/rp/1.tar" {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+
+	if n, err := c.Size(ctx, "db/rp/1.tar"); err != nil {
+		t.Fatal(err)
+	} else if n != 5 {
+		t.Fatalf("unexpected size: %d", n)
+	}
+
+	rc, err := c.Get(ctx, "db/rp/1.tar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(b) != "hello" {
+		t.Fatalf("unexpected contents: %q", b)
+	}
+
+	if err := c.Delete(ctx, "db/rp/1.tar"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "db/rp/1.tar"); err != s3.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// Deleting a missing object is not an error.
+	if err := c.Delete(ctx, "db/rp/1.tar"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_Upload(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+
+	c := srv.Client("bucket")
+	c.PartSize = s3.MinPartSize
+	ctx := context.Background()
+
+	for _, n := range []int{0, 5, s3.MinPartSize, 2 * s3.MinPartSize, 2*s3.MinPartSize + 1} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+		if size, err := c.Upload(ctx, "db/rp/1.tar", bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		} else if size != int64(n) {
+			t.Fatalf("unexpected size: %d, exp %d", size, n)
+		}
+
+		rc, err := c.Get(ctx, "db/rp/1.tar")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(b, data) {
+			t.Fatalf("unexpected contents of %d byte upload", n)
+		}
+	}
+	if n := srv.Uploads(); n != 0 {
+		t.Fatalf("unexpected incomplete uploads: %d", n)
+	}
+}
+
+// Ensure a multipart upload is aborted if reading the object fails.
+func TestClient_Upload_Abort(t *testing.T) {
+	srv := s3test.NewServer()
+	defer srv.Close()
+
+	c := srv.Client("bucket")
+	c.PartSize = s3.MinPartSize
+
+	errRead := errors.New("read failed")
+	r := io.MultiReader(bytes.NewReader(make([]byte, s3.MinPartSize+1)), iotest.ErrReader(errRead))
+	if _, err := c.Upload(context.Background(), "db/rp/1.tar", r); !errors.Is(err, errRead) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := srv.Uploads(); n != 0 {
+		t.Fatalf("unexpected incomplete uploads: %d", n)
+	}
+	if keys := srv.Keys(); len(keys) != 0 {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+}
+
+func TestClient_Sign(t *testing.T) {
+	var auth, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("Authorization"), r.URL.Path
+	}))
+	defer srv.Close()
+
+	cfg := s3.NewConfig()
+	cfg.Endpoint = srv.URL
+	cfg.Bucket = "bucket"
+	cfg.Prefix = "influxdb"
+	cfg.AccessKeyID = "AKID"
+	cfg.SecretAccessKey = "secret"
+	c, err := s3.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Delete(context.Background(), "db/rp/1.tar"); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/bucket/influxdb/db/rp/1.tar" {
+		t.Fatalf("unexpected path: %s", path)
+	}
+	exp := regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`)
+	if !exp.MatchString(auth) {
+		t.Fatalf("unexpected authorization header: %q", auth)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := s3.NewConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from disabled config: %s", err)
+	}
+
+	c.Enabled = true
+	c.Endpoint = "http://localhost:9000"
+	c.Bucket = "bucket"
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail: %s", err)
+	}
+
+	c.AccessKeyID = "AKID"
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for access-key-id without secret-access-key, got nil")
+	}
+
+	c.AccessKeyID = ""
+	c.Endpoint = "localhost:9000"
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for endpoint without scheme, got nil")
+	}
+}
@@ -0,0 +1,175 @@
+// Package s3test provides an in-process stand-in for S3-compatible object
+// storage, for use in tests.
+package s3test // import "github.com/influxdata/influxdb/pkg/s3/s3test"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/influxdata/influxdb/pkg/s3"
+)
+
+// Server is an in-memory object store served over HTTP. Objects are keyed by
+// their full request path, including the bucket.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]*upload
+	nextID  int
+}
+
+// upload is an in-progress multipart upload.
+type upload struct {
+	key   string
+	parts map[int][]byte
+}
+
+// NewServer returns a running Server. The caller must call Close.
+func NewServer() *Server {
+	s := &Server{objects: make(map[string][]byte), uploads: make(map[string]*upload)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Config returns a client configuration for the given bucket on the server.
+func (s *Server) Config(bucket string) s3.Config {
+	c := s3.NewConfig()
+	c.Enabled = true
+	c.Endpoint = s.URL
+	c.Bucket = bucket
+	return c
+}
+
+// Client returns a client for the given bucket on the server.
+func (s *Server) Client(bucket string) *s3.Client {
+	c, err := s3.NewClient(s.Config(bucket))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Keys returns the paths of all stored objects.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Uploads returns the number of multipart uploads neither completed nor
+// aborted.
+func (s *Server) Uploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	if _, ok := query["uploads"]; ok && r.Method == http.MethodPost {
+		s.nextID++
+		id := strconv.Itoa(s.nextID)
+		s.uploads[id] = &upload{key: key, parts: make(map[int][]byte)}
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", key, id)
+		return
+	} else if id := query.Get("uploadId"); id != "" {
+		s.serveUpload(w, r, key, id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = b
+	case http.MethodGet, http.MethodHead:
+		b, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+		if r.Method == http.MethodGet {
+			w.Write(b)
+		}
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveUpload serves the requests of the multipart upload id.
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request, key, id string) {
+	u, ok := s.uploads[id]
+	if !ok || u.key != key {
+		http.Error(w, "NoSuchUpload", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		n, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+		if err != nil || n < 1 {
+			http.Error(w, "InvalidArgument", http.StatusBadRequest)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		u.parts[n] = b
+		w.Header().Set("ETag", fmt.Sprintf(`"%d-%d"`, n, len(b)))
+	case http.MethodPost:
+		var req struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var buf bytes.Buffer
+		for i, p := range req.Parts {
+			b, ok := u.parts[p.PartNumber]
+			if !ok || p.PartNumber != i+1 || p.ETag != fmt.Sprintf(`"%d-%d"`, p.PartNumber, len(b)) {
+				http.Error(w, "InvalidPart", http.StatusBadRequest)
+				return
+			}
+			buf.Write(b)
+		}
+		s.objects[key] = buf.Bytes()
+		delete(s.uploads, id)
+		fmt.Fprintf(w, "<CompleteMultipartUploadResult><Key>%s</Key></CompleteMultipartUploadResult>", key)
+	case http.MethodDelete:
+		delete(s.uploads, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,185 @@
+package prometheus
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// maxSamplesPerChunk is the number of samples Prometheus stores in each of
+// its own chunks.
+const maxSamplesPerChunk = 120
+
+// xorChunk encodes samples in the Gorilla-style XOR chunk format used by
+// the Prometheus TSDB, so that it can be decoded by chunkenc.XORChunk.
+//
+// The chunk starts with a big endian uint16 holding the number of samples,
+// followed by the bit stream of delta-of-delta encoded timestamps and XOR
+// encoded values.
+type xorChunk struct {
+	b bstream
+
+	num      uint16
+	t        int64
+	v        float64
+	tDelta   uint64
+	leading  uint8
+	trailing uint8
+}
+
+func newXORChunk() *xorChunk {
+	c := &xorChunk{leading: 0xff}
+	c.b.stream = make([]byte, 2, 128)
+	return c
+}
+
+// NumSamples returns the number of samples in the chunk.
+func (c *xorChunk) NumSamples() int { return int(c.num) }
+
+// Bytes returns the encoded chunk.
+func (c *xorChunk) Bytes() []byte {
+	binary.BigEndian.PutUint16(c.b.stream, c.num)
+	return c.b.stream
+}
+
+// Append adds a sample to the chunk. Samples must be appended in
+// increasing time order.
+func (c *xorChunk) Append(t int64, v float64) {
+	var tDelta uint64
+
+	switch c.num {
+	case 0:
+		buf := make([]byte, binary.MaxVarintLen64)
+		for _, b := range buf[:binary.PutVarint(buf, t)] {
+			c.b.writeByte(b)
+		}
+		c.b.writeBits(math.Float64bits(v), 64)
+
+	case 1:
+		tDelta = uint64(t - c.t)
+
+		buf := make([]byte, binary.MaxVarintLen64)
+		for _, b := range buf[:binary.PutUvarint(buf, tDelta)] {
+			c.b.writeByte(b)
+		}
+		c.writeVDelta(v)
+
+	default:
+		tDelta = uint64(t - c.t)
+		dod := int64(tDelta - c.tDelta)
+
+		switch {
+		case dod == 0:
+			c.b.writeBit(false)
+		case bitRange(dod, 14):
+			c.b.writeBits(0x02, 2) // '10'
+			c.b.writeBits(uint64(dod), 14)
+		case bitRange(dod, 17):
+			c.b.writeBits(0x06, 3) // '110'
+			c.b.writeBits(uint64(dod), 17)
+		case bitRange(dod, 20):
+			c.b.writeBits(0x0e, 4) // '1110'
+			c.b.writeBits(uint64(dod), 20)
+		default:
+			c.b.writeBits(0x0f, 4) // '1111'
+			c.b.writeBits(uint64(dod), 64)
+		}
+		c.writeVDelta(v)
+	}
+
+	c.t = t
+	c.v = v
+	c.num++
+	c.tDelta = tDelta
+}
+
+// bitRange reports whether x can be represented using nbits.
+func bitRange(x int64, nbits uint8) bool {
+	return -((1<<(nbits-1))-1) <= x && x <= 1<<(nbits-1)
+}
+
+func (c *xorChunk) writeVDelta(v float64) {
+	vDelta := math.Float64bits(v) ^ math.Float64bits(c.v)
+
+	if vDelta == 0 {
+		c.b.writeBit(false)
+		return
+	}
+	c.b.writeBit(true)
+
+	leading := uint8(bits.LeadingZeros64(vDelta))
+	trailing := uint8(bits.TrailingZeros64(vDelta))
+
+	// Clamp number of leading zeros to avoid overflow when encoding.
+	if leading >= 32 {
+		leading = 31
+	}
+
+	if c.leading != 0xff && leading >= c.leading && trailing >= c.trailing {
+		// The meaningful bits fit within the previous window.
+		c.b.writeBit(false)
+		c.b.writeBits(vDelta>>c.trailing, 64-int(c.leading)-int(c.trailing))
+		return
+	}
+
+	c.leading, c.trailing = leading, trailing
+
+	c.b.writeBit(true)
+	c.b.writeBits(uint64(leading), 5)
+
+	// 64 significant bits overflow to 0 in the 6 bit field, which the
+	// decoder interprets as 64.
+	sigbits := 64 - leading - trailing
+	c.b.writeBits(uint64(sigbits), 6)
+	c.b.writeBits(vDelta>>trailing, int(sigbits))
+}
+
+// bstream is an append-only stream of bits, written most significant bit
+// first.
+type bstream struct {
+	stream []byte
+	count  uint8 // number of bits still free in the last byte
+}
+
+func (b *bstream) writeBit(bit bool) {
+	if b.count == 0 {
+		b.stream = append(b.stream, 0)
+		b.count = 8
+	}
+
+	i := len(b.stream) - 1
+	if bit {
+		b.stream[i] |= 1 << (b.count - 1)
+	}
+	b.count--
+}
+
+func (b *bstream) writeByte(byt byte) {
+	if b.count == 0 {
+		b.stream = append(b.stream, 0)
+		b.count = 8
+	}
+
+	i := len(b.stream) - 1
+
+	// Fill up the free bits of the last byte and carry the remainder into
+	// a new one.
+	b.stream[i] |= byt >> (8 - b.count)
+	b.stream = append(b.stream, 0)
+	b.stream[i+1] = byt << b.count
+}
+
+func (b *bstream) writeBits(u uint64, nbits int) {
+	u <<= 64 - uint(nbits)
+	for nbits >= 8 {
+		b.writeByte(byte(u >> 56))
+		u <<= 8
+		nbits -= 8
+	}
+
+	for nbits > 0 {
+		b.writeBit((u >> 63) == 1)
+		u <<= 1
+		nbits--
+	}
+}
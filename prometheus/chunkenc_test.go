@@ -0,0 +1,185 @@
+package prometheus
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestXORChunk(t *testing.T) {
+	type sample struct {
+		t int64
+		v float64
+	}
+
+	var samples []sample
+	ts := int64(1234123324)
+	v := 1243535.123
+	for i := 0; i < maxSamplesPerChunk; i++ {
+		// Exercise every delta-of-delta width and both value encodings.
+		switch i % 6 {
+		case 0:
+			ts += 1000
+		case 1:
+			ts += 1000 + int64(i)*10
+			v += 1
+		case 2:
+			ts += 1 << 15
+		case 3:
+			ts += 1 << 18
+			v = -v
+		case 4:
+			ts += 1 << 30
+			v = math.Inf(1)
+		case 5:
+			ts += 1
+			v = float64(i) / 7
+		}
+		samples = append(samples, sample{ts, v})
+	}
+
+	c := newXORChunk()
+	for _, s := range samples {
+		c.Append(s.t, s.v)
+	}
+	if got, want := c.NumSamples(), len(samples); got != want {
+		t.Fatalf("unexpected number of samples: got %d, want %d", got, want)
+	}
+
+	it := newXORIterator(c.Bytes())
+	for i, exp := range samples {
+		if !it.next() {
+			t.Fatalf("sample %d: unexpected end of chunk: %v", i, it.err)
+		}
+		if it.t != exp.t || math.Float64bits(it.v) != math.Float64bits(exp.v) {
+			t.Fatalf("sample %d: got (%d, %v), want (%d, %v)", i, it.t, it.v, exp.t, exp.v)
+		}
+	}
+	if it.next() {
+		t.Fatalf("unexpected sample after end of chunk: (%d, %v)", it.t, it.v)
+	}
+}
+
+// xorIterator decodes an XOR chunk as chunkenc.XORChunk does.
+type xorIterator struct {
+	b        []byte
+	pos      int // bit position in b
+	numTotal uint16
+	numRead  uint16
+
+	t        int64
+	v        float64
+	tDelta   uint64
+	leading  uint8
+	trailing uint8
+	err      error
+}
+
+func newXORIterator(b []byte) *xorIterator {
+	return &xorIterator{b: b[2:], numTotal: binary.BigEndian.Uint16(b)}
+}
+
+func (it *xorIterator) readBit() bool {
+	bit := it.b[it.pos/8]&(0x80>>uint(it.pos%8)) != 0
+	it.pos++
+	return bit
+}
+
+func (it *xorIterator) readBits(n int) uint64 {
+	var u uint64
+	for i := 0; i < n; i++ {
+		u <<= 1
+		if it.readBit() {
+			u |= 1
+		}
+	}
+	return u
+}
+
+func (it *xorIterator) ReadByte() (byte, error) {
+	return byte(it.readBits(8)), nil
+}
+
+func (it *xorIterator) next() bool {
+	if it.err != nil || it.numRead == it.numTotal {
+		return false
+	}
+
+	switch it.numRead {
+	case 0:
+		t, err := binary.ReadVarint(it)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.t = t
+		it.v = math.Float64frombits(it.readBits(64))
+	case 1:
+		tDelta, err := binary.ReadUvarint(it)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.tDelta = tDelta
+		it.t += int64(tDelta)
+		it.readValue()
+	default:
+		var d byte
+		for i := 0; i < 4; i++ {
+			d <<= 1
+			if !it.readBit() {
+				break
+			}
+			d |= 1
+		}
+
+		var sz int
+		var dod int64
+		switch d {
+		case 0x02:
+			sz = 14
+		case 0x06:
+			sz = 17
+		case 0x0e:
+			sz = 20
+		case 0x0f:
+			dod = int64(it.readBits(64))
+		}
+		if sz != 0 {
+			bits := it.readBits(sz)
+			if bits > (1 << (sz - 1)) {
+				// Sign extend.
+				bits = bits - (1 << sz)
+			}
+			dod = int64(bits)
+		}
+
+		it.tDelta = uint64(int64(it.tDelta) + dod)
+		it.t += int64(it.tDelta)
+		it.readValue()
+	}
+
+	it.numRead++
+	return true
+}
+
+func (it *xorIterator) readValue() {
+	if !it.readBit() {
+		return // Value unchanged.
+	}
+
+	if it.readBit() {
+		it.leading = uint8(it.readBits(5))
+		mbits := uint8(it.readBits(6))
+		// 0 significant bits here means we overflowed and we actually need 64.
+		if mbits == 0 {
+			mbits = 64
+		}
+		it.trailing = 64 - it.leading - mbits
+	}
+
+	mbits := int(64 - it.leading - it.trailing)
+	vbits := math.Float64bits(it.v)
+	vbits ^= it.readBits(mbits) << it.trailing
+	it.v = math.Float64frombits(vbits)
+}
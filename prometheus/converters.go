@@ -101,18 +101,32 @@ func ReadRequestToInfluxStorageRequest(req *remote.ReadRequest, db, rp string) (
 	if len(req.Queries) != 1 {
 		return nil, errors.New("Prometheus read endpoint currently only supports one query at a time")
 	}
-	q := req.Queries[0]
+	return QueryToInfluxStorageRequest(req.Queries[0], db, rp)
+}
 
+// QueryToInfluxStorageRequest converts a single query of a Prometheus remote read request into
+// a storage read request. The time range is narrowed to the one given by the query hints, if any.
+func QueryToInfluxStorageRequest(q *remote.Query, db, rp string) (*datatypes.ReadFilterRequest, error) {
 	src, err := types.MarshalAny(&storage.ReadSource{Database: db, RetentionPolicy: rp})
 	if err != nil {
 		return nil, err
 	}
 
+	start, end := q.StartTimestampMs, q.EndTimestampMs
+	if h := q.Hints; h != nil {
+		if h.StartMs > start {
+			start = h.StartMs
+		}
+		if h.EndMs != 0 && h.EndMs < end {
+			end = h.EndMs
+		}
+	}
+
 	sreq := &datatypes.ReadFilterRequest{
 		ReadSource: src,
 		Range: datatypes.TimestampRange{
-			Start: time.Unix(0, q.StartTimestampMs*int64(time.Millisecond)).UnixNano(),
-			End:   time.Unix(0, q.EndTimestampMs*int64(time.Millisecond)).UnixNano(),
+			Start: time.Unix(0, start*int64(time.Millisecond)).UnixNano(),
+			End:   time.Unix(0, end*int64(time.Millisecond)).UnixNano(),
 		},
 	}
 
@@ -0,0 +1,196 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// group is the state of an aggregation over the samples of one group.
+type group struct {
+	metric Labels
+	value  float64
+	mean   float64
+	count  int
+	values []float64
+	heap   Vector
+}
+
+func (ev *evaluator) aggregate(e *AggregateExpr, ts int64) (Value, error) {
+	v, err := ev.eval(e.Expr, ts)
+	if err != nil {
+		return nil, err
+	}
+	vec, _ := v.(Vector)
+
+	var (
+		param      float64
+		valueLabel string
+	)
+	if e.Param != nil {
+		p, err := ev.eval(e.Param, ts)
+		if err != nil {
+			return nil, err
+		}
+		switch p := p.(type) {
+		case Scalar:
+			param = p.V
+		case String:
+			valueLabel = p.V
+		}
+	}
+
+	grouping := e.Grouping
+	if e.Without {
+		grouping = append([]string{MetricNameLabel}, grouping...)
+	}
+
+	if e.Op == "count_values" {
+		if valueLabel == "" || !IsValidLabelName(valueLabel) {
+			return nil, fmt.Errorf("invalid label name %q", valueLabel)
+		}
+
+		// Group by the value as well as the grouping labels.
+		counted := make(Vector, 0, len(vec))
+		for _, s := range vec {
+			metric := s.Metric.set(valueLabel, strconv.FormatFloat(s.V, 'f', -1, 64))
+			counted = append(counted, Sample{Point: s.Point, Metric: metric})
+		}
+		vec = counted
+		if !e.Without {
+			grouping = append([]string{valueLabel}, grouping...)
+		}
+	}
+
+	k := int(param)
+	if e.Op == "topk" || e.Op == "bottomk" {
+		if k < 1 {
+			return Vector{}, nil
+		}
+	}
+
+	groups := make(map[string]*group)
+	var order []string
+	for _, s := range vec {
+		key := s.Metric.keyOf(grouping, !e.Without)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{
+				metric: s.Metric.keep(grouping, !e.Without),
+				value:  s.V,
+				mean:   s.V,
+				count:  1,
+			}
+			switch e.Op {
+			case "group":
+				g.value = 1
+			case "stddev", "stdvar":
+				g.value = 0
+			case "quantile":
+				g.values = []float64{s.V}
+			case "topk", "bottomk":
+				g.heap = Vector{s}
+			}
+			groups[key] = g
+			order = append(order, key)
+			continue
+		}
+
+		g.count++
+		switch e.Op {
+		case "sum":
+			g.value += s.V
+		case "avg":
+			g.mean += (s.V - g.mean) / float64(g.count)
+		case "min":
+			if g.value > s.V || math.IsNaN(g.value) {
+				g.value = s.V
+			}
+		case "max":
+			if g.value < s.V || math.IsNaN(g.value) {
+				g.value = s.V
+			}
+		case "stddev", "stdvar":
+			// Welford's online algorithm.
+			delta := s.V - g.mean
+			g.mean += delta / float64(g.count)
+			g.value += delta * (s.V - g.mean)
+		case "quantile":
+			g.values = append(g.values, s.V)
+		case "topk", "bottomk":
+			g.heap = append(g.heap, s)
+		}
+	}
+
+	res := make(Vector, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		switch e.Op {
+		case "avg":
+			g.value = g.mean
+		case "count", "count_values":
+			g.value = float64(g.count)
+		case "stddev":
+			g.value = math.Sqrt(g.value / float64(g.count))
+		case "stdvar":
+			g.value = g.value / float64(g.count)
+		case "quantile":
+			g.value = quantile(param, g.values)
+		case "topk", "bottomk":
+			sortByValue(g.heap, e.Op == "topk")
+			if len(g.heap) > k {
+				g.heap = g.heap[:k]
+			}
+			for _, s := range g.heap {
+				res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: s.Metric})
+			}
+			continue
+		}
+		res = append(res, Sample{Point: Point{T: ts, V: g.value}, Metric: g.metric})
+	}
+	return res, nil
+}
+
+// sortByValue sorts v by value, in descending order if desc is set. NaN
+// values sort last.
+func sortByValue(v Vector, desc bool) {
+	sort.SliceStable(v, func(i, j int) bool {
+		a, b := v[i].V, v[j].V
+		if math.IsNaN(a) {
+			return false
+		}
+		if math.IsNaN(b) {
+			return true
+		}
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+// quantile returns the φ-quantile of values, interpolating between the
+// nearest ranks.
+func quantile(q float64, values []float64) float64 {
+	if len(values) == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(+1)
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	n := float64(len(sorted))
+	rank := q * (n - 1)
+	lower := math.Max(0, math.Floor(rank))
+	upper := math.Min(n-1, lower+1)
+	weight := rank - math.Floor(rank)
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
@@ -0,0 +1,272 @@
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Expr is a node of a parsed PromQL expression.
+type Expr interface {
+	// Type returns the type the expression evaluates to.
+	Type() ValueType
+}
+
+// MatchType is the type of a label matcher.
+type MatchType int
+
+// The types of label matchers.
+const (
+	MatchEqual MatchType = iota
+	MatchNotEqual
+	MatchRegexp
+	MatchNotRegexp
+)
+
+func (t MatchType) String() string {
+	switch t {
+	case MatchEqual:
+		return "="
+	case MatchNotEqual:
+		return "!="
+	case MatchRegexp:
+		return "=~"
+	case MatchNotRegexp:
+		return "!~"
+	}
+	return fmt.Sprintf("MatchType(%d)", int(t))
+}
+
+// Matcher matches the value of a label.
+type Matcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+
+	re *regexp.Regexp
+}
+
+// NewMatcher returns a matcher, compiling the regular expression of
+// regexp matchers. Regular expressions are anchored at both ends.
+func NewMatcher(t MatchType, name, value string) (*Matcher, error) {
+	m := &Matcher{Type: t, Name: name, Value: value}
+	if t == MatchRegexp || t == MatchNotRegexp {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, err
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+// Matches reports whether the value v of the label matches.
+func (m *Matcher) Matches(v string) bool {
+	switch m.Type {
+	case MatchEqual:
+		return v == m.Value
+	case MatchNotEqual:
+		return v != m.Value
+	case MatchRegexp:
+		return m.re.MatchString(v)
+	case MatchNotRegexp:
+		return !m.re.MatchString(v)
+	}
+	return false
+}
+
+// VectorSelector selects the latest sample of each matching series.
+type VectorSelector struct {
+	Name     string
+	Matchers []*Matcher
+	Offset   time.Duration
+}
+
+// MatrixSelector selects the samples of each matching series within a
+// range.
+type MatrixSelector struct {
+	VectorSelector *VectorSelector
+	Range          time.Duration
+}
+
+// SubqueryExpr evaluates an expression at each step of a range.
+type SubqueryExpr struct {
+	Expr   Expr
+	Range  time.Duration
+	Step   time.Duration
+	Offset time.Duration
+}
+
+// NumberLiteral is a number.
+type NumberLiteral struct {
+	Val float64
+}
+
+// StringLiteral is a string.
+type StringLiteral struct {
+	Val string
+}
+
+// ParenExpr is an expression in parentheses.
+type ParenExpr struct {
+	Expr Expr
+}
+
+// UnaryExpr is a negated expression.
+type UnaryExpr struct {
+	Op   tokenType
+	Expr Expr
+}
+
+// VectorMatchCardinality describes how the samples of two vectors are
+// matched by a binary operation.
+type VectorMatchCardinality int
+
+// The cardinalities of vector matching.
+const (
+	CardOneToOne VectorMatchCardinality = iota
+	CardManyToOne
+	CardOneToMany
+	CardManyToMany
+)
+
+// VectorMatching describes how the samples of two vectors are matched.
+type VectorMatching struct {
+	Card VectorMatchCardinality
+	// MatchingLabels are the labels used to match samples, or the labels
+	// ignored if On is false.
+	MatchingLabels []string
+	On             bool
+	// Include are the labels of the "one" side copied to the result of a
+	// many-to-one or one-to-many match.
+	Include []string
+}
+
+// BinaryExpr is a binary operation.
+type BinaryExpr struct {
+	Op       tokenType
+	LHS, RHS Expr
+
+	// VectorMatching is set if both sides are vectors.
+	VectorMatching *VectorMatching
+	// ReturnBool makes comparisons return 0 or 1 rather than filtering.
+	ReturnBool bool
+}
+
+// Call is a function call.
+type Call struct {
+	Func *Function
+	Args []Expr
+}
+
+// AggregateExpr is an aggregation over the samples of a vector.
+type AggregateExpr struct {
+	Op       string
+	Expr     Expr
+	Param    Expr
+	Grouping []string
+	Without  bool
+}
+
+// Type implements Expr.
+func (*VectorSelector) Type() ValueType { return ValueTypeVector }
+
+// Type implements Expr.
+func (*MatrixSelector) Type() ValueType { return ValueTypeMatrix }
+
+// Type implements Expr.
+func (*SubqueryExpr) Type() ValueType { return ValueTypeMatrix }
+
+// Type implements Expr.
+func (*NumberLiteral) Type() ValueType { return ValueTypeScalar }
+
+// Type implements Expr.
+func (*StringLiteral) Type() ValueType { return ValueTypeString }
+
+// Type implements Expr.
+func (e *ParenExpr) Type() ValueType { return e.Expr.Type() }
+
+// Type implements Expr.
+func (e *UnaryExpr) Type() ValueType { return e.Expr.Type() }
+
+// Type implements Expr.
+func (e *BinaryExpr) Type() ValueType {
+	if e.LHS.Type() == ValueTypeScalar && e.RHS.Type() == ValueTypeScalar {
+		return ValueTypeScalar
+	}
+	return ValueTypeVector
+}
+
+// Type implements Expr.
+func (e *Call) Type() ValueType { return e.Func.ReturnType }
+
+// Type implements Expr.
+func (*AggregateExpr) Type() ValueType { return ValueTypeVector }
+
+// isComparison reports whether op is a comparison operator.
+func isComparison(op tokenType) bool {
+	switch op {
+	case tokEqlC, tokNotEq, tokGtr, tokLss, tokGte, tokLte:
+		return true
+	}
+	return false
+}
+
+// isSetOperator reports whether op is a set operator.
+func isSetOperator(op tokenType) bool {
+	switch op {
+	case tokLand, tokLor, tokLunless:
+		return true
+	}
+	return false
+}
+
+// precedence returns the precedence of a binary operator, or zero if op is
+// not one.
+func precedence(op tokenType) int {
+	switch op {
+	case tokLor:
+		return 1
+	case tokLand, tokLunless:
+		return 2
+	case tokEqlC, tokNotEq, tokGtr, tokLss, tokGte, tokLte:
+		return 3
+	case tokAdd, tokSub:
+		return 4
+	case tokMul, tokDiv, tokMod, tokAtan2:
+		return 5
+	case tokPow:
+		return 6
+	}
+	return 0
+}
+
+// walk calls fn for node and each of its descendants. path holds the
+// ancestors of each node.
+func walk(node Expr, path []Expr, fn func(node Expr, path []Expr)) {
+	fn(node, path)
+	path = append(path, node)
+
+	switch n := node.(type) {
+	case *MatrixSelector:
+		walk(n.VectorSelector, path, fn)
+	case *SubqueryExpr:
+		walk(n.Expr, path, fn)
+	case *ParenExpr:
+		walk(n.Expr, path, fn)
+	case *UnaryExpr:
+		walk(n.Expr, path, fn)
+	case *BinaryExpr:
+		walk(n.LHS, path, fn)
+		walk(n.RHS, path, fn)
+	case *Call:
+		for _, arg := range n.Args {
+			walk(arg, path, fn)
+		}
+	case *AggregateExpr:
+		if n.Param != nil {
+			walk(n.Param, path, fn)
+		}
+		walk(n.Expr, path, fn)
+	}
+}
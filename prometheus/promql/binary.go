@@ -0,0 +1,279 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+)
+
+func (ev *evaluator) binary(e *BinaryExpr, ts int64) (Value, error) {
+	lhs, err := ev.eval(e.LHS, ts)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := ev.eval(e.RHS, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch l := lhs.(type) {
+	case Scalar:
+		switch r := rhs.(type) {
+		case Scalar:
+			v, keep := scalarBinop(e.Op, l.V, r.V)
+			if isComparison(e.Op) {
+				// Comparisons between scalars require bool, so keep is the
+				// result.
+				v = 0
+				if keep {
+					v = 1
+				}
+			}
+			return Scalar{T: ts, V: v}, nil
+		case Vector:
+			return vectorScalarBinop(e.Op, r, l.V, true, e.ReturnBool, ts), nil
+		}
+	case Vector:
+		switch r := rhs.(type) {
+		case Scalar:
+			return vectorScalarBinop(e.Op, l, r.V, false, e.ReturnBool, ts), nil
+		case Vector:
+			switch e.Op {
+			case tokLand:
+				return vectorAnd(l, r, e.VectorMatching, ts), nil
+			case tokLor:
+				return vectorOr(l, r, e.VectorMatching, ts), nil
+			case tokLunless:
+				return vectorUnless(l, r, e.VectorMatching, ts), nil
+			}
+			return vectorBinop(e.Op, l, r, e.VectorMatching, e.ReturnBool, ts)
+		}
+	}
+	return nil, fmt.Errorf("invalid operand types for binary operator %s", e.Op)
+}
+
+// scalarBinop applies op to lhs and rhs. Comparisons return lhs and
+// whether the comparison is true.
+func scalarBinop(op tokenType, lhs, rhs float64) (float64, bool) {
+	switch op {
+	case tokAdd:
+		return lhs + rhs, true
+	case tokSub:
+		return lhs - rhs, true
+	case tokMul:
+		return lhs * rhs, true
+	case tokDiv:
+		return lhs / rhs, true
+	case tokMod:
+		return math.Mod(lhs, rhs), true
+	case tokPow:
+		return math.Pow(lhs, rhs), true
+	case tokAtan2:
+		return math.Atan2(lhs, rhs), true
+	case tokEqlC:
+		return lhs, lhs == rhs
+	case tokNotEq:
+		return lhs, lhs != rhs
+	case tokGtr:
+		return lhs, lhs > rhs
+	case tokLss:
+		return lhs, lhs < rhs
+	case tokGte:
+		return lhs, lhs >= rhs
+	case tokLte:
+		return lhs, lhs <= rhs
+	}
+	return math.NaN(), false
+}
+
+// vectorScalarBinop applies op to each sample of v and the scalar s. swap
+// is set if the scalar is the left operand.
+func vectorScalarBinop(op tokenType, v Vector, s float64, swap, returnBool bool, ts int64) Vector {
+	res := make(Vector, 0, len(v))
+	for _, sample := range v {
+		lv, rv := sample.V, s
+		if swap {
+			lv, rv = rv, lv
+		}
+		value, keep := scalarBinop(op, lv, rv)
+		// A comparison keeps the value of the vector, whichever side it
+		// is on.
+		if isComparison(op) && swap {
+			value = rv
+		}
+		if returnBool {
+			value = 0
+			if keep {
+				value = 1
+			}
+			keep = true
+		}
+		if !keep {
+			continue
+		}
+
+		metric := sample.Metric
+		if returnBool || !isComparison(op) {
+			metric = metric.dropMetricName()
+		}
+		res = append(res, Sample{Point: Point{T: ts, V: value}, Metric: metric})
+	}
+	return res
+}
+
+// signature returns the function identifying the labels used to match
+// samples of two vectors.
+func signature(m *VectorMatching) func(Labels) string {
+	if m.On {
+		return func(ls Labels) string { return ls.keyOf(m.MatchingLabels, true) }
+	}
+	names := append([]string{MetricNameLabel}, m.MatchingLabels...)
+	return func(ls Labels) string { return ls.keyOf(names, false) }
+}
+
+func vectorAnd(lhs, rhs Vector, m *VectorMatching, ts int64) Vector {
+	sig := signature(m)
+	right := make(map[string]struct{}, len(rhs))
+	for _, s := range rhs {
+		right[sig(s.Metric)] = struct{}{}
+	}
+
+	var res Vector
+	for _, s := range lhs {
+		if _, ok := right[sig(s.Metric)]; ok {
+			res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: s.Metric})
+		}
+	}
+	return res
+}
+
+func vectorOr(lhs, rhs Vector, m *VectorMatching, ts int64) Vector {
+	sig := signature(m)
+	left := make(map[string]struct{}, len(lhs))
+	res := make(Vector, 0, len(lhs)+len(rhs))
+	for _, s := range lhs {
+		left[sig(s.Metric)] = struct{}{}
+		res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: s.Metric})
+	}
+	for _, s := range rhs {
+		if _, ok := left[sig(s.Metric)]; !ok {
+			res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: s.Metric})
+		}
+	}
+	return res
+}
+
+func vectorUnless(lhs, rhs Vector, m *VectorMatching, ts int64) Vector {
+	sig := signature(m)
+	right := make(map[string]struct{}, len(rhs))
+	for _, s := range rhs {
+		right[sig(s.Metric)] = struct{}{}
+	}
+
+	var res Vector
+	for _, s := range lhs {
+		if _, ok := right[sig(s.Metric)]; !ok {
+			res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: s.Metric})
+		}
+	}
+	return res
+}
+
+// vectorBinop applies an arithmetic or comparison operator to the matching
+// samples of two vectors.
+func vectorBinop(op tokenType, lhs, rhs Vector, m *VectorMatching, returnBool bool, ts int64) (Vector, error) {
+	// Iterate over the "many" side and look up the "one" side.
+	swap := m.Card == CardOneToMany
+	if swap {
+		lhs, rhs = rhs, lhs
+	}
+
+	sig := signature(m)
+	right := make(map[string]Sample, len(rhs))
+	for _, s := range rhs {
+		key := sig(s.Metric)
+		if _, ok := right[key]; ok {
+			return nil, fmt.Errorf("found duplicate series for the match group %s on the %s hand-side of the operation; many-to-many matching not allowed: matching labels must be unique on one side",
+				s.Metric.keep(m.MatchingLabels, m.On), side(!swap))
+		}
+		right[key] = s
+	}
+
+	// One-to-one matching must also be unique on the left.
+	var matchedLeft map[string]struct{}
+	if m.Card == CardOneToOne {
+		matchedLeft = make(map[string]struct{}, len(lhs))
+	}
+	seen := make(map[string]struct{}, len(lhs))
+
+	var res Vector
+	for _, ls := range lhs {
+		key := sig(ls.Metric)
+		rs, ok := right[key]
+		if !ok {
+			continue
+		}
+
+		lv, rv := ls.V, rs.V
+		if swap {
+			lv, rv = rv, lv
+		}
+		value, keep := scalarBinop(op, lv, rv)
+		if returnBool {
+			value = 0
+			if keep {
+				value = 1
+			}
+			keep = true
+		}
+
+		if matchedLeft != nil {
+			if _, ok := matchedLeft[key]; ok {
+				return nil, fmt.Errorf("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+			}
+			matchedLeft[key] = struct{}{}
+		}
+		if !keep {
+			continue
+		}
+
+		metric := resultMetric(ls.Metric, rs.Metric, op, m, returnBool)
+		if m.Card != CardOneToOne {
+			k := metric.key()
+			if _, ok := seen[k]; ok {
+				return nil, fmt.Errorf("multiple matches for labels: grouping labels must ensure unique matches")
+			}
+			seen[k] = struct{}{}
+		}
+		res = append(res, Sample{Point: Point{T: ts, V: value}, Metric: metric})
+	}
+	return res, nil
+}
+
+func side(left bool) string {
+	if left {
+		return "right"
+	}
+	return "left"
+}
+
+// resultMetric returns the labels of the result of a binary operation
+// between a sample of the "many" side, lhs, and a sample of the "one"
+// side, rhs.
+func resultMetric(lhs, rhs Labels, op tokenType, m *VectorMatching, returnBool bool) Labels {
+	res := lhs
+	if returnBool || !isComparison(op) {
+		res = res.dropMetricName()
+	}
+
+	if m.Card == CardOneToOne {
+		if m.On {
+			res = res.keep(m.MatchingLabels, true)
+		} else {
+			res = res.keep(m.MatchingLabels, false)
+		}
+	}
+	for _, name := range m.Include {
+		res = res.set(name, rhs.Get(name))
+	}
+	return res
+}
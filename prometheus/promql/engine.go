@@ -0,0 +1,409 @@
+package promql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultLookbackDelta is how far back an instant vector selector
+	// looks for the latest sample of a series.
+	DefaultLookbackDelta = 5 * time.Minute
+
+	// DefaultMaxSamples is the default maximum number of samples a query
+	// may load into memory.
+	DefaultMaxSamples = 50000000
+
+	// defaultSubqueryStep is the step of subqueries without one, when it
+	// can't be taken from the step of a range query. It matches the default
+	// Prometheus evaluation interval.
+	defaultSubqueryStep = time.Minute
+)
+
+// ErrTooManySamples is returned when a query would load more samples than
+// the engine allows.
+var ErrTooManySamples = errors.New("query processing would load too many samples into memory")
+
+// Queryable reads series from storage.
+type Queryable interface {
+	// Select returns the points between mint and maxt, inclusive, of every
+	// series matching all of matchers. Times are in milliseconds and the
+	// points of each series are in time order.
+	Select(ctx context.Context, mint, maxt int64, matchers []*Matcher) ([]Series, error)
+}
+
+// Engine evaluates PromQL expressions.
+type Engine struct {
+	LookbackDelta time.Duration
+	MaxSamples    int
+}
+
+// NewEngine returns an Engine with the default settings.
+func NewEngine() *Engine {
+	return &Engine{
+		LookbackDelta: DefaultLookbackDelta,
+		MaxSamples:    DefaultMaxSamples,
+	}
+}
+
+// InstantQuery evaluates qs at ts.
+func (e *Engine) InstantQuery(ctx context.Context, q Queryable, qs string, ts time.Time) (Value, error) {
+	expr, err := ParseExpr(qs)
+	if err != nil {
+		return nil, err
+	}
+
+	t := timeMilliseconds(ts)
+	ev, err := e.newEvaluator(ctx, q, expr, t, t, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := ev.eval(expr, t)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case Vector:
+		if err := checkDuplicates(v); err != nil {
+			return nil, err
+		}
+	case Matrix:
+		sort.Sort(v)
+	}
+	return v, nil
+}
+
+// RangeQuery evaluates qs at each step between start and end and returns
+// the result as a Matrix.
+func (e *Engine) RangeQuery(ctx context.Context, q Queryable, qs string, start, end time.Time, step time.Duration) (Value, error) {
+	expr, err := ParseExpr(qs)
+	if err != nil {
+		return nil, err
+	}
+	if t := expr.Type(); t != ValueTypeVector && t != ValueTypeScalar {
+		return nil, fmt.Errorf("invalid expression type %q for range query, must be scalar or instant vector", t)
+	}
+	if step <= 0 {
+		return nil, errors.New("zero or negative query resolution step widths are not accepted")
+	}
+
+	mint, maxt, stepMs := timeMilliseconds(start), timeMilliseconds(end), durationMilliseconds(step)
+	ev, err := e.newEvaluator(ctx, q, expr, mint, maxt, stepMs)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[string]*Series)
+	for ts := mint; ts <= maxt; ts += stepMs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		v, err := ev.eval(expr, ts)
+		if err != nil {
+			return nil, err
+		}
+
+		var vec Vector
+		switch v := v.(type) {
+		case Scalar:
+			vec = Vector{{Point: Point{T: ts, V: v.V}, Metric: Labels{}}}
+		case Vector:
+			vec = v
+		}
+		if err := checkDuplicates(vec); err != nil {
+			return nil, err
+		}
+
+		for _, s := range vec {
+			key := s.Metric.key()
+			ss, ok := series[key]
+			if !ok {
+				ss = &Series{Metric: s.Metric}
+				series[key] = ss
+			}
+			ss.Points = append(ss.Points, Point{T: ts, V: s.V})
+		}
+	}
+
+	m := make(Matrix, 0, len(series))
+	for _, s := range series {
+		m = append(m, *s)
+	}
+	sort.Sort(m)
+	return m, nil
+}
+
+// evaluator evaluates an expression using the series loaded for each of its
+// selectors.
+type evaluator struct {
+	ctx      context.Context
+	lookback int64
+	step     int64
+	data     map[*VectorSelector][]Series
+}
+
+// newEvaluator loads the series of each selector of expr needed to
+// evaluate it between start and end.
+func (e *Engine) newEvaluator(ctx context.Context, q Queryable, expr Expr, start, end, step int64) (*evaluator, error) {
+	ev := &evaluator{
+		ctx:      ctx,
+		lookback: durationMilliseconds(e.LookbackDelta),
+		step:     step,
+		data:     make(map[*VectorSelector][]Series),
+	}
+	if ev.lookback <= 0 {
+		ev.lookback = durationMilliseconds(DefaultLookbackDelta)
+	}
+
+	var (
+		samples int
+		err     error
+	)
+	walk(expr, nil, func(node Expr, path []Expr) {
+		vs, ok := node.(*VectorSelector)
+		if !ok || err != nil {
+			return
+		}
+
+		mint, maxt := start, end
+		for _, p := range path {
+			if sq, ok := p.(*SubqueryExpr); ok {
+				mint -= durationMilliseconds(sq.Offset + sq.Range)
+				maxt -= durationMilliseconds(sq.Offset)
+			}
+		}
+		if len(path) > 0 {
+			if ms, ok := path[len(path)-1].(*MatrixSelector); ok {
+				mint -= durationMilliseconds(ms.Range)
+			} else {
+				mint -= ev.lookback
+			}
+		} else {
+			mint -= ev.lookback
+		}
+		mint -= durationMilliseconds(vs.Offset)
+		maxt -= durationMilliseconds(vs.Offset)
+
+		var series []Series
+		if series, err = q.Select(ctx, mint, maxt, vs.Matchers); err != nil {
+			return
+		}
+		for _, s := range series {
+			samples += len(s.Points)
+		}
+		if e.MaxSamples > 0 && samples > e.MaxSamples {
+			err = ErrTooManySamples
+			return
+		}
+		ev.data[vs] = series
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+func (ev *evaluator) eval(expr Expr, ts int64) (Value, error) {
+	if err := ev.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch e := expr.(type) {
+	case *NumberLiteral:
+		return Scalar{T: ts, V: e.Val}, nil
+	case *StringLiteral:
+		return String{T: ts, V: e.Val}, nil
+	case *ParenExpr:
+		return ev.eval(e.Expr, ts)
+	case *VectorSelector:
+		return ev.vectorSelector(e, ts, false), nil
+	case *MatrixSelector:
+		return ev.matrixSelector(e, ts), nil
+	case *SubqueryExpr:
+		return ev.subquery(e, ts)
+	case *UnaryExpr:
+		v, err := ev.eval(e.Expr, ts)
+		if err != nil {
+			return nil, err
+		}
+		switch v := v.(type) {
+		case Scalar:
+			return Scalar{T: ts, V: -v.V}, nil
+		case Vector:
+			res := make(Vector, 0, len(v))
+			for _, s := range v {
+				res = append(res, Sample{Point: Point{T: ts, V: -s.V}, Metric: s.Metric.dropMetricName()})
+			}
+			return res, nil
+		}
+	case *BinaryExpr:
+		return ev.binary(e, ts)
+	case *AggregateExpr:
+		return ev.aggregate(e, ts)
+	case *Call:
+		return ev.call(e, ts)
+	}
+	return nil, fmt.Errorf("unhandled expression of type %T", expr)
+}
+
+// vectorSelector returns the latest sample of each series within the
+// lookback delta of ts. Samples are timestamped with ts unless keepTime is
+// set.
+func (ev *evaluator) vectorSelector(vs *VectorSelector, ts int64, keepTime bool) Vector {
+	ref := ts - durationMilliseconds(vs.Offset)
+
+	var res Vector
+	for _, s := range ev.data[vs] {
+		i := sort.Search(len(s.Points), func(i int) bool { return s.Points[i].T > ref }) - 1
+		if i < 0 || s.Points[i].T <= ref-ev.lookback {
+			continue
+		}
+
+		p := s.Points[i]
+		if !keepTime {
+			p.T = ts
+		}
+		res = append(res, Sample{Point: p, Metric: s.Metric})
+	}
+	return res
+}
+
+// matrixSelector returns the points of each series in the range ending at
+// ts. The range excludes its start.
+func (ev *evaluator) matrixSelector(ms *MatrixSelector, ts int64) Matrix {
+	ref := ts - durationMilliseconds(ms.VectorSelector.Offset)
+	start := ref - durationMilliseconds(ms.Range)
+
+	var res Matrix
+	for _, s := range ev.data[ms.VectorSelector] {
+		i := sort.Search(len(s.Points), func(i int) bool { return s.Points[i].T > start })
+		j := sort.Search(len(s.Points), func(i int) bool { return s.Points[i].T > ref })
+		if i >= j {
+			continue
+		}
+		res = append(res, Series{Metric: s.Metric, Points: s.Points[i:j]})
+	}
+	return res
+}
+
+// subquery evaluates the expression of sq at each step within its range.
+// Steps are aligned to multiples of the step width.
+func (ev *evaluator) subquery(sq *SubqueryExpr, ts int64) (Value, error) {
+	step := durationMilliseconds(sq.Step)
+	if step <= 0 {
+		step = ev.step
+		if step <= 0 {
+			step = durationMilliseconds(defaultSubqueryStep)
+		}
+	}
+
+	ref := ts - durationMilliseconds(sq.Offset)
+	start := ref - durationMilliseconds(sq.Range)
+
+	// The first step after the start of the range.
+	t := start - mod(start, step) + step
+
+	series := make(map[string]*Series)
+	var order []string
+	for ; t <= ref; t += step {
+		v, err := ev.eval(sq.Expr, t)
+		if err != nil {
+			return nil, err
+		}
+		vec, _ := v.(Vector)
+		for _, s := range vec {
+			key := s.Metric.key()
+			ss, ok := series[key]
+			if !ok {
+				ss = &Series{Metric: s.Metric}
+				series[key] = ss
+				order = append(order, key)
+			}
+			ss.Points = append(ss.Points, Point{T: t, V: s.V})
+		}
+	}
+
+	res := make(Matrix, 0, len(order))
+	for _, key := range order {
+		res = append(res, *series[key])
+	}
+	return res, nil
+}
+
+// mod returns the non-negative remainder of a / b.
+func mod(a, b int64) int64 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
+
+func (ev *evaluator) call(e *Call, ts int64) (Value, error) {
+	args := make([]Value, len(e.Args))
+	for i, arg := range e.Args {
+		// timestamp() returns the time of the samples rather than the
+		// evaluation time.
+		if e.Func.Name == "timestamp" {
+			if vs, ok := unwrapParens(arg).(*VectorSelector); ok {
+				args[i] = ev.vectorSelector(vs, ts, true)
+				continue
+			}
+		}
+
+		v, err := ev.eval(arg, ts)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return e.Func.call(ev, args, e, ts)
+}
+
+func unwrapParens(e Expr) Expr {
+	for {
+		p, ok := e.(*ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.Expr
+	}
+}
+
+// checkDuplicates returns an error if two samples of v have the same labels.
+func checkDuplicates(v Vector) error {
+	if len(v) < 2 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(v))
+	for _, s := range v {
+		key := s.Metric.key()
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("vector cannot contain metrics with the same labelset")
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+func timeMilliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func durationMilliseconds(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
+
+// scalarValue returns the value of a scalar argument.
+func scalarValue(v Value) float64 {
+	if s, ok := v.(Scalar); ok {
+		return s.V
+	}
+	return math.NaN()
+}
@@ -0,0 +1,206 @@
+package promql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// storage is an in-memory Queryable.
+type storage []Series
+
+func (s storage) Select(ctx context.Context, mint, maxt int64, matchers []*Matcher) ([]Series, error) {
+	var res []Series
+next:
+	for _, series := range s {
+		for _, m := range matchers {
+			if !m.Matches(series.Metric.Get(m.Name)) {
+				continue next
+			}
+		}
+
+		var points []Point
+		for _, p := range series.Points {
+			if p.T >= mint && p.T <= maxt {
+				points = append(points, p)
+			}
+		}
+		if len(points) > 0 {
+			res = append(res, Series{Metric: series.Metric, Points: points})
+		}
+	}
+	return res, nil
+}
+
+// newTestStorage returns two counters increasing by 1 and 2 per second, a
+// histogram and an info metric, sampled every 15s for 5m.
+func newTestStorage() storage {
+	series := func(labels map[string]string, fn func(t int64) float64) Series {
+		s := Series{Metric: NewLabels(labels)}
+		for t := int64(0); t <= 300; t += 15 {
+			s.Points = append(s.Points, Point{T: t * 1000, V: fn(t)})
+		}
+		return s
+	}
+	constant := func(v float64) func(int64) float64 {
+		return func(int64) float64 { return v }
+	}
+
+	return storage{
+		series(map[string]string{"__name__": "http_requests_total", "job": "api", "instance": "a"}, func(t int64) float64 { return float64(t) }),
+		series(map[string]string{"__name__": "http_requests_total", "job": "api", "instance": "b"}, func(t int64) float64 { return 2 * float64(t) }),
+		series(map[string]string{"__name__": "req_duration_bucket", "le": "1"}, constant(50)),
+		series(map[string]string{"__name__": "req_duration_bucket", "le": "2"}, constant(80)),
+		series(map[string]string{"__name__": "req_duration_bucket", "le": "+Inf"}, constant(100)),
+		series(map[string]string{"__name__": "info", "instance": "a", "version": "1.0"}, constant(1)),
+	}
+}
+
+func TestEngine_InstantQuery(t *testing.T) {
+	for _, tt := range []struct {
+		query string
+		exp   []string
+	}{
+		{
+			query: "http_requests_total",
+			exp: []string{
+				`{__name__="http_requests_total", instance="a", job="api"} 300`,
+				`{__name__="http_requests_total", instance="b", job="api"} 600`,
+			},
+		},
+		{
+			query: "http_requests_total offset 1m",
+			exp: []string{
+				`{__name__="http_requests_total", instance="a", job="api"} 240`,
+				`{__name__="http_requests_total", instance="b", job="api"} 480`,
+			},
+		},
+		{
+			query: "rate(http_requests_total[1m])",
+			exp: []string{
+				`{instance="a", job="api"} 1`,
+				`{instance="b", job="api"} 2`,
+			},
+		},
+		{
+			query: "sum by (job) (rate(http_requests_total[1m]))",
+			exp:   []string{`{job="api"} 3`},
+		},
+		{
+			query: `max_over_time(rate(http_requests_total{instance="a"}[1m])[5m:1m])`,
+			exp:   []string{`{instance="a", job="api"} 1`},
+		},
+		{
+			query: "http_requests_total / on (instance) group_left (version) info",
+			exp:   []string{`{instance="a", job="api", version="1.0"} 300`},
+		},
+		{
+			query: "http_requests_total > 400",
+			exp:   []string{`{__name__="http_requests_total", instance="b", job="api"} 600`},
+		},
+		{
+			query: "http_requests_total > bool 400",
+			exp: []string{
+				`{instance="a", job="api"} 0`,
+				`{instance="b", job="api"} 1`,
+			},
+		},
+		{
+			query: `http_requests_total unless on (instance) info`,
+			exp:   []string{`{__name__="http_requests_total", instance="b", job="api"} 600`},
+		},
+		{
+			query: "topk(1, http_requests_total)",
+			exp:   []string{`{__name__="http_requests_total", instance="b", job="api"} 600`},
+		},
+		{
+			query: `count_values("value", info)`,
+			exp:   []string{`{value="1"} 1`},
+		},
+		{
+			query: "histogram_quantile(0.65, req_duration_bucket)",
+			exp:   []string{`{} 1.5`},
+		},
+		{
+			query: `label_replace(info, "major", "$1", "version", "(\\d+)\\..*")`,
+			exp:   []string{`{__name__="info", instance="a", major="1", version="1.0"} 1`},
+		},
+		{
+			query: `absent(nonexistent{job="x"})`,
+			exp:   []string{`{job="x"} 1`},
+		},
+		{
+			query: "timestamp(http_requests_total)",
+			exp: []string{
+				`{instance="a", job="api"} 300`,
+				`{instance="b", job="api"} 300`,
+			},
+		},
+		{
+			query: "scalar(sum(info)) * 2",
+			exp:   []string{"scalar 2"},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			v, err := NewEngine().InstantQuery(context.Background(), newTestStorage(), tt.query, time.Unix(300, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := formatValue(v); !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("unexpected result:\ngot %s\nexp %s", strings.Join(got, "\n"), strings.Join(tt.exp, "\n"))
+			}
+		})
+	}
+}
+
+func TestEngine_RangeQuery(t *testing.T) {
+	v, err := NewEngine().RangeQuery(context.Background(), newTestStorage(), "sum(rate(http_requests_total[1m]))", time.Unix(60, 0), time.Unix(120, 0), 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := Matrix{{
+		Metric: Labels{},
+		Points: []Point{{T: 60000, V: 3}, {T: 90000, V: 3}, {T: 120000, V: 3}},
+	}}
+	if !reflect.DeepEqual(v, exp) {
+		t.Fatalf("unexpected result: %v", v)
+	}
+}
+
+func TestEngine_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := NewEngine().InstantQuery(ctx, newTestStorage(), "http_requests_total - ignoring (instance) http_requests_total", time.Unix(300, 0)); err == nil || !strings.Contains(err.Error(), "many-to-many matching not allowed") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewEngine().RangeQuery(ctx, newTestStorage(), "http_requests_total[1m]", time.Unix(0, 0), time.Unix(60, 0), time.Second); err == nil {
+		t.Fatal("expected error for range vector in range query")
+	}
+
+	e := NewEngine()
+	e.MaxSamples = 10
+	if _, err := e.InstantQuery(ctx, newTestStorage(), "http_requests_total[5m]", time.Unix(300, 0)); err != ErrTooManySamples {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// formatValue returns the samples of v as sorted strings.
+func formatValue(v Value) []string {
+	var res []string
+	switch v := v.(type) {
+	case Scalar:
+		res = append(res, fmt.Sprintf("scalar %g", v.V))
+	case Vector:
+		for _, s := range v {
+			res = append(res, fmt.Sprintf("%s %g", s.Metric, s.V))
+		}
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,735 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Function is a PromQL function.
+type Function struct {
+	Name     string
+	ArgTypes []ValueType
+	// Optional is the number of trailing arguments which may be omitted.
+	Optional int
+	// Variadic allows any number of arguments of the last type.
+	Variadic   bool
+	ReturnType ValueType
+
+	call func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error)
+}
+
+var functions = map[string]*Function{}
+
+func init() {
+	for _, fn := range []*Function{
+		rangeFunction("rate", func(s Series, r rangeInfo) (float64, bool) {
+			return extrapolatedRate(s.Points, r, true, true)
+		}),
+		rangeFunction("increase", func(s Series, r rangeInfo) (float64, bool) {
+			return extrapolatedRate(s.Points, r, true, false)
+		}),
+		rangeFunction("delta", func(s Series, r rangeInfo) (float64, bool) {
+			return extrapolatedRate(s.Points, r, false, false)
+		}),
+		rangeFunction("irate", func(s Series, _ rangeInfo) (float64, bool) {
+			return instantValue(s.Points, true)
+		}),
+		rangeFunction("idelta", func(s Series, _ rangeInfo) (float64, bool) {
+			return instantValue(s.Points, false)
+		}),
+		rangeFunction("resets", func(s Series, _ rangeInfo) (float64, bool) {
+			var n int
+			for i := 1; i < len(s.Points); i++ {
+				if s.Points[i].V < s.Points[i-1].V {
+					n++
+				}
+			}
+			return float64(n), true
+		}),
+		rangeFunction("changes", func(s Series, _ rangeInfo) (float64, bool) {
+			var n int
+			for i := 1; i < len(s.Points); i++ {
+				if cur, prev := s.Points[i].V, s.Points[i-1].V; cur != prev && !(math.IsNaN(cur) && math.IsNaN(prev)) {
+					n++
+				}
+			}
+			return float64(n), true
+		}),
+		rangeFunction("deriv", func(s Series, _ rangeInfo) (float64, bool) {
+			if len(s.Points) < 2 {
+				return 0, false
+			}
+			slope, _ := linearRegression(s.Points, s.Points[0].T)
+			return slope, true
+		}),
+		overTime("avg_over_time", func(points []Point) float64 {
+			var mean float64
+			for i, p := range points {
+				mean += (p.V - mean) / float64(i+1)
+			}
+			return mean
+		}),
+		overTime("min_over_time", func(points []Point) float64 {
+			min := points[0].V
+			for _, p := range points {
+				if p.V < min || math.IsNaN(min) {
+					min = p.V
+				}
+			}
+			return min
+		}),
+		overTime("max_over_time", func(points []Point) float64 {
+			max := points[0].V
+			for _, p := range points {
+				if p.V > max || math.IsNaN(max) {
+					max = p.V
+				}
+			}
+			return max
+		}),
+		overTime("sum_over_time", func(points []Point) float64 {
+			var sum float64
+			for _, p := range points {
+				sum += p.V
+			}
+			return sum
+		}),
+		overTime("count_over_time", func(points []Point) float64 {
+			return float64(len(points))
+		}),
+		overTime("last_over_time", func(points []Point) float64 {
+			return points[len(points)-1].V
+		}),
+		overTime("present_over_time", func([]Point) float64 {
+			return 1
+		}),
+		overTime("stddev_over_time", func(points []Point) float64 {
+			return math.Sqrt(variance(points))
+		}),
+		overTime("stdvar_over_time", variance),
+		{
+			Name:       "quantile_over_time",
+			ArgTypes:   []ValueType{ValueTypeScalar, ValueTypeMatrix},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				q := scalarValue(args[0])
+				m := args[1].(Matrix)
+				res := make(Vector, 0, len(m))
+				for _, s := range m {
+					values := make([]float64, len(s.Points))
+					for i, p := range s.Points {
+						values[i] = p.V
+					}
+					res = append(res, Sample{Point: Point{T: ts, V: quantile(q, values)}, Metric: s.Metric.dropMetricName()})
+				}
+				return res, nil
+			},
+		},
+		{
+			Name:       "predict_linear",
+			ArgTypes:   []ValueType{ValueTypeMatrix, ValueTypeScalar},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				m := args[0].(Matrix)
+				duration := scalarValue(args[1])
+				res := make(Vector, 0, len(m))
+				for _, s := range m {
+					if len(s.Points) < 2 {
+						continue
+					}
+					slope, intercept := linearRegression(s.Points, ts)
+					res = append(res, Sample{Point: Point{T: ts, V: slope*duration + intercept}, Metric: s.Metric.dropMetricName()})
+				}
+				return res, nil
+			},
+		},
+		{
+			Name:       "absent_over_time",
+			ArgTypes:   []ValueType{ValueTypeMatrix},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				if len(args[0].(Matrix)) > 0 {
+					return Vector{}, nil
+				}
+				return Vector{{Point: Point{T: ts, V: 1}, Metric: absentLabels(e.Args[0])}}, nil
+			},
+		},
+		{
+			Name:       "absent",
+			ArgTypes:   []ValueType{ValueTypeVector},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				if len(args[0].(Vector)) > 0 {
+					return Vector{}, nil
+				}
+				return Vector{{Point: Point{T: ts, V: 1}, Metric: absentLabels(e.Args[0])}}, nil
+			},
+		},
+
+		mathFunction("abs", math.Abs),
+		mathFunction("ceil", math.Ceil),
+		mathFunction("floor", math.Floor),
+		mathFunction("exp", math.Exp),
+		mathFunction("ln", math.Log),
+		mathFunction("log2", math.Log2),
+		mathFunction("log10", math.Log10),
+		mathFunction("sqrt", math.Sqrt),
+		mathFunction("sgn", func(v float64) float64 {
+			switch {
+			case v < 0:
+				return -1
+			case v > 0:
+				return 1
+			}
+			return v
+		}),
+		{
+			Name:       "round",
+			ArgTypes:   []ValueType{ValueTypeVector, ValueTypeScalar},
+			Optional:   1,
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				toNearest := 1.0
+				if len(args) > 1 {
+					toNearest = scalarValue(args[1])
+				}
+				// Divide by the inverse for better precision with decimal
+				// fractions such as 0.1.
+				inverse := 1 / toNearest
+				return mapVector(args[0].(Vector), ts, func(v float64) float64 {
+					return math.Floor(v*inverse+0.5) / inverse
+				}), nil
+			},
+		},
+		{
+			Name:       "clamp",
+			ArgTypes:   []ValueType{ValueTypeVector, ValueTypeScalar, ValueTypeScalar},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				min, max := scalarValue(args[1]), scalarValue(args[2])
+				if max < min {
+					return Vector{}, nil
+				}
+				return mapVector(args[0].(Vector), ts, func(v float64) float64 {
+					return math.Max(min, math.Min(max, v))
+				}), nil
+			},
+		},
+		{
+			Name:       "clamp_min",
+			ArgTypes:   []ValueType{ValueTypeVector, ValueTypeScalar},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				min := scalarValue(args[1])
+				return mapVector(args[0].(Vector), ts, func(v float64) float64 {
+					return math.Max(min, v)
+				}), nil
+			},
+		},
+		{
+			Name:       "clamp_max",
+			ArgTypes:   []ValueType{ValueTypeVector, ValueTypeScalar},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				max := scalarValue(args[1])
+				return mapVector(args[0].(Vector), ts, func(v float64) float64 {
+					return math.Min(max, v)
+				}), nil
+			},
+		},
+		{
+			Name:       "scalar",
+			ArgTypes:   []ValueType{ValueTypeVector},
+			ReturnType: ValueTypeScalar,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				v := args[0].(Vector)
+				if len(v) != 1 {
+					return Scalar{T: ts, V: math.NaN()}, nil
+				}
+				return Scalar{T: ts, V: v[0].V}, nil
+			},
+		},
+		{
+			Name:       "vector",
+			ArgTypes:   []ValueType{ValueTypeScalar},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				return Vector{{Point: Point{T: ts, V: scalarValue(args[0])}, Metric: Labels{}}}, nil
+			},
+		},
+		{
+			Name:       "time",
+			ReturnType: ValueTypeScalar,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				return Scalar{T: ts, V: float64(ts) / 1000}, nil
+			},
+		},
+		{
+			Name:       "timestamp",
+			ArgTypes:   []ValueType{ValueTypeVector},
+			ReturnType: ValueTypeVector,
+			call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+				v := args[0].(Vector)
+				res := make(Vector, 0, len(v))
+				for _, s := range v {
+					res = append(res, Sample{Point: Point{T: ts, V: float64(s.T) / 1000}, Metric: s.Metric.dropMetricName()})
+				}
+				return res, nil
+			},
+		},
+		{
+			Name:       "histogram_quantile",
+			ArgTypes:   []ValueType{ValueTypeScalar, ValueTypeVector},
+			ReturnType: ValueTypeVector,
+			call:       funcHistogramQuantile,
+		},
+		{
+			Name:       "label_replace",
+			ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString, ValueTypeString, ValueTypeString, ValueTypeString},
+			ReturnType: ValueTypeVector,
+			call:       funcLabelReplace,
+		},
+		{
+			Name:       "label_join",
+			ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString, ValueTypeString, ValueTypeString},
+			Variadic:   true,
+			ReturnType: ValueTypeVector,
+			call:       funcLabelJoin,
+		},
+		sortFunction("sort", false),
+		sortFunction("sort_desc", true),
+
+		dateFunction("minute", func(t time.Time) float64 { return float64(t.Minute()) }),
+		dateFunction("hour", func(t time.Time) float64 { return float64(t.Hour()) }),
+		dateFunction("day_of_week", func(t time.Time) float64 { return float64(t.Weekday()) }),
+		dateFunction("day_of_month", func(t time.Time) float64 { return float64(t.Day()) }),
+		dateFunction("days_in_month", func(t time.Time) float64 {
+			return float64(32 - time.Date(t.Year(), t.Month(), 32, 0, 0, 0, 0, time.UTC).Day())
+		}),
+		dateFunction("month", func(t time.Time) float64 { return float64(t.Month()) }),
+		dateFunction("year", func(t time.Time) float64 { return float64(t.Year()) }),
+	} {
+		functions[fn.Name] = fn
+	}
+}
+
+// rangeInfo is the range of a range vector argument, in milliseconds.
+type rangeInfo struct {
+	start, end int64
+}
+
+// argRange returns the range covered by the range vector expression e
+// evaluated at ts.
+func argRange(e Expr, ts int64) rangeInfo {
+	var rng, offset time.Duration
+	switch e := unwrapParens(e).(type) {
+	case *MatrixSelector:
+		rng, offset = e.Range, e.VectorSelector.Offset
+	case *SubqueryExpr:
+		rng, offset = e.Range, e.Offset
+	}
+	end := ts - durationMilliseconds(offset)
+	return rangeInfo{start: end - durationMilliseconds(rng), end: end}
+}
+
+// rangeFunction returns a function of a range vector which calls fn for
+// each series. Series for which fn returns false are dropped.
+func rangeFunction(name string, fn func(Series, rangeInfo) (float64, bool)) *Function {
+	return &Function{
+		Name:       name,
+		ArgTypes:   []ValueType{ValueTypeMatrix},
+		ReturnType: ValueTypeVector,
+		call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+			r := argRange(e.Args[0], ts)
+			m := args[0].(Matrix)
+			res := make(Vector, 0, len(m))
+			for _, s := range m {
+				v, ok := fn(s, r)
+				if !ok {
+					continue
+				}
+				res = append(res, Sample{Point: Point{T: ts, V: v}, Metric: s.Metric.dropMetricName()})
+			}
+			return res, nil
+		},
+	}
+}
+
+// overTime returns a function aggregating the points of each series of a
+// range vector. Only last_over_time keeps the metric name, as it selects a
+// sample rather than computing a new value.
+func overTime(name string, fn func([]Point) float64) *Function {
+	return &Function{
+		Name:       name,
+		ArgTypes:   []ValueType{ValueTypeMatrix},
+		ReturnType: ValueTypeVector,
+		call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+			m := args[0].(Matrix)
+			res := make(Vector, 0, len(m))
+			for _, s := range m {
+				metric := s.Metric
+				if name != "last_over_time" {
+					metric = metric.dropMetricName()
+				}
+				res = append(res, Sample{Point: Point{T: ts, V: fn(s.Points)}, Metric: metric})
+			}
+			return res, nil
+		},
+	}
+}
+
+// mathFunction returns a function applying fn to each sample of a vector.
+func mathFunction(name string, fn func(float64) float64) *Function {
+	return &Function{
+		Name:       name,
+		ArgTypes:   []ValueType{ValueTypeVector},
+		ReturnType: ValueTypeVector,
+		call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+			return mapVector(args[0].(Vector), ts, fn), nil
+		},
+	}
+}
+
+func mapVector(v Vector, ts int64, fn func(float64) float64) Vector {
+	res := make(Vector, 0, len(v))
+	for _, s := range v {
+		res = append(res, Sample{Point: Point{T: ts, V: fn(s.V)}, Metric: s.Metric.dropMetricName()})
+	}
+	return res
+}
+
+func sortFunction(name string, desc bool) *Function {
+	return &Function{
+		Name:       name,
+		ArgTypes:   []ValueType{ValueTypeVector},
+		ReturnType: ValueTypeVector,
+		call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+			v := append(Vector(nil), args[0].(Vector)...)
+			sortByValue(v, desc)
+			return v, nil
+		},
+	}
+}
+
+// dateFunction returns a function of the UTC time of each sample, which
+// defaults to the evaluation time.
+func dateFunction(name string, fn func(time.Time) float64) *Function {
+	return &Function{
+		Name:       name,
+		ArgTypes:   []ValueType{ValueTypeVector},
+		Optional:   1,
+		ReturnType: ValueTypeVector,
+		call: func(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+			v := Vector{{Point: Point{T: ts, V: float64(ts) / 1000}, Metric: Labels{}}}
+			if len(args) > 0 {
+				v = args[0].(Vector)
+			}
+			return mapVector(v, ts, func(v float64) float64 {
+				sec, frac := math.Modf(v)
+				return fn(time.Unix(int64(sec), int64(frac*1e9)).UTC())
+			}), nil
+		},
+	}
+}
+
+// extrapolatedRate returns the increase of points over the range,
+// extrapolated to the range boundaries where the points don't reach them.
+// Counter resets are accounted for if isCounter is set, and the result is
+// per second if isRate is set.
+func extrapolatedRate(points []Point, r rangeInfo, isCounter, isRate bool) (float64, bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+
+	first, last := points[0], points[len(points)-1]
+	result := last.V - first.V
+	if isCounter {
+		var prev float64
+		for _, p := range points {
+			if p.V < prev {
+				result += prev
+			}
+			prev = p.V
+		}
+	}
+
+	durationToStart := float64(first.T-r.start) / 1000
+	durationToEnd := float64(r.end-last.T) / 1000
+	sampledInterval := float64(last.T-first.T) / 1000
+	averageInterval := sampledInterval / float64(len(points)-1)
+
+	// Counters can't be extrapolated below zero.
+	if isCounter && result > 0 && first.V >= 0 {
+		if durationToZero := sampledInterval * (first.V / result); durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	// Extrapolate to the boundaries only if they are close to the first and
+	// last points, otherwise assume the series starts or ends half an
+	// interval away.
+	threshold := averageInterval * 1.1
+	interval := sampledInterval
+	if durationToStart < threshold {
+		interval += durationToStart
+	} else {
+		interval += averageInterval / 2
+	}
+	if durationToEnd < threshold {
+		interval += durationToEnd
+	} else {
+		interval += averageInterval / 2
+	}
+
+	result *= interval / sampledInterval
+	if isRate {
+		result /= float64(r.end-r.start) / 1000
+	}
+	return result, true
+}
+
+// instantValue returns the difference between the last two points, per
+// second if isRate is set, in which case a decrease is a counter reset.
+func instantValue(points []Point, isRate bool) (float64, bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+
+	last, prev := points[len(points)-1], points[len(points)-2]
+	result := last.V - prev.V
+	if isRate && last.V < prev.V {
+		result = last.V
+	}
+
+	interval := last.T - prev.T
+	if interval == 0 {
+		return 0, false
+	}
+	if isRate {
+		result /= float64(interval) / 1000
+	}
+	return result, true
+}
+
+// linearRegression returns the least squares fit of points, with the
+// intercept at interceptTime.
+func linearRegression(points []Point, interceptTime int64) (slope, intercept float64) {
+	var n, sumX, sumY, sumXY, sumX2 float64
+	for _, p := range points {
+		x := float64(p.T-interceptTime) / 1000
+		n++
+		sumX += x
+		sumY += p.V
+		sumXY += x * p.V
+		sumX2 += x * x
+	}
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+	slope = covXY / varX
+	intercept = sumY/n - slope*sumX/n
+	return slope, intercept
+}
+
+func variance(points []Point) float64 {
+	var mean, m2 float64
+	for i, p := range points {
+		delta := p.V - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (p.V - mean)
+	}
+	return m2 / float64(len(points))
+}
+
+// absentLabels returns the labels of the result of absent() for the
+// selector e: the labels it matches for equality, unless matched twice.
+func absentLabels(e Expr) Labels {
+	var vs *VectorSelector
+	switch e := unwrapParens(e).(type) {
+	case *VectorSelector:
+		vs = e
+	case *MatrixSelector:
+		vs = e.VectorSelector
+	default:
+		return Labels{}
+	}
+
+	m := make(map[string]string)
+	var dup []string
+	for _, matcher := range vs.Matchers {
+		if matcher.Type != MatchEqual || matcher.Name == MetricNameLabel {
+			continue
+		}
+		if _, ok := m[matcher.Name]; ok {
+			dup = append(dup, matcher.Name)
+		}
+		m[matcher.Name] = matcher.Value
+	}
+	for _, name := range dup {
+		delete(m, name)
+	}
+	return NewLabels(m)
+}
+
+// bucket is a cumulative histogram bucket.
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+func funcHistogramQuantile(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+	q := scalarValue(args[0])
+
+	type histogram struct {
+		metric  Labels
+		buckets []bucket
+	}
+	histograms := make(map[string]*histogram)
+	var order []string
+	for _, s := range args[1].(Vector) {
+		upperBound, err := strconv.ParseFloat(s.Metric.Get("le"), 64)
+		if err != nil {
+			// Samples without a valid bucket bound are ignored.
+			continue
+		}
+		metric := s.Metric.keep([]string{MetricNameLabel, "le"}, false)
+		key := metric.key()
+		h, ok := histograms[key]
+		if !ok {
+			h = &histogram{metric: metric}
+			histograms[key] = h
+			order = append(order, key)
+		}
+		h.buckets = append(h.buckets, bucket{upperBound: upperBound, count: s.V})
+	}
+
+	res := make(Vector, 0, len(order))
+	for _, key := range order {
+		h := histograms[key]
+		res = append(res, Sample{Point: Point{T: ts, V: bucketQuantile(q, h.buckets)}, Metric: h.metric})
+	}
+	return res, nil
+}
+
+// bucketQuantile returns the q-quantile of a histogram, interpolating
+// linearly within the bucket holding it. The highest bucket must have an
+// upper bound of +Inf.
+func bucketQuantile(q float64, buckets []bucket) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(+1)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].upperBound < buckets[j].upperBound })
+	if len(buckets) == 0 || !math.IsInf(buckets[len(buckets)-1].upperBound, +1) {
+		return math.NaN()
+	}
+
+	// Merge buckets with the same bound, and make the counts monotonic as
+	// series of a histogram may be scraped at slightly different times.
+	merged := buckets[:1]
+	for _, b := range buckets[1:] {
+		if last := &merged[len(merged)-1]; b.upperBound == last.upperBound {
+			last.count += b.count
+		} else {
+			merged = append(merged, b)
+		}
+	}
+	buckets = merged
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i].count < buckets[i-1].count {
+			buckets[i].count = buckets[i-1].count
+		}
+	}
+
+	if len(buckets) < 2 {
+		return math.NaN()
+	}
+	observations := buckets[len(buckets)-1].count
+	if observations == 0 {
+		return math.NaN()
+	}
+
+	rank := q * observations
+	b := sort.Search(len(buckets)-1, func(i int) bool { return buckets[i].count >= rank })
+	if b == len(buckets)-1 {
+		return buckets[len(buckets)-2].upperBound
+	}
+	if b == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	var start float64
+	end, count := buckets[b].upperBound, buckets[b].count
+	if b > 0 {
+		start = buckets[b-1].upperBound
+		count -= buckets[b-1].count
+		rank -= buckets[b-1].count
+	}
+	return start + (end-start)*(rank/count)
+}
+
+func funcLabelReplace(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+	var (
+		v           = args[0].(Vector)
+		dst         = args[1].(String).V
+		replacement = args[2].(String).V
+		src         = args[3].(String).V
+		expr        = args[4].(String).V
+	)
+
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression in label_replace(): %s", expr)
+	}
+	if !IsValidLabelName(dst) {
+		return nil, fmt.Errorf("invalid destination label name in label_replace(): %s", dst)
+	}
+
+	res := make(Vector, 0, len(v))
+	for _, s := range v {
+		metric := s.Metric
+		value := metric.Get(src)
+		if match := re.FindStringSubmatchIndex(value); match != nil {
+			repl := re.ExpandString(nil, replacement, value, match)
+			metric = metric.set(dst, string(repl))
+		}
+		res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: metric})
+	}
+	return res, nil
+}
+
+func funcLabelJoin(ev *evaluator, args []Value, e *Call, ts int64) (Value, error) {
+	var (
+		v   = args[0].(Vector)
+		dst = args[1].(String).V
+		sep = args[2].(String).V
+		src []string
+	)
+	for _, arg := range args[3:] {
+		name := arg.(String).V
+		if !IsValidLabelName(name) {
+			return nil, fmt.Errorf("invalid source label name in label_join(): %s", name)
+		}
+		src = append(src, name)
+	}
+	if !IsValidLabelName(dst) {
+		return nil, fmt.Errorf("invalid destination label name in label_join(): %s", dst)
+	}
+
+	res := make(Vector, 0, len(v))
+	for _, s := range v {
+		values := make([]string, len(src))
+		for i, name := range src {
+			values[i] = s.Metric.Get(name)
+		}
+		metric := s.Metric.set(dst, strings.Join(values, sep))
+		res = append(res, Sample{Point: Point{T: ts, V: s.V}, Metric: metric})
+	}
+	return res, nil
+}
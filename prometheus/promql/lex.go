@@ -0,0 +1,369 @@
+package promql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokIdentifier
+	tokNumber
+	tokString
+	tokDuration
+
+	tokLeftParen
+	tokRightParen
+	tokLeftBrace
+	tokRightBrace
+	tokLeftBracket
+	tokRightBracket
+	tokComma
+	tokColon
+	tokAt
+
+	// Label matching operators.
+	tokAssign // =
+	tokNotEq  // !=
+	tokRegex  // =~
+	tokNotRe  // !~
+
+	// Binary operators.
+	tokAdd
+	tokSub
+	tokMul
+	tokDiv
+	tokMod
+	tokPow
+	tokEqlC // ==
+	tokGtr
+	tokLss
+	tokGte
+	tokLte
+	tokLand
+	tokLor
+	tokLunless
+	tokAtan2
+)
+
+var tokenNames = map[tokenType]string{
+	tokEOF:          "end of input",
+	tokIdentifier:   "identifier",
+	tokNumber:       "number",
+	tokString:       "string",
+	tokDuration:     "duration",
+	tokLeftParen:    "(",
+	tokRightParen:   ")",
+	tokLeftBrace:    "{",
+	tokRightBrace:   "}",
+	tokLeftBracket:  "[",
+	tokRightBracket: "]",
+	tokComma:        ",",
+	tokColon:        ":",
+	tokAt:           "@",
+	tokAssign:       "=",
+	tokNotEq:        "!=",
+	tokRegex:        "=~",
+	tokNotRe:        "!~",
+	tokAdd:          "+",
+	tokSub:          "-",
+	tokMul:          "*",
+	tokDiv:          "/",
+	tokMod:          "%",
+	tokPow:          "^",
+	tokEqlC:         "==",
+	tokGtr:          ">",
+	tokLss:          "<",
+	tokGte:          ">=",
+	tokLte:          "<=",
+	tokLand:         "and",
+	tokLor:          "or",
+	tokLunless:      "unless",
+	tokAtan2:        "atan2",
+}
+
+func (t tokenType) String() string {
+	if s, ok := tokenNames[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("token(%d)", int(t))
+}
+
+// keywordOperators are the binary operators spelled as identifiers.
+var keywordOperators = map[string]tokenType{
+	"and":    tokLand,
+	"or":     tokLor,
+	"unless": tokLunless,
+	"atan2":  tokAtan2,
+}
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokEOF:
+		return t.typ.String()
+	case tokIdentifier, tokNumber, tokString, tokDuration:
+		return fmt.Sprintf("%s %q", t.typ, t.val)
+	}
+	return fmt.Sprintf("%q", t.val)
+}
+
+// lex splits input into tokens.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	// Within brackets a colon separates the range and step of a subquery
+	// rather than being part of a metric name.
+	brackets := 0
+	emit := func(typ tokenType, start int) {
+		tokens = append(tokens, token{typ: typ, val: input[start:pos], pos: start})
+	}
+
+	for pos < len(input) {
+		r, w := utf8.DecodeRuneInString(input[pos:])
+		start := pos
+
+		switch {
+		case unicode.IsSpace(r):
+			pos += w
+			continue
+		case r == '#':
+			// Comments run to the end of the line.
+			if i := strings.IndexByte(input[pos:], '\n'); i >= 0 {
+				pos += i
+			} else {
+				pos = len(input)
+			}
+			continue
+		case r == '"' || r == '\'' || r == '`':
+			s, n, err := lexString(input[pos:])
+			if err != nil {
+				return nil, &ParseError{Pos: pos, Err: err.Error()}
+			}
+			pos += n
+			tokens = append(tokens, token{typ: tokString, val: s, pos: start})
+			continue
+		case isDigit(r) || (r == '.' && pos+1 < len(input) && isDigit(rune(input[pos+1]))):
+			typ, n := lexNumberOrDuration(input[pos:])
+			pos += n
+			emit(typ, start)
+			continue
+		case isAlpha(r) || (r == ':' && brackets == 0):
+			for pos < len(input) {
+				r, w := utf8.DecodeRuneInString(input[pos:])
+				if !isAlpha(r) && !isDigit(r) && (r != ':' || brackets > 0) {
+					break
+				}
+				pos += w
+			}
+			if typ, ok := keywordOperators[strings.ToLower(input[start:pos])]; ok {
+				emit(typ, start)
+			} else {
+				emit(tokIdentifier, start)
+			}
+			continue
+		}
+
+		// Operators and punctuation.
+		two := ""
+		if pos+2 <= len(input) {
+			two = input[pos : pos+2]
+		}
+		switch two {
+		case "!=":
+			pos += 2
+			emit(tokNotEq, start)
+			continue
+		case "=~":
+			pos += 2
+			emit(tokRegex, start)
+			continue
+		case "!~":
+			pos += 2
+			emit(tokNotRe, start)
+			continue
+		case "==":
+			pos += 2
+			emit(tokEqlC, start)
+			continue
+		case ">=":
+			pos += 2
+			emit(tokGte, start)
+			continue
+		case "<=":
+			pos += 2
+			emit(tokLte, start)
+			continue
+		}
+
+		var typ tokenType
+		switch r {
+		case '(':
+			typ = tokLeftParen
+		case ')':
+			typ = tokRightParen
+		case '{':
+			typ = tokLeftBrace
+		case '}':
+			typ = tokRightBrace
+		case '[':
+			typ = tokLeftBracket
+			brackets++
+		case ']':
+			typ = tokRightBracket
+			brackets--
+		case ':':
+			typ = tokColon
+		case ',':
+			typ = tokComma
+		case '@':
+			typ = tokAt
+		case '=':
+			typ = tokAssign
+		case '+':
+			typ = tokAdd
+		case '-':
+			typ = tokSub
+		case '*':
+			typ = tokMul
+		case '/':
+			typ = tokDiv
+		case '%':
+			typ = tokMod
+		case '^':
+			typ = tokPow
+		case '>':
+			typ = tokGtr
+		case '<':
+			typ = tokLss
+		default:
+			return nil, &ParseError{Pos: pos, Err: fmt.Sprintf("unexpected character %q", r)}
+		}
+		pos += w
+		emit(typ, start)
+	}
+
+	tokens = append(tokens, token{typ: tokEOF, pos: len(input)})
+	return tokens, nil
+}
+
+// lexString returns the unquoted value of the string at the start of s and
+// the number of bytes it spans.
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case '\n':
+			if quote != '`' {
+				return "", 0, fmt.Errorf("unterminated quoted string")
+			}
+		case quote:
+			lit := s[:i+1]
+			if quote == '\'' {
+				// strconv only unquotes single characters in single quotes.
+				lit = `"` + strings.Replace(strings.Replace(lit[1:len(lit)-1], `\'`, `'`, -1), `"`, `\"`, -1) + `"`
+			}
+			v, err := strconv.Unquote(lit)
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid quoted string %s", s[:i+1])
+			}
+			return v, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+// lexNumberOrDuration returns the type and length of the number or
+// duration at the start of s.
+func lexNumberOrDuration(s string) (tokenType, int) {
+	// Hexadecimal numbers.
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		i := 2
+		for i < len(s) && strings.IndexByte("0123456789abcdefABCDEF", s[i]) >= 0 {
+			i++
+		}
+		return tokNumber, i
+	}
+
+	// Durations are sequences of integers and units, such as 1h30m.
+	if n := durationPrefix(s); n > 0 {
+		return tokDuration, n
+	}
+
+	i := 0
+	for i < len(s) && isDigit(rune(s[i])) {
+		i++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for i < len(s) && isDigit(rune(s[i])) {
+			i++
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(rune(s[j])) {
+			i = j
+			for i < len(s) && isDigit(rune(s[i])) {
+				i++
+			}
+		}
+	}
+	return tokNumber, i
+}
+
+// durationPrefix returns the length of the duration at the start of s, or
+// zero if s does not start with one.
+func durationPrefix(s string) int {
+	i := 0
+	for {
+		j := i
+		for j < len(s) && isDigit(rune(s[j])) {
+			j++
+		}
+		if j == i {
+			return i
+		}
+
+		unit := 0
+		switch {
+		case strings.HasPrefix(s[j:], "ms"):
+			unit = 2
+		case j < len(s) && strings.IndexByte("smhdwy", s[j]) >= 0:
+			unit = 1
+		}
+		if unit == 0 {
+			return i
+		}
+
+		// A unit must not be followed by more identifier characters.
+		k := j + unit
+		if k < len(s) && (isAlpha(rune(s[k])) && !isDigit(rune(s[k]))) {
+			return i
+		}
+		i = k
+	}
+}
+
+func isDigit(r rune) bool { return '0' <= r && r <= '9' }
+
+func isAlpha(r rune) bool {
+	return r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
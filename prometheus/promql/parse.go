@@ -0,0 +1,656 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseError is returned when an expression cannot be parsed.
+type ParseError struct {
+	Pos int
+	Err string
+}
+
+// Error implements error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error at char %d: %s", e.Pos+1, e.Err)
+}
+
+// aggregators are the aggregation operators, and whether they take a
+// parameter.
+var aggregators = map[string]bool{
+	"sum":          false,
+	"avg":          false,
+	"count":        false,
+	"min":          false,
+	"max":          false,
+	"group":        false,
+	"stddev":       false,
+	"stdvar":       false,
+	"topk":         true,
+	"bottomk":      true,
+	"quantile":     true,
+	"count_values": true,
+}
+
+// ParseExpr parses a PromQL expression.
+func ParseExpr(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.typ != tokEOF {
+		return nil, p.errorf(tok, "unexpected %s", tok)
+	}
+	return expr, nil
+}
+
+// ParseMetricSelector parses a single vector selector, such as the
+// match[] parameters of the series API.
+func ParseMetricSelector(input string) ([]*Matcher, error) {
+	expr, err := ParseExpr(input)
+	if err != nil {
+		return nil, err
+	}
+	vs, ok := expr.(*VectorSelector)
+	if !ok {
+		return nil, fmt.Errorf("expected a vector selector, got %s", input)
+	}
+	if vs.Offset != 0 {
+		return nil, fmt.Errorf("offset is not allowed in a series selector")
+	}
+	return vs.Matchers, nil
+}
+
+// ParseDuration parses a PromQL duration, such as 1h30m.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" || durationPrefix(s) != len(s) {
+		return 0, fmt.Errorf("not a valid duration string: %q", s)
+	}
+
+	var d time.Duration
+	for i := 0; i < len(s); {
+		j := i
+		for j < len(s) && isDigit(rune(s[j])) {
+			j++
+		}
+		n, err := strconv.ParseInt(s[i:j], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("not a valid duration string: %q", s)
+		}
+
+		var unit time.Duration
+		switch {
+		case strings.HasPrefix(s[j:], "ms"):
+			unit, j = time.Millisecond, j+2
+		case s[j] == 's':
+			unit, j = time.Second, j+1
+		case s[j] == 'm':
+			unit, j = time.Minute, j+1
+		case s[j] == 'h':
+			unit, j = time.Hour, j+1
+		case s[j] == 'd':
+			unit, j = 24*time.Hour, j+1
+		case s[j] == 'w':
+			unit, j = 7*24*time.Hour, j+1
+		case s[j] == 'y':
+			unit, j = 365*24*time.Hour, j+1
+		}
+		if n > int64(math.MaxInt64/unit) {
+			return 0, fmt.Errorf("duration out of range: %q", s)
+		}
+		d += time.Duration(n) * unit
+		i = j
+	}
+	return d, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.typ != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// peekKeyword reports whether the next token is the identifier kw.
+func (p *parser) peekKeyword(kw string) bool {
+	tok := p.peek()
+	return tok.typ == tokIdentifier && strings.EqualFold(tok.val, kw)
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return &ParseError{Pos: tok.pos, Err: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expect(typ tokenType, context string) (token, error) {
+	tok := p.next()
+	if tok.typ != typ {
+		return tok, p.errorf(tok, "unexpected %s in %s, expected %s", tok, context, typ)
+	}
+	return tok, nil
+}
+
+// parseExpr parses a binary expression of operators with at least the
+// precedence minPrec.
+func (p *parser) parseExpr(minPrec int) (Expr, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		opTok := p.peek()
+		prec := precedence(opTok.typ)
+		if prec == 0 || prec < minPrec {
+			return lhs, nil
+		}
+		p.next()
+
+		be := &BinaryExpr{Op: opTok.typ}
+		if p.peekKeyword("bool") {
+			if !isComparison(be.Op) {
+				return nil, p.errorf(p.peek(), "bool modifier can only be used on comparison operators")
+			}
+			p.next()
+			be.ReturnBool = true
+		}
+
+		var matching *VectorMatching
+		if p.peekKeyword("on") || p.peekKeyword("ignoring") {
+			matching = &VectorMatching{Card: CardOneToOne, On: p.peekKeyword("on")}
+			p.next()
+			if matching.MatchingLabels, err = p.parseLabels(); err != nil {
+				return nil, err
+			}
+
+			if p.peekKeyword("group_left") || p.peekKeyword("group_right") {
+				tok := p.next()
+				if isSetOperator(be.Op) {
+					return nil, p.errorf(tok, "no grouping allowed for %q operation", be.Op)
+				}
+				matching.Card = CardManyToOne
+				if strings.EqualFold(tok.val, "group_right") {
+					matching.Card = CardOneToMany
+				}
+				if p.peek().typ == tokLeftParen {
+					if matching.Include, err = p.parseLabels(); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		// Exponentiation is right associative.
+		nextPrec := prec + 1
+		if be.Op == tokPow {
+			nextPrec = prec
+		}
+		rhs, err := p.parseExpr(nextPrec)
+		if err != nil {
+			return nil, err
+		}
+		be.LHS, be.RHS = lhs, rhs
+
+		if err := p.checkBinary(be, matching, opTok); err != nil {
+			return nil, err
+		}
+		lhs = be
+	}
+}
+
+func (p *parser) checkBinary(be *BinaryExpr, matching *VectorMatching, tok token) error {
+	lt, rt := be.LHS.Type(), be.RHS.Type()
+	for _, t := range []ValueType{lt, rt} {
+		if t != ValueTypeScalar && t != ValueTypeVector {
+			return p.errorf(tok, "binary expression must contain only scalar and instant vector types")
+		}
+	}
+
+	bothVectors := lt == ValueTypeVector && rt == ValueTypeVector
+	switch {
+	case isSetOperator(be.Op) && !bothVectors:
+		return p.errorf(tok, "set operator %q not allowed in binary scalar expression", be.Op)
+	case isComparison(be.Op) && !be.ReturnBool && lt == ValueTypeScalar && rt == ValueTypeScalar:
+		return p.errorf(tok, "comparisons between scalars must use BOOL modifier")
+	case matching != nil && !bothVectors:
+		return p.errorf(tok, "vector matching only allowed between instant vectors")
+	}
+
+	if bothVectors {
+		if matching == nil {
+			matching = &VectorMatching{Card: CardOneToOne}
+		}
+		if isSetOperator(be.Op) {
+			matching.Card = CardManyToMany
+		}
+		be.VectorMatching = matching
+	}
+	return nil
+}
+
+// parseLabels parses a parenthesized list of label names.
+func (p *parser) parseLabels() ([]string, error) {
+	if _, err := p.expect(tokLeftParen, "grouping opts"); err != nil {
+		return nil, err
+	}
+
+	labels := []string{}
+	for {
+		tok := p.next()
+		switch tok.typ {
+		case tokRightParen:
+			return labels, nil
+		case tokIdentifier, tokLand, tokLor, tokLunless, tokAtan2:
+			labels = append(labels, tok.val)
+		default:
+			return nil, p.errorf(tok, "unexpected %s in grouping opts, expected label", tok)
+		}
+
+		tok = p.next()
+		switch tok.typ {
+		case tokRightParen:
+			return labels, nil
+		case tokComma:
+		default:
+			return nil, p.errorf(tok, "unexpected %s in grouping opts, expected \",\" or \")\"", tok)
+		}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	if tok.typ != tokAdd && tok.typ != tokSub {
+		return p.parsePostfix()
+	}
+	p.next()
+
+	// Unary operators bind less tightly than exponentiation.
+	expr, err := p.parseExpr(precedence(tokPow))
+	if err != nil {
+		return nil, err
+	}
+	if t := expr.Type(); t != ValueTypeScalar && t != ValueTypeVector {
+		return nil, p.errorf(tok, "unary expression only allowed on expressions of type scalar or instant vector, got %q", t)
+	}
+
+	if tok.typ == tokAdd {
+		return expr, nil
+	}
+	if n, ok := expr.(*NumberLiteral); ok {
+		return &NumberLiteral{Val: -n.Val}, nil
+	}
+	return &UnaryExpr{Op: tokSub, Expr: expr}, nil
+}
+
+func (p *parser) parsePostfix() (Expr, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		switch {
+		case tok.typ == tokLeftBracket:
+			if expr, err = p.parseRange(expr); err != nil {
+				return nil, err
+			}
+		case p.peekKeyword("offset"):
+			p.next()
+			if expr, err = p.parseOffset(expr, tok); err != nil {
+				return nil, err
+			}
+		case tok.typ == tokAt:
+			return nil, p.errorf(tok, "@ modifier is not supported")
+		default:
+			return expr, nil
+		}
+	}
+}
+
+// parseRange parses the range of a matrix selector or subquery following
+// expr.
+func (p *parser) parseRange(expr Expr) (Expr, error) {
+	open := p.next()
+	rng, err := p.parseDurationToken("range")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().typ == tokColon {
+		p.next()
+		var step time.Duration
+		if p.peek().typ == tokDuration {
+			if step, err = p.parseDurationToken("subquery step"); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := p.expect(tokRightBracket, "subquery selector"); err != nil {
+			return nil, err
+		}
+		if expr.Type() != ValueTypeVector {
+			return nil, p.errorf(open, "subquery is only allowed on instant vector, got %q", expr.Type())
+		}
+		return &SubqueryExpr{Expr: expr, Range: rng, Step: step}, nil
+	}
+
+	if _, err := p.expect(tokRightBracket, "matrix selector"); err != nil {
+		return nil, err
+	}
+	vs, ok := expr.(*VectorSelector)
+	if !ok {
+		return nil, p.errorf(open, "ranges only allowed for vector selectors")
+	} else if vs.Offset != 0 {
+		return nil, p.errorf(open, "no offset modifiers allowed before range")
+	}
+	return &MatrixSelector{VectorSelector: vs, Range: rng}, nil
+}
+
+func (p *parser) parseOffset(expr Expr, tok token) (Expr, error) {
+	neg := false
+	if p.peek().typ == tokSub {
+		p.next()
+		neg = true
+	}
+	offset, err := p.parseDurationToken("offset")
+	if err != nil {
+		return nil, err
+	}
+	if neg {
+		offset = -offset
+	}
+
+	var cur *time.Duration
+	switch e := expr.(type) {
+	case *VectorSelector:
+		cur = &e.Offset
+	case *MatrixSelector:
+		cur = &e.VectorSelector.Offset
+	case *SubqueryExpr:
+		cur = &e.Offset
+	default:
+		return nil, p.errorf(tok, "offset modifier must be preceded by an instant vector selector or range vector selector or a subquery")
+	}
+	if *cur != 0 {
+		return nil, p.errorf(tok, "offset may not be set multiple times")
+	}
+	*cur = offset
+	return expr, nil
+}
+
+func (p *parser) parseDurationToken(context string) (time.Duration, error) {
+	tok, err := p.expect(tokDuration, context)
+	if err != nil {
+		return 0, err
+	}
+	d, err := ParseDuration(tok.val)
+	if err != nil {
+		return 0, p.errorf(tok, "%s", err)
+	}
+	if d <= 0 {
+		return 0, p.errorf(tok, "duration must be greater than 0")
+	}
+	return d, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.typ {
+	case tokNumber:
+		v, err := parseNumber(tok.val)
+		if err != nil {
+			return nil, p.errorf(tok, "%s", err)
+		}
+		return &NumberLiteral{Val: v}, nil
+
+	case tokString:
+		return &StringLiteral{Val: tok.val}, nil
+
+	case tokLeftParen:
+		expr, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRightParen, "paren expression"); err != nil {
+			return nil, err
+		}
+		return &ParenExpr{Expr: expr}, nil
+
+	case tokLeftBrace:
+		return p.parseSelector(tok, "")
+
+	case tokIdentifier:
+		name := tok.val
+		if _, ok := aggregators[strings.ToLower(name)]; ok && (p.peek().typ == tokLeftParen || p.peekKeyword("by") || p.peekKeyword("without")) {
+			return p.parseAggregate(tok)
+		}
+		if p.peek().typ == tokLeftParen {
+			return p.parseCall(tok)
+		}
+		switch strings.ToLower(name) {
+		case "inf":
+			return &NumberLiteral{Val: math.Inf(1)}, nil
+		case "nan":
+			return &NumberLiteral{Val: math.NaN()}, nil
+		}
+
+		if p.peek().typ == tokLeftBrace {
+			return p.parseSelector(p.next(), name)
+		}
+		return p.newSelector(tok, name, nil)
+	}
+	return nil, p.errorf(tok, "unexpected %s", tok)
+}
+
+func parseNumber(s string) (float64, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		n, err := strconv.ParseInt(s, 0, 64)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing number: %s", err)
+		}
+		return float64(n), nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing number: %s", err)
+	}
+	return v, nil
+}
+
+// parseSelector parses the label matchers of a vector selector following
+// the opening brace.
+func (p *parser) parseSelector(open token, name string) (Expr, error) {
+	var matchers []*Matcher
+	for {
+		tok := p.next()
+		if tok.typ == tokRightBrace {
+			break
+		}
+		if tok.typ != tokIdentifier && tok.typ != tokLand && tok.typ != tokLor && tok.typ != tokLunless && tok.typ != tokAtan2 {
+			return nil, p.errorf(tok, "unexpected %s in label matching, expected label", tok)
+		}
+
+		opTok := p.next()
+		var typ MatchType
+		switch opTok.typ {
+		case tokAssign:
+			typ = MatchEqual
+		case tokNotEq:
+			typ = MatchNotEqual
+		case tokRegex:
+			typ = MatchRegexp
+		case tokNotRe:
+			typ = MatchNotRegexp
+		default:
+			return nil, p.errorf(opTok, "unexpected %s in label matching, expected label matching operator", opTok)
+		}
+
+		val, err := p.expect(tokString, "label matching")
+		if err != nil {
+			return nil, err
+		}
+		m, err := NewMatcher(typ, tok.val, val.val)
+		if err != nil {
+			return nil, p.errorf(val, "%s", err)
+		}
+		matchers = append(matchers, m)
+
+		tok = p.next()
+		if tok.typ == tokRightBrace {
+			break
+		} else if tok.typ != tokComma {
+			return nil, p.errorf(tok, "unexpected %s in label matching, expected \",\" or \"}\"", tok)
+		}
+	}
+	return p.newSelector(open, name, matchers)
+}
+
+func (p *parser) newSelector(tok token, name string, matchers []*Matcher) (Expr, error) {
+	if name != "" {
+		for _, m := range matchers {
+			if m.Name == MetricNameLabel {
+				return nil, p.errorf(tok, "metric name must not be set twice: %q or %q", name, m.Value)
+			}
+		}
+		m, _ := NewMatcher(MatchEqual, MetricNameLabel, name)
+		matchers = append([]*Matcher{m}, matchers...)
+	}
+
+	// A selector must not match every series.
+	nonEmpty := false
+	for _, m := range matchers {
+		if !m.Matches("") {
+			nonEmpty = true
+			break
+		}
+	}
+	if !nonEmpty {
+		return nil, p.errorf(tok, "vector selector must contain at least one non-empty matcher")
+	}
+	return &VectorSelector{Name: name, Matchers: matchers}, nil
+}
+
+func (p *parser) parseAggregate(opTok token) (Expr, error) {
+	agg := &AggregateExpr{Op: strings.ToLower(opTok.val)}
+
+	parseGrouping := func() error {
+		agg.Without = p.peekKeyword("without")
+		p.next()
+		labels, err := p.parseLabels()
+		agg.Grouping = labels
+		return err
+	}
+
+	grouped := false
+	if p.peekKeyword("by") || p.peekKeyword("without") {
+		if err := parseGrouping(); err != nil {
+			return nil, err
+		}
+		grouped = true
+	}
+
+	if _, err := p.expect(tokLeftParen, "aggregation"); err != nil {
+		return nil, err
+	}
+	if aggregators[agg.Op] {
+		param, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokComma, "aggregation"); err != nil {
+			return nil, err
+		}
+		agg.Param = param
+	}
+	expr, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokRightParen, "aggregation"); err != nil {
+		return nil, err
+	}
+	agg.Expr = expr
+
+	if !grouped && (p.peekKeyword("by") || p.peekKeyword("without")) {
+		if err := parseGrouping(); err != nil {
+			return nil, err
+		}
+	}
+
+	if t := expr.Type(); t != ValueTypeVector {
+		return nil, p.errorf(opTok, "expected type instant vector in aggregation expression, got %s", t)
+	}
+	switch agg.Op {
+	case "topk", "bottomk", "quantile":
+		if t := agg.Param.Type(); t != ValueTypeScalar {
+			return nil, p.errorf(opTok, "expected type scalar in aggregation parameter, got %s", t)
+		}
+	case "count_values":
+		if t := agg.Param.Type(); t != ValueTypeString {
+			return nil, p.errorf(opTok, "expected type string in aggregation parameter, got %s", t)
+		}
+	}
+	return agg, nil
+}
+
+func (p *parser) parseCall(nameTok token) (Expr, error) {
+	fn, ok := functions[nameTok.val]
+	if !ok {
+		return nil, p.errorf(nameTok, "unknown function with name %q", nameTok.val)
+	}
+	p.next() // (
+
+	var args []Expr
+	if p.peek().typ != tokRightParen {
+		for {
+			arg, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.peek().typ != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if _, err := p.expect(tokRightParen, "function call"); err != nil {
+		return nil, err
+	}
+
+	min, max := len(fn.ArgTypes)-fn.Optional, len(fn.ArgTypes)
+	if fn.Variadic {
+		max = -1
+	}
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return nil, p.errorf(nameTok, "wrong number of arguments for function %q", fn.Name)
+	}
+	for i, arg := range args {
+		want := fn.ArgTypes[len(fn.ArgTypes)-1]
+		if i < len(fn.ArgTypes) {
+			want = fn.ArgTypes[i]
+		}
+		if got := arg.Type(); got != want {
+			return nil, p.errorf(nameTok, "expected type %s in call to function %q, got %s", want, fn.Name, got)
+		}
+	}
+	return &Call{Func: fn, Args: args}, nil
+}
@@ -0,0 +1,180 @@
+package promql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseExpr(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		exp   Expr
+	}{
+		{
+			input: "1 + 2 * 3",
+			exp: &BinaryExpr{
+				Op:  tokAdd,
+				LHS: &NumberLiteral{Val: 1},
+				RHS: &BinaryExpr{Op: tokMul, LHS: &NumberLiteral{Val: 2}, RHS: &NumberLiteral{Val: 3}},
+			},
+		},
+		{
+			input: "2 ^ 3 ^ 2",
+			exp: &BinaryExpr{
+				Op:  tokPow,
+				LHS: &NumberLiteral{Val: 2},
+				RHS: &BinaryExpr{Op: tokPow, LHS: &NumberLiteral{Val: 3}, RHS: &NumberLiteral{Val: 2}},
+			},
+		},
+		{
+			input: "-2 ^ 2",
+			exp: &UnaryExpr{
+				Op:   tokSub,
+				Expr: &BinaryExpr{Op: tokPow, LHS: &NumberLiteral{Val: 2}, RHS: &NumberLiteral{Val: 2}},
+			},
+		},
+		{
+			input: `foo{a="b", c!~"d.*"}[5m] offset 1m`,
+			exp: &MatrixSelector{
+				VectorSelector: &VectorSelector{
+					Name: "foo",
+					Matchers: []*Matcher{
+						mustNewMatcher(MatchEqual, MetricNameLabel, "foo"),
+						mustNewMatcher(MatchEqual, "a", "b"),
+						mustNewMatcher(MatchNotRegexp, "c", "d.*"),
+					},
+					Offset: time.Minute,
+				},
+				Range: 5 * time.Minute,
+			},
+		},
+		{
+			input: "sum without (a) (foo)",
+			exp: &AggregateExpr{
+				Op:       "sum",
+				Expr:     &VectorSelector{Name: "foo", Matchers: []*Matcher{mustNewMatcher(MatchEqual, MetricNameLabel, "foo")}},
+				Grouping: []string{"a"},
+				Without:  true,
+			},
+		},
+		{
+			input: "topk(3, foo) by (a)",
+			exp: &AggregateExpr{
+				Op:       "topk",
+				Param:    &NumberLiteral{Val: 3},
+				Expr:     &VectorSelector{Name: "foo", Matchers: []*Matcher{mustNewMatcher(MatchEqual, MetricNameLabel, "foo")}},
+				Grouping: []string{"a"},
+			},
+		},
+		{
+			input: "a / on (b) group_left (c) d",
+			exp: &BinaryExpr{
+				Op:  tokDiv,
+				LHS: &VectorSelector{Name: "a", Matchers: []*Matcher{mustNewMatcher(MatchEqual, MetricNameLabel, "a")}},
+				RHS: &VectorSelector{Name: "d", Matchers: []*Matcher{mustNewMatcher(MatchEqual, MetricNameLabel, "d")}},
+				VectorMatching: &VectorMatching{
+					Card:           CardManyToOne,
+					MatchingLabels: []string{"b"},
+					On:             true,
+					Include:        []string{"c"},
+				},
+			},
+		},
+		{
+			input: "rate(foo[5m])[30m:1m]",
+			exp: &SubqueryExpr{
+				Expr: &Call{
+					Func: functions["rate"],
+					Args: []Expr{&MatrixSelector{
+						VectorSelector: &VectorSelector{Name: "foo", Matchers: []*Matcher{mustNewMatcher(MatchEqual, MetricNameLabel, "foo")}},
+						Range:          5 * time.Minute,
+					}},
+				},
+				Range: 30 * time.Minute,
+				Step:  time.Minute,
+			},
+		},
+		{
+			input: "0x1F > bool 3",
+			exp:   &BinaryExpr{Op: tokGtr, LHS: &NumberLiteral{Val: 31}, RHS: &NumberLiteral{Val: 3}, ReturnBool: true},
+		},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseExpr(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("unexpected expression:\ngot %#v\nexp %#v", got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestParseExpr_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		err   string
+	}{
+		{input: "", err: "unexpected end of input"},
+		{input: "sum(", err: "unexpected end of input"},
+		{input: `{a=""}`, err: "vector selector must contain at least one non-empty matcher"},
+		{input: `foo{__name__="bar"}`, err: "metric name must not be set twice"},
+		{input: "rate(foo)", err: `expected type matrix in call to function "rate", got vector`},
+		{input: "nope(foo)", err: `unknown function with name "nope"`},
+		{input: "foo @ 1", err: "@"},
+		{input: `"a" + 1`, err: "binary expression must contain only scalar and instant vector types"},
+		{input: "1 and 2", err: "set operator"},
+		{input: `foo{a~"b"}`, err: "unexpected character"},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseExpr(tt.input)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if _, ok := err.(*ParseError); !ok {
+				t.Fatalf("unexpected error type %T", err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		exp   time.Duration
+	}{
+		{input: "30s", exp: 30 * time.Second},
+		{input: "1h30m", exp: 90 * time.Minute},
+		{input: "500ms", exp: 500 * time.Millisecond},
+		{input: "2w", exp: 14 * 24 * time.Hour},
+		{input: "1y", exp: 365 * 24 * time.Hour},
+	} {
+		got, err := ParseDuration(tt.input)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.input, err)
+		}
+		if got != tt.exp {
+			t.Fatalf("%s: got %s, exp %s", tt.input, got, tt.exp)
+		}
+	}
+
+	for _, input := range []string{"", "5", "1.5h", "5x", "h"} {
+		if _, err := ParseDuration(input); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+func mustNewMatcher(typ MatchType, name, value string) *Matcher {
+	m, err := NewMatcher(typ, name, value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
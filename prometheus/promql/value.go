@@ -0,0 +1,224 @@
+package promql
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricNameLabel is the label holding the name of a metric.
+const MetricNameLabel = "__name__"
+
+// ValueType is the type of the result of an expression.
+type ValueType string
+
+// The types of expression results.
+const (
+	ValueTypeNone   ValueType = "none"
+	ValueTypeVector ValueType = "vector"
+	ValueTypeScalar ValueType = "scalar"
+	ValueTypeMatrix ValueType = "matrix"
+	ValueTypeString ValueType = "string"
+)
+
+// Label is a single name/value pair of a series.
+type Label struct {
+	Name, Value string
+}
+
+// Labels is a set of labels sorted by name.
+type Labels []Label
+
+// NewLabels returns the sorted Labels of m. Empty values are dropped, as
+// an empty label is equivalent to a missing one.
+func NewLabels(m map[string]string) Labels {
+	ls := make(Labels, 0, len(m))
+	for k, v := range m {
+		if v == "" {
+			continue
+		}
+		ls = append(ls, Label{Name: k, Value: v})
+	}
+	sort.Sort(ls)
+	return ls
+}
+
+func (ls Labels) Len() int           { return len(ls) }
+func (ls Labels) Less(i, j int) bool { return ls[i].Name < ls[j].Name }
+func (ls Labels) Swap(i, j int)      { ls[i], ls[j] = ls[j], ls[i] }
+
+// Get returns the value of the label name, or an empty string.
+func (ls Labels) Get(name string) string {
+	for _, l := range ls {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// Map returns the labels as a map.
+func (ls Labels) Map() map[string]string {
+	m := make(map[string]string, len(ls))
+	for _, l := range ls {
+		m[l.Name] = l.Value
+	}
+	return m
+}
+
+// String returns the labels in the Prometheus text format.
+func (ls Labels) String() string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, l := range ls {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(l.Name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(l.Value))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// key returns a string which uniquely identifies the label set.
+func (ls Labels) key() string {
+	var b bytes.Buffer
+	for _, l := range ls {
+		b.WriteString(l.Name)
+		b.WriteByte(0xff)
+		b.WriteString(l.Value)
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+// keyOf returns a string identifying the labels with the given names when
+// on is true, or all other labels when on is false.
+func (ls Labels) keyOf(names []string, on bool) string {
+	var b bytes.Buffer
+	for _, l := range ls {
+		if containsString(names, l.Name) != on {
+			continue
+		}
+		b.WriteString(l.Name)
+		b.WriteByte(0xff)
+		b.WriteString(l.Value)
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+// keep returns the labels with the given names when on is true, or
+// without them when on is false.
+func (ls Labels) keep(names []string, on bool) Labels {
+	res := make(Labels, 0, len(ls))
+	for _, l := range ls {
+		if containsString(names, l.Name) == on {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// dropMetricName returns the labels without the metric name.
+func (ls Labels) dropMetricName() Labels {
+	return ls.keep([]string{MetricNameLabel}, false)
+}
+
+// set returns a copy of the labels with name set to value. An empty value
+// removes the label.
+func (ls Labels) set(name, value string) Labels {
+	res := make(Labels, 0, len(ls)+1)
+	for _, l := range ls {
+		if l.Name != name {
+			res = append(res, l)
+		}
+	}
+	if value != "" {
+		res = append(res, Label{Name: name, Value: value})
+		sort.Sort(res)
+	}
+	return res
+}
+
+// IsValidLabelName reports whether s is a valid Prometheus label name.
+func IsValidLabelName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !isAlpha(r) && (i == 0 || !isDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Point is a single sample of a series, with the time in milliseconds.
+type Point struct {
+	T int64
+	V float64
+}
+
+// Series is a series of points with its labels.
+type Series struct {
+	Metric Labels
+	Points []Point
+}
+
+// Sample is a single point of a series at the evaluation time.
+type Sample struct {
+	Point
+	Metric Labels
+}
+
+// Value is the result of evaluating an expression.
+type Value interface {
+	Type() ValueType
+}
+
+// Vector is a set of samples at the same time.
+type Vector []Sample
+
+// Type implements Value.
+func (Vector) Type() ValueType { return ValueTypeVector }
+
+// Matrix is a set of series.
+type Matrix []Series
+
+// Type implements Value.
+func (Matrix) Type() ValueType { return ValueTypeMatrix }
+
+func (m Matrix) Len() int           { return len(m) }
+func (m Matrix) Less(i, j int) bool { return m[i].Metric.key() < m[j].Metric.key() }
+func (m Matrix) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+
+// Scalar is a single number at a time.
+type Scalar struct {
+	T int64
+	V float64
+}
+
+// Type implements Value.
+func (Scalar) Type() ValueType { return ValueTypeScalar }
+
+// String is a string at a time.
+type String struct {
+	T int64
+	V string
+}
+
+// Type implements Value.
+func (String) Type() ValueType { return ValueTypeString }
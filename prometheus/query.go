@@ -0,0 +1,177 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/promql"
+	"github.com/influxdata/influxdb/prometheus/remote"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"go.uber.org/zap"
+)
+
+// Reader reads series from the storage engine.
+type Reader interface {
+	ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error)
+}
+
+// Queryable reads the series of a retention policy for the PromQL engine.
+// Series are read as they are written by the remote write API: labels are
+// tags and the sample value is the "value" field.
+type Queryable struct {
+	Reader          Reader
+	Database        string
+	RetentionPolicy string
+	Logger          *zap.Logger
+}
+
+// NewQueryable returns a Queryable reading the series of db and rp from r.
+func NewQueryable(r Reader, db, rp string, logger *zap.Logger) *Queryable {
+	return &Queryable{
+		Reader:          r,
+		Database:        db,
+		RetentionPolicy: rp,
+		Logger:          logger,
+	}
+}
+
+// Select implements promql.Queryable.
+func (q *Queryable) Select(ctx context.Context, mint, maxt int64, matchers []*promql.Matcher) ([]promql.Series, error) {
+	var series []promql.Series
+	index := make(map[string]int)
+	err := q.read(ctx, mint, maxt, matchers, func(labels promql.Labels, cur cursors.FloatArrayCursor) error {
+		var points []promql.Point
+		for {
+			a := cur.Next()
+			if a.Len() == 0 {
+				break
+			}
+			for i, ts := range a.Timestamps {
+				points = append(points, promql.Point{T: ts / int64(time.Millisecond), V: a.Values[i]})
+			}
+		}
+		if len(points) == 0 {
+			return nil
+		}
+
+		// Series written with and without a __name__ tag may map to the
+		// same labels.
+		key := labels.String()
+		if i, ok := index[key]; ok {
+			s := &series[i]
+			s.Points = append(s.Points, points...)
+			sort.SliceStable(s.Points, func(i, j int) bool { return s.Points[i].T < s.Points[j].T })
+			return nil
+		}
+		index[key] = len(series)
+		series = append(series, promql.Series{Metric: labels, Points: points})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// Series returns the labels of the series matching all of matchers which
+// have samples between mint and maxt.
+func (q *Queryable) Series(ctx context.Context, mint, maxt int64, matchers []*promql.Matcher) ([]promql.Labels, error) {
+	var series []promql.Labels
+	seen := make(map[string]struct{})
+	err := q.read(ctx, mint, maxt, matchers, func(labels promql.Labels, cur cursors.FloatArrayCursor) error {
+		if cur.Next().Len() == 0 {
+			return nil
+		}
+
+		key := labels.String()
+		if _, ok := seen[key]; ok {
+			return nil
+		}
+		seen[key] = struct{}{}
+		series = append(series, labels)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (q *Queryable) read(ctx context.Context, mint, maxt int64, matchers []*promql.Matcher, fn func(promql.Labels, cursors.FloatArrayCursor) error) error {
+	query := &remote.Query{
+		StartTimestampMs: mint,
+		EndTimestampMs:   maxt,
+		Matchers:         make([]*remote.LabelMatcher, 0, len(matchers)),
+	}
+	for _, m := range matchers {
+		lm, err := labelMatcher(m)
+		if err != nil {
+			return err
+		}
+		query.Matchers = append(query.Matchers, lm)
+	}
+
+	req, err := QueryToInfluxStorageRequest(query, q.Database, q.RetentionPolicy)
+	if err != nil {
+		return err
+	}
+
+	rs, err := q.Reader.ReadFilter(ctx, req)
+	if err != nil {
+		return err
+	} else if rs == nil {
+		return nil
+	}
+	defer rs.Close()
+
+	logger := q.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return readFloatCursors(rs, logger, func(tags models.Tags, cur cursors.FloatArrayCursor) error {
+		return fn(SeriesLabels(tags), cur)
+	})
+}
+
+// SeriesLabels returns the Prometheus labels of a series from its tags.
+// The measurement is the metric name for series without a __name__ tag,
+// such as those not written by Prometheus.
+func SeriesLabels(tags models.Tags) promql.Labels {
+	m := make(map[string]string, len(tags))
+	var measurement string
+	for _, t := range tags {
+		switch string(t.Key) {
+		case measurementTagKey:
+			measurement = string(t.Value)
+		case fieldTagKey:
+		default:
+			m[string(t.Key)] = string(t.Value)
+		}
+	}
+	if _, ok := m[prometheusNameTag]; !ok && measurement != "" {
+		m[prometheusNameTag] = measurement
+	}
+	return promql.NewLabels(m)
+}
+
+func labelMatcher(m *promql.Matcher) (*remote.LabelMatcher, error) {
+	var typ remote.MatchType
+	switch m.Type {
+	case promql.MatchEqual:
+		typ = remote.MatchType_EQUAL
+	case promql.MatchNotEqual:
+		typ = remote.MatchType_NOT_EQUAL
+	case promql.MatchRegexp:
+		typ = remote.MatchType_REGEX_MATCH
+	case promql.MatchNotRegexp:
+		typ = remote.MatchType_REGEX_NO_MATCH
+	default:
+		return nil, fmt.Errorf("unknown match type %v", m.Type)
+	}
+	return &remote.LabelMatcher{Type: typ, Name: m.Name, Value: m.Value}, nil
+}
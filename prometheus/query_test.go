@@ -0,0 +1,39 @@
+package prometheus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/promql"
+)
+
+func TestSeriesLabels(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		tags map[string]string
+		exp  promql.Labels
+	}{
+		{
+			name: "prometheus series",
+			tags: map[string]string{"__name__": "up", "_measurement": "up", "_field": "value", "job": "api"},
+			exp:  promql.Labels{{Name: "__name__", Value: "up"}, {Name: "job", Value: "api"}},
+		},
+		{
+			name: "measurement as name",
+			tags: map[string]string{"_measurement": "cpu", "_field": "value", "host": "a"},
+			exp:  promql.Labels{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "a"}},
+		},
+		{
+			name: "empty tag",
+			tags: map[string]string{"__name__": "up", "_measurement": "up", "job": ""},
+			exp:  promql.Labels{{Name: "__name__", Value: "up"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeriesLabels(models.NewTags(tt.tags)); !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("unexpected labels: got %s, exp %s", got, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package prometheus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus/remote"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"go.uber.org/zap"
+)
+
+const (
+	// StreamedContentType is the content type of a STREAMED_XOR_CHUNKS response.
+	StreamedContentType = "application/x-streamed-protobuf; proto=prometheus.ChunkedReadResponse"
+
+	// seriesFunc is the read hint function name Prometheus uses when only
+	// the labels of matching series are required.
+	seriesFunc = "series"
+)
+
+// ErrReadLimitExceeded is returned when a remote read request would return
+// more data than its ReadLimit allows.
+var ErrReadLimitExceeded = errors.New("prometheus remote read exceeded the maximum response size")
+
+// NegotiateResponseType returns the first response type in accepted that is
+// supported. An empty list selects SAMPLES, as in Prometheus.
+func NegotiateResponseType(accepted []remote.ReadRequest_ResponseType) (remote.ReadRequest_ResponseType, error) {
+	if len(accepted) == 0 {
+		return remote.ReadRequest_SAMPLES, nil
+	}
+	for _, typ := range accepted {
+		switch typ {
+		case remote.ReadRequest_SAMPLES, remote.ReadRequest_STREAMED_XOR_CHUNKS:
+			return typ, nil
+		}
+	}
+	return 0, fmt.Errorf("server does not support any of the requested response types: %v", accepted)
+}
+
+// ReadLimit is the byte budget of a single remote read request. A nil
+// ReadLimit or one with a non-positive maximum does not limit reads.
+type ReadLimit struct {
+	max int64
+	n   int64
+}
+
+// NewReadLimit returns a ReadLimit allowing max bytes.
+func NewReadLimit(max int64) *ReadLimit {
+	return &ReadLimit{max: max}
+}
+
+// add charges n bytes to the budget.
+func (l *ReadLimit) add(n int) error {
+	if l == nil || l.max <= 0 {
+		return nil
+	}
+	l.n += int64(n)
+	if l.n > l.max {
+		return ErrReadLimitExceeded
+	}
+	return nil
+}
+
+// ReadQueryResult reads the series of rs into the QueryResult of a SAMPLES
+// response.
+//
+// The read hints of the query are respected where it is safe to do so: the
+// "series" function returns labels without samples. The step and range of
+// the hints describe the outermost evaluation only and do not account for
+// subqueries, so they are not used to skip samples.
+func ReadQueryResult(rs reads.ResultSet, hints *remote.ReadHints, limit *ReadLimit, logger *zap.Logger) (*remote.QueryResult, error) {
+	result := &remote.QueryResult{}
+	if rs == nil {
+		return result, nil
+	}
+
+	err := readSeries(rs, logger, func(labels []*remote.LabelPair, cur cursors.FloatArrayCursor) error {
+		var series *remote.TimeSeries
+		for {
+			a := cur.Next()
+			if a.Len() == 0 {
+				break
+			}
+
+			// We have some data for this series.
+			if series == nil {
+				series = &remote.TimeSeries{Labels: labels}
+			}
+			if hints.GetFunc() == seriesFunc {
+				break
+			}
+
+			for i, ts := range a.Timestamps {
+				series.Samples = append(series.Samples, &remote.Sample{
+					TimestampMs: ts / int64(time.Millisecond),
+					Value:       a.Values[i],
+				})
+			}
+		}
+
+		// There was data for the series.
+		if series == nil {
+			return nil
+		}
+		if err := limit.add(series.Size()); err != nil {
+			return err
+		}
+		result.Timeseries = append(result.Timeseries, series)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StreamChunkedReadResponses writes the series of rs to w as
+// ChunkedReadResponse messages for the query at queryIndex. The samples of
+// each series are encoded as XOR chunks and a series is split across
+// several frames once it exceeds maxBytesInFrame.
+//
+// Read hints are respected as described by ReadQueryResult.
+func StreamChunkedReadResponses(w *ChunkedWriter, rs reads.ResultSet, queryIndex int64, hints *remote.ReadHints, maxBytesInFrame int, limit *ReadLimit, logger *zap.Logger) error {
+	if rs == nil {
+		return nil
+	}
+
+	writeFrame := func(series *remote.ChunkedSeries) error {
+		b, err := (&remote.ChunkedReadResponse{
+			ChunkedSeries: []*remote.ChunkedSeries{series},
+			QueryIndex:    queryIndex,
+		}).Marshal()
+		if err != nil {
+			return err
+		}
+		if err := limit.add(len(b)); err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+
+	return readSeries(rs, logger, func(labels []*remote.LabelPair, cur cursors.FloatArrayCursor) error {
+		series := &remote.ChunkedSeries{Labels: labels}
+		frameBytes := series.Size()
+
+		var (
+			chunk      *xorChunk
+			minT, maxT int64
+		)
+		flushChunk := func() error {
+			c := &remote.Chunk{
+				MinTimeMs: minT,
+				MaxTimeMs: maxT,
+				Type:      remote.Chunk_XOR,
+				Data:      chunk.Bytes(),
+			}
+			chunk = nil
+			series.Chunks = append(series.Chunks, c)
+			frameBytes += c.Size()
+			if frameBytes < maxBytesInFrame {
+				return nil
+			}
+
+			// Continue the series in the next frame.
+			if err := writeFrame(series); err != nil {
+				return err
+			}
+			series = &remote.ChunkedSeries{Labels: labels}
+			frameBytes = series.Size()
+			return nil
+		}
+
+		var found bool
+		for {
+			a := cur.Next()
+			if a.Len() == 0 {
+				break
+			}
+			found = true
+			if hints.GetFunc() == seriesFunc {
+				break
+			}
+
+			for i, ts := range a.Timestamps {
+				ms := ts / int64(time.Millisecond)
+				if chunk == nil {
+					chunk, minT = newXORChunk(), ms
+				}
+				chunk.Append(ms, a.Values[i])
+				maxT = ms
+
+				if chunk.NumSamples() >= maxSamplesPerChunk {
+					if err := flushChunk(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if chunk != nil {
+			if err := flushChunk(); err != nil {
+				return err
+			}
+		}
+		if !found || (len(series.Chunks) == 0 && hints.GetFunc() != seriesFunc) {
+			return nil
+		}
+		return writeFrame(series)
+	})
+}
+
+// readSeries calls fn with the labels and cursor of each float series of
+// rs. Other field types cannot be read by Prometheus and are skipped.
+func readSeries(rs reads.ResultSet, logger *zap.Logger, fn func(labels []*remote.LabelPair, cur cursors.FloatArrayCursor) error) error {
+	return readFloatCursors(rs, logger, func(tags models.Tags, cur cursors.FloatArrayCursor) error {
+		return fn(ModelTagsToLabelPairs(RemoveInfluxSystemTags(tags)), cur)
+	})
+}
+
+// readFloatCursors calls fn with the tags and cursor of each float series
+// of rs, closing the cursor afterwards. Other field types are logged and
+// skipped.
+func readFloatCursors(rs reads.ResultSet, logger *zap.Logger, fn func(tags models.Tags, cur cursors.FloatArrayCursor) error) error {
+	for rs.Next() {
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		tags := rs.Tags()
+		var unsupportedCursor string
+		switch cur := cur.(type) {
+		case cursors.FloatArrayCursor:
+			err := fn(tags, cur)
+			cur.Close()
+			if err != nil {
+				return err
+			}
+			continue
+		case cursors.IntegerArrayCursor:
+			unsupportedCursor = "int64"
+		case cursors.UnsignedArrayCursor:
+			unsupportedCursor = "uint"
+		case cursors.BooleanArrayCursor:
+			unsupportedCursor = "bool"
+		case cursors.StringArrayCursor:
+			unsupportedCursor = "string"
+		default:
+			panic(fmt.Sprintf("unreachable: %T", cur))
+		}
+		cur.Close()
+
+		logger.Info("Prometheus can't read cursor",
+			zap.String("cursor_type", unsupportedCursor),
+			zap.Stringer("series", tags),
+		)
+	}
+	return rs.Err()
+}
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ChunkedWriter writes the length delimited frames of a streamed remote
+// read response. Each frame is the uvarint size of the message, a big endian
+// CRC32 Castagnoli checksum of the message and the message itself.
+type ChunkedWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+	n       int64
+}
+
+// NewChunkedWriter returns a ChunkedWriter which flushes f after each frame.
+// f may be nil.
+func NewChunkedWriter(w io.Writer, f http.Flusher) *ChunkedWriter {
+	return &ChunkedWriter{w: w, flusher: f}
+}
+
+// Write writes b as a single frame.
+func (w *ChunkedWriter) Write(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	var buf [binary.MaxVarintLen64 + 4]byte
+	n := binary.PutUvarint(buf[:], uint64(len(b)))
+	binary.BigEndian.PutUint32(buf[n:], crc32.Checksum(b, castagnoliTable))
+	n += 4
+
+	if _, err := w.w.Write(buf[:n]); err != nil {
+		return 0, err
+	}
+	if _, err := w.w.Write(b); err != nil {
+		return 0, err
+	}
+	w.n += int64(n + len(b))
+
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+	return len(b), nil
+}
+
+// BytesWritten returns the number of bytes written to the underlying writer,
+// including frame headers.
+func (w *ChunkedWriter) BytesWritten() int64 { return w.n }
@@ -20,6 +20,9 @@ const (
 	// DefaultProtocol is the default IP protocol used by the Graphite input.
 	DefaultProtocol = "tcp"
 
+	// DefaultFormat is the default format of the data received by the Graphite input.
+	DefaultFormat = FormatPlaintext
+
 	// DefaultConsistencyLevel is the default write consistency for the Graphite input.
 	DefaultConsistencyLevel = "one"
 
@@ -50,6 +53,16 @@ const (
 	DefaultUDPReadBuffer = 0
 )
 
+// The formats of the data received by the Graphite input.
+const (
+	// FormatPlaintext is the line-oriented plaintext protocol: name value [timestamp].
+	FormatPlaintext = "plaintext"
+
+	// FormatPickle is the pickle protocol used by carbon-relay. It is only
+	// supported over TCP.
+	FormatPickle = "pickle"
+)
+
 // Config represents the configuration for Graphite endpoints.
 type Config struct {
 	Enabled          bool          `toml:"enabled"`
@@ -57,6 +70,7 @@ type Config struct {
 	Database         string        `toml:"database"`
 	RetentionPolicy  string        `toml:"retention-policy"`
 	Protocol         string        `toml:"protocol"`
+	Format           string        `toml:"format"`
 	BatchSize        int           `toml:"batch-size"`
 	BatchPending     int           `toml:"batch-pending"`
 	BatchTimeout     toml.Duration `toml:"batch-timeout"`
@@ -73,6 +87,7 @@ func NewConfig() Config {
 		BindAddress:      DefaultBindAddress,
 		Database:         DefaultDatabase,
 		Protocol:         DefaultProtocol,
+		Format:           DefaultFormat,
 		BatchSize:        DefaultBatchSize,
 		BatchPending:     DefaultBatchPending,
 		BatchTimeout:     toml.Duration(DefaultBatchTimeout),
@@ -94,6 +109,9 @@ func (c *Config) WithDefaults() *Config {
 	if d.Protocol == "" {
 		d.Protocol = DefaultProtocol
 	}
+	if d.Format == "" {
+		d.Format = DefaultFormat
+	}
 	if d.BatchSize == 0 {
 		d.BatchSize = DefaultBatchSize
 	}
@@ -125,8 +143,12 @@ func (c *Config) DefaultTags() models.Tags {
 	return models.NewTags(m)
 }
 
-// Validate validates the config's templates and tags.
+// Validate validates the config's format, templates and tags.
 func (c *Config) Validate() error {
+	if err := c.validateFormat(); err != nil {
+		return err
+	}
+
 	if err := c.validateTemplates(); err != nil {
 		return err
 	}
@@ -138,6 +160,19 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateFormat() error {
+	switch strings.ToLower(c.Format) {
+	case "", FormatPlaintext:
+	case FormatPickle:
+		if c.Protocol != "" && strings.ToLower(c.Protocol) != "tcp" {
+			return fmt.Errorf("graphite format %q requires the tcp protocol", c.Format)
+		}
+	default:
+		return fmt.Errorf("unrecognized graphite format %q", c.Format)
+	}
+	return nil
+}
+
 func (c *Config) validateTemplates() error {
 	// map to keep track of filters we see
 	filters := map[string]struct{}{}
@@ -261,7 +296,7 @@ type Configs []Config
 // Diagnostics returns one set of diagnostics for all of the Configs.
 func (c Configs) Diagnostics() (*diagnostics.Diagnostics, error) {
 	d := &diagnostics.Diagnostics{
-		Columns: []string{"enabled", "bind-address", "protocol", "database", "retention-policy", "batch-size", "batch-pending", "batch-timeout"},
+		Columns: []string{"enabled", "bind-address", "protocol", "format", "database", "retention-policy", "batch-size", "batch-pending", "batch-timeout"},
 	}
 
 	for _, cc := range c {
@@ -270,7 +305,7 @@ func (c Configs) Diagnostics() (*diagnostics.Diagnostics, error) {
 			continue
 		}
 
-		r := []interface{}{true, cc.BindAddress, cc.Protocol, cc.Database, cc.RetentionPolicy, cc.BatchSize, cc.BatchPending, cc.BatchTimeout}
+		r := []interface{}{true, cc.BindAddress, cc.Protocol, cc.Format, cc.Database, cc.RetentionPolicy, cc.BatchSize, cc.BatchPending, cc.BatchTimeout}
 		d.AddRow(r)
 	}
 
@@ -168,3 +168,20 @@ func TestConfigValidateFilterDuplicates(t *testing.T) {
 	}
 
 }
+
+func TestConfigValidateFormat(t *testing.T) {
+	c := &graphite.Config{Format: graphite.FormatPickle}
+	if err := c.Validate(); err != nil {
+		t.Errorf("config validate expected success, got %v", err)
+	}
+
+	c.Protocol = "udp"
+	if err := c.Validate(); err == nil {
+		t.Errorf("config validate expected error for pickle over udp. got nil")
+	}
+
+	c = &graphite.Config{Format: "json"}
+	if err := c.Validate(); err == nil {
+		t.Errorf("config validate expected error. got nil")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/influxdb/models"
@@ -20,20 +21,24 @@ var (
 	MaxDate = time.Date(2038, 1, 19, 0, 0, 0, 0, time.UTC)
 )
 
-var defaultTemplate *Template
-
-func init() {
-	var err error
-	defaultTemplate, err = NewTemplate("measurement*", nil, DefaultSeparator)
-	if err != nil {
-		panic(err)
-	}
-}
+// DefaultTemplateName is the name under which metrics matched by no
+// configured template are counted.
+const DefaultTemplateName = "default"
 
 // Parser encapsulates a Graphite Parser.
 type Parser struct {
 	matcher *matcher
 	tags    models.Tags
+
+	// templates holds every template of the parser, in the order they were
+	// configured, followed by the built-in default template.
+	templates []*Template
+}
+
+// TemplateMatches is the number of metrics matched by a template.
+type TemplateMatches struct {
+	Template string
+	Matches  int64
 }
 
 // Options are configurable values that can be provided to a Parser.
@@ -46,9 +51,16 @@ type Options struct {
 // NewParserWithOptions returns a graphite parser using the given options.
 func NewParserWithOptions(options Options) (*Parser, error) {
 
+	defaultTemplate, err := NewTemplate("measurement*", nil, DefaultSeparator)
+	if err != nil {
+		return nil, err
+	}
+	defaultTemplate.name = DefaultTemplateName
+
 	matcher := newMatcher()
 	matcher.AddDefaultTemplate(defaultTemplate)
 
+	var templates []*Template
 	for _, pattern := range options.Templates {
 
 		template := pattern
@@ -80,9 +92,16 @@ func NewParserWithOptions(options Options) (*Parser, error) {
 		if err != nil {
 			return nil, err
 		}
+		tmpl.name = pattern
 		matcher.Add(filter, tmpl)
+		templates = append(templates, tmpl)
+	}
+
+	// A configured template without a filter replaces the built-in default.
+	if matcher.defaultTemplate == defaultTemplate {
+		templates = append(templates, defaultTemplate)
 	}
-	return &Parser{matcher: matcher, tags: options.DefaultTags}, nil
+	return &Parser{matcher: matcher, tags: options.DefaultTags, templates: templates}, nil
 }
 
 // NewParser returns a GraphiteParser instance.
@@ -103,26 +122,51 @@ func (p *Parser) Parse(line string) (models.Point, error) {
 		return nil, fmt.Errorf("received %q which doesn't have required fields", line)
 	}
 
-	// decode the name and tags
-	template := p.matcher.Match(fields[0])
-	measurement, tags, field, err := template.Apply(fields[0])
+	// Parse value.
+	v, err := strconv.ParseFloat(fields[1], 64)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf(`field "%s" value: %s`, fields[0], err)
 	}
 
-	// Could not extract measurement, use the raw value
-	if measurement == "" {
-		measurement = fields[0]
+	// If no 3rd field, use now as timestamp
+	unixTime := float64(-1)
+	if len(fields) == 3 {
+		// Parse timestamp.
+		unixTime, err = strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf(`field "%s" time: %s`, fields[0], err)
+		}
 	}
+	return p.ParseMetric(fields[0], v, unixTime)
+}
 
-	// Parse value.
-	v, err := strconv.ParseFloat(fields[1], 64)
+// ParseMetric returns the point of the metric name with value v at unixTime,
+// in seconds. A unixTime of -1 is the current time.
+//
+// The name may be a Graphite tagged series, name;tag1=value1;tag2=value2,
+// in which case the template is applied to the name and the tags of the
+// series are added to the point. They take precedence over the tags of the
+// template.
+func (p *Parser) ParseMetric(name string, v float64, unixTime float64) (models.Point, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, &UnsupportedValueError{Field: name, Value: v}
+	}
+
+	metric, seriesTags, err := parseTaggedName(name)
 	if err != nil {
-		return nil, fmt.Errorf(`field "%s" value: %s`, fields[0], err)
+		return nil, err
 	}
 
-	if math.IsNaN(v) || math.IsInf(v, 0) {
-		return nil, &UnsupportedValueError{Field: fields[0], Value: v}
+	// decode the name and tags
+	template := p.matcher.Match(metric)
+	measurement, tags, field, err := template.Apply(metric)
+	if err != nil {
+		return nil, err
+	}
+
+	// Could not extract measurement, use the raw value
+	if measurement == "" {
+		measurement = metric
 	}
 
 	fieldValues := map[string]interface{}{}
@@ -132,25 +176,20 @@ func (p *Parser) Parse(line string) (models.Point, error) {
 		fieldValues["value"] = v
 	}
 
-	// If no 3rd field, use now as timestamp
 	timestamp := time.Now().UTC()
 
-	if len(fields) == 3 {
-		// Parse timestamp.
-		unixTime, err := strconv.ParseFloat(fields[2], 64)
-		if err != nil {
-			return nil, fmt.Errorf(`field "%s" time: %s`, fields[0], err)
+	// -1 is a special value that gets converted to current UTC time
+	// See https://github.com/graphite-project/carbon/issues/54
+	if unixTime != float64(-1) {
+		// Check if we have fractional seconds
+		timestamp = time.Unix(int64(unixTime), int64((unixTime-math.Floor(unixTime))*float64(time.Second)))
+		if timestamp.Before(MinDate) || timestamp.After(MaxDate) {
+			return nil, fmt.Errorf("timestamp out of range")
 		}
+	}
 
-		// -1 is a special value that gets converted to current UTC time
-		// See https://github.com/graphite-project/carbon/issues/54
-		if unixTime != float64(-1) {
-			// Check if we have fractional seconds
-			timestamp = time.Unix(int64(unixTime), int64((unixTime-math.Floor(unixTime))*float64(time.Second)))
-			if timestamp.Before(MinDate) || timestamp.After(MaxDate) {
-				return nil, fmt.Errorf("timestamp out of range")
-			}
-		}
+	for k, v := range seriesTags {
+		tags[k] = v
 	}
 
 	// Set the default tags on the point if they are not already set
@@ -170,9 +209,17 @@ func (p *Parser) ApplyTemplate(line string) (string, map[string]string, string,
 	if len(fields) == 0 {
 		return "", make(map[string]string), "", nil
 	}
+	metric, seriesTags, err := parseTaggedName(fields[0])
+	if err != nil {
+		return "", make(map[string]string), "", err
+	}
+
 	// decode the name and tags
-	template := p.matcher.Match(fields[0])
-	name, tags, field, err := template.Apply(fields[0])
+	template := p.matcher.Match(metric)
+	name, tags, field, err := template.Apply(metric)
+	for k, v := range seriesTags {
+		tags[k] = v
+	}
 	// Set the default tags on the point if they are not already set
 	for _, t := range p.tags {
 		if _, ok := tags[string(t.Key)]; !ok {
@@ -182,8 +229,64 @@ func (p *Parser) ApplyTemplate(line string) (string, map[string]string, string,
 	return name, tags, field, err
 }
 
+// TemplateMatches returns the number of metrics matched by each template of
+// the parser. Templates are named as configured, and the built-in template
+// used when no configured template matches is named DefaultTemplateName.
+func (p *Parser) TemplateMatches() []TemplateMatches {
+	matches := make([]TemplateMatches, 0, len(p.templates))
+	for _, t := range p.templates {
+		matches = append(matches, TemplateMatches{Template: t.name, Matches: atomic.LoadInt64(&t.matches)})
+	}
+	return matches
+}
+
+// InheritMatches carries the number of matches of the templates of other
+// over to the templates of p with the same name, so that the counts survive
+// a reload of the templates.
+func (p *Parser) InheritMatches(other *Parser) {
+	for _, t := range p.templates {
+		for _, o := range other.templates {
+			if t.name == o.name {
+				atomic.StoreInt64(&t.matches, atomic.LoadInt64(&o.matches))
+				break
+			}
+		}
+	}
+}
+
+// parseTaggedName splits a Graphite tagged series name,
+// name;tag1=value1;tag2=value2, into the name and its tags. Names without
+// tags are returned as they are.
+func parseTaggedName(name string) (string, map[string]string, error) {
+	i := strings.IndexByte(name, ';')
+	if i < 0 {
+		return name, nil, nil
+	}
+
+	metric := name[:i]
+	if metric == "" {
+		return "", nil, fmt.Errorf("tagged series %q has no name", name)
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(name[i+1:], ";") {
+		j := strings.IndexByte(tag, '=')
+		if j <= 0 || j == len(tag)-1 {
+			return "", nil, fmt.Errorf("invalid tag %q in tagged series %q", tag, name)
+		}
+		key, value := tag[:j], tag[j+1:]
+		if strings.ContainsAny(key, "!^=") || strings.HasPrefix(value, "~") {
+			return "", nil, fmt.Errorf("invalid tag %q in tagged series %q", tag, name)
+		}
+		tags[key] = value
+	}
+	return metric, tags, nil
+}
+
 // Template represents a pattern and tags to map a graphite metric string to a influxdb Point.
 type Template struct {
+	name              string
+	matches           int64 // number of metrics matched, accessed atomically
 	tags              []string
 	defaultTags       models.Tags
 	greedyMeasurement bool
@@ -303,11 +406,11 @@ func (m *matcher) AddDefaultTemplate(template *Template) {
 // Match returns the template that matches the given graphite line.
 func (m *matcher) Match(line string) *Template {
 	tmpl := m.root.Search(line)
-	if tmpl != nil {
-		return tmpl
+	if tmpl == nil {
+		tmpl = m.defaultTemplate
 	}
-
-	return m.defaultTemplate
+	atomic.AddInt64(&tmpl.matches, 1)
+	return tmpl
 }
 
 // node is an item in a sorted k-ary tree.  Each child is sorted by its value.
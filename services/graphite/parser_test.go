@@ -716,6 +716,86 @@ func TestApplyTemplateFieldError(t *testing.T) {
 }
 
 // Test Helpers
+func TestParseTaggedSeries(t *testing.T) {
+	p, err := graphite.NewParserWithOptions(graphite.Options{
+		Templates:   []string{"servers.* .host.measurement* region=us-east,dc=1"},
+		DefaultTags: models.NewTags(map[string]string{"zone": "1c", "dc": "0"}),
+		Separator:   graphite.DefaultSeparator,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating parser, got %v", err)
+	}
+
+	exp := models.MustNewPoint("cpu_load",
+		models.NewTags(map[string]string{"host": "localhost", "region": "us-west", "dc": "1", "core": "0", "zone": "1c"}),
+		models.Fields{"value": float64(11)},
+		time.Unix(1435077219, 0))
+
+	pt, err := p.Parse("servers.localhost.cpu_load;region=us-west;core=0 11 1435077219")
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+
+	if exp.String() != pt.String() {
+		t.Errorf("parse mismatch: got %v, exp %v", pt.String(), exp.String())
+	}
+
+	for _, line := range []string{
+		";a=b 1 1435077219",
+		"cpu;a 1 1435077219",
+		"cpu;=b 1 1435077219",
+		"cpu;a= 1 1435077219",
+		"cpu;a=~b 1 1435077219",
+		"cpu;a=b; 1 1435077219",
+	} {
+		if _, err := p.Parse(line); err == nil {
+			t.Errorf("%s: expected error", line)
+		}
+	}
+}
+
+func TestParserTemplateMatches(t *testing.T) {
+	p, err := graphite.NewParser([]string{"servers.* .host.measurement*", "stats.* .measurement*"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating parser, got %v", err)
+	}
+
+	for _, line := range []string{
+		"servers.a.cpu 1",
+		"servers.b.cpu;core=0 1",
+		"stats.requests 1",
+		"other.metric 1",
+	} {
+		if _, err := p.Parse(line); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+	}
+
+	exp := []graphite.TemplateMatches{
+		{Template: "servers.* .host.measurement*", Matches: 2},
+		{Template: "stats.* .measurement*", Matches: 1},
+		{Template: graphite.DefaultTemplateName, Matches: 1},
+	}
+	if got := p.TemplateMatches(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected matches: got %v, exp %v", got, exp)
+	}
+
+	// Matches carry over to a parser with the same template.
+	p2, err := graphite.NewParser([]string{"servers.* .host.measurement*", "measurement.field"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating parser, got %v", err)
+	}
+	p2.InheritMatches(p)
+
+	exp = []graphite.TemplateMatches{
+		{Template: "servers.* .host.measurement*", Matches: 2},
+		{Template: "measurement.field", Matches: 0},
+	}
+	if got := p2.TemplateMatches(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected matches: got %v, exp %v", got, exp)
+	}
+}
+
 func errstr(err error) string {
 	if err != nil {
 		return err.Error()
@@ -0,0 +1,674 @@
+package graphite
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxPickleSize is the maximum size of a single pickle message, as in carbon.
+const maxPickleSize = 1 << 20
+
+// ErrPickleTooLarge is returned when a pickle message is larger than
+// maxPickleSize.
+var ErrPickleTooLarge = errors.New("pickle message too large")
+
+// pickleMetric is a single datapoint of a pickle message.
+type pickleMetric struct {
+	name     string
+	unixTime float64
+	value    float64
+}
+
+// readPickle reads a single message of the Graphite pickle protocol, used by
+// carbon-relay, from r. A message is a 4-byte big-endian length followed by
+// a pickled list of (name, (timestamp, value)) tuples.
+func readPickle(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > maxPickleSize {
+		return nil, ErrPickleTooLarge
+	}
+
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// parsePickle decodes the metrics of a pickle message. Only the opcodes
+// needed to encode lists, tuples, strings and numbers are supported, so
+// that a message can't construct arbitrary objects.
+func parsePickle(data []byte) ([]pickleMetric, error) {
+	v, err := unpickle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := v.(*pickleList)
+	if !ok {
+		return nil, fmt.Errorf("pickle: expected list of metrics, got %T", v)
+	}
+
+	metrics := make([]pickleMetric, 0, len(list.items))
+	for _, item := range list.items {
+		metric, ok := item.(pickleTuple)
+		if !ok || len(metric) != 2 {
+			return nil, errors.New("pickle: expected (name, (timestamp, value)) tuple")
+		}
+		datapoint, ok := metric[1].(pickleTuple)
+		if !ok || len(datapoint) != 2 {
+			return nil, errors.New("pickle: expected (timestamp, value) tuple")
+		}
+
+		var m pickleMetric
+		if m.name, ok = pickleString(metric[0]); !ok {
+			return nil, fmt.Errorf("pickle: invalid metric name of type %T", metric[0])
+		}
+		if m.unixTime, err = pickleFloat(datapoint[0]); err != nil {
+			return nil, fmt.Errorf("pickle: metric %q time: %s", m.name, err)
+		}
+		if m.value, err = pickleFloat(datapoint[1]); err != nil {
+			return nil, fmt.Errorf("pickle: metric %q value: %s", m.name, err)
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+func pickleString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case pickleBytes:
+		return string(v), true
+	}
+	return "", false
+}
+
+// pickleFloat converts a number, or a string holding one, to a float, as
+// carbon does.
+func pickleFloat(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if s, ok := pickleString(v); ok {
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	}
+	return 0, fmt.Errorf("unsupported type %T", v)
+}
+
+type (
+	pickleList  struct{ items []interface{} }
+	pickleTuple []interface{}
+	pickleBytes []byte
+	pickleMark  struct{}
+)
+
+// The pickle opcodes supported by unpickle.
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opFloat          = 'F'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opLong           = 'L'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opAppends        = 'e'
+	opGet            = 'g'
+	opBinGet         = 'h'
+	opLongBinGet     = 'j'
+	opList           = 'l'
+	opEmptyList      = ']'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opBinFloat       = 'G'
+	opBinBytes       = 'B'
+	opShortBinBytes  = 'C'
+
+	// Protocol 2 and later.
+	opProto           = 0x80
+	opTuple1          = 0x85
+	opTuple2          = 0x86
+	opTuple3          = 0x87
+	opNewTrue         = 0x88
+	opNewFalse        = 0x89
+	opLong1           = 0x8a
+	opLong4           = 0x8b
+	opShortBinUnicode = 0x8c
+	opBinUnicode8     = 0x8d
+	opBinBytes8       = 0x8e
+	opMemoize         = 0x94
+	opFrame           = 0x95
+)
+
+// unpickler decodes the subset of the pickle format used for metrics.
+type unpickler struct {
+	r     *bufio.Reader
+	stack []interface{}
+	memo  map[int]interface{}
+}
+
+func unpickle(data []byte) (interface{}, error) {
+	u := &unpickler{
+		r:    bufio.NewReader(bytes.NewReader(data)),
+		memo: make(map[int]interface{}),
+	}
+	for {
+		op, err := u.r.ReadByte()
+		if err == io.EOF {
+			return nil, errors.New("pickle: missing STOP opcode")
+		} else if err != nil {
+			return nil, err
+		}
+
+		if op == opStop {
+			return u.pop()
+		}
+		if err := u.exec(op); err != nil {
+			return nil, fmt.Errorf("pickle: %s", err)
+		}
+	}
+}
+
+func (u *unpickler) exec(op byte) error {
+	switch op {
+	case opProto:
+		_, err := u.r.ReadByte()
+		return err
+	case opFrame:
+		_, err := u.readN(8)
+		return err
+	case opMark:
+		u.push(pickleMark{})
+	case opPop:
+		_, err := u.pop()
+		return err
+	case opPopMark:
+		_, err := u.popMark()
+		return err
+	case opDup:
+		v, err := u.top()
+		if err != nil {
+			return err
+		}
+		u.push(v)
+
+	case opNone:
+		u.push(nil)
+	case opNewTrue:
+		u.push(true)
+	case opNewFalse:
+		u.push(false)
+	case opInt:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		// Protocol 0 encodes booleans as INT 01 and INT 00.
+		switch line {
+		case "01":
+			u.push(true)
+		case "00":
+			u.push(false)
+		default:
+			return u.pushInt(line)
+		}
+	case opLong:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		return u.pushInt(strings.TrimSuffix(line, "L"))
+	case opBinInt:
+		b, err := u.readN(4)
+		if err != nil {
+			return err
+		}
+		u.push(int64(int32(binary.LittleEndian.Uint32(b))))
+	case opBinInt1:
+		b, err := u.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		u.push(int64(b))
+	case opBinInt2:
+		b, err := u.readN(2)
+		if err != nil {
+			return err
+		}
+		u.push(int64(binary.LittleEndian.Uint16(b)))
+	case opLong1, opLong4:
+		n, err := u.readLength(op == opLong4)
+		if err != nil {
+			return err
+		}
+		b, err := u.readN(n)
+		if err != nil {
+			return err
+		}
+		u.push(decodeLong(b))
+	case opFloat:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		f, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			return err
+		}
+		u.push(f)
+	case opBinFloat:
+		b, err := u.readN(8)
+		if err != nil {
+			return err
+		}
+		u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+	case opString:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		s, err := unquotePython(line)
+		if err != nil {
+			return err
+		}
+		u.push(pickleBytes(s))
+	case opUnicode:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		s, err := decodeRawUnicodeEscape(line)
+		if err != nil {
+			return err
+		}
+		u.push(s)
+	case opBinString, opShortBinString, opBinBytes, opShortBinBytes, opBinBytes8:
+		var n int
+		var err error
+		switch op {
+		case opBinBytes8:
+			n, err = u.readLength8()
+		default:
+			n, err = u.readLength(op == opBinString || op == opBinBytes)
+		}
+		if err != nil {
+			return err
+		}
+		b, err := u.readN(n)
+		if err != nil {
+			return err
+		}
+		u.push(pickleBytes(b))
+	case opBinUnicode, opShortBinUnicode, opBinUnicode8:
+		var n int
+		var err error
+		switch op {
+		case opBinUnicode8:
+			n, err = u.readLength8()
+		default:
+			n, err = u.readLength(op == opBinUnicode)
+		}
+		if err != nil {
+			return err
+		}
+		b, err := u.readN(n)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return errors.New("invalid UTF-8 string")
+		}
+		u.push(string(b))
+
+	case opEmptyList:
+		u.push(&pickleList{})
+	case opList:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(&pickleList{items: items})
+	case opAppend:
+		v, err := u.pop()
+		if err != nil {
+			return err
+		}
+		return u.appendTo(v)
+	case opAppends:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		return u.appendTo(items...)
+	case opEmptyTuple:
+		u.push(pickleTuple{})
+	case opTuple:
+		items, err := u.popMark()
+		if err != nil {
+			return err
+		}
+		u.push(pickleTuple(items))
+	case opTuple1, opTuple2, opTuple3:
+		n := int(op-opTuple1) + 1
+		if len(u.stack) < n {
+			return errors.New("stack underflow")
+		}
+		items := append(pickleTuple(nil), u.stack[len(u.stack)-n:]...)
+		u.stack = u.stack[:len(u.stack)-n]
+		u.push(items)
+
+	case opPut:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(line)
+		if err != nil {
+			return err
+		}
+		return u.put(i)
+	case opBinPut:
+		b, err := u.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		return u.put(int(b))
+	case opLongBinPut:
+		n, err := u.readLength(true)
+		if err != nil {
+			return err
+		}
+		return u.put(n)
+	case opMemoize:
+		return u.put(len(u.memo))
+	case opGet:
+		line, err := u.readLine()
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(line)
+		if err != nil {
+			return err
+		}
+		return u.get(i)
+	case opBinGet:
+		b, err := u.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		return u.get(int(b))
+	case opLongBinGet:
+		n, err := u.readLength(true)
+		if err != nil {
+			return err
+		}
+		return u.get(n)
+
+	default:
+		return fmt.Errorf("unsupported opcode %#x", op)
+	}
+	return nil
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) == 0 {
+		return nil, errors.New("stack underflow")
+	}
+	return u.stack[len(u.stack)-1], nil
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	v, err := u.top()
+	if err != nil {
+		return nil, err
+	}
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+// popMark pops the items pushed since the last mark, and the mark.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	for i := len(u.stack) - 1; i >= 0; i-- {
+		if _, ok := u.stack[i].(pickleMark); ok {
+			items := append([]interface{}(nil), u.stack[i+1:]...)
+			u.stack = u.stack[:i]
+			return items, nil
+		}
+	}
+	return nil, errors.New("mark not found")
+}
+
+// appendTo appends items to the list at the top of the stack.
+func (u *unpickler) appendTo(items ...interface{}) error {
+	v, err := u.top()
+	if err != nil {
+		return err
+	}
+	list, ok := v.(*pickleList)
+	if !ok {
+		return fmt.Errorf("can't append to %T", v)
+	}
+	list.items = append(list.items, items...)
+	return nil
+}
+
+func (u *unpickler) put(i int) error {
+	v, err := u.top()
+	if err != nil {
+		return err
+	}
+	u.memo[i] = v
+	return nil
+}
+
+func (u *unpickler) get(i int) error {
+	v, ok := u.memo[i]
+	if !ok {
+		return fmt.Errorf("memo key %d not found", i)
+	}
+	u.push(v)
+	return nil
+}
+
+func (u *unpickler) pushInt(s string) error {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	u.push(i)
+	return nil
+}
+
+func (u *unpickler) readN(n int) ([]byte, error) {
+	if n < 0 || n > maxPickleSize {
+		return nil, ErrPickleTooLarge
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(u.r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// readLength reads a little-endian length of 4 bytes if long is set, and of
+// a single byte otherwise.
+func (u *unpickler) readLength(long bool) (int, error) {
+	if !long {
+		b, err := u.r.ReadByte()
+		return int(b), err
+	}
+	b, err := u.readN(4)
+	if err != nil {
+		return 0, err
+	}
+	n := binary.LittleEndian.Uint32(b)
+	if n > maxPickleSize {
+		return 0, ErrPickleTooLarge
+	}
+	return int(n), nil
+}
+
+func (u *unpickler) readLength8() (int, error) {
+	b, err := u.readN(8)
+	if err != nil {
+		return 0, err
+	}
+	n := binary.LittleEndian.Uint64(b)
+	if n > maxPickleSize {
+		return 0, ErrPickleTooLarge
+	}
+	return int(n), nil
+}
+
+// readLine reads the argument of a text opcode, without its newline.
+func (u *unpickler) readLine() (string, error) {
+	line, err := u.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// decodeLong decodes a little-endian two's complement integer of LONG1 and
+// LONG4. Integers which don't fit in 64 bits are converted to floats.
+func decodeLong(b []byte) interface{} {
+	if len(b) == 0 {
+		return int64(0)
+	}
+	if len(b) <= 8 {
+		var v uint64
+		for i := len(b) - 1; i >= 0; i-- {
+			v = v<<8 | uint64(b[i])
+		}
+		// Sign extend.
+		if shift := uint(64 - 8*len(b)); shift > 0 {
+			return int64(v<<shift) >> shift
+		}
+		return int64(v)
+	}
+
+	var f float64
+	negative := b[len(b)-1]&0x80 != 0
+	for i := len(b) - 1; i >= 0; i-- {
+		c := b[i]
+		if negative {
+			c = ^c
+		}
+		f = f*256 + float64(c)
+	}
+	if negative {
+		f = -(f + 1)
+	}
+	return f
+}
+
+// unquotePython decodes a quoted Python 2 string literal, as written by the
+// STRING opcode.
+func unquotePython(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", fmt.Errorf("invalid quoted string %q", s)
+	}
+	s = s[1 : len(s)-1]
+
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			buf.WriteByte(c)
+			continue
+		}
+		if i++; i == len(s) {
+			return "", errors.New("invalid escape at end of string")
+		}
+		switch c := s[i]; c {
+		case '\\', '\'', '"':
+			buf.WriteByte(c)
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case 'x':
+			if i+2 >= len(s) {
+				return "", errors.New("invalid \\x escape")
+			}
+			v, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteByte(byte(v))
+			i += 2
+		default:
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String(), nil
+}
+
+// decodeRawUnicodeEscape decodes the raw-unicode-escape encoding of the
+// UNICODE opcode: \uXXXX and \UXXXXXXXX escapes, and Latin-1 otherwise.
+func decodeRawUnicodeEscape(s string) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) && (s[i+1] == 'u' || s[i+1] == 'U') {
+			n := 4
+			if s[i+1] == 'U' {
+				n = 8
+			}
+			if i+2+n > len(s) {
+				return "", errors.New("invalid unicode escape")
+			}
+			r, err := strconv.ParseUint(s[i+2:i+2+n], 16, 32)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteRune(rune(r))
+			i += 1 + n
+			continue
+		}
+		buf.WriteRune(rune(c))
+	}
+	return buf.String(), nil
+}
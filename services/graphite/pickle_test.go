@@ -0,0 +1,92 @@
+package graphite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePickle(t *testing.T) {
+	exp := []pickleMetric{
+		{name: "servers.a.cpu", unixTime: 1435077219, value: 1.5},
+		{name: "load;host=a", unixTime: 1435077220.5, value: 2},
+		{name: "mem", unixTime: 1435077221, value: 3},
+	}
+
+	// pickle.dumps(metrics, protocol=n) of the metrics above, where the
+	// datapoint of "mem" holds strings.
+	for _, tt := range []struct {
+		protocol string
+		data     string
+	}{
+		{
+			protocol: "0",
+			data:     "(lp0\n(Vservers.a.cpu\np1\n(I1435077219\nF1.5\ntp2\ntp3\na(Vload;host=a\np4\n(F1435077220.5\nI2\ntp5\ntp6\na(Vmem\np7\n(V1435077221\np8\nV3\np9\ntp10\ntp11\na.",
+		},
+		{
+			protocol: "0 (Python 2)",
+			data:     "(lp0\n(S'servers.a.cpu'\np1\n(I1435077219\nF1.5\ntp2\ntp3\na(S'load;host=a'\np4\n(F1435077220.5\nI2\ntp5\ntp6\na(S\"mem\"\np7\n(S'1435077221'\np8\nS'3'\np9\ntp10\ntp11\na.",
+		},
+		{
+			protocol: "2",
+			data:     "\x80\x02]q\x00(X\r\x00\x00\x00servers.a.cpuq\x01Jc\x8a\x89UG?\xf8\x00\x00\x00\x00\x00\x00\x86q\x02\x86q\x03X\x0b\x00\x00\x00load;host=aq\x04GA\xd5bb\x99 \x00\x00K\x02\x86q\x05\x86q\x06X\x03\x00\x00\x00memq\x07X\n\x00\x00\x001435077221q\x08X\x01\x00\x00\x003q\t\x86q\n\x86q\x0be.",
+		},
+		{
+			protocol: "4",
+			data:     "\x80\x04\x95_\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\rservers.a.cpu\x94Jc\x8a\x89UG?\xf8\x00\x00\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x0bload;host=a\x94GA\xd5bb\x99 \x00\x00K\x02\x86\x94\x86\x94\x8c\x03mem\x94\x8c\n1435077221\x94\x8c\x013\x94\x86\x94\x86\x94e.",
+		},
+	} {
+		t.Run(tt.protocol, func(t *testing.T) {
+			got, err := parsePickle([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, exp) {
+				t.Fatalf("unexpected metrics:\ngot %#v\nexp %#v", got, exp)
+			}
+		})
+	}
+}
+
+func TestParsePickle_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+		err  string
+	}{
+		// os.system('true'), which must not be evaluated.
+		{name: "global", data: "cos\nsystem\n(S'true'\ntR.", err: "unsupported opcode"},
+		{name: "truncated", data: "\x80\x02]q\x00(X\r\x00\x00", err: "EOF"},
+		{name: "no stop", data: "(l", err: "missing STOP"},
+		{name: "not a list", data: "I1\n.", err: "expected list of metrics"},
+		{name: "bad datapoint", data: "(l(S'a'\nI1\ntp0\na.", err: "expected (timestamp, value) tuple"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parsePickle([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReadPickle(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint32(3))
+	buf.WriteString("abcdef")
+
+	b, err := readPickle(&buf)
+	if err != nil {
+		t.Fatal(err)
+	} else if string(b) != "abc" {
+		t.Fatalf("unexpected message: %q", b)
+	}
+
+	buf.Reset()
+	binary.Write(&buf, binary.BigEndian, uint32(maxPickleSize+1))
+	if _, err := readPickle(&buf); err != ErrPickleTooLarge {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
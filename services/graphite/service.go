@@ -4,6 +4,7 @@ package graphite // import "github.com/influxdata/influxdb/services/graphite"
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"math"
 	"net"
 	"strings"
@@ -32,6 +33,8 @@ const (
 	statBatchesTransmitFail = "batchesTxFail"
 	statConnectionsActive   = "connsActive"
 	statConnectionsHandled  = "connsHandled"
+
+	statTemplateMatches = "matches"
 )
 
 type tcpConnection struct {
@@ -49,6 +52,7 @@ type Service struct {
 	database         string
 	retentionPolicy  string
 	protocol         string
+	format           string
 	batchSize        int
 	batchPending     int
 	batchTimeout     time.Duration
@@ -56,7 +60,9 @@ type Service struct {
 	udpReadBuffer    int
 
 	batcher *tsdb.PointBatcher
-	parser  *Parser
+
+	parserMu sync.RWMutex
+	parser   *Parser
 
 	logger      *zap.Logger
 	stats       *Statistics
@@ -101,6 +107,7 @@ func NewService(c Config) (*Service, error) {
 		database:        d.Database,
 		retentionPolicy: d.RetentionPolicy,
 		protocol:        d.Protocol,
+		format:          strings.ToLower(d.Format),
 		batchSize:       d.BatchSize,
 		batchPending:    d.BatchPending,
 		udpReadBuffer:   d.UDPReadBuffer,
@@ -142,6 +149,7 @@ func (s *Service) Open() error {
 	s.done = make(chan struct{})
 
 	s.logger.Info("Starting graphite service",
+		zap.String("format", s.format),
 		zap.Int("batch_size", s.batchSize),
 		logger.DurationLiteral("batch_timeout", s.batchTimeout))
 
@@ -158,6 +166,9 @@ func (s *Service) Open() error {
 	go s.processBatches(s.batcher)
 
 	var err error
+	if s.format == FormatPickle && strings.ToLower(s.protocol) != "tcp" {
+		return fmt.Errorf("graphite format %q requires the tcp protocol", s.format)
+	}
 	if strings.ToLower(s.protocol) == "tcp" {
 		s.addr, err = s.openTCPServer()
 	} else if strings.ToLower(s.protocol) == "udp" {
@@ -279,6 +290,45 @@ func (s *Service) WithLogger(log *zap.Logger) {
 	)
 }
 
+// Matches returns true if c configures the listener of the service.
+func (s *Service) Matches(c Config) bool {
+	d := c.WithDefaults()
+	return strings.EqualFold(d.Protocol, s.protocol) && d.BindAddress == s.bindAddress
+}
+
+// Reload replaces the templates, default tags and separator used to parse
+// metrics with those of c. Open connections are kept, and the other settings
+// of c are ignored.
+func (s *Service) Reload(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	d := c.WithDefaults()
+	parser, err := NewParserWithOptions(Options{
+		Templates:   d.Templates,
+		DefaultTags: d.DefaultTags(),
+		Separator:   d.Separator})
+	if err != nil {
+		return err
+	}
+
+	s.parserMu.Lock()
+	parser.InheritMatches(s.parser)
+	s.parser = parser
+	s.parserMu.Unlock()
+
+	s.logger.Info("Reloaded graphite templates", zap.Int("templates", len(d.Templates)))
+	return nil
+}
+
+// getParser returns the parser of the service.
+func (s *Service) getParser() *Parser {
+	s.parserMu.RLock()
+	defer s.parserMu.RUnlock()
+	return s.parser
+}
+
 // Statistics maintains statistics for the graphite service.
 type Statistics struct {
 	PointsReceived      int64
@@ -293,8 +343,11 @@ type Statistics struct {
 }
 
 // Statistics returns statistics for periodic monitoring.
+//
+// Besides the statistics of the service, the number of metrics matched by
+// each template is returned, tagged with the template.
 func (s *Service) Statistics(tags map[string]string) []models.Statistic {
-	return []models.Statistic{{
+	statistics := []models.Statistic{{
 		Name: "graphite",
 		Tags: s.defaultTags.Merge(tags),
 		Values: map[string]interface{}{
@@ -309,6 +362,17 @@ func (s *Service) Statistics(tags map[string]string) []models.Statistic {
 			statConnectionsHandled:  atomic.LoadInt64(&s.stats.HandledConnections),
 		},
 	}}
+
+	for _, m := range s.getParser().TemplateMatches() {
+		statistics = append(statistics, models.Statistic{
+			Name: "graphite_template",
+			Tags: models.StatisticTags{"template": m.Template}.Merge(s.defaultTags.Merge(tags)),
+			Values: map[string]interface{}{
+				statTemplateMatches: m.Matches,
+			},
+		})
+	}
+	return statistics
 }
 
 // Addr returns the address the Service binds to.
@@ -357,6 +421,11 @@ func (s *Service) handleTCPConnection(conn net.Conn) {
 
 	reader := bufio.NewReader(conn)
 
+	if s.format == FormatPickle {
+		s.handlePickle(reader)
+		return
+	}
+
 	for {
 		// Read up to the next newline.
 		buf, err := reader.ReadBytes('\n')
@@ -373,6 +442,34 @@ func (s *Service) handleTCPConnection(conn net.Conn) {
 	}
 }
 
+// handlePickle services a TCP connection sending pickle messages.
+func (s *Service) handlePickle(r io.Reader) {
+	for {
+		buf, err := readPickle(r)
+		if err == ErrPickleTooLarge {
+			s.logger.Info("Closing graphite pickle connection", zap.Error(err))
+			return
+		} else if err != nil {
+			return
+		}
+		atomic.AddInt64(&s.stats.BytesReceived, int64(len(buf)+4))
+
+		metrics, err := parsePickle(buf)
+		if err != nil {
+			s.logger.Info("Unable to parse pickle message", zap.Error(err))
+			atomic.AddInt64(&s.stats.PointsParseFail, 1)
+			continue
+		}
+
+		atomic.AddInt64(&s.stats.PointsReceived, int64(len(metrics)))
+		parser := s.getParser()
+		for _, m := range metrics {
+			point, err := parser.ParseMetric(m.name, m.value, m.unixTime)
+			s.handlePoint(point, err, m.name)
+		}
+	}
+}
+
 func (s *Service) trackConnection(c net.Conn) {
 	s.tcpConnectionsMu.Lock()
 	defer s.tcpConnectionsMu.Unlock()
@@ -435,7 +532,13 @@ func (s *Service) handleLine(line string) {
 	}
 
 	// Parse it.
-	point, err := s.parser.Parse(line)
+	point, err := s.getParser().Parse(line)
+	s.handlePoint(point, err, line)
+}
+
+// handlePoint batches a parsed point, or records the error parsing it from
+// the metric line.
+func (s *Service) handlePoint(point models.Point, err error, line string) {
 	if err != nil {
 		switch err := err.(type) {
 		case *UnsupportedValueError:
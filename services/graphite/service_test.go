@@ -1,10 +1,14 @@
 package graphite
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
 	"os"
+	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -252,6 +256,137 @@ func Test_Service_UDP(t *testing.T) {
 	conn.Close()
 }
 
+func Test_Service_Pickle(t *testing.T) {
+	t.Parallel()
+
+	config := Config{}
+	config.Database = "graphitedb"
+	config.BatchSize = 0 // No batching.
+	config.BatchTimeout = toml.Duration(time.Second)
+	config.BindAddress = ":0"
+	config.Format = FormatPickle
+
+	service := NewTestService(&config)
+
+	// Allow test to wait until points are written.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var mu sync.Mutex
+	var got []string
+	service.WritePointsFn = func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, p := range points {
+			got = append(got, p.String())
+			wg.Done()
+		}
+		return nil
+	}
+
+	if err := service.Service.Open(); err != nil {
+		t.Fatalf("failed to open Graphite service: %s", err.Error())
+	}
+	defer service.Service.Close()
+
+	// Connect to the graphite endpoint we just spun up
+	_, port, _ := net.SplitHostPort(service.Service.Addr().String())
+	conn, err := net.Dial("tcp", "127.0.0.1:"+port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pickle.dumps([("cpu", (1435077219, 1.5)), ("mem;host=a", (1435077219, 2))], protocol=2)
+	msg := "\x80\x02]q\x00(X\x03\x00\x00\x00cpuq\x01Jc\x8a\x89UG?\xf8\x00\x00\x00\x00\x00\x00\x86q\x02\x86q\x03X\n\x00\x00\x00mem;host=aq\x04Jc\x8a\x89UK\x02\x86q\x05\x86q\x06e."
+	var data bytes.Buffer
+	binary.Write(&data, binary.BigEndian, uint32(len(msg)))
+	data.WriteString(msg)
+	_, err = conn.Write(data.Bytes())
+	conn.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+
+	sort.Strings(got)
+	exp := []string{"cpu value=1.5 1435077219000000000", "mem,host=a value=2 1435077219000000000"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points: got %v, exp %v", got, exp)
+	}
+}
+
+func Test_Service_Reload(t *testing.T) {
+	t.Parallel()
+
+	config := Config{}
+	config.Database = "graphitedb"
+	config.BatchSize = 0 // No batching.
+	config.BatchTimeout = toml.Duration(time.Second)
+	config.BindAddress = ":0"
+
+	service := NewTestService(&config)
+
+	points := make(chan models.Point, 2)
+	service.WritePointsFn = func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, batch []models.Point) error {
+		for _, p := range batch {
+			points <- p
+		}
+		return nil
+	}
+
+	if err := service.Service.Open(); err != nil {
+		t.Fatalf("failed to open Graphite service: %s", err.Error())
+	}
+	defer service.Service.Close()
+
+	_, port, _ := net.SplitHostPort(service.Service.Addr().String())
+	conn, err := net.Dial("tcp", "127.0.0.1:"+port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("servers.a.cpu 1 1435077219\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := (<-points).String(), "servers.a.cpu value=1 1435077219000000000"; got != exp {
+		t.Fatalf("unexpected point: got %s, exp %s", got, exp)
+	}
+
+	// Reload the templates and write to the same connection.
+	config.Templates = []string{"servers.* .host.measurement*"}
+	if !service.Service.Matches(config) {
+		t.Fatal("expected config to match service")
+	}
+	if err := service.Service.Reload(config); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conn.Write([]byte("servers.a.cpu 1 1435077219\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := (<-points).String(), "cpu,host=a value=1 1435077219000000000"; got != exp {
+		t.Fatalf("unexpected point: got %s, exp %s", got, exp)
+	}
+
+	var matches []string
+	for _, s := range service.Service.Statistics(nil) {
+		if s.Name == "graphite_template" {
+			matches = append(matches, fmt.Sprintf("%s=%v", s.Tags["template"], s.Values[statTemplateMatches]))
+		}
+	}
+	// The match of the built-in default before the reload is kept.
+	if exp := []string{"servers.* .host.measurement*=1", "default=1"}; !reflect.DeepEqual(matches, exp) {
+		t.Fatalf("unexpected template matches: %v", matches)
+	}
+
+	config.Templates = []string{"no.template"}
+	if err := service.Service.Reload(config); err == nil {
+		t.Fatal("expected error reloading invalid template")
+	}
+}
+
 type TestService struct {
 	Service       *Service
 	MetaClient    *internal.MetaClientMock
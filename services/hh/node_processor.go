@@ -12,6 +12,7 @@ import (
 
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
 	"go.uber.org/zap"
 )
 
@@ -154,6 +155,7 @@ func (n *NodeProcessor) Statistics(tags map[string]string) []models.Statistic {
 			statWriteNodeReq:        atomic.LoadInt64(&n.stats.WriteNodeReq),
 			statWriteNodeReqFail:    atomic.LoadInt64(&n.stats.WriteNodeReqFail),
 			statWriteNodeReqPoints:  atomic.LoadInt64(&n.stats.WriteNodeReqPoints),
+			statWriteNodeReqQuota:   atomic.LoadInt64(&n.stats.WriteNodeReqQuota),
 		},
 	}}
 }
@@ -315,7 +317,12 @@ func (n *NodeProcessor) SendWrite() (int, error) {
 		return 0, err
 	}
 
-	if err := n.writer.WriteShardBinary(n.shardID, n.nodeID, points); err != nil && IsRetryable(err) {
+	if err := n.writer.WriteShardBinary(n.shardID, n.nodeID, points); tsdb.IsDiskQuotaExceeded(err) {
+		// Keep the block queued until the quota is cleared, or the block
+		// is purged by its age.
+		atomic.AddInt64(&n.stats.WriteNodeReqQuota, 1)
+		return 0, err
+	} else if err != nil && IsRetryable(err) {
 		atomic.AddInt64(&n.stats.WriteNodeReqFail, 1)
 		return 0, err
 	}
@@ -362,12 +369,15 @@ func (n *NodeProcessor) Empty() bool {
 	return n.queue.Empty()
 }
 
-// IsRetryable returns true if this error is temporary and could be retried
+// IsRetryable returns true if this error is temporary and could be retried.
+// A write rejected by a disk quota is not retried, so that the client sees
+// the rejection, but a block already queued waits for the quota to clear.
 func IsRetryable(err error) bool {
 	if err == nil {
 		return false
 	}
-	if strings.Contains(err.Error(), "field type conflict") || strings.Contains(err.Error(), "partial write") {
+	if strings.Contains(err.Error(), "field type conflict") || strings.Contains(err.Error(), "partial write") ||
+		strings.Contains(err.Error(), "disk quota exceeded") {
 		return false
 	}
 	return true
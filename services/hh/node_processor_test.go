@@ -9,6 +9,7 @@ import (
 
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
 )
 
 type fakeShardWriter struct {
@@ -159,6 +160,52 @@ func TestNodeProcessorSendBlock(t *testing.T) {
 	}
 }
 
+func TestNodeProcessorSendBlock_DiskQuota(t *testing.T) {
+	dir := t.TempDir()
+
+	var quotaErr error = tsdb.DiskQuotaExceededError{Database: "db0", Bytes: 20, Limit: 10}
+	var count int
+	sh := &fakeShardWriter{
+		ShardWriteFn: func(shardID, nodeID uint64, points [][]byte) error {
+			count++
+			return quotaErr
+		},
+	}
+	metastore := &fakeMetaStore{
+		NodeFn: func(nodeID uint64) (*meta.NodeInfo, error) { return &meta.NodeInfo{}, nil },
+	}
+
+	n := NewNodeProcessor(NewConfig(), 2, 1, dir, sh, metastore)
+	if err := n.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+
+	pt := models.MustNewPoint("cpu", nil, models.Fields{"value": 1.0}, time.Unix(0, 0))
+	if err := n.WriteShard([]models.Point{pt}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A block rejected by a disk quota stays queued.
+	if _, err := n.SendWrite(); !tsdb.IsDiskQuotaExceeded(err) {
+		t.Fatalf("unexpected error: %v", err)
+	} else if n.Empty() {
+		t.Fatal("block rejected by quota removed from the queue")
+	} else if v := n.Statistics(nil)[0].Values[statWriteNodeReqQuota]; v != int64(1) {
+		t.Fatalf("unexpected %s: %v", statWriteNodeReqQuota, v)
+	}
+
+	// It is sent once the quota is cleared.
+	quotaErr = nil
+	if _, err := n.SendWrite(); err != nil {
+		t.Fatal(err)
+	} else if count != 2 {
+		t.Fatalf("unexpected writes: %d", count)
+	} else if _, err := n.SendWrite(); err != io.EOF {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestNodeProcessorMarshalWrite(t *testing.T) {
 	expShardID := uint64(127)
 	expPointsStr := `cpu value1=1.0,value2=1.0,value3=3.0,value4=4,value5="five" 1000000000
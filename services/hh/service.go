@@ -36,6 +36,7 @@ const (
 	statWriteNodeReq        = "writeNodeReq"
 	statWriteNodeReqFail    = "writeNodeReqFail"
 	statWriteNodeReqPoints  = "writeNodeReqPoints"
+	statWriteNodeReqQuota   = "writeNodeReqQuota"
 )
 
 // Service represents a hinted handoff service.
@@ -99,6 +100,7 @@ type Statistics struct {
 	WriteNodeReq        int64
 	WriteNodeReqFail    int64
 	WriteNodeReqPoints  int64
+	WriteNodeReqQuota   int64
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -118,6 +120,7 @@ func (s *Service) Statistics(tags map[string]string) []models.Statistic {
 			statWriteNodeReq:        atomic.LoadInt64(&s.stats.WriteNodeReq),
 			statWriteNodeReqFail:    atomic.LoadInt64(&s.stats.WriteNodeReqFail),
 			statWriteNodeReqPoints:  atomic.LoadInt64(&s.stats.WriteNodeReqPoints),
+			statWriteNodeReqQuota:   atomic.LoadInt64(&s.stats.WriteNodeReqQuota),
 		},
 	}}
 	for _, processors := range s.processors {
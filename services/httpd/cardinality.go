@@ -0,0 +1,62 @@
+package httpd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxql"
+)
+
+// serveCardinalityReport returns the cardinality report of the database of
+// the request, listing the measurements and tag keys with the most series and
+// values, and how fast they grew since the previous report.
+func (h *Handler) serveCardinalityReport(w http.ResponseWriter, r *http.Request, user meta.User) {
+	h.requestTracker.Add(r, user)
+
+	db := r.FormValue("db")
+	if db == "" {
+		h.httpError(w, "database is required", http.StatusBadRequest)
+		return
+	}
+	if h.Config.AuthEnabled {
+		if user == nil {
+			h.httpError(w, fmt.Sprintf("user is required to read from database %q", db), http.StatusForbidden)
+			return
+		}
+		if h.QueryAuthorizer.AuthorizeDatabase(user, influxql.ReadPrivilege, db) != nil {
+			h.httpError(w, fmt.Sprintf("user %q is not authorized to read from database %q", user.ID(), db), http.StatusForbidden)
+			return
+		}
+	}
+	if h.MetaClient.Database(db) == nil {
+		h.httpError(w, fmt.Sprintf("database not found: %q", db), http.StatusNotFound)
+		return
+	}
+
+	var n int
+	if s := r.FormValue("n"); s != "" {
+		var err error
+		if n, err = strconv.Atoi(s); err != nil || n <= 0 {
+			h.httpError(w, fmt.Sprintf("invalid n: %s", s), http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.CardinalityReporter.CardinalityReport(r.Context(), db, n)
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	h.writeHeader(w, http.StatusOK)
+	w.Write(b)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/opentelemetry"
 	"github.com/influxdata/influxdb/toml"
 )
 
@@ -28,66 +29,81 @@ const (
 
 	// DefaultEnqueuedWriteTimeout is the maximum time a write request can wait to be processed.
 	DefaultEnqueuedWriteTimeout = 30 * time.Second
+
+	// DefaultPromReadMaxBytesInFrame is the default maximum size of a single frame of a streamed
+	// Prometheus remote read response, in bytes.
+	DefaultPromReadMaxBytesInFrame = 1024 * 1024
+
+	// DefaultPromQueryMaxSamples is the default maximum number of samples a single PromQL
+	// query may load into memory.
+	DefaultPromQueryMaxSamples = 50000000
 )
 
 // Config represents a configuration for a HTTP service.
 type Config struct {
-	Enabled                 bool              `toml:"enabled"`
-	BindAddress             string            `toml:"bind-address"`
-	AuthEnabled             bool              `toml:"auth-enabled"`
-	LogEnabled              bool              `toml:"log-enabled"`
-	SuppressWriteLog        bool              `toml:"suppress-write-log"`
-	WriteTracing            bool              `toml:"write-tracing"`
-	FluxEnabled             bool              `toml:"flux-enabled"`
-	FluxLogEnabled          bool              `toml:"flux-log-enabled"`
-	PprofEnabled            bool              `toml:"pprof-enabled"`
-	PprofAuthEnabled        bool              `toml:"pprof-auth-enabled"`
-	DebugPprofEnabled       bool              `toml:"debug-pprof-enabled"`
-	PingAuthEnabled         bool              `toml:"ping-auth-enabled"`
-	PromReadAuthEnabled     bool              `toml:"prom-read-auth-enabled"`
-	HTTPHeaders             map[string]string `toml:"headers"`
-	HTTPSEnabled            bool              `toml:"https-enabled"`
-	HTTPSCertificate        string            `toml:"https-certificate"`
-	HTTPSPrivateKey         string            `toml:"https-private-key"`
-	MaxRowLimit             int               `toml:"max-row-limit"`
-	MaxConnectionLimit      int               `toml:"max-connection-limit"`
-	SharedSecret            string            `toml:"shared-secret"`
-	Realm                   string            `toml:"realm"`
-	UnixSocketEnabled       bool              `toml:"unix-socket-enabled"`
-	UnixSocketGroup         *toml.Group       `toml:"unix-socket-group"`
-	UnixSocketPermissions   toml.FileMode     `toml:"unix-socket-permissions"`
-	BindSocket              string            `toml:"bind-socket"`
-	MaxBodySize             int               `toml:"max-body-size"`
-	AccessLogPath           string            `toml:"access-log-path"`
-	AccessLogStatusFilters  []StatusFilter    `toml:"access-log-status-filters"`
-	MaxConcurrentWriteLimit int               `toml:"max-concurrent-write-limit"`
-	MaxEnqueuedWriteLimit   int               `toml:"max-enqueued-write-limit"`
-	EnqueuedWriteTimeout    time.Duration     `toml:"enqueued-write-timeout"`
-	TLS                     *tls.Config       `toml:"-"`
+	Enabled                        bool              `toml:"enabled"`
+	BindAddress                    string            `toml:"bind-address"`
+	AuthEnabled                    bool              `toml:"auth-enabled"`
+	LogEnabled                     bool              `toml:"log-enabled"`
+	SuppressWriteLog               bool              `toml:"suppress-write-log"`
+	WriteTracing                   bool              `toml:"write-tracing"`
+	FluxEnabled                    bool              `toml:"flux-enabled"`
+	FluxLogEnabled                 bool              `toml:"flux-log-enabled"`
+	PprofEnabled                   bool              `toml:"pprof-enabled"`
+	PprofAuthEnabled               bool              `toml:"pprof-auth-enabled"`
+	DebugPprofEnabled              bool              `toml:"debug-pprof-enabled"`
+	PingAuthEnabled                bool              `toml:"ping-auth-enabled"`
+	PromReadAuthEnabled            bool              `toml:"prom-read-auth-enabled"`
+	PromReadMaxBytes               int               `toml:"prom-read-max-bytes"`
+	PromReadMaxBytesInFrame        int               `toml:"prom-read-max-bytes-in-frame"`
+	PromQueryMaxSamples            int               `toml:"prom-query-max-samples"`
+	OTLPPromotedResourceAttributes []string          `toml:"otlp-promoted-resource-attributes"`
+	HTTPHeaders                    map[string]string `toml:"headers"`
+	HTTPSEnabled                   bool              `toml:"https-enabled"`
+	HTTPSCertificate               string            `toml:"https-certificate"`
+	HTTPSPrivateKey                string            `toml:"https-private-key"`
+	MaxRowLimit                    int               `toml:"max-row-limit"`
+	MaxConnectionLimit             int               `toml:"max-connection-limit"`
+	SharedSecret                   string            `toml:"shared-secret"`
+	Realm                          string            `toml:"realm"`
+	UnixSocketEnabled              bool              `toml:"unix-socket-enabled"`
+	UnixSocketGroup                *toml.Group       `toml:"unix-socket-group"`
+	UnixSocketPermissions          toml.FileMode     `toml:"unix-socket-permissions"`
+	BindSocket                     string            `toml:"bind-socket"`
+	MaxBodySize                    int               `toml:"max-body-size"`
+	AccessLogPath                  string            `toml:"access-log-path"`
+	AccessLogStatusFilters         []StatusFilter    `toml:"access-log-status-filters"`
+	MaxConcurrentWriteLimit        int               `toml:"max-concurrent-write-limit"`
+	MaxEnqueuedWriteLimit          int               `toml:"max-enqueued-write-limit"`
+	EnqueuedWriteTimeout           time.Duration     `toml:"enqueued-write-timeout"`
+	TLS                            *tls.Config       `toml:"-"`
 }
 
 // NewConfig returns a new Config with default settings.
 func NewConfig() Config {
 	return Config{
-		Enabled:               true,
-		FluxEnabled:           false,
-		FluxLogEnabled:        false,
-		BindAddress:           DefaultBindAddress,
-		LogEnabled:            true,
-		PprofEnabled:          true,
-		PprofAuthEnabled:      false,
-		DebugPprofEnabled:     false,
-		PingAuthEnabled:       false,
-		PromReadAuthEnabled:   false,
-		HTTPSEnabled:          false,
-		HTTPSCertificate:      "/etc/ssl/influxdb.pem",
-		MaxRowLimit:           0,
-		Realm:                 DefaultRealm,
-		UnixSocketEnabled:     false,
-		UnixSocketPermissions: 0777,
-		BindSocket:            DefaultBindSocket,
-		MaxBodySize:           DefaultMaxBodySize,
-		EnqueuedWriteTimeout:  DefaultEnqueuedWriteTimeout,
+		Enabled:                        true,
+		FluxEnabled:                    false,
+		FluxLogEnabled:                 false,
+		BindAddress:                    DefaultBindAddress,
+		LogEnabled:                     true,
+		PprofEnabled:                   true,
+		PprofAuthEnabled:               false,
+		DebugPprofEnabled:              false,
+		PingAuthEnabled:                false,
+		PromReadAuthEnabled:            false,
+		PromReadMaxBytesInFrame:        DefaultPromReadMaxBytesInFrame,
+		PromQueryMaxSamples:            DefaultPromQueryMaxSamples,
+		OTLPPromotedResourceAttributes: append([]string(nil), opentelemetry.DefaultPromotedResourceAttributes...),
+		HTTPSEnabled:                   false,
+		HTTPSCertificate:               "/etc/ssl/influxdb.pem",
+		MaxRowLimit:                    0,
+		Realm:                          DefaultRealm,
+		UnixSocketEnabled:              false,
+		UnixSocketPermissions:          0777,
+		BindSocket:                     DefaultBindSocket,
+		MaxBodySize:                    DefaultMaxBodySize,
+		EnqueuedWriteTimeout:           DefaultEnqueuedWriteTimeout,
 	}
 }
 
@@ -132,6 +132,10 @@ type Handler struct {
 
 	Store Store
 
+	CardinalityReporter interface {
+		CardinalityReport(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error)
+	}
+
 	// Flux services
 	Controller       Controller
 	CompilerMappings flux.CompilerMappings
@@ -215,6 +219,50 @@ func NewHandler(c Config) *Handler {
 			"prometheus-read", // Prometheus remote read
 			"POST", "/api/v1/prom/read", true, true, h.servePromRead,
 		},
+		Route{
+			"prometheus-query", // Prometheus instant query
+			"GET", "/api/v1/query", true, true, h.servePromQuery,
+		},
+		Route{
+			"prometheus-query", // Prometheus instant query
+			"POST", "/api/v1/query", true, true, h.servePromQuery,
+		},
+		Route{
+			"prometheus-query-range", // Prometheus range query
+			"GET", "/api/v1/query_range", true, true, h.servePromQueryRange,
+		},
+		Route{
+			"prometheus-query-range", // Prometheus range query
+			"POST", "/api/v1/query_range", true, true, h.servePromQueryRange,
+		},
+		Route{
+			"prometheus-series", // Prometheus series metadata
+			"GET", "/api/v1/series", true, true, h.servePromSeries,
+		},
+		Route{
+			"prometheus-series", // Prometheus series metadata
+			"POST", "/api/v1/series", true, true, h.servePromSeries,
+		},
+		Route{
+			"prometheus-labels", // Prometheus label names
+			"GET", "/api/v1/labels", true, true, h.servePromLabels,
+		},
+		Route{
+			"prometheus-labels", // Prometheus label names
+			"POST", "/api/v1/labels", true, true, h.servePromLabels,
+		},
+		Route{
+			"prometheus-label-values", // Prometheus label values
+			"GET", "/api/v1/label/:name/values", true, true, h.servePromLabelValues,
+		},
+		Route{
+			"otlp-metrics", // OpenTelemetry OTLP/HTTP metrics export
+			"POST", "/v1/metrics", false, true, h.serveOTLPMetrics,
+		},
+		Route{
+			"cardinality-report", // Cardinality report of a database
+			"GET", "/cardinality", true, true, h.serveCardinalityReport,
+		},
 		Route{ // Ping
 			"ping",
 			"GET", "/ping", false, true, authWrapper(h.servePing),
@@ -370,6 +418,8 @@ type Statistics struct {
 	RecoveredPanics              int64
 	PromWriteRequests            int64
 	PromReadRequests             int64
+	PromQueryRequests            int64
+	OTLPWriteRequests            int64
 	FluxQueryRequests            int64
 	FluxQueryRequestDuration     int64
 }
@@ -402,6 +452,8 @@ func (h *Handler) Statistics(tags map[string]string) []models.Statistic {
 			statRecoveredPanics:              atomic.LoadInt64(&h.stats.RecoveredPanics),
 			statPromWriteRequest:             atomic.LoadInt64(&h.stats.PromWriteRequests),
 			statPromReadRequest:              atomic.LoadInt64(&h.stats.PromReadRequests),
+			statPromQueryRequest:             atomic.LoadInt64(&h.stats.PromQueryRequests),
+			statOTLPWriteRequest:             atomic.LoadInt64(&h.stats.OTLPWriteRequests),
 			statFluxQueryRequests:            atomic.LoadInt64(&h.stats.FluxQueryRequests),
 			statFluxQueryRequestDuration:     atomic.LoadInt64(&h.stats.FluxQueryRequestDuration),
 		},
@@ -426,7 +478,7 @@ func (h *Handler) AddRoutes(routes ...Route) {
 		// Throttle route if this is a write endpoint.
 		if r.Method == http.MethodPost {
 			switch r.Pattern {
-			case "/write", "/api/v1/prom/write":
+			case "/write", "/api/v1/prom/write", "/v1/metrics":
 				handler = h.writeThrottler.Handler(handler)
 			default:
 			}
@@ -984,31 +1036,8 @@ func (h *Handler) serveWrite(database, retentionPolicy, precision string, w http
 		}
 	}
 
-	type pointsWriterWithContext interface {
-		WritePointsWithContext(context.Context, string, string, models.ConsistencyLevel, meta.User, []models.Point) error
-	}
-
-	writePoints := func() error {
-		switch pw := h.PointsWriter.(type) {
-		case pointsWriterWithContext:
-			var npoints, nvalues int64
-			ctx := context.WithValue(context.Background(), coordinator.StatPointsWritten, &npoints)
-			ctx = context.WithValue(ctx, coordinator.StatValuesWritten, &nvalues)
-
-			// for now, just store the number of values used.
-			err := pw.WritePointsWithContext(ctx, database, retentionPolicy, consistency, user, points)
-			atomic.AddInt64(&h.stats.ValuesWrittenOK, nvalues)
-			if err != nil {
-				return err
-			}
-			return nil
-		default:
-			return h.PointsWriter.WritePoints(database, retentionPolicy, consistency, user, points)
-		}
-	}
-
 	// Write points.
-	if err := writePoints(); influxdb.IsClientError(err) {
+	if err := h.writePoints(database, retentionPolicy, consistency, user, points); influxdb.IsClientError(err) {
 		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
 		h.httpError(w, err.Error(), http.StatusBadRequest)
 		return
@@ -1016,6 +1045,10 @@ func (h *Handler) serveWrite(database, retentionPolicy, precision string, w http
 		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
 		h.httpError(w, err.Error(), http.StatusForbidden)
 		return
+	} else if tsdb.IsDiskQuotaExceeded(err) {
+		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
+		h.httpError(w, err.Error(), http.StatusInsufficientStorage)
+		return
 	} else if werr, ok := err.(tsdb.PartialWriteError); ok {
 		atomic.AddInt64(&h.stats.PointsWrittenOK, int64(len(points)-werr.Dropped))
 		atomic.AddInt64(&h.stats.PointsWrittenDropped, int64(werr.Dropped))
@@ -1038,6 +1071,30 @@ func (h *Handler) serveWrite(database, retentionPolicy, precision string, w http
 	h.writeHeader(w, http.StatusNoContent)
 }
 
+// pointsWriterWithContext is a PointsWriter which reports the number of
+// points and values written through its context.
+type pointsWriterWithContext interface {
+	WritePointsWithContext(context.Context, string, string, models.ConsistencyLevel, meta.User, []models.Point) error
+}
+
+// writePoints writes points to the PointsWriter, counting the values written
+// when it supports WritePointsWithContext.
+func (h *Handler) writePoints(database, retentionPolicy string, consistency models.ConsistencyLevel, user meta.User, points []models.Point) error {
+	switch pw := h.PointsWriter.(type) {
+	case pointsWriterWithContext:
+		var npoints, nvalues int64
+		ctx := context.WithValue(context.Background(), coordinator.StatPointsWritten, &npoints)
+		ctx = context.WithValue(ctx, coordinator.StatValuesWritten, &nvalues)
+
+		// for now, just store the number of values used.
+		err := pw.WritePointsWithContext(ctx, database, retentionPolicy, consistency, user, points)
+		atomic.AddInt64(&h.stats.ValuesWrittenOK, nvalues)
+		return err
+	default:
+		return h.PointsWriter.WritePoints(database, retentionPolicy, consistency, user, points)
+	}
+}
+
 // serveOptions returns an empty response to comply with OPTIONS pre-flight requests
 func (h *Handler) serveOptions(w http.ResponseWriter, r *http.Request) {
 	h.writeHeader(w, http.StatusNoContent)
@@ -1226,6 +1283,10 @@ func (h *Handler) servePromWrite(w http.ResponseWriter, r *http.Request, user me
 		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
 		h.httpError(w, err.Error(), http.StatusForbidden)
 		return
+	} else if tsdb.IsDiskQuotaExceeded(err) {
+		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
+		h.httpError(w, err.Error(), http.StatusInsufficientStorage)
+		return
 	} else if werr, ok := err.(tsdb.PartialWriteError); ok {
 		atomic.AddInt64(&h.stats.PointsWrittenOK, int64(len(points)-werr.Dropped))
 		atomic.AddInt64(&h.stats.PointsWrittenDropped, int64(werr.Dropped))
@@ -1268,15 +1329,21 @@ func (h *Handler) servePromRead(w http.ResponseWriter, r *http.Request, user met
 	db := r.FormValue("db")
 	rp := r.FormValue("rp")
 
-	if h.Config.AuthEnabled && h.Config.PromReadAuthEnabled {
-		if user == nil {
-			h.httpError(w, fmt.Sprintf("user is required to read from database %q", db), http.StatusForbidden)
-			return
-		}
-		if h.QueryAuthorizer.AuthorizeDatabase(user, influxql.ReadPrivilege, db) != nil {
-			h.httpError(w, fmt.Sprintf("user %q is not authorized to read from database %q", user.ID(), db), http.StatusForbidden)
-			return
-		}
+	if err := h.authorizePromRead(user, db); err != nil {
+		h.httpError(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	responseType, err := prometheus.NegotiateResponseType(req.AcceptedResponseTypes)
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit := prometheus.NewReadLimit(int64(h.Config.PromReadMaxBytes))
+	if responseType == remote.ReadRequest_STREAMED_XOR_CHUNKS {
+		h.servePromReadStreamed(w, r, &req, db, rp, limit)
+		return
 	}
 
 	readRequest, err := prometheus.ReadRequestToInfluxStorageRequest(&req, db, rp)
@@ -1310,76 +1377,96 @@ func (h *Handler) servePromRead(w http.ResponseWriter, r *http.Request, user met
 		h.httpError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-
-	resp := &remote.ReadResponse{
-		Results: []*remote.QueryResult{{}},
+	if rs != nil {
+		defer rs.Close()
 	}
 
-	if rs == nil {
-		respond(resp)
+	result, err := prometheus.ReadQueryResult(rs, req.Queries[0].Hints, limit, h.Logger)
+	if err == prometheus.ErrReadLimitExceeded {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer rs.Close()
 
-	for rs.Next() {
-		cur := rs.Cursor()
-		if cur == nil {
-			// no data for series key + field combination
-			continue
+	respond(&remote.ReadResponse{
+		Results: []*remote.QueryResult{result},
+	})
+}
+
+// authorizePromRead returns an error if user may not read db through the
+// Prometheus APIs. Authorization is only checked when enabled for remote read.
+func (h *Handler) authorizePromRead(user meta.User, db string) error {
+	if !h.Config.AuthEnabled || !h.Config.PromReadAuthEnabled {
+		return nil
+	}
+	if user == nil {
+		return fmt.Errorf("user is required to read from database %q", db)
+	}
+	if h.QueryAuthorizer.AuthorizeDatabase(user, influxql.ReadPrivilege, db) != nil {
+		return fmt.Errorf("user %q is not authorized to read from database %q", user.ID(), db)
+	}
+	return nil
+}
+
+// servePromReadStreamed returns the result of each query of a Prometheus remote
+// read request as a stream of XOR encoded chunks, so that the response never has
+// to be held in memory.
+func (h *Handler) servePromReadStreamed(w http.ResponseWriter, r *http.Request, req *remote.ReadRequest, db, rp string, limit *prometheus.ReadLimit) {
+	readRequests := make([]*datatypes.ReadFilterRequest, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		readRequest, err := prometheus.QueryToInfluxStorageRequest(q, db, rp)
+		if err != nil {
+			h.httpError(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		readRequests = append(readRequests, readRequest)
+	}
 
-		tags := prometheus.RemoveInfluxSystemTags(rs.Tags())
-		var unsupportedCursor string
-		switch cur := cur.(type) {
-		case tsdb.FloatArrayCursor:
-			var series *remote.TimeSeries
-			for {
-				a := cur.Next()
-				if a.Len() == 0 {
-					break
-				}
+	maxBytesInFrame := h.Config.PromReadMaxBytesInFrame
+	if maxBytesInFrame <= 0 {
+		maxBytesInFrame = DefaultPromReadMaxBytesInFrame
+	}
 
-				// We have some data for this series.
-				if series == nil {
-					series = &remote.TimeSeries{
-						Labels: prometheus.ModelTagsToLabelPairs(tags),
-					}
-				}
+	flusher, _ := w.(http.Flusher)
+	cw := prometheus.NewChunkedWriter(w, flusher)
+	defer func() {
+		atomic.AddInt64(&h.stats.QueryRequestBytesTransmitted, cw.BytesWritten())
+	}()
 
-				for i, ts := range a.Timestamps {
-					series.Samples = append(series.Samples, &remote.Sample{
-						TimestampMs: ts / int64(time.Millisecond),
-						Value:       a.Values[i],
-					})
-				}
-			}
+	w.Header().Set("Content-Type", prometheus.StreamedContentType)
 
-			// There was data for the series.
-			if series != nil {
-				resp.Results[0].Timeseries = append(resp.Results[0].Timeseries, series)
+	for i, readRequest := range readRequests {
+		err := func() error {
+			rs, err := h.Store.ReadFilter(r.Context(), readRequest)
+			if err != nil {
+				return err
 			}
-		case tsdb.IntegerArrayCursor:
-			unsupportedCursor = "int64"
-		case tsdb.UnsignedArrayCursor:
-			unsupportedCursor = "uint"
-		case tsdb.BooleanArrayCursor:
-			unsupportedCursor = "bool"
-		case tsdb.StringArrayCursor:
-			unsupportedCursor = "string"
-		default:
-			panic(fmt.Sprintf("unreachable: %T", cur))
+			if rs != nil {
+				defer rs.Close()
+			}
+			return prometheus.StreamChunkedReadResponses(cw, rs, int64(i), req.Queries[i].Hints, maxBytesInFrame, limit, h.Logger)
+		}()
+		if err == nil {
+			continue
 		}
-		cur.Close()
 
-		if len(unsupportedCursor) > 0 {
-			h.Logger.Info("Prometheus can't read cursor",
-				zap.String("cursor_type", unsupportedCursor),
-				zap.Stringer("series", tags),
-			)
+		if cw.BytesWritten() == 0 {
+			w.Header().Del("Content-Type")
+			code := http.StatusInternalServerError
+			if err == prometheus.ErrReadLimitExceeded {
+				code = http.StatusBadRequest
+			}
+			h.httpError(w, err.Error(), code)
+			return
 		}
-	}
 
-	respond(resp)
+		// The response is already partially written, so abort it to make sure
+		// the client does not mistake it for a complete one.
+		h.Logger.Info("Prometheus streamed read failed", zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
 }
 
 func (h *Handler) serveFluxQuery(w http.ResponseWriter, r *http.Request, user meta.User) {
@@ -2071,6 +2158,11 @@ func (h *Handler) recovery(inner http.Handler, name string) http.Handler {
 
 		defer func() {
 			if err := recover(); err != nil {
+				if err == http.ErrAbortHandler {
+					// The handler chose to abort the response.
+					panic(err)
+				}
+
 				logLine := buildLogLine(l, r, start)
 				logLine = fmt.Sprintf("%s [panic:%s] %s", logLine, err, debug.Stack())
 				h.CLFLogger.Println(logLine)
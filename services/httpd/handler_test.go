@@ -3,9 +3,11 @@ package httpd_test
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"log"
 	"math"
@@ -22,6 +24,7 @@ import (
 	"time"
 
 	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/google/go-cmp/cmp"
@@ -35,6 +38,7 @@ import (
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/monitor"
 	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/opentelemetry/otlp"
 	"github.com/influxdata/influxdb/pkg/testing/assert"
 	"github.com/influxdata/influxdb/prometheus/remote"
 	"github.com/influxdata/influxdb/query"
@@ -1255,6 +1259,531 @@ func TestHandler_PromRead_NilResultSet(t *testing.T) {
 	}
 }
 
+// Ensure Prometheus remote read requests accepting streamed responses are
+// returned as frames of XOR encoded chunks.
+func TestHandler_PromRead_Streamed(t *testing.T) {
+	req := &remote.ReadRequest{
+		Queries: []*remote.Query{{
+			Matchers: []*remote.LabelMatcher{
+				{
+					Type:  remote.MatchType_EQUAL,
+					Name:  "__name__",
+					Value: "value",
+				},
+			},
+			StartTimestampMs: 1,
+			EndTimestampMs:   30000,
+		}},
+		AcceptedResponseTypes: []remote.ReadRequest_ResponseType{remote.ReadRequest_STREAMED_XOR_CHUNKS},
+	}
+	data, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal("couldn't marshal prometheus request")
+	}
+	b := bytes.NewReader(snappy.Encode(nil, data))
+	h := NewHandler(false)
+	w := httptest.NewRecorder()
+
+	// Number of results in the result set
+	var i int64
+	h.Store.ResultSet.NextFn = func() bool {
+		i++
+		return i <= 2
+	}
+
+	// 150 samples for each cursor, which spans two chunks.
+	h.Store.ResultSet.CursorFn = func() tsdb.Cursor {
+		cursor := internal.NewFloatArrayCursorMock()
+
+		var n int64
+		cursor.NextFn = func() *tsdb.FloatArray {
+			n++
+			if n > 3 {
+				return &tsdb.FloatArray{}
+			}
+			a := &tsdb.FloatArray{}
+			for j := int64(0); j < 50; j++ {
+				a.Timestamps = append(a.Timestamps, ((n-1)*50+j+1)*int64(100*time.Millisecond))
+				a.Values = append(a.Values, float64(j))
+			}
+			return a
+		}
+
+		return cursor
+	}
+
+	// Tags for each cursor.
+	h.Store.ResultSet.TagsFn = func() models.Tags {
+		return models.NewTags(map[string]string{
+			"host":         fmt.Sprintf("server-%d", i),
+			"_measurement": "mem",
+		})
+	}
+
+	h.ServeHTTP(w, MustNewRequest("POST", "/api/v1/prom/read?db=foo&rp=bar", b))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", w.Code)
+	}
+	if got, exp := w.Header().Get("Content-Type"), "application/x-streamed-protobuf; proto=prometheus.ChunkedReadResponse"; got != exp {
+		t.Fatalf("unexpected Content-Type:\n%v", cmp.Diff(exp, got))
+	}
+
+	var frames []*remote.ChunkedReadResponse
+	body := w.Body.Bytes()
+	for len(body) > 0 {
+		size, n := binary.Uvarint(body)
+		if n <= 0 || len(body) < n+4+int(size) {
+			t.Fatalf("invalid frame header")
+		}
+		crc := binary.BigEndian.Uint32(body[n:])
+		msg := body[n+4 : n+4+int(size)]
+		if crc32.Checksum(msg, crc32.MakeTable(crc32.Castagnoli)) != crc {
+			t.Fatalf("frame checksum mismatch")
+		}
+
+		resp := new(remote.ChunkedReadResponse)
+		if err := proto.Unmarshal(msg, resp); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, resp)
+		body = body[n+4+int(size):]
+	}
+
+	if got, exp := len(frames), 2; got != exp {
+		t.Fatalf("unexpected number of frames: got %d, exp %d", got, exp)
+	}
+	for i, frame := range frames {
+		if got, exp := len(frame.ChunkedSeries), 1; got != exp {
+			t.Fatalf("unexpected number of series: got %d, exp %d", got, exp)
+		}
+		series := frame.ChunkedSeries[0]
+
+		expLabels := []*remote.LabelPair{{Name: "host", Value: fmt.Sprintf("server-%d", i+1)}}
+		if !reflect.DeepEqual(series.Labels, expLabels) {
+			t.Fatalf("unexpected labels:\n%v", cmp.Diff(expLabels, series.Labels))
+		}
+
+		if got, exp := len(series.Chunks), 2; got != exp {
+			t.Fatalf("unexpected number of chunks: got %d, exp %d", got, exp)
+		}
+		for j, exp := range []struct {
+			min, max int64
+			samples  uint16
+		}{
+			{min: 100, max: 12000, samples: 120},
+			{min: 12100, max: 15000, samples: 30},
+		} {
+			chunk := series.Chunks[j]
+			if chunk.Type != remote.Chunk_XOR {
+				t.Fatalf("unexpected chunk encoding: %v", chunk.Type)
+			}
+			if chunk.MinTimeMs != exp.min || chunk.MaxTimeMs != exp.max {
+				t.Fatalf("unexpected chunk time range: got [%d, %d], exp [%d, %d]", chunk.MinTimeMs, chunk.MaxTimeMs, exp.min, exp.max)
+			}
+			if got := binary.BigEndian.Uint16(chunk.Data); got != exp.samples {
+				t.Fatalf("unexpected number of samples in chunk: got %d, exp %d", got, exp.samples)
+			}
+		}
+	}
+}
+
+// Ensure Prometheus remote read requests fail when the response exceeds the
+// configured byte budget.
+func TestHandler_PromRead_MaxBytes(t *testing.T) {
+	newRequest := func(typ remote.ReadRequest_ResponseType) *http.Request {
+		req := &remote.ReadRequest{
+			Queries: []*remote.Query{{
+				Matchers: []*remote.LabelMatcher{
+					{
+						Type:  remote.MatchType_EQUAL,
+						Name:  "__name__",
+						Value: "value",
+					},
+				},
+				StartTimestampMs: 1,
+				EndTimestampMs:   2,
+			}},
+			AcceptedResponseTypes: []remote.ReadRequest_ResponseType{typ},
+		}
+		data, err := proto.Marshal(req)
+		if err != nil {
+			t.Fatal("couldn't marshal prometheus request")
+		}
+		return MustNewRequest("POST", "/api/v1/prom/read?db=foo&rp=bar", bytes.NewReader(snappy.Encode(nil, data)))
+	}
+
+	newHandler := func(maxBytes int) *Handler {
+		h := NewHandlerWithConfig(NewHandlerConfig(func(c *httpd.Config) {
+			c.PromReadMaxBytes = maxBytes
+		}))
+		h.Store.ResultSet.NextFn = func() bool { return true }
+		h.Store.ResultSet.CursorFn = func() tsdb.Cursor {
+			cursor := internal.NewFloatArrayCursorMock()
+
+			var n int
+			cursor.NextFn = func() *tsdb.FloatArray {
+				n++
+				if n > 1 {
+					return &tsdb.FloatArray{}
+				}
+				return &tsdb.FloatArray{Timestamps: []int64{1000000}, Values: []float64{1}}
+			}
+			return cursor
+		}
+		h.Store.ResultSet.TagsFn = func() models.Tags {
+			return models.NewTags(map[string]string{"host": "server", "_measurement": "mem"})
+		}
+		return h
+	}
+
+	for _, typ := range []remote.ReadRequest_ResponseType{
+		remote.ReadRequest_SAMPLES,
+		remote.ReadRequest_STREAMED_XOR_CHUNKS,
+	} {
+		t.Run(typ.String(), func(t *testing.T) {
+			h := newHandler(8)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, newRequest(typ))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("unexpected status: %d", w.Code)
+			}
+		})
+	}
+
+	// A streamed response which has already been partially written is aborted.
+	t.Run("abort streamed", func(t *testing.T) {
+		defer func() {
+			if err := recover(); err != http.ErrAbortHandler {
+				t.Fatalf("unexpected panic: %v", err)
+			}
+		}()
+
+		h := newHandler(64)
+		h.ServeHTTP(httptest.NewRecorder(), newRequest(remote.ReadRequest_STREAMED_XOR_CHUNKS))
+		t.Fatal("expected the response to be aborted")
+	})
+}
+
+func TestHandler_PromQuery(t *testing.T) {
+	h := NewHandler(false)
+
+	// Two counters, sampled every 15s from 0s to 60s.
+	series := []struct {
+		instance string
+		values   []float64
+	}{
+		{instance: "a", values: []float64{0, 10, 20, 30, 40}},
+		{instance: "b", values: []float64{0, 20, 40, 60, 80}},
+	}
+	h.Handler.Store.(*internal.StorageStoreMock).ReadFilterFn = func(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+		rs := internal.NewStorageResultsMock()
+		i := -1
+		rs.NextFn = func() bool {
+			i++
+			return i < len(series)
+		}
+		rs.CursorFn = func() tsdb.Cursor {
+			cursor := internal.NewFloatArrayCursorMock()
+			done := false
+			cursor.NextFn = func() *tsdb.FloatArray {
+				a := tsdb.NewFloatArrayLen(0)
+				if done {
+					return a
+				}
+				done = true
+				for j, v := range series[i].values {
+					a.Timestamps = append(a.Timestamps, int64(j)*int64(15*time.Second))
+					a.Values = append(a.Values, v)
+				}
+				return a
+			}
+			return cursor
+		}
+		rs.TagsFn = func() models.Tags {
+			return models.NewTags(map[string]string{
+				"__name__":     "http_requests_total",
+				"_measurement": "http_requests_total",
+				"_field":       "value",
+				"job":          "api",
+				"instance":     series[i].instance,
+			})
+		}
+		return rs, nil
+	}
+
+	for _, tt := range []struct {
+		name string
+		url  string
+		code int
+		exp  string
+	}{
+		{
+			name: "instant query",
+			url:  "/api/v1/query?db=prom&time=60&query=" + url.QueryEscape("sum by (job) (http_requests_total)"),
+			code: http.StatusOK,
+			exp:  `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"api"},"value":[60,"120"]}]}}`,
+		},
+		{
+			name: "scalar",
+			url:  "/api/v1/query?db=prom&time=1.5&query=" + url.QueryEscape("1 + 2"),
+			code: http.StatusOK,
+			exp:  `{"status":"success","data":{"resultType":"scalar","result":[1.5,"3"]}}`,
+		},
+		{
+			name: "range query",
+			url:  "/api/v1/query_range?db=prom&start=30&end=60&step=15s&query=" + url.QueryEscape("sum(http_requests_total)"),
+			code: http.StatusOK,
+			exp:  `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[30,"60"],[45,"90"],[60,"120"]]}]}}`,
+		},
+		{
+			name: "series",
+			url:  "/api/v1/series?db=prom&match[]=http_requests_total",
+			code: http.StatusOK,
+			exp: `{"status":"success","data":[` +
+				`{"__name__":"http_requests_total","instance":"a","job":"api"},` +
+				`{"__name__":"http_requests_total","instance":"b","job":"api"}]}`,
+		},
+		{
+			name: "labels",
+			url:  "/api/v1/labels?db=prom",
+			code: http.StatusOK,
+			exp:  `{"status":"success","data":["__name__","instance","job"]}`,
+		},
+		{
+			name: "label values",
+			url:  "/api/v1/label/instance/values?db=prom",
+			code: http.StatusOK,
+			exp:  `{"status":"success","data":["a","b"]}`,
+		},
+		{
+			name: "missing database",
+			url:  "/api/v1/query?query=up",
+			code: http.StatusBadRequest,
+			exp:  `{"status":"error","errorType":"bad_data","error":"database is required"}`,
+		},
+		{
+			name: "parse error",
+			url:  "/api/v1/query?db=prom&query=" + url.QueryEscape("sum("),
+			code: http.StatusBadRequest,
+			exp:  `{"status":"error","errorType":"bad_data","error":"parse error at char 5: unexpected end of input"}`,
+		},
+		{
+			name: "series without match",
+			url:  "/api/v1/series?db=prom",
+			code: http.StatusBadRequest,
+			exp:  `{"status":"error","errorType":"bad_data","error":"no match[] parameter provided"}`,
+		},
+		{
+			name: "step too small",
+			url:  "/api/v1/query_range?db=prom&start=0&end=86400&step=1&query=up",
+			code: http.StatusBadRequest,
+			exp:  `{"status":"error","errorType":"bad_data","error":"exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)"}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, MustNewRequest("GET", tt.url, nil))
+			if w.Code != tt.code {
+				t.Fatalf("unexpected status: got %d, exp %d: %s", w.Code, tt.code, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Fatalf("unexpected content type: %s", got)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.exp {
+				t.Fatalf("unexpected body:\n%s", cmp.Diff(tt.exp, got))
+			}
+		})
+	}
+}
+
+// Ensure the handler writes OTLP metrics encoded as protobuf or JSON.
+func TestHandler_OTLPMetrics(t *testing.T) {
+	req := &otlp.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlp.ResourceMetrics{{
+			Resource: &otlp.Resource{Attributes: []*otlp.KeyValue{
+				{Key: "service.name", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "api"}}},
+			}},
+			ScopeMetrics: []*otlp.ScopeMetrics{{
+				Metrics: []*otlp.Metric{{
+					Name: "requests",
+					Data: &otlp.Metric_Sum{Sum: &otlp.Sum{IsMonotonic: true, DataPoints: []*otlp.NumberDataPoint{
+						{
+							Attributes:   []*otlp.KeyValue{{Key: "host", Value: &otlp.AnyValue{Value: &otlp.AnyValue_StringValue{StringValue: "a"}}}},
+							TimeUnixNano: 1000,
+							Value:        &otlp.NumberDataPoint_AsInt{AsInt: 7},
+							Exemplars: []*otlp.Exemplar{{
+								TimeUnixNano: 900,
+								Value:        &otlp.Exemplar_AsInt{AsInt: 1},
+								SpanId:       []byte{1, 2, 3, 4, 5, 6, 7, 8},
+								TraceId:      bytes.Repeat([]byte{0xab}, 16),
+							}},
+						},
+						{TimeUnixNano: 2000, Value: &otlp.NumberDataPoint_AsDouble{AsDouble: math.NaN()}},
+					}}},
+				}},
+			}},
+		}},
+	}
+
+	pb, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := (&jsonpb.Marshaler{}).MarshalToString(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Trace and span IDs are hex encoded in OTLP/JSON.
+	if !strings.Contains(js, `"spanId":"0102030405060708"`) || !strings.Contains(js, `"traceId":"abababababababababababababababab"`) {
+		t.Fatalf("unexpected JSON: %s", js)
+	}
+	var decoded otlp.ExportMetricsServiceRequest
+	if err := jsonpb.UnmarshalString(js, &decoded); err != nil {
+		t.Fatal(err)
+	} else if exp := req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].GetSum().DataPoints[0].Exemplars[0]; !reflect.DeepEqual(decoded.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].GetSum().DataPoints[0].Exemplars[0], exp) {
+		t.Fatalf("unexpected exemplar: %v", decoded.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].GetSum().DataPoints[0].Exemplars[0])
+	}
+	if err := jsonpb.UnmarshalString(`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"gauge":{"dataPoints":[{"exemplars":[{"traceId":"AQID"}]}]}}]}]}]}`, &decoded); err == nil || !strings.Contains(err.Error(), "invalid exemplar traceId") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		contentType string
+		body        []byte
+	}{
+		{contentType: "application/x-protobuf", body: pb},
+		{contentType: "application/json", body: []byte(js)},
+	} {
+		t.Run(tt.contentType, func(t *testing.T) {
+			h := NewHandler(false)
+			h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+				return &meta.DatabaseInfo{}
+			}
+
+			var called bool
+			h.PointsWriter.WritePointsFn = func(db, rp string, consistency models.ConsistencyLevel, _ meta.User, points []models.Point) error {
+				called = true
+				if db != "foo" || rp != "bar" || consistency != models.ConsistencyLevelAll {
+					t.Fatalf("unexpected write target: db=%s rp=%s consistency=%v", db, rp, consistency)
+				}
+				if len(points) != 1 {
+					t.Fatalf("got %d points, expected 1", len(points))
+				}
+				if got, exp := points[0].String(), "requests,host=a,service.name=api counter=7 1000"; got != exp {
+					t.Fatalf("unexpected point: got %s, exp %s", got, exp)
+				}
+				return nil
+			}
+
+			w := httptest.NewRecorder()
+			r := MustNewRequest("POST", "/v1/metrics?db=foo&rp=bar&consistency=all", bytes.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			h.ServeHTTP(w, r)
+			if !called {
+				t.Fatal("WritePoints: expected call")
+			}
+			if w.Code != http.StatusOK {
+				t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Fatalf("unexpected content type: %s", got)
+			}
+
+			var resp otlp.ExportMetricsServiceResponse
+			if tt.contentType == "application/json" {
+				err = jsonpb.Unmarshal(w.Body, &resp)
+			} else {
+				err = proto.Unmarshal(w.Body.Bytes(), &resp)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := resp.GetPartialSuccess().GetRejectedDataPoints(); got != 1 {
+				t.Fatalf("unexpected rejected data points: %d", got)
+			}
+		})
+	}
+
+	// Errors are Status messages encoded like the request.
+	h := NewHandler(false)
+	w := httptest.NewRecorder()
+	r := MustNewRequest("POST", "/v1/metrics?db=foo", bytes.NewReader(pb))
+	r.Header.Set("Content-Type", "text/plain")
+	h.ServeHTTP(w, r)
+	var status otlp.Status
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if err := proto.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	} else if status.Code != 3 || status.Message != `unsupported content type "text/plain"` {
+		t.Fatalf("unexpected status message: %v", &status)
+	}
+
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo { return nil }
+	w = httptest.NewRecorder()
+	r = MustNewRequest("POST", "/v1/metrics?db=foo", strings.NewReader(js))
+	r.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unexpected status: %d", w.Code)
+	} else if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("unexpected content type: %s", got)
+	} else if err := jsonpb.Unmarshal(w.Body, &status); err != nil {
+		t.Fatal(err)
+	} else if status.Code != 5 || status.Message != `database not found: "foo"` {
+		t.Fatalf("unexpected status message: %v", &status)
+	}
+}
+
+func TestHandler_CardinalityReport(t *testing.T) {
+	h := NewHandler(false)
+	h.MetaClient.DatabaseFn = func(name string) *meta.DatabaseInfo {
+		if name != "foo" {
+			return nil
+		}
+		return &meta.DatabaseInfo{Name: name}
+	}
+	h.Handler.CardinalityReporter = cardinalityReporterFunc(func(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error) {
+		if database != "foo" || n != 5 {
+			t.Fatalf("unexpected report request: db=%s n=%d", database, n)
+		}
+		return &tsdb.CardinalityReport{
+			Database:     database,
+			Time:         time.Unix(0, 0).UTC(),
+			SeriesN:      2,
+			Measurements: []tsdb.MeasurementCardinality{{Measurement: "cpu", SeriesN: 2}},
+		}, nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, MustNewRequest("GET", "/cardinality?db=foo&n=5", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", w.Code, w.Body.String())
+	}
+	var report tsdb.CardinalityReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	} else if report.SeriesN != 2 || len(report.Measurements) != 1 || report.Measurements[0].Measurement != "cpu" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	for _, u := range []string{"/cardinality", "/cardinality?db=foo&n=x", "/cardinality?db=bar"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, MustNewRequest("GET", u, nil))
+		if w.Code == http.StatusOK {
+			t.Fatalf("%s: unexpected status: %d", u, w.Code)
+		}
+	}
+}
+
+// cardinalityReporterFunc is a function returning the cardinality report of a database.
+type cardinalityReporterFunc func(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error)
+
+func (fn cardinalityReporterFunc) CardinalityReport(ctx context.Context, database string, n int) (*tsdb.CardinalityReport, error) {
+	return fn(ctx, database, n)
+}
+
 func TestHandler_Flux_QueryJSON(t *testing.T) {
 	h := NewHandlerWithConfig(NewHandlerConfig(WithFlux(), WithNoLog()))
 	called := false
@@ -0,0 +1,240 @@
+package httpd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/opentelemetry"
+	"github.com/influxdata/influxdb/opentelemetry/otlp"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// The content types of OTLP/HTTP requests and responses.
+const (
+	otlpContentTypeProtobuf = "application/x-protobuf"
+	otlpContentTypeJSON     = "application/json"
+)
+
+// serveOTLPMetrics converts an OTLP/HTTP metrics export request, encoded as
+// protobuf or JSON, into points and writes them to the database and
+// retention policy given by the db and rp parameters.
+func (h *Handler) serveOTLPMetrics(w http.ResponseWriter, r *http.Request, user meta.User) {
+	atomic.AddInt64(&h.stats.WriteRequests, 1)
+	atomic.AddInt64(&h.stats.ActiveWriteRequests, 1)
+	atomic.AddInt64(&h.stats.OTLPWriteRequests, 1)
+	defer func(start time.Time) {
+		atomic.AddInt64(&h.stats.ActiveWriteRequests, -1)
+		atomic.AddInt64(&h.stats.WriteRequestDuration, time.Since(start).Nanoseconds())
+	}(time.Now())
+	h.requestTracker.Add(r, user)
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (contentType != otlpContentTypeProtobuf && contentType != otlpContentTypeJSON) {
+		h.otlpError(w, otlpContentTypeProtobuf, fmt.Sprintf("unsupported content type %q", r.Header.Get("Content-Type")), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	database := r.URL.Query().Get("db")
+	if database == "" {
+		h.otlpError(w, contentType, "database is required", http.StatusBadRequest)
+		return
+	}
+
+	if di := h.MetaClient.Database(database); di == nil {
+		h.otlpError(w, contentType, fmt.Sprintf("database not found: %q", database), http.StatusNotFound)
+		return
+	}
+
+	if h.Config.AuthEnabled {
+		if user == nil {
+			h.otlpError(w, contentType, fmt.Sprintf("user is required to write to database %q", database), http.StatusForbidden)
+			return
+		}
+
+		if err := h.WriteAuthorizer.AuthorizeWrite(user.ID(), database); err != nil {
+			h.otlpError(w, contentType, fmt.Sprintf("%q user is not authorized to write to database %q", user.ID(), database), http.StatusForbidden)
+			return
+		}
+	}
+
+	body := r.Body
+	if h.Config.MaxBodySize > 0 {
+		body = truncateReader(body, int64(h.Config.MaxBodySize))
+	}
+
+	// Handle gzip decoding of the body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		b, err := gzip.NewReader(body)
+		if err != nil {
+			h.otlpError(w, contentType, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer b.Close()
+		body = b
+	}
+
+	var bs []byte
+	if r.ContentLength > 0 {
+		if h.Config.MaxBodySize > 0 && r.ContentLength > int64(h.Config.MaxBodySize) {
+			h.otlpError(w, contentType, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// This will just be an initial hint for the reader, as the
+		// bytes.Buffer will grow as needed when ReadFrom is called
+		bs = make([]byte, 0, r.ContentLength)
+	}
+	buf := bytes.NewBuffer(bs)
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		if err == errTruncated {
+			h.otlpError(w, contentType, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if h.Config.WriteTracing {
+			h.Logger.Info("OTLP write handler unable to read bytes from request body")
+		}
+		h.otlpError(w, contentType, err.Error(), http.StatusBadRequest)
+		return
+	}
+	atomic.AddInt64(&h.stats.WriteRequestBytesReceived, int64(buf.Len()))
+
+	if h.Config.WriteTracing {
+		h.Logger.Info("OTLP write body received by handler", zap.ByteString("body", buf.Bytes()))
+	}
+
+	var req otlp.ExportMetricsServiceRequest
+	if contentType == otlpContentTypeJSON {
+		u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+		err = u.Unmarshal(buf, &req)
+	} else {
+		err = proto.Unmarshal(buf.Bytes(), &req)
+	}
+	if err != nil {
+		h.otlpError(w, contentType, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var resp otlp.ExportMetricsServiceResponse
+	points, err := opentelemetry.MetricsRequestToPoints(&req, h.Config.OTLPPromotedResourceAttributes, time.Now().UTC())
+	if err != nil {
+		if h.Config.WriteTracing {
+			h.Logger.Info("OTLP write handler", zap.Error(err))
+		}
+
+		rerr, ok := err.(opentelemetry.RejectedPointsError)
+		if !ok {
+			h.otlpError(w, contentType, err.Error(), http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt64(&h.stats.PointsWrittenDropped, rerr.Rejected)
+		resp.PartialSuccess = &otlp.ExportMetricsPartialSuccess{
+			RejectedDataPoints: rerr.Rejected,
+			ErrorMessage:       rerr.Error(),
+		}
+	}
+
+	// Determine required consistency level.
+	level := r.URL.Query().Get("consistency")
+	consistency := models.ConsistencyLevelOne
+	if level != "" {
+		consistency, err = models.ParseConsistencyLevel(level)
+		if err != nil {
+			h.otlpError(w, contentType, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Write points.
+	if err := h.writePoints(database, r.URL.Query().Get("rp"), consistency, user, points); influxdb.IsClientError(err) {
+		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
+		h.otlpError(w, contentType, err.Error(), http.StatusBadRequest)
+		return
+	} else if influxdb.IsAuthorizationError(err) {
+		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
+		h.otlpError(w, contentType, err.Error(), http.StatusForbidden)
+		return
+	} else if werr, ok := err.(tsdb.PartialWriteError); ok {
+		atomic.AddInt64(&h.stats.PointsWrittenOK, int64(len(points)-werr.Dropped))
+		atomic.AddInt64(&h.stats.PointsWrittenDropped, int64(werr.Dropped))
+		h.otlpError(w, contentType, werr.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		atomic.AddInt64(&h.stats.PointsWrittenFail, int64(len(points)))
+		h.otlpError(w, contentType, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	atomic.AddInt64(&h.stats.PointsWrittenOK, int64(len(points)))
+
+	var b []byte
+	if contentType == otlpContentTypeJSON {
+		var s string
+		s, err = (&jsonpb.Marshaler{}).MarshalToString(&resp)
+		b = []byte(s)
+	} else {
+		b, err = proto.Marshal(&resp)
+	}
+	if err != nil {
+		h.otlpError(w, contentType, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	h.writeHeader(w, http.StatusOK)
+	w.Write(b)
+}
+
+// otlpError writes an error as a Status message encoded with the content type
+// of the request, as OTLP/HTTP clients expect.
+func (h *Handler) otlpError(w http.ResponseWriter, contentType, errmsg string, code int) {
+	sz := math.Min(float64(len(errmsg)), 1024.0)
+	w.Header().Set("X-InfluxDB-Error", errmsg[:int(sz)])
+
+	status := &otlp.Status{Code: otlpStatusCode(code), Message: errmsg}
+	var b []byte
+	if contentType == otlpContentTypeJSON {
+		s, _ := (&jsonpb.Marshaler{}).MarshalToString(status)
+		b = []byte(s)
+	} else {
+		b, _ = proto.Marshal(status)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	h.writeHeader(w, code)
+	w.Write(b)
+}
+
+// otlpStatusCode returns the gRPC status code matching an HTTP status code.
+func otlpStatusCode(code int) int32 {
+	switch code {
+	case http.StatusBadRequest, http.StatusUnsupportedMediaType:
+		return 3 // INVALID_ARGUMENT
+	case http.StatusUnauthorized:
+		return 16 // UNAUTHENTICATED
+	case http.StatusForbidden:
+		return 7 // PERMISSION_DENIED
+	case http.StatusNotFound:
+		return 5 // NOT_FOUND
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests, http.StatusInsufficientStorage:
+		return 8 // RESOURCE_EXHAUSTED
+	case http.StatusServiceUnavailable:
+		return 14 // UNAVAILABLE
+	case http.StatusInternalServerError:
+		return 13 // INTERNAL
+	default:
+		return 2 // UNKNOWN
+	}
+}
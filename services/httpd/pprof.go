@@ -12,6 +12,7 @@ import (
 	"runtime/trace"
 	"sort"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -345,7 +346,7 @@ func (h *Handler) showShards() ([]*models.Row, error) {
 
 	rows := []*models.Row{}
 	for _, di := range dis {
-		row := &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners"}, Name: di.Name}
+		row := &models.Row{Columns: []string{"id", "database", "retention_policy", "shard_group", "start_time", "end_time", "expiry_time", "owners", "owner_tiers"}, Name: di.Name}
 		for _, rpi := range di.RetentionPolicies {
 			for _, sgi := range rpi.ShardGroups {
 				// Shards associated with deleted shard groups are effectively deleted.
@@ -356,8 +357,12 @@ func (h *Handler) showShards() ([]*models.Row, error) {
 
 				for _, si := range sgi.Shards {
 					ownerIDs := make([]uint64, len(si.Owners))
+					var ownerTiers []string
 					for i, owner := range si.Owners {
 						ownerIDs[i] = owner.NodeID
+						if owner.Tier != "" {
+							ownerTiers = append(ownerTiers, fmt.Sprintf("%d:%s", owner.NodeID, owner.Tier))
+						}
 					}
 
 					row.Values = append(row.Values, []interface{}{
@@ -369,6 +374,7 @@ func (h *Handler) showShards() ([]*models.Row, error) {
 						sgi.EndTime.UTC().Format(time.RFC3339),
 						sgi.EndTime.Add(rpi.Duration).UTC().Format(time.RFC3339),
 						joinUint64(ownerIDs),
+						strings.Join(ownerTiers, ","),
 					})
 				}
 			}
@@ -0,0 +1,480 @@
+package httpd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/prometheus"
+	"github.com/influxdata/influxdb/prometheus/promql"
+	"github.com/influxdata/influxdb/services/meta"
+)
+
+// The error types of the Prometheus HTTP API.
+const (
+	promErrorBadData   = "bad_data"
+	promErrorExecution = "execution"
+	promErrorTimeout   = "timeout"
+	promErrorCanceled  = "canceled"
+	promErrorInternal  = "internal"
+)
+
+// promMaxPointsPerSeries is the most points a range query may return for a
+// series, as in Prometheus.
+const promMaxPointsPerSeries = 11000
+
+var (
+	promMinTime = time.Unix(0, models.MinNanoTime).UTC()
+	promMaxTime = time.Unix(0, models.MaxNanoTime).UTC()
+)
+
+// promAPIResponse is the envelope of every Prometheus HTTP API response.
+type promAPIResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// servePromQuery evaluates a PromQL expression at a single time.
+func (h *Handler) servePromQuery(w http.ResponseWriter, r *http.Request, user meta.User) {
+	atomic.AddInt64(&h.stats.PromQueryRequests, 1)
+	h.requestTracker.Add(r, user)
+
+	q, ok := h.promQueryable(w, r, user)
+	if !ok {
+		return
+	}
+
+	ts := time.Now()
+	if s := r.FormValue("time"); s != "" {
+		t, err := parsePromTime(s)
+		if err != nil {
+			h.promError(w, promErrorBadData, fmt.Errorf("invalid parameter \"time\": %s", err), http.StatusBadRequest)
+			return
+		}
+		ts = t
+	}
+
+	ctx, cancel, err := promQueryContext(r)
+	if err != nil {
+		h.promError(w, promErrorBadData, err, http.StatusBadRequest)
+		return
+	}
+	defer cancel()
+
+	v, err := h.promEngine().InstantQuery(ctx, q, r.FormValue("query"), ts)
+	if err != nil {
+		h.promQueryError(w, err)
+		return
+	}
+	h.promRespond(w, promQueryData(v))
+}
+
+// servePromQueryRange evaluates a PromQL expression at each step of a
+// range.
+func (h *Handler) servePromQueryRange(w http.ResponseWriter, r *http.Request, user meta.User) {
+	atomic.AddInt64(&h.stats.PromQueryRequests, 1)
+	h.requestTracker.Add(r, user)
+
+	q, ok := h.promQueryable(w, r, user)
+	if !ok {
+		return
+	}
+
+	start, err := parsePromTime(r.FormValue("start"))
+	if err != nil {
+		h.promError(w, promErrorBadData, fmt.Errorf("invalid parameter \"start\": %s", err), http.StatusBadRequest)
+		return
+	}
+	end, err := parsePromTime(r.FormValue("end"))
+	if err != nil {
+		h.promError(w, promErrorBadData, fmt.Errorf("invalid parameter \"end\": %s", err), http.StatusBadRequest)
+		return
+	}
+	if end.Before(start) {
+		h.promError(w, promErrorBadData, errors.New("end timestamp must not be before start time"), http.StatusBadRequest)
+		return
+	}
+
+	step, err := parsePromDuration(r.FormValue("step"))
+	if err != nil {
+		h.promError(w, promErrorBadData, fmt.Errorf("invalid parameter \"step\": %s", err), http.StatusBadRequest)
+		return
+	}
+	if step <= 0 {
+		h.promError(w, promErrorBadData, errors.New("zero or negative query resolution step widths are not accepted. Try a positive integer"), http.StatusBadRequest)
+		return
+	}
+	if end.Sub(start)/step > promMaxPointsPerSeries {
+		h.promError(w, promErrorBadData, errors.New("exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)"), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel, err := promQueryContext(r)
+	if err != nil {
+		h.promError(w, promErrorBadData, err, http.StatusBadRequest)
+		return
+	}
+	defer cancel()
+
+	v, err := h.promEngine().RangeQuery(ctx, q, r.FormValue("query"), start, end, step)
+	if err != nil {
+		h.promQueryError(w, err)
+		return
+	}
+	h.promRespond(w, promQueryData(v))
+}
+
+// servePromSeries returns the labels of the series matching any of the
+// match[] selectors.
+func (h *Handler) servePromSeries(w http.ResponseWriter, r *http.Request, user meta.User) {
+	atomic.AddInt64(&h.stats.PromQueryRequests, 1)
+	h.requestTracker.Add(r, user)
+
+	q, ok := h.promQueryable(w, r, user)
+	if !ok {
+		return
+	}
+	if len(r.Form["match[]"]) == 0 {
+		h.promError(w, promErrorBadData, errors.New("no match[] parameter provided"), http.StatusBadRequest)
+		return
+	}
+
+	series, ok := h.promSeries(w, r, q)
+	if !ok {
+		return
+	}
+
+	data := make([]map[string]string, 0, len(series))
+	for _, ls := range series {
+		data = append(data, ls.Map())
+	}
+	h.promRespond(w, data)
+}
+
+// servePromLabels returns the sorted names of the labels of the series
+// matching any of the optional match[] selectors.
+func (h *Handler) servePromLabels(w http.ResponseWriter, r *http.Request, user meta.User) {
+	atomic.AddInt64(&h.stats.PromQueryRequests, 1)
+	h.requestTracker.Add(r, user)
+
+	q, ok := h.promQueryable(w, r, user)
+	if !ok {
+		return
+	}
+
+	series, ok := h.promSeries(w, r, q)
+	if !ok {
+		return
+	}
+
+	names := make(map[string]struct{})
+	for _, ls := range series {
+		for _, l := range ls {
+			names[l.Name] = struct{}{}
+		}
+	}
+	h.promRespond(w, sortedKeys(names))
+}
+
+// servePromLabelValues returns the sorted values of a label of the series
+// matching any of the optional match[] selectors.
+func (h *Handler) servePromLabelValues(w http.ResponseWriter, r *http.Request, user meta.User) {
+	atomic.AddInt64(&h.stats.PromQueryRequests, 1)
+	h.requestTracker.Add(r, user)
+
+	name := r.URL.Query().Get(":name")
+	if !promql.IsValidLabelName(name) {
+		h.promError(w, promErrorBadData, fmt.Errorf("invalid label name: %q", name), http.StatusBadRequest)
+		return
+	}
+
+	q, ok := h.promQueryable(w, r, user)
+	if !ok {
+		return
+	}
+
+	series, ok := h.promSeries(w, r, q)
+	if !ok {
+		return
+	}
+
+	values := make(map[string]struct{})
+	for _, ls := range series {
+		if v := ls.Get(name); v != "" {
+			values[v] = struct{}{}
+		}
+	}
+	h.promRespond(w, sortedKeys(values))
+}
+
+// promQueryable returns the store of the database and retention policy of
+// the request, once the user is authorized to read it. The response has
+// been written if it returns false.
+func (h *Handler) promQueryable(w http.ResponseWriter, r *http.Request, user meta.User) (*prometheus.Queryable, bool) {
+	if err := r.ParseForm(); err != nil {
+		h.promError(w, promErrorBadData, err, http.StatusBadRequest)
+		return nil, false
+	}
+
+	db, rp := r.FormValue("db"), r.FormValue("rp")
+	if db == "" {
+		h.promError(w, promErrorBadData, errors.New("database is required"), http.StatusBadRequest)
+		return nil, false
+	}
+	if err := h.authorizePromRead(user, db); err != nil {
+		h.httpError(w, err.Error(), http.StatusForbidden)
+		return nil, false
+	}
+	return prometheus.NewQueryable(h.Store, db, rp, h.Logger), true
+}
+
+// promSeries returns the labels of the series matching any of the match[]
+// selectors of the request between its optional start and end times. All
+// series are matched without selectors. The response has been written if it
+// returns false.
+func (h *Handler) promSeries(w http.ResponseWriter, r *http.Request, q *prometheus.Queryable) ([]promql.Labels, bool) {
+	start, end := promMinTime, promMaxTime
+	if s := r.FormValue("start"); s != "" {
+		t, err := parsePromTime(s)
+		if err != nil {
+			h.promError(w, promErrorBadData, fmt.Errorf("invalid parameter \"start\": %s", err), http.StatusBadRequest)
+			return nil, false
+		}
+		start = t
+	}
+	if s := r.FormValue("end"); s != "" {
+		t, err := parsePromTime(s)
+		if err != nil {
+			h.promError(w, promErrorBadData, fmt.Errorf("invalid parameter \"end\": %s", err), http.StatusBadRequest)
+			return nil, false
+		}
+		end = t
+	}
+
+	selectors := r.Form["match[]"]
+	if len(selectors) == 0 {
+		selectors = []string{`{__name__=~".+"}`}
+	}
+	var matcherSets [][]*promql.Matcher
+	for _, s := range selectors {
+		matchers, err := promql.ParseMetricSelector(s)
+		if err != nil {
+			h.promError(w, promErrorBadData, err, http.StatusBadRequest)
+			return nil, false
+		}
+		matcherSets = append(matcherSets, matchers)
+	}
+
+	ctx, cancel, err := promQueryContext(r)
+	if err != nil {
+		h.promError(w, promErrorBadData, err, http.StatusBadRequest)
+		return nil, false
+	}
+	defer cancel()
+
+	mint, maxt := start.UnixNano()/int64(time.Millisecond), end.UnixNano()/int64(time.Millisecond)
+	var series []promql.Labels
+	seen := make(map[string]struct{})
+	for _, matchers := range matcherSets {
+		res, err := q.Series(ctx, mint, maxt, matchers)
+		if err != nil {
+			h.promQueryError(w, err)
+			return nil, false
+		}
+		for _, ls := range res {
+			key := ls.String()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			series = append(series, ls)
+		}
+	}
+	return series, true
+}
+
+func (h *Handler) promEngine() *promql.Engine {
+	e := promql.NewEngine()
+	e.MaxSamples = h.Config.PromQueryMaxSamples
+	return e
+}
+
+// promQueryContext returns the context of a request, limited by its
+// optional timeout parameter.
+func promQueryContext(r *http.Request) (context.Context, context.CancelFunc, error) {
+	s := r.FormValue("timeout")
+	if s == "" {
+		ctx, cancel := context.WithCancel(r.Context())
+		return ctx, cancel, nil
+	}
+
+	timeout, err := parsePromDuration(s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid parameter \"timeout\": %s", err)
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return ctx, cancel, nil
+}
+
+// promQueryError writes the error of a query with the error type and
+// status code Prometheus uses for it.
+func (h *Handler) promQueryError(w http.ResponseWriter, err error) {
+	var perr *promql.ParseError
+	switch {
+	case errors.As(err, &perr):
+		h.promError(w, promErrorBadData, err, http.StatusBadRequest)
+	case errors.Is(err, context.DeadlineExceeded):
+		h.promError(w, promErrorTimeout, err, http.StatusServiceUnavailable)
+	case errors.Is(err, context.Canceled):
+		h.promError(w, promErrorCanceled, err, http.StatusServiceUnavailable)
+	default:
+		h.promError(w, promErrorExecution, err, http.StatusUnprocessableEntity)
+	}
+}
+
+// promError writes an error in the format of the Prometheus HTTP API.
+func (h *Handler) promError(w http.ResponseWriter, errorType string, err error, code int) {
+	b, _ := json.Marshal(&promAPIResponse{
+		Status:    "error",
+		ErrorType: errorType,
+		Error:     err.Error(),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	h.writeHeader(w, code)
+	w.Write(b)
+}
+
+// promRespond writes data in the format of the Prometheus HTTP API.
+func (h *Handler) promRespond(w http.ResponseWriter, data interface{}) {
+	b, err := json.Marshal(&promAPIResponse{
+		Status: "success",
+		Data:   data,
+	})
+	if err != nil {
+		h.promError(w, promErrorInternal, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	n, _ := w.Write(b)
+	atomic.AddInt64(&h.stats.QueryRequestBytesTransmitted, int64(n))
+}
+
+// promQueryResult is the data of a query response.
+type promQueryResult struct {
+	ResultType promql.ValueType `json:"resultType"`
+	Result     interface{}      `json:"result"`
+}
+
+// promSample is a sample of an instant vector.
+type promSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  promPoint         `json:"value"`
+}
+
+// promMatrixSeries is a series of a range vector.
+type promMatrixSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values []promPoint       `json:"values"`
+}
+
+// promPoint is encoded as a time in seconds and the value as a string, as
+// JSON can't represent every float.
+type promPoint promql.Point
+
+// MarshalJSON implements json.Marshaler.
+func (p promPoint) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("[%s,%q]", formatPromTime(p.T), strconv.FormatFloat(p.V, 'f', -1, 64))), nil
+}
+
+// promString is a string at a time.
+type promString promql.String
+
+// MarshalJSON implements json.Marshaler.
+func (s promString) MarshalJSON() ([]byte, error) {
+	v, err := json.Marshal(s.V)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("[%s,%s]", formatPromTime(s.T), v)), nil
+}
+
+func promQueryData(v promql.Value) *promQueryResult {
+	res := &promQueryResult{ResultType: v.Type()}
+	switch v := v.(type) {
+	case promql.Scalar:
+		res.Result = promPoint{T: v.T, V: v.V}
+	case promql.String:
+		res.Result = promString(v)
+	case promql.Vector:
+		samples := make([]promSample, 0, len(v))
+		for _, s := range v {
+			samples = append(samples, promSample{Metric: s.Metric.Map(), Value: promPoint(s.Point)})
+		}
+		res.Result = samples
+	case promql.Matrix:
+		series := make([]promMatrixSeries, 0, len(v))
+		for _, s := range v {
+			values := make([]promPoint, 0, len(s.Points))
+			for _, p := range s.Points {
+				values = append(values, promPoint(p))
+			}
+			series = append(series, promMatrixSeries{Metric: s.Metric.Map(), Values: values})
+		}
+		res.Result = series
+	}
+	return res
+}
+
+// formatPromTime formats a time in milliseconds as seconds.
+func formatPromTime(t int64) string {
+	return strconv.FormatFloat(float64(t)/1000, 'f', -1, 64)
+}
+
+// parsePromTime parses a time given in seconds or in RFC 3339 format.
+func parsePromTime(s string) (time.Time, error) {
+	if t, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(t)
+		frac = math.Round(frac*1000) / 1000
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+}
+
+// parsePromDuration parses a duration given in seconds or as a PromQL
+// duration.
+func parsePromDuration(s string) (time.Duration, error) {
+	if d, err := strconv.ParseFloat(s, 64); err == nil {
+		ns := d * float64(time.Second)
+		if ns > math.MaxInt64 || ns < math.MinInt64 {
+			return 0, fmt.Errorf("cannot parse %q to a valid duration. It overflows int64", s)
+		}
+		return time.Duration(ns), nil
+	}
+	if d, err := promql.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return 0, fmt.Errorf("cannot parse %q to a valid duration", s)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -42,6 +42,8 @@ const (
 	statRecoveredPanics              = "recoveredPanics"        // Number of panics recovered by HTTP handler.
 	statPromWriteRequest             = "promWriteReq"           // Number of write requests to the prometheus endpoint.
 	statPromReadRequest              = "promReadReq"            // Number of read requests to the prometheus endpoint.
+	statPromQueryRequest             = "promQueryReq"           // Number of requests to the prometheus query API endpoints.
+	statOTLPWriteRequest             = "otlpWriteReq"           // Number of write requests to the OTLP metrics endpoint.
 	statFluxQueryRequests            = "fluxQueryReq"           // Number of flux query requests served.
 	statFluxQueryRequestDuration     = "fluxQueryReqDurationNs" // Number of (wall-time) nanoseconds spent executing Flux query requests.
 
@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	// DefaultGroupID is the default consumer group used to track offsets.
+	DefaultGroupID = "influxdb"
+
+	// DefaultFormat is the default format of the messages.
+	DefaultFormat = FormatLine
+
+	// DefaultPrecision is the default time precision of the messages.
+	DefaultPrecision = "n"
+
+	// DefaultConsistencyLevel is the default write consistency for the Kafka input.
+	DefaultConsistencyLevel = "one"
+
+	// DefaultBatchSize is the default write batch size.
+	DefaultBatchSize = 5000
+
+	// DefaultBatchPending is the default number of pending write batches.
+	DefaultBatchPending = 10
+
+	// DefaultBatchTimeout is the default Kafka batch timeout.
+	DefaultBatchTimeout = time.Second
+
+	// DefaultRetryInterval is the default time to wait before retrying a
+	// failed write.
+	DefaultRetryInterval = time.Second
+
+	// DefaultMaxRetryInterval is the default maximum time to wait before
+	// retrying a failed write.
+	DefaultMaxRetryInterval = 30 * time.Second
+)
+
+// The formats of the messages consumed by the Kafka input.
+const (
+	// FormatLine is line protocol, one or more points per message.
+	FormatLine = "line"
+
+	// FormatJSON is a JSON object, or an array of them, per message.
+	FormatJSON = "json"
+)
+
+// TopicConfig maps a topic to the database and retention policy its points
+// are written to.
+type TopicConfig struct {
+	Topic           string `toml:"topic"`
+	Database        string `toml:"database"`
+	RetentionPolicy string `toml:"retention-policy"`
+}
+
+// Config holds various configuration settings for the Kafka input.
+type Config struct {
+	Enabled          bool          `toml:"enabled"`
+	Brokers          []string      `toml:"brokers"`
+	GroupID          string        `toml:"group-id"`
+	Topics           []TopicConfig `toml:"topics"`
+	Format           string        `toml:"format"`
+	Precision        string        `toml:"precision"`
+	ConsistencyLevel string        `toml:"consistency-level"`
+	BatchSize        int           `toml:"batch-size"`
+	BatchPending     int           `toml:"batch-pending"`
+	BatchTimeout     toml.Duration `toml:"batch-timeout"`
+	RetryInterval    toml.Duration `toml:"retry-interval"`
+	MaxRetryInterval toml.Duration `toml:"max-retry-interval"`
+}
+
+// NewConfig returns a new instance of Config with defaults.
+func NewConfig() Config {
+	return Config{
+		GroupID:          DefaultGroupID,
+		Format:           DefaultFormat,
+		ConsistencyLevel: DefaultConsistencyLevel,
+		BatchSize:        DefaultBatchSize,
+		BatchPending:     DefaultBatchPending,
+		BatchTimeout:     toml.Duration(DefaultBatchTimeout),
+		RetryInterval:    toml.Duration(DefaultRetryInterval),
+		MaxRetryInterval: toml.Duration(DefaultMaxRetryInterval),
+	}
+}
+
+// WithDefaults takes the given config and returns a new config with any required
+// default values set.
+func (c *Config) WithDefaults() *Config {
+	d := *c
+	if d.GroupID == "" {
+		d.GroupID = DefaultGroupID
+	}
+	if d.Format == "" {
+		d.Format = DefaultFormat
+	}
+	if d.Precision == "" {
+		d.Precision = DefaultPrecision
+	}
+	if d.ConsistencyLevel == "" {
+		d.ConsistencyLevel = DefaultConsistencyLevel
+	}
+	if d.BatchSize == 0 {
+		d.BatchSize = DefaultBatchSize
+	}
+	if d.BatchPending == 0 {
+		d.BatchPending = DefaultBatchPending
+	}
+	if d.BatchTimeout == 0 {
+		d.BatchTimeout = toml.Duration(DefaultBatchTimeout)
+	}
+	if d.RetryInterval == 0 {
+		d.RetryInterval = toml.Duration(DefaultRetryInterval)
+	}
+	if d.MaxRetryInterval == 0 {
+		d.MaxRetryInterval = toml.Duration(DefaultMaxRetryInterval)
+	}
+	return &d
+}
+
+// Validate validates the config's settings.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Brokers) == 0 {
+		return errors.New("at least one broker must be specified")
+	}
+
+	if len(c.Topics) == 0 {
+		return errors.New("at least one topic must be specified")
+	}
+	seen := make(map[string]struct{}, len(c.Topics))
+	for _, t := range c.Topics {
+		if t.Topic == "" {
+			return errors.New("topic name must be specified")
+		}
+		if t.Database == "" {
+			return fmt.Errorf("database must be specified for topic %q", t.Topic)
+		}
+		if _, ok := seen[t.Topic]; ok {
+			return fmt.Errorf("duplicate topic %q", t.Topic)
+		}
+		seen[t.Topic] = struct{}{}
+	}
+
+	switch strings.ToLower(c.Format) {
+	case "", FormatLine, FormatJSON:
+	default:
+		return fmt.Errorf("unrecognized kafka format %q", c.Format)
+	}
+
+	if c.ConsistencyLevel != "" {
+		if _, err := models.ParseConsistencyLevel(c.ConsistencyLevel); err != nil {
+			return err
+		}
+	}
+
+	if c.BatchSize < 0 || c.BatchPending < 0 {
+		return errors.New("batch-size and batch-pending must not be negative")
+	}
+	return nil
+}
+
+// Configs wraps a slice of Config to aggregate diagnostics.
+type Configs []Config
+
+// Diagnostics returns one set of diagnostics for all of the Configs.
+func (c Configs) Diagnostics() (*diagnostics.Diagnostics, error) {
+	d := &diagnostics.Diagnostics{
+		Columns: []string{"enabled", "brokers", "group-id", "topics", "format", "consistency-level", "batch-size", "batch-pending", "batch-timeout"},
+	}
+
+	for _, cc := range c {
+		if !cc.Enabled {
+			d.AddRow([]interface{}{false})
+			continue
+		}
+
+		topics := make([]string, 0, len(cc.Topics))
+		for _, t := range cc.Topics {
+			if t.RetentionPolicy == "" {
+				topics = append(topics, t.Topic+"="+t.Database)
+			} else {
+				topics = append(topics, t.Topic+"="+t.Database+"."+t.RetentionPolicy)
+			}
+		}
+
+		r := []interface{}{true, strings.Join(cc.Brokers, ","), cc.GroupID, strings.Join(topics, ","), cc.Format, cc.ConsistencyLevel, cc.BatchSize, cc.BatchPending, cc.BatchTimeout}
+		d.AddRow(r)
+	}
+
+	return d, nil
+}
+
+// Enabled returns true if any underlying Config is Enabled.
+func (c Configs) Enabled() bool {
+	for _, cc := range c {
+		if cc.Enabled {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package kafka_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/kafka"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c kafka.Config
+	if _, err := toml.Decode(`
+enabled = true
+brokers = ["kafka-1:9092", "kafka-2:9092"]
+group-id = "ingest"
+format = "json"
+precision = "ms"
+consistency-level = "quorum"
+batch-size = 100
+batch-pending = 9
+batch-timeout = "10ms"
+retry-interval = "2s"
+max-retry-interval = "1m"
+
+[[topics]]
+  topic = "metrics"
+  database = "telegraf"
+
+[[topics]]
+  topic = "events"
+  database = "events"
+  retention-policy = "weekly"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if !c.Enabled {
+		t.Fatalf("unexpected enabled: %v", c.Enabled)
+	} else if len(c.Brokers) != 2 || c.Brokers[1] != "kafka-2:9092" {
+		t.Fatalf("unexpected brokers: %v", c.Brokers)
+	} else if c.GroupID != "ingest" {
+		t.Fatalf("unexpected group id: %s", c.GroupID)
+	} else if c.Format != kafka.FormatJSON {
+		t.Fatalf("unexpected format: %s", c.Format)
+	} else if c.Precision != "ms" {
+		t.Fatalf("unexpected precision: %s", c.Precision)
+	} else if c.ConsistencyLevel != "quorum" {
+		t.Fatalf("unexpected consistency level: %s", c.ConsistencyLevel)
+	} else if c.BatchSize != 100 {
+		t.Fatalf("unexpected batch size: %d", c.BatchSize)
+	} else if c.BatchPending != 9 {
+		t.Fatalf("unexpected batch pending: %d", c.BatchPending)
+	} else if time.Duration(c.BatchTimeout) != (10 * time.Millisecond) {
+		t.Fatalf("unexpected batch timeout: %v", c.BatchTimeout)
+	} else if time.Duration(c.RetryInterval) != 2*time.Second {
+		t.Fatalf("unexpected retry interval: %v", c.RetryInterval)
+	} else if time.Duration(c.MaxRetryInterval) != time.Minute {
+		t.Fatalf("unexpected max retry interval: %v", c.MaxRetryInterval)
+	} else if len(c.Topics) != 2 {
+		t.Fatalf("unexpected topics: %v", c.Topics)
+	} else if tc := c.Topics[1]; tc.Topic != "events" || tc.Database != "events" || tc.RetentionPolicy != "weekly" {
+		t.Fatalf("unexpected topic: %v", tc)
+	}
+
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() kafka.Config {
+		c := kafka.NewConfig()
+		c.Enabled = true
+		c.Brokers = []string{"localhost:9092"}
+		c.Topics = []kafka.TopicConfig{{Topic: "metrics", Database: "telegraf"}}
+		return c
+	}
+
+	for _, tt := range []struct {
+		name string
+		fn   func(c *kafka.Config)
+	}{
+		{name: "no brokers", fn: func(c *kafka.Config) { c.Brokers = nil }},
+		{name: "no topics", fn: func(c *kafka.Config) { c.Topics = nil }},
+		{name: "no database", fn: func(c *kafka.Config) { c.Topics[0].Database = "" }},
+		{name: "duplicate topic", fn: func(c *kafka.Config) { c.Topics = append(c.Topics, c.Topics[0]) }},
+		{name: "format", fn: func(c *kafka.Config) { c.Format = "csv" }},
+		{name: "consistency level", fn: func(c *kafka.Config) { c.ConsistencyLevel = "most" }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			if err := c.Validate(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tt.fn(&c)
+			if err := c.Validate(); err == nil {
+				t.Fatal("expected error")
+			}
+
+			// A disabled input is not validated.
+			c.Enabled = false
+			if err := c.Validate(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
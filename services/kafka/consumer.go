@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// Message is a message consumed from a topic.
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Value     []byte
+}
+
+// Consumer consumes the messages of a topic as a member of a consumer group.
+type Consumer interface {
+	// FetchMessage returns the next message. It blocks until a message is
+	// available, the context is done or the consumer is closed.
+	FetchMessage(ctx context.Context) (Message, error)
+
+	// CommitMessages commits the offsets of the messages for the group,
+	// so that they are not consumed again by the group.
+	CommitMessages(ctx context.Context, msgs ...Message) error
+
+	// Close closes the consumer and leaves the group.
+	Close() error
+}
+
+// NewConsumer returns a Consumer of the topic using the Kafka protocol.
+func NewConsumer(brokers []string, groupID, topic string) Consumer {
+	return &reader{r: kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		GroupID: groupID,
+		Topic:   topic,
+	})}
+}
+
+// reader wraps a kafka.Reader to implement Consumer.
+type reader struct {
+	r *kafka.Reader
+}
+
+func (r *reader) FetchMessage(ctx context.Context) (Message, error) {
+	m, err := r.r.FetchMessage(ctx)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Topic: m.Topic, Partition: m.Partition, Offset: m.Offset, Value: m.Value}, nil
+}
+
+func (r *reader) CommitMessages(ctx context.Context, msgs ...Message) error {
+	kmsgs := make([]kafka.Message, len(msgs))
+	for i, m := range msgs {
+		kmsgs[i] = kafka.Message{Topic: m.Topic, Partition: m.Partition, Offset: m.Offset}
+	}
+	return r.r.CommitMessages(ctx, kmsgs...)
+}
+
+func (r *reader) Close() error {
+	return r.r.Close()
+}
@@ -0,0 +1,586 @@
+package kafka
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewConsumer(t *testing.T) {
+	b := NewProtocolBroker(t)
+	defer b.Close()
+	b.Produce("metrics", "cpu value=1 1000000000", "cpu value=2 2000000000", "cpu value=3 3000000000")
+
+	c := NewConsumer([]string{b.Addr()}, "influxdb", "metrics")
+	for i := 0; i < 3; i++ {
+		m := MustFetchMessage(t, c)
+		if m.Offset != int64(i) || m.Topic != "metrics" || m.Partition != 0 {
+			t.Fatalf("unexpected message: %+v", m)
+		} else if got, exp := string(m.Value), fmt.Sprintf("cpu value=%d %d000000000", i+1, i+1); got != exp {
+			t.Fatalf("unexpected message value: got %q, exp %q", got, exp)
+		}
+		if i == 1 {
+			if err := c.CommitMessages(context.Background(), m); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := b.Committed("influxdb", "metrics"); got != 2 {
+		t.Fatalf("unexpected committed offset: %d", got)
+	}
+
+	// A new member of the group resumes after the committed message, and
+	// receives messages produced while it waits.
+	c = NewConsumer([]string{b.Addr()}, "influxdb", "metrics")
+	defer c.Close()
+	if m := MustFetchMessage(t, c); m.Offset != 2 {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		b.Produce("metrics", "cpu value=4 4000000000")
+	}()
+	if m := MustFetchMessage(t, c); m.Offset != 3 || string(m.Value) != "cpu value=4 4000000000" {
+		t.Fatalf("unexpected message: %+v", m)
+	}
+}
+
+func TestService_ProtocolBroker(t *testing.T) {
+	b := NewProtocolBroker(t)
+	defer b.Close()
+	b.Produce("metrics", "cpu value=1 1000000000", "cpu value=2 2000000000")
+
+	c := NewTestConfig()
+	c.Brokers = []string{b.Addr()}
+	s := NewTestService(&c)
+	s.Service.NewConsumer = NewConsumer
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	timeout := time.After(10 * time.Second)
+	for b.Committed(c.GroupID, "metrics") < 2 {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out waiting for commit, committed %d", b.Committed(c.GroupID, "metrics"))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got := s.Writes["telegraf."]; len(got) != 2 || got[0] != "cpu value=1 1000000000" || got[1] != "cpu value=2 2000000000" {
+		t.Fatalf("unexpected points written: %v", got)
+	}
+}
+
+// MustFetchMessage fetches the next message of the consumer or fails the test.
+func MustFetchMessage(t *testing.T, c Consumer) Message {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	m, err := c.FetchMessage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// Kafka API keys served by ProtocolBroker.
+const (
+	apiFetch           = 1
+	apiListOffsets     = 2
+	apiMetadata        = 3
+	apiOffsetCommit    = 8
+	apiOffsetFetch     = 9
+	apiFindCoordinator = 10
+	apiJoinGroup       = 11
+	apiHeartbeat       = 12
+	apiLeaveGroup      = 13
+	apiSyncGroup       = 14
+)
+
+// ProtocolBroker is a single Kafka broker speaking the subset of the wire
+// protocol used by the consumer: metadata, offsets, fetches and the consumer
+// group API. Each topic has one partition, created on first use, and each
+// group has at most one member at a time.
+type ProtocolBroker struct {
+	t  *testing.T
+	ln net.Listener
+	wg sync.WaitGroup
+
+	mu          sync.Mutex
+	conns       map[net.Conn]struct{}
+	messages    map[string][][]byte
+	committed   map[[2]string]int64 // next offset of each group and topic
+	generations map[string]int32
+	assignments map[string][]byte // assignment of the member of each group
+	members     int
+	produced    chan struct{} // closed when messages are produced
+}
+
+// NewProtocolBroker returns a broker listening on a local port.
+func NewProtocolBroker(t *testing.T) *ProtocolBroker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &ProtocolBroker{
+		t:           t,
+		ln:          ln,
+		conns:       make(map[net.Conn]struct{}),
+		messages:    make(map[string][][]byte),
+		committed:   make(map[[2]string]int64),
+		generations: make(map[string]int32),
+		assignments: make(map[string][]byte),
+		produced:    make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.serve()
+	return b
+}
+
+// Addr returns the address of the broker.
+func (b *ProtocolBroker) Addr() string { return b.ln.Addr().String() }
+
+// Close closes the listener and all connections.
+func (b *ProtocolBroker) Close() {
+	b.ln.Close()
+	b.mu.Lock()
+	for conn := range b.conns {
+		conn.Close()
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+// Produce appends messages to the topic.
+func (b *ProtocolBroker) Produce(topic string, values ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, v := range values {
+		b.messages[topic] = append(b.messages[topic], []byte(v))
+	}
+	close(b.produced)
+	b.produced = make(chan struct{})
+}
+
+// Committed returns the offset committed by the group for the topic.
+func (b *ProtocolBroker) Committed(group, topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.committed[[2]string{group, topic}]
+}
+
+func (b *ProtocolBroker) serve() {
+	defer b.wg.Done()
+	for {
+		conn, err := b.ln.Accept()
+		if err != nil {
+			return
+		}
+		b.mu.Lock()
+		b.conns[conn] = struct{}{}
+		b.mu.Unlock()
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.serveConn(conn)
+			conn.Close()
+			b.mu.Lock()
+			delete(b.conns, conn)
+			b.mu.Unlock()
+		}()
+	}
+}
+
+// serveConn answers the requests of a connection in order.
+func (b *ProtocolBroker) serveConn(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		var size int32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return
+		}
+
+		req := &kafkaDecoder{buf: buf}
+		apiKey, _ := req.int16(), req.int16()
+		correlationID := req.int32()
+		req.string() // client ID
+
+		var resp kafkaEncoder
+		resp.int32(correlationID)
+		if err := b.handle(apiKey, req, &resp); err != nil {
+			b.t.Errorf("api %d: %s", apiKey, err)
+			return
+		}
+
+		var hdr [4]byte
+		binary.BigEndian.PutUint32(hdr[:], uint32(resp.Len()))
+		if _, err := conn.Write(append(hdr[:], resp.Bytes()...)); err != nil {
+			return
+		}
+	}
+}
+
+func (b *ProtocolBroker) handle(apiKey int16, req *kafkaDecoder, resp *kafkaEncoder) error {
+	switch apiKey {
+	case apiMetadata:
+		topics := req.strings()
+		host, port := b.hostPort()
+		resp.array(1, func(int) {
+			resp.int32(0) // node ID
+			resp.string(host)
+			resp.int32(port)
+			resp.string("") // rack
+		})
+		resp.int32(0) // controller ID
+		resp.array(len(topics), func(i int) {
+			resp.int16(0)
+			resp.string(topics[i])
+			resp.bool(false)
+			resp.array(1, func(int) {
+				resp.int16(0)
+				resp.int32(0) // partition
+				resp.int32(0) // leader
+				resp.array(1, func(int) { resp.int32(0) })
+				resp.array(1, func(int) { resp.int32(0) })
+			})
+		})
+
+	case apiFindCoordinator:
+		req.string() // group ID
+		host, port := b.hostPort()
+		resp.int16(0)
+		resp.int32(0)
+		resp.string(host)
+		resp.int32(port)
+
+	case apiJoinGroup:
+		group := req.string()
+		req.int32() // session timeout
+		req.int32() // rebalance timeout
+		member := req.string()
+		req.string() // protocol type
+		var protocol string
+		var metadata []byte
+		req.array(func(i int) {
+			name, meta := req.string(), req.bytes()
+			if i == 0 {
+				protocol, metadata = name, meta
+			}
+		})
+
+		b.mu.Lock()
+		if member == "" {
+			b.members++
+			member = "member-" + strconv.Itoa(b.members)
+		}
+		b.generations[group]++
+		generation := b.generations[group]
+		b.mu.Unlock()
+
+		// The only member is the leader.
+		resp.int16(0)
+		resp.int32(generation)
+		resp.string(protocol)
+		resp.string(member)
+		resp.string(member)
+		resp.array(1, func(int) {
+			resp.string(member)
+			resp.bytes(metadata)
+		})
+
+	case apiSyncGroup:
+		group := req.string()
+		req.int32() // generation
+		member := req.string()
+		assignments := make(map[string][]byte)
+		req.array(func(int) { assignments[req.string()] = req.bytes() })
+
+		b.mu.Lock()
+		if a, ok := assignments[member]; ok {
+			b.assignments[group] = a
+		}
+		assignment := b.assignments[group]
+		b.mu.Unlock()
+
+		resp.int16(0)
+		resp.bytes(assignment)
+
+	case apiHeartbeat, apiLeaveGroup:
+		resp.int16(0)
+
+	case apiOffsetFetch:
+		group := req.string()
+		req.array(func(int) {
+			topic := req.string()
+			partitions := req.int32s()
+			resp.array(1, func(int) {
+				resp.string(topic)
+				resp.array(len(partitions), func(i int) {
+					offset := int64(-1)
+					b.mu.Lock()
+					if o, ok := b.committed[[2]string{group, topic}]; ok {
+						offset = o
+					}
+					b.mu.Unlock()
+					resp.int32(partitions[i])
+					resp.int64(offset)
+					resp.string("") // metadata
+					resp.int16(0)
+				})
+			})
+		})
+
+	case apiOffsetCommit:
+		group := req.string()
+		req.int32()  // generation
+		req.string() // member
+		req.int64()  // retention time
+		type commit struct {
+			topic     string
+			partition int32
+		}
+		var commits []commit
+		req.array(func(int) {
+			topic := req.string()
+			req.array(func(int) {
+				partition, offset := req.int32(), req.int64()
+				req.string() // metadata
+				b.mu.Lock()
+				b.committed[[2]string{group, topic}] = offset
+				b.mu.Unlock()
+				commits = append(commits, commit{topic: topic, partition: partition})
+			})
+		})
+		resp.array(len(commits), func(i int) {
+			resp.string(commits[i].topic)
+			resp.array(1, func(int) {
+				resp.int32(commits[i].partition)
+				resp.int16(0)
+			})
+		})
+
+	case apiListOffsets:
+		req.int32() // replica ID
+		req.array(func(int) {
+			topic := req.string()
+			resp.array(1, func(int) {
+				resp.string(topic)
+				req.array(func(int) {
+					partition, ts := req.int32(), req.int64()
+					offset := int64(0) // first offset
+					if ts == -1 {
+						// Last offset.
+						b.mu.Lock()
+						offset = int64(len(b.messages[topic]))
+						b.mu.Unlock()
+					}
+					resp.array(1, func(int) {
+						resp.int32(partition)
+						resp.int16(0)
+						resp.int64(-1) // timestamp
+						resp.int64(offset)
+					})
+				})
+			})
+		})
+
+	case apiFetch:
+		req.int32() // replica ID
+		maxWait := time.Duration(req.int32()) * time.Millisecond
+		req.int32() // min bytes
+		req.array(func(int) {
+			topic := req.string()
+			resp.int32(0) // throttle time
+			resp.array(1, func(int) {
+				resp.string(topic)
+				req.array(func(int) {
+					partition, offset := req.int32(), req.int64()
+					req.int32() // max bytes
+					msgs := b.wait(topic, offset, maxWait)
+					resp.array(1, func(int) {
+						resp.int32(partition)
+						resp.int16(0)
+						resp.int64(offset + int64(len(msgs))) // high water mark
+						set := messageSet(offset, msgs)
+						resp.int32(int32(len(set)))
+						resp.Write(set)
+					})
+				})
+			})
+		})
+
+	default:
+		return fmt.Errorf("unsupported api")
+	}
+	return req.err
+}
+
+// wait returns the messages of the topic from offset on, waiting up to
+// maxWait, or 100ms at most so that closing consumers is quick, for messages
+// to be produced if there are none yet.
+func (b *ProtocolBroker) wait(topic string, offset int64, maxWait time.Duration) [][]byte {
+	if maxWait > 100*time.Millisecond {
+		maxWait = 100 * time.Millisecond
+	}
+	timeout := time.After(maxWait)
+	for {
+		b.mu.Lock()
+		msgs, produced := b.messages[topic], b.produced
+		b.mu.Unlock()
+
+		if offset < int64(len(msgs)) {
+			return msgs[offset:]
+		}
+		select {
+		case <-produced:
+		case <-timeout:
+			return nil
+		}
+	}
+}
+
+// messageSet encodes messages starting at offset as a set of version 1
+// messages without keys.
+func messageSet(offset int64, msgs [][]byte) []byte {
+	var e kafkaEncoder
+	for i, v := range msgs {
+		var m kafkaEncoder
+		m.int8(1) // magic
+		m.int8(0) // attributes
+		m.int64(0)
+		m.bytes(nil) // key
+		m.bytes(v)
+
+		e.int64(offset + int64(i))
+		e.int32(int32(4 + m.Len()))
+		e.int32(int32(crc32.ChecksumIEEE(m.Bytes())))
+		e.Write(m.Bytes())
+	}
+	return e.Bytes()
+}
+
+// hostPort returns the host and port of the listener.
+func (b *ProtocolBroker) hostPort() (string, int32) {
+	addr := b.ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), int32(addr.Port)
+}
+
+// kafkaDecoder decodes the big-endian primitives of the Kafka protocol,
+// recording the first error.
+type kafkaDecoder struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (d *kafkaDecoder) read(n int) []byte {
+	if d.err != nil || n < 0 || d.off+n > len(d.buf) {
+		if d.err == nil {
+			d.err = io.ErrUnexpectedEOF
+		}
+		return make([]byte, max(n, 0))
+	}
+	p := d.buf[d.off : d.off+n]
+	d.off += n
+	return p
+}
+
+func (d *kafkaDecoder) int16() int16 { return int16(binary.BigEndian.Uint16(d.read(2))) }
+func (d *kafkaDecoder) int32() int32 { return int32(binary.BigEndian.Uint32(d.read(4))) }
+func (d *kafkaDecoder) int64() int64 { return int64(binary.BigEndian.Uint64(d.read(8))) }
+
+func (d *kafkaDecoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.read(int(n)))
+}
+
+func (d *kafkaDecoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return append([]byte(nil), d.read(int(n))...)
+}
+
+func (d *kafkaDecoder) array(fn func(i int)) {
+	n := int(d.int32())
+	for i := 0; i < n && d.err == nil; i++ {
+		fn(i)
+	}
+}
+
+func (d *kafkaDecoder) strings() []string {
+	var a []string
+	d.array(func(int) { a = append(a, d.string()) })
+	return a
+}
+
+func (d *kafkaDecoder) int32s() []int32 {
+	var a []int32
+	d.array(func(int) { a = append(a, d.int32()) })
+	return a
+}
+
+// kafkaEncoder encodes the big-endian primitives of the Kafka protocol.
+type kafkaEncoder struct {
+	bytes.Buffer
+}
+
+func (e *kafkaEncoder) int8(v int8)   { e.WriteByte(byte(v)) }
+func (e *kafkaEncoder) int16(v int16) { binary.Write(e, binary.BigEndian, v) }
+func (e *kafkaEncoder) int32(v int32) { binary.Write(e, binary.BigEndian, v) }
+func (e *kafkaEncoder) int64(v int64) { binary.Write(e, binary.BigEndian, v) }
+
+func (e *kafkaEncoder) bool(v bool) {
+	if v {
+		e.int8(1)
+	} else {
+		e.int8(0)
+	}
+}
+
+func (e *kafkaEncoder) string(v string) {
+	e.int16(int16(len(v)))
+	e.WriteString(v)
+}
+
+func (e *kafkaEncoder) bytes(v []byte) {
+	if v == nil {
+		e.int32(-1)
+		return
+	}
+	e.int32(int32(len(v)))
+	e.Write(v)
+}
+
+func (e *kafkaEncoder) array(n int, fn func(i int)) {
+	e.int32(int32(n))
+	for i := 0; i < n; i++ {
+		fn(i)
+	}
+}
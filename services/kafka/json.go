@@ -0,0 +1,117 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+// jsonPoint is a point of a JSON message.
+type jsonPoint struct {
+	Measurement string                 `json:"measurement"`
+	Tags        map[string]string      `json:"tags"`
+	Fields      map[string]interface{} `json:"fields"`
+	Time        interface{}            `json:"time"`
+}
+
+// parseJSON parses a JSON object, or an array of them, into points. A
+// numeric time is in the given precision and a string time is RFC3339.
+// Points without a time are given defaultTime.
+//
+// Numbers are written as floats so that a field always has the same type,
+// whichever way the producer encoded it. The points that could be parsed
+// are returned along with an error for the others.
+func parseJSON(buf []byte, defaultTime time.Time, precision string) ([]models.Point, error) {
+	buf = bytes.TrimSpace(buf)
+	if len(buf) == 0 {
+		return nil, nil
+	}
+
+	var raw []json.RawMessage
+	if buf[0] == '[' {
+		if err := json.Unmarshal(buf, &raw); err != nil {
+			return nil, err
+		}
+	} else {
+		raw = []json.RawMessage{buf}
+	}
+
+	var (
+		points []models.Point
+		failed int
+		last   error
+	)
+	for _, b := range raw {
+		pt, err := parseJSONPoint(b, defaultTime, precision)
+		if err != nil {
+			failed++
+			last = err
+			continue
+		}
+		points = append(points, pt)
+	}
+
+	if last != nil {
+		if failed == 1 {
+			return points, last
+		}
+		return points, fmt.Errorf("%d points failed to parse, last error: %s", failed, last)
+	}
+	return points, nil
+}
+
+func parseJSONPoint(b []byte, defaultTime time.Time, precision string) (models.Point, error) {
+	var jp jsonPoint
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&jp); err != nil {
+		return nil, err
+	}
+
+	if jp.Measurement == "" {
+		return nil, errors.New("missing measurement")
+	}
+
+	fields := make(models.Fields, len(jp.Fields))
+	for k, v := range jp.Fields {
+		switch v := v.(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of field %q: %s", k, err)
+			}
+			fields[k] = f
+		case string, bool:
+			fields[k] = v
+		case nil:
+		default:
+			return nil, fmt.Errorf("unsupported value of field %q", k)
+		}
+	}
+
+	ts := defaultTime
+	switch t := jp.Time.(type) {
+	case nil:
+	case json.Number:
+		n, err := t.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %s", t)
+		}
+		if ts, err = models.SafeCalcTime(n, precision); err != nil {
+			return nil, err
+		}
+	case string:
+		var err error
+		if ts, err = time.Parse(time.RFC3339Nano, t); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("time must be a number or an RFC3339 string")
+	}
+
+	return models.NewPoint(jp.Measurement, models.NewTags(jp.Tags), fields, ts)
+}
@@ -0,0 +1,460 @@
+// Package kafka provides a service for InfluxDB to ingest line protocol and
+// JSON from topics of a Kafka-compatible broker.
+package kafka // import "github.com/influxdata/influxdb/services/kafka"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// statistics gathered by the Kafka package.
+const (
+	statMessagesReceived    = "messagesRx"
+	statBytesReceived       = "bytesRx"
+	statPointsReceived      = "pointsRx"
+	statMessagesParseFail   = "messagesParseFail"
+	statReadFail            = "readFail"
+	statBatchesTransmitted  = "batchesTx"
+	statPointsTransmitted   = "pointsTx"
+	statBatchesTransmitFail = "batchesTxFail"
+	statPointsDropped       = "pointsDropped"
+	statBatchesDropped      = "batchesDropped"
+	statCommitFail          = "commitFail"
+)
+
+// Service is a service that consumes points from topics of a Kafka-compatible
+// broker and writes them to their databases.
+//
+// The offsets of the messages are committed to the consumer group only once
+// their points have been written at the configured consistency level. A
+// failed write is retried until it succeeds or the service is closed, so
+// messages are delivered at least once: messages which were consumed but not
+// committed are consumed again, for example after a restart. A batch failing
+// with an error that retrying cannot fix, such as a missing retention policy,
+// is dropped and its messages committed.
+type Service struct {
+	config           Config
+	format           string
+	consistencyLevel models.ConsistencyLevel
+	batchSize        int
+	batchPending     int
+	batchTimeout     time.Duration
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+
+	topics []*topic
+
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	done   chan struct{} // Is the service closing or closed?
+	cancel context.CancelFunc
+
+	// NewConsumer returns the consumer of a topic. It defaults to a
+	// consumer using the Kafka protocol.
+	NewConsumer func(brokers []string, groupID, topic string) Consumer
+
+	PointsWriter interface {
+		WritePointsPrivileged(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error
+	}
+
+	MetaClient interface {
+		CreateDatabase(name string) (*meta.DatabaseInfo, error)
+	}
+
+	Logger *zap.Logger
+}
+
+// topic is a topic consumed by the service.
+type topic struct {
+	config      TopicConfig
+	consumer    Consumer
+	ready       bool // Has the required database been created?
+	stats       *Statistics
+	defaultTags models.StatisticTags
+}
+
+// batch is a batch of points and the messages they were parsed from.
+type batch struct {
+	points   []models.Point
+	messages map[int]Message // last message of each partition
+}
+
+// NewService returns a new instance of Service.
+func NewService(c Config) (*Service, error) {
+	d := c.WithDefaults()
+
+	consistencyLevel, err := models.ParseConsistencyLevel(d.ConsistencyLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		config:           *d,
+		format:           strings.ToLower(d.Format),
+		consistencyLevel: consistencyLevel,
+		batchSize:        d.BatchSize,
+		batchPending:     d.BatchPending,
+		batchTimeout:     time.Duration(d.BatchTimeout),
+		retryInterval:    time.Duration(d.RetryInterval),
+		maxRetryInterval: time.Duration(d.MaxRetryInterval),
+		NewConsumer:      NewConsumer,
+		Logger:           zap.NewNop(),
+	}
+	for _, tc := range d.Topics {
+		s.topics = append(s.topics, &topic{
+			config:      tc,
+			stats:       &Statistics{},
+			defaultTags: models.StatisticTags{"topic": tc.Topic, "database": tc.Database},
+		})
+	}
+	return s, nil
+}
+
+// Open starts the service.
+func (s *Service) Open() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed() {
+		return nil // Already open.
+	}
+
+	if len(s.config.Brokers) == 0 {
+		return errors.New("at least one broker has to be specified in config")
+	}
+	if len(s.topics) == 0 {
+		return errors.New("at least one topic has to be specified in config")
+	}
+	s.done = make(chan struct{})
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+
+	for _, t := range s.topics {
+		t.consumer = s.NewConsumer(s.config.Brokers, s.config.GroupID, t.config.Topic)
+
+		batches := make(chan *batch, s.batchPending)
+		s.wg.Add(2)
+		go s.consume(ctx, t, batches)
+		go s.write(ctx, t, batches)
+
+		s.Logger.Info("Started consuming topic",
+			zap.String("topic", t.config.Topic),
+			logger.Database(t.config.Database),
+			logger.RetentionPolicy(t.config.RetentionPolicy))
+	}
+	return nil
+}
+
+// Close stops consuming the topics. Points that have not been written yet are
+// dropped without committing their messages.
+func (s *Service) Close() error {
+	if wait := func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.closed() {
+			return false // Already closed.
+		}
+		close(s.done)
+		s.cancel()
+		return true
+	}(); !wait {
+		return nil
+	}
+	s.wg.Wait()
+
+	// Release all remaining resources.
+	s.mu.Lock()
+	for _, t := range s.topics {
+		if err := t.consumer.Close(); err != nil {
+			s.Logger.Info("Failed to close consumer", zap.String("topic", t.config.Topic), zap.Error(err))
+		}
+		t.consumer = nil
+	}
+	s.done = nil
+	s.mu.Unlock()
+
+	s.Logger.Info("Service closed")
+
+	return nil
+}
+
+// Closed returns true if the service is currently closed.
+func (s *Service) Closed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed()
+}
+
+func (s *Service) closed() bool {
+	select {
+	case <-s.done:
+		// Service is closing.
+		return true
+	default:
+	}
+	return s.done == nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.Logger = log.With(zap.String("service", "kafka"))
+}
+
+// Statistics maintains statistics for a topic of the Kafka service.
+type Statistics struct {
+	MessagesReceived    int64
+	BytesReceived       int64
+	PointsReceived      int64
+	MessagesParseFail   int64
+	ReadFail            int64
+	BatchesTransmitted  int64
+	PointsTransmitted   int64
+	BatchesTransmitFail int64
+	PointsDropped       int64
+	BatchesDropped      int64
+	CommitFail          int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	statistics := make([]models.Statistic, 0, len(s.topics))
+	for _, t := range s.topics {
+		statistics = append(statistics, models.Statistic{
+			Name: "kafka",
+			Tags: t.defaultTags.Merge(tags),
+			Values: map[string]interface{}{
+				statMessagesReceived:    atomic.LoadInt64(&t.stats.MessagesReceived),
+				statBytesReceived:       atomic.LoadInt64(&t.stats.BytesReceived),
+				statPointsReceived:      atomic.LoadInt64(&t.stats.PointsReceived),
+				statMessagesParseFail:   atomic.LoadInt64(&t.stats.MessagesParseFail),
+				statReadFail:            atomic.LoadInt64(&t.stats.ReadFail),
+				statBatchesTransmitted:  atomic.LoadInt64(&t.stats.BatchesTransmitted),
+				statPointsTransmitted:   atomic.LoadInt64(&t.stats.PointsTransmitted),
+				statBatchesTransmitFail: atomic.LoadInt64(&t.stats.BatchesTransmitFail),
+				statPointsDropped:       atomic.LoadInt64(&t.stats.PointsDropped),
+				statBatchesDropped:      atomic.LoadInt64(&t.stats.BatchesDropped),
+				statCommitFail:          atomic.LoadInt64(&t.stats.CommitFail),
+			},
+		})
+	}
+	return statistics
+}
+
+// consume fetches the messages of the topic and sends them in batches to
+// out. A batch is sent once it holds batch-size points, or batch-timeout
+// after its first message.
+func (s *Service) consume(ctx context.Context, t *topic, out chan<- *batch) {
+	defer s.wg.Done()
+
+	var (
+		b        *batch
+		deadline time.Time
+	)
+	for {
+		m, err := fetch(ctx, t.consumer, deadline)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			} else if err == context.DeadlineExceeded && b != nil {
+				// Batch timeout.
+				select {
+				case out <- b:
+				case <-ctx.Done():
+					return
+				}
+				b, deadline = nil, time.Time{}
+				continue
+			}
+
+			atomic.AddInt64(&t.stats.ReadFail, 1)
+			s.Logger.Info("Failed to fetch message", zap.String("topic", t.config.Topic), zap.Error(err))
+			select {
+			case <-time.After(s.retryInterval):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		atomic.AddInt64(&t.stats.MessagesReceived, 1)
+		atomic.AddInt64(&t.stats.BytesReceived, int64(len(m.Value)))
+
+		points, err := s.parse(m.Value)
+		if err != nil {
+			// The points which could be parsed are still written; the
+			// others would fail again if the message were consumed again.
+			atomic.AddInt64(&t.stats.MessagesParseFail, 1)
+			s.Logger.Info("Failed to parse points",
+				zap.String("topic", t.config.Topic),
+				zap.Int("partition", m.Partition),
+				zap.Int64("offset", m.Offset),
+				zap.Error(err))
+		}
+		atomic.AddInt64(&t.stats.PointsReceived, int64(len(points)))
+
+		if b == nil {
+			b = &batch{messages: make(map[int]Message)}
+			deadline = time.Now().Add(s.batchTimeout)
+		}
+		b.points = append(b.points, points...)
+		m.Value = nil
+		b.messages[m.Partition] = m
+
+		if len(b.points) >= s.batchSize {
+			select {
+			case out <- b:
+			case <-ctx.Done():
+				return
+			}
+			b, deadline = nil, time.Time{}
+		}
+	}
+}
+
+// fetch fetches the next message of the consumer, giving up at the deadline
+// unless it is zero.
+func fetch(ctx context.Context, c Consumer, deadline time.Time) (Message, error) {
+	if !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	return c.FetchMessage(ctx)
+}
+
+// parse parses the points of a message in the configured format.
+func (s *Service) parse(buf []byte) ([]models.Point, error) {
+	now := time.Now().UTC()
+	if s.format == FormatJSON {
+		return parseJSON(buf, now, s.config.Precision)
+	}
+	return models.ParsePointsWithPrecision(buf, now, s.config.Precision)
+}
+
+// write writes the batches of the topic in order and commits their messages.
+func (s *Service) write(ctx context.Context, t *topic, in <-chan *batch) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case b := <-in:
+			if !s.writeBatch(ctx, t, b) {
+				return
+			}
+
+			msgs := make([]Message, 0, len(b.messages))
+			for _, m := range b.messages {
+				msgs = append(msgs, m)
+			}
+			if err := t.consumer.CommitMessages(ctx, msgs...); err != nil {
+				// The messages will be consumed again, which rewrites
+				// the same points.
+				atomic.AddInt64(&t.stats.CommitFail, 1)
+				s.Logger.Info("Failed to commit messages", zap.String("topic", t.config.Topic), zap.Error(err))
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// writeBatch writes the points of the batch, retrying with backoff until the
+// write succeeds or fails permanently. It returns false if the service was
+// closed first.
+func (s *Service) writeBatch(ctx context.Context, t *topic, b *batch) bool {
+	interval := s.retryInterval
+	for {
+		err := s.writePoints(t, b.points)
+		if err == nil {
+			atomic.AddInt64(&t.stats.BatchesTransmitted, 1)
+			atomic.AddInt64(&t.stats.PointsTransmitted, int64(len(b.points)))
+			return true
+		} else if werr, ok := err.(tsdb.PartialWriteError); ok {
+			// The dropped points would be dropped again.
+			atomic.AddInt64(&t.stats.BatchesTransmitted, 1)
+			atomic.AddInt64(&t.stats.PointsTransmitted, int64(len(b.points)-werr.Dropped))
+			atomic.AddInt64(&t.stats.PointsDropped, int64(werr.Dropped))
+			s.Logger.Info("Dropped points of batch",
+				zap.String("topic", t.config.Topic),
+				logger.Database(t.config.Database),
+				zap.Error(err))
+			return true
+		} else if isPermanent(err) {
+			// Retrying would block the topic forever.
+			atomic.AddInt64(&t.stats.BatchesDropped, 1)
+			atomic.AddInt64(&t.stats.PointsDropped, int64(len(b.points)))
+			s.Logger.Info("Dropped point batch failing permanently",
+				zap.String("topic", t.config.Topic),
+				logger.Database(t.config.Database),
+				zap.Error(err))
+			if strings.HasPrefix(err.Error(), "database not found") {
+				// Recreate the database if it was dropped.
+				t.ready = false
+			}
+			return true
+		}
+
+		atomic.AddInt64(&t.stats.BatchesTransmitFail, 1)
+		s.Logger.Info("Failed to write point batch to database",
+			zap.String("topic", t.config.Topic),
+			logger.Database(t.config.Database),
+			logger.DurationLiteral("retry_interval", interval),
+			zap.Error(err))
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return false
+		}
+		if interval *= 2; interval > s.maxRetryInterval {
+			interval = s.maxRetryInterval
+		}
+	}
+}
+
+// isPermanent returns true if a write failing with err would fail again if
+// retried. Errors returned by other nodes are only known by their message.
+func isPermanent(err error) bool {
+	if influxdb.IsAuthorizationError(err) || influxdb.IsClientError(err) {
+		return true
+	}
+	msg := err.Error()
+	for _, prefix := range []string{
+		"database not found",
+		"retention policy not found",
+		"authorization failed",
+		"authentication failed",
+	} {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// writePoints creates the database of the topic if needed and writes the points.
+func (s *Service) writePoints(t *topic, points []models.Point) error {
+	if !t.ready {
+		if _, err := s.MetaClient.CreateDatabase(t.config.Database); err != nil {
+			return err
+		}
+		t.ready = true
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	return s.PointsWriter.WritePointsPrivileged(t.config.Database, t.config.RetentionPolicy, s.consistencyLevel, points)
+}
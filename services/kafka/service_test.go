@@ -0,0 +1,446 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/toml"
+)
+
+func TestService_OpenClose(t *testing.T) {
+	s := NewTestService(nil)
+
+	// Closing a closed service is fine.
+	if err := s.Service.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Opening an already open service is fine.
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening a previously opened service is fine.
+	if err := s.Service.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tidy up.
+	if err := s.Service.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_WritesTopics(t *testing.T) {
+	c := NewTestConfig()
+	c.Topics = append(c.Topics, TopicConfig{Topic: "events", Database: "events", RetentionPolicy: "weekly"})
+	c.ConsistencyLevel = "all"
+	s := NewTestService(&c)
+
+	var created []string
+	s.MetaClient.CreateDatabaseFn = func(name string) (*meta.DatabaseInfo, error) {
+		s.mu.Lock()
+		created = append(created, name)
+		s.mu.Unlock()
+		return nil, nil
+	}
+
+	s.Broker.Produce("metrics", "cpu value=1 1000000000\ncpu value=2 2000000000", "mem value=3 3000000000")
+	s.Broker.Produce("events", "deploy count=1i 4000000000")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	s.WaitCommitted(t, "metrics", 2)
+	s.WaitCommitted(t, "events", 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got, exp := s.Writes["telegraf."], []string{
+		"cpu value=1 1000000000",
+		"cpu value=2 2000000000",
+		"mem value=3 3000000000",
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points written to telegraf:\ngot %v\nexp %v", got, exp)
+	}
+	if got, exp := s.Writes["events.weekly"], []string{"deploy count=1i 4000000000"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points written to events:\ngot %v\nexp %v", got, exp)
+	}
+	if s.ConsistencyLevel != models.ConsistencyLevelAll {
+		t.Fatalf("unexpected consistency level: %v", s.ConsistencyLevel)
+	}
+	if len(created) != 2 {
+		t.Fatalf("unexpected databases created: %v", created)
+	}
+}
+
+func TestService_CommitsAfterWrite(t *testing.T) {
+	s := NewTestService(nil)
+
+	// Fail the first writes.
+	failures := 3
+	s.WritePointsFn = func(database, retentionPolicy string, points []models.Point) error {
+		if failures > 0 {
+			failures--
+			return errors.New("write failed")
+		}
+		return nil
+	}
+
+	s.Broker.Produce("metrics", "cpu value=1 1000000000")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	s.WaitCommitted(t, "metrics", 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if failures != 0 {
+		t.Fatalf("offsets committed before write succeeded, %d failures left", failures)
+	}
+	if got, exp := s.Writes["telegraf."], []string{"cpu value=1 1000000000"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points written:\ngot %v\nexp %v", got, exp)
+	}
+
+	stats := s.Service.Statistics(nil)[0].Values
+	if stats[statBatchesTransmitFail] != int64(3) || stats[statBatchesTransmitted] != int64(1) {
+		t.Fatalf("unexpected statistics: %v", stats)
+	}
+}
+
+func TestService_DropsPermanentFailures(t *testing.T) {
+	c := NewTestConfig()
+	c.BatchSize = 1
+	s := NewTestService(&c)
+
+	// The retention policy is missing for the first batch, the database has
+	// been dropped for the second.
+	var creates int
+	s.MetaClient.CreateDatabaseFn = func(string) (*meta.DatabaseInfo, error) {
+		creates++
+		return nil, nil
+	}
+	errs := []error{
+		errors.New("retention policy not found: autogen"),
+		errors.New("database not found: telegraf"),
+	}
+	s.WritePointsFn = func(database, retentionPolicy string, points []models.Point) error {
+		if len(errs) > 0 {
+			err := errs[0]
+			errs = errs[1:]
+			return err
+		}
+		return nil
+	}
+
+	s.Broker.Produce("metrics", "cpu value=1 1000000000", "cpu value=2 2000000000", "cpu value=3 3000000000")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	// The failing batches are committed without being retried.
+	s.WaitCommitted(t, "metrics", 3)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got, exp := s.Writes["telegraf."], []string{"cpu value=3 3000000000"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points written:\ngot %v\nexp %v", got, exp)
+	} else if creates != 2 {
+		t.Fatalf("database created %d times, expected 2", creates)
+	}
+
+	stats := s.Service.Statistics(nil)[0].Values
+	if stats[statBatchesDropped] != int64(2) || stats[statPointsDropped] != int64(2) || stats[statBatchesTransmitFail] != int64(0) {
+		t.Fatalf("unexpected statistics: %v", stats)
+	}
+}
+
+func TestService_RedeliversUncommitted(t *testing.T) {
+	s := NewTestService(nil)
+
+	// Fail every write so that nothing is committed.
+	failed := make(chan struct{}, 1)
+	s.WritePointsFn = func(database, retentionPolicy string, points []models.Point) error {
+		select {
+		case failed <- struct{}{}:
+		default:
+		}
+		return errors.New("write failed")
+	}
+
+	s.Broker.Produce("metrics", "cpu value=1 1000000000")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for write")
+	}
+	if err := s.Service.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Broker.Committed("metrics"); got != 0 {
+		t.Fatalf("unexpected committed offset: %d", got)
+	}
+
+	// The message is consumed again once the writes succeed.
+	s.WritePointsFn = nil
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	s.WaitCommitted(t, "metrics", 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got, exp := s.Writes["telegraf."], []string{"cpu value=1 1000000000"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points written:\ngot %v\nexp %v", got, exp)
+	}
+}
+
+func TestService_BatchSize(t *testing.T) {
+	c := NewTestConfig()
+	c.BatchSize = 2
+	c.BatchTimeout = toml.Duration(time.Hour)
+	s := NewTestService(&c)
+
+	s.Broker.Produce("metrics", "cpu value=1 1000000000", "cpu value=2 2000000000", "cpu value=3 3000000000")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	// Only the first full batch is written before the batch timeout.
+	s.WaitCommitted(t, "metrics", 2)
+	time.Sleep(50 * time.Millisecond)
+	if got := s.Broker.Committed("metrics"); got != 2 {
+		t.Fatalf("unexpected committed offset: %d", got)
+	}
+}
+
+func TestService_ParseErrors(t *testing.T) {
+	c := NewTestConfig()
+	c.Format = FormatJSON
+	c.Precision = "s"
+	s := NewTestService(&c)
+
+	s.Broker.Produce("metrics",
+		`{"measurement":"cpu","tags":{"host":"a"},"fields":{"value":1,"ok":true},"time":1}`,
+		`not json`,
+		`[{"measurement":"mem","fields":{"free":"lots"},"time":"1970-01-01T00:00:02Z"},{"fields":{"value":1}}]`,
+	)
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	// Messages which fail to parse are still committed.
+	s.WaitCommitted(t, "metrics", 3)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got, exp := s.Writes["telegraf."], []string{
+		"cpu,host=a ok=true,value=1 1000000000",
+		`mem free="lots" 2000000000`,
+	}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected points written:\ngot %v\nexp %v", got, exp)
+	}
+
+	stats := s.Service.Statistics(nil)[0].Values
+	if stats[statMessagesParseFail] != int64(2) || stats[statPointsReceived] != int64(2) {
+		t.Fatalf("unexpected statistics: %v", stats)
+	}
+}
+
+type TestService struct {
+	Service    *Service
+	Broker     *Broker
+	MetaClient *internal.MetaClientMock
+
+	mu               sync.Mutex
+	Writes           map[string][]string // points written to each database.rp
+	ConsistencyLevel models.ConsistencyLevel
+	WritePointsFn    func(database, retentionPolicy string, points []models.Point) error
+}
+
+// NewTestConfig returns a config consuming the topic "metrics" into the
+// database "telegraf".
+func NewTestConfig() Config {
+	c := NewConfig()
+	c.Enabled = true
+	c.Brokers = []string{"localhost:9092"}
+	c.Topics = []TopicConfig{{Topic: "metrics", Database: "telegraf"}}
+	c.BatchTimeout = toml.Duration(10 * time.Millisecond)
+	c.RetryInterval = toml.Duration(time.Millisecond)
+	return c
+}
+
+func NewTestService(c *Config) *TestService {
+	if c == nil {
+		defaultC := NewTestConfig()
+		c = &defaultC
+	}
+
+	srv, err := NewService(*c)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &TestService{
+		Service:    srv,
+		Broker:     NewBroker(),
+		MetaClient: &internal.MetaClientMock{},
+		Writes:     make(map[string][]string),
+	}
+	s.MetaClient.CreateDatabaseFn = func(string) (*meta.DatabaseInfo, error) { return nil, nil }
+
+	if testing.Verbose() {
+		s.Service.WithLogger(logger.New(os.Stderr))
+	}
+
+	s.Service.NewConsumer = s.Broker.NewConsumer
+	s.Service.MetaClient = s.MetaClient
+	s.Service.PointsWriter = s
+	return s
+}
+
+func (s *TestService) WritePointsPrivileged(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.WritePointsFn != nil {
+		if err := s.WritePointsFn(database, retentionPolicy, points); err != nil {
+			return err
+		}
+	}
+
+	key := database + "." + retentionPolicy
+	for _, p := range points {
+		s.Writes[key] = append(s.Writes[key], p.String())
+	}
+	s.ConsistencyLevel = consistencyLevel
+	return nil
+}
+
+// WaitCommitted waits until the offset committed for the topic reaches offset.
+func (s *TestService) WaitCommitted(t *testing.T, topic string, offset int64) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for s.Broker.Committed(topic) < offset {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out waiting for offset %d of %s, committed %d", offset, topic, s.Broker.Committed(topic))
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// Broker is an in-memory stand-in for a Kafka broker with a single consumer
+// group. Each topic has one partition.
+type Broker struct {
+	mu        sync.Mutex
+	messages  map[string][][]byte
+	committed map[string]int64 // next offset of each topic for the group
+	produced  chan struct{}    // closed when messages are produced
+}
+
+// NewBroker returns a new instance of Broker.
+func NewBroker() *Broker {
+	return &Broker{
+		messages:  make(map[string][][]byte),
+		committed: make(map[string]int64),
+		produced:  make(chan struct{}),
+	}
+}
+
+// Produce appends messages to the topic.
+func (b *Broker) Produce(topic string, values ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, v := range values {
+		b.messages[topic] = append(b.messages[topic], []byte(v))
+	}
+	close(b.produced)
+	b.produced = make(chan struct{})
+}
+
+// Committed returns the offset committed for the topic.
+func (b *Broker) Committed(topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.committed[topic]
+}
+
+// NewConsumer returns a consumer of the topic, starting at its committed offset.
+func (b *Broker) NewConsumer(brokers []string, groupID, topic string) Consumer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &brokerConsumer{broker: b, topic: topic, offset: b.committed[topic], closing: make(chan struct{})}
+}
+
+type brokerConsumer struct {
+	broker  *Broker
+	topic   string
+	offset  int64
+	closing chan struct{}
+}
+
+func (c *brokerConsumer) FetchMessage(ctx context.Context) (Message, error) {
+	for {
+		c.broker.mu.Lock()
+		msgs, produced := c.broker.messages[c.topic], c.broker.produced
+		c.broker.mu.Unlock()
+
+		if c.offset < int64(len(msgs)) {
+			m := Message{Topic: c.topic, Offset: c.offset, Value: msgs[c.offset]}
+			c.offset++
+			return m, nil
+		}
+
+		select {
+		case <-produced:
+		case <-ctx.Done():
+			return Message{}, ctx.Err()
+		case <-c.closing:
+			return Message{}, errors.New("consumer closed")
+		}
+	}
+}
+
+func (c *brokerConsumer) CommitMessages(ctx context.Context, msgs ...Message) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	for _, m := range msgs {
+		if m.Offset+1 > c.broker.committed[m.Topic] {
+			c.broker.committed[m.Topic] = m.Offset + 1
+		}
+	}
+	return nil
+}
+
+func (c *brokerConsumer) Close() error {
+	close(c.closing)
+	return nil
+}
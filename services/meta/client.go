@@ -864,6 +864,28 @@ func (c *Client) DropShard(id uint64) error {
 	return c.retryUntilExec(internal.Command_DropShardCommand, internal.E_DropShardCommand_Command, cmd)
 }
 
+// SetShardOwnerQuarantine marks or clears the quarantine on a node's copy of a shard.
+func (c *Client) SetShardOwnerQuarantine(id, nodeID uint64, quarantined bool) error {
+	cmd := &internal.SetShardOwnerQuarantineCommand{
+		ID:          proto.Uint64(id),
+		NodeID:      proto.Uint64(nodeID),
+		Quarantined: proto.Bool(quarantined),
+	}
+
+	return c.retryUntilExec(internal.Command_SetShardOwnerQuarantineCommand, internal.E_SetShardOwnerQuarantineCommand_Command, cmd)
+}
+
+// SetShardOwnerTier records the storage tier holding a node's copy of a shard.
+func (c *Client) SetShardOwnerTier(id, nodeID uint64, tier string) error {
+	cmd := &internal.SetShardOwnerTierCommand{
+		ID:     proto.Uint64(id),
+		NodeID: proto.Uint64(nodeID),
+		Tier:   proto.String(tier),
+	}
+
+	return c.retryUntilExec(internal.Command_SetShardOwnerTierCommand, internal.E_SetShardOwnerTierCommand_Command, cmd)
+}
+
 // TruncateShardGroups truncates any shard group that could contain timestamps beyond t.
 func (c *Client) TruncateShardGroups(t time.Time) error {
 	return c.retryUntilExec(internal.Command_TruncateShardGroupsCommand, internal.E_TruncateShardGroupsCommand_Command,
@@ -1030,6 +1052,103 @@ func (c *Client) DropSubscription(database, rp, name string) error {
 	)
 }
 
+// CreateRollupRule creates a rollup rule against the given database and retention policy.
+func (c *Client) CreateRollupRule(database, rp, name string, after, interval time.Duration) error {
+	return c.retryUntilExec(internal.Command_CreateRollupRuleCommand, internal.E_CreateRollupRuleCommand_Command,
+		&internal.CreateRollupRuleCommand{
+			Database:        proto.String(database),
+			RetentionPolicy: proto.String(rp),
+			Name:            proto.String(name),
+			After:           proto.Int64(int64(after)),
+			Interval:        proto.Int64(int64(interval)),
+		},
+	)
+}
+
+// DropRollupRule removes the named rollup rule from the given database and retention policy.
+func (c *Client) DropRollupRule(database, rp, name string) error {
+	return c.retryUntilExec(internal.Command_DropRollupRuleCommand, internal.E_DropRollupRuleCommand_Command,
+		&internal.DropRollupRuleCommand{
+			Database:        proto.String(database),
+			RetentionPolicy: proto.String(rp),
+			Name:            proto.String(name),
+		},
+	)
+}
+
+// CreateMeasurementSchema creates the schema of a measurement in the given database.
+func (c *Client) CreateMeasurementSchema(database string, schema *MeasurementSchemaInfo) error {
+	return c.retryUntilExec(internal.Command_CreateMeasurementSchemaCommand, internal.E_CreateMeasurementSchemaCommand_Command,
+		&internal.CreateMeasurementSchemaCommand{
+			Database: proto.String(database),
+			Schema:   schema.marshal(),
+		},
+	)
+}
+
+// DropMeasurementSchema removes the schema of the named measurement from the given database.
+func (c *Client) DropMeasurementSchema(database, name string) error {
+	return c.retryUntilExec(internal.Command_DropMeasurementSchemaCommand, internal.E_DropMeasurementSchemaCommand_Command,
+		&internal.DropMeasurementSchemaCommand{
+			Database: proto.String(database),
+			Name:     proto.String(name),
+		},
+	)
+}
+
+// SetCardinalityLimits sets the cardinality limits of the given database.
+// Nil or empty limits remove the limits of the database.
+func (c *Client) SetCardinalityLimits(database string, limits *CardinalityLimitsInfo) error {
+	cmd := &internal.SetCardinalityLimitsCommand{
+		Database: proto.String(database),
+	}
+	if !limits.IsEmpty() {
+		cmd.Limits = limits.marshal()
+	}
+	return c.retryUntilExec(internal.Command_SetCardinalityLimitsCommand, internal.E_SetCardinalityLimitsCommand_Command, cmd)
+}
+
+// SetDiskQuota sets the disk quota of the given database, or of one of its
+// retention policies if rp is not empty. A nil or empty quota removes it.
+func (c *Client) SetDiskQuota(database, rp string, quota *DiskQuotaInfo) error {
+	cmd := &internal.SetDiskQuotaCommand{
+		Database: proto.String(database),
+	}
+	if rp != "" {
+		cmd.RetentionPolicy = proto.String(rp)
+	}
+	if !quota.IsEmpty() {
+		cmd.Quota = quota.marshal()
+	}
+	return c.retryUntilExec(internal.Command_SetDiskQuotaCommand, internal.E_SetDiskQuotaCommand_Command, cmd)
+}
+
+// SetReshardCopied records that node nodeID copied the data of shard id, a
+// shard being resharded in the given database and retention policy.
+func (c *Client) SetReshardCopied(database, rp string, id, nodeID uint64) error {
+	return c.retryUntilExec(internal.Command_SetReshardCopiedCommand, internal.E_SetReshardCopiedCommand_Command,
+		&internal.SetReshardCopiedCommand{
+			Database:        proto.String(database),
+			RetentionPolicy: proto.String(rp),
+			ShardID:         proto.Uint64(id),
+			NodeID:          proto.Uint64(nodeID),
+			Timestamp:       proto.Int64(time.Now().UnixNano()),
+		},
+	)
+}
+
+// SetShardLeader makes node nodeID the leader of shard id if its leader is
+// still node prev, or unconditionally if prev is zero.
+func (c *Client) SetShardLeader(id, nodeID, prev uint64) error {
+	return c.retryUntilExec(internal.Command_SetShardLeaderCommand, internal.E_SetShardLeaderCommand_Command,
+		&internal.SetShardLeaderCommand{
+			ID:         proto.Uint64(id),
+			NodeID:     proto.Uint64(nodeID),
+			PrevNodeID: proto.Uint64(prev),
+		},
+	)
+}
+
 // SetData overwrites the underlying data in the meta store.
 func (c *Client) SetData(data *Data) error {
 	return c.retryUntilExec(internal.Command_SetDataCommand, internal.E_SetDataCommand_Command,
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -621,6 +622,50 @@ func (data *Data) CopyShardOwner(id, nodeID uint64) {
 	}
 }
 
+// SetShardOwnerQuarantine marks or clears the quarantine on a shard owner.
+func (data *Data) SetShardOwnerQuarantine(id, nodeID uint64, quarantined bool) {
+	for dbidx, dbi := range data.Databases {
+		for rpidx, rpi := range dbi.RetentionPolicies {
+			for sgidx, sg := range rpi.ShardGroups {
+				for sidx, s := range sg.Shards {
+					if s.ID != id {
+						continue
+					}
+					for i, owner := range s.Owners {
+						if owner.NodeID == nodeID {
+							data.Databases[dbidx].RetentionPolicies[rpidx].ShardGroups[sgidx].Shards[sidx].Owners[i].Quarantined = quarantined
+							return
+						}
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// SetShardOwnerTier records the storage tier holding a shard owner's copy.
+func (data *Data) SetShardOwnerTier(id, nodeID uint64, tier string) {
+	for dbidx, dbi := range data.Databases {
+		for rpidx, rpi := range dbi.RetentionPolicies {
+			for sgidx, sg := range rpi.ShardGroups {
+				for sidx, s := range sg.Shards {
+					if s.ID != id {
+						continue
+					}
+					for i, owner := range s.Owners {
+						if owner.NodeID == nodeID {
+							data.Databases[dbidx].RetentionPolicies[rpidx].ShardGroups[sgidx].Shards[sidx].Owners[i].Tier = tier
+							return
+						}
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
 // RemoveShardOwner removes a shard owner by ID and NodeID.
 func (data *Data) RemoveShardOwner(id, nodeID uint64) {
 	found := -1
@@ -741,6 +786,16 @@ func (data *Data) CreateShardGroup(database, policy string, timestamp time.Time)
 		return nil
 	}
 
+	// A group can't be created where a group is being resharded, as the
+	// resharded group would overlap it.
+	if rpi.Reshard != nil {
+		for i := range rpi.Reshard.ShardGroups {
+			if rpi.Reshard.ShardGroups[i].Contains(timestamp) {
+				return ErrShardGroupResharding
+			}
+		}
+	}
+
 	// Require at least one replica but no more replicas than nodes.
 	replicaN := rpi.ReplicaN
 	if replicaN == 0 {
@@ -765,14 +820,18 @@ func (data *Data) CreateShardGroup(database, policy string, timestamp time.Time)
 		endTime = time.Unix(0, models.MaxNanoTime+1)
 	}
 
-	for i := range rpi.ShardGroups {
-		if rpi.ShardGroups[i].Deleted() {
+	groups := rpi.ShardGroups
+	if rpi.Reshard != nil {
+		groups = append(groups[:len(groups):len(groups)], rpi.Reshard.ShardGroups...)
+	}
+	for i := range groups {
+		if groups[i].Deleted() {
 			continue
 		}
-		startI := rpi.ShardGroups[i].StartTime
-		endI := rpi.ShardGroups[i].EndTime
-		if rpi.ShardGroups[i].Truncated() {
-			endI = rpi.ShardGroups[i].TruncatedAt
+		startI := groups[i].StartTime
+		endI := groups[i].EndTime
+		if groups[i].Truncated() {
+			endI = groups[i].TruncatedAt
 		}
 
 		// shard_i covers range [start_i, end_i)
@@ -801,32 +860,38 @@ func (data *Data) CreateShardGroup(database, policy string, timestamp time.Time)
 	sgi.StartTime = startTime
 	sgi.EndTime = endTime
 
+	sgi.Shards = data.newShards(shardN, replicaN)
+
+	// Retention policy has a new shard group, so update the policy. Shard
+	// Groups must be stored in sorted order, as other parts of the system
+	// assume this to be the case.
+	rpi.ShardGroups = append(rpi.ShardGroups, sgi)
+	sort.Sort(ShardGroupInfos(rpi.ShardGroups))
+
+	return nil
+}
+
+// newShards returns shardN new shards, each owned by replicaN data nodes.
+func (data *Data) newShards(shardN, replicaN int) []ShardInfo {
 	// Create shards on the group.
-	sgi.Shards = make([]ShardInfo, shardN)
-	for i := range sgi.Shards {
+	shards := make([]ShardInfo, shardN)
+	for i := range shards {
 		data.MaxShardID++
-		sgi.Shards[i] = ShardInfo{ID: data.MaxShardID}
+		shards[i] = ShardInfo{ID: data.MaxShardID}
 	}
 
 	// Assign data nodes to shards via round robin.
 	// Start from a repeatably "random" place in the node list.
 	nodeIndex := int(data.Index % uint64(len(data.DataNodes)))
-	for i := range sgi.Shards {
-		si := &sgi.Shards[i]
+	for i := range shards {
+		si := &shards[i]
 		for j := 0; j < replicaN; j++ {
 			nodeID := data.DataNodes[nodeIndex%len(data.DataNodes)].ID
 			si.Owners = append(si.Owners, ShardOwner{NodeID: nodeID})
 			nodeIndex++
 		}
 	}
-
-	// Retention policy has a new shard group, so update the policy. Shard
-	// Groups must be stored in sorted order, as other parts of the system
-	// assume this to be the case.
-	rpi.ShardGroups = append(rpi.ShardGroups, sgi)
-	sort.Sort(ShardGroupInfos(rpi.ShardGroups))
-
-	return nil
+	return shards
 }
 
 // DeleteShardGroup removes a shard group from a database and retention policy by id.
@@ -964,6 +1029,353 @@ func (data *Data) DropSubscription(database, rp, name string) error {
 	return ErrSubscriptionNotFound
 }
 
+// SetRetentionPolicyReplication sets how the shards of a retention policy are
+// replicated, one of ReplicationModeFanout or ReplicationModeWAL.
+func (data *Data) SetRetentionPolicyReplication(database, rp, mode string) error {
+	switch mode {
+	case ReplicationModeFanout:
+		mode = ""
+	case ReplicationModeWAL:
+	default:
+		return ErrInvalidReplicationMode(mode)
+	}
+
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(rp)
+	}
+	rpi.ReplicationMode = mode
+	return nil
+}
+
+// SetShardLeader makes node nodeID the leader of shard id. If prev is not
+// zero, the leader is only changed if it is still node prev, so that owners
+// failing over the same leader at once agree on the new one.
+func (data *Data) SetShardLeader(id, nodeID, prev uint64) error {
+	for dbidx, dbi := range data.Databases {
+		for rpidx, rpi := range dbi.RetentionPolicies {
+			for sgidx, sg := range rpi.ShardGroups {
+				for sidx, s := range sg.Shards {
+					if s.ID != id {
+						continue
+					}
+					if !s.OwnedBy(nodeID) {
+						return ErrShardLeaderNotOwner
+					} else if prev != 0 && s.LeaderID() != prev {
+						return ErrShardLeaderChanged
+					}
+					data.Databases[dbidx].RetentionPolicies[rpidx].ShardGroups[sgidx].Shards[sidx].Leader = nodeID
+					return nil
+				}
+			}
+		}
+	}
+	return ErrShardNotFound
+}
+
+// CreateRollupRule adds a named rollup rule to a database and retention policy.
+// Shards in the policy whose group ended more than after ago are downsampled
+// to the rule's interval.
+func (data *Data) CreateRollupRule(database, rp, name string, after, interval time.Duration) error {
+	if after <= 0 {
+		return ErrRollupRuleAfterRequired
+	} else if interval <= 0 {
+		return ErrRollupRuleIntervalRequired
+	}
+
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(rp)
+	}
+
+	// Ensure the name doesn't already exist.
+	for i := range rpi.RollupRules {
+		if rpi.RollupRules[i].Name == name {
+			return ErrRollupRuleExists
+		}
+	}
+
+	rpi.RollupRules = append(rpi.RollupRules, RollupRuleInfo{
+		Name:     name,
+		After:    after,
+		Interval: interval,
+	})
+
+	return nil
+}
+
+// DropRollupRule removes a rollup rule.
+func (data *Data) DropRollupRule(database, rp, name string) error {
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(rp)
+	}
+
+	for i := range rpi.RollupRules {
+		if rpi.RollupRules[i].Name == name {
+			rpi.RollupRules = append(rpi.RollupRules[:i:i], rpi.RollupRules[i+1:]...)
+			return nil
+		}
+	}
+	return ErrRollupRuleNotFound
+}
+
+// CreateMeasurementSchema adds the schema of a measurement to a database.
+// Points written to the measurement must then conform to the schema.
+func (data *Data) CreateMeasurementSchema(database string, schema *MeasurementSchemaInfo) error {
+	if err := schema.validate(); err != nil {
+		return err
+	}
+
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	} else if di.MeasurementSchema(schema.Name) != nil {
+		return ErrMeasurementSchemaExists
+	}
+
+	other := schema.clone()
+	if other.Mode == "" {
+		other.Mode = MeasurementSchemaModeStrict
+	}
+	di.MeasurementSchemas = append(di.MeasurementSchemas, other)
+	return nil
+}
+
+// DropMeasurementSchema removes the schema of a measurement from a database.
+func (data *Data) DropMeasurementSchema(database, name string) error {
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	for i := range di.MeasurementSchemas {
+		if di.MeasurementSchemas[i].Name == name {
+			di.MeasurementSchemas = append(di.MeasurementSchemas[:i:i], di.MeasurementSchemas[i+1:]...)
+			return nil
+		}
+	}
+	return ErrMeasurementSchemaNotFound
+}
+
+// SetCardinalityLimits sets the cardinality limits of a database, which
+// override those of the data nodes. Nil or empty limits remove the overrides.
+func (data *Data) SetCardinalityLimits(database string, limits *CardinalityLimitsInfo) error {
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	if limits.IsEmpty() {
+		di.CardinalityLimits = nil
+		return nil
+	}
+	if err := limits.validate(); err != nil {
+		return err
+	}
+	di.CardinalityLimits = limits.clone()
+	return nil
+}
+
+// SetDiskQuota sets the disk quota of a database, or of one of its retention
+// policies if rp is not empty. Nil or empty quotas remove the quota.
+func (data *Data) SetDiskQuota(database, rp string, quota *DiskQuotaInfo) error {
+	di := data.Database(database)
+	if di == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	target := &di.DiskQuota
+	if rp != "" {
+		rpi, err := data.RetentionPolicy(database, rp)
+		if err != nil {
+			return err
+		} else if rpi == nil {
+			return influxdb.ErrRetentionPolicyNotFound(rp)
+		}
+		target = &rpi.DiskQuota
+	}
+
+	if quota.IsEmpty() {
+		*target = nil
+		return nil
+	}
+	if err := quota.validate(); err != nil {
+		return err
+	}
+	other := *quota
+	*target = &other
+	return nil
+}
+
+// CreateReshard starts resharding a retention policy to shard groups of the
+// given duration. A new shard group is created for each window of duration,
+// ended before now, which holds groups of another duration. The data nodes
+// copy the data of the window into the new group, which replaces the old
+// groups once every owner has copied it. Groups created from now on have the
+// new duration.
+func (data *Data) CreateReshard(database, rp string, duration time.Duration, now time.Time) error {
+	if duration <= 0 {
+		return ErrReshardDurationRequired
+	} else if len(data.DataNodes) == 0 {
+		return ErrNodeNotFound
+	}
+
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(rp)
+	} else if rpi.Reshard != nil {
+		return ErrReshardExists
+	}
+
+	replicaN := rpi.ReplicaN
+	if replicaN == 0 {
+		replicaN = 1
+	} else if replicaN > len(data.DataNodes) {
+		replicaN = len(data.DataNodes)
+	}
+
+	// Group the shard groups by the window of the new duration they are in.
+	// Shard groups are sorted, so the windows are too.
+	var windows [][]ShardGroupInfo
+	for _, sgi := range rpi.ShardGroups {
+		if sgi.Deleted() {
+			continue
+		}
+		start := sgi.StartTime.Truncate(duration).UTC()
+		if sgi.EndTime.After(start.Add(duration)) {
+			return ErrReshardUnaligned
+		}
+		if n := len(windows); n > 0 && windows[n-1][0].StartTime.Truncate(duration).Equal(start) {
+			windows[n-1] = append(windows[n-1], sgi)
+		} else {
+			windows = append(windows, []ShardGroupInfo{sgi})
+		}
+	}
+
+	reshard := &ReshardInfo{Duration: duration}
+	for _, groups := range windows {
+		start := groups[0].StartTime.Truncate(duration).UTC()
+		end := start.Add(duration).UTC()
+		if end.After(now) {
+			// Groups still written to are not resharded.
+			continue
+		} else if len(groups) == 1 && groups[0].StartTime.Equal(start) && groups[0].EndTime.Equal(end) {
+			// The window already is a single group of the duration.
+			continue
+		}
+
+		// The points of a series are in the shard of the same index in each
+		// group, as long as the groups have as many shards.
+		shardN := len(groups[0].Shards)
+		for _, sgi := range groups[1:] {
+			if len(sgi.Shards) != shardN {
+				return ErrReshardShardCount
+			}
+		}
+
+		data.MaxShardGroupID++
+		reshard.ShardGroups = append(reshard.ShardGroups, ShardGroupInfo{
+			ID:        data.MaxShardGroupID,
+			StartTime: start,
+			EndTime:   end,
+			Shards:    data.newShards(shardN, replicaN),
+		})
+	}
+
+	rpi.ShardGroupDuration = duration
+	if len(reshard.ShardGroups) > 0 {
+		rpi.Reshard = reshard
+	}
+	return nil
+}
+
+// SetReshardCopied records that node nodeID copied the data of shard id of
+// a resharded group. Once every owner of every shard of the group copied it,
+// the group replaces the old groups, which are marked as deleted.
+func (data *Data) SetReshardCopied(database, rp string, id, nodeID uint64, now time.Time) error {
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(rp)
+	} else if rpi.Reshard == nil {
+		return ErrReshardNotFound
+	}
+	reshard := rpi.Reshard
+
+	sgi := reshard.ShardGroup(id)
+	if sgi == nil {
+		return ErrReshardShardNotFound
+	}
+	if !reshard.Copied(id, nodeID) {
+		reshard.Copies = append(reshard.Copies, ReshardCopy{ShardID: id, NodeID: nodeID})
+	}
+
+	for _, si := range sgi.Shards {
+		for _, owner := range si.Owners {
+			if !reshard.Copied(si.ID, owner.NodeID) {
+				return nil
+			}
+		}
+	}
+
+	// Every owner copied the group, replace the old groups with it.
+	for _, src := range rpi.ReshardSources(sgi) {
+		src.DeletedAt = now.UTC()
+	}
+	rpi.ShardGroups = append(rpi.ShardGroups, sgi.clone())
+	sort.Sort(ShardGroupInfos(rpi.ShardGroups))
+
+	copies := reshard.Copies[:0]
+	for _, c := range reshard.Copies {
+		if !sgi.hasShard(c.ShardID) {
+			copies = append(copies, c)
+		}
+	}
+	reshard.Copies = copies
+	for i := range reshard.ShardGroups {
+		if reshard.ShardGroups[i].ID == sgi.ID {
+			reshard.ShardGroups = append(reshard.ShardGroups[:i:i], reshard.ShardGroups[i+1:]...)
+			break
+		}
+	}
+	if len(reshard.ShardGroups) == 0 {
+		rpi.Reshard = nil
+	}
+	return nil
+}
+
+// DropReshard stops resharding a retention policy. The groups not yet
+// copied are marked as deleted, so that the data nodes remove their shards.
+func (data *Data) DropReshard(database, rp string, now time.Time) error {
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	} else if rpi == nil {
+		return influxdb.ErrRetentionPolicyNotFound(rp)
+	} else if rpi.Reshard == nil {
+		return ErrReshardNotFound
+	}
+
+	for _, sgi := range rpi.Reshard.ShardGroups {
+		sgi = sgi.clone()
+		sgi.DeletedAt = now.UTC()
+		rpi.ShardGroups = append(rpi.ShardGroups, sgi)
+	}
+	sort.Sort(ShardGroupInfos(rpi.ShardGroups))
+	rpi.Reshard = nil
+	return nil
+}
+
 func (data *Data) user(username string) *UserInfo {
 	for i := range data.Users {
 		if data.Users[i].Name == username {
@@ -1420,6 +1832,20 @@ type DatabaseInfo struct {
 	DefaultRetentionPolicy string
 	RetentionPolicies      []RetentionPolicyInfo
 	ContinuousQueries      []ContinuousQueryInfo
+	MeasurementSchemas     []MeasurementSchemaInfo
+	CardinalityLimits      *CardinalityLimitsInfo
+	DiskQuota              *DiskQuotaInfo
+}
+
+// MeasurementSchema returns the schema of a measurement by name, or nil if
+// the measurement has no schema.
+func (di DatabaseInfo) MeasurementSchema(name string) *MeasurementSchemaInfo {
+	for i := range di.MeasurementSchemas {
+		if di.MeasurementSchemas[i].Name == name {
+			return &di.MeasurementSchemas[i]
+		}
+	}
+	return nil
 }
 
 // RetentionPolicy returns a retention policy by name.
@@ -1483,6 +1909,22 @@ func (di DatabaseInfo) clone() DatabaseInfo {
 		}
 	}
 
+	if di.MeasurementSchemas != nil {
+		other.MeasurementSchemas = make([]MeasurementSchemaInfo, len(di.MeasurementSchemas))
+		for i := range di.MeasurementSchemas {
+			other.MeasurementSchemas[i] = di.MeasurementSchemas[i].clone()
+		}
+	}
+
+	if di.CardinalityLimits != nil {
+		other.CardinalityLimits = di.CardinalityLimits.clone()
+	}
+
+	if di.DiskQuota != nil {
+		quota := *di.DiskQuota
+		other.DiskQuota = &quota
+	}
+
 	return other
 }
 
@@ -1501,7 +1943,20 @@ func (di DatabaseInfo) marshal() *internal.DatabaseInfo {
 	for i := range di.ContinuousQueries {
 		pb.ContinuousQueries[i] = di.ContinuousQueries[i].marshal()
 	}
-	return pb
+
+	pb.MeasurementSchemas = make([]*internal.MeasurementSchemaInfo, len(di.MeasurementSchemas))
+	for i := range di.MeasurementSchemas {
+		pb.MeasurementSchemas[i] = di.MeasurementSchemas[i].marshal()
+	}
+
+	if di.CardinalityLimits != nil {
+		pb.CardinalityLimits = di.CardinalityLimits.marshal()
+	}
+
+	if di.DiskQuota != nil {
+		pb.DiskQuota = di.DiskQuota.marshal()
+	}
+	return pb
 }
 
 // unmarshal deserializes from a protobuf representation.
@@ -1522,6 +1977,23 @@ func (di *DatabaseInfo) unmarshal(pb *internal.DatabaseInfo) {
 			di.ContinuousQueries[i].unmarshal(x)
 		}
 	}
+
+	if len(pb.GetMeasurementSchemas()) > 0 {
+		di.MeasurementSchemas = make([]MeasurementSchemaInfo, len(pb.GetMeasurementSchemas()))
+		for i, x := range pb.GetMeasurementSchemas() {
+			di.MeasurementSchemas[i].unmarshal(x)
+		}
+	}
+
+	if pb.CardinalityLimits != nil {
+		di.CardinalityLimits = &CardinalityLimitsInfo{}
+		di.CardinalityLimits.unmarshal(pb.GetCardinalityLimits())
+	}
+
+	if pb.DiskQuota != nil {
+		di.DiskQuota = &DiskQuotaInfo{}
+		di.DiskQuota.unmarshal(pb.GetDiskQuota())
+	}
 }
 
 // RetentionPolicySpec represents the specification for a new retention policy.
@@ -1616,6 +2088,33 @@ type RetentionPolicyInfo struct {
 	ShardGroupDuration time.Duration
 	ShardGroups        []ShardGroupInfo
 	Subscriptions      []SubscriptionInfo
+	RollupRules        []RollupRuleInfo
+	Reshard            *ReshardInfo
+
+	// ReplicationMode is how the shards of the policy are replicated. It is
+	// empty for ReplicationModeFanout.
+	ReplicationMode string
+
+	// DiskQuota limits the disk used by the shards of the policy on each
+	// data node, in addition to the quota of the database.
+	DiskQuota *DiskQuotaInfo
+}
+
+// Replication modes of a retention policy.
+const (
+	// ReplicationModeFanout writes the points of a shard to each of its
+	// owners. It is the default.
+	ReplicationModeFanout = "fanout"
+
+	// ReplicationModeWAL writes the points of a shard to its leader only,
+	// which ships its WAL segments to the other owners.
+	ReplicationModeWAL = "wal"
+)
+
+// WALReplicated returns true if the shards of the policy are replicated by
+// shipping the WAL of their leader.
+func (rpi *RetentionPolicyInfo) WALReplicated() bool {
+	return rpi.ReplicationMode == ReplicationModeWAL
 }
 
 // NewRetentionPolicyInfo returns a new instance of RetentionPolicyInfo
@@ -1682,6 +2181,22 @@ func (rpi *RetentionPolicyInfo) ExpiredShardGroups(t time.Time) []*ShardGroupInf
 	return groups
 }
 
+// ReshardSources returns the shard groups replaced by sgi, a group being
+// resharded.
+func (rpi *RetentionPolicyInfo) ReshardSources(sgi *ShardGroupInfo) []*ShardGroupInfo {
+	var groups []*ShardGroupInfo
+	for i := range rpi.ShardGroups {
+		g := &rpi.ShardGroups[i]
+		if g.Deleted() || g.ID == sgi.ID {
+			continue
+		}
+		if !g.StartTime.Before(sgi.StartTime) && !g.EndTime.After(sgi.EndTime) {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
 // DeletedShardGroups returns the Shard Groups which are marked as deleted.
 func (rpi *RetentionPolicyInfo) DeletedShardGroups() []*ShardGroupInfo {
 	var groups = make([]*ShardGroupInfo, 0)
@@ -1712,6 +2227,23 @@ func (rpi *RetentionPolicyInfo) marshal() *internal.RetentionPolicyInfo {
 		pb.Subscriptions[i] = sub.marshal()
 	}
 
+	pb.RollupRules = make([]*internal.RollupRuleInfo, len(rpi.RollupRules))
+	for i, rule := range rpi.RollupRules {
+		pb.RollupRules[i] = rule.marshal()
+	}
+
+	if rpi.Reshard != nil {
+		pb.Reshard = rpi.Reshard.marshal()
+	}
+
+	if rpi.ReplicationMode != "" {
+		pb.ReplicationMode = proto.String(rpi.ReplicationMode)
+	}
+
+	if rpi.DiskQuota != nil {
+		pb.DiskQuota = rpi.DiskQuota.marshal()
+	}
+
 	return pb
 }
 
@@ -1734,6 +2266,21 @@ func (rpi *RetentionPolicyInfo) unmarshal(pb *internal.RetentionPolicyInfo) {
 			rpi.Subscriptions[i].unmarshal(x)
 		}
 	}
+	if len(pb.GetRollupRules()) > 0 {
+		rpi.RollupRules = make([]RollupRuleInfo, len(pb.GetRollupRules()))
+		for i, x := range pb.GetRollupRules() {
+			rpi.RollupRules[i].unmarshal(x)
+		}
+	}
+	if pb.Reshard != nil {
+		rpi.Reshard = &ReshardInfo{}
+		rpi.Reshard.unmarshal(pb.GetReshard())
+	}
+	rpi.ReplicationMode = pb.GetReplicationMode()
+	if pb.DiskQuota != nil {
+		rpi.DiskQuota = &DiskQuotaInfo{}
+		rpi.DiskQuota.unmarshal(pb.GetDiskQuota())
+	}
 }
 
 // clone returns a deep copy of rpi.
@@ -1747,6 +2294,20 @@ func (rpi RetentionPolicyInfo) clone() RetentionPolicyInfo {
 		}
 	}
 
+	if rpi.RollupRules != nil {
+		other.RollupRules = make([]RollupRuleInfo, len(rpi.RollupRules))
+		copy(other.RollupRules, rpi.RollupRules)
+	}
+
+	if rpi.Reshard != nil {
+		other.Reshard = rpi.Reshard.clone()
+	}
+
+	if rpi.DiskQuota != nil {
+		quota := *rpi.DiskQuota
+		other.DiskQuota = &quota
+	}
+
 	return other
 }
 
@@ -1865,6 +2426,16 @@ func (sgi ShardGroupInfo) clone() ShardGroupInfo {
 	return other
 }
 
+// hasShard returns true if shard id is in the group.
+func (sgi *ShardGroupInfo) hasShard(id uint64) bool {
+	for i := range sgi.Shards {
+		if sgi.Shards[i].ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 type hashIDer interface {
 	HashID() uint64
 }
@@ -1929,6 +2500,28 @@ func (sgi *ShardGroupInfo) unmarshal(pb *internal.ShardGroupInfo) {
 type ShardInfo struct {
 	ID     uint64
 	Owners []ShardOwner
+
+	// Leader is the node accepting the writes to the shard when its
+	// retention policy is WAL replicated. Zero selects the default leader.
+	Leader uint64
+}
+
+// LeaderID returns the node leading the shard: Leader if it still owns the
+// shard, otherwise the first owner whose copy is not quarantined. It returns
+// zero if the shard has no owners.
+func (si ShardInfo) LeaderID() uint64 {
+	if si.Leader != 0 && si.OwnedBy(si.Leader) {
+		return si.Leader
+	}
+	for _, so := range si.Owners {
+		if !so.Quarantined {
+			return so.NodeID
+		}
+	}
+	if len(si.Owners) > 0 {
+		return si.Owners[0].NodeID
+	}
+	return 0
 }
 
 // OwnedBy determines whether the shard's owner IDs includes nodeID.
@@ -1941,6 +2534,37 @@ func (si ShardInfo) OwnedBy(nodeID uint64) bool {
 	return false
 }
 
+// QuarantinedBy returns true if the node's copy of the shard is quarantined.
+func (si ShardInfo) QuarantinedBy(nodeID uint64) bool {
+	for _, so := range si.Owners {
+		if so.NodeID == nodeID {
+			return so.Quarantined
+		}
+	}
+	return false
+}
+
+// HealthyOwners returns the owners whose copy of the shard is not quarantined.
+func (si ShardInfo) HealthyOwners() []ShardOwner {
+	owners := make([]ShardOwner, 0, len(si.Owners))
+	for _, so := range si.Owners {
+		if !so.Quarantined {
+			owners = append(owners, so)
+		}
+	}
+	return owners
+}
+
+// TierOf returns the storage tier of the node's copy of the shard.
+func (si ShardInfo) TierOf(nodeID uint64) string {
+	for _, so := range si.Owners {
+		if so.NodeID == nodeID {
+			return so.Tier
+		}
+	}
+	return ""
+}
+
 // clone returns a deep copy of si.
 func (si ShardInfo) clone() ShardInfo {
 	other := si
@@ -1966,6 +2590,10 @@ func (si ShardInfo) marshal() *internal.ShardInfo {
 		pb.Owners[i] = si.Owners[i].marshal()
 	}
 
+	if si.Leader != 0 {
+		pb.Leader = proto.Uint64(si.Leader)
+	}
+
 	return pb
 }
 
@@ -1982,6 +2610,7 @@ func (si *ShardInfo) UnmarshalBinary(buf []byte) error {
 // unmarshal deserializes from a protobuf representation.
 func (si *ShardInfo) unmarshal(pb *internal.ShardInfo) {
 	si.ID = pb.GetID()
+	si.Leader = pb.GetLeader()
 
 	// If deprecated "OwnerIDs" exists then convert it to "Owners" format.
 	if len(pb.GetOwnerIDs()) > 0 {
@@ -2031,9 +2660,496 @@ func (si *SubscriptionInfo) unmarshal(pb *internal.SubscriptionInfo) {
 	}
 }
 
+// RollupRuleInfo holds a rollup rule. Shards whose group ended more than
+// After ago are downsampled to Interval.
+type RollupRuleInfo struct {
+	Name     string
+	After    time.Duration
+	Interval time.Duration
+}
+
+// marshal serializes to a protobuf representation.
+func (ri RollupRuleInfo) marshal() *internal.RollupRuleInfo {
+	return &internal.RollupRuleInfo{
+		Name:     proto.String(ri.Name),
+		After:    proto.Int64(int64(ri.After)),
+		Interval: proto.Int64(int64(ri.Interval)),
+	}
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (ri *RollupRuleInfo) unmarshal(pb *internal.RollupRuleInfo) {
+	ri.Name = pb.GetName()
+	ri.After = time.Duration(pb.GetAfter())
+	ri.Interval = time.Duration(pb.GetInterval())
+}
+
+// ReshardInfo holds the resharding of a retention policy to shard groups of
+// Duration. ShardGroups are the groups being copied, which replace the groups
+// they overlap once every owner of their shards is in Copies.
+type ReshardInfo struct {
+	Duration    time.Duration
+	ShardGroups []ShardGroupInfo
+	Copies      []ReshardCopy
+}
+
+// ReshardCopy records that a node copied the data of a resharded shard.
+type ReshardCopy struct {
+	ShardID uint64
+	NodeID  uint64
+}
+
+// ShardGroup returns the group being resharded which holds shard id, or nil.
+func (ri *ReshardInfo) ShardGroup(id uint64) *ShardGroupInfo {
+	for i := range ri.ShardGroups {
+		if ri.ShardGroups[i].hasShard(id) {
+			return &ri.ShardGroups[i]
+		}
+	}
+	return nil
+}
+
+// ShardGroupAt returns the group being resharded which contains t, or nil.
+func (ri *ReshardInfo) ShardGroupAt(t time.Time) *ShardGroupInfo {
+	for i := range ri.ShardGroups {
+		if ri.ShardGroups[i].Contains(t) {
+			return &ri.ShardGroups[i]
+		}
+	}
+	return nil
+}
+
+// Copied returns true if node nodeID copied the data of shard id.
+func (ri *ReshardInfo) Copied(id, nodeID uint64) bool {
+	for _, c := range ri.Copies {
+		if c.ShardID == id && c.NodeID == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a deep copy of ri.
+func (ri *ReshardInfo) clone() *ReshardInfo {
+	other := &ReshardInfo{Duration: ri.Duration}
+	if ri.ShardGroups != nil {
+		other.ShardGroups = make([]ShardGroupInfo, len(ri.ShardGroups))
+		for i := range ri.ShardGroups {
+			other.ShardGroups[i] = ri.ShardGroups[i].clone()
+		}
+	}
+	if ri.Copies != nil {
+		other.Copies = make([]ReshardCopy, len(ri.Copies))
+		copy(other.Copies, ri.Copies)
+	}
+	return other
+}
+
+// marshal serializes to a protobuf representation.
+func (ri *ReshardInfo) marshal() *internal.ReshardInfo {
+	pb := &internal.ReshardInfo{
+		Duration: proto.Int64(int64(ri.Duration)),
+	}
+
+	pb.ShardGroups = make([]*internal.ShardGroupInfo, len(ri.ShardGroups))
+	for i := range ri.ShardGroups {
+		pb.ShardGroups[i] = ri.ShardGroups[i].marshal()
+	}
+
+	pb.Copies = make([]*internal.ReshardCopy, len(ri.Copies))
+	for i, c := range ri.Copies {
+		pb.Copies[i] = &internal.ReshardCopy{
+			ShardID: proto.Uint64(c.ShardID),
+			NodeID:  proto.Uint64(c.NodeID),
+		}
+	}
+
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (ri *ReshardInfo) unmarshal(pb *internal.ReshardInfo) {
+	ri.Duration = time.Duration(pb.GetDuration())
+
+	if len(pb.GetShardGroups()) > 0 {
+		ri.ShardGroups = make([]ShardGroupInfo, len(pb.GetShardGroups()))
+		for i, x := range pb.GetShardGroups() {
+			ri.ShardGroups[i].unmarshal(x)
+		}
+	}
+	if len(pb.GetCopies()) > 0 {
+		ri.Copies = make([]ReshardCopy, len(pb.GetCopies()))
+		for i, x := range pb.GetCopies() {
+			ri.Copies[i] = ReshardCopy{ShardID: x.GetShardID(), NodeID: x.GetNodeID()}
+		}
+	}
+}
+
+// Modes of a measurement schema.
+const (
+	// MeasurementSchemaModeStrict rejects points whose field values don't
+	// have the type of their field.
+	MeasurementSchemaModeStrict = "strict"
+
+	// MeasurementSchemaModeCoerce converts numeric field values to the type
+	// of their field when it can be done without loss, and rejects the others.
+	MeasurementSchemaModeCoerce = "coerce"
+)
+
+// MeasurementSchemaInfo holds the schema of a measurement.
+//
+// Points of the measurement may only have the fields of Fields, with their
+// types, unless Fields is empty. They must have every tag of RequiredTags and,
+// unless AllowedTags is empty, no tags other than those of RequiredTags and
+// AllowedTags.
+type MeasurementSchemaInfo struct {
+	Name         string
+	Fields       []FieldSchemaInfo
+	RequiredTags []string
+	AllowedTags  []string
+	Mode         string
+}
+
+// FieldSchemaInfo holds the name and type of a field of a measurement schema.
+type FieldSchemaInfo struct {
+	Name string
+	Type influxql.DataType
+}
+
+// String returns the field as name:type.
+func (f FieldSchemaInfo) String() string {
+	return f.Name + ":" + f.Type.String()
+}
+
+// ParseFieldSchemas parses a comma separated list of name:type fields, such
+// as "value:float,count:integer".
+func ParseFieldSchemas(s string) ([]FieldSchemaInfo, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var fields []FieldSchemaInfo
+	for _, f := range strings.Split(s, ",") {
+		i := strings.LastIndex(f, ":")
+		if i < 0 {
+			return nil, ErrInvalidMeasurementSchema(fmt.Sprintf("field %q must be name:type", f))
+		}
+		typ := influxql.DataTypeFromString(strings.TrimSpace(f[i+1:]))
+		if typ == influxql.Unknown {
+			return nil, ErrInvalidMeasurementSchema(fmt.Sprintf("unknown type of field %q", f))
+		}
+		fields = append(fields, FieldSchemaInfo{Name: strings.TrimSpace(f[:i]), Type: typ})
+	}
+	return fields, nil
+}
+
+// Field returns the field of the schema by name, or nil if there is none.
+func (si *MeasurementSchemaInfo) Field(name string) *FieldSchemaInfo {
+	for i := range si.Fields {
+		if si.Fields[i].Name == name {
+			return &si.Fields[i]
+		}
+	}
+	return nil
+}
+
+// TagAllowed returns true if points of the measurement may have the tag.
+func (si *MeasurementSchemaInfo) TagAllowed(key string) bool {
+	if len(si.AllowedTags) == 0 {
+		return true
+	}
+	for _, k := range si.AllowedTags {
+		if k == key {
+			return true
+		}
+	}
+	for _, k := range si.RequiredTags {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// validate returns an error if the schema is invalid.
+func (si *MeasurementSchemaInfo) validate() error {
+	if si.Name == "" {
+		return ErrMeasurementSchemaNameRequired
+	}
+
+	switch si.Mode {
+	case "", MeasurementSchemaModeStrict, MeasurementSchemaModeCoerce:
+	default:
+		return ErrInvalidMeasurementSchema(fmt.Sprintf("unknown mode %q", si.Mode))
+	}
+
+	seen := make(map[string]struct{}, len(si.Fields))
+	for _, f := range si.Fields {
+		if f.Name == "" {
+			return ErrInvalidMeasurementSchema("field name required")
+		} else if _, ok := seen[f.Name]; ok {
+			return ErrInvalidMeasurementSchema(fmt.Sprintf("duplicate field %q", f.Name))
+		}
+		seen[f.Name] = struct{}{}
+
+		switch f.Type {
+		case influxql.Float, influxql.Integer, influxql.Unsigned, influxql.String, influxql.Boolean:
+		default:
+			return ErrInvalidMeasurementSchema(fmt.Sprintf("unsupported type %s of field %q", f.Type, f.Name))
+		}
+	}
+
+	for _, tags := range [][]string{si.RequiredTags, si.AllowedTags} {
+		seen := make(map[string]struct{}, len(tags))
+		for _, k := range tags {
+			if k == "" {
+				return ErrInvalidMeasurementSchema("tag key required")
+			} else if _, ok := seen[k]; ok {
+				return ErrInvalidMeasurementSchema(fmt.Sprintf("duplicate tag %q", k))
+			}
+			seen[k] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of si.
+func (si MeasurementSchemaInfo) clone() MeasurementSchemaInfo {
+	other := si
+	if si.Fields != nil {
+		other.Fields = make([]FieldSchemaInfo, len(si.Fields))
+		copy(other.Fields, si.Fields)
+	}
+	if si.RequiredTags != nil {
+		other.RequiredTags = make([]string, len(si.RequiredTags))
+		copy(other.RequiredTags, si.RequiredTags)
+	}
+	if si.AllowedTags != nil {
+		other.AllowedTags = make([]string, len(si.AllowedTags))
+		copy(other.AllowedTags, si.AllowedTags)
+	}
+	return other
+}
+
+// marshal serializes to a protobuf representation.
+func (si MeasurementSchemaInfo) marshal() *internal.MeasurementSchemaInfo {
+	pb := &internal.MeasurementSchemaInfo{
+		Name:         proto.String(si.Name),
+		RequiredTags: si.RequiredTags,
+		AllowedTags:  si.AllowedTags,
+		Mode:         proto.String(si.Mode),
+	}
+
+	pb.Fields = make([]*internal.FieldSchemaInfo, len(si.Fields))
+	for i, f := range si.Fields {
+		pb.Fields[i] = &internal.FieldSchemaInfo{
+			Name: proto.String(f.Name),
+			Type: proto.Int32(int32(f.Type)),
+		}
+	}
+	return pb
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (si *MeasurementSchemaInfo) unmarshal(pb *internal.MeasurementSchemaInfo) {
+	si.Name = pb.GetName()
+	si.RequiredTags = pb.GetRequiredTags()
+	si.AllowedTags = pb.GetAllowedTags()
+	si.Mode = pb.GetMode()
+
+	if len(pb.GetFields()) > 0 {
+		si.Fields = make([]FieldSchemaInfo, len(pb.GetFields()))
+		for i, x := range pb.GetFields() {
+			si.Fields[i] = FieldSchemaInfo{Name: x.GetName(), Type: influxql.DataType(x.GetType())}
+		}
+	}
+}
+
+// DiskQuotaInfo holds the disk quota of a database or retention policy, in
+// bytes used on each data node. Writes are rejected once the hard limit is
+// reached, and the soft limit only raises alerts. A zero limit is unset.
+type DiskQuotaInfo struct {
+	SoftLimit int64
+	HardLimit int64
+}
+
+// IsEmpty returns true if q is nil or sets no limit.
+func (q *DiskQuotaInfo) IsEmpty() bool {
+	return q == nil || (q.SoftLimit == 0 && q.HardLimit == 0)
+}
+
+// validate returns an error if the quota is invalid.
+func (q *DiskQuotaInfo) validate() error {
+	if q.SoftLimit < 0 || q.HardLimit < 0 {
+		return ErrInvalidDiskQuota("limits must not be negative")
+	} else if q.SoftLimit > 0 && q.HardLimit > 0 && q.SoftLimit > q.HardLimit {
+		return ErrInvalidDiskQuota("soft limit must not be above hard limit")
+	}
+	return nil
+}
+
+// marshal serializes to a protobuf representation.
+func (q *DiskQuotaInfo) marshal() *internal.DiskQuotaInfo {
+	return &internal.DiskQuotaInfo{
+		SoftLimit: proto.Int64(q.SoftLimit),
+		HardLimit: proto.Int64(q.HardLimit),
+	}
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (q *DiskQuotaInfo) unmarshal(pb *internal.DiskQuotaInfo) {
+	q.SoftLimit = pb.GetSoftLimit()
+	q.HardLimit = pb.GetHardLimit()
+}
+
+// CardinalityLimitsInfo holds the cardinality limits of a database, which
+// override those of the data nodes. A zero limit inherits the limit of the
+// node, and a negative limit disables it.
+//
+// Measurements and TagKeys hold the limits of specific measurements and tag
+// keys, which override MaxSeriesPerMeasurement and MaxValuesPerTag.
+type CardinalityLimitsInfo struct {
+	MaxSeriesPerMeasurement int64
+	MaxValuesPerTag         int64
+	Measurements            map[string]int64
+	TagKeys                 map[string]int64
+}
+
+// ParseCardinalityLimits parses a comma separated list of name=limit
+// limits, such as "cpu=10000,pod_id=100".
+func ParseCardinalityLimits(s string) (map[string]int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	limits := make(map[string]int64)
+	for _, l := range strings.Split(s, ",") {
+		i := strings.LastIndex(l, "=")
+		if i < 0 {
+			return nil, ErrInvalidCardinalityLimits(fmt.Sprintf("limit %q must be name=limit", l))
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(l[i+1:]), 10, 64)
+		if err != nil {
+			return nil, ErrInvalidCardinalityLimits(fmt.Sprintf("limit %q: %s", l, err))
+		}
+		limits[strings.TrimSpace(l[:i])] = n
+	}
+	return limits, nil
+}
+
+// FormatCardinalityLimits formats limits as a comma separated list of
+// name=limit limits, sorted by name.
+func FormatCardinalityLimits(limits map[string]int64) string {
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		names[i] = name + "=" + strconv.FormatInt(limits[name], 10)
+	}
+	return strings.Join(names, ",")
+}
+
+// IsEmpty returns true if li is nil or overrides no limit.
+func (li *CardinalityLimitsInfo) IsEmpty() bool {
+	return li == nil || (li.MaxSeriesPerMeasurement == 0 && li.MaxValuesPerTag == 0 &&
+		len(li.Measurements) == 0 && len(li.TagKeys) == 0)
+}
+
+// validate returns an error if the limits are invalid.
+func (li *CardinalityLimitsInfo) validate() error {
+	for name := range li.Measurements {
+		if name == "" {
+			return ErrInvalidCardinalityLimits("measurement name required")
+		}
+	}
+	for key := range li.TagKeys {
+		if key == "" {
+			return ErrInvalidCardinalityLimits("tag key required")
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of li.
+func (li *CardinalityLimitsInfo) clone() *CardinalityLimitsInfo {
+	other := *li
+	if li.Measurements != nil {
+		other.Measurements = make(map[string]int64, len(li.Measurements))
+		for k, v := range li.Measurements {
+			other.Measurements[k] = v
+		}
+	}
+	if li.TagKeys != nil {
+		other.TagKeys = make(map[string]int64, len(li.TagKeys))
+		for k, v := range li.TagKeys {
+			other.TagKeys[k] = v
+		}
+	}
+	return &other
+}
+
+// marshal serializes to a protobuf representation.
+func (li *CardinalityLimitsInfo) marshal() *internal.CardinalityLimitsInfo {
+	return &internal.CardinalityLimitsInfo{
+		MaxSeriesPerMeasurement: proto.Int64(li.MaxSeriesPerMeasurement),
+		MaxValuesPerTag:         proto.Int64(li.MaxValuesPerTag),
+		Measurements:            marshalCardinalityLimits(li.Measurements),
+		TagKeys:                 marshalCardinalityLimits(li.TagKeys),
+	}
+}
+
+// unmarshal deserializes from a protobuf representation.
+func (li *CardinalityLimitsInfo) unmarshal(pb *internal.CardinalityLimitsInfo) {
+	li.MaxSeriesPerMeasurement = pb.GetMaxSeriesPerMeasurement()
+	li.MaxValuesPerTag = pb.GetMaxValuesPerTag()
+	li.Measurements = unmarshalCardinalityLimits(pb.GetMeasurements())
+	li.TagKeys = unmarshalCardinalityLimits(pb.GetTagKeys())
+}
+
+// marshalCardinalityLimits serializes limits by name, sorted by name.
+func marshalCardinalityLimits(limits map[string]int64) []*internal.CardinalityLimitInfo {
+	names := make([]string, 0, len(limits))
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pb := make([]*internal.CardinalityLimitInfo, len(names))
+	for i, name := range names {
+		pb[i] = &internal.CardinalityLimitInfo{
+			Name:  proto.String(name),
+			Limit: proto.Int64(limits[name]),
+		}
+	}
+	return pb
+}
+
+// unmarshalCardinalityLimits deserializes limits by name.
+func unmarshalCardinalityLimits(pb []*internal.CardinalityLimitInfo) map[string]int64 {
+	if len(pb) == 0 {
+		return nil
+	}
+	limits := make(map[string]int64, len(pb))
+	for _, x := range pb {
+		limits[x.GetName()] = x.GetLimit()
+	}
+	return limits
+}
+
 // ShardOwner represents a node that owns a shard.
 type ShardOwner struct {
 	NodeID uint64
+
+	// Quarantined is set when the owner's copy of the shard failed an
+	// integrity check. Reads are routed to other owners where possible.
+	Quarantined bool
+
+	// Tier is the storage tier holding the owner's copy of the shard. An
+	// empty tier is the node's primary data directory.
+	Tier string
 }
 
 // clone returns a deep copy of so.
@@ -2044,13 +3160,17 @@ func (so ShardOwner) clone() ShardOwner {
 // marshal serializes to a protobuf representation.
 func (so ShardOwner) marshal() *internal.ShardOwner {
 	return &internal.ShardOwner{
-		NodeID: proto.Uint64(so.NodeID),
+		NodeID:      proto.Uint64(so.NodeID),
+		Quarantined: proto.Bool(so.Quarantined),
+		Tier:        proto.String(so.Tier),
 	}
 }
 
 // unmarshal deserializes from a protobuf representation.
 func (so *ShardOwner) unmarshal(pb *internal.ShardOwner) {
 	so.NodeID = pb.GetNodeID()
+	so.Quarantined = pb.GetQuarantined()
+	so.Tier = pb.GetTier()
 }
 
 // ContinuousQueryInfo represents metadata about a continuous query.
@@ -2349,6 +3469,57 @@ type ClusterInfo struct {
 	Meta []*MetaNodeInfo `json:"meta"`
 }
 
+type ClusterRollupRuleInfo struct {
+	Database        string `json:"database"`
+	RetentionPolicy string `json:"retention-policy"`
+	Name            string `json:"name"`
+	After           string `json:"after"`
+	Interval        string `json:"interval"`
+}
+
+type ClusterDiskQuotaInfo struct {
+	Database        string                 `json:"database"`
+	RetentionPolicy string                 `json:"retention-policy,omitempty"`
+	SoftLimit       int64                  `json:"soft-limit"`
+	HardLimit       int64                  `json:"hard-limit"`
+	Usage           []ClusterDiskUsageInfo `json:"usage,omitempty"`
+}
+
+type ClusterDiskUsageInfo struct {
+	NodeID  uint64 `json:"node-id"`
+	TCPAddr string `json:"tcpAddr"`
+	Bytes   int64  `json:"bytes"`
+}
+
+type ClusterCardinalityLimitsInfo struct {
+	Database                string           `json:"database"`
+	MaxSeriesPerMeasurement int64            `json:"max-series-per-measurement"`
+	MaxValuesPerTag         int64            `json:"max-values-per-tag"`
+	Measurements            map[string]int64 `json:"measurements,omitempty"`
+	TagKeys                 map[string]int64 `json:"tag-keys,omitempty"`
+}
+
+type ClusterReshardInfo struct {
+	Database        string    `json:"database"`
+	RetentionPolicy string    `json:"retention-policy"`
+	Duration        string    `json:"duration"`
+	ShardGroup      uint64    `json:"shard-group"`
+	StartTime       time.Time `json:"start-time"`
+	EndTime         time.Time `json:"end-time"`
+	Sources         []uint64  `json:"sources"`
+	Copied          int       `json:"copied"`
+	Copies          int       `json:"copies"`
+}
+
+type ClusterMeasurementSchemaInfo struct {
+	Database     string   `json:"database"`
+	Name         string   `json:"name"`
+	Fields       []string `json:"fields"`
+	RequiredTags []string `json:"required-tags"`
+	AllowedTags  []string `json:"allowed-tags"`
+	Mode         string   `json:"mode"`
+}
+
 type ClusterShardInfo struct {
 	ID              uint64            `json:"id"`
 	Database        string            `json:"database"`
@@ -2359,6 +3530,7 @@ type ClusterShardInfo struct {
 	EndTime         time.Time         `json:"end-time"`
 	ExpireTime      time.Time         `json:"expire-time"`
 	TruncatedAt     time.Time         `json:"truncated-at"`
+	Leader          uint64            `json:"leader,omitempty"`
 	Owners          []*ShardOwnerInfo `json:"owners"`
 }
 
@@ -2369,6 +3541,43 @@ type ShardOwnerInfo struct {
 	LastModified time.Time `json:"last-modified"`
 	Size         int64     `json:"size"`
 	Err          string    `json:"err"`
+	Quarantined  bool      `json:"quarantined"`
+	LastVerified time.Time `json:"last-verified"`
+	VerifyErr    string    `json:"verify-err"`
+	Tier         string    `json:"tier"`
+
+	IndexRebuild       string `json:"index-rebuild"`
+	IndexRebuildSeries int64  `json:"index-rebuild-series"`
+	IndexRebuildErr    string `json:"index-rebuild-err"`
+}
+
+// CompactionInfo describes a running or planned compaction of a shard on a
+// data node.
+type CompactionInfo struct {
+	NodeID    uint64    `json:"node-id"`
+	TCPAddr   string    `json:"tcpAddr"`
+	ShardID   uint64    `json:"shard-id"`
+	Level     int       `json:"level"`
+	Strategy  string    `json:"strategy"`
+	State     string    `json:"state"`
+	Files     int       `json:"files"`
+	Bytes     int64     `json:"bytes"`
+	Progress  float64   `json:"progress"`
+	StartedAt time.Time `json:"started-at"`
+}
+
+// ReplicaInfo describes the replication of a shard on a data node from the
+// WAL of the shard's leader.
+type ReplicaInfo struct {
+	NodeID          uint64    `json:"node-id"`
+	TCPAddr         string    `json:"tcpAddr"`
+	ShardID         uint64    `json:"shard-id"`
+	Database        string    `json:"database"`
+	RetentionPolicy string    `json:"retention-policy"`
+	Leader          uint64    `json:"leader"`
+	Position        string    `json:"position"`
+	LagBytes        int64     `json:"lag-bytes"`
+	LastContact     time.Time `json:"last-contact"`
 }
 
 type UserPrivilege struct {
@@ -401,6 +401,440 @@ func TestData_TruncateShardGroups(t *testing.T) {
 	}
 }
 
+func TestData_SetShardOwnerQuarantine(t *testing.T) {
+	data := &meta.Data{}
+
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDataNode("foo:8086", "foo:8088"))
+	must(data.CreateDataNode("bar:8086", "bar:8088"))
+	must(data.CreateDatabase("db"))
+	rp := meta.NewRetentionPolicyInfo("rp")
+	rp.ReplicaN = 2
+	must(data.CreateRetentionPolicy("db", rp, true))
+	must(data.CreateShardGroup("db", "rp", time.Unix(0, 0)))
+
+	sg, err := data.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0))
+	if err != nil {
+		t.Fatal("Failed to find shard group:", err)
+	}
+	si := sg.Shards[0]
+	nodeID := si.Owners[0].NodeID
+
+	data.SetShardOwnerQuarantine(si.ID, nodeID, true)
+
+	// Round trip through protobuf to ensure the flag is persisted.
+	buf, err := data.MarshalBinary()
+	must(err)
+	other := &meta.Data{}
+	must(other.UnmarshalBinary(buf))
+
+	sg, err = other.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0))
+	if err != nil {
+		t.Fatal("Failed to find shard group:", err)
+	}
+	si = sg.Shards[0]
+	if !si.QuarantinedBy(nodeID) {
+		t.Fatal("expected owner to be quarantined")
+	} else if owners := si.HealthyOwners(); len(owners) != 1 || owners[0].NodeID == nodeID {
+		t.Fatalf("unexpected healthy owners: %v", owners)
+	}
+
+	other.SetShardOwnerQuarantine(si.ID, nodeID, false)
+	sg, _ = other.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0))
+	if sg.Shards[0].QuarantinedBy(nodeID) {
+		t.Fatal("expected quarantine to be cleared")
+	}
+}
+
+func TestData_SetShardOwnerTier(t *testing.T) {
+	data := &meta.Data{}
+
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDataNode("foo:8086", "foo:8088"))
+	must(data.CreateDatabase("db"))
+	must(data.CreateRetentionPolicy("db", meta.NewRetentionPolicyInfo("rp"), true))
+	must(data.CreateShardGroup("db", "rp", time.Unix(0, 0)))
+
+	sg, err := data.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0))
+	if err != nil {
+		t.Fatal("Failed to find shard group:", err)
+	}
+	si := sg.Shards[0]
+	nodeID := si.Owners[0].NodeID
+
+	data.SetShardOwnerTier(si.ID, nodeID, "cold")
+
+	// Round trip through protobuf to ensure the tier is persisted.
+	buf, err := data.MarshalBinary()
+	must(err)
+	other := &meta.Data{}
+	must(other.UnmarshalBinary(buf))
+
+	sg, err = other.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0))
+	if err != nil {
+		t.Fatal("Failed to find shard group:", err)
+	}
+	if tier := sg.Shards[0].TierOf(nodeID); tier != "cold" {
+		t.Fatalf("unexpected tier: %q", tier)
+	}
+}
+
+func TestData_RollupRules(t *testing.T) {
+	data := &meta.Data{}
+
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDatabase("db"))
+	must(data.CreateRetentionPolicy("db", meta.NewRetentionPolicyInfo("rp"), true))
+
+	if err := data.CreateRollupRule("db", "rp", "r", 0, time.Minute); err != meta.ErrRollupRuleAfterRequired {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.CreateRollupRule("db", "rp", "r", time.Hour, 0); err != meta.ErrRollupRuleIntervalRequired {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.CreateRollupRule("db", "nope", "r", time.Hour, time.Minute); err == nil {
+		t.Fatal("expected error for missing retention policy")
+	}
+
+	must(data.CreateRollupRule("db", "rp", "5m", 7*24*time.Hour, 5*time.Minute))
+	must(data.CreateRollupRule("db", "rp", "1h", 30*24*time.Hour, time.Hour))
+	if err := data.CreateRollupRule("db", "rp", "5m", time.Hour, time.Minute); err != meta.ErrRollupRuleExists {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Round trip through protobuf to ensure the rules are persisted.
+	buf, err := data.MarshalBinary()
+	must(err)
+	other := &meta.Data{}
+	must(other.UnmarshalBinary(buf))
+
+	rpi, err := other.RetentionPolicy("db", "rp")
+	must(err)
+	exp := []meta.RollupRuleInfo{
+		{Name: "5m", After: 7 * 24 * time.Hour, Interval: 5 * time.Minute},
+		{Name: "1h", After: 30 * 24 * time.Hour, Interval: time.Hour},
+	}
+	if !reflect.DeepEqual(rpi.RollupRules, exp) {
+		t.Fatalf("unexpected rules: %+v", rpi.RollupRules)
+	}
+
+	// Dropping from a clone must not affect the original.
+	clone := other.Clone()
+	must(clone.DropRollupRule("db", "rp", "5m"))
+	if err := clone.DropRollupRule("db", "rp", "5m"); err != meta.ErrRollupRuleNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rpi, _ = clone.RetentionPolicy("db", "rp")
+	if len(rpi.RollupRules) != 1 || rpi.RollupRules[0].Name != "1h" {
+		t.Fatalf("unexpected rules after drop: %+v", rpi.RollupRules)
+	}
+	rpi, _ = other.RetentionPolicy("db", "rp")
+	if !reflect.DeepEqual(rpi.RollupRules, exp) {
+		t.Fatalf("original rules modified: %+v", rpi.RollupRules)
+	}
+}
+
+func TestData_MeasurementSchemas(t *testing.T) {
+	data := &meta.Data{}
+
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDatabase("db"))
+
+	if err := data.CreateMeasurementSchema("db", &meta.MeasurementSchemaInfo{}); err != meta.ErrMeasurementSchemaNameRequired {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.CreateMeasurementSchema("db", &meta.MeasurementSchemaInfo{Name: "cpu", Mode: "lenient"}); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+	if err := data.CreateMeasurementSchema("db", &meta.MeasurementSchemaInfo{Name: "cpu", Fields: []meta.FieldSchemaInfo{{Name: "v", Type: influxql.Time}}}); err == nil {
+		t.Fatal("expected error for unsupported field type")
+	}
+	if err := data.CreateMeasurementSchema("nope", &meta.MeasurementSchemaInfo{Name: "cpu"}); err == nil {
+		t.Fatal("expected error for missing database")
+	}
+
+	fields, err := meta.ParseFieldSchemas("value:float, count:integer")
+	must(err)
+	must(data.CreateMeasurementSchema("db", &meta.MeasurementSchemaInfo{
+		Name:         "cpu",
+		Fields:       fields,
+		RequiredTags: []string{"host"},
+		AllowedTags:  []string{"region"},
+	}))
+	must(data.CreateMeasurementSchema("db", &meta.MeasurementSchemaInfo{Name: "mem", Mode: meta.MeasurementSchemaModeCoerce}))
+	if err := data.CreateMeasurementSchema("db", &meta.MeasurementSchemaInfo{Name: "cpu"}); err != meta.ErrMeasurementSchemaExists {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Round trip through protobuf to ensure the schemas are persisted.
+	buf, err := data.MarshalBinary()
+	must(err)
+	other := &meta.Data{}
+	must(other.UnmarshalBinary(buf))
+
+	exp := []meta.MeasurementSchemaInfo{
+		{
+			Name:         "cpu",
+			Fields:       []meta.FieldSchemaInfo{{Name: "value", Type: influxql.Float}, {Name: "count", Type: influxql.Integer}},
+			RequiredTags: []string{"host"},
+			AllowedTags:  []string{"region"},
+			Mode:         meta.MeasurementSchemaModeStrict,
+		},
+		{Name: "mem", Mode: meta.MeasurementSchemaModeCoerce},
+	}
+	if got := other.Database("db").MeasurementSchemas; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected schemas: %+v", got)
+	}
+
+	si := other.Database("db").MeasurementSchema("cpu")
+	if !si.TagAllowed("host") || !si.TagAllowed("region") || si.TagAllowed("dc") {
+		t.Fatal("unexpected allowed tags")
+	}
+
+	// Dropping from a clone must not affect the original.
+	clone := other.Clone()
+	must(clone.DropMeasurementSchema("db", "cpu"))
+	if err := clone.DropMeasurementSchema("db", "cpu"); err != meta.ErrMeasurementSchemaNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.Database("db").MeasurementSchema("cpu") != nil {
+		t.Fatal("expected schema to be dropped")
+	}
+	if got := other.Database("db").MeasurementSchemas; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("original schemas modified: %+v", got)
+	}
+}
+
+func TestParseFieldSchemas(t *testing.T) {
+	for _, s := range []string{"value", "value:number", "value:float,"} {
+		if _, err := meta.ParseFieldSchemas(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
+
+func TestData_SetCardinalityLimits(t *testing.T) {
+	data := &meta.Data{}
+	if err := data.CreateDatabase("db"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetCardinalityLimits("nope", &meta.CardinalityLimitsInfo{MaxValuesPerTag: 10}); err == nil {
+		t.Fatal("expected error for missing database")
+	}
+	if err := data.SetCardinalityLimits("db", &meta.CardinalityLimitsInfo{TagKeys: map[string]int64{"": 10}}); err == nil {
+		t.Fatal("expected error for empty tag key")
+	}
+
+	measurements, err := meta.ParseCardinalityLimits("cpu=10000, mem=-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &meta.CardinalityLimitsInfo{
+		MaxSeriesPerMeasurement: 1000,
+		Measurements:            measurements,
+		TagKeys:                 map[string]int64{"pod_id": 100},
+	}
+	if err := data.SetCardinalityLimits("db", exp); err != nil {
+		t.Fatal(err)
+	}
+
+	// Round trip through protobuf to ensure the limits are persisted.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &meta.Data{}
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := other.Database("db").CardinalityLimits; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected limits: %+v", got)
+	} else if s := meta.FormatCardinalityLimits(got.Measurements); s != "cpu=10000,mem=-1" {
+		t.Fatalf("unexpected format: %s", s)
+	}
+
+	// Empty limits remove the overrides.
+	if err := other.SetCardinalityLimits("db", &meta.CardinalityLimitsInfo{}); err != nil {
+		t.Fatal(err)
+	} else if other.Database("db").CardinalityLimits != nil {
+		t.Fatal("expected limits to be removed")
+	}
+
+	for _, s := range []string{"cpu", "cpu=many"} {
+		if _, err := meta.ParseCardinalityLimits(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
+
+func TestData_SetDiskQuota(t *testing.T) {
+	data := &meta.Data{}
+	if err := data.CreateDatabase("db"); err != nil {
+		t.Fatal(err)
+	}
+	if err := data.CreateRetentionPolicy("db", &meta.RetentionPolicyInfo{Name: "rp", ReplicaN: 1}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetDiskQuota("nope", "", &meta.DiskQuotaInfo{HardLimit: 10}); err == nil {
+		t.Fatal("expected error for missing database")
+	} else if err := data.SetDiskQuota("db", "nope", &meta.DiskQuotaInfo{HardLimit: 10}); err == nil {
+		t.Fatal("expected error for missing retention policy")
+	} else if err := data.SetDiskQuota("db", "", &meta.DiskQuotaInfo{SoftLimit: 20, HardLimit: 10}); err == nil {
+		t.Fatal("expected error for soft limit above hard limit")
+	} else if err := data.SetDiskQuota("db", "", &meta.DiskQuotaInfo{HardLimit: -1}); err == nil {
+		t.Fatal("expected error for negative limit")
+	}
+
+	dbQuota := &meta.DiskQuotaInfo{SoftLimit: 1 << 30, HardLimit: 2 << 30}
+	rpQuota := &meta.DiskQuotaInfo{HardLimit: 1 << 20}
+	if err := data.SetDiskQuota("db", "", dbQuota); err != nil {
+		t.Fatal(err)
+	} else if err := data.SetDiskQuota("db", "rp", rpQuota); err != nil {
+		t.Fatal(err)
+	}
+
+	// Round trip through protobuf to ensure the quotas are persisted.
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &meta.Data{}
+	if err := other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := other.Database("db").DiskQuota; !reflect.DeepEqual(got, dbQuota) {
+		t.Fatalf("unexpected database quota: %+v", got)
+	}
+	if rpi, _ := other.RetentionPolicy("db", "rp"); !reflect.DeepEqual(rpi.DiskQuota, rpQuota) {
+		t.Fatalf("unexpected retention policy quota: %+v", rpi.DiskQuota)
+	}
+
+	// Empty quotas remove the quota.
+	if err := other.SetDiskQuota("db", "rp", &meta.DiskQuotaInfo{}); err != nil {
+		t.Fatal(err)
+	} else if rpi, _ := other.RetentionPolicy("db", "rp"); rpi.DiskQuota != nil {
+		t.Fatal("expected retention policy quota to be removed")
+	} else if other.Database("db").DiskQuota == nil {
+		t.Fatal("expected database quota to be kept")
+	}
+}
+
+func TestData_Reshard(t *testing.T) {
+	data := &meta.Data{}
+
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDataNode("foo:8086", "foo:8088"))
+	must(data.CreateDataNode("bar:8086", "bar:8088"))
+	must(data.CreateDatabase("db"))
+	rp := meta.NewRetentionPolicyInfo("rp")
+	rp.ShardGroupDuration = time.Hour
+	must(data.CreateRetentionPolicy("db", rp, true))
+	for i := 0; i < 3; i++ {
+		must(data.CreateShardGroup("db", "rp", time.Unix(0, 0).Add(time.Duration(i)*time.Hour)))
+	}
+
+	if err := data.CreateReshard("db", "rp", 0, time.Unix(0, 0)); err != meta.ErrReshardDurationRequired {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.CreateReshard("db", "rp", 90*time.Minute, time.Unix(0, 0).Add(4*time.Hour)); err != meta.ErrReshardUnaligned {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The groups of [0h, 2h) are merged and the group of [2h, 3h) is
+	// extended to [2h, 4h).
+	must(data.CreateReshard("db", "rp", 2*time.Hour, time.Unix(0, 0).Add(4*time.Hour)))
+	if err := data.CreateReshard("db", "rp", 2*time.Hour, time.Unix(0, 0).Add(4*time.Hour)); err != meta.ErrReshardExists {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.CreateShardGroup("db", "rp", time.Unix(0, 0).Add(3*time.Hour)); err != meta.ErrShardGroupResharding {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Round trip through protobuf to ensure the resharding is persisted.
+	buf, err := data.MarshalBinary()
+	must(err)
+	other := &meta.Data{}
+	must(other.UnmarshalBinary(buf))
+
+	rpi, err := other.RetentionPolicy("db", "rp")
+	must(err)
+	if rpi.ShardGroupDuration != 2*time.Hour {
+		t.Fatalf("unexpected shard group duration: %s", rpi.ShardGroupDuration)
+	} else if rpi.Reshard == nil || len(rpi.Reshard.ShardGroups) != 2 {
+		t.Fatalf("unexpected reshard: %+v", rpi.Reshard)
+	}
+	sgi := rpi.Reshard.ShardGroups[0]
+	if !sgi.StartTime.Equal(time.Unix(0, 0)) || !sgi.EndTime.Equal(time.Unix(0, 0).Add(2*time.Hour)) {
+		t.Fatalf("unexpected shard group range: %s - %s", sgi.StartTime, sgi.EndTime)
+	} else if len(sgi.Shards) != 2 {
+		t.Fatalf("unexpected shard count: %d", len(sgi.Shards))
+	} else if srcs := rpi.ReshardSources(&sgi); len(srcs) != 2 {
+		t.Fatalf("unexpected sources: %d", len(srcs))
+	}
+
+	// The group replaces the old groups once every owner copied it.
+	must(other.SetReshardCopied("db", "rp", sgi.Shards[0].ID, sgi.Shards[0].Owners[0].NodeID, time.Unix(0, 0).Add(5*time.Hour)))
+	if g, _ := other.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0)); g == nil || g.ID == sgi.ID {
+		t.Fatal("expected old shard group before every shard is copied")
+	}
+	must(other.SetReshardCopied("db", "rp", sgi.Shards[1].ID, sgi.Shards[1].Owners[0].NodeID, time.Unix(0, 0).Add(5*time.Hour)))
+	for _, ts := range []time.Duration{0, 90 * time.Minute} {
+		if g, _ := other.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0).Add(ts)); g == nil || g.ID != sgi.ID {
+			t.Fatalf("expected resharded group at %s, got %+v", ts, g)
+		}
+	}
+	rpi, _ = other.RetentionPolicy("db", "rp")
+	if n := len(rpi.DeletedShardGroups()); n != 2 {
+		t.Fatalf("unexpected deleted shard groups: %d", n)
+	} else if len(rpi.Reshard.ShardGroups) != 1 || len(rpi.Reshard.Copies) != 0 {
+		t.Fatalf("unexpected reshard: %+v", rpi.Reshard)
+	}
+	if err := other.SetReshardCopied("db", "rp", sgi.Shards[0].ID, 1, time.Unix(0, 0)); err != meta.ErrReshardShardNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Dropping the resharding deletes the groups not yet copied.
+	must(other.DropReshard("db", "rp", time.Unix(0, 0).Add(5*time.Hour)))
+	if err := other.DropReshard("db", "rp", time.Unix(0, 0)); err != meta.ErrReshardNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rpi, _ = other.RetentionPolicy("db", "rp")
+	if rpi.Reshard != nil {
+		t.Fatalf("unexpected reshard: %+v", rpi.Reshard)
+	} else if n := len(rpi.DeletedShardGroups()); n != 3 {
+		t.Fatalf("unexpected deleted shard groups: %d", n)
+	} else if g, _ := other.ShardGroupByTimestamp("db", "rp", time.Unix(0, 0).Add(2*time.Hour)); g == nil || !g.EndTime.Equal(time.Unix(0, 0).Add(3*time.Hour)) {
+		t.Fatalf("expected old shard group after drop, got %+v", g)
+	}
+}
+
 func TestUserInfo_AuthorizeDatabase(t *testing.T) {
 	emptyUser := &meta.UserInfo{}
 	if !emptyUser.AuthorizeDatabase(influxql.NoPrivileges, "anydb") {
@@ -448,3 +882,71 @@ func randString(n int) string {
 	}
 	return string(b)
 }
+
+func TestData_WALReplication(t *testing.T) {
+	data := &meta.Data{}
+
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDataNode("foo:8086", "foo:8088"))
+	must(data.CreateDataNode("bar:8086", "bar:8088"))
+	must(data.CreateDataNode("baz:8086", "baz:8088"))
+	must(data.CreateDatabase("db"))
+	rp := meta.NewRetentionPolicyInfo("rp")
+	rp.ReplicaN = 2
+	must(data.CreateRetentionPolicy("db", rp, true))
+	must(data.CreateShardGroup("db", "rp", time.Unix(0, 0)))
+
+	if err := data.SetRetentionPolicyReplication("db", "rp", "raft"); err == nil {
+		t.Fatal("expected error setting an invalid replication mode")
+	}
+	must(data.SetRetentionPolicyReplication("db", "rp", meta.ReplicationModeWAL))
+
+	rpi, err := data.RetentionPolicy("db", "rp")
+	must(err)
+	si := rpi.ShardGroups[0].Shards[0]
+	first, second := si.Owners[0].NodeID, si.Owners[1].NodeID
+	if got := si.LeaderID(); got != first {
+		t.Fatalf("unexpected default leader: got %d, exp %d", got, first)
+	}
+
+	var other uint64
+	for _, n := range data.DataNodes {
+		if !si.OwnedBy(n.ID) {
+			other = n.ID
+		}
+	}
+	if err := data.SetShardLeader(si.ID, other, 0); err != meta.ErrShardLeaderNotOwner {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := data.SetShardLeader(si.ID+100, first, 0); err != meta.ErrShardNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Failing over a leader that already changed is rejected.
+	must(data.SetShardLeader(si.ID, second, first))
+	if err := data.SetShardLeader(si.ID, first, first); err != meta.ErrShardLeaderChanged {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Round trip through protobuf to ensure the mode and leader are persisted.
+	buf, err := data.MarshalBinary()
+	must(err)
+	got := &meta.Data{}
+	must(got.UnmarshalBinary(buf))
+	rpi, err = got.RetentionPolicy("db", "rp")
+	must(err)
+	if !rpi.WALReplicated() {
+		t.Fatalf("unexpected replication mode: %q", rpi.ReplicationMode)
+	} else if leader := rpi.ShardGroups[0].Shards[0].LeaderID(); leader != second {
+		t.Fatalf("unexpected leader: got %d, exp %d", leader, second)
+	}
+
+	must(got.SetRetentionPolicyReplication("db", "rp", meta.ReplicationModeFanout))
+	if rpi, _ = got.RetentionPolicy("db", "rp"); rpi.WALReplicated() || rpi.ReplicationMode != "" {
+		t.Fatalf("unexpected replication mode: %q", rpi.ReplicationMode)
+	}
+}
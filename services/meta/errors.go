@@ -100,11 +100,32 @@ var (
 	// ErrShardGroupNotFound is returned when mutating a shard group that doesn't exist.
 	ErrShardGroupNotFound = errors.New("shard group not found")
 
+	// ErrShardGroupResharding is returned when creating a shard group where
+	// a shard group is being resharded.
+	ErrShardGroupResharding = errors.New("shard group is being resharded")
+
 	// ErrShardNotReplicated is returned if the node requested to be dropped has
 	// the last copy of a shard present and the force keyword was not used
 	ErrShardNotReplicated = errors.New("shard not replicated")
+
+	// ErrShardNotFound is returned when mutating a shard that doesn't exist.
+	ErrShardNotFound = errors.New("shard not found")
+
+	// ErrShardLeaderNotOwner is returned when making a node which doesn't
+	// own a shard its leader.
+	ErrShardLeaderNotOwner = errors.New("shard leader must be an owner of the shard")
+
+	// ErrShardLeaderChanged is returned when failing over the leader of a
+	// shard whose leader is no longer the expected one.
+	ErrShardLeaderChanged = errors.New("shard leader changed")
 )
 
+// ErrInvalidReplicationMode is returned when setting an unknown replication
+// mode on a retention policy.
+func ErrInvalidReplicationMode(mode string) error {
+	return fmt.Errorf("invalid replication mode %q: expected %q or %q", mode, ReplicationModeFanout, ReplicationModeWAL)
+}
+
 var (
 	// ErrContinuousQueryExists is returned when creating an already existing continuous query.
 	ErrContinuousQueryExists = errors.New("continuous query already exists")
@@ -121,6 +142,74 @@ var (
 	ErrSubscriptionNotFound = errors.New("subscription not found")
 )
 
+var (
+	// ErrRollupRuleExists is returned when creating an already existing rollup rule.
+	ErrRollupRuleExists = errors.New("rollup rule already exists")
+
+	// ErrRollupRuleNotFound is returned when removing a rollup rule that doesn't exist.
+	ErrRollupRuleNotFound = errors.New("rollup rule not found")
+
+	// ErrRollupRuleAfterRequired is returned when creating a rollup rule
+	// without a positive age.
+	ErrRollupRuleAfterRequired = errors.New("rollup rule age must be greater than zero")
+
+	// ErrRollupRuleIntervalRequired is returned when creating a rollup rule
+	// without a positive interval.
+	ErrRollupRuleIntervalRequired = errors.New("rollup rule interval must be greater than zero")
+)
+
+var (
+	// ErrReshardExists is returned when resharding a retention policy which
+	// is already being resharded.
+	ErrReshardExists = errors.New("retention policy is already being resharded")
+
+	// ErrReshardNotFound is returned when mutating a resharding that doesn't exist.
+	ErrReshardNotFound = errors.New("retention policy is not being resharded")
+
+	// ErrReshardDurationRequired is returned when resharding without a
+	// positive shard group duration.
+	ErrReshardDurationRequired = errors.New("reshard duration must be greater than zero")
+
+	// ErrReshardUnaligned is returned when resharding to a duration which
+	// doesn't hold whole shard groups.
+	ErrReshardUnaligned = errors.New("shard groups are not aligned on the reshard duration")
+
+	// ErrReshardShardCount is returned when resharding shard groups which
+	// don't have as many shards.
+	ErrReshardShardCount = errors.New("shard groups to merge have different shard counts")
+
+	// ErrReshardShardNotFound is returned when copying a shard which isn't
+	// being resharded.
+	ErrReshardShardNotFound = errors.New("shard is not being resharded")
+)
+
+var (
+	// ErrMeasurementSchemaExists is returned when creating an already existing measurement schema.
+	ErrMeasurementSchemaExists = errors.New("measurement schema already exists")
+
+	// ErrMeasurementSchemaNotFound is returned when removing a measurement schema that doesn't exist.
+	ErrMeasurementSchemaNotFound = errors.New("measurement schema not found")
+
+	// ErrMeasurementSchemaNameRequired is returned when creating a measurement
+	// schema without a measurement name.
+	ErrMeasurementSchemaNameRequired = errors.New("measurement schema name required")
+)
+
+// ErrInvalidMeasurementSchema is returned when a measurement schema is invalid.
+func ErrInvalidMeasurementSchema(reason string) error {
+	return fmt.Errorf("invalid measurement schema: %s", reason)
+}
+
+// ErrInvalidDiskQuota is returned when a disk quota is invalid.
+func ErrInvalidDiskQuota(reason string) error {
+	return fmt.Errorf("invalid disk quota: %s", reason)
+}
+
+// ErrInvalidCardinalityLimits is returned when cardinality limits are invalid.
+func ErrInvalidCardinalityLimits(reason string) error {
+	return fmt.Errorf("invalid cardinality limits: %s", reason)
+}
+
 // ErrInvalidSubscriptionURL is returned when the subscription's destination URL is invalid.
 func ErrInvalidSubscriptionURL(url string) error {
 	return fmt.Errorf("invalid subscription URL: %s", url)
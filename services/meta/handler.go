@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -48,6 +49,10 @@ type RPCClient interface {
 	JoinCluster(address string, metaServers []string, update bool) (*NodeInfo, error)
 	LeaveCluster(address string) error
 	RemoveHintedHandoff(address string, nodeID uint64) error
+	RebuildIndex(address string, shardIDs []uint64) error
+	ListCompactions(address string) ([]*CompactionInfo, error)
+	CompactShard(address string, shardID uint64, action string) error
+	ReplicationStatus(address string) ([]*ReplicaInfo, error)
 }
 
 // handler represents an HTTP handler for the meta service.
@@ -76,6 +81,16 @@ type handler struct {
 		copyShard(id, nodeID uint64) error
 		removeShard(id, nodeID uint64) error
 		truncateShards(delay time.Duration) error
+		createRollupRule(database, rp, name string, after, interval time.Duration) error
+		dropRollupRule(database, rp, name string) error
+		createMeasurementSchema(database string, schema *MeasurementSchemaInfo) error
+		dropMeasurementSchema(database, name string) error
+		setCardinalityLimits(database string, limits *CardinalityLimitsInfo) error
+		setDiskQuota(database, rp string, quota *DiskQuotaInfo) error
+		createReshard(database, rp string, duration time.Duration) error
+		dropReshard(database, rp string) error
+		setRetentionPolicyReplication(database, rp, mode string) error
+		setShardLeader(id, nodeID uint64) error
 		metaServersHTTP() []string
 		otherMetaServersHTTP() []string
 		dataServers() []string
@@ -91,6 +106,11 @@ type handler struct {
 		cluster() *ClusterInfo
 		shards() []*ClusterShardInfo
 		shard(id uint64) *ClusterShardInfo
+		rollupRules() []*ClusterRollupRuleInfo
+		measurementSchemas() []*ClusterMeasurementSchemaInfo
+		cardinalityLimits() []*ClusterCardinalityLimitsInfo
+		diskQuotas() []*ClusterDiskQuotaInfo
+		reshards() []*ClusterReshardInfo
 	}
 	s *Service
 
@@ -176,6 +196,20 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			h.WrapHandler("show-cluster", h.serveShowCluster).ServeHTTP(w, r)
 		case "/show-shards":
 			h.WrapHandler("show-shards", h.serveShowShards).ServeHTTP(w, r)
+		case "/show-rollup-rules":
+			h.WrapHandler("show-rollup-rules", h.serveShowRollupRules).ServeHTTP(w, r)
+		case "/show-measurement-schemas":
+			h.WrapHandler("show-measurement-schemas", h.serveShowMeasurementSchemas).ServeHTTP(w, r)
+		case "/show-cardinality-limits":
+			h.WrapHandler("show-cardinality-limits", h.serveShowCardinalityLimits).ServeHTTP(w, r)
+		case "/show-disk-quotas":
+			h.WrapHandler("show-disk-quotas", h.serveShowDiskQuotas).ServeHTTP(w, r)
+		case "/show-reshards":
+			h.WrapHandler("show-reshards", h.serveShowReshards).ServeHTTP(w, r)
+		case "/show-compactions":
+			h.WrapHandler("show-compactions", h.serveShowCompactions).ServeHTTP(w, r)
+		case "/show-replication":
+			h.WrapHandler("show-replication", h.serveShowReplication).ServeHTTP(w, r)
 		case "/user":
 			h.WrapHandler("user", h.serveUser).ServeHTTP(w, r)
 		case "/role":
@@ -209,6 +243,30 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			h.WrapHandler("remove-shard", h.serveRemoveShard).ServeHTTP(w, r)
 		case "/truncate-shards":
 			h.WrapHandler("truncate-shards", h.serveTruncateShards).ServeHTTP(w, r)
+		case "/rebuild-index":
+			h.WrapHandler("rebuild-index", h.serveRebuildIndex).ServeHTTP(w, r)
+		case "/compact":
+			h.WrapHandler("compact", h.serveCompact).ServeHTTP(w, r)
+		case "/create-rollup-rule":
+			h.WrapHandler("create-rollup-rule", h.serveCreateRollupRule).ServeHTTP(w, r)
+		case "/drop-rollup-rule":
+			h.WrapHandler("drop-rollup-rule", h.serveDropRollupRule).ServeHTTP(w, r)
+		case "/create-measurement-schema":
+			h.WrapHandler("create-measurement-schema", h.serveCreateMeasurementSchema).ServeHTTP(w, r)
+		case "/drop-measurement-schema":
+			h.WrapHandler("drop-measurement-schema", h.serveDropMeasurementSchema).ServeHTTP(w, r)
+		case "/set-cardinality-limits":
+			h.WrapHandler("set-cardinality-limits", h.serveSetCardinalityLimits).ServeHTTP(w, r)
+		case "/set-disk-quota":
+			h.WrapHandler("set-disk-quota", h.serveSetDiskQuota).ServeHTTP(w, r)
+		case "/reshard":
+			h.WrapHandler("reshard", h.serveReshard).ServeHTTP(w, r)
+		case "/drop-reshard":
+			h.WrapHandler("drop-reshard", h.serveDropReshard).ServeHTTP(w, r)
+		case "/set-replication":
+			h.WrapHandler("set-replication", h.serveSetReplication).ServeHTTP(w, r)
+		case "/set-shard-leader":
+			h.WrapHandler("set-shard-leader", h.serveSetShardLeader).ServeHTTP(w, r)
 		case "/announce":
 			h.WrapHandler("announce", h.serveAnnounce).ServeHTTP(w, r)
 		case "/user":
@@ -769,6 +827,11 @@ func (h *handler) serveShowShards(w http.ResponseWriter, r *http.Request) {
 								oi.LastModified = owner.LastModified
 								oi.Size = owner.Size
 								oi.Err = owner.Err
+								oi.LastVerified = owner.LastVerified
+								oi.VerifyErr = owner.VerifyErr
+								oi.IndexRebuild = owner.IndexRebuild
+								oi.IndexRebuildSeries = owner.IndexRebuildSeries
+								oi.IndexRebuildErr = owner.IndexRebuildErr
 								break
 							}
 						}
@@ -841,11 +904,16 @@ func (h *handler) serveCopyShard(w http.ResponseWriter, r *http.Request) {
 		h.httpError(w, fmt.Sprintf("shard not found for id: %d", shardID), http.StatusBadRequest)
 		return
 	}
-	isOwner := false
+	isOwner, repair := false, false
 	for _, owner := range si.Owners {
 		if owner.ID == srcNode.ID {
+			if owner.Quarantined {
+				h.httpError(w, fmt.Sprintf("\"%s\" copy of shard %d is quarantined", src, shardID), http.StatusBadRequest)
+				return
+			}
 			isOwner = true
-			break
+		} else if owner.ID == destNode.ID && owner.Quarantined {
+			repair = true
 		}
 	}
 	if !isOwner {
@@ -865,6 +933,15 @@ func (h *handler) serveCopyShard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Repairing a quarantined copy: drop the corrupt data before copying
+	// rather than restoring on top of it.
+	if repair {
+		if err := h.rpcClient.RemoveShard(dest, shardID); err != nil {
+			h.httpError(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	err = h.rpcClient.CopyShard(dest, src, si.Database, si.RetentionPolicy, shardID, time.Time{})
 	if err != nil {
 		h.httpError(w, err.Error(), http.StatusInternalServerError)
@@ -942,6 +1019,166 @@ func (h *handler) serveRemoveShard(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// serveRebuildIndex starts an online index rebuild of a shard, or of every
+// shard in a database, on each data node that owns it.
+func (h *handler) serveRebuildIndex(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	shard, db := r.FormValue("shard"), r.FormValue("db")
+	if (shard == "") == (db == "") {
+		h.httpError(w, "exactly one of 'shard' or 'db' is required", http.StatusBadRequest)
+		return
+	}
+
+	var shardInfos []*ClusterShardInfo
+	if shard != "" {
+		shardID, err := strconv.ParseUint(shard, 10, 64)
+		if err != nil {
+			h.httpError(w, fmt.Sprintf("error converting shard to int: %s", shard), http.StatusBadRequest)
+			return
+		}
+		si := h.store.shard(shardID)
+		if si == nil {
+			h.httpError(w, fmt.Sprintf("shard not found for id: %d", shardID), http.StatusBadRequest)
+			return
+		}
+		shardInfos = append(shardInfos, si)
+	} else {
+		for _, si := range h.store.shards() {
+			if si.Database == db {
+				shardInfos = append(shardInfos, si)
+			}
+		}
+		if len(shardInfos) == 0 {
+			h.httpError(w, fmt.Sprintf("no shards found for database: %s", db), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Group the shards by the data nodes that own them.
+	var addrs []string
+	shardIDs := make(map[string][]uint64)
+	for _, si := range shardInfos {
+		for _, oi := range si.Owners {
+			if _, ok := shardIDs[oi.TCPAddr]; !ok {
+				addrs = append(addrs, oi.TCPAddr)
+			}
+			shardIDs[oi.TCPAddr] = append(shardIDs[oi.TCPAddr], si.ID)
+		}
+	}
+
+	var errs []string
+	for _, addr := range addrs {
+		if err := h.rpcClient.RebuildIndex(addr, shardIDs[addr]); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", addr, err))
+		}
+	}
+	if len(errs) > 0 {
+		h.httpError(w, strings.Join(errs, "; "), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveShowCompactions returns the running and planned compactions of the
+// shards on every data node, optionally of a single shard.
+func (h *handler) serveShowCompactions(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	var shardID uint64
+	if shard := r.FormValue("shard"); shard != "" {
+		id, err := strconv.ParseUint(shard, 10, 64)
+		if err != nil {
+			h.httpError(w, fmt.Sprintf("error converting shard to int: %s", shard), http.StatusBadRequest)
+			return
+		}
+		shardID = id
+	}
+
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		compactions = make([]*CompactionInfo, 0)
+	)
+	for _, tcpAddr := range h.store.dataServers() {
+		wg.Add(1)
+		go func(tcpAddr string) {
+			defer wg.Done()
+			infos, err := h.rpcClient.ListCompactions(tcpAddr)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, info := range infos {
+				if shardID == 0 || info.ShardID == shardID {
+					compactions = append(compactions, info)
+				}
+			}
+		}(tcpAddr)
+	}
+	wg.Wait()
+
+	sort.SliceStable(compactions, func(i, j int) bool {
+		if compactions[i].NodeID != compactions[j].NodeID {
+			return compactions[i].NodeID < compactions[j].NodeID
+		}
+		return compactions[i].ShardID < compactions[j].ShardID
+	})
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(compactions); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// serveCompact pauses, resumes or schedules a full compaction of a shard on
+// each data node that owns it.
+func (h *handler) serveCompact(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	shard, action := r.FormValue("shard"), r.FormValue("action")
+	switch action {
+	case "pause", "resume", "full":
+	default:
+		h.httpError(w, "'action' must be one of pause, resume or full", http.StatusBadRequest)
+		return
+	}
+	shardID, err := strconv.ParseUint(shard, 10, 64)
+	if err != nil {
+		h.httpError(w, fmt.Sprintf("error converting shard to int: %s", shard), http.StatusBadRequest)
+		return
+	}
+	si := h.store.shard(shardID)
+	if si == nil {
+		h.httpError(w, fmt.Sprintf("shard not found for id: %d", shardID), http.StatusBadRequest)
+		return
+	}
+
+	var errs []string
+	for _, oi := range si.Owners {
+		if err := h.rpcClient.CompactShard(oi.TCPAddr, shardID, action); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", oi.TCPAddr, err))
+		}
+	}
+	if len(errs) > 0 {
+		h.httpError(w, strings.Join(errs, "; "), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // serveTruncateShards
 func (h *handler) serveTruncateShards(w http.ResponseWriter, r *http.Request) {
 	if h.isClosed() {
@@ -974,6 +1211,558 @@ func (h *handler) serveTruncateShards(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *handler) serveShowRollupRules(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.store.rollupRules()); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) serveCreateRollupRule(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, rp, name := r.FormValue("db"), r.FormValue("rp"), r.FormValue("name")
+	if db == "" || rp == "" || name == "" {
+		h.httpError(w, "db, rp and name are required", http.StatusBadRequest)
+		return
+	}
+	after, err := time.ParseDuration(r.FormValue("after"))
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	interval, err := time.ParseDuration(r.FormValue("interval"))
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.store.createRollupRule(db, rp, name, after, interval)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/create-rollup-rule", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveDropRollupRule(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, rp, name := r.FormValue("db"), r.FormValue("rp"), r.FormValue("name")
+	if db == "" || rp == "" || name == "" {
+		h.httpError(w, "db, rp and name are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.store.dropRollupRule(db, rp, name)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/drop-rollup-rule", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveShowMeasurementSchemas(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.store.measurementSchemas()); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) serveCreateMeasurementSchema(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, name := r.FormValue("db"), r.FormValue("name")
+	if db == "" || name == "" {
+		h.httpError(w, "db and name are required", http.StatusBadRequest)
+		return
+	}
+	fields, err := ParseFieldSchemas(r.FormValue("fields"))
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	schema := &MeasurementSchemaInfo{
+		Name:         name,
+		Fields:       fields,
+		RequiredTags: splitList(r.FormValue("required-tags")),
+		AllowedTags:  splitList(r.FormValue("allowed-tags")),
+		Mode:         r.FormValue("mode"),
+	}
+
+	err = h.store.createMeasurementSchema(db, schema)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/create-measurement-schema", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveDropMeasurementSchema(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, name := r.FormValue("db"), r.FormValue("name")
+	if db == "" || name == "" {
+		h.httpError(w, "db and name are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.store.dropMeasurementSchema(db, name)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/drop-measurement-schema", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveShowReshards(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.store.reshards()); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) serveReshard(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, rp := r.FormValue("db"), r.FormValue("rp")
+	if db == "" || rp == "" {
+		h.httpError(w, "db and rp are required", http.StatusBadRequest)
+		return
+	}
+	duration, err := time.ParseDuration(r.FormValue("duration"))
+	if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.store.createReshard(db, rp, duration)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/reshard", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveDropReshard(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, rp := r.FormValue("db"), r.FormValue("rp")
+	if db == "" || rp == "" {
+		h.httpError(w, "db and rp are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.store.dropReshard(db, rp)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/drop-reshard", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveSetReplication(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db, rp, mode := r.FormValue("db"), r.FormValue("rp"), r.FormValue("mode")
+	if db == "" || rp == "" || mode == "" {
+		h.httpError(w, "db, rp and mode are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.store.setRetentionPolicyReplication(db, rp, mode)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/set-replication", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveSetShardLeader(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	shard := r.FormValue("shard")
+	shardID, err := strconv.ParseUint(shard, 10, 64)
+	if err != nil {
+		h.httpError(w, fmt.Sprintf("error converting shard to int: %s", shard), http.StatusBadRequest)
+		return
+	}
+	node := r.FormValue("node")
+	if node == "" {
+		h.httpError(w, "'node' is a required parameter", http.StatusBadRequest)
+		return
+	}
+	n, err := h.store.dataNodeByTCPAddr(node)
+	if err != nil {
+		h.httpError(w, fmt.Sprintf("unable to find node for \"%s\"", node), http.StatusBadRequest)
+		return
+	}
+
+	err = h.store.setShardLeader(shardID, n.ID)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/set-shard-leader", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveShowReplication returns the replication of the shards on every data
+// node from the WAL of their leaders.
+func (h *handler) serveShowReplication(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		replicas = make([]*ReplicaInfo, 0)
+	)
+	for _, tcpAddr := range h.store.dataServers() {
+		wg.Add(1)
+		go func(tcpAddr string) {
+			defer wg.Done()
+			infos, err := h.rpcClient.ReplicationStatus(tcpAddr)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			replicas = append(replicas, infos...)
+		}(tcpAddr)
+	}
+	wg.Wait()
+
+	for _, ri := range replicas {
+		if si := h.store.shard(ri.ShardID); si != nil {
+			ri.Database, ri.RetentionPolicy = si.Database, si.RetentionPolicy
+		}
+	}
+	sort.SliceStable(replicas, func(i, j int) bool {
+		if replicas[i].ShardID != replicas[j].ShardID {
+			return replicas[i].ShardID < replicas[j].ShardID
+		}
+		return replicas[i].NodeID < replicas[j].NodeID
+	})
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(replicas); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) serveShowCardinalityLimits(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.store.cardinalityLimits()); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) serveSetCardinalityLimits(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db := r.FormValue("db")
+	if db == "" {
+		h.httpError(w, "db is required", http.StatusBadRequest)
+		return
+	}
+
+	limits := &CardinalityLimitsInfo{}
+	for name, v := range map[string]*int64{
+		"max-series-per-measurement": &limits.MaxSeriesPerMeasurement,
+		"max-values-per-tag":         &limits.MaxValuesPerTag,
+	} {
+		if s := r.FormValue(name); s != "" {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				h.httpError(w, fmt.Sprintf("invalid %s: %s", name, s), http.StatusBadRequest)
+				return
+			}
+			*v = n
+		}
+	}
+	var err error
+	if limits.Measurements, err = ParseCardinalityLimits(r.FormValue("measurements")); err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if limits.TagKeys, err = ParseCardinalityLimits(r.FormValue("tag-keys")); err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.store.setCardinalityLimits(db, limits)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/set-cardinality-limits", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) serveShowDiskQuotas(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	quotas := h.store.diskQuotas()
+	if len(quotas) > 0 {
+		// Sum the sizes of the shards of each database and retention policy
+		// reported by every data node.
+		type key struct{ db, rp string }
+		shards := make(map[uint64]key)
+		for _, si := range h.store.shards() {
+			shards[si.ID] = key{si.Database, si.RetentionPolicy}
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, tcpAddr := range h.store.dataServers() {
+			wg.Add(1)
+			go func(tcpAddr string) {
+				defer wg.Done()
+				owners, err := h.rpcClient.ListShards(tcpAddr)
+				if err != nil || len(owners) == 0 {
+					return
+				}
+				var nodeID uint64
+				usages := make(map[key]int64)
+				for id, owner := range owners {
+					nodeID = owner.ID
+					if k, ok := shards[id]; ok {
+						usages[k] += owner.Size
+						usages[key{k.db, ""}] += owner.Size
+					}
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				for _, q := range quotas {
+					q.Usage = append(q.Usage, ClusterDiskUsageInfo{
+						NodeID:  nodeID,
+						TCPAddr: tcpAddr,
+						Bytes:   usages[key{q.Database, q.RetentionPolicy}],
+					})
+				}
+			}(tcpAddr)
+		}
+		wg.Wait()
+
+		for _, q := range quotas {
+			sort.Slice(q.Usage, func(i, j int) bool { return q.Usage[i].NodeID < q.Usage[j].NodeID })
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(quotas); err != nil {
+		h.httpError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *handler) serveSetDiskQuota(w http.ResponseWriter, r *http.Request) {
+	if h.isClosed() {
+		h.httpError(w, "server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	db := r.FormValue("db")
+	if db == "" {
+		h.httpError(w, "db is required", http.StatusBadRequest)
+		return
+	}
+
+	quota := &DiskQuotaInfo{}
+	for name, v := range map[string]*int64{
+		"soft": &quota.SoftLimit,
+		"hard": &quota.HardLimit,
+	} {
+		if s := r.FormValue(name); s != "" {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				h.httpError(w, fmt.Sprintf("invalid %s: %s", name, s), http.StatusBadRequest)
+				return
+			}
+			*v = n
+		}
+	}
+
+	err := h.store.setDiskQuota(db, r.FormValue("rp"), quota)
+	if err == raft.ErrNotLeader {
+		l := h.store.leaderHTTP()
+		if l == "" {
+			// No cluster leader. Client will have to try again later.
+			h.httpError(w, "no leader", http.StatusServiceUnavailable)
+			return
+		}
+		l = fmt.Sprintf("%s://%s/set-disk-quota", h.s.HTTPScheme(), l)
+		http.Redirect(w, r, l, http.StatusTemporaryRedirect)
+		return
+	} else if err != nil {
+		h.httpError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// splitList splits a comma separated list, ignoring empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // serveLease
 func (h *handler) serveLease(w http.ResponseWriter, r *http.Request) {
 	var name, nodeIDStr string
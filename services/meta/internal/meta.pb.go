@@ -23,39 +23,52 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Command_Type int32
 
 const (
-	Command_CreateNodeCommand                Command_Type = 1
-	Command_DeleteNodeCommand                Command_Type = 2
-	Command_CreateDatabaseCommand            Command_Type = 3
-	Command_DropDatabaseCommand              Command_Type = 4
-	Command_CreateRetentionPolicyCommand     Command_Type = 5
-	Command_DropRetentionPolicyCommand       Command_Type = 6
-	Command_SetDefaultRetentionPolicyCommand Command_Type = 7
-	Command_UpdateRetentionPolicyCommand     Command_Type = 8
-	Command_CreateShardGroupCommand          Command_Type = 9
-	Command_DeleteShardGroupCommand          Command_Type = 10
-	Command_CreateContinuousQueryCommand     Command_Type = 11
-	Command_DropContinuousQueryCommand       Command_Type = 12
-	Command_CreateUserCommand                Command_Type = 13
-	Command_DropUserCommand                  Command_Type = 14
-	Command_UpdateUserCommand                Command_Type = 15
-	Command_SetPrivilegeCommand              Command_Type = 16
-	Command_SetDataCommand                   Command_Type = 17
-	Command_SetAdminPrivilegeCommand         Command_Type = 18
-	Command_UpdateNodeCommand                Command_Type = 19
-	Command_CreateSubscriptionCommand        Command_Type = 21
-	Command_DropSubscriptionCommand          Command_Type = 22
-	Command_RemovePeerCommand                Command_Type = 23
-	Command_CreateMetaNodeCommand            Command_Type = 24
-	Command_CreateDataNodeCommand            Command_Type = 25
-	Command_UpdateDataNodeCommand            Command_Type = 26
-	Command_DeleteMetaNodeCommand            Command_Type = 27
-	Command_DeleteDataNodeCommand            Command_Type = 28
-	Command_SetMetaNodeCommand               Command_Type = 29
-	Command_DropShardCommand                 Command_Type = 30
-	Command_TruncateShardGroupsCommand       Command_Type = 31
-	Command_PruneShardGroupsCommand          Command_Type = 32
-	Command_CopyShardOwnerCommand            Command_Type = 33
-	Command_RemoveShardOwnerCommand          Command_Type = 34
+	Command_CreateNodeCommand                    Command_Type = 1
+	Command_DeleteNodeCommand                    Command_Type = 2
+	Command_CreateDatabaseCommand                Command_Type = 3
+	Command_DropDatabaseCommand                  Command_Type = 4
+	Command_CreateRetentionPolicyCommand         Command_Type = 5
+	Command_DropRetentionPolicyCommand           Command_Type = 6
+	Command_SetDefaultRetentionPolicyCommand     Command_Type = 7
+	Command_UpdateRetentionPolicyCommand         Command_Type = 8
+	Command_CreateShardGroupCommand              Command_Type = 9
+	Command_DeleteShardGroupCommand              Command_Type = 10
+	Command_CreateContinuousQueryCommand         Command_Type = 11
+	Command_DropContinuousQueryCommand           Command_Type = 12
+	Command_CreateUserCommand                    Command_Type = 13
+	Command_DropUserCommand                      Command_Type = 14
+	Command_UpdateUserCommand                    Command_Type = 15
+	Command_SetPrivilegeCommand                  Command_Type = 16
+	Command_SetDataCommand                       Command_Type = 17
+	Command_SetAdminPrivilegeCommand             Command_Type = 18
+	Command_UpdateNodeCommand                    Command_Type = 19
+	Command_CreateSubscriptionCommand            Command_Type = 21
+	Command_DropSubscriptionCommand              Command_Type = 22
+	Command_RemovePeerCommand                    Command_Type = 23
+	Command_CreateMetaNodeCommand                Command_Type = 24
+	Command_CreateDataNodeCommand                Command_Type = 25
+	Command_UpdateDataNodeCommand                Command_Type = 26
+	Command_DeleteMetaNodeCommand                Command_Type = 27
+	Command_DeleteDataNodeCommand                Command_Type = 28
+	Command_SetMetaNodeCommand                   Command_Type = 29
+	Command_DropShardCommand                     Command_Type = 30
+	Command_TruncateShardGroupsCommand           Command_Type = 31
+	Command_PruneShardGroupsCommand              Command_Type = 32
+	Command_CopyShardOwnerCommand                Command_Type = 33
+	Command_RemoveShardOwnerCommand              Command_Type = 34
+	Command_SetShardOwnerQuarantineCommand       Command_Type = 35
+	Command_SetShardOwnerTierCommand             Command_Type = 36
+	Command_CreateRollupRuleCommand              Command_Type = 37
+	Command_DropRollupRuleCommand                Command_Type = 38
+	Command_CreateMeasurementSchemaCommand       Command_Type = 39
+	Command_DropMeasurementSchemaCommand         Command_Type = 40
+	Command_SetCardinalityLimitsCommand          Command_Type = 41
+	Command_CreateReshardCommand                 Command_Type = 42
+	Command_SetReshardCopiedCommand              Command_Type = 43
+	Command_DropReshardCommand                   Command_Type = 44
+	Command_SetRetentionPolicyReplicationCommand Command_Type = 45
+	Command_SetShardLeaderCommand                Command_Type = 46
+	Command_SetDiskQuotaCommand                  Command_Type = 47
 )
 
 var Command_Type_name = map[int32]string{
@@ -92,42 +105,68 @@ var Command_Type_name = map[int32]string{
 	32: "PruneShardGroupsCommand",
 	33: "CopyShardOwnerCommand",
 	34: "RemoveShardOwnerCommand",
+	35: "SetShardOwnerQuarantineCommand",
+	36: "SetShardOwnerTierCommand",
+	37: "CreateRollupRuleCommand",
+	38: "DropRollupRuleCommand",
+	39: "CreateMeasurementSchemaCommand",
+	40: "DropMeasurementSchemaCommand",
+	41: "SetCardinalityLimitsCommand",
+	42: "CreateReshardCommand",
+	43: "SetReshardCopiedCommand",
+	44: "DropReshardCommand",
+	45: "SetRetentionPolicyReplicationCommand",
+	46: "SetShardLeaderCommand",
+	47: "SetDiskQuotaCommand",
 }
 
 var Command_Type_value = map[string]int32{
-	"CreateNodeCommand":                1,
-	"DeleteNodeCommand":                2,
-	"CreateDatabaseCommand":            3,
-	"DropDatabaseCommand":              4,
-	"CreateRetentionPolicyCommand":     5,
-	"DropRetentionPolicyCommand":       6,
-	"SetDefaultRetentionPolicyCommand": 7,
-	"UpdateRetentionPolicyCommand":     8,
-	"CreateShardGroupCommand":          9,
-	"DeleteShardGroupCommand":          10,
-	"CreateContinuousQueryCommand":     11,
-	"DropContinuousQueryCommand":       12,
-	"CreateUserCommand":                13,
-	"DropUserCommand":                  14,
-	"UpdateUserCommand":                15,
-	"SetPrivilegeCommand":              16,
-	"SetDataCommand":                   17,
-	"SetAdminPrivilegeCommand":         18,
-	"UpdateNodeCommand":                19,
-	"CreateSubscriptionCommand":        21,
-	"DropSubscriptionCommand":          22,
-	"RemovePeerCommand":                23,
-	"CreateMetaNodeCommand":            24,
-	"CreateDataNodeCommand":            25,
-	"UpdateDataNodeCommand":            26,
-	"DeleteMetaNodeCommand":            27,
-	"DeleteDataNodeCommand":            28,
-	"SetMetaNodeCommand":               29,
-	"DropShardCommand":                 30,
-	"TruncateShardGroupsCommand":       31,
-	"PruneShardGroupsCommand":          32,
-	"CopyShardOwnerCommand":            33,
-	"RemoveShardOwnerCommand":          34,
+	"CreateNodeCommand":                    1,
+	"DeleteNodeCommand":                    2,
+	"CreateDatabaseCommand":                3,
+	"DropDatabaseCommand":                  4,
+	"CreateRetentionPolicyCommand":         5,
+	"DropRetentionPolicyCommand":           6,
+	"SetDefaultRetentionPolicyCommand":     7,
+	"UpdateRetentionPolicyCommand":         8,
+	"CreateShardGroupCommand":              9,
+	"DeleteShardGroupCommand":              10,
+	"CreateContinuousQueryCommand":         11,
+	"DropContinuousQueryCommand":           12,
+	"CreateUserCommand":                    13,
+	"DropUserCommand":                      14,
+	"UpdateUserCommand":                    15,
+	"SetPrivilegeCommand":                  16,
+	"SetDataCommand":                       17,
+	"SetAdminPrivilegeCommand":             18,
+	"UpdateNodeCommand":                    19,
+	"CreateSubscriptionCommand":            21,
+	"DropSubscriptionCommand":              22,
+	"RemovePeerCommand":                    23,
+	"CreateMetaNodeCommand":                24,
+	"CreateDataNodeCommand":                25,
+	"UpdateDataNodeCommand":                26,
+	"DeleteMetaNodeCommand":                27,
+	"DeleteDataNodeCommand":                28,
+	"SetMetaNodeCommand":                   29,
+	"DropShardCommand":                     30,
+	"TruncateShardGroupsCommand":           31,
+	"PruneShardGroupsCommand":              32,
+	"CopyShardOwnerCommand":                33,
+	"RemoveShardOwnerCommand":              34,
+	"SetShardOwnerQuarantineCommand":       35,
+	"SetShardOwnerTierCommand":             36,
+	"CreateRollupRuleCommand":              37,
+	"DropRollupRuleCommand":                38,
+	"CreateMeasurementSchemaCommand":       39,
+	"DropMeasurementSchemaCommand":         40,
+	"SetCardinalityLimitsCommand":          41,
+	"CreateReshardCommand":                 42,
+	"SetReshardCopiedCommand":              43,
+	"DropReshardCommand":                   44,
+	"SetRetentionPolicyReplicationCommand": 45,
+	"SetShardLeaderCommand":                46,
+	"SetDiskQuotaCommand":                  47,
 }
 
 func (x Command_Type) Enum() *Command_Type {
@@ -150,7 +189,7 @@ func (x *Command_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (Command_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{12, 0}
+	return fileDescriptor_59b0956366e72083, []int{20, 0}
 }
 
 type Data struct {
@@ -327,13 +366,16 @@ func (m *NodeInfo) GetTCPAddr() string {
 }
 
 type DatabaseInfo struct {
-	Name                   *string                `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	DefaultRetentionPolicy *string                `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
-	RetentionPolicies      []*RetentionPolicyInfo `protobuf:"bytes,3,rep,name=RetentionPolicies" json:"RetentionPolicies,omitempty"`
-	ContinuousQueries      []*ContinuousQueryInfo `protobuf:"bytes,4,rep,name=ContinuousQueries" json:"ContinuousQueries,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{}               `json:"-"`
-	XXX_unrecognized       []byte                 `json:"-"`
-	XXX_sizecache          int32                  `json:"-"`
+	Name                   *string                  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	DefaultRetentionPolicy *string                  `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
+	RetentionPolicies      []*RetentionPolicyInfo   `protobuf:"bytes,3,rep,name=RetentionPolicies" json:"RetentionPolicies,omitempty"`
+	ContinuousQueries      []*ContinuousQueryInfo   `protobuf:"bytes,4,rep,name=ContinuousQueries" json:"ContinuousQueries,omitempty"`
+	MeasurementSchemas     []*MeasurementSchemaInfo `protobuf:"bytes,5,rep,name=MeasurementSchemas" json:"MeasurementSchemas,omitempty"`
+	CardinalityLimits      *CardinalityLimitsInfo   `protobuf:"bytes,6,opt,name=CardinalityLimits" json:"CardinalityLimits,omitempty"`
+	DiskQuota              *DiskQuotaInfo           `protobuf:"bytes,7,opt,name=DiskQuota" json:"DiskQuota,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
+	XXX_unrecognized       []byte                   `json:"-"`
+	XXX_sizecache          int32                    `json:"-"`
 }
 
 func (m *DatabaseInfo) Reset()         { *m = DatabaseInfo{} }
@@ -388,6 +430,27 @@ func (m *DatabaseInfo) GetContinuousQueries() []*ContinuousQueryInfo {
 	return nil
 }
 
+func (m *DatabaseInfo) GetMeasurementSchemas() []*MeasurementSchemaInfo {
+	if m != nil {
+		return m.MeasurementSchemas
+	}
+	return nil
+}
+
+func (m *DatabaseInfo) GetCardinalityLimits() *CardinalityLimitsInfo {
+	if m != nil {
+		return m.CardinalityLimits
+	}
+	return nil
+}
+
+func (m *DatabaseInfo) GetDiskQuota() *DiskQuotaInfo {
+	if m != nil {
+		return m.DiskQuota
+	}
+	return nil
+}
+
 type RetentionPolicySpec struct {
 	Name                 *string  `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
 	Duration             *int64   `protobuf:"varint,2,opt,name=Duration" json:"Duration,omitempty"`
@@ -457,6 +520,10 @@ type RetentionPolicyInfo struct {
 	ReplicaN             *uint32             `protobuf:"varint,4,req,name=ReplicaN" json:"ReplicaN,omitempty"`
 	ShardGroups          []*ShardGroupInfo   `protobuf:"bytes,5,rep,name=ShardGroups" json:"ShardGroups,omitempty"`
 	Subscriptions        []*SubscriptionInfo `protobuf:"bytes,6,rep,name=Subscriptions" json:"Subscriptions,omitempty"`
+	RollupRules          []*RollupRuleInfo   `protobuf:"bytes,7,rep,name=RollupRules" json:"RollupRules,omitempty"`
+	Reshard              *ReshardInfo        `protobuf:"bytes,8,opt,name=Reshard" json:"Reshard,omitempty"`
+	ReplicationMode      *string             `protobuf:"bytes,9,opt,name=ReplicationMode" json:"ReplicationMode,omitempty"`
+	DiskQuota            *DiskQuotaInfo      `protobuf:"bytes,10,opt,name=DiskQuota" json:"DiskQuota,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
@@ -528,6 +595,34 @@ func (m *RetentionPolicyInfo) GetSubscriptions() []*SubscriptionInfo {
 	return nil
 }
 
+func (m *RetentionPolicyInfo) GetRollupRules() []*RollupRuleInfo {
+	if m != nil {
+		return m.RollupRules
+	}
+	return nil
+}
+
+func (m *RetentionPolicyInfo) GetReshard() *ReshardInfo {
+	if m != nil {
+		return m.Reshard
+	}
+	return nil
+}
+
+func (m *RetentionPolicyInfo) GetReplicationMode() string {
+	if m != nil && m.ReplicationMode != nil {
+		return *m.ReplicationMode
+	}
+	return ""
+}
+
+func (m *RetentionPolicyInfo) GetDiskQuota() *DiskQuotaInfo {
+	if m != nil {
+		return m.DiskQuota
+	}
+	return nil
+}
+
 type ShardGroupInfo struct {
 	ID                   *uint64      `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	StartTime            *int64       `protobuf:"varint,2,req,name=StartTime" json:"StartTime,omitempty"`
@@ -610,6 +705,7 @@ type ShardInfo struct {
 	ID                   *uint64       `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	OwnerIDs             []uint64      `protobuf:"varint,2,rep,name=OwnerIDs" json:"OwnerIDs,omitempty"` // Deprecated: Do not use.
 	Owners               []*ShardOwner `protobuf:"bytes,3,rep,name=Owners" json:"Owners,omitempty"`
+	Leader               *uint64       `protobuf:"varint,4,opt,name=Leader" json:"Leader,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -661,6 +757,13 @@ func (m *ShardInfo) GetOwners() []*ShardOwner {
 	return nil
 }
 
+func (m *ShardInfo) GetLeader() uint64 {
+	if m != nil && m.Leader != nil {
+		return *m.Leader
+	}
+	return 0
+}
+
 type SubscriptionInfo struct {
 	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
 	Mode                 *string  `protobuf:"bytes,2,req,name=Mode" json:"Mode,omitempty"`
@@ -715,2188 +818,3463 @@ func (m *SubscriptionInfo) GetDestinations() []string {
 	return nil
 }
 
-type ShardOwner struct {
-	NodeID               *uint64  `protobuf:"varint,1,req,name=NodeID" json:"NodeID,omitempty"`
+type RollupRuleInfo struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	After                *int64   `protobuf:"varint,2,req,name=After" json:"After,omitempty"`
+	Interval             *int64   `protobuf:"varint,3,req,name=Interval" json:"Interval,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ShardOwner) Reset()         { *m = ShardOwner{} }
-func (m *ShardOwner) String() string { return proto.CompactTextString(m) }
-func (*ShardOwner) ProtoMessage()    {}
-func (*ShardOwner) Descriptor() ([]byte, []int) {
+func (m *RollupRuleInfo) Reset()         { *m = RollupRuleInfo{} }
+func (m *RollupRuleInfo) String() string { return proto.CompactTextString(m) }
+func (*RollupRuleInfo) ProtoMessage()    {}
+func (*RollupRuleInfo) Descriptor() ([]byte, []int) {
 	return fileDescriptor_59b0956366e72083, []int{8}
 }
-func (m *ShardOwner) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ShardOwner.Unmarshal(m, b)
+func (m *RollupRuleInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollupRuleInfo.Unmarshal(m, b)
 }
-func (m *ShardOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ShardOwner.Marshal(b, m, deterministic)
+func (m *RollupRuleInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollupRuleInfo.Marshal(b, m, deterministic)
 }
-func (m *ShardOwner) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ShardOwner.Merge(m, src)
+func (m *RollupRuleInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollupRuleInfo.Merge(m, src)
 }
-func (m *ShardOwner) XXX_Size() int {
-	return xxx_messageInfo_ShardOwner.Size(m)
+func (m *RollupRuleInfo) XXX_Size() int {
+	return xxx_messageInfo_RollupRuleInfo.Size(m)
 }
-func (m *ShardOwner) XXX_DiscardUnknown() {
-	xxx_messageInfo_ShardOwner.DiscardUnknown(m)
+func (m *RollupRuleInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollupRuleInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ShardOwner proto.InternalMessageInfo
+var xxx_messageInfo_RollupRuleInfo proto.InternalMessageInfo
 
-func (m *ShardOwner) GetNodeID() uint64 {
-	if m != nil && m.NodeID != nil {
-		return *m.NodeID
+func (m *RollupRuleInfo) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *RollupRuleInfo) GetAfter() int64 {
+	if m != nil && m.After != nil {
+		return *m.After
 	}
 	return 0
 }
 
-type ContinuousQueryInfo struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Query                *string  `protobuf:"bytes,2,req,name=Query" json:"Query,omitempty"`
+func (m *RollupRuleInfo) GetInterval() int64 {
+	if m != nil && m.Interval != nil {
+		return *m.Interval
+	}
+	return 0
+}
+
+type ReshardInfo struct {
+	Duration             *int64            `protobuf:"varint,1,req,name=Duration" json:"Duration,omitempty"`
+	ShardGroups          []*ShardGroupInfo `protobuf:"bytes,2,rep,name=ShardGroups" json:"ShardGroups,omitempty"`
+	Copies               []*ReshardCopy    `protobuf:"bytes,3,rep,name=Copies" json:"Copies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReshardInfo) Reset()         { *m = ReshardInfo{} }
+func (m *ReshardInfo) String() string { return proto.CompactTextString(m) }
+func (*ReshardInfo) ProtoMessage()    {}
+func (*ReshardInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{9}
+}
+func (m *ReshardInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReshardInfo.Unmarshal(m, b)
+}
+func (m *ReshardInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReshardInfo.Marshal(b, m, deterministic)
+}
+func (m *ReshardInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReshardInfo.Merge(m, src)
+}
+func (m *ReshardInfo) XXX_Size() int {
+	return xxx_messageInfo_ReshardInfo.Size(m)
+}
+func (m *ReshardInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReshardInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReshardInfo proto.InternalMessageInfo
+
+func (m *ReshardInfo) GetDuration() int64 {
+	if m != nil && m.Duration != nil {
+		return *m.Duration
+	}
+	return 0
+}
+
+func (m *ReshardInfo) GetShardGroups() []*ShardGroupInfo {
+	if m != nil {
+		return m.ShardGroups
+	}
+	return nil
+}
+
+func (m *ReshardInfo) GetCopies() []*ReshardCopy {
+	if m != nil {
+		return m.Copies
+	}
+	return nil
+}
+
+type ReshardCopy struct {
+	ShardID              *uint64  `protobuf:"varint,1,req,name=ShardID" json:"ShardID,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ContinuousQueryInfo) Reset()         { *m = ContinuousQueryInfo{} }
-func (m *ContinuousQueryInfo) String() string { return proto.CompactTextString(m) }
-func (*ContinuousQueryInfo) ProtoMessage()    {}
-func (*ContinuousQueryInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{9}
+func (m *ReshardCopy) Reset()         { *m = ReshardCopy{} }
+func (m *ReshardCopy) String() string { return proto.CompactTextString(m) }
+func (*ReshardCopy) ProtoMessage()    {}
+func (*ReshardCopy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{10}
 }
-func (m *ContinuousQueryInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ContinuousQueryInfo.Unmarshal(m, b)
+func (m *ReshardCopy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReshardCopy.Unmarshal(m, b)
 }
-func (m *ContinuousQueryInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ContinuousQueryInfo.Marshal(b, m, deterministic)
+func (m *ReshardCopy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReshardCopy.Marshal(b, m, deterministic)
 }
-func (m *ContinuousQueryInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ContinuousQueryInfo.Merge(m, src)
+func (m *ReshardCopy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReshardCopy.Merge(m, src)
 }
-func (m *ContinuousQueryInfo) XXX_Size() int {
-	return xxx_messageInfo_ContinuousQueryInfo.Size(m)
+func (m *ReshardCopy) XXX_Size() int {
+	return xxx_messageInfo_ReshardCopy.Size(m)
 }
-func (m *ContinuousQueryInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ContinuousQueryInfo.DiscardUnknown(m)
+func (m *ReshardCopy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReshardCopy.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ContinuousQueryInfo proto.InternalMessageInfo
+var xxx_messageInfo_ReshardCopy proto.InternalMessageInfo
 
-func (m *ContinuousQueryInfo) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *ReshardCopy) GetShardID() uint64 {
+	if m != nil && m.ShardID != nil {
+		return *m.ShardID
 	}
-	return ""
+	return 0
 }
 
-func (m *ContinuousQueryInfo) GetQuery() string {
-	if m != nil && m.Query != nil {
-		return *m.Query
+func (m *ReshardCopy) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
 	}
-	return ""
+	return 0
 }
 
-type UserInfo struct {
-	Name                 *string          `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Hash                 *string          `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
-	Admin                *bool            `protobuf:"varint,3,req,name=Admin" json:"Admin,omitempty"`
-	Privileges           []*UserPrivilege `protobuf:"bytes,4,rep,name=Privileges" json:"Privileges,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type MeasurementSchemaInfo struct {
+	Name                 *string            `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Fields               []*FieldSchemaInfo `protobuf:"bytes,2,rep,name=Fields" json:"Fields,omitempty"`
+	RequiredTags         []string           `protobuf:"bytes,3,rep,name=RequiredTags" json:"RequiredTags,omitempty"`
+	AllowedTags          []string           `protobuf:"bytes,4,rep,name=AllowedTags" json:"AllowedTags,omitempty"`
+	Mode                 *string            `protobuf:"bytes,5,req,name=Mode" json:"Mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *UserInfo) Reset()         { *m = UserInfo{} }
-func (m *UserInfo) String() string { return proto.CompactTextString(m) }
-func (*UserInfo) ProtoMessage()    {}
-func (*UserInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{10}
+func (m *MeasurementSchemaInfo) Reset()         { *m = MeasurementSchemaInfo{} }
+func (m *MeasurementSchemaInfo) String() string { return proto.CompactTextString(m) }
+func (*MeasurementSchemaInfo) ProtoMessage()    {}
+func (*MeasurementSchemaInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{11}
 }
-func (m *UserInfo) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UserInfo.Unmarshal(m, b)
+func (m *MeasurementSchemaInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeasurementSchemaInfo.Unmarshal(m, b)
 }
-func (m *UserInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UserInfo.Marshal(b, m, deterministic)
+func (m *MeasurementSchemaInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeasurementSchemaInfo.Marshal(b, m, deterministic)
 }
-func (m *UserInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserInfo.Merge(m, src)
+func (m *MeasurementSchemaInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeasurementSchemaInfo.Merge(m, src)
 }
-func (m *UserInfo) XXX_Size() int {
-	return xxx_messageInfo_UserInfo.Size(m)
+func (m *MeasurementSchemaInfo) XXX_Size() int {
+	return xxx_messageInfo_MeasurementSchemaInfo.Size(m)
 }
-func (m *UserInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserInfo.DiscardUnknown(m)
+func (m *MeasurementSchemaInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeasurementSchemaInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserInfo proto.InternalMessageInfo
+var xxx_messageInfo_MeasurementSchemaInfo proto.InternalMessageInfo
 
-func (m *UserInfo) GetName() string {
+func (m *MeasurementSchemaInfo) GetName() string {
 	if m != nil && m.Name != nil {
 		return *m.Name
 	}
 	return ""
 }
 
-func (m *UserInfo) GetHash() string {
-	if m != nil && m.Hash != nil {
-		return *m.Hash
+func (m *MeasurementSchemaInfo) GetFields() []*FieldSchemaInfo {
+	if m != nil {
+		return m.Fields
 	}
-	return ""
+	return nil
 }
 
-func (m *UserInfo) GetAdmin() bool {
-	if m != nil && m.Admin != nil {
-		return *m.Admin
+func (m *MeasurementSchemaInfo) GetRequiredTags() []string {
+	if m != nil {
+		return m.RequiredTags
 	}
-	return false
+	return nil
 }
 
-func (m *UserInfo) GetPrivileges() []*UserPrivilege {
+func (m *MeasurementSchemaInfo) GetAllowedTags() []string {
 	if m != nil {
-		return m.Privileges
+		return m.AllowedTags
 	}
 	return nil
 }
 
-type UserPrivilege struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Privilege            *int32   `protobuf:"varint,2,req,name=Privilege" json:"Privilege,omitempty"`
+func (m *MeasurementSchemaInfo) GetMode() string {
+	if m != nil && m.Mode != nil {
+		return *m.Mode
+	}
+	return ""
+}
+
+type FieldSchemaInfo struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Type                 *int32   `protobuf:"varint,2,req,name=Type" json:"Type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UserPrivilege) Reset()         { *m = UserPrivilege{} }
-func (m *UserPrivilege) String() string { return proto.CompactTextString(m) }
-func (*UserPrivilege) ProtoMessage()    {}
-func (*UserPrivilege) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{11}
+func (m *FieldSchemaInfo) Reset()         { *m = FieldSchemaInfo{} }
+func (m *FieldSchemaInfo) String() string { return proto.CompactTextString(m) }
+func (*FieldSchemaInfo) ProtoMessage()    {}
+func (*FieldSchemaInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{12}
 }
-func (m *UserPrivilege) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UserPrivilege.Unmarshal(m, b)
+func (m *FieldSchemaInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldSchemaInfo.Unmarshal(m, b)
 }
-func (m *UserPrivilege) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UserPrivilege.Marshal(b, m, deterministic)
+func (m *FieldSchemaInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldSchemaInfo.Marshal(b, m, deterministic)
 }
-func (m *UserPrivilege) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserPrivilege.Merge(m, src)
+func (m *FieldSchemaInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldSchemaInfo.Merge(m, src)
 }
-func (m *UserPrivilege) XXX_Size() int {
-	return xxx_messageInfo_UserPrivilege.Size(m)
+func (m *FieldSchemaInfo) XXX_Size() int {
+	return xxx_messageInfo_FieldSchemaInfo.Size(m)
 }
-func (m *UserPrivilege) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserPrivilege.DiscardUnknown(m)
+func (m *FieldSchemaInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldSchemaInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserPrivilege proto.InternalMessageInfo
+var xxx_messageInfo_FieldSchemaInfo proto.InternalMessageInfo
 
-func (m *UserPrivilege) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *FieldSchemaInfo) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
 	}
 	return ""
 }
 
-func (m *UserPrivilege) GetPrivilege() int32 {
-	if m != nil && m.Privilege != nil {
-		return *m.Privilege
+func (m *FieldSchemaInfo) GetType() int32 {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
 	return 0
 }
 
-type Command struct {
-	Type                         *Command_Type `protobuf:"varint,1,req,name=type,enum=meta.Command_Type" json:"type,omitempty"`
-	XXX_NoUnkeyedLiteral         struct{}      `json:"-"`
-	proto.XXX_InternalExtensions `json:"-"`
-	XXX_unrecognized             []byte `json:"-"`
-	XXX_sizecache                int32  `json:"-"`
-}
-
-func (m *Command) Reset()         { *m = Command{} }
-func (m *Command) String() string { return proto.CompactTextString(m) }
-func (*Command) ProtoMessage()    {}
-func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{12}
-}
-
-var extRange_Command = []proto.ExtensionRange{
-	{Start: 100, End: 536870911},
+type CardinalityLimitsInfo struct {
+	MaxSeriesPerMeasurement *int64                  `protobuf:"varint,1,req,name=MaxSeriesPerMeasurement" json:"MaxSeriesPerMeasurement,omitempty"`
+	MaxValuesPerTag         *int64                  `protobuf:"varint,2,req,name=MaxValuesPerTag" json:"MaxValuesPerTag,omitempty"`
+	Measurements            []*CardinalityLimitInfo `protobuf:"bytes,3,rep,name=Measurements" json:"Measurements,omitempty"`
+	TagKeys                 []*CardinalityLimitInfo `protobuf:"bytes,4,rep,name=TagKeys" json:"TagKeys,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                `json:"-"`
+	XXX_unrecognized        []byte                  `json:"-"`
+	XXX_sizecache           int32                   `json:"-"`
 }
 
-func (*Command) ExtensionRangeArray() []proto.ExtensionRange {
-	return extRange_Command
+func (m *CardinalityLimitsInfo) Reset()         { *m = CardinalityLimitsInfo{} }
+func (m *CardinalityLimitsInfo) String() string { return proto.CompactTextString(m) }
+func (*CardinalityLimitsInfo) ProtoMessage()    {}
+func (*CardinalityLimitsInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{13}
 }
-
-func (m *Command) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Command.Unmarshal(m, b)
+func (m *CardinalityLimitsInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CardinalityLimitsInfo.Unmarshal(m, b)
 }
-func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+func (m *CardinalityLimitsInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CardinalityLimitsInfo.Marshal(b, m, deterministic)
 }
-func (m *Command) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Command.Merge(m, src)
+func (m *CardinalityLimitsInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CardinalityLimitsInfo.Merge(m, src)
 }
-func (m *Command) XXX_Size() int {
-	return xxx_messageInfo_Command.Size(m)
+func (m *CardinalityLimitsInfo) XXX_Size() int {
+	return xxx_messageInfo_CardinalityLimitsInfo.Size(m)
 }
-func (m *Command) XXX_DiscardUnknown() {
-	xxx_messageInfo_Command.DiscardUnknown(m)
+func (m *CardinalityLimitsInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CardinalityLimitsInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Command proto.InternalMessageInfo
+var xxx_messageInfo_CardinalityLimitsInfo proto.InternalMessageInfo
 
-func (m *Command) GetType() Command_Type {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (m *CardinalityLimitsInfo) GetMaxSeriesPerMeasurement() int64 {
+	if m != nil && m.MaxSeriesPerMeasurement != nil {
+		return *m.MaxSeriesPerMeasurement
 	}
-	return Command_CreateNodeCommand
+	return 0
 }
 
-// This isn't used in >= 0.10.0. Kept around for upgrade purposes. Instead
-// look at CreateDataNodeCommand and CreateMetaNodeCommand
-type CreateNodeCommand struct {
-	Host                 *string  `protobuf:"bytes,1,req,name=Host" json:"Host,omitempty"`
-	Rand                 *uint64  `protobuf:"varint,2,req,name=Rand" json:"Rand,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CardinalityLimitsInfo) GetMaxValuesPerTag() int64 {
+	if m != nil && m.MaxValuesPerTag != nil {
+		return *m.MaxValuesPerTag
+	}
+	return 0
 }
 
-func (m *CreateNodeCommand) Reset()         { *m = CreateNodeCommand{} }
-func (m *CreateNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateNodeCommand) ProtoMessage()    {}
-func (*CreateNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{13}
+func (m *CardinalityLimitsInfo) GetMeasurements() []*CardinalityLimitInfo {
+	if m != nil {
+		return m.Measurements
+	}
+	return nil
 }
-func (m *CreateNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateNodeCommand.Unmarshal(m, b)
+
+func (m *CardinalityLimitsInfo) GetTagKeys() []*CardinalityLimitInfo {
+	if m != nil {
+		return m.TagKeys
+	}
+	return nil
 }
-func (m *CreateNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateNodeCommand.Marshal(b, m, deterministic)
+
+type DiskQuotaInfo struct {
+	SoftLimit            *int64   `protobuf:"varint,1,req,name=SoftLimit" json:"SoftLimit,omitempty"`
+	HardLimit            *int64   `protobuf:"varint,2,req,name=HardLimit" json:"HardLimit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *CreateNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateNodeCommand.Merge(m, src)
+
+func (m *DiskQuotaInfo) Reset()         { *m = DiskQuotaInfo{} }
+func (m *DiskQuotaInfo) String() string { return proto.CompactTextString(m) }
+func (*DiskQuotaInfo) ProtoMessage()    {}
+func (*DiskQuotaInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{14}
 }
-func (m *CreateNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateNodeCommand.Size(m)
+func (m *DiskQuotaInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskQuotaInfo.Unmarshal(m, b)
 }
-func (m *CreateNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateNodeCommand.DiscardUnknown(m)
+func (m *DiskQuotaInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskQuotaInfo.Marshal(b, m, deterministic)
+}
+func (m *DiskQuotaInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskQuotaInfo.Merge(m, src)
+}
+func (m *DiskQuotaInfo) XXX_Size() int {
+	return xxx_messageInfo_DiskQuotaInfo.Size(m)
+}
+func (m *DiskQuotaInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskQuotaInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_DiskQuotaInfo proto.InternalMessageInfo
 
-func (m *CreateNodeCommand) GetHost() string {
-	if m != nil && m.Host != nil {
-		return *m.Host
+func (m *DiskQuotaInfo) GetSoftLimit() int64 {
+	if m != nil && m.SoftLimit != nil {
+		return *m.SoftLimit
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateNodeCommand) GetRand() uint64 {
-	if m != nil && m.Rand != nil {
-		return *m.Rand
+func (m *DiskQuotaInfo) GetHardLimit() int64 {
+	if m != nil && m.HardLimit != nil {
+		return *m.HardLimit
 	}
 	return 0
 }
 
-var E_CreateNodeCommand_Command = &proto.ExtensionDesc{
-	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateNodeCommand)(nil),
-	Field:         101,
-	Name:          "meta.CreateNodeCommand.command",
-	Tag:           "bytes,101,opt,name=command",
-	Filename:      "internal/meta.proto",
-}
-
-type DeleteNodeCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
-	Force                *bool    `protobuf:"varint,2,req,name=Force" json:"Force,omitempty"`
+type CardinalityLimitInfo struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Limit                *int64   `protobuf:"varint,2,req,name=Limit" json:"Limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteNodeCommand) Reset()         { *m = DeleteNodeCommand{} }
-func (m *DeleteNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*DeleteNodeCommand) ProtoMessage()    {}
-func (*DeleteNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{14}
+func (m *CardinalityLimitInfo) Reset()         { *m = CardinalityLimitInfo{} }
+func (m *CardinalityLimitInfo) String() string { return proto.CompactTextString(m) }
+func (*CardinalityLimitInfo) ProtoMessage()    {}
+func (*CardinalityLimitInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{15}
 }
-func (m *DeleteNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteNodeCommand.Unmarshal(m, b)
+func (m *CardinalityLimitInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CardinalityLimitInfo.Unmarshal(m, b)
 }
-func (m *DeleteNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteNodeCommand.Marshal(b, m, deterministic)
+func (m *CardinalityLimitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CardinalityLimitInfo.Marshal(b, m, deterministic)
 }
-func (m *DeleteNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteNodeCommand.Merge(m, src)
+func (m *CardinalityLimitInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CardinalityLimitInfo.Merge(m, src)
 }
-func (m *DeleteNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_DeleteNodeCommand.Size(m)
+func (m *CardinalityLimitInfo) XXX_Size() int {
+	return xxx_messageInfo_CardinalityLimitInfo.Size(m)
 }
-func (m *DeleteNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteNodeCommand.DiscardUnknown(m)
+func (m *CardinalityLimitInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_CardinalityLimitInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_CardinalityLimitInfo proto.InternalMessageInfo
 
-func (m *DeleteNodeCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
+func (m *CardinalityLimitInfo) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *DeleteNodeCommand) GetForce() bool {
-	if m != nil && m.Force != nil {
-		return *m.Force
+func (m *CardinalityLimitInfo) GetLimit() int64 {
+	if m != nil && m.Limit != nil {
+		return *m.Limit
 	}
-	return false
-}
-
-var E_DeleteNodeCommand_Command = &proto.ExtensionDesc{
-	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DeleteNodeCommand)(nil),
-	Field:         102,
-	Name:          "meta.DeleteNodeCommand.command",
-	Tag:           "bytes,102,opt,name=command",
-	Filename:      "internal/meta.proto",
+	return 0
 }
 
-type CreateDatabaseCommand struct {
-	Name                 *string              `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	RetentionPolicy      *RetentionPolicyInfo `protobuf:"bytes,2,opt,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type ShardOwner struct {
+	NodeID               *uint64  `protobuf:"varint,1,req,name=NodeID" json:"NodeID,omitempty"`
+	Quarantined          *bool    `protobuf:"varint,2,opt,name=Quarantined" json:"Quarantined,omitempty"`
+	Tier                 *string  `protobuf:"bytes,3,opt,name=Tier" json:"Tier,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateDatabaseCommand) Reset()         { *m = CreateDatabaseCommand{} }
-func (m *CreateDatabaseCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateDatabaseCommand) ProtoMessage()    {}
-func (*CreateDatabaseCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{15}
+func (m *ShardOwner) Reset()         { *m = ShardOwner{} }
+func (m *ShardOwner) String() string { return proto.CompactTextString(m) }
+func (*ShardOwner) ProtoMessage()    {}
+func (*ShardOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{16}
 }
-func (m *CreateDatabaseCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateDatabaseCommand.Unmarshal(m, b)
+func (m *ShardOwner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShardOwner.Unmarshal(m, b)
 }
-func (m *CreateDatabaseCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateDatabaseCommand.Marshal(b, m, deterministic)
+func (m *ShardOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShardOwner.Marshal(b, m, deterministic)
 }
-func (m *CreateDatabaseCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateDatabaseCommand.Merge(m, src)
+func (m *ShardOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardOwner.Merge(m, src)
 }
-func (m *CreateDatabaseCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateDatabaseCommand.Size(m)
+func (m *ShardOwner) XXX_Size() int {
+	return xxx_messageInfo_ShardOwner.Size(m)
 }
-func (m *CreateDatabaseCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateDatabaseCommand.DiscardUnknown(m)
+func (m *ShardOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardOwner.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateDatabaseCommand proto.InternalMessageInfo
+var xxx_messageInfo_ShardOwner proto.InternalMessageInfo
 
-func (m *CreateDatabaseCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *ShardOwner) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateDatabaseCommand) GetRetentionPolicy() *RetentionPolicyInfo {
-	if m != nil {
-		return m.RetentionPolicy
+func (m *ShardOwner) GetQuarantined() bool {
+	if m != nil && m.Quarantined != nil {
+		return *m.Quarantined
 	}
-	return nil
+	return false
 }
 
-var E_CreateDatabaseCommand_Command = &proto.ExtensionDesc{
-	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateDatabaseCommand)(nil),
-	Field:         103,
-	Name:          "meta.CreateDatabaseCommand.command",
-	Tag:           "bytes,103,opt,name=command",
-	Filename:      "internal/meta.proto",
+func (m *ShardOwner) GetTier() string {
+	if m != nil && m.Tier != nil {
+		return *m.Tier
+	}
+	return ""
 }
 
-type DropDatabaseCommand struct {
+type ContinuousQueryInfo struct {
 	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Query                *string  `protobuf:"bytes,2,req,name=Query" json:"Query,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DropDatabaseCommand) Reset()         { *m = DropDatabaseCommand{} }
-func (m *DropDatabaseCommand) String() string { return proto.CompactTextString(m) }
-func (*DropDatabaseCommand) ProtoMessage()    {}
-func (*DropDatabaseCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{16}
+func (m *ContinuousQueryInfo) Reset()         { *m = ContinuousQueryInfo{} }
+func (m *ContinuousQueryInfo) String() string { return proto.CompactTextString(m) }
+func (*ContinuousQueryInfo) ProtoMessage()    {}
+func (*ContinuousQueryInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{17}
 }
-func (m *DropDatabaseCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropDatabaseCommand.Unmarshal(m, b)
+func (m *ContinuousQueryInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContinuousQueryInfo.Unmarshal(m, b)
 }
-func (m *DropDatabaseCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DropDatabaseCommand.Marshal(b, m, deterministic)
+func (m *ContinuousQueryInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContinuousQueryInfo.Marshal(b, m, deterministic)
 }
-func (m *DropDatabaseCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DropDatabaseCommand.Merge(m, src)
+func (m *ContinuousQueryInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContinuousQueryInfo.Merge(m, src)
 }
-func (m *DropDatabaseCommand) XXX_Size() int {
-	return xxx_messageInfo_DropDatabaseCommand.Size(m)
+func (m *ContinuousQueryInfo) XXX_Size() int {
+	return xxx_messageInfo_ContinuousQueryInfo.Size(m)
 }
-func (m *DropDatabaseCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DropDatabaseCommand.DiscardUnknown(m)
+func (m *ContinuousQueryInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContinuousQueryInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DropDatabaseCommand proto.InternalMessageInfo
+var xxx_messageInfo_ContinuousQueryInfo proto.InternalMessageInfo
 
-func (m *DropDatabaseCommand) GetName() string {
+func (m *ContinuousQueryInfo) GetName() string {
 	if m != nil && m.Name != nil {
 		return *m.Name
 	}
 	return ""
 }
 
-var E_DropDatabaseCommand_Command = &proto.ExtensionDesc{
-	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DropDatabaseCommand)(nil),
-	Field:         104,
-	Name:          "meta.DropDatabaseCommand.command",
-	Tag:           "bytes,104,opt,name=command",
-	Filename:      "internal/meta.proto",
+func (m *ContinuousQueryInfo) GetQuery() string {
+	if m != nil && m.Query != nil {
+		return *m.Query
+	}
+	return ""
 }
 
-type CreateRetentionPolicyCommand struct {
-	Database             *string              `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	RetentionPolicy      *RetentionPolicyInfo `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
-	Default              *bool                `protobuf:"varint,3,opt,name=Default" json:"Default,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type UserInfo struct {
+	Name                 *string          `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Hash                 *string          `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
+	Admin                *bool            `protobuf:"varint,3,req,name=Admin" json:"Admin,omitempty"`
+	Privileges           []*UserPrivilege `protobuf:"bytes,4,rep,name=Privileges" json:"Privileges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *CreateRetentionPolicyCommand) Reset()         { *m = CreateRetentionPolicyCommand{} }
-func (m *CreateRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateRetentionPolicyCommand) ProtoMessage()    {}
-func (*CreateRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{17}
+func (m *UserInfo) Reset()         { *m = UserInfo{} }
+func (m *UserInfo) String() string { return proto.CompactTextString(m) }
+func (*UserInfo) ProtoMessage()    {}
+func (*UserInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{18}
 }
-func (m *CreateRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateRetentionPolicyCommand.Unmarshal(m, b)
+func (m *UserInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserInfo.Unmarshal(m, b)
 }
-func (m *CreateRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateRetentionPolicyCommand.Marshal(b, m, deterministic)
+func (m *UserInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserInfo.Marshal(b, m, deterministic)
 }
-func (m *CreateRetentionPolicyCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRetentionPolicyCommand.Merge(m, src)
+func (m *UserInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserInfo.Merge(m, src)
 }
-func (m *CreateRetentionPolicyCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateRetentionPolicyCommand.Size(m)
+func (m *UserInfo) XXX_Size() int {
+	return xxx_messageInfo_UserInfo.Size(m)
 }
-func (m *CreateRetentionPolicyCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRetentionPolicyCommand.DiscardUnknown(m)
+func (m *UserInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRetentionPolicyCommand proto.InternalMessageInfo
+var xxx_messageInfo_UserInfo proto.InternalMessageInfo
 
-func (m *CreateRetentionPolicyCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *UserInfo) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *UserInfo) GetHash() string {
+	if m != nil && m.Hash != nil {
+		return *m.Hash
+	}
+	return ""
+}
+
+func (m *UserInfo) GetAdmin() bool {
+	if m != nil && m.Admin != nil {
+		return *m.Admin
+	}
+	return false
+}
+
+func (m *UserInfo) GetPrivileges() []*UserPrivilege {
+	if m != nil {
+		return m.Privileges
+	}
+	return nil
+}
+
+type UserPrivilege struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Privilege            *int32   `protobuf:"varint,2,req,name=Privilege" json:"Privilege,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserPrivilege) Reset()         { *m = UserPrivilege{} }
+func (m *UserPrivilege) String() string { return proto.CompactTextString(m) }
+func (*UserPrivilege) ProtoMessage()    {}
+func (*UserPrivilege) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{19}
+}
+func (m *UserPrivilege) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserPrivilege.Unmarshal(m, b)
+}
+func (m *UserPrivilege) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserPrivilege.Marshal(b, m, deterministic)
+}
+func (m *UserPrivilege) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserPrivilege.Merge(m, src)
+}
+func (m *UserPrivilege) XXX_Size() int {
+	return xxx_messageInfo_UserPrivilege.Size(m)
+}
+func (m *UserPrivilege) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserPrivilege.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserPrivilege proto.InternalMessageInfo
+
+func (m *UserPrivilege) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *UserPrivilege) GetPrivilege() int32 {
+	if m != nil && m.Privilege != nil {
+		return *m.Privilege
+	}
+	return 0
+}
+
+type Command struct {
+	Type                         *Command_Type `protobuf:"varint,1,req,name=type,enum=meta.Command_Type" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}      `json:"-"`
+	proto.XXX_InternalExtensions `json:"-"`
+	XXX_unrecognized             []byte `json:"-"`
+	XXX_sizecache                int32  `json:"-"`
+}
+
+func (m *Command) Reset()         { *m = Command{} }
+func (m *Command) String() string { return proto.CompactTextString(m) }
+func (*Command) ProtoMessage()    {}
+func (*Command) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{20}
+}
+
+var extRange_Command = []proto.ExtensionRange{
+	{Start: 100, End: 536870911},
+}
+
+func (*Command) ExtensionRangeArray() []proto.ExtensionRange {
+	return extRange_Command
+}
+
+func (m *Command) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Command.Unmarshal(m, b)
+}
+func (m *Command) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Command.Marshal(b, m, deterministic)
+}
+func (m *Command) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Command.Merge(m, src)
+}
+func (m *Command) XXX_Size() int {
+	return xxx_messageInfo_Command.Size(m)
+}
+func (m *Command) XXX_DiscardUnknown() {
+	xxx_messageInfo_Command.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Command proto.InternalMessageInfo
+
+func (m *Command) GetType() Command_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return Command_CreateNodeCommand
+}
+
+// This isn't used in >= 0.10.0. Kept around for upgrade purposes. Instead
+// look at CreateDataNodeCommand and CreateMetaNodeCommand
+type CreateNodeCommand struct {
+	Host                 *string  `protobuf:"bytes,1,req,name=Host" json:"Host,omitempty"`
+	Rand                 *uint64  `protobuf:"varint,2,req,name=Rand" json:"Rand,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateNodeCommand) Reset()         { *m = CreateNodeCommand{} }
+func (m *CreateNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateNodeCommand) ProtoMessage()    {}
+func (*CreateNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{21}
+}
+func (m *CreateNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateNodeCommand.Unmarshal(m, b)
+}
+func (m *CreateNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateNodeCommand.Marshal(b, m, deterministic)
+}
+func (m *CreateNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateNodeCommand.Merge(m, src)
+}
+func (m *CreateNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateNodeCommand.Size(m)
+}
+func (m *CreateNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateNodeCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateNodeCommand proto.InternalMessageInfo
+
+func (m *CreateNodeCommand) GetHost() string {
+	if m != nil && m.Host != nil {
+		return *m.Host
+	}
+	return ""
+}
+
+func (m *CreateNodeCommand) GetRand() uint64 {
+	if m != nil && m.Rand != nil {
+		return *m.Rand
+	}
+	return 0
+}
+
+var E_CreateNodeCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateNodeCommand)(nil),
+	Field:         101,
+	Name:          "meta.CreateNodeCommand.command",
+	Tag:           "bytes,101,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type DeleteNodeCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	Force                *bool    `protobuf:"varint,2,req,name=Force" json:"Force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteNodeCommand) Reset()         { *m = DeleteNodeCommand{} }
+func (m *DeleteNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*DeleteNodeCommand) ProtoMessage()    {}
+func (*DeleteNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{22}
+}
+func (m *DeleteNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteNodeCommand.Unmarshal(m, b)
+}
+func (m *DeleteNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteNodeCommand.Marshal(b, m, deterministic)
+}
+func (m *DeleteNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteNodeCommand.Merge(m, src)
+}
+func (m *DeleteNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_DeleteNodeCommand.Size(m)
+}
+func (m *DeleteNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteNodeCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteNodeCommand proto.InternalMessageInfo
+
+func (m *DeleteNodeCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
+	}
+	return 0
+}
+
+func (m *DeleteNodeCommand) GetForce() bool {
+	if m != nil && m.Force != nil {
+		return *m.Force
+	}
+	return false
+}
+
+var E_DeleteNodeCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DeleteNodeCommand)(nil),
+	Field:         102,
+	Name:          "meta.DeleteNodeCommand.command",
+	Tag:           "bytes,102,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type CreateDatabaseCommand struct {
+	Name                 *string              `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	RetentionPolicy      *RetentionPolicyInfo `protobuf:"bytes,2,opt,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateDatabaseCommand) Reset()         { *m = CreateDatabaseCommand{} }
+func (m *CreateDatabaseCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateDatabaseCommand) ProtoMessage()    {}
+func (*CreateDatabaseCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{23}
+}
+func (m *CreateDatabaseCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDatabaseCommand.Unmarshal(m, b)
+}
+func (m *CreateDatabaseCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDatabaseCommand.Marshal(b, m, deterministic)
+}
+func (m *CreateDatabaseCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDatabaseCommand.Merge(m, src)
+}
+func (m *CreateDatabaseCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateDatabaseCommand.Size(m)
+}
+func (m *CreateDatabaseCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDatabaseCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateDatabaseCommand proto.InternalMessageInfo
+
+func (m *CreateDatabaseCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *CreateDatabaseCommand) GetRetentionPolicy() *RetentionPolicyInfo {
+	if m != nil {
+		return m.RetentionPolicy
+	}
+	return nil
+}
+
+var E_CreateDatabaseCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateDatabaseCommand)(nil),
+	Field:         103,
+	Name:          "meta.CreateDatabaseCommand.command",
+	Tag:           "bytes,103,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type DropDatabaseCommand struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropDatabaseCommand) Reset()         { *m = DropDatabaseCommand{} }
+func (m *DropDatabaseCommand) String() string { return proto.CompactTextString(m) }
+func (*DropDatabaseCommand) ProtoMessage()    {}
+func (*DropDatabaseCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{24}
+}
+func (m *DropDatabaseCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropDatabaseCommand.Unmarshal(m, b)
+}
+func (m *DropDatabaseCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropDatabaseCommand.Marshal(b, m, deterministic)
+}
+func (m *DropDatabaseCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropDatabaseCommand.Merge(m, src)
+}
+func (m *DropDatabaseCommand) XXX_Size() int {
+	return xxx_messageInfo_DropDatabaseCommand.Size(m)
+}
+func (m *DropDatabaseCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropDatabaseCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropDatabaseCommand proto.InternalMessageInfo
+
+func (m *DropDatabaseCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+var E_DropDatabaseCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DropDatabaseCommand)(nil),
+	Field:         104,
+	Name:          "meta.DropDatabaseCommand.command",
+	Tag:           "bytes,104,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type CreateRetentionPolicyCommand struct {
+	Database             *string              `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *RetentionPolicyInfo `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Default              *bool                `protobuf:"varint,3,opt,name=Default" json:"Default,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateRetentionPolicyCommand) Reset()         { *m = CreateRetentionPolicyCommand{} }
+func (m *CreateRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateRetentionPolicyCommand) ProtoMessage()    {}
+func (*CreateRetentionPolicyCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{25}
+}
+func (m *CreateRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRetentionPolicyCommand.Unmarshal(m, b)
+}
+func (m *CreateRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRetentionPolicyCommand.Marshal(b, m, deterministic)
+}
+func (m *CreateRetentionPolicyCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRetentionPolicyCommand.Merge(m, src)
+}
+func (m *CreateRetentionPolicyCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateRetentionPolicyCommand.Size(m)
+}
+func (m *CreateRetentionPolicyCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRetentionPolicyCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRetentionPolicyCommand proto.InternalMessageInfo
+
+func (m *CreateRetentionPolicyCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
 	return ""
 }
 
 func (m *CreateRetentionPolicyCommand) GetRetentionPolicy() *RetentionPolicyInfo {
 	if m != nil {
-		return m.RetentionPolicy
+		return m.RetentionPolicy
+	}
+	return nil
+}
+
+func (m *CreateRetentionPolicyCommand) GetDefault() bool {
+	if m != nil && m.Default != nil {
+		return *m.Default
+	}
+	return false
+}
+
+var E_CreateRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateRetentionPolicyCommand)(nil),
+	Field:         105,
+	Name:          "meta.CreateRetentionPolicyCommand.command",
+	Tag:           "bytes,105,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type DropRetentionPolicyCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropRetentionPolicyCommand) Reset()         { *m = DropRetentionPolicyCommand{} }
+func (m *DropRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
+func (*DropRetentionPolicyCommand) ProtoMessage()    {}
+func (*DropRetentionPolicyCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{26}
+}
+func (m *DropRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropRetentionPolicyCommand.Unmarshal(m, b)
+}
+func (m *DropRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropRetentionPolicyCommand.Marshal(b, m, deterministic)
+}
+func (m *DropRetentionPolicyCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropRetentionPolicyCommand.Merge(m, src)
+}
+func (m *DropRetentionPolicyCommand) XXX_Size() int {
+	return xxx_messageInfo_DropRetentionPolicyCommand.Size(m)
+}
+func (m *DropRetentionPolicyCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropRetentionPolicyCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropRetentionPolicyCommand proto.InternalMessageInfo
+
+func (m *DropRetentionPolicyCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *DropRetentionPolicyCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+var E_DropRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DropRetentionPolicyCommand)(nil),
+	Field:         106,
+	Name:          "meta.DropRetentionPolicyCommand.command",
+	Tag:           "bytes,106,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type SetDefaultRetentionPolicyCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDefaultRetentionPolicyCommand) Reset()         { *m = SetDefaultRetentionPolicyCommand{} }
+func (m *SetDefaultRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
+func (*SetDefaultRetentionPolicyCommand) ProtoMessage()    {}
+func (*SetDefaultRetentionPolicyCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{27}
+}
+func (m *SetDefaultRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDefaultRetentionPolicyCommand.Unmarshal(m, b)
+}
+func (m *SetDefaultRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDefaultRetentionPolicyCommand.Marshal(b, m, deterministic)
+}
+func (m *SetDefaultRetentionPolicyCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDefaultRetentionPolicyCommand.Merge(m, src)
+}
+func (m *SetDefaultRetentionPolicyCommand) XXX_Size() int {
+	return xxx_messageInfo_SetDefaultRetentionPolicyCommand.Size(m)
+}
+func (m *SetDefaultRetentionPolicyCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDefaultRetentionPolicyCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDefaultRetentionPolicyCommand proto.InternalMessageInfo
+
+func (m *SetDefaultRetentionPolicyCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *SetDefaultRetentionPolicyCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+var E_SetDefaultRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*SetDefaultRetentionPolicyCommand)(nil),
+	Field:         107,
+	Name:          "meta.SetDefaultRetentionPolicyCommand.command",
+	Tag:           "bytes,107,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type UpdateRetentionPolicyCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+	NewName              *string  `protobuf:"bytes,3,opt,name=NewName" json:"NewName,omitempty"`
+	Duration             *int64   `protobuf:"varint,4,opt,name=Duration" json:"Duration,omitempty"`
+	ReplicaN             *uint32  `protobuf:"varint,5,opt,name=ReplicaN" json:"ReplicaN,omitempty"`
+	ShardGroupDuration   *int64   `protobuf:"varint,6,opt,name=ShardGroupDuration" json:"ShardGroupDuration,omitempty"`
+	Default              *bool    `protobuf:"varint,7,opt,name=Default" json:"Default,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRetentionPolicyCommand) Reset()         { *m = UpdateRetentionPolicyCommand{} }
+func (m *UpdateRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
+func (*UpdateRetentionPolicyCommand) ProtoMessage()    {}
+func (*UpdateRetentionPolicyCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{28}
+}
+func (m *UpdateRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRetentionPolicyCommand.Unmarshal(m, b)
+}
+func (m *UpdateRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRetentionPolicyCommand.Marshal(b, m, deterministic)
+}
+func (m *UpdateRetentionPolicyCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRetentionPolicyCommand.Merge(m, src)
+}
+func (m *UpdateRetentionPolicyCommand) XXX_Size() int {
+	return xxx_messageInfo_UpdateRetentionPolicyCommand.Size(m)
+}
+func (m *UpdateRetentionPolicyCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRetentionPolicyCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRetentionPolicyCommand proto.InternalMessageInfo
+
+func (m *UpdateRetentionPolicyCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *UpdateRetentionPolicyCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *UpdateRetentionPolicyCommand) GetNewName() string {
+	if m != nil && m.NewName != nil {
+		return *m.NewName
+	}
+	return ""
+}
+
+func (m *UpdateRetentionPolicyCommand) GetDuration() int64 {
+	if m != nil && m.Duration != nil {
+		return *m.Duration
+	}
+	return 0
+}
+
+func (m *UpdateRetentionPolicyCommand) GetReplicaN() uint32 {
+	if m != nil && m.ReplicaN != nil {
+		return *m.ReplicaN
+	}
+	return 0
+}
+
+func (m *UpdateRetentionPolicyCommand) GetShardGroupDuration() int64 {
+	if m != nil && m.ShardGroupDuration != nil {
+		return *m.ShardGroupDuration
+	}
+	return 0
+}
+
+func (m *UpdateRetentionPolicyCommand) GetDefault() bool {
+	if m != nil && m.Default != nil {
+		return *m.Default
+	}
+	return false
+}
+
+var E_UpdateRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*UpdateRetentionPolicyCommand)(nil),
+	Field:         108,
+	Name:          "meta.UpdateRetentionPolicyCommand.command",
+	Tag:           "bytes,108,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type CreateShardGroupCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Policy               *string  `protobuf:"bytes,2,req,name=Policy" json:"Policy,omitempty"`
+	Timestamp            *int64   `protobuf:"varint,3,req,name=Timestamp" json:"Timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateShardGroupCommand) Reset()         { *m = CreateShardGroupCommand{} }
+func (m *CreateShardGroupCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateShardGroupCommand) ProtoMessage()    {}
+func (*CreateShardGroupCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{29}
+}
+func (m *CreateShardGroupCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateShardGroupCommand.Unmarshal(m, b)
+}
+func (m *CreateShardGroupCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateShardGroupCommand.Marshal(b, m, deterministic)
+}
+func (m *CreateShardGroupCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateShardGroupCommand.Merge(m, src)
+}
+func (m *CreateShardGroupCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateShardGroupCommand.Size(m)
+}
+func (m *CreateShardGroupCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateShardGroupCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateShardGroupCommand proto.InternalMessageInfo
+
+func (m *CreateShardGroupCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *CreateShardGroupCommand) GetPolicy() string {
+	if m != nil && m.Policy != nil {
+		return *m.Policy
+	}
+	return ""
+}
+
+func (m *CreateShardGroupCommand) GetTimestamp() int64 {
+	if m != nil && m.Timestamp != nil {
+		return *m.Timestamp
+	}
+	return 0
+}
+
+var E_CreateShardGroupCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateShardGroupCommand)(nil),
+	Field:         109,
+	Name:          "meta.CreateShardGroupCommand.command",
+	Tag:           "bytes,109,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type DeleteShardGroupCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Policy               *string  `protobuf:"bytes,2,req,name=Policy" json:"Policy,omitempty"`
+	ShardGroupID         *uint64  `protobuf:"varint,3,req,name=ShardGroupID" json:"ShardGroupID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteShardGroupCommand) Reset()         { *m = DeleteShardGroupCommand{} }
+func (m *DeleteShardGroupCommand) String() string { return proto.CompactTextString(m) }
+func (*DeleteShardGroupCommand) ProtoMessage()    {}
+func (*DeleteShardGroupCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{30}
+}
+func (m *DeleteShardGroupCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteShardGroupCommand.Unmarshal(m, b)
+}
+func (m *DeleteShardGroupCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteShardGroupCommand.Marshal(b, m, deterministic)
+}
+func (m *DeleteShardGroupCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteShardGroupCommand.Merge(m, src)
+}
+func (m *DeleteShardGroupCommand) XXX_Size() int {
+	return xxx_messageInfo_DeleteShardGroupCommand.Size(m)
+}
+func (m *DeleteShardGroupCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteShardGroupCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteShardGroupCommand proto.InternalMessageInfo
+
+func (m *DeleteShardGroupCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *DeleteShardGroupCommand) GetPolicy() string {
+	if m != nil && m.Policy != nil {
+		return *m.Policy
+	}
+	return ""
+}
+
+func (m *DeleteShardGroupCommand) GetShardGroupID() uint64 {
+	if m != nil && m.ShardGroupID != nil {
+		return *m.ShardGroupID
+	}
+	return 0
+}
+
+var E_DeleteShardGroupCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DeleteShardGroupCommand)(nil),
+	Field:         110,
+	Name:          "meta.DeleteShardGroupCommand.command",
+	Tag:           "bytes,110,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type CreateContinuousQueryCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+	Query                *string  `protobuf:"bytes,3,req,name=Query" json:"Query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateContinuousQueryCommand) Reset()         { *m = CreateContinuousQueryCommand{} }
+func (m *CreateContinuousQueryCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateContinuousQueryCommand) ProtoMessage()    {}
+func (*CreateContinuousQueryCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{31}
+}
+func (m *CreateContinuousQueryCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateContinuousQueryCommand.Unmarshal(m, b)
+}
+func (m *CreateContinuousQueryCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateContinuousQueryCommand.Marshal(b, m, deterministic)
+}
+func (m *CreateContinuousQueryCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateContinuousQueryCommand.Merge(m, src)
+}
+func (m *CreateContinuousQueryCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateContinuousQueryCommand.Size(m)
+}
+func (m *CreateContinuousQueryCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateContinuousQueryCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateContinuousQueryCommand proto.InternalMessageInfo
+
+func (m *CreateContinuousQueryCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *CreateContinuousQueryCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *CreateContinuousQueryCommand) GetQuery() string {
+	if m != nil && m.Query != nil {
+		return *m.Query
+	}
+	return ""
+}
+
+var E_CreateContinuousQueryCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateContinuousQueryCommand)(nil),
+	Field:         111,
+	Name:          "meta.CreateContinuousQueryCommand.command",
+	Tag:           "bytes,111,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type DropContinuousQueryCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropContinuousQueryCommand) Reset()         { *m = DropContinuousQueryCommand{} }
+func (m *DropContinuousQueryCommand) String() string { return proto.CompactTextString(m) }
+func (*DropContinuousQueryCommand) ProtoMessage()    {}
+func (*DropContinuousQueryCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{32}
+}
+func (m *DropContinuousQueryCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropContinuousQueryCommand.Unmarshal(m, b)
+}
+func (m *DropContinuousQueryCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropContinuousQueryCommand.Marshal(b, m, deterministic)
+}
+func (m *DropContinuousQueryCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropContinuousQueryCommand.Merge(m, src)
+}
+func (m *DropContinuousQueryCommand) XXX_Size() int {
+	return xxx_messageInfo_DropContinuousQueryCommand.Size(m)
+}
+func (m *DropContinuousQueryCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropContinuousQueryCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropContinuousQueryCommand proto.InternalMessageInfo
+
+func (m *DropContinuousQueryCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *DropContinuousQueryCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+var E_DropContinuousQueryCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DropContinuousQueryCommand)(nil),
+	Field:         112,
+	Name:          "meta.DropContinuousQueryCommand.command",
+	Tag:           "bytes,112,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type CreateUserCommand struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Hash                 *string  `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
+	Admin                *bool    `protobuf:"varint,3,req,name=Admin" json:"Admin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateUserCommand) Reset()         { *m = CreateUserCommand{} }
+func (m *CreateUserCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateUserCommand) ProtoMessage()    {}
+func (*CreateUserCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{33}
+}
+func (m *CreateUserCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateUserCommand.Unmarshal(m, b)
+}
+func (m *CreateUserCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateUserCommand.Marshal(b, m, deterministic)
+}
+func (m *CreateUserCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateUserCommand.Merge(m, src)
+}
+func (m *CreateUserCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateUserCommand.Size(m)
+}
+func (m *CreateUserCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateUserCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateUserCommand proto.InternalMessageInfo
+
+func (m *CreateUserCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *CreateUserCommand) GetHash() string {
+	if m != nil && m.Hash != nil {
+		return *m.Hash
+	}
+	return ""
+}
+
+func (m *CreateUserCommand) GetAdmin() bool {
+	if m != nil && m.Admin != nil {
+		return *m.Admin
+	}
+	return false
+}
+
+var E_CreateUserCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateUserCommand)(nil),
+	Field:         113,
+	Name:          "meta.CreateUserCommand.command",
+	Tag:           "bytes,113,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type DropUserCommand struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropUserCommand) Reset()         { *m = DropUserCommand{} }
+func (m *DropUserCommand) String() string { return proto.CompactTextString(m) }
+func (*DropUserCommand) ProtoMessage()    {}
+func (*DropUserCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{34}
+}
+func (m *DropUserCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropUserCommand.Unmarshal(m, b)
+}
+func (m *DropUserCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropUserCommand.Marshal(b, m, deterministic)
+}
+func (m *DropUserCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropUserCommand.Merge(m, src)
+}
+func (m *DropUserCommand) XXX_Size() int {
+	return xxx_messageInfo_DropUserCommand.Size(m)
+}
+func (m *DropUserCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropUserCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropUserCommand proto.InternalMessageInfo
+
+func (m *DropUserCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+var E_DropUserCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DropUserCommand)(nil),
+	Field:         114,
+	Name:          "meta.DropUserCommand.command",
+	Tag:           "bytes,114,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type UpdateUserCommand struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Hash                 *string  `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateUserCommand) Reset()         { *m = UpdateUserCommand{} }
+func (m *UpdateUserCommand) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserCommand) ProtoMessage()    {}
+func (*UpdateUserCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{35}
+}
+func (m *UpdateUserCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateUserCommand.Unmarshal(m, b)
+}
+func (m *UpdateUserCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateUserCommand.Marshal(b, m, deterministic)
+}
+func (m *UpdateUserCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserCommand.Merge(m, src)
+}
+func (m *UpdateUserCommand) XXX_Size() int {
+	return xxx_messageInfo_UpdateUserCommand.Size(m)
+}
+func (m *UpdateUserCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserCommand proto.InternalMessageInfo
+
+func (m *UpdateUserCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *UpdateUserCommand) GetHash() string {
+	if m != nil && m.Hash != nil {
+		return *m.Hash
+	}
+	return ""
+}
+
+var E_UpdateUserCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*UpdateUserCommand)(nil),
+	Field:         115,
+	Name:          "meta.UpdateUserCommand.command",
+	Tag:           "bytes,115,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type SetPrivilegeCommand struct {
+	Username             *string  `protobuf:"bytes,1,req,name=Username" json:"Username,omitempty"`
+	Database             *string  `protobuf:"bytes,2,req,name=Database" json:"Database,omitempty"`
+	Privilege            *int32   `protobuf:"varint,3,req,name=Privilege" json:"Privilege,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetPrivilegeCommand) Reset()         { *m = SetPrivilegeCommand{} }
+func (m *SetPrivilegeCommand) String() string { return proto.CompactTextString(m) }
+func (*SetPrivilegeCommand) ProtoMessage()    {}
+func (*SetPrivilegeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{36}
+}
+func (m *SetPrivilegeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetPrivilegeCommand.Unmarshal(m, b)
+}
+func (m *SetPrivilegeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetPrivilegeCommand.Marshal(b, m, deterministic)
+}
+func (m *SetPrivilegeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPrivilegeCommand.Merge(m, src)
+}
+func (m *SetPrivilegeCommand) XXX_Size() int {
+	return xxx_messageInfo_SetPrivilegeCommand.Size(m)
+}
+func (m *SetPrivilegeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPrivilegeCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetPrivilegeCommand proto.InternalMessageInfo
+
+func (m *SetPrivilegeCommand) GetUsername() string {
+	if m != nil && m.Username != nil {
+		return *m.Username
+	}
+	return ""
+}
+
+func (m *SetPrivilegeCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *SetPrivilegeCommand) GetPrivilege() int32 {
+	if m != nil && m.Privilege != nil {
+		return *m.Privilege
+	}
+	return 0
+}
+
+var E_SetPrivilegeCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*SetPrivilegeCommand)(nil),
+	Field:         116,
+	Name:          "meta.SetPrivilegeCommand.command",
+	Tag:           "bytes,116,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type SetDataCommand struct {
+	Data                 *Data    `protobuf:"bytes,1,req,name=Data" json:"Data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDataCommand) Reset()         { *m = SetDataCommand{} }
+func (m *SetDataCommand) String() string { return proto.CompactTextString(m) }
+func (*SetDataCommand) ProtoMessage()    {}
+func (*SetDataCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{37}
+}
+func (m *SetDataCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDataCommand.Unmarshal(m, b)
+}
+func (m *SetDataCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDataCommand.Marshal(b, m, deterministic)
+}
+func (m *SetDataCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDataCommand.Merge(m, src)
+}
+func (m *SetDataCommand) XXX_Size() int {
+	return xxx_messageInfo_SetDataCommand.Size(m)
+}
+func (m *SetDataCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDataCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDataCommand proto.InternalMessageInfo
+
+func (m *SetDataCommand) GetData() *Data {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
 
-func (m *CreateRetentionPolicyCommand) GetDefault() bool {
-	if m != nil && m.Default != nil {
-		return *m.Default
+var E_SetDataCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*SetDataCommand)(nil),
+	Field:         117,
+	Name:          "meta.SetDataCommand.command",
+	Tag:           "bytes,117,opt,name=command",
+	Filename:      "internal/meta.proto",
+}
+
+type SetAdminPrivilegeCommand struct {
+	Username             *string  `protobuf:"bytes,1,req,name=Username" json:"Username,omitempty"`
+	Admin                *bool    `protobuf:"varint,2,req,name=Admin" json:"Admin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAdminPrivilegeCommand) Reset()         { *m = SetAdminPrivilegeCommand{} }
+func (m *SetAdminPrivilegeCommand) String() string { return proto.CompactTextString(m) }
+func (*SetAdminPrivilegeCommand) ProtoMessage()    {}
+func (*SetAdminPrivilegeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{38}
+}
+func (m *SetAdminPrivilegeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAdminPrivilegeCommand.Unmarshal(m, b)
+}
+func (m *SetAdminPrivilegeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAdminPrivilegeCommand.Marshal(b, m, deterministic)
+}
+func (m *SetAdminPrivilegeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAdminPrivilegeCommand.Merge(m, src)
+}
+func (m *SetAdminPrivilegeCommand) XXX_Size() int {
+	return xxx_messageInfo_SetAdminPrivilegeCommand.Size(m)
+}
+func (m *SetAdminPrivilegeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAdminPrivilegeCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAdminPrivilegeCommand proto.InternalMessageInfo
+
+func (m *SetAdminPrivilegeCommand) GetUsername() string {
+	if m != nil && m.Username != nil {
+		return *m.Username
+	}
+	return ""
+}
+
+func (m *SetAdminPrivilegeCommand) GetAdmin() bool {
+	if m != nil && m.Admin != nil {
+		return *m.Admin
 	}
 	return false
 }
 
-var E_CreateRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+var E_SetAdminPrivilegeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateRetentionPolicyCommand)(nil),
-	Field:         105,
-	Name:          "meta.CreateRetentionPolicyCommand.command",
-	Tag:           "bytes,105,opt,name=command",
+	ExtensionType: (*SetAdminPrivilegeCommand)(nil),
+	Field:         118,
+	Name:          "meta.SetAdminPrivilegeCommand.command",
+	Tag:           "bytes,118,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DropRetentionPolicyCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+type UpdateNodeCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	Host                 *string  `protobuf:"bytes,2,req,name=Host" json:"Host,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DropRetentionPolicyCommand) Reset()         { *m = DropRetentionPolicyCommand{} }
-func (m *DropRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
-func (*DropRetentionPolicyCommand) ProtoMessage()    {}
-func (*DropRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{18}
+func (m *UpdateNodeCommand) Reset()         { *m = UpdateNodeCommand{} }
+func (m *UpdateNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeCommand) ProtoMessage()    {}
+func (*UpdateNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{39}
 }
-func (m *DropRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropRetentionPolicyCommand.Unmarshal(m, b)
+func (m *UpdateNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNodeCommand.Unmarshal(m, b)
 }
-func (m *DropRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DropRetentionPolicyCommand.Marshal(b, m, deterministic)
+func (m *UpdateNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *DropRetentionPolicyCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DropRetentionPolicyCommand.Merge(m, src)
+func (m *UpdateNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeCommand.Merge(m, src)
 }
-func (m *DropRetentionPolicyCommand) XXX_Size() int {
-	return xxx_messageInfo_DropRetentionPolicyCommand.Size(m)
+func (m *UpdateNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_UpdateNodeCommand.Size(m)
 }
-func (m *DropRetentionPolicyCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DropRetentionPolicyCommand.DiscardUnknown(m)
+func (m *UpdateNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNodeCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DropRetentionPolicyCommand proto.InternalMessageInfo
+var xxx_messageInfo_UpdateNodeCommand proto.InternalMessageInfo
 
-func (m *DropRetentionPolicyCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *UpdateNodeCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *DropRetentionPolicyCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *UpdateNodeCommand) GetHost() string {
+	if m != nil && m.Host != nil {
+		return *m.Host
 	}
 	return ""
 }
 
-var E_DropRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+var E_UpdateNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DropRetentionPolicyCommand)(nil),
-	Field:         106,
-	Name:          "meta.DropRetentionPolicyCommand.command",
-	Tag:           "bytes,106,opt,name=command",
+	ExtensionType: (*UpdateNodeCommand)(nil),
+	Field:         119,
+	Name:          "meta.UpdateNodeCommand.command",
+	Tag:           "bytes,119,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type SetDefaultRetentionPolicyCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+type CreateSubscriptionCommand struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Database             *string  `protobuf:"bytes,2,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,3,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Mode                 *string  `protobuf:"bytes,4,req,name=Mode" json:"Mode,omitempty"`
+	Destinations         []string `protobuf:"bytes,5,rep,name=Destinations" json:"Destinations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetDefaultRetentionPolicyCommand) Reset()         { *m = SetDefaultRetentionPolicyCommand{} }
-func (m *SetDefaultRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
-func (*SetDefaultRetentionPolicyCommand) ProtoMessage()    {}
-func (*SetDefaultRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{19}
+func (m *CreateSubscriptionCommand) Reset()         { *m = CreateSubscriptionCommand{} }
+func (m *CreateSubscriptionCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateSubscriptionCommand) ProtoMessage()    {}
+func (*CreateSubscriptionCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{40}
 }
-func (m *SetDefaultRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetDefaultRetentionPolicyCommand.Unmarshal(m, b)
+func (m *CreateSubscriptionCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateSubscriptionCommand.Unmarshal(m, b)
 }
-func (m *SetDefaultRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetDefaultRetentionPolicyCommand.Marshal(b, m, deterministic)
+func (m *CreateSubscriptionCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateSubscriptionCommand.Marshal(b, m, deterministic)
 }
-func (m *SetDefaultRetentionPolicyCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetDefaultRetentionPolicyCommand.Merge(m, src)
+func (m *CreateSubscriptionCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateSubscriptionCommand.Merge(m, src)
 }
-func (m *SetDefaultRetentionPolicyCommand) XXX_Size() int {
-	return xxx_messageInfo_SetDefaultRetentionPolicyCommand.Size(m)
+func (m *CreateSubscriptionCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateSubscriptionCommand.Size(m)
 }
-func (m *SetDefaultRetentionPolicyCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetDefaultRetentionPolicyCommand.DiscardUnknown(m)
+func (m *CreateSubscriptionCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateSubscriptionCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetDefaultRetentionPolicyCommand proto.InternalMessageInfo
+var xxx_messageInfo_CreateSubscriptionCommand proto.InternalMessageInfo
 
-func (m *SetDefaultRetentionPolicyCommand) GetDatabase() string {
+func (m *CreateSubscriptionCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *CreateSubscriptionCommand) GetDatabase() string {
 	if m != nil && m.Database != nil {
 		return *m.Database
 	}
 	return ""
 }
 
-func (m *SetDefaultRetentionPolicyCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *CreateSubscriptionCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
+	}
+	return ""
+}
+
+func (m *CreateSubscriptionCommand) GetMode() string {
+	if m != nil && m.Mode != nil {
+		return *m.Mode
 	}
 	return ""
 }
 
-var E_SetDefaultRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+func (m *CreateSubscriptionCommand) GetDestinations() []string {
+	if m != nil {
+		return m.Destinations
+	}
+	return nil
+}
+
+var E_CreateSubscriptionCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*SetDefaultRetentionPolicyCommand)(nil),
-	Field:         107,
-	Name:          "meta.SetDefaultRetentionPolicyCommand.command",
-	Tag:           "bytes,107,opt,name=command",
+	ExtensionType: (*CreateSubscriptionCommand)(nil),
+	Field:         121,
+	Name:          "meta.CreateSubscriptionCommand.command",
+	Tag:           "bytes,121,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type UpdateRetentionPolicyCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
-	NewName              *string  `protobuf:"bytes,3,opt,name=NewName" json:"NewName,omitempty"`
-	Duration             *int64   `protobuf:"varint,4,opt,name=Duration" json:"Duration,omitempty"`
-	ReplicaN             *uint32  `protobuf:"varint,5,opt,name=ReplicaN" json:"ReplicaN,omitempty"`
-	ShardGroupDuration   *int64   `protobuf:"varint,6,opt,name=ShardGroupDuration" json:"ShardGroupDuration,omitempty"`
-	Default              *bool    `protobuf:"varint,7,opt,name=Default" json:"Default,omitempty"`
+type DropSubscriptionCommand struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Database             *string  `protobuf:"bytes,2,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,3,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateRetentionPolicyCommand) Reset()         { *m = UpdateRetentionPolicyCommand{} }
-func (m *UpdateRetentionPolicyCommand) String() string { return proto.CompactTextString(m) }
-func (*UpdateRetentionPolicyCommand) ProtoMessage()    {}
-func (*UpdateRetentionPolicyCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{20}
+func (m *DropSubscriptionCommand) Reset()         { *m = DropSubscriptionCommand{} }
+func (m *DropSubscriptionCommand) String() string { return proto.CompactTextString(m) }
+func (*DropSubscriptionCommand) ProtoMessage()    {}
+func (*DropSubscriptionCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{41}
 }
-func (m *UpdateRetentionPolicyCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateRetentionPolicyCommand.Unmarshal(m, b)
+func (m *DropSubscriptionCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropSubscriptionCommand.Unmarshal(m, b)
 }
-func (m *UpdateRetentionPolicyCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateRetentionPolicyCommand.Marshal(b, m, deterministic)
+func (m *DropSubscriptionCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropSubscriptionCommand.Marshal(b, m, deterministic)
 }
-func (m *UpdateRetentionPolicyCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateRetentionPolicyCommand.Merge(m, src)
+func (m *DropSubscriptionCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropSubscriptionCommand.Merge(m, src)
 }
-func (m *UpdateRetentionPolicyCommand) XXX_Size() int {
-	return xxx_messageInfo_UpdateRetentionPolicyCommand.Size(m)
+func (m *DropSubscriptionCommand) XXX_Size() int {
+	return xxx_messageInfo_DropSubscriptionCommand.Size(m)
 }
-func (m *UpdateRetentionPolicyCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateRetentionPolicyCommand.DiscardUnknown(m)
+func (m *DropSubscriptionCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropSubscriptionCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateRetentionPolicyCommand proto.InternalMessageInfo
-
-func (m *UpdateRetentionPolicyCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
-	}
-	return ""
-}
+var xxx_messageInfo_DropSubscriptionCommand proto.InternalMessageInfo
 
-func (m *UpdateRetentionPolicyCommand) GetName() string {
+func (m *DropSubscriptionCommand) GetName() string {
 	if m != nil && m.Name != nil {
 		return *m.Name
 	}
 	return ""
 }
 
-func (m *UpdateRetentionPolicyCommand) GetNewName() string {
-	if m != nil && m.NewName != nil {
-		return *m.NewName
+func (m *DropSubscriptionCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
 	return ""
 }
 
-func (m *UpdateRetentionPolicyCommand) GetDuration() int64 {
-	if m != nil && m.Duration != nil {
-		return *m.Duration
-	}
-	return 0
-}
-
-func (m *UpdateRetentionPolicyCommand) GetReplicaN() uint32 {
-	if m != nil && m.ReplicaN != nil {
-		return *m.ReplicaN
-	}
-	return 0
-}
-
-func (m *UpdateRetentionPolicyCommand) GetShardGroupDuration() int64 {
-	if m != nil && m.ShardGroupDuration != nil {
-		return *m.ShardGroupDuration
-	}
-	return 0
-}
-
-func (m *UpdateRetentionPolicyCommand) GetDefault() bool {
-	if m != nil && m.Default != nil {
-		return *m.Default
+func (m *DropSubscriptionCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
 	}
-	return false
+	return ""
 }
 
-var E_UpdateRetentionPolicyCommand_Command = &proto.ExtensionDesc{
+var E_DropSubscriptionCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*UpdateRetentionPolicyCommand)(nil),
-	Field:         108,
-	Name:          "meta.UpdateRetentionPolicyCommand.command",
-	Tag:           "bytes,108,opt,name=command",
+	ExtensionType: (*DropSubscriptionCommand)(nil),
+	Field:         122,
+	Name:          "meta.DropSubscriptionCommand.command",
+	Tag:           "bytes,122,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CreateShardGroupCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Policy               *string  `protobuf:"bytes,2,req,name=Policy" json:"Policy,omitempty"`
-	Timestamp            *int64   `protobuf:"varint,3,req,name=Timestamp" json:"Timestamp,omitempty"`
+type RemovePeerCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
+	Addr                 *string  `protobuf:"bytes,2,req,name=Addr" json:"Addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateShardGroupCommand) Reset()         { *m = CreateShardGroupCommand{} }
-func (m *CreateShardGroupCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateShardGroupCommand) ProtoMessage()    {}
-func (*CreateShardGroupCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{21}
+func (m *RemovePeerCommand) Reset()         { *m = RemovePeerCommand{} }
+func (m *RemovePeerCommand) String() string { return proto.CompactTextString(m) }
+func (*RemovePeerCommand) ProtoMessage()    {}
+func (*RemovePeerCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{42}
 }
-func (m *CreateShardGroupCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateShardGroupCommand.Unmarshal(m, b)
+func (m *RemovePeerCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemovePeerCommand.Unmarshal(m, b)
 }
-func (m *CreateShardGroupCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateShardGroupCommand.Marshal(b, m, deterministic)
+func (m *RemovePeerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemovePeerCommand.Marshal(b, m, deterministic)
 }
-func (m *CreateShardGroupCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateShardGroupCommand.Merge(m, src)
+func (m *RemovePeerCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemovePeerCommand.Merge(m, src)
 }
-func (m *CreateShardGroupCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateShardGroupCommand.Size(m)
+func (m *RemovePeerCommand) XXX_Size() int {
+	return xxx_messageInfo_RemovePeerCommand.Size(m)
 }
-func (m *CreateShardGroupCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateShardGroupCommand.DiscardUnknown(m)
+func (m *RemovePeerCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemovePeerCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateShardGroupCommand proto.InternalMessageInfo
+var xxx_messageInfo_RemovePeerCommand proto.InternalMessageInfo
 
-func (m *CreateShardGroupCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *RemovePeerCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateShardGroupCommand) GetPolicy() string {
-	if m != nil && m.Policy != nil {
-		return *m.Policy
+func (m *RemovePeerCommand) GetAddr() string {
+	if m != nil && m.Addr != nil {
+		return *m.Addr
 	}
 	return ""
 }
 
-func (m *CreateShardGroupCommand) GetTimestamp() int64 {
-	if m != nil && m.Timestamp != nil {
-		return *m.Timestamp
-	}
-	return 0
-}
-
-var E_CreateShardGroupCommand_Command = &proto.ExtensionDesc{
+var E_RemovePeerCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateShardGroupCommand)(nil),
-	Field:         109,
-	Name:          "meta.CreateShardGroupCommand.command",
-	Tag:           "bytes,109,opt,name=command",
+	ExtensionType: (*RemovePeerCommand)(nil),
+	Field:         123,
+	Name:          "meta.RemovePeerCommand.command",
+	Tag:           "bytes,123,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DeleteShardGroupCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Policy               *string  `protobuf:"bytes,2,req,name=Policy" json:"Policy,omitempty"`
-	ShardGroupID         *uint64  `protobuf:"varint,3,req,name=ShardGroupID" json:"ShardGroupID,omitempty"`
+type CreateMetaNodeCommand struct {
+	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
+	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
+	Rand                 *uint64  `protobuf:"varint,3,req,name=Rand" json:"Rand,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteShardGroupCommand) Reset()         { *m = DeleteShardGroupCommand{} }
-func (m *DeleteShardGroupCommand) String() string { return proto.CompactTextString(m) }
-func (*DeleteShardGroupCommand) ProtoMessage()    {}
-func (*DeleteShardGroupCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{22}
+func (m *CreateMetaNodeCommand) Reset()         { *m = CreateMetaNodeCommand{} }
+func (m *CreateMetaNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateMetaNodeCommand) ProtoMessage()    {}
+func (*CreateMetaNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{43}
 }
-func (m *DeleteShardGroupCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteShardGroupCommand.Unmarshal(m, b)
+func (m *CreateMetaNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMetaNodeCommand.Unmarshal(m, b)
 }
-func (m *DeleteShardGroupCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteShardGroupCommand.Marshal(b, m, deterministic)
+func (m *CreateMetaNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMetaNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *DeleteShardGroupCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteShardGroupCommand.Merge(m, src)
+func (m *CreateMetaNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMetaNodeCommand.Merge(m, src)
 }
-func (m *DeleteShardGroupCommand) XXX_Size() int {
-	return xxx_messageInfo_DeleteShardGroupCommand.Size(m)
+func (m *CreateMetaNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateMetaNodeCommand.Size(m)
 }
-func (m *DeleteShardGroupCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteShardGroupCommand.DiscardUnknown(m)
+func (m *CreateMetaNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMetaNodeCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteShardGroupCommand proto.InternalMessageInfo
+var xxx_messageInfo_CreateMetaNodeCommand proto.InternalMessageInfo
 
-func (m *DeleteShardGroupCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *CreateMetaNodeCommand) GetHTTPAddr() string {
+	if m != nil && m.HTTPAddr != nil {
+		return *m.HTTPAddr
 	}
 	return ""
 }
 
-func (m *DeleteShardGroupCommand) GetPolicy() string {
-	if m != nil && m.Policy != nil {
-		return *m.Policy
+func (m *CreateMetaNodeCommand) GetTCPAddr() string {
+	if m != nil && m.TCPAddr != nil {
+		return *m.TCPAddr
 	}
 	return ""
 }
 
-func (m *DeleteShardGroupCommand) GetShardGroupID() uint64 {
-	if m != nil && m.ShardGroupID != nil {
-		return *m.ShardGroupID
+func (m *CreateMetaNodeCommand) GetRand() uint64 {
+	if m != nil && m.Rand != nil {
+		return *m.Rand
 	}
 	return 0
 }
-
-var E_DeleteShardGroupCommand_Command = &proto.ExtensionDesc{
-	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DeleteShardGroupCommand)(nil),
-	Field:         110,
-	Name:          "meta.DeleteShardGroupCommand.command",
-	Tag:           "bytes,110,opt,name=command",
+
+var E_CreateMetaNodeCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*CreateMetaNodeCommand)(nil),
+	Field:         124,
+	Name:          "meta.CreateMetaNodeCommand.command",
+	Tag:           "bytes,124,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CreateContinuousQueryCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
-	Query                *string  `protobuf:"bytes,3,req,name=Query" json:"Query,omitempty"`
+type CreateDataNodeCommand struct {
+	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
+	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateContinuousQueryCommand) Reset()         { *m = CreateContinuousQueryCommand{} }
-func (m *CreateContinuousQueryCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateContinuousQueryCommand) ProtoMessage()    {}
-func (*CreateContinuousQueryCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{23}
+func (m *CreateDataNodeCommand) Reset()         { *m = CreateDataNodeCommand{} }
+func (m *CreateDataNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateDataNodeCommand) ProtoMessage()    {}
+func (*CreateDataNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{44}
 }
-func (m *CreateContinuousQueryCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateContinuousQueryCommand.Unmarshal(m, b)
+func (m *CreateDataNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDataNodeCommand.Unmarshal(m, b)
 }
-func (m *CreateContinuousQueryCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateContinuousQueryCommand.Marshal(b, m, deterministic)
+func (m *CreateDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDataNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *CreateContinuousQueryCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateContinuousQueryCommand.Merge(m, src)
+func (m *CreateDataNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDataNodeCommand.Merge(m, src)
 }
-func (m *CreateContinuousQueryCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateContinuousQueryCommand.Size(m)
+func (m *CreateDataNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateDataNodeCommand.Size(m)
 }
-func (m *CreateContinuousQueryCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateContinuousQueryCommand.DiscardUnknown(m)
+func (m *CreateDataNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDataNodeCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateContinuousQueryCommand proto.InternalMessageInfo
-
-func (m *CreateContinuousQueryCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
-	}
-	return ""
-}
+var xxx_messageInfo_CreateDataNodeCommand proto.InternalMessageInfo
 
-func (m *CreateContinuousQueryCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *CreateDataNodeCommand) GetHTTPAddr() string {
+	if m != nil && m.HTTPAddr != nil {
+		return *m.HTTPAddr
 	}
 	return ""
 }
 
-func (m *CreateContinuousQueryCommand) GetQuery() string {
-	if m != nil && m.Query != nil {
-		return *m.Query
+func (m *CreateDataNodeCommand) GetTCPAddr() string {
+	if m != nil && m.TCPAddr != nil {
+		return *m.TCPAddr
 	}
 	return ""
 }
 
-var E_CreateContinuousQueryCommand_Command = &proto.ExtensionDesc{
+var E_CreateDataNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateContinuousQueryCommand)(nil),
-	Field:         111,
-	Name:          "meta.CreateContinuousQueryCommand.command",
-	Tag:           "bytes,111,opt,name=command",
+	ExtensionType: (*CreateDataNodeCommand)(nil),
+	Field:         125,
+	Name:          "meta.CreateDataNodeCommand.command",
+	Tag:           "bytes,125,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DropContinuousQueryCommand struct {
-	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
-	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
+type UpdateDataNodeCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	HTTPAddr             *string  `protobuf:"bytes,2,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
+	TCPAddr              *string  `protobuf:"bytes,3,req,name=TCPAddr" json:"TCPAddr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DropContinuousQueryCommand) Reset()         { *m = DropContinuousQueryCommand{} }
-func (m *DropContinuousQueryCommand) String() string { return proto.CompactTextString(m) }
-func (*DropContinuousQueryCommand) ProtoMessage()    {}
-func (*DropContinuousQueryCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{24}
+func (m *UpdateDataNodeCommand) Reset()         { *m = UpdateDataNodeCommand{} }
+func (m *UpdateDataNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*UpdateDataNodeCommand) ProtoMessage()    {}
+func (*UpdateDataNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{45}
 }
-func (m *DropContinuousQueryCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropContinuousQueryCommand.Unmarshal(m, b)
+func (m *UpdateDataNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateDataNodeCommand.Unmarshal(m, b)
 }
-func (m *DropContinuousQueryCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DropContinuousQueryCommand.Marshal(b, m, deterministic)
+func (m *UpdateDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateDataNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *DropContinuousQueryCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DropContinuousQueryCommand.Merge(m, src)
+func (m *UpdateDataNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDataNodeCommand.Merge(m, src)
 }
-func (m *DropContinuousQueryCommand) XXX_Size() int {
-	return xxx_messageInfo_DropContinuousQueryCommand.Size(m)
+func (m *UpdateDataNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_UpdateDataNodeCommand.Size(m)
 }
-func (m *DropContinuousQueryCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DropContinuousQueryCommand.DiscardUnknown(m)
+func (m *UpdateDataNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDataNodeCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DropContinuousQueryCommand proto.InternalMessageInfo
+var xxx_messageInfo_UpdateDataNodeCommand proto.InternalMessageInfo
 
-func (m *DropContinuousQueryCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *UpdateDataNodeCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
+	}
+	return 0
+}
+
+func (m *UpdateDataNodeCommand) GetHTTPAddr() string {
+	if m != nil && m.HTTPAddr != nil {
+		return *m.HTTPAddr
 	}
 	return ""
 }
 
-func (m *DropContinuousQueryCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *UpdateDataNodeCommand) GetTCPAddr() string {
+	if m != nil && m.TCPAddr != nil {
+		return *m.TCPAddr
 	}
 	return ""
 }
 
-var E_DropContinuousQueryCommand_Command = &proto.ExtensionDesc{
+var E_UpdateDataNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DropContinuousQueryCommand)(nil),
-	Field:         112,
-	Name:          "meta.DropContinuousQueryCommand.command",
-	Tag:           "bytes,112,opt,name=command",
+	ExtensionType: (*UpdateDataNodeCommand)(nil),
+	Field:         126,
+	Name:          "meta.UpdateDataNodeCommand.command",
+	Tag:           "bytes,126,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CreateUserCommand struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Hash                 *string  `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
-	Admin                *bool    `protobuf:"varint,3,req,name=Admin" json:"Admin,omitempty"`
+type DeleteMetaNodeCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateUserCommand) Reset()         { *m = CreateUserCommand{} }
-func (m *CreateUserCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateUserCommand) ProtoMessage()    {}
-func (*CreateUserCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{25}
-}
-func (m *CreateUserCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateUserCommand.Unmarshal(m, b)
+func (m *DeleteMetaNodeCommand) Reset()         { *m = DeleteMetaNodeCommand{} }
+func (m *DeleteMetaNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*DeleteMetaNodeCommand) ProtoMessage()    {}
+func (*DeleteMetaNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{46}
 }
-func (m *CreateUserCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateUserCommand.Marshal(b, m, deterministic)
+func (m *DeleteMetaNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMetaNodeCommand.Unmarshal(m, b)
 }
-func (m *CreateUserCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateUserCommand.Merge(m, src)
+func (m *DeleteMetaNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMetaNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *CreateUserCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateUserCommand.Size(m)
+func (m *DeleteMetaNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMetaNodeCommand.Merge(m, src)
 }
-func (m *CreateUserCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateUserCommand.DiscardUnknown(m)
+func (m *DeleteMetaNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_DeleteMetaNodeCommand.Size(m)
 }
-
-var xxx_messageInfo_CreateUserCommand proto.InternalMessageInfo
-
-func (m *CreateUserCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
-	}
-	return ""
+func (m *DeleteMetaNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMetaNodeCommand.DiscardUnknown(m)
 }
 
-func (m *CreateUserCommand) GetHash() string {
-	if m != nil && m.Hash != nil {
-		return *m.Hash
-	}
-	return ""
-}
+var xxx_messageInfo_DeleteMetaNodeCommand proto.InternalMessageInfo
 
-func (m *CreateUserCommand) GetAdmin() bool {
-	if m != nil && m.Admin != nil {
-		return *m.Admin
+func (m *DeleteMetaNodeCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return false
+	return 0
 }
 
-var E_CreateUserCommand_Command = &proto.ExtensionDesc{
+var E_DeleteMetaNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateUserCommand)(nil),
-	Field:         113,
-	Name:          "meta.CreateUserCommand.command",
-	Tag:           "bytes,113,opt,name=command",
+	ExtensionType: (*DeleteMetaNodeCommand)(nil),
+	Field:         127,
+	Name:          "meta.DeleteMetaNodeCommand.command",
+	Tag:           "bytes,127,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DropUserCommand struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+type DeleteDataNodeCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DropUserCommand) Reset()         { *m = DropUserCommand{} }
-func (m *DropUserCommand) String() string { return proto.CompactTextString(m) }
-func (*DropUserCommand) ProtoMessage()    {}
-func (*DropUserCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{26}
+func (m *DeleteDataNodeCommand) Reset()         { *m = DeleteDataNodeCommand{} }
+func (m *DeleteDataNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*DeleteDataNodeCommand) ProtoMessage()    {}
+func (*DeleteDataNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{47}
 }
-func (m *DropUserCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropUserCommand.Unmarshal(m, b)
+func (m *DeleteDataNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDataNodeCommand.Unmarshal(m, b)
 }
-func (m *DropUserCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DropUserCommand.Marshal(b, m, deterministic)
+func (m *DeleteDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDataNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *DropUserCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DropUserCommand.Merge(m, src)
+func (m *DeleteDataNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDataNodeCommand.Merge(m, src)
 }
-func (m *DropUserCommand) XXX_Size() int {
-	return xxx_messageInfo_DropUserCommand.Size(m)
+func (m *DeleteDataNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_DeleteDataNodeCommand.Size(m)
 }
-func (m *DropUserCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DropUserCommand.DiscardUnknown(m)
+func (m *DeleteDataNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDataNodeCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DropUserCommand proto.InternalMessageInfo
+var xxx_messageInfo_DeleteDataNodeCommand proto.InternalMessageInfo
 
-func (m *DropUserCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *DeleteDataNodeCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return ""
+	return 0
 }
 
-var E_DropUserCommand_Command = &proto.ExtensionDesc{
+var E_DeleteDataNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DropUserCommand)(nil),
-	Field:         114,
-	Name:          "meta.DropUserCommand.command",
-	Tag:           "bytes,114,opt,name=command",
+	ExtensionType: (*DeleteDataNodeCommand)(nil),
+	Field:         128,
+	Name:          "meta.DeleteDataNodeCommand.command",
+	Tag:           "bytes,128,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type UpdateUserCommand struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Hash                 *string  `protobuf:"bytes,2,req,name=Hash" json:"Hash,omitempty"`
+type Response struct {
+	OK                   *bool    `protobuf:"varint,1,req,name=OK" json:"OK,omitempty"`
+	Error                *string  `protobuf:"bytes,2,opt,name=Error" json:"Error,omitempty"`
+	Index                *uint64  `protobuf:"varint,3,opt,name=Index" json:"Index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateUserCommand) Reset()         { *m = UpdateUserCommand{} }
-func (m *UpdateUserCommand) String() string { return proto.CompactTextString(m) }
-func (*UpdateUserCommand) ProtoMessage()    {}
-func (*UpdateUserCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{27}
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{48}
 }
-func (m *UpdateUserCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateUserCommand.Unmarshal(m, b)
+func (m *Response) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Response.Unmarshal(m, b)
 }
-func (m *UpdateUserCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateUserCommand.Marshal(b, m, deterministic)
+func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Response.Marshal(b, m, deterministic)
 }
-func (m *UpdateUserCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateUserCommand.Merge(m, src)
+func (m *Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response.Merge(m, src)
 }
-func (m *UpdateUserCommand) XXX_Size() int {
-	return xxx_messageInfo_UpdateUserCommand.Size(m)
+func (m *Response) XXX_Size() int {
+	return xxx_messageInfo_Response.Size(m)
 }
-func (m *UpdateUserCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateUserCommand.DiscardUnknown(m)
+func (m *Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateUserCommand proto.InternalMessageInfo
+var xxx_messageInfo_Response proto.InternalMessageInfo
 
-func (m *UpdateUserCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (m *Response) GetOK() bool {
+	if m != nil && m.OK != nil {
+		return *m.OK
 	}
-	return ""
+	return false
 }
 
-func (m *UpdateUserCommand) GetHash() string {
-	if m != nil && m.Hash != nil {
-		return *m.Hash
+func (m *Response) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
 	return ""
 }
 
-var E_UpdateUserCommand_Command = &proto.ExtensionDesc{
-	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*UpdateUserCommand)(nil),
-	Field:         115,
-	Name:          "meta.UpdateUserCommand.command",
-	Tag:           "bytes,115,opt,name=command",
-	Filename:      "internal/meta.proto",
+func (m *Response) GetIndex() uint64 {
+	if m != nil && m.Index != nil {
+		return *m.Index
+	}
+	return 0
 }
 
-type SetPrivilegeCommand struct {
-	Username             *string  `protobuf:"bytes,1,req,name=Username" json:"Username,omitempty"`
-	Database             *string  `protobuf:"bytes,2,req,name=Database" json:"Database,omitempty"`
-	Privilege            *int32   `protobuf:"varint,3,req,name=Privilege" json:"Privilege,omitempty"`
+// SetMetaNodeCommand is for the initial metanode in a cluster or
+// if the single host restarts and its hostname changes, this will update it
+type SetMetaNodeCommand struct {
+	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
+	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
+	Rand                 *uint64  `protobuf:"varint,3,req,name=Rand" json:"Rand,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetPrivilegeCommand) Reset()         { *m = SetPrivilegeCommand{} }
-func (m *SetPrivilegeCommand) String() string { return proto.CompactTextString(m) }
-func (*SetPrivilegeCommand) ProtoMessage()    {}
-func (*SetPrivilegeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{28}
+func (m *SetMetaNodeCommand) Reset()         { *m = SetMetaNodeCommand{} }
+func (m *SetMetaNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*SetMetaNodeCommand) ProtoMessage()    {}
+func (*SetMetaNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{49}
 }
-func (m *SetPrivilegeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetPrivilegeCommand.Unmarshal(m, b)
+func (m *SetMetaNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMetaNodeCommand.Unmarshal(m, b)
 }
-func (m *SetPrivilegeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetPrivilegeCommand.Marshal(b, m, deterministic)
+func (m *SetMetaNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMetaNodeCommand.Marshal(b, m, deterministic)
 }
-func (m *SetPrivilegeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetPrivilegeCommand.Merge(m, src)
+func (m *SetMetaNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMetaNodeCommand.Merge(m, src)
 }
-func (m *SetPrivilegeCommand) XXX_Size() int {
-	return xxx_messageInfo_SetPrivilegeCommand.Size(m)
+func (m *SetMetaNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_SetMetaNodeCommand.Size(m)
 }
-func (m *SetPrivilegeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetPrivilegeCommand.DiscardUnknown(m)
+func (m *SetMetaNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMetaNodeCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetPrivilegeCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetMetaNodeCommand proto.InternalMessageInfo
 
-func (m *SetPrivilegeCommand) GetUsername() string {
-	if m != nil && m.Username != nil {
-		return *m.Username
+func (m *SetMetaNodeCommand) GetHTTPAddr() string {
+	if m != nil && m.HTTPAddr != nil {
+		return *m.HTTPAddr
 	}
 	return ""
 }
 
-func (m *SetPrivilegeCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *SetMetaNodeCommand) GetTCPAddr() string {
+	if m != nil && m.TCPAddr != nil {
+		return *m.TCPAddr
 	}
 	return ""
 }
 
-func (m *SetPrivilegeCommand) GetPrivilege() int32 {
-	if m != nil && m.Privilege != nil {
-		return *m.Privilege
+func (m *SetMetaNodeCommand) GetRand() uint64 {
+	if m != nil && m.Rand != nil {
+		return *m.Rand
 	}
 	return 0
 }
 
-var E_SetPrivilegeCommand_Command = &proto.ExtensionDesc{
+var E_SetMetaNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*SetPrivilegeCommand)(nil),
-	Field:         116,
-	Name:          "meta.SetPrivilegeCommand.command",
-	Tag:           "bytes,116,opt,name=command",
+	ExtensionType: (*SetMetaNodeCommand)(nil),
+	Field:         129,
+	Name:          "meta.SetMetaNodeCommand.command",
+	Tag:           "bytes,129,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type SetDataCommand struct {
-	Data                 *Data    `protobuf:"bytes,1,req,name=Data" json:"Data,omitempty"`
+type DropShardCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetDataCommand) Reset()         { *m = SetDataCommand{} }
-func (m *SetDataCommand) String() string { return proto.CompactTextString(m) }
-func (*SetDataCommand) ProtoMessage()    {}
-func (*SetDataCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{29}
+func (m *DropShardCommand) Reset()         { *m = DropShardCommand{} }
+func (m *DropShardCommand) String() string { return proto.CompactTextString(m) }
+func (*DropShardCommand) ProtoMessage()    {}
+func (*DropShardCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{50}
 }
-func (m *SetDataCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetDataCommand.Unmarshal(m, b)
+func (m *DropShardCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropShardCommand.Unmarshal(m, b)
 }
-func (m *SetDataCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetDataCommand.Marshal(b, m, deterministic)
+func (m *DropShardCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropShardCommand.Marshal(b, m, deterministic)
 }
-func (m *SetDataCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetDataCommand.Merge(m, src)
+func (m *DropShardCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropShardCommand.Merge(m, src)
 }
-func (m *SetDataCommand) XXX_Size() int {
-	return xxx_messageInfo_SetDataCommand.Size(m)
+func (m *DropShardCommand) XXX_Size() int {
+	return xxx_messageInfo_DropShardCommand.Size(m)
 }
-func (m *SetDataCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetDataCommand.DiscardUnknown(m)
+func (m *DropShardCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropShardCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetDataCommand proto.InternalMessageInfo
+var xxx_messageInfo_DropShardCommand proto.InternalMessageInfo
 
-func (m *SetDataCommand) GetData() *Data {
-	if m != nil {
-		return m.Data
+func (m *DropShardCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return nil
+	return 0
 }
 
-var E_SetDataCommand_Command = &proto.ExtensionDesc{
+var E_DropShardCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*SetDataCommand)(nil),
-	Field:         117,
-	Name:          "meta.SetDataCommand.command",
-	Tag:           "bytes,117,opt,name=command",
+	ExtensionType: (*DropShardCommand)(nil),
+	Field:         130,
+	Name:          "meta.DropShardCommand.command",
+	Tag:           "bytes,130,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type SetAdminPrivilegeCommand struct {
-	Username             *string  `protobuf:"bytes,1,req,name=Username" json:"Username,omitempty"`
-	Admin                *bool    `protobuf:"varint,2,req,name=Admin" json:"Admin,omitempty"`
+type TruncateShardGroupsCommand struct {
+	Timestamp            *int64   `protobuf:"varint,1,req,name=Timestamp" json:"Timestamp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetAdminPrivilegeCommand) Reset()         { *m = SetAdminPrivilegeCommand{} }
-func (m *SetAdminPrivilegeCommand) String() string { return proto.CompactTextString(m) }
-func (*SetAdminPrivilegeCommand) ProtoMessage()    {}
-func (*SetAdminPrivilegeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{30}
+func (m *TruncateShardGroupsCommand) Reset()         { *m = TruncateShardGroupsCommand{} }
+func (m *TruncateShardGroupsCommand) String() string { return proto.CompactTextString(m) }
+func (*TruncateShardGroupsCommand) ProtoMessage()    {}
+func (*TruncateShardGroupsCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{51}
 }
-func (m *SetAdminPrivilegeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetAdminPrivilegeCommand.Unmarshal(m, b)
+func (m *TruncateShardGroupsCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TruncateShardGroupsCommand.Unmarshal(m, b)
 }
-func (m *SetAdminPrivilegeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetAdminPrivilegeCommand.Marshal(b, m, deterministic)
+func (m *TruncateShardGroupsCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TruncateShardGroupsCommand.Marshal(b, m, deterministic)
 }
-func (m *SetAdminPrivilegeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetAdminPrivilegeCommand.Merge(m, src)
+func (m *TruncateShardGroupsCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TruncateShardGroupsCommand.Merge(m, src)
 }
-func (m *SetAdminPrivilegeCommand) XXX_Size() int {
-	return xxx_messageInfo_SetAdminPrivilegeCommand.Size(m)
+func (m *TruncateShardGroupsCommand) XXX_Size() int {
+	return xxx_messageInfo_TruncateShardGroupsCommand.Size(m)
 }
-func (m *SetAdminPrivilegeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetAdminPrivilegeCommand.DiscardUnknown(m)
+func (m *TruncateShardGroupsCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_TruncateShardGroupsCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetAdminPrivilegeCommand proto.InternalMessageInfo
-
-func (m *SetAdminPrivilegeCommand) GetUsername() string {
-	if m != nil && m.Username != nil {
-		return *m.Username
-	}
-	return ""
-}
+var xxx_messageInfo_TruncateShardGroupsCommand proto.InternalMessageInfo
 
-func (m *SetAdminPrivilegeCommand) GetAdmin() bool {
-	if m != nil && m.Admin != nil {
-		return *m.Admin
+func (m *TruncateShardGroupsCommand) GetTimestamp() int64 {
+	if m != nil && m.Timestamp != nil {
+		return *m.Timestamp
 	}
-	return false
+	return 0
 }
 
-var E_SetAdminPrivilegeCommand_Command = &proto.ExtensionDesc{
+var E_TruncateShardGroupsCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*SetAdminPrivilegeCommand)(nil),
-	Field:         118,
-	Name:          "meta.SetAdminPrivilegeCommand.command",
-	Tag:           "bytes,118,opt,name=command",
+	ExtensionType: (*TruncateShardGroupsCommand)(nil),
+	Field:         131,
+	Name:          "meta.TruncateShardGroupsCommand.command",
+	Tag:           "bytes,131,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type UpdateNodeCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
-	Host                 *string  `protobuf:"bytes,2,req,name=Host" json:"Host,omitempty"`
+type PruneShardGroupsCommand struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateNodeCommand) Reset()         { *m = UpdateNodeCommand{} }
-func (m *UpdateNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*UpdateNodeCommand) ProtoMessage()    {}
-func (*UpdateNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{31}
-}
-func (m *UpdateNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateNodeCommand.Unmarshal(m, b)
+func (m *PruneShardGroupsCommand) Reset()         { *m = PruneShardGroupsCommand{} }
+func (m *PruneShardGroupsCommand) String() string { return proto.CompactTextString(m) }
+func (*PruneShardGroupsCommand) ProtoMessage()    {}
+func (*PruneShardGroupsCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{52}
 }
-func (m *UpdateNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateNodeCommand.Marshal(b, m, deterministic)
+func (m *PruneShardGroupsCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneShardGroupsCommand.Unmarshal(m, b)
 }
-func (m *UpdateNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateNodeCommand.Merge(m, src)
+func (m *PruneShardGroupsCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneShardGroupsCommand.Marshal(b, m, deterministic)
 }
-func (m *UpdateNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_UpdateNodeCommand.Size(m)
+func (m *PruneShardGroupsCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneShardGroupsCommand.Merge(m, src)
 }
-func (m *UpdateNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateNodeCommand.DiscardUnknown(m)
+func (m *PruneShardGroupsCommand) XXX_Size() int {
+	return xxx_messageInfo_PruneShardGroupsCommand.Size(m)
 }
-
-var xxx_messageInfo_UpdateNodeCommand proto.InternalMessageInfo
-
-func (m *UpdateNodeCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
-	}
-	return 0
+func (m *PruneShardGroupsCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneShardGroupsCommand.DiscardUnknown(m)
 }
 
-func (m *UpdateNodeCommand) GetHost() string {
-	if m != nil && m.Host != nil {
-		return *m.Host
-	}
-	return ""
-}
+var xxx_messageInfo_PruneShardGroupsCommand proto.InternalMessageInfo
 
-var E_UpdateNodeCommand_Command = &proto.ExtensionDesc{
+var E_PruneShardGroupsCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*UpdateNodeCommand)(nil),
-	Field:         119,
-	Name:          "meta.UpdateNodeCommand.command",
-	Tag:           "bytes,119,opt,name=command",
+	ExtensionType: (*PruneShardGroupsCommand)(nil),
+	Field:         132,
+	Name:          "meta.PruneShardGroupsCommand.command",
+	Tag:           "bytes,132,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CreateSubscriptionCommand struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Database             *string  `protobuf:"bytes,2,req,name=Database" json:"Database,omitempty"`
-	RetentionPolicy      *string  `protobuf:"bytes,3,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
-	Mode                 *string  `protobuf:"bytes,4,req,name=Mode" json:"Mode,omitempty"`
-	Destinations         []string `protobuf:"bytes,5,rep,name=Destinations" json:"Destinations,omitempty"`
+type CopyShardOwnerCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateSubscriptionCommand) Reset()         { *m = CreateSubscriptionCommand{} }
-func (m *CreateSubscriptionCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateSubscriptionCommand) ProtoMessage()    {}
-func (*CreateSubscriptionCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{32}
-}
-func (m *CreateSubscriptionCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateSubscriptionCommand.Unmarshal(m, b)
-}
-func (m *CreateSubscriptionCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateSubscriptionCommand.Marshal(b, m, deterministic)
+func (m *CopyShardOwnerCommand) Reset()         { *m = CopyShardOwnerCommand{} }
+func (m *CopyShardOwnerCommand) String() string { return proto.CompactTextString(m) }
+func (*CopyShardOwnerCommand) ProtoMessage()    {}
+func (*CopyShardOwnerCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{53}
 }
-func (m *CreateSubscriptionCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateSubscriptionCommand.Merge(m, src)
+func (m *CopyShardOwnerCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyShardOwnerCommand.Unmarshal(m, b)
 }
-func (m *CreateSubscriptionCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateSubscriptionCommand.Size(m)
+func (m *CopyShardOwnerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyShardOwnerCommand.Marshal(b, m, deterministic)
 }
-func (m *CreateSubscriptionCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateSubscriptionCommand.DiscardUnknown(m)
+func (m *CopyShardOwnerCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyShardOwnerCommand.Merge(m, src)
 }
-
-var xxx_messageInfo_CreateSubscriptionCommand proto.InternalMessageInfo
-
-func (m *CreateSubscriptionCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
-	}
-	return ""
+func (m *CopyShardOwnerCommand) XXX_Size() int {
+	return xxx_messageInfo_CopyShardOwnerCommand.Size(m)
 }
-
-func (m *CreateSubscriptionCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
-	}
-	return ""
+func (m *CopyShardOwnerCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyShardOwnerCommand.DiscardUnknown(m)
 }
 
-func (m *CreateSubscriptionCommand) GetRetentionPolicy() string {
-	if m != nil && m.RetentionPolicy != nil {
-		return *m.RetentionPolicy
-	}
-	return ""
-}
+var xxx_messageInfo_CopyShardOwnerCommand proto.InternalMessageInfo
 
-func (m *CreateSubscriptionCommand) GetMode() string {
-	if m != nil && m.Mode != nil {
-		return *m.Mode
+func (m *CopyShardOwnerCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateSubscriptionCommand) GetDestinations() []string {
-	if m != nil {
-		return m.Destinations
+func (m *CopyShardOwnerCommand) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
 	}
-	return nil
+	return 0
 }
 
-var E_CreateSubscriptionCommand_Command = &proto.ExtensionDesc{
+var E_CopyShardOwnerCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateSubscriptionCommand)(nil),
-	Field:         121,
-	Name:          "meta.CreateSubscriptionCommand.command",
-	Tag:           "bytes,121,opt,name=command",
+	ExtensionType: (*CopyShardOwnerCommand)(nil),
+	Field:         133,
+	Name:          "meta.CopyShardOwnerCommand.command",
+	Tag:           "bytes,133,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DropSubscriptionCommand struct {
-	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
-	Database             *string  `protobuf:"bytes,2,req,name=Database" json:"Database,omitempty"`
-	RetentionPolicy      *string  `protobuf:"bytes,3,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+type RemoveShardOwnerCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DropSubscriptionCommand) Reset()         { *m = DropSubscriptionCommand{} }
-func (m *DropSubscriptionCommand) String() string { return proto.CompactTextString(m) }
-func (*DropSubscriptionCommand) ProtoMessage()    {}
-func (*DropSubscriptionCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{33}
+func (m *RemoveShardOwnerCommand) Reset()         { *m = RemoveShardOwnerCommand{} }
+func (m *RemoveShardOwnerCommand) String() string { return proto.CompactTextString(m) }
+func (*RemoveShardOwnerCommand) ProtoMessage()    {}
+func (*RemoveShardOwnerCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{54}
 }
-func (m *DropSubscriptionCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropSubscriptionCommand.Unmarshal(m, b)
+func (m *RemoveShardOwnerCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveShardOwnerCommand.Unmarshal(m, b)
 }
-func (m *DropSubscriptionCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DropSubscriptionCommand.Marshal(b, m, deterministic)
+func (m *RemoveShardOwnerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveShardOwnerCommand.Marshal(b, m, deterministic)
 }
-func (m *DropSubscriptionCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DropSubscriptionCommand.Merge(m, src)
+func (m *RemoveShardOwnerCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveShardOwnerCommand.Merge(m, src)
 }
-func (m *DropSubscriptionCommand) XXX_Size() int {
-	return xxx_messageInfo_DropSubscriptionCommand.Size(m)
+func (m *RemoveShardOwnerCommand) XXX_Size() int {
+	return xxx_messageInfo_RemoveShardOwnerCommand.Size(m)
 }
-func (m *DropSubscriptionCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DropSubscriptionCommand.DiscardUnknown(m)
+func (m *RemoveShardOwnerCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveShardOwnerCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DropSubscriptionCommand proto.InternalMessageInfo
-
-func (m *DropSubscriptionCommand) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
-	}
-	return ""
-}
+var xxx_messageInfo_RemoveShardOwnerCommand proto.InternalMessageInfo
 
-func (m *DropSubscriptionCommand) GetDatabase() string {
-	if m != nil && m.Database != nil {
-		return *m.Database
+func (m *RemoveShardOwnerCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *DropSubscriptionCommand) GetRetentionPolicy() string {
-	if m != nil && m.RetentionPolicy != nil {
-		return *m.RetentionPolicy
+func (m *RemoveShardOwnerCommand) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
 	}
-	return ""
+	return 0
 }
 
-var E_DropSubscriptionCommand_Command = &proto.ExtensionDesc{
+var E_RemoveShardOwnerCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DropSubscriptionCommand)(nil),
-	Field:         122,
-	Name:          "meta.DropSubscriptionCommand.command",
-	Tag:           "bytes,122,opt,name=command",
+	ExtensionType: (*RemoveShardOwnerCommand)(nil),
+	Field:         134,
+	Name:          "meta.RemoveShardOwnerCommand.command",
+	Tag:           "bytes,134,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type RemovePeerCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,opt,name=ID" json:"ID,omitempty"`
-	Addr                 *string  `protobuf:"bytes,2,req,name=Addr" json:"Addr,omitempty"`
+type SetShardOwnerQuarantineCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
+	Quarantined          *bool    `protobuf:"varint,3,req,name=Quarantined" json:"Quarantined,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RemovePeerCommand) Reset()         { *m = RemovePeerCommand{} }
-func (m *RemovePeerCommand) String() string { return proto.CompactTextString(m) }
-func (*RemovePeerCommand) ProtoMessage()    {}
-func (*RemovePeerCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{34}
+func (m *SetShardOwnerQuarantineCommand) Reset()         { *m = SetShardOwnerQuarantineCommand{} }
+func (m *SetShardOwnerQuarantineCommand) String() string { return proto.CompactTextString(m) }
+func (*SetShardOwnerQuarantineCommand) ProtoMessage()    {}
+func (*SetShardOwnerQuarantineCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{55}
 }
-func (m *RemovePeerCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemovePeerCommand.Unmarshal(m, b)
+func (m *SetShardOwnerQuarantineCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetShardOwnerQuarantineCommand.Unmarshal(m, b)
 }
-func (m *RemovePeerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemovePeerCommand.Marshal(b, m, deterministic)
+func (m *SetShardOwnerQuarantineCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetShardOwnerQuarantineCommand.Marshal(b, m, deterministic)
 }
-func (m *RemovePeerCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemovePeerCommand.Merge(m, src)
+func (m *SetShardOwnerQuarantineCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetShardOwnerQuarantineCommand.Merge(m, src)
 }
-func (m *RemovePeerCommand) XXX_Size() int {
-	return xxx_messageInfo_RemovePeerCommand.Size(m)
+func (m *SetShardOwnerQuarantineCommand) XXX_Size() int {
+	return xxx_messageInfo_SetShardOwnerQuarantineCommand.Size(m)
 }
-func (m *RemovePeerCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemovePeerCommand.DiscardUnknown(m)
+func (m *SetShardOwnerQuarantineCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetShardOwnerQuarantineCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RemovePeerCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetShardOwnerQuarantineCommand proto.InternalMessageInfo
 
-func (m *RemovePeerCommand) GetID() uint64 {
+func (m *SetShardOwnerQuarantineCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
 		return *m.ID
 	}
 	return 0
 }
 
-func (m *RemovePeerCommand) GetAddr() string {
-	if m != nil && m.Addr != nil {
-		return *m.Addr
+func (m *SetShardOwnerQuarantineCommand) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
 	}
-	return ""
+	return 0
 }
 
-var E_RemovePeerCommand_Command = &proto.ExtensionDesc{
+func (m *SetShardOwnerQuarantineCommand) GetQuarantined() bool {
+	if m != nil && m.Quarantined != nil {
+		return *m.Quarantined
+	}
+	return false
+}
+
+var E_SetShardOwnerQuarantineCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*RemovePeerCommand)(nil),
-	Field:         123,
-	Name:          "meta.RemovePeerCommand.command",
-	Tag:           "bytes,123,opt,name=command",
+	ExtensionType: (*SetShardOwnerQuarantineCommand)(nil),
+	Field:         135,
+	Name:          "meta.SetShardOwnerQuarantineCommand.command",
+	Tag:           "bytes,135,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CreateMetaNodeCommand struct {
-	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
-	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
-	Rand                 *uint64  `protobuf:"varint,3,req,name=Rand" json:"Rand,omitempty"`
+type SetShardOwnerTierCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
+	Tier                 *string  `protobuf:"bytes,3,req,name=Tier" json:"Tier,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateMetaNodeCommand) Reset()         { *m = CreateMetaNodeCommand{} }
-func (m *CreateMetaNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateMetaNodeCommand) ProtoMessage()    {}
-func (*CreateMetaNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{35}
+func (m *SetShardOwnerTierCommand) Reset()         { *m = SetShardOwnerTierCommand{} }
+func (m *SetShardOwnerTierCommand) String() string { return proto.CompactTextString(m) }
+func (*SetShardOwnerTierCommand) ProtoMessage()    {}
+func (*SetShardOwnerTierCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{56}
 }
-func (m *CreateMetaNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMetaNodeCommand.Unmarshal(m, b)
+func (m *SetShardOwnerTierCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetShardOwnerTierCommand.Unmarshal(m, b)
 }
-func (m *CreateMetaNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMetaNodeCommand.Marshal(b, m, deterministic)
+func (m *SetShardOwnerTierCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetShardOwnerTierCommand.Marshal(b, m, deterministic)
 }
-func (m *CreateMetaNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMetaNodeCommand.Merge(m, src)
+func (m *SetShardOwnerTierCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetShardOwnerTierCommand.Merge(m, src)
 }
-func (m *CreateMetaNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateMetaNodeCommand.Size(m)
+func (m *SetShardOwnerTierCommand) XXX_Size() int {
+	return xxx_messageInfo_SetShardOwnerTierCommand.Size(m)
 }
-func (m *CreateMetaNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMetaNodeCommand.DiscardUnknown(m)
+func (m *SetShardOwnerTierCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetShardOwnerTierCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateMetaNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetShardOwnerTierCommand proto.InternalMessageInfo
 
-func (m *CreateMetaNodeCommand) GetHTTPAddr() string {
-	if m != nil && m.HTTPAddr != nil {
-		return *m.HTTPAddr
+func (m *SetShardOwnerTierCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateMetaNodeCommand) GetTCPAddr() string {
-	if m != nil && m.TCPAddr != nil {
-		return *m.TCPAddr
+func (m *SetShardOwnerTierCommand) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateMetaNodeCommand) GetRand() uint64 {
-	if m != nil && m.Rand != nil {
-		return *m.Rand
+func (m *SetShardOwnerTierCommand) GetTier() string {
+	if m != nil && m.Tier != nil {
+		return *m.Tier
 	}
-	return 0
+	return ""
 }
 
-var E_CreateMetaNodeCommand_Command = &proto.ExtensionDesc{
+var E_SetShardOwnerTierCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateMetaNodeCommand)(nil),
-	Field:         124,
-	Name:          "meta.CreateMetaNodeCommand.command",
-	Tag:           "bytes,124,opt,name=command",
+	ExtensionType: (*SetShardOwnerTierCommand)(nil),
+	Field:         136,
+	Name:          "meta.SetShardOwnerTierCommand.command",
+	Tag:           "bytes,136,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CreateDataNodeCommand struct {
-	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
-	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
+type CreateRollupRuleCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Name                 *string  `protobuf:"bytes,3,req,name=Name" json:"Name,omitempty"`
+	After                *int64   `protobuf:"varint,4,req,name=After" json:"After,omitempty"`
+	Interval             *int64   `protobuf:"varint,5,req,name=Interval" json:"Interval,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateDataNodeCommand) Reset()         { *m = CreateDataNodeCommand{} }
-func (m *CreateDataNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*CreateDataNodeCommand) ProtoMessage()    {}
-func (*CreateDataNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{36}
+func (m *CreateRollupRuleCommand) Reset()         { *m = CreateRollupRuleCommand{} }
+func (m *CreateRollupRuleCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateRollupRuleCommand) ProtoMessage()    {}
+func (*CreateRollupRuleCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{57}
 }
-func (m *CreateDataNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateDataNodeCommand.Unmarshal(m, b)
+func (m *CreateRollupRuleCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRollupRuleCommand.Unmarshal(m, b)
 }
-func (m *CreateDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateDataNodeCommand.Marshal(b, m, deterministic)
+func (m *CreateRollupRuleCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRollupRuleCommand.Marshal(b, m, deterministic)
 }
-func (m *CreateDataNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateDataNodeCommand.Merge(m, src)
+func (m *CreateRollupRuleCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRollupRuleCommand.Merge(m, src)
 }
-func (m *CreateDataNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_CreateDataNodeCommand.Size(m)
+func (m *CreateRollupRuleCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateRollupRuleCommand.Size(m)
 }
-func (m *CreateDataNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateDataNodeCommand.DiscardUnknown(m)
+func (m *CreateRollupRuleCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRollupRuleCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateDataNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_CreateRollupRuleCommand proto.InternalMessageInfo
 
-func (m *CreateDataNodeCommand) GetHTTPAddr() string {
-	if m != nil && m.HTTPAddr != nil {
-		return *m.HTTPAddr
+func (m *CreateRollupRuleCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
 	return ""
 }
 
-func (m *CreateDataNodeCommand) GetTCPAddr() string {
-	if m != nil && m.TCPAddr != nil {
-		return *m.TCPAddr
+func (m *CreateRollupRuleCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
 	}
 	return ""
 }
 
-var E_CreateDataNodeCommand_Command = &proto.ExtensionDesc{
+func (m *CreateRollupRuleCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *CreateRollupRuleCommand) GetAfter() int64 {
+	if m != nil && m.After != nil {
+		return *m.After
+	}
+	return 0
+}
+
+func (m *CreateRollupRuleCommand) GetInterval() int64 {
+	if m != nil && m.Interval != nil {
+		return *m.Interval
+	}
+	return 0
+}
+
+var E_CreateRollupRuleCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CreateDataNodeCommand)(nil),
-	Field:         125,
-	Name:          "meta.CreateDataNodeCommand.command",
-	Tag:           "bytes,125,opt,name=command",
+	ExtensionType: (*CreateRollupRuleCommand)(nil),
+	Field:         137,
+	Name:          "meta.CreateRollupRuleCommand.command",
+	Tag:           "bytes,137,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type UpdateDataNodeCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
-	HTTPAddr             *string  `protobuf:"bytes,2,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
-	TCPAddr              *string  `protobuf:"bytes,3,req,name=TCPAddr" json:"TCPAddr,omitempty"`
+type DropRollupRuleCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Name                 *string  `protobuf:"bytes,3,req,name=Name" json:"Name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateDataNodeCommand) Reset()         { *m = UpdateDataNodeCommand{} }
-func (m *UpdateDataNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*UpdateDataNodeCommand) ProtoMessage()    {}
-func (*UpdateDataNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{37}
+func (m *DropRollupRuleCommand) Reset()         { *m = DropRollupRuleCommand{} }
+func (m *DropRollupRuleCommand) String() string { return proto.CompactTextString(m) }
+func (*DropRollupRuleCommand) ProtoMessage()    {}
+func (*DropRollupRuleCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{58}
 }
-func (m *UpdateDataNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateDataNodeCommand.Unmarshal(m, b)
+func (m *DropRollupRuleCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropRollupRuleCommand.Unmarshal(m, b)
 }
-func (m *UpdateDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateDataNodeCommand.Marshal(b, m, deterministic)
+func (m *DropRollupRuleCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropRollupRuleCommand.Marshal(b, m, deterministic)
 }
-func (m *UpdateDataNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateDataNodeCommand.Merge(m, src)
+func (m *DropRollupRuleCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropRollupRuleCommand.Merge(m, src)
 }
-func (m *UpdateDataNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_UpdateDataNodeCommand.Size(m)
+func (m *DropRollupRuleCommand) XXX_Size() int {
+	return xxx_messageInfo_DropRollupRuleCommand.Size(m)
 }
-func (m *UpdateDataNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateDataNodeCommand.DiscardUnknown(m)
+func (m *DropRollupRuleCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropRollupRuleCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateDataNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_DropRollupRuleCommand proto.InternalMessageInfo
 
-func (m *UpdateDataNodeCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
+func (m *DropRollupRuleCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
-	return 0
+	return ""
 }
 
-func (m *UpdateDataNodeCommand) GetHTTPAddr() string {
-	if m != nil && m.HTTPAddr != nil {
-		return *m.HTTPAddr
+func (m *DropRollupRuleCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
 	}
 	return ""
 }
 
-func (m *UpdateDataNodeCommand) GetTCPAddr() string {
-	if m != nil && m.TCPAddr != nil {
-		return *m.TCPAddr
+func (m *DropRollupRuleCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
 	}
 	return ""
 }
 
-var E_UpdateDataNodeCommand_Command = &proto.ExtensionDesc{
+var E_DropRollupRuleCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*UpdateDataNodeCommand)(nil),
-	Field:         126,
-	Name:          "meta.UpdateDataNodeCommand.command",
-	Tag:           "bytes,126,opt,name=command",
+	ExtensionType: (*DropRollupRuleCommand)(nil),
+	Field:         138,
+	Name:          "meta.DropRollupRuleCommand.command",
+	Tag:           "bytes,138,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DeleteMetaNodeCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type CreateMeasurementSchemaCommand struct {
+	Database             *string                `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Schema               *MeasurementSchemaInfo `protobuf:"bytes,2,req,name=Schema" json:"Schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *DeleteMetaNodeCommand) Reset()         { *m = DeleteMetaNodeCommand{} }
-func (m *DeleteMetaNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*DeleteMetaNodeCommand) ProtoMessage()    {}
-func (*DeleteMetaNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{38}
+func (m *CreateMeasurementSchemaCommand) Reset()         { *m = CreateMeasurementSchemaCommand{} }
+func (m *CreateMeasurementSchemaCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateMeasurementSchemaCommand) ProtoMessage()    {}
+func (*CreateMeasurementSchemaCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{59}
 }
-func (m *DeleteMetaNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteMetaNodeCommand.Unmarshal(m, b)
+func (m *CreateMeasurementSchemaCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMeasurementSchemaCommand.Unmarshal(m, b)
 }
-func (m *DeleteMetaNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteMetaNodeCommand.Marshal(b, m, deterministic)
+func (m *CreateMeasurementSchemaCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMeasurementSchemaCommand.Marshal(b, m, deterministic)
 }
-func (m *DeleteMetaNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteMetaNodeCommand.Merge(m, src)
+func (m *CreateMeasurementSchemaCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMeasurementSchemaCommand.Merge(m, src)
 }
-func (m *DeleteMetaNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_DeleteMetaNodeCommand.Size(m)
+func (m *CreateMeasurementSchemaCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateMeasurementSchemaCommand.Size(m)
 }
-func (m *DeleteMetaNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteMetaNodeCommand.DiscardUnknown(m)
+func (m *CreateMeasurementSchemaCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMeasurementSchemaCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteMetaNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_CreateMeasurementSchemaCommand proto.InternalMessageInfo
 
-func (m *DeleteMetaNodeCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
+func (m *CreateMeasurementSchemaCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
-	return 0
+	return ""
 }
 
-var E_DeleteMetaNodeCommand_Command = &proto.ExtensionDesc{
+func (m *CreateMeasurementSchemaCommand) GetSchema() *MeasurementSchemaInfo {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+var E_CreateMeasurementSchemaCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DeleteMetaNodeCommand)(nil),
-	Field:         127,
-	Name:          "meta.DeleteMetaNodeCommand.command",
-	Tag:           "bytes,127,opt,name=command",
+	ExtensionType: (*CreateMeasurementSchemaCommand)(nil),
+	Field:         139,
+	Name:          "meta.CreateMeasurementSchemaCommand.command",
+	Tag:           "bytes,139,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DeleteDataNodeCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+type DropMeasurementSchemaCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Name                 *string  `protobuf:"bytes,2,req,name=Name" json:"Name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteDataNodeCommand) Reset()         { *m = DeleteDataNodeCommand{} }
-func (m *DeleteDataNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*DeleteDataNodeCommand) ProtoMessage()    {}
-func (*DeleteDataNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{39}
+func (m *DropMeasurementSchemaCommand) Reset()         { *m = DropMeasurementSchemaCommand{} }
+func (m *DropMeasurementSchemaCommand) String() string { return proto.CompactTextString(m) }
+func (*DropMeasurementSchemaCommand) ProtoMessage()    {}
+func (*DropMeasurementSchemaCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{60}
 }
-func (m *DeleteDataNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteDataNodeCommand.Unmarshal(m, b)
+func (m *DropMeasurementSchemaCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropMeasurementSchemaCommand.Unmarshal(m, b)
 }
-func (m *DeleteDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteDataNodeCommand.Marshal(b, m, deterministic)
+func (m *DropMeasurementSchemaCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropMeasurementSchemaCommand.Marshal(b, m, deterministic)
 }
-func (m *DeleteDataNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteDataNodeCommand.Merge(m, src)
+func (m *DropMeasurementSchemaCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropMeasurementSchemaCommand.Merge(m, src)
 }
-func (m *DeleteDataNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_DeleteDataNodeCommand.Size(m)
+func (m *DropMeasurementSchemaCommand) XXX_Size() int {
+	return xxx_messageInfo_DropMeasurementSchemaCommand.Size(m)
 }
-func (m *DeleteDataNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteDataNodeCommand.DiscardUnknown(m)
+func (m *DropMeasurementSchemaCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropMeasurementSchemaCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteDataNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_DropMeasurementSchemaCommand proto.InternalMessageInfo
 
-func (m *DeleteDataNodeCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
+func (m *DropMeasurementSchemaCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
-	return 0
+	return ""
 }
 
-var E_DeleteDataNodeCommand_Command = &proto.ExtensionDesc{
+func (m *DropMeasurementSchemaCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+var E_DropMeasurementSchemaCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DeleteDataNodeCommand)(nil),
-	Field:         128,
-	Name:          "meta.DeleteDataNodeCommand.command",
-	Tag:           "bytes,128,opt,name=command",
+	ExtensionType: (*DropMeasurementSchemaCommand)(nil),
+	Field:         140,
+	Name:          "meta.DropMeasurementSchemaCommand.command",
+	Tag:           "bytes,140,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type Response struct {
-	OK                   *bool    `protobuf:"varint,1,req,name=OK" json:"OK,omitempty"`
-	Error                *string  `protobuf:"bytes,2,opt,name=Error" json:"Error,omitempty"`
-	Index                *uint64  `protobuf:"varint,3,opt,name=Index" json:"Index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type SetCardinalityLimitsCommand struct {
+	Database             *string                `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	Limits               *CardinalityLimitsInfo `protobuf:"bytes,2,opt,name=Limits" json:"Limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *Response) Reset()         { *m = Response{} }
-func (m *Response) String() string { return proto.CompactTextString(m) }
-func (*Response) ProtoMessage()    {}
-func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{40}
+func (m *SetCardinalityLimitsCommand) Reset()         { *m = SetCardinalityLimitsCommand{} }
+func (m *SetCardinalityLimitsCommand) String() string { return proto.CompactTextString(m) }
+func (*SetCardinalityLimitsCommand) ProtoMessage()    {}
+func (*SetCardinalityLimitsCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{61}
 }
-func (m *Response) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Response.Unmarshal(m, b)
+func (m *SetCardinalityLimitsCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCardinalityLimitsCommand.Unmarshal(m, b)
 }
-func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Response.Marshal(b, m, deterministic)
+func (m *SetCardinalityLimitsCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCardinalityLimitsCommand.Marshal(b, m, deterministic)
 }
-func (m *Response) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Response.Merge(m, src)
+func (m *SetCardinalityLimitsCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCardinalityLimitsCommand.Merge(m, src)
 }
-func (m *Response) XXX_Size() int {
-	return xxx_messageInfo_Response.Size(m)
+func (m *SetCardinalityLimitsCommand) XXX_Size() int {
+	return xxx_messageInfo_SetCardinalityLimitsCommand.Size(m)
 }
-func (m *Response) XXX_DiscardUnknown() {
-	xxx_messageInfo_Response.DiscardUnknown(m)
+func (m *SetCardinalityLimitsCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCardinalityLimitsCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Response proto.InternalMessageInfo
-
-func (m *Response) GetOK() bool {
-	if m != nil && m.OK != nil {
-		return *m.OK
-	}
-	return false
-}
+var xxx_messageInfo_SetCardinalityLimitsCommand proto.InternalMessageInfo
 
-func (m *Response) GetError() string {
-	if m != nil && m.Error != nil {
-		return *m.Error
+func (m *SetCardinalityLimitsCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
 	return ""
 }
 
-func (m *Response) GetIndex() uint64 {
-	if m != nil && m.Index != nil {
-		return *m.Index
+func (m *SetCardinalityLimitsCommand) GetLimits() *CardinalityLimitsInfo {
+	if m != nil {
+		return m.Limits
 	}
-	return 0
+	return nil
+}
+
+var E_SetCardinalityLimitsCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*SetCardinalityLimitsCommand)(nil),
+	Field:         141,
+	Name:          "meta.SetCardinalityLimitsCommand.command",
+	Tag:           "bytes,141,opt,name=command",
+	Filename:      "internal/meta.proto",
 }
 
-// SetMetaNodeCommand is for the initial metanode in a cluster or
-// if the single host restarts and its hostname changes, this will update it
-type SetMetaNodeCommand struct {
-	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
-	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
-	Rand                 *uint64  `protobuf:"varint,3,req,name=Rand" json:"Rand,omitempty"`
+type CreateReshardCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Duration             *int64   `protobuf:"varint,3,req,name=Duration" json:"Duration,omitempty"`
+	Timestamp            *int64   `protobuf:"varint,4,req,name=Timestamp" json:"Timestamp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetMetaNodeCommand) Reset()         { *m = SetMetaNodeCommand{} }
-func (m *SetMetaNodeCommand) String() string { return proto.CompactTextString(m) }
-func (*SetMetaNodeCommand) ProtoMessage()    {}
-func (*SetMetaNodeCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{41}
+func (m *CreateReshardCommand) Reset()         { *m = CreateReshardCommand{} }
+func (m *CreateReshardCommand) String() string { return proto.CompactTextString(m) }
+func (*CreateReshardCommand) ProtoMessage()    {}
+func (*CreateReshardCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{62}
 }
-func (m *SetMetaNodeCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetMetaNodeCommand.Unmarshal(m, b)
+func (m *CreateReshardCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateReshardCommand.Unmarshal(m, b)
 }
-func (m *SetMetaNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetMetaNodeCommand.Marshal(b, m, deterministic)
+func (m *CreateReshardCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateReshardCommand.Marshal(b, m, deterministic)
 }
-func (m *SetMetaNodeCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetMetaNodeCommand.Merge(m, src)
+func (m *CreateReshardCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateReshardCommand.Merge(m, src)
 }
-func (m *SetMetaNodeCommand) XXX_Size() int {
-	return xxx_messageInfo_SetMetaNodeCommand.Size(m)
+func (m *CreateReshardCommand) XXX_Size() int {
+	return xxx_messageInfo_CreateReshardCommand.Size(m)
 }
-func (m *SetMetaNodeCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetMetaNodeCommand.DiscardUnknown(m)
+func (m *CreateReshardCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateReshardCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetMetaNodeCommand proto.InternalMessageInfo
+var xxx_messageInfo_CreateReshardCommand proto.InternalMessageInfo
 
-func (m *SetMetaNodeCommand) GetHTTPAddr() string {
-	if m != nil && m.HTTPAddr != nil {
-		return *m.HTTPAddr
+func (m *CreateReshardCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
 	return ""
 }
 
-func (m *SetMetaNodeCommand) GetTCPAddr() string {
-	if m != nil && m.TCPAddr != nil {
-		return *m.TCPAddr
+func (m *CreateReshardCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
 	}
 	return ""
 }
 
-func (m *SetMetaNodeCommand) GetRand() uint64 {
-	if m != nil && m.Rand != nil {
-		return *m.Rand
+func (m *CreateReshardCommand) GetDuration() int64 {
+	if m != nil && m.Duration != nil {
+		return *m.Duration
 	}
 	return 0
 }
 
-var E_SetMetaNodeCommand_Command = &proto.ExtensionDesc{
+func (m *CreateReshardCommand) GetTimestamp() int64 {
+	if m != nil && m.Timestamp != nil {
+		return *m.Timestamp
+	}
+	return 0
+}
+
+var E_CreateReshardCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*SetMetaNodeCommand)(nil),
-	Field:         129,
-	Name:          "meta.SetMetaNodeCommand.command",
-	Tag:           "bytes,129,opt,name=command",
+	ExtensionType: (*CreateReshardCommand)(nil),
+	Field:         142,
+	Name:          "meta.CreateReshardCommand.command",
+	Tag:           "bytes,142,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type DropShardCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+type SetReshardCopiedCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	ShardID              *uint64  `protobuf:"varint,3,req,name=ShardID" json:"ShardID,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,4,req,name=NodeID" json:"NodeID,omitempty"`
+	Timestamp            *int64   `protobuf:"varint,5,req,name=Timestamp" json:"Timestamp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DropShardCommand) Reset()         { *m = DropShardCommand{} }
-func (m *DropShardCommand) String() string { return proto.CompactTextString(m) }
-func (*DropShardCommand) ProtoMessage()    {}
-func (*DropShardCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{42}
+func (m *SetReshardCopiedCommand) Reset()         { *m = SetReshardCopiedCommand{} }
+func (m *SetReshardCopiedCommand) String() string { return proto.CompactTextString(m) }
+func (*SetReshardCopiedCommand) ProtoMessage()    {}
+func (*SetReshardCopiedCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{63}
 }
-func (m *DropShardCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DropShardCommand.Unmarshal(m, b)
+func (m *SetReshardCopiedCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReshardCopiedCommand.Unmarshal(m, b)
 }
-func (m *DropShardCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DropShardCommand.Marshal(b, m, deterministic)
+func (m *SetReshardCopiedCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReshardCopiedCommand.Marshal(b, m, deterministic)
 }
-func (m *DropShardCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DropShardCommand.Merge(m, src)
+func (m *SetReshardCopiedCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReshardCopiedCommand.Merge(m, src)
 }
-func (m *DropShardCommand) XXX_Size() int {
-	return xxx_messageInfo_DropShardCommand.Size(m)
+func (m *SetReshardCopiedCommand) XXX_Size() int {
+	return xxx_messageInfo_SetReshardCopiedCommand.Size(m)
 }
-func (m *DropShardCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_DropShardCommand.DiscardUnknown(m)
+func (m *SetReshardCopiedCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReshardCopiedCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DropShardCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetReshardCopiedCommand proto.InternalMessageInfo
 
-func (m *DropShardCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
+func (m *SetReshardCopiedCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *SetReshardCopiedCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
+	}
+	return ""
+}
+
+func (m *SetReshardCopiedCommand) GetShardID() uint64 {
+	if m != nil && m.ShardID != nil {
+		return *m.ShardID
 	}
 	return 0
 }
 
-var E_DropShardCommand_Command = &proto.ExtensionDesc{
+func (m *SetReshardCopiedCommand) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
+	}
+	return 0
+}
+
+func (m *SetReshardCopiedCommand) GetTimestamp() int64 {
+	if m != nil && m.Timestamp != nil {
+		return *m.Timestamp
+	}
+	return 0
+}
+
+var E_SetReshardCopiedCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*DropShardCommand)(nil),
-	Field:         130,
-	Name:          "meta.DropShardCommand.command",
-	Tag:           "bytes,130,opt,name=command",
+	ExtensionType: (*SetReshardCopiedCommand)(nil),
+	Field:         143,
+	Name:          "meta.SetReshardCopiedCommand.command",
+	Tag:           "bytes,143,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type TruncateShardGroupsCommand struct {
-	Timestamp            *int64   `protobuf:"varint,1,req,name=Timestamp" json:"Timestamp,omitempty"`
+type DropReshardCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Timestamp            *int64   `protobuf:"varint,3,req,name=Timestamp" json:"Timestamp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TruncateShardGroupsCommand) Reset()         { *m = TruncateShardGroupsCommand{} }
-func (m *TruncateShardGroupsCommand) String() string { return proto.CompactTextString(m) }
-func (*TruncateShardGroupsCommand) ProtoMessage()    {}
-func (*TruncateShardGroupsCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{43}
+func (m *DropReshardCommand) Reset()         { *m = DropReshardCommand{} }
+func (m *DropReshardCommand) String() string { return proto.CompactTextString(m) }
+func (*DropReshardCommand) ProtoMessage()    {}
+func (*DropReshardCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{64}
 }
-func (m *TruncateShardGroupsCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TruncateShardGroupsCommand.Unmarshal(m, b)
+func (m *DropReshardCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropReshardCommand.Unmarshal(m, b)
 }
-func (m *TruncateShardGroupsCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TruncateShardGroupsCommand.Marshal(b, m, deterministic)
+func (m *DropReshardCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropReshardCommand.Marshal(b, m, deterministic)
 }
-func (m *TruncateShardGroupsCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TruncateShardGroupsCommand.Merge(m, src)
+func (m *DropReshardCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropReshardCommand.Merge(m, src)
 }
-func (m *TruncateShardGroupsCommand) XXX_Size() int {
-	return xxx_messageInfo_TruncateShardGroupsCommand.Size(m)
+func (m *DropReshardCommand) XXX_Size() int {
+	return xxx_messageInfo_DropReshardCommand.Size(m)
 }
-func (m *TruncateShardGroupsCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_TruncateShardGroupsCommand.DiscardUnknown(m)
+func (m *DropReshardCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropReshardCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TruncateShardGroupsCommand proto.InternalMessageInfo
+var xxx_messageInfo_DropReshardCommand proto.InternalMessageInfo
 
-func (m *TruncateShardGroupsCommand) GetTimestamp() int64 {
+func (m *DropReshardCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *DropReshardCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
+	}
+	return ""
+}
+
+func (m *DropReshardCommand) GetTimestamp() int64 {
 	if m != nil && m.Timestamp != nil {
 		return *m.Timestamp
 	}
 	return 0
 }
 
-var E_TruncateShardGroupsCommand_Command = &proto.ExtensionDesc{
+var E_DropReshardCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*TruncateShardGroupsCommand)(nil),
-	Field:         131,
-	Name:          "meta.TruncateShardGroupsCommand.command",
-	Tag:           "bytes,131,opt,name=command",
+	ExtensionType: (*DropReshardCommand)(nil),
+	Field:         144,
+	Name:          "meta.DropReshardCommand.command",
+	Tag:           "bytes,144,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type PruneShardGroupsCommand struct {
+type SetRetentionPolicyReplicationCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string  `protobuf:"bytes,2,req,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Mode                 *string  `protobuf:"bytes,3,req,name=Mode" json:"Mode,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PruneShardGroupsCommand) Reset()         { *m = PruneShardGroupsCommand{} }
-func (m *PruneShardGroupsCommand) String() string { return proto.CompactTextString(m) }
-func (*PruneShardGroupsCommand) ProtoMessage()    {}
-func (*PruneShardGroupsCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{44}
+func (m *SetRetentionPolicyReplicationCommand) Reset()         { *m = SetRetentionPolicyReplicationCommand{} }
+func (m *SetRetentionPolicyReplicationCommand) String() string { return proto.CompactTextString(m) }
+func (*SetRetentionPolicyReplicationCommand) ProtoMessage()    {}
+func (*SetRetentionPolicyReplicationCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{65}
 }
-func (m *PruneShardGroupsCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PruneShardGroupsCommand.Unmarshal(m, b)
+func (m *SetRetentionPolicyReplicationCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRetentionPolicyReplicationCommand.Unmarshal(m, b)
 }
-func (m *PruneShardGroupsCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PruneShardGroupsCommand.Marshal(b, m, deterministic)
+func (m *SetRetentionPolicyReplicationCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRetentionPolicyReplicationCommand.Marshal(b, m, deterministic)
 }
-func (m *PruneShardGroupsCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PruneShardGroupsCommand.Merge(m, src)
+func (m *SetRetentionPolicyReplicationCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRetentionPolicyReplicationCommand.Merge(m, src)
 }
-func (m *PruneShardGroupsCommand) XXX_Size() int {
-	return xxx_messageInfo_PruneShardGroupsCommand.Size(m)
+func (m *SetRetentionPolicyReplicationCommand) XXX_Size() int {
+	return xxx_messageInfo_SetRetentionPolicyReplicationCommand.Size(m)
 }
-func (m *PruneShardGroupsCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_PruneShardGroupsCommand.DiscardUnknown(m)
+func (m *SetRetentionPolicyReplicationCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRetentionPolicyReplicationCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PruneShardGroupsCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetRetentionPolicyReplicationCommand proto.InternalMessageInfo
 
-var E_PruneShardGroupsCommand_Command = &proto.ExtensionDesc{
+func (m *SetRetentionPolicyReplicationCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *SetRetentionPolicyReplicationCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
+	}
+	return ""
+}
+
+func (m *SetRetentionPolicyReplicationCommand) GetMode() string {
+	if m != nil && m.Mode != nil {
+		return *m.Mode
+	}
+	return ""
+}
+
+var E_SetRetentionPolicyReplicationCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*PruneShardGroupsCommand)(nil),
-	Field:         132,
-	Name:          "meta.PruneShardGroupsCommand.command",
-	Tag:           "bytes,132,opt,name=command",
+	ExtensionType: (*SetRetentionPolicyReplicationCommand)(nil),
+	Field:         145,
+	Name:          "meta.SetRetentionPolicyReplicationCommand.command",
+	Tag:           "bytes,145,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type CopyShardOwnerCommand struct {
+type SetShardLeaderCommand struct {
 	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
+	PrevNodeID           *uint64  `protobuf:"varint,3,opt,name=PrevNodeID" json:"PrevNodeID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CopyShardOwnerCommand) Reset()         { *m = CopyShardOwnerCommand{} }
-func (m *CopyShardOwnerCommand) String() string { return proto.CompactTextString(m) }
-func (*CopyShardOwnerCommand) ProtoMessage()    {}
-func (*CopyShardOwnerCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{45}
+func (m *SetShardLeaderCommand) Reset()         { *m = SetShardLeaderCommand{} }
+func (m *SetShardLeaderCommand) String() string { return proto.CompactTextString(m) }
+func (*SetShardLeaderCommand) ProtoMessage()    {}
+func (*SetShardLeaderCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{66}
 }
-func (m *CopyShardOwnerCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CopyShardOwnerCommand.Unmarshal(m, b)
+func (m *SetShardLeaderCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetShardLeaderCommand.Unmarshal(m, b)
 }
-func (m *CopyShardOwnerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CopyShardOwnerCommand.Marshal(b, m, deterministic)
+func (m *SetShardLeaderCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetShardLeaderCommand.Marshal(b, m, deterministic)
 }
-func (m *CopyShardOwnerCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CopyShardOwnerCommand.Merge(m, src)
+func (m *SetShardLeaderCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetShardLeaderCommand.Merge(m, src)
 }
-func (m *CopyShardOwnerCommand) XXX_Size() int {
-	return xxx_messageInfo_CopyShardOwnerCommand.Size(m)
+func (m *SetShardLeaderCommand) XXX_Size() int {
+	return xxx_messageInfo_SetShardLeaderCommand.Size(m)
 }
-func (m *CopyShardOwnerCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_CopyShardOwnerCommand.DiscardUnknown(m)
+func (m *SetShardLeaderCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetShardLeaderCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CopyShardOwnerCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetShardLeaderCommand proto.InternalMessageInfo
 
-func (m *CopyShardOwnerCommand) GetID() uint64 {
+func (m *SetShardLeaderCommand) GetID() uint64 {
 	if m != nil && m.ID != nil {
 		return *m.ID
 	}
 	return 0
 }
 
-func (m *CopyShardOwnerCommand) GetNodeID() uint64 {
+func (m *SetShardLeaderCommand) GetNodeID() uint64 {
 	if m != nil && m.NodeID != nil {
 		return *m.NodeID
 	}
 	return 0
 }
 
-var E_CopyShardOwnerCommand_Command = &proto.ExtensionDesc{
+func (m *SetShardLeaderCommand) GetPrevNodeID() uint64 {
+	if m != nil && m.PrevNodeID != nil {
+		return *m.PrevNodeID
+	}
+	return 0
+}
+
+var E_SetShardLeaderCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*CopyShardOwnerCommand)(nil),
-	Field:         133,
-	Name:          "meta.CopyShardOwnerCommand.command",
-	Tag:           "bytes,133,opt,name=command",
+	ExtensionType: (*SetShardLeaderCommand)(nil),
+	Field:         146,
+	Name:          "meta.SetShardLeaderCommand.command",
+	Tag:           "bytes,146,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
-type RemoveShardOwnerCommand struct {
-	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
-	NodeID               *uint64  `protobuf:"varint,2,req,name=NodeID" json:"NodeID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type SetDiskQuotaCommand struct {
+	Database             *string        `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	RetentionPolicy      *string        `protobuf:"bytes,2,opt,name=RetentionPolicy" json:"RetentionPolicy,omitempty"`
+	Quota                *DiskQuotaInfo `protobuf:"bytes,3,opt,name=Quota" json:"Quota,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *RemoveShardOwnerCommand) Reset()         { *m = RemoveShardOwnerCommand{} }
-func (m *RemoveShardOwnerCommand) String() string { return proto.CompactTextString(m) }
-func (*RemoveShardOwnerCommand) ProtoMessage()    {}
-func (*RemoveShardOwnerCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_59b0956366e72083, []int{46}
+func (m *SetDiskQuotaCommand) Reset()         { *m = SetDiskQuotaCommand{} }
+func (m *SetDiskQuotaCommand) String() string { return proto.CompactTextString(m) }
+func (*SetDiskQuotaCommand) ProtoMessage()    {}
+func (*SetDiskQuotaCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59b0956366e72083, []int{67}
 }
-func (m *RemoveShardOwnerCommand) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveShardOwnerCommand.Unmarshal(m, b)
+func (m *SetDiskQuotaCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDiskQuotaCommand.Unmarshal(m, b)
 }
-func (m *RemoveShardOwnerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveShardOwnerCommand.Marshal(b, m, deterministic)
+func (m *SetDiskQuotaCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDiskQuotaCommand.Marshal(b, m, deterministic)
 }
-func (m *RemoveShardOwnerCommand) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveShardOwnerCommand.Merge(m, src)
+func (m *SetDiskQuotaCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDiskQuotaCommand.Merge(m, src)
 }
-func (m *RemoveShardOwnerCommand) XXX_Size() int {
-	return xxx_messageInfo_RemoveShardOwnerCommand.Size(m)
+func (m *SetDiskQuotaCommand) XXX_Size() int {
+	return xxx_messageInfo_SetDiskQuotaCommand.Size(m)
 }
-func (m *RemoveShardOwnerCommand) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveShardOwnerCommand.DiscardUnknown(m)
+func (m *SetDiskQuotaCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDiskQuotaCommand.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RemoveShardOwnerCommand proto.InternalMessageInfo
+var xxx_messageInfo_SetDiskQuotaCommand proto.InternalMessageInfo
 
-func (m *RemoveShardOwnerCommand) GetID() uint64 {
-	if m != nil && m.ID != nil {
-		return *m.ID
+func (m *SetDiskQuotaCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
 	}
-	return 0
+	return ""
 }
 
-func (m *RemoveShardOwnerCommand) GetNodeID() uint64 {
-	if m != nil && m.NodeID != nil {
-		return *m.NodeID
+func (m *SetDiskQuotaCommand) GetRetentionPolicy() string {
+	if m != nil && m.RetentionPolicy != nil {
+		return *m.RetentionPolicy
 	}
-	return 0
+	return ""
 }
 
-var E_RemoveShardOwnerCommand_Command = &proto.ExtensionDesc{
+func (m *SetDiskQuotaCommand) GetQuota() *DiskQuotaInfo {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+var E_SetDiskQuotaCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
-	ExtensionType: (*RemoveShardOwnerCommand)(nil),
-	Field:         134,
-	Name:          "meta.RemoveShardOwnerCommand.command",
-	Tag:           "bytes,134,opt,name=command",
+	ExtensionType: (*SetDiskQuotaCommand)(nil),
+	Field:         147,
+	Name:          "meta.SetDiskQuotaCommand.command",
+	Tag:           "bytes,147,opt,name=command",
 	Filename:      "internal/meta.proto",
 }
 
@@ -2910,6 +4288,14 @@ func init() {
 	proto.RegisterType((*ShardGroupInfo)(nil), "meta.ShardGroupInfo")
 	proto.RegisterType((*ShardInfo)(nil), "meta.ShardInfo")
 	proto.RegisterType((*SubscriptionInfo)(nil), "meta.SubscriptionInfo")
+	proto.RegisterType((*RollupRuleInfo)(nil), "meta.RollupRuleInfo")
+	proto.RegisterType((*ReshardInfo)(nil), "meta.ReshardInfo")
+	proto.RegisterType((*ReshardCopy)(nil), "meta.ReshardCopy")
+	proto.RegisterType((*MeasurementSchemaInfo)(nil), "meta.MeasurementSchemaInfo")
+	proto.RegisterType((*FieldSchemaInfo)(nil), "meta.FieldSchemaInfo")
+	proto.RegisterType((*CardinalityLimitsInfo)(nil), "meta.CardinalityLimitsInfo")
+	proto.RegisterType((*DiskQuotaInfo)(nil), "meta.DiskQuotaInfo")
+	proto.RegisterType((*CardinalityLimitInfo)(nil), "meta.CardinalityLimitInfo")
 	proto.RegisterType((*ShardOwner)(nil), "meta.ShardOwner")
 	proto.RegisterType((*ContinuousQueryInfo)(nil), "meta.ContinuousQueryInfo")
 	proto.RegisterType((*UserInfo)(nil), "meta.UserInfo")
@@ -2982,132 +4368,216 @@ func init() {
 	proto.RegisterType((*CopyShardOwnerCommand)(nil), "meta.CopyShardOwnerCommand")
 	proto.RegisterExtension(E_RemoveShardOwnerCommand_Command)
 	proto.RegisterType((*RemoveShardOwnerCommand)(nil), "meta.RemoveShardOwnerCommand")
+	proto.RegisterExtension(E_SetShardOwnerQuarantineCommand_Command)
+	proto.RegisterType((*SetShardOwnerQuarantineCommand)(nil), "meta.SetShardOwnerQuarantineCommand")
+	proto.RegisterExtension(E_SetShardOwnerTierCommand_Command)
+	proto.RegisterType((*SetShardOwnerTierCommand)(nil), "meta.SetShardOwnerTierCommand")
+	proto.RegisterExtension(E_CreateRollupRuleCommand_Command)
+	proto.RegisterType((*CreateRollupRuleCommand)(nil), "meta.CreateRollupRuleCommand")
+	proto.RegisterExtension(E_DropRollupRuleCommand_Command)
+	proto.RegisterType((*DropRollupRuleCommand)(nil), "meta.DropRollupRuleCommand")
+	proto.RegisterExtension(E_CreateMeasurementSchemaCommand_Command)
+	proto.RegisterType((*CreateMeasurementSchemaCommand)(nil), "meta.CreateMeasurementSchemaCommand")
+	proto.RegisterExtension(E_DropMeasurementSchemaCommand_Command)
+	proto.RegisterType((*DropMeasurementSchemaCommand)(nil), "meta.DropMeasurementSchemaCommand")
+	proto.RegisterExtension(E_SetCardinalityLimitsCommand_Command)
+	proto.RegisterType((*SetCardinalityLimitsCommand)(nil), "meta.SetCardinalityLimitsCommand")
+	proto.RegisterExtension(E_CreateReshardCommand_Command)
+	proto.RegisterType((*CreateReshardCommand)(nil), "meta.CreateReshardCommand")
+	proto.RegisterExtension(E_SetReshardCopiedCommand_Command)
+	proto.RegisterType((*SetReshardCopiedCommand)(nil), "meta.SetReshardCopiedCommand")
+	proto.RegisterExtension(E_DropReshardCommand_Command)
+	proto.RegisterType((*DropReshardCommand)(nil), "meta.DropReshardCommand")
+	proto.RegisterExtension(E_SetRetentionPolicyReplicationCommand_Command)
+	proto.RegisterType((*SetRetentionPolicyReplicationCommand)(nil), "meta.SetRetentionPolicyReplicationCommand")
+	proto.RegisterExtension(E_SetShardLeaderCommand_Command)
+	proto.RegisterType((*SetShardLeaderCommand)(nil), "meta.SetShardLeaderCommand")
+	proto.RegisterExtension(E_SetDiskQuotaCommand_Command)
+	proto.RegisterType((*SetDiskQuotaCommand)(nil), "meta.SetDiskQuotaCommand")
 }
 
 func init() { proto.RegisterFile("internal/meta.proto", fileDescriptor_59b0956366e72083) }
 
 var fileDescriptor_59b0956366e72083 = []byte{
-	// 1940 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4b, 0x8f, 0x24, 0x39,
-	0x11, 0x96, 0xb3, 0xde, 0xd1, 0xd3, 0x8f, 0x71, 0xbf, 0xb2, 0x67, 0x7a, 0x7a, 0x8b, 0xd4, 0x68,
-	0x29, 0x21, 0xd4, 0xa0, 0x42, 0xda, 0x13, 0xaf, 0xd9, 0xae, 0x99, 0xe9, 0x62, 0xd4, 0x0f, 0xb2,
-	0x7a, 0xaf, 0x48, 0x39, 0x5d, 0x9e, 0x9d, 0x82, 0xaa, 0xcc, 0x22, 0x33, 0x6b, 0x66, 0x9a, 0xa5,
-	0xa1, 0x79, 0x2d, 0x12, 0xa7, 0x45, 0x08, 0x71, 0x67, 0x0f, 0x1c, 0x11, 0x42, 0x02, 0x21, 0x4e,
-	0xfc, 0x01, 0x7e, 0x06, 0x27, 0xee, 0x5c, 0x91, 0xed, 0x74, 0xda, 0x99, 0xb6, 0xb3, 0xbb, 0x97,
-	0xe1, 0x96, 0x8e, 0x08, 0x3b, 0xbe, 0x08, 0x87, 0xc3, 0x11, 0x4e, 0x58, 0x9f, 0x84, 0x29, 0x89,
-	0xc3, 0x60, 0xfa, 0xa5, 0x19, 0x49, 0x83, 0xfd, 0x79, 0x1c, 0xa5, 0x11, 0xae, 0xd3, 0x6f, 0xef,
-	0x93, 0x1a, 0xd4, 0x07, 0x41, 0x1a, 0x60, 0x0c, 0xf5, 0x33, 0x12, 0xcf, 0x5c, 0xd4, 0x75, 0x7a,
-	0x75, 0x9f, 0x7d, 0xe3, 0x0d, 0x68, 0x0c, 0xc3, 0x31, 0x79, 0xe3, 0x3a, 0x8c, 0xc8, 0x07, 0x78,
-	0x17, 0x3a, 0x07, 0xd3, 0x45, 0x92, 0x92, 0x78, 0x38, 0x70, 0x6b, 0x8c, 0x23, 0x09, 0xf8, 0x21,
-	0x34, 0x8e, 0xa3, 0x31, 0x49, 0xdc, 0x7a, 0xb7, 0xd6, 0x5b, 0xea, 0xaf, 0xec, 0x33, 0x95, 0x94,
-	0x34, 0x0c, 0x5f, 0x44, 0x3e, 0x67, 0xe2, 0x2f, 0x43, 0x87, 0x6a, 0x7d, 0x1e, 0x24, 0x24, 0x71,
-	0x1b, 0x4c, 0x12, 0x73, 0x49, 0x41, 0x66, 0xd2, 0x52, 0x88, 0xae, 0xfb, 0x41, 0x42, 0xe2, 0xc4,
-	0x6d, 0xaa, 0xeb, 0x52, 0x12, 0x5f, 0x97, 0x31, 0x29, 0xb6, 0xa3, 0xe0, 0x0d, 0xd3, 0x36, 0x70,
-	0x5b, 0x1c, 0x5b, 0x4e, 0xc0, 0x3d, 0x58, 0x3d, 0x0a, 0xde, 0x8c, 0x5e, 0x06, 0xf1, 0xf8, 0x69,
-	0x1c, 0x2d, 0xe6, 0xc3, 0x81, 0xdb, 0x66, 0x32, 0x65, 0x32, 0xde, 0x03, 0x10, 0xa4, 0xe1, 0xc0,
-	0xed, 0x30, 0x21, 0x85, 0x82, 0xbf, 0xc8, 0xf1, 0x73, 0x4b, 0xc1, 0x68, 0xa9, 0x14, 0xa0, 0xd2,
-	0x47, 0x44, 0x48, 0x2f, 0x99, 0xa5, 0x73, 0x01, 0xef, 0x10, 0xda, 0x82, 0x8c, 0x57, 0xc0, 0x19,
-	0x0e, 0xb2, 0x3d, 0x71, 0x86, 0x03, 0xba, 0x4b, 0x8f, 0xc6, 0xe3, 0xd8, 0x75, 0xba, 0xa8, 0xd7,
-	0xf1, 0xd9, 0x37, 0x76, 0xa1, 0x75, 0x76, 0x70, 0xca, 0xc8, 0x35, 0x46, 0x16, 0x43, 0xef, 0xdf,
-	0x08, 0xee, 0xa8, 0xfe, 0xa4, 0xd3, 0x8f, 0x83, 0x19, 0x61, 0x0b, 0x76, 0x7c, 0xf6, 0x8d, 0xdf,
-	0x83, 0xad, 0x01, 0x79, 0x11, 0x2c, 0xa6, 0xa9, 0x4f, 0x52, 0x12, 0xa6, 0x93, 0x28, 0x3c, 0x8d,
-	0xa6, 0x93, 0xf3, 0x0b, 0xb6, 0xeb, 0x1d, 0xdf, 0xc2, 0xc5, 0x4f, 0xe1, 0x6e, 0x91, 0x34, 0x21,
-	0x89, 0x5b, 0x63, 0xc6, 0xed, 0x70, 0xe3, 0x4a, 0x33, 0x98, 0x9d, 0xfa, 0x1c, 0xba, 0xd0, 0x41,
-	0x14, 0xa6, 0x93, 0x70, 0x11, 0x2d, 0x92, 0x6f, 0x2f, 0x48, 0x3c, 0xc9, 0xa3, 0x27, 0x5b, 0xa8,
-	0xc8, 0xce, 0x16, 0xd2, 0xe6, 0x78, 0xbf, 0x46, 0xb0, 0x5e, 0xd2, 0x39, 0x9a, 0x93, 0x73, 0xc5,
-	0x6a, 0x94, 0x5b, 0x7d, 0x0f, 0xda, 0x83, 0x45, 0x1c, 0x50, 0x49, 0xe6, 0xcc, 0x9a, 0x9f, 0x8f,
-	0xf1, 0x3e, 0x60, 0x19, 0x0c, 0xb9, 0x54, 0x8d, 0x49, 0x19, 0x38, 0x74, 0x2d, 0x9f, 0xcc, 0xa7,
-	0x93, 0xf3, 0xe0, 0xd8, 0xad, 0x77, 0x51, 0x6f, 0xd9, 0xcf, 0xc7, 0xde, 0x2f, 0x1d, 0x0d, 0x93,
-	0x75, 0x27, 0x8a, 0x98, 0x9c, 0x1b, 0x61, 0x72, 0x6e, 0x84, 0xc9, 0x51, 0x31, 0xe1, 0xf7, 0x60,
-	0x49, 0xce, 0x10, 0xc7, 0x6f, 0x83, 0xbb, 0x5a, 0x39, 0x05, 0xd4, 0xcb, 0xaa, 0x20, 0xfe, 0x2a,
-	0x2c, 0x8f, 0x16, 0xcf, 0x93, 0xf3, 0x78, 0x32, 0xa7, 0x3a, 0xc4, 0x51, 0xdc, 0xca, 0x66, 0x2a,
-	0x2c, 0x36, 0xb7, 0x28, 0xec, 0xfd, 0x03, 0xc1, 0x4a, 0x71, 0x75, 0x2d, 0xba, 0x77, 0xa1, 0x33,
-	0x4a, 0x83, 0x38, 0x3d, 0x9b, 0xcc, 0x48, 0xe6, 0x01, 0x49, 0xa0, 0x71, 0xfe, 0x38, 0x1c, 0x33,
-	0x1e, 0xb7, 0x5b, 0x0c, 0xe9, 0xbc, 0x01, 0x99, 0x92, 0x94, 0x8c, 0x1f, 0xa5, 0xcc, 0xda, 0x9a,
-	0x2f, 0x09, 0xf8, 0xf3, 0xd0, 0x64, 0x7a, 0x85, 0xa5, 0xab, 0x8a, 0xa5, 0x0c, 0x68, 0xc6, 0xc6,
-	0x5d, 0x58, 0x3a, 0x8b, 0x17, 0xe1, 0x79, 0xc0, 0x17, 0x6a, 0xb2, 0x0d, 0x57, 0x49, 0x1e, 0x81,
-	0x4e, 0x3e, 0x4d, 0x43, 0xbf, 0x07, 0xed, 0x93, 0xd7, 0x21, 0x4d, 0x82, 0x89, 0xeb, 0x74, 0x6b,
-	0xbd, 0xfa, 0xfb, 0x8e, 0x8b, 0xfc, 0x9c, 0x86, 0x7b, 0xd0, 0x64, 0xdf, 0xe2, 0x94, 0xac, 0x29,
-	0x38, 0x18, 0xc3, 0xcf, 0xf8, 0xde, 0x77, 0x60, 0xad, 0xec, 0x4d, 0x63, 0xc0, 0x60, 0xa8, 0x1f,
-	0x45, 0x63, 0x92, 0x1d, 0x54, 0xf6, 0x8d, 0x3d, 0xb8, 0x33, 0x20, 0x49, 0x3a, 0x09, 0x03, 0xbe,
-	0x47, 0x54, 0x57, 0xc7, 0x2f, 0xd0, 0xbc, 0x87, 0x00, 0x52, 0x2b, 0xde, 0x82, 0x66, 0x96, 0x30,
-	0xb9, 0x2d, 0xd9, 0xc8, 0xfb, 0x06, 0xac, 0x1b, 0x0e, 0x9e, 0x11, 0xc8, 0x06, 0x34, 0x98, 0x40,
-	0x86, 0x84, 0x0f, 0xbc, 0x4b, 0x68, 0x8b, 0xfc, 0x6c, 0x83, 0x7f, 0x18, 0x24, 0x2f, 0x05, 0x7c,
-	0xfa, 0x4d, 0x57, 0x7a, 0x34, 0x9e, 0x4d, 0x78, 0x68, 0xb7, 0x7d, 0x3e, 0xc0, 0x5f, 0x01, 0x38,
-	0x8d, 0x27, 0xaf, 0x26, 0x53, 0xf2, 0x61, 0x9e, 0x1b, 0xd6, 0xe5, 0x0d, 0x90, 0xf3, 0x7c, 0x45,
-	0xcc, 0x1b, 0xc2, 0x72, 0x81, 0xc9, 0xce, 0x57, 0x96, 0x0d, 0x33, 0x1c, 0xf9, 0x98, 0x86, 0x50,
-	0x2e, 0xc8, 0x00, 0x35, 0x7c, 0x49, 0xf0, 0xfe, 0xd9, 0x82, 0xd6, 0x41, 0x34, 0x9b, 0x05, 0xe1,
-	0x18, 0xbf, 0x0b, 0xf5, 0xf4, 0x62, 0xce, 0x57, 0x58, 0x11, 0xb7, 0x56, 0xc6, 0xdc, 0x3f, 0xbb,
-	0x98, 0x13, 0x9f, 0xf1, 0xbd, 0x4f, 0x5a, 0x50, 0xa7, 0x43, 0xbc, 0x09, 0x77, 0x0f, 0x62, 0x12,
-	0xa4, 0x84, 0xfa, 0x35, 0x13, 0x5c, 0x43, 0x94, 0xcc, 0x63, 0x54, 0x25, 0x3b, 0x78, 0x07, 0x36,
-	0xb9, 0xb4, 0x80, 0x26, 0x58, 0x35, 0xbc, 0x0d, 0xeb, 0x83, 0x38, 0x9a, 0x97, 0x19, 0x75, 0xdc,
-	0x85, 0x5d, 0x3e, 0xa7, 0x94, 0x69, 0x84, 0x44, 0x03, 0xef, 0xc1, 0x3d, 0x3a, 0xd5, 0xc2, 0x6f,
-	0xe2, 0x87, 0xd0, 0x1d, 0x91, 0xd4, 0x9c, 0xe9, 0x85, 0x54, 0x8b, 0xea, 0xf9, 0x60, 0x3e, 0xb6,
-	0xeb, 0x69, 0xe3, 0xfb, 0xb0, 0xcd, 0x91, 0xc8, 0x93, 0x2e, 0x98, 0x1d, 0xca, 0xe4, 0x16, 0xeb,
-	0x4c, 0x90, 0x36, 0x94, 0x62, 0x4e, 0x48, 0x2c, 0x09, 0x1b, 0x2c, 0xfc, 0x3b, 0xd2, 0xcf, 0x74,
-	0xd7, 0x05, 0x79, 0x19, 0xaf, 0xc3, 0x2a, 0x9d, 0xa6, 0x12, 0x57, 0xa8, 0x2c, 0xb7, 0x44, 0x25,
-	0xaf, 0x52, 0x0f, 0x8f, 0x48, 0x9a, 0xef, 0xbb, 0x60, 0xac, 0x61, 0x0c, 0x2b, 0xd4, 0x3f, 0x41,
-	0x1a, 0x08, 0xda, 0x5d, 0xbc, 0x0b, 0xee, 0x88, 0xa4, 0x2c, 0x40, 0xb5, 0x19, 0x58, 0x6a, 0x50,
-	0xb7, 0x77, 0x1d, 0x3f, 0x80, 0x9d, 0xcc, 0x41, 0xca, 0x01, 0x17, 0xec, 0x4d, 0xe6, 0xa2, 0x38,
-	0x9a, 0x9b, 0x98, 0x5b, 0x74, 0x49, 0x9f, 0xcc, 0xa2, 0x57, 0xe4, 0x94, 0x48, 0xd0, 0xdb, 0x32,
-	0x62, 0x44, 0x09, 0x21, 0x58, 0x6e, 0x31, 0x98, 0x54, 0xd6, 0x0e, 0x65, 0x71, 0x7c, 0x65, 0xd6,
-	0x3d, 0xca, 0xe2, 0xfb, 0x54, 0x5e, 0xf0, 0xbe, 0x64, 0x95, 0x67, 0xed, 0xe2, 0x2d, 0xc0, 0x23,
-	0x92, 0x96, 0xa7, 0x3c, 0xc0, 0x1b, 0xb0, 0xc6, 0x4c, 0xa2, 0x7b, 0x2e, 0xa8, 0x7b, 0x74, 0x33,
-	0x45, 0x62, 0x55, 0xae, 0x18, 0xc1, 0x7f, 0x87, 0x3a, 0xe2, 0x34, 0x5e, 0x84, 0x26, 0x66, 0x97,
-	0x99, 0x15, 0xcd, 0x2f, 0x64, 0x0e, 0x13, 0xac, 0xcf, 0xd1, 0x79, 0xdc, 0x47, 0x3a, 0xd3, 0xfb,
-	0x42, 0xbb, 0x3d, 0x5e, 0xbb, 0xba, 0xba, 0xba, 0x72, 0xbc, 0x4b, 0xc3, 0x99, 0x64, 0xf9, 0x28,
-	0x4a, 0x52, 0x91, 0xa3, 0xe8, 0x37, 0xa5, 0xf9, 0x41, 0x38, 0xce, 0x2a, 0x60, 0xf6, 0xdd, 0xff,
-	0x26, 0xb4, 0xce, 0xb3, 0x29, 0xcb, 0x85, 0xe3, 0xef, 0x92, 0x2e, 0xea, 0x2d, 0xf5, 0xb7, 0x33,
-	0x62, 0x59, 0x81, 0x2f, 0xa6, 0x79, 0x1f, 0x19, 0xce, 0xbe, 0x76, 0x9f, 0x6c, 0x40, 0xe3, 0x49,
-	0x14, 0x9f, 0xf3, 0x74, 0xd4, 0xf6, 0xf9, 0xa0, 0x42, 0xf9, 0x0b, 0x55, 0xb9, 0xb6, 0xbc, 0x54,
-	0xfe, 0x17, 0x64, 0x49, 0x31, 0xc6, 0x24, 0x7d, 0x00, 0xab, 0x7a, 0x5d, 0x88, 0xaa, 0x8b, 0xbc,
-	0xf2, 0x8c, 0xfe, 0xc0, 0x0a, 0xfa, 0x43, 0xb6, 0xd6, 0x7d, 0xd5, 0x63, 0x25, 0x54, 0x12, 0xf8,
-	0xcc, 0x98, 0xff, 0x4c, 0xa8, 0xfb, 0xef, 0x5b, 0x15, 0xbe, 0x54, 0xc1, 0x1b, 0x96, 0x93, 0xea,
-	0xfe, 0x85, 0xaa, 0xd3, 0x6a, 0xe5, 0x7d, 0x62, 0x74, 0x9b, 0x73, 0x3b, 0xb7, 0xd1, 0x8a, 0x27,
-	0x4b, 0xc9, 0xac, 0xfa, 0x6c, 0xfb, 0x62, 0xd8, 0x7f, 0x66, 0xb5, 0x6f, 0xc2, 0xec, 0xf3, 0x54,
-	0x87, 0x9a, 0xe1, 0x4b, 0x43, 0x7f, 0x87, 0xaa, 0x6e, 0x87, 0x4a, 0x33, 0x85, 0xef, 0x1d, 0xc5,
-	0xf7, 0x43, 0x2b, 0xb6, 0xef, 0x32, 0x6c, 0x5d, 0xe9, 0xfb, 0xeb, 0x90, 0x7d, 0x8a, 0xae, 0xbf,
-	0x97, 0x6e, 0x8d, 0xef, 0xc4, 0x8a, 0xef, 0x7b, 0x0c, 0xdf, 0xbb, 0x59, 0x5d, 0x76, 0x8d, 0x5e,
-	0x89, 0xf2, 0xaf, 0x4e, 0xf5, 0xbd, 0x78, 0x5b, 0x84, 0x74, 0xdf, 0x8f, 0xc9, 0x6b, 0x46, 0xce,
-	0x3a, 0xba, 0x6c, 0x58, 0x68, 0x11, 0xea, 0xa5, 0xb6, 0x45, 0x2d, 0xf9, 0x1b, 0xc5, 0x36, 0xc4,
-	0xd2, 0x3e, 0x34, 0xad, 0x2d, 0x8d, 0x12, 0x79, 0xad, 0x9b, 0x46, 0xde, 0x54, 0x8d, 0xbc, 0x2a,
-	0x7f, 0x48, 0xcf, 0xfd, 0x19, 0x59, 0xeb, 0x85, 0x4a, 0xa7, 0x6d, 0x41, 0xb3, 0xd0, 0xa3, 0x66,
-	0x23, 0x5a, 0xc5, 0xd1, 0x86, 0x20, 0x49, 0x83, 0xd9, 0x3c, 0x6b, 0x12, 0x24, 0xa1, 0xff, 0xc4,
-	0x0a, 0x7d, 0xc6, 0xa0, 0x3f, 0x50, 0x0f, 0x8d, 0x06, 0x48, 0xa2, 0xfe, 0x1b, 0xb2, 0x16, 0x32,
-	0x9f, 0x09, 0xb5, 0x07, 0x77, 0x0a, 0x6f, 0x12, 0xfc, 0x4d, 0xa5, 0x40, 0xab, 0xc0, 0x1e, 0xaa,
-	0xd8, 0x2d, 0xb0, 0x24, 0xf6, 0x3f, 0xa1, 0xea, 0x3a, 0xeb, 0xd6, 0xb1, 0x9a, 0x97, 0xfe, 0x35,
-	0xa5, 0xf4, 0xaf, 0x88, 0x92, 0x48, 0xcf, 0x4f, 0x66, 0x24, 0x7a, 0x7e, 0x7a, 0x3b, 0x88, 0x2b,
-	0xf2, 0xd3, 0xbc, 0x9c, 0x9f, 0xae, 0x43, 0xf6, 0x1b, 0x64, 0xa8, 0x39, 0xff, 0xb7, 0x5e, 0xa7,
-	0xe2, 0x82, 0xff, 0xbe, 0x5e, 0x5d, 0x28, 0x6a, 0x25, 0x2a, 0xa2, 0x55, 0xbc, 0xc6, 0x3b, 0xf2,
-	0xeb, 0x56, 0x45, 0x31, 0x53, 0xb4, 0x29, 0xfd, 0x60, 0x54, 0x73, 0x69, 0xa8, 0xa1, 0x6f, 0x6a,
-	0x7b, 0x85, 0x95, 0x89, 0x6a, 0xa5, 0xa6, 0x40, 0xaa, 0xff, 0x23, 0x32, 0x16, 0xeb, 0x34, 0x1c,
-	0xa8, 0x7c, 0x28, 0x51, 0xe4, 0xe3, 0x42, 0xa8, 0x38, 0x55, 0x1d, 0x60, 0xad, 0xd4, 0x01, 0x56,
-	0x14, 0x14, 0xa9, 0x5a, 0x50, 0x18, 0x00, 0x49, 0xc4, 0x51, 0xb9, 0x89, 0xc0, 0x7b, 0xfc, 0xf1,
-	0x95, 0xe1, 0x5c, 0xea, 0x83, 0x7c, 0x01, 0xf5, 0x19, 0xbd, 0xff, 0x35, 0xab, 0xd6, 0x05, 0xd3,
-	0xba, 0x21, 0xaf, 0x2a, 0xb9, 0xaa, 0x54, 0xf8, 0x5b, 0x64, 0x6f, 0x51, 0x2a, 0xfd, 0x94, 0x47,
-	0xa6, 0xa3, 0x46, 0xe6, 0x53, 0x2b, 0x9a, 0x57, 0x0c, 0xcd, 0x5e, 0x8e, 0xc6, 0xa8, 0x51, 0xe2,
-	0xba, 0x30, 0xf4, 0x46, 0xa6, 0xa7, 0x4e, 0x56, 0x8d, 0x3b, 0xb2, 0x1a, 0xaf, 0x88, 0x9a, 0xd7,
-	0x7a, 0xd4, 0x18, 0x8b, 0xdf, 0xff, 0xa0, 0x8a, 0x06, 0xcc, 0xfa, 0x2a, 0x67, 0x8b, 0x99, 0x9e,
-	0x5e, 0xe5, 0xf1, 0x34, 0xa8, 0x95, 0x72, 0xe2, 0xa9, 0xa6, 0x5e, 0xf1, 0x54, 0xd3, 0xd0, 0x9f,
-	0x6a, 0xfa, 0x87, 0x56, 0x8b, 0x2f, 0x98, 0xc5, 0xef, 0x14, 0xee, 0x2c, 0xdd, 0x24, 0x69, 0xf9,
-	0xdf, 0x91, 0xb5, 0xb7, 0xfc, 0xff, 0xd9, 0x5d, 0x71, 0x6f, 0xfd, 0xa0, 0x70, 0x6f, 0x99, 0x81,
-	0x15, 0x42, 0x46, 0xeb, 0x7d, 0xf3, 0x90, 0x41, 0xda, 0xeb, 0xb8, 0x23, 0x5e, 0xc7, 0x2b, 0x42,
-	0xe6, 0x23, 0x35, 0x64, 0xb4, 0xc5, 0xa5, 0xea, 0x3f, 0x20, 0x4b, 0x83, 0x4d, 0x5d, 0x74, 0x78,
-	0x76, 0xc6, 0x9f, 0xde, 0xb3, 0x23, 0x24, 0xc6, 0xea, 0xab, 0x3c, 0x87, 0x23, 0x86, 0x79, 0x4b,
-	0x59, 0x53, 0x5a, 0x4a, 0x7b, 0x83, 0xf4, 0x43, 0xbd, 0x41, 0x2a, 0xc1, 0x28, 0x5c, 0x47, 0xe6,
-	0x7e, 0xff, 0xb3, 0x21, 0xad, 0x40, 0x75, 0x69, 0x6e, 0xdb, 0x8c, 0xa8, 0x3e, 0x45, 0x96, 0xa7,
-	0x06, 0xed, 0xc8, 0xab, 0x28, 0x1d, 0x3b, 0xca, 0xda, 0x4d, 0x51, 0xfe, 0x48, 0x45, 0x69, 0x84,
-	0xa0, 0x36, 0x97, 0xe6, 0x47, 0x8f, 0x32, 0xc8, 0x0a, 0x75, 0x3f, 0x56, 0xd5, 0x19, 0x17, 0x93,
-	0xea, 0x42, 0xcb, 0x43, 0x8a, 0xa6, 0xee, 0xb1, 0x55, 0xdd, 0x15, 0xd2, 0xf5, 0x59, 0xcd, 0x7b,
-	0x42, 0x9b, 0x83, 0x64, 0x1e, 0x85, 0x09, 0xa1, 0x2a, 0x4e, 0x9e, 0x31, 0x15, 0x6d, 0xdf, 0x39,
-	0x79, 0x46, 0xb3, 0xfd, 0xe3, 0x38, 0x8e, 0xc4, 0x5f, 0x25, 0x3e, 0x90, 0x3f, 0xff, 0x6a, 0xec,
-	0x7c, 0xf1, 0x81, 0xf7, 0x7b, 0x64, 0x7a, 0xe6, 0x79, 0x8b, 0x27, 0xc1, 0x7e, 0xd1, 0xfe, 0x84,
-	0xdb, 0xeb, 0xe6, 0xb7, 0x8c, 0xd5, 0xb9, 0x63, 0xfd, 0xc9, 0x49, 0xf3, 0xab, 0x3d, 0x2f, 0xfc,
-	0x94, 0xeb, 0xd9, 0x52, 0x32, 0x93, 0xb2, 0x90, 0xd4, 0xf2, 0x31, 0xaa, 0x7a, 0xc3, 0x2a, 0xf6,
-	0x22, 0xa8, 0xdc, 0x8b, 0x7c, 0xcb, 0xaa, 0xfe, 0x67, 0x48, 0xad, 0x42, 0xed, 0x0a, 0x24, 0x90,
-	0xe7, 0xd6, 0xb7, 0xb2, 0x8a, 0x2b, 0xfb, 0xe7, 0x48, 0xcd, 0xbf, 0x96, 0xf9, 0x05, 0x63, 0xcd,
-	0x6f, 0x6e, 0xda, 0x21, 0x96, 0xbf, 0x13, 0x1c, 0xf5, 0x77, 0x42, 0x45, 0x20, 0xff, 0xa2, 0x10,
-	0xc8, 0x46, 0x2d, 0x12, 0xc8, 0xaf, 0x90, 0xf5, 0x85, 0xef, 0xc6, 0x50, 0xec, 0x5e, 0xf9, 0xb8,
-	0xe0, 0x15, 0x8b, 0x9e, 0x1c, 0xcc, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x7d, 0xe7, 0x5c, 0xdb,
-	0x59, 0x1f, 0x00, 0x00,
+	// 2880 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x4d, 0x8c, 0x1c, 0x47,
+	0x15, 0x56, 0xf5, 0xcc, 0xec, 0xce, 0xd4, 0x7a, 0x7f, 0x5c, 0xfb, 0xe3, 0xf6, 0xdf, 0x7a, 0xd2,
+	0x18, 0x67, 0xed, 0x24, 0x0e, 0x4c, 0x50, 0x04, 0x12, 0x24, 0x71, 0x76, 0xfc, 0xb3, 0xac, 0x7f,
+	0xd6, 0x3d, 0x9b, 0x1c, 0x38, 0x20, 0xb5, 0x77, 0xca, 0x76, 0x93, 0x99, 0xee, 0x49, 0x77, 0x8f,
+	0xed, 0x25, 0x18, 0xcc, 0x5f, 0x80, 0x10, 0x20, 0x21, 0x42, 0x5c, 0x10, 0x42, 0x44, 0x82, 0x23,
+	0x42, 0x20, 0xc2, 0xdf, 0x25, 0x47, 0xc4, 0x9d, 0x53, 0xce, 0x1c, 0x91, 0x38, 0xc1, 0x11, 0xd5,
+	0x5f, 0x57, 0x75, 0x77, 0x55, 0xed, 0x6c, 0xd8, 0xdc, 0xba, 0xde, 0x7b, 0x55, 0xef, 0x7b, 0x55,
+	0xaf, 0xea, 0xbd, 0x7a, 0xd5, 0x70, 0x31, 0x8c, 0x32, 0x9c, 0x44, 0xc1, 0xe0, 0xe9, 0x21, 0xce,
+	0x82, 0xf3, 0xa3, 0x24, 0xce, 0x62, 0x54, 0x27, 0xdf, 0xde, 0x5b, 0x35, 0x58, 0xef, 0x06, 0x59,
+	0x80, 0x10, 0xac, 0x6f, 0xe3, 0x64, 0xe8, 0x82, 0xb6, 0xb3, 0x56, 0xf7, 0xe9, 0x37, 0x5a, 0x82,
+	0x8d, 0x8d, 0xa8, 0x8f, 0x1f, 0xb8, 0x0e, 0x25, 0xb2, 0x06, 0x3a, 0x01, 0x5b, 0xeb, 0x83, 0x71,
+	0x9a, 0xe1, 0x64, 0xa3, 0xeb, 0xd6, 0x28, 0x47, 0x12, 0xd0, 0x69, 0xd8, 0xb8, 0x1e, 0xf7, 0x71,
+	0xea, 0xd6, 0xdb, 0xb5, 0xb5, 0x99, 0xce, 0xdc, 0x79, 0xaa, 0x92, 0x90, 0x36, 0xa2, 0xdb, 0xb1,
+	0xcf, 0x98, 0xe8, 0x13, 0xb0, 0x45, 0xb4, 0xde, 0x0a, 0x52, 0x9c, 0xba, 0x0d, 0x2a, 0x89, 0x98,
+	0xa4, 0x20, 0x53, 0x69, 0x29, 0x44, 0xc6, 0x7d, 0x29, 0xc5, 0x49, 0xea, 0x4e, 0xa9, 0xe3, 0x12,
+	0x12, 0x1b, 0x97, 0x32, 0x09, 0xb6, 0x6b, 0xc1, 0x03, 0xaa, 0xad, 0xeb, 0x4e, 0x33, 0x6c, 0x39,
+	0x01, 0xad, 0xc1, 0xf9, 0x6b, 0xc1, 0x83, 0xde, 0xdd, 0x20, 0xe9, 0x5f, 0x4e, 0xe2, 0xf1, 0x68,
+	0xa3, 0xeb, 0x36, 0xa9, 0x4c, 0x99, 0x8c, 0x56, 0x21, 0x14, 0xa4, 0x8d, 0xae, 0xdb, 0xa2, 0x42,
+	0x0a, 0x05, 0x3d, 0xc9, 0xf0, 0x33, 0x4b, 0xa1, 0xd6, 0x52, 0x29, 0x40, 0xa4, 0xaf, 0x61, 0x21,
+	0x3d, 0xa3, 0x97, 0xce, 0x05, 0xbc, 0x2b, 0xb0, 0x29, 0xc8, 0x68, 0x0e, 0x3a, 0x1b, 0x5d, 0xbe,
+	0x26, 0xce, 0x46, 0x97, 0xac, 0xd2, 0x85, 0x7e, 0x3f, 0x71, 0x9d, 0x36, 0x58, 0x6b, 0xf9, 0xf4,
+	0x1b, 0xb9, 0x70, 0x7a, 0x7b, 0x7d, 0x8b, 0x92, 0x6b, 0x94, 0x2c, 0x9a, 0xde, 0x7b, 0x35, 0x78,
+	0x48, 0x9d, 0x4f, 0xd2, 0xfd, 0x7a, 0x30, 0xc4, 0x74, 0xc0, 0x96, 0x4f, 0xbf, 0xd1, 0xb3, 0x70,
+	0xa5, 0x8b, 0x6f, 0x07, 0xe3, 0x41, 0xe6, 0xe3, 0x0c, 0x47, 0x59, 0x18, 0x47, 0x5b, 0xf1, 0x20,
+	0xdc, 0xd9, 0xa5, 0xab, 0xde, 0xf2, 0x0d, 0x5c, 0x74, 0x19, 0x1e, 0x2e, 0x92, 0x42, 0x9c, 0xba,
+	0x35, 0x6a, 0xdc, 0x51, 0x66, 0x5c, 0xa9, 0x07, 0xb5, 0xb3, 0xda, 0x87, 0x0c, 0xb4, 0x1e, 0x47,
+	0x59, 0x18, 0x8d, 0xe3, 0x71, 0x7a, 0x73, 0x8c, 0x93, 0x30, 0xf7, 0x1e, 0x3e, 0x50, 0x91, 0xcd,
+	0x07, 0xaa, 0xf4, 0x41, 0x9b, 0x10, 0x5d, 0xc3, 0x41, 0x3a, 0x4e, 0xf0, 0x10, 0x47, 0x59, 0x6f,
+	0xe7, 0x2e, 0x1e, 0x06, 0xc2, 0xbb, 0x8e, 0xb3, 0x91, 0x2a, 0x7c, 0x3a, 0x96, 0xa6, 0x1b, 0xda,
+	0x80, 0x87, 0xd7, 0x83, 0xa4, 0x1f, 0x46, 0xc1, 0x20, 0xcc, 0x76, 0xaf, 0x86, 0xc3, 0x30, 0x23,
+	0xbe, 0x07, 0xe4, 0x58, 0x15, 0x36, 0xc7, 0x55, 0x26, 0xa3, 0x4f, 0xc2, 0x56, 0x37, 0x4c, 0x5f,
+	0xb9, 0x39, 0x8e, 0xb3, 0xc0, 0x9d, 0xa6, 0x43, 0x2c, 0x72, 0x67, 0x17, 0x64, 0xee, 0x31, 0xa2,
+	0xe9, 0xbd, 0x0d, 0xe0, 0x62, 0x69, 0xfa, 0x7a, 0x23, 0xbc, 0xa3, 0x2c, 0x20, 0xc8, 0x17, 0xf0,
+	0x18, 0x6c, 0x76, 0xc7, 0x49, 0x40, 0x24, 0xa9, 0x5f, 0xd4, 0xfc, 0xbc, 0x8d, 0xce, 0x43, 0x24,
+	0xfd, 0x3a, 0x97, 0xaa, 0x51, 0x29, 0x0d, 0x87, 0x8c, 0xe5, 0xe3, 0xd1, 0x20, 0xdc, 0x09, 0xae,
+	0xbb, 0xf5, 0x36, 0x58, 0x9b, 0xf5, 0xf3, 0xb6, 0xf7, 0xb7, 0x5a, 0x05, 0x93, 0xd1, 0xa9, 0x8a,
+	0x98, 0x9c, 0x89, 0x30, 0x39, 0x13, 0x61, 0x72, 0x54, 0x4c, 0xe8, 0x59, 0x38, 0x23, 0x7b, 0x88,
+	0xb5, 0x5e, 0x62, 0x93, 0xab, 0x6c, 0x68, 0x32, 0xbb, 0xaa, 0x20, 0xfa, 0x2c, 0x9c, 0xed, 0x8d,
+	0x6f, 0xa5, 0x3b, 0x49, 0x38, 0x22, 0x3a, 0xc4, 0xa9, 0xb2, 0xc2, 0x7b, 0x2a, 0x2c, 0xda, 0xb7,
+	0x28, 0x4c, 0xb4, 0xfa, 0xf1, 0x60, 0x30, 0x1e, 0xf9, 0xe3, 0x01, 0x4e, 0xdd, 0x69, 0x55, 0xab,
+	0x64, 0x30, 0xad, 0x8a, 0x20, 0x7a, 0x02, 0x4e, 0xfb, 0x38, 0x25, 0x30, 0xdc, 0x26, 0x75, 0x83,
+	0xc3, 0xbc, 0x0f, 0x23, 0xd2, 0x0e, 0x42, 0x82, 0x1c, 0x56, 0xdc, 0x4c, 0xa2, 0xf4, 0x5a, 0xdc,
+	0xc7, 0x6e, 0x8b, 0xae, 0x7a, 0x99, 0x5c, 0xf4, 0x2f, 0x38, 0x91, 0x7f, 0xbd, 0x0f, 0xe0, 0x5c,
+	0x71, 0x7e, 0x2a, 0x47, 0xcd, 0x09, 0xd8, 0xea, 0x65, 0x41, 0x92, 0x6d, 0x87, 0x43, 0xcc, 0xd7,
+	0x50, 0x12, 0xc8, 0xa1, 0x73, 0x31, 0xea, 0x53, 0x1e, 0x5b, 0x39, 0xd1, 0x24, 0xfd, 0xba, 0x78,
+	0x80, 0x33, 0xdc, 0xbf, 0x90, 0xd1, 0xf5, 0xaa, 0xf9, 0x92, 0x80, 0x1e, 0x87, 0x53, 0x54, 0xaf,
+	0x58, 0xab, 0x79, 0x65, 0xad, 0x28, 0x48, 0xce, 0x46, 0x6d, 0x38, 0xb3, 0x9d, 0x8c, 0xa3, 0x9d,
+	0x80, 0x0d, 0x34, 0x45, 0x5d, 0x56, 0x25, 0x79, 0x0f, 0x61, 0x2b, 0xef, 0x56, 0x41, 0xbf, 0x0a,
+	0x9b, 0x37, 0xee, 0x47, 0x24, 0x22, 0xa5, 0xae, 0xd3, 0xae, 0xad, 0xd5, 0x5f, 0x74, 0x5c, 0xe0,
+	0xe7, 0x34, 0xb4, 0x06, 0xa7, 0xe8, 0xb7, 0x38, 0xb2, 0x16, 0x14, 0x1c, 0x94, 0xe1, 0x73, 0x3e,
+	0x5a, 0x81, 0x53, 0x57, 0x71, 0xd0, 0xc7, 0x09, 0xdd, 0x10, 0x75, 0x9f, 0xb7, 0xbc, 0x2f, 0xc2,
+	0x85, 0xb2, 0x9f, 0x68, 0xb7, 0x02, 0x82, 0x75, 0xba, 0x78, 0xec, 0x34, 0xa5, 0xdf, 0xc8, 0x83,
+	0x87, 0xba, 0x38, 0xcd, 0xc2, 0x28, 0x60, 0xde, 0x47, 0x30, 0xb4, 0xfc, 0x02, 0xcd, 0x7b, 0x19,
+	0xce, 0x15, 0x7d, 0x49, 0x3b, 0xfa, 0x12, 0x6c, 0x5c, 0xb8, 0x9d, 0xe1, 0x84, 0xaf, 0x10, 0x6b,
+	0x90, 0x2d, 0xb3, 0x41, 0x42, 0xfe, 0xbd, 0x60, 0xc0, 0x97, 0x27, 0x6f, 0x7b, 0x6f, 0x02, 0x38,
+	0xa3, 0x38, 0x5c, 0x61, 0xab, 0x82, 0xd2, 0x56, 0x2d, 0x6d, 0x2f, 0x67, 0xd2, 0xed, 0x75, 0x16,
+	0x4e, 0xad, 0xc7, 0x23, 0x19, 0x10, 0x8a, 0x7e, 0xbe, 0x1e, 0x8f, 0x76, 0x7d, 0x2e, 0xe0, 0x3d,
+	0x0f, 0x67, 0x14, 0x32, 0xf1, 0x2b, 0x11, 0x75, 0xd9, 0x62, 0x8a, 0x26, 0x59, 0x07, 0x1e, 0xd7,
+	0x59, 0x36, 0xc2, 0x5b, 0xde, 0xef, 0x01, 0x5c, 0xd6, 0x1e, 0xeb, 0xda, 0xf9, 0x7a, 0x0a, 0x4e,
+	0x5d, 0x0a, 0xf1, 0xa0, 0x2f, 0x8c, 0x59, 0x66, 0xc8, 0x28, 0x4d, 0x76, 0xf5, 0xb9, 0x10, 0x59,
+	0x28, 0x1f, 0xbf, 0x3a, 0x0e, 0x13, 0xdc, 0xdf, 0x0e, 0xee, 0xe4, 0x0b, 0xa5, 0xd2, 0x88, 0xa7,
+	0x5e, 0x18, 0x0c, 0xe2, 0xfb, 0x5c, 0xa4, 0x4e, 0x45, 0x54, 0x52, 0xee, 0x02, 0x0d, 0xe9, 0x02,
+	0xde, 0x67, 0xe0, 0x7c, 0x49, 0xa9, 0xc9, 0x7b, 0xb6, 0x77, 0x47, 0xcc, 0x7b, 0x1a, 0x3e, 0xfd,
+	0xf6, 0xfe, 0x0d, 0xe0, 0xb2, 0x36, 0xf8, 0xa0, 0x4f, 0xc3, 0x23, 0x24, 0x49, 0xa1, 0xe1, 0x70,
+	0x0b, 0x27, 0xca, 0xb4, 0xf0, 0xa5, 0x35, 0xb1, 0x79, 0x6a, 0xf4, 0x72, 0x30, 0x18, 0x53, 0xd6,
+	0x76, 0x70, 0x87, 0x7b, 0x54, 0x99, 0x8c, 0x9e, 0x83, 0x87, 0x94, 0x8e, 0x62, 0x85, 0x8f, 0xe9,
+	0x63, 0x22, 0x9d, 0xcc, 0x82, 0x3c, 0xfa, 0x14, 0x9c, 0xde, 0x0e, 0xee, 0x6c, 0xe2, 0x5d, 0x11,
+	0xe4, 0x6d, 0x5d, 0x85, 0xa8, 0xb7, 0x09, 0x67, 0x0b, 0x87, 0x19, 0x3d, 0x9e, 0xe2, 0xdb, 0x19,
+	0x15, 0xe5, 0xc6, 0x49, 0x02, 0xe1, 0x5e, 0x09, 0x92, 0x3e, 0xe3, 0xf2, 0xc3, 0x2b, 0x27, 0x78,
+	0x2f, 0xc0, 0x25, 0x9d, 0x36, 0xd3, 0x06, 0x53, 0x47, 0x61, 0x0d, 0xef, 0x0b, 0x10, 0xca, 0xa3,
+	0x42, 0x71, 0x4d, 0xa0, 0xba, 0x26, 0xf1, 0x8c, 0x9b, 0xe3, 0x20, 0x09, 0xa2, 0x2c, 0x8c, 0x70,
+	0x9f, 0x06, 0xe7, 0xa6, 0xaf, 0x92, 0xe8, 0xf2, 0x86, 0x58, 0x24, 0x6e, 0xf4, 0xdb, 0x7b, 0x1e,
+	0x2e, 0x6a, 0x12, 0x1e, 0x13, 0x38, 0x2a, 0xc0, 0x0f, 0x17, 0xd6, 0xf0, 0x1e, 0xc2, 0xa6, 0xc8,
+	0x8b, 0x4d, 0x3e, 0x75, 0x25, 0x48, 0xef, 0x8a, 0x13, 0x89, 0x7c, 0xd3, 0x73, 0xa4, 0x3f, 0x0c,
+	0x59, 0x1c, 0x6e, 0xfa, 0xac, 0x81, 0x9e, 0x81, 0x70, 0x2b, 0x09, 0xef, 0x85, 0x03, 0x7c, 0x27,
+	0xcf, 0xc9, 0x16, 0x65, 0xe6, 0x9d, 0xf3, 0x7c, 0x45, 0xcc, 0xdb, 0x80, 0xb3, 0x05, 0x26, 0x3d,
+	0x61, 0x78, 0x16, 0xca, 0x71, 0xe4, 0x6d, 0xb2, 0x50, 0xb9, 0x20, 0x77, 0x72, 0x49, 0xf0, 0x3e,
+	0x80, 0x70, 0x7a, 0x3d, 0x1e, 0x0e, 0x83, 0xa8, 0x8f, 0xce, 0xc0, 0x7a, 0xb6, 0x3b, 0x62, 0x23,
+	0xcc, 0x89, 0xdb, 0x02, 0x67, 0x9e, 0x27, 0xfb, 0xc2, 0xa7, 0x7c, 0xef, 0xe7, 0x90, 0x6d, 0x19,
+	0xb4, 0x0c, 0x0f, 0xaf, 0x27, 0x38, 0xc8, 0x30, 0x59, 0x0d, 0x2e, 0xb8, 0x00, 0x08, 0x99, 0x85,
+	0x23, 0x95, 0xec, 0xa0, 0xa3, 0x70, 0x99, 0x49, 0x0b, 0x68, 0x82, 0x55, 0x43, 0x47, 0xe0, 0x62,
+	0x37, 0x89, 0x47, 0x65, 0x46, 0x1d, 0xb5, 0xe1, 0x09, 0xd6, 0xa7, 0x94, 0x16, 0x09, 0x89, 0x06,
+	0x5a, 0x85, 0xc7, 0x48, 0x57, 0x03, 0x7f, 0x0a, 0x9d, 0x86, 0xed, 0x1e, 0xce, 0xf4, 0x19, 0xb6,
+	0x90, 0x9a, 0x26, 0x7a, 0x5e, 0x1a, 0xf5, 0xcd, 0x7a, 0x9a, 0xe8, 0x38, 0x3c, 0xc2, 0x90, 0xc8,
+	0x53, 0x58, 0x30, 0x5b, 0x84, 0xc9, 0x2c, 0xae, 0x32, 0xa1, 0xb4, 0xa1, 0xe4, 0x73, 0x42, 0x62,
+	0x46, 0xd8, 0x60, 0xe0, 0x1f, 0x92, 0xf3, 0x4c, 0x56, 0x5d, 0x90, 0x67, 0xd1, 0x22, 0x9c, 0x27,
+	0xdd, 0x54, 0xe2, 0x1c, 0x91, 0x65, 0x96, 0xa8, 0xe4, 0x79, 0x32, 0xc3, 0x3d, 0x9c, 0xe5, 0xeb,
+	0x2e, 0x18, 0x0b, 0x08, 0xc1, 0x39, 0x32, 0x3f, 0x41, 0x16, 0x08, 0xda, 0x61, 0x74, 0x02, 0xba,
+	0x3d, 0x9c, 0x51, 0x07, 0xad, 0xf4, 0x40, 0x52, 0x83, 0xba, 0xbc, 0x8b, 0xe8, 0x24, 0x3c, 0xca,
+	0x27, 0x48, 0x89, 0xd9, 0x82, 0xbd, 0x4c, 0xa7, 0x28, 0x89, 0x47, 0x3a, 0xe6, 0x0a, 0x19, 0xd2,
+	0xc7, 0xc3, 0xf8, 0x1e, 0xde, 0xc2, 0x12, 0xf4, 0x11, 0xe9, 0x31, 0xe2, 0xea, 0x26, 0x58, 0x6e,
+	0xd1, 0x99, 0x54, 0xd6, 0x51, 0xc2, 0x62, 0xf8, 0xca, 0xac, 0x63, 0x84, 0xc5, 0xd6, 0xa9, 0x3c,
+	0xe0, 0x71, 0xc9, 0x2a, 0xf7, 0x3a, 0x81, 0x56, 0x20, 0xea, 0xe1, 0xac, 0xdc, 0xe5, 0x24, 0x5a,
+	0x82, 0x0b, 0xd4, 0x24, 0x16, 0x5a, 0x19, 0x75, 0x95, 0x2c, 0xa6, 0xc8, 0xa1, 0x94, 0x80, 0x2d,
+	0xf8, 0xa7, 0xc8, 0x44, 0x6c, 0x25, 0xe3, 0x48, 0xc7, 0x6c, 0x53, 0xb3, 0xe2, 0xd1, 0xae, 0x3c,
+	0xf9, 0x04, 0xeb, 0x31, 0xd2, 0x8f, 0xcd, 0x51, 0x95, 0xe9, 0x21, 0x0f, 0xae, 0xf6, 0x70, 0x26,
+	0x39, 0xf2, 0x04, 0x14, 0x32, 0x1f, 0xe3, 0xab, 0x2a, 0x65, 0xc8, 0x51, 0x28, 0xb8, 0xa7, 0xa5,
+	0x7f, 0xcb, 0x94, 0x48, 0x30, 0x3f, 0x4e, 0x27, 0x87, 0x6c, 0xb2, 0x0a, 0xeb, 0x0c, 0xd1, 0x2c,
+	0xd6, 0xa8, 0x94, 0x21, 0x08, 0x99, 0xc7, 0xc9, 0x0e, 0x20, 0xdd, 0x8d, 0x12, 0x6b, 0xe8, 0x14,
+	0x3c, 0xde, 0xc3, 0x59, 0x25, 0xe4, 0x0a, 0x81, 0xb3, 0xc8, 0x85, 0x4b, 0xe2, 0x20, 0x48, 0xd5,
+	0xf9, 0x3e, 0x47, 0x80, 0xf7, 0x70, 0x96, 0x93, 0x47, 0x21, 0xce, 0x99, 0x4f, 0x90, 0xa5, 0x63,
+	0xa7, 0x43, 0xa1, 0xd3, 0x93, 0x68, 0x0d, 0x9e, 0xa6, 0x9d, 0x0a, 0x9b, 0x5d, 0xc9, 0xfa, 0x85,
+	0xe4, 0x53, 0xc4, 0x74, 0x31, 0x6b, 0x2c, 0x2d, 0x15, 0xac, 0xf3, 0x7c, 0x4f, 0xe5, 0x41, 0x53,
+	0x30, 0x9e, 0x3e, 0xd7, 0x6c, 0xf6, 0x17, 0x1e, 0x3d, 0x7a, 0xf4, 0xc8, 0xf1, 0x1e, 0x6a, 0x4e,
+	0x48, 0x1a, 0x1d, 0xe2, 0x34, 0x13, 0x11, 0x83, 0x7c, 0x13, 0x9a, 0x1f, 0x44, 0x7d, 0x9e, 0x79,
+	0xd1, 0xef, 0xce, 0x0b, 0x70, 0x7a, 0x87, 0x77, 0x99, 0x2d, 0x1c, 0xc6, 0x2e, 0xa6, 0x57, 0x90,
+	0x23, 0x9c, 0x58, 0x56, 0xe0, 0x8b, 0x6e, 0xde, 0x6b, 0x9a, 0x93, 0xb8, 0x92, 0xc8, 0x2f, 0xc1,
+	0xc6, 0xa5, 0x38, 0xd9, 0x61, 0xc1, 0xa1, 0xe9, 0xb3, 0x86, 0x45, 0xf9, 0x6d, 0x55, 0x79, 0x65,
+	0x78, 0xa9, 0xfc, 0x0f, 0xc0, 0x70, 0xe0, 0x6b, 0x43, 0xe6, 0x3a, 0x9c, 0x2f, 0x2d, 0x07, 0x8d,
+	0xe6, 0xd6, 0x52, 0x47, 0xb9, 0x47, 0xa7, 0x6b, 0x04, 0x7d, 0xa7, 0x50, 0x57, 0xd0, 0xa1, 0x92,
+	0xc0, 0x87, 0xda, 0x68, 0xa4, 0x43, 0xdd, 0x79, 0xd1, 0xa8, 0xf0, 0xae, 0x0a, 0x5e, 0x33, 0x9c,
+	0x54, 0xf7, 0x4f, 0x60, 0x0f, 0x72, 0xd6, 0xe8, 0xae, 0x9d, 0x36, 0x67, 0x7f, 0xd3, 0x46, 0xae,
+	0x04, 0x3c, 0x40, 0xd2, 0x34, 0xa9, 0xe9, 0x8b, 0x66, 0x67, 0xd3, 0x68, 0x5f, 0x48, 0xed, 0xf3,
+	0xd4, 0x09, 0xd5, 0xc3, 0x97, 0x86, 0xfe, 0x14, 0xd8, 0x62, 0xb5, 0xd5, 0x4c, 0x31, 0xf7, 0x8e,
+	0x32, 0xf7, 0x1b, 0x46, 0x6c, 0x5f, 0xa2, 0xd8, 0xda, 0x72, 0xee, 0xf7, 0x42, 0xf6, 0x2e, 0xd8,
+	0x3b, 0x4b, 0xd8, 0x37, 0xbe, 0x1b, 0x46, 0x7c, 0xaf, 0x50, 0x7c, 0x67, 0x18, 0x71, 0x2f, 0xbd,
+	0x12, 0xe5, 0x7b, 0x8e, 0x3d, 0x4b, 0xd9, 0x2f, 0x42, 0xb2, 0xee, 0xd7, 0xf1, 0x7d, 0x4a, 0xe6,
+	0x75, 0x4d, 0xde, 0x2c, 0x5c, 0x59, 0xeb, 0xa5, 0x8a, 0x97, 0x5a, 0x2d, 0x6a, 0x14, 0x2b, 0x58,
+	0x86, 0xca, 0xd3, 0x94, 0xb1, 0x1a, 0xa6, 0x78, 0xde, 0xf4, 0xa4, 0x9e, 0x37, 0x50, 0x3d, 0xcf,
+	0x36, 0x1f, 0x72, 0xe6, 0x7e, 0x07, 0x8c, 0xd9, 0x9b, 0x75, 0xd2, 0x56, 0xe0, 0x54, 0xa1, 0x52,
+	0xcb, 0x5b, 0x24, 0xa7, 0x26, 0x95, 0x98, 0x34, 0x0b, 0x86, 0x23, 0x7e, 0xfd, 0x97, 0x84, 0xce,
+	0x25, 0x23, 0xf4, 0x21, 0x85, 0x7e, 0x52, 0xdd, 0x34, 0x15, 0x40, 0x12, 0xf5, 0x9f, 0x80, 0x31,
+	0xad, 0xfc, 0x50, 0xa8, 0x3d, 0x78, 0xa8, 0x50, 0x99, 0x67, 0x2f, 0x0b, 0x05, 0x9a, 0x05, 0x7b,
+	0xa4, 0x62, 0x37, 0xc0, 0x92, 0xd8, 0x7f, 0x0b, 0xec, 0x59, 0xef, 0xbe, 0x7d, 0x35, 0xbf, 0x88,
+	0xd5, 0x94, 0x8b, 0x98, 0xc5, 0x4b, 0xe2, 0xea, 0xf9, 0xa4, 0x47, 0x52, 0x3d, 0x9f, 0x0e, 0x06,
+	0xb1, 0xe5, 0x7c, 0x1a, 0x95, 0xcf, 0xa7, 0xbd, 0x90, 0xbd, 0x03, 0x34, 0x37, 0x80, 0xff, 0xef,
+	0xe6, 0x69, 0x09, 0xf0, 0xaf, 0x56, 0xb3, 0x0b, 0x45, 0xad, 0x44, 0x85, 0x2b, 0xf7, 0x0f, 0x6d,
+	0x8c, 0x7c, 0xce, 0xa8, 0x28, 0x69, 0x03, 0x59, 0x20, 0x2a, 0x0d, 0x25, 0xd5, 0x3c, 0xd4, 0xdc,
+	0x68, 0x26, 0xb5, 0xdd, 0x62, 0x65, 0xaa, 0x5a, 0x59, 0x51, 0x20, 0xd5, 0xff, 0x06, 0x68, 0xaf,
+	0x4e, 0xc4, 0x1d, 0x88, 0x7c, 0x24, 0x51, 0xe4, 0xed, 0x82, 0xab, 0x38, 0xb6, 0xfb, 0x78, 0xad,
+	0x74, 0x1f, 0xb7, 0x24, 0x14, 0x99, 0x9a, 0x50, 0x68, 0x00, 0x49, 0xc4, 0x71, 0xf9, 0x4a, 0x87,
+	0x56, 0xd9, 0x13, 0x24, 0xc5, 0x39, 0xd3, 0x81, 0xf2, 0x1d, 0xd0, 0xa7, 0xf4, 0xce, 0xe7, 0x8c,
+	0x5a, 0xc7, 0x6d, 0xa0, 0x14, 0x24, 0x0b, 0xa3, 0x4a, 0x85, 0x3f, 0x01, 0xe6, 0x0b, 0xa3, 0x75,
+	0x9e, 0x72, 0xcf, 0x74, 0x54, 0xcf, 0xbc, 0x6c, 0x44, 0x73, 0x8f, 0xa2, 0x59, 0xcd, 0xd1, 0x68,
+	0x35, 0x4a, 0x5c, 0xbb, 0x9a, 0x9b, 0xaa, 0xee, 0xc1, 0x8f, 0x66, 0xe3, 0x8e, 0xcc, 0xc6, 0x2d,
+	0x5e, 0x73, 0xbf, 0xea, 0x35, 0xda, 0xe4, 0xf7, 0x3f, 0xc0, 0x72, 0x1d, 0x36, 0x3e, 0xe8, 0x98,
+	0x7c, 0x66, 0xad, 0x9a, 0xe5, 0xb1, 0x63, 0xb0, 0x4c, 0xce, 0x0b, 0xa1, 0x75, 0x4b, 0x2d, 0xbc,
+	0x51, 0xad, 0x85, 0x77, 0xae, 0x18, 0x2d, 0xde, 0xa5, 0x16, 0x9f, 0x2a, 0xc4, 0xac, 0xaa, 0x49,
+	0xd2, 0xf2, 0xbf, 0x00, 0xe3, 0x4d, 0xff, 0xa3, 0xb3, 0xdb, 0x12, 0xb7, 0xbe, 0x5c, 0x88, 0x5b,
+	0x7a, 0x60, 0x05, 0x97, 0xa9, 0x54, 0x22, 0x72, 0x97, 0x01, 0x95, 0x37, 0x62, 0x47, 0xbc, 0x11,
+	0x5b, 0x5c, 0xe6, 0x35, 0xd5, 0x65, 0x2a, 0x83, 0x4b, 0xd5, 0xbf, 0x06, 0x86, 0x72, 0x07, 0x99,
+	0xa2, 0x2b, 0xdb, 0xdb, 0xec, 0x01, 0x9a, 0x6f, 0x21, 0xd1, 0x56, 0xdf, 0xa6, 0x19, 0x1c, 0xd1,
+	0xcc, 0xaf, 0x94, 0x35, 0xe5, 0x4a, 0x69, 0xbe, 0x20, 0x7d, 0xa5, 0x7a, 0x41, 0x2a, 0xc1, 0x28,
+	0x84, 0x23, 0x7d, 0xf5, 0xe5, 0xc3, 0x21, 0xb5, 0xa0, 0x7a, 0xa8, 0xbf, 0xb6, 0x69, 0x51, 0xbd,
+	0x0b, 0x0c, 0x85, 0x9f, 0xca, 0x96, 0x57, 0x51, 0x3a, 0x66, 0x94, 0xb5, 0x49, 0x51, 0x7e, 0x55,
+	0x45, 0xa9, 0x85, 0xa0, 0x5e, 0x2e, 0xf5, 0x25, 0xa8, 0x32, 0x48, 0x8b, 0xba, 0xaf, 0xa9, 0xea,
+	0xb4, 0x83, 0x49, 0x75, 0x91, 0xa1, 0xac, 0x55, 0x51, 0x77, 0xd1, 0xa8, 0xee, 0x11, 0xa8, 0xea,
+	0x33, 0x9a, 0x77, 0x89, 0x5c, 0x0e, 0xd2, 0x51, 0x1c, 0xa5, 0x98, 0xa8, 0xb8, 0xb1, 0x49, 0x55,
+	0x34, 0x7d, 0xe7, 0xc6, 0x26, 0x39, 0xed, 0x2f, 0x26, 0x49, 0x2c, 0xfe, 0xad, 0x60, 0x0d, 0xf9,
+	0x0b, 0x4c, 0x8d, 0xee, 0x2f, 0xd6, 0xf0, 0x7e, 0x09, 0x74, 0x45, 0xb7, 0x03, 0xdc, 0x09, 0xe6,
+	0x40, 0xfb, 0x75, 0x66, 0xaf, 0x9b, 0x47, 0x19, 0xe3, 0xe4, 0xf6, 0xab, 0x05, 0xc0, 0xca, 0xbc,
+	0x9a, 0xcf, 0x85, 0x6f, 0x30, 0x3d, 0x2b, 0xca, 0xc9, 0xa4, 0x0c, 0x24, 0xb5, 0xbc, 0x0e, 0x6c,
+	0x15, 0xc5, 0xe2, 0x5d, 0x04, 0x94, 0xef, 0x22, 0x9f, 0x37, 0xaa, 0xff, 0x26, 0x50, 0xb3, 0x50,
+	0xb3, 0x02, 0x09, 0xe4, 0x96, 0xb1, 0x72, 0x69, 0x09, 0xd9, 0xdf, 0x02, 0xea, 0xf9, 0x6b, 0xe8,
+	0x5f, 0x30, 0x56, 0x5f, 0x01, 0xad, 0x6c, 0x62, 0xc3, 0x6b, 0xa5, 0xc5, 0x91, 0xbf, 0x5d, 0x70,
+	0x64, 0xad, 0x16, 0x09, 0xe4, 0x0d, 0x60, 0xac, 0xb7, 0x4e, 0x0c, 0xc5, 0x3c, 0x2b, 0xaf, 0x17,
+	0x66, 0xc5, 0xa0, 0x47, 0x82, 0xf9, 0x33, 0xd8, 0xab, 0xbe, 0x3b, 0x29, 0xa6, 0xf2, 0x8b, 0x19,
+	0xbb, 0x12, 0xa8, 0xa4, 0xce, 0x75, 0x23, 0xea, 0xef, 0x30, 0xd4, 0xa7, 0xf3, 0x9d, 0x61, 0x01,
+	0x24, 0xc1, 0xff, 0x02, 0x98, 0x0b, 0xcf, 0x13, 0xc3, 0x96, 0xcf, 0x78, 0x8e, 0x78, 0xc6, 0xb3,
+	0xe4, 0x2c, 0xdf, 0x05, 0xa5, 0x44, 0x51, 0xab, 0x5c, 0x42, 0xfc, 0x17, 0x30, 0x56, 0xbf, 0xad,
+	0xd7, 0xbe, 0x35, 0x7d, 0xf5, 0x4d, 0x9f, 0x97, 0xf1, 0x3a, 0x8b, 0xe6, 0xcf, 0x82, 0xba, 0xe9,
+	0xcf, 0x82, 0x46, 0xf1, 0xcf, 0x02, 0x8b, 0x43, 0x7d, 0x0f, 0x54, 0x4b, 0x0b, 0x15, 0x5b, 0xa4,
+	0xc1, 0x7f, 0x04, 0x86, 0x8a, 0xfe, 0x47, 0x67, 0xae, 0x65, 0x63, 0xbe, 0x51, 0x8c, 0x30, 0x3a,
+	0x5c, 0x12, 0xfa, 0xfb, 0x60, 0xaf, 0x17, 0x07, 0xab, 0x0d, 0xcf, 0xc0, 0x29, 0x26, 0xcc, 0xeb,
+	0xa4, 0xd6, 0xdf, 0xd6, 0xb8, 0xa8, 0x65, 0x4b, 0x7c, 0xbf, 0xb0, 0x25, 0xec, 0xb8, 0xa4, 0x0d,
+	0x3f, 0x03, 0xf6, 0x17, 0x91, 0x7d, 0xd7, 0x1a, 0xae, 0x1a, 0x01, 0xbe, 0x09, 0xd4, 0x42, 0x88,
+	0x4d, 0x69, 0x21, 0x85, 0xb7, 0x3d, 0xc7, 0xec, 0x35, 0xbf, 0x4c, 0x98, 0x97, 0xef, 0xad, 0xbf,
+	0xf2, 0x71, 0x51, 0x4b, 0x19, 0xe7, 0x07, 0x0c, 0xfe, 0x63, 0xf9, 0x4e, 0x36, 0x81, 0x92, 0xe8,
+	0x3f, 0x00, 0xfa, 0xb7, 0xa2, 0x03, 0x72, 0x6d, 0xb5, 0x34, 0x5a, 0x2b, 0xfd, 0xcd, 0x53, 0x88,
+	0xc5, 0xf5, 0x72, 0x2c, 0x36, 0x67, 0x74, 0x3f, 0x64, 0x56, 0x1e, 0x2b, 0x56, 0xd3, 0x53, 0x6d,
+	0x3a, 0xf0, 0x5f, 0x60, 0x7c, 0xf0, 0x3a, 0x20, 0x0b, 0x95, 0x3f, 0x84, 0x6a, 0xa6, 0x3f, 0x84,
+	0xea, 0x85, 0xd3, 0xb9, 0x60, 0x77, 0xa3, 0x6c, 0xb7, 0xf9, 0xd4, 0xfa, 0x51, 0xe1, 0xd4, 0x32,
+	0x58, 0x25, 0x4d, 0xff, 0x2b, 0xd0, 0x3d, 0xe7, 0x1d, 0x90, 0xd5, 0xf6, 0x9a, 0xae, 0x39, 0x5d,
+	0x7c, 0xab, 0x90, 0x2e, 0x56, 0xe1, 0x49, 0xf8, 0xff, 0x00, 0x93, 0xbd, 0x3a, 0x1e, 0xdc, 0x19,
+	0x4c, 0x4b, 0x01, 0x35, 0x59, 0x0a, 0xe8, 0x6c, 0x1b, 0xcd, 0x78, 0x9b, 0x99, 0x71, 0x4e, 0x59,
+	0x8a, 0x3d, 0x60, 0x4a, 0xc3, 0x7e, 0x05, 0x0c, 0x8f, 0xa4, 0x13, 0x87, 0xf7, 0x55, 0xf2, 0x1b,
+	0x0c, 0xbe, 0xc7, 0x79, 0xec, 0x26, 0xa0, 0x50, 0x2c, 0xb1, 0xe3, 0xc7, 0x85, 0xd8, 0xa1, 0x45,
+	0x21, 0x81, 0xfe, 0x1d, 0x68, 0x9f, 0x6c, 0xf7, 0x3f, 0xe1, 0x40, 0x37, 0xe1, 0x67, 0x61, 0x83,
+	0xfd, 0x21, 0x5a, 0x33, 0xff, 0x21, 0xca, 0x24, 0x3a, 0xeb, 0x46, 0x7b, 0xde, 0x01, 0xa5, 0x3a,
+	0x5f, 0x19, 0x6c, 0x6e, 0xcd, 0xff, 0x06, 0x00, 0x3b, 0x38, 0x4b, 0xa0, 0x75, 0x30, 0x00, 0x00,
 }
@@ -573,7 +573,19 @@ func (s *store) copyShard(id, nodeID uint64) error {
 	if !s.isLeader() {
 		return raft.ErrNotLeader
 	}
-	return s.copyShardOwner(id, nodeID)
+	if err := s.copyShardOwner(id, nodeID); err != nil {
+		return err
+	}
+
+	// A completed copy replaces any quarantined data on the node.
+	if si := s.shard(id); si != nil {
+		for _, owner := range si.Owners {
+			if owner.ID == nodeID && owner.Quarantined {
+				return s.setShardOwnerQuarantine(id, nodeID, false)
+			}
+		}
+	}
+	return nil
 }
 
 // removeShard removes a shard in the metastore
@@ -593,6 +605,264 @@ func (s *store) truncateShards(delay time.Duration) error {
 	return s.truncateShardGroups(timestamp)
 }
 
+// createRollupRule creates a rollup rule on a retention policy.
+func (s *store) createRollupRule(database, rp, name string, after, interval time.Duration) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.CreateRollupRuleCommand{
+		Database:        proto.String(database),
+		RetentionPolicy: proto.String(rp),
+		Name:            proto.String(name),
+		After:           proto.Int64(int64(after)),
+		Interval:        proto.Int64(int64(interval)),
+	}
+	t := internal.Command_CreateRollupRuleCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_CreateRollupRuleCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// dropRollupRule removes a rollup rule from a retention policy.
+func (s *store) dropRollupRule(database, rp, name string) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.DropRollupRuleCommand{
+		Database:        proto.String(database),
+		RetentionPolicy: proto.String(rp),
+		Name:            proto.String(name),
+	}
+	t := internal.Command_DropRollupRuleCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_DropRollupRuleCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// createMeasurementSchema creates the schema of a measurement.
+func (s *store) createMeasurementSchema(database string, schema *MeasurementSchemaInfo) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.CreateMeasurementSchemaCommand{
+		Database: proto.String(database),
+		Schema:   schema.marshal(),
+	}
+	t := internal.Command_CreateMeasurementSchemaCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_CreateMeasurementSchemaCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// dropMeasurementSchema removes the schema of a measurement.
+func (s *store) dropMeasurementSchema(database, name string) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.DropMeasurementSchemaCommand{
+		Database: proto.String(database),
+		Name:     proto.String(name),
+	}
+	t := internal.Command_DropMeasurementSchemaCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_DropMeasurementSchemaCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// setCardinalityLimits sets the cardinality limits of a database.
+func (s *store) setCardinalityLimits(database string, limits *CardinalityLimitsInfo) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.SetCardinalityLimitsCommand{
+		Database: proto.String(database),
+	}
+	if !limits.IsEmpty() {
+		val.Limits = limits.marshal()
+	}
+	t := internal.Command_SetCardinalityLimitsCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_SetCardinalityLimitsCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// setDiskQuota sets the disk quota of a database, or of one of its retention
+// policies if rp is not empty.
+func (s *store) setDiskQuota(database, rp string, quota *DiskQuotaInfo) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.SetDiskQuotaCommand{
+		Database: proto.String(database),
+	}
+	if rp != "" {
+		val.RetentionPolicy = proto.String(rp)
+	}
+	if !quota.IsEmpty() {
+		val.Quota = quota.marshal()
+	}
+	t := internal.Command_SetDiskQuotaCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_SetDiskQuotaCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// createReshard starts resharding a retention policy to shard groups of
+// the given duration.
+func (s *store) createReshard(database, rp string, duration time.Duration) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.CreateReshardCommand{
+		Database:        proto.String(database),
+		RetentionPolicy: proto.String(rp),
+		Duration:        proto.Int64(int64(duration)),
+		Timestamp:       proto.Int64(time.Now().UnixNano()),
+	}
+	t := internal.Command_CreateReshardCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_CreateReshardCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// dropReshard stops resharding a retention policy.
+func (s *store) dropReshard(database, rp string) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.DropReshardCommand{
+		Database:        proto.String(database),
+		RetentionPolicy: proto.String(rp),
+		Timestamp:       proto.Int64(time.Now().UnixNano()),
+	}
+	t := internal.Command_DropReshardCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_DropReshardCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// setRetentionPolicyReplication sets how the shards of a retention policy
+// are replicated.
+func (s *store) setRetentionPolicyReplication(database, rp, mode string) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.SetRetentionPolicyReplicationCommand{
+		Database:        proto.String(database),
+		RetentionPolicy: proto.String(rp),
+		Mode:            proto.String(mode),
+	}
+	t := internal.Command_SetRetentionPolicyReplicationCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_SetRetentionPolicyReplicationCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
+// setShardLeader makes a node the leader of a shard.
+func (s *store) setShardLeader(id, nodeID uint64) error {
+	if !s.isLeader() {
+		return raft.ErrNotLeader
+	}
+
+	val := &internal.SetShardLeaderCommand{
+		ID:     proto.Uint64(id),
+		NodeID: proto.Uint64(nodeID),
+	}
+	t := internal.Command_SetShardLeaderCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_SetShardLeaderCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
 // createMetaNode is used by the join command to create the metanode in
 // the metastore
 func (s *store) createMetaNode(addr, raftAddr string) error {
@@ -740,6 +1010,28 @@ func (s *store) removeShardOwner(id, nodeID uint64) error {
 	return s.apply(b)
 }
 
+// setShardOwnerQuarantine is used by the copy-shard command to clear the
+// quarantine on a repaired shard owner
+func (s *store) setShardOwnerQuarantine(id, nodeID uint64, quarantined bool) error {
+	val := &internal.SetShardOwnerQuarantineCommand{
+		ID:          proto.Uint64(id),
+		NodeID:      proto.Uint64(nodeID),
+		Quarantined: proto.Bool(quarantined),
+	}
+	t := internal.Command_SetShardOwnerQuarantineCommand
+	cmd := &internal.Command{Type: &t}
+	if err := proto.SetExtension(cmd, internal.E_SetShardOwnerQuarantineCommand_Command, val); err != nil {
+		panic(err)
+	}
+
+	b, err := proto.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(b)
+}
+
 // truncateShardGroups is used by the truncate-shards command to truncate shard groups
 func (s *store) truncateShardGroups(timestamp time.Time) error {
 	val := &internal.TruncateShardGroupsCommand{
@@ -958,6 +1250,135 @@ func (s *store) shards() []*ClusterShardInfo {
 	return shardInfos
 }
 
+func (s *store) rollupRules() []*ClusterRollupRuleInfo {
+	s.mu.RLock()
+	dis := s.data.Databases
+	s.mu.RUnlock()
+	var rules []*ClusterRollupRuleInfo
+	for _, di := range dis {
+		for _, rpi := range di.RetentionPolicies {
+			for _, ri := range rpi.RollupRules {
+				rules = append(rules, &ClusterRollupRuleInfo{
+					Database:        di.Name,
+					RetentionPolicy: rpi.Name,
+					Name:            ri.Name,
+					After:           ri.After.String(),
+					Interval:        ri.Interval.String(),
+				})
+			}
+		}
+	}
+	return rules
+}
+
+func (s *store) reshards() []*ClusterReshardInfo {
+	s.mu.RLock()
+	dis := s.data.Databases
+	s.mu.RUnlock()
+	var reshards []*ClusterReshardInfo
+	for _, di := range dis {
+		for _, rpi := range di.RetentionPolicies {
+			ri := rpi.Reshard
+			if ri == nil {
+				continue
+			}
+			for _, sgi := range ri.ShardGroups {
+				info := &ClusterReshardInfo{
+					Database:        di.Name,
+					RetentionPolicy: rpi.Name,
+					Duration:        ri.Duration.String(),
+					ShardGroup:      sgi.ID,
+					StartTime:       sgi.StartTime,
+					EndTime:         sgi.EndTime,
+				}
+				for _, src := range rpi.ReshardSources(&sgi) {
+					info.Sources = append(info.Sources, src.ID)
+				}
+				for _, si := range sgi.Shards {
+					for _, owner := range si.Owners {
+						info.Copies++
+						if ri.Copied(si.ID, owner.NodeID) {
+							info.Copied++
+						}
+					}
+				}
+				reshards = append(reshards, info)
+			}
+		}
+	}
+	return reshards
+}
+
+func (s *store) measurementSchemas() []*ClusterMeasurementSchemaInfo {
+	s.mu.RLock()
+	dis := s.data.Databases
+	s.mu.RUnlock()
+	var schemas []*ClusterMeasurementSchemaInfo
+	for _, di := range dis {
+		for _, si := range di.MeasurementSchemas {
+			fields := make([]string, len(si.Fields))
+			for i, f := range si.Fields {
+				fields[i] = f.String()
+			}
+			schemas = append(schemas, &ClusterMeasurementSchemaInfo{
+				Database:     di.Name,
+				Name:         si.Name,
+				Fields:       fields,
+				RequiredTags: si.RequiredTags,
+				AllowedTags:  si.AllowedTags,
+				Mode:         si.Mode,
+			})
+		}
+	}
+	return schemas
+}
+
+func (s *store) cardinalityLimits() []*ClusterCardinalityLimitsInfo {
+	s.mu.RLock()
+	dis := s.data.Databases
+	s.mu.RUnlock()
+	var limits []*ClusterCardinalityLimitsInfo
+	for _, di := range dis {
+		if li := di.CardinalityLimits; li != nil {
+			limits = append(limits, &ClusterCardinalityLimitsInfo{
+				Database:                di.Name,
+				MaxSeriesPerMeasurement: li.MaxSeriesPerMeasurement,
+				MaxValuesPerTag:         li.MaxValuesPerTag,
+				Measurements:            li.Measurements,
+				TagKeys:                 li.TagKeys,
+			})
+		}
+	}
+	return limits
+}
+
+func (s *store) diskQuotas() []*ClusterDiskQuotaInfo {
+	s.mu.RLock()
+	dis := s.data.Databases
+	s.mu.RUnlock()
+	var quotas []*ClusterDiskQuotaInfo
+	for _, di := range dis {
+		if q := di.DiskQuota; q != nil {
+			quotas = append(quotas, &ClusterDiskQuotaInfo{
+				Database:  di.Name,
+				SoftLimit: q.SoftLimit,
+				HardLimit: q.HardLimit,
+			})
+		}
+		for _, rpi := range di.RetentionPolicies {
+			if q := rpi.DiskQuota; q != nil {
+				quotas = append(quotas, &ClusterDiskQuotaInfo{
+					Database:        di.Name,
+					RetentionPolicy: rpi.Name,
+					SoftLimit:       q.SoftLimit,
+					HardLimit:       q.HardLimit,
+				})
+			}
+		}
+	}
+	return quotas
+}
+
 func (s *store) shard(id uint64) *ClusterShardInfo {
 	s.mu.RLock()
 	dis := s.data.Databases
@@ -985,15 +1406,22 @@ func (s *store) shardInfo(di DatabaseInfo, rpi RetentionPolicyInfo, sgi ShardGro
 	for i, owner := range si.Owners {
 		n, _ := s.dataNode(owner.NodeID)
 		owners[i] = &ShardOwnerInfo{
-			ID:      owner.NodeID,
-			TCPAddr: n.TCPAddr,
+			ID:          owner.NodeID,
+			TCPAddr:     n.TCPAddr,
+			Quarantined: owner.Quarantined,
+			Tier:        owner.Tier,
 		}
 	}
+	var leader uint64
+	if rpi.WALReplicated() {
+		leader = si.LeaderID()
+	}
 	return &ClusterShardInfo{
 		ID:              si.ID,
 		Database:        di.Name,
 		RetentionPolicy: rpi.Name,
 		ReplicaN:        rpi.ReplicaN,
+		Leader:          leader,
 		ShardGroupID:    sgi.ID,
 		StartTime:       sgi.StartTime,
 		EndTime:         sgi.EndTime,
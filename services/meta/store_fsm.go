@@ -98,6 +98,32 @@ func (fsm *storeFSM) Apply(l *raft.Log) interface{} {
 			return fsm.applyCopyShardOwnerCommand(&cmd)
 		case internal.Command_RemoveShardOwnerCommand:
 			return fsm.applyRemoveShardOwnerCommand(&cmd)
+		case internal.Command_SetShardOwnerQuarantineCommand:
+			return fsm.applySetShardOwnerQuarantineCommand(&cmd)
+		case internal.Command_SetShardOwnerTierCommand:
+			return fsm.applySetShardOwnerTierCommand(&cmd)
+		case internal.Command_CreateRollupRuleCommand:
+			return fsm.applyCreateRollupRuleCommand(&cmd)
+		case internal.Command_DropRollupRuleCommand:
+			return fsm.applyDropRollupRuleCommand(&cmd)
+		case internal.Command_CreateMeasurementSchemaCommand:
+			return fsm.applyCreateMeasurementSchemaCommand(&cmd)
+		case internal.Command_DropMeasurementSchemaCommand:
+			return fsm.applyDropMeasurementSchemaCommand(&cmd)
+		case internal.Command_SetCardinalityLimitsCommand:
+			return fsm.applySetCardinalityLimitsCommand(&cmd)
+		case internal.Command_SetDiskQuotaCommand:
+			return fsm.applySetDiskQuotaCommand(&cmd)
+		case internal.Command_CreateReshardCommand:
+			return fsm.applyCreateReshardCommand(&cmd)
+		case internal.Command_SetReshardCopiedCommand:
+			return fsm.applySetReshardCopiedCommand(&cmd)
+		case internal.Command_DropReshardCommand:
+			return fsm.applyDropReshardCommand(&cmd)
+		case internal.Command_SetRetentionPolicyReplicationCommand:
+			return fsm.applySetRetentionPolicyReplicationCommand(&cmd)
+		case internal.Command_SetShardLeaderCommand:
+			return fsm.applySetShardLeaderCommand(&cmd)
 		default:
 			panic(fmt.Errorf("cannot apply command: %x", l.Data))
 		}
@@ -420,6 +446,30 @@ func (fsm *storeFSM) applyRemoveShardOwnerCommand(cmd *internal.Command) interfa
 	return nil
 }
 
+func (fsm *storeFSM) applySetShardOwnerQuarantineCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetShardOwnerQuarantineCommand_Command)
+	v := ext.(*internal.SetShardOwnerQuarantineCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	other.SetShardOwnerQuarantine(v.GetID(), v.GetNodeID(), v.GetQuarantined())
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applySetShardOwnerTierCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetShardOwnerTierCommand_Command)
+	v := ext.(*internal.SetShardOwnerTierCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	other.SetShardOwnerTier(v.GetID(), v.GetNodeID(), v.GetTier())
+	fsm.data = other
+
+	return nil
+}
+
 func (fsm *storeFSM) applyCreateContinuousQueryCommand(cmd *internal.Command) interface{} {
 	ext, _ := proto.GetExtension(cmd, internal.E_CreateContinuousQueryCommand_Command)
 	v := ext.(*internal.CreateContinuousQueryCommand)
@@ -476,6 +526,175 @@ func (fsm *storeFSM) applyDropSubscriptionCommand(cmd *internal.Command) interfa
 	return nil
 }
 
+func (fsm *storeFSM) applyCreateRollupRuleCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_CreateRollupRuleCommand_Command)
+	v := ext.(*internal.CreateRollupRuleCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.CreateRollupRule(v.GetDatabase(), v.GetRetentionPolicy(), v.GetName(), time.Duration(v.GetAfter()), time.Duration(v.GetInterval())); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applyDropRollupRuleCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_DropRollupRuleCommand_Command)
+	v := ext.(*internal.DropRollupRuleCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.DropRollupRule(v.GetDatabase(), v.GetRetentionPolicy(), v.GetName()); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applyCreateMeasurementSchemaCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_CreateMeasurementSchemaCommand_Command)
+	v := ext.(*internal.CreateMeasurementSchemaCommand)
+
+	var schema MeasurementSchemaInfo
+	schema.unmarshal(v.GetSchema())
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.CreateMeasurementSchema(v.GetDatabase(), &schema); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applyDropMeasurementSchemaCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_DropMeasurementSchemaCommand_Command)
+	v := ext.(*internal.DropMeasurementSchemaCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.DropMeasurementSchema(v.GetDatabase(), v.GetName()); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applySetCardinalityLimitsCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetCardinalityLimitsCommand_Command)
+	v := ext.(*internal.SetCardinalityLimitsCommand)
+
+	var limits *CardinalityLimitsInfo
+	if v.Limits != nil {
+		limits = &CardinalityLimitsInfo{}
+		limits.unmarshal(v.GetLimits())
+	}
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.SetCardinalityLimits(v.GetDatabase(), limits); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applySetDiskQuotaCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetDiskQuotaCommand_Command)
+	v := ext.(*internal.SetDiskQuotaCommand)
+
+	var quota *DiskQuotaInfo
+	if v.Quota != nil {
+		quota = &DiskQuotaInfo{}
+		quota.unmarshal(v.GetQuota())
+	}
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.SetDiskQuota(v.GetDatabase(), v.GetRetentionPolicy(), quota); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applyCreateReshardCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_CreateReshardCommand_Command)
+	v := ext.(*internal.CreateReshardCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.CreateReshard(v.GetDatabase(), v.GetRetentionPolicy(), time.Duration(v.GetDuration()), time.Unix(0, v.GetTimestamp())); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applySetReshardCopiedCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetReshardCopiedCommand_Command)
+	v := ext.(*internal.SetReshardCopiedCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.SetReshardCopied(v.GetDatabase(), v.GetRetentionPolicy(), v.GetShardID(), v.GetNodeID(), time.Unix(0, v.GetTimestamp())); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applyDropReshardCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_DropReshardCommand_Command)
+	v := ext.(*internal.DropReshardCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.DropReshard(v.GetDatabase(), v.GetRetentionPolicy(), time.Unix(0, v.GetTimestamp())); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applySetRetentionPolicyReplicationCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetRetentionPolicyReplicationCommand_Command)
+	v := ext.(*internal.SetRetentionPolicyReplicationCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.SetRetentionPolicyReplication(v.GetDatabase(), v.GetRetentionPolicy(), v.GetMode()); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
+func (fsm *storeFSM) applySetShardLeaderCommand(cmd *internal.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, internal.E_SetShardLeaderCommand_Command)
+	v := ext.(*internal.SetShardLeaderCommand)
+
+	// Copy data and update.
+	other := fsm.data.Clone()
+	if err := other.SetShardLeader(v.GetID(), v.GetNodeID(), v.GetPrevNodeID()); err != nil {
+		return err
+	}
+	fsm.data = other
+
+	return nil
+}
+
 func (fsm *storeFSM) applyCreateUserCommand(cmd *internal.Command) interface{} {
 	ext, _ := proto.GetExtension(cmd, internal.E_CreateUserCommand_Command)
 	v := ext.(*internal.CreateUserCommand)
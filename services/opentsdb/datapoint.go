@@ -0,0 +1,206 @@
+package opentsdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+// maxSecondsTimestamp is the largest timestamp in seconds. Larger timestamps
+// are in milliseconds, as in OpenTSDB.
+const maxSecondsTimestamp = 9999999999
+
+// datapoint holds the fields common to every JSON datapoint.
+type datapoint struct {
+	Metric    string            `json:"metric"`
+	Timestamp int64             `json:"timestamp"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+// parseTime returns the time of the datapoint, which is in seconds or
+// milliseconds.
+func (dp *datapoint) parseTime() (time.Time, error) {
+	if dp.Timestamp <= 0 {
+		return time.Time{}, errors.New("invalid timestamp, it must be a positive number of seconds or milliseconds")
+	}
+	if dp.Timestamp <= maxSecondsTimestamp {
+		return time.Unix(dp.Timestamp, 0), nil
+	}
+	return time.Unix(0, dp.Timestamp*int64(time.Millisecond)), nil
+}
+
+// newPoint returns the point of the datapoint with fields.
+func (dp *datapoint) newPoint(fields models.Fields) (models.Point, error) {
+	if dp.Metric == "" {
+		return nil, errors.New("metric name was empty")
+	}
+	ts, err := dp.parseTime()
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPoint(dp.Metric, models.NewTags(dp.Tags), fields, ts)
+}
+
+// datapointValue is the value of a datapoint, which may be sent as a JSON
+// number or a string holding one.
+type datapointValue struct {
+	v   float64
+	set bool
+}
+
+// UnmarshalJSON decodes a number or a string holding one.
+func (v *datapointValue) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse value %s", b)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("unsupported value %s", b)
+	}
+	v.v, v.set = f, true
+	return nil
+}
+
+// putDatapoint converts a datapoint of /api/put to a point with the field
+// "value".
+func putDatapoint(b json.RawMessage) (models.Point, error) {
+	var dp struct {
+		datapoint
+		Value datapointValue `json:"value"`
+	}
+	if err := json.Unmarshal(b, &dp); err != nil {
+		return nil, err
+	}
+	if !dp.Value.set {
+		return nil, errors.New("missing value")
+	}
+	return dp.newPoint(models.Fields{"value": dp.Value.v})
+}
+
+// histogramDatapoint converts a datapoint of /api/histogram to a point. The
+// count of each bucket is written to the integer field
+// "bucket_<lower>_<upper>", and the counts below and above the buckets to
+// "underflow" and "overflow".
+//
+// Histograms encoded by an OpenTSDB histogram codec are not supported.
+func histogramDatapoint(b json.RawMessage) (models.Point, error) {
+	var dp struct {
+		datapoint
+		Buckets   map[string]int64 `json:"buckets"`
+		Underflow int64            `json:"underflow"`
+		Overflow  int64            `json:"overflow"`
+		ID        *int             `json:"id"`
+		Value     *string          `json:"value"`
+	}
+	if err := json.Unmarshal(b, &dp); err != nil {
+		return nil, err
+	}
+	if dp.ID != nil || dp.Value != nil {
+		return nil, errors.New("encoded histograms are not supported, send buckets instead")
+	}
+	if len(dp.Buckets) == 0 {
+		return nil, errors.New("missing buckets")
+	}
+
+	fields := make(models.Fields, len(dp.Buckets)+2)
+	for bucket, count := range dp.Buckets {
+		bounds := strings.Split(bucket, ",")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid bucket %q, expected lower,upper", bucket)
+		}
+		lower, err := strconv.ParseFloat(strings.TrimSpace(bounds[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket %q: %s", bucket, err)
+		}
+		upper, err := strconv.ParseFloat(strings.TrimSpace(bounds[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket %q: %s", bucket, err)
+		}
+		if upper <= lower {
+			return nil, fmt.Errorf("invalid bucket %q, upper bound must be greater than lower bound", bucket)
+		}
+		fields["bucket_"+formatBound(lower)+"_"+formatBound(upper)] = count
+	}
+	fields["underflow"] = dp.Underflow
+	fields["overflow"] = dp.Overflow
+	return dp.newPoint(fields)
+}
+
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// rollupDatapoint converts a datapoint of /api/rollup to a point. The value
+// is written to the field named after the aggregator, in lower case, and the
+// rollup interval is written to the tag "interval".
+//
+// Pre-aggregated datapoints, which only have a groupByAggregator, are
+// written to the field named after it. When a rollup is also pre-aggregated
+// the group by aggregator is written to the tag "groupby". Datapoints which
+// already have a tag written by the rollup are rejected.
+func rollupDatapoint(b json.RawMessage) (models.Point, error) {
+	var dp struct {
+		datapoint
+		Value             datapointValue `json:"value"`
+		Interval          string         `json:"interval"`
+		Aggregator        string         `json:"aggregator"`
+		GroupByAggregator string         `json:"groupByAggregator"`
+	}
+	if err := json.Unmarshal(b, &dp); err != nil {
+		return nil, err
+	}
+	if !dp.Value.set {
+		return nil, errors.New("missing value")
+	}
+
+	var field string
+	switch {
+	case dp.Aggregator != "":
+		if dp.Interval == "" {
+			return nil, errors.New("missing interval for rollup")
+		}
+		field = strings.ToLower(dp.Aggregator)
+	case dp.GroupByAggregator != "":
+		field = strings.ToLower(dp.GroupByAggregator)
+	default:
+		return nil, errors.New("missing aggregator or groupByAggregator")
+	}
+
+	tags := make(map[string]string, len(dp.Tags)+2)
+	for k, v := range dp.Tags {
+		tags[k] = v
+	}
+	addTag := func(k, v string) error {
+		if _, ok := tags[k]; ok {
+			return fmt.Errorf("tag %q is reserved for rollups", k)
+		}
+		tags[k] = v
+		return nil
+	}
+	if dp.Interval != "" {
+		if err := addTag("interval", dp.Interval); err != nil {
+			return nil, err
+		}
+	}
+	if dp.Aggregator != "" && dp.GroupByAggregator != "" {
+		if err := addTag("groupby", strings.ToLower(dp.GroupByAggregator)); err != nil {
+			return nil, err
+		}
+	}
+	dp.Tags = tags
+
+	return dp.newPoint(models.Fields{field: dp.Value.v})
+}
@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
@@ -38,14 +37,26 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case "/api/metadata/put":
 		w.WriteHeader(http.StatusNoContent)
 	case "/api/put":
-		h.servePut(w, r)
+		h.serveDatapoints(w, r, putDatapoint)
+	case "/api/histogram":
+		h.serveDatapoints(w, r, histogramDatapoint)
+	case "/api/rollup":
+		h.serveDatapoints(w, r, rollupDatapoint)
 	default:
 		http.NotFound(w, r)
 	}
 }
 
-// servePut implements OpenTSDB's HTTP /api/put endpoint.
-func (h *Handler) servePut(w http.ResponseWriter, r *http.Request) {
+// serveDatapoints implements OpenTSDB's HTTP /api/put endpoint and the
+// /api/histogram and /api/rollup endpoints, which accept the same requests
+// with different datapoints. Each datapoint is converted to a point by
+// convert.
+//
+// Datapoints which can't be converted are dropped and the others written.
+// As in OpenTSDB, the summary and details query parameters return the
+// number of datapoints written and failed, and details the error of each
+// failed datapoint.
+func (h *Handler) serveDatapoints(w http.ResponseWriter, r *http.Request, convert func(json.RawMessage) (models.Point, error)) {
 	defer r.Body.Close()
 
 	// Require POST method.
@@ -86,8 +97,8 @@ func (h *Handler) servePut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Decode JSON data into slice of points.
-	dps := make([]point, 1)
+	// Decode JSON data into slice of datapoints.
+	dps := make([]json.RawMessage, 1)
 	if dec := json.NewDecoder(br); multi {
 		if err = dec.Decode(&dps); err != nil {
 			http.Error(w, "json array decode error", http.StatusBadRequest)
@@ -99,44 +110,68 @@ func (h *Handler) servePut(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if h.stats != nil {
+		atomic.AddInt64(&h.stats.HTTPPointsReceived, int64(len(dps)))
+	}
 
-	// Convert points into TSDB points.
+	// Convert datapoints into TSDB points.
 	points := make([]models.Point, 0, len(dps))
-	for i := range dps {
-		p := dps[i]
-
-		// Convert timestamp to Go time.
-		// If time value is over a billion then it's microseconds.
-		var ts time.Time
-		if p.Time < 10000000000 {
-			ts = time.Unix(p.Time, 0)
-		} else {
-			ts = time.Unix(p.Time/1000, (p.Time%1000)*1000)
-		}
-
-		pt, err := models.NewPoint(p.Metric, models.NewTags(p.Tags), map[string]interface{}{"value": p.Value}, ts)
+	var errs []datapointError
+	for _, dp := range dps {
+		pt, err := convert(dp)
 		if err != nil {
-			h.Logger.Info("Dropping point", zap.String("name", p.Metric), zap.Error(err))
+			h.Logger.Info("Dropping point", zap.ByteString("datapoint", dp), zap.Error(err))
 			if h.stats != nil {
 				atomic.AddInt64(&h.stats.InvalidDroppedPoints, 1)
 			}
+			errs = append(errs, datapointError{Datapoint: dp, Error: err.Error()})
 			continue
 		}
 		points = append(points, pt)
 	}
 
 	// Write points.
-	if err := h.PointsWriter.WritePointsPrivileged(h.Database, h.RetentionPolicy, h.ConsistencyLevel, points); influxdb.IsClientError(err) {
-		h.Logger.Info("Write series error", zap.Error(err))
-		http.Error(w, "write series error: "+err.Error(), http.StatusBadRequest)
-		return
-	} else if err != nil {
-		h.Logger.Info("Write series error", zap.Error(err))
-		http.Error(w, "write series error: "+err.Error(), http.StatusInternalServerError)
-		return
+	if len(points) > 0 {
+		if err := h.PointsWriter.WritePointsPrivileged(h.Database, h.RetentionPolicy, h.ConsistencyLevel, points); influxdb.IsClientError(err) {
+			h.Logger.Info("Write series error", zap.Error(err))
+			http.Error(w, "write series error: "+err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			h.Logger.Info("Write series error", zap.Error(err))
+			http.Error(w, "write series error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Failed datapoints are reported as a bad request.
+	code := http.StatusOK
+	if len(errs) > 0 {
+		code = http.StatusBadRequest
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	q := r.URL.Query()
+	_, details := q["details"]
+	_, summary := q["summary"]
+	switch {
+	case details:
+		if errs == nil {
+			errs = []datapointError{}
+		}
+		writeJSON(w, code, putDetails{Errors: errs, Failed: len(errs), Success: len(points)})
+	case summary:
+		writeJSON(w, code, putSummary{Failed: len(errs), Success: len(points)})
+	case len(errs) > 0:
+		http.Error(w, `one or more data points had errors, append "details" to the request for their errors`, http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// writeJSON writes v as the JSON body of the response.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
 }
 
 // chanListener represents a listener that receives connections through a channel.
@@ -189,10 +224,21 @@ type readerConn struct {
 // Read implements the io.Reader interface.
 func (conn *readerConn) Read(b []byte) (n int, err error) { return conn.r.Read(b) }
 
-// point represents an incoming JSON data point.
-type point struct {
-	Metric string            `json:"metric"`
-	Time   int64             `json:"timestamp"`
-	Value  float64           `json:"value"`
-	Tags   map[string]string `json:"tags,omitempty"`
+// putSummary is the response of a request with the summary parameter.
+type putSummary struct {
+	Failed  int `json:"failed"`
+	Success int `json:"success"`
+}
+
+// putDetails is the response of a request with the details parameter.
+type putDetails struct {
+	Errors  []datapointError `json:"errors"`
+	Failed  int              `json:"failed"`
+	Success int              `json:"success"`
+}
+
+// datapointError is the error of a datapoint which wasn't written.
+type datapointError struct {
+	Datapoint json.RawMessage `json:"datapoint"`
+	Error     string          `json:"error"`
 }
@@ -25,6 +25,7 @@ import (
 // statistics gathered by the openTSDB package.
 const (
 	statHTTPConnectionsHandled   = "httpConnsHandled"
+	statHTTPPointsReceived       = "httpPointsRx"
 	statTelnetConnectionsActive  = "tlConnsActive"
 	statTelnetConnectionsHandled = "tlConnsHandled"
 	statTelnetPointsReceived     = "tlPointsRx"
@@ -252,6 +253,7 @@ func (s *Service) WithLogger(log *zap.Logger) {
 // Statistics maintains statistics for the subscriber service.
 type Statistics struct {
 	HTTPConnectionsHandled   int64
+	HTTPPointsReceived       int64
 	ActiveTelnetConnections  int64
 	HandledTelnetConnections int64
 	TelnetPointsReceived     int64
@@ -276,6 +278,7 @@ func (s *Service) Statistics(tags map[string]string) []models.Statistic {
 		Tags: s.defaultTags.Merge(tags),
 		Values: map[string]interface{}{
 			statHTTPConnectionsHandled:   atomic.LoadInt64(&s.stats.HTTPConnectionsHandled),
+			statHTTPPointsReceived:       atomic.LoadInt64(&s.stats.HTTPPointsReceived),
 			statTelnetConnectionsActive:  atomic.LoadInt64(&s.stats.ActiveTelnetConnections),
 			statTelnetConnectionsHandled: atomic.LoadInt64(&s.stats.HandledTelnetConnections),
 			statTelnetPointsReceived:     atomic.LoadInt64(&s.stats.TelnetPointsReceived),
@@ -3,6 +3,7 @@ package opentsdb
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
@@ -248,6 +249,150 @@ func TestService_HTTP(t *testing.T) {
 	}
 }
 
+// Ensure failed datapoints are reported with the details and summary parameters.
+func TestService_HTTP_Details(t *testing.T) {
+	t.Parallel()
+
+	s := NewTestService("db0", "127.0.0.1:0")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	var written int64
+	s.WritePointsFn = func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+		atomic.AddInt64(&written, int64(len(points)))
+		return nil
+	}
+
+	body := `[
+		{"metric":"sys.cpu.nice", "timestamp":1346846400000, "value":"18.5", "tags":{"host":"web01"}},
+		{"metric":"sys.cpu.nice", "timestamp":1346846400, "tags":{"host":"web02"}},
+		{"metric":"", "timestamp":1346846400, "value":1}
+	]`
+
+	for _, tt := range []struct {
+		query string
+		code  int
+		body  string
+	}{
+		{
+			query: "",
+			code:  http.StatusBadRequest,
+		},
+		{
+			query: "?summary",
+			code:  http.StatusBadRequest,
+			body:  `{"failed":2,"success":1}`,
+		},
+		{
+			query: "?details",
+			code:  http.StatusBadRequest,
+			body:  `{"errors":[{"datapoint":{"metric":"sys.cpu.nice","timestamp":1346846400,"tags":{"host":"web02"}},"error":"missing value"},{"datapoint":{"metric":"","timestamp":1346846400,"value":1},"error":"metric name was empty"}],"failed":2,"success":1}`,
+		},
+	} {
+		resp, err := http.Post("http://"+s.Service.Addr().String()+"/api/put"+tt.query, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.code {
+			t.Fatalf("%q: unexpected status code: %d", tt.query, resp.StatusCode)
+		}
+		if tt.body != "" && strings.TrimSpace(string(b)) != tt.body {
+			t.Fatalf("%q: unexpected body:\ngot %s\nexp %s", tt.query, b, tt.body)
+		}
+	}
+
+	if got := atomic.LoadInt64(&written); got != 3 {
+		t.Fatalf("unexpected number of points written: %d", got)
+	}
+
+	resp, err := http.Post("http://"+s.Service.Addr().String()+"/api/put?summary", "application/json", strings.NewReader(`{"metric":"sys.cpu.nice", "timestamp":1346846400, "value":18}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(b)) != `{"failed":0,"success":1}` {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, b)
+	}
+}
+
+// Ensure histograms and rollups can be written via the HTTP protocol.
+func TestService_HTTP_HistogramRollup(t *testing.T) {
+	t.Parallel()
+
+	s := NewTestService("db0", "127.0.0.1:0")
+	if err := s.Service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Service.Close()
+
+	for _, tt := range []struct {
+		path  string
+		body  string
+		point string
+	}{
+		{
+			path:  "/api/histogram",
+			body:  `{"metric":"req.latency", "timestamp":1346846400, "tags":{"host":"web01"}, "buckets":{"0,1.75":12, "1.75,3.5":16}, "underflow":1}`,
+			point: "req.latency,host=web01 bucket_0_1.75=12i,bucket_1.75_3.5=16i,overflow=0i,underflow=1i 1346846400000000000",
+		},
+		{
+			path:  "/api/rollup",
+			body:  `{"metric":"sys.cpu.nice", "timestamp":1346846400, "value":18, "tags":{"host":"web01"}, "interval":"1h", "aggregator":"SUM"}`,
+			point: "sys.cpu.nice,host=web01,interval=1h sum=18 1346846400000000000",
+		},
+		{
+			path:  "/api/rollup",
+			body:  `{"metric":"sys.cpu.nice", "timestamp":1346846400, "value":18, "tags":{"dc":"lga"}, "groupByAggregator":"max"}`,
+			point: "sys.cpu.nice,dc=lga max=18 1346846400000000000",
+		},
+	} {
+		var got string
+		s.WritePointsFn = func(database, retentionPolicy string, consistencyLevel models.ConsistencyLevel, points []models.Point) error {
+			got = points[0].String()
+			return nil
+		}
+
+		resp, err := http.Post("http://"+s.Service.Addr().String()+tt.path, "application/json", strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("%s: unexpected status code: %d", tt.path, resp.StatusCode)
+		} else if got != tt.point {
+			t.Fatalf("%s: unexpected point:\ngot %s\nexp %s", tt.path, got, tt.point)
+		}
+	}
+
+	resp, err := http.Post("http://"+s.Service.Addr().String()+"/api/histogram?details", "application/json", strings.NewReader(`{"metric":"req.latency", "timestamp":1346846400, "id":0, "value":"AQ=="}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(b), "encoded histograms are not supported") {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, b)
+	}
+
+	// Tags added by a rollup aren't overwritten.
+	resp, err = http.Post("http://"+s.Service.Addr().String()+"/api/rollup?details", "application/json", strings.NewReader(`{"metric":"sys.cpu.nice", "timestamp":1346846400, "value":18, "tags":{"interval":"5m"}, "interval":"1h", "aggregator":"sum"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(b), `tag \"interval\" is reserved for rollups`) {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, b)
+	}
+}
+
 type TestService struct {
 	Service       *Service
 	MetaClient    *internal.MetaClientMock
@@ -0,0 +1,45 @@
+package quota_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/quota"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c quota.Config
+	if _, err := toml.Decode(`
+enabled = true
+check-interval = "30s"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if !c.Enabled {
+		t.Fatalf("unexpected enabled state: %v", c.Enabled)
+	} else if time.Duration(c.CheckInterval) != 30*time.Second {
+		t.Fatalf("unexpected check interval: %v", c.CheckInterval)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := quota.NewConfig()
+	c.Enabled = true
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from NewConfig: %s", err)
+	}
+
+	c.CheckInterval = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for check-interval = 0, got nil")
+	}
+
+	c.Enabled = false
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from disabled config: %s", err)
+	}
+}
@@ -0,0 +1,259 @@
+// Package quota provides a service that enforces the disk quotas of the
+// databases and retention policies on the local data node.
+package quota // import "github.com/influxdata/influxdb/services/quota"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the disk quota service, one per quota.
+const (
+	statUsageBytes   = "usageBytes"
+	statSoftLimit    = "softLimit"
+	statHardLimit    = "hardLimit"
+	statSoftExceeded = "softExceeded"
+	statHardExceeded = "hardExceeded"
+)
+
+// Service represents the disk quota service. It periodically sums the disk
+// used by the shards of every database and retention policy with a quota on
+// this node. Writes to those over their hard limit are rejected by the store
+// until enough data is dropped or expires, while crossing a soft limit is
+// only logged and reported in the statistics.
+type Service struct {
+	MetaClient interface {
+		Databases() []meta.DatabaseInfo
+	}
+	TSDBStore interface {
+		DiskUsage(ctx context.Context, database string) (*tsdb.DiskUsage, error)
+		SetDiskQuotaExceeded(errs []tsdb.DiskQuotaExceededError)
+	}
+
+	config Config
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	usages map[quotaKey]Usage
+
+	logger *zap.Logger
+}
+
+// quotaKey identifies the quota of a database, when rp is empty, or of one of
+// its retention policies.
+type quotaKey struct {
+	db, rp string
+}
+
+// Usage is the disk used on this node under a quota as of the last check.
+type Usage struct {
+	Database        string
+	RetentionPolicy string
+	Bytes           int64
+	SoftLimit       int64
+	HardLimit       int64
+}
+
+// SoftExceeded returns true if the usage is over the soft limit.
+func (u Usage) SoftExceeded() bool { return u.SoftLimit > 0 && u.Bytes > u.SoftLimit }
+
+// HardExceeded returns true if the usage is over the hard limit.
+func (u Usage) HardExceeded() bool { return u.HardLimit > 0 && u.Bytes > u.HardLimit }
+
+// NewService returns a configured disk quota service.
+func NewService(c Config) *Service {
+	return &Service{
+		config: c,
+		usages: make(map[quotaKey]Usage),
+		logger: zap.NewNop(),
+	}
+}
+
+// Open starts the service.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting disk quota service",
+		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the service.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing disk quota service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "quota"))
+}
+
+// Statistics returns statistics for periodic monitoring, one per quota.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	usages := s.Usages()
+	statistics := make([]models.Statistic, 0, len(usages))
+	for _, u := range usages {
+		t := map[string]string{"database": u.Database, "retentionPolicy": u.RetentionPolicy}
+		for k, v := range tags {
+			t[k] = v
+		}
+		statistics = append(statistics, models.Statistic{
+			Name: "diskQuota",
+			Tags: t,
+			Values: map[string]interface{}{
+				statUsageBytes:   u.Bytes,
+				statSoftLimit:    u.SoftLimit,
+				statHardLimit:    u.HardLimit,
+				statSoftExceeded: u.SoftExceeded(),
+				statHardExceeded: u.HardExceeded(),
+			},
+		})
+	}
+	return statistics
+}
+
+// Usages returns the usages of the quotas as of the last check, sorted by
+// database and retention policy.
+func (s *Service) Usages() []Usage {
+	s.mu.RLock()
+	usages := make([]Usage, 0, len(s.usages))
+	for _, u := range s.usages {
+		usages = append(usages, u)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Database != usages[j].Database {
+			return usages[i].Database < usages[j].Database
+		}
+		return usages[i].RetentionPolicy < usages[j].RetentionPolicy
+	})
+	return usages
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(time.Duration(s.config.CheckInterval))
+	defer ticker.Stop()
+	for {
+		s.Check()
+
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check sums the disk used under every quota and updates the quotas exceeded
+// in the store.
+func (s *Service) Check() {
+	s.mu.RLock()
+	prev := s.usages
+	s.mu.RUnlock()
+
+	usages := make(map[quotaKey]Usage)
+	for _, di := range s.MetaClient.Databases() {
+		quotas := make(map[quotaKey]*meta.DiskQuotaInfo)
+		if di.DiskQuota != nil {
+			quotas[quotaKey{db: di.Name}] = di.DiskQuota
+		}
+		for _, rpi := range di.RetentionPolicies {
+			if rpi.DiskQuota != nil {
+				quotas[quotaKey{db: di.Name, rp: rpi.Name}] = rpi.DiskQuota
+			}
+		}
+		if len(quotas) == 0 {
+			continue
+		}
+
+		du, err := s.TSDBStore.DiskUsage(context.Background(), di.Name)
+		if err != nil {
+			// Keep enforcing the last known usage of the database.
+			s.logger.Warn("Unable to compute disk usage", logger.Database(di.Name), zap.Error(err))
+			for k := range quotas {
+				if u, ok := prev[k]; ok {
+					usages[k] = u
+				}
+			}
+			continue
+		}
+
+		for k, q := range quotas {
+			u := Usage{
+				Database:        k.db,
+				RetentionPolicy: k.rp,
+				Bytes:           du.Bytes,
+				SoftLimit:       q.SoftLimit,
+				HardLimit:       q.HardLimit,
+			}
+			if k.rp != "" {
+				u.Bytes = du.RetentionPolicies[k.rp]
+			}
+			usages[k] = u
+			s.logTransition(prev[k], u)
+		}
+	}
+
+	var exceeded []tsdb.DiskQuotaExceededError
+	for _, u := range usages {
+		if u.HardExceeded() {
+			exceeded = append(exceeded, tsdb.DiskQuotaExceededError{
+				Database:        u.Database,
+				RetentionPolicy: u.RetentionPolicy,
+				Bytes:           u.Bytes,
+				Limit:           u.HardLimit,
+			})
+		}
+	}
+	s.TSDBStore.SetDiskQuotaExceeded(exceeded)
+
+	s.mu.Lock()
+	s.usages = usages
+	s.mu.Unlock()
+}
+
+// logTransition logs the limits of a quota crossed since the last check.
+func (s *Service) logTransition(prev, u Usage) {
+	fields := []zap.Field{
+		logger.Database(u.Database),
+		logger.RetentionPolicy(u.RetentionPolicy),
+		zap.Int64("bytes", u.Bytes),
+	}
+	switch {
+	case u.HardExceeded() && !prev.HardExceeded():
+		s.logger.Warn("Disk quota hard limit exceeded, rejecting writes",
+			append(fields, zap.Int64("hard_limit", u.HardLimit))...)
+	case u.SoftExceeded() && !prev.SoftExceeded() && !u.HardExceeded():
+		s.logger.Warn("Disk quota soft limit exceeded",
+			append(fields, zap.Int64("soft_limit", u.SoftLimit))...)
+	case prev.HardExceeded() && !u.HardExceeded():
+		s.logger.Info("Disk usage back under quota hard limit, accepting writes",
+			append(fields, zap.Int64("hard_limit", u.HardLimit))...)
+	}
+}
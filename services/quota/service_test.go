@@ -0,0 +1,149 @@
+package quota_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/services/quota"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestService_OpenDisabled(t *testing.T) {
+	// Opening a disabled service should be a no-op.
+	c := quota.NewConfig()
+	c.Enabled = false
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() != "" {
+		t.Fatalf("service logged %q, didn't expect any logging", s.LogBuf.String())
+	}
+}
+
+func TestService_OpenClose(t *testing.T) {
+	c := quota.NewConfig()
+	s := NewService(c)
+	s.MetaClient.DatabasesFn = func() []meta.DatabaseInfo { return nil }
+	s.TSDBStore.SetDiskQuotaExceededFn = func(errs []tsdb.DiskQuotaExceededError) {}
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() == "" {
+		t.Fatal("service didn't log anything on open")
+	}
+
+	// Reopening is a no-op
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-closing is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	s := NewService(quota.NewConfig())
+
+	s.MetaClient.DatabasesFn = func() []meta.DatabaseInfo {
+		return []meta.DatabaseInfo{
+			{
+				Name:      "db0",
+				DiskQuota: &meta.DiskQuotaInfo{SoftLimit: 100, HardLimit: 200},
+				RetentionPolicies: []meta.RetentionPolicyInfo{
+					{Name: "rp0", DiskQuota: &meta.DiskQuotaInfo{HardLimit: 50}},
+					{Name: "rp1"},
+				},
+			},
+			{Name: "db1", DiskQuota: &meta.DiskQuotaInfo{HardLimit: 10}},
+			{Name: "db2"},
+		}
+	}
+	rpBytes := map[string]int64{"rp0": 60, "rp1": 90}
+	s.TSDBStore.DiskUsageFn = func(ctx context.Context, database string) (*tsdb.DiskUsage, error) {
+		switch database {
+		case "db0":
+			return &tsdb.DiskUsage{Database: database, Bytes: rpBytes["rp0"] + rpBytes["rp1"], RetentionPolicies: rpBytes}, nil
+		case "db1":
+			return nil, errors.New("marker")
+		}
+		t.Fatalf("unexpected database without quota: %s", database)
+		return nil, nil
+	}
+	var exceeded []tsdb.DiskQuotaExceededError
+	s.TSDBStore.SetDiskQuotaExceededFn = func(errs []tsdb.DiskQuotaExceededError) { exceeded = errs }
+
+	s.Check()
+
+	// The database is over its soft limit only, its first retention policy
+	// over its hard limit.
+	if len(exceeded) != 1 {
+		t.Fatalf("unexpected quotas exceeded: %v", exceeded)
+	} else if exp := (tsdb.DiskQuotaExceededError{Database: "db0", RetentionPolicy: "rp0", Bytes: 60, Limit: 50}); exceeded[0] != exp {
+		t.Fatalf("unexpected quota exceeded: %v", exceeded[0])
+	}
+	usages := s.Usages()
+	if len(usages) != 2 {
+		t.Fatalf("unexpected usages: %v", usages)
+	} else if u := usages[0]; u.RetentionPolicy != "" || u.Bytes != 150 || !u.SoftExceeded() || u.HardExceeded() {
+		t.Fatalf("unexpected database usage: %+v", u)
+	}
+	if got := s.LogBuf.String(); !strings.Contains(got, "soft limit exceeded") || !strings.Contains(got, "hard limit exceeded") {
+		t.Fatalf("expected limits to be logged: %s", got)
+	}
+
+	stats := s.Statistics(map[string]string{"hostname": "h"})
+	if len(stats) != 2 {
+		t.Fatalf("unexpected statistics: %v", stats)
+	} else if st := stats[1]; st.Name != "diskQuota" || st.Tags["retentionPolicy"] != "rp0" || st.Tags["hostname"] != "h" {
+		t.Fatalf("unexpected statistic: %+v", st)
+	} else if st.Values["hardExceeded"] != true || st.Values["usageBytes"] != int64(60) {
+		t.Fatalf("unexpected statistic values: %v", st.Values)
+	}
+
+	// Writes are accepted again once the usage drops under the hard limit.
+	rpBytes["rp0"] = 40
+	s.Check()
+	if len(exceeded) != 0 {
+		t.Fatalf("unexpected quotas exceeded: %v", exceeded)
+	}
+}
+
+type Service struct {
+	MetaClient *internal.MetaClientMock
+	TSDBStore  *internal.TSDBStoreMock
+
+	LogBuf bytes.Buffer
+	*quota.Service
+}
+
+func NewService(c quota.Config) *Service {
+	s := &Service{
+		MetaClient: &internal.MetaClientMock{},
+		TSDBStore:  &internal.TSDBStoreMock{},
+		Service:    quota.NewService(c),
+	}
+
+	l := logger.New(&s.LogBuf)
+	s.WithLogger(l)
+
+	s.Service.MetaClient = s.MetaClient
+	s.Service.TSDBStore = s.TSDBStore
+	return s
+}
@@ -0,0 +1,83 @@
+package replication
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	// DefaultShipInterval is the interval of time between shipments of the
+	// WAL of the shards led by a node to the other owners.
+	DefaultShipInterval = time.Second
+
+	// DefaultMaxBatchSize is the maximum number of bytes of WAL entries
+	// shipped in a single request.
+	DefaultMaxBatchSize = 1024 * 1024
+
+	// DefaultMaxRetainedSize is the maximum number of bytes of the WAL of a
+	// shard kept for an owner that is behind.
+	DefaultMaxRetainedSize = 1024 * 1024 * 1024
+
+	// DefaultLeaderTimeout is the duration an owner waits without hearing
+	// from the leader of a shard before taking over as leader.
+	DefaultLeaderTimeout = 30 * time.Second
+)
+
+// Config represents the configuration for the replication service.
+type Config struct {
+	Enabled         bool          `toml:"enabled"`
+	ShipInterval    toml.Duration `toml:"ship-interval"`
+	MaxBatchSize    toml.Size     `toml:"max-batch-size"`
+	MaxRetainedSize toml.Size     `toml:"max-retained-size"`
+	LeaderTimeout   toml.Duration `toml:"leader-timeout"`
+}
+
+// NewConfig returns an instance of Config with defaults.
+func NewConfig() Config {
+	return Config{
+		Enabled:         true,
+		ShipInterval:    toml.Duration(DefaultShipInterval),
+		MaxBatchSize:    toml.Size(DefaultMaxBatchSize),
+		MaxRetainedSize: toml.Size(DefaultMaxRetainedSize),
+		LeaderTimeout:   toml.Duration(DefaultLeaderTimeout),
+	}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.ShipInterval <= 0 {
+		return errors.New("ship-interval must be positive")
+	}
+	if c.MaxBatchSize <= 0 {
+		return errors.New("max-batch-size must be positive")
+	}
+	if c.LeaderTimeout <= c.ShipInterval {
+		return errors.New("leader-timeout must be greater than ship-interval")
+	}
+
+	return nil
+}
+
+// Diagnostics returns a diagnostics representation of a subset of the Config.
+func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
+	if !c.Enabled {
+		return diagnostics.RowFromMap(map[string]interface{}{
+			"enabled": false,
+		}), nil
+	}
+
+	return diagnostics.RowFromMap(map[string]interface{}{
+		"enabled":           true,
+		"ship-interval":     c.ShipInterval,
+		"max-batch-size":    c.MaxBatchSize,
+		"max-retained-size": c.MaxRetainedSize,
+		"leader-timeout":    c.LeaderTimeout,
+	}), nil
+}
@@ -0,0 +1,59 @@
+package replication_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/replication"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c replication.Config
+	if _, err := toml.Decode(`
+enabled = false
+ship-interval = "2s"
+max-batch-size = "4m"
+max-retained-size = "2g"
+leader-timeout = "1m"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if c.Enabled {
+		t.Fatalf("unexpected enabled state: %v", c.Enabled)
+	} else if time.Duration(c.ShipInterval) != 2*time.Second {
+		t.Fatalf("unexpected ship interval: %v", c.ShipInterval)
+	} else if c.MaxBatchSize != 4*1024*1024 {
+		t.Fatalf("unexpected max batch size: %v", c.MaxBatchSize)
+	} else if c.MaxRetainedSize != 2*1024*1024*1024 {
+		t.Fatalf("unexpected max retained size: %v", c.MaxRetainedSize)
+	} else if time.Duration(c.LeaderTimeout) != time.Minute {
+		t.Fatalf("unexpected leader timeout: %v", c.LeaderTimeout)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := replication.NewConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from NewConfig: %s", err)
+	}
+
+	c.LeaderTimeout = c.ShipInterval
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for leader-timeout not above ship-interval")
+	}
+
+	c = replication.NewConfig()
+	c.MaxBatchSize = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for zero max-batch-size")
+	}
+
+	c.Enabled = false
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail for disabled config: %s", err)
+	}
+}
@@ -0,0 +1,368 @@
+// Package replication provides a service that ships the WAL of the shards led
+// by a data node to the other owners of the shards, for retention policies
+// replicated by WAL shipping, and fails over the leader of a shard whose
+// leader stopped shipping.
+package replication // import "github.com/influxdata/influxdb/services/replication"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the replication service.
+const (
+	statBatchesShipped = "batchesShipped"
+	statBytesShipped   = "bytesShipped"
+	statFailovers      = "failovers"
+	statErrors         = "errors"
+)
+
+// Service represents the replication service.
+type Service struct {
+	MetaClient interface {
+		NodeID() uint64
+		Databases() []meta.DatabaseInfo
+		SetShardLeader(id, nodeID, prev uint64) error
+	}
+	TSDBStore interface {
+		Shard(id uint64) *tsdb.Shard
+	}
+	ShardWriter interface {
+		ReplicateWAL(ownerID uint64, req *coordinator.ReplicateWALRequest) (tsdb.WALPosition, error)
+	}
+
+	config Config
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	// cursors holds the position of the leader's WAL applied to each
+	// other owner of the shards led by this node.
+	cursors map[cursorKey]*cursor
+
+	// following holds the time each shard followed by this node was first
+	// seen, to fail over leaders that never shipped to it.
+	following map[uint64]time.Time
+
+	logger *zap.Logger
+	stats  *Statistics
+}
+
+// cursorKey identifies an owner of a shard.
+type cursorKey struct {
+	shardID uint64
+	nodeID  uint64
+}
+
+// cursor is the position of the leader's WAL applied to an owner of a shard.
+// It is unknown until the owner reports it.
+type cursor struct {
+	pos   tsdb.WALPosition
+	known bool
+}
+
+// NewService returns a configured replication service.
+func NewService(c Config) *Service {
+	return &Service{
+		config:    c,
+		cursors:   make(map[cursorKey]*cursor),
+		following: make(map[uint64]time.Time),
+		logger:    zap.NewNop(),
+		stats:     &Statistics{},
+	}
+}
+
+// Open starts the replication service.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting replication service",
+		logger.DurationLiteral("ship_interval", time.Duration(s.config.ShipInterval)),
+		logger.DurationLiteral("leader_timeout", time.Duration(s.config.LeaderTimeout)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the replication service.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing replication service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "replication"))
+}
+
+// Statistics maintains the statistics for the replication service.
+type Statistics struct {
+	BatchesShipped int64
+	BytesShipped   int64
+	Failovers      int64
+	Errors         int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "replication",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statBatchesShipped: atomic.LoadInt64(&s.stats.BatchesShipped),
+			statBytesShipped:   atomic.LoadInt64(&s.stats.BytesShipped),
+			statFailovers:      atomic.LoadInt64(&s.stats.Failovers),
+			statErrors:         atomic.LoadInt64(&s.stats.Errors),
+		},
+	}}
+}
+
+func (s *Service) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.config.ShipInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Enforce(ctx)
+		}
+	}
+}
+
+// Enforce runs a single pass over the shards of WAL replicated retention
+// policies owned by this node. The WAL of each shard led by this node is
+// shipped to the other owners, and the leader of each other shard is failed
+// over to this node if it was not heard from within the leader timeout.
+func (s *Service) Enforce(ctx context.Context) {
+	nodeID := s.MetaClient.NodeID()
+	leading := make(map[uint64]struct{})
+	following := make(map[uint64]struct{})
+	for _, di := range s.MetaClient.Databases() {
+		for _, rpi := range di.RetentionPolicies {
+			if !rpi.WALReplicated() {
+				continue
+			}
+
+			for _, sgi := range rpi.ShardGroups {
+				if sgi.Deleted() {
+					continue
+				}
+				for _, si := range sgi.Shards {
+					if ctx.Err() != nil {
+						return
+					} else if !si.OwnedBy(nodeID) {
+						continue
+					}
+					sh := s.TSDBStore.Shard(si.ID)
+					if sh == nil {
+						continue
+					}
+
+					log := s.logger.With(logger.Database(di.Name), logger.RetentionPolicy(rpi.Name), logger.Shard(si.ID))
+					if si.LeaderID() == nodeID {
+						leading[si.ID] = struct{}{}
+						s.ship(ctx, log, si, sh)
+					} else {
+						following[si.ID] = struct{}{}
+						s.follow(log, si, sh)
+					}
+				}
+			}
+		}
+	}
+
+	// Forget the owners of shards no longer led by this node, and the
+	// shards no longer followed.
+	for k := range s.cursors {
+		if _, ok := leading[k.shardID]; !ok {
+			delete(s.cursors, k)
+		}
+	}
+	for id := range s.following {
+		if _, ok := following[id]; !ok {
+			delete(s.following, id)
+		}
+	}
+}
+
+// ship ships the WAL of shard si, led by this node, to each other owner, and
+// keeps the WAL from the position of the owner furthest behind. Owners behind
+// by more than the maximum retained size are not waited for.
+func (s *Service) ship(ctx context.Context, log *zap.Logger, si meta.ShardInfo, sh *tsdb.Shard) {
+	nodeID := s.MetaClient.NodeID()
+	end := sh.WALEnd()
+
+	var retain tsdb.WALPosition
+	for _, owner := range si.Owners {
+		if owner.NodeID == nodeID {
+			continue
+		}
+
+		k := cursorKey{shardID: si.ID, nodeID: owner.NodeID}
+		c := s.cursors[k]
+		if c == nil {
+			c = &cursor{}
+			s.cursors[k] = c
+		}
+		if err := s.shipTo(ctx, log, si.ID, owner.NodeID, sh, c, end); err != nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Info("Unable to ship WAL", zap.Uint64("owner", owner.NodeID), zap.Error(err))
+		}
+
+		// Keep every segment for an owner whose position is unknown.
+		pos := c.pos
+		if pos.IsZero() {
+			pos = tsdb.WALPosition{Segment: 1}
+		}
+		if s.config.MaxRetainedSize > 0 && sh.WALBytesSince(pos) > int64(s.config.MaxRetainedSize) {
+			continue
+		} else if retain.IsZero() || pos.Less(retain) {
+			retain = pos
+		}
+	}
+
+	if retain.IsZero() {
+		retain = end
+	}
+	if err := sh.SetWALRetention(retain); err != nil {
+		log.Info("Unable to set WAL retention", zap.Error(err))
+	}
+}
+
+// shipTo ships the WAL of shard id to owner nodeID from cursor c, up to end.
+// An owner is first asked for its position. Once it is caught up, a request
+// with no entries is sent so that it knows the leader is alive.
+func (s *Service) shipTo(ctx context.Context, log *zap.Logger, id, nodeID uint64, sh *tsdb.Shard, c *cursor, end tsdb.WALPosition) error {
+	leaderID := s.MetaClient.NodeID()
+	if !c.known {
+		applied, err := s.ShardWriter.ReplicateWAL(nodeID, &coordinator.ReplicateWALRequest{
+			ShardID:  id,
+			LeaderID: leaderID,
+			LagBytes: sh.WALBytesSince(tsdb.WALPosition{}),
+		})
+		if err != nil {
+			return err
+		}
+		c.pos, c.known = applied, true
+	}
+
+	for ctx.Err() == nil {
+		// The owner applied entries beyond the end of this WAL, such as
+		// when the leader's WAL was lost. Ship it from the start.
+		if end.Less(c.pos) {
+			c.pos = tsdb.WALPosition{}
+		}
+
+		b, next, err := sh.ReadWAL(c.pos, int(s.config.MaxBatchSize))
+		if err == tsdb.ErrWALPositionRemoved {
+			log.Warn("Owner is behind the kept WAL, shipping from the oldest segment",
+				zap.Uint64("owner", nodeID), zap.Stringer("position", c.pos))
+			c.pos = tsdb.WALPosition{}
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		applied, err := s.ShardWriter.ReplicateWAL(nodeID, &coordinator.ReplicateWALRequest{
+			ShardID:  id,
+			LeaderID: leaderID,
+			Start:    c.pos,
+			Next:     next,
+			Data:     b,
+			LagBytes: sh.WALBytesSince(next),
+		})
+		if err != nil {
+			c.known = false
+			return err
+		} else if applied != next {
+			// The owner applied up to another position; resume from it.
+			c.pos = applied
+			return nil
+		}
+		c.pos = next
+
+		if len(b) == 0 {
+			return nil
+		}
+		atomic.AddInt64(&s.stats.BatchesShipped, 1)
+		atomic.AddInt64(&s.stats.BytesShipped, int64(len(b)))
+		if !next.Less(end) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// follow fails over the leader of shard si to this node if the leader was not
+// heard from within the leader timeout. Owners wait an extra ship interval
+// for each owner before them, so that the first owner still alive usually
+// takes over; the meta store only accepts the first failover of a leader.
+func (s *Service) follow(log *zap.Logger, si meta.ShardInfo, sh *tsdb.Shard) {
+	nodeID, leader := s.MetaClient.NodeID(), si.LeaderID()
+
+	seen, ok := s.following[si.ID]
+	if !ok {
+		// Stop keeping segments kept while this node was leader.
+		if err := sh.SetWALRetention(tsdb.WALPosition{}); err != nil && err != tsdb.ErrWALDisabled {
+			log.Info("Unable to set WAL retention", zap.Error(err))
+		}
+		seen = time.Now()
+		s.following[si.ID] = seen
+	}
+	if r := sh.ReplicaStatus(); r != nil && r.Leader == leader && r.LastContact.After(seen) {
+		seen = r.LastContact
+	}
+
+	var rank int
+	for _, owner := range si.Owners {
+		if owner.NodeID == nodeID {
+			break
+		} else if owner.NodeID != leader && !owner.Quarantined {
+			rank++
+		}
+	}
+	timeout := time.Duration(s.config.LeaderTimeout) + time.Duration(rank)*time.Duration(s.config.ShipInterval)
+	if time.Since(seen) < timeout {
+		return
+	}
+
+	if err := s.MetaClient.SetShardLeader(si.ID, nodeID, leader); err != nil {
+		log.Info("Unable to fail over shard leader", zap.Uint64("leader", leader), zap.Error(err))
+		return
+	}
+	atomic.AddInt64(&s.stats.Failovers, 1)
+	log.Info("Failed over shard leader", zap.Uint64("old_leader", leader), zap.Uint64("leader", nodeID))
+	s.following[si.ID] = time.Now()
+}
@@ -0,0 +1,162 @@
+package replication_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/services/replication"
+	"github.com/influxdata/influxdb/toml"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestService_OpenDisabled(t *testing.T) {
+	// Opening a disabled service should be a no-op.
+	c := replication.NewConfig()
+	c.Enabled = false
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() != "" {
+		t.Fatalf("service logged %q, didn't expect any logging", s.LogBuf.String())
+	}
+}
+
+func TestService_OpenClose(t *testing.T) {
+	s := NewService(replication.NewConfig())
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() == "" {
+		t.Fatal("service didn't log anything on open")
+	}
+
+	// Reopening is a no-op
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-closing is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Enforce_Failover(t *testing.T) {
+	dir, err := os.MkdirTemp("", "replication-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := replication.NewConfig()
+	c.LeaderTimeout = toml.Duration(time.Millisecond)
+	s := NewService(c)
+
+	rpi := meta.RetentionPolicyInfo{
+		Name:            "rp",
+		ReplicationMode: meta.ReplicationModeWAL,
+		ShardGroups: []meta.ShardGroupInfo{{
+			ID: 1,
+			Shards: []meta.ShardInfo{
+				{ID: 1, Owners: []meta.ShardOwner{{NodeID: 1}, {NodeID: 2}}},
+				{ID: 2, Owners: []meta.ShardOwner{{NodeID: 3}, {NodeID: 4}}},
+			},
+		}},
+	}
+	s.MetaClient.NodeIDFn = func() uint64 { return 2 }
+	s.MetaClient.DatabasesFn = func() []meta.DatabaseInfo {
+		return []meta.DatabaseInfo{{Name: "db", RetentionPolicies: []meta.RetentionPolicyInfo{rpi}}}
+	}
+
+	sh := tsdb.NewShard(1, filepath.Join(dir, "data"), filepath.Join(dir, "wal"), nil, tsdb.NewEngineOptions())
+	s.TSDBStore.ShardFn = func(id uint64) *tsdb.Shard {
+		if id != 1 {
+			t.Fatalf("unexpected shard: %d", id)
+		}
+		return sh
+	}
+
+	var failovers int
+	s.MetaClient.SetShardLeaderFn = func(id, nodeID, prev uint64) error {
+		if id != 1 || nodeID != 2 || prev != 1 {
+			t.Fatalf("unexpected failover of shard %d to %d from %d", id, nodeID, prev)
+		}
+		failovers++
+		return nil
+	}
+
+	// The leader is failed over once it was not heard from within the
+	// leader timeout.
+	s.Enforce(context.Background())
+	time.Sleep(2 * time.Millisecond)
+	s.Enforce(context.Background())
+	if failovers != 1 {
+		t.Fatalf("unexpected failovers: %d", failovers)
+	}
+
+	// Shards led by this node are shipped to the other owners.
+	rpi.ShardGroups[0].Shards[0].Leader = 2
+	s.ShardWriter.ReplicateWALFn = func(ownerID uint64, req *coordinator.ReplicateWALRequest) (tsdb.WALPosition, error) {
+		if ownerID != 1 || req.ShardID != 1 || req.LeaderID != 2 {
+			t.Fatalf("unexpected request to %d: %+v", ownerID, req)
+		}
+		return tsdb.WALPosition{}, nil
+	}
+	s.Enforce(context.Background())
+	if failovers != 1 {
+		t.Fatalf("unexpected failovers: %d", failovers)
+	}
+}
+
+// Service is a test wrapper for replication.Service.
+type Service struct {
+	*replication.Service
+
+	LogBuf      bytes.Buffer
+	MetaClient  *internal.MetaClientMock
+	TSDBStore   *internal.TSDBStoreMock
+	ShardWriter *ShardWriter
+}
+
+func NewService(c replication.Config) *Service {
+	s := &Service{
+		MetaClient:  &internal.MetaClientMock{},
+		TSDBStore:   &internal.TSDBStoreMock{},
+		ShardWriter: &ShardWriter{},
+		Service:     replication.NewService(c),
+	}
+
+	l := logger.New(&s.LogBuf)
+	s.WithLogger(l)
+
+	s.Service.MetaClient = s.MetaClient
+	s.Service.TSDBStore = s.TSDBStore
+	s.Service.ShardWriter = s.ShardWriter
+	return s
+}
+
+// ShardWriter is a mock of the shipping of WAL entries to other nodes.
+type ShardWriter struct {
+	ReplicateWALFn func(ownerID uint64, req *coordinator.ReplicateWALRequest) (tsdb.WALPosition, error)
+}
+
+func (w *ShardWriter) ReplicateWAL(ownerID uint64, req *coordinator.ReplicateWALRequest) (tsdb.WALPosition, error) {
+	return w.ReplicateWALFn(ownerID, req)
+}
@@ -0,0 +1,72 @@
+package reshard
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	// DefaultCheckInterval is the interval of time between scans for shards to reshard.
+	DefaultCheckInterval = time.Minute
+
+	// DefaultMaxThroughput is the rate limit in bytes per second for copying
+	// shard data into resharded shards.
+	DefaultMaxThroughput = 16 * 1024 * 1024
+
+	// DefaultMaxThroughputBurst is the maximum number of bytes copied at once
+	// into resharded shards.
+	DefaultMaxThroughputBurst = 16 * 1024 * 1024
+)
+
+// Config represents the configuration for the reshard service.
+type Config struct {
+	Enabled            bool          `toml:"enabled"`
+	CheckInterval      toml.Duration `toml:"check-interval"`
+	MaxThroughput      toml.Size     `toml:"max-throughput"`
+	MaxThroughputBurst toml.Size     `toml:"max-throughput-burst"`
+}
+
+// NewConfig returns an instance of Config with defaults.
+func NewConfig() Config {
+	return Config{
+		Enabled:            true,
+		CheckInterval:      toml.Duration(DefaultCheckInterval),
+		MaxThroughput:      toml.Size(DefaultMaxThroughput),
+		MaxThroughputBurst: toml.Size(DefaultMaxThroughputBurst),
+	}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.CheckInterval <= 0 {
+		return errors.New("check-interval must be positive")
+	}
+	if c.MaxThroughput > 0 && c.MaxThroughputBurst < c.MaxThroughput {
+		return errors.New("max-throughput-burst must be greater than or equal to max-throughput")
+	}
+
+	return nil
+}
+
+// Diagnostics returns a diagnostics representation of a subset of the Config.
+func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
+	if !c.Enabled {
+		return diagnostics.RowFromMap(map[string]interface{}{
+			"enabled": false,
+		}), nil
+	}
+
+	return diagnostics.RowFromMap(map[string]interface{}{
+		"enabled":              true,
+		"check-interval":       c.CheckInterval,
+		"max-throughput":       c.MaxThroughput,
+		"max-throughput-burst": c.MaxThroughputBurst,
+	}), nil
+}
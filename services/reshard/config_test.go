@@ -0,0 +1,56 @@
+package reshard_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/reshard"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c reshard.Config
+	if _, err := toml.Decode(`
+enabled = false
+check-interval = "10s"
+max-throughput = "1m"
+max-throughput-burst = "2m"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if c.Enabled {
+		t.Fatalf("unexpected enabled state: %v", c.Enabled)
+	} else if time.Duration(c.CheckInterval) != 10*time.Second {
+		t.Fatalf("unexpected check interval: %v", c.CheckInterval)
+	} else if c.MaxThroughput != 1024*1024 {
+		t.Fatalf("unexpected max throughput: %v", c.MaxThroughput)
+	} else if c.MaxThroughputBurst != 2*1024*1024 {
+		t.Fatalf("unexpected max throughput burst: %v", c.MaxThroughputBurst)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := reshard.NewConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from NewConfig: %s", err)
+	}
+
+	c.MaxThroughputBurst = c.MaxThroughput - 1
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for max-throughput-burst below max-throughput")
+	}
+
+	c = reshard.NewConfig()
+	c.CheckInterval = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for zero check-interval")
+	}
+
+	c.Enabled = false
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail for disabled config: %s", err)
+	}
+}
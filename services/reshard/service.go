@@ -0,0 +1,314 @@
+// Package reshard provides a service that copies the data of the shard groups
+// of a retention policy being resharded into the new shard groups.
+package reshard // import "github.com/influxdata/influxdb/services/reshard"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the reshard service.
+const (
+	statShardsCopied = "shardsCopied"
+	statBytesCopied  = "bytesCopied"
+	statErrors       = "errors"
+)
+
+// Service represents the reshard service.
+type Service struct {
+	MetaClient interface {
+		NodeID() uint64
+		Databases() []meta.DatabaseInfo
+		DataNode(id uint64) (*meta.NodeInfo, error)
+		SetReshardCopied(database, rp string, id, nodeID uint64) error
+	}
+	TSDBStore interface {
+		Shard(id uint64) *tsdb.Shard
+		CreateShard(database, policy string, shardID uint64, enabled bool) error
+		BackupShard(id uint64, since time.Time, w io.Writer) error
+		MergeShard(id uint64, basePath string, r io.Reader) error
+	}
+	ShardBackuper interface {
+		BackupRemoteShard(host string, shardID uint64, since time.Time) (io.ReadCloser, error)
+	}
+
+	config Config
+	rate   limiter.Rate
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	logger *zap.Logger
+	stats  *Statistics
+}
+
+// NewService returns a configured reshard service.
+func NewService(c Config) *Service {
+	s := &Service{
+		config: c,
+		logger: zap.NewNop(),
+		stats:  &Statistics{},
+	}
+	if c.MaxThroughput > 0 {
+		s.rate = limiter.NewRate(int(c.MaxThroughput), int(c.MaxThroughputBurst))
+	}
+	return s
+}
+
+// Open starts the reshard service.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting reshard service",
+		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the reshard service. A copy in progress is abandoned and
+// started over once the service is opened again.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing reshard service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "reshard"))
+}
+
+// Statistics maintains the statistics for the reshard service.
+type Statistics struct {
+	ShardsCopied int64
+	BytesCopied  int64
+	Errors       int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "reshard",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statShardsCopied: atomic.LoadInt64(&s.stats.ShardsCopied),
+			statBytesCopied:  atomic.LoadInt64(&s.stats.BytesCopied),
+			statErrors:       atomic.LoadInt64(&s.stats.Errors),
+		},
+	}}
+}
+
+func (s *Service) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.config.CheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Enforce(ctx)
+		}
+	}
+}
+
+// Enforce runs a single pass over the retention policies being resharded,
+// copying the data of the old shard groups into each shard of the new groups
+// owned by this node and not copied yet, one at a time. The earliest groups
+// are copied first, so that they replace the old groups as soon as possible.
+func (s *Service) Enforce(ctx context.Context) {
+	nodeID := s.MetaClient.NodeID()
+	for _, di := range s.MetaClient.Databases() {
+		for i := range di.RetentionPolicies {
+			rpi := &di.RetentionPolicies[i]
+			if rpi.Reshard == nil {
+				continue
+			}
+
+			for j := range rpi.Reshard.ShardGroups {
+				sgi := &rpi.Reshard.ShardGroups[j]
+				sources := rpi.ReshardSources(sgi)
+				for k, si := range sgi.Shards {
+					if ctx.Err() != nil {
+						return
+					} else if !si.OwnedBy(nodeID) || rpi.Reshard.Copied(si.ID, nodeID) {
+						continue
+					}
+					s.copyShard(ctx, di.Name, rpi.Name, si.ID, k, sources)
+				}
+			}
+		}
+	}
+}
+
+// copyShard copies the data of the shards of index i of the source groups into
+// shard id, then records the copy in the meta store. The shard is kept if it
+// exists, as it holds the points written to the new group since resharding
+// started; a copy interrupted by a restart merges the sources again, which
+// overwrites the points already copied with the same values.
+func (s *Service) copyShard(ctx context.Context, database, rp string, id uint64, i int, sources []*meta.ShardGroupInfo) {
+	log := s.logger.With(logger.Database(database), logger.RetentionPolicy(rp), logger.Shard(id))
+	start := time.Now()
+
+	var n int64
+	if err := func() error {
+		if s.TSDBStore.Shard(id) == nil {
+			if err := s.TSDBStore.CreateShard(database, rp, id, true); err != nil {
+				return err
+			}
+		}
+
+		for _, sgi := range sources {
+			if i >= len(sgi.Shards) {
+				return fmt.Errorf("shard group %d has no shard %d", sgi.ID, i)
+			}
+			c, err := s.mergeShard(ctx, database, rp, id, sgi.Shards[i], time.Time{})
+			n += c
+			if err != nil {
+				return err
+			}
+		}
+
+		// Catch up with the points flushed from the caches of the old shards
+		// during the copy. Points written since resharding started are also
+		// written to the new group until it replaces the old groups.
+		for _, sgi := range sources {
+			c, err := s.mergeShard(ctx, database, rp, id, sgi.Shards[i], start)
+			n += c
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.MetaClient.SetReshardCopied(database, rp, id, s.MetaClient.NodeID())
+	}(); err != nil {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Unable to copy resharded shard", zap.Error(err))
+		}
+		return
+	}
+
+	atomic.AddInt64(&s.stats.ShardsCopied, 1)
+	atomic.AddInt64(&s.stats.BytesCopied, n)
+	log.Info("Resharded shard copied",
+		zap.Int("sources", len(sources)),
+		zap.Int64("bytes", n),
+		logger.DurationLiteral("duration", time.Since(start)))
+}
+
+// mergeShard imports the files of shard src modified since the given time
+// into shard id, reading them from the local store if src is stored locally
+// or from one of its owners otherwise. It returns the number of bytes copied.
+func (s *Service) mergeShard(ctx context.Context, database, rp string, id uint64, src meta.ShardInfo, since time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	backup, err := s.backupShard(src, since)
+	if err != nil {
+		return 0, err
+	}
+
+	pr, pw := io.Pipe()
+	var n int64
+	go func() {
+		var w io.Writer = pw
+		if s.rate != nil {
+			w = limiter.NewWriterWithRate(pw, s.rate)
+		}
+		w = &countingWriter{w: w, n: &n}
+		pw.CloseWithError(backup(w))
+	}()
+	defer pr.Close()
+
+	basePath := filepath.Join(database, rp, strconv.FormatUint(src.ID, 10))
+	if err := s.TSDBStore.MergeShard(id, basePath, pr); err != nil {
+		return atomic.LoadInt64(&n), err
+	}
+
+	// Drain the end of the archive so that the backup completes.
+	if _, err := io.Copy(io.Discard, pr); err != nil {
+		return atomic.LoadInt64(&n), err
+	}
+	return atomic.LoadInt64(&n), nil
+}
+
+// backupShard returns a function writing the backup of shard si to a writer.
+func (s *Service) backupShard(si meta.ShardInfo, since time.Time) (func(w io.Writer) error, error) {
+	if s.TSDBStore.Shard(si.ID) != nil {
+		return func(w io.Writer) error {
+			return s.TSDBStore.BackupShard(si.ID, since, w)
+		}, nil
+	}
+
+	nodeID := s.MetaClient.NodeID()
+	for _, owner := range si.HealthyOwners() {
+		if owner.NodeID == nodeID {
+			continue
+		}
+		ni, err := s.MetaClient.DataNode(owner.NodeID)
+		if err != nil || ni == nil {
+			continue
+		}
+
+		r, err := s.ShardBackuper.BackupRemoteShard(ni.TCPAddr, si.ID, since)
+		if err != nil {
+			s.logger.Info("Unable to back up remote shard",
+				logger.Shard(si.ID), zap.Uint64("node_id", owner.NodeID), zap.Error(err))
+			continue
+		}
+		return func(w io.Writer) error {
+			defer r.Close()
+			_, err := io.Copy(w, r)
+			return err
+		}, nil
+	}
+	return nil, fmt.Errorf("no owner of shard %d available", si.ID)
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	atomic.AddInt64(w.n, int64(n))
+	return n, err
+}
@@ -0,0 +1,323 @@
+package reshard_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/coordinator"
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/services/reshard"
+	"github.com/influxdata/influxdb/tsdb"
+	_ "github.com/influxdata/influxdb/tsdb/engine"
+	_ "github.com/influxdata/influxdb/tsdb/index"
+	"github.com/influxdata/influxql"
+)
+
+func TestService_OpenDisabled(t *testing.T) {
+	// Opening a disabled service should be a no-op.
+	c := reshard.NewConfig()
+	c.Enabled = false
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() != "" {
+		t.Fatalf("service logged %q, didn't expect any logging", s.LogBuf.String())
+	}
+}
+
+func TestService_OpenClose(t *testing.T) {
+	s := NewService(reshard.NewConfig())
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() == "" {
+		t.Fatal("service didn't log anything on open")
+	}
+
+	// Reopening is a no-op
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-closing is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Enforce(t *testing.T) {
+	c := reshard.NewConfig()
+	c.MaxThroughput = 0
+	s := NewService(c)
+
+	// Shard 10 merges shards 1 and 3, shard 11 merges shards 2 and 4. Only
+	// shard 10 is owned by this node.
+	owners := func(ids ...uint64) []meta.ShardOwner {
+		var a []meta.ShardOwner
+		for _, id := range ids {
+			a = append(a, meta.ShardOwner{NodeID: id})
+		}
+		return a
+	}
+	rpi := meta.RetentionPolicyInfo{
+		Name: "rp",
+		ShardGroups: []meta.ShardGroupInfo{
+			{ID: 1, StartTime: time.Unix(0, 0), EndTime: time.Unix(3600, 0), Shards: []meta.ShardInfo{{ID: 1, Owners: owners(2)}, {ID: 2, Owners: owners(2)}}},
+			{ID: 2, StartTime: time.Unix(3600, 0), EndTime: time.Unix(7200, 0), Shards: []meta.ShardInfo{{ID: 3, Owners: owners(2)}, {ID: 4, Owners: owners(2)}}},
+		},
+		Reshard: &meta.ReshardInfo{
+			Duration: 2 * time.Hour,
+			ShardGroups: []meta.ShardGroupInfo{
+				{ID: 3, StartTime: time.Unix(0, 0), EndTime: time.Unix(7200, 0), Shards: []meta.ShardInfo{{ID: 10, Owners: owners(1)}, {ID: 11, Owners: owners(2)}}},
+			},
+		},
+	}
+	s.MetaClient.NodeIDFn = func() uint64 { return 1 }
+	s.MetaClient.DatabasesFn = func() []meta.DatabaseInfo {
+		return []meta.DatabaseInfo{{Name: "db", RetentionPolicies: []meta.RetentionPolicyInfo{rpi}}}
+	}
+	s.MetaClient.DataNodeFn = func(id uint64) (*meta.NodeInfo, error) {
+		return &meta.NodeInfo{ID: id, TCPAddr: fmt.Sprintf("node%d:8088", id)}, nil
+	}
+	var copied []uint64
+	s.MetaClient.SetReshardCopiedFn = func(database, rp string, id, nodeID uint64) error {
+		if database != "db" || rp != "rp" || nodeID != 1 {
+			t.Fatalf("unexpected copy: %s %s %d %d", database, rp, id, nodeID)
+		}
+		copied = append(copied, id)
+		return nil
+	}
+
+	var created []uint64
+	s.TSDBStore.ShardFn = func(id uint64) *tsdb.Shard { return nil }
+	s.TSDBStore.CreateShardFn = func(database, policy string, id uint64, enabled bool) error {
+		created = append(created, id)
+		return nil
+	}
+	var merged []string
+	s.TSDBStore.MergeShardFn = func(id uint64, basePath string, r io.Reader) error {
+		buf, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		merged = append(merged, fmt.Sprintf("%d<%s:%s", id, basePath, buf))
+		return nil
+	}
+	s.ShardBackuper.BackupRemoteShardFn = func(host string, id uint64, since time.Time) (io.ReadCloser, error) {
+		if host != "node2:8088" {
+			t.Fatalf("unexpected host: %s", host)
+		}
+		return io.NopCloser(strings.NewReader(fmt.Sprintf("%d@%t", id, since.IsZero()))), nil
+	}
+
+	s.Enforce(context.Background())
+
+	if exp := []uint64{10}; !reflect.DeepEqual(created, exp) {
+		t.Fatalf("unexpected created shards: %v", created)
+	} else if !reflect.DeepEqual(copied, exp) {
+		t.Fatalf("unexpected copied shards: %v", copied)
+	}
+	exp := []string{
+		"10<db/rp/1:1@true",
+		"10<db/rp/3:3@true",
+		"10<db/rp/1:1@false",
+		"10<db/rp/3:3@false",
+	}
+	if !reflect.DeepEqual(merged, exp) {
+		t.Fatalf("unexpected merges: %v", merged)
+	}
+
+	stats := s.Statistics(nil)[0].Values
+	if got := stats["shardsCopied"]; got != int64(1) {
+		t.Fatalf("unexpected shards copied: %v", got)
+	} else if got := stats["bytesCopied"]; got != int64(26) {
+		t.Fatalf("unexpected bytes copied: %v", got)
+	}
+
+	// Copied shards are skipped.
+	rpi.Reshard.Copies = []meta.ReshardCopy{{ShardID: 10, NodeID: 1}}
+	created, copied, merged = nil, nil, nil
+	s.Enforce(context.Background())
+	if len(created) != 0 || len(copied) != 0 || len(merged) != 0 {
+		t.Fatalf("unexpected copy: %v %v %v", created, copied, merged)
+	}
+}
+
+// Ensures the points written while a shard is copied are in the new shard once
+// it replaces the old ones, including those written after the last pass.
+func TestService_Enforce_Writes(t *testing.T) {
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Unix(0, 0)
+	data := &meta.Data{}
+	must(data.CreateDataNode("localhost:8086", "localhost:8088"))
+	must(data.CreateDatabase("db"))
+	rp := meta.NewRetentionPolicyInfo("rp")
+	rp.ShardGroupDuration = time.Hour
+	must(data.CreateRetentionPolicy("db", rp, true))
+	nodeID := data.DataNodes[0].ID
+
+	path := t.TempDir()
+	store := tsdb.NewStore(path)
+	store.EngineOptions.Config.WALDir = filepath.Join(path, "wal")
+	must(store.Open())
+	defer store.Close()
+
+	mc := &internal.MetaClientMock{
+		NodeIDFn:          func() uint64 { return nodeID },
+		DatabaseFn:        data.Database,
+		DatabasesFn:       data.CloneDatabases,
+		RetentionPolicyFn: data.RetentionPolicy,
+		DataNodeFn: func(id uint64) (*meta.NodeInfo, error) {
+			return data.DataNode(id), nil
+		},
+		CreateShardGroupFn: func(database, policy string, timestamp time.Time) (*meta.ShardGroupInfo, error) {
+			if err := data.CreateShardGroup(database, policy, timestamp); err != nil {
+				return nil, err
+			}
+			rpi, err := data.RetentionPolicy(database, policy)
+			if err != nil {
+				return nil, err
+			}
+			return rpi.ShardGroupByTimestamp(timestamp), nil
+		},
+		SetReshardCopiedFn: func(database, rp string, id, nodeID uint64) error {
+			return data.SetReshardCopied(database, rp, id, nodeID, time.Now())
+		},
+	}
+
+	w := coordinator.NewPointsWriter()
+	w.MetaClient = mc
+	w.TSDBStore = store
+	must(w.Open())
+	defer w.Close()
+
+	// Each write adds a point to each hour of the window resharded.
+	var n int
+	write := func() {
+		ts := start.Add(time.Duration(n) * time.Second)
+		pts := []models.Point{
+			models.MustNewPoint("cpu", nil, models.Fields{"value": float64(n)}, ts),
+			models.MustNewPoint("cpu", nil, models.Fields{"value": float64(n)}, ts.Add(time.Hour)),
+		}
+		must(w.WritePointsPrivileged("db", "rp", models.ConsistencyLevelOne, pts))
+		n++
+	}
+
+	write()
+	must(data.CreateReshard("db", "rp", 2*time.Hour, start.Add(4*time.Hour)))
+	write()
+
+	c := reshard.NewConfig()
+	c.MaxThroughput = 0
+	s := NewService(c)
+	s.Service.MetaClient = mc
+	s.Service.TSDBStore = &MergeStore{Store: store, Fn: write}
+	s.Enforce(context.Background())
+
+	rpi, err := data.RetentionPolicy("db", "rp")
+	must(err)
+	if rpi.Reshard != nil {
+		t.Fatal("expected resharded groups to replace the old groups")
+	}
+	sgi := rpi.ShardGroupByTimestamp(start)
+	if sgi == nil || !sgi.EndTime.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("unexpected shard group: %+v", sgi)
+	}
+
+	sh := store.Shard(sgi.Shards[0].ID)
+	if sh == nil {
+		t.Fatal("resharded shard not found")
+	}
+	itr, err := sh.CreateIterator(context.Background(), &influxql.Measurement{Name: "cpu"}, query.IteratorOptions{
+		Expr:      influxql.MustParseExpr(`value`),
+		Ascending: true,
+		StartTime: influxql.MinTime,
+		EndTime:   influxql.MaxTime,
+	})
+	must(err)
+	defer itr.Close()
+
+	var got int
+	for fitr := itr.(query.FloatIterator); ; got++ {
+		p, err := fitr.Next()
+		must(err)
+		if p == nil {
+			break
+		}
+	}
+	if exp := 2 * n; got != exp {
+		t.Fatalf("unexpected points: got %d, exp %d", got, exp)
+	}
+}
+
+type Service struct {
+	MetaClient    *internal.MetaClientMock
+	TSDBStore     *internal.TSDBStoreMock
+	ShardBackuper *ShardBackuper
+
+	LogBuf bytes.Buffer
+	*reshard.Service
+}
+
+func NewService(c reshard.Config) *Service {
+	s := &Service{
+		MetaClient:    &internal.MetaClientMock{},
+		TSDBStore:     &internal.TSDBStoreMock{},
+		ShardBackuper: &ShardBackuper{},
+		Service:       reshard.NewService(c),
+	}
+
+	l := logger.New(&s.LogBuf)
+	s.WithLogger(l)
+
+	s.Service.MetaClient = s.MetaClient
+	s.Service.TSDBStore = s.TSDBStore
+	s.Service.ShardBackuper = s.ShardBackuper
+	return s
+}
+
+// MergeStore is a store writing more points around each merge.
+type MergeStore struct {
+	*tsdb.Store
+	Fn func()
+}
+
+func (s *MergeStore) MergeShard(id uint64, basePath string, r io.Reader) error {
+	s.Fn()
+	defer s.Fn()
+	return s.Store.MergeShard(id, basePath, r)
+}
+
+// ShardBackuper is a mock of the backups of remote shards.
+type ShardBackuper struct {
+	BackupRemoteShardFn func(host string, shardID uint64, since time.Time) (io.ReadCloser, error)
+}
+
+func (b *ShardBackuper) BackupRemoteShard(host string, shardID uint64, since time.Time) (io.ReadCloser, error) {
+	return b.BackupRemoteShardFn(host, shardID, since)
+}
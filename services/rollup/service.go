@@ -0,0 +1,208 @@
+// Package rollup provides a service that downsamples old shards according to
+// the rollup rules of their retention policy.
+package rollup // import "github.com/influxdata/influxdb/services/rollup"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the rollup service.
+const (
+	statShardsRolledUp = "shardsRolledUp"
+	statBytesReclaimed = "bytesReclaimed"
+	statErrors         = "errors"
+)
+
+// Service represents the rollup service.
+type Service struct {
+	MetaClient interface {
+		ShardOwner(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
+		RetentionPolicy(database, name string) (*meta.RetentionPolicyInfo, error)
+	}
+	TSDBStore interface {
+		ShardIDs() []uint64
+		Shard(id uint64) *tsdb.Shard
+		RollupShard(ctx context.Context, id uint64, interval time.Duration) error
+	}
+
+	config Config
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	logger *zap.Logger
+	stats  *Statistics
+}
+
+// NewService returns a configured rollup service.
+func NewService(c Config) *Service {
+	return &Service{
+		config: c,
+		logger: zap.NewNop(),
+		stats:  &Statistics{},
+	}
+}
+
+// Open starts the rollup service.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting rollup service",
+		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the rollup service. A rollup in progress is abandoned and the
+// shard keeps its existing data.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing rollup service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "rollup"))
+}
+
+// Statistics maintains the statistics for the rollup service.
+type Statistics struct {
+	ShardsRolledUp int64
+	BytesReclaimed int64
+	Errors         int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "rollup",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statShardsRolledUp: atomic.LoadInt64(&s.stats.ShardsRolledUp),
+			statBytesReclaimed: atomic.LoadInt64(&s.stats.BytesReclaimed),
+			statErrors:         atomic.LoadInt64(&s.stats.Errors),
+		},
+	}}
+}
+
+func (s *Service) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.config.CheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Enforce(ctx)
+		}
+	}
+}
+
+// Enforce runs a single pass over the local shards, rolling up those that are
+// old enough under one of their retention policy's rollup rules, one at a time.
+// Offloaded shards are skipped.
+func (s *Service) Enforce(ctx context.Context) {
+	now := time.Now().UTC()
+	for _, id := range s.TSDBStore.ShardIDs() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		sh := s.TSDBStore.Shard(id)
+		if sh == nil || sh.Tier() == tsdb.TierRemote {
+			continue
+		}
+		_, _, sgi := s.MetaClient.ShardOwner(id)
+		if sgi == nil {
+			continue
+		}
+		rpi, err := s.MetaClient.RetentionPolicy(sh.Database(), sh.RetentionPolicy())
+		if err != nil || rpi == nil {
+			continue
+		}
+
+		if interval := s.target(sh, rpi, sgi, now); interval > 0 {
+			s.rollupShard(ctx, sh, interval)
+		}
+	}
+}
+
+// target returns the interval the shard should be rolled up to, or zero if it
+// should be left as it is. Of the rules whose age the shard group has passed,
+// the one with the greatest age applies. A shard is only rolled up once it is
+// no longer being written.
+func (s *Service) target(sh *tsdb.Shard, rpi *meta.RetentionPolicyInfo, sgi *meta.ShardGroupInfo, now time.Time) time.Duration {
+	var rule *meta.RollupRuleInfo
+	age := now.Sub(sgi.EndTime)
+	for i := range rpi.RollupRules {
+		r := &rpi.RollupRules[i]
+		if age >= r.After && (rule == nil || r.After > rule.After) {
+			rule = r
+		}
+	}
+	if rule == nil || sh.RollupInterval() >= rule.Interval {
+		return 0
+	}
+
+	if isIdle, _ := sh.IsIdle(); !isIdle {
+		return 0
+	}
+	return rule.Interval
+}
+
+func (s *Service) rollupShard(ctx context.Context, sh *tsdb.Shard, interval time.Duration) {
+	log := s.logger.With(logger.Database(sh.Database()), logger.Shard(sh.ID()))
+	start := time.Now()
+
+	before, _ := sh.DiskSize()
+	if err := s.TSDBStore.RollupShard(ctx, sh.ID(), interval); err != nil {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Unable to roll up shard", logger.DurationLiteral("interval", interval), zap.Error(err))
+		}
+		return
+	}
+	after, _ := sh.DiskSize()
+
+	atomic.AddInt64(&s.stats.ShardsRolledUp, 1)
+	if before > after {
+		atomic.AddInt64(&s.stats.BytesReclaimed, before-after)
+	}
+	log.Info("Shard rolled up",
+		logger.DurationLiteral("interval", interval),
+		zap.Int64("bytes_before", before),
+		zap.Int64("bytes_after", after),
+		logger.DurationLiteral("duration", time.Since(start)))
+}
@@ -0,0 +1,95 @@
+package rollup_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/services/rollup"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestService_OpenDisabled(t *testing.T) {
+	// Opening a disabled service should be a no-op.
+	c := rollup.NewConfig()
+	c.Enabled = false
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() != "" {
+		t.Fatalf("service logged %q, didn't expect any logging", s.LogBuf.String())
+	}
+}
+
+func TestService_OpenClose(t *testing.T) {
+	s := NewService(rollup.NewConfig())
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() == "" {
+		t.Fatal("service didn't log anything on open")
+	}
+
+	// Reopening is a no-op
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-closing is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Enforce_MissingShard(t *testing.T) {
+	s := NewService(rollup.NewConfig())
+
+	s.TSDBStore.ShardIDsFn = func() []uint64 { return []uint64{1, 2} }
+	s.TSDBStore.ShardFn = func(id uint64) *tsdb.Shard { return nil }
+	s.TSDBStore.RollupShardFn = func(ctx context.Context, id uint64, interval time.Duration) error {
+		t.Fatalf("unexpected rollup of shard %d", id)
+		return nil
+	}
+
+	s.Enforce(context.Background())
+
+	stats := s.Statistics(nil)[0].Values
+	if got := stats["shardsRolledUp"]; got != int64(0) {
+		t.Fatalf("unexpected shards rolled up: %v", got)
+	}
+}
+
+type Service struct {
+	MetaClient *internal.MetaClientMock
+	TSDBStore  *internal.TSDBStoreMock
+
+	LogBuf bytes.Buffer
+	*rollup.Service
+}
+
+func NewService(c rollup.Config) *Service {
+	s := &Service{
+		MetaClient: &internal.MetaClientMock{},
+		TSDBStore:  &internal.TSDBStoreMock{},
+		Service:    rollup.NewService(c),
+	}
+
+	l := logger.New(&s.LogBuf)
+	s.WithLogger(l)
+
+	s.Service.MetaClient = s.MetaClient
+	s.Service.TSDBStore = s.TSDBStore
+	return s
+}
@@ -0,0 +1,88 @@
+package scrubber
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	// DefaultCheckInterval is the interval of time between scans for shards to verify.
+	DefaultCheckInterval = 30 * time.Minute
+
+	// DefaultRescrubInterval is the minimum amount of time before an unchanged
+	// shard is verified again.
+	DefaultRescrubInterval = 7 * 24 * time.Hour
+
+	// DefaultMaxThroughput is the rate limit in bytes per second for reading
+	// TSM data during verification.
+	DefaultMaxThroughput = 8 * 1024 * 1024
+
+	// DefaultMaxThroughputBurst is the maximum number of bytes read at once
+	// during verification.
+	DefaultMaxThroughputBurst = 8 * 1024 * 1024
+
+	// DefaultQuarantineCorrupt enables corrupt shard copies to be quarantined.
+	DefaultQuarantineCorrupt = true
+)
+
+// Config represents the configuration for the scrubber service.
+type Config struct {
+	Enabled            bool          `toml:"enabled"`
+	CheckInterval      toml.Duration `toml:"check-interval"`
+	RescrubInterval    toml.Duration `toml:"rescrub-interval"`
+	MaxThroughput      toml.Size     `toml:"max-throughput"`
+	MaxThroughputBurst toml.Size     `toml:"max-throughput-burst"`
+	QuarantineCorrupt  bool          `toml:"quarantine-corrupt"`
+}
+
+// NewConfig returns an instance of Config with defaults.
+func NewConfig() Config {
+	return Config{
+		Enabled:            false,
+		CheckInterval:      toml.Duration(DefaultCheckInterval),
+		RescrubInterval:    toml.Duration(DefaultRescrubInterval),
+		MaxThroughput:      toml.Size(DefaultMaxThroughput),
+		MaxThroughputBurst: toml.Size(DefaultMaxThroughputBurst),
+		QuarantineCorrupt:  DefaultQuarantineCorrupt,
+	}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.CheckInterval <= 0 {
+		return errors.New("check-interval must be positive")
+	}
+	if c.RescrubInterval < 0 {
+		return errors.New("rescrub-interval must not be negative")
+	}
+	if c.MaxThroughput > 0 && c.MaxThroughputBurst < c.MaxThroughput {
+		return errors.New("max-throughput-burst must be greater than or equal to max-throughput")
+	}
+
+	return nil
+}
+
+// Diagnostics returns a diagnostics representation of a subset of the Config.
+func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
+	if !c.Enabled {
+		return diagnostics.RowFromMap(map[string]interface{}{
+			"enabled": false,
+		}), nil
+	}
+
+	return diagnostics.RowFromMap(map[string]interface{}{
+		"enabled":              true,
+		"check-interval":       c.CheckInterval,
+		"rescrub-interval":     c.RescrubInterval,
+		"max-throughput":       c.MaxThroughput,
+		"max-throughput-burst": c.MaxThroughputBurst,
+		"quarantine-corrupt":   c.QuarantineCorrupt,
+	}), nil
+}
@@ -0,0 +1,66 @@
+package scrubber_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/scrubber"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c scrubber.Config
+	if _, err := toml.Decode(`
+enabled = true
+check-interval = "1m"
+rescrub-interval = "24h"
+max-throughput = "1m"
+max-throughput-burst = "2m"
+quarantine-corrupt = false
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if !c.Enabled {
+		t.Fatalf("unexpected enabled state: %v", c.Enabled)
+	} else if time.Duration(c.CheckInterval) != time.Minute {
+		t.Fatalf("unexpected check interval: %v", c.CheckInterval)
+	} else if time.Duration(c.RescrubInterval) != 24*time.Hour {
+		t.Fatalf("unexpected rescrub interval: %v", c.RescrubInterval)
+	} else if c.MaxThroughput != 1024*1024 {
+		t.Fatalf("unexpected max throughput: %v", c.MaxThroughput)
+	} else if c.MaxThroughputBurst != 2*1024*1024 {
+		t.Fatalf("unexpected max throughput burst: %v", c.MaxThroughputBurst)
+	} else if c.QuarantineCorrupt != false {
+		t.Fatalf("unexpected quarantine corrupt: %v", c.QuarantineCorrupt)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := scrubber.NewConfig()
+	c.Enabled = true
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from NewConfig: %s", err)
+	}
+
+	c = scrubber.NewConfig()
+	c.Enabled = true
+	c.CheckInterval = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for check-interval = 0, got nil")
+	}
+
+	c = scrubber.NewConfig()
+	c.Enabled = true
+	c.MaxThroughputBurst = c.MaxThroughput - 1
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for max-throughput-burst < max-throughput, got nil")
+	}
+
+	c.Enabled = false
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from disabled config: %s", err)
+	}
+}
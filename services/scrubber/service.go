@@ -0,0 +1,283 @@
+// Package scrubber provides a service that verifies the integrity of shard
+// data in the background while the node remains online.
+package scrubber // import "github.com/influxdata/influxdb/services/scrubber"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the scrubber service.
+const (
+	statShardsVerified      = "shardsVerified"
+	statSeriesFilesVerified = "seriesFilesVerified"
+	statBytesVerified       = "bytesVerified"
+	statCorruptShards       = "corruptShards"
+	statCorruptSeriesFiles  = "corruptSeriesFiles"
+	statQuarantined         = "quarantined"
+	statErrors              = "errors"
+)
+
+// Service represents the scrubber service.
+type Service struct {
+	MetaClient interface {
+		NodeID() uint64
+		ShardOwner(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
+		SetShardOwnerQuarantine(id, nodeID uint64, quarantined bool) error
+	}
+	TSDBStore interface {
+		ShardIDs() []uint64
+		Shard(id uint64) *tsdb.Shard
+	}
+
+	config Config
+	rate   limiter.Rate
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	// seriesFiles holds the last time each database's series file was verified.
+	seriesFiles map[string]time.Time
+
+	logger *zap.Logger
+	stats  *Statistics
+}
+
+// NewService returns a configured scrubber service.
+func NewService(c Config) *Service {
+	s := &Service{
+		config:      c,
+		seriesFiles: make(map[string]time.Time),
+		logger:      zap.NewNop(),
+		stats:       &Statistics{},
+	}
+	if c.MaxThroughput > 0 {
+		s.rate = limiter.NewRate(int(c.MaxThroughput), int(c.MaxThroughputBurst))
+	}
+	return s
+}
+
+// Open starts the scrubber.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting scrubber service",
+		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)),
+		logger.DurationLiteral("rescrub_interval", time.Duration(s.config.RescrubInterval)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the scrubber. A verification in progress is abandoned.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing scrubber service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "scrubber"))
+}
+
+// Statistics maintains the statistics for the scrubber service.
+type Statistics struct {
+	ShardsVerified      int64
+	SeriesFilesVerified int64
+	BytesVerified       int64
+	CorruptShards       int64
+	CorruptSeriesFiles  int64
+	Quarantined         int64
+	Errors              int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "scrubber",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statShardsVerified:      atomic.LoadInt64(&s.stats.ShardsVerified),
+			statSeriesFilesVerified: atomic.LoadInt64(&s.stats.SeriesFilesVerified),
+			statBytesVerified:       atomic.LoadInt64(&s.stats.BytesVerified),
+			statCorruptShards:       atomic.LoadInt64(&s.stats.CorruptShards),
+			statCorruptSeriesFiles:  atomic.LoadInt64(&s.stats.CorruptSeriesFiles),
+			statQuarantined:         atomic.LoadInt64(&s.stats.Quarantined),
+			statErrors:              atomic.LoadInt64(&s.stats.Errors),
+		},
+	}}
+}
+
+func (s *Service) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.config.CheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Scrub(ctx)
+		}
+	}
+}
+
+// Scrub runs a single verification pass over the local shards that are due
+// and the series files of their databases.
+func (s *Service) Scrub(ctx context.Context) {
+	nodeID := s.MetaClient.NodeID()
+	sfiles := make(map[string]*tsdb.SeriesFile)
+	for _, id := range s.TSDBStore.ShardIDs() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		sh := s.TSDBStore.Shard(id)
+		if sh == nil {
+			continue
+		}
+		if sfile, err := sh.SeriesFile(); err == nil {
+			sfiles[sh.Database()] = sfile
+		}
+		if !s.due(sh) {
+			continue
+		}
+		s.scrubShard(ctx, nodeID, sh)
+	}
+
+	for db, sfile := range sfiles {
+		if ctx.Err() != nil {
+			return
+		}
+		if last, ok := s.seriesFiles[db]; ok && time.Since(last) < time.Duration(s.config.RescrubInterval) {
+			continue
+		}
+		s.scrubSeriesFile(ctx, db, sfile)
+	}
+}
+
+// due returns true if the shard is cold and has changed, or has not been
+// verified within the rescrub interval.
+func (s *Service) due(sh *tsdb.Shard) bool {
+	if isIdle, _ := sh.IsIdle(); !isIdle {
+		return false
+	}
+
+	status := sh.VerifyStatus()
+	if status.VerifiedAt.IsZero() || sh.LastModified().After(status.VerifiedAt) {
+		return true
+	}
+	return time.Since(status.VerifiedAt) >= time.Duration(s.config.RescrubInterval)
+}
+
+func (s *Service) scrubShard(ctx context.Context, nodeID uint64, sh *tsdb.Shard) {
+	log := s.logger.With(logger.Database(sh.Database()), logger.Shard(sh.ID()))
+	start := time.Now()
+
+	stats, err := sh.Verify(ctx, s.rate)
+	atomic.AddInt64(&s.stats.BytesVerified, stats.Bytes)
+	if err != nil && !tsdb.IsCorruptionError(err) {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Unable to verify shard", zap.Error(err))
+		}
+		return
+	}
+	atomic.AddInt64(&s.stats.ShardsVerified, 1)
+
+	corrupt := err != nil
+	if corrupt {
+		atomic.AddInt64(&s.stats.CorruptShards, 1)
+		log.Error("Shard failed verification", zap.Error(err))
+	} else {
+		log.Info("Shard verified",
+			zap.Int64("files", stats.Files),
+			zap.Int64("blocks", stats.Blocks),
+			zap.Int64("bytes", stats.Bytes),
+			logger.DurationLiteral("duration", time.Since(start)))
+	}
+
+	// Quarantine a corrupt copy so reads go to another owner. A copy that
+	// verifies cleanly again, e.g. after being restored, is released.
+	if !s.config.QuarantineCorrupt || s.quarantined(nodeID, sh.ID()) == corrupt {
+		return
+	}
+	if err := s.MetaClient.SetShardOwnerQuarantine(sh.ID(), nodeID, corrupt); err != nil {
+		atomic.AddInt64(&s.stats.Errors, 1)
+		log.Warn("Unable to update shard quarantine", zap.Bool("quarantined", corrupt), zap.Error(err))
+		return
+	}
+	if corrupt {
+		atomic.AddInt64(&s.stats.Quarantined, 1)
+		log.Warn("Shard quarantined; repair it with copy-shard from a healthy owner")
+	} else {
+		log.Info("Shard released from quarantine")
+	}
+}
+
+// quarantined returns true if the node's copy of the shard is quarantined.
+func (s *Service) quarantined(nodeID, shardID uint64) bool {
+	_, _, sgi := s.MetaClient.ShardOwner(shardID)
+	if sgi == nil {
+		return false
+	}
+	for _, si := range sgi.Shards {
+		if si.ID == shardID {
+			return si.QuarantinedBy(nodeID)
+		}
+	}
+	return false
+}
+
+func (s *Service) scrubSeriesFile(ctx context.Context, db string, sfile *tsdb.SeriesFile) {
+	log := s.logger.With(logger.Database(db))
+
+	stats, err := sfile.Verify(ctx)
+	atomic.AddInt64(&s.stats.BytesVerified, stats.Bytes)
+	if err != nil && !tsdb.IsCorruptionError(err) {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Unable to verify series file", zap.Error(err))
+		}
+		return
+	}
+	atomic.AddInt64(&s.stats.SeriesFilesVerified, 1)
+	s.seriesFiles[db] = time.Now()
+
+	if err != nil {
+		atomic.AddInt64(&s.stats.CorruptSeriesFiles, 1)
+		log.Error("Series file failed verification", zap.Error(err))
+		return
+	}
+	log.Info("Series file verified", zap.Int64("entries", stats.Blocks))
+}
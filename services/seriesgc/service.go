@@ -0,0 +1,153 @@
+// Package seriesgc provides a service that removes series no shard references
+// from the series files of the local databases.
+package seriesgc // import "github.com/influxdata/influxdb/services/seriesgc"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the series garbage collection service.
+const (
+	statSeriesCollected = "seriesCollected"
+	statEntriesRemoved  = "entriesRemoved"
+	statBytesReclaimed  = "bytesReclaimed"
+	statErrors          = "errors"
+)
+
+// Service represents the series garbage collection service.
+type Service struct {
+	TSDBStore interface {
+		Databases() []string
+		CollectSeries(ctx context.Context, database string) (tsdb.SeriesGCStats, error)
+	}
+
+	config Config
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	logger *zap.Logger
+	stats  *Statistics
+}
+
+// NewService returns a configured series garbage collection service.
+func NewService(c Config) *Service {
+	return &Service{
+		config: c,
+		logger: zap.NewNop(),
+		stats:  &Statistics{},
+	}
+}
+
+// Open starts the service.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting series garbage collection service",
+		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the service. A collection in progress is abandoned.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing series garbage collection service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "seriesgc"))
+}
+
+// Statistics maintains the statistics for the series garbage collection service.
+type Statistics struct {
+	SeriesCollected int64
+	EntriesRemoved  int64
+	BytesReclaimed  int64
+	Errors          int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "seriesgc",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statSeriesCollected: atomic.LoadInt64(&s.stats.SeriesCollected),
+			statEntriesRemoved:  atomic.LoadInt64(&s.stats.EntriesRemoved),
+			statBytesReclaimed:  atomic.LoadInt64(&s.stats.BytesReclaimed),
+			statErrors:          atomic.LoadInt64(&s.stats.Errors),
+		},
+	}}
+}
+
+func (s *Service) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.config.CheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Collect(ctx)
+		}
+	}
+}
+
+// Collect runs a single garbage collection over the series file of every
+// local database.
+func (s *Service) Collect(ctx context.Context) {
+	for _, db := range s.TSDBStore.Databases() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		log, logEnd := logger.NewOperation(s.logger, "Series garbage collection", "series_gc", logger.Database(db))
+		stats, err := s.TSDBStore.CollectSeries(ctx, db)
+		atomic.AddInt64(&s.stats.SeriesCollected, stats.SeriesN)
+		atomic.AddInt64(&s.stats.EntriesRemoved, stats.EntryN)
+		atomic.AddInt64(&s.stats.BytesReclaimed, stats.Bytes)
+		if err != nil && ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Series garbage collection failed", zap.Error(err))
+		} else if err == nil {
+			log.Info("Series garbage collection complete",
+				zap.Int64("series", stats.SeriesN),
+				zap.Int64("entries", stats.EntryN),
+				zap.Int64("bytes", stats.Bytes))
+		}
+		logEnd()
+	}
+}
@@ -0,0 +1,105 @@
+package seriesgc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/services/seriesgc"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestService_OpenDisabled(t *testing.T) {
+	// Opening a disabled service should be a no-op.
+	c := seriesgc.NewConfig()
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() != "" {
+		t.Fatalf("service logged %q, didn't expect any logging", s.LogBuf.String())
+	}
+}
+
+func TestService_OpenClose(t *testing.T) {
+	c := seriesgc.NewConfig()
+	c.Enabled = true
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() == "" {
+		t.Fatal("service didn't log anything on open")
+	}
+
+	// Reopening is a no-op
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-closing is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Collect(t *testing.T) {
+	c := seriesgc.NewConfig()
+	c.Enabled = true
+	s := NewService(c)
+
+	var collected []string
+	s.TSDBStore.DatabasesFn = func() []string { return []string{"db0", "db1"} }
+	s.TSDBStore.CollectSeriesFn = func(ctx context.Context, database string) (tsdb.SeriesGCStats, error) {
+		collected = append(collected, database)
+		if database == "db1" {
+			return tsdb.SeriesGCStats{}, errors.New("marker")
+		}
+		return tsdb.SeriesGCStats{SeriesN: 2, EntryN: 3, Bytes: 100}, nil
+	}
+
+	s.Collect(context.Background())
+
+	if len(collected) != 2 {
+		t.Fatalf("unexpected databases collected: %v", collected)
+	}
+	stats := s.Statistics(nil)[0].Values
+	if got := stats["seriesCollected"]; got != int64(2) {
+		t.Fatalf("unexpected series collected: %v", got)
+	} else if got := stats["bytesReclaimed"]; got != int64(100) {
+		t.Fatalf("unexpected bytes reclaimed: %v", got)
+	} else if got := stats["errors"]; got != int64(1) {
+		t.Fatalf("unexpected errors: %v", got)
+	}
+}
+
+type Service struct {
+	TSDBStore *internal.TSDBStoreMock
+
+	LogBuf bytes.Buffer
+	*seriesgc.Service
+}
+
+func NewService(c seriesgc.Config) *Service {
+	s := &Service{
+		TSDBStore: &internal.TSDBStoreMock{},
+		Service:   seriesgc.NewService(c),
+	}
+
+	l := logger.New(&s.LogBuf)
+	s.WithLogger(l)
+
+	s.Service.TSDBStore = s.TSDBStore
+	return s
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/services/meta"
 	"github.com/influxdata/influxdb/tsdb"
 	"go.uber.org/zap"
@@ -49,6 +50,9 @@ type Service struct {
 
 	TSDBStore interface {
 		BackupShard(id uint64, since time.Time, w io.Writer) error
+		BackupShardSnapshot(snapshotID, shardID uint64, since time.Time, w io.Writer) error
+		CreateDatabaseSnapshot(database string) (*tsdb.DatabaseSnapshot, error)
+		ReleaseDatabaseSnapshot(id uint64) error
 		ExportShard(id uint64, ExportStart time.Time, ExportEnd time.Time, w io.Writer) error
 		Shard(id uint64) *tsdb.Shard
 		ShardRelativePath(id uint64) (string, error)
@@ -139,6 +143,9 @@ func (s *Service) handleConn(conn net.Conn) error {
 
 	switch RequestType(typ[0]) {
 	case RequestShardBackup:
+		if r.SnapshotID != 0 {
+			return s.TSDBStore.BackupShardSnapshot(r.SnapshotID, r.ShardID, r.Since, conn)
+		}
 		if err := s.TSDBStore.BackupShard(r.ShardID, r.Since, conn); err != nil {
 			return err
 		}
@@ -154,6 +161,10 @@ func (s *Service) handleConn(conn net.Conn) error {
 		return s.writeDatabaseInfo(conn, r.BackupDatabase)
 	case RequestRetentionPolicyInfo:
 		return s.writeRetentionPolicyInfo(conn, r.BackupDatabase, r.BackupRetentionPolicy)
+	case RequestDatabaseSnapshot:
+		return s.writeDatabaseSnapshot(conn, r.BackupDatabase)
+	case RequestDatabaseSnapshotRelease:
+		return s.releaseDatabaseSnapshot(conn, r.SnapshotID)
 	case RequestMetaStoreUpdate:
 		return s.updateMetaStore(conn, bytes, r.BackupDatabase, r.RestoreDatabase, r.BackupRetentionPolicy, r.RestoreRetentionPolicy)
 	default:
@@ -343,6 +354,44 @@ func (s *Service) writeDatabaseInfo(conn net.Conn, database string) error {
 	return nil
 }
 
+// writeDatabaseSnapshot creates a point-in-time snapshot of the shards of the
+// database on this server and writes its ID and the relative paths of its
+// shards into the connection. The shards are then backed up by passing the
+// snapshot ID with RequestShardBackup, until the snapshot is released.
+func (s *Service) writeDatabaseSnapshot(conn net.Conn, database string) error {
+	if database == "" {
+		return fmt.Errorf("database required to create a snapshot")
+	} else if s.MetaClient.Database(database) == nil {
+		return influxdb.ErrDatabaseNotFound(database)
+	}
+
+	snap, err := s.TSDBStore.CreateDatabaseSnapshot(database)
+	if err != nil {
+		return err
+	}
+	s.Logger.Info("Created database snapshot", zap.Uint64("snapshot", snap.ID), logger.Database(database))
+
+	res := Response{SnapshotID: snap.ID, Paths: snap.RelativePaths()}
+	if err := json.NewEncoder(conn).Encode(res); err != nil {
+		return fmt.Errorf("encode response: %s", err.Error())
+	}
+	return nil
+}
+
+// releaseDatabaseSnapshot releases a database snapshot and writes an empty
+// response into the connection.
+func (s *Service) releaseDatabaseSnapshot(conn net.Conn, id uint64) error {
+	if err := s.TSDBStore.ReleaseDatabaseSnapshot(id); err != nil {
+		return err
+	}
+	s.Logger.Info("Released database snapshot", zap.Uint64("snapshot", id))
+
+	if err := json.NewEncoder(conn).Encode(Response{SnapshotID: id}); err != nil {
+		return fmt.Errorf("encode response: %s", err.Error())
+	}
+	return nil
+}
+
 // writeDatabaseInfo will write the relative paths of all shards in the retention policy on
 // this server into the connection
 func (s *Service) writeRetentionPolicyInfo(conn net.Conn, database, retentionPolicy string) error {
@@ -468,6 +517,14 @@ const (
 	// RequestShardUpdate will initiate the upload of a shard data tar file
 	// and have the engine import the data.
 	RequestShardUpdate
+
+	// RequestDatabaseSnapshot represents a request to create a point-in-time
+	// snapshot of the shards of a database.
+	RequestDatabaseSnapshot
+
+	// RequestDatabaseSnapshotRelease represents a request to release a
+	// database snapshot.
+	RequestDatabaseSnapshotRelease
 )
 
 // Request represents a request for a specific backup or for information
@@ -483,10 +540,13 @@ type Request struct {
 	ExportStart            time.Time
 	ExportEnd              time.Time
 	UploadSize             int64
+	SnapshotID             uint64
 }
 
 // Response contains the relative paths for all the shards on this server
-// that are in the requested database or retention policy.
+// that are in the requested database or retention policy, and the ID of the
+// snapshot holding them for database snapshots.
 type Response struct {
-	Paths []string
+	SnapshotID uint64 `json:",omitempty"`
+	Paths      []string
 }
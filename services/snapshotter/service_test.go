@@ -158,6 +158,68 @@ func TestSnapshotter_RequestShardBackup(t *testing.T) {
 	}
 }
 
+func TestSnapshotter_RequestShardBackup_Snapshot(t *testing.T) {
+	s, l, err := NewTestService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	released := make(chan uint64, 1)
+	var tsdbStore internal.TSDBStoreMock
+	tsdbStore.BackupShardSnapshotFn = func(snapshotID, shardID uint64, since time.Time, w io.Writer) error {
+		if snapshotID != 10 || shardID != 5 {
+			t.Errorf("unexpected snapshot/shard: got=%d/%d want=10/5", snapshotID, shardID)
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+		return nil
+	}
+	tsdbStore.ReleaseDatabaseSnapshotFn = func(id uint64) error {
+		released <- id
+		return nil
+	}
+	s.TSDBStore = &tsdbStore
+
+	if err := s.Open(); err != nil {
+		t.Fatalf("unexpected open error: %s", err)
+	}
+	defer s.Close()
+
+	request := func(req snapshotter.Request) []byte {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		defer conn.Close()
+
+		conn.Write([]byte{snapshotter.MuxHeader})
+		conn.Write([]byte{byte(req.Type)})
+		if err := json.NewEncoder(conn).Encode(&req); err != nil {
+			t.Fatalf("unable to encode request: %s", err)
+		}
+		out, err := io.ReadAll(conn)
+		if err != nil {
+			t.Fatalf("unexpected error reading response: %s", err)
+		}
+		return out
+	}
+
+	out := request(snapshotter.Request{Type: snapshotter.RequestShardBackup, ShardID: 5, SnapshotID: 10})
+	if got, want := string(out), `{"status":"ok"}`; got != want {
+		t.Errorf("unexpected shard data: got=%#v want=%#v", got, want)
+	}
+
+	var resp snapshotter.Response
+	out = request(snapshotter.Request{Type: snapshotter.RequestDatabaseSnapshotRelease, SnapshotID: 10})
+	if err := json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("unable to decode response: %s", err)
+	} else if resp.SnapshotID != 10 {
+		t.Errorf("unexpected snapshot id: got=%d want=10", resp.SnapshotID)
+	} else if id := <-released; id != 10 {
+		t.Errorf("unexpected released snapshot: got=%d want=10", id)
+	}
+}
+
 func TestSnapshotter_RequestMetastoreBackup(t *testing.T) {
 	s, l, err := NewTestService()
 	if err != nil {
@@ -207,6 +207,54 @@ func (s *Store) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest)
 	return rs, nil
 }
 
+func (s *Store) ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	if req.ReadSource == nil {
+		return nil, errors.New("missing read source")
+	}
+
+	source, err := GetReadSource(*req.ReadSource)
+	if err != nil {
+		return nil, err
+	}
+
+	database, rp, start, end, err := s.validateArgs(source.Database, source.RetentionPolicy, req.Range.Start, req.Range.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var shardIDs []uint64
+	if sIDs, ok := ctx.Value(coordinator.ShardIDsKey).([]uint64); ok {
+		shardIDs = sIDs
+	} else {
+		shardIDs, err = s.findShardIDs(database, rp, false, start, end)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(shardIDs) == 0 {
+		return nil, nil
+	}
+
+	var cur reads.SeriesCursor
+	if ic, err := newIndexSeriesCursor(ctx, req.Predicate, s.TSDBStore.Shards(shardIDs)); err != nil {
+		return nil, err
+	} else if ic == nil {
+		return nil, nil
+	} else {
+		cur = ic
+	}
+
+	req.Range.Start = start
+	req.Range.End = end
+
+	rs, err := reads.NewWindowAggregateResultSet(ctx, req, cur)
+	if err != nil {
+		cur.Close()
+		return nil, err
+	}
+	return rs, nil
+}
+
 func (s *Store) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
 	if req.TagsSource == nil {
 		return nil, errors.New("missing read source")
@@ -489,6 +537,51 @@ func (s *ClusterStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilter
 	return shards.ReadFilter(ctx, req)
 }
 
+func (s *ClusterStore) ReadWindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	if req.ReadSource == nil {
+		return nil, errors.New("missing read source")
+	}
+
+	source, err := GetReadSource(*req.ReadSource)
+	if err != nil {
+		return nil, err
+	}
+
+	database, rp, start, end, err := s.validateArgs(source.Database, source.RetentionPolicy, req.Range.Start, req.Range.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeID uint64
+	if opts := ReadOptionsFromContext(ctx); opts != nil {
+		nodeID = opts.NodeID
+	}
+
+	req.Range.Start = start
+	req.Range.End = end
+
+	cursorFn := func(ctx context.Context, shardIDs []uint64) (reads.SeriesCursor, error) {
+		var cur reads.SeriesCursor
+		if ic, err := newIndexSeriesCursor(ctx, req.Predicate, s.TSDBStore.Shards(shardIDs)); err != nil {
+			return nil, err
+		} else if ic == nil {
+			return nil, nil
+		} else {
+			cur = ic
+		}
+		return cur, nil
+	}
+
+	shards, err := s.StoreMapper.MapShards(database, rp, start, end, nodeID, cursorFn)
+	if err != nil {
+		return nil, err
+	} else if shards == nil {
+		return nil, nil
+	}
+
+	return shards.ReadWindowAggregate(ctx, req)
+}
+
 func (s *ClusterStore) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error) {
 	if req.ReadSource == nil {
 		return nil, errors.New("missing read source")
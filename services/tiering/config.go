@@ -0,0 +1,128 @@
+package tiering
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	// DefaultCheckInterval is the interval of time between scans for shards to move.
+	DefaultCheckInterval = 30 * time.Minute
+
+	// DefaultMaxThroughput is the rate limit in bytes per second for copying
+	// shard data to another tier.
+	DefaultMaxThroughput = 16 * 1024 * 1024
+
+	// DefaultMaxThroughputBurst is the maximum number of bytes copied at once
+	// when moving shard data to another tier.
+	DefaultMaxThroughputBurst = 16 * 1024 * 1024
+)
+
+// Policy selects the shards of a retention policy that are moved to the cold
+// tier or offloaded to object storage. An empty database or retention policy
+// matches any. A zero duration disables that tier.
+type Policy struct {
+	Database        string        `toml:"database"`
+	RetentionPolicy string        `toml:"retention-policy"`
+	ColdAfter       toml.Duration `toml:"cold-after"`
+	OffloadAfter    toml.Duration `toml:"offload-after"`
+}
+
+// Matches returns true if the policy applies to the retention policy.
+func (p Policy) Matches(database, retentionPolicy string) bool {
+	return (p.Database == "" || p.Database == database) &&
+		(p.RetentionPolicy == "" || p.RetentionPolicy == retentionPolicy)
+}
+
+// Config represents the configuration for the tiering service.
+type Config struct {
+	Enabled            bool          `toml:"enabled"`
+	CheckInterval      toml.Duration `toml:"check-interval"`
+	MaxThroughput      toml.Size     `toml:"max-throughput"`
+	MaxThroughputBurst toml.Size     `toml:"max-throughput-burst"`
+	Policies           []Policy      `toml:"policy"`
+}
+
+// NewConfig returns an instance of Config with defaults.
+func NewConfig() Config {
+	return Config{
+		Enabled:            false,
+		CheckInterval:      toml.Duration(DefaultCheckInterval),
+		MaxThroughput:      toml.Size(DefaultMaxThroughput),
+		MaxThroughputBurst: toml.Size(DefaultMaxThroughputBurst),
+	}
+}
+
+// Validate returns an error if the Config is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.CheckInterval <= 0 {
+		return errors.New("check-interval must be positive")
+	}
+	if c.MaxThroughput > 0 && c.MaxThroughputBurst < c.MaxThroughput {
+		return errors.New("max-throughput-burst must be greater than or equal to max-throughput")
+	}
+	for i, p := range c.Policies {
+		if p.ColdAfter < 0 || p.OffloadAfter < 0 {
+			return fmt.Errorf("policy %d: cold-after and offload-after must not be negative", i)
+		} else if p.ColdAfter == 0 && p.OffloadAfter == 0 {
+			return fmt.Errorf("policy %d: cold-after or offload-after must be set", i)
+		}
+	}
+
+	return nil
+}
+
+// Policy returns the first policy matching the retention policy, or nil.
+func (c Config) Policy(database, retentionPolicy string) *Policy {
+	for i := range c.Policies {
+		if c.Policies[i].Matches(database, retentionPolicy) {
+			return &c.Policies[i]
+		}
+	}
+	return nil
+}
+
+// ColdTier returns true if any policy moves shards to the cold tier.
+func (c Config) ColdTier() bool {
+	for _, p := range c.Policies {
+		if p.ColdAfter > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoteTier returns true if any policy offloads shards to object storage.
+func (c Config) RemoteTier() bool {
+	for _, p := range c.Policies {
+		if p.OffloadAfter > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagnostics returns a diagnostics representation of a subset of the Config.
+func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
+	if !c.Enabled {
+		return diagnostics.RowFromMap(map[string]interface{}{
+			"enabled": false,
+		}), nil
+	}
+
+	return diagnostics.RowFromMap(map[string]interface{}{
+		"enabled":              true,
+		"check-interval":       c.CheckInterval,
+		"max-throughput":       c.MaxThroughput,
+		"max-throughput-burst": c.MaxThroughputBurst,
+		"policies":             len(c.Policies),
+	}), nil
+}
@@ -0,0 +1,84 @@
+package tiering_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/tiering"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c tiering.Config
+	if _, err := toml.Decode(`
+enabled = true
+check-interval = "1m"
+max-throughput = "1m"
+max-throughput-burst = "2m"
+
+[[policy]]
+database = "db0"
+retention-policy = "rp0"
+cold-after = "720h"
+
+[[policy]]
+cold-after = "2160h"
+offload-after = "8760h"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if !c.Enabled {
+		t.Fatalf("unexpected enabled state: %v", c.Enabled)
+	} else if time.Duration(c.CheckInterval) != time.Minute {
+		t.Fatalf("unexpected check interval: %v", c.CheckInterval)
+	} else if c.MaxThroughput != 1024*1024 {
+		t.Fatalf("unexpected max throughput: %v", c.MaxThroughput)
+	} else if c.MaxThroughputBurst != 2*1024*1024 {
+		t.Fatalf("unexpected max throughput burst: %v", c.MaxThroughputBurst)
+	} else if len(c.Policies) != 2 {
+		t.Fatalf("unexpected policies: %v", c.Policies)
+	}
+
+	if p := c.Policy("db0", "rp0"); p == nil || time.Duration(p.ColdAfter) != 720*time.Hour {
+		t.Fatalf("unexpected policy for db0.rp0: %v", p)
+	} else if p := c.Policy("db1", "autogen"); p == nil || time.Duration(p.ColdAfter) != 2160*time.Hour || time.Duration(p.OffloadAfter) != 8760*time.Hour {
+		t.Fatalf("unexpected policy for db1.autogen: %v", p)
+	} else if !c.ColdTier() || !c.RemoteTier() {
+		t.Fatal("expected cold and remote tiers to be used")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := tiering.NewConfig()
+	c.Enabled = true
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from NewConfig: %s", err)
+	}
+
+	c = tiering.NewConfig()
+	c.Enabled = true
+	c.CheckInterval = 0
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for check-interval = 0, got nil")
+	}
+
+	c = tiering.NewConfig()
+	c.Enabled = true
+	c.Policies = []tiering.Policy{{Database: "db0"}}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for cold-after = 0, got nil")
+	}
+
+	c.Policies = []tiering.Policy{{Database: "db0", OffloadAfter: -1}}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected error for offload-after < 0, got nil")
+	}
+
+	c.Enabled = false
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation fail from disabled config: %s", err)
+	}
+}
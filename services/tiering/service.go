@@ -0,0 +1,280 @@
+// Package tiering provides a service that moves cold shards to a secondary
+// data directory on slower storage, and offloads them to object storage.
+package tiering // import "github.com/influxdata/influxdb/services/tiering"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/influxdata/influxdb/services/meta"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// Statistics for the tiering service.
+const (
+	statShardsMoved     = "shardsMoved"
+	statBytesMoved      = "bytesMoved"
+	statShardsOffloaded = "shardsOffloaded"
+	statBytesOffloaded  = "bytesOffloaded"
+	statErrors          = "errors"
+)
+
+// Service represents the tiering service.
+type Service struct {
+	MetaClient interface {
+		NodeID() uint64
+		ShardOwner(shardID uint64) (database, policy string, sgi *meta.ShardGroupInfo)
+		SetShardOwnerTier(id, nodeID uint64, tier string) error
+	}
+	TSDBStore interface {
+		ShardIDs() []uint64
+		Shard(id uint64) *tsdb.Shard
+		MoveShard(ctx context.Context, id uint64, tier string, rate limiter.Rate) error
+		OffloadShard(ctx context.Context, id uint64) error
+	}
+
+	config Config
+	rate   limiter.Rate
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	logger *zap.Logger
+	stats  *Statistics
+}
+
+// NewService returns a configured tiering service.
+func NewService(c Config) *Service {
+	s := &Service{
+		config: c,
+		logger: zap.NewNop(),
+		stats:  &Statistics{},
+	}
+	if c.MaxThroughput > 0 {
+		s.rate = limiter.NewRate(int(c.MaxThroughput), int(c.MaxThroughputBurst))
+	}
+	return s
+}
+
+// Open starts the tiering service.
+func (s *Service) Open() error {
+	if !s.config.Enabled || s.done != nil {
+		return nil
+	}
+
+	s.logger.Info("Starting tiering service",
+		logger.DurationLiteral("check_interval", time.Duration(s.config.CheckInterval)),
+		zap.Int("policies", len(s.config.Policies)))
+	s.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.run() }()
+	return nil
+}
+
+// Close stops the tiering service. A move in progress is abandoned and the
+// shard is left on its original tier.
+func (s *Service) Close() error {
+	if !s.config.Enabled || s.done == nil {
+		return nil
+	}
+
+	s.logger.Info("Closing tiering service")
+	close(s.done)
+
+	s.wg.Wait()
+	s.done = nil
+
+	return nil
+}
+
+// WithLogger sets the logger on the service.
+func (s *Service) WithLogger(log *zap.Logger) {
+	s.logger = log.With(zap.String("service", "tiering"))
+}
+
+// Statistics maintains the statistics for the tiering service.
+type Statistics struct {
+	ShardsMoved     int64
+	BytesMoved      int64
+	ShardsOffloaded int64
+	BytesOffloaded  int64
+	Errors          int64
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (s *Service) Statistics(tags map[string]string) []models.Statistic {
+	return []models.Statistic{{
+		Name: "tiering",
+		Tags: tags,
+		Values: map[string]interface{}{
+			statShardsMoved:     atomic.LoadInt64(&s.stats.ShardsMoved),
+			statBytesMoved:      atomic.LoadInt64(&s.stats.BytesMoved),
+			statShardsOffloaded: atomic.LoadInt64(&s.stats.ShardsOffloaded),
+			statBytesOffloaded:  atomic.LoadInt64(&s.stats.BytesOffloaded),
+			statErrors:          atomic.LoadInt64(&s.stats.Errors),
+		},
+	}}
+}
+
+func (s *Service) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(s.config.CheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.Enforce(ctx)
+		}
+	}
+}
+
+// Enforce runs a single pass over the local shards, moving those that are cold
+// under their retention policy's tiering policy to the cold tier or offloading
+// them to object storage, one at a time. The tier of each local shard is also
+// recorded in the meta store so it is visible in SHOW SHARDS.
+//
+// Offloaded shards are skipped unless they are in the store's offload cache.
+func (s *Service) Enforce(ctx context.Context) {
+	nodeID := s.MetaClient.NodeID()
+	now := time.Now().UTC()
+	for _, id := range s.TSDBStore.ShardIDs() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		sh := s.TSDBStore.Shard(id)
+		if sh == nil {
+			continue
+		}
+		_, _, sgi := s.MetaClient.ShardOwner(id)
+		if sgi == nil {
+			continue
+		}
+
+		tier := sh.Tier()
+		switch s.target(sh, sgi, now) {
+		case tsdb.TierRemote:
+			if s.offloadShard(ctx, sh) {
+				tier = tsdb.TierRemote
+			}
+		case tsdb.TierCold:
+			if s.moveShard(ctx, sh) {
+				tier = tsdb.TierCold
+			}
+		}
+		s.recordTier(nodeID, id, sgi, tier)
+	}
+}
+
+// target returns the tier the shard should be moved to under the matching
+// policy, or an empty string if it should stay where it is. A shard is only
+// moved once its group ended longer ago than the policy's duration for the
+// tier and it is no longer being written.
+func (s *Service) target(sh *tsdb.Shard, sgi *meta.ShardGroupInfo, now time.Time) string {
+	p := s.config.Policy(sh.Database(), sh.RetentionPolicy())
+	if p == nil {
+		return ""
+	}
+
+	var tier string
+	age := now.Sub(sgi.EndTime)
+	switch current := sh.Tier(); {
+	case current == tsdb.TierRemote:
+		return ""
+	case p.OffloadAfter > 0 && age >= time.Duration(p.OffloadAfter):
+		tier = tsdb.TierRemote
+	case current == tsdb.TierHot && p.ColdAfter > 0 && age >= time.Duration(p.ColdAfter):
+		tier = tsdb.TierCold
+	default:
+		return ""
+	}
+
+	if isIdle, _ := sh.IsIdle(); !isIdle {
+		return ""
+	}
+	return tier
+}
+
+func (s *Service) offloadShard(ctx context.Context, sh *tsdb.Shard) bool {
+	log := s.logger.With(logger.Database(sh.Database()), logger.Shard(sh.ID()))
+	start := time.Now()
+
+	size, _ := sh.DiskSize()
+	if err := s.TSDBStore.OffloadShard(ctx, sh.ID()); errors.Is(err, tsdb.ErrShardModified) {
+		log.Info("Shard modified while offloading, will retry")
+		return false
+	} else if err != nil {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Unable to offload shard", zap.Error(err))
+		}
+		return false
+	}
+
+	atomic.AddInt64(&s.stats.ShardsOffloaded, 1)
+	atomic.AddInt64(&s.stats.BytesOffloaded, size)
+	log.Info("Shard offloaded to object storage",
+		zap.Int64("bytes", size),
+		logger.DurationLiteral("duration", time.Since(start)))
+	return true
+}
+
+func (s *Service) moveShard(ctx context.Context, sh *tsdb.Shard) bool {
+	log := s.logger.With(logger.Database(sh.Database()), logger.Shard(sh.ID()))
+	start := time.Now()
+
+	size, _ := sh.DiskSize()
+	if err := s.TSDBStore.MoveShard(ctx, sh.ID(), tsdb.TierCold, s.rate); errors.Is(err, tsdb.ErrShardModified) {
+		log.Info("Shard modified while moving to cold tier, will retry")
+		return false
+	} else if err != nil {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			log.Warn("Unable to move shard to cold tier", zap.Error(err))
+		}
+		return false
+	}
+
+	atomic.AddInt64(&s.stats.ShardsMoved, 1)
+	atomic.AddInt64(&s.stats.BytesMoved, size)
+	log.Info("Shard moved to cold tier",
+		zap.Int64("bytes", size),
+		logger.DurationLiteral("duration", time.Since(start)))
+	return true
+}
+
+// recordTier updates the meta store if it does not have the current tier of
+// the node's copy of the shard. The primary tier is recorded as empty.
+func (s *Service) recordTier(nodeID, id uint64, sgi *meta.ShardGroupInfo, tier string) {
+	if tier == tsdb.TierHot {
+		tier = ""
+	}
+
+	for _, si := range sgi.Shards {
+		if si.ID != id || !si.OwnedBy(nodeID) || si.TierOf(nodeID) == tier {
+			continue
+		}
+		if err := s.MetaClient.SetShardOwnerTier(id, nodeID, tier); err != nil {
+			atomic.AddInt64(&s.stats.Errors, 1)
+			s.logger.Warn("Unable to record shard tier", logger.Shard(id), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tiering_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/internal"
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/influxdata/influxdb/services/tiering"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestService_OpenDisabled(t *testing.T) {
+	// Opening a disabled service should be a no-op.
+	c := tiering.NewConfig()
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() != "" {
+		t.Fatalf("service logged %q, didn't expect any logging", s.LogBuf.String())
+	}
+}
+
+func TestService_OpenClose(t *testing.T) {
+	c := tiering.NewConfig()
+	c.Enabled = true
+	s := NewService(c)
+
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.LogBuf.String() == "" {
+		t.Fatal("service didn't log anything on open")
+	}
+
+	// Reopening is a no-op
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-closing is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestService_Enforce_MissingShard(t *testing.T) {
+	c := tiering.NewConfig()
+	c.Enabled = true
+	s := NewService(c)
+
+	s.MetaClient.NodeIDFn = func() uint64 { return 1 }
+	s.TSDBStore.ShardIDsFn = func() []uint64 { return []uint64{1, 2} }
+	s.TSDBStore.ShardFn = func(id uint64) *tsdb.Shard { return nil }
+	s.TSDBStore.MoveShardFn = func(ctx context.Context, id uint64, tier string, rate limiter.Rate) error {
+		t.Fatalf("unexpected move of shard %d", id)
+		return nil
+	}
+
+	s.Enforce(context.Background())
+
+	stats := s.Statistics(nil)[0].Values
+	if got := stats["shardsMoved"]; got != int64(0) {
+		t.Fatalf("unexpected shards moved: %v", got)
+	}
+}
+
+type Service struct {
+	MetaClient *internal.MetaClientMock
+	TSDBStore  *internal.TSDBStoreMock
+
+	LogBuf bytes.Buffer
+	*tiering.Service
+}
+
+func NewService(c tiering.Config) *Service {
+	s := &Service{
+		MetaClient: &internal.MetaClientMock{},
+		TSDBStore:  &internal.TSDBStoreMock{},
+		Service:    tiering.NewService(c),
+	}
+
+	l := logger.New(&s.LogBuf)
+	s.WithLogger(l)
+
+	s.Service.MetaClient = s.MetaClient
+	s.Service.TSDBStore = s.TSDBStore
+	return s
+}
@@ -0,0 +1,168 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/influxdata/influxdb/monitor/diagnostics"
+)
+
+// Actions of a transformation rule.
+const (
+	// ActionDropTag removes the tag key.
+	ActionDropTag = "drop-tag"
+
+	// ActionRenameTag renames the tag key to new-key.
+	ActionRenameTag = "rename-tag"
+
+	// ActionDropField removes the field key. Points left without fields are dropped.
+	ActionDropField = "drop-field"
+
+	// ActionRenameField renames the field key to new-key.
+	ActionRenameField = "rename-field"
+
+	// ActionRenameMeasurement renames the measurements matching measurement
+	// to new-key.
+	ActionRenameMeasurement = "rename-measurement"
+
+	// ActionRelabel sets the tag new-key to replacement, expanded with the
+	// submatches of regex, when regex matches the value of the tag key.
+	ActionRelabel = "relabel"
+
+	// ActionDropSeries drops the points of the series whose tag key matches
+	// regex, or all the points of the matching measurements without key.
+	ActionDropSeries = "drop-series"
+
+	// ActionCastField converts the values of the field key to type.
+	ActionCastField = "cast-field"
+)
+
+// DefaultReplacement is the default replacement of a relabel rule.
+const DefaultReplacement = "$1"
+
+// Config represents a transformation rule applied to the points written to
+// a database.
+type Config struct {
+	// Name identifies the rule in the statistics.
+	Name string `toml:"name"`
+
+	// Database restricts the rule to a database. All databases if empty.
+	Database string `toml:"database"`
+
+	// Measurement restricts the rule to the measurements fully matching the
+	// regular expression. All measurements if empty.
+	Measurement string `toml:"measurement"`
+
+	Action      string `toml:"action"`
+	Key         string `toml:"key"`
+	NewKey      string `toml:"new-key"`
+	Regex       string `toml:"regex"`
+	Replacement string `toml:"replacement"`
+	Type        string `toml:"type"`
+}
+
+// WithDefaults takes the given config and returns a new config with any required
+// default values set.
+func (c *Config) WithDefaults() *Config {
+	d := *c
+	if d.Action == ActionRelabel {
+		if d.NewKey == "" {
+			d.NewKey = d.Key
+		}
+		if d.Replacement == "" {
+			d.Replacement = DefaultReplacement
+		}
+	}
+	return &d
+}
+
+// Validate validates the config's settings.
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("transform name must be specified")
+	}
+
+	if c.Measurement != "" {
+		if _, err := regexp.Compile(c.Measurement); err != nil {
+			return fmt.Errorf("transform %q: invalid measurement: %s", c.Name, err)
+		}
+	}
+	if c.Regex != "" {
+		if _, err := regexp.Compile(c.Regex); err != nil {
+			return fmt.Errorf("transform %q: invalid regex: %s", c.Name, err)
+		}
+	}
+
+	switch c.Action {
+	case ActionDropTag, ActionDropField:
+		if c.Key == "" {
+			return fmt.Errorf("transform %q: %s requires a key", c.Name, c.Action)
+		}
+	case ActionRenameTag, ActionRenameField:
+		if c.Key == "" || c.NewKey == "" {
+			return fmt.Errorf("transform %q: %s requires a key and a new-key", c.Name, c.Action)
+		}
+	case ActionRenameMeasurement:
+		if c.Measurement == "" || c.NewKey == "" {
+			return fmt.Errorf("transform %q: %s requires a measurement and a new-key", c.Name, c.Action)
+		}
+	case ActionRelabel:
+		if c.Key == "" || c.Regex == "" {
+			return fmt.Errorf("transform %q: %s requires a key and a regex", c.Name, c.Action)
+		}
+	case ActionDropSeries:
+		if (c.Key == "") != (c.Regex == "") {
+			return fmt.Errorf("transform %q: %s requires both a key and a regex, or neither", c.Name, c.Action)
+		} else if c.Key == "" && c.Measurement == "" {
+			return fmt.Errorf("transform %q: %s requires a measurement or a key", c.Name, c.Action)
+		}
+	case ActionCastField:
+		if c.Key == "" {
+			return fmt.Errorf("transform %q: %s requires a key", c.Name, c.Action)
+		}
+		if _, ok := castTypes[c.Type]; !ok {
+			return fmt.Errorf("transform %q: unrecognized type %q", c.Name, c.Type)
+		}
+	default:
+		return fmt.Errorf("transform %q: unrecognized action %q", c.Name, c.Action)
+	}
+	return nil
+}
+
+// Configs wraps a slice of Config to aggregate diagnostics.
+type Configs []Config
+
+// Validate validates the rules and checks that their names are unique.
+func (c Configs) Validate() error {
+	seen := make(map[string]struct{}, len(c))
+	for _, cc := range c {
+		if err := cc.Validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[cc.Name]; ok {
+			return fmt.Errorf("duplicate transform %q", cc.Name)
+		}
+		seen[cc.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Enabled returns true if any rule is configured.
+func (c Configs) Enabled() bool {
+	return len(c) > 0
+}
+
+// Diagnostics returns one set of diagnostics for all of the Configs.
+func (c Configs) Diagnostics() (*diagnostics.Diagnostics, error) {
+	d := &diagnostics.Diagnostics{
+		Columns: []string{"name", "database", "measurement", "action", "key", "new-key", "regex", "replacement", "type"},
+	}
+
+	for _, cc := range c {
+		cc = *cc.WithDefaults()
+		d.AddRow([]interface{}{cc.Name, cc.Database, cc.Measurement, cc.Action, cc.Key, cc.NewKey, cc.Regex, cc.Replacement, cc.Type})
+	}
+
+	return d, nil
+}
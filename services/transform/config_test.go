@@ -0,0 +1,78 @@
+package transform_test
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/influxdb/services/transform"
+)
+
+func TestConfig_Parse(t *testing.T) {
+	// Parse configuration.
+	var c struct {
+		Transforms []transform.Config `toml:"transform"`
+	}
+	if _, err := toml.Decode(`
+[[transform]]
+  name = "drop-pod-id"
+  database = "telegraf"
+  measurement = "kube_.*"
+  action = "drop-tag"
+  key = "pod_id"
+
+[[transform]]
+  name = "short-host"
+  action = "relabel"
+  key = "host"
+  regex = "([^.]+)\\..*"
+`, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Validate configuration.
+	if len(c.Transforms) != 2 {
+		t.Fatalf("unexpected transforms: %v", c.Transforms)
+	} else if tc := c.Transforms[0]; tc.Name != "drop-pod-id" || tc.Database != "telegraf" || tc.Measurement != "kube_.*" || tc.Action != transform.ActionDropTag || tc.Key != "pod_id" {
+		t.Fatalf("unexpected transform: %+v", tc)
+	} else if tc := c.Transforms[1].WithDefaults(); tc.NewKey != "host" || tc.Regex != `([^.]+)\..*` || tc.Replacement != transform.DefaultReplacement {
+		t.Fatalf("unexpected transform: %+v", tc)
+	}
+
+	if err := transform.Configs(c.Transforms).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		c    transform.Config
+	}{
+		{name: "no name", c: transform.Config{Action: transform.ActionDropTag, Key: "host"}},
+		{name: "action", c: transform.Config{Name: "r", Action: "drop-everything"}},
+		{name: "drop-tag without key", c: transform.Config{Name: "r", Action: transform.ActionDropTag}},
+		{name: "rename-field without new-key", c: transform.Config{Name: "r", Action: transform.ActionRenameField, Key: "value"}},
+		{name: "rename-measurement without new-key", c: transform.Config{Name: "r", Measurement: "cpu", Action: transform.ActionRenameMeasurement}},
+		{name: "rename-measurement without measurement", c: transform.Config{Name: "r", Action: transform.ActionRenameMeasurement, NewKey: "cpu"}},
+		{name: "relabel without regex", c: transform.Config{Name: "r", Action: transform.ActionRelabel, Key: "host"}},
+		{name: "drop-series without regex", c: transform.Config{Name: "r", Action: transform.ActionDropSeries, Key: "host"}},
+		{name: "drop-series without predicate", c: transform.Config{Name: "r", Action: transform.ActionDropSeries}},
+		{name: "cast-field type", c: transform.Config{Name: "r", Action: transform.ActionCastField, Key: "value", Type: "decimal"}},
+		{name: "measurement", c: transform.Config{Name: "r", Measurement: "cpu(", Action: transform.ActionDropTag, Key: "host"}},
+		{name: "regex", c: transform.Config{Name: "r", Action: transform.ActionRelabel, Key: "host", Regex: "("}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+
+	c := transform.Configs{
+		{Name: "r", Action: transform.ActionDropTag, Key: "host"},
+		{Name: "r", Action: transform.ActionDropTag, Key: "region"},
+	}
+	if err := c.Validate(); err == nil {
+		t.Fatal("expected duplicate name error")
+	}
+}
@@ -0,0 +1,470 @@
+// Package transform rewrites the points written to a database with a pipeline
+// of configured rules, before they are mapped to shards.
+package transform // import "github.com/influxdata/influxdb/services/transform"
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxql"
+	"go.uber.org/zap"
+)
+
+// statistics gathered for each rule.
+const (
+	statHits = "hits" // Number of points changed or dropped by the rule.
+)
+
+// castTypes maps the types of a cast-field rule to their data type.
+var castTypes = map[string]influxql.DataType{
+	"float":    influxql.Float,
+	"integer":  influxql.Integer,
+	"unsigned": influxql.Unsigned,
+	"string":   influxql.String,
+	"boolean":  influxql.Boolean,
+}
+
+// Pipeline applies the transformation rules to the points of a write.
+// The rules are evaluated in order, each on the result of the previous ones.
+type Pipeline struct {
+	mu    sync.RWMutex
+	rules []*rule
+
+	Logger *zap.Logger
+}
+
+// NewPipeline returns a pipeline of the rules of c.
+func NewPipeline(c Configs) (*Pipeline, error) {
+	p := &Pipeline{Logger: zap.NewNop()}
+	rules, err := p.compile(c)
+	if err != nil {
+		return nil, err
+	}
+	p.rules = rules
+	return p, nil
+}
+
+// WithLogger sets the logger on the pipeline.
+func (p *Pipeline) WithLogger(log *zap.Logger) {
+	p.Logger = log.With(zap.String("service", "transform"))
+}
+
+// Reload replaces the rules of the pipeline with those of c. The hit counters
+// of the rules which keep their name are kept.
+func (p *Pipeline) Reload(c Configs) error {
+	rules, err := p.compile(c)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.rules = rules
+	p.mu.Unlock()
+
+	p.Logger.Info("Reloaded transform rules", zap.Int("rules", len(rules)))
+	return nil
+}
+
+// compile validates and compiles the rules of c.
+func (p *Pipeline) compile(c Configs) ([]*rule, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	hits := make(map[string]*int64, len(p.rules))
+	for _, r := range p.rules {
+		hits[r.name] = r.hits
+	}
+	p.mu.RUnlock()
+
+	rules := make([]*rule, 0, len(c))
+	for _, cc := range c {
+		r := newRule(cc.WithDefaults())
+		if h, ok := hits[r.name]; ok {
+			r.hits = h
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// Transform returns the points to write to database once transformed by the
+// rules. Points dropped by a rule are omitted, and points are returned
+// unchanged when no rule applies to them, or when their fields can't be
+// parsed so that the write reports them. The returned index holds the index
+// in points of each returned point, and is nil if points is returned as is.
+func (p *Pipeline) Transform(database string, points []models.Point) ([]models.Point, []int) {
+	if p == nil {
+		return points, nil
+	}
+
+	p.mu.RLock()
+	var rules []*rule
+	for _, r := range p.rules {
+		if r.database == "" || r.database == database {
+			rules = append(rules, r)
+		}
+	}
+	p.mu.RUnlock()
+
+	if len(rules) == 0 {
+		return points, nil
+	}
+
+	var (
+		transformed []models.Point
+		index       []int
+	)
+	for i, pt := range points {
+		out, changed := transformPoint(rules, pt)
+		if !changed {
+			if transformed != nil {
+				transformed = append(transformed, pt)
+				index = append(index, i)
+			}
+			continue
+		}
+
+		// Copy the unchanged points on the first change so the slice of the
+		// caller is left untouched.
+		if transformed == nil {
+			transformed = make([]models.Point, i, len(points))
+			copy(transformed, points[:i])
+			index = make([]int, i, len(points))
+			for j := range index {
+				index[j] = j
+			}
+		}
+		if out != nil {
+			transformed = append(transformed, out)
+			index = append(index, i)
+		}
+	}
+
+	if transformed == nil {
+		return points, nil
+	}
+	return transformed, index
+}
+
+// Statistics returns statistics for periodic monitoring.
+func (p *Pipeline) Statistics(tags map[string]string) []models.Statistic {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	statistics := make([]models.Statistic, 0, len(p.rules))
+	for _, r := range p.rules {
+		statistics = append(statistics, models.Statistic{
+			Name: "transform",
+			Tags: models.StatisticTags{"rule": r.name, "database": r.database, "action": r.action}.Merge(tags),
+			Values: map[string]interface{}{
+				statHits: atomic.LoadInt64(r.hits),
+			},
+		})
+	}
+	return statistics
+}
+
+// transformPoint applies the rules to pt. It returns whether a rule changed
+// or dropped the point, and the new point, nil if it was dropped. A point
+// which can't be rebuilt, because its fields fail to parse or the result is
+// invalid, is returned unchanged and counted by no rule.
+func transformPoint(rules []*rule, pt models.Point) (models.Point, bool) {
+	s := &state{name: pt.Name(), tags: pt.Tags()}
+
+	var hits []*rule
+	for _, r := range rules {
+		if r.measurement != nil && !r.measurement.Match(s.name) {
+			continue
+		}
+
+		hit, drop := r.apply(s, pt)
+		if hit {
+			hits = append(hits, r)
+		}
+		if drop {
+			countHits(hits)
+			return nil, true
+		}
+	}
+
+	if len(hits) == 0 {
+		return pt, false
+	}
+
+	if s.fields == nil {
+		fields, err := pt.Fields()
+		if err != nil {
+			return pt, false
+		}
+		s.fields = fields
+	}
+	out, err := models.NewPoint(string(s.name), s.tags, s.fields, pt.Time())
+	if err != nil {
+		return pt, false
+	}
+	countHits(hits)
+	return out, true
+}
+
+// countHits increments the hit counters of the rules.
+func countHits(rules []*rule) {
+	for _, r := range rules {
+		atomic.AddInt64(r.hits, 1)
+	}
+}
+
+// state holds the parts of a point changed by the rules. The tags and fields
+// of the point are cached by it, so they are copied before being changed, and
+// the fields are only parsed once a rule needs them.
+type state struct {
+	name       []byte
+	tags       models.Tags
+	tagsCopied bool
+	fields     models.Fields
+}
+
+// editTags copies the tags of the point unless they were already.
+func (s *state) editTags() {
+	if !s.tagsCopied {
+		s.tags = s.tags.Clone()
+		s.tagsCopied = true
+	}
+}
+
+// loadFields copies the fields of pt unless they were already.
+func (s *state) loadFields(pt models.Point) bool {
+	if s.fields != nil {
+		return true
+	}
+	fields, err := pt.Fields()
+	if err != nil {
+		return false
+	}
+	s.fields = make(models.Fields, len(fields))
+	for k, v := range fields {
+		s.fields[k] = v
+	}
+	return true
+}
+
+// rule is a compiled transformation rule.
+type rule struct {
+	name        string
+	database    string
+	measurement *regexp.Regexp
+	action      string
+	key         []byte
+	newKey      []byte
+	regex       *regexp.Regexp
+	replacement []byte
+	typ         influxql.DataType
+
+	hits *int64
+}
+
+// newRule compiles the rule of a validated config.
+func newRule(c *Config) *rule {
+	r := &rule{
+		name:        c.Name,
+		database:    c.Database,
+		action:      c.Action,
+		key:         []byte(c.Key),
+		newKey:      []byte(c.NewKey),
+		replacement: []byte(c.Replacement),
+		typ:         castTypes[c.Type],
+		hits:        new(int64),
+	}
+	if c.Measurement != "" {
+		r.measurement = regexp.MustCompile("^(?:" + c.Measurement + ")$")
+	}
+	if c.Regex != "" {
+		r.regex = regexp.MustCompile("^(?:" + c.Regex + ")$")
+	}
+	return r
+}
+
+// apply applies the rule to the point in s. It returns whether the rule
+// changed the point, and whether the point must be dropped.
+func (r *rule) apply(s *state, pt models.Point) (hit, drop bool) {
+	switch r.action {
+	case ActionDropTag:
+		if s.tags.Get(r.key) == nil {
+			return false, false
+		}
+		s.editTags()
+		s.tags.Delete(r.key)
+		return true, false
+
+	case ActionRenameTag:
+		v := s.tags.Get(r.key)
+		if v == nil {
+			return false, false
+		}
+		s.editTags()
+		s.tags.Delete(r.key)
+		s.tags.Set(r.newKey, v)
+		return true, false
+
+	case ActionDropField:
+		if !s.loadFields(pt) {
+			return false, false
+		}
+		if _, ok := s.fields[string(r.key)]; !ok {
+			return false, false
+		}
+		delete(s.fields, string(r.key))
+		return true, len(s.fields) == 0
+
+	case ActionRenameField:
+		if !s.loadFields(pt) {
+			return false, false
+		}
+		v, ok := s.fields[string(r.key)]
+		if !ok {
+			return false, false
+		}
+		delete(s.fields, string(r.key))
+		s.fields[string(r.newKey)] = v
+		return true, false
+
+	case ActionRenameMeasurement:
+		if bytes.Equal(s.name, r.newKey) {
+			return false, false
+		}
+		s.name = r.newKey
+		return true, false
+
+	case ActionRelabel:
+		v := s.tags.Get(r.key)
+		m := r.regex.FindSubmatchIndex(v)
+		if m == nil {
+			return false, false
+		}
+		nv := r.regex.Expand(nil, r.replacement, v, m)
+		s.editTags()
+		if len(nv) == 0 {
+			s.tags.Delete(r.newKey)
+		} else {
+			s.tags.Set(r.newKey, nv)
+		}
+		return true, false
+
+	case ActionDropSeries:
+		if r.regex != nil && !r.regex.Match(s.tags.Get(r.key)) {
+			return false, false
+		}
+		return true, true
+
+	case ActionCastField:
+		if !s.loadFields(pt) {
+			return false, false
+		}
+		v, ok := s.fields[string(r.key)]
+		if !ok || influxql.InspectDataType(v) == r.typ {
+			return false, false
+		}
+		cv, ok := castValue(v, r.typ)
+		if !ok {
+			return false, false
+		}
+		s.fields[string(r.key)] = cv
+		return true, false
+	}
+	return false, false
+}
+
+// castValue converts v to typ. Floats are truncated when converted to
+// integers, and strings are parsed. It returns false if v can't be
+// represented as typ.
+func castValue(v interface{}, typ influxql.DataType) (interface{}, bool) {
+	switch typ {
+	case influxql.Float:
+		switch v := v.(type) {
+		case int64:
+			return float64(v), true
+		case uint64:
+			return float64(v), true
+		case bool:
+			if v {
+				return float64(1), true
+			}
+			return float64(0), true
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	case influxql.Integer:
+		switch v := v.(type) {
+		case float64:
+			if v >= math.MinInt64 && v < math.MaxInt64 {
+				return int64(v), true
+			}
+		case uint64:
+			if v <= math.MaxInt64 {
+				return int64(v), true
+			}
+		case bool:
+			if v {
+				return int64(1), true
+			}
+			return int64(0), true
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i, true
+			}
+		}
+	case influxql.Unsigned:
+		switch v := v.(type) {
+		case float64:
+			if v >= 0 && v < math.MaxUint64 {
+				return uint64(v), true
+			}
+		case int64:
+			if v >= 0 {
+				return uint64(v), true
+			}
+		case bool:
+			if v {
+				return uint64(1), true
+			}
+			return uint64(0), true
+		case string:
+			if u, err := strconv.ParseUint(v, 10, 64); err == nil {
+				return u, true
+			}
+		}
+	case influxql.String:
+		switch v := v.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		case int64:
+			return strconv.FormatInt(v, 10), true
+		case uint64:
+			return strconv.FormatUint(v, 10), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+	case influxql.Boolean:
+		switch v := v.(type) {
+		case float64:
+			return v != 0, true
+		case int64:
+			return v != 0, true
+		case uint64:
+			return v != 0, true
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, true
+			}
+		}
+	}
+	return nil, false
+}
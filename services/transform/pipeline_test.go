@@ -0,0 +1,250 @@
+package transform_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/transform"
+)
+
+func TestPipeline_Transform(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		rules transform.Configs
+		in    string
+		out   string
+	}{
+		{
+			name:  "drop tag",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionDropTag, Key: "pod"}},
+			in:    "cpu,host=a,pod=x value=1 10\nmem,host=a value=2 10",
+			out:   "cpu,host=a value=1 10\nmem,host=a value=2 10",
+		},
+		{
+			name:  "rename tag",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionRenameTag, Key: "host", NewKey: "server"}},
+			in:    "cpu,host=a,region=west value=1 10",
+			out:   "cpu,region=west,server=a value=1 10",
+		},
+		{
+			name:  "drop field",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionDropField, Key: "debug"}},
+			in:    "cpu value=1,debug=\"x\" 10\ncpu debug=\"y\" 20",
+			out:   "cpu value=1 10",
+		},
+		{
+			name:  "rename field",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionRenameField, Key: "val", NewKey: "value"}},
+			in:    "cpu val=1 10",
+			out:   "cpu value=1 10",
+		},
+		{
+			name:  "rename measurement",
+			rules: transform.Configs{{Name: "r", Measurement: "cpu_.*", Action: transform.ActionRenameMeasurement, NewKey: "cpu"}},
+			in:    "cpu_total value=1 10\nmem value=2 10",
+			out:   "cpu value=1 10\nmem value=2 10",
+		},
+		{
+			name:  "relabel",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionRelabel, Key: "host", Regex: `([^.]+)\..*`}},
+			in:    "cpu,host=a.example.com value=1 10\ncpu,host=b value=2 10",
+			out:   "cpu,host=a value=1 10\ncpu,host=b value=2 10",
+		},
+		{
+			name:  "relabel to another tag",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionRelabel, Key: "host", NewKey: "domain", Regex: `[^.]+\.(.*)`}},
+			in:    "cpu,host=a.example.com value=1 10",
+			out:   "cpu,domain=example.com,host=a.example.com value=1 10",
+		},
+		{
+			name:  "drop series",
+			rules: transform.Configs{{Name: "r", Action: transform.ActionDropSeries, Key: "host", Regex: "test-.*"}},
+			in:    "cpu,host=test-1 value=1 10\ncpu,host=prod-1 value=2 10\ncpu value=3 10",
+			out:   "cpu,host=prod-1 value=2 10\ncpu value=3 10",
+		},
+		{
+			name:  "drop measurement",
+			rules: transform.Configs{{Name: "r", Measurement: "debug", Action: transform.ActionDropSeries}},
+			in:    "debug value=1 10\ncpu value=2 10",
+			out:   "cpu value=2 10",
+		},
+		{
+			name: "cast field",
+			rules: transform.Configs{
+				{Name: "float", Action: transform.ActionCastField, Key: "value", Type: "float"},
+				{Name: "integer", Action: transform.ActionCastField, Key: "count", Type: "integer"},
+				{Name: "string", Action: transform.ActionCastField, Key: "code", Type: "string"},
+			},
+			in:  "cpu value=1i,count=2.5,code=404i 10\ncpu value=\"x\" 20",
+			out: "cpu code=\"404\",count=2i,value=1 10\ncpu value=\"x\" 20",
+		},
+		{
+			name: "rules in order",
+			rules: transform.Configs{
+				{Name: "rename", Action: transform.ActionRenameMeasurement, Measurement: "cpu_total", NewKey: "cpu"},
+				{Name: "drop", Action: transform.ActionDropTag, Measurement: "cpu", Key: "pod"},
+			},
+			in:  "cpu_total,pod=x value=1 10",
+			out: "cpu value=1 10",
+		},
+		{
+			name:  "other database",
+			rules: transform.Configs{{Name: "r", Database: "other", Action: transform.ActionDropTag, Key: "pod"}},
+			in:    "cpu,pod=x value=1 10",
+			out:   "cpu,pod=x value=1 10",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := transform.NewPipeline(tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			in := mustParsePoints(t, tt.in)
+			orig := pointsString(in)
+
+			out, _ := p.Transform("db0", in)
+			if got := pointsString(out); got != tt.out {
+				t.Fatalf("unexpected points:\n got: %s\nwant: %s", got, tt.out)
+			}
+			if got := pointsString(in); got != orig {
+				t.Fatalf("points of the caller changed:\n got: %s\nwant: %s", got, orig)
+			}
+		})
+	}
+}
+
+func TestPipeline_Transform_Index(t *testing.T) {
+	p, err := transform.NewPipeline(transform.Configs{
+		{Name: "drop-debug", Measurement: "debug", Action: transform.ActionDropSeries},
+		{Name: "drop-pod", Action: transform.ActionDropTag, Key: "pod"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := mustParsePoints(t, "cpu value=1 10\ndebug value=1 10\ncpu,pod=x value=1 10\nmem value=1 10")
+	out, index := p.Transform("db0", in)
+	if got, exp := pointsString(out), "cpu value=1 10\ncpu value=1 10\nmem value=1 10"; got != exp {
+		t.Fatalf("unexpected points:\n got: %s\nwant: %s", got, exp)
+	} else if exp := []int{0, 2, 3}; !reflect.DeepEqual(index, exp) {
+		t.Fatalf("unexpected index: %v", index)
+	}
+
+	// Points returned as is have no index.
+	if out, index := p.Transform("db0", in[:1]); len(out) != 1 || index != nil {
+		t.Fatalf("unexpected points %v and index %v", out, index)
+	}
+}
+
+func TestPipeline_Transform_InvalidFields(t *testing.T) {
+	p, err := transform.NewPipeline(transform.Configs{
+		{Name: "drop-pod", Action: transform.ActionDropTag, Key: "pod"},
+		{Name: "rename", Action: transform.ActionRenameField, Key: "val", NewKey: "value"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A point whose fields fail to parse is written unchanged, so that the
+	// write reports it.
+	in := []models.Point{invalidFieldsPoint{mustParsePoints(t, "cpu,pod=x val=1 10")[0]}}
+	if out, _ := p.Transform("db0", in); len(out) != 1 || out[0] != in[0] {
+		t.Fatalf("unexpected points: %v", out)
+	}
+	if got := hits(p); got["drop-pod"] != 0 || got["rename"] != 0 {
+		t.Fatalf("unexpected hits: %v", got)
+	}
+}
+
+func TestPipeline_Transform_RenameMeasurement(t *testing.T) {
+	p, err := transform.NewPipeline(transform.Configs{
+		{Name: "r", Measurement: "cpu.*", Action: transform.ActionRenameMeasurement, NewKey: "cpu"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Points already named new-key are not hits.
+	in := mustParsePoints(t, "cpu value=1 10\ncpu_total value=1 10")
+	if out, _ := p.Transform("db0", in); pointsString(out) != "cpu value=1 10\ncpu value=1 10" {
+		t.Fatalf("unexpected points: %s", pointsString(out))
+	}
+	if got := hits(p); got["r"] != 1 {
+		t.Fatalf("unexpected hits: %v", got)
+	}
+}
+
+func TestPipeline_Statistics(t *testing.T) {
+	p, err := transform.NewPipeline(transform.Configs{
+		{Name: "drop-pod", Database: "db0", Action: transform.ActionDropTag, Key: "pod"},
+		{Name: "drop-test", Action: transform.ActionDropSeries, Key: "host", Regex: "test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Transform("db0", mustParsePoints(t, "cpu,host=test,pod=x value=1 10\ncpu,host=a,pod=y value=1 10\ncpu,host=a value=1 10"))
+	p.Transform("db1", mustParsePoints(t, "cpu,host=test,pod=x value=1 10"))
+	if got := hits(p); got["drop-pod"] != 2 || got["drop-test"] != 2 {
+		t.Fatalf("unexpected hits: %v", got)
+	}
+
+	// Reloading keeps the counters of the rules which keep their name.
+	if err := p.Reload(transform.Configs{
+		{Name: "drop-pod", Action: transform.ActionDropTag, Key: "pod"},
+		{Name: "drop-region", Action: transform.ActionDropTag, Key: "region"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	p.Transform("db1", mustParsePoints(t, "cpu,host=test,pod=x value=1 10"))
+	if got := hits(p); len(got) != 2 || got["drop-pod"] != 3 || got["drop-region"] != 0 {
+		t.Fatalf("unexpected hits: %v", got)
+	}
+
+	// An invalid reload keeps the rules.
+	if err := p.Reload(transform.Configs{{Name: "bad", Action: "nope"}}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := hits(p); len(got) != 2 {
+		t.Fatalf("unexpected hits: %v", got)
+	}
+}
+
+func mustParsePoints(t *testing.T, s string) []models.Point {
+	t.Helper()
+	points, err := models.ParsePointsString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return points
+}
+
+func pointsString(points []models.Point) string {
+	lines := make([]string, 0, len(points))
+	for _, p := range points {
+		lines = append(lines, p.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// invalidFieldsPoint is a point whose fields fail to parse.
+type invalidFieldsPoint struct {
+	models.Point
+}
+
+func (invalidFieldsPoint) Fields() (models.Fields, error) {
+	return nil, errors.New("invalid fields")
+}
+
+// hits returns the hits of the rules of p, by rule name.
+func hits(p *transform.Pipeline) map[string]int64 {
+	m := make(map[string]int64)
+	for _, s := range p.Statistics(nil) {
+		m[s.Tags["rule"]] = s.Values["hits"].(int64)
+	}
+	return m
+}
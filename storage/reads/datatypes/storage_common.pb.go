@@ -99,18 +99,33 @@ const (
 	AggregateTypeNone  Aggregate_AggregateType = 0
 	AggregateTypeSum   Aggregate_AggregateType = 1
 	AggregateTypeCount Aggregate_AggregateType = 2
+	AggregateTypeMin   Aggregate_AggregateType = 3
+	AggregateTypeMax   Aggregate_AggregateType = 4
+	AggregateTypeFirst Aggregate_AggregateType = 5
+	AggregateTypeLast  Aggregate_AggregateType = 6
+	AggregateTypeMean  Aggregate_AggregateType = 7
 )
 
 var Aggregate_AggregateType_name = map[int32]string{
 	0: "NONE",
 	1: "SUM",
 	2: "COUNT",
+	3: "MIN",
+	4: "MAX",
+	5: "FIRST",
+	6: "LAST",
+	7: "MEAN",
 }
 
 var Aggregate_AggregateType_value = map[string]int32{
 	"NONE":  0,
 	"SUM":   1,
 	"COUNT": 2,
+	"MIN":   3,
+	"MAX":   4,
+	"FIRST": 5,
+	"LAST":  6,
+	"MEAN":  7,
 }
 
 func (x Aggregate_AggregateType) String() string {
@@ -118,7 +133,7 @@ func (x Aggregate_AggregateType) String() string {
 }
 
 func (Aggregate_AggregateType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{2, 0}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{3, 0}
 }
 
 type ReadResponse_FrameType int32
@@ -143,7 +158,7 @@ func (x ReadResponse_FrameType) String() string {
 }
 
 func (ReadResponse_FrameType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 0}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 0}
 }
 
 type ReadResponse_DataType int32
@@ -177,7 +192,7 @@ func (x ReadResponse_DataType) String() string {
 }
 
 func (ReadResponse_DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 1}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 1}
 }
 
 type ReadFilterRequest struct {
@@ -264,6 +279,52 @@ func (m *ReadGroupRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ReadGroupRequest proto.InternalMessageInfo
 
+type ReadWindowAggregateRequest struct {
+	ReadSource *types.Any     `protobuf:"bytes,1,opt,name=read_source,json=readSource,proto3" json:"read_source,omitempty"`
+	Range      TimestampRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range"`
+	Predicate  *Predicate     `protobuf:"bytes,3,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	// WindowEvery is the duration of each window in nanoseconds.
+	WindowEvery int64 `protobuf:"varint,4,opt,name=WindowEvery,proto3" json:"WindowEvery,omitempty"`
+	// Offset shifts the window boundaries, in nanoseconds, from the epoch.
+	Offset int64 `protobuf:"varint,6,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// Aggregate is the aggregate applied to the values of each window.
+	// Only a single aggregate is currently supported.
+	Aggregate []*Aggregate `protobuf:"bytes,5,rep,name=aggregate,proto3" json:"aggregate,omitempty"`
+}
+
+func (m *ReadWindowAggregateRequest) Reset()         { *m = ReadWindowAggregateRequest{} }
+func (m *ReadWindowAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadWindowAggregateRequest) ProtoMessage()    {}
+func (*ReadWindowAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_715e4bf4cdf1f73d, []int{2}
+}
+func (m *ReadWindowAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadWindowAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadWindowAggregateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadWindowAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadWindowAggregateRequest.Merge(m, src)
+}
+func (m *ReadWindowAggregateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadWindowAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadWindowAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadWindowAggregateRequest proto.InternalMessageInfo
+
 type Aggregate struct {
 	Type Aggregate_AggregateType `protobuf:"varint,1,opt,name=type,proto3,enum=influxdata.platform.storage.Aggregate_AggregateType" json:"type,omitempty"`
 }
@@ -272,7 +333,7 @@ func (m *Aggregate) Reset()         { *m = Aggregate{} }
 func (m *Aggregate) String() string { return proto.CompactTextString(m) }
 func (*Aggregate) ProtoMessage()    {}
 func (*Aggregate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{2}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{3}
 }
 func (m *Aggregate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -310,7 +371,7 @@ func (m *Tag) Reset()         { *m = Tag{} }
 func (m *Tag) String() string { return proto.CompactTextString(m) }
 func (*Tag) ProtoMessage()    {}
 func (*Tag) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{3}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{4}
 }
 func (m *Tag) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -348,7 +409,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -393,7 +454,7 @@ func (m *ReadResponse_Frame) Reset()         { *m = ReadResponse_Frame{} }
 func (m *ReadResponse_Frame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_Frame) ProtoMessage()    {}
 func (*ReadResponse_Frame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 0}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 0}
 }
 func (m *ReadResponse_Frame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -538,7 +599,7 @@ func (m *ReadResponse_GroupFrame) Reset()         { *m = ReadResponse_GroupFrame
 func (m *ReadResponse_GroupFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_GroupFrame) ProtoMessage()    {}
 func (*ReadResponse_GroupFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 1}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 1}
 }
 func (m *ReadResponse_GroupFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -576,7 +637,7 @@ func (m *ReadResponse_SeriesFrame) Reset()         { *m = ReadResponse_SeriesFra
 func (m *ReadResponse_SeriesFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_SeriesFrame) ProtoMessage()    {}
 func (*ReadResponse_SeriesFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 2}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 2}
 }
 func (m *ReadResponse_SeriesFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -614,7 +675,7 @@ func (m *ReadResponse_FloatPointsFrame) Reset()         { *m = ReadResponse_Floa
 func (m *ReadResponse_FloatPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_FloatPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_FloatPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 3}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 3}
 }
 func (m *ReadResponse_FloatPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -652,7 +713,7 @@ func (m *ReadResponse_IntegerPointsFrame) Reset()         { *m = ReadResponse_In
 func (m *ReadResponse_IntegerPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_IntegerPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_IntegerPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 4}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 4}
 }
 func (m *ReadResponse_IntegerPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -690,7 +751,7 @@ func (m *ReadResponse_UnsignedPointsFrame) Reset()         { *m = ReadResponse_U
 func (m *ReadResponse_UnsignedPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_UnsignedPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_UnsignedPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 5}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 5}
 }
 func (m *ReadResponse_UnsignedPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -728,7 +789,7 @@ func (m *ReadResponse_BooleanPointsFrame) Reset()         { *m = ReadResponse_Bo
 func (m *ReadResponse_BooleanPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_BooleanPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_BooleanPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 6}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 6}
 }
 func (m *ReadResponse_BooleanPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -766,7 +827,7 @@ func (m *ReadResponse_StringPointsFrame) Reset()         { *m = ReadResponse_Str
 func (m *ReadResponse_StringPointsFrame) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse_StringPointsFrame) ProtoMessage()    {}
 func (*ReadResponse_StringPointsFrame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{4, 7}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{5, 7}
 }
 func (m *ReadResponse_StringPointsFrame) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -803,7 +864,7 @@ func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
 func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
 func (*CapabilitiesResponse) ProtoMessage()    {}
 func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{5}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{6}
 }
 func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -844,7 +905,7 @@ func (m *TimestampRange) Reset()         { *m = TimestampRange{} }
 func (m *TimestampRange) String() string { return proto.CompactTextString(m) }
 func (*TimestampRange) ProtoMessage()    {}
 func (*TimestampRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{6}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{7}
 }
 func (m *TimestampRange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -884,7 +945,7 @@ func (m *TagKeysRequest) Reset()         { *m = TagKeysRequest{} }
 func (m *TagKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*TagKeysRequest) ProtoMessage()    {}
 func (*TagKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{7}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{8}
 }
 func (m *TagKeysRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -925,7 +986,7 @@ func (m *TagValuesRequest) Reset()         { *m = TagValuesRequest{} }
 func (m *TagValuesRequest) String() string { return proto.CompactTextString(m) }
 func (*TagValuesRequest) ProtoMessage()    {}
 func (*TagValuesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{8}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{9}
 }
 func (m *TagValuesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -963,7 +1024,7 @@ func (m *StringValuesResponse) Reset()         { *m = StringValuesResponse{} }
 func (m *StringValuesResponse) String() string { return proto.CompactTextString(m) }
 func (*StringValuesResponse) ProtoMessage()    {}
 func (*StringValuesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_715e4bf4cdf1f73d, []int{9}
+	return fileDescriptor_715e4bf4cdf1f73d, []int{10}
 }
 func (m *StringValuesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1000,6 +1061,7 @@ func init() {
 	proto.RegisterEnum("influxdata.platform.storage.ReadResponse_DataType", ReadResponse_DataType_name, ReadResponse_DataType_value)
 	proto.RegisterType((*ReadFilterRequest)(nil), "influxdata.platform.storage.ReadFilterRequest")
 	proto.RegisterType((*ReadGroupRequest)(nil), "influxdata.platform.storage.ReadGroupRequest")
+	proto.RegisterType((*ReadWindowAggregateRequest)(nil), "influxdata.platform.storage.ReadWindowAggregateRequest")
 	proto.RegisterType((*Aggregate)(nil), "influxdata.platform.storage.Aggregate")
 	proto.RegisterType((*Tag)(nil), "influxdata.platform.storage.Tag")
 	proto.RegisterType((*ReadResponse)(nil), "influxdata.platform.storage.ReadResponse")
@@ -1022,101 +1084,109 @@ func init() {
 func init() { proto.RegisterFile("storage_common.proto", fileDescriptor_715e4bf4cdf1f73d) }
 
 var fileDescriptor_715e4bf4cdf1f73d = []byte{
-	// 1498 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcb, 0x6f, 0xdb, 0xc8,
-	0x19, 0x17, 0xf5, 0x34, 0x3f, 0xc9, 0x32, 0x3d, 0x51, 0x5d, 0x87, 0x69, 0x24, 0x56, 0x28, 0x52,
-	0x17, 0x49, 0xe4, 0xd4, 0x49, 0xd1, 0x20, 0x6d, 0x0f, 0x96, 0x23, 0x5b, 0x6a, 0x6c, 0xc9, 0xa0,
-	0xe4, 0x00, 0xe9, 0x45, 0x18, 0xdb, 0x63, 0x86, 0x88, 0x44, 0xb2, 0x24, 0x15, 0x58, 0x40, 0x2f,
-	0xbd, 0x05, 0x3a, 0xb5, 0x97, 0x1e, 0x0a, 0x08, 0x58, 0x60, 0x8f, 0x7b, 0xdf, 0xbf, 0x21, 0x87,
-	0x05, 0x36, 0xc7, 0x3d, 0x09, 0xbb, 0x0a, 0xb0, 0xc0, 0xfe, 0x0b, 0x7b, 0x5a, 0xcc, 0x0c, 0x47,
-	0xa2, 0x6c, 0xc3, 0x96, 0xf6, 0xb4, 0xc8, 0x6d, 0xe6, 0x7b, 0xfc, 0xbe, 0x07, 0xbf, 0xc7, 0x10,
-	0x72, 0x9e, 0x6f, 0xbb, 0xd8, 0x20, 0xed, 0x13, 0xbb, 0xdb, 0xb5, 0xad, 0x92, 0xe3, 0xda, 0xbe,
-	0x8d, 0xee, 0x98, 0xd6, 0x59, 0xa7, 0x77, 0x7e, 0x8a, 0x7d, 0x5c, 0x72, 0x3a, 0xd8, 0x3f, 0xb3,
-	0xdd, 0x6e, 0x29, 0x90, 0x54, 0x73, 0x86, 0x6d, 0xd8, 0x4c, 0x6e, 0x93, 0x9e, 0xb8, 0x8a, 0x7a,
-	0xc7, 0xb0, 0x6d, 0xa3, 0x43, 0x36, 0xd9, 0xed, 0xb8, 0x77, 0xb6, 0x49, 0xba, 0x8e, 0xdf, 0x0f,
-	0x98, 0xb7, 0x2f, 0x32, 0xb1, 0x25, 0x58, 0x2b, 0x8e, 0x4b, 0x4e, 0xcd, 0x13, 0xec, 0x13, 0x4e,
-	0x28, 0xfe, 0x20, 0xc1, 0xaa, 0x4e, 0xf0, 0xe9, 0xae, 0xd9, 0xf1, 0x89, 0xab, 0x93, 0x7f, 0xf6,
-	0x88, 0xe7, 0xa3, 0x0a, 0xa4, 0x5d, 0x82, 0x4f, 0xdb, 0x9e, 0xdd, 0x73, 0x4f, 0xc8, 0xba, 0xa4,
-	0x49, 0x1b, 0xe9, 0xad, 0x5c, 0x89, 0xe3, 0x96, 0x04, 0x6e, 0x69, 0xdb, 0xea, 0x97, 0xb3, 0xe3,
-	0x51, 0x01, 0x28, 0x42, 0x93, 0xc9, 0xea, 0xe0, 0x4e, 0xce, 0x68, 0x0f, 0x12, 0x2e, 0xb6, 0x0c,
-	0xb2, 0x1e, 0x65, 0x00, 0xf7, 0x4b, 0xd7, 0x04, 0x5a, 0x6a, 0x99, 0x5d, 0xe2, 0xf9, 0xb8, 0xeb,
-	0xe8, 0x54, 0xa5, 0x1c, 0x7f, 0x3f, 0x2a, 0x44, 0x74, 0xae, 0x8f, 0x9e, 0x83, 0x3c, 0x71, 0x7c,
-	0x3d, 0xc6, 0xc0, 0xee, 0x5d, 0x0b, 0x76, 0x28, 0xa4, 0xf5, 0xa9, 0x62, 0xf1, 0xab, 0x04, 0x28,
-	0xd4, 0xd3, 0x3d, 0xd7, 0xee, 0x39, 0x9f, 0x74, 0xa8, 0xe8, 0x01, 0x80, 0x41, 0xa3, 0x6c, 0xbf,
-	0x21, 0x7d, 0x6f, 0x3d, 0xae, 0xc5, 0x36, 0xe4, 0xf2, 0xf2, 0x78, 0x54, 0x90, 0x59, 0xec, 0x2f,
-	0x48, 0xdf, 0xd3, 0x65, 0x43, 0x1c, 0x51, 0x0d, 0x12, 0xec, 0xb2, 0x9e, 0xd0, 0xa4, 0x8d, 0xec,
-	0xd6, 0xe3, 0x6b, 0xed, 0x5d, 0xcc, 0x60, 0x89, 0x5f, 0x38, 0x02, 0x75, 0x1f, 0x1b, 0x86, 0x4b,
-	0x0c, 0xea, 0x7e, 0x72, 0x0e, 0xf7, 0xb7, 0x85, 0xb4, 0x3e, 0x55, 0x44, 0x0f, 0x20, 0xf1, 0xda,
-	0xb4, 0x7c, 0x6f, 0x3d, 0xa5, 0x49, 0x1b, 0xa9, 0xf2, 0xda, 0x78, 0x54, 0x48, 0x54, 0x29, 0xe1,
-	0xc7, 0x51, 0x41, 0xa6, 0x87, 0xdd, 0x0e, 0x36, 0x3c, 0x9d, 0x0b, 0x15, 0xf7, 0x20, 0xc1, 0x7c,
-	0x40, 0x77, 0x01, 0xf6, 0xf4, 0xc6, 0xd1, 0x61, 0xbb, 0xde, 0xa8, 0x57, 0x94, 0x88, 0xba, 0x3c,
-	0x18, 0x6a, 0x3c, 0xe2, 0xba, 0x6d, 0x11, 0x74, 0x1b, 0x96, 0x38, 0xbb, 0xfc, 0x4a, 0x89, 0xaa,
-	0xe9, 0xc1, 0x50, 0x4b, 0x31, 0x66, 0xb9, 0xaf, 0xc6, 0xdf, 0x7d, 0x9e, 0x8f, 0x14, 0xbf, 0x90,
-	0x60, 0x8a, 0x8e, 0xee, 0x80, 0x5c, 0xad, 0xd5, 0x5b, 0x02, 0x2c, 0x33, 0x18, 0x6a, 0x4b, 0x94,
-	0xcb, 0xb0, 0x7e, 0x07, 0xd9, 0x80, 0xd9, 0x3e, 0x6c, 0xd4, 0xea, 0xad, 0xa6, 0x22, 0xa9, 0xca,
-	0x60, 0xa8, 0x65, 0xb8, 0xc4, 0xa1, 0x4d, 0x3d, 0x0b, 0x4b, 0x35, 0x2b, 0x7a, 0xad, 0xd2, 0x54,
-	0xa2, 0x61, 0xa9, 0x26, 0x71, 0x4d, 0xe2, 0xa1, 0x4d, 0xc8, 0x31, 0xa9, 0xe6, 0x4e, 0xb5, 0x72,
-	0xb0, 0xdd, 0xde, 0xde, 0xdf, 0x6f, 0xb7, 0x6a, 0x07, 0x15, 0x25, 0xae, 0xfe, 0x6a, 0x30, 0xd4,
-	0x56, 0xa9, 0x6c, 0xf3, 0xe4, 0x35, 0xe9, 0xe2, 0xed, 0x4e, 0x87, 0x96, 0x4e, 0xe0, 0xed, 0xd7,
-	0x12, 0xc8, 0x93, 0xec, 0xa1, 0x2a, 0xc4, 0xfd, 0xbe, 0xc3, 0x0b, 0x38, 0xbb, 0xf5, 0x64, 0xbe,
-	0x9c, 0x4f, 0x4f, 0xad, 0xbe, 0x43, 0x74, 0x86, 0x50, 0x3c, 0x87, 0xe5, 0x19, 0x32, 0x2a, 0x40,
-	0x3c, 0xc8, 0x01, 0xf3, 0x67, 0x86, 0xc9, 0x92, 0x71, 0x17, 0x62, 0xcd, 0xa3, 0x03, 0x45, 0x52,
-	0x73, 0x83, 0xa1, 0xa6, 0xcc, 0xf0, 0x9b, 0xbd, 0x2e, 0xfa, 0x2d, 0x24, 0x76, 0x1a, 0x47, 0xf5,
-	0x96, 0x12, 0x55, 0xd7, 0x06, 0x43, 0x0d, 0xcd, 0x08, 0xec, 0xd8, 0x3d, 0xcb, 0x0f, 0x22, 0x7a,
-	0x08, 0xb1, 0x16, 0x36, 0x90, 0x02, 0xb1, 0x37, 0xa4, 0xcf, 0x22, 0xc9, 0xe8, 0xf4, 0x88, 0x72,
-	0x90, 0x78, 0x8b, 0x3b, 0x3d, 0xde, 0x5d, 0x19, 0x9d, 0x5f, 0x8a, 0xff, 0xcd, 0x42, 0x86, 0x56,
-	0xa3, 0x4e, 0x3c, 0xc7, 0xb6, 0x3c, 0x82, 0x0e, 0x20, 0x79, 0xe6, 0xe2, 0x2e, 0xf1, 0xd6, 0x25,
-	0x2d, 0xb6, 0x91, 0xde, 0xda, 0xbc, 0xb1, 0x90, 0x85, 0x6a, 0x69, 0x97, 0xea, 0x05, 0x9d, 0x18,
-	0x80, 0xa8, 0xef, 0x92, 0x90, 0x60, 0x74, 0xb4, 0x2f, 0x1a, 0x24, 0xc5, 0x2a, 0xfa, 0xc9, 0xfc,
-	0xb8, 0xac, 0xc0, 0x18, 0x48, 0x35, 0x22, 0x7a, 0xa4, 0x01, 0x49, 0x8f, 0x7d, 0xf9, 0x60, 0xda,
-	0xfc, 0x69, 0x7e, 0x38, 0x5e, 0x31, 0x02, 0x2f, 0x80, 0x41, 0x0e, 0x64, 0xce, 0x3a, 0x36, 0xf6,
-	0xdb, 0x0e, 0x2b, 0xbb, 0x60, 0x06, 0x3d, 0x5b, 0x20, 0x7a, 0xaa, 0xcd, 0x6b, 0x96, 0x27, 0x62,
-	0x65, 0x3c, 0x2a, 0xa4, 0x43, 0xd4, 0x6a, 0x44, 0x4f, 0x9f, 0x4d, 0xaf, 0xe8, 0x1c, 0xb2, 0xa6,
-	0xe5, 0x13, 0x83, 0xb8, 0xc2, 0x26, 0x1f, 0x55, 0x7f, 0x9d, 0xdf, 0x66, 0x8d, 0xeb, 0x87, 0xad,
-	0xae, 0x8e, 0x47, 0x85, 0xe5, 0x19, 0x7a, 0x35, 0xa2, 0x2f, 0x9b, 0x61, 0x02, 0xfa, 0x17, 0xac,
-	0xf4, 0x2c, 0xcf, 0x34, 0x2c, 0x72, 0x2a, 0x4c, 0xc7, 0x99, 0xe9, 0xbf, 0xcd, 0x6f, 0xfa, 0x28,
-	0x00, 0x08, 0xdb, 0x46, 0xe3, 0x51, 0x21, 0x3b, 0xcb, 0xa8, 0x46, 0xf4, 0x6c, 0x6f, 0x86, 0x42,
-	0xe3, 0x3e, 0xb6, 0xed, 0x0e, 0xc1, 0x96, 0x30, 0x9e, 0x58, 0x34, 0xee, 0x32, 0xd7, 0xbf, 0x14,
-	0xf7, 0x0c, 0x9d, 0xc6, 0x7d, 0x1c, 0x26, 0x20, 0x1f, 0x96, 0x3d, 0xdf, 0x35, 0x2d, 0x43, 0x18,
-	0xe6, 0xc3, 0xf5, 0x2f, 0x0b, 0xd4, 0x0e, 0x53, 0x0f, 0xdb, 0x55, 0xc6, 0xa3, 0x42, 0x26, 0x4c,
-	0xae, 0x46, 0xf4, 0x8c, 0x17, 0xba, 0x97, 0x93, 0x10, 0xa7, 0xc8, 0xea, 0x39, 0xc0, 0xb4, 0x92,
-	0xd1, 0x3d, 0x58, 0xf2, 0xb1, 0xc1, 0x77, 0x0b, 0xed, 0xb4, 0x4c, 0x39, 0x3d, 0x1e, 0x15, 0x52,
-	0x2d, 0x6c, 0xb0, 0xcd, 0x92, 0xf2, 0xf9, 0x01, 0x95, 0x01, 0x39, 0xd8, 0xf5, 0x4d, 0xdf, 0xb4,
-	0x2d, 0x2a, 0xdd, 0x7e, 0x8b, 0x3b, 0xb4, 0x3a, 0xa9, 0x46, 0x6e, 0x3c, 0x2a, 0x28, 0x87, 0x82,
-	0xfb, 0x82, 0xf4, 0x5f, 0xe2, 0x8e, 0xa7, 0x2b, 0xce, 0x05, 0x8a, 0xfa, 0x7f, 0x09, 0xd2, 0xa1,
-	0xaa, 0x47, 0xcf, 0x20, 0xee, 0x63, 0x43, 0x74, 0xb8, 0x76, 0xfd, 0x9e, 0xc5, 0x46, 0xd0, 0xd2,
-	0x4c, 0x07, 0x35, 0x40, 0xa6, 0x82, 0x6d, 0x36, 0x28, 0xa3, 0x6c, 0x50, 0x6e, 0xcd, 0x9f, 0xbf,
-	0xe7, 0xd8, 0xc7, 0x6c, 0x4c, 0x2e, 0x9d, 0x06, 0x27, 0xf5, 0xef, 0xa0, 0x5c, 0x6c, 0x1d, 0x94,
-	0x07, 0xf0, 0xc5, 0x7e, 0xe7, 0x6e, 0x2a, 0x7a, 0x88, 0x82, 0xd6, 0x20, 0xc9, 0xc6, 0x17, 0x4f,
-	0x84, 0xa4, 0x07, 0x37, 0x75, 0x1f, 0xd0, 0xe5, 0x96, 0x58, 0x10, 0x2d, 0x36, 0x41, 0x3b, 0x80,
-	0x5b, 0x57, 0x54, 0xf9, 0x82, 0x70, 0xf1, 0xb0, 0x73, 0x97, 0xeb, 0x76, 0x41, 0xb4, 0xa5, 0x09,
-	0xda, 0x0b, 0x58, 0xbd, 0x54, 0x8c, 0x0b, 0x82, 0xc9, 0x02, 0xac, 0xd8, 0x04, 0x99, 0x01, 0x04,
-	0xab, 0x2a, 0x19, 0x2c, 0xda, 0x88, 0x7a, 0x6b, 0x30, 0xd4, 0x56, 0x26, 0xac, 0x60, 0xd7, 0x16,
-	0x20, 0x39, 0xd9, 0xd7, 0xb3, 0x02, 0xdc, 0x97, 0x60, 0x13, 0x7d, 0x29, 0xc1, 0x92, 0xf8, 0xde,
-	0xe8, 0x37, 0x90, 0xd8, 0xdd, 0x6f, 0x6c, 0xb7, 0x94, 0x88, 0xba, 0x3a, 0x18, 0x6a, 0xcb, 0x82,
-	0xc1, 0x3e, 0x3d, 0xd2, 0x20, 0x55, 0xab, 0xb7, 0x2a, 0x7b, 0x15, 0x5d, 0x40, 0x0a, 0x7e, 0xf0,
-	0x39, 0x51, 0x11, 0x96, 0x8e, 0xea, 0xcd, 0xda, 0x5e, 0xbd, 0xf2, 0x5c, 0x89, 0xf2, 0x1d, 0x29,
-	0x44, 0xc4, 0x37, 0xa2, 0x28, 0xe5, 0x46, 0x63, 0xbf, 0xb2, 0x5d, 0x57, 0x62, 0xb3, 0x28, 0x41,
-	0xde, 0x51, 0x1e, 0x92, 0xcd, 0x96, 0x5e, 0xab, 0xef, 0x29, 0x71, 0x15, 0x0d, 0x86, 0x5a, 0x56,
-	0x08, 0xf0, 0x54, 0x06, 0x8e, 0x7f, 0x26, 0x41, 0x6e, 0x07, 0x3b, 0xf8, 0xd8, 0xec, 0x98, 0xbe,
-	0x49, 0xbc, 0xc9, 0x6e, 0x6c, 0x40, 0xfc, 0x04, 0x3b, 0xa2, 0x6f, 0xae, 0x1f, 0x1b, 0x57, 0x01,
-	0x50, 0xa2, 0x57, 0xb1, 0x7c, 0xb7, 0xaf, 0x33, 0x20, 0xf5, 0xcf, 0x20, 0x4f, 0x48, 0xe1, 0x95,
-	0x2d, 0x5f, 0xb1, 0xb2, 0xe5, 0x60, 0x65, 0x3f, 0x8b, 0x3e, 0x95, 0x8a, 0x4f, 0x21, 0x3b, 0xfb,
-	0x00, 0xa6, 0xb2, 0x9e, 0x8f, 0x5d, 0x9f, 0xe9, 0xc7, 0x74, 0x7e, 0xa1, 0x98, 0xc4, 0x3a, 0x65,
-	0xfa, 0x31, 0x9d, 0x1e, 0x8b, 0xdf, 0x4b, 0x90, 0x15, 0x43, 0x66, 0xfa, 0x7c, 0xa7, 0xad, 0x3d,
-	0xf7, 0xf3, 0xbd, 0x85, 0x0d, 0x4f, 0x3c, 0xdf, 0xfd, 0xc9, 0xf9, 0x97, 0xf6, 0xa7, 0xf2, 0xef,
-	0x28, 0x28, 0x2d, 0x6c, 0xbc, 0x64, 0x15, 0xfe, 0x49, 0x87, 0x8a, 0x7e, 0x0d, 0xa9, 0x60, 0x97,
-	0xb0, 0x3d, 0x2e, 0xeb, 0x49, 0xbe, 0x3d, 0x8a, 0x25, 0xc8, 0xf1, 0xca, 0x16, 0x59, 0x08, 0x0a,
-	0x79, 0x3a, 0x07, 0xd8, 0xea, 0x11, 0x73, 0x60, 0xeb, 0x7f, 0x71, 0x48, 0x35, 0xb9, 0x25, 0x64,
-	0x02, 0x4c, 0x7f, 0x6a, 0x51, 0xe9, 0xc6, 0x19, 0x3f, 0xf3, 0xf7, 0xab, 0xfe, 0x61, 0xee, 0x9d,
-	0xf0, 0x48, 0x42, 0x06, 0xc8, 0x93, 0x3f, 0x22, 0xf4, 0x70, 0xa1, 0x3f, 0xa7, 0xc5, 0x0c, 0xbd,
-	0x01, 0xb1, 0x60, 0xd1, 0xfd, 0x9b, 0xb6, 0x5e, 0xa8, 0x43, 0xd4, 0x3f, 0x5e, 0x2b, 0x7c, 0x55,
-	0x8a, 0x1f, 0x49, 0xc8, 0x06, 0x79, 0x52, 0x7f, 0x37, 0x44, 0x75, 0xb1, 0x4e, 0x7f, 0x9e, 0xc1,
-	0x57, 0x90, 0x09, 0x4f, 0x1d, 0xb4, 0x76, 0xa9, 0xae, 0x2b, 0x5d, 0xc7, 0xef, 0xdf, 0x00, 0x7e,
-	0xd5, 0xe0, 0x2a, 0xff, 0xfe, 0xfd, 0x77, 0xf9, 0xc8, 0xfb, 0x71, 0x5e, 0xfa, 0x30, 0xce, 0x4b,
-	0xdf, 0x8e, 0xf3, 0xd2, 0x7f, 0x3e, 0xe6, 0x23, 0x1f, 0x3e, 0xe6, 0x23, 0xdf, 0x7c, 0xcc, 0x47,
-	0xfe, 0xc1, 0x5e, 0x04, 0xf4, 0x41, 0xe0, 0x1d, 0x27, 0x99, 0xad, 0xc7, 0x3f, 0x05, 0x00, 0x00,
-	0xff, 0xff, 0x7f, 0x0e, 0xbb, 0xbb, 0xa6, 0x11, 0x00, 0x00,
+	// 1622 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0xcd, 0x6f, 0x1b, 0x4d,
+	0x19, 0xf7, 0xfa, 0x33, 0xfb, 0xd8, 0x71, 0x36, 0x53, 0x13, 0xfc, 0x6e, 0x79, 0xed, 0xc5, 0x42,
+	0x2f, 0x41, 0x6d, 0x9d, 0x92, 0x16, 0xb5, 0x2a, 0x70, 0xb0, 0x53, 0x27, 0x36, 0xf5, 0x47, 0xb4,
+	0x76, 0x0a, 0xe5, 0x62, 0x4d, 0xe2, 0xf1, 0x76, 0x55, 0x7b, 0xd7, 0xec, 0xae, 0x4b, 0x2c, 0xb8,
+	0x70, 0xab, 0x7c, 0x02, 0x71, 0x03, 0x59, 0x42, 0xe2, 0xc8, 0x9d, 0xbf, 0xa1, 0x07, 0x0e, 0x3d,
+	0x72, 0xb2, 0xc0, 0x95, 0x90, 0xb8, 0xc2, 0x8d, 0x13, 0x9a, 0x99, 0x5d, 0x7b, 0x9d, 0x58, 0x89,
+	0xdd, 0xd3, 0xab, 0xde, 0x66, 0x9e, 0x8f, 0xdf, 0xf3, 0x3c, 0x33, 0xcf, 0xc7, 0xec, 0x42, 0xca,
+	0x76, 0x4c, 0x0b, 0x6b, 0xa4, 0x7d, 0x61, 0xf6, 0xfb, 0xa6, 0x91, 0x1f, 0x58, 0xa6, 0x63, 0xa2,
+	0xbb, 0xba, 0xd1, 0xed, 0x0d, 0x2f, 0x3b, 0xd8, 0xc1, 0xf9, 0x41, 0x0f, 0x3b, 0x5d, 0xd3, 0xea,
+	0xe7, 0x5d, 0x49, 0x39, 0xa5, 0x99, 0x9a, 0xc9, 0xe4, 0x0e, 0xe8, 0x8a, 0xab, 0xc8, 0x77, 0x35,
+	0xd3, 0xd4, 0x7a, 0xe4, 0x80, 0xed, 0xce, 0x87, 0xdd, 0x03, 0xd2, 0x1f, 0x38, 0x23, 0x97, 0xf9,
+	0xc5, 0x55, 0x26, 0x36, 0x3c, 0xd6, 0xce, 0xc0, 0x22, 0x1d, 0xfd, 0x02, 0x3b, 0x84, 0x13, 0x72,
+	0xff, 0x16, 0x60, 0x57, 0x25, 0xb8, 0x73, 0xac, 0xf7, 0x1c, 0x62, 0xa9, 0xe4, 0x17, 0x43, 0x62,
+	0x3b, 0xa8, 0x04, 0x71, 0x8b, 0xe0, 0x4e, 0xdb, 0x36, 0x87, 0xd6, 0x05, 0x49, 0x0b, 0x8a, 0xb0,
+	0x1f, 0x3f, 0x4c, 0xe5, 0x39, 0x6e, 0xde, 0xc3, 0xcd, 0x17, 0x8c, 0x51, 0x31, 0x39, 0x9b, 0x66,
+	0x81, 0x22, 0x34, 0x99, 0xac, 0x0a, 0xd6, 0x7c, 0x8d, 0x4e, 0x20, 0x62, 0x61, 0x43, 0x23, 0xe9,
+	0x20, 0x03, 0xb8, 0x97, 0xbf, 0x21, 0xd0, 0x7c, 0x4b, 0xef, 0x13, 0xdb, 0xc1, 0xfd, 0x81, 0x4a,
+	0x55, 0x8a, 0xe1, 0xf7, 0xd3, 0x6c, 0x40, 0xe5, 0xfa, 0xe8, 0x39, 0x88, 0x73, 0xc7, 0xd3, 0x21,
+	0x06, 0xf6, 0xd5, 0x8d, 0x60, 0xa7, 0x9e, 0xb4, 0xba, 0x50, 0xcc, 0xfd, 0x2d, 0x02, 0x12, 0xf5,
+	0xf4, 0xc4, 0x32, 0x87, 0x83, 0xcf, 0x3a, 0x54, 0x74, 0x1f, 0x40, 0xa3, 0x51, 0xb6, 0xdf, 0x90,
+	0x91, 0x9d, 0x0e, 0x2b, 0xa1, 0x7d, 0xb1, 0xb8, 0x3d, 0x9b, 0x66, 0x45, 0x16, 0xfb, 0x0b, 0x32,
+	0xb2, 0x55, 0x51, 0xf3, 0x96, 0xa8, 0x02, 0x11, 0xb6, 0x49, 0x47, 0x14, 0x61, 0x3f, 0x79, 0xf8,
+	0xe8, 0x46, 0x7b, 0x57, 0x4f, 0x30, 0xcf, 0x37, 0x1c, 0x81, 0xba, 0x8f, 0x35, 0xcd, 0x22, 0x1a,
+	0x75, 0x3f, 0xba, 0x86, 0xfb, 0x05, 0x4f, 0x5a, 0x5d, 0x28, 0xa2, 0xfb, 0x10, 0x79, 0xad, 0x1b,
+	0x8e, 0x9d, 0x8e, 0x29, 0xc2, 0x7e, 0xac, 0xb8, 0x37, 0x9b, 0x66, 0x23, 0x65, 0x4a, 0xf8, 0xdf,
+	0x34, 0x2b, 0xd2, 0xc5, 0x71, 0x0f, 0x6b, 0xb6, 0xca, 0x85, 0x72, 0x27, 0x10, 0x61, 0x3e, 0xa0,
+	0x2f, 0x01, 0x4e, 0xd4, 0xc6, 0xd9, 0x69, 0xbb, 0xde, 0xa8, 0x97, 0xa4, 0x80, 0xbc, 0x3d, 0x9e,
+	0x28, 0x3c, 0xe2, 0xba, 0x69, 0x10, 0xf4, 0x05, 0x6c, 0x71, 0x76, 0xf1, 0x95, 0x14, 0x94, 0xe3,
+	0xe3, 0x89, 0x12, 0x63, 0xcc, 0xe2, 0x48, 0x0e, 0xbf, 0xfb, 0x73, 0x26, 0x90, 0xfb, 0x8b, 0x00,
+	0x0b, 0x74, 0x74, 0x17, 0xc4, 0x72, 0xa5, 0xde, 0xf2, 0xc0, 0x12, 0xe3, 0x89, 0xb2, 0x45, 0xb9,
+	0x0c, 0xeb, 0x3b, 0x90, 0x74, 0x99, 0xed, 0xd3, 0x46, 0xa5, 0xde, 0x6a, 0x4a, 0x82, 0x2c, 0x8d,
+	0x27, 0x4a, 0x82, 0x4b, 0x9c, 0x9a, 0xd4, 0x33, 0xbf, 0x54, 0xb3, 0xa4, 0x56, 0x4a, 0x4d, 0x29,
+	0xe8, 0x97, 0x6a, 0x12, 0x4b, 0x27, 0x36, 0x3a, 0x80, 0x14, 0x93, 0x6a, 0x1e, 0x95, 0x4b, 0xb5,
+	0x42, 0xbb, 0x50, 0xad, 0xb6, 0x5b, 0x95, 0x5a, 0x49, 0x0a, 0xcb, 0xdf, 0x18, 0x4f, 0x94, 0x5d,
+	0x2a, 0xdb, 0xbc, 0x78, 0x4d, 0xfa, 0xb8, 0xd0, 0xeb, 0xd1, 0xd4, 0x71, 0xbd, 0xfd, 0x4f, 0x10,
+	0x64, 0x7a, 0x19, 0x3f, 0xd5, 0x8d, 0x8e, 0xf9, 0xcb, 0xc5, 0x39, 0x7e, 0xd6, 0x89, 0xad, 0x40,
+	0x9c, 0xc7, 0x5b, 0x7a, 0x4b, 0xac, 0x51, 0x3a, 0xac, 0x08, 0xfb, 0x21, 0xd5, 0x4f, 0x42, 0x7b,
+	0x10, 0x6d, 0x74, 0xbb, 0x36, 0x71, 0x58, 0xfa, 0x85, 0x54, 0x77, 0xb7, 0x9c, 0x99, 0x11, 0x25,
+	0xf4, 0x49, 0x99, 0x99, 0xfb, 0x6f, 0x10, 0xc4, 0x39, 0x03, 0x95, 0x21, 0xec, 0x8c, 0x06, 0xfc,
+	0x70, 0x93, 0x87, 0x8f, 0xd7, 0x83, 0x5b, 0xac, 0x5a, 0xa3, 0x01, 0x51, 0x19, 0x42, 0xee, 0x8f,
+	0x41, 0xd8, 0x5e, 0xa2, 0xa3, 0x2c, 0x84, 0xdd, 0xcc, 0x63, 0x59, 0xb0, 0xc4, 0x64, 0x29, 0xf8,
+	0x25, 0x84, 0x9a, 0x67, 0x35, 0x49, 0x90, 0x53, 0xe3, 0x89, 0x22, 0x2d, 0xf1, 0x9b, 0xc3, 0x3e,
+	0xfa, 0x36, 0x44, 0x8e, 0x1a, 0x67, 0xf5, 0x96, 0x14, 0x94, 0xf7, 0xc6, 0x13, 0x05, 0x2d, 0x09,
+	0x1c, 0x99, 0x43, 0xc3, 0xa1, 0x08, 0xb5, 0x4a, 0x5d, 0x0a, 0xad, 0x40, 0xa8, 0xe9, 0x06, 0x63,
+	0x17, 0x7e, 0x26, 0x85, 0x57, 0xb1, 0xf1, 0x25, 0x35, 0x70, 0x5c, 0x51, 0x9b, 0x2d, 0x29, 0xb2,
+	0xc2, 0xc0, 0xb1, 0x6e, 0xd9, 0x0e, 0x8d, 0xa1, 0x5a, 0x68, 0xb6, 0xa4, 0xe8, 0x8a, 0x18, 0xaa,
+	0x98, 0x0b, 0xd4, 0x4a, 0x85, 0xba, 0x14, 0x5b, 0x21, 0x50, 0x23, 0xd8, 0x70, 0x53, 0xfd, 0x01,
+	0x84, 0x5a, 0x58, 0x43, 0x12, 0x84, 0xde, 0x90, 0x11, 0x3b, 0xed, 0x84, 0x4a, 0x97, 0x28, 0x05,
+	0x91, 0xb7, 0xb8, 0x37, 0xe4, 0xd9, 0x99, 0x50, 0xf9, 0x26, 0xf7, 0xbb, 0x24, 0x24, 0x68, 0x3a,
+	0xab, 0xc4, 0x1e, 0x98, 0x86, 0x4d, 0x50, 0x0d, 0xa2, 0x5d, 0x0b, 0xf7, 0x89, 0x9d, 0x16, 0xd8,
+	0xc5, 0x1f, 0xdc, 0xda, 0xe1, 0x3c, 0xd5, 0xfc, 0x31, 0xd5, 0x73, 0x33, 0xd9, 0x05, 0x91, 0xdf,
+	0x45, 0x21, 0xc2, 0xe8, 0xa8, 0xea, 0x75, 0xce, 0x18, 0x4b, 0xe8, 0xc7, 0xeb, 0xe3, 0xb2, 0xce,
+	0xc3, 0x40, 0xca, 0x01, 0xaf, 0x79, 0x36, 0x20, 0x6a, 0xb3, 0x96, 0xe0, 0x56, 0xeb, 0x0f, 0xd6,
+	0x87, 0xe3, 0xad, 0xc4, 0xc3, 0x73, 0x61, 0xd0, 0x00, 0x12, 0xdd, 0x9e, 0x89, 0x9d, 0xf6, 0x80,
+	0xf5, 0x23, 0xb7, 0x86, 0x9f, 0x6d, 0x10, 0x3d, 0xd5, 0xe6, 0xcd, 0x8c, 0x1f, 0xc4, 0xce, 0x6c,
+	0x9a, 0x8d, 0xfb, 0xa8, 0xe5, 0x80, 0x1a, 0xef, 0x2e, 0xb6, 0xe8, 0x12, 0x92, 0xba, 0xe1, 0x10,
+	0x8d, 0x58, 0x9e, 0x4d, 0x5e, 0xea, 0x3f, 0x5a, 0xdf, 0x66, 0x85, 0xeb, 0xfb, 0xad, 0xee, 0xce,
+	0xa6, 0xd9, 0xed, 0x25, 0x7a, 0x39, 0xa0, 0x6e, 0xeb, 0x7e, 0x02, 0xfa, 0x35, 0xec, 0x0c, 0x0d,
+	0x5b, 0xd7, 0x0c, 0xd2, 0xf1, 0x4c, 0x87, 0x99, 0xe9, 0x1f, 0xaf, 0x6f, 0xfa, 0xcc, 0x05, 0xf0,
+	0xdb, 0x46, 0xb3, 0x69, 0x36, 0xb9, 0xcc, 0x28, 0x07, 0xd4, 0xe4, 0x70, 0x89, 0x42, 0xe3, 0x3e,
+	0x37, 0xcd, 0x1e, 0xc1, 0x86, 0x67, 0x3c, 0xb2, 0x69, 0xdc, 0x45, 0xae, 0x7f, 0x2d, 0xee, 0x25,
+	0x3a, 0x8d, 0xfb, 0xdc, 0x4f, 0x40, 0x0e, 0x6c, 0xdb, 0x8e, 0xa5, 0x1b, 0x9a, 0x67, 0x98, 0x4f,
+	0xdd, 0x1f, 0x6e, 0x90, 0x3b, 0x4c, 0xdd, 0x6f, 0x57, 0x9a, 0x4d, 0xb3, 0x09, 0x3f, 0xb9, 0x1c,
+	0x50, 0x13, 0xb6, 0x6f, 0x5f, 0x8c, 0x42, 0x98, 0x22, 0xcb, 0x97, 0x00, 0x8b, 0x4c, 0x46, 0x5f,
+	0xc1, 0x96, 0x83, 0x35, 0xfe, 0xe8, 0xa0, 0x95, 0x96, 0x28, 0xc6, 0x67, 0xd3, 0x6c, 0xac, 0x85,
+	0x35, 0xf6, 0xe4, 0x88, 0x39, 0x7c, 0x81, 0x8a, 0x80, 0x06, 0xd8, 0x72, 0x74, 0x47, 0x37, 0x0d,
+	0x2a, 0xdd, 0x7e, 0x8b, 0x7b, 0x34, 0x3b, 0xa9, 0x46, 0x6a, 0x36, 0xcd, 0x4a, 0xa7, 0x1e, 0xf7,
+	0x05, 0x19, 0xbd, 0xc4, 0x3d, 0x5b, 0x95, 0x06, 0x57, 0x28, 0xf2, 0x1f, 0x04, 0x88, 0xfb, 0xb2,
+	0x1e, 0x3d, 0x83, 0xb0, 0x83, 0x35, 0xaf, 0xc2, 0x95, 0x9b, 0xe7, 0x14, 0xd6, 0xdc, 0x92, 0x66,
+	0x3a, 0xa8, 0x01, 0x22, 0x15, 0x6c, 0xb3, 0x66, 0x1e, 0x64, 0xcd, 0xfc, 0x70, 0xfd, 0xf3, 0x7b,
+	0x8e, 0x1d, 0xcc, 0x5a, 0xf9, 0x56, 0xc7, 0x5d, 0xc9, 0x3f, 0x01, 0xe9, 0x6a, 0xe9, 0xa0, 0x0c,
+	0x80, 0xe3, 0xcd, 0x47, 0xee, 0xa6, 0xa4, 0xfa, 0x28, 0x74, 0x70, 0xb1, 0xf6, 0xc5, 0x0f, 0x42,
+	0x50, 0xdd, 0x9d, 0x5c, 0x05, 0x74, 0xbd, 0x24, 0x36, 0x44, 0x0b, 0xcd, 0xd1, 0x6a, 0x70, 0x67,
+	0x45, 0x96, 0x6f, 0x08, 0x17, 0xf6, 0x3b, 0x77, 0x3d, 0x6f, 0x37, 0x44, 0xdb, 0x9a, 0xa3, 0xbd,
+	0x80, 0xdd, 0x6b, 0xc9, 0xb8, 0x21, 0x98, 0xe8, 0x81, 0xe5, 0x9a, 0x20, 0x32, 0x00, 0x77, 0x9a,
+	0x46, 0xdd, 0x17, 0x58, 0x40, 0xbe, 0x33, 0x9e, 0x28, 0x3b, 0x73, 0x96, 0xfb, 0x08, 0xcb, 0x42,
+	0x74, 0xfe, 0x90, 0x5b, 0x16, 0xe0, 0xbe, 0xb8, 0x93, 0xe8, 0xaf, 0x02, 0x6c, 0x79, 0xf7, 0x8d,
+	0xbe, 0x05, 0x91, 0xe3, 0x6a, 0xa3, 0xd0, 0x92, 0x02, 0xf2, 0xee, 0x78, 0xa2, 0x6c, 0x7b, 0x0c,
+	0x76, 0xf5, 0x48, 0x81, 0x58, 0xa5, 0xde, 0x2a, 0x9d, 0x94, 0x54, 0x0f, 0xd2, 0xe3, 0xbb, 0xd7,
+	0x89, 0x72, 0xb0, 0x75, 0x56, 0x6f, 0x56, 0x4e, 0xea, 0xa5, 0xe7, 0x52, 0x90, 0x4f, 0x59, 0x4f,
+	0xc4, 0xbb, 0x23, 0x8a, 0x52, 0x6c, 0x34, 0xaa, 0x74, 0x48, 0x86, 0x96, 0x51, 0xdc, 0x73, 0x47,
+	0x19, 0x88, 0x36, 0x5b, 0x6a, 0xa5, 0x7e, 0x22, 0x85, 0x65, 0x34, 0x9e, 0x28, 0x49, 0x4f, 0x80,
+	0x1f, 0xa5, 0xeb, 0xf8, 0x9f, 0x04, 0x48, 0x1d, 0xe1, 0x01, 0x3e, 0xd7, 0x7b, 0xba, 0xa3, 0x13,
+	0x7b, 0x3e, 0x1b, 0x1b, 0x10, 0xbe, 0xc0, 0x03, 0xaf, 0x6e, 0x6e, 0x6e, 0x1b, 0xab, 0x00, 0x28,
+	0xd1, 0x2e, 0x19, 0x8e, 0x35, 0x52, 0x19, 0x90, 0xfc, 0x04, 0xc4, 0x39, 0xc9, 0x3f, 0xb2, 0xc5,
+	0x15, 0x23, 0x5b, 0x74, 0x47, 0xf6, 0xb3, 0xe0, 0x53, 0x21, 0xf7, 0x14, 0x92, 0xcb, 0x0f, 0x48,
+	0x2a, 0x6b, 0x3b, 0xd8, 0x72, 0x98, 0x7e, 0x48, 0xe5, 0x1b, 0x8a, 0x49, 0x8c, 0x0e, 0xd3, 0x0f,
+	0xa9, 0x74, 0x99, 0xfb, 0x97, 0x00, 0x49, 0xaf, 0xc9, 0x2c, 0x9e, 0xbf, 0xb4, 0xb4, 0xd7, 0x7e,
+	0xfe, 0xb6, 0xb0, 0x66, 0x7b, 0xcf, 0x5f, 0x67, 0xbe, 0xfe, 0xba, 0x7d, 0xc2, 0xfe, 0x26, 0x08,
+	0x52, 0x0b, 0x6b, 0x2f, 0x59, 0x86, 0x7f, 0xd6, 0xa1, 0xa2, 0x6f, 0x42, 0xcc, 0x9d, 0x25, 0x6c,
+	0x8e, 0x8b, 0x6a, 0x94, 0x4f, 0x8f, 0x5c, 0x1e, 0x52, 0x3c, 0xb3, 0xbd, 0x53, 0x70, 0x13, 0x79,
+	0xd1, 0x07, 0xd8, 0xe8, 0xf1, 0xfa, 0xc0, 0xe1, 0xef, 0x23, 0x10, 0x6b, 0x72, 0x4b, 0x48, 0x07,
+	0x58, 0xfc, 0xed, 0x40, 0xf9, 0x5b, 0x7b, 0xfc, 0xd2, 0x6f, 0x11, 0xf9, 0x7b, 0x6b, 0xcf, 0x84,
+	0x87, 0x02, 0xd2, 0x40, 0x9c, 0x7f, 0x2a, 0xa3, 0x07, 0x1b, 0x7d, 0x52, 0x6f, 0x66, 0xe8, 0x57,
+	0x70, 0x67, 0xc5, 0x67, 0x20, 0x7a, 0x72, 0x2b, 0xc6, 0xea, 0x0f, 0xc7, 0xcd, 0x8c, 0xbf, 0x01,
+	0x6f, 0xba, 0xa3, 0x7b, 0xb7, 0x8d, 0x5c, 0x5f, 0x79, 0xca, 0xdf, 0xbf, 0x51, 0x78, 0xd5, 0xfd,
+	0x3e, 0x14, 0x90, 0x09, 0xe2, 0x3c, 0xf9, 0x6f, 0x39, 0xd2, 0xab, 0x45, 0xf2, 0x69, 0x06, 0x5f,
+	0x41, 0xc2, 0xdf, 0xf2, 0xd0, 0xde, 0xb5, 0xa2, 0x2a, 0xd1, 0xff, 0x6e, 0xb7, 0x80, 0xaf, 0xea,
+	0x9a, 0xc5, 0xef, 0xbe, 0xff, 0x67, 0x26, 0xf0, 0x7e, 0x96, 0x11, 0x3e, 0xcc, 0x32, 0xc2, 0x3f,
+	0x66, 0x19, 0xe1, 0xb7, 0x1f, 0x33, 0x81, 0x0f, 0x1f, 0x33, 0x81, 0xbf, 0x7f, 0xcc, 0x04, 0x7e,
+	0xce, 0x9e, 0x23, 0xf4, 0x35, 0x62, 0x9f, 0x47, 0x99, 0xad, 0x47, 0xff, 0x1f, 0x00, 0xfa, 0x32,
+	0xb3, 0x8c, 0x3c, 0x14, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1135,6 +1205,8 @@ type StorageClient interface {
 	ReadFilter(ctx context.Context, in *ReadFilterRequest, opts ...grpc.CallOption) (Storage_ReadFilterClient, error)
 	// ReadGroup performs a group operation at storage
 	ReadGroup(ctx context.Context, in *ReadGroupRequest, opts ...grpc.CallOption) (Storage_ReadGroupClient, error)
+	// ReadWindowAggregate performs a windowed aggregate operation at storage
+	ReadWindowAggregate(ctx context.Context, in *ReadWindowAggregateRequest, opts ...grpc.CallOption) (Storage_ReadWindowAggregateClient, error)
 	// TagKeys performs a read operation for tag keys
 	TagKeys(ctx context.Context, in *TagKeysRequest, opts ...grpc.CallOption) (Storage_TagKeysClient, error)
 	// TagValues performs a read operation for tag values
@@ -1215,8 +1287,40 @@ func (x *storageReadGroupClient) Recv() (*ReadResponse, error) {
 	return m, nil
 }
 
+func (c *storageClient) ReadWindowAggregate(ctx context.Context, in *ReadWindowAggregateRequest, opts ...grpc.CallOption) (Storage_ReadWindowAggregateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[2], "/influxdata.platform.storage.Storage/ReadWindowAggregate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageReadWindowAggregateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ReadWindowAggregateClient interface {
+	Recv() (*ReadResponse, error)
+	grpc.ClientStream
+}
+
+type storageReadWindowAggregateClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageReadWindowAggregateClient) Recv() (*ReadResponse, error) {
+	m := new(ReadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *storageClient) TagKeys(ctx context.Context, in *TagKeysRequest, opts ...grpc.CallOption) (Storage_TagKeysClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[2], "/influxdata.platform.storage.Storage/TagKeys", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[3], "/influxdata.platform.storage.Storage/TagKeys", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1248,7 +1352,7 @@ func (x *storageTagKeysClient) Recv() (*StringValuesResponse, error) {
 }
 
 func (c *storageClient) TagValues(ctx context.Context, in *TagValuesRequest, opts ...grpc.CallOption) (Storage_TagValuesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[3], "/influxdata.platform.storage.Storage/TagValues", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Storage_serviceDesc.Streams[4], "/influxdata.platform.storage.Storage/TagValues", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1294,6 +1398,8 @@ type StorageServer interface {
 	ReadFilter(*ReadFilterRequest, Storage_ReadFilterServer) error
 	// ReadGroup performs a group operation at storage
 	ReadGroup(*ReadGroupRequest, Storage_ReadGroupServer) error
+	// ReadWindowAggregate performs a windowed aggregate operation at storage
+	ReadWindowAggregate(*ReadWindowAggregateRequest, Storage_ReadWindowAggregateServer) error
 	// TagKeys performs a read operation for tag keys
 	TagKeys(*TagKeysRequest, Storage_TagKeysServer) error
 	// TagValues performs a read operation for tag values
@@ -1312,6 +1418,9 @@ func (*UnimplementedStorageServer) ReadFilter(req *ReadFilterRequest, srv Storag
 func (*UnimplementedStorageServer) ReadGroup(req *ReadGroupRequest, srv Storage_ReadGroupServer) error {
 	return status.Errorf(codes.Unimplemented, "method ReadGroup not implemented")
 }
+func (*UnimplementedStorageServer) ReadWindowAggregate(req *ReadWindowAggregateRequest, srv Storage_ReadWindowAggregateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadWindowAggregate not implemented")
+}
 func (*UnimplementedStorageServer) TagKeys(req *TagKeysRequest, srv Storage_TagKeysServer) error {
 	return status.Errorf(codes.Unimplemented, "method TagKeys not implemented")
 }
@@ -1368,6 +1477,27 @@ func (x *storageReadGroupServer) Send(m *ReadResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Storage_ReadWindowAggregate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadWindowAggregateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ReadWindowAggregate(m, &storageReadWindowAggregateServer{stream})
+}
+
+type Storage_ReadWindowAggregateServer interface {
+	Send(*ReadResponse) error
+	grpc.ServerStream
+}
+
+type storageReadWindowAggregateServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageReadWindowAggregateServer) Send(m *ReadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Storage_TagKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TagKeysRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1448,6 +1578,11 @@ var _Storage_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Storage_ReadGroup_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ReadWindowAggregate",
+			Handler:       _Storage_ReadWindowAggregate_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "TagKeys",
 			Handler:       _Storage_TagKeys_Handler,
@@ -1608,6 +1743,87 @@ func (m *ReadGroupRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ReadWindowAggregateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadWindowAggregateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadWindowAggregateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Offset != 0 {
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Aggregate) > 0 {
+		for iNdEx := len(m.Aggregate) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Aggregate[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintStorageCommon(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.WindowEvery != 0 {
+		i = encodeVarintStorageCommon(dAtA, i, uint64(m.WindowEvery))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Predicate != nil {
+		{
+			size, err := m.Predicate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStorageCommon(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Range.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintStorageCommon(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.ReadSource != nil {
+		{
+			size, err := m.ReadSource.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStorageCommon(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Aggregate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1994,9 +2210,9 @@ func (m *ReadResponse_FloatPointsFrame) MarshalToSizedBuffer(dAtA []byte) (int,
 	_ = l
 	if len(m.Values) > 0 {
 		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
-			f15 := math.Float64bits(float64(m.Values[iNdEx]))
+			f18 := math.Float64bits(float64(m.Values[iNdEx]))
 			i -= 8
-			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f15))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f18))
 		}
 		i = encodeVarintStorageCommon(dAtA, i, uint64(len(m.Values)*8))
 		i--
@@ -2035,21 +2251,21 @@ func (m *ReadResponse_IntegerPointsFrame) MarshalToSizedBuffer(dAtA []byte) (int
 	var l int
 	_ = l
 	if len(m.Values) > 0 {
-		dAtA17 := make([]byte, len(m.Values)*10)
-		var j16 int
+		dAtA20 := make([]byte, len(m.Values)*10)
+		var j19 int
 		for _, num1 := range m.Values {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA17[j16] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j16++
+				j19++
 			}
-			dAtA17[j16] = uint8(num)
-			j16++
+			dAtA20[j19] = uint8(num)
+			j19++
 		}
-		i -= j16
-		copy(dAtA[i:], dAtA17[:j16])
-		i = encodeVarintStorageCommon(dAtA, i, uint64(j16))
+		i -= j19
+		copy(dAtA[i:], dAtA20[:j19])
+		i = encodeVarintStorageCommon(dAtA, i, uint64(j19))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -2086,20 +2302,20 @@ func (m *ReadResponse_UnsignedPointsFrame) MarshalToSizedBuffer(dAtA []byte) (in
 	var l int
 	_ = l
 	if len(m.Values) > 0 {
-		dAtA19 := make([]byte, len(m.Values)*10)
-		var j18 int
+		dAtA22 := make([]byte, len(m.Values)*10)
+		var j21 int
 		for _, num := range m.Values {
 			for num >= 1<<7 {
-				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA22[j21] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j18++
+				j21++
 			}
-			dAtA19[j18] = uint8(num)
-			j18++
+			dAtA22[j21] = uint8(num)
+			j21++
 		}
-		i -= j18
-		copy(dAtA[i:], dAtA19[:j18])
-		i = encodeVarintStorageCommon(dAtA, i, uint64(j18))
+		i -= j21
+		copy(dAtA[i:], dAtA22[:j21])
+		i = encodeVarintStorageCommon(dAtA, i, uint64(j21))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -2494,6 +2710,37 @@ func (m *ReadGroupRequest) Size() (n int) {
 	return n
 }
 
+func (m *ReadWindowAggregateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReadSource != nil {
+		l = m.ReadSource.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	l = m.Range.Size()
+	n += 1 + l + sovStorageCommon(uint64(l))
+	if m.Predicate != nil {
+		l = m.Predicate.Size()
+		n += 1 + l + sovStorageCommon(uint64(l))
+	}
+	if m.WindowEvery != 0 {
+		n += 1 + sovStorageCommon(uint64(m.WindowEvery))
+	}
+	if len(m.Aggregate) > 0 {
+		for _, e := range m.Aggregate {
+			l = e.Size()
+			n += 1 + l + sovStorageCommon(uint64(l))
+		}
+	}
+	if m.Offset != 0 {
+		n += 1 + sovStorageCommon(uint64(m.Offset))
+	}
+	return n
+}
+
 func (m *Aggregate) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3261,6 +3508,233 @@ func (m *ReadGroupRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ReadWindowAggregateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageCommon
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadWindowAggregateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadWindowAggregateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadSource", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadSource == nil {
+				m.ReadSource = &types.Any{}
+			}
+			if err := m.ReadSource.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Range", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Range.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Predicate == nil {
+				m.Predicate = &Predicate{}
+			}
+			if err := m.Predicate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowEvery", wireType)
+			}
+			m.WindowEvery = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WindowEvery |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aggregate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aggregate = append(m.Aggregate, &Aggregate{})
+			if err := m.Aggregate[len(m.Aggregate)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageCommon(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorageCommon
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Aggregate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
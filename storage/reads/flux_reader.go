@@ -260,6 +260,8 @@ func (gi *groupIterator) Do(f func(flux.Table) error) error {
 
 	if agg, err := determineAggregateMethod(gi.spec.AggregateMethod); err != nil {
 		return err
+	} else if agg != datatypes.AggregateTypeNone && agg != datatypes.AggregateTypeSum && agg != datatypes.AggregateTypeCount {
+		return fmt.Errorf("aggregate type %q is not supported when grouping", gi.spec.AggregateMethod)
 	} else if agg != datatypes.AggregateTypeNone {
 		req.Aggregate = &datatypes.Aggregate{Type: agg}
 	}
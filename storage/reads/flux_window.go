@@ -0,0 +1,376 @@
+package reads
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/flux/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+func (r *storeReader) ReadWindowAggregate(ctx context.Context, spec influxdb.ReadWindowAggregateSpec, alloc *memory.Allocator) (influxdb.TableIterator, error) {
+	return &windowAggregateIterator{
+		ctx:   ctx,
+		s:     r.s,
+		spec:  spec,
+		alloc: alloc,
+	}, nil
+}
+
+type windowAggregateIterator struct {
+	ctx   context.Context
+	s     Store
+	spec  influxdb.ReadWindowAggregateSpec
+	stats cursors.CursorStats
+	alloc *memory.Allocator
+}
+
+func (wai *windowAggregateIterator) Statistics() cursors.CursorStats { return wai.stats }
+
+func (wai *windowAggregateIterator) Do(f func(flux.Table) error) error {
+	src := wai.s.GetSource(
+		wai.spec.Database,
+		wai.spec.RetentionPolicy,
+	)
+
+	// Setup read request
+	any, err := types.MarshalAny(src)
+	if err != nil {
+		return err
+	}
+
+	var predicate *datatypes.Predicate
+	if wai.spec.Predicate != nil {
+		p, err := toStoragePredicate(wai.spec.Predicate)
+		if err != nil {
+			return err
+		}
+		predicate = p
+	}
+
+	if len(wai.spec.Aggregates) != 1 {
+		return fmt.Errorf("window aggregate requires exactly one aggregate, got %d", len(wai.spec.Aggregates))
+	}
+	agg, err := determineAggregateMethod(string(wai.spec.Aggregates[0]))
+	if err != nil {
+		return err
+	}
+
+	var req datatypes.ReadWindowAggregateRequest
+	req.ReadSource = any
+	req.Predicate = predicate
+	req.Range.Start = int64(wai.spec.Bounds.Start)
+	req.Range.End = int64(wai.spec.Bounds.Stop)
+	req.WindowEvery = wai.spec.WindowEvery
+	req.Offset = wai.spec.Offset
+	req.Aggregate = []*datatypes.Aggregate{{Type: agg}}
+
+	rs, err := wai.s.ReadWindowAggregate(wai.ctx, &req)
+	if err != nil {
+		return err
+	}
+
+	if rs == nil {
+		return nil
+	}
+	return wai.handleRead(f, rs, agg)
+}
+
+func (wai *windowAggregateIterator) handleRead(f func(flux.Table) error, rs ResultSet, agg datatypes.Aggregate_AggregateType) error {
+	defer rs.Close()
+
+	bnds := wai.spec.Bounds
+	w := newWindow(wai.spec.WindowEvery, wai.spec.Offset, int64(bnds.Stop))
+	for rs.Next() {
+		if err := wai.ctx.Err(); err != nil {
+			return err
+		}
+
+		cur := rs.Cursor()
+		if cur == nil {
+			// no data for series key + field combination
+			continue
+		}
+
+		pts := readWindowPoints(cur)
+		wai.stats.Add(cur.Stats())
+		cur.Close()
+		if len(pts.ts) == 0 {
+			continue
+		}
+
+		wins := wai.windows(w, agg, pts)
+		var err error
+		if wai.spec.TimeColumn == "" {
+			err = wai.emitWindowTables(f, rs.Tags(), agg, pts, wins)
+		} else {
+			err = wai.emitSeriesTable(f, rs.Tags(), agg, pts, wins)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return rs.Err()
+}
+
+// windowPoints holds the values produced by a window aggregate cursor,
+// at most one per window.
+type windowPoints struct {
+	typ flux.ColType
+	ts  []int64
+	vs  []values.Value
+}
+
+func readWindowPoints(cur cursors.Cursor) windowPoints {
+	var pts windowPoints
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		pts.typ = flux.TFloat
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, v := range a.Values {
+				pts.ts = append(pts.ts, a.Timestamps[i])
+				pts.vs = append(pts.vs, values.NewFloat(v))
+			}
+		}
+	case cursors.IntegerArrayCursor:
+		pts.typ = flux.TInt
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, v := range a.Values {
+				pts.ts = append(pts.ts, a.Timestamps[i])
+				pts.vs = append(pts.vs, values.NewInt(v))
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		pts.typ = flux.TUInt
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, v := range a.Values {
+				pts.ts = append(pts.ts, a.Timestamps[i])
+				pts.vs = append(pts.vs, values.NewUInt(v))
+			}
+		}
+	case cursors.StringArrayCursor:
+		pts.typ = flux.TString
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, v := range a.Values {
+				pts.ts = append(pts.ts, a.Timestamps[i])
+				pts.vs = append(pts.vs, values.NewString(v))
+			}
+		}
+	case cursors.BooleanArrayCursor:
+		pts.typ = flux.TBool
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, v := range a.Values {
+				pts.ts = append(pts.ts, a.Timestamps[i])
+				pts.vs = append(pts.vs, values.NewBool(v))
+			}
+		}
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+	return pts
+}
+
+// windowBounds identifies a window and the index of its point, or -1 if
+// the window is empty.
+type windowBounds struct {
+	start, stop int64
+	idx         int
+}
+
+// windows returns the windows of pts, including the empty windows between
+// them if CreateEmpty is set. Each window is clipped to the read bounds.
+func (wai *windowAggregateIterator) windows(w window, agg datatypes.Aggregate_AggregateType, pts windowPoints) []windowBounds {
+	start, stop := int64(wai.spec.Bounds.Start), int64(wai.spec.Bounds.Stop)
+	clip := func(t int64) int64 {
+		if t < start {
+			return start
+		}
+		return t
+	}
+
+	if !wai.spec.CreateEmpty {
+		wins := make([]windowBounds, len(pts.ts))
+		for i, t := range pts.ts {
+			wstop := w.key(agg, t)
+			wins[i] = windowBounds{start: clip(w.start(wstop - 1)), stop: wstop, idx: i}
+		}
+		return wins
+	}
+
+	var wins []windowBounds
+	i := 0
+	for ws := w.start(start); ws < stop; {
+		wstop := w.stop(ws)
+		b := windowBounds{start: clip(ws), stop: wstop, idx: -1}
+		if i < len(pts.ts) && w.key(agg, pts.ts[i]) == wstop {
+			b.idx = i
+			i++
+		}
+		wins = append(wins, b)
+		ws = wstop
+	}
+	return wins
+}
+
+// emptyWindowValue returns the aggregate of an empty window. A nil value is
+// null and row is false if the aggregate produces no row for the window.
+func emptyWindowValue(agg datatypes.Aggregate_AggregateType) (v values.Value, row bool) {
+	switch agg {
+	case datatypes.AggregateTypeCount:
+		return values.NewInt(0), true
+	case datatypes.AggregateTypeSum, datatypes.AggregateTypeMean:
+		return nil, true
+	default:
+		return nil, false
+	}
+}
+
+// emitWindowTables produces a table for each window, matching the output of
+// 'window() |> <aggregate>()'.
+func (wai *windowAggregateIterator) emitWindowTables(f func(flux.Table) error, tags models.Tags, agg datatypes.Aggregate_AggregateType, pts windowPoints, wins []windowBounds) error {
+	selector := IsSelector(agg)
+	for _, win := range wins {
+		key := defaultGroupKeyForSeries(tags, execute.Bounds{
+			Start: execute.Time(win.start),
+			Stop:  execute.Time(win.stop),
+		})
+		b, idx, err := wai.newWindowTableBuilder(key, tags, pts.typ, selector)
+		if err != nil {
+			return err
+		}
+
+		if win.idx >= 0 {
+			err = appendWindowRow(b, idx, tags, win.start, win.stop, pts.ts[win.idx], pts.vs[win.idx])
+		} else if v, ok := emptyWindowValue(agg); ok {
+			err = appendWindowRow(b, idx, tags, win.start, win.stop, 0, v)
+		}
+		if err != nil {
+			return err
+		}
+
+		tbl, err := b.Table()
+		if err != nil {
+			return err
+		}
+		b.ClearData()
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emitSeriesTable produces a single table with a row for each window,
+// matching the output of aggregateWindow().
+func (wai *windowAggregateIterator) emitSeriesTable(f func(flux.Table) error, tags models.Tags, agg datatypes.Aggregate_AggregateType, pts windowPoints, wins []windowBounds) error {
+	bnds := wai.spec.Bounds
+	key := defaultGroupKeyForSeries(tags, bnds)
+	selector := IsSelector(agg)
+	b, idx, err := wai.newWindowTableBuilder(key, tags, pts.typ, selector)
+	if err != nil {
+		return err
+	}
+	defer b.ClearData()
+
+	for _, win := range wins {
+		t := win.stop
+		if wai.spec.TimeColumn == execute.DefaultStartColLabel {
+			t = win.start
+		}
+
+		if win.idx >= 0 {
+			err = appendWindowRow(b, idx, tags, int64(bnds.Start), int64(bnds.Stop), t, pts.vs[win.idx])
+		} else if v, ok := emptyWindowValue(agg); ok {
+			err = appendWindowRow(b, idx, tags, int64(bnds.Start), int64(bnds.Stop), t, v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tbl, err := b.Table()
+	if err != nil {
+		return err
+	}
+	return f(tbl)
+}
+
+// windowColumns holds the column indexes of a window table.
+type windowColumns struct {
+	start, stop, time, value int
+	tags                     []int
+}
+
+// newWindowTableBuilder returns a builder with the columns produced by
+// flux for the aggregate. Selectors keep the columns of the input table,
+// whereas aggregates produce the group key followed by _value. The _time
+// column of an aggregate is added by the subsequent duplicate, if any.
+func (wai *windowAggregateIterator) newWindowTableBuilder(key flux.GroupKey, tags models.Tags, typ flux.ColType, selector bool) (*execute.ColListTableBuilder, windowColumns, error) {
+	cols := make([]flux.ColMeta, 0, 4+len(tags))
+	idx := windowColumns{time: -1, tags: make([]int, len(tags))}
+	add := func(label string, typ flux.ColType) int {
+		cols = append(cols, flux.ColMeta{Label: label, Type: typ})
+		return len(cols) - 1
+	}
+	addTags := func() {
+		for i := range tags {
+			idx.tags[i] = add(string(tags[i].Key), flux.TString)
+		}
+	}
+
+	idx.start = add(execute.DefaultStartColLabel, flux.TTime)
+	idx.stop = add(execute.DefaultStopColLabel, flux.TTime)
+	if selector {
+		idx.time = add(execute.DefaultTimeColLabel, flux.TTime)
+		idx.value = add(execute.DefaultValueColLabel, typ)
+		addTags()
+	} else {
+		addTags()
+		idx.value = add(execute.DefaultValueColLabel, typ)
+		if wai.spec.TimeColumn != "" {
+			idx.time = add(execute.DefaultTimeColLabel, flux.TTime)
+		}
+	}
+
+	b := execute.NewColListTableBuilder(key, wai.alloc)
+	for _, col := range cols {
+		if _, err := b.AddCol(col); err != nil {
+			return nil, idx, err
+		}
+	}
+	return b, idx, nil
+}
+
+func appendWindowRow(b *execute.ColListTableBuilder, idx windowColumns, tags models.Tags, start, stop, t int64, v values.Value) error {
+	if err := b.AppendTime(idx.start, execute.Time(start)); err != nil {
+		return err
+	}
+	if err := b.AppendTime(idx.stop, execute.Time(stop)); err != nil {
+		return err
+	}
+	if idx.time >= 0 {
+		if err := b.AppendTime(idx.time, execute.Time(t)); err != nil {
+			return err
+		}
+	}
+	if v == nil {
+		if err := b.AppendNil(idx.value); err != nil {
+			return err
+		}
+	} else if err := b.AppendValue(idx.value, v); err != nil {
+		return err
+	}
+	for i, j := range idx.tags {
+		if err := b.AppendString(j, string(tags[i].Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
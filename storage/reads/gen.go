@@ -1 +1,3 @@
 package reads
+
+//go:generate tmpl -data=@window_array_cursor.gen.go.tmpldata window_array_cursor.gen.go.tmpl
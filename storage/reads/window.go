@@ -0,0 +1,61 @@
+package reads
+
+import (
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+)
+
+// window describes a sequence of fixed-width windows aligned to the Unix
+// epoch plus an offset. Windows are clipped to the exclusive end of the
+// requested time range.
+type window struct {
+	every  int64
+	offset int64
+	end    int64
+}
+
+func newWindow(every, offset, end int64) window {
+	offset %= every
+	if offset < 0 {
+		offset += every
+	}
+	return window{every: every, offset: offset, end: end}
+}
+
+// start returns the start of the window containing t.
+func (w window) start(t int64) int64 {
+	d := (t%w.every - w.offset) % w.every
+	if d < 0 {
+		d += w.every
+	}
+	return t - d
+}
+
+// stop returns the exclusive stop of the window containing t.
+func (w window) stop(t int64) int64 {
+	s := w.start(t)
+	if stop := s + w.every; stop > s && stop < w.end {
+		return stop
+	}
+	return w.end
+}
+
+// key returns a value identifying the window of a point produced by a
+// window cursor for agg. Aggregates are timestamped with the stop of their
+// window, whereas selectors keep the time of the selected point.
+func (w window) key(agg datatypes.Aggregate_AggregateType, t int64) int64 {
+	if IsSelector(agg) {
+		return w.stop(t)
+	}
+	return t
+}
+
+// IsSelector reports whether agg selects an existing point from each window
+// rather than computing a new value.
+func IsSelector(agg datatypes.Aggregate_AggregateType) bool {
+	switch agg {
+	case datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast,
+		datatypes.AggregateTypeMin, datatypes.AggregateTypeMax:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,1889 @@
+// Generated by tmpl
+// https://github.com/benbjohnson/tmpl
+//
+// DO NOT EDIT!
+// Source: window_array_cursor.gen.go.tmpl
+
+package reads
+
+import (
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+func newWindowSumArrayCursor(cur cursors.Cursor, w window) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowSumArrayCursor(cur, w)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowSumArrayCursor(cur, w)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowSumArrayCursor(cur, w)
+	default:
+		return nil
+	}
+}
+
+func newWindowCountArrayCursor(cur cursors.Cursor, w window) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newIntegerFloatWindowCountArrayCursor(cur, w)
+	case cursors.IntegerArrayCursor:
+		return newIntegerIntegerWindowCountArrayCursor(cur, w)
+	case cursors.UnsignedArrayCursor:
+		return newIntegerUnsignedWindowCountArrayCursor(cur, w)
+	case cursors.StringArrayCursor:
+		return newIntegerStringWindowCountArrayCursor(cur, w)
+	case cursors.BooleanArrayCursor:
+		return newIntegerBooleanWindowCountArrayCursor(cur, w)
+	default:
+		return nil
+	}
+}
+
+func newWindowMeanArrayCursor(cur cursors.Cursor, w window) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatFloatWindowMeanArrayCursor(cur, w)
+	case cursors.IntegerArrayCursor:
+		return newFloatIntegerWindowMeanArrayCursor(cur, w)
+	case cursors.UnsignedArrayCursor:
+		return newFloatUnsignedWindowMeanArrayCursor(cur, w)
+	default:
+		return nil
+	}
+}
+
+func newWindowSelectorArrayCursor(cur cursors.Cursor, w window, agg datatypes.Aggregate_AggregateType) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowSelectorArrayCursor(cur, w, agg)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowSelectorArrayCursor(cur, w, agg)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowSelectorArrayCursor(cur, w, agg)
+	case cursors.StringArrayCursor:
+		if agg == datatypes.AggregateTypeMin || agg == datatypes.AggregateTypeMax {
+			return nil
+		}
+		return newStringWindowSelectorArrayCursor(cur, w, agg)
+	case cursors.BooleanArrayCursor:
+		if agg == datatypes.AggregateTypeMin || agg == datatypes.AggregateTypeMax {
+			return nil
+		}
+		return newBooleanWindowSelectorArrayCursor(cur, w, agg)
+	default:
+		return nil
+	}
+}
+
+// mergeWindowArrayCursor reads all of src and combines the windows with
+// those already accumulated in dst, which is either nil or a cursor
+// previously returned by mergeWindowArrayCursor.
+func mergeWindowArrayCursor(dst, src cursors.Cursor, w window, agg datatypes.Aggregate_AggregateType) cursors.Cursor {
+	switch src := src.(type) {
+	case cursors.FloatArrayCursor:
+		a := readFloatArrayCursor(src)
+		stats := src.Stats()
+		src.Close()
+		if dst, ok := dst.(*floatWindowMergedArrayCursor); ok {
+			dst.res = mergeFloatWindowArrays(dst.res, a, w, agg)
+			dst.stats.Add(stats)
+			return dst
+		}
+		return &floatWindowMergedArrayCursor{res: a, stats: stats}
+	case cursors.IntegerArrayCursor:
+		a := readIntegerArrayCursor(src)
+		stats := src.Stats()
+		src.Close()
+		if dst, ok := dst.(*integerWindowMergedArrayCursor); ok {
+			dst.res = mergeIntegerWindowArrays(dst.res, a, w, agg)
+			dst.stats.Add(stats)
+			return dst
+		}
+		return &integerWindowMergedArrayCursor{res: a, stats: stats}
+	case cursors.UnsignedArrayCursor:
+		a := readUnsignedArrayCursor(src)
+		stats := src.Stats()
+		src.Close()
+		if dst, ok := dst.(*unsignedWindowMergedArrayCursor); ok {
+			dst.res = mergeUnsignedWindowArrays(dst.res, a, w, agg)
+			dst.stats.Add(stats)
+			return dst
+		}
+		return &unsignedWindowMergedArrayCursor{res: a, stats: stats}
+	case cursors.StringArrayCursor:
+		a := readStringArrayCursor(src)
+		stats := src.Stats()
+		src.Close()
+		if dst, ok := dst.(*stringWindowMergedArrayCursor); ok {
+			dst.res = mergeStringWindowArrays(dst.res, a, w, agg)
+			dst.stats.Add(stats)
+			return dst
+		}
+		return &stringWindowMergedArrayCursor{res: a, stats: stats}
+	case cursors.BooleanArrayCursor:
+		a := readBooleanArrayCursor(src)
+		stats := src.Stats()
+		src.Close()
+		if dst, ok := dst.(*booleanWindowMergedArrayCursor); ok {
+			dst.res = mergeBooleanWindowArrays(dst.res, a, w, agg)
+			dst.stats.Add(stats)
+			return dst
+		}
+		return &booleanWindowMergedArrayCursor{res: a, stats: stats}
+	default:
+		return dst
+	}
+}
+
+// ********************
+// Float Window Array Cursors
+
+type floatWindowSumArrayCursor struct {
+	cursors.FloatArrayCursor
+	window window
+	res    *cursors.FloatArray
+	tmp    *cursors.FloatArray
+}
+
+func newFloatWindowSumArrayCursor(cur cursors.FloatArrayCursor, w window) *floatWindowSumArrayCursor {
+	return &floatWindowSumArrayCursor{
+		FloatArrayCursor: cur,
+		window:           w,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+		tmp:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatWindowSumArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.FloatArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.FloatArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		acc  float64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = acc
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				acc = 0
+			}
+			acc += a.Values[i]
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+type floatFloatWindowMeanArrayCursor struct {
+	cursors.FloatArrayCursor
+	window window
+	res    *cursors.FloatArray
+	tmp    *cursors.FloatArray
+}
+
+func newFloatFloatWindowMeanArrayCursor(cur cursors.FloatArrayCursor, w window) *floatFloatWindowMeanArrayCursor {
+	return &floatFloatWindowMeanArrayCursor{
+		FloatArrayCursor: cur,
+		window:           w,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+		tmp:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatFloatWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatFloatWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.FloatArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.FloatArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		acc  float64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = acc / float64(n)
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				acc = 0
+			}
+			acc += float64(a.Values[i])
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = acc / float64(n)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// meanFloatWindowArrays divides the per-window sums by the per-window
+// counts. Both arrays are keyed by the stop time of each window.
+func meanFloatWindowArrays(sum *cursors.FloatArray, count *cursors.IntegerArray) *cursors.FloatArray {
+	res := &cursors.FloatArray{
+		Timestamps: make([]int64, 0, sum.Len()),
+		Values:     make([]float64, 0, sum.Len()),
+	}
+	i, j := 0, 0
+	for i < sum.Len() && j < count.Len() {
+		switch ts := sum.Timestamps[i]; {
+		case ts < count.Timestamps[j]:
+			i++
+		case ts > count.Timestamps[j]:
+			j++
+		default:
+			if n := count.Values[j]; n > 0 {
+				res.Timestamps = append(res.Timestamps, ts)
+				res.Values = append(res.Values, float64(sum.Values[i])/float64(n))
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+type integerFloatWindowCountArrayCursor struct {
+	cursors.FloatArrayCursor
+	window window
+	res    *cursors.IntegerArray
+	tmp    *cursors.FloatArray
+}
+
+func newIntegerFloatWindowCountArrayCursor(cur cursors.FloatArrayCursor, w window) *integerFloatWindowCountArrayCursor {
+	return &integerFloatWindowCountArrayCursor{
+		FloatArrayCursor: cur,
+		window:           w,
+		res:              cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:              &cursors.FloatArray{},
+	}
+}
+
+func (c *integerFloatWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *integerFloatWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.FloatArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.FloatArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		n    int64
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = n
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = n
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// floatWindowSelectorArrayCursor selects a single point from each
+// window. Unlike the aggregate cursors, the selected point keeps its
+// original timestamp.
+type floatWindowSelectorArrayCursor struct {
+	cursors.FloatArrayCursor
+	window window
+	agg    datatypes.Aggregate_AggregateType
+	res    *cursors.FloatArray
+	tmp    *cursors.FloatArray
+}
+
+func newFloatWindowSelectorArrayCursor(cur cursors.FloatArrayCursor, w window, agg datatypes.Aggregate_AggregateType) *floatWindowSelectorArrayCursor {
+	return &floatWindowSelectorArrayCursor{
+		FloatArrayCursor: cur,
+		window:           w,
+		agg:              agg,
+		res:              cursors.NewFloatArrayLen(MaxPointsPerBlock),
+		tmp:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.FloatArrayCursor.Stats()
+}
+
+func (c *floatWindowSelectorArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.FloatArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.FloatArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		ts   int64
+		v    float64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = v
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				ts, v = t, a.Values[i]
+			} else if floatWindowSelect(c.agg, ts, v, t, a.Values[i]) {
+				ts, v = t, a.Values[i]
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.FloatArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = v
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// floatWindowSelect reports whether the candidate point (t, v) should
+// replace the currently selected point (ts, cur) of a window.
+func floatWindowSelect(agg datatypes.Aggregate_AggregateType, ts int64, cur float64, t int64, v float64) bool {
+	switch agg {
+	case datatypes.AggregateTypeFirst:
+		return t < ts
+	case datatypes.AggregateTypeLast:
+		return t > ts
+	case datatypes.AggregateTypeMin:
+		return v < cur || (v == cur && t < ts)
+	case datatypes.AggregateTypeMax:
+		return v > cur || (v == cur && t < ts)
+	}
+	return false
+}
+
+// mergeFloatWindowArrays combines two sets of partial window aggregates,
+// each ordered by time, into a single set.
+func mergeFloatWindowArrays(a, b *cursors.FloatArray, w window, agg datatypes.Aggregate_AggregateType) *cursors.FloatArray {
+	res := &cursors.FloatArray{
+		Timestamps: make([]int64, 0, a.Len()+b.Len()),
+		Values:     make([]float64, 0, a.Len()+b.Len()),
+	}
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		ka, kb := w.key(agg, a.Timestamps[i]), w.key(agg, b.Timestamps[j])
+		switch {
+		case ka < kb:
+			res.Timestamps = append(res.Timestamps, a.Timestamps[i])
+			res.Values = append(res.Values, a.Values[i])
+			i++
+		case ka > kb:
+			res.Timestamps = append(res.Timestamps, b.Timestamps[j])
+			res.Values = append(res.Values, b.Values[j])
+			j++
+		default:
+			ts, v := a.Timestamps[i], a.Values[i]
+			switch agg {
+			case datatypes.AggregateTypeSum, datatypes.AggregateTypeCount:
+				v += b.Values[j]
+			default:
+				if floatWindowSelect(agg, ts, v, b.Timestamps[j], b.Values[j]) {
+					ts, v = b.Timestamps[j], b.Values[j]
+				}
+			}
+			res.Timestamps = append(res.Timestamps, ts)
+			res.Values = append(res.Values, v)
+			i++
+			j++
+		}
+	}
+	res.Timestamps = append(res.Timestamps, a.Timestamps[i:]...)
+	res.Values = append(res.Values, a.Values[i:]...)
+	res.Timestamps = append(res.Timestamps, b.Timestamps[j:]...)
+	res.Values = append(res.Values, b.Values[j:]...)
+	return res
+}
+
+// readFloatArrayCursor reads all remaining values from cur into a
+// newly allocated array.
+func readFloatArrayCursor(cur cursors.FloatArrayCursor) *cursors.FloatArray {
+	res := &cursors.FloatArray{}
+	for {
+		a := cur.Next()
+		if a.Len() == 0 {
+			return res
+		}
+		res.Timestamps = append(res.Timestamps, a.Timestamps...)
+		res.Values = append(res.Values, a.Values...)
+	}
+}
+
+// floatWindowMergedArrayCursor returns a fully materialized set of
+// window aggregates as a single block.
+type floatWindowMergedArrayCursor struct {
+	res   *cursors.FloatArray
+	stats cursors.CursorStats
+}
+
+func (c *floatWindowMergedArrayCursor) Close()                     {}
+func (c *floatWindowMergedArrayCursor) Err() error                 { return nil }
+func (c *floatWindowMergedArrayCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *floatWindowMergedArrayCursor) Next() *cursors.FloatArray {
+	a := c.res
+	c.res = &cursors.FloatArray{}
+	return a
+}
+
+// ********************
+// Integer Window Array Cursors
+
+type integerWindowSumArrayCursor struct {
+	cursors.IntegerArrayCursor
+	window window
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+}
+
+func newIntegerWindowSumArrayCursor(cur cursors.IntegerArrayCursor, w window) *integerWindowSumArrayCursor {
+	return &integerWindowSumArrayCursor{
+		IntegerArrayCursor: cur,
+		window:             w,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowSumArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		acc  int64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = acc
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				acc = 0
+			}
+			acc += a.Values[i]
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+type floatIntegerWindowMeanArrayCursor struct {
+	cursors.IntegerArrayCursor
+	window window
+	res    *cursors.FloatArray
+	tmp    *cursors.IntegerArray
+}
+
+func newFloatIntegerWindowMeanArrayCursor(cur cursors.IntegerArrayCursor, w window) *floatIntegerWindowMeanArrayCursor {
+	return &floatIntegerWindowMeanArrayCursor{
+		IntegerArrayCursor: cur,
+		window:             w,
+		res:                cursors.NewFloatArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *floatIntegerWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *floatIntegerWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		acc  float64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = acc / float64(n)
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				acc = 0
+			}
+			acc += float64(a.Values[i])
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = acc / float64(n)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// meanIntegerWindowArrays divides the per-window sums by the per-window
+// counts. Both arrays are keyed by the stop time of each window.
+func meanIntegerWindowArrays(sum *cursors.IntegerArray, count *cursors.IntegerArray) *cursors.FloatArray {
+	res := &cursors.FloatArray{
+		Timestamps: make([]int64, 0, sum.Len()),
+		Values:     make([]float64, 0, sum.Len()),
+	}
+	i, j := 0, 0
+	for i < sum.Len() && j < count.Len() {
+		switch ts := sum.Timestamps[i]; {
+		case ts < count.Timestamps[j]:
+			i++
+		case ts > count.Timestamps[j]:
+			j++
+		default:
+			if n := count.Values[j]; n > 0 {
+				res.Timestamps = append(res.Timestamps, ts)
+				res.Values = append(res.Values, float64(sum.Values[i])/float64(n))
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+type integerIntegerWindowCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+	window window
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+}
+
+func newIntegerIntegerWindowCountArrayCursor(cur cursors.IntegerArrayCursor, w window) *integerIntegerWindowCountArrayCursor {
+	return &integerIntegerWindowCountArrayCursor{
+		IntegerArrayCursor: cur,
+		window:             w,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerIntegerWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerIntegerWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		n    int64
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = n
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = n
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// integerWindowSelectorArrayCursor selects a single point from each
+// window. Unlike the aggregate cursors, the selected point keeps its
+// original timestamp.
+type integerWindowSelectorArrayCursor struct {
+	cursors.IntegerArrayCursor
+	window window
+	agg    datatypes.Aggregate_AggregateType
+	res    *cursors.IntegerArray
+	tmp    *cursors.IntegerArray
+}
+
+func newIntegerWindowSelectorArrayCursor(cur cursors.IntegerArrayCursor, w window, agg datatypes.Aggregate_AggregateType) *integerWindowSelectorArrayCursor {
+	return &integerWindowSelectorArrayCursor{
+		IntegerArrayCursor: cur,
+		window:             w,
+		agg:                agg,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerWindowSelectorArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.IntegerArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		ts   int64
+		v    int64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = v
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				ts, v = t, a.Values[i]
+			} else if integerWindowSelect(c.agg, ts, v, t, a.Values[i]) {
+				ts, v = t, a.Values[i]
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.IntegerArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = v
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// integerWindowSelect reports whether the candidate point (t, v) should
+// replace the currently selected point (ts, cur) of a window.
+func integerWindowSelect(agg datatypes.Aggregate_AggregateType, ts int64, cur int64, t int64, v int64) bool {
+	switch agg {
+	case datatypes.AggregateTypeFirst:
+		return t < ts
+	case datatypes.AggregateTypeLast:
+		return t > ts
+	case datatypes.AggregateTypeMin:
+		return v < cur || (v == cur && t < ts)
+	case datatypes.AggregateTypeMax:
+		return v > cur || (v == cur && t < ts)
+	}
+	return false
+}
+
+// mergeIntegerWindowArrays combines two sets of partial window aggregates,
+// each ordered by time, into a single set.
+func mergeIntegerWindowArrays(a, b *cursors.IntegerArray, w window, agg datatypes.Aggregate_AggregateType) *cursors.IntegerArray {
+	res := &cursors.IntegerArray{
+		Timestamps: make([]int64, 0, a.Len()+b.Len()),
+		Values:     make([]int64, 0, a.Len()+b.Len()),
+	}
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		ka, kb := w.key(agg, a.Timestamps[i]), w.key(agg, b.Timestamps[j])
+		switch {
+		case ka < kb:
+			res.Timestamps = append(res.Timestamps, a.Timestamps[i])
+			res.Values = append(res.Values, a.Values[i])
+			i++
+		case ka > kb:
+			res.Timestamps = append(res.Timestamps, b.Timestamps[j])
+			res.Values = append(res.Values, b.Values[j])
+			j++
+		default:
+			ts, v := a.Timestamps[i], a.Values[i]
+			switch agg {
+			case datatypes.AggregateTypeSum, datatypes.AggregateTypeCount:
+				v += b.Values[j]
+			default:
+				if integerWindowSelect(agg, ts, v, b.Timestamps[j], b.Values[j]) {
+					ts, v = b.Timestamps[j], b.Values[j]
+				}
+			}
+			res.Timestamps = append(res.Timestamps, ts)
+			res.Values = append(res.Values, v)
+			i++
+			j++
+		}
+	}
+	res.Timestamps = append(res.Timestamps, a.Timestamps[i:]...)
+	res.Values = append(res.Values, a.Values[i:]...)
+	res.Timestamps = append(res.Timestamps, b.Timestamps[j:]...)
+	res.Values = append(res.Values, b.Values[j:]...)
+	return res
+}
+
+// readIntegerArrayCursor reads all remaining values from cur into a
+// newly allocated array.
+func readIntegerArrayCursor(cur cursors.IntegerArrayCursor) *cursors.IntegerArray {
+	res := &cursors.IntegerArray{}
+	for {
+		a := cur.Next()
+		if a.Len() == 0 {
+			return res
+		}
+		res.Timestamps = append(res.Timestamps, a.Timestamps...)
+		res.Values = append(res.Values, a.Values...)
+	}
+}
+
+// integerWindowMergedArrayCursor returns a fully materialized set of
+// window aggregates as a single block.
+type integerWindowMergedArrayCursor struct {
+	res   *cursors.IntegerArray
+	stats cursors.CursorStats
+}
+
+func (c *integerWindowMergedArrayCursor) Close()                     {}
+func (c *integerWindowMergedArrayCursor) Err() error                 { return nil }
+func (c *integerWindowMergedArrayCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *integerWindowMergedArrayCursor) Next() *cursors.IntegerArray {
+	a := c.res
+	c.res = &cursors.IntegerArray{}
+	return a
+}
+
+// ********************
+// Unsigned Window Array Cursors
+
+type unsignedWindowSumArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	window window
+	res    *cursors.UnsignedArray
+	tmp    *cursors.UnsignedArray
+}
+
+func newUnsignedWindowSumArrayCursor(cur cursors.UnsignedArrayCursor, w window) *unsignedWindowSumArrayCursor {
+	return &unsignedWindowSumArrayCursor{
+		UnsignedArrayCursor: cur,
+		window:              w,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedWindowSumArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowSumArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.UnsignedArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		acc  uint64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = acc
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				acc = 0
+			}
+			acc += a.Values[i]
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = acc
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+type floatUnsignedWindowMeanArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	window window
+	res    *cursors.FloatArray
+	tmp    *cursors.UnsignedArray
+}
+
+func newFloatUnsignedWindowMeanArrayCursor(cur cursors.UnsignedArrayCursor, w window) *floatUnsignedWindowMeanArrayCursor {
+	return &floatUnsignedWindowMeanArrayCursor{
+		UnsignedArrayCursor: cur,
+		window:              w,
+		res:                 cursors.NewFloatArrayLen(MaxPointsPerBlock),
+		tmp:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *floatUnsignedWindowMeanArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *floatUnsignedWindowMeanArrayCursor) Next() *cursors.FloatArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.UnsignedArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		acc  float64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = acc / float64(n)
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				acc = 0
+			}
+			acc += float64(a.Values[i])
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = acc / float64(n)
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// meanUnsignedWindowArrays divides the per-window sums by the per-window
+// counts. Both arrays are keyed by the stop time of each window.
+func meanUnsignedWindowArrays(sum *cursors.UnsignedArray, count *cursors.IntegerArray) *cursors.FloatArray {
+	res := &cursors.FloatArray{
+		Timestamps: make([]int64, 0, sum.Len()),
+		Values:     make([]float64, 0, sum.Len()),
+	}
+	i, j := 0, 0
+	for i < sum.Len() && j < count.Len() {
+		switch ts := sum.Timestamps[i]; {
+		case ts < count.Timestamps[j]:
+			i++
+		case ts > count.Timestamps[j]:
+			j++
+		default:
+			if n := count.Values[j]; n > 0 {
+				res.Timestamps = append(res.Timestamps, ts)
+				res.Values = append(res.Values, float64(sum.Values[i])/float64(n))
+			}
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+type integerUnsignedWindowCountArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	window window
+	res    *cursors.IntegerArray
+	tmp    *cursors.UnsignedArray
+}
+
+func newIntegerUnsignedWindowCountArrayCursor(cur cursors.UnsignedArrayCursor, w window) *integerUnsignedWindowCountArrayCursor {
+	return &integerUnsignedWindowCountArrayCursor{
+		UnsignedArrayCursor: cur,
+		window:              w,
+		res:                 cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *integerUnsignedWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *integerUnsignedWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.UnsignedArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		n    int64
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = n
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = n
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// unsignedWindowSelectorArrayCursor selects a single point from each
+// window. Unlike the aggregate cursors, the selected point keeps its
+// original timestamp.
+type unsignedWindowSelectorArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	window window
+	agg    datatypes.Aggregate_AggregateType
+	res    *cursors.UnsignedArray
+	tmp    *cursors.UnsignedArray
+}
+
+func newUnsignedWindowSelectorArrayCursor(cur cursors.UnsignedArrayCursor, w window, agg datatypes.Aggregate_AggregateType) *unsignedWindowSelectorArrayCursor {
+	return &unsignedWindowSelectorArrayCursor{
+		UnsignedArrayCursor: cur,
+		window:              w,
+		agg:                 agg,
+		res:                 cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.UnsignedArrayCursor.Stats()
+}
+
+func (c *unsignedWindowSelectorArrayCursor) Next() *cursors.UnsignedArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.UnsignedArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		ts   int64
+		v    uint64
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = v
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				ts, v = t, a.Values[i]
+			} else if unsignedWindowSelect(c.agg, ts, v, t, a.Values[i]) {
+				ts, v = t, a.Values[i]
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.UnsignedArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = v
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// unsignedWindowSelect reports whether the candidate point (t, v) should
+// replace the currently selected point (ts, cur) of a window.
+func unsignedWindowSelect(agg datatypes.Aggregate_AggregateType, ts int64, cur uint64, t int64, v uint64) bool {
+	switch agg {
+	case datatypes.AggregateTypeFirst:
+		return t < ts
+	case datatypes.AggregateTypeLast:
+		return t > ts
+	case datatypes.AggregateTypeMin:
+		return v < cur || (v == cur && t < ts)
+	case datatypes.AggregateTypeMax:
+		return v > cur || (v == cur && t < ts)
+	}
+	return false
+}
+
+// mergeUnsignedWindowArrays combines two sets of partial window aggregates,
+// each ordered by time, into a single set.
+func mergeUnsignedWindowArrays(a, b *cursors.UnsignedArray, w window, agg datatypes.Aggregate_AggregateType) *cursors.UnsignedArray {
+	res := &cursors.UnsignedArray{
+		Timestamps: make([]int64, 0, a.Len()+b.Len()),
+		Values:     make([]uint64, 0, a.Len()+b.Len()),
+	}
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		ka, kb := w.key(agg, a.Timestamps[i]), w.key(agg, b.Timestamps[j])
+		switch {
+		case ka < kb:
+			res.Timestamps = append(res.Timestamps, a.Timestamps[i])
+			res.Values = append(res.Values, a.Values[i])
+			i++
+		case ka > kb:
+			res.Timestamps = append(res.Timestamps, b.Timestamps[j])
+			res.Values = append(res.Values, b.Values[j])
+			j++
+		default:
+			ts, v := a.Timestamps[i], a.Values[i]
+			switch agg {
+			case datatypes.AggregateTypeSum, datatypes.AggregateTypeCount:
+				v += b.Values[j]
+			default:
+				if unsignedWindowSelect(agg, ts, v, b.Timestamps[j], b.Values[j]) {
+					ts, v = b.Timestamps[j], b.Values[j]
+				}
+			}
+			res.Timestamps = append(res.Timestamps, ts)
+			res.Values = append(res.Values, v)
+			i++
+			j++
+		}
+	}
+	res.Timestamps = append(res.Timestamps, a.Timestamps[i:]...)
+	res.Values = append(res.Values, a.Values[i:]...)
+	res.Timestamps = append(res.Timestamps, b.Timestamps[j:]...)
+	res.Values = append(res.Values, b.Values[j:]...)
+	return res
+}
+
+// readUnsignedArrayCursor reads all remaining values from cur into a
+// newly allocated array.
+func readUnsignedArrayCursor(cur cursors.UnsignedArrayCursor) *cursors.UnsignedArray {
+	res := &cursors.UnsignedArray{}
+	for {
+		a := cur.Next()
+		if a.Len() == 0 {
+			return res
+		}
+		res.Timestamps = append(res.Timestamps, a.Timestamps...)
+		res.Values = append(res.Values, a.Values...)
+	}
+}
+
+// unsignedWindowMergedArrayCursor returns a fully materialized set of
+// window aggregates as a single block.
+type unsignedWindowMergedArrayCursor struct {
+	res   *cursors.UnsignedArray
+	stats cursors.CursorStats
+}
+
+func (c *unsignedWindowMergedArrayCursor) Close()                     {}
+func (c *unsignedWindowMergedArrayCursor) Err() error                 { return nil }
+func (c *unsignedWindowMergedArrayCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *unsignedWindowMergedArrayCursor) Next() *cursors.UnsignedArray {
+	a := c.res
+	c.res = &cursors.UnsignedArray{}
+	return a
+}
+
+// ********************
+// String Window Array Cursors
+
+type integerStringWindowCountArrayCursor struct {
+	cursors.StringArrayCursor
+	window window
+	res    *cursors.IntegerArray
+	tmp    *cursors.StringArray
+}
+
+func newIntegerStringWindowCountArrayCursor(cur cursors.StringArrayCursor, w window) *integerStringWindowCountArrayCursor {
+	return &integerStringWindowCountArrayCursor{
+		StringArrayCursor: cur,
+		window:            w,
+		res:               cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:               &cursors.StringArray{},
+	}
+}
+
+func (c *integerStringWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *integerStringWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.StringArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.StringArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		n    int64
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = n
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.StringArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = n
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// stringWindowSelectorArrayCursor selects a single point from each
+// window. Unlike the aggregate cursors, the selected point keeps its
+// original timestamp.
+type stringWindowSelectorArrayCursor struct {
+	cursors.StringArrayCursor
+	window window
+	agg    datatypes.Aggregate_AggregateType
+	res    *cursors.StringArray
+	tmp    *cursors.StringArray
+}
+
+func newStringWindowSelectorArrayCursor(cur cursors.StringArrayCursor, w window, agg datatypes.Aggregate_AggregateType) *stringWindowSelectorArrayCursor {
+	return &stringWindowSelectorArrayCursor{
+		StringArrayCursor: cur,
+		window:            w,
+		agg:               agg,
+		res:               cursors.NewStringArrayLen(MaxPointsPerBlock),
+		tmp:               &cursors.StringArray{},
+	}
+}
+
+func (c *stringWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.StringArrayCursor.Stats()
+}
+
+func (c *stringWindowSelectorArrayCursor) Next() *cursors.StringArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.StringArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.StringArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		ts   int64
+		v    string
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = v
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				ts, v = t, a.Values[i]
+			} else if stringWindowSelect(c.agg, ts, v, t, a.Values[i]) {
+				ts, v = t, a.Values[i]
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.StringArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = v
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// stringWindowSelect reports whether the candidate point (t, v) should
+// replace the currently selected point (ts, cur) of a window.
+func stringWindowSelect(agg datatypes.Aggregate_AggregateType, ts int64, cur string, t int64, v string) bool {
+	switch agg {
+	case datatypes.AggregateTypeFirst:
+		return t < ts
+	case datatypes.AggregateTypeLast:
+		return t > ts
+	}
+	return false
+}
+
+// mergeStringWindowArrays combines two sets of partial window aggregates,
+// each ordered by time, into a single set.
+func mergeStringWindowArrays(a, b *cursors.StringArray, w window, agg datatypes.Aggregate_AggregateType) *cursors.StringArray {
+	res := &cursors.StringArray{
+		Timestamps: make([]int64, 0, a.Len()+b.Len()),
+		Values:     make([]string, 0, a.Len()+b.Len()),
+	}
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		ka, kb := w.key(agg, a.Timestamps[i]), w.key(agg, b.Timestamps[j])
+		switch {
+		case ka < kb:
+			res.Timestamps = append(res.Timestamps, a.Timestamps[i])
+			res.Values = append(res.Values, a.Values[i])
+			i++
+		case ka > kb:
+			res.Timestamps = append(res.Timestamps, b.Timestamps[j])
+			res.Values = append(res.Values, b.Values[j])
+			j++
+		default:
+			ts, v := a.Timestamps[i], a.Values[i]
+			switch agg {
+			default:
+				if stringWindowSelect(agg, ts, v, b.Timestamps[j], b.Values[j]) {
+					ts, v = b.Timestamps[j], b.Values[j]
+				}
+			}
+			res.Timestamps = append(res.Timestamps, ts)
+			res.Values = append(res.Values, v)
+			i++
+			j++
+		}
+	}
+	res.Timestamps = append(res.Timestamps, a.Timestamps[i:]...)
+	res.Values = append(res.Values, a.Values[i:]...)
+	res.Timestamps = append(res.Timestamps, b.Timestamps[j:]...)
+	res.Values = append(res.Values, b.Values[j:]...)
+	return res
+}
+
+// readStringArrayCursor reads all remaining values from cur into a
+// newly allocated array.
+func readStringArrayCursor(cur cursors.StringArrayCursor) *cursors.StringArray {
+	res := &cursors.StringArray{}
+	for {
+		a := cur.Next()
+		if a.Len() == 0 {
+			return res
+		}
+		res.Timestamps = append(res.Timestamps, a.Timestamps...)
+		res.Values = append(res.Values, a.Values...)
+	}
+}
+
+// stringWindowMergedArrayCursor returns a fully materialized set of
+// window aggregates as a single block.
+type stringWindowMergedArrayCursor struct {
+	res   *cursors.StringArray
+	stats cursors.CursorStats
+}
+
+func (c *stringWindowMergedArrayCursor) Close()                     {}
+func (c *stringWindowMergedArrayCursor) Err() error                 { return nil }
+func (c *stringWindowMergedArrayCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *stringWindowMergedArrayCursor) Next() *cursors.StringArray {
+	a := c.res
+	c.res = &cursors.StringArray{}
+	return a
+}
+
+// ********************
+// Boolean Window Array Cursors
+
+type integerBooleanWindowCountArrayCursor struct {
+	cursors.BooleanArrayCursor
+	window window
+	res    *cursors.IntegerArray
+	tmp    *cursors.BooleanArray
+}
+
+func newIntegerBooleanWindowCountArrayCursor(cur cursors.BooleanArrayCursor, w window) *integerBooleanWindowCountArrayCursor {
+	return &integerBooleanWindowCountArrayCursor{
+		BooleanArrayCursor: cur,
+		window:             w,
+		res:                cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.BooleanArray{},
+	}
+}
+
+func (c *integerBooleanWindowCountArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *integerBooleanWindowCountArrayCursor) Next() *cursors.IntegerArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.BooleanArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.BooleanArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		n    int64
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = stop
+				c.res.Values[pos] = n
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.BooleanArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = stop
+		c.res.Values[pos] = n
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// booleanWindowSelectorArrayCursor selects a single point from each
+// window. Unlike the aggregate cursors, the selected point keeps its
+// original timestamp.
+type booleanWindowSelectorArrayCursor struct {
+	cursors.BooleanArrayCursor
+	window window
+	agg    datatypes.Aggregate_AggregateType
+	res    *cursors.BooleanArray
+	tmp    *cursors.BooleanArray
+}
+
+func newBooleanWindowSelectorArrayCursor(cur cursors.BooleanArrayCursor, w window, agg datatypes.Aggregate_AggregateType) *booleanWindowSelectorArrayCursor {
+	return &booleanWindowSelectorArrayCursor{
+		BooleanArrayCursor: cur,
+		window:             w,
+		agg:                agg,
+		res:                cursors.NewBooleanArrayLen(MaxPointsPerBlock),
+		tmp:                &cursors.BooleanArray{},
+	}
+}
+
+func (c *booleanWindowSelectorArrayCursor) Stats() cursors.CursorStats {
+	return c.BooleanArrayCursor.Stats()
+}
+
+func (c *booleanWindowSelectorArrayCursor) Next() *cursors.BooleanArray {
+	pos := 0
+	c.res.Timestamps = c.res.Timestamps[:cap(c.res.Timestamps)]
+	c.res.Values = c.res.Values[:cap(c.res.Values)]
+
+	var a *cursors.BooleanArray
+	if c.tmp.Len() > 0 {
+		a = c.tmp
+	} else {
+		a = c.BooleanArrayCursor.Next()
+	}
+
+	var (
+		stop int64
+		ts   int64
+		v    bool
+		n    int
+	)
+
+LOOP:
+	for a.Len() > 0 {
+		for i, t := range a.Timestamps {
+			if n > 0 && t >= stop {
+				c.res.Timestamps[pos] = ts
+				c.res.Values[pos] = v
+				pos++
+				n = 0
+				if pos >= MaxPointsPerBlock {
+					c.tmp.Timestamps = a.Timestamps[i:]
+					c.tmp.Values = a.Values[i:]
+					break LOOP
+				}
+			}
+			if n == 0 {
+				stop = c.window.stop(t)
+				ts, v = t, a.Values[i]
+			} else if booleanWindowSelect(c.agg, ts, v, t, a.Values[i]) {
+				ts, v = t, a.Values[i]
+			}
+			n++
+		}
+		c.tmp.Timestamps = nil
+		c.tmp.Values = nil
+		a = c.BooleanArrayCursor.Next()
+	}
+
+	if n > 0 {
+		c.res.Timestamps[pos] = ts
+		c.res.Values[pos] = v
+		pos++
+	}
+
+	c.res.Timestamps = c.res.Timestamps[:pos]
+	c.res.Values = c.res.Values[:pos]
+	return c.res
+}
+
+// booleanWindowSelect reports whether the candidate point (t, v) should
+// replace the currently selected point (ts, cur) of a window.
+func booleanWindowSelect(agg datatypes.Aggregate_AggregateType, ts int64, cur bool, t int64, v bool) bool {
+	switch agg {
+	case datatypes.AggregateTypeFirst:
+		return t < ts
+	case datatypes.AggregateTypeLast:
+		return t > ts
+	}
+	return false
+}
+
+// mergeBooleanWindowArrays combines two sets of partial window aggregates,
+// each ordered by time, into a single set.
+func mergeBooleanWindowArrays(a, b *cursors.BooleanArray, w window, agg datatypes.Aggregate_AggregateType) *cursors.BooleanArray {
+	res := &cursors.BooleanArray{
+		Timestamps: make([]int64, 0, a.Len()+b.Len()),
+		Values:     make([]bool, 0, a.Len()+b.Len()),
+	}
+
+	i, j := 0, 0
+	for i < a.Len() && j < b.Len() {
+		ka, kb := w.key(agg, a.Timestamps[i]), w.key(agg, b.Timestamps[j])
+		switch {
+		case ka < kb:
+			res.Timestamps = append(res.Timestamps, a.Timestamps[i])
+			res.Values = append(res.Values, a.Values[i])
+			i++
+		case ka > kb:
+			res.Timestamps = append(res.Timestamps, b.Timestamps[j])
+			res.Values = append(res.Values, b.Values[j])
+			j++
+		default:
+			ts, v := a.Timestamps[i], a.Values[i]
+			switch agg {
+			default:
+				if booleanWindowSelect(agg, ts, v, b.Timestamps[j], b.Values[j]) {
+					ts, v = b.Timestamps[j], b.Values[j]
+				}
+			}
+			res.Timestamps = append(res.Timestamps, ts)
+			res.Values = append(res.Values, v)
+			i++
+			j++
+		}
+	}
+	res.Timestamps = append(res.Timestamps, a.Timestamps[i:]...)
+	res.Values = append(res.Values, a.Values[i:]...)
+	res.Timestamps = append(res.Timestamps, b.Timestamps[j:]...)
+	res.Values = append(res.Values, b.Values[j:]...)
+	return res
+}
+
+// readBooleanArrayCursor reads all remaining values from cur into a
+// newly allocated array.
+func readBooleanArrayCursor(cur cursors.BooleanArrayCursor) *cursors.BooleanArray {
+	res := &cursors.BooleanArray{}
+	for {
+		a := cur.Next()
+		if a.Len() == 0 {
+			return res
+		}
+		res.Timestamps = append(res.Timestamps, a.Timestamps...)
+		res.Values = append(res.Values, a.Values...)
+	}
+}
+
+// booleanWindowMergedArrayCursor returns a fully materialized set of
+// window aggregates as a single block.
+type booleanWindowMergedArrayCursor struct {
+	res   *cursors.BooleanArray
+	stats cursors.CursorStats
+}
+
+func (c *booleanWindowMergedArrayCursor) Close()                     {}
+func (c *booleanWindowMergedArrayCursor) Err() error                 { return nil }
+func (c *booleanWindowMergedArrayCursor) Stats() cursors.CursorStats { return c.stats }
+
+func (c *booleanWindowMergedArrayCursor) Next() *cursors.BooleanArray {
+	a := c.res
+	c.res = &cursors.BooleanArray{}
+	return a
+}
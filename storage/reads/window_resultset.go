@@ -0,0 +1,384 @@
+package reads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+var (
+	errWindowAggregateCount = errors.New("window aggregate requires exactly one aggregate")
+	errWindowEvery          = errors.New("window aggregate requires a positive window duration")
+)
+
+type windowAggregateResultSet struct {
+	ctx    context.Context
+	agg    datatypes.Aggregate_AggregateType
+	window window
+	cur    SeriesCursor
+	row    SeriesRow
+	mb     multiShardCursors
+}
+
+// NewWindowAggregateResultSet returns a ResultSet which produces the
+// aggregate of each window of every series produced by cur.
+func NewWindowAggregateResultSet(ctx context.Context, req *datatypes.ReadWindowAggregateRequest, cur SeriesCursor) (ResultSet, error) {
+	agg, err := WindowAggregateType(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The storage cursors treat the end of the range as inclusive,
+	// however windows exclude their stop time.
+	return &windowAggregateResultSet{
+		ctx:    ctx,
+		agg:    agg,
+		window: newWindow(req.WindowEvery, req.Offset, req.Range.End),
+		cur:    cur,
+		mb:     newMultiShardArrayCursors(ctx, req.Range.Start, req.Range.End-1, true, math.MaxInt64),
+	}, nil
+}
+
+// WindowAggregateType validates req and returns its aggregate type.
+func WindowAggregateType(req *datatypes.ReadWindowAggregateRequest) (datatypes.Aggregate_AggregateType, error) {
+	if len(req.Aggregate) != 1 {
+		return 0, errWindowAggregateCount
+	}
+	if req.WindowEvery <= 0 {
+		return 0, errWindowEvery
+	}
+
+	switch agg := req.Aggregate[0].Type; agg {
+	case datatypes.AggregateTypeCount, datatypes.AggregateTypeSum, datatypes.AggregateTypeMean,
+		datatypes.AggregateTypeMin, datatypes.AggregateTypeMax,
+		datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		return agg, nil
+	default:
+		return 0, fmt.Errorf("unsupported window aggregate %s", agg)
+	}
+}
+
+func (r *windowAggregateResultSet) Err() error { return nil }
+
+// Close closes the result set. Close is idempotent.
+func (r *windowAggregateResultSet) Close() {
+	if r == nil {
+		return // Nothing to do.
+	}
+	r.row.Query = nil
+	r.cur.Close()
+}
+
+// Next returns true if there are more results available.
+func (r *windowAggregateResultSet) Next() bool {
+	if r == nil {
+		return false
+	}
+
+	row := r.cur.Next()
+	if row == nil {
+		return false
+	}
+
+	r.row = *row
+
+	return true
+}
+
+func (r *windowAggregateResultSet) Cursor() cursors.Cursor {
+	cur := r.mb.createCursor(r.row)
+	if cur == nil {
+		return nil
+	}
+	return newWindowAggregateCursor(cur, r.window, r.agg)
+}
+
+// newWindowAggregateCursor returns a cursor of the aggregate agg of each
+// window of cur, or nil if agg is not supported for the type of cur.
+func newWindowAggregateCursor(cur cursors.Cursor, w window, agg datatypes.Aggregate_AggregateType) cursors.Cursor {
+	var wcur cursors.Cursor
+	switch agg {
+	case datatypes.AggregateTypeSum:
+		wcur = newWindowSumArrayCursor(cur, w)
+	case datatypes.AggregateTypeCount:
+		wcur = newWindowCountArrayCursor(cur, w)
+	case datatypes.AggregateTypeMean:
+		wcur = newWindowMeanArrayCursor(cur, w)
+	default:
+		wcur = newWindowSelectorArrayCursor(cur, w, agg)
+	}
+
+	if wcur == nil {
+		// aggregate is not supported for the field type
+		cur.Close()
+	}
+	return wcur
+}
+
+func (r *windowAggregateResultSet) Tags() models.Tags {
+	return r.row.Tags
+}
+
+// Stats returns the stats for the underlying cursors.
+// Available after resultset has been scanned.
+func (r *windowAggregateResultSet) Stats() cursors.CursorStats { return r.row.Query.Stats() }
+
+type windowFilteredResultSet struct {
+	rs     ResultSet
+	agg    datatypes.Aggregate_AggregateType
+	window window
+}
+
+// WindowAggregateFilterRequest returns the request reading the values
+// aggregated by req, which NewWindowAggregateFilteredResultSet aggregates.
+func WindowAggregateFilterRequest(req *datatypes.ReadWindowAggregateRequest) *datatypes.ReadFilterRequest {
+	// The end of the range of a ReadFilterRequest is inclusive.
+	return &datatypes.ReadFilterRequest{
+		ReadSource: req.ReadSource,
+		Range:      datatypes.TimestampRange{Start: req.Range.Start, End: req.Range.End - 1},
+		Predicate:  req.Predicate,
+	}
+}
+
+// NewWindowAggregateFilteredResultSet returns a ResultSet which produces the
+// aggregate of each window of every series of rs, the result of the request
+// returned by WindowAggregateFilterRequest for req. It aggregates the values
+// read from stores that can't aggregate windows themselves.
+func NewWindowAggregateFilteredResultSet(req *datatypes.ReadWindowAggregateRequest, rs ResultSet) (ResultSet, error) {
+	agg, err := WindowAggregateType(req)
+	if err != nil {
+		return nil, err
+	}
+	return &windowFilteredResultSet{
+		rs:     rs,
+		agg:    agg,
+		window: newWindow(req.WindowEvery, req.Offset, req.Range.End),
+	}, nil
+}
+
+func (r *windowFilteredResultSet) Err() error { return r.rs.Err() }
+
+func (r *windowFilteredResultSet) Close() { r.rs.Close() }
+
+func (r *windowFilteredResultSet) Next() bool { return r.rs.Next() }
+
+func (r *windowFilteredResultSet) Cursor() cursors.Cursor {
+	cur := r.rs.Cursor()
+	if cur == nil {
+		return nil
+	}
+	return newWindowAggregateCursor(cur, r.window, r.agg)
+}
+
+func (r *windowFilteredResultSet) Tags() models.Tags { return r.rs.Tags() }
+
+func (r *windowFilteredResultSet) Stats() cursors.CursorStats { return r.rs.Stats() }
+
+type windowMergedResultSet struct {
+	rs     ResultSet
+	agg    datatypes.Aggregate_AggregateType
+	window window
+	more   bool
+	tags   models.Tags
+	cur    cursors.Cursor
+}
+
+// NewWindowAggregateMergedResultSet combines the partial window aggregates
+// produced for the same series by each of results, such as when the shards
+// of a series are spread across several nodes. All results must be ordered
+// by series key and produced by the same ReadWindowAggregateRequest.
+func NewWindowAggregateMergedResultSet(req *datatypes.ReadWindowAggregateRequest, results []ResultSet) (ResultSet, error) {
+	agg, err := WindowAggregateType(req)
+	if err != nil {
+		return nil, err
+	}
+	if agg == datatypes.AggregateTypeMean {
+		return nil, errors.New("mean cannot be merged; merge sum and count instead")
+	}
+
+	rs := NewMergedResultSet(results)
+	if rs == nil {
+		return nil, nil
+	}
+	return &windowMergedResultSet{
+		rs:     rs,
+		agg:    agg,
+		window: newWindow(req.WindowEvery, req.Offset, req.Range.End),
+		more:   rs.Next(),
+	}, nil
+}
+
+func (r *windowMergedResultSet) Err() error { return r.rs.Err() }
+
+func (r *windowMergedResultSet) Close() {
+	if r.cur != nil {
+		r.cur.Close()
+		r.cur = nil
+	}
+	r.rs.Close()
+	r.more = false
+}
+
+func (r *windowMergedResultSet) Next() bool {
+	if r.cur != nil {
+		r.cur.Close()
+		r.cur = nil
+	}
+	if !r.more {
+		return false
+	}
+
+	// Each cursor must be read in full before advancing the underlying
+	// result set, as advancing may invalidate it.
+	r.tags = r.rs.Tags().Clone()
+	for {
+		if cur := r.rs.Cursor(); cur != nil {
+			r.cur = mergeWindowArrayCursor(r.cur, cur, r.window, r.agg)
+		}
+		r.more = r.rs.Next()
+		if !r.more || !r.rs.Tags().Equal(r.tags) {
+			break
+		}
+	}
+	return true
+}
+
+func (r *windowMergedResultSet) Cursor() cursors.Cursor {
+	cur := r.cur
+	r.cur = nil
+	return cur
+}
+
+func (r *windowMergedResultSet) Tags() models.Tags { return r.tags }
+
+func (r *windowMergedResultSet) Stats() cursors.CursorStats { return r.rs.Stats() }
+
+type windowMeanResultSet struct {
+	sum, count     ResultSet
+	first          bool
+	sumOK, countOK bool
+	tags           models.Tags
+	cur            cursors.Cursor
+}
+
+// NewWindowMeanResultSet computes the mean of each window from the results
+// of the equivalent sum and count requests. Both sum and count must be
+// returned by NewWindowAggregateMergedResultSet.
+func NewWindowMeanResultSet(sum, count ResultSet) ResultSet {
+	if sum == nil || count == nil {
+		if sum != nil {
+			sum.Close()
+		}
+		if count != nil {
+			count.Close()
+		}
+		return nil
+	}
+	return &windowMeanResultSet{sum: sum, count: count, first: true}
+}
+
+func (r *windowMeanResultSet) Err() error {
+	if err := r.sum.Err(); err != nil {
+		return err
+	}
+	return r.count.Err()
+}
+
+func (r *windowMeanResultSet) Close() {
+	if r.cur != nil {
+		r.cur.Close()
+		r.cur = nil
+	}
+	r.sum.Close()
+	r.count.Close()
+	r.sumOK, r.countOK = false, false
+}
+
+func (r *windowMeanResultSet) Next() bool {
+	if r.cur != nil {
+		r.cur.Close()
+		r.cur = nil
+	}
+	if r.first {
+		r.first = false
+		r.sumOK, r.countOK = r.sum.Next(), r.count.Next()
+	}
+
+	// A series missing from either side, such as a string field which
+	// cannot be summed, produces no mean.
+	for r.sumOK && r.countOK {
+		switch models.CompareTags(r.sum.Tags(), r.count.Tags()) {
+		case -1:
+			closeCursor(r.sum.Cursor())
+			r.sumOK = r.sum.Next()
+		case 1:
+			closeCursor(r.count.Cursor())
+			r.countOK = r.count.Next()
+		default:
+			r.tags = r.sum.Tags()
+			r.cur = windowMeanCursor(r.sum.Cursor(), r.count.Cursor())
+			r.sumOK, r.countOK = r.sum.Next(), r.count.Next()
+			return true
+		}
+	}
+	return false
+}
+
+func closeCursor(cur cursors.Cursor) {
+	if cur != nil {
+		cur.Close()
+	}
+}
+
+// windowMeanCursor reads the per-window sums and counts and returns a cursor
+// of their quotients.
+func windowMeanCursor(sum, count cursors.Cursor) cursors.Cursor {
+	if sum == nil || count == nil {
+		closeCursor(sum)
+		closeCursor(count)
+		return nil
+	}
+	defer sum.Close()
+	defer count.Close()
+
+	ccur, ok := count.(cursors.IntegerArrayCursor)
+	if !ok {
+		return nil
+	}
+	n := readIntegerArrayCursor(ccur)
+
+	var res *cursors.FloatArray
+	switch sum := sum.(type) {
+	case cursors.FloatArrayCursor:
+		res = meanFloatWindowArrays(readFloatArrayCursor(sum), n)
+	case cursors.IntegerArrayCursor:
+		res = meanIntegerWindowArrays(readIntegerArrayCursor(sum), n)
+	case cursors.UnsignedArrayCursor:
+		res = meanUnsignedWindowArrays(readUnsignedArrayCursor(sum), n)
+	default:
+		return nil
+	}
+
+	stats := sum.Stats()
+	stats.Add(count.Stats())
+	return &floatWindowMergedArrayCursor{res: res, stats: stats}
+}
+
+func (r *windowMeanResultSet) Cursor() cursors.Cursor {
+	cur := r.cur
+	r.cur = nil
+	return cur
+}
+
+func (r *windowMeanResultSet) Tags() models.Tags { return r.tags }
+
+func (r *windowMeanResultSet) Stats() cursors.CursorStats {
+	stats := r.sum.Stats()
+	stats.Add(r.count.Stats())
+	return stats
+}
@@ -0,0 +1,307 @@
+package reads
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+type floatSliceArrayCursor struct {
+	arrs []*cursors.FloatArray
+}
+
+func (c *floatSliceArrayCursor) Close()                     {}
+func (c *floatSliceArrayCursor) Err() error                 { return nil }
+func (c *floatSliceArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *floatSliceArrayCursor) Next() *cursors.FloatArray {
+	if len(c.arrs) == 0 {
+		return &cursors.FloatArray{}
+	}
+	a := c.arrs[0]
+	c.arrs = c.arrs[1:]
+	return a
+}
+
+func newFloatCursor(blocks ...*cursors.FloatArray) cursors.FloatArrayCursor {
+	return &floatSliceArrayCursor{arrs: blocks}
+}
+
+func floatArray(ts []int64, vs []float64) *cursors.FloatArray {
+	return &cursors.FloatArray{Timestamps: ts, Values: vs}
+}
+
+func readCursor(t *testing.T, cur cursors.Cursor) (ts []int64, vs []interface{}) {
+	t.Helper()
+	for {
+		switch c := cur.(type) {
+		case cursors.FloatArrayCursor:
+			a := c.Next()
+			if a.Len() == 0 {
+				return ts, vs
+			}
+			for i := range a.Timestamps {
+				ts = append(ts, a.Timestamps[i])
+				vs = append(vs, a.Values[i])
+			}
+		case cursors.IntegerArrayCursor:
+			a := c.Next()
+			if a.Len() == 0 {
+				return ts, vs
+			}
+			for i := range a.Timestamps {
+				ts = append(ts, a.Timestamps[i])
+				vs = append(vs, a.Values[i])
+			}
+		default:
+			t.Fatalf("unexpected cursor type %T", cur)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name                string
+		every, offset       int64
+		end                 int64
+		t                   int64
+		wantStart, wantStop int64
+	}{
+		{name: "aligned", every: 10, end: 100, t: 20, wantStart: 20, wantStop: 30},
+		{name: "within", every: 10, end: 100, t: 25, wantStart: 20, wantStop: 30},
+		{name: "offset", every: 10, offset: 3, end: 100, t: 25, wantStart: 23, wantStop: 33},
+		{name: "offset before", every: 10, offset: 3, end: 100, t: 22, wantStart: 13, wantStop: 23},
+		{name: "negative offset", every: 10, offset: -3, end: 100, t: 25, wantStart: 17, wantStop: 27},
+		{name: "clipped", every: 10, end: 95, t: 91, wantStart: 90, wantStop: 95},
+		{name: "negative time", every: 10, end: 100, t: -5, wantStart: -10, wantStop: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWindow(tt.every, tt.offset, tt.end)
+			if got := w.start(tt.t); got != tt.wantStart {
+				t.Errorf("start(%d) = %d, want %d", tt.t, got, tt.wantStart)
+			}
+			if got := w.stop(tt.t); got != tt.wantStop {
+				t.Errorf("stop(%d) = %d, want %d", tt.t, got, tt.wantStop)
+			}
+		})
+	}
+}
+
+func TestWindowArrayCursor(t *testing.T) {
+	// Two blocks with a window spanning the block boundary.
+	input := func() cursors.FloatArrayCursor {
+		return newFloatCursor(
+			floatArray([]int64{0, 5, 10}, []float64{4, 2, 6}),
+			floatArray([]int64{15, 30}, []float64{1, 3}),
+		)
+	}
+	w := newWindow(10, 0, 35)
+
+	tests := []struct {
+		name   string
+		cur    func() cursors.Cursor
+		wantTs []int64
+		wantVs []interface{}
+	}{
+		{
+			name:   "sum",
+			cur:    func() cursors.Cursor { return newWindowSumArrayCursor(input(), w) },
+			wantTs: []int64{10, 20, 35},
+			wantVs: []interface{}{6.0, 7.0, 3.0},
+		},
+		{
+			name:   "count",
+			cur:    func() cursors.Cursor { return newWindowCountArrayCursor(input(), w) },
+			wantTs: []int64{10, 20, 35},
+			wantVs: []interface{}{int64(2), int64(2), int64(1)},
+		},
+		{
+			name:   "mean",
+			cur:    func() cursors.Cursor { return newWindowMeanArrayCursor(input(), w) },
+			wantTs: []int64{10, 20, 35},
+			wantVs: []interface{}{3.0, 3.5, 3.0},
+		},
+		{
+			name:   "first",
+			cur:    func() cursors.Cursor { return newWindowSelectorArrayCursor(input(), w, datatypes.AggregateTypeFirst) },
+			wantTs: []int64{0, 10, 30},
+			wantVs: []interface{}{4.0, 6.0, 3.0},
+		},
+		{
+			name:   "last",
+			cur:    func() cursors.Cursor { return newWindowSelectorArrayCursor(input(), w, datatypes.AggregateTypeLast) },
+			wantTs: []int64{5, 15, 30},
+			wantVs: []interface{}{2.0, 1.0, 3.0},
+		},
+		{
+			name:   "min",
+			cur:    func() cursors.Cursor { return newWindowSelectorArrayCursor(input(), w, datatypes.AggregateTypeMin) },
+			wantTs: []int64{5, 15, 30},
+			wantVs: []interface{}{2.0, 1.0, 3.0},
+		},
+		{
+			name:   "max",
+			cur:    func() cursors.Cursor { return newWindowSelectorArrayCursor(input(), w, datatypes.AggregateTypeMax) },
+			wantTs: []int64{0, 10, 30},
+			wantVs: []interface{}{4.0, 6.0, 3.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, vs := readCursor(t, tt.cur())
+			if !cmp.Equal(ts, tt.wantTs) {
+				t.Errorf("unexpected timestamps -got/+want\n%s", cmp.Diff(ts, tt.wantTs))
+			}
+			if !cmp.Equal(vs, tt.wantVs) {
+				t.Errorf("unexpected values -got/+want\n%s", cmp.Diff(vs, tt.wantVs))
+			}
+		})
+	}
+}
+
+func TestWindowArrayCursor_ManyWindows(t *testing.T) {
+	n := 2*MaxPointsPerBlock + 7
+	ts := make([]int64, n)
+	vs := make([]float64, n)
+	for i := range ts {
+		ts[i] = int64(i)
+		vs[i] = 1
+	}
+
+	cur := newWindowCountArrayCursor(newFloatCursor(floatArray(ts, vs)), newWindow(1, 0, int64(n)))
+	gotTs, gotVs := readCursor(t, cur)
+	if len(gotTs) != n {
+		t.Fatalf("got %d windows, want %d", len(gotTs), n)
+	}
+	for i := range gotTs {
+		if gotTs[i] != int64(i+1) || gotVs[i] != int64(1) {
+			t.Fatalf("window %d: got (%d, %v), want (%d, 1)", i, gotTs[i], gotVs[i], i+1)
+		}
+	}
+}
+
+func TestMergeWindowArrayCursor(t *testing.T) {
+	w := newWindow(10, 0, 40)
+	a := func() cursors.FloatArrayCursor {
+		return newFloatCursor(floatArray([]int64{0, 5, 20}, []float64{4, 2, 6}))
+	}
+	b := func() cursors.FloatArrayCursor {
+		return newFloatCursor(floatArray([]int64{3, 12, 25}, []float64{1, 5, 8}))
+	}
+
+	tests := []struct {
+		name   string
+		agg    datatypes.Aggregate_AggregateType
+		wantTs []int64
+		wantVs []interface{}
+	}{
+		{
+			name:   "sum",
+			agg:    datatypes.AggregateTypeSum,
+			wantTs: []int64{10, 20, 30},
+			wantVs: []interface{}{7.0, 5.0, 14.0},
+		},
+		{
+			name:   "first",
+			agg:    datatypes.AggregateTypeFirst,
+			wantTs: []int64{0, 12, 20},
+			wantVs: []interface{}{4.0, 5.0, 6.0},
+		},
+		{
+			name:   "max",
+			agg:    datatypes.AggregateTypeMax,
+			wantTs: []int64{0, 12, 25},
+			wantVs: []interface{}{4.0, 5.0, 8.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newCursor := newWindowSumArrayCursor
+			if IsSelector(tt.agg) {
+				newCursor = func(cur cursors.Cursor, w window) cursors.Cursor {
+					return newWindowSelectorArrayCursor(cur, w, tt.agg)
+				}
+			}
+
+			cur := mergeWindowArrayCursor(nil, newCursor(a(), w), w, tt.agg)
+			cur = mergeWindowArrayCursor(cur, newCursor(b(), w), w, tt.agg)
+			ts, vs := readCursor(t, cur)
+			if !cmp.Equal(ts, tt.wantTs) {
+				t.Errorf("unexpected timestamps -got/+want\n%s", cmp.Diff(ts, tt.wantTs))
+			}
+			if !cmp.Equal(vs, tt.wantVs) {
+				t.Errorf("unexpected values -got/+want\n%s", cmp.Diff(vs, tt.wantVs))
+			}
+		})
+	}
+}
+
+func TestWindowMeanCursor(t *testing.T) {
+	w := newWindow(10, 0, 30)
+	input := func() cursors.FloatArrayCursor {
+		return newFloatCursor(floatArray([]int64{0, 5, 20}, []float64{4, 2, 6}))
+	}
+
+	sum := mergeWindowArrayCursor(nil, newWindowSumArrayCursor(input(), w), w, datatypes.AggregateTypeSum)
+	count := mergeWindowArrayCursor(nil, newWindowCountArrayCursor(input(), w), w, datatypes.AggregateTypeCount)
+	ts, vs := readCursor(t, windowMeanCursor(sum, count))
+	if want := []int64{10, 30}; !cmp.Equal(ts, want) {
+		t.Errorf("unexpected timestamps -got/+want\n%s", cmp.Diff(ts, want))
+	}
+	if want := []interface{}{3.0, 6.0}; !cmp.Equal(vs, want) {
+		t.Errorf("unexpected values -got/+want\n%s", cmp.Diff(vs, want))
+	}
+}
+
+type sliceResultSet struct {
+	tags []models.Tags
+	curs []cursors.Cursor
+	i    int
+}
+
+func (r *sliceResultSet) Next() bool {
+	r.i++
+	return r.i <= len(r.curs)
+}
+func (r *sliceResultSet) Cursor() cursors.Cursor     { return r.curs[r.i-1] }
+func (r *sliceResultSet) Tags() models.Tags          { return r.tags[r.i-1] }
+func (r *sliceResultSet) Close()                     {}
+func (r *sliceResultSet) Err() error                 { return nil }
+func (r *sliceResultSet) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func TestWindowAggregateFilteredResultSet(t *testing.T) {
+	req := &datatypes.ReadWindowAggregateRequest{
+		Range:       datatypes.TimestampRange{Start: 0, End: 30},
+		WindowEvery: 10,
+		Aggregate:   []*datatypes.Aggregate{{Type: datatypes.AggregateTypeSum}},
+	}
+	if got := WindowAggregateFilterRequest(req).Range; got.Start != 0 || got.End != 29 {
+		t.Fatalf("unexpected filter range: %+v", got)
+	}
+
+	rs, err := NewWindowAggregateFilteredResultSet(req, &sliceResultSet{
+		tags: []models.Tags{models.ParseTags([]byte("cpu,host=a")), models.ParseTags([]byte("cpu,host=b"))},
+		curs: []cursors.Cursor{
+			newFloatCursor(floatArray([]int64{0, 5, 20}, []float64{4, 2, 6})),
+			newFloatCursor(floatArray([]int64{12, 25}, []float64{5, 8})),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for rs.Next() {
+		ts, vs := readCursor(t, rs.Cursor())
+		got = append(got, fmt.Sprintf("%s %v %v", rs.Tags().HashKey(false), ts, vs))
+	}
+	want := []string{",host=a [10 30] [6 6]", ",host=b [20 30] [5 8]"}
+	if !cmp.Equal(got, want) {
+		t.Errorf("unexpected windows -got/+want\n%s", cmp.Diff(got, want))
+	}
+}
@@ -0,0 +1,496 @@
+package tsdb
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash"
+)
+
+// DefaultCardinalityReportN is the default number of measurements and tag keys
+// listed by a cardinality report.
+const DefaultCardinalityReportN = 10
+
+// CardinalityLimits are the limits on the number of series of the measurements
+// and on the number of values of the tag keys of a database, in a shard. A
+// zero limit inherits the limit it overrides, and a negative limit disables it.
+type CardinalityLimits struct {
+	MaxSeriesPerMeasurement int
+	MaxValuesPerTag         int
+
+	// Measurements and TagKeys hold the limits of specific measurements and
+	// tag keys, which override MaxSeriesPerMeasurement and MaxValuesPerTag.
+	Measurements map[string]int
+	TagKeys      map[string]int
+}
+
+// Override returns the limits l overridden by those of o.
+func (l *CardinalityLimits) Override(o *CardinalityLimits) *CardinalityLimits {
+	if o == nil {
+		return l
+	}
+
+	other := *l
+	if o.MaxSeriesPerMeasurement != 0 {
+		other.MaxSeriesPerMeasurement = o.MaxSeriesPerMeasurement
+	}
+	if o.MaxValuesPerTag != 0 {
+		other.MaxValuesPerTag = o.MaxValuesPerTag
+	}
+	if len(o.Measurements) > 0 {
+		other.Measurements = o.Measurements
+	}
+	if len(o.TagKeys) > 0 {
+		other.TagKeys = o.TagKeys
+	}
+	return &other
+}
+
+// Enabled returns true if any limit is set.
+func (l *CardinalityLimits) Enabled() bool {
+	if l == nil {
+		return false
+	}
+	if l.MaxSeriesPerMeasurement > 0 || l.MaxValuesPerTag > 0 {
+		return true
+	}
+	for _, n := range l.Measurements {
+		if n > 0 {
+			return true
+		}
+	}
+	for _, n := range l.TagKeys {
+		if n > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSeries returns the maximum number of series of the measurement.
+func (l *CardinalityLimits) MaxSeries(name []byte) int {
+	if n, ok := l.Measurements[string(name)]; ok && n != 0 {
+		return n
+	}
+	return l.MaxSeriesPerMeasurement
+}
+
+// MaxValues returns the maximum number of values of the tag key.
+func (l *CardinalityLimits) MaxValues(key []byte) int {
+	if n, ok := l.TagKeys[string(key)]; ok && n != 0 {
+		return n
+	}
+	return l.MaxValuesPerTag
+}
+
+// CardinalityReport reports the cardinality of a database, to find the
+// measurements and tag keys responsible for its series.
+type CardinalityReport struct {
+	Database string    `json:"database"`
+	Time     time.Time `json:"time"`
+
+	// SeriesN and MeasurementsN are estimated with the sketches of the shards.
+	SeriesN       int64 `json:"series"`
+	MeasurementsN int64 `json:"measurements"`
+
+	// Measurements and TagKeys are the ones with the most series and values.
+	Measurements []MeasurementCardinality `json:"top_measurements"`
+	TagKeys      []TagKeyCardinality      `json:"top_tag_keys"`
+}
+
+// MeasurementCardinality is the number of series of a measurement.
+type MeasurementCardinality struct {
+	Measurement string `json:"measurement"`
+	SeriesN     int64  `json:"series"`
+
+	// Growth is the number of series added per hour since the previous
+	// report, or zero for the first report.
+	Growth float64 `json:"growth_per_hour"`
+}
+
+// TagKeyCardinality is the number of values of a tag key in a measurement.
+type TagKeyCardinality struct {
+	Measurement string `json:"measurement"`
+	Key         string `json:"key"`
+	ValuesN     int64  `json:"values"`
+
+	// Growth is the number of values added per hour since the previous
+	// report, or zero for the first report.
+	Growth float64 `json:"growth_per_hour"`
+}
+
+// Top sorts the measurements and tag keys of the report by decreasing
+// cardinality and keeps the first n of each.
+func (r *CardinalityReport) Top(n int) {
+	sort.Slice(r.Measurements, func(i, j int) bool {
+		a, b := r.Measurements[i], r.Measurements[j]
+		if a.SeriesN != b.SeriesN {
+			return a.SeriesN > b.SeriesN
+		}
+		return a.Measurement < b.Measurement
+	})
+	sort.Slice(r.TagKeys, func(i, j int) bool {
+		a, b := r.TagKeys[i], r.TagKeys[j]
+		if a.ValuesN != b.ValuesN {
+			return a.ValuesN > b.ValuesN
+		} else if a.Measurement != b.Measurement {
+			return a.Measurement < b.Measurement
+		}
+		return a.Key < b.Key
+	})
+
+	if len(r.Measurements) > n {
+		r.Measurements = r.Measurements[:n]
+	}
+	if len(r.TagKeys) > n {
+		r.TagKeys = r.TagKeys[:n]
+	}
+}
+
+// cardinalitySample holds the cardinality of the measurements and tag keys of
+// a database at the time of a report, to compute the growth of the next one.
+type cardinalitySample struct {
+	time   time.Time
+	series map[string]int64
+	values map[string]int64
+}
+
+// cardinalitySamples holds the sample of the last report of each database.
+type cardinalitySamples struct {
+	mu      sync.Mutex
+	samples map[string]*cardinalitySample
+}
+
+// swap stores the sample of database and returns the previous one.
+func (s *cardinalitySamples) swap(database string, sample *cardinalitySample) *cardinalitySample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.samples == nil {
+		s.samples = make(map[string]*cardinalitySample)
+	}
+	prev := s.samples[database]
+	s.samples[database] = sample
+	return prev
+}
+
+// CardinalityReport returns the n measurements with the most series and the
+// n tag keys with the most values in the shards of database on this node.
+// The series of a measurement and the values of a tag key are counted
+// exactly across the shards, and their growth is computed since the previous
+// report of the database.
+func (s *Store) CardinalityReport(ctx context.Context, database string, n int) (*CardinalityReport, error) {
+	if n <= 0 {
+		n = DefaultCardinalityReportN
+	}
+
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	// Shards sharing an inmem index only need to be read once.
+	indexes := make(map[uintptr]Index, len(shards))
+	for _, sh := range shards {
+		index, err := sh.Index()
+		if err != nil {
+			return nil, err
+		}
+		indexes[index.UniqueReferenceID()] = index
+	}
+
+	series := make(map[string]*SeriesIDSet)
+	values := make(map[string]map[uint64]struct{})
+	for _, index := range indexes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := collectCardinality(index, series, values); err != nil {
+			return nil, err
+		}
+	}
+
+	sample := &cardinalitySample{
+		time:   time.Now().UTC(),
+		series: make(map[string]int64, len(series)),
+		values: make(map[string]int64, len(values)),
+	}
+	for name, ids := range series {
+		sample.series[name] = int64(ids.Cardinality())
+	}
+	for k, vs := range values {
+		sample.values[k] = int64(len(vs))
+	}
+	prev := s.cardinality.swap(database, sample)
+
+	// growth returns the number of elements added per hour since the previous report.
+	growth := func(count int64, prevCounts map[string]int64, k string) float64 {
+		if prev == nil {
+			return 0
+		}
+		hours := sample.time.Sub(prev.time).Hours()
+		if hours <= 0 {
+			return 0
+		}
+		return float64(count-prevCounts[k]) / hours
+	}
+
+	report := &CardinalityReport{Database: database, Time: sample.time}
+	for name, count := range sample.series {
+		report.Measurements = append(report.Measurements, MeasurementCardinality{
+			Measurement: name,
+			SeriesN:     count,
+			Growth:      growth(count, prev.seriesMap(), name),
+		})
+	}
+	for k, count := range sample.values {
+		name, key := splitTagKeyID(k)
+		report.TagKeys = append(report.TagKeys, TagKeyCardinality{
+			Measurement: name,
+			Key:         key,
+			ValuesN:     count,
+			Growth:      growth(count, prev.valuesMap(), k),
+		})
+	}
+	report.Top(n)
+
+	if len(shards) > 0 {
+		ss, ts, err := s.SeriesSketches(ctx, database)
+		if err != nil {
+			return nil, err
+		}
+		report.SeriesN = int64(ss.Count()) - int64(ts.Count())
+
+		ss, ts, err = s.MeasurementsSketches(ctx, database)
+		if err != nil {
+			return nil, err
+		}
+		report.MeasurementsN = int64(ss.Count()) - int64(ts.Count())
+	}
+	return report, nil
+}
+
+// seriesMap returns the series of the sample, nil for a nil sample.
+func (s *cardinalitySample) seriesMap() map[string]int64 {
+	if s == nil {
+		return nil
+	}
+	return s.series
+}
+
+// valuesMap returns the tag values of the sample, nil for a nil sample.
+func (s *cardinalitySample) valuesMap() map[string]int64 {
+	if s == nil {
+		return nil
+	}
+	return s.values
+}
+
+// collectCardinality adds the series IDs of each measurement and the hashes
+// of the values of each tag key of index to series and values.
+func collectCardinality(index Index, series map[string]*SeriesIDSet, values map[string]map[uint64]struct{}) error {
+	return index.ForEachMeasurementName(func(name []byte) error {
+		ids := series[string(name)]
+		if ids == nil {
+			ids = NewSeriesIDSet()
+			series[string(name)] = ids
+		}
+
+		sitr, err := index.MeasurementSeriesIDIterator(name)
+		if err != nil {
+			return err
+		} else if sitr != nil {
+			for {
+				e, err := sitr.Next()
+				if err != nil {
+					sitr.Close()
+					return err
+				} else if e.SeriesID == 0 {
+					break
+				}
+				ids.AddNoLock(e.SeriesID)
+			}
+			sitr.Close()
+		}
+
+		kitr, err := index.TagKeyIterator(name)
+		if err != nil {
+			return err
+		} else if kitr == nil {
+			return nil
+		}
+		defer kitr.Close()
+
+		for {
+			key, err := kitr.Next()
+			if err != nil {
+				return err
+			} else if key == nil {
+				return nil
+			}
+
+			id := tagKeyID(name, key)
+			vs := values[id]
+			if vs == nil {
+				vs = make(map[uint64]struct{})
+				values[id] = vs
+			}
+			if err := forEachTagValue(index, name, key, func(value []byte) {
+				vs[xxhash.Sum64(value)] = struct{}{}
+			}); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// forEachTagValue calls fn with each value of the tag key of the measurement.
+func forEachTagValue(index Index, name, key []byte, fn func(value []byte)) error {
+	itr, err := index.TagValueIterator(name, key)
+	if err != nil {
+		return err
+	} else if itr == nil {
+		return nil
+	}
+	defer itr.Close()
+
+	for {
+		value, err := itr.Next()
+		if err != nil {
+			return err
+		} else if value == nil {
+			return nil
+		}
+		fn(value)
+	}
+}
+
+// measurementSeriesN returns the number of series of the measurement in index.
+func measurementSeriesN(index Index, name []byte) (int, error) {
+	itr, err := index.MeasurementSeriesIDIterator(name)
+	if err != nil {
+		return 0, err
+	} else if itr == nil {
+		return 0, nil
+	}
+	defer itr.Close()
+
+	var n int
+	for {
+		e, err := itr.Next()
+		if err != nil {
+			return 0, err
+		} else if e.SeriesID == 0 {
+			return n, nil
+		}
+		n++
+	}
+}
+
+// tagValueN returns the number of values of the tag key of the measurement in index.
+func tagValueN(index Index, name, key []byte) (int, error) {
+	var n int
+	err := forEachTagValue(index, name, key, func([]byte) { n++ })
+	return n, err
+}
+
+// cardinalityIndex is implemented by indexes counting the series of a
+// measurement and the values of a tag key in constant time.
+type cardinalityIndex interface {
+	MeasurementSeriesN(name []byte) int
+	TagKeyCardinality(name, key []byte) int
+}
+
+// cardinalityCache caches the number of series of the measurements and the
+// number of values of the tag keys of a shard's index, so that enforcing the
+// cardinality limits doesn't iterate over the index on every write.
+//
+// Counts are read from the index the first time, then incremented as series
+// and values are created. A count may overestimate the index, e.g. when two
+// writes create the same series, so it is read again from the index before a
+// limit is enforced on it.
+type cardinalityCache struct {
+	mu     sync.Mutex
+	series map[string]*cardinalityCount
+	values map[string]*cardinalityCount
+}
+
+type cardinalityCount struct {
+	n     int
+	exact bool // n was read from the index and not incremented since
+}
+
+// seriesN returns the number of series of the measurement in index.
+func (c *cardinalityCache) seriesN(index Index, name []byte, max int) (int, error) {
+	if idx, ok := index.(cardinalityIndex); ok {
+		return idx.MeasurementSeriesN(name), nil
+	}
+	return c.count(&c.series, string(name), max, func() (int, error) {
+		return measurementSeriesN(index, name)
+	})
+}
+
+// valueN returns the number of values of the tag key of the measurement in index.
+func (c *cardinalityCache) valueN(index Index, name, key []byte, max int) (int, error) {
+	if idx, ok := index.(cardinalityIndex); ok {
+		return idx.TagKeyCardinality(name, key), nil
+	}
+	return c.count(&c.values, tagKeyID(name, key), max, func() (int, error) {
+		return tagValueN(index, name, key)
+	})
+}
+
+func (c *cardinalityCache) count(m *map[string]*cardinalityCount, id string, max int, fn func() (int, error)) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cnt := (*m)[id]; cnt != nil && (cnt.exact || cnt.n < max) {
+		return cnt.n, nil
+	}
+
+	n, err := fn()
+	if err != nil {
+		return 0, err
+	}
+	if *m == nil {
+		*m = make(map[string]*cardinalityCount)
+	}
+	(*m)[id] = &cardinalityCount{n: n, exact: true}
+	return n, nil
+}
+
+// add increments the number of series of the measurement and the number of
+// values of the tag keys, by identifier.
+func (c *cardinalityCache) add(name []byte, keyIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cnt := c.series[string(name)]; cnt != nil {
+		cnt.n, cnt.exact = cnt.n+1, false
+	}
+	for _, id := range keyIDs {
+		if cnt := c.values[id]; cnt != nil {
+			cnt.n, cnt.exact = cnt.n+1, false
+		}
+	}
+}
+
+// reset drops the counts, e.g. after series are deleted from the index.
+func (c *cardinalityCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.series, c.values = nil, nil
+}
+
+// tagKeyID returns the identifier of a tag key of a measurement.
+func tagKeyID(name, key []byte) string {
+	return string(name) + "\x00" + string(key)
+}
+
+// splitTagKeyID returns the measurement and the tag key of an identifier
+// returned by tagKeyID.
+func splitTagKeyID(id string) (name, key string) {
+	name, key, _ = strings.Cut(id, "\x00")
+	return name, key
+}
@@ -0,0 +1,118 @@
+package tsdb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Compaction states reported in CompactionInfo.
+const (
+	// CompactionRunning is the state of a compaction that is writing files.
+	CompactionRunning = "running"
+
+	// CompactionQueued is the state of a planned compaction that is waiting
+	// for a compaction slot.
+	CompactionQueued = "queued"
+
+	// CompactionDeferred is the state of a planned full or optimize
+	// compaction that is waiting for a compaction window to open.
+	CompactionDeferred = "deferred"
+
+	// CompactionPaused is the state reported for a shard whose compactions
+	// are paused.
+	CompactionPaused = "paused"
+)
+
+// CompactionInfo describes a running or planned compaction of a shard.
+type CompactionInfo struct {
+	ShardID   uint64
+	Level     int
+	Strategy  string
+	State     string
+	Files     int
+	Bytes     int64
+	Progress  float64
+	StartedAt time.Time
+}
+
+// CompactionWindow is a time of day range, in local time, during which full
+// and optimize compactions of cold shards may run. A window whose end is
+// before its start spans midnight.
+type CompactionWindow struct {
+	Start time.Duration // offset from midnight
+	End   time.Duration // offset from midnight
+}
+
+// ParseCompactionWindow parses a window of the form "HH:MM-HH:MM".
+func ParseCompactionWindow(s string) (CompactionWindow, error) {
+	var w CompactionWindow
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return w, fmt.Errorf("invalid compaction window %q: expected HH:MM-HH:MM", s)
+	}
+
+	var err error
+	if w.Start, err = parseTimeOfDay(start); err != nil {
+		return w, fmt.Errorf("invalid compaction window %q: %s", s, err)
+	}
+	if w.End, err = parseTimeOfDay(end); err != nil {
+		return w, fmt.Errorf("invalid compaction window %q: %s", s, err)
+	}
+	if w.Start == w.End {
+		return w, fmt.Errorf("invalid compaction window %q: start and end are equal", s)
+	}
+	return w, nil
+}
+
+// ParseCompactionWindows parses each of a, in the form "HH:MM-HH:MM".
+func ParseCompactionWindows(a []string) ([]CompactionWindow, error) {
+	windows := make([]CompactionWindow, 0, len(a))
+	for _, s := range a {
+		w, err := ParseCompactionWindow(s)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// parseTimeOfDay parses "HH:MM" as an offset from midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains returns true if the time of day of t is within the window.
+func (w CompactionWindow) Contains(t time.Time) bool {
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.Start < w.End {
+		return d >= w.Start && d < w.End
+	}
+	return d >= w.Start || d < w.End
+}
+
+// String returns the window in the form "HH:MM-HH:MM".
+func (w CompactionWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d",
+		int(w.Start/time.Hour), int(w.Start%time.Hour/time.Minute),
+		int(w.End/time.Hour), int(w.End%time.Hour/time.Minute))
+}
+
+// InCompactionWindow returns true if windows is empty or t is within any of
+// windows.
+func InCompactionWindow(windows []CompactionWindow, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
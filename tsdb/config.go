@@ -3,6 +3,8 @@ package tsdb
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb/monitor/diagnostics"
@@ -24,6 +26,10 @@ const (
 	// reach before it starts rejecting writes.
 	DefaultCacheMaxMemorySize = 1024 * 1024 * 1024 // 1GB
 
+	// DefaultCacheSpillMaxSize is the maximum size of the writes a shard can
+	// spill to disk while its cache is full before it starts rejecting writes.
+	DefaultCacheSpillMaxSize = 4 * 1024 * 1024 * 1024 // 4GB
+
 	// DefaultCacheSnapshotMemorySize is the size at which the engine will
 	// snapshot the cache and write it to a TSM file, freeing up memory
 	DefaultCacheSnapshotMemorySize = 25 * 1024 * 1024 // 25MB
@@ -73,10 +79,18 @@ const (
 	// DefaultSeriesIDSetCacheSize is the default number of series ID sets to cache in the TSI index.
 	DefaultSeriesIDSetCacheSize = 100
 
+	// DefaultTSMBlockEncodingVersion is the default version of the encodings
+	// TSM blocks are written with.
+	DefaultTSMBlockEncodingVersion = 1
+
 	// DefaultSeriesFileMaxConcurrentSnapshotCompactions is the maximum number of concurrent series
 	// partition snapshot compactions that can run at one time.
 	// A value of 0 results in runtime.GOMAXPROCS(0).
 	DefaultSeriesFileMaxConcurrentSnapshotCompactions = 0
+
+	// DefaultOffloadCacheMaxSize is the default size, in bytes, that shards
+	// fetched from object storage may use in the offload cache.
+	DefaultOffloadCacheMaxSize = 10 * 1024 * 1024 * 1024 // 10GB
 )
 
 // Config holds the configuration for the tsbd package.
@@ -85,6 +99,16 @@ type Config struct {
 	Engine string `toml:"-"`
 	Index  string `toml:"index-version"`
 
+	// ColdDir is an optional secondary data directory, typically on slower
+	// and cheaper storage, that cold shards are moved to by the tiering service.
+	ColdDir string `toml:"cold-dir"`
+
+	// OffloadCacheDir is the directory that shards offloaded to object storage
+	// are fetched into when they are accessed. OffloadCacheMaxSize bounds its
+	// size; the least recently accessed shards are evicted first.
+	OffloadCacheDir     string    `toml:"offload-cache-dir"`
+	OffloadCacheMaxSize toml.Size `toml:"offload-cache-max-size"`
+
 	// General WAL configuration options
 	WALDir string `toml:"wal-dir"`
 
@@ -110,6 +134,18 @@ type Config struct {
 	CompactThroughput              toml.Size     `toml:"compact-throughput"`
 	CompactThroughputBurst         toml.Size     `toml:"compact-throughput-burst"`
 
+	// CompactFullWindows are the times of day, in local time and of the form
+	// "HH:MM-HH:MM", during which full and optimize compactions of cold
+	// shards may start. Compactions may start at any time if empty.
+	CompactFullWindows []string `toml:"compact-full-windows"`
+
+	// CacheSpillEnabled makes writes that do not fit in a full cache be
+	// written to the WAL only, instead of being rejected. They are folded
+	// into TSM files in the background and are not queryable until then.
+	// CacheSpillMaxSize is the maximum size of a shard's spilled writes.
+	CacheSpillEnabled bool      `toml:"cache-spill-enabled"`
+	CacheSpillMaxSize toml.Size `toml:"cache-spill-max-size"`
+
 	// Limits
 
 	// MaxSeriesPerDatabase is the maximum number of series a node can hold per database.
@@ -122,6 +158,11 @@ type Config struct {
 	// A value of 0 disables the limit.
 	MaxValuesPerTag int `toml:"max-values-per-tag"`
 
+	// MaxSeriesPerMeasurement is the maximum number of series a measurement can have in
+	// a shard. Points of new series beyond the limit are dropped, whatever the index.
+	// A value of 0 disables the limit. It can be overridden per database in meta.
+	MaxSeriesPerMeasurement int `toml:"max-series-per-measurement"`
+
 	// MaxConcurrentCompactions is the maximum number of concurrent level and full compactions
 	// that can be running at one time across all shards.  Compactions scheduled to run when the
 	// limit is reached are blocked until a running compaction completes.  Snapshot compactions are
@@ -157,6 +198,12 @@ type Config struct {
 	// been found to be problematic in some cases. It may help users who have
 	// slow disks.
 	TSMWillNeed bool `toml:"tsm-use-madv-willneed"`
+
+	// TSMBlockEncodingVersion is the version of the encodings new TSM blocks
+	// are written with. Version 1 writes floats with Gorilla encoding and
+	// strings with snappy. Version 2 writes floats with ALP encoding and
+	// strings with zstd, in TSM files that earlier releases cannot read.
+	TSMBlockEncodingVersion int `toml:"tsm-block-encoding-version"`
 }
 
 // NewConfig returns the default configuration for tsdb.
@@ -170,6 +217,7 @@ func NewConfig() Config {
 
 		CacheMaxMemorySize:             toml.Size(DefaultCacheMaxMemorySize),
 		CacheSnapshotMemorySize:        toml.Size(DefaultCacheSnapshotMemorySize),
+		CacheSpillMaxSize:              toml.Size(DefaultCacheSpillMaxSize),
 		CacheSnapshotWriteColdDuration: toml.Duration(DefaultCacheSnapshotWriteColdDuration),
 		CompactFullWriteColdDuration:   toml.Duration(DefaultCompactFullWriteColdDuration),
 		CompactThroughput:              toml.Size(DefaultCompactThroughput),
@@ -185,8 +233,12 @@ func NewConfig() Config {
 
 		SeriesFileMaxConcurrentSnapshotCompactions: DefaultSeriesFileMaxConcurrentSnapshotCompactions,
 
+		OffloadCacheMaxSize: toml.Size(DefaultOffloadCacheMaxSize),
+
 		TraceLoggingEnabled: false,
 		TSMWillNeed:         false,
+
+		TSMBlockEncodingVersion: DefaultTSMBlockEncodingVersion,
 	}
 }
 
@@ -196,12 +248,24 @@ func (c *Config) Validate() error {
 		return errors.New("Data.Dir must be specified")
 	} else if c.WALDir == "" {
 		return errors.New("Data.WALDir must be specified")
+	} else if c.ColdDir != "" && nestedDir(c.ColdDir, c.Dir) {
+		return errors.New("Data.ColdDir must not be inside Data.Dir")
+	} else if c.OffloadCacheDir != "" && (nestedDir(c.OffloadCacheDir, c.Dir) || (c.ColdDir != "" && nestedDir(c.OffloadCacheDir, c.ColdDir))) {
+		return errors.New("Data.OffloadCacheDir must not be inside Data.Dir or Data.ColdDir")
+	}
+
+	if c.CacheSpillEnabled && c.CacheSpillMaxSize == 0 {
+		return errors.New("cache-spill-max-size must be positive when cache-spill-enabled is set")
 	}
 
 	if c.MaxConcurrentCompactions < 0 {
 		return errors.New("max-concurrent-compactions must be non-negative")
 	}
 
+	if _, err := ParseCompactionWindows(c.CompactFullWindows); err != nil {
+		return fmt.Errorf("compact-full-windows: %s", err)
+	}
+
 	if c.MaxConcurrentDeletes <= 0 {
 		return errors.New("max-concurrent-deletes must be positive")
 	}
@@ -214,6 +278,10 @@ func (c *Config) Validate() error {
 		return errors.New("series-file-max-concurrent-compactions must be non-negative")
 	}
 
+	if c.TSMBlockEncodingVersion != 1 && c.TSMBlockEncodingVersion != 2 {
+		return fmt.Errorf("tsm-block-encoding-version must be 1 or 2, got %d", c.TSMBlockEncodingVersion)
+	}
+
 	valid := false
 	for _, e := range RegisteredEngines() {
 		if e == c.Engine {
@@ -239,23 +307,37 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// nestedDir returns true if dir is the same as or inside parent.
+func nestedDir(dir, parent string) bool {
+	rel, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(dir))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // Diagnostics returns a diagnostics representation of a subset of the Config.
 func (c Config) Diagnostics() (*diagnostics.Diagnostics, error) {
 	return diagnostics.RowFromMap(map[string]interface{}{
 		"dir":                                    c.Dir,
+		"cold-dir":                               c.ColdDir,
+		"offload-cache-dir":                      c.OffloadCacheDir,
+		"offload-cache-max-size":                 c.OffloadCacheMaxSize,
 		"wal-dir":                                c.WALDir,
 		"wal-fsync-delay":                        c.WALFsyncDelay,
 		"strict-error-handling":                  c.StrictErrorHandling,
 		"cache-max-memory-size":                  c.CacheMaxMemorySize,
 		"cache-snapshot-memory-size":             c.CacheSnapshotMemorySize,
+		"cache-spill-enabled":                    c.CacheSpillEnabled,
+		"cache-spill-max-size":                   c.CacheSpillMaxSize,
 		"cache-snapshot-write-cold-duration":     c.CacheSnapshotWriteColdDuration,
 		"compact-full-write-cold-duration":       c.CompactFullWriteColdDuration,
+		"compact-full-windows":                   strings.Join(c.CompactFullWindows, ","),
 		"max-series-per-database":                c.MaxSeriesPerDatabase,
 		"max-values-per-tag":                     c.MaxValuesPerTag,
+		"max-series-per-measurement":             c.MaxSeriesPerMeasurement,
 		"max-concurrent-compactions":             c.MaxConcurrentCompactions,
 		"max-concurrent-deletes":                 c.MaxConcurrentDeletes,
 		"max-index-log-file-size":                c.MaxIndexLogFileSize,
 		"series-id-set-cache-size":               c.SeriesIDSetCacheSize,
 		"series-file-max-concurrent-compactions": c.SeriesFileMaxConcurrentSnapshotCompactions,
+		"tsm-block-encoding-version":             c.TSMBlockEncodingVersion,
 	}), nil
 }
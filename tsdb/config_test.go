@@ -75,6 +75,70 @@ func TestConfig_Validate_Error(t *testing.T) {
 	if err := c.Validate(); err == nil || err.Error() != "series-id-set-cache-size must be non-negative" {
 		t.Errorf("unexpected error: %s", err)
 	}
+
+	c.SeriesIDSetCacheSize = 0
+	c.CacheSpillEnabled = true
+	c.CacheSpillMaxSize = 0
+	if err := c.Validate(); err == nil || err.Error() != "cache-spill-max-size must be positive when cache-spill-enabled is set" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.CacheSpillEnabled = false
+	c.CompactFullWindows = []string{"01:00-05:00", "23:00"}
+	if err := c.Validate(); err == nil || err.Error() != `compact-full-windows: invalid compaction window "23:00": expected HH:MM-HH:MM` {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	c.CompactFullWindows = nil
+	c.TSMBlockEncodingVersion = 3
+	if err := c.Validate(); err == nil || err.Error() != "tsm-block-encoding-version must be 1 or 2, got 3" {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestCompactionWindow_Contains(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2020, 1, 1, hour, min, 0, 0, time.Local)
+	}
+
+	w, err := tsdb.ParseCompactionWindow("01:00-05:30")
+	if err != nil {
+		t.Fatal(err)
+	} else if got, exp := w.String(), "01:00-05:30"; got != exp {
+		t.Fatalf("unexpected window: got %s, exp %s", got, exp)
+	}
+	for _, tt := range []struct {
+		t   time.Time
+		exp bool
+	}{{at(0, 59), false}, {at(1, 0), true}, {at(5, 29), true}, {at(5, 30), false}} {
+		if got := w.Contains(tt.t); got != tt.exp {
+			t.Errorf("Contains(%s) = %v, exp %v", tt.t.Format("15:04"), got, tt.exp)
+		}
+	}
+
+	// A window ending before it starts spans midnight.
+	w, err = tsdb.ParseCompactionWindow("22:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		t   time.Time
+		exp bool
+	}{{at(21, 59), false}, {at(23, 0), true}, {at(1, 59), true}, {at(2, 0), false}} {
+		if got := w.Contains(tt.t); got != tt.exp {
+			t.Errorf("Contains(%s) = %v, exp %v", tt.t.Format("15:04"), got, tt.exp)
+		}
+	}
+
+	for _, s := range []string{"25:00-01:00", "01:00-01:00", "0100-0200"} {
+		if _, err := tsdb.ParseCompactionWindow(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+
+	if !tsdb.InCompactionWindow(nil, at(12, 0)) {
+		t.Error("expected no windows to allow compactions at any time")
+	}
 }
 
 func TestConfig_ByteSizes(t *testing.T) {
@@ -36,6 +36,15 @@ type Engine interface {
 	SetEnabled(enabled bool)
 	SetCompactionsEnabled(enabled bool)
 	ScheduleFullCompaction() error
+	Compactions() []CompactionInfo
+	SetCompactionsPaused(paused bool) error
+	CompactionsPaused() bool
+
+	ReadWAL(pos WALPosition, limit int) ([]byte, WALPosition, error)
+	WALEnd() WALPosition
+	WALBytesSince(pos WALPosition) int64
+	SetWALRetention(pos WALPosition) error
+	DecodeWAL(b []byte) ([]models.Point, error)
 
 	WithLogger(*zap.Logger)
 
@@ -47,6 +56,9 @@ type Engine interface {
 	Restore(r io.Reader, basePath string) error
 	Import(r io.Reader, basePath string) error
 	Digest() (io.ReadCloser, int64, error)
+	Verify(ctx context.Context, rate limiter.Rate) (VerifyStats, error)
+	BuildIndex(ctx context.Context, index Index, progress func(n int)) error
+	Rollup(ctx context.Context, interval, previous time.Duration) error
 
 	CreateIterator(ctx context.Context, measurement string, opt query.IteratorOptions) (query.Iterator, error)
 	CreateCursorIterator(ctx context.Context) (CursorIterator, error)
@@ -69,6 +81,7 @@ type Engine interface {
 	MeasurementFields(measurement []byte) *MeasurementFields
 	ForEachMeasurementName(fn func(name []byte) error) error
 	DeleteMeasurement(name []byte) error
+	DeleteFieldRange(name []byte, fields []string, min, max int64) error
 
 	HasTagKey(name, key []byte) (bool, error)
 	MeasurementTagKeysByExpr(name []byte, expr influxql.Expr) (map[string]struct{}, error)
@@ -191,6 +204,9 @@ type EngineOptions struct {
 	SeriesIDSets   SeriesIDSets
 	FieldValidator FieldValidator
 
+	// CardinalityLimits returns the limits of a database overriding those of Config.
+	CardinalityLimits func(database string) *CardinalityLimits
+
 	OnNewEngine func(Engine)
 
 	FileStoreObserver FileStoreObserver
@@ -12,9 +12,18 @@ import (
 // FloatArrayEncodeAll encodes src into b, returning b and any error encountered.
 // The returned slice may be of a different length and capactity to b.
 //
-// Currently only the float compression scheme used in Facebook's Gorilla is
-// supported, so this method implements a batch oriented version of that.
+// Values are encoded with ALP encoding if the block encoding version is
+// BlockEncodingV2, and with Gorilla encoding otherwise.
 func FloatArrayEncodeAll(src []float64, b []byte) ([]byte, error) {
+	if blockEncodingVersion() == BlockEncodingV2 {
+		return floatALPEncodeAll(src, b)
+	}
+	return floatGorillaEncodeAll(src, b)
+}
+
+// floatGorillaEncodeAll is a batch oriented version of the float compression
+// scheme used in Facebook's Gorilla.
+func floatGorillaEncodeAll(src []float64, b []byte) ([]byte, error) {
 	if cap(b) < 9 {
 		b = make([]byte, 0, 9) // Enough room for the header and one value.
 	}
@@ -276,6 +285,9 @@ func init() {
 }
 
 func FloatArrayDecodeAll(b []byte, buf []float64) ([]float64, error) {
+	if len(b) > 0 && b[0]>>4 == floatCompressedALP {
+		return floatALPDecodeAll(b, buf)
+	}
 	if len(b) < 9 {
 		return []float64{}, nil
 	}
@@ -286,7 +298,7 @@ func FloatArrayDecodeAll(b []byte, buf []float64) ([]float64, error) {
 		meaningfulN uint8  = 64 // meaningful bit count
 	)
 
-	// first byte is the compression type; always Gorilla
+	// first byte is the compression type; Gorilla
 	b = b[1:]
 
 	val = binary.BigEndian.Uint64(b)
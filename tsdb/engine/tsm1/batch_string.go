@@ -22,8 +22,35 @@ var (
 // StringArrayEncodeAll encodes src into b, returning b and any error encountered.
 // The returned slice may be of a different length and capactity to b.
 //
-// Currently only the string compression scheme used snappy.
+// Strings are compressed with zstd if the block encoding version is
+// BlockEncodingV2, and with snappy otherwise.
 func StringArrayEncodeAll(src []string, b []byte) ([]byte, error) {
+	if blockEncodingVersion() == BlockEncodingV2 {
+		return stringZstdEncodeAll(src, b)
+	}
+	return stringSnappyEncodeAll(src, b)
+}
+
+// stringZstdEncodeAll encodes src into b using zstd compression.
+func stringZstdEncodeAll(src []string, b []byte) ([]byte, error) {
+	var srcSz int64
+	for i := range src {
+		srcSz += int64(binary.MaxVarintLen32 + len(src[i]))
+	}
+	if srcSz > math.MaxUint32 {
+		return b[:0], ErrStringArrayEncodeTooLarge
+	}
+
+	dta := make([]byte, 0, srcSz)
+	for i := range src {
+		dta = binary.AppendUvarint(dta, uint64(len(src[i])))
+		dta = append(dta, src[i]...)
+	}
+	return stringZstdEncoder.EncodeAll(dta, append(b[:0], stringCompressedZstd<<4)), nil
+}
+
+// stringSnappyEncodeAll encodes src into b using snappy compression.
+func stringSnappyEncodeAll(src []string, b []byte) ([]byte, error) {
 	srcSz64 := int64(2 + len(src)*binary.MaxVarintLen32) // strings should't be longer than 64kb
 	for i := range src {
 		srcSz64 += int64(len(src[i]))
@@ -78,16 +105,15 @@ func StringArrayEncodeAll(src []string, b []byte) ([]byte, error) {
 }
 
 func StringArrayDecodeAll(b []byte, dst []string) ([]string, error) {
-	// First byte stores the encoding type, only have snappy format
-	// currently so ignore for now.
+	// First byte stores the encoding type.
 	if len(b) > 0 {
 		var err error
-		// it is important that to note that `snappy.Decode` always returns
+		// it is important that to note that decodeStringBytes always returns
 		// a newly allocated slice as the final strings reference this slice
 		// directly.
-		b, err = snappy.Decode(nil, b[1:])
+		b, err = decodeStringBytes(b)
 		if err != nil {
-			return []string{}, fmt.Errorf("failed to decode string block: %v", err.Error())
+			return []string{}, err
 		}
 	} else {
 		return []string{}, nil
@@ -0,0 +1,168 @@
+package tsm1
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	// BlockEncodingV1 writes float blocks with Gorilla encoding and string
+	// blocks with snappy compression, in TSM files of Version.
+	BlockEncodingV1 = 1
+
+	// BlockEncodingV2 writes float blocks with ALP encoding and string blocks
+	// with zstd compression and a shared dictionary, in TSM files of Version2. Blocks of version 1
+	// are rewritten in the version 2 encodings by compactions that are not
+	// fast.
+	BlockEncodingV2 = 2
+)
+
+// blockEncoding is the block encoding version new blocks are written with.
+// It is process wide, as blocks are encoded without reference to an engine.
+var blockEncoding int32 = BlockEncodingV1
+
+// SetBlockEncodingVersion sets the block encoding version new blocks and TSM
+// files are written with. Versions other than BlockEncodingV2 select
+// BlockEncodingV1.
+func SetBlockEncodingVersion(v int) {
+	if v != BlockEncodingV2 {
+		v = BlockEncodingV1
+	}
+	atomic.StoreInt32(&blockEncoding, int32(v))
+}
+
+// blockEncodingVersion returns the block encoding version new blocks are
+// written with.
+func blockEncodingVersion() int {
+	return int(atomic.LoadInt32(&blockEncoding))
+}
+
+// fileVersion returns the version of TSM files written with the current
+// block encoding version.
+func fileVersion() byte {
+	if blockEncodingVersion() == BlockEncodingV2 {
+		return Version2
+	}
+	return Version
+}
+
+// blockValuesEncoding returns the type of block and the encoding of its values.
+func blockValuesEncoding(block []byte) (typ, enc byte, err error) {
+	if len(block) <= encodedBlockHeaderSize {
+		return 0, 0, fmt.Errorf("short block: got %v, exp > %v", len(block), encodedBlockHeaderSize)
+	}
+	_, vb, err := unpackBlock(block[1:])
+	if err != nil {
+		return 0, 0, err
+	} else if len(vb) == 0 {
+		return block[0], 0, nil
+	}
+	return block[0], vb[0] >> 4, nil
+}
+
+// blockEncodingOf returns the block encoding version a block was written with.
+func blockEncodingOf(block []byte) (int, error) {
+	typ, enc, err := blockValuesEncoding(block)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case typ == BlockFloat64 && enc == floatCompressedALP:
+		return BlockEncodingV2, nil
+	case typ == BlockString && enc == stringCompressedZstd:
+		return BlockEncodingV2, nil
+	}
+	return BlockEncodingV1, nil
+}
+
+// reencodeBlock returns true if a compaction must decode and re-encode block
+// rather than copy it. Blocks of a newer encoding version than the current one
+// are always re-encoded, so files of the current version can be read by
+// releases that only know it. Float and string blocks of an older encoding
+// version are re-encoded unless fast is set.
+func reencodeBlock(block []byte, fast bool) bool {
+	v, err := blockEncodingOf(block)
+	if err != nil {
+		// Decoding the block will report the error.
+		return false
+	}
+	current := blockEncodingVersion()
+	if v > current {
+		return true
+	} else if v == current || fast {
+		return false
+	}
+	typ := block[0]
+	return typ == BlockFloat64 || typ == BlockString
+}
+
+// BlockEncodingName returns the name of the encoding of the values of block.
+func BlockEncodingName(block []byte) (string, error) {
+	typ, enc, err := blockValuesEncoding(block)
+	if err != nil {
+		return "", err
+	}
+	switch typ {
+	case BlockFloat64:
+		switch enc {
+		case floatCompressedGorilla:
+			return "gorilla", nil
+		case floatCompressedALP:
+			return "alp", nil
+		}
+	case BlockString:
+		switch enc {
+		case stringCompressedSnappy:
+			return "snappy", nil
+		case stringCompressedZstd:
+			return "zstd", nil
+		}
+	case BlockInteger, BlockUnsigned:
+		switch enc {
+		case intUncompressed:
+			return "uncompressed", nil
+		case intCompressedSimple:
+			return "simple8b", nil
+		case intCompressedRLE:
+			return "rle", nil
+		}
+	case BlockBoolean:
+		return "bitpacked", nil
+	}
+	return "", fmt.Errorf("unknown encoding %d of block type %d", enc, typ)
+}
+
+// BlockSizeV1 returns the size block would have with the encodings of
+// BlockEncodingV1.
+func BlockSizeV1(block []byte) (int, error) {
+	v, err := blockEncodingOf(block)
+	if err != nil {
+		return 0, err
+	} else if v == BlockEncodingV1 {
+		return len(block), nil
+	}
+
+	tb, vb, err := unpackBlock(block[1:])
+	if err != nil {
+		return 0, err
+	}
+	switch block[0] {
+	case BlockFloat64:
+		values, err := FloatArrayDecodeAll(vb, nil)
+		if err != nil {
+			return 0, err
+		}
+		if vb, err = floatGorillaEncodeAll(values, nil); err != nil {
+			return 0, err
+		}
+	case BlockString:
+		values, err := StringArrayDecodeAll(vb, nil)
+		if err != nil {
+			return 0, err
+		}
+		if vb, err = stringSnappyEncodeAll(values, nil); err != nil {
+			return 0, err
+		}
+	}
+	return len(packBlock(nil, block[0], tb, vb)), nil
+}
@@ -0,0 +1,296 @@
+package tsm1_test
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestFloatArrayEncodeAll_ALP(t *testing.T) {
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	decimals := make([]float64, 1000)
+	for i := range decimals {
+		decimals[i] = float64(2000+rand.Intn(1000)) / 100
+	}
+	exceptions := append([]float64{}, decimals...)
+	exceptions[10], exceptions[500] = math.Pi, math.Inf(1)
+
+	random := make([]float64, 1000)
+	for i := range random {
+		random[i] = rand.NormFloat64()
+	}
+
+	examples := map[string][]float64{
+		"decimals":   decimals,
+		"exceptions": exceptions,
+		"random":     random,
+		"integers":   {12, 12, 24, 13, 24, 24, 24, 24},
+		"large":      {math.MaxFloat64, -math.MaxFloat64, 1 << 60, math.SmallestNonzeroFloat64},
+		"zeros":      {0, math.Copysign(0, -1), 0},
+		"ones":       fullBlockFloat64Ones,
+		"twoHours":   twoHoursData,
+		"single":     {1.5},
+		"empty":      {},
+	}
+
+	for name, src := range examples {
+		t.Run(name, func(t *testing.T) {
+			b, err := tsm1.FloatArrayEncodeAll(src, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got, exp := b[0]>>4, byte(2); got != exp {
+				t.Fatalf("encoding mismatch: got %v, exp %v", got, exp)
+			}
+
+			got, err := tsm1.FloatArrayDecodeAll(b, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(src) {
+				t.Fatalf("length mismatch: got %v, exp %v", len(got), len(src))
+			}
+			for i := range src {
+				if math.Float64bits(got[i]) != math.Float64bits(src[i]) {
+					t.Fatalf("value %d mismatch: got %v, exp %v", i, got[i], src[i])
+				}
+			}
+
+			// The streaming decoder reads the same values.
+			var dec tsm1.FloatDecoder
+			if err := dec.SetBytes(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var n int
+			for ; dec.Next(); n++ {
+				if v := dec.Values(); math.Float64bits(v) != math.Float64bits(src[n]) {
+					t.Fatalf("value %d mismatch: got %v, exp %v", n, v, src[n])
+				}
+			}
+			if err := dec.Error(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if n != len(src) {
+				t.Fatalf("decoded length mismatch: got %v, exp %v", n, len(src))
+			}
+		})
+	}
+}
+
+func TestFloatArrayEncodeAll_ALP_Smaller(t *testing.T) {
+	src := make([]float64, 1000)
+	for i := range src {
+		src[i] = float64(2000+rand.Intn(1000)) / 100
+	}
+
+	v1, err := tsm1.FloatArrayEncodeAll(src, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+	v2, err := tsm1.FloatArrayEncodeAll(src, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(v2) >= len(v1) {
+		t.Fatalf("ALP block not smaller than Gorilla block: got %v, Gorilla %v", len(v2), len(v1))
+	}
+}
+
+func TestFloatArrayEncodeAll_ALP_NaN(t *testing.T) {
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	if _, err := tsm1.FloatArrayEncodeAll([]float64{1.5, math.NaN()}, nil); err == nil {
+		t.Fatal("expected error encoding NaN")
+	}
+
+	enc := tsm1.NewFloatEncoder()
+	enc.Write(1.5)
+	enc.Write(math.NaN())
+	enc.Flush()
+	if _, err := enc.Bytes(); err == nil {
+		t.Fatal("expected error encoding NaN")
+	}
+}
+
+func TestFloatEncoder_ALP(t *testing.T) {
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	src := []float64{21.5, 21.75, 22, 21.25, 1e-3}
+	enc := tsm1.NewFloatEncoder()
+	for _, v := range src {
+		enc.Write(v)
+	}
+	enc.Flush()
+	b, err := enc.Bytes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := tsm1.FloatArrayDecodeAll(b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, src) {
+		t.Fatalf("unexpected values: got %v, exp %v", got, src)
+	}
+
+	// The encoder is reused with the version at the time of Reset.
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+	enc.Reset()
+	for _, v := range src {
+		enc.Write(v)
+	}
+	enc.Flush()
+	if b, err = enc.Bytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if got, exp := b[0]>>4, byte(1); got != exp {
+		t.Fatalf("encoding mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+func TestStringArrayEncodeAll_Zstd(t *testing.T) {
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	src := make([]string, 1000)
+	for i := range src {
+		src[i] = strings.Repeat("level=info msg=\"request complete\" ", 1+i%3)
+	}
+
+	b, err := tsm1.StringArrayEncodeAll(src, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if got, exp := b[0]>>4, byte(2); got != exp {
+		t.Fatalf("encoding mismatch: got %v, exp %v", got, exp)
+	}
+
+	got, err := tsm1.StringArrayDecodeAll(b, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if !reflect.DeepEqual(got, src) {
+		t.Fatal("unexpected values")
+	}
+
+	// The streaming encoder writes blocks the array decoder reads.
+	enc := tsm1.NewStringEncoder(1024)
+	for _, v := range src {
+		enc.Write(v)
+	}
+	if b, err = enc.Bytes(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var dec tsm1.StringDecoder
+	if err := dec.SetBytes(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var n int
+	for ; dec.Next(); n++ {
+		if got, exp := dec.Read(), src[n]; got != exp {
+			t.Fatalf("value %d mismatch: got %q, exp %q", n, got, exp)
+		}
+	}
+	if n != len(src) {
+		t.Fatalf("decoded length mismatch: got %v, exp %v", n, len(src))
+	}
+}
+
+// Ensure string blocks are compressed with the shared dictionary, and blocks
+// compressed without one are still read.
+func TestStringArrayEncodeAll_ZstdDict(t *testing.T) {
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	src := []string{
+		`2024-03-01T10:00:00.123Z INFO  [api] request completed: id=1f2e3d4c duration=12ms`,
+		`2024-03-01T10:00:01.456Z WARN  [auth] retrying request: id=9a8b7c6d duration=305ms`,
+		`2024-03-01T10:00:02.789Z ERROR [db] connection refused: id=0a1b2c3d duration=5000ms`,
+	}
+	b, err := tsm1.StringArrayEncodeAll(src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw []byte
+	for _, s := range src {
+		raw = binary.AppendUvarint(raw, uint64(len(s)))
+		raw = append(raw, s...)
+	}
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noDict := enc.EncodeAll(raw, []byte{2 << 4})
+	if len(b) >= len(noDict) {
+		t.Fatalf("expected the dictionary to shrink the block: got %d bytes, %d without", len(b), len(noDict))
+	}
+
+	for _, b := range [][]byte{b, noDict} {
+		if got, err := tsm1.StringArrayDecodeAll(b, nil); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(got, src) {
+			t.Fatalf("unexpected values: %q", got)
+		}
+	}
+}
+
+func TestBlockSizeV1(t *testing.T) {
+	floats := make(tsm1.Values, 1000)
+	strs := make(tsm1.Values, 1000)
+	for i := range floats {
+		floats[i] = tsm1.NewValue(int64(i), float64(i%100)/10)
+		strs[i] = tsm1.NewValue(int64(i), strings.Repeat("abc", i%10))
+	}
+
+	var v1 [][]byte
+	for _, values := range []tsm1.Values{floats, strs} {
+		b, err := values.Encode(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		v1 = append(v1, b)
+	}
+
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	for i, values := range []tsm1.Values{floats, strs} {
+		b, err := values.Encode(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, err := tsm1.BlockSizeV1(b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if exp := len(v1[i]); got != exp {
+			t.Fatalf("size mismatch: got %v, exp %v", got, exp)
+		}
+
+		if got, err := tsm1.BlockSizeV1(v1[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if exp := len(v1[i]); got != exp {
+			t.Fatalf("size mismatch: got %v, exp %v", got, exp)
+		}
+	}
+
+	for i, exp := range []string{"gorilla", "snappy"} {
+		if got, err := tsm1.BlockEncodingName(v1[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if got != exp {
+			t.Fatalf("encoding mismatch: got %v, exp %v", got, exp)
+		}
+	}
+}
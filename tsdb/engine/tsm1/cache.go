@@ -1,6 +1,7 @@
 package tsm1
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -26,10 +27,13 @@ var (
 	ErrSnapshotInProgress = fmt.Errorf("snapshot in progress")
 )
 
+// errCacheFull is wrapped by the errors returned by ErrCacheMemorySizeLimitExceeded.
+var errCacheFull = errors.New("cache-max-memory-size exceeded")
+
 // ErrCacheMemorySizeLimitExceeded returns an error indicating an operation
 // could not be completed due to exceeding the cache-max-memory-size setting.
 func ErrCacheMemorySizeLimitExceeded(n, limit uint64) error {
-	return fmt.Errorf("cache-max-memory-size exceeded: (%d/%d)", n, limit)
+	return fmt.Errorf("%w: (%d/%d)", errCacheFull, n, limit)
 }
 
 // entry is a set of values and some metadata.
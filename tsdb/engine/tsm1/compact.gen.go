@@ -33,6 +33,11 @@ func (k *tsmKeyIterator) mergeFloat() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineFloat(dedup)
@@ -237,6 +242,11 @@ func (k *tsmKeyIterator) mergeInteger() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineInteger(dedup)
@@ -441,6 +451,11 @@ func (k *tsmKeyIterator) mergeUnsigned() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineUnsigned(dedup)
@@ -645,6 +660,11 @@ func (k *tsmKeyIterator) mergeString() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineString(dedup)
@@ -849,6 +869,11 @@ func (k *tsmKeyIterator) mergeBoolean() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineBoolean(dedup)
@@ -1053,6 +1078,11 @@ func (k *tsmBatchKeyIterator) mergeFloat() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineFloat(dedup)
@@ -1277,6 +1307,11 @@ func (k *tsmBatchKeyIterator) mergeInteger() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineInteger(dedup)
@@ -1501,6 +1536,11 @@ func (k *tsmBatchKeyIterator) mergeUnsigned() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineUnsigned(dedup)
@@ -1725,6 +1765,11 @@ func (k *tsmBatchKeyIterator) mergeString() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineString(dedup)
@@ -1949,6 +1994,11 @@ func (k *tsmBatchKeyIterator) mergeBoolean() {
 				len(k.blocks[i].tombstones) > 0
 		}
 
+		// Blocks in an encoding that must not be kept are decoded and re-encoded.
+		for i := 0; !dedup && i < len(k.blocks); i++ {
+			dedup = reencodeBlock(k.blocks[i].b, k.fast)
+		}
+
 	}
 
 	k.merged = k.combineBoolean(dedup)
@@ -724,6 +724,10 @@ type Compactor struct {
 	compactionsInterrupt chan struct{}
 
 	files map[string]struct{}
+
+	// progress holds the bytes of blocks read by each running compaction,
+	// keyed by the first of its files.
+	progress map[string]*int64
 }
 
 // NewCompactor returns a new instance of Compactor.
@@ -902,6 +906,14 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 	intC := c.compactionsInterrupt
 	c.mu.RUnlock()
 
+	return c.compactReaders(tsmFiles, intC, func(trs []*TSMReader) (KeyIterator, error) {
+		return NewTSMBatchKeyIterator(size, fast, intC, tsmFiles, trs...)
+	})
+}
+
+// compactReaders writes the TSM files into new files using the key iterator
+// returned by newIter for readers of the files.
+func (c *Compactor) compactReaders(tsmFiles []string, intC chan struct{}, newIter func(trs []*TSMReader) (KeyIterator, error)) ([]string, error) {
 	// The new compacted files need to added to the max generation in the
 	// set.  We need to find that max generation as well as the max sequence
 	// number to ensure we write to the next unique location.
@@ -946,12 +958,60 @@ func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 		return nil, nil
 	}
 
-	tsm, err := NewTSMBatchKeyIterator(size, fast, intC, tsmFiles, trs...)
+	tsm, err := newIter(trs)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.writeNewFiles(maxGeneration, maxSequence, tsmFiles, tsm, true)
+	n := c.trackProgress(tsmFiles)
+	defer c.untrackProgress(tsmFiles)
+	return c.writeNewFiles(maxGeneration, maxSequence, tsmFiles, &progressKeyIterator{KeyIterator: tsm, n: n}, true)
+}
+
+// Progress returns the bytes of blocks read so far by the running compaction
+// of tsmFiles, or 0 if they are not being compacted.
+func (c *Compactor) Progress(tsmFiles []string) int64 {
+	if len(tsmFiles) == 0 {
+		return 0
+	}
+	c.mu.RLock()
+	n := c.progress[tsmFiles[0]]
+	c.mu.RUnlock()
+	if n == nil {
+		return 0
+	}
+	return atomic.LoadInt64(n)
+}
+
+// trackProgress returns the counter of bytes read by the compaction of tsmFiles.
+func (c *Compactor) trackProgress(tsmFiles []string) *int64 {
+	n := new(int64)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.progress == nil {
+		c.progress = make(map[string]*int64)
+	}
+	c.progress[tsmFiles[0]] = n
+	return n
+}
+
+// untrackProgress removes the counter of the compaction of tsmFiles.
+func (c *Compactor) untrackProgress(tsmFiles []string) {
+	c.mu.Lock()
+	delete(c.progress, tsmFiles[0])
+	c.mu.Unlock()
+}
+
+// progressKeyIterator counts the bytes of the blocks read from a KeyIterator.
+type progressKeyIterator struct {
+	KeyIterator
+	n *int64
+}
+
+func (itr *progressKeyIterator) Read() ([]byte, int64, int64, []byte, error) {
+	key, minTime, maxTime, block, err := itr.KeyIterator.Read()
+	atomic.AddInt64(itr.n, int64(len(block)))
+	return key, minTime, maxTime, block, err
 }
 
 // CompactFull writes multiple smaller TSM files into 1 or more larger files.
@@ -690,6 +690,103 @@ func TestCompactor_CompactFull_SkipFullBlocks(t *testing.T) {
 	}
 }
 
+// Ensures that full compactions rewrite float and string blocks in the
+// encodings of the current block encoding version, and that fast compactions
+// only rewrite blocks of a newer version.
+func TestCompactor_BlockEncodingVersion(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	writes := map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": {tsm1.NewValue(1, 1.5), tsm1.NewValue(2, 2.25)},
+		"cpu,host=A#!~#msg":   {tsm1.NewValue(1, "starting"), tsm1.NewValue(2, "started")},
+		"cpu,host=A#!~#count": {tsm1.NewValue(1, int64(1)), tsm1.NewValue(2, int64(2))},
+	}
+	f1 := MustWriteTSM(dir, 1, writes)
+
+	fs := &fakeFileStore{}
+	defer fs.Close()
+	compactor := tsm1.NewCompactor()
+	compactor.Dir = dir
+	compactor.FileStore = fs
+	compactor.Open()
+
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV2)
+	defer tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+
+	compact := func(fast bool, name string) string {
+		t.Helper()
+		var files []string
+		var err error
+		if fast {
+			files, err = compactor.CompactFast([]string{name})
+		} else {
+			files, err = compactor.CompactFull([]string{name})
+		}
+		if err != nil {
+			t.Fatalf("unexpected error compacting: %v", err)
+		} else if len(files) != 1 {
+			t.Fatalf("files length mismatch: got %v, exp 1", len(files))
+		}
+		return files[0]
+	}
+
+	assertEncodings := func(name string, version byte, exp map[string]string) {
+		t.Helper()
+		r := MustOpenTSMReader(name)
+		defer r.Close()
+
+		header := make([]byte, 5)
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.Read(header); err != nil {
+			t.Fatal(err)
+		} else if header[4] != version {
+			t.Fatalf("file version mismatch: got %v, exp %v", header[4], version)
+		}
+
+		iter := r.BlockIterator()
+		for iter.Next() {
+			key, _, _, _, _, buf, err := iter.Read()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tsm1.BlockEncodingName(buf)
+			if err != nil {
+				t.Fatal(err)
+			} else if got != exp[string(key)] {
+				t.Fatalf("encoding mismatch %s: got %v, exp %v", key, got, exp[string(key)])
+			}
+
+			values, err := r.ReadAll(key)
+			if err != nil {
+				t.Fatalf("unexpected error reading: %v", err)
+			}
+			for i, point := range writes[string(key)] {
+				assertValueEqual(t, values[i], point)
+			}
+		}
+	}
+
+	v1 := map[string]string{"cpu,host=A#!~#value": "gorilla", "cpu,host=A#!~#msg": "snappy", "cpu,host=A#!~#count": "simple8b"}
+	v2 := map[string]string{"cpu,host=A#!~#value": "alp", "cpu,host=A#!~#msg": "zstd", "cpu,host=A#!~#count": "simple8b"}
+
+	// Fast compactions copy blocks of an older version.
+	f2 := compact(true, f1)
+	assertEncodings(f2, tsm1.Version2, v1)
+
+	f3 := compact(false, f2)
+	assertEncodings(f3, tsm1.Version2, v2)
+
+	// Blocks of a newer version are rewritten even by fast compactions.
+	tsm1.SetBlockEncodingVersion(tsm1.BlockEncodingV1)
+	f4 := compact(true, f3)
+	assertEncodings(f4, tsm1.Version, v1)
+}
+
 // Ensures that a full compaction will skip over blocks that have the full
 // range of time contained in the block tombstoned
 func TestCompactor_CompactFull_TombstonedSkipBlock(t *testing.T) {
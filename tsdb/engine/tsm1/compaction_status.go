@@ -0,0 +1,163 @@
+package tsm1
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// plannedCompaction is a compaction group planned by the last iteration of
+// the compaction loop that was not started.
+type plannedCompaction struct {
+	group    CompactionGroup
+	level    int
+	strategy string
+	state    string
+}
+
+// appendPlannedCompactions appends a plannedCompaction for each of groups to dst.
+func appendPlannedCompactions(dst []plannedCompaction, groups []CompactionGroup, level int, strategy, state string) []plannedCompaction {
+	for _, group := range groups {
+		dst = append(dst, plannedCompaction{group: group, level: level, strategy: strategy, state: state})
+	}
+	return dst
+}
+
+// setPlannedCompactions replaces the compactions reported as queued or deferred.
+func (e *Engine) setPlannedCompactions(planned []plannedCompaction) {
+	e.compactionsMu.Lock()
+	e.planned = planned
+	e.compactionsMu.Unlock()
+}
+
+// trackCompaction reports s as running until untrackCompaction is called.
+func (e *Engine) trackCompaction(s *compactionStrategy, start time.Time) {
+	e.compactionsMu.Lock()
+	s.started = start
+	e.running[s] = struct{}{}
+	e.compactionsMu.Unlock()
+}
+
+// untrackCompaction stops reporting s as running.
+func (e *Engine) untrackCompaction(s *compactionStrategy) {
+	e.compactionsMu.Lock()
+	delete(e.running, s)
+	e.compactionsMu.Unlock()
+}
+
+// inCompactFullWindow returns true if full and optimize compactions may start
+// at now: the shard is hot, a full compaction was scheduled, or now is within
+// one of the configured windows.
+func (e *Engine) inCompactFullWindow(now time.Time) bool {
+	if len(e.compactFullWindows) == 0 || atomic.LoadInt32(&e.forceFull) == 1 {
+		return true
+	}
+	if now.Sub(e.LastModified()) < e.compactFullWriteColdDuration {
+		return true
+	}
+	return tsdb.InCompactionWindow(e.compactFullWindows, now)
+}
+
+// Compactions returns the running compactions of the engine, followed by the
+// compactions planned by the last iteration of the compaction loop. If
+// compactions are paused, a single compaction in the paused state is first.
+func (e *Engine) Compactions() []tsdb.CompactionInfo {
+	var infos []tsdb.CompactionInfo
+	if e.CompactionsPaused() {
+		infos = append(infos, tsdb.CompactionInfo{ShardID: e.id, State: tsdb.CompactionPaused})
+	}
+
+	sizes := make(map[string]int64)
+	for _, st := range e.FileStore.Stats() {
+		sizes[st.Path] = int64(st.Size)
+	}
+	groupSize := func(group CompactionGroup) int64 {
+		var n int64
+		for _, f := range group {
+			n += sizes[f]
+		}
+		return n
+	}
+
+	e.compactionsMu.Lock()
+	running := make([]tsdb.CompactionInfo, 0, len(e.running))
+	for s := range e.running {
+		info := tsdb.CompactionInfo{
+			ShardID:   e.id,
+			Level:     s.level,
+			Strategy:  s.name,
+			State:     tsdb.CompactionRunning,
+			Files:     len(s.group),
+			Bytes:     groupSize(s.group),
+			StartedAt: s.started,
+		}
+		// Blocks make up most of a file, so the bytes of blocks read are
+		// a close estimate of how much of the group has been compacted.
+		if info.Bytes > 0 {
+			info.Progress = float64(e.Compactor.Progress(s.group)) / float64(info.Bytes)
+			if info.Progress > 1 {
+				info.Progress = 1
+			}
+		}
+		running = append(running, info)
+	}
+	planned := e.planned
+	e.compactionsMu.Unlock()
+
+	sort.Slice(running, func(i, j int) bool { return running[i].StartedAt.Before(running[j].StartedAt) })
+	infos = append(infos, running...)
+	for _, p := range planned {
+		infos = append(infos, tsdb.CompactionInfo{
+			ShardID:  e.id,
+			Level:    p.level,
+			Strategy: p.strategy,
+			State:    p.state,
+			Files:    len(p.group),
+			Bytes:    groupSize(p.group),
+		})
+	}
+	return infos
+}
+
+// SetCompactionsPaused pauses or resumes level, full and optimize compactions
+// of the engine. Running compactions are aborted when pausing. Snapshots of
+// the cache continue while paused. The pause is kept across restarts by the
+// DoNotCompactFile in the engine's directory.
+func (e *Engine) SetCompactionsPaused(paused bool) error {
+	path := filepath.Join(e.path, DoNotCompactFile)
+	if !paused {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// Restart the compaction loop to abort running compactions. It does
+	// not start new ones while the file exists.
+	e.mu.RLock()
+	enabled := e.done != nil
+	e.mu.RUnlock()
+	if enabled {
+		e.disableLevelCompactions(false)
+		e.enableLevelCompactions(false)
+	}
+	return nil
+}
+
+// CompactionsPaused returns true if compactions of the engine are paused.
+func (e *Engine) CompactionsPaused() bool {
+	_, err := os.Stat(filepath.Join(e.path, DoNotCompactFile))
+	return err == nil
+}
@@ -165,6 +165,15 @@ type Engine struct {
 	CompactionPlan CompactionPlanner
 	FileStore      *FileStore
 
+	// Spill holds writes that arrive while the cache is full, when cache
+	// spilling is enabled or spilled writes remain from when it was. It is
+	// nil otherwise.
+	Spill        *WAL
+	spillEnabled bool
+	spillMaxSize int64
+	spilling     int32      // set while writes bypass the cache; accessed atomically
+	spillMu      sync.Mutex // serializes folds of spilled writes into TSM files
+
 	MaxPointsPerBlock int
 
 	// CacheFlushMemorySizeThreshold specifies the minimum size threshold for
@@ -193,6 +202,17 @@ type Engine struct {
 
 	scheduler *scheduler
 
+	// compactFullWindows are the times of day during which full and optimize
+	// compactions of cold shards may start. They may start at any time if empty.
+	compactFullWindows           []tsdb.CompactionWindow
+	compactFullWriteColdDuration time.Duration
+	forceFull                    int32 // set while a scheduled full compaction is pending; accessed atomically
+
+	// compactionsMu guards running and planned, which are reported by Compactions.
+	compactionsMu sync.Mutex
+	running       map[*compactionStrategy]struct{}
+	planned       []plannedCompaction
+
 	// provides access to the total set of series IDs
 	seriesIDSets tsdb.SeriesIDSets
 
@@ -219,6 +239,12 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, sfile *ts
 
 	cache := NewCache(uint64(opt.Config.CacheMaxMemorySize))
 
+	var spill *WAL
+	if opt.WALEnabled && opt.Config.CacheSpillEnabled {
+		spill = NewWAL(filepath.Join(walPath, SpillDirectory))
+		spill.syncDelay = time.Duration(opt.Config.WALFsyncDelay)
+	}
+
 	c := NewCompactor()
 	c.Dir = path
 	c.FileStore = fs
@@ -230,6 +256,13 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, sfile *ts
 		planner.SetFileStore(fs)
 	}
 
+	// Windows are checked by Config.Validate.
+	windows, _ := tsdb.ParseCompactionWindows(opt.Config.CompactFullWindows)
+
+	// Blocks are encoded without reference to an engine, so the version is
+	// the same for all shards.
+	SetBlockEncodingVersion(opt.Config.TSMBlockEncodingVersion)
+
 	logger := zap.NewNop()
 	stats := &EngineStatistics{}
 	e := &Engine{
@@ -244,6 +277,10 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, sfile *ts
 		WAL:   wal,
 		Cache: cache,
 
+		Spill:        spill,
+		spillEnabled: spill != nil,
+		spillMaxSize: int64(opt.Config.CacheSpillMaxSize),
+
 		FileStore:      fs,
 		Compactor:      c,
 		CompactionPlan: planner,
@@ -257,6 +294,10 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, sfile *ts
 		compactionLimiter:             opt.CompactionLimiter,
 		scheduler:                     newScheduler(stats, opt.CompactionLimiter.Capacity()),
 		seriesIDSets:                  opt.SeriesIDSets,
+
+		compactFullWindows:           windows,
+		compactFullWriteColdDuration: time.Duration(opt.Config.CompactFullWriteColdDuration),
+		running:                      make(map[*compactionStrategy]struct{}),
 	}
 
 	// Feature flag to enable per-series type checking, by default this is off and
@@ -270,6 +311,9 @@ func NewEngine(id uint64, idx tsdb.Index, path string, walPath string, sfile *ts
 		if e.WALEnabled {
 			e.WAL.enableTraceLogging(true)
 		}
+		if e.Spill != nil {
+			e.Spill.enableTraceLogging(true)
+		}
 	}
 
 	return e
@@ -558,8 +602,10 @@ func (e *Engine) ScheduleFullCompaction() error {
 	// Ensure compactions are restarted
 	defer e.SetCompactionsEnabled(true)
 
-	// Force the planner to only create a full plan.
+	// Force the planner to only create a full plan. It starts regardless of
+	// the compaction windows.
 	e.CompactionPlan.ForceFull()
+	atomic.StoreInt32(&e.forceFull, 1)
 	return nil
 }
 
@@ -624,7 +670,10 @@ func (e *Engine) LastModified() time.Time {
 	fsTime := e.FileStore.LastModified()
 
 	if e.WALEnabled && e.WAL.LastWriteTime().After(fsTime) {
-		return e.WAL.LastWriteTime()
+		fsTime = e.WAL.LastWriteTime()
+	}
+	if e.Spill != nil && e.Spill.LastWriteTime().After(fsTime) {
+		fsTime = e.Spill.LastWriteTime()
 	}
 
 	return fsTime
@@ -654,6 +703,12 @@ type EngineStatistics struct {
 	TSMFullCompactionErrors   int64 // Counter of full compactions that have failed due to error.
 	TSMFullCompactionDuration int64 // Counter of number of wall nanoseconds spent in full compactions.
 	TSMFullCompactionsQueue   int64 // Gauge of full compactions queue.
+
+	SpillWriteOK      int64 // Counter of writes spilled to disk because the cache was full.
+	SpillWriteErr     int64 // Counter of spilled writes that failed, including those over the disk cap.
+	SpillFolds        int64 // Counter of spilled segments folded into TSM files.
+	SpillFoldErrors   int64 // Counter of folds of spilled writes that have failed due to error.
+	SpillFoldDuration int64 // Counter of number of wall nanoseconds spent folding spilled writes.
 }
 
 // Statistics returns statistics for periodic monitoring.
@@ -697,6 +752,14 @@ func (e *Engine) Statistics(tags map[string]string) []models.Statistic {
 			statTSMFullCompactionError:    atomic.LoadInt64(&e.stats.TSMFullCompactionErrors),
 			statTSMFullCompactionDuration: atomic.LoadInt64(&e.stats.TSMFullCompactionDuration),
 			statTSMFullCompactionQueue:    atomic.LoadInt64(&e.stats.TSMFullCompactionsQueue),
+
+			statSpillActive:       int64(atomic.LoadInt32(&e.spilling)),
+			statSpillDiskBytes:    e.spillDiskSize(),
+			statSpillWriteOK:      atomic.LoadInt64(&e.stats.SpillWriteOK),
+			statSpillWriteErr:     atomic.LoadInt64(&e.stats.SpillWriteErr),
+			statSpillFolds:        atomic.LoadInt64(&e.stats.SpillFolds),
+			statSpillFoldError:    atomic.LoadInt64(&e.stats.SpillFoldErrors),
+			statSpillFoldDuration: atomic.LoadInt64(&e.stats.SpillFoldDuration),
 		},
 	})
 
@@ -714,7 +777,7 @@ func (e *Engine) DiskSize() int64 {
 	if e.WALEnabled {
 		walDiskSizeBytes = e.WAL.DiskSizeBytes()
 	}
-	return e.FileStore.DiskSizeBytes() + walDiskSizeBytes
+	return e.FileStore.DiskSizeBytes() + walDiskSizeBytes + e.spillDiskSize()
 }
 
 // Open opens and initializes the engine.
@@ -754,6 +817,10 @@ func (e *Engine) Open() error {
 		}
 	}
 
+	if err := e.openSpill(); err != nil {
+		return err
+	}
+
 	e.Compactor.Open()
 
 	if e.enableCompactionsOnOpen {
@@ -776,6 +843,11 @@ func (e *Engine) Close() error {
 	if err := e.FileStore.Close(); err != nil {
 		return err
 	}
+	if e.Spill != nil {
+		if err := e.Spill.Close(); err != nil {
+			return err
+		}
+	}
 	if e.WALEnabled {
 		return e.WAL.Close()
 	}
@@ -793,6 +865,9 @@ func (e *Engine) WithLogger(log *zap.Logger) {
 	if e.WALEnabled {
 		e.WAL.WithLogger(e.logger)
 	}
+	if e.Spill != nil {
+		e.Spill.WithLogger(e.logger)
+	}
 	e.FileStore.WithLogger(e.logger)
 }
 
@@ -1401,12 +1476,20 @@ func (e *Engine) WritePointsWithContext(ctx context.Context, points []models.Poi
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	// first try to write to the cache
-	if err := e.Cache.WriteMulti(values); err != nil {
-		return err
-	}
-
-	if e.WALEnabled {
+	// first try to write to the cache, unless earlier writes are spilled and
+	// not yet folded into TSM files.
+	if e.isSpilling() {
+		if err := e.writeSpill(values); err != nil {
+			return err
+		}
+	} else if err := e.Cache.WriteMulti(values); err != nil {
+		if !e.spillEnabled || !errors.Is(err, errCacheFull) {
+			return err
+		}
+		if err := e.writeSpill(values); err != nil {
+			return err
+		}
+	} else if e.WALEnabled {
 		if _, err := e.WAL.WriteMulti(values); err != nil {
 			return err
 		}
@@ -1437,6 +1520,12 @@ func (e *Engine) DeleteSeriesRange(itr tsdb.SeriesIterator, min, max int64) erro
 func (e *Engine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, predicate func(name []byte, tags models.Tags) (int64, int64, bool)) error {
 	var disableOnce bool
 
+	// Spilled writes must reach TSM files before the delete or they would
+	// be folded in afterwards and reappear.
+	if err := e.foldSpill(); err != nil {
+		return err
+	}
+
 	// Ensure that the index does not compact away the measurement or series we're
 	// going to delete before we're done with them.
 	if tsiIndex, ok := e.index.(*tsi1.Index); ok {
@@ -1655,6 +1744,18 @@ func (e *Engine) deleteSeriesRange(seriesKeys [][]byte, min, max int64) error {
 		}
 	}
 
+	return e.deleteEmptySeries(seriesKeys, deleteKeys)
+}
+
+// deleteEmptySeries removes the series of seriesKeys which no longer have
+// values on disk or in the cache from the index, and the measurements left
+// without series. seriesKeys must be sorted, and deleteKeys holds the sorted
+// cache keys which were deleted.
+func (e *Engine) deleteEmptySeries(seriesKeys, deleteKeys [][]byte) error {
+	if len(seriesKeys) == 0 {
+		return nil
+	}
+
 	// The series are deleted on disk, but the index may still say they exist.
 	// Depending on the the min,max time passed in, the series may or not actually
 	// exists now.  To reconcile the index, we walk the series keys that still exists
@@ -1874,6 +1975,204 @@ func (e *Engine) cleanupMeasurement(name []byte) error {
 	return nil
 }
 
+// DeleteFieldRange removes the values between min and max (inclusive) of
+// fields from all series of measurement name. Fields left without values are
+// removed from the measurement's field set, and series left without fields
+// are removed from the index.
+func (e *Engine) DeleteFieldRange(name []byte, fields []string, min, max int64) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// Spilled writes must reach TSM files before the delete or they would
+	// be folded in afterwards and reappear.
+	if err := e.foldSpill(); err != nil {
+		return err
+	}
+
+	// Ensure that the index does not compact away the series we may drop.
+	if tsiIndex, ok := e.index.(*tsi1.Index); ok {
+		tsiIndex.DisableCompactions()
+		defer tsiIndex.EnableCompactions()
+		tsiIndex.Wait()
+
+		fs, err := tsiIndex.RetainFileSet()
+		if err != nil {
+			return err
+		}
+		defer fs.Release()
+	}
+
+	// Keep tombstones from being compacted away while they are written, as
+	// DeleteSeriesRangeWithPredicate does.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	e.sfile.DisableCompactions()
+	defer e.sfile.EnableCompactions()
+	e.sfile.Wait()
+
+	// Min and max time in the engine are slightly different from the query language values.
+	if min == influxql.MinTime {
+		min = math.MinInt64
+	}
+	if max == influxql.MaxTime {
+		max = math.MaxInt64
+	}
+
+	fieldSet := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		fieldSet[f] = struct{}{}
+	}
+
+	encodedName := models.EscapeMeasurement(name)
+	sep := len(encodedName)
+	// match returns the series key of k if k is the key of one of fields of
+	// the measurement.
+	match := func(k []byte) ([]byte, bool) {
+		if !bytes.HasPrefix(k, encodedName) || len(k) <= sep || (k[sep] != ',' && k[sep] != keyFieldSeparator[0]) {
+			return nil, false
+		}
+		seriesKey, field := SeriesAndFieldFromCompositeKey(k)
+		if _, ok := fieldSet[string(field)]; !ok {
+			return nil, false
+		}
+		return seriesKey, true
+	}
+
+	var mu sync.Mutex
+	series := make(map[string]struct{})
+
+	// Delete the keys of the fields in each TSM file.
+	if err := e.FileStore.Apply(func(r TSMFile) error {
+		if !r.OverlapsTimeRange(min, max) {
+			return nil
+		}
+
+		batch := r.BatchDelete()
+		n := r.KeyCount()
+		for i := r.Seek(encodedName); i < n; i++ {
+			indexKey, _ := r.KeyAt(i)
+			if !bytes.HasPrefix(indexKey, encodedName) {
+				break
+			}
+			seriesKey, ok := match(indexKey)
+			if !ok {
+				continue
+			}
+			if err := batch.DeleteRange([][]byte{indexKey}, min, max); err != nil {
+				batch.Rollback()
+				return err
+			}
+			mu.Lock()
+			series[string(seriesKey)] = struct{}{}
+			mu.Unlock()
+		}
+		return batch.Commit()
+	}); err != nil {
+		return err
+	}
+
+	// Find the keys of the fields in the cache and remove them.
+	var deleteKeys [][]byte
+	_ = e.Cache.ApplyEntryFn(func(k []byte, _ *entry) error {
+		if seriesKey, ok := match(k); ok {
+			mu.Lock()
+			deleteKeys = append(deleteKeys, k)
+			series[string(seriesKey)] = struct{}{}
+			mu.Unlock()
+		}
+		return nil
+	})
+	bytesutil.Sort(deleteKeys)
+
+	e.Cache.DeleteRange(deleteKeys, min, max)
+
+	// delete from the WAL
+	if e.WALEnabled {
+		if _, err := e.WAL.DeleteRange(deleteKeys, min, max); err != nil {
+			return err
+		}
+	}
+
+	// Remove the fields which no longer have values from the field set.
+	if mf := e.fieldset.Fields(name); mf != nil {
+		var changed bool
+		for _, f := range fields {
+			if !mf.HasField(f) {
+				continue
+			}
+			if err := mf.DeleteFieldWithLock(f, func() error {
+				return e.fieldHasValues(name, f)
+			}); err == errFieldHasValues {
+				continue
+			} else if err != nil {
+				return err
+			}
+			changed = true
+		}
+		if changed {
+			if err := e.fieldset.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Drop the series whose only fields were deleted.
+	seriesKeys := make([][]byte, 0, len(series))
+	for k := range series {
+		seriesKeys = append(seriesKeys, []byte(k))
+	}
+	bytesutil.Sort(seriesKeys)
+	if err := e.deleteEmptySeries(seriesKeys, deleteKeys); err != nil {
+		return err
+	}
+
+	e.index.Rebuild()
+	return nil
+}
+
+// errFieldHasValues is returned by fieldHasValues when the field still has
+// values.
+var errFieldHasValues = errors.New("field has values")
+
+// fieldHasValues returns errFieldHasValues if field of measurement name has
+// values in the cache or in TSM files.
+func (e *Engine) fieldHasValues(name []byte, field string) error {
+	encodedName := models.EscapeMeasurement(name)
+	sep := len(encodedName)
+	match := func(k []byte) bool {
+		if len(k) <= sep || (k[sep] != ',' && k[sep] != keyFieldSeparator[0]) {
+			return false
+		}
+		_, f := SeriesAndFieldFromCompositeKey(k)
+		return string(f) == field
+	}
+
+	if err := e.Cache.ApplyEntryFn(func(k []byte, entry *entry) error {
+		if bytes.HasPrefix(k, encodedName) && match(k) && entry.count() > 0 {
+			return errFieldHasValues
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Keys are sorted, so stop at the first key past the measurement.
+	errDone := errors.New("done")
+	if err := e.FileStore.WalkKeys(encodedName, func(k []byte, _ byte) error {
+		if !bytes.HasPrefix(k, encodedName) {
+			return errDone
+		} else if match(k) {
+			return errFieldHasValues
+		}
+		return nil
+	}); err != nil && err != errDone {
+		return err
+	}
+	return nil
+}
+
 // DeleteMeasurement deletes a measurement and all related series.
 func (e *Engine) DeleteMeasurement(name []byte) error {
 	// Attempt to find the series keys.
@@ -1980,6 +2279,11 @@ func (e *Engine) CreateSnapshot(skipCacheOk bool) (string, error) {
 		e.logger.Warn("Snapshotter busy: proceeding without cache contents.")
 	} else if err != nil {
 		return "", err
+	} else if err := e.foldSpill(); err != nil {
+		if !skipCacheOk {
+			return "", err
+		}
+		e.logger.Warn("Unable to fold spilled writes: proceeding without them.", zap.Error(err))
 	}
 
 	e.mu.RLock()
@@ -2063,6 +2367,11 @@ func (e *Engine) compactCache() {
 				}
 				atomic.AddInt64(&e.stats.CacheCompactionDuration, time.Since(start).Nanoseconds())
 			}
+			if e.isSpilling() {
+				if err := e.foldSpill(); err != nil && err != errSnapshotsDisabled {
+					e.logger.Info("Error folding spilled writes", zap.Error(err))
+				}
+			}
 		}
 	}
 }
@@ -2096,6 +2405,7 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 
 		select {
 		case <-quit:
+			e.setPlannedCompactions(nil)
 			return
 
 		case <-t.C:
@@ -2108,6 +2418,7 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 					e.logger.Info("TSM compaction disabled", logger.Shard(e.id), zap.String("reason", doNotCompactFile))
 					nextDisabledMsg = now.Add(time.Minute * 15)
 				}
+				e.setPlannedCompactions(nil)
 				continue
 			}
 
@@ -2116,14 +2427,23 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 			level2Groups := e.CompactionPlan.PlanLevel(2)
 			level3Groups := e.CompactionPlan.PlanLevel(3)
 			level4Groups := e.CompactionPlan.Plan(e.LastModified())
+			level4Strategy := "full"
 			atomic.StoreInt64(&e.stats.TSMOptimizeCompactionsQueue, int64(len(level4Groups)))
 
 			// If no full compactions are need, see if an optimize is needed
 			if len(level4Groups) == 0 {
 				level4Groups = e.CompactionPlan.PlanOptimize()
+				level4Strategy = "optimize"
 				atomic.StoreInt64(&e.stats.TSMOptimizeCompactionsQueue, int64(len(level4Groups)))
 			}
 
+			// Full and optimize compactions of cold shards wait for a
+			// compaction window unless one was scheduled.
+			level4State := tsdb.CompactionQueued
+			if len(level4Groups) > 0 && !e.inCompactFullWindow(time.Now()) {
+				level4State = tsdb.CompactionDeferred
+			}
+
 			// Update the level plan queue stats
 			atomic.StoreInt64(&e.stats.TSMCompactionsQueue[0], int64(len(level1Groups)))
 			atomic.StoreInt64(&e.stats.TSMCompactionsQueue[1], int64(len(level2Groups)))
@@ -2133,7 +2453,11 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 			e.scheduler.setDepth(1, len(level1Groups))
 			e.scheduler.setDepth(2, len(level2Groups))
 			e.scheduler.setDepth(3, len(level3Groups))
-			e.scheduler.setDepth(4, len(level4Groups))
+			if level4State == tsdb.CompactionDeferred {
+				e.scheduler.setDepth(4, 0)
+			} else {
+				e.scheduler.setDepth(4, len(level4Groups))
+			}
 
 			// Find the next compaction that can run and try to kick it off
 			if level, runnable := e.scheduler.next(); runnable {
@@ -2151,12 +2475,20 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 						level3Groups = level3Groups[1:]
 					}
 				case 4:
-					if e.compactFull(level4Groups[0], wg) {
+					if e.compactFull(level4Groups[0], level4Strategy, wg) {
 						level4Groups = level4Groups[1:]
+						atomic.StoreInt32(&e.forceFull, 0)
 					}
 				}
 			}
 
+			planned := make([]plannedCompaction, 0, len(level1Groups)+len(level2Groups)+len(level3Groups)+len(level4Groups))
+			planned = appendPlannedCompactions(planned, level1Groups, 1, "level", tsdb.CompactionQueued)
+			planned = appendPlannedCompactions(planned, level2Groups, 2, "level", tsdb.CompactionQueued)
+			planned = appendPlannedCompactions(planned, level3Groups, 3, "level", tsdb.CompactionQueued)
+			planned = appendPlannedCompactions(planned, level4Groups, 4, level4Strategy, level4State)
+			e.setPlannedCompactions(planned)
+
 			// Release all the plans we didn't start.
 			e.CompactionPlan.Release(level1Groups)
 			e.CompactionPlan.Release(level2Groups)
@@ -2222,11 +2554,12 @@ func (e *Engine) compactLoPriorityLevel(grp CompactionGroup, level int, fast boo
 
 // compactFull kicks off full and optimize compactions using the lo priority policy. It returns
 // the plans that were not able to be started.
-func (e *Engine) compactFull(grp CompactionGroup, wg *sync.WaitGroup) bool {
+func (e *Engine) compactFull(grp CompactionGroup, name string, wg *sync.WaitGroup) bool {
 	s := e.fullCompactionStrategy(grp, false)
 	if s == nil {
 		return false
 	}
+	s.name = name
 
 	// Try the lo priority limiter, otherwise steal a little from the high priority if we can.
 	if e.compactionLimiter.TryTake() {
@@ -2249,8 +2582,10 @@ func (e *Engine) compactFull(grp CompactionGroup, wg *sync.WaitGroup) bool {
 type compactionStrategy struct {
 	group CompactionGroup
 
-	fast  bool
-	level int
+	fast    bool
+	level   int
+	name    string
+	started time.Time // guarded by engine.compactionsMu
 
 	durationStat *int64
 	activeStat   *int64
@@ -2267,6 +2602,8 @@ type compactionStrategy struct {
 // Apply concurrently compacts all the groups in a compaction strategy.
 func (s *compactionStrategy) Apply() {
 	start := time.Now()
+	s.engine.trackCompaction(s, start)
+	defer s.engine.untrackCompaction(s)
 	s.compactGroup()
 	atomic.AddInt64(s.durationStat, time.Since(start).Nanoseconds())
 }
@@ -2355,6 +2692,7 @@ func (e *Engine) levelCompactionStrategy(group CompactionGroup, fast bool, level
 		fast:      fast,
 		engine:    e,
 		level:     level,
+		name:      "level",
 
 		activeStat:   &e.stats.TSMCompactionsActive[level-1],
 		successStat:  &e.stats.TSMCompactions[level-1],
@@ -2374,6 +2712,7 @@ func (e *Engine) fullCompactionStrategy(group CompactionGroup, optimize bool) *c
 		fast:      optimize,
 		engine:    e,
 		level:     4,
+		name:      "full",
 	}
 
 	if optimize {
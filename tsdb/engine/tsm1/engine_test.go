@@ -22,6 +22,7 @@ import (
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/deep"
+	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
@@ -1368,6 +1369,85 @@ func TestEngine_DeleteSeriesRange(t *testing.T) {
 	}
 }
 
+func TestEngine_DeleteFieldRange(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			p1 := MustParsePointString("cpu,host=A value=1.1,debug=1 1000000000")
+			p2 := MustParsePointString("cpu,host=B debug=2 1000000000")
+			p3 := MustParsePointString("cpu2,host=A debug=3 1000000000") // Should not be deleted
+			p4 := MustParsePointString("cpu,host=A debug=4 5000000000")  // In the cache, should not be deleted
+
+			e, err := NewEngine(index)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// mock the planner so compactions don't run during the test
+			e.CompactionPlan = &mockPlanner{}
+			if err := e.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer e.Close()
+
+			for _, p := range []models.Point{p1, p2, p3} {
+				if err := e.CreateSeriesIfNotExists(p.Key(), p.Name(), p.Tags()); err != nil {
+					t.Fatalf("create series index error: %v", err)
+				}
+			}
+			if err := e.WritePoints([]models.Point{p1, p2, p3}); err != nil {
+				t.Fatalf("failed to write points: %s", err.Error())
+			}
+			if err := e.WriteSnapshot(); err != nil {
+				t.Fatalf("failed to snapshot: %s", err.Error())
+			}
+			if err := e.WritePoints([]models.Point{p4}); err != nil {
+				t.Fatalf("failed to write points: %s", err.Error())
+			}
+
+			if err := e.DeleteFieldRange([]byte("cpu"), []string{"debug"}, 0, 3000000000); err != nil {
+				t.Fatalf("failed to delete fields: %v", err)
+			}
+
+			keys := e.FileStore.Keys()
+			if _, ok := keys["cpu,host=A#!~#debug"]; ok {
+				t.Fatalf("field not deleted: %v", keys)
+			} else if _, ok := keys["cpu,host=B#!~#debug"]; ok {
+				t.Fatalf("field not deleted: %v", keys)
+			} else if _, ok := keys["cpu,host=A#!~#value"]; !ok {
+				t.Fatalf("wrong field deleted: %v", keys)
+			} else if _, ok := keys["cpu2,host=A#!~#debug"]; !ok {
+				t.Fatalf("wrong measurement deleted: %v", keys)
+			}
+			if n := e.Cache.Values([]byte("cpu,host=A#!~#debug")).Len(); n != 1 {
+				t.Fatalf("unexpected cached values: %d", n)
+			}
+
+			// The series left without fields is dropped from the index.
+			indexSet := tsdb.IndexSet{Indexes: []tsdb.Index{e.index}, SeriesFile: e.sfile}
+			iter, err := indexSet.MeasurementSeriesIDIterator([]byte("cpu"))
+			if err != nil {
+				t.Fatalf("iterator error: %v", err)
+			}
+			defer iter.Close()
+
+			var hosts []string
+			for {
+				elem, err := iter.Next()
+				if err != nil {
+					t.Fatal(err)
+				} else if elem.SeriesID == 0 {
+					break
+				}
+				_, tags := e.sfile.Series(elem.SeriesID)
+				hosts = append(hosts, tags.GetString("host"))
+			}
+			if !reflect.DeepEqual(hosts, []string{"A"}) {
+				t.Fatalf("unexpected series: %v", hosts)
+			}
+		})
+	}
+}
+
 func TestEngine_DeleteSeriesRangeWithPredicate(t *testing.T) {
 	for _, index := range tsdb.RegisteredIndexes() {
 		t.Run(index, func(t *testing.T) {
@@ -2111,6 +2191,73 @@ func TestEngine_DisableEnableCompactions_Concurrent(t *testing.T) {
 
 }
 
+// Ensures full compactions of cold shards wait for a compaction window unless
+// scheduled, and that compactions can be paused.
+func TestEngine_Compactions(t *testing.T) {
+	// A one hour window that starts in two hours.
+	now := time.Now()
+	window := now.Add(2*time.Hour).Format("15:04") + "-" + now.Add(3*time.Hour).Format("15:04")
+
+	e, err := NewEngine(tsdb.InmemIndexName, func(opt *tsdb.EngineOptions) {
+		opt.Config.CompactFullWindows = []string{window}
+		opt.Config.CompactFullWriteColdDuration = 1
+		opt.CompactionLimiter = limiter.NewFixed(2)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	e.MustAddSeries("cpu", map[string]string{"host": "A"})
+	for i := 1; i <= 2; i++ {
+		if err := e.WritePointsString(fmt.Sprintf("cpu,host=A value=%d %d", i, i)); err != nil {
+			t.Fatal(err)
+		}
+		e.MustWriteSnapshot()
+	}
+
+	waitFor := func(desc string, fn func() bool) {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); !fn(); time.Sleep(10 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s: %+v", desc, e.Compactions())
+			}
+		}
+	}
+
+	waitFor("deferred full compaction", func() bool {
+		infos := e.Compactions()
+		return len(infos) == 1 && infos[0].Level == 4 && infos[0].Strategy == "full" &&
+			infos[0].State == tsdb.CompactionDeferred && infos[0].Files == 2 && infos[0].Bytes > 0
+	})
+	if got := e.FileStore.Count(); got != 2 {
+		t.Fatalf("unexpected file count: got %d, exp 2", got)
+	}
+
+	// A scheduled full compaction does not wait for the window.
+	if err := e.ScheduleFullCompaction(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("full compaction", func() bool { return e.FileStore.Count() == 1 && len(e.Compactions()) == 0 })
+
+	if err := e.SetCompactionsPaused(true); err != nil {
+		t.Fatal(err)
+	} else if !e.CompactionsPaused() {
+		t.Fatal("expected compactions to be paused")
+	} else if infos := e.Compactions(); len(infos) != 1 || infos[0].State != tsdb.CompactionPaused {
+		t.Fatalf("unexpected compactions: %+v", infos)
+	}
+
+	if err := e.SetCompactionsPaused(false); err != nil {
+		t.Fatal(err)
+	} else if e.CompactionsPaused() {
+		t.Fatal("expected compactions to be resumed")
+	}
+}
+
 func TestEngine_WritePointsWithContext(t *testing.T) {
 	// Create a few points.
 	points := []models.Point{
@@ -2569,8 +2716,9 @@ type Engine struct {
 	sfile     *tsdb.SeriesFile
 }
 
-// NewEngine returns a new instance of Engine at a temporary location.
-func NewEngine(index string) (*Engine, error) {
+// NewEngine returns a new instance of Engine at a temporary location. The
+// engine options may be modified by the optional configure functions.
+func NewEngine(index string, configure ...func(*tsdb.EngineOptions)) (*Engine, error) {
 	root, err := os.MkdirTemp("", "tsm1-")
 	if err != nil {
 		panic(err)
@@ -2599,6 +2747,9 @@ func NewEngine(index string) (*Engine, error) {
 	// store level.
 	seriesIDs := tsdb.NewSeriesIDSet()
 	opt.SeriesIDSets = seriesIDSets([]*tsdb.SeriesIDSet{seriesIDs})
+	for _, fn := range configure {
+		fn(&opt)
+	}
 
 	idxPath := filepath.Join(dbPath, "index")
 	idx := tsdb.MustOpenIndex(1, db, idxPath, seriesIDs, sfile, opt)
@@ -2651,7 +2802,7 @@ func (e *Engine) close(cleanup bool) error {
 }
 
 // Reopen closes and reopens the engine.
-func (e *Engine) Reopen() error {
+func (e *Engine) Reopen(configure ...func(*tsdb.EngineOptions)) error {
 	// Close engine without removing underlying engine data.
 	if err := e.close(false); err != nil {
 		return err
@@ -2666,6 +2817,9 @@ func (e *Engine) Reopen() error {
 	db := path.Base(e.root)
 	opt := tsdb.NewEngineOptions()
 	opt.InmemIndex = inmem.NewIndex(db, e.sfile)
+	for _, fn := range configure {
+		fn(&opt)
+	}
 
 	// Re-initialise the series id set
 	seriesIDSet := tsdb.NewSeriesIDSet()
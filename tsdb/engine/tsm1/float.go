@@ -38,6 +38,10 @@ type FloatEncoder struct {
 
 	first    bool
 	finished bool
+
+	// With BlockEncodingV2, values are buffered and ALP encoded by Bytes.
+	alp    bool
+	values []float64
 }
 
 // NewFloatEncoder returns a new FloatEncoder.
@@ -45,6 +49,7 @@ func NewFloatEncoder() *FloatEncoder {
 	s := FloatEncoder{
 		first:   true,
 		leading: ^uint64(0),
+		alp:     blockEncodingVersion() == BlockEncodingV2,
 	}
 
 	s.bw = bitstream.NewWriter(&s.buf)
@@ -66,15 +71,27 @@ func (s *FloatEncoder) Reset() {
 
 	s.finished = false
 	s.first = true
+	s.alp = blockEncodingVersion() == BlockEncodingV2
+	s.values = s.values[:0]
 }
 
 // Bytes returns a copy of the underlying byte buffer used in the encoder.
 func (s *FloatEncoder) Bytes() ([]byte, error) {
+	if s.alp {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return floatALPEncodeAll(s.values, nil)
+	}
 	return s.buf.Bytes(), s.err
 }
 
 // Flush indicates there are no more values to encode.
 func (s *FloatEncoder) Flush() {
+	if s.alp {
+		s.finished = true
+		return
+	}
 	if !s.finished {
 		// write an end-of-stream record
 		s.finished = true
@@ -90,6 +107,10 @@ func (s *FloatEncoder) Write(v float64) {
 		s.err = fmt.Errorf("unsupported value: NaN")
 		return
 	}
+	if s.alp {
+		s.values = append(s.values, v)
+		return
+	}
 	if s.first {
 		// first point
 		s.val = v
@@ -151,11 +172,32 @@ type FloatDecoder struct {
 	first    bool
 	finished bool
 
+	// ALP encoded blocks are decoded at once into alp.
+	isALP bool
+	alp   []float64
+	alpI  int
+
 	err error
 }
 
 // SetBytes initializes the decoder with b. Must call before calling Next().
 func (it *FloatDecoder) SetBytes(b []byte) error {
+	if len(b) > 0 && b[0]>>4 == floatCompressedALP {
+		values, err := floatALPDecodeAll(b, it.alp[:0])
+		if err != nil {
+			return err
+		}
+		it.isALP = true
+		it.alp = values
+		it.alpI = -1
+		it.b = b
+		it.first = false
+		it.finished = false
+		it.err = nil
+		return nil
+	}
+	it.isALP = false
+
 	var v uint64
 	if len(b) == 0 {
 		v = uvnan
@@ -189,6 +231,16 @@ func (it *FloatDecoder) Next() bool {
 		return false
 	}
 
+	if it.isALP {
+		it.alpI++
+		if it.alpI >= len(it.alp) {
+			it.finished = true
+			return false
+		}
+		it.val = math.Float64bits(it.alp[it.alpI])
+		return true
+	}
+
 	if it.first {
 		it.first = false
 
@@ -0,0 +1,198 @@
+package tsm1
+
+// ALP float encoding, after "ALP: Adaptive Lossless floating-Point
+// Compression" (Afroozeh et al., SIGMOD 2024). Most floats written by sensors
+// are decimals with few digits, so a block of them multiplied by the same
+// power of ten yields integers that convert back exactly. Those integers are
+// stored with the integer encoding, which delta and simple8b encodes them.
+// Values that do not convert back exactly are stored verbatim as exceptions.
+//
+// A block is a 1 byte header, a 1 byte exponent, the uvarint count of
+// exceptions, each exception as the uvarint distance from the previous
+// exception's index and its 8 byte big endian bits, and then the integer
+// encoded block. If too many values are exceptions, the exponent is
+// alpGorillaExponent and the rest of the block is a Gorilla encoded block.
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+const (
+	// floatCompressedALP is a compressed format using the ALP encoding,
+	// written with block encoding version 2.
+	floatCompressedALP = 2
+
+	// alpMaxExponent is the largest power of ten values are scaled by.
+	alpMaxExponent = 18
+
+	// alpGorillaExponent marks an ALP block holding a Gorilla encoded block.
+	alpGorillaExponent = 0xFF
+
+	// alpMaxExact is the largest magnitude of an integer that converts to a
+	// float64 exactly.
+	alpMaxExact = 1 << 53
+
+	// alpSampleN is the number of values sampled to choose the exponent.
+	alpSampleN = 64
+)
+
+var errFloatALPShortBuffer = errors.New("FloatArrayDecodeAll: short ALP buffer")
+
+// alpPow10 holds the powers of ten, each exactly representable as a float64.
+var alpPow10 = func() (a [alpMaxExponent + 1]float64) {
+	p := 1.0
+	for i := range a {
+		a[i] = p
+		p *= 10
+	}
+	return a
+}()
+
+// alpEncode returns v scaled by 10^e as an integer, and false if the integer
+// does not convert back to v exactly.
+func alpEncode(v float64, e int) (int64, bool) {
+	x := math.Round(v * alpPow10[e])
+	if math.IsNaN(x) || math.Abs(x) > alpMaxExact {
+		return 0, false
+	}
+	n := int64(x)
+	return n, math.Float64bits(alpDecode(n, e)) == math.Float64bits(v)
+}
+
+// alpDecode returns n scaled by 10^-e.
+func alpDecode(n int64, e int) float64 {
+	return float64(n) / alpPow10[e]
+}
+
+// alpExponent returns the power of ten that leaves the fewest of a sample of
+// src as exceptions.
+func alpExponent(src []float64) int {
+	step := len(src) / alpSampleN
+	if step == 0 {
+		step = 1
+	}
+
+	best, bestN := 0, len(src)+1
+	for e := 0; e <= alpMaxExponent; e++ {
+		var n int
+		for i := 0; i < len(src) && n < bestN; i += step {
+			if _, ok := alpEncode(src[i], e); !ok {
+				n++
+			}
+		}
+		if n < bestN {
+			best, bestN = e, n
+			if n == 0 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// floatALPEncodeAll encodes src into b using the ALP encoding, falling back
+// to Gorilla encoding if more than an eighth of src would be exceptions.
+func floatALPEncodeAll(src []float64, b []byte) ([]byte, error) {
+	for _, v := range src {
+		if math.IsNaN(v) {
+			return nil, fmt.Errorf("unsupported value: NaN")
+		}
+	}
+
+	e := alpExponent(src)
+	ints := make([]int64, len(src))
+	var exceptions []int
+	var prev int64
+	for i, v := range src {
+		n, ok := alpEncode(v, e)
+		if !ok {
+			// Repeating the previous integer keeps the delta small.
+			exceptions = append(exceptions, i)
+			n = prev
+		}
+		ints[i] = n
+		prev = n
+	}
+
+	if len(src) == 0 || len(exceptions) > len(src)/8 {
+		b = append(b[:0], floatCompressedALP<<4, alpGorillaExponent)
+		vb, err := floatGorillaEncodeAll(src, nil)
+		if err != nil {
+			return nil, err
+		}
+		return append(b, vb...), nil
+	}
+
+	b = append(b[:0], floatCompressedALP<<4, byte(e))
+	b = binary.AppendUvarint(b, uint64(len(exceptions)))
+	last := 0
+	for _, i := range exceptions {
+		b = binary.AppendUvarint(b, uint64(i-last))
+		b = binary.BigEndian.AppendUint64(b, math.Float64bits(src[i]))
+		last = i
+	}
+
+	ib, err := IntegerArrayEncodeAll(ints, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, ib...), nil
+}
+
+// floatALPDecodeAll decodes an ALP encoded block b into dst.
+func floatALPDecodeAll(b []byte, dst []float64) ([]float64, error) {
+	if len(b) < 2 {
+		return nil, errFloatALPShortBuffer
+	}
+	e := int(b[1])
+	b = b[2:]
+	if e == alpGorillaExponent {
+		return FloatArrayDecodeAll(b, dst)
+	} else if e > alpMaxExponent {
+		return nil, fmt.Errorf("FloatArrayDecodeAll: invalid ALP exponent %d", e)
+	}
+
+	exceptionN, n := binary.Uvarint(b)
+	if n <= 0 || exceptionN > uint64(len(b)) {
+		return nil, errFloatALPShortBuffer
+	}
+	b = b[n:]
+	exceptions := b
+	for i := uint64(0); i < exceptionN; i++ {
+		if _, n = binary.Uvarint(b); n <= 0 || len(b) < n+8 {
+			return nil, errFloatALPShortBuffer
+		}
+		b = b[n+8:]
+	}
+	exceptions = exceptions[:len(exceptions)-len(b)]
+
+	// Decode the integers into dst's memory and convert them in place.
+	dst = dst[:cap(dst)]
+	ints, err := IntegerArrayDecodeAll(b, *(*[]int64)(unsafe.Pointer(&dst)))
+	if err != nil {
+		return nil, err
+	}
+	if cap(dst) < len(ints) {
+		dst = make([]float64, len(ints))
+	}
+	dst = dst[:len(ints)]
+	for i, n := range ints {
+		dst[i] = alpDecode(n, e)
+	}
+
+	var i uint64
+	for j := uint64(0); j < exceptionN; j++ {
+		delta, n := binary.Uvarint(exceptions)
+		i += delta
+		if i >= uint64(len(dst)) {
+			return nil, fmt.Errorf("FloatArrayDecodeAll: ALP exception index %d out of range", i)
+		}
+		dst[i] = math.Float64frombits(binary.BigEndian.Uint64(exceptions[n:]))
+		exceptions = exceptions[n+8:]
+	}
+	return dst, nil
+}
@@ -0,0 +1,91 @@
+package tsm1
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// indexBuildBatchSize is the number of series keys added to an index at a time
+// by BuildIndex.
+const indexBuildBatchSize = 10000
+
+// BuildIndex adds every series in the engine's TSM files and cache to index,
+// calling progress with the number of series keys added after each batch.
+// Unlike LoadMetadataIndex, the engine's own index and field set are left
+// untouched, so the build can run while the engine is in use.
+func (e *Engine) BuildIndex(ctx context.Context, index tsdb.Index, progress func(n int)) error {
+	keys := make([][]byte, 0, indexBuildBatchSize)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		names := make([][]byte, len(keys))
+		tags := make([]models.Tags, len(keys))
+		for i, key := range keys {
+			names[i], tags[i] = models.ParseKeyBytes(key)
+		}
+		if err := index.CreateSeriesListIfNotExists(keys, names, tags); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(len(keys))
+		}
+		keys = keys[:0]
+		return ctx.Err()
+	}
+
+	// Walk the cache, including any snapshot being written, before the TSM
+	// files. Series only leave a snapshot once its file is in the file store,
+	// so every series is seen in at least one of them.
+	e.Cache.mu.RLock()
+	stores := []storer{e.Cache.store}
+	if e.Cache.snapshot != nil {
+		stores = append(stores, e.Cache.snapshot.store)
+	}
+	e.Cache.mu.RUnlock()
+
+	var cacheKeys [][]byte
+	seen := make(map[string]struct{})
+	for _, store := range stores {
+		if store == nil {
+			continue
+		}
+		if err := store.applySerial(func(key []byte, _ *entry) error {
+			seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+			if _, ok := seen[string(seriesKey)]; !ok {
+				seen[string(seriesKey)] = struct{}{}
+				cacheKeys = append(cacheKeys, seriesKey)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	for _, key := range cacheKeys {
+		if keys = append(keys, key); len(keys) == cap(keys) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// TSM keys are sorted, so all fields of a series are adjacent.
+	var prev []byte
+	if err := e.FileStore.WalkKeys(nil, func(key []byte, _ byte) error {
+		seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+		if bytes.Equal(seriesKey, prev) {
+			return nil
+		}
+		prev = seriesKey
+		if keys = append(keys, seriesKey); len(keys) == cap(keys) {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return flush()
+}
@@ -1317,7 +1317,7 @@ type mmapAccessor struct {
 }
 
 // verifyVersion verifies that the reader's bytes are a TSM byte
-// stream of the correct version (1)
+// stream of a known version (1 or 2)
 func verifyVersion(r io.Reader) error {
 	// Attempt to read magic number.
 	var magic uint32
@@ -1337,8 +1337,8 @@ func verifyVersion(r io.Reader) error {
 	}
 
 	// Ensure version matches expectations.
-	if version != Version {
-		return fmt.Errorf("init: file is version %b. expected %b", version, Version)
+	if version != Version && version != Version2 {
+		return fmt.Errorf("init: file is version %b. expected %b or %b", version, Version, Version2)
 	}
 
 	return nil
@@ -1951,6 +1951,14 @@ func TestVerifyVersion(t *testing.T) {
 				return buf
 			},
 		},
+		"Version 2 Header": {
+			buf: func() *bytes.Buffer {
+				buf := &bytes.Buffer{}
+				binary.Write(buf, binary.BigEndian, MagicNumber)
+				binary.Write(buf, binary.BigEndian, Version2)
+				return buf
+			},
+		},
 		"Header With Bad Version": {
 			shouldErr: true,
 			buf: func() *bytes.Buffer {
@@ -0,0 +1,549 @@
+package tsm1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
+	"go.uber.org/zap"
+)
+
+// Suffixes of the fields that hold the minimum, maximum and number of values
+// in each window of a rolled up numeric field.
+const (
+	RollupMinSuffix   = tsdb.RollupMinSuffix
+	RollupMaxSuffix   = tsdb.RollupMaxSuffix
+	RollupCountSuffix = tsdb.RollupCountSuffix
+)
+
+// Rollup describes how a compaction downsamples the values of each series and
+// field to one value per interval.
+type Rollup struct {
+	// Interval is the width of each window.
+	Interval time.Duration
+
+	// Rerollup is set if the data was already rolled up to a smaller interval.
+	// The rollup fields of each field are then combined rather than created.
+	Rerollup bool
+
+	// Fields is set by the compaction to the rollup fields it created, by
+	// measurement.
+	Fields map[string]map[string]influxql.DataType
+}
+
+// Rollup downsamples all data in the engine to one value per interval for
+// each series and field, with a full compaction of its TSM files.
+//
+// A numeric field keeps the mean of each window, and fields with the
+// RollupMinSuffix, RollupMaxSuffix and RollupCountSuffix suffixes are added
+// for the minimum, maximum and number of values. Boolean and string fields
+// keep the last value of each window. If previous is non-zero the data was
+// already rolled up to that interval, and the existing rollup fields are
+// combined, with the means weighted by their counts. The rollup fails if a
+// rollup field would replace a field of the same name.
+func (e *Engine) Rollup(ctx context.Context, interval, previous time.Duration) error {
+	if interval <= 0 {
+		return errors.New("rollup interval must be positive")
+	} else if previous > 0 && interval%previous != 0 {
+		return fmt.Errorf("rollup interval %s is not a multiple of %s", interval, previous)
+	}
+
+	log, logEnd := logger.NewOperation(e.logger, "TSM rollup", "tsm1_rollup",
+		logger.Shard(e.id), zap.Duration("interval", interval))
+	defer logEnd()
+
+	if err := e.WriteSnapshot(); err != nil {
+		return err
+	} else if err := e.foldSpill(); err != nil {
+		return err
+	}
+
+	// Stop level and full compactions so the rollup has all TSM files.
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+
+	var files []string
+	for _, f := range e.FileStore.Files() {
+		files = append(files, f.Path())
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	r := &Rollup{Interval: interval, Rerollup: previous > 0}
+	newFiles, err := e.Compactor.CompactRollup(ctx, files, r)
+	if err != nil {
+		return err
+	}
+	if err := e.FileStore.ReplaceWithCallback(files, newFiles, nil); err != nil {
+		for _, f := range newFiles {
+			if rmErr := os.Remove(f); rmErr != nil {
+				log.Error("Unable to remove file", zap.String("path", f), zap.Error(rmErr))
+			}
+		}
+		return err
+	}
+
+	for name, fields := range r.Fields {
+		mf := e.fieldset.CreateFieldsIfNotExists([]byte(name))
+		for field, typ := range fields {
+			if err := mf.CreateFieldIfNotExists([]byte(field), typ); err != nil {
+				return err
+			}
+		}
+	}
+	if len(r.Fields) > 0 {
+		if err := e.fieldset.Save(); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Rolled up TSM files", zap.Int("tsm1_files_n", len(newFiles)))
+	return nil
+}
+
+// CompactRollup writes the TSM files into new files with their values
+// downsampled as described by r. It runs regardless of whether compactions
+// are enabled, and is aborted if ctx is canceled.
+func (c *Compactor) CompactRollup(ctx context.Context, tsmFiles []string, r *Rollup) ([]string, error) {
+	if !c.add(tsmFiles) {
+		return nil, errCompactionInProgress{}
+	}
+	defer c.remove(tsmFiles)
+
+	size := c.Size
+	if size <= 0 {
+		size = tsdb.DefaultMaxPointsPerBlock
+	}
+
+	intC := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() { close(intC) })
+	defer stop()
+
+	r.Fields = make(map[string]map[string]influxql.DataType)
+	return c.compactReaders(tsmFiles, intC, func(trs []*TSMReader) (KeyIterator, error) {
+		itr, err := NewTSMBatchKeyIterator(size, false, intC, tsmFiles, trs...)
+		if err != nil {
+			return nil, err
+		}
+		return newRollupKeyIterator(itr, r, size, trs), nil
+	})
+}
+
+// rollupBlock is an encoded block of rolled up values.
+type rollupBlock struct {
+	key              []byte
+	minTime, maxTime int64
+	data             []byte
+}
+
+// rollupKeyIterator downsamples the values read from another key iterator.
+// The rollup fields of each field are held until the keys between them and
+// the field have been read, so keys are still returned in sorted order.
+type rollupKeyIterator struct {
+	itr      KeyIterator
+	rollup   *Rollup
+	interval int64
+	size     int
+
+	// The files compacted, read directly for the counts of fields already
+	// rolled up.
+	readers []*TSMReader
+
+	// The first block of the next key, if it has been read.
+	peeked bool
+	key    []byte
+	block  []byte
+
+	// Blocks to be returned, and rollup fields waiting for their keys.
+	ready   []rollupBlock
+	pending map[string][]Value
+
+	// The series of the last key and the types of the fields read for it.
+	series []byte
+	fields map[string]byte
+
+	cur rollupBlock
+	err error
+}
+
+func newRollupKeyIterator(itr KeyIterator, r *Rollup, size int, readers []*TSMReader) *rollupKeyIterator {
+	return &rollupKeyIterator{
+		itr:      itr,
+		rollup:   r,
+		interval: int64(r.Interval),
+		size:     size,
+		readers:  readers,
+		pending:  make(map[string][]Value),
+		fields:   make(map[string]byte),
+	}
+}
+
+func (k *rollupKeyIterator) Next() bool {
+	for {
+		if len(k.ready) > 0 {
+			k.cur, k.ready = k.ready[0], k.ready[1:]
+			return true
+		} else if k.err != nil {
+			return false
+		}
+
+		key, windows, typ, err := k.readKey()
+		if err != nil {
+			k.err = err
+			return false
+		} else if key == nil {
+			k.flushPending(nil)
+			if len(k.ready) == 0 {
+				return false
+			}
+			continue
+		}
+
+		k.flushPending(key)
+		if _, ok := k.pending[string(key)]; ok {
+			series, field := SeriesAndFieldFromCompositeKey(key)
+			k.err = fmt.Errorf("rollup field %q collides with a field of measurement %q", field, models.ParseName(series))
+			continue
+		}
+		if err := k.rollupKey(key, windows, typ); err != nil {
+			k.err = err
+		}
+	}
+}
+
+// readKey aggregates all blocks of the next key into windows. It returns a
+// nil key once the underlying iterator is exhausted.
+func (k *rollupKeyIterator) readKey() ([]byte, []*rollupWindow, byte, error) {
+	if !k.peeked {
+		if !k.itr.Next() {
+			return nil, nil, 0, k.itr.Err()
+		}
+		key, _, _, block, err := k.itr.Read()
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		k.key, k.block, k.peeked = append(k.key[:0:0], key...), block, true
+	}
+
+	key := k.key
+	typ, err := BlockType(k.block)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// The mean of a field already rolled up is weighted by its count.
+	var counts map[int64]int64
+	if k.rollup.Rerollup && isNumericBlock(typ) {
+		series, field := SeriesAndFieldFromCompositeKey(key)
+		if k.rolledUp(series, string(field)) {
+			if counts, err = k.counts(SeriesFieldKeyBytes(string(series), string(field)+RollupCountSuffix)); err != nil {
+				return nil, nil, 0, err
+			}
+		}
+	}
+
+	var windows []*rollupWindow
+	var values []Value
+	for {
+		if values, err = DecodeBlock(k.block, values[:0]); err != nil {
+			return nil, nil, 0, err
+		}
+		for _, v := range values {
+			start := v.UnixNano() - mod(v.UnixNano(), k.interval)
+			if n := len(windows); n == 0 || windows[n-1].start != start {
+				windows = append(windows, &rollupWindow{start: start})
+			}
+			n := int64(1)
+			if c, ok := counts[v.UnixNano()]; ok && c > 0 {
+				n = c
+			}
+			windows[len(windows)-1].add(v, n)
+		}
+
+		k.peeked = false
+		if !k.itr.Next() {
+			if err := k.itr.Err(); err != nil {
+				return nil, nil, 0, err
+			}
+			break
+		}
+		next, _, _, block, err := k.itr.Read()
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if !bytes.Equal(next, key) {
+			k.key, k.block, k.peeked = append(k.key[:0:0], next...), block, true
+			break
+		}
+		k.block, k.peeked = block, true
+	}
+	return key, windows, typ, nil
+}
+
+// rollupKey queues the rolled up values of a key, and its rollup fields.
+func (k *rollupKeyIterator) rollupKey(key []byte, windows []*rollupWindow, typ byte) error {
+	series, field := SeriesAndFieldFromCompositeKey(key)
+	if !bytes.Equal(series, k.series) {
+		k.series = append(k.series[:0], series...)
+		k.fields = make(map[string]byte)
+	}
+	k.fields[string(field)] = typ
+
+	numeric := isNumericBlock(typ)
+	values := make([]Value, 0, len(windows))
+	if !k.rollup.Rerollup || !numeric {
+		for _, w := range windows {
+			values = append(values, w.mean())
+		}
+		if numeric && !k.rollup.Rerollup {
+			k.addRollupFields(series, string(field), windows, typ)
+		}
+		return k.queue(key, values)
+	}
+
+	// Combine the existing rollup fields of a numeric field seen earlier in
+	// the series.
+	agg := (*rollupWindow).mean
+	for _, suffix := range []string{RollupMinSuffix, RollupMaxSuffix, RollupCountSuffix} {
+		base := strings.TrimSuffix(string(field), suffix)
+		if typ, ok := k.fields[base]; !ok || base == string(field) || !isNumericBlock(typ) || !k.rolledUp(series, base) {
+			continue
+		}
+		switch suffix {
+		case RollupMinSuffix:
+			agg = func(w *rollupWindow) Value { return w.min }
+		case RollupMaxSuffix:
+			agg = func(w *rollupWindow) Value { return w.max }
+		case RollupCountSuffix:
+			agg = (*rollupWindow).sum
+		}
+		break
+	}
+	for _, w := range windows {
+		values = append(values, agg(w))
+	}
+	return k.queue(key, values)
+}
+
+// rolledUp returns true if all rollup fields of field exist in the series.
+func (k *rollupKeyIterator) rolledUp(series []byte, field string) bool {
+	for _, suffix := range []string{RollupMinSuffix, RollupMaxSuffix, RollupCountSuffix} {
+		key := SeriesFieldKeyBytes(string(series), field+suffix)
+		var found bool
+		for _, r := range k.readers {
+			if r.Contains(key) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// counts returns the values of a count field by timestamp. Values of later
+// files replace those of earlier files.
+func (k *rollupKeyIterator) counts(key []byte) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	for _, r := range k.readers {
+		values, err := r.ReadAll(key)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if v, ok := v.(IntegerValue); ok {
+				counts[v.UnixNano()] = v.value
+			}
+		}
+	}
+	return counts, nil
+}
+
+// addRollupFields adds the minimum, maximum and count fields of a field to
+// the pending keys.
+func (k *rollupKeyIterator) addRollupFields(series []byte, field string, windows []*rollupWindow, typ byte) {
+	name := models.ParseName(series)
+	fields := k.rollup.Fields[string(name)]
+	if fields == nil {
+		fields = make(map[string]influxql.DataType)
+		k.rollup.Fields[string(name)] = fields
+	}
+
+	mins := make([]Value, 0, len(windows))
+	maxs := make([]Value, 0, len(windows))
+	counts := make([]Value, 0, len(windows))
+	for _, w := range windows {
+		mins = append(mins, w.min)
+		maxs = append(maxs, w.max)
+		counts = append(counts, NewIntegerValue(w.start, w.count))
+	}
+
+	dataType := BlockTypeToInfluxQLDataType(typ)
+	fields[field+RollupMinSuffix] = dataType
+	fields[field+RollupMaxSuffix] = dataType
+	fields[field+RollupCountSuffix] = influxql.Integer
+	k.pending[string(SeriesFieldKeyBytes(string(series), field+RollupMinSuffix))] = mins
+	k.pending[string(SeriesFieldKeyBytes(string(series), field+RollupMaxSuffix))] = maxs
+	k.pending[string(SeriesFieldKeyBytes(string(series), field+RollupCountSuffix))] = counts
+}
+
+// flushPending queues the pending keys that sort before key, or all pending
+// keys if key is nil.
+func (k *rollupKeyIterator) flushPending(key []byte) {
+	var keys []string
+	for pk := range k.pending {
+		if key == nil || pk < string(key) {
+			keys = append(keys, pk)
+		}
+	}
+	sort.Strings(keys)
+	for _, pk := range keys {
+		k.flushKey([]byte(pk))
+	}
+}
+
+// flushKey queues the pending values for key.
+func (k *rollupKeyIterator) flushKey(key []byte) {
+	values := k.pending[string(key)]
+	delete(k.pending, string(key))
+	if err := k.queue(key, values); err != nil && k.err == nil {
+		k.err = err
+	}
+}
+
+// queue encodes values into blocks to be returned for key.
+func (k *rollupKeyIterator) queue(key []byte, values []Value) error {
+	for len(values) > 0 {
+		n := k.size
+		if n > len(values) {
+			n = len(values)
+		}
+		chunk := values[:n]
+		values = values[n:]
+
+		data, err := Values(chunk).Encode(nil)
+		if err != nil {
+			return err
+		}
+		k.ready = append(k.ready, rollupBlock{
+			key:     key,
+			minTime: chunk[0].UnixNano(),
+			maxTime: chunk[len(chunk)-1].UnixNano(),
+			data:    data,
+		})
+	}
+	return nil
+}
+
+func (k *rollupKeyIterator) Read() ([]byte, int64, int64, []byte, error) {
+	return k.cur.key, k.cur.minTime, k.cur.maxTime, k.cur.data, nil
+}
+
+func (k *rollupKeyIterator) Close() error { return k.itr.Close() }
+
+func (k *rollupKeyIterator) Err() error {
+	if k.err != nil {
+		return k.err
+	}
+	return k.itr.Err()
+}
+
+func (k *rollupKeyIterator) EstimatedIndexSize() int { return k.itr.EstimatedIndexSize() }
+
+// rollupWindow aggregates the values of a key in a single window.
+type rollupWindow struct {
+	start    int64
+	count    int64
+	min, max Value
+	last     Value
+
+	fsum float64
+	isum int64
+	usum uint64
+}
+
+// add adds v to the window, weighted by the number of values n it stands for.
+func (w *rollupWindow) add(v Value, n int64) {
+	w.count += n
+	w.last = v
+	switch v := v.(type) {
+	case FloatValue:
+		w.fsum += v.value * float64(n)
+		if w.min == nil || v.value < w.min.(FloatValue).value {
+			w.min = NewFloatValue(w.start, v.value)
+		}
+		if w.max == nil || v.value > w.max.(FloatValue).value {
+			w.max = NewFloatValue(w.start, v.value)
+		}
+	case IntegerValue:
+		w.isum += v.value * n
+		if w.min == nil || v.value < w.min.(IntegerValue).value {
+			w.min = NewIntegerValue(w.start, v.value)
+		}
+		if w.max == nil || v.value > w.max.(IntegerValue).value {
+			w.max = NewIntegerValue(w.start, v.value)
+		}
+	case UnsignedValue:
+		w.usum += v.value * uint64(n)
+		if w.min == nil || v.value < w.min.(UnsignedValue).value {
+			w.min = NewUnsignedValue(w.start, v.value)
+		}
+		if w.max == nil || v.value > w.max.(UnsignedValue).value {
+			w.max = NewUnsignedValue(w.start, v.value)
+		}
+	}
+}
+
+// mean returns the mean of the window's numeric values, rounded for integer
+// types, or the last value for other types.
+func (w *rollupWindow) mean() Value {
+	switch w.last.(type) {
+	case FloatValue:
+		return NewFloatValue(w.start, w.fsum/float64(w.count))
+	case IntegerValue:
+		return NewIntegerValue(w.start, int64(math.Round(float64(w.isum)/float64(w.count))))
+	case UnsignedValue:
+		return NewUnsignedValue(w.start, uint64(math.Round(float64(w.usum)/float64(w.count))))
+	default:
+		return NewValue(w.start, w.last.Value())
+	}
+}
+
+// sum returns the sum of the window's values.
+func (w *rollupWindow) sum() Value {
+	switch w.last.(type) {
+	case FloatValue:
+		return NewFloatValue(w.start, w.fsum)
+	case UnsignedValue:
+		return NewUnsignedValue(w.start, w.usum)
+	default:
+		return NewIntegerValue(w.start, w.isum)
+	}
+}
+
+// isNumericBlock returns true if blocks of type typ hold numeric values.
+func isNumericBlock(typ byte) bool {
+	return typ == BlockFloat64 || typ == BlockInteger || typ == BlockUnsigned
+}
+
+// mod returns the non-negative remainder of t divided by d.
+func mod(t, d int64) int64 {
+	if m := t % d; m < 0 {
+		return m + d
+	} else {
+		return m
+	}
+}
@@ -0,0 +1,112 @@
+package tsm1_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	"github.com/influxdata/influxql"
+)
+
+func TestCompactor_CompactRollup(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	m := int64(time.Minute)
+	f1 := MustWriteTSM(dir, 1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#n":       {tsm1.NewValue(0, int64(1)), tsm1.NewValue(m, int64(2))},
+		"cpu,host=A#!~#s":       {tsm1.NewValue(0, "a"), tsm1.NewValue(m, "b")},
+		"cpu,host=A#!~#value":   {tsm1.NewValue(0, 1.0), tsm1.NewValue(m, 3.0)},
+		"cpu,host=A#!~#value_a": {tsm1.NewValue(0, true)},
+	})
+	f2 := MustWriteTSM(dir, 2, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value": {tsm1.NewValue(6*m, 5.0)},
+	})
+
+	fs := &fakeFileStore{}
+	defer fs.Close()
+	compactor := tsm1.NewCompactor()
+	compactor.Dir = dir
+	compactor.FileStore = fs
+	compactor.Open()
+
+	rollup := func(files []string, r *tsm1.Rollup) *tsm1.TSMReader {
+		t.Helper()
+		files, err := compactor.CompactRollup(context.Background(), files, r)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if len(files) != 1 {
+			t.Fatalf("unexpected files: %v", files)
+		}
+		return MustOpenTSMReader(files[0])
+	}
+	check := func(r *tsm1.TSMReader, exp map[string][]tsm1.Value) {
+		t.Helper()
+		if got := r.KeyCount(); got != len(exp) {
+			t.Fatalf("unexpected key count: got %d, exp %d", got, len(exp))
+		}
+		for key, points := range exp {
+			values, err := r.ReadAll([]byte(key))
+			if err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(values, points) {
+				t.Fatalf("unexpected values for %s: got %v, exp %v", key, values, points)
+			}
+		}
+	}
+
+	r := &tsm1.Rollup{Interval: 5 * time.Minute}
+	r1 := rollup([]string{f1, f2}, r)
+	defer r1.Close()
+	check(r1, map[string][]tsm1.Value{
+		"cpu,host=A#!~#n":           {tsm1.NewValue(0, int64(2))},
+		"cpu,host=A#!~#n_count":     {tsm1.NewValue(0, int64(2))},
+		"cpu,host=A#!~#n_max":       {tsm1.NewValue(0, int64(2))},
+		"cpu,host=A#!~#n_min":       {tsm1.NewValue(0, int64(1))},
+		"cpu,host=A#!~#s":           {tsm1.NewValue(0, "b")},
+		"cpu,host=A#!~#value":       {tsm1.NewValue(0, 2.0), tsm1.NewValue(5*m, 5.0)},
+		"cpu,host=A#!~#value_a":     {tsm1.NewValue(0, true)},
+		"cpu,host=A#!~#value_count": {tsm1.NewValue(0, int64(2)), tsm1.NewValue(5*m, int64(1))},
+		"cpu,host=A#!~#value_max":   {tsm1.NewValue(0, 3.0), tsm1.NewValue(5*m, 5.0)},
+		"cpu,host=A#!~#value_min":   {tsm1.NewValue(0, 1.0), tsm1.NewValue(5*m, 5.0)},
+	})
+	if exp := map[string]map[string]influxql.DataType{
+		"cpu": {
+			"n_count": influxql.Integer, "n_max": influxql.Integer, "n_min": influxql.Integer,
+			"value_count": influxql.Integer, "value_max": influxql.Float, "value_min": influxql.Float,
+		},
+	}; !reflect.DeepEqual(r.Fields, exp) {
+		t.Fatalf("unexpected fields: %v", r.Fields)
+	}
+
+	// Rolling up again combines the existing rollup fields, with the means
+	// weighted by their counts.
+	r2 := rollup([]string{r1.Path()}, &tsm1.Rollup{Interval: 10 * time.Minute, Rerollup: true})
+	defer r2.Close()
+	check(r2, map[string][]tsm1.Value{
+		"cpu,host=A#!~#n":           {tsm1.NewValue(0, int64(2))},
+		"cpu,host=A#!~#n_count":     {tsm1.NewValue(0, int64(2))},
+		"cpu,host=A#!~#n_max":       {tsm1.NewValue(0, int64(2))},
+		"cpu,host=A#!~#n_min":       {tsm1.NewValue(0, int64(1))},
+		"cpu,host=A#!~#s":           {tsm1.NewValue(0, "b")},
+		"cpu,host=A#!~#value":       {tsm1.NewValue(0, 3.0)},
+		"cpu,host=A#!~#value_a":     {tsm1.NewValue(0, true)},
+		"cpu,host=A#!~#value_count": {tsm1.NewValue(0, int64(3))},
+		"cpu,host=A#!~#value_max":   {tsm1.NewValue(0, 5.0)},
+		"cpu,host=A#!~#value_min":   {tsm1.NewValue(0, 1.0)},
+	})
+
+	// A rollup field can't replace a field of the same name.
+	f3 := MustWriteTSM(dir, 3, map[string][]tsm1.Value{
+		"cpu,host=A#!~#value":     {tsm1.NewValue(0, 1.0)},
+		"cpu,host=A#!~#value_max": {tsm1.NewValue(0, 2.0)},
+	})
+	_, err := compactor.CompactRollup(context.Background(), []string{f3}, &tsm1.Rollup{Interval: 5 * time.Minute})
+	if err == nil || !strings.Contains(err.Error(), `rollup field "value_max" collides`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
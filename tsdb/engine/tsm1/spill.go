@@ -0,0 +1,182 @@
+package tsm1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"go.uber.org/zap"
+)
+
+// SpillDirectory is the directory under a shard's WAL directory that holds
+// writes spilled to disk while the cache was full.
+const SpillDirectory = "spill"
+
+// Statistics gathered by the engine about spilled writes.
+const (
+	statSpillActive       = "spillActive"
+	statSpillDiskBytes    = "spillDiskBytes"
+	statSpillWriteOK      = "spillWriteOk"
+	statSpillWriteErr     = "spillWriteErr"
+	statSpillFolds        = "spillFolds"
+	statSpillFoldError    = "spillFoldErr"
+	statSpillFoldDuration = "spillFoldDuration"
+)
+
+// ErrSpillSizeLimitExceeded returns an error indicating a write could not be
+// spilled to disk due to exceeding the cache-spill-max-size setting.
+func ErrSpillSizeLimitExceeded(n, limit int64) error {
+	return fmt.Errorf("cache-spill-max-size exceeded: (%d/%d)", n, limit)
+}
+
+// isSpilling returns true while writes bypass the cache because earlier
+// writes were spilled and are not yet folded into TSM files. Writes keep
+// spilling until then so that spilled values are never older than values in
+// the cache.
+func (e *Engine) isSpilling() bool {
+	return atomic.LoadInt32(&e.spilling) == 1
+}
+
+// spillDiskSize returns the size in bytes of the spilled writes on disk.
+func (e *Engine) spillDiskSize() int64 {
+	if e.Spill == nil {
+		return 0
+	}
+	return e.Spill.DiskSizeBytes()
+}
+
+// openSpill opens the spill WAL and resumes spilling if it holds writes that
+// were not folded before the engine was closed. Spilled writes left from when
+// spilling was enabled are still folded if it has since been disabled.
+func (e *Engine) openSpill() error {
+	if !e.WALEnabled {
+		return nil
+	}
+
+	if e.Spill == nil {
+		path := filepath.Join(e.WAL.Path(), SpillDirectory)
+		segments, err := segmentFileNames(path)
+		if err != nil || len(segments) == 0 {
+			return err
+		}
+		e.Spill = NewWAL(path)
+		e.Spill.syncDelay = e.WAL.syncDelay
+		e.Spill.WithLogger(e.logger)
+	}
+
+	if err := e.Spill.Open(); err != nil {
+		return err
+	}
+	if e.Spill.DiskSizeBytes() > 0 {
+		atomic.StoreInt32(&e.spilling, 1)
+	}
+	return nil
+}
+
+// writeSpill writes values to the spill WAL instead of the cache. The values
+// are durable once it returns but are not queryable until they are folded
+// into TSM files. Writes fail once the spill reaches its maximum size.
+func (e *Engine) writeSpill(values map[string][]Value) error {
+	if n := e.Spill.DiskSizeBytes(); e.spillMaxSize > 0 && n >= e.spillMaxSize {
+		atomic.AddInt64(&e.stats.SpillWriteErr, 1)
+		return ErrSpillSizeLimitExceeded(n, e.spillMaxSize)
+	}
+
+	atomic.StoreInt32(&e.spilling, 1)
+	if _, err := e.Spill.WriteMulti(values); err != nil {
+		atomic.AddInt64(&e.stats.SpillWriteErr, 1)
+		return err
+	}
+	atomic.AddInt64(&e.stats.SpillWriteOK, 1)
+	return nil
+}
+
+// foldSpill writes the spilled writes to TSM files and returns the engine to
+// writing to the cache. The cache is snapshotted first, as everything in it
+// was written before the spilled values, which therefore land in newer TSM
+// files. The last writes to spill are folded with writes blocked.
+func (e *Engine) foldSpill() (err error) {
+	if !e.isSpilling() {
+		return nil
+	}
+
+	e.spillMu.Lock()
+	defer e.spillMu.Unlock()
+	if !e.isSpilling() {
+		return nil
+	}
+
+	start := time.Now()
+	log, logEnd := logger.NewOperation(e.logger, "Fold spilled writes", "tsm1_spill_fold")
+	defer func() {
+		if err != nil {
+			atomic.AddInt64(&e.stats.SpillFoldErrors, 1)
+		} else {
+			log.Info("Spilled writes folded", zap.String("path", e.path), zap.Duration("duration", time.Since(start)))
+		}
+		atomic.AddInt64(&e.stats.SpillFoldDuration, time.Since(start).Nanoseconds())
+		logEnd()
+	}()
+
+	if err := e.WriteSnapshot(); err != nil {
+		return err
+	}
+	if err := e.foldSpillSegments(log); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.foldSpillSegments(log); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&e.spilling, 0)
+	return nil
+}
+
+// foldSpillSegments closes the current spill segment and writes each closed
+// segment to new TSM files, oldest first, removing it once its files are in
+// the file store. Only one segment is held in memory at a time.
+func (e *Engine) foldSpillSegments(log *zap.Logger) error {
+	if err := e.Spill.CloseSegment(); err != nil {
+		return err
+	}
+	segments, err := e.Spill.ClosedSegments()
+	if err != nil {
+		return err
+	}
+
+	for _, segment := range segments {
+		cache := NewCache(0)
+		loader := NewCacheLoader([]string{segment})
+		loader.WithLogger(e.logger)
+		if err := loader.Load(cache); err != nil {
+			return err
+		}
+
+		if cache.Size() > 0 {
+			cache.Deduplicate()
+			newFiles, err := e.Compactor.WriteSnapshot(cache)
+			if err != nil {
+				return err
+			}
+			if err := e.FileStore.Replace(nil, newFiles); err != nil {
+				for _, file := range newFiles {
+					if err := os.Remove(file); err != nil {
+						log.Info("Unable to remove file", zap.String("path", file), zap.Error(err))
+					}
+				}
+				return err
+			}
+		}
+
+		if err := e.Spill.Remove([]string{segment}); err != nil {
+			return err
+		}
+		atomic.AddInt64(&e.stats.SpillFolds, 1)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package tsm1_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+func TestEngine_CacheSpill(t *testing.T) {
+	e, err := NewEngine(tsdb.TSI1IndexName, func(opt *tsdb.EngineOptions) {
+		opt.Config.CacheMaxMemorySize = 64
+		opt.Config.CacheSpillEnabled = true
+		opt.Config.CacheSpillMaxSize = 1024 * 1024
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	// The first write fits in the cache, the rest are spilled.
+	if err := e.WritePointsString(`cpu,host=A value=1 1000000000`); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WritePointsString(
+		`cpu,host=A value=2 1000000000`,
+		`cpu,host=A value=3 2000000000`,
+		`cpu,host=A value=4 3000000000`,
+	); err != nil {
+		t.Fatalf("expected write to be spilled: %v", err)
+	}
+	if err := e.WritePointsString(`cpu,host=A value=5 4000000000`); err != nil {
+		t.Fatalf("expected write to be spilled: %v", err)
+	}
+
+	stats := e.Statistics(nil)[0].Values
+	if got := stats["spillActive"]; got != int64(1) {
+		t.Fatalf("unexpected spill state: %v", got)
+	} else if got := stats["spillWriteOk"]; got != int64(2) {
+		t.Fatalf("unexpected spilled writes: %v", got)
+	} else if got := stats["spillDiskBytes"].(int64); got <= 0 {
+		t.Fatalf("unexpected spill size: %v", got)
+	}
+	if got := e.Cache.Values([]byte(tsm1.SeriesFieldKey("cpu,host=A", "value"))); len(got) != 1 {
+		t.Fatalf("expected spilled values to be kept out of the cache: %v", got)
+	}
+
+	// Spilled writes are folded into TSM files and overwrite older values.
+	path, err := e.CreateSnapshot(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(path)
+
+	exp := []float64{2, 3, 4, 5}
+	if got := readFloats(t, e, "cpu,host=A", "value"); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected values: got %v, exp %v", got, exp)
+	}
+
+	stats = e.Statistics(nil)[0].Values
+	if got := stats["spillActive"]; got != int64(0) {
+		t.Fatalf("unexpected spill state: %v", got)
+	} else if got := stats["spillFolds"].(int64); got == 0 {
+		t.Fatal("expected spilled segments to be folded")
+	} else if got := stats["spillDiskBytes"]; got != int64(0) {
+		t.Fatalf("unexpected spill size: %v", got)
+	}
+
+	// Writes go to the cache again once the spill is folded.
+	if err := e.WritePointsString(`cpu,host=A value=6 5000000000`); err != nil {
+		t.Fatal(err)
+	} else if got := e.Cache.Values([]byte(tsm1.SeriesFieldKey("cpu,host=A", "value"))); len(got) != 1 {
+		t.Fatalf("expected write to the cache: %v", got)
+	}
+}
+
+func TestEngine_CacheSpill_MaxSize(t *testing.T) {
+	e, err := NewEngine(tsdb.TSI1IndexName, func(opt *tsdb.EngineOptions) {
+		opt.Config.CacheMaxMemorySize = 1
+		opt.Config.CacheSpillEnabled = true
+		opt.Config.CacheSpillMaxSize = 1
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.WritePointsString(`cpu,host=A value=1 1000000000`); err != nil {
+		t.Fatalf("expected write to be spilled: %v", err)
+	}
+	if err := e.WritePointsString(`cpu,host=A value=2 2000000000`); err == nil || !strings.Contains(err.Error(), "cache-spill-max-size exceeded") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := e.Statistics(nil)[0].Values["spillWriteErr"]; got != int64(1) {
+		t.Fatalf("unexpected spill write errors: %v", got)
+	}
+}
+
+func TestEngine_CacheSpill_Reopen(t *testing.T) {
+	e, err := NewEngine(tsdb.TSI1IndexName, func(opt *tsdb.EngineOptions) {
+		opt.Config.CacheMaxMemorySize = 1
+		opt.Config.CacheSpillEnabled = true
+		opt.Config.CacheSpillMaxSize = 1024 * 1024
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.CompactionPlan = &mockPlanner{}
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.WritePointsString(`cpu,host=A value=1 1000000000`); err != nil {
+		t.Fatalf("expected write to be spilled: %v", err)
+	}
+
+	// Spilled writes are folded after a restart, even with spilling disabled.
+	if err := e.Reopen(func(opt *tsdb.EngineOptions) {
+		opt.Config.CacheSpillEnabled = false
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := e.Statistics(nil)[0].Values["spillActive"]; got != int64(1) {
+		t.Fatalf("unexpected spill state after reopen: %v", got)
+	}
+	path, err := e.CreateSnapshot(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.RemoveAll(path)
+
+	if got := readFloats(t, e, "cpu,host=A", "value"); !reflect.DeepEqual(got, []float64{1}) {
+		t.Fatalf("unexpected values: %v", got)
+	}
+}
+
+// readFloats returns the float values of a series field in the engine's TSM files.
+func readFloats(t *testing.T, e *Engine, series, field string) []float64 {
+	t.Helper()
+	cur := e.KeyCursor(context.Background(), []byte(tsm1.SeriesFieldKey(series, field)), 0, true)
+	defer cur.Close()
+
+	var got []float64
+	var buf []tsm1.FloatValue
+	for {
+		values, err := cur.ReadFloatBlock(&buf)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(values) == 0 {
+			return got
+		}
+		for _, v := range values {
+			got = append(got, v.Value().(float64))
+		}
+		cur.Next()
+	}
+}
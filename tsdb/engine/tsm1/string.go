@@ -3,19 +3,77 @@ package tsm1
 // String encoding uses snappy compression to compress each string.  Each string is
 // appended to byte slice prefixed with a variable byte length followed by the string
 // bytes.  The bytes are compressed using snappy compressor and a 1 byte header is used
-// to indicate the type of encoding.
+// to indicate the type of encoding.  With BlockEncodingV2, the bytes are compressed
+// using zstd instead, which compresses long, repetitive strings such as log lines
+// considerably better. The zstd frames are encoded with a dictionary shared by all
+// blocks, string.zstd.dict, which primes the compression of small blocks with
+// common strings such as timestamps, log levels and JSON punctuation.
+
+//go:generate go run string_dict_gen.go
 
 import (
+	_ "embed"
 	"encoding/binary"
 	"fmt"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 )
 
 // Note: an uncompressed format is not yet implemented.
 
-// stringCompressedSnappy is a compressed encoding using Snappy compression
-const stringCompressedSnappy = 1
+const (
+	// stringCompressedSnappy is a compressed encoding using Snappy compression
+	stringCompressedSnappy = 1
+
+	// stringCompressedZstd is a compressed encoding using zstd compression,
+	// written with block encoding version 2.
+	stringCompressedZstd = 2
+)
+
+// stringZstdDict is the zstd dictionary of string blocks. Its ID is written in
+// the frame of each block, and frames without one are decoded too.
+//
+//go:embed string.zstd.dict
+var stringZstdDict []byte
+
+// The zstd encoder and decoder are safe for concurrent use by EncodeAll and
+// DecodeAll.
+var (
+	stringZstdEncoder *zstd.Encoder
+	stringZstdDecoder *zstd.Decoder
+)
+
+func init() {
+	var err error
+	// The default level makes little use of the dictionary.
+	if stringZstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedBetterCompression),
+		zstd.WithEncoderDict(stringZstdDict)); err != nil {
+		panic(fmt.Sprintf("invalid string zstd dictionary: %v", err))
+	}
+	if stringZstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderDicts(stringZstdDict)); err != nil {
+		panic(fmt.Sprintf("invalid string zstd dictionary: %v", err))
+	}
+}
+
+// decodeStringBytes returns the decompressed bytes of the string encoded block b.
+// The returned slice is always newly allocated.
+func decodeStringBytes(b []byte) ([]byte, error) {
+	var data []byte
+	var err error
+	switch b[0] >> 4 {
+	case stringCompressedSnappy:
+		data, err = snappy.Decode(nil, b[1:])
+	case stringCompressedZstd:
+		data, err = stringZstdDecoder.DecodeAll(b[1:], nil)
+	default:
+		return nil, fmt.Errorf("failed to decode string block: unknown encoding %d", b[0]>>4)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode string block: %v", err.Error())
+	}
+	return data, nil
+}
 
 // StringEncoder encodes multiple strings into a byte slice.
 type StringEncoder struct {
@@ -51,6 +109,10 @@ func (e *StringEncoder) Write(s string) {
 
 // Bytes returns a copy of the underlying buffer.
 func (e *StringEncoder) Bytes() ([]byte, error) {
+	if blockEncodingVersion() == BlockEncodingV2 {
+		return stringZstdEncoder.EncodeAll(e.bytes, []byte{stringCompressedZstd << 4}), nil
+	}
+
 	// Compress the currently appended bytes using snappy and prefix with
 	// a 1 byte header for future extension
 	data := snappy.Encode(nil, e.bytes)
@@ -68,14 +130,13 @@ type StringDecoder struct {
 // SetBytes initializes the decoder with bytes to read from.
 // This must be called before calling any other method.
 func (e *StringDecoder) SetBytes(b []byte) error {
-	// First byte stores the encoding type, only have snappy format
-	// currently so ignore for now.
+	// First byte stores the encoding type.
 	var data []byte
 	if len(b) > 0 {
 		var err error
-		data, err = snappy.Decode(nil, b[1:])
+		data, err = decodeStringBytes(b)
 		if err != nil {
-			return fmt.Errorf("failed to decode string block: %v", err.Error())
+			return err
 		}
 	}
 
@@ -0,0 +1,141 @@
+//go:build ignore
+
+// This program generates string.zstd.dict, the zstd dictionary of string
+// blocks written with BlockEncodingV2. It writes sample blocks of typical
+// string field values, such as log lines, to a temporary directory and trains
+// the dictionary on them with the zstd command.
+//
+// The dictionary must never change once released, as blocks are decoded with
+// the dictionary of the ID they were encoded with. A new dictionary needs a
+// new ID, and the decoder must keep the old ones.
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// dictID is the ID of the generated dictionary, see stringZstdDictID.
+const dictID = 0x54534d01
+
+var (
+	levels   = []string{"DEBUG", "INFO", "INFO", "INFO", "WARN", "ERROR"}
+	methods  = []string{"GET", "GET", "GET", "POST", "PUT", "DELETE", "HEAD"}
+	statuses = []int{200, 200, 200, 201, 204, 301, 304, 400, 401, 403, 404, 500, 502, 503}
+	paths    = []string{"/", "/api/v1/users", "/api/v1/orders", "/health", "/metrics", "/login", "/static/app.js", "/query", "/write"}
+	agents   = []string{"Mozilla/5.0 (X11; Linux x86_64)", "curl/7.68.0", "Go-http-client/1.1", "python-requests/2.31.0", "kube-probe/1.27"}
+	services = []string{"api", "auth", "billing", "gateway", "worker", "scheduler", "db", "cache"}
+	messages = []string{
+		"request completed",
+		"connection refused",
+		"connection reset by peer",
+		"context deadline exceeded",
+		"starting server",
+		"shutting down",
+		"retrying request",
+		"failed to connect to database",
+		"cache miss",
+		"user logged in",
+		"permission denied",
+		"timeout waiting for response",
+		"health check passed",
+		"processing job",
+		"job finished",
+		"out of memory",
+		"invalid argument",
+		"no such file or directory",
+	}
+	states = []string{"ok", "running", "stopped", "pending", "failed", "succeeded", "unknown", "true", "false", "healthy", "degraded"}
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "string-dict-")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rnd := rand.New(rand.NewSource(1))
+	kinds := []func(*rand.Rand, time.Time) string{logLine, logfmtLine, accessLine, jsonLine, stackLine, stateValue}
+	for i := 0; i < 4000; i++ {
+		kind := kinds[i%len(kinds)]
+		ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(rnd.Int63n(int64(365 * 24 * time.Hour))))
+
+		// Blocks hold up to 1000 values; most are smaller.
+		var block []byte
+		for j, n := 0, 10+rnd.Intn(500); j < n; j++ {
+			s := kind(rnd, ts)
+			block = binary.AppendUvarint(block, uint64(len(s)))
+			block = append(block, s...)
+			ts = ts.Add(time.Duration(rnd.Intn(10000)) * time.Millisecond)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%04d", i)), block, 0666); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command("zstd", "--train", "-r", dir, "-o", "string.zstd.dict", "--maxdict=16384", fmt.Sprintf("--dictID=%d", dictID), "-q", "-f")
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func pick(rnd *rand.Rand, a []string) string { return a[rnd.Intn(len(a))] }
+
+func ip(rnd *rand.Rand) string {
+	return fmt.Sprintf("10.%d.%d.%d", rnd.Intn(256), rnd.Intn(256), rnd.Intn(256))
+}
+
+func hexID(rnd *rand.Rand, n int) string {
+	const digits = "0123456789abcdef"
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteByte(digits[rnd.Intn(len(digits))])
+	}
+	return sb.String()
+}
+
+func logLine(rnd *rand.Rand, ts time.Time) string {
+	return fmt.Sprintf("%s %-5s [%s] %s: id=%s duration=%dms", ts.Format(time.RFC3339Nano), pick(rnd, levels),
+		pick(rnd, services), pick(rnd, messages), hexID(rnd, 8), rnd.Intn(5000))
+}
+
+func logfmtLine(rnd *rand.Rand, ts time.Time) string {
+	return fmt.Sprintf("ts=%s lvl=%s msg=%q service=%s trace_id=%s user_id=%d elapsed=%.3fms", ts.Format(time.RFC3339Nano),
+		strings.ToLower(pick(rnd, levels)), pick(rnd, messages), pick(rnd, services), hexID(rnd, 16), rnd.Intn(100000), rnd.Float64()*1000)
+}
+
+func accessLine(rnd *rand.Rand, ts time.Time) string {
+	return fmt.Sprintf("%s - - [%s] \"%s %s HTTP/1.1\" %d %d \"-\" \"%s\"", ip(rnd), ts.Format("02/Jan/2006:15:04:05 -0700"),
+		pick(rnd, methods), pick(rnd, paths), statuses[rnd.Intn(len(statuses))], rnd.Intn(100000), pick(rnd, agents))
+}
+
+func jsonLine(rnd *rand.Rand, ts time.Time) string {
+	return fmt.Sprintf(`{"timestamp":"%s","level":"%s","service":"%s","message":"%s","request_id":"%s-%s-%s","status":%d,"latency_ms":%d}`,
+		ts.Format(time.RFC3339Nano), strings.ToLower(pick(rnd, levels)), pick(rnd, services), pick(rnd, messages),
+		hexID(rnd, 8), hexID(rnd, 4), hexID(rnd, 12), statuses[rnd.Intn(len(statuses))], rnd.Intn(2000))
+}
+
+func stackLine(rnd *rand.Rand, ts time.Time) string {
+	return fmt.Sprintf("panic: %s\n\ngoroutine %d [running]:\nmain.(*Server).handle(0xc%09x)\n\t/app/server.go:%d +0x%x\nnet/http.HandlerFunc.ServeHTTP(...)\n\t/usr/local/go/src/net/http/server.go:%d",
+		pick(rnd, messages), rnd.Intn(1000), rnd.Int63n(1<<36), rnd.Intn(500), rnd.Intn(0x400), 2000+rnd.Intn(200))
+}
+
+func stateValue(rnd *rand.Rand, _ time.Time) string {
+	switch rnd.Intn(3) {
+	case 0:
+		return pick(rnd, states)
+	case 1:
+		return fmt.Sprintf("%s-%s-%s-%s-%s", hexID(rnd, 8), hexID(rnd, 4), hexID(rnd, 4), hexID(rnd, 4), hexID(rnd, 12))
+	default:
+		return fmt.Sprintf("%s-%d.%s.svc.cluster.local", pick(rnd, services), rnd.Intn(20), pick(rnd, []string{"default", "prod", "staging"}))
+	}
+}
@@ -0,0 +1,116 @@
+package tsm1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// Verify checks the integrity of every TSM file in the engine. See FileStore.Verify.
+func (e *Engine) Verify(ctx context.Context, rate limiter.Rate) (tsdb.VerifyStats, error) {
+	return e.FileStore.Verify(ctx, rate)
+}
+
+// Verify re-reads every TSM file currently loaded, validating the checksum of
+// each block and the ordering of the file's index. Files are referenced for
+// the duration of the check so compactions can proceed without removing them
+// underneath the reader. If rate is non-nil, reads are throttled to it.
+//
+// The first corrupt file found is returned as a *tsdb.CorruptionError.
+func (f *FileStore) Verify(ctx context.Context, rate limiter.Rate) (tsdb.VerifyStats, error) {
+	f.mu.RLock()
+	files := make([]TSMFile, len(f.files))
+	copy(files, f.files)
+	for _, r := range files {
+		r.Ref()
+	}
+	f.mu.RUnlock()
+
+	defer func() {
+		for _, r := range files {
+			r.Unref()
+		}
+	}()
+
+	var stats tsdb.VerifyStats
+	for _, r := range files {
+		s, err := verifyTSMFile(ctx, r, rate)
+		stats.Add(s)
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// verifyTSMFile checks the blocks and index of a single TSM file.
+func verifyTSMFile(ctx context.Context, r TSMFile, rate limiter.Rate) (tsdb.VerifyStats, error) {
+	stats := tsdb.VerifyStats{Files: 1}
+	corrupt := func(format string, a ...interface{}) error {
+		return &tsdb.CorruptionError{Path: r.Path(), Reason: fmt.Sprintf(format, a...)}
+	}
+
+	var prevKey []byte
+	itr := r.BlockIterator()
+	for itr.Next() {
+		select {
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		default:
+		}
+
+		key, minTime, maxTime, _, checksum, buf, err := itr.Read()
+		if err != nil {
+			return stats, corrupt("unable to read block %d for key %q: %v", stats.Blocks, key, err)
+		}
+
+		if err := waitN(ctx, rate, len(buf)); err != nil {
+			return stats, err
+		}
+		block := stats.Blocks
+		stats.Blocks++
+		stats.Bytes += int64(len(buf))
+
+		if exp := crc32.ChecksumIEEE(buf); checksum != exp {
+			return stats, corrupt("checksum mismatch for key %q block %d: got %d, exp %d", key, block, checksum, exp)
+		} else if len(buf) == 0 {
+			return stats, corrupt("empty block for key %q", key)
+		} else if _, err := BlockType(buf); err != nil {
+			return stats, corrupt("key %q: %v", key, err)
+		} else if minTime > maxTime {
+			return stats, corrupt("key %q has min time %d after max time %d", key, minTime, maxTime)
+		} else if prevKey != nil && bytes.Compare(prevKey, key) > 0 {
+			return stats, corrupt("index key %q out of order after %q", key, prevKey)
+		}
+		prevKey = append(prevKey[:0], key...)
+	}
+
+	// An iterator error here means the file was modified while it was being
+	// read, not that it is corrupt. The caller can retry later.
+	if err := itr.Err(); err != nil {
+		return stats, fmt.Errorf("verify %s: %w", r.Path(), err)
+	}
+	return stats, nil
+}
+
+// waitN blocks until rate allows n bytes to be read.
+func waitN(ctx context.Context, rate limiter.Rate, n int) error {
+	if rate == nil {
+		return nil
+	}
+	for n > 0 {
+		wait := n
+		if burst := rate.Burst(); wait > burst {
+			wait = burst
+		}
+		if err := rate.WaitN(ctx, wait); err != nil {
+			return err
+		}
+		n -= wait
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package tsm1_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+)
+
+func TestFileStore_Verify(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	data := []keyValues{
+		keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(1, 2.0)}},
+		keyValues{"mem", []tsm1.Value{tsm1.NewValue(0, 1.0)}},
+	}
+	if _, err := newFileDir(dir, data...); err != nil {
+		fatal(t, "creating test files", err)
+	}
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	stats, err := fs.Verify(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if stats.Files != 2 || stats.Blocks != 2 || stats.Bytes == 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestFileStore_Verify_Corrupt(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	files, err := newFileDir(dir, keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0), tsm1.NewValue(1, 2.0)}})
+	if err != nil {
+		fatal(t, "creating test files", err)
+	}
+
+	// Flip a byte in the first block, past the file header and block checksum.
+	f, err := os.OpenFile(files[0], os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1)
+	if _, err := f.ReadAt(buf, 12); err != nil {
+		t.Fatal(err)
+	}
+	buf[0] ^= 0xff
+	if _, err := f.WriteAt(buf, 12); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	_, err = fs.Verify(context.Background(), nil)
+	if !tsdb.IsCorruptionError(err) {
+		t.Fatalf("expected corruption error, got %v", err)
+	} else if e := err.(*tsdb.CorruptionError); e.Path != files[0] {
+		t.Fatalf("unexpected path: got %s, exp %s", e.Path, files[0])
+	}
+}
+
+func TestFileStore_Verify_Cancelled(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	if _, err := newFileDir(dir, keyValues{"cpu", []tsm1.Value{tsm1.NewValue(0, 1.0)}}); err != nil {
+		fatal(t, "creating test files", err)
+	}
+
+	fs := tsm1.NewFileStore(dir)
+	if err := fs.Open(); err != nil {
+		fatal(t, "opening file store", err)
+	}
+	defer fs.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fs.Verify(ctx, nil); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
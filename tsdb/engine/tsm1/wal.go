@@ -21,6 +21,7 @@ import (
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/pkg/pool"
+	"github.com/influxdata/influxdb/tsdb"
 	"go.uber.org/zap"
 )
 
@@ -111,6 +112,10 @@ type WAL struct {
 	// SegmentSize is the file size at which a segment file will be rotated
 	SegmentSize int
 
+	// retain is the position from whose segment removed segments are kept
+	// for replication. The zero position keeps none.
+	retain tsdb.WALPosition
+
 	// statistics for the WAL
 	stats   *WALStatistics
 	limiter limiter.Fixed
@@ -196,6 +201,18 @@ func (l *WAL) Open() error {
 		return err
 	}
 
+	// Segments kept for replication are not replayed, but their ids are not
+	// reused.
+	retained, err := l.retainedSegmentNames()
+	if err != nil {
+		return err
+	}
+	if len(retained) > 0 {
+		if l.currentSegmentID, err = idFromFileName(retained[len(retained)-1]); err != nil {
+			return err
+		}
+	}
+
 	if len(segments) > 0 {
 		lastSegment := segments[len(segments)-1]
 		id, err := idFromFileName(lastSegment)
@@ -236,7 +253,10 @@ func (l *WAL) Open() error {
 		}
 
 		if stat.Size() > 0 {
-			totalOldDiskSize += stat.Size()
+			// The current segment is accounted for separately.
+			if l.currentSegmentWriter == nil || seg != l.currentSegmentWriter.path() {
+				totalOldDiskSize += stat.Size()
+			}
 			if stat.ModTime().After(l.lastWriteTime) {
 				l.lastWriteTime = stat.ModTime().UTC()
 			}
@@ -360,6 +380,12 @@ func (l *WAL) Remove(files []string) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	for _, fn := range files {
+		if l.retainSegment(fn) {
+			l.traceLogger.Info("Keeping WAL file for replication", zap.String("path", fn))
+			if err := l.moveToRetained(fn); err == nil {
+				continue
+			}
+		}
 		l.traceLogger.Info("Removing WAL file", zap.String("path", fn))
 		os.RemoveAll(fn)
 	}
@@ -370,8 +396,17 @@ func (l *WAL) Remove(files []string) error {
 		return err
 	}
 
+	var currentFile string
+	if l.currentSegmentWriter != nil {
+		currentFile = l.currentSegmentWriter.path()
+	}
+
 	var totalOldDiskSize int64
 	for _, seg := range segments {
+		if seg == currentFile {
+			continue
+		}
+
 		stat, err := os.Stat(seg)
 		if err != nil {
 			return err
@@ -565,7 +600,7 @@ func (l *WAL) newSegmentFile() error {
 		if err := l.currentSegmentWriter.close(); err != nil {
 			return err
 		}
-		atomic.StoreInt64(&l.stats.OldBytes, int64(l.currentSegmentWriter.size))
+		atomic.AddInt64(&l.stats.OldBytes, int64(l.currentSegmentWriter.size))
 	}
 
 	fileName := filepath.Join(l.path, fmt.Sprintf("%s%05d.%s", WALFilePrefix, l.currentSegmentID, WALFileExtension))
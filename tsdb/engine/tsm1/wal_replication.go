@@ -0,0 +1,356 @@
+package tsm1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// ReplicationDirectory is the directory under a shard's WAL directory that
+// holds segments kept for replication after their writes were snapshotted.
+const ReplicationDirectory = "replication"
+
+// walEntryHeaderSize is the size of the type and length preceding each
+// entry of a WAL segment.
+const walEntryHeaderSize = 5
+
+// walSegment is a WAL segment file and its id.
+type walSegment struct {
+	id   int
+	path string
+}
+
+// retainedSegmentNames returns the segments kept for replication in sorted
+// order by ascending ID.
+func (l *WAL) retainedSegmentNames() ([]string, error) {
+	return segmentFileNames(filepath.Join(l.path, ReplicationDirectory))
+}
+
+// retainSegment returns true if the segment fn is to be kept for replication
+// rather than removed. l.mu must be held.
+func (l *WAL) retainSegment(fn string) bool {
+	if l.retain.IsZero() {
+		return false
+	}
+	id, err := idFromFileName(fn)
+	return err == nil && id >= l.retain.Segment
+}
+
+// moveToRetained moves the segment fn to the replication directory.
+func (l *WAL) moveToRetained(fn string) error {
+	dir := filepath.Join(l.path, ReplicationDirectory)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	return os.Rename(fn, filepath.Join(dir, filepath.Base(fn)))
+}
+
+// SetRetention keeps segments from the segment of pos when they are removed,
+// and removes the kept segments before it. The zero position stops keeping
+// segments and removes those kept.
+func (l *WAL) SetRetention(pos tsdb.WALPosition) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.retain = pos
+
+	retained, err := l.retainedSegmentNames()
+	if err != nil {
+		return err
+	}
+	for _, fn := range retained {
+		if l.retainSegment(fn) {
+			continue
+		}
+		l.traceLogger.Info("Removing retained WAL file", zap.String("path", fn))
+		if err := os.Remove(fn); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// segments returns the retained and current segments in sorted order by
+// ascending ID.
+func (l *WAL) segments() ([]walSegment, error) {
+	retained, err := l.retainedSegmentNames()
+	if err != nil {
+		return nil, err
+	}
+	current, err := segmentFileNames(l.path)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]walSegment, 0, len(retained)+len(current))
+	for _, fn := range append(retained, current...) {
+		id, err := idFromFileName(fn)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, walSegment{id: id, path: fn})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].id < segments[j].id })
+	return segments, nil
+}
+
+// End returns the position following the last entry written to the WAL.
+func (l *WAL) End() tsdb.WALPosition {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.currentSegmentWriter == nil {
+		return tsdb.WALPosition{Segment: l.currentSegmentID}
+	}
+	return tsdb.WALPosition{Segment: l.currentSegmentID, Offset: int64(l.currentSegmentWriter.size)}
+}
+
+// BytesSince returns the number of bytes written to the WAL after pos,
+// including segments that are no longer available.
+func (l *WAL) BytesSince(pos tsdb.WALPosition) int64 {
+	segments, err := l.segments()
+	if err != nil {
+		return 0
+	}
+
+	var n int64
+	for _, seg := range segments {
+		if seg.id < pos.Segment {
+			continue
+		}
+		stat, err := os.Stat(seg.path)
+		if err != nil {
+			continue
+		}
+		n += stat.Size()
+		if seg.id == pos.Segment {
+			n -= pos.Offset
+		}
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Read returns the complete entries of the WAL from pos, up to about limit
+// bytes, in the format they are written to segments, and the position
+// following them. The zero position reads from the oldest segment. Reading
+// moves on to the next segment at the end of a closed one.
+func (l *WAL) Read(pos tsdb.WALPosition, limit int) ([]byte, tsdb.WALPosition, error) {
+	// A segment may be moved to the replication directory while it is read,
+	// so look it up again if it is not found.
+	for i := 0; ; i++ {
+		b, next, err := l.read(pos, limit)
+		if os.IsNotExist(err) && i < 2 {
+			continue
+		}
+		return b, next, err
+	}
+}
+
+func (l *WAL) read(pos tsdb.WALPosition, limit int) ([]byte, tsdb.WALPosition, error) {
+	segments, err := l.segments()
+	if err != nil {
+		return nil, pos, err
+	} else if len(segments) == 0 {
+		return nil, pos, nil
+	}
+
+	if pos.IsZero() {
+		pos = tsdb.WALPosition{Segment: segments[0].id}
+	} else if pos.Segment < segments[0].id {
+		return nil, pos, tsdb.ErrWALPositionRemoved
+	}
+
+	var buf []byte
+	for i, seg := range segments {
+		if seg.id < pos.Segment {
+			continue
+		} else if seg.id > pos.Segment {
+			// The segment of pos was removed without being kept.
+			if i > 0 && segments[i-1].id < pos.Segment {
+				return nil, pos, tsdb.ErrWALPositionRemoved
+			}
+			pos = tsdb.WALPosition{Segment: seg.id}
+		}
+
+		n, err := readWALEntries(seg.path, pos.Offset, limit-len(buf), &buf)
+		if err != nil {
+			return nil, pos, err
+		}
+		pos.Offset += n
+
+		// Only a closed segment is followed by another, so the rest of
+		// the entries are in the next one.
+		if len(buf) >= limit || i == len(segments)-1 {
+			break
+		}
+		pos = tsdb.WALPosition{Segment: segments[i+1].id}
+	}
+	return buf, pos, nil
+}
+
+// readWALEntries appends the complete entries of the segment at path from
+// offset to buf, up to about limit bytes, and returns the number of bytes
+// appended.
+func readWALEntries(path string, offset int64, limit int, buf *[]byte) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	var hdr [walEntryHeaderSize]byte
+	for int(n) < limit {
+		if _, err := io.ReadFull(f, hdr[:]); err != nil {
+			// The entry is not completely written yet.
+			break
+		}
+		length := int(binary.BigEndian.Uint32(hdr[1:]))
+		start := len(*buf)
+		*buf = append(*buf, hdr[:]...)
+		*buf = append(*buf, make([]byte, length)...)
+		if _, err := io.ReadFull(f, (*buf)[start+walEntryHeaderSize:]); err != nil {
+			*buf = (*buf)[:start]
+			break
+		}
+		n += int64(walEntryHeaderSize + length)
+	}
+	return n, nil
+}
+
+// decodeWALEntries decodes the entries of b, in the format they are written
+// to segments.
+func decodeWALEntries(b []byte) ([]WALEntry, error) {
+	var entries []WALEntry
+	for len(b) > 0 {
+		if len(b) < walEntryHeaderSize {
+			return nil, fmt.Errorf("short WAL entry header: %d bytes", len(b))
+		}
+		typ, length := WalEntryType(b[0]), int(binary.BigEndian.Uint32(b[1:walEntryHeaderSize]))
+		b = b[walEntryHeaderSize:]
+		if len(b) < length {
+			return nil, fmt.Errorf("short WAL entry: got %d bytes, exp %d", len(b), length)
+		}
+
+		data, err := snappy.Decode(nil, b[:length])
+		if err != nil {
+			return nil, err
+		}
+		b = b[length:]
+
+		var entry WALEntry
+		switch typ {
+		case WriteWALEntryType:
+			entry = &WriteWALEntry{Values: make(map[string][]Value)}
+		case DeleteWALEntryType:
+			entry = &DeleteWALEntry{}
+		case DeleteRangeWALEntryType:
+			entry = &DeleteRangeWALEntry{}
+		default:
+			return nil, fmt.Errorf("unknown wal entry type: %v", typ)
+		}
+		if err := entry.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ReadWAL returns the complete entries of the engine's WAL from pos, up to
+// about limit bytes, and the position following them.
+func (e *Engine) ReadWAL(pos tsdb.WALPosition, limit int) ([]byte, tsdb.WALPosition, error) {
+	if !e.WALEnabled {
+		return nil, pos, tsdb.ErrWALDisabled
+	}
+	return e.WAL.Read(pos, limit)
+}
+
+// WALEnd returns the position following the last entry written to the
+// engine's WAL.
+func (e *Engine) WALEnd() tsdb.WALPosition {
+	if !e.WALEnabled {
+		return tsdb.WALPosition{}
+	}
+	return e.WAL.End()
+}
+
+// WALBytesSince returns the number of bytes written to the engine's WAL after
+// pos.
+func (e *Engine) WALBytesSince(pos tsdb.WALPosition) int64 {
+	if !e.WALEnabled {
+		return 0
+	}
+	return e.WAL.BytesSince(pos)
+}
+
+// SetWALRetention keeps the segments of the engine's WAL from the segment of
+// pos after their writes are snapshotted. Writes spilled while the cache is
+// full are not written to the WAL.
+func (e *Engine) SetWALRetention(pos tsdb.WALPosition) error {
+	if !e.WALEnabled {
+		return tsdb.ErrWALDisabled
+	}
+	return e.WAL.SetRetention(pos)
+}
+
+// DecodeWAL returns the points written by the WAL entries b, read from the
+// WAL of an engine with ReadWAL. Deletes are skipped, as they are executed on
+// every owner of a shard.
+func (e *Engine) DecodeWAL(b []byte) ([]models.Point, error) {
+	entries, err := decodeWALEntries(b)
+	if err != nil {
+		return nil, err
+	}
+
+	type seriesTime struct {
+		series string
+		time   int64
+	}
+	fields := make(map[seriesTime]models.Fields)
+	var order []seriesTime
+	for _, entry := range entries {
+		w, ok := entry.(*WriteWALEntry)
+		if !ok {
+			continue
+		}
+		for k, values := range w.Values {
+			series, field := SeriesAndFieldFromCompositeKey([]byte(k))
+			for _, v := range values {
+				st := seriesTime{series: string(series), time: v.UnixNano()}
+				f, ok := fields[st]
+				if !ok {
+					f = make(models.Fields)
+					fields[st] = f
+					order = append(order, st)
+				}
+				f[string(field)] = v.Value()
+			}
+		}
+	}
+
+	points := make([]models.Point, 0, len(order))
+	for _, st := range order {
+		name, tags := models.ParseKeyBytes([]byte(st.series))
+		pt, err := models.NewPoint(string(name), tags, fields[st], time.Unix(0, st.time))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+	}
+	return points, nil
+}
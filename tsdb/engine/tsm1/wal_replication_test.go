@@ -0,0 +1,112 @@
+package tsm1_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/engine/tsm1"
+	"github.com/influxdata/influxdb/tsdb/index/inmem"
+)
+
+func TestWAL_Read(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	w := tsm1.NewWAL(dir)
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	defer w.Close()
+
+	write := func(ts int64) {
+		if _, err := w.WriteMulti(map[string][]tsm1.Value{
+			"cpu,host=A#!~#value": {tsm1.NewValue(ts, float64(ts))},
+		}); err != nil {
+			t.Fatalf("error writing points: %v", err)
+		}
+	}
+
+	write(1)
+	b, pos, err := w.Read(tsdb.WALPosition{}, 1<<20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(b) == 0 {
+		t.Fatal("expected entries")
+	} else if got, exp := pos, w.End(); got != exp {
+		t.Fatalf("position mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Nothing more to read at the end.
+	if b, next, err := w.Read(pos, 1<<20); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(b) != 0 || next != pos {
+		t.Fatalf("unexpected read: %d bytes to %v", len(b), next)
+	}
+
+	// Segments are kept once retention is set, and read across.
+	if err := w.SetRetention(pos); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.CloseSegment(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	write(2)
+	closed, err := w.ClosedSegments()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := w.Remove(closed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, tsm1.ReplicationDirectory, filepath.Base(closed[0]))); err != nil {
+		t.Fatalf("segment not kept: %v", err)
+	}
+
+	b, next, err := w.Read(tsdb.WALPosition{Segment: pos.Segment}, 1<<20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if got, exp := next, w.End(); got != exp {
+		t.Fatalf("position mismatch: got %v, exp %v", got, exp)
+	} else if got, exp := int64(len(b)), w.BytesSince(tsdb.WALPosition{Segment: pos.Segment}); got != exp {
+		t.Fatalf("bytes mismatch: got %v, exp %v", got, exp)
+	}
+
+	// Kept segments before the retention position are removed.
+	if err := w.SetRetention(next); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := w.Read(pos, 1<<20); err != tsdb.ErrWALPositionRemoved {
+		t.Fatalf("unexpected error: got %v, exp %v", err, tsdb.ErrWALPositionRemoved)
+	}
+}
+
+func TestEngine_DecodeWAL(t *testing.T) {
+	e := MustOpenEngine(inmem.IndexName)
+	defer e.Close()
+
+	e.CreateSeriesIfNotExists([]byte("cpu,host=A"), []byte("cpu"), nil)
+	if err := e.WritePoints(MustParsePointsString("cpu,host=A value=1.5,count=2i 1000000000\ncpu,host=A value=2.5 2000000000")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, _, err := e.ReadWAL(tsdb.WALPosition{}, 1<<20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	points, err := e.DecodeWAL(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, p := range points {
+		got[p.String()] = true
+	}
+	for _, exp := range []string{"cpu,host=A count=2i,value=1.5 1000000000", "cpu,host=A value=2.5 2000000000"} {
+		if !got[exp] {
+			t.Fatalf("point %q not decoded: got %v", exp, points)
+		}
+	}
+}
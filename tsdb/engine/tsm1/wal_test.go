@@ -506,6 +506,76 @@ func TestWAL_ClosedSegments(t *testing.T) {
 	}
 }
 
+func TestWAL_DiskSizeBytes(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	// diskSize returns the size of the segments on disk.
+	diskSize := func() int64 {
+		t.Helper()
+		var n int64
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range entries {
+			fi, err := e.Info()
+			if err != nil {
+				t.Fatal(err)
+			}
+			n += fi.Size()
+		}
+		return n
+	}
+
+	w := tsm1.NewWAL(dir)
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+
+	// Write to three segments, closing the first two.
+	for i := 0; i < 3; i++ {
+		if _, err := w.WriteMulti(map[string][]tsm1.Value{
+			"cpu,host=A#!~#value": []tsm1.Value{tsm1.NewValue(int64(i), 1.1)},
+		}); err != nil {
+			t.Fatalf("error writing points: %v", err)
+		}
+		if i < 2 {
+			if err := w.CloseSegment(); err != nil {
+				t.Fatalf("error closing segment: %v", err)
+			}
+		}
+	}
+	if got, exp := w.DiskSizeBytes(), diskSize(); got != exp {
+		t.Fatalf("unexpected disk size: got %d, exp %d", got, exp)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("error closing wal: %v", err)
+	}
+
+	// Re-open the WAL, the current segment must not be counted twice.
+	w = tsm1.NewWAL(dir)
+	defer w.Close()
+	if err := w.Open(); err != nil {
+		t.Fatalf("error opening WAL: %v", err)
+	}
+	if got, exp := w.DiskSizeBytes(), diskSize(); got != exp {
+		t.Fatalf("unexpected disk size after reopen: got %d, exp %d", got, exp)
+	}
+
+	files, err := w.ClosedSegments()
+	if err != nil {
+		t.Fatalf("error getting closed segments: %v", err)
+	}
+	if err := w.Remove(files); err != nil {
+		t.Fatalf("error removing segments: %v", err)
+	}
+	if got, exp := w.DiskSizeBytes(), diskSize(); got != exp {
+		t.Fatalf("unexpected disk size after remove: got %d, exp %d", got, exp)
+	}
+}
+
 func TestWAL_Delete(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
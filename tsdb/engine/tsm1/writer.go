@@ -83,6 +83,10 @@ const (
 	// Version indicates the version of the TSM file format.
 	Version byte = 1
 
+	// Version2 is the version of TSM files written with BlockEncodingV2. They
+	// may hold blocks in the encodings of both block encoding versions.
+	Version2 byte = 2
+
 	// Size in bytes of an index entry
 	indexEntrySize = 28
 
@@ -578,7 +582,7 @@ func NewTSMWriterWithDiskBuffer(w io.Writer) (TSMWriter, error) {
 func (t *tsmWriter) writeHeader() error {
 	var buf [5]byte
 	binary.BigEndian.PutUint32(buf[0:4], MagicNumber)
-	buf[4] = Version
+	buf[4] = fileVersion()
 
 	n, err := t.w.Write(buf[:])
 	if err != nil {
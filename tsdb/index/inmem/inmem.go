@@ -523,6 +523,18 @@ func (i *Index) TagKeyCardinality(name, key []byte) int {
 	return mm.CardinalityBytes(key)
 }
 
+// MeasurementSeriesN returns the number of series of a measurement.
+func (i *Index) MeasurementSeriesN(name []byte) int {
+	i.mu.RLock()
+	mm := i.measurements[string(name)]
+	i.mu.RUnlock()
+
+	if mm == nil {
+		return 0
+	}
+	return mm.SeriesN()
+}
+
 // TagsForSeries returns the tag map for the passed in series
 func (i *Index) TagsForSeries(key string) (models.Tags, error) {
 	i.mu.RLock()
@@ -246,6 +246,13 @@ func (m *measurement) HasSeries() bool {
 	return len(m.seriesByID) > 0
 }
 
+// SeriesN returns the number of series of the measurement.
+func (m *measurement) SeriesN() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.seriesByID)
+}
+
 // CardinalityBytes returns the number of values associated with the given tag key.
 func (m *measurement) CardinalityBytes(key []byte) int {
 	m.mu.RLock()
@@ -1,6 +1,8 @@
 package tsi1
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -581,6 +583,72 @@ func (i *Index) MeasurementSeriesIDIterator(name []byte) (tsdb.SeriesIDIterator,
 	return tsdb.MergeSeriesIDIterators(itrs...), nil
 }
 
+// Verify checks that every series referenced by the index resolves to a key in
+// the series file that belongs to the measurement it is indexed under.
+func (i *Index) Verify(ctx context.Context) error {
+	mitr, err := i.MeasurementIterator()
+	if err != nil {
+		return err
+	} else if mitr == nil {
+		return nil
+	}
+	defer mitr.Close()
+
+	for {
+		name, err := mitr.Next()
+		if err != nil {
+			return err
+		} else if name == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if err := i.verifyMeasurement(name); err != nil {
+			return err
+		}
+	}
+}
+
+// verifyMeasurement checks the series indexed under a single measurement.
+func (i *Index) verifyMeasurement(name []byte) error {
+	sitr, err := i.MeasurementSeriesIDIterator(name)
+	if err != nil {
+		return err
+	} else if sitr == nil {
+		return nil
+	}
+	defer sitr.Close()
+
+	for {
+		e, err := sitr.Next()
+		if err != nil {
+			return err
+		} else if e.SeriesID == 0 {
+			return nil
+		} else if i.sfile.IsDeleted(e.SeriesID) {
+			continue
+		}
+
+		key := i.sfile.SeriesKey(e.SeriesID)
+		if key == nil {
+			return &tsdb.CorruptionError{
+				Path:   i.path,
+				Reason: fmt.Sprintf("measurement %q references unknown series id %d", name, e.SeriesID),
+			}
+		} else if mname, _ := tsdb.ParseSeriesKey(key); !bytes.Equal(mname, name) {
+			return &tsdb.CorruptionError{
+				Path:   i.path,
+				Reason: fmt.Sprintf("series id %d indexed under %q belongs to %q", e.SeriesID, name, mname),
+			}
+		}
+	}
+}
+
 // MeasurementNamesByRegex returns measurement names for the provided regex.
 func (i *Index) MeasurementNamesByRegex(re *regexp.Regexp) ([][]byte, error) {
 	return i.fetchByteValues(func(idx int) ([][]byte, error) {
@@ -0,0 +1,312 @@
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/file"
+	"go.uber.org/zap"
+)
+
+// States of an online index rebuild.
+const (
+	IndexRebuildRunning = "running"
+	IndexRebuildDone    = "done"
+	IndexRebuildFailed  = "failed"
+)
+
+// Directories, relative to the shard, that hold the index being rebuilt and
+// the index it replaces while the two are swapped.
+const (
+	indexRebuildDir = "index.rebuild"
+	indexOldDir     = "index.old"
+)
+
+var (
+	// ErrIndexRebuildInProgress is returned when rebuilding the index of a
+	// shard that is already being rebuilt.
+	ErrIndexRebuildInProgress = errors.New("index rebuild already in progress")
+)
+
+// IndexRebuildStatus is the progress or outcome of the most recent online
+// index rebuild of a shard.
+type IndexRebuildStatus struct {
+	// State is one of the IndexRebuild constants, or empty if the index has
+	// never been rebuilt.
+	State string
+
+	// SeriesN is the number of series keys added to the new index so far.
+	SeriesN int64
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// Err holds the error that stopped a failed rebuild.
+	Err error
+}
+
+// indexRebuild is a tsi1 index being built alongside a shard's live index.
+type indexRebuild struct {
+	index    Index
+	path     string
+	seriesN  int64 // atomic
+	modified bool  // set when series may have been deleted during the build
+	err      error // set when a write could not be added to the new index
+}
+
+// RebuildIndex builds a new tsi1 index for the shard from its TSM files,
+// cache and series file, and swaps it in for the current index. The shard
+// remains readable and writable while the index is built; series created in
+// the meantime are added to both indexes. The swap itself happens under the
+// shard lock, so queries and writes wait for it rather than fail.
+//
+// Shards using the inmem index are converted to tsi1. If series are deleted
+// during the build, ErrShardModified is returned and the current index is
+// kept. Progress and the outcome are available from IndexRebuildStatus.
+func (s *Shard) RebuildIndex(ctx context.Context) (err error) {
+	r, err := s.startIndexRebuild()
+	if err != nil {
+		return err
+	}
+	defer func() { s.finishIndexRebuild(r, err) }()
+
+	engine, err := s.Engine()
+	if err != nil {
+		return err
+	}
+	if err := engine.BuildIndex(ctx, r.index, func(n int) {
+		atomic.AddInt64(&r.seriesN, int64(n))
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.swapIndexNoLock(r)
+}
+
+// IndexRebuildStatus returns the progress of a running index rebuild, or the
+// outcome of the last one to complete.
+func (s *Shard) IndexRebuildStatus() IndexRebuildStatus {
+	s.rebuildMu.Lock()
+	defer s.rebuildMu.Unlock()
+	status := s.rebuildStatus
+	if s.rebuild != nil {
+		status.SeriesN = atomic.LoadInt64(&s.rebuild.seriesN)
+	}
+	return status
+}
+
+// startIndexRebuild creates and opens an empty tsi1 index next to the
+// shard's current index and registers it to receive new series.
+func (s *Shard) startIndexRebuild() (*indexRebuild, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.ready(); err != nil {
+		return nil, err
+	}
+
+	s.rebuildMu.Lock()
+	defer s.rebuildMu.Unlock()
+	if s.rebuild != nil {
+		return nil, ErrIndexRebuildInProgress
+	}
+
+	path := filepath.Join(s.path, indexRebuildDir)
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	}
+
+	opt := s.options
+	opt.IndexVersion = TSI1IndexName
+	idx, err := NewIndex(s.id, s.database, path, NewSeriesIDSet(), s.sfile, opt)
+	if err != nil {
+		return nil, err
+	}
+	idx.WithLogger(s.baseLogger)
+	if err := idx.Open(); err != nil {
+		os.RemoveAll(path)
+		return nil, err
+	}
+
+	s.rebuild = &indexRebuild{index: idx, path: path}
+	s.rebuildStatus = IndexRebuildStatus{State: IndexRebuildRunning, StartedAt: time.Now().UTC()}
+	s.logger.Info("Rebuilding index", logger.Shard(s.id), zap.String("path", path))
+	return s.rebuild, nil
+}
+
+// finishIndexRebuild discards r if it was not swapped in and records the
+// outcome of the rebuild.
+func (s *Shard) finishIndexRebuild(r *indexRebuild, err error) {
+	s.mu.Lock()
+	s.rebuildMu.Lock()
+	s.rebuild = nil
+	s.rebuildMu.Unlock()
+	if r.index != nil {
+		r.index.Close()
+	}
+	os.RemoveAll(r.path)
+	s.mu.Unlock()
+
+	status := IndexRebuildStatus{
+		State:      IndexRebuildDone,
+		SeriesN:    atomic.LoadInt64(&r.seriesN),
+		FinishedAt: time.Now().UTC(),
+		Err:        err,
+	}
+	if err != nil {
+		status.State = IndexRebuildFailed
+		s.logger.Warn("Index rebuild failed", logger.Shard(s.id), zap.Error(err))
+	} else {
+		s.logger.Info("Index rebuilt", logger.Shard(s.id), zap.Int64("series", status.SeriesN))
+	}
+
+	s.rebuildMu.Lock()
+	status.StartedAt = s.rebuildStatus.StartedAt
+	s.rebuildStatus = status
+	s.rebuildMu.Unlock()
+}
+
+// swapIndexNoLock closes the shard, replaces its index with the one built by
+// r and reopens it. If the shard cannot be reopened with the new index, the
+// old index is put back. Must hold s.mu before calling.
+func (s *Shard) swapIndexNoLock(r *indexRebuild) error {
+	s.rebuildMu.Lock()
+	s.rebuild = nil
+	modified, err := r.modified, r.err
+	s.rebuildMu.Unlock()
+
+	if modified {
+		return ErrShardModified
+	} else if err != nil {
+		return err
+	}
+
+	err = r.index.Close()
+	r.index = nil
+	if err != nil {
+		return err
+	}
+
+	enabled := s.enabled
+	if err := s.closeNoLock(); err != nil {
+		return err
+	}
+	defer func(enableOnOpen bool) { s.EnableOnOpen = enableOnOpen }(s.EnableOnOpen)
+	s.EnableOnOpen = enabled
+
+	ipath := filepath.Join(s.path, "index")
+	opath := filepath.Join(s.path, indexOldDir)
+	if err := os.RemoveAll(opath); err != nil {
+		return err
+	}
+
+	// Shards using the inmem index have no index directory to keep.
+	_, statErr := os.Stat(ipath)
+	hasOld := statErr == nil
+	if hasOld {
+		if err := file.RenameFile(ipath, opath); err != nil {
+			return err
+		}
+	}
+
+	err = file.RenameFile(r.path, ipath)
+	if err == nil {
+		if err = s.openNoLock(); err == nil {
+			if rmErr := os.RemoveAll(opath); rmErr != nil {
+				s.logger.Warn("Unable to remove replaced index", logger.Shard(s.id), zap.String("path", opath), zap.Error(rmErr))
+			}
+			return nil
+		}
+	}
+
+	// Put the old index back and reopen the shard with it.
+	os.RemoveAll(ipath)
+	if hasOld {
+		if rnErr := file.RenameFile(opath, ipath); rnErr != nil {
+			s.logger.Error("Unable to restore index after failed rebuild", logger.Shard(s.id), zap.Error(rnErr))
+		}
+	}
+	if openErr := s.openNoLock(); openErr != nil {
+		s.logger.Error("Unable to reopen shard after failed index rebuild", logger.Shard(s.id), zap.Error(openErr))
+	}
+	return err
+}
+
+// addIndexRebuildSeries adds series being created by a write to the index
+// being rebuilt, if any. Must hold s.mu for reading before calling.
+func (s *Shard) addIndexRebuildSeries(keys, names [][]byte, tags []models.Tags) {
+	s.rebuildMu.Lock()
+	r := s.rebuild
+	s.rebuildMu.Unlock()
+	if r == nil {
+		return
+	}
+
+	if err := r.index.CreateSeriesListIfNotExists(keys, names, tags); err != nil {
+		s.rebuildMu.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		s.rebuildMu.Unlock()
+	}
+}
+
+// markIndexRebuildModified records that series may be deleted from the
+// shard, which invalidates an index rebuild in progress.
+func (s *Shard) markIndexRebuildModified() {
+	s.rebuildMu.Lock()
+	if s.rebuild != nil {
+		s.rebuild.modified = true
+	}
+	s.rebuildMu.Unlock()
+}
+
+// recoverIndexRebuild restores the previous index of a shard whose index
+// swap was interrupted after the old index was moved aside.
+func recoverIndexRebuild(path string) error {
+	ipath := filepath.Join(path, "index")
+	opath := filepath.Join(path, indexOldDir)
+	if _, err := os.Stat(opath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(ipath); os.IsNotExist(err) {
+		return file.RenameFile(opath, ipath)
+	} else if err != nil {
+		return err
+	}
+	return os.RemoveAll(opath)
+}
+
+// RebuildShardIndex rebuilds the index of a shard while it stays online. See
+// Shard.RebuildIndex.
+func (s *Store) RebuildShardIndex(ctx context.Context, id uint64) error {
+	sh := s.Shard(id)
+	if sh == nil {
+		return ErrShardNotFound
+	} else if sh.Tier() == TierRemote {
+		return ErrShardOffloaded
+	}
+
+	indexType := sh.IndexType()
+	if err := sh.RebuildIndex(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state := s.databases[sh.database]; state != nil && s.shards[id] == sh {
+		state.removeIndexType(indexType)
+		state.addIndexType(sh.IndexType())
+	}
+	return nil
+}
@@ -0,0 +1,555 @@
+package tsdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/pkg/file"
+	"go.uber.org/zap"
+)
+
+// OffloadManifestFile is the name of the file that replaces the contents of a
+// shard directory once the shard has been offloaded to object storage.
+const OffloadManifestFile = "offload.json"
+
+var (
+	// ErrObjectStoreNotConfigured is returned when offloading or fetching a
+	// shard without object storage.
+	ErrObjectStoreNotConfigured = errors.New("object storage not configured")
+
+	// ErrShardOffloaded is returned for operations that cannot be applied to
+	// a shard stored in object storage.
+	ErrShardOffloaded = errors.New("shard is offloaded to object storage")
+)
+
+// ObjectStore stores the bundles of offloaded shards.
+type ObjectStore interface {
+	Upload(ctx context.Context, key string, r io.Reader) (int64, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// OffloadManifest describes a shard bundle stored in object storage.
+type OffloadManifest struct {
+	Key         string    `json:"key"`
+	BasePath    string    `json:"basePath"`
+	Size        int64     `json:"size"`
+	OffloadedAt time.Time `json:"offloadedAt"`
+}
+
+// readOffloadManifest reads the manifest in a shard directory.
+func readOffloadManifest(dir string) (OffloadManifest, error) {
+	var m OffloadManifest
+	b, err := os.ReadFile(filepath.Join(dir, OffloadManifestFile))
+	if err != nil {
+		return m, err
+	}
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
+
+// writeOffloadManifest atomically writes the manifest to a shard directory.
+func writeOffloadManifest(dir string, m OffloadManifest) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, OffloadManifestFile+".tmp")
+	if err := os.WriteFile(tmp, b, 0666); err != nil {
+		return err
+	} else if err := file.RenameFile(tmp, filepath.Join(dir, OffloadManifestFile)); err != nil {
+		return err
+	}
+	return file.SyncDir(dir)
+}
+
+// offloadedShard tracks a shard whose data is in object storage.
+type offloadedShard struct {
+	id              uint64
+	database        string
+	retentionPolicy string
+	path            string // directory holding the manifest
+	walPath         string
+
+	// lastAccess is the time, in nanoseconds, the shard was last accessed.
+	lastAccess int64
+
+	// fetchMu serializes fetches and evictions of the shard.
+	fetchMu sync.Mutex
+
+	// The fields below are protected by Store.mu.
+	manifest OffloadManifest
+
+	// cached is the shard fetched into the offload cache, if any.
+	cached *Shard
+
+	// modified is the cached shard's last modified time after it was
+	// fetched. The cached shard is uploaded again before being evicted if
+	// it has changed.
+	modified time.Time
+}
+
+func (o *offloadedShard) touch() { atomic.StoreInt64(&o.lastAccess, time.Now().UnixNano()) }
+
+func (o *offloadedShard) accessed() int64 { return atomic.LoadInt64(&o.lastAccess) }
+
+// offloadCachePath returns the directory a shard is fetched into.
+func (s *Store) offloadCachePath(database, retentionPolicy string, id uint64) string {
+	return filepath.Join(s.EngineOptions.Config.OffloadCacheDir, database, retentionPolicy, strconv.FormatUint(id, 10))
+}
+
+// loadOffloadedShard returns the offloaded shard with its manifest in path,
+// or nil if the shard is not offloaded. The data of an offload that was
+// interrupted after the manifest was written is removed.
+func (s *Store) loadOffloadedShard(database, retentionPolicy string, id uint64, path string) (*offloadedShard, error) {
+	m, err := readOffloadManifest(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading offload manifest for shard %d: %w", id, err)
+	}
+	if err := removeOffloadedData(path); err != nil {
+		return nil, err
+	}
+	return &offloadedShard{
+		id:              id,
+		database:        database,
+		retentionPolicy: retentionPolicy,
+		path:            path,
+		walPath:         filepath.Join(s.EngineOptions.Config.WALDir, database, retentionPolicy, strconv.FormatUint(id, 10)),
+		manifest:        m,
+	}, nil
+}
+
+// loadShard returns a shard by id, fetching it from object storage if it has
+// been offloaded. It must not be called with s.mu held.
+func (s *Store) loadShard(id uint64) (*Shard, error) {
+	s.mu.RLock()
+	sh, o := s.shards[id], s.offloaded[id]
+	s.mu.RUnlock()
+
+	if o != nil {
+		o.touch()
+		if sh == nil {
+			return s.fetchShard(o)
+		}
+	}
+	if sh == nil {
+		return nil, ErrShardNotFound
+	}
+	return sh, nil
+}
+
+// OffloadedShardIDs returns the IDs of the shards offloaded to object storage,
+// including those currently fetched into the offload cache.
+func (s *Store) OffloadedShardIDs() []uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	a := make([]uint64, 0, len(s.offloaded))
+	for id := range s.offloaded {
+		a = append(a, id)
+	}
+	return a
+}
+
+// OffloadShard uploads a shard to object storage and replaces its local data
+// with a manifest. The shard's series remain in the database's series file.
+//
+// Once offloaded, the shard is fetched into the offload cache the next time
+// it is accessed. If the shard is written to during the upload,
+// ErrShardModified is returned and the shard is left in place.
+func (s *Store) OffloadShard(ctx context.Context, id uint64) error {
+	if s.ObjectStore == nil {
+		return ErrObjectStoreNotConfigured
+	}
+
+	s.mu.RLock()
+	sh, o := s.shards[id], s.offloaded[id]
+	s.mu.RUnlock()
+	if o != nil {
+		return nil
+	} else if sh == nil {
+		return ErrShardNotFound
+	}
+
+	name := strconv.FormatUint(id, 10)
+	m := OffloadManifest{
+		Key:      path.Join(sh.database, sh.retentionPolicy, name+".tar"),
+		BasePath: filepath.Join(sh.database, sh.retentionPolicy, name),
+	}
+	size, modified, err := s.uploadShard(ctx, sh, m)
+	if err != nil {
+		return err
+	}
+	m.Size = size
+	m.OffloadedAt = time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shards[id] != sh {
+		return ErrShardNotFound
+	} else if !sh.LastModified().Equal(modified) {
+		return ErrShardModified
+	}
+
+	indexType := sh.IndexType()
+	if err := sh.Close(); err != nil {
+		return err
+	}
+
+	// The manifest is written before the data is removed so an interrupted
+	// offload is completed when the store is next opened.
+	if err := writeOffloadManifest(sh.path, m); err != nil {
+		if openErr := s.OpenShard(sh, true); openErr != nil {
+			s.Logger.Error("Unable to reopen shard after failed offload", logger.Shard(id), zap.Error(openErr))
+		}
+		return err
+	}
+	if err := removeOffloadedData(sh.path); err != nil {
+		s.Logger.Warn("Unable to remove offloaded shard data", logger.Shard(id), zap.String("path", sh.path), zap.Error(err))
+	} else if err := os.RemoveAll(sh.walPath); err != nil {
+		s.Logger.Warn("Unable to remove offloaded shard WAL", logger.Shard(id), zap.String("path", sh.walPath), zap.Error(err))
+	}
+
+	delete(s.shards, id)
+	if state := s.databases[sh.database]; state != nil {
+		state.removeIndexType(indexType)
+	}
+	s.offloaded[id] = &offloadedShard{
+		id:              id,
+		database:        sh.database,
+		retentionPolicy: sh.retentionPolicy,
+		path:            sh.path,
+		walPath:         sh.walPath,
+		manifest:        m,
+	}
+	return nil
+}
+
+// uploadShard streams a full backup of the shard to object storage. It
+// returns the size of the bundle and the shard's last modified time as of the
+// backup.
+func (s *Store) uploadShard(ctx context.Context, sh *Shard, m OffloadManifest) (int64, time.Time, error) {
+	pr, pw := io.Pipe()
+	var modified time.Time
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := sh.Backup(pw, m.BasePath, time.Time{})
+		modified = sh.LastModified()
+		pw.CloseWithError(err)
+	}()
+
+	size, err := s.ObjectStore.Upload(ctx, m.Key, pr)
+	// Unblock the backup if the upload stopped reading early.
+	pr.CloseWithError(err)
+	<-done
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return size, modified, nil
+}
+
+// removeOffloadedData removes everything in a shard directory except the
+// offload manifest.
+func removeOffloadedData(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Name() == OffloadManifestFile {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchShard restores an offloaded shard from object storage into the
+// offload cache and opens it, evicting other cached shards if the cache is
+// over its size limit.
+func (s *Store) fetchShard(o *offloadedShard) (*Shard, error) {
+	o.fetchMu.Lock()
+	defer o.fetchMu.Unlock()
+
+	s.mu.RLock()
+	sh, current, m := o.cached, s.offloaded[o.id] == o, o.manifest
+	s.mu.RUnlock()
+	if !current {
+		return nil, ErrShardNotFound
+	} else if sh != nil {
+		return sh, nil
+	} else if s.ObjectStore == nil {
+		return nil, ErrObjectStoreNotConfigured
+	}
+
+	start := time.Now()
+	cachePath := s.offloadCachePath(o.database, o.retentionPolicy, o.id)
+	if err := os.RemoveAll(cachePath); err != nil {
+		return nil, err
+	} else if err := os.MkdirAll(cachePath, 0700); err != nil {
+		return nil, err
+	}
+
+	sh, err := s.newOffloadCacheShard(o, cachePath)
+	if err == nil {
+		err = s.restoreShard(sh, m)
+	}
+	if err != nil {
+		if sh != nil {
+			sh.Close()
+		}
+		os.RemoveAll(cachePath)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.offloaded[o.id] != o {
+		// Deleted while it was being fetched.
+		s.mu.Unlock()
+		sh.Close()
+		os.RemoveAll(cachePath)
+		return nil, ErrShardNotFound
+	}
+	o.cached = sh
+	o.modified = sh.LastModified()
+	s.shards[o.id] = sh
+	if _, ok := s.databases[o.database]; !ok {
+		s.databases[o.database] = new(databaseState)
+	}
+	s.databases[o.database].addIndexType(sh.IndexType())
+	s.mu.Unlock()
+
+	s.Logger.Info("Fetched offloaded shard", logger.Database(o.database), logger.Shard(o.id),
+		zap.Int64("bytes", m.Size), logger.DurationLiteral("duration", time.Since(start)))
+
+	s.evictOffloadCache(o.id)
+	return sh, nil
+}
+
+// newOffloadCacheShard opens an empty shard in the offload cache.
+func (s *Store) newOffloadCacheShard(o *offloadedShard, path string) (*Shard, error) {
+	s.mu.Lock()
+	sfile, err := s.openSeriesFile(o.database)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	idx, err := s.createIndexIfNotExists(o.database)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.mu.Unlock()
+
+	opt := s.EngineOptions
+	opt.InmemIndex = idx
+	opt.SeriesIDSets = shardSet{store: s, db: o.database}
+
+	sh := NewShard(o.id, path, o.walPath, sfile, opt)
+	sh.WithLogger(s.baseLogger)
+	if err := s.OpenShard(sh, true); err != nil {
+		return nil, err
+	}
+	return sh, nil
+}
+
+// restoreShard restores the shard's bundle from object storage.
+func (s *Store) restoreShard(sh *Shard, m OffloadManifest) error {
+	rc, err := s.ObjectStore.Get(context.Background(), m.Key)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return sh.Restore(rc, m.BasePath)
+}
+
+// evictOffloadCache evicts the least recently accessed shards from the
+// offload cache until it is within its size limit. The shard with ID keep is
+// never evicted.
+func (s *Store) evictOffloadCache(keep uint64) {
+	limit := int64(s.EngineOptions.Config.OffloadCacheMaxSize)
+	if limit <= 0 {
+		return
+	}
+
+	for {
+		var total int64
+		var lru *offloadedShard
+		s.mu.RLock()
+		for _, o := range s.offloaded {
+			if o.cached == nil {
+				continue
+			}
+			size, _ := o.cached.DiskSize()
+			total += size
+			if o.id != keep && (lru == nil || o.accessed() < lru.accessed()) {
+				lru = o
+			}
+		}
+		s.mu.RUnlock()
+
+		if total <= limit || lru == nil {
+			return
+		}
+		if err := s.evictShard(lru); err != nil {
+			s.Logger.Warn("Unable to evict shard from offload cache", logger.Shard(lru.id), zap.Error(err))
+			return
+		}
+	}
+}
+
+// evictShard closes a shard in the offload cache and removes its files. A
+// shard that changed since it was fetched is uploaded again first.
+func (s *Store) evictShard(o *offloadedShard) error {
+	o.fetchMu.Lock()
+	defer o.fetchMu.Unlock()
+
+	s.mu.RLock()
+	sh, modified, m := o.cached, o.modified, o.manifest
+	s.mu.RUnlock()
+	if sh == nil {
+		return nil
+	}
+
+	if !sh.LastModified().Equal(modified) {
+		size, uploaded, err := s.uploadShard(context.Background(), sh, m)
+		if err != nil {
+			return err
+		}
+		m.Size = size
+		m.OffloadedAt = time.Now().UTC()
+		if err := writeOffloadManifest(o.path, m); err != nil {
+			return err
+		}
+		modified = uploaded
+	}
+
+	s.mu.Lock()
+	if o.cached != sh {
+		s.mu.Unlock()
+		return nil
+	} else if !sh.LastModified().Equal(modified) {
+		s.mu.Unlock()
+		return ErrShardModified
+	}
+	indexType := sh.IndexType()
+	delete(s.shards, o.id)
+	if state := s.databases[o.database]; state != nil {
+		state.removeIndexType(indexType)
+	}
+	o.cached = nil
+	o.manifest = m
+	s.mu.Unlock()
+
+	if err := sh.Close(); err != nil {
+		return err
+	}
+	return os.RemoveAll(sh.path)
+}
+
+// deleteOffloadedShard removes an offloaded shard's bundle from object
+// storage, along with its manifest and any cached copy. Series that exist
+// only in the offloaded shard are left in the series file.
+func (s *Store) deleteOffloadedShard(o *offloadedShard) error {
+	o.fetchMu.Lock()
+	defer o.fetchMu.Unlock()
+
+	s.mu.RLock()
+	m := o.manifest
+	s.mu.RUnlock()
+
+	if s.ObjectStore == nil {
+		return ErrObjectStoreNotConfigured
+	} else if err := s.ObjectStore.Delete(context.Background(), m.Key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.offloaded[o.id] != o {
+		s.mu.Unlock()
+		return nil
+	}
+	delete(s.offloaded, o.id)
+	epoch := s.epochs[o.id]
+	delete(s.epochs, o.id)
+	sh := o.cached
+	if sh != nil {
+		indexType := sh.IndexType()
+		delete(s.shards, o.id)
+		if state := s.databases[o.database]; state != nil {
+			state.removeIndexType(indexType)
+		}
+		o.cached = nil
+	}
+	s.mu.Unlock()
+
+	if sh != nil {
+		// Wait for writes in progress before closing the cached shard.
+		if epoch != nil {
+			guards, gen := epoch.StartWrite()
+			for _, guard := range guards {
+				guard.Wait()
+			}
+			epoch.EndWrite(gen)
+		}
+		if err := sh.Close(); err != nil {
+			return err
+		} else if err := os.RemoveAll(sh.path); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(o.path); err != nil {
+		return err
+	}
+	return os.RemoveAll(o.walPath)
+}
+
+// deleteOffloadedShards removes the bundles of the offloaded shards matching
+// fn from object storage and stops tracking them. Local files are left for
+// the caller to remove.
+func (s *Store) deleteOffloadedShards(fn func(o *offloadedShard) bool) error {
+	s.mu.RLock()
+	var offloaded []*offloadedShard
+	for _, o := range s.offloaded {
+		if fn(o) {
+			offloaded = append(offloaded, o)
+		}
+	}
+	s.mu.RUnlock()
+	if len(offloaded) == 0 {
+		return nil
+	} else if s.ObjectStore == nil {
+		return ErrObjectStoreNotConfigured
+	}
+
+	for _, o := range offloaded {
+		s.mu.RLock()
+		key := o.manifest.Key
+		s.mu.RUnlock()
+		if err := s.ObjectStore.Delete(context.Background(), key); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		delete(s.offloaded, o.id)
+		delete(s.epochs, o.id)
+		s.mu.Unlock()
+	}
+	return nil
+}
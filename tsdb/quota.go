@@ -0,0 +1,93 @@
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DiskQuotaExceededError is returned by writes to the shards of a database or
+// retention policy using more disk on this node than its hard quota.
+type DiskQuotaExceededError struct {
+	Database        string
+	RetentionPolicy string // empty for the quota of the database
+	Bytes           int64
+	Limit           int64
+}
+
+// Error returns a string representation of the error.
+func (e DiskQuotaExceededError) Error() string {
+	if e.RetentionPolicy != "" {
+		return fmt.Sprintf("disk quota exceeded: retention policy %q of database %q uses %d bytes, hard limit is %d bytes",
+			e.RetentionPolicy, e.Database, e.Bytes, e.Limit)
+	}
+	return fmt.Sprintf("disk quota exceeded: database %q uses %d bytes, hard limit is %d bytes",
+		e.Database, e.Bytes, e.Limit)
+}
+
+// IsDiskQuotaExceeded returns true if err is a DiskQuotaExceededError,
+// including one returned by another node and only known by its message.
+func IsDiskQuotaExceeded(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "disk quota exceeded")
+}
+
+// DiskUsage is the disk used by the shards of a database on this node.
+type DiskUsage struct {
+	Database          string           `json:"database"`
+	Bytes             int64            `json:"bytes"`
+	RetentionPolicies map[string]int64 `json:"retention-policies,omitempty"`
+}
+
+// DiskUsage returns the disk used by the shards of database on this node.
+// Shards offloaded to object storage only count while they are fetched.
+func (s *Store) DiskUsage(ctx context.Context, database string) (*DiskUsage, error) {
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	usage := &DiskUsage{Database: database, RetentionPolicies: make(map[string]int64)}
+	for _, sh := range shards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		size, err := sh.DiskSize()
+		if err == ErrEngineClosed {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("shard %d: %s", sh.id, err)
+		}
+		usage.Bytes += size
+		usage.RetentionPolicies[sh.retentionPolicy] += size
+	}
+	return usage, nil
+}
+
+// SetDiskQuotaExceeded replaces the quotas exceeded on this node. Writes to
+// the shards of their databases and retention policies are rejected with the
+// error until the quotas are cleared by the next call.
+func (s *Store) SetDiskQuotaExceeded(errs []DiskQuotaExceededError) {
+	exceeded := make(map[[2]string]DiskQuotaExceededError, len(errs))
+	for _, err := range errs {
+		exceeded[[2]string{err.Database, err.RetentionPolicy}] = err
+	}
+
+	s.quotaMu.Lock()
+	s.quotaExceeded = exceeded
+	s.quotaMu.Unlock()
+}
+
+// diskQuotaExceeded returns the error of the quota exceeded by the database
+// or retention policy, or nil.
+func (s *Store) diskQuotaExceeded(database, rp string) error {
+	s.quotaMu.RLock()
+	defer s.quotaMu.RUnlock()
+	if len(s.quotaExceeded) == 0 {
+		return nil
+	}
+	if err, ok := s.quotaExceeded[[2]string{database, ""}]; ok {
+		return err
+	} else if err, ok := s.quotaExceeded[[2]string{database, rp}]; ok {
+		return err
+	}
+	return nil
+}
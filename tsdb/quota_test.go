@@ -0,0 +1,55 @@
+package tsdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestStore_DiskQuota(t *testing.T) {
+	test := func(index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 100, `cpu value=1 0`)
+		s.MustCreateShardWithData("db0", "rp1", 101, `mem value=1 0`)
+		s.MustCreateShardWithData("db1", "rp0", 102, `cpu value=1 0`)
+
+		usage, err := s.DiskUsage(context.Background(), "db0")
+		if err != nil {
+			t.Fatal(err)
+		} else if usage.Bytes <= 0 || usage.Bytes != usage.RetentionPolicies["rp0"]+usage.RetentionPolicies["rp1"] {
+			t.Fatalf("unexpected usage: %+v", usage)
+		}
+
+		pts := []models.Point{models.MustNewPoint("cpu", nil, map[string]interface{}{"value": 2.0}, time.Unix(1, 0))}
+
+		// A retention policy over quota only rejects writes to its shards.
+		s.SetDiskQuotaExceeded([]tsdb.DiskQuotaExceededError{{Database: "db0", RetentionPolicy: "rp0", Bytes: 20, Limit: 10}})
+		if err := s.WriteToShard(100, pts); !tsdb.IsDiskQuotaExceeded(err) {
+			t.Fatalf("unexpected error: %v", err)
+		} else if err := s.WriteToShard(101, pts); err != nil {
+			t.Fatal(err)
+		}
+
+		// A database over quota rejects writes to all its shards.
+		s.SetDiskQuotaExceeded([]tsdb.DiskQuotaExceededError{{Database: "db0", Bytes: 20, Limit: 10}})
+		if err := s.WriteToShard(101, pts); !tsdb.IsDiskQuotaExceeded(err) {
+			t.Fatalf("unexpected error: %v", err)
+		} else if err := s.WriteToShard(102, pts); err != nil {
+			t.Fatal(err)
+		}
+
+		s.SetDiskQuotaExceeded(nil)
+		if err := s.WriteToShard(100, pts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(index) })
+	}
+}
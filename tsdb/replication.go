@@ -0,0 +1,223 @@
+package tsdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ReplicaFile is the file in a shard's WAL directory holding the leader and
+// the position of the leader's WAL applied to the shard.
+const ReplicaFile = "replica"
+
+var (
+	// ErrWALPositionRemoved is returned when reading a WAL from a position
+	// whose segment was already removed.
+	ErrWALPositionRemoved = errors.New("WAL position removed")
+
+	// ErrWALDisabled is returned when reading the WAL of a shard whose
+	// engine does not write one.
+	ErrWALDisabled = errors.New("WAL is disabled")
+)
+
+// WALPosition is a position in the WAL of a shard: the id of a segment and
+// the offset of an entry within it. The zero position is before the oldest
+// segment.
+type WALPosition struct {
+	Segment int   `json:"segment"`
+	Offset  int64 `json:"offset"`
+}
+
+// IsZero returns true if p is the zero position.
+func (p WALPosition) IsZero() bool {
+	return p.Segment == 0 && p.Offset == 0
+}
+
+// Less returns true if p is before other.
+func (p WALPosition) Less(other WALPosition) bool {
+	if p.Segment != other.Segment {
+		return p.Segment < other.Segment
+	}
+	return p.Offset < other.Offset
+}
+
+// String returns the position in the form "segment:offset".
+func (p WALPosition) String() string {
+	return fmt.Sprintf("%d:%d", p.Segment, p.Offset)
+}
+
+// ReplicaStatus describes the replication of a shard from the WAL of its
+// leader.
+type ReplicaStatus struct {
+	Leader      uint64      `json:"leader"`
+	Applied     WALPosition `json:"applied"`
+	LagBytes    int64       `json:"-"`
+	LastContact time.Time   `json:"-"`
+}
+
+// ReadWAL returns the complete WAL entries of the shard from pos, up to about
+// limit bytes, and the position following them. The zero position reads from
+// the oldest segment available. It returns ErrWALPositionRemoved if the
+// segment of pos was already removed.
+func (s *Shard) ReadWAL(pos WALPosition, limit int) ([]byte, WALPosition, error) {
+	engine, err := s.Engine()
+	if err != nil {
+		return nil, pos, err
+	}
+	return engine.ReadWAL(pos, limit)
+}
+
+// WALEnd returns the position following the last entry written to the WAL of
+// the shard.
+func (s *Shard) WALEnd() WALPosition {
+	engine, err := s.Engine()
+	if err != nil {
+		return WALPosition{}
+	}
+	return engine.WALEnd()
+}
+
+// WALBytesSince returns the number of bytes written to the WAL of the shard
+// after pos.
+func (s *Shard) WALBytesSince(pos WALPosition) int64 {
+	engine, err := s.Engine()
+	if err != nil {
+		return 0
+	}
+	return engine.WALBytesSince(pos)
+}
+
+// SetWALRetention keeps the WAL segments of the shard from the segment of pos
+// after their writes are snapshotted, so they can still be read. The zero
+// position stops keeping segments and removes those kept.
+func (s *Shard) SetWALRetention(pos WALPosition) error {
+	engine, err := s.Engine()
+	if err != nil {
+		return err
+	}
+	return engine.SetWALRetention(pos)
+}
+
+// ApplyWAL applies WAL entries shipped by the leader of a shard, like
+// Shard.ApplyWAL. The entries are applied within the shard's epoch tracker,
+// so that they are ordered with deletes and paused by database snapshots.
+func (s *Store) ApplyWAL(shardID, leaderID uint64, start, next WALPosition, lagBytes int64, b []byte) (WALPosition, error) {
+	s.mu.RLock()
+	sh, epoch := s.shards[shardID], s.epochs[shardID]
+	s.mu.RUnlock()
+	if sh == nil {
+		return WALPosition{}, ErrShardNotFound
+	}
+
+	guards, gen := epoch.StartWrite()
+	defer epoch.EndWrite(gen)
+
+	// The entries are not decoded yet, so wait for every guard.
+	for _, guard := range guards {
+		guard.Wait()
+	}
+	return sh.ApplyWAL(leaderID, start, next, lagBytes, b)
+}
+
+// ApplyWAL writes WAL entries b read by the shard's leader from start up to
+// next. The entries are applied only if start is the position last applied
+// from the same leader, the zero position or the first entries from the
+// leader; otherwise nothing is written and the leader is expected to resend
+// from the returned position. Entries with no data and a zero next position
+// only report the applied position. lagBytes is the number of bytes the
+// leader has written after next.
+func (s *Shard) ApplyWAL(leaderID uint64, start, next WALPosition, lagBytes int64, b []byte) (WALPosition, error) {
+	engine, err := s.Engine()
+	if err != nil {
+		return WALPosition{}, err
+	}
+
+	s.replicaMu.Lock()
+	defer s.replicaMu.Unlock()
+	if err := s.loadReplicaNoLock(); err != nil {
+		return WALPosition{}, err
+	}
+	r := s.replica
+	if r.Leader != leaderID {
+		// Positions in the WAL of another leader are meaningless.
+		r.Leader, r.Applied = leaderID, WALPosition{}
+	}
+	r.LastContact = time.Now().UTC()
+	r.LagBytes = lagBytes
+
+	if len(b) == 0 && next.IsZero() {
+		return r.Applied, nil
+	} else if !start.IsZero() && !r.Applied.IsZero() && start != r.Applied {
+		return r.Applied, nil
+	}
+
+	if len(b) > 0 {
+		points, err := engine.DecodeWAL(b)
+		if err != nil {
+			return r.Applied, err
+		}
+		if err := s.WritePoints(points); err != nil {
+			var partial PartialWriteError
+			if !errors.As(err, &partial) {
+				return r.Applied, err
+			}
+		}
+	}
+
+	if r.Applied == next {
+		return r.Applied, nil
+	}
+	r.Applied = next
+	return r.Applied, s.saveReplicaNoLock()
+}
+
+// ReplicaStatus returns the replication of the shard from the WAL of its
+// leader, or nil if nothing was replicated to the shard.
+func (s *Shard) ReplicaStatus() *ReplicaStatus {
+	s.replicaMu.Lock()
+	defer s.replicaMu.Unlock()
+	if err := s.loadReplicaNoLock(); err != nil || s.replica.Leader == 0 {
+		return nil
+	}
+	r := *s.replica
+	return &r
+}
+
+// loadReplicaNoLock reads the ReplicaFile of the shard, if it was not read.
+// s.replicaMu must be held.
+func (s *Shard) loadReplicaNoLock() error {
+	if s.replica != nil {
+		return nil
+	}
+
+	r := &ReplicaStatus{}
+	buf, err := os.ReadFile(filepath.Join(s.walPath, ReplicaFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	} else if err == nil {
+		if err := json.Unmarshal(buf, r); err != nil {
+			return fmt.Errorf("reading %s: %w", ReplicaFile, err)
+		}
+	}
+	s.replica = r
+	return nil
+}
+
+// saveReplicaNoLock writes the ReplicaFile of the shard. s.replicaMu must be
+// held.
+func (s *Shard) saveReplicaNoLock() error {
+	buf, err := json.Marshal(s.replica)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.walPath, ReplicaFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,87 @@
+package tsdb_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/index/inmem"
+)
+
+func TestShard_ApplyWAL(t *testing.T) {
+	tmpDir, _ := os.MkdirTemp("", "shard_test")
+	defer os.RemoveAll(tmpDir)
+
+	sfile := MustOpenSeriesFile()
+	defer sfile.Close()
+
+	newShard := func(name string) *tsdb.Shard {
+		opts := tsdb.NewEngineOptions()
+		opts.Config.WALDir = filepath.Join(tmpDir, name, "wal")
+		opts.InmemIndex = inmem.NewIndex(name, sfile.SeriesFile)
+		sh := tsdb.NewShard(1, filepath.Join(tmpDir, name, "shard"), filepath.Join(tmpDir, name, "wal"), sfile.SeriesFile, opts)
+		if err := sh.Open(); err != nil {
+			t.Fatalf("error opening shard: %s", err)
+		}
+		return sh
+	}
+	leader, follower := newShard("leader"), newShard("follower")
+	defer leader.Close()
+
+	if err := leader.WritePoints([]models.Point{models.MustNewPoint(
+		"cpu",
+		models.Tags{{Key: []byte("host"), Value: []byte("server")}},
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, next, err := leader.ReadWAL(tsdb.WALPosition{}, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	} else if next != leader.WALEnd() {
+		t.Fatalf("unexpected position: got %v, exp %v", next, leader.WALEnd())
+	}
+
+	// A probe reports the applied position without writing.
+	if applied, err := follower.ApplyWAL(2, tsdb.WALPosition{}, tsdb.WALPosition{}, 0, nil); err != nil {
+		t.Fatal(err)
+	} else if !applied.IsZero() {
+		t.Fatalf("unexpected applied position: %v", applied)
+	}
+
+	start := tsdb.WALPosition{Segment: next.Segment}
+	if applied, err := follower.ApplyWAL(2, start, next, 0, b); err != nil {
+		t.Fatal(err)
+	} else if applied != next {
+		t.Fatalf("unexpected applied position: got %v, exp %v", applied, next)
+	} else if n := follower.SeriesN(); n != 1 {
+		t.Fatalf("unexpected series count: %d", n)
+	}
+
+	// Entries not following the applied position are not written.
+	if applied, err := follower.ApplyWAL(2, start, tsdb.WALPosition{Segment: next.Segment + 1}, 0, b); err != nil {
+		t.Fatal(err)
+	} else if applied != next {
+		t.Fatalf("unexpected applied position: got %v, exp %v", applied, next)
+	}
+
+	// The applied position is kept across restarts.
+	follower.Close()
+	follower = newShard("follower")
+	defer follower.Close()
+	if r := follower.ReplicaStatus(); r == nil || r.Leader != 2 || r.Applied != next {
+		t.Fatalf("unexpected replica status: %+v", r)
+	}
+
+	// Entries from another leader are applied from its own positions.
+	if applied, err := follower.ApplyWAL(3, tsdb.WALPosition{}, tsdb.WALPosition{}, 0, nil); err != nil {
+		t.Fatal(err)
+	} else if !applied.IsZero() {
+		t.Fatalf("unexpected applied position: %v", applied)
+	}
+}
@@ -0,0 +1,249 @@
+package tsdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/influxdb/pkg/file"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxql"
+)
+
+// Suffixes of the fields that hold the minimum, maximum and number of values
+// in each window of a rolled up numeric field. The field itself holds the
+// mean of each window.
+const (
+	RollupMinSuffix   = "_min"
+	RollupMaxSuffix   = "_max"
+	RollupCountSuffix = "_count"
+)
+
+// RollupFile is the name of the file in a shard directory that records the
+// interval the shard's data was rolled up to.
+const RollupFile = "rollup.json"
+
+// ErrShardRolledUp is returned when rolling up a shard to an interval that is
+// not a multiple of the interval it was already rolled up to.
+var ErrShardRolledUp = errors.New("shard already rolled up to an incompatible interval")
+
+type rollupState struct {
+	Interval   time.Duration `json:"interval"`
+	RolledUpAt time.Time     `json:"rolledUpAt"`
+}
+
+// RollupInterval returns the interval the shard's data was rolled up to, or
+// zero if it holds raw data.
+func (s *Shard) RollupInterval() time.Duration {
+	s.rollupMu.Lock()
+	defer s.rollupMu.Unlock()
+	if !s.rollupLoaded {
+		s.rollupInterval = s.readRollupInterval()
+		s.rollupLoaded = true
+	}
+	return s.rollupInterval
+}
+
+func (s *Shard) readRollupInterval() time.Duration {
+	b, err := os.ReadFile(filepath.Join(s.path, RollupFile))
+	if err != nil {
+		return 0
+	}
+	var st rollupState
+	if err := json.Unmarshal(b, &st); err != nil {
+		return 0
+	}
+	return st.Interval
+}
+
+// rollupCall returns the call to run on a rolled up shard in place of call.
+// The minimum and maximum of a field read its <field>_min and <field>_max
+// fields, and its count sums its <field>_count field. Other calls read the
+// mean of each window, as do all calls on a shard holding raw data. Sums and
+// means are weighted by the counts in createRollupIterator instead.
+func (s *Shard) rollupCall(measurement string, call *influxql.Call) *influxql.Call {
+	var name, suffix string
+	switch call.Name {
+	case "min":
+		name, suffix = "min", RollupMinSuffix
+	case "max":
+		name, suffix = "max", RollupMaxSuffix
+	case "count":
+		name, suffix = "sum", RollupCountSuffix
+	default:
+		return call
+	}
+	if len(call.Args) != 1 || s.RollupInterval() == 0 {
+		return call
+	}
+	ref, ok := call.Args[0].(*influxql.VarRef)
+	if !ok {
+		return call
+	}
+
+	mf := s.MeasurementFields([]byte(measurement))
+	if mf == nil {
+		return call
+	}
+	f := mf.Field(ref.Val + suffix)
+	if f == nil {
+		return call
+	}
+	return &influxql.Call{
+		Name: name,
+		Args: []influxql.Expr{&influxql.VarRef{Val: ref.Val + suffix, Type: f.Type}},
+	}
+}
+
+// createRollupIterator returns an iterator for a sum or mean of a field of a
+// rolled up shard, which weights the mean of each window by its
+// <field>_count field: sum() is the sum of mean*count and mean() is that sum
+// divided by the sum of the counts. It returns false for other calls, fields
+// without counts and shards holding raw data. As the means of integer fields
+// are rounded, so are their sums.
+func (s *Shard) createRollupIterator(ctx context.Context, engine Engine, measurement string, call *influxql.Call, opt query.IteratorOptions) (query.Iterator, bool, error) {
+	if call.Name != "sum" && call.Name != "mean" {
+		return nil, false, nil
+	} else if len(call.Args) != 1 || s.RollupInterval() == 0 {
+		return nil, false, nil
+	}
+	ref, ok := call.Args[0].(*influxql.VarRef)
+	if !ok {
+		return nil, false, nil
+	}
+
+	mf := s.MeasurementFields([]byte(measurement))
+	if mf == nil {
+		return nil, false, nil
+	}
+	f, count := mf.Field(ref.Val), mf.Field(ref.Val+RollupCountSuffix)
+	if f == nil || count == nil {
+		return nil, false, nil
+	}
+
+	// Read the means with their counts as an auxiliary field, and aggregate
+	// them with the call.
+	refOpt := opt
+	refOpt.Expr = &influxql.VarRef{Val: ref.Val, Type: f.Type}
+	refOpt.Aux = []influxql.VarRef{{Val: ref.Val + RollupCountSuffix, Type: count.Type}}
+	refOpt.Limit, refOpt.Offset = 0, 0
+	input, err := engine.CreateIterator(ctx, measurement, refOpt)
+	if err != nil || input == nil {
+		return nil, true, err
+	}
+	itr, err := query.NewCallIterator(&rollupWeightIterator{input: input, sum: call.Name == "sum"}, opt)
+	if err != nil {
+		input.Close()
+		return nil, true, err
+	}
+	return itr, true, nil
+}
+
+// rollupWeightIterator reads the mean of each window of a rolled up field,
+// with its count as the first auxiliary field. For a sum it returns the mean
+// multiplied by the count, and for a mean it returns the mean aggregated from
+// count points, which the mean reducer weights by.
+type rollupWeightIterator struct {
+	input query.Iterator
+	sum   bool
+}
+
+func (itr *rollupWeightIterator) Stats() query.IteratorStats { return itr.input.Stats() }
+func (itr *rollupWeightIterator) Close() error               { return itr.input.Close() }
+
+func (itr *rollupWeightIterator) Next() (*query.FloatPoint, error) {
+	for {
+		var p query.FloatPoint
+		switch input := itr.input.(type) {
+		case query.FloatIterator:
+			fp, err := input.Next()
+			if err != nil || fp == nil {
+				return nil, err
+			}
+			p = query.FloatPoint{Name: fp.Name, Tags: fp.Tags, Time: fp.Time, Value: fp.Value, Aux: fp.Aux, Nil: fp.Nil}
+		case query.IntegerIterator:
+			ip, err := input.Next()
+			if err != nil || ip == nil {
+				return nil, err
+			}
+			p = query.FloatPoint{Name: ip.Name, Tags: ip.Tags, Time: ip.Time, Value: float64(ip.Value), Aux: ip.Aux, Nil: ip.Nil}
+		case query.UnsignedIterator:
+			up, err := input.Next()
+			if err != nil || up == nil {
+				return nil, err
+			}
+			p = query.FloatPoint{Name: up.Name, Tags: up.Tags, Time: up.Time, Value: float64(up.Value), Aux: up.Aux, Nil: up.Nil}
+		default:
+			return nil, fmt.Errorf("unsupported rollup iterator type: %T", input)
+		}
+		if p.Nil {
+			continue
+		}
+
+		n := int64(1)
+		if len(p.Aux) > 0 {
+			if v, ok := p.Aux[0].(int64); ok && v > 0 {
+				n = v
+			}
+		}
+		p.Aux = nil
+		if itr.sum {
+			p.Value *= float64(n)
+		} else {
+			p.Aggregated = uint32(n)
+		}
+		return &p, nil
+	}
+}
+
+// Rollup downsamples the shard's data to one value per interval for each
+// series and field. Rolling up to the interval the shard already has, or a
+// smaller one, does nothing.
+func (s *Shard) Rollup(ctx context.Context, interval time.Duration) error {
+	previous := s.RollupInterval()
+	if previous >= interval {
+		return nil
+	} else if previous > 0 && interval%previous != 0 {
+		return ErrShardRolledUp
+	}
+
+	engine, err := s.Engine()
+	if err != nil {
+		return err
+	}
+	if err := engine.Rollup(ctx, interval, previous); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(rollupState{Interval: interval, RolledUpAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.path, RollupFile+".tmp")
+	if err := os.WriteFile(tmp, b, 0666); err != nil {
+		return err
+	} else if err := file.RenameFile(tmp, filepath.Join(s.path, RollupFile)); err != nil {
+		return err
+	}
+
+	s.rollupMu.Lock()
+	s.rollupInterval, s.rollupLoaded = interval, true
+	s.rollupMu.Unlock()
+	return nil
+}
+
+// RollupShard downsamples a shard's data to one value per interval for each
+// series and field. Offloaded shards are not rolled up.
+func (s *Store) RollupShard(ctx context.Context, id uint64, interval time.Duration) error {
+	sh := s.Shard(id)
+	if sh == nil {
+		return ErrShardNotFound
+	} else if sh.Tier() == TierRemote {
+		return ErrShardOffloaded
+	}
+	return sh.Rollup(ctx, interval)
+}
@@ -2,6 +2,7 @@ package tsdb
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -41,9 +42,29 @@ type SeriesFile struct {
 
 	refs sync.RWMutex // RWMutex to track references to the SeriesFile that are in use.
 
+	// createMu is held for reading while series are created and added to an
+	// index, and for writing while garbage collection looks for series that
+	// no index references.
+	createMu sync.RWMutex
+
 	Logger *zap.Logger
 }
 
+// SeriesGCStats counts the series and segment data removed, or that can be
+// removed, by series file garbage collection.
+type SeriesGCStats struct {
+	SeriesN int64 // Number of unreferenced series deleted.
+	EntryN  int64 // Number of segment entries removed.
+	Bytes   int64 // Number of segment bytes removed.
+}
+
+// Add accumulates the stats in other into s.
+func (s *SeriesGCStats) Add(other SeriesGCStats) {
+	s.SeriesN += other.SeriesN
+	s.EntryN += other.EntryN
+	s.Bytes += other.Bytes
+}
+
 // NewSeriesFile returns a new instance of SeriesFile.
 func NewSeriesFile(path string) *SeriesFile {
 	maxSnapshotConcurrency := runtime.GOMAXPROCS(0)
@@ -141,6 +162,17 @@ func (f *SeriesFile) Retain() func() {
 	return nop
 }
 
+// retainCreate prevents garbage collection from looking for unreferenced
+// series until the returned func is called. Hold it from creating series in
+// the file until they have been added to an index.
+func (f *SeriesFile) retainCreate() func() {
+	if f != nil {
+		f.createMu.RLock()
+		return f.createMu.RUnlock
+	}
+	return nop
+}
+
 // EnableCompactions allows compactions to run.
 func (f *SeriesFile) EnableCompactions() {
 	for _, p := range f.partitions {
@@ -173,6 +205,22 @@ func (f *SeriesFile) FileSize() (n int64, err error) {
 	return n, err
 }
 
+// Verify checks the integrity of every partition in the series file.
+// The first corrupt segment found is returned as a *CorruptionError.
+func (f *SeriesFile) Verify(ctx context.Context) (VerifyStats, error) {
+	defer f.Retain()()
+
+	var stats VerifyStats
+	for _, p := range f.partitions {
+		s, err := p.Verify(ctx)
+		stats.Add(s)
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
 // CreateSeriesListIfNotExists creates a list of series in bulk if they don't exist.
 // The returned ids slice returns IDs for every name+tags, creating new series IDs as needed.
 func (f *SeriesFile) CreateSeriesListIfNotExists(names [][]byte, tagsSlice []models.Tags) ([]uint64, error) {
@@ -203,6 +251,67 @@ func (f *SeriesFile) DeleteSeriesID(id uint64) error {
 	return p.DeleteSeriesID(id)
 }
 
+// DeleteSeriesIDs flags a list of series as permanently deleted.
+func (f *SeriesFile) DeleteSeriesIDs(ids []uint64) error {
+	byPartition := make([][]uint64, len(f.partitions))
+	for _, id := range ids {
+		i := f.SeriesIDPartitionID(id)
+		byPartition[i] = append(byPartition[i], id)
+	}
+	for i, p := range f.partitions {
+		if len(byPartition[i]) == 0 {
+			continue
+		}
+		if err := p.DeleteSeriesIDs(byPartition[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnreferencedSeriesIDs returns the series in the file that are neither
+// deleted nor in referenced.
+func (f *SeriesFile) UnreferencedSeriesIDs(referenced *SeriesIDSet) ([]uint64, error) {
+	var ids []uint64
+	for _, p := range f.partitions {
+		var err error
+		if ids, err = p.appendUnreferencedSeriesIDs(ids, referenced); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// Reclaimable returns the segment entries CompactSegments would remove: those
+// of deleted series and, if extra is not nil, of the series in extra.
+func (f *SeriesFile) Reclaimable(extra *SeriesIDSet) (SeriesGCStats, error) {
+	var stats SeriesGCStats
+	for _, p := range f.partitions {
+		s, err := p.Reclaimable(extra)
+		stats.Add(s)
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// CompactSegments removes the entries of deleted series from the segments of
+// every partition. See SeriesPartition.CompactSegments.
+func (f *SeriesFile) CompactSegments(ctx context.Context) (SeriesGCStats, error) {
+	defer f.Retain()()
+
+	var stats SeriesGCStats
+	for _, p := range f.partitions {
+		s, err := p.CompactSegments(ctx)
+		stats.Add(s)
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
 // IsDeleted returns true if the ID has been deleted before.
 func (f *SeriesFile) IsDeleted(id uint64) bool {
 	p := f.SeriesIDPartition(id)
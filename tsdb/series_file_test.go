@@ -2,13 +2,16 @@ package tsdb_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/tsdb"
 	"golang.org/x/sync/errgroup"
 )
@@ -225,6 +228,135 @@ func TestSeriesFile_DeleteSeriesID(t *testing.T) {
 	}
 }
 
+func TestSeriesFile_Verify(t *testing.T) {
+	sfile := MustOpenSeriesFile()
+	defer sfile.Close()
+
+	var mms [][]byte
+	var tagSets []models.Tags
+	for i := 0; i < 100; i++ {
+		mms = append(mms, []byte("cpu"))
+		tagSets = append(tagSets, models.NewTags(map[string]string{"region": fmt.Sprintf("r%d", i)}))
+	}
+
+	ids, err := sfile.CreateSeriesListIfNotExists(mms[:50], tagSets[:50])
+	if err != nil {
+		t.Fatal(err)
+	} else if err := sfile.DeleteSeriesID(ids[0]); err != nil {
+		t.Fatal(err)
+	} else if err := sfile.ForceCompact(); err != nil {
+		t.Fatal(err)
+	} else if _, err := sfile.CreateSeriesListIfNotExists(mms[50:], tagSets[50:]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Verify with entries in both the on-disk and in-memory index.
+	stats, err := sfile.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if got, exp := stats.Blocks, int64(101); got != exp {
+		t.Fatalf("unexpected entry count: got %d, exp %d", got, exp)
+	}
+
+	if err := sfile.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if _, err := sfile.Verify(context.Background()); err != nil {
+		t.Fatalf("unexpected error after reopen: %v", err)
+	}
+}
+
+func TestSeriesPartition_CompactSegments(t *testing.T) {
+	dir := t.TempDir()
+	p := tsdb.NewSeriesPartition(0, dir, limiter.NewFixed(1))
+	if err := p.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { p.Close() }()
+
+	// Use large keys so the first segment fills up.
+	padding := strings.Repeat("x", 4096)
+	var keys [][]byte
+	for i := 0; i < 1100; i++ {
+		tags := models.NewTags(map[string]string{"host": fmt.Sprintf("%04d%s", i, padding)})
+		keys = append(keys, tsdb.AppendSeriesKey(nil, []byte("cpu"), tags))
+	}
+	ids := make([]uint64, len(keys))
+	if err := p.CreateSeriesListIfNotExists(keys, make([]int, len(keys)), ids); err != nil {
+		t.Fatal(err)
+	} else if n := len(p.Segments()); n != 2 {
+		t.Fatalf("unexpected segment count: %d", n)
+	}
+
+	// Delete every other series in the first segment, and the last series.
+	var deleted []uint64
+	for i := 0; i < 500; i += 2 {
+		deleted = append(deleted, ids[i])
+	}
+	deleted = append(deleted, ids[len(ids)-1])
+	if err := p.DeleteSeriesIDs(deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tombstones and series in the active segment are not reclaimable.
+	if stats, err := p.Reclaimable(nil); err != nil {
+		t.Fatal(err)
+	} else if stats.EntryN != 250 {
+		t.Fatalf("unexpected reclaimable entries: %d", stats.EntryN)
+	} else if stats, err := p.Reclaimable(tsdb.NewSeriesIDSet(ids[1])); err != nil {
+		t.Fatal(err)
+	} else if stats.EntryN != 251 {
+		t.Fatalf("unexpected reclaimable entries with extra series: %d", stats.EntryN)
+	}
+
+	sz := p.Segments()[0].Size()
+	if stats, err := p.CompactSegments(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if stats.EntryN != 250 {
+		t.Fatalf("unexpected compacted entries: %d", stats.EntryN)
+	} else if fi, err := os.Stat(p.Segments()[0].Path()); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() >= sz {
+		t.Fatalf("expected segment to shrink from %d bytes, got %d", sz, fi.Size())
+	}
+
+	verify := func() {
+		t.Helper()
+		for i, id := range ids {
+			isDeleted := (i < 500 && i%2 == 0) || i == len(ids)-1
+			if got := p.IsDeleted(id); got != isDeleted {
+				t.Fatalf("IsDeleted(%d)=%v, want %v", id, got, isDeleted)
+			} else if isDeleted {
+				continue
+			}
+			if got := p.SeriesKey(id); !bytes.Equal(got, keys[i]) {
+				t.Fatalf("unexpected key for series %d", id)
+			} else if got := p.FindIDBySeriesKey(keys[i]); got != id {
+				t.Fatalf("FindIDBySeriesKey()=%d, want %d", got, id)
+			}
+		}
+	}
+	verify()
+
+	// Series ids are not reused after reopening, even though the last
+	// series was deleted.
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	p = tsdb.NewSeriesPartition(0, dir, limiter.NewFixed(1))
+	if err := p.Open(); err != nil {
+		t.Fatal(err)
+	}
+	verify()
+
+	key := tsdb.AppendSeriesKey(nil, []byte("mem"), nil)
+	id := make([]uint64, 1)
+	if err := p.CreateSeriesListIfNotExists([][]byte{key}, []int{0}, id); err != nil {
+		t.Fatal(err)
+	} else if id[0] <= ids[len(ids)-1] {
+		t.Fatalf("series id %d reused", id[0])
+	}
+}
+
 func TestSeriesFile_Compaction(t *testing.T) {
 	sfile := MustOpenSeriesFile()
 	defer sfile.Close()
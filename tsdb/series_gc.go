@@ -0,0 +1,170 @@
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/influxdb/models"
+	"go.uber.org/zap"
+)
+
+var (
+	// errSeriesGCSkipped is returned when the series referenced by a
+	// database's shards cannot all be determined.
+	errSeriesGCSkipped = errors.New("unreferenced series not collected")
+)
+
+// CollectSeries deletes the series of a database that are not referenced by
+// the index of any of its shards, then compacts the database's series file to
+// reclaim the space held by deleted series.
+//
+// Unreferenced series are only deleted if every shard of the database is open
+// and idle enough to be inspected. Otherwise only the compaction runs. Series
+// cannot be created in the database while unreferenced series are deleted.
+func (s *Store) CollectSeries(ctx context.Context, database string) (SeriesGCStats, error) {
+	var stats SeriesGCStats
+	sfile := s.seriesFile(database)
+	if sfile == nil {
+		return stats, nil
+	}
+
+	n, err := s.deleteUnreferencedSeries(database, sfile)
+	if errors.Is(err, errSeriesGCSkipped) {
+		s.Logger.Info("Skipping unreferenced series", zap.String("db", database), zap.Error(err))
+	} else if err != nil {
+		return stats, err
+	}
+	stats.SeriesN = int64(n)
+
+	compacted, err := sfile.CompactSegments(ctx)
+	stats.Add(compacted)
+	return stats, err
+}
+
+// deleteUnreferencedSeries deletes the series in sfile that no shard of the
+// database references and returns the number deleted.
+func (s *Store) deleteUnreferencedSeries(database string, sfile *SeriesFile) (int, error) {
+	// Find candidates while writes continue. A candidate may be referenced by
+	// the time creation is blocked, so the set is checked again below.
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	s.mu.RUnlock()
+
+	referenced, _, release, err := referencedSeriesIDs(shards)
+	if err != nil {
+		return 0, err
+	}
+	release()
+	candidates, err := sfile.UnreferencedSeriesIDs(referenced)
+	if err != nil || len(candidates) == 0 {
+		return 0, err
+	}
+
+	// Hold the store lock so shards are not added, moved or fetched, and block
+	// creation of series until the unreferenced ones are deleted.
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, o := range s.offloaded {
+		if o.database == database {
+			return 0, fmt.Errorf("%w: shard %d is offloaded", errSeriesGCSkipped, o.id)
+		}
+	}
+	shards = s.filterShards(byDatabase(database))
+
+	sfile.createMu.Lock()
+	defer sfile.createMu.Unlock()
+
+	// Shards stay read locked so that nothing, such as a restore, adds
+	// series to their indexes before the unreferenced ones are deleted.
+	referenced, inmem, release, err := referencedSeriesIDs(shards)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	ids := candidates[:0]
+	for _, id := range candidates {
+		if !referenced.Contains(id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// The inmem index is shared by the database's shards and may still
+	// hold the series even though no shard does.
+	if inmem != nil {
+		var keyBuf, name []byte
+		var tagsBuf models.Tags
+		for _, id := range ids {
+			skey := sfile.SeriesKey(id)
+			if skey == nil {
+				continue
+			}
+			name, tagsBuf = ParseSeriesKeyInto(skey, tagsBuf)
+			keyBuf = models.AppendMakeKey(keyBuf[:0], name, tagsBuf)
+			if err := inmem.DropSeriesGlobal(keyBuf); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := sfile.DeleteSeriesIDs(ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// referencedSeriesIDs returns the union of the series in the indexes of
+// shards, and the database's inmem index if the shards use one. The shards
+// are read locked until release is called. Shards that are closed, locked or
+// rebuilding their index are not waited for; an error wrapping
+// errSeriesGCSkipped is returned instead.
+func referencedSeriesIDs(shards []*Shard) (referenced *SeriesIDSet, inmem Index, release func(), err error) {
+	locked := make([]*Shard, 0, len(shards))
+	release = func() {
+		for _, sh := range locked {
+			sh.mu.RUnlock()
+		}
+	}
+
+	referenced = NewSeriesIDSet()
+	for _, sh := range shards {
+		// The shard lock must not be waited for while creation is blocked:
+		// writers hold it while waiting to create series.
+		if !sh.mu.TryRLock() {
+			release()
+			return nil, nil, nil, fmt.Errorf("%w: shard %d is busy", errSeriesGCSkipped, sh.id)
+		}
+		locked = append(locked, sh)
+
+		index, err := sh.referencedIndexNoLock()
+		if err != nil {
+			release()
+			return nil, nil, nil, fmt.Errorf("%w: shard %d: %s", errSeriesGCSkipped, sh.id, err)
+		}
+		referenced.Merge(index.SeriesIDSet())
+		if index.Type() == InmemIndexName {
+			inmem = index
+		}
+	}
+	return referenced, inmem, release, nil
+}
+
+// referencedIndexNoLock returns the shard's index if it holds every series the
+// shard references. Must hold s.mu for reading before calling.
+func (s *Shard) referencedIndexNoLock() (Index, error) {
+	if s._engine == nil {
+		return nil, ErrEngineClosed
+	}
+
+	// Series added to an index being rebuilt may be missing from the live one.
+	s.rebuildMu.Lock()
+	rebuilding := s.rebuild != nil
+	s.rebuildMu.Unlock()
+	if rebuilding {
+		return nil, ErrIndexRebuildInProgress
+	}
+	return s.index, nil
+}
@@ -1,6 +1,7 @@
 package tsdb
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/models"
 	errors2 "github.com/influxdata/influxdb/pkg/errors"
+	"github.com/influxdata/influxdb/pkg/file"
 	"github.com/influxdata/influxdb/pkg/limiter"
 	"github.com/influxdata/influxdb/pkg/rhh"
 	"go.uber.org/zap"
@@ -41,6 +43,10 @@ type SeriesPartition struct {
 	index    *SeriesIndex
 	seq      uint64 // series id sequence
 
+	// retired holds segments replaced by CompactSegments. Series keys read
+	// from them may still be in use, so they stay mapped until Close.
+	retired []*SeriesSegment
+
 	compacting          bool
 	compactionLimiter   limiter.Fixed
 	compactionsDisabled int
@@ -158,6 +164,13 @@ func (p *SeriesPartition) Close() (err error) {
 	}
 	p.segments = nil
 
+	for _, s := range p.retired {
+		if e := s.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	p.retired = nil
+
 	if p.index != nil {
 		if e := p.index.Close(); e != nil && err == nil {
 			err = e
@@ -195,6 +208,93 @@ func (p *SeriesPartition) FileSize() (n int64, err error) {
 	return n, err
 }
 
+// Verify checks that every entry in the partition's segments is well formed and
+// that the index maps each live series ID and key back to its entry. The
+// partition is read locked one segment at a time so writers are not blocked for
+// the whole pass.
+func (p *SeriesPartition) Verify(ctx context.Context) (VerifyStats, error) {
+	var stats VerifyStats
+
+	p.mu.RLock()
+	n := len(p.segments)
+	p.mu.RUnlock()
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			return stats, ctx.Err()
+		default:
+		}
+
+		s, err := p.verifySegment(i)
+		stats.Add(s)
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// verifySegment verifies the i-th segment of the partition.
+func (p *SeriesPartition) verifySegment(i int) (stats VerifyStats, err error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return stats, ErrSeriesPartitionClosed
+	}
+
+	segment := p.segments[i]
+	pos := uint32(SeriesSegmentHeaderSize)
+	corrupt := func(format string, a ...interface{}) error {
+		return &CorruptionError{
+			Path:   segment.Path(),
+			Reason: fmt.Sprintf("offset %d: %s", pos, fmt.Sprintf(format, a...)),
+		}
+	}
+
+	// A malformed entry can index past the end of the segment data.
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = corrupt("panic reading entry: %v", rec)
+		}
+	}()
+
+	stats.Files = 1
+	data := segment.Data()
+	for pos < uint32(segment.Size()) {
+		// A zero flag marks the end of the entries written to the segment.
+		if data[pos] == 0 {
+			break
+		}
+
+		flag, id, key, sz := ReadSeriesEntry(data[pos:segment.Size()])
+		switch flag {
+		case SeriesEntryInsertFlag:
+			ParseSeriesKey(key)
+			if p.index.IsDeleted(id) {
+				break
+			}
+			offset := JoinSeriesOffset(segment.ID(), pos)
+			if got := p.index.FindOffsetByID(id); got != offset {
+				return stats, corrupt("index offset for series id %d is %d, exp %d", id, got, offset)
+			} else if got := p.index.FindIDBySeriesKey(p.segments, key); got != id {
+				return stats, corrupt("index id for series key %q is %d, exp %d", key, got, id)
+			}
+		case SeriesEntryTombstoneFlag:
+			if !p.index.IsDeleted(id) {
+				return stats, corrupt("series id %d is tombstoned but not deleted in index", id)
+			}
+		default:
+			return stats, corrupt("invalid entry flag %d", data[pos])
+		}
+
+		stats.Blocks++
+		stats.Bytes += sz
+		pos += uint32(sz)
+	}
+	return stats, nil
+}
+
 // CreateSeriesListIfNotExists creates a list of series in bulk if they don't exist.
 // The ids parameter is modified to contain series IDs for all keys belonging to this partition.
 func (p *SeriesPartition) CreateSeriesListIfNotExists(keys [][]byte, keyPartitionIDs []int, ids []uint64) error {
@@ -347,6 +447,39 @@ func (p *SeriesPartition) DeleteSeriesID(id uint64) error {
 	return nil
 }
 
+// DeleteSeriesIDs flags a list of series as permanently deleted. Unlike calling
+// DeleteSeriesID for each id, the tombstones are synced to disk only once.
+func (p *SeriesPartition) DeleteSeriesIDs(ids []uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrSeriesPartitionClosed
+	}
+
+	deleted := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if p.index.IsDeleted(id) {
+			continue
+		}
+		if _, err := p.writeLogEntry(AppendSeriesEntry(nil, SeriesEntryTombstoneFlag, id, nil)); err != nil {
+			return err
+		}
+		deleted = append(deleted, id)
+	}
+
+	if segment := p.activeSegment(); segment != nil {
+		if err := segment.Flush(); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range deleted {
+		p.index.Delete(id)
+	}
+	return nil
+}
+
 // IsDeleted returns true if the ID has been deleted before.
 func (p *SeriesPartition) IsDeleted(id uint64) bool {
 	p.mu.RLock()
@@ -435,6 +568,195 @@ func (p *SeriesPartition) AppendSeriesIDs(a []uint64) []uint64 {
 	return a
 }
 
+// appendUnreferencedSeriesIDs adds to dst the series in the partition that are
+// neither deleted nor in referenced.
+func (p *SeriesPartition) appendUnreferencedSeriesIDs(dst []uint64, referenced *SeriesIDSet) ([]uint64, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return dst, ErrSeriesPartitionClosed
+	}
+
+	for _, segment := range p.segments {
+		segment.ForEachEntry(func(flag uint8, id uint64, _ int64, _ []byte) error {
+			if flag == SeriesEntryInsertFlag && !referenced.Contains(id) && !p.index.IsDeleted(id) {
+				dst = append(dst, id)
+			}
+			return nil
+		})
+	}
+	return dst, nil
+}
+
+// Reclaimable returns the entries CompactSegments would remove from the
+// partition: those of deleted series and, if extra is not nil, of the series
+// in extra. Entries in the active segment are not counted.
+func (p *SeriesPartition) Reclaimable(extra *SeriesIDSet) (SeriesGCStats, error) {
+	var stats SeriesGCStats
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return stats, ErrSeriesPartitionClosed
+	}
+
+	maxID := p.maxSeriesID()
+	for _, segment := range p.segments[:len(p.segments)-1] {
+		segment.ForEachEntry(func(flag uint8, id uint64, _ int64, key []byte) error {
+			if id == maxID {
+				return nil
+			} else if p.index.IsDeleted(id) || (extra != nil && extra.Contains(id)) {
+				stats.EntryN++
+				stats.Bytes += int64(SeriesEntryHeaderSize + len(key))
+			}
+			return nil
+		})
+	}
+	return stats, nil
+}
+
+// CompactSegments rewrites every segment but the active one without the
+// entries of deleted series and rebuilds the partition index over the
+// rewritten segments. The entries of the highest series id assigned are kept
+// so that ids are not reused after the partition is reopened.
+//
+// Segments are rewritten without holding the partition lock. Deleted series
+// stay deleted and new entries only go to the active segment, so the copies
+// are still current when they are swapped in.
+func (p *SeriesPartition) CompactSegments(ctx context.Context) (SeriesGCStats, error) {
+	var stats SeriesGCStats
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return stats, ErrSeriesPartitionClosed
+	} else if p.compacting || !p.compactionsEnabled() {
+		p.mu.Unlock()
+		return stats, nil
+	}
+	p.compacting = true
+	p.wg.Add(1)
+	segments := CloneSeriesSegments(p.segments[:len(p.segments)-1])
+	maxID := p.maxSeriesID()
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.compacting = false
+		p.mu.Unlock()
+		p.wg.Done()
+	}()
+
+	drop := func(_ uint8, id uint64) (bool, error) {
+		return id != maxID && p.IsDeleted(id), nil
+	}
+
+	// Rewrite segments with deleted entries to temporary files.
+	rewritten := make(map[uint16]string)
+	defer func() {
+		for _, path := range rewritten {
+			os.Remove(path)
+		}
+	}()
+	for _, segment := range segments {
+		select {
+		case <-ctx.Done():
+			return SeriesGCStats{}, ctx.Err()
+		case <-p.closing:
+			return SeriesGCStats{}, ErrSeriesPartitionCompactionCancelled
+		default:
+		}
+
+		var s SeriesGCStats
+		segment.ForEachEntry(func(flag uint8, id uint64, _ int64, key []byte) error {
+			if ok, _ := drop(flag, id); ok {
+				s.EntryN++
+				s.Bytes += int64(SeriesEntryHeaderSize + len(key))
+			}
+			return nil
+		})
+		if s.EntryN == 0 {
+			continue
+		}
+
+		path := segment.Path() + ".compacting"
+		rewritten[segment.ID()] = path
+		if err := segment.compactToPath(path, drop); err != nil {
+			return SeriesGCStats{}, err
+		}
+		stats.Add(s)
+	}
+	if len(rewritten) == 0 {
+		return stats, nil
+	}
+
+	if err := p.swapSegments(rewritten); err != nil {
+		return SeriesGCStats{}, err
+	}
+
+	// Write the rebuilt index to disk.
+	compactor := NewSeriesPartitionCompactor()
+	compactor.cancel = p.closing
+	if err := compactor.Compact(p); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// swapSegments replaces segments with the rewritten files, keyed by segment
+// id, and rebuilds the index from the segments.
+func (p *SeriesPartition) swapSegments(rewritten map[uint16]string) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrSeriesPartitionClosed
+	}
+
+	// The offsets in the index are invalid for the new segments. Remove it
+	// first so that, if interrupted, the partition rebuilds it on open.
+	if err := p.index.Close(); err != nil {
+		return err
+	} else if err := os.Remove(p.IndexPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	defer func() {
+		p.index = NewSeriesIndex(p.IndexPath())
+		if e := p.index.Open(); e != nil && err == nil {
+			err = e
+		} else if e := p.index.Recover(p.segments); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	for i, segment := range p.segments {
+		path, ok := rewritten[segment.ID()]
+		if !ok {
+			continue
+		}
+		if err := file.RenameFile(path, segment.Path()); err != nil {
+			return err
+		}
+		delete(rewritten, segment.ID())
+
+		other := NewSeriesSegment(segment.ID(), segment.Path())
+		if err := other.Open(); err != nil {
+			return err
+		}
+		p.retired = append(p.retired, segment)
+		p.segments[i] = other
+	}
+	return file.SyncDir(p.path)
+}
+
+// maxSeriesID returns the highest series id assigned by the partition, or zero
+// if none has been.
+func (p *SeriesPartition) maxSeriesID() uint64 {
+	if p.seq <= uint64(p.id)+1 {
+		return 0
+	}
+	return p.seq - SeriesFilePartitionN
+}
+
 // activeSegment returns the last segment.
 func (p *SeriesPartition) activeSegment() *SeriesSegment {
 	if len(p.segments) == 0 {
@@ -278,6 +278,19 @@ func (s *SeriesSegment) Clone() *SeriesSegment {
 
 // CompactToPath rewrites the segment to a new file and removes tombstoned entries.
 func (s *SeriesSegment) CompactToPath(path string, index *SeriesIndex) error {
+	return s.compactToPath(path, func(flag uint8, id uint64) (bool, error) {
+		if index.IsDeleted(id) {
+			return true, nil // series id has been deleted from index
+		} else if flag == SeriesEntryTombstoneFlag {
+			return false, fmt.Errorf("[series id %d]: tombstone entry but exists in index", id)
+		}
+		return false, nil
+	})
+}
+
+// compactToPath rewrites the segment to a new file without the entries for
+// which drop returns true.
+func (s *SeriesSegment) compactToPath(path string, drop func(flag uint8, id uint64) (bool, error)) error {
 	dst, err := CreateSeriesSegment(s.id, path)
 	if err != nil {
 		return err
@@ -288,14 +301,12 @@ func (s *SeriesSegment) CompactToPath(path string, index *SeriesIndex) error {
 		return err
 	}
 
-	// Iterate through the segment and write any entries to a new segment
-	// that exist in the index.
 	var buf []byte
 	if err = s.ForEachEntry(func(flag uint8, id uint64, _ int64, key []byte) error {
-		if index.IsDeleted(id) {
-			return nil // series id has been deleted from index
-		} else if flag == SeriesEntryTombstoneFlag {
-			return fmt.Errorf("[series id %d]: tombstone entry but exists in index", id)
+		if ok, err := drop(flag, id); err != nil {
+			return err
+		} else if ok {
+			return nil
 		}
 
 		// copy entry over to new segment
@@ -148,6 +148,23 @@ type Shard struct {
 	baseLogger *zap.Logger
 	logger     *zap.Logger
 
+	verifyMu     sync.Mutex
+	verifyStatus VerifyStatus
+
+	rebuildMu     sync.Mutex
+	rebuild       *indexRebuild
+	rebuildStatus IndexRebuildStatus
+
+	replicaMu sync.Mutex
+	replica   *ReplicaStatus
+
+	rollupMu       sync.Mutex
+	rollupInterval time.Duration
+	rollupLoaded   bool
+
+	// Cached cardinality of the index, for the cardinality limits.
+	cardinality cardinalityCache
+
 	EnableOnOpen bool
 
 	// CompactionDisabled specifies the shard should not schedule compactions.
@@ -228,6 +245,26 @@ func (s *Shard) ScheduleFullCompaction() error {
 	return engine.ScheduleFullCompaction()
 }
 
+// Compactions returns the running and planned compactions of the shard, or
+// nil if the shard is closed.
+func (s *Shard) Compactions() []CompactionInfo {
+	engine, err := s.Engine()
+	if err != nil {
+		return nil
+	}
+	return engine.Compactions()
+}
+
+// SetCompactionsPaused pauses or resumes compactions of the shard's TSM files.
+// Running compactions are aborted when pausing.
+func (s *Shard) SetCompactionsPaused(paused bool) error {
+	engine, err := s.Engine()
+	if err != nil {
+		return err
+	}
+	return engine.SetCompactionsPaused(paused)
+}
+
 // ID returns the shards ID.
 func (s *Shard) ID() uint64 {
 	return s.id
@@ -319,6 +356,11 @@ func (s *Shard) openNoLock() error {
 			return nil
 		}
 
+		// Restore the index if a rebuild was interrupted while swapping it.
+		if err := recoverIndexRebuild(s.path); err != nil {
+			return err
+		}
+
 		seriesIDSet := NewSeriesIDSet()
 
 		// Initialize underlying index.
@@ -335,6 +377,7 @@ func (s *Shard) openNoLock() error {
 			return err
 		}
 		s.index = idx
+		s.cardinality.reset()
 
 		// Initialize underlying engine.
 		e, err := NewEngine(s.id, idx, s.path, s.walPath, s.sfile, s.options)
@@ -593,6 +636,112 @@ func (s *Shard) WritePointsWithContext(ctx context.Context, points []models.Poin
 	return writeError
 }
 
+// cardinalityLimits returns the cardinality limits of the shard's database.
+func (s *Shard) cardinalityLimits() *CardinalityLimits {
+	limits := &CardinalityLimits{MaxSeriesPerMeasurement: s.options.Config.MaxSeriesPerMeasurement}
+	if s.options.CardinalityLimits != nil {
+		limits = limits.Override(s.options.CardinalityLimits(s.database))
+	}
+	return limits
+}
+
+// enforceCardinalityLimits moves the points whose series exist, or can be
+// created within the limits, to the front of points, keys, names and
+// tagsSlice and returns their number. The reason of the first point dropped
+// is returned too.
+func (s *Shard) enforceCardinalityLimits(limits *CardinalityLimits, points []models.Point, keys, names [][]byte, tagsSlice []models.Tags) (int, string, error) {
+	var (
+		reason string
+
+		// The cardinality of the measurements and tag keys, including the
+		// series and values created by the write.
+		seriesN = make(map[string]int)
+		valuesN = make(map[string]int)
+
+		// The series and tag values created by the write.
+		created = make(map[string]struct{})
+	)
+
+	ids := s.index.SeriesIDSet()
+
+	var j int
+outer:
+	for i := range points {
+		name, tags := names[i], tagsSlice[i]
+
+		if _, ok := created[string(keys[i])]; ok {
+			// Same new series as a previous point.
+		} else if id := s.sfile.SeriesID(name, tags, nil); id != 0 && ids.Contains(id) {
+			// Existing series.
+		} else {
+			max := limits.MaxSeries(name)
+			n, ok := seriesN[string(name)]
+			if max > 0 && !ok {
+				var err error
+				if n, err = s.cardinality.seriesN(s.index, name, max); err != nil {
+					return 0, "", err
+				}
+				seriesN[string(name)] = n
+			}
+			if max > 0 && n >= max {
+				if reason == "" {
+					reason = fmt.Sprintf("max-series-per-measurement limit exceeded (%d/%d): measurement=%q", n, max, name)
+				}
+				continue
+			}
+
+			var newKeys, newValues []string
+			for _, t := range tags {
+				max := limits.MaxValues(t.Key)
+				if max <= 0 {
+					continue
+				}
+
+				id := tagKeyID(name, t.Key)
+				vid := id + "\x00" + string(t.Value)
+				if _, ok := created[vid]; ok {
+					continue
+				} else if ok, err := s.index.HasTagValue(name, t.Key, t.Value); err != nil {
+					return 0, "", err
+				} else if ok {
+					continue
+				}
+
+				n, ok := valuesN[id]
+				if !ok {
+					var err error
+					if n, err = s.cardinality.valueN(s.index, name, t.Key, max); err != nil {
+						return 0, "", err
+					}
+					valuesN[id] = n
+				}
+				if n >= max {
+					if reason == "" {
+						reason = fmt.Sprintf("max-values-per-tag limit exceeded (%d/%d): measurement=%q tag=%q value=%q",
+							n, max, name, t.Key, t.Value)
+					}
+					continue outer
+				}
+				newKeys, newValues = append(newKeys, id), append(newValues, vid)
+			}
+
+			created[string(keys[i])] = struct{}{}
+			if max > 0 {
+				seriesN[string(name)] = n + 1
+			}
+			for k, id := range newKeys {
+				created[newValues[k]] = struct{}{}
+				valuesN[id]++
+			}
+			s.cardinality.add(name, newKeys)
+		}
+
+		points[j], keys[j], names[j], tagsSlice[j] = points[i], keys[i], names[i], tagsSlice[i]
+		j++
+	}
+	return j, reason, nil
+}
+
 // validateSeriesAndFields checks which series and fields are new and whose metadata should be saved and indexed.
 func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point, []*FieldCreate, error) {
 	var (
@@ -647,9 +796,35 @@ func (s *Shard) validateSeriesAndFields(points []models.Point) ([]models.Point,
 		return nil, nil, err
 	}
 
-	// Add new series. Check for partial writes.
+	// Drop new series beyond the cardinality limits.
+	if limits := s.cardinalityLimits(); limits.Enabled() {
+		n, limitReason, err := s.enforceCardinalityLimits(limits, points, keys, names, tagsSlice)
+		if err != nil {
+			return nil, nil, err
+		}
+		if n < len(points) {
+			if reason == "" {
+				reason = limitReason
+			}
+			dropped += len(points) - n
+			atomic.AddInt64(&s.stats.WritePointsDropped, int64(len(points)-n))
+		}
+		points, keys, names, tagsSlice = points[:n], keys[:n], names[:n], tagsSlice[:n]
+	}
+
+	// Add new series. Check for partial writes. Series garbage collection
+	// waits until the new series are in the index.
 	var droppedKeys [][]byte
-	if err := engine.CreateSeriesListIfNotExists(keys, names, tagsSlice); err != nil {
+	release := s.sfile.retainCreate()
+	err = engine.CreateSeriesListIfNotExists(keys, names, tagsSlice)
+	if _, ok := err.(*PartialWriteError); err == nil || ok {
+		s.addIndexRebuildSeries(keys, names, tagsSlice)
+	}
+	release()
+	if err != nil {
+		// The cached cardinality counted series that weren't created.
+		s.cardinality.reset()
+
 		switch err := err.(type) {
 		// TODO(jmw): why is this a *PartialWriteError when everything else is not a pointer?
 		// Maybe we can just change it to be consistent if we change it also in all
@@ -799,6 +974,8 @@ func (s *Shard) DeleteSeriesRange(itr SeriesIterator, min, max int64) error {
 	if err != nil {
 		return err
 	}
+	s.markIndexRebuildModified()
+	defer s.cardinality.reset()
 	return engine.DeleteSeriesRange(itr, min, max)
 }
 
@@ -809,15 +986,31 @@ func (s *Shard) DeleteSeriesRangeWithPredicate(itr SeriesIterator, predicate fun
 	if err != nil {
 		return err
 	}
+	s.markIndexRebuildModified()
+	defer s.cardinality.reset()
 	return engine.DeleteSeriesRangeWithPredicate(itr, predicate)
 }
 
+// DeleteFieldRange deletes the values of fields of measurement name between
+// min and max (inclusive).
+func (s *Shard) DeleteFieldRange(name []byte, fields []string, min, max int64) error {
+	engine, err := s.Engine()
+	if err != nil {
+		return err
+	}
+	s.markIndexRebuildModified()
+	defer s.cardinality.reset()
+	return engine.DeleteFieldRange(name, fields, min, max)
+}
+
 // DeleteMeasurement deletes a measurement and all underlying series.
 func (s *Shard) DeleteMeasurement(name []byte) error {
 	engine, err := s.Engine()
 	if err != nil {
 		return err
 	}
+	s.markIndexRebuildModified()
+	defer s.cardinality.reset()
 	return engine.DeleteMeasurement(name)
 }
 
@@ -924,6 +1117,12 @@ func (s *Shard) CreateIterator(ctx context.Context, m *influxql.Measurement, opt
 	case "_tagKeys":
 		return NewTagKeysIterator(s, opt)
 	}
+	if call, ok := opt.Expr.(*influxql.Call); ok {
+		if itr, ok, err := s.createRollupIterator(ctx, engine, m.Name, call, opt); ok {
+			return itr, err
+		}
+		opt.Expr = s.rollupCall(m.Name, call)
+	}
 	return engine.CreateIterator(ctx, m.Name, opt)
 }
 
@@ -1223,6 +1422,42 @@ func (s *Shard) Digest() (io.ReadCloser, int64, error, string) {
 	return readCloser, size, err, ""
 }
 
+// Verify checks the integrity of the shard's TSM files and index while the
+// shard remains online. The series file is shared by every shard in a database
+// and is verified separately. The outcome of a completed pass is available from
+// VerifyStatus.
+func (s *Shard) Verify(ctx context.Context, rate limiter.Rate) (VerifyStats, error) {
+	engine, err := s.Engine()
+	if err != nil {
+		return VerifyStats{}, err
+	}
+
+	stats, err := engine.Verify(ctx, rate)
+	if err == nil {
+		var index Index
+		if index, err = s.Index(); err == nil {
+			if v, ok := index.(IndexVerifier); ok {
+				err = v.Verify(ctx)
+			}
+		}
+	}
+
+	// An interrupted pass says nothing about the state of the shard.
+	if err == nil || IsCorruptionError(err) {
+		s.verifyMu.Lock()
+		s.verifyStatus = VerifyStatus{VerifiedAt: time.Now().UTC(), Err: err}
+		s.verifyMu.Unlock()
+	}
+	return stats, err
+}
+
+// VerifyStatus returns the result of the last completed call to Verify.
+func (s *Shard) VerifyStatus() VerifyStatus {
+	s.verifyMu.Lock()
+	defer s.verifyMu.Unlock()
+	return s.verifyStatus
+}
+
 // engine safely (under an RLock) returns a reference to the shard's Engine, or
 // an error if the Engine is closed, or the shard is currently disabled.
 //
@@ -1640,6 +1875,30 @@ func (m *MeasurementFields) CreateFieldIfNotExists(name []byte, typ influxql.Dat
 	return nil
 }
 
+// DeleteFieldWithLock executes fn and removes the field name under lock.
+// The field is kept if fn returns an error.
+func (m *MeasurementFields) DeleteFieldWithLock(name string, fn func() error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	fields := m.fields.Load().(map[string]*Field)
+	if _, ok := fields[name]; !ok {
+		return nil
+	}
+	fieldsUpdate := make(map[string]*Field, len(fields))
+	for k, v := range fields {
+		if k != name {
+			fieldsUpdate[k] = v
+		}
+	}
+	m.fields.Store(fieldsUpdate)
+	return nil
+}
+
 func (m *MeasurementFields) FieldN() int {
 	n := len(m.fields.Load().(map[string]*Field))
 	return n
@@ -235,6 +235,50 @@ mem,host=serverB value=50i,val3=t 10
 	}
 }
 
+// Ensure the cached cardinality is read from the index only the first time and
+// before a limit is enforced on an estimated count.
+func TestCardinalityCache(t *testing.T) {
+	var c cardinalityCache
+
+	var reads int
+	count := func(n int) func() (int, error) {
+		return func() (int, error) {
+			reads++
+			return n, nil
+		}
+	}
+
+	// Read from the index the first time only.
+	for i := 0; i < 2; i++ {
+		if n, err := c.count(&c.series, "cpu", 3, count(1)); err != nil {
+			t.Fatal(err)
+		} else if n != 1 || reads != 1 {
+			t.Fatalf("unexpected count: n=%d reads=%d", n, reads)
+		}
+	}
+
+	// Incremented counts below the limit aren't read again.
+	c.add([]byte("cpu"), nil)
+	if n, _ := c.count(&c.series, "cpu", 3, count(2)); n != 2 || reads != 1 {
+		t.Fatalf("unexpected count: n=%d reads=%d", n, reads)
+	}
+
+	// An estimated count reaching the limit is read again, and is exact until
+	// incremented.
+	c.add([]byte("cpu"), nil)
+	for i := 0; i < 2; i++ {
+		if n, _ := c.count(&c.series, "cpu", 3, count(2)); n != 2 || reads != 2 {
+			t.Fatalf("unexpected count: n=%d reads=%d", n, reads)
+		}
+	}
+
+	// Counts are read again after a reset.
+	c.reset()
+	if n, _ := c.count(&c.series, "cpu", 3, count(1)); n != 1 || reads != 3 {
+		t.Fatalf("unexpected count: n=%d reads=%d", n, reads)
+	}
+}
+
 // TempShard represents a test wrapper for Shard that uses temporary
 // filesystem paths.
 type TempShard struct {
@@ -1054,6 +1054,102 @@ func TestShard_Disabled_WriteQuery(t *testing.T) {
 	}
 }
 
+func TestShard_Verify(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			sh := MustNewOpenShard(index)
+			defer sh.Close()
+
+			if status := sh.VerifyStatus(); !status.VerifiedAt.IsZero() {
+				t.Fatalf("unexpected verify status before verification: %+v", status)
+			}
+
+			pt := models.MustNewPoint(
+				"cpu",
+				models.NewTags(map[string]string{"host": "server"}),
+				map[string]interface{}{"value": 1.0},
+				time.Unix(1, 2),
+			)
+			if err := sh.WritePoints([]models.Point{pt}); err != nil {
+				t.Fatal(err)
+			} else if _, err := sh.CreateSnapshot(false); err != nil {
+				t.Fatal(err)
+			}
+
+			stats, err := sh.Verify(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if stats.Files != 1 || stats.Blocks != 1 {
+				t.Fatalf("unexpected stats: %+v", stats)
+			}
+
+			if status := sh.VerifyStatus(); status.VerifiedAt.IsZero() || status.Err != nil {
+				t.Fatalf("unexpected verify status: %+v", status)
+			}
+		})
+	}
+}
+
+func TestShard_RebuildIndex(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			sh := MustNewOpenShard(index)
+			defer sh.Close()
+
+			if status := sh.IndexRebuildStatus(); status.State != "" {
+				t.Fatalf("unexpected rebuild status before rebuild: %+v", status)
+			}
+
+			// Leave one series in a TSM file and one only in the cache.
+			for i, host := range []string{"a", "b"} {
+				pt := models.MustNewPoint(
+					"cpu",
+					models.NewTags(map[string]string{"host": host}),
+					map[string]interface{}{"value": 1.0, "count": int64(i)},
+					time.Unix(int64(i), 0),
+				)
+				if err := sh.WritePoints([]models.Point{pt}); err != nil {
+					t.Fatal(err)
+				}
+				if i == 0 {
+					if _, err := sh.CreateSnapshot(false); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			if err := sh.RebuildIndex(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got, exp := sh.IndexType(), tsdb.TSI1IndexName; got != exp {
+				t.Fatalf("got index type %q, expected %q", got, exp)
+			} else if got, exp := sh.SeriesN(), int64(2); got != exp {
+				t.Fatalf("got %d series, expected %d", got, exp)
+			}
+			if status := sh.IndexRebuildStatus(); status.State != tsdb.IndexRebuildDone || status.Err != nil || status.SeriesN != 2 {
+				t.Fatalf("unexpected rebuild status: %+v", status)
+			}
+			if _, err := os.Stat(filepath.Join(sh.Path(), "index.rebuild")); !os.IsNotExist(err) {
+				t.Fatalf("rebuild directory not removed: %v", err)
+			}
+
+			// The shard keeps accepting writes with the new index.
+			pt := models.MustNewPoint(
+				"cpu",
+				models.NewTags(map[string]string{"host": "c"}),
+				map[string]interface{}{"value": 1.0},
+				time.Unix(3, 0),
+			)
+			if err := sh.WritePoints([]models.Point{pt}); err != nil {
+				t.Fatal(err)
+			} else if got, exp := sh.SeriesN(), int64(3); got != exp {
+				t.Fatalf("got %d series, expected %d", got, exp)
+			}
+		})
+	}
+}
+
 func TestShard_Closed_Functions(t *testing.T) {
 	var sh *Shard
 	test := func(index string) {
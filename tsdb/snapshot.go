@@ -0,0 +1,232 @@
+package tsdb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	intar "github.com/influxdata/influxdb/pkg/tar"
+	"github.com/influxdata/influxql"
+	"go.uber.org/zap"
+)
+
+// ErrSnapshotNotFound is returned when a database snapshot does not exist or
+// was released.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// DatabaseSnapshot is a point-in-time image of the local shards of a
+// database. It holds hard links to the TSM files of every shard, taken while
+// the writes to all of them were paused, and is kept until released.
+type DatabaseSnapshot struct {
+	ID        uint64
+	Database  string
+	CreatedAt time.Time
+
+	// mu is held for reading while a shard of the snapshot is streamed, so
+	// that a release waits for the streams to finish.
+	mu       sync.RWMutex
+	shards   map[uint64]snapshotShard
+	released bool
+}
+
+// snapshotShard is the snapshot of a single shard.
+type snapshotShard struct {
+	dir          string // directory holding the hard links
+	relativePath string // path of the shard relative to the store
+}
+
+// ShardIDs returns the sorted IDs of the shards in the snapshot.
+func (s *DatabaseSnapshot) ShardIDs() []uint64 {
+	ids := make([]uint64, 0, len(s.shards))
+	for id := range s.shards {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// RelativePaths returns the paths of the shards in the snapshot relative to
+// the store, in the same form as Store.ShardRelativePath, sorted by shard ID.
+func (s *DatabaseSnapshot) RelativePaths() []string {
+	ids := s.ShardIDs()
+	paths := make([]string, len(ids))
+	for i, id := range ids {
+		paths[i] = s.shards[id].relativePath
+	}
+	return paths
+}
+
+// remove removes the hard links of every shard in the snapshot.
+func (s *DatabaseSnapshot) remove() error {
+	var err error
+	for _, sh := range s.shards {
+		if e := os.RemoveAll(sh.dir); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// CreateDatabaseSnapshot creates a point-in-time snapshot of the local shards
+// of a database. Shards offloaded to object storage are fetched first. The
+// writes to every shard are then paused, the writes in progress waited for,
+// and each shard's cache flushed and its TSM files hard linked, before the
+// writes resume. The snapshot is kept until ReleaseDatabaseSnapshot is called
+// or the store is closed.
+func (s *Store) CreateDatabaseSnapshot(database string) (*DatabaseSnapshot, error) {
+	s.mu.RLock()
+	select {
+	case <-s.closing:
+		s.mu.RUnlock()
+		return nil, ErrStoreClosed
+	default:
+	}
+	var offloaded []uint64
+	for id, o := range s.offloaded {
+		if o.database == database && s.shards[id] == nil {
+			offloaded = append(offloaded, id)
+		}
+	}
+	s.mu.RUnlock()
+
+	// Fetch offloaded shards before pausing the writes, so that they are not
+	// paused for the duration of a download.
+	for _, id := range offloaded {
+		if _, err := s.loadShard(id); err != nil && err != ErrShardNotFound {
+			return nil, fmt.Errorf("shard %d: %s", id, err)
+		}
+	}
+
+	s.mu.RLock()
+	shards := s.filterShards(byDatabase(database))
+	epochs := s.epochsForShards(shards)
+	s.mu.RUnlock()
+
+	// Install a guard matching every point on each shard, which blocks the
+	// writes that start from now on, then wait for the writes in progress.
+	waiters := make([]epochWaiter, 0, len(shards))
+	for _, sh := range shards {
+		waiters = append(waiters, epochs[sh.id].WaitDelete(newGuard(influxql.MinTime, influxql.MaxTime, nil, nil)))
+	}
+	start := time.Now()
+	defer func() {
+		for _, waiter := range waiters {
+			waiter.Done()
+		}
+		s.Logger.Info("Resumed writes after database snapshot",
+			logger.Database(database), zap.Duration("paused", time.Since(start)))
+	}()
+	for _, waiter := range waiters {
+		waiter.Wait()
+	}
+
+	snap := &DatabaseSnapshot{
+		Database:  database,
+		CreatedAt: time.Now().UTC(),
+		shards:    make(map[uint64]snapshotShard, len(shards)),
+	}
+	var mu sync.Mutex
+	if err := s.walkShards(shards, func(sh *Shard) error {
+		relativePath, err := relativePath(s.shardRoot(sh), sh.path)
+		if err != nil {
+			return err
+		}
+		dir, err := sh.CreateSnapshot(false)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		snap.shards[sh.id] = snapshotShard{dir: dir, relativePath: relativePath}
+		return nil
+	}); err != nil {
+		if err := snap.remove(); err != nil {
+			s.Logger.Warn("Unable to remove partial database snapshot", logger.Database(database), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+	snap.ID = uint64(snap.CreatedAt.UnixNano())
+	if snap.ID <= s.lastSnapshotID {
+		snap.ID = s.lastSnapshotID + 1
+	}
+	s.lastSnapshotID = snap.ID
+	s.snapshots[snap.ID] = snap
+	return snap, nil
+}
+
+// DatabaseSnapshot returns the database snapshot with the given ID, or nil if
+// it does not exist.
+func (s *Store) DatabaseSnapshot(id uint64) *DatabaseSnapshot {
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+	return s.snapshots[id]
+}
+
+// DatabaseSnapshots returns the database snapshots kept by the store, sorted
+// by ID.
+func (s *Store) DatabaseSnapshots() []*DatabaseSnapshot {
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+	a := make([]*DatabaseSnapshot, 0, len(s.snapshots))
+	for _, snap := range s.snapshots {
+		a = append(a, snap)
+	}
+	sort.Slice(a, func(i, j int) bool { return a[i].ID < a[j].ID })
+	return a
+}
+
+// ReleaseDatabaseSnapshot removes a database snapshot, once the shards of
+// the snapshot being streamed are done.
+func (s *Store) ReleaseDatabaseSnapshot(id uint64) error {
+	s.snapshotMu.Lock()
+	snap := s.snapshots[id]
+	delete(s.snapshots, id)
+	s.snapshotMu.Unlock()
+	if snap == nil {
+		return ErrSnapshotNotFound
+	}
+
+	snap.mu.Lock()
+	defer snap.mu.Unlock()
+	snap.released = true
+	return snap.remove()
+}
+
+// releaseDatabaseSnapshots releases every database snapshot.
+func (s *Store) releaseDatabaseSnapshots() {
+	for _, snap := range s.DatabaseSnapshots() {
+		if err := s.ReleaseDatabaseSnapshot(snap.ID); err != nil && err != ErrSnapshotNotFound {
+			s.Logger.Warn("Unable to release database snapshot", zap.Uint64("snapshot", snap.ID), zap.Error(err))
+		}
+	}
+}
+
+// BackupShardSnapshot writes a tar archive of the snapshot of a shard taken
+// by a database snapshot to w, in the same format as BackupShard. Only files
+// modified after since are included.
+func (s *Store) BackupShardSnapshot(snapshotID, shardID uint64, since time.Time, w io.Writer) error {
+	snap := s.DatabaseSnapshot(snapshotID)
+	if snap == nil {
+		return ErrSnapshotNotFound
+	}
+
+	snap.mu.RLock()
+	defer snap.mu.RUnlock()
+	if snap.released {
+		return ErrSnapshotNotFound
+	}
+	sh, ok := snap.shards[shardID]
+	if !ok {
+		return fmt.Errorf("shard %d is not in snapshot %d", shardID, snapshotID)
+	}
+	return intar.Stream(w, sh.dir, sh.relativePath, intar.SinceFilterTarFile(since))
+}
@@ -0,0 +1,100 @@
+package tsdb_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxql"
+)
+
+func TestStore_DatabaseSnapshot(t *testing.T) {
+	test := func(index string) {
+		s0, s1 := MustOpenStore(index), MustOpenStore(index)
+		defer s0.Close()
+		defer s1.Close()
+
+		// The points are still in the caches when the snapshot is created.
+		s0.MustCreateShardWithData("db0", "rp0", 100, `cpu value=1 0`, `cpu value=2 10`)
+		s0.MustCreateShardWithData("db0", "rp1", 101, `mem value=1 0`)
+		s0.MustCreateShardWithData("db1", "rp0", 102, `cpu value=1 0`)
+
+		snap, err := s0.CreateDatabaseSnapshot("db0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := snap.ShardIDs(), []uint64{100, 101}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected shards: got %v, exp %v", got, exp)
+		} else if got, exp := snap.RelativePaths(), []string{"db0/rp0/100", "db0/rp1/101"}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected paths: got %v, exp %v", got, exp)
+		} else if s0.DatabaseSnapshot(snap.ID) != snap {
+			t.Fatal("expected snapshot to be kept")
+		}
+
+		// Points written after the snapshot are not in its backups.
+		s0.MustWriteToShardString(100, `cpu value=3 20`)
+
+		var buf bytes.Buffer
+		if err := s0.BackupShardSnapshot(snap.ID, 100, time.Time{}, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := s1.CreateShard("db0", "rp0", 100, true); err != nil {
+			t.Fatal(err)
+		} else if err := s1.RestoreShard(100, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := countShardPoints(t, s1.Shard(100), "cpu"), 2; got != exp {
+			t.Fatalf("unexpected restored points: got %d, exp %d", got, exp)
+		}
+
+		if err := s0.BackupShardSnapshot(snap.ID, 102, time.Time{}, &buf); err == nil {
+			t.Fatal("expected error for shard not in snapshot")
+		}
+
+		if err := s0.ReleaseDatabaseSnapshot(snap.ID); err != nil {
+			t.Fatal(err)
+		} else if err := s0.BackupShardSnapshot(snap.ID, 100, time.Time{}, &buf); err != tsdb.ErrSnapshotNotFound {
+			t.Fatalf("unexpected error: got %v, exp %v", err, tsdb.ErrSnapshotNotFound)
+		} else if err := s0.ReleaseDatabaseSnapshot(snap.ID); err != tsdb.ErrSnapshotNotFound {
+			t.Fatalf("unexpected error: got %v, exp %v", err, tsdb.ErrSnapshotNotFound)
+		}
+		if got := len(s0.DatabaseSnapshots()); got != 0 {
+			t.Fatalf("unexpected snapshots: %d", got)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(index) })
+	}
+}
+
+// countShardPoints returns the number of values of a measurement in a shard.
+func countShardPoints(t *testing.T, sh *tsdb.Shard, name string) int {
+	t.Helper()
+	itr, err := sh.CreateIterator(context.Background(), &influxql.Measurement{Name: name}, query.IteratorOptions{
+		Expr:      influxql.MustParseExpr(`value`),
+		Ascending: true,
+		StartTime: influxql.MinTime,
+		EndTime:   influxql.MaxTime,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer itr.Close()
+
+	var n int
+	fitr := itr.(query.FloatIterator)
+	for {
+		p, err := fitr.Next()
+		if err != nil {
+			t.Fatal(err)
+		} else if p == nil {
+			return n
+		}
+		n++
+	}
+}
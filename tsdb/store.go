@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -116,6 +117,25 @@ type Store struct {
 	// is stored by shard.
 	epochs map[uint64]*epochTracker
 
+	// Shards stored in object storage, by ID. An offloaded shard is also in
+	// shards while it is fetched into the offload cache.
+	offloaded map[uint64]*offloadedShard
+
+	// ObjectStore stores offloaded shards. Offloading is disabled if nil.
+	ObjectStore ObjectStore
+
+	// Samples of the last cardinality report of each database.
+	cardinality cardinalitySamples
+
+	// Database snapshots kept until released, by ID.
+	snapshotMu     sync.Mutex
+	snapshots      map[uint64]*DatabaseSnapshot
+	lastSnapshotID uint64
+
+	// Disk quotas exceeded on this node, by database and retention policy.
+	quotaMu       sync.RWMutex
+	quotaExceeded map[[2]string]DiskQuotaExceededError
+
 	EngineOptions EngineOptions
 
 	baseLogger *zap.Logger
@@ -138,6 +158,8 @@ func NewStore(path string) *Store {
 		pendingShardDeletes: make(map[uint64]struct{}),
 		badShards:           shardErrorMap{shardErrors: make(map[uint64]error)},
 		epochs:              make(map[uint64]*epochTracker),
+		offloaded:           make(map[uint64]*offloadedShard),
+		snapshots:           make(map[uint64]*DatabaseSnapshot),
 		EngineOptions:       NewEngineOptions(),
 		Logger:              logger,
 		baseLogger:          logger,
@@ -234,6 +256,7 @@ func (s *Store) Open() error {
 
 	s.closing = make(chan struct{})
 	s.shards = map[uint64]*Shard{}
+	s.offloaded = map[uint64]*offloadedShard{}
 
 	s.Logger.Info("Using data dir", zap.String("path", s.Path()))
 
@@ -241,6 +264,18 @@ func (s *Store) Open() error {
 	if err := os.MkdirAll(s.path, 0777); err != nil {
 		return err
 	}
+	if coldDir := s.EngineOptions.Config.ColdDir; coldDir != "" {
+		s.Logger.Info("Using cold data dir", zap.String("path", coldDir))
+		if err := os.MkdirAll(coldDir, 0777); err != nil {
+			return err
+		}
+	}
+	if cacheDir := s.EngineOptions.Config.OffloadCacheDir; cacheDir != "" {
+		s.Logger.Info("Using offload cache dir", zap.String("path", cacheDir))
+		if err := os.MkdirAll(cacheDir, 0777); err != nil {
+			return err
+		}
+	}
 
 	if err := s.loadShards(); err != nil {
 		return err
@@ -317,124 +352,169 @@ func (s *Store) loadShards() error {
 	resC := make(chan *res)
 	var n int
 
-	// Determine how many shards we need to open by checking the store path.
-	dbDirs, err := os.ReadDir(s.path)
-	if err != nil {
+	// Determine how many shards we need to open by checking the store path
+	// and the cold data directory, if configured. A shard found under both is
+	// opened from the first one.
+	if err := s.finishShardMoves(); err != nil {
 		return err
 	}
-
-	for _, db := range dbDirs {
-		dbPath := filepath.Join(s.path, db.Name())
-		if !db.IsDir() {
-			log.Info("Skipping database dir", zap.String("name", db.Name()), zap.String("reason", "not a directory"))
+	seen := make(map[uint64]string)
+	for _, root := range s.tierRoots() {
+		dbDirs, err := os.ReadDir(root)
+		if os.IsNotExist(err) {
 			continue
-		}
-
-		if s.EngineOptions.DatabaseFilter != nil && !s.EngineOptions.DatabaseFilter(db.Name()) {
-			log.Info("Skipping database dir", logger.Database(db.Name()), zap.String("reason", "failed database filter"))
-			continue
-		}
-
-		// Load series file.
-		sfile, err := s.openSeriesFile(db.Name())
-		if err != nil {
-			return err
-		}
-
-		// Retrieve database index.
-		idx, err := s.createIndexIfNotExists(db.Name())
-		if err != nil {
-			return err
-		}
-
-		// Load each retention policy within the database directory.
-		rpDirs, err := os.ReadDir(dbPath)
-		if err != nil {
+		} else if err != nil {
 			return err
 		}
 
-		for _, rp := range rpDirs {
-			rpPath := filepath.Join(s.path, db.Name(), rp.Name())
-			if !rp.IsDir() {
-				log.Info("Skipping retention policy dir", zap.String("name", rp.Name()), zap.String("reason", "not a directory"))
+		for _, db := range dbDirs {
+			dbPath := filepath.Join(root, db.Name())
+			if !db.IsDir() {
+				log.Info("Skipping database dir", zap.String("name", db.Name()), zap.String("reason", "not a directory"))
 				continue
 			}
 
-			// The .series directory is not a retention policy.
-			if rp.Name() == SeriesFileDirectory {
+			if s.EngineOptions.DatabaseFilter != nil && !s.EngineOptions.DatabaseFilter(db.Name()) {
+				log.Info("Skipping database dir", logger.Database(db.Name()), zap.String("reason", "failed database filter"))
 				continue
 			}
 
-			if s.EngineOptions.RetentionPolicyFilter != nil && !s.EngineOptions.RetentionPolicyFilter(db.Name(), rp.Name()) {
-				log.Info("Skipping retention policy dir", logger.RetentionPolicy(rp.Name()), zap.String("reason", "failed retention policy filter"))
-				continue
+			// Load series file.
+			sfile, err := s.openSeriesFile(db.Name())
+			if err != nil {
+				return err
 			}
 
-			shardDirs, err := os.ReadDir(rpPath)
+			// Retrieve database index.
+			idx, err := s.createIndexIfNotExists(db.Name())
 			if err != nil {
 				return err
 			}
 
-			for _, sh := range shardDirs {
-				// Series file should not be in a retention policy but skip just in case.
-				if sh.Name() == SeriesFileDirectory {
-					log.Warn("Skipping series file in retention policy dir", zap.String("path", filepath.Join(s.path, db.Name(), rp.Name())))
+			// Load each retention policy within the database directory.
+			rpDirs, err := os.ReadDir(dbPath)
+			if err != nil {
+				return err
+			}
+
+			for _, rp := range rpDirs {
+				rpPath := filepath.Join(root, db.Name(), rp.Name())
+				if !rp.IsDir() {
+					log.Info("Skipping retention policy dir", zap.String("name", rp.Name()), zap.String("reason", "not a directory"))
 					continue
 				}
 
-				n++
-				go func(db, rp, sh string) {
-					t.Take()
-					defer t.Release()
+				// The .series directory is not a retention policy.
+				if rp.Name() == SeriesFileDirectory {
+					continue
+				}
 
-					start := time.Now()
-					path := filepath.Join(s.path, db, rp, sh)
-					walPath := filepath.Join(s.EngineOptions.Config.WALDir, db, rp, sh)
+				if s.EngineOptions.RetentionPolicyFilter != nil && !s.EngineOptions.RetentionPolicyFilter(db.Name(), rp.Name()) {
+					log.Info("Skipping retention policy dir", logger.RetentionPolicy(rp.Name()), zap.String("reason", "failed retention policy filter"))
+					continue
+				}
 
-					// Shard file names are numeric shardIDs
-					shardID, err := strconv.ParseUint(sh, 10, 64)
-					if err != nil {
-						log.Info("invalid shard ID found at path", zap.String("path", path))
-						resC <- &res{err: fmt.Errorf("%s is not a valid ID. Skipping shard.", sh)}
-						return
+				shardDirs, err := os.ReadDir(rpPath)
+				if err != nil {
+					return err
+				}
+
+				for _, sh := range shardDirs {
+					// Series file should not be in a retention policy but skip just in case.
+					if sh.Name() == SeriesFileDirectory {
+						log.Warn("Skipping series file in retention policy dir", zap.String("path", rpPath))
+						continue
 					}
 
-					if s.EngineOptions.ShardFilter != nil && !s.EngineOptions.ShardFilter(db, rp, shardID) {
-						log.Info("skipping shard", zap.String("path", path), logger.Shard(shardID))
-						resC <- &res{}
-						return
+					// Remove the remains of a tier move that did not complete.
+					if strings.HasSuffix(sh.Name(), tierStagingExt) {
+						log.Info("Removing incomplete shard move", zap.String("path", filepath.Join(rpPath, sh.Name())))
+						if err := os.RemoveAll(filepath.Join(rpPath, sh.Name())); err != nil {
+							return err
+						}
+						continue
 					}
 
-					// Copy options and assign shared index.
-					opt := s.EngineOptions
-					opt.InmemIndex = idx
+					shardRoot := root
+					if shardID, err := strconv.ParseUint(sh.Name(), 10, 64); err == nil {
+						if prev, ok := seen[shardID]; ok {
+							log.Warn("Skipping duplicate shard", logger.Shard(shardID),
+								zap.String("path", filepath.Join(rpPath, sh.Name())), zap.String("opened_path", prev))
+							continue
+						}
+						seen[shardID] = filepath.Join(rpPath, sh.Name())
+
+						// Offloaded shards are only opened if they were in the
+						// offload cache.
+						if o, err := s.loadOffloadedShard(db.Name(), rp.Name(), shardID, filepath.Join(rpPath, sh.Name())); err != nil {
+							return err
+						} else if o != nil {
+							s.offloaded[shardID] = o
+							s.epochs[shardID] = newEpochTracker()
+							if _, ok := s.databases[db.Name()]; !ok {
+								s.databases[db.Name()] = new(databaseState)
+							}
+							if _, err := os.Stat(s.offloadCachePath(db.Name(), rp.Name(), shardID)); err != nil {
+								continue
+							}
+							shardRoot = s.EngineOptions.Config.OffloadCacheDir
+						}
+					}
 
-					// Provide an implementation of the ShardIDSets
-					opt.SeriesIDSets = shardSet{store: s, db: db}
+					n++
+					go func(root, db, rp, sh string) {
+						t.Take()
+						defer t.Release()
+
+						start := time.Now()
+						path := filepath.Join(root, db, rp, sh)
+						walPath := filepath.Join(s.EngineOptions.Config.WALDir, db, rp, sh)
+
+						// Shard file names are numeric shardIDs
+						shardID, err := strconv.ParseUint(sh, 10, 64)
+						if err != nil {
+							log.Info("invalid shard ID found at path", zap.String("path", path))
+							resC <- &res{err: fmt.Errorf("%s is not a valid ID. Skipping shard.", sh)}
+							return
+						}
 
-					// Existing shards should continue to use inmem index.
-					if _, err := os.Stat(filepath.Join(path, "index")); os.IsNotExist(err) {
-						opt.IndexVersion = InmemIndexName
-					}
+						if s.EngineOptions.ShardFilter != nil && !s.EngineOptions.ShardFilter(db, rp, shardID) {
+							log.Info("skipping shard", zap.String("path", path), logger.Shard(shardID))
+							resC <- &res{}
+							return
+						}
 
-					// Open engine.
-					shard := NewShard(shardID, path, walPath, sfile, opt)
+						// Copy options and assign shared index.
+						opt := s.EngineOptions
+						opt.InmemIndex = idx
 
-					// Disable compactions, writes and queries until all shards are loaded
-					shard.EnableOnOpen = false
-					shard.CompactionDisabled = s.EngineOptions.CompactionDisabled
-					shard.WithLogger(s.baseLogger)
+						// Provide an implementation of the ShardIDSets
+						opt.SeriesIDSets = shardSet{store: s, db: db}
 
-					err = s.OpenShard(shard, false)
-					if err != nil {
-						log.Error("Failed to open shard", logger.Shard(shardID), zap.Error(err))
-						resC <- &res{err: fmt.Errorf("Failed to open shard: %d: %s", shardID, err)}
-						return
-					}
+						// Existing shards should continue to use inmem index.
+						if _, err := os.Stat(filepath.Join(path, "index")); os.IsNotExist(err) {
+							opt.IndexVersion = InmemIndexName
+						}
 
-					resC <- &res{s: shard}
-					log.Info("Opened shard", zap.String("index_version", shard.IndexType()), zap.String("path", path), zap.Duration("duration", time.Since(start)))
-				}(db.Name(), rp.Name(), sh.Name())
+						// Open engine.
+						shard := NewShard(shardID, path, walPath, sfile, opt)
+
+						// Disable compactions, writes and queries until all shards are loaded
+						shard.EnableOnOpen = false
+						shard.CompactionDisabled = s.EngineOptions.CompactionDisabled
+						shard.WithLogger(s.baseLogger)
+
+						err = s.OpenShard(shard, false)
+						if err != nil {
+							log.Error("Failed to open shard", logger.Shard(shardID), zap.Error(err))
+							resC <- &res{err: fmt.Errorf("Failed to open shard: %d: %s", shardID, err)}
+							return
+						}
+
+						resC <- &res{s: shard}
+						log.Info("Opened shard", zap.String("index_version", shard.IndexType()), zap.String("path", path), zap.Duration("duration", time.Since(start)))
+					}(shardRoot, db.Name(), rp.Name(), sh.Name())
+				}
 			}
 		}
 	}
@@ -448,6 +528,11 @@ func (s *Store) loadShards() error {
 		}
 		s.shards[res.s.id] = res.s
 		s.epochs[res.s.id] = newEpochTracker()
+		if o := s.offloaded[res.s.id]; o != nil {
+			// The cached copy may have been written to since it was fetched,
+			// so leave modified unset to upload it again when evicted.
+			o.cached = res.s
+		}
 		if _, ok := s.databases[res.s.database]; !ok {
 			s.databases[res.s.database] = new(databaseState)
 		}
@@ -491,6 +576,9 @@ func (s *Store) Close() error {
 	s.wg.Wait()
 	// No other goroutines accessing the store, so no need for a Lock.
 
+	// Remove the snapshots before their shards are closed.
+	s.releaseDatabaseSnapshots()
+
 	// Close all the shards in parallel.
 	if err := s.walkShards(s.shardsSlice(), func(sh *Shard) error {
 		return sh.Close()
@@ -512,6 +600,7 @@ func (s *Store) Close() error {
 	s.indexes = make(map[string]interface{})
 	s.pendingShardDeletes = make(map[uint64]struct{})
 	s.shards = nil
+	s.offloaded = nil
 	s.opened = false // Store may now be opened again.
 	s.mu.Unlock()
 	return nil
@@ -572,7 +661,8 @@ func (s *Store) createIndexIfNotExists(name string) (interface{}, error) {
 	return idx, nil
 }
 
-// Shard returns a shard by id.
+// Shard returns a shard by id. An offloaded shard is only returned while it
+// is in the offload cache.
 func (s *Store) Shard(id uint64) *Shard {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -619,14 +709,16 @@ func (s *Store) SetShardOpenErrorForTest(shardID uint64, err error) {
 	s.badShards.setShardOpenError(shardID, err)
 }
 
-// Shards returns a list of shards by id.
+// Shards returns a list of shards by id. Offloaded shards are fetched from
+// object storage.
 func (s *Store) Shards(ids []uint64) []*Shard {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
 	a := make([]*Shard, 0, len(ids))
 	for _, id := range ids {
-		sh, ok := s.shards[id]
-		if !ok {
+		sh, err := s.loadShard(id)
+		if err != nil {
+			if err != ErrShardNotFound {
+				s.Logger.Warn("Unable to fetch offloaded shard", logger.Shard(id), zap.Error(err))
+			}
 			continue
 		}
 		a = append(a, sh)
@@ -671,6 +763,8 @@ func (s *Store) CreateShard(database, retentionPolicy string, shardID uint64, en
 	// Shard already exists.
 	if _, ok := s.shards[shardID]; ok {
 		return nil
+	} else if _, ok := s.offloaded[shardID]; ok {
+		return nil
 	}
 
 	// Shard may be undergoing a pending deletion. While the shard can be
@@ -754,8 +848,16 @@ func (s *Store) SetShardEnabled(shardID uint64, enabled bool) error {
 	return nil
 }
 
-// DeleteShard removes a shard from disk.
+// DeleteShard removes a shard from disk. An offloaded shard is also removed
+// from object storage.
 func (s *Store) DeleteShard(shardID uint64) error {
+	s.mu.RLock()
+	o := s.offloaded[shardID]
+	s.mu.RUnlock()
+	if o != nil {
+		return s.deleteOffloadedShard(o)
+	}
+
 	sh := s.Shard(shardID)
 	if sh == nil {
 		return ErrShardNotFound
@@ -899,6 +1001,15 @@ func (s *Store) DeleteDatabase(name string) error {
 		// no files locally, so nothing to do
 		return nil
 	}
+	s.mu.RUnlock()
+
+	if err := s.deleteOffloadedShards(func(o *offloadedShard) bool {
+		return o.database == name
+	}); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
 	shards := s.filterShards(byDatabase(name))
 	epochs := s.epochsForShards(shards)
 	s.mu.RUnlock()
@@ -947,6 +1058,16 @@ func (s *Store) DeleteDatabase(name string) error {
 	if err := os.RemoveAll(dbPath); err != nil {
 		return err
 	}
+	if coldDir := s.EngineOptions.Config.ColdDir; coldDir != "" {
+		if err := os.RemoveAll(filepath.Join(coldDir, filepath.Base(dbPath))); err != nil {
+			return err
+		}
+	}
+	if cacheDir := s.EngineOptions.Config.OffloadCacheDir; cacheDir != "" {
+		if err := os.RemoveAll(filepath.Join(cacheDir, filepath.Base(dbPath))); err != nil {
+			return err
+		}
+	}
 	if err := os.RemoveAll(filepath.Join(s.EngineOptions.Config.WALDir, name)); err != nil {
 		return err
 	}
@@ -975,6 +1096,15 @@ func (s *Store) DeleteRetentionPolicy(database, name string) error {
 		// unknown database, nothing to do
 		return nil
 	}
+	s.mu.RUnlock()
+
+	if err := s.deleteOffloadedShards(func(o *offloadedShard) bool {
+		return o.database == database && o.retentionPolicy == name
+	}); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
 	shards := s.filterShards(func(sh *Shard) bool {
 		return sh.database == database && sh.retentionPolicy == name
 	})
@@ -1004,6 +1134,16 @@ func (s *Store) DeleteRetentionPolicy(database, name string) error {
 	if err := os.RemoveAll(filepath.Join(s.path, database, name)); err != nil {
 		return err
 	}
+	if coldDir := s.EngineOptions.Config.ColdDir; coldDir != "" {
+		if err := os.RemoveAll(filepath.Join(coldDir, database, name)); err != nil {
+			return err
+		}
+	}
+	if cacheDir := s.EngineOptions.Config.OffloadCacheDir; cacheDir != "" {
+		if err := os.RemoveAll(filepath.Join(cacheDir, database, name)); err != nil {
+			return err
+		}
+	}
 
 	// Remove the retention policy folder from the the WAL.
 	if err := os.RemoveAll(filepath.Join(s.EngineOptions.Config.WALDir, database, name)); err != nil {
@@ -1113,11 +1253,18 @@ func (s *Store) walkShards(shards []*Shard, fn func(sh *Shard) error) error {
 	return err
 }
 
-// ShardIDs returns a slice of all ShardIDs under management.
+// ShardIDs returns a slice of all ShardIDs under management, including
+// offloaded shards.
 func (s *Store) ShardIDs() []uint64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.shardIDs()
+	a := s.shardIDs()
+	for id, o := range s.offloaded {
+		if o.cached == nil {
+			a = append(a, id)
+		}
+	}
+	return a
 }
 
 func (s *Store) shardIDs() []uint64 {
@@ -1309,12 +1456,14 @@ func (s *Store) MeasurementsSketches(ctx context.Context, database string) (esti
 // BackupShard will get the shard and have the engine backup since the passed in
 // time to the writer.
 func (s *Store) BackupShard(id uint64, since time.Time, w io.Writer) error {
-	shard := s.Shard(id)
-	if shard == nil {
+	shard, err := s.loadShard(id)
+	if err == ErrShardNotFound {
 		return fmt.Errorf("shard %d doesn't exist on this server", id)
+	} else if err != nil {
+		return err
 	}
 
-	path, err := relativePath(s.path, shard.path)
+	path, err := relativePath(s.shardRoot(shard), shard.path)
 	if err != nil {
 		return err
 	}
@@ -1323,12 +1472,14 @@ func (s *Store) BackupShard(id uint64, since time.Time, w io.Writer) error {
 }
 
 func (s *Store) ExportShard(id uint64, start time.Time, end time.Time, w io.Writer) error {
-	shard := s.Shard(id)
-	if shard == nil {
+	shard, err := s.loadShard(id)
+	if err == ErrShardNotFound {
 		return fmt.Errorf("shard %d doesn't exist on this server", id)
+	} else if err != nil {
+		return err
 	}
 
-	path, err := relativePath(s.path, shard.path)
+	path, err := relativePath(s.shardRoot(shard), shard.path)
 	if err != nil {
 		return err
 	}
@@ -1344,7 +1495,7 @@ func (s *Store) RestoreShard(id uint64, r io.Reader) error {
 		return fmt.Errorf("shard %d doesn't exist on this server", id)
 	}
 
-	path, err := relativePath(s.path, shard.path)
+	path, err := relativePath(s.shardRoot(shard), shard.path)
 	if err != nil {
 		return err
 	}
@@ -1362,7 +1513,7 @@ func (s *Store) ImportShard(id uint64, r io.Reader) error {
 		return fmt.Errorf("shard %d doesn't exist on this server", id)
 	}
 
-	path, err := relativePath(s.path, shard.path)
+	path, err := relativePath(s.shardRoot(shard), shard.path)
 	if err != nil {
 		return err
 	}
@@ -1370,6 +1521,18 @@ func (s *Store) ImportShard(id uint64, r io.Reader) error {
 	return shard.Import(r, path)
 }
 
+// MergeShard imports the files of another shard, archived by BackupShard
+// or ExportShard under basePath, to a given shard. The files are added as
+// new files, so the data of several shards can be merged in one.
+func (s *Store) MergeShard(id uint64, basePath string, r io.Reader) error {
+	shard := s.Shard(id)
+	if shard == nil {
+		return fmt.Errorf("shard %d doesn't exist on this server", id)
+	}
+
+	return shard.Import(r, basePath)
+}
+
 // ShardRelativePath will return the relative path to the shard, i.e.,
 // <database>/<retention>/<id>.
 func (s *Store) ShardRelativePath(id uint64) (string, error) {
@@ -1377,7 +1540,7 @@ func (s *Store) ShardRelativePath(id uint64) (string, error) {
 	if shard == nil {
 		return "", fmt.Errorf("shard %d doesn't exist on this server", id)
 	}
-	return relativePath(s.path, shard.path)
+	return relativePath(s.shardRoot(shard), shard.path)
 }
 
 // DeleteSeries loops through the local shards and deletes the series data for
@@ -1481,6 +1644,90 @@ func (s *Store) DeleteSeries(database string, sources []influxql.Source, conditi
 	})
 }
 
+// DeleteFields loops through the local shards and deletes the values of fields
+// from the measurements of sources, or from all measurements if sources is
+// empty. The condition may only restrict the time range of the delete.
+func (s *Store) DeleteFields(database string, sources []influxql.Source, fields []string, condition influxql.Expr) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// Expand regex expressions in the FROM clause.
+	a, err := s.ExpandSources(sources)
+	if err != nil {
+		return err
+	} else if len(sources) > 0 && len(a) == 0 {
+		return nil
+	}
+	sources = a
+
+	// Determine deletion time range.
+	condition, timeRange, err := influxql.ConditionExpr(condition, nil)
+	if err != nil {
+		return err
+	} else if condition != nil {
+		return errors.New("only time conditions are supported when deleting fields")
+	}
+
+	var min, max int64
+	if !timeRange.Min.IsZero() {
+		min = timeRange.Min.UnixNano()
+	} else {
+		min = influxql.MinTime
+	}
+	if !timeRange.Max.IsZero() {
+		max = timeRange.Max.UnixNano()
+	} else {
+		max = influxql.MaxTime
+	}
+
+	s.mu.RLock()
+	if s.sfiles[database] == nil {
+		s.mu.RUnlock()
+		// No series file means nothing has been written to this DB and thus nothing to delete.
+		return nil
+	}
+	shards := s.filterShards(byDatabase(database))
+	epochs := s.epochsForShards(shards)
+	s.mu.RUnlock()
+
+	limit := limiter.NewFixed(s.EngineOptions.Config.MaxConcurrentDeletes)
+
+	return s.walkShards(shards, func(sh *Shard) error {
+		// Determine list of measurements from sources.
+		// Use all measurements if no FROM clause was provided.
+		var names []string
+		if len(sources) > 0 {
+			for _, source := range sources {
+				names = append(names, source.(*influxql.Measurement).Name)
+			}
+		} else {
+			if err := sh.ForEachMeasurementName(func(name []byte) error {
+				names = append(names, string(name))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		sort.Strings(names)
+
+		limit.Take()
+		defer limit.Release()
+
+		// Wait for prior deletes and make conflicting writes wait for us.
+		waiter := epochs[sh.id].WaitDelete(newGuard(min, max, names, nil))
+		waiter.Wait()
+		defer waiter.Done()
+
+		for _, name := range names {
+			if err := sh.DeleteFieldRange([]byte(name), fields, min, max); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // ExpandSources expands sources against all local shards.
 func (s *Store) ExpandSources(sources influxql.Sources) (influxql.Sources, error) {
 	shards := func() Shards {
@@ -1506,15 +1753,25 @@ func (s *Store) WriteToShardWithContext(ctx context.Context, shardID uint64, poi
 	default:
 	}
 
-	sh := s.shards[shardID]
-	if sh == nil {
-		s.mu.RUnlock()
+	sh, o := s.shards[shardID], s.offloaded[shardID]
+	epoch := s.epochs[shardID]
+	s.mu.RUnlock()
+
+	if sh == nil && o != nil {
+		var err error
+		if sh, err = s.fetchShard(o); err != nil {
+			return err
+		}
+	} else if sh == nil {
 		return ErrShardNotFound
 	}
+	if o != nil {
+		o.touch()
+	}
 
-	epoch := s.epochs[shardID]
-
-	s.mu.RUnlock()
+	if err := s.diskQuotaExceeded(sh.database, sh.retentionPolicy); err != nil {
+		return err
+	}
 
 	// enter the epoch tracker
 	guards, gen := epoch.StartWrite()
@@ -12,6 +12,7 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -22,6 +23,7 @@ import (
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/deep"
+	"github.com/influxdata/influxdb/pkg/s3/s3test"
 	"github.com/influxdata/influxdb/pkg/slices"
 	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/tsdb"
@@ -542,6 +544,249 @@ func TestStore_CreateShardSnapShot(t *testing.T) {
 	}
 }
 
+func TestStore_MoveShard(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		s := NewStore(index)
+		s.EngineOptions.Config.ColdDir = t.TempDir()
+		if err := s.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA value=1 0`,
+			`mem,host=serverB value=2 10`,
+		)
+		hotPath := s.Shard(1).Path()
+
+		if err := s.MoveShard(context.Background(), 1, tsdb.TierCold, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		checkShard := func(tier string) {
+			t.Helper()
+			sh := s.Shard(1)
+			if sh == nil {
+				t.Fatal("expected shard")
+			} else if got := sh.Tier(); got != tier {
+				t.Fatalf("unexpected tier: got %s, exp %s", got, tier)
+			}
+			names, err := s.MeasurementNames(context.Background(), query.OpenAuthorizer, "db0", "", nil)
+			if err != nil {
+				t.Fatal(err)
+			} else if exp := [][]byte{[]byte("cpu"), []byte("mem")}; !reflect.DeepEqual(names, exp) {
+				t.Fatalf("unexpected measurements: %s", names)
+			}
+		}
+		checkShard(tsdb.TierCold)
+
+		if _, err := os.Stat(hotPath); !os.IsNotExist(err) {
+			t.Fatalf("expected hot shard path to be removed: %v", err)
+		} else if !strings.HasPrefix(s.Shard(1).Path(), s.EngineOptions.Config.ColdDir) {
+			t.Fatalf("unexpected shard path: %s", s.Shard(1).Path())
+		}
+
+		// The shard is loaded from the cold tier after a restart.
+		if err := s.Reopen(); err != nil {
+			t.Fatal(err)
+		}
+		checkShard(tsdb.TierCold)
+
+		if err := s.MoveShard(context.Background(), 1, tsdb.TierHot, nil); err != nil {
+			t.Fatal(err)
+		}
+		checkShard(tsdb.TierHot)
+		if got := s.Shard(1).Path(); got != hotPath {
+			t.Fatalf("unexpected shard path: got %s, exp %s", got, hotPath)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+// Ensure a move interrupted after it was committed is completed on open.
+func TestStore_MoveShard_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	s := NewStore(tsdb.TSI1IndexName)
+	s.EngineOptions.Config.ColdDir = t.TempDir()
+	if err := s.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	s.MustCreateShardWithData("db0", "rp0", 1,
+		`cpu,host=serverA value=1 0`,
+		`mem,host=serverB value=2 10`,
+	)
+	hotPath := s.Shard(1).Path()
+	if err := s.MoveShard(context.Background(), 1, tsdb.TierCold, nil); err != nil {
+		t.Fatal(err)
+	}
+	coldPath := s.Shard(1).Path()
+
+	// leaveShard leaves the directory the shard was moved from, as if the
+	// server stopped before removing it.
+	leaveShard := func() {
+		t.Helper()
+		if err := os.MkdirAll(hotPath, 0777); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(filepath.Join(hotPath, tsdb.TierMoveFile), []byte(coldPath), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkShard := func() {
+		t.Helper()
+		if err := s.Reopen(); err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Shard(1).Path(); got != coldPath {
+			t.Fatalf("unexpected shard path: got %s, exp %s", got, coldPath)
+		} else if _, err := os.Stat(hotPath); !os.IsNotExist(err) {
+			t.Fatalf("expected hot shard path to be removed: %v", err)
+		}
+		names, err := s.MeasurementNames(context.Background(), query.OpenAuthorizer, "db0", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		} else if exp := [][]byte{[]byte("cpu"), []byte("mem")}; !reflect.DeepEqual(names, exp) {
+			t.Fatalf("unexpected measurements: %s", names)
+		}
+	}
+
+	// The copy was renamed into place.
+	leaveShard()
+	checkShard()
+
+	// The copy was not renamed into place yet.
+	leaveShard()
+	if err := os.Rename(coldPath, coldPath+".tiering"); err != nil {
+		t.Fatal(err)
+	}
+	checkShard()
+}
+
+func TestStore_MoveShard_NoColdDir(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore(tsdb.TSI1IndexName)
+	defer s.Close()
+
+	if err := s.CreateShard("db0", "rp0", 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MoveShard(context.Background(), 1, tsdb.TierCold, nil); err != tsdb.ErrColdDirNotConfigured {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStore_OffloadShard(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		srv := s3test.NewServer()
+		defer srv.Close()
+
+		s := NewStore(index)
+		s.ObjectStore = srv.Client("bucket")
+		s.EngineOptions.Config.OffloadCacheDir = t.TempDir()
+		if err := s.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA value=1 0`,
+			`mem,host=serverB value=2 10`,
+		)
+		s.MustCreateShardWithData("db0", "rp0", 2, `disk,host=serverA value=3 20`)
+		hotPath := s.Shard(1).Path()
+
+		for _, id := range []uint64{1, 2} {
+			if err := s.OffloadShard(context.Background(), id); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if keys := srv.Keys(); len(keys) != 2 {
+			t.Fatalf("unexpected objects: %v", keys)
+		} else if s.Shard(1) != nil {
+			t.Fatal("expected offloaded shard to be unloaded")
+		} else if entries, err := os.ReadDir(hotPath); err != nil {
+			t.Fatal(err)
+		} else if len(entries) != 1 || entries[0].Name() != tsdb.OffloadManifestFile {
+			t.Fatalf("unexpected shard dir contents: %v", entries)
+		}
+		if ids := s.ShardIDs(); len(ids) != 2 {
+			t.Fatalf("unexpected shard ids: %v", ids)
+		}
+
+		checkShard := func(id uint64, exp ...string) {
+			t.Helper()
+			shards := s.Shards([]uint64{id})
+			if len(shards) != 1 {
+				t.Fatal("expected shard to be fetched")
+			} else if got := shards[0].Tier(); got != tsdb.TierRemote {
+				t.Fatalf("unexpected tier: %s", got)
+			}
+			for _, name := range exp {
+				if ok, err := shards[0].MeasurementExists([]byte(name)); err != nil {
+					t.Fatal(err)
+				} else if !ok {
+					t.Fatalf("expected measurement %s in shard %d", name, id)
+				}
+			}
+		}
+		checkShard(1, "cpu", "mem")
+
+		// The shard is opened from the offload cache after a restart.
+		if err := s.Reopen(); err != nil {
+			t.Fatal(err)
+		} else if s.Shard(1) == nil {
+			t.Fatal("expected cached shard to be loaded")
+		} else if s.Shard(2) != nil {
+			t.Fatal("expected uncached shard to be unloaded")
+		}
+
+		// Fetching another shard evicts the least recently used one once the
+		// cache is over its limit.
+		s.EngineOptions.Config.OffloadCacheMaxSize = 1
+		checkShard(2, "disk")
+		if s.Shard(1) != nil {
+			t.Fatal("expected shard to be evicted")
+		}
+		checkShard(1, "cpu", "mem")
+
+		if err := s.DeleteShard(1); err != nil {
+			t.Fatal(err)
+		} else if keys := srv.Keys(); len(keys) != 1 {
+			t.Fatalf("unexpected objects: %v", keys)
+		} else if _, err := os.Stat(hotPath); !os.IsNotExist(err) {
+			t.Fatalf("expected shard path to be removed: %v", err)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
+func TestStore_OffloadShard_NoObjectStore(t *testing.T) {
+	t.Parallel()
+
+	s := MustOpenStore(tsdb.TSI1IndexName)
+	defer s.Close()
+
+	if err := s.CreateShard("db0", "rp0", 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.OffloadShard(context.Background(), 1); err != tsdb.ErrObjectStoreNotConfigured {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestStore_Open(t *testing.T) {
 	t.Parallel()
 
@@ -671,6 +916,134 @@ func TestStore_Open_InvalidShard(t *testing.T) {
 }
 
 // Ensure shards can create iterators.
+func TestStore_RollupShard(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=serverA value=1 0`,
+			`cpu,host=serverA value=2 10`,
+			`cpu,host=serverA value=5 70`,
+		)
+
+		if err := s.RollupShard(context.Background(), 1, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		readPoints := func(field string) []*query.FloatPoint {
+			t.Helper()
+			itr, err := s.Shard(1).CreateIterator(context.Background(), &influxql.Measurement{Name: "cpu"}, query.IteratorOptions{
+				Expr:      influxql.MustParseExpr(field),
+				Ascending: true,
+				StartTime: influxql.MinTime,
+				EndTime:   influxql.MaxTime,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer itr.Close()
+
+			var points []*query.FloatPoint
+			fitr := itr.(query.FloatIterator)
+			for {
+				p, err := fitr.Next()
+				if err != nil {
+					t.Fatal(err)
+				} else if p == nil {
+					return points
+				}
+				points = append(points, &query.FloatPoint{Time: p.Time, Value: p.Value})
+			}
+		}
+		readAggregate := func(call string) interface{} {
+			t.Helper()
+			itr, err := s.Shard(1).CreateIterator(context.Background(), &influxql.Measurement{Name: "cpu"}, query.IteratorOptions{
+				Expr:      influxql.MustParseExpr(call),
+				Ascending: true,
+				StartTime: influxql.MinTime,
+				EndTime:   influxql.MaxTime,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer itr.Close()
+
+			switch itr := itr.(type) {
+			case query.FloatIterator:
+				if p, err := itr.Next(); err != nil || p == nil {
+					t.Fatalf("unexpected point: %v %v", p, err)
+				} else {
+					return p.Value
+				}
+			case query.IntegerIterator:
+				if p, err := itr.Next(); err != nil || p == nil {
+					t.Fatalf("unexpected point: %v %v", p, err)
+				} else {
+					return p.Value
+				}
+			}
+			t.Fatalf("unexpected iterator: %T", itr)
+			return nil
+		}
+		checkShard := func() {
+			t.Helper()
+			if got := s.Shard(1).RollupInterval(); got != time.Minute {
+				t.Fatalf("unexpected rollup interval: %s", got)
+			}
+			exp := []*query.FloatPoint{{Time: 0, Value: 1.5}, {Time: int64(time.Minute), Value: 5}}
+			if got := readPoints("value"); !deep.Equal(got, exp) {
+				t.Fatalf("unexpected points: %s", spew.Sdump(got))
+			}
+			exp = []*query.FloatPoint{{Time: 0, Value: 1}, {Time: int64(time.Minute), Value: 5}}
+			if got := readPoints("value_min"); !deep.Equal(got, exp) {
+				t.Fatalf("unexpected min points: %s", spew.Sdump(got))
+			}
+			if f := s.Shard(1).MeasurementFields([]byte("cpu")).Field("value_count"); f == nil || f.Type != influxql.Integer {
+				t.Fatalf("unexpected count field: %v", f)
+			}
+
+			// Aggregates answered by the rollup fields read them rather
+			// than the means.
+			if got := readAggregate("min(value)"); got != 1.0 {
+				t.Fatalf("unexpected min: %v", got)
+			} else if got := readAggregate("max(value)"); got != 5.0 {
+				t.Fatalf("unexpected max: %v", got)
+			} else if got := readAggregate("count(value)"); got != int64(3) {
+				t.Fatalf("unexpected count: %v", got)
+			}
+
+			// Sums and means weight the mean of each window by its count.
+			if got := readAggregate("sum(value)"); got != 8.0 {
+				t.Fatalf("unexpected sum: %v", got)
+			} else if got := readAggregate("mean(value)"); got != 8.0/3 {
+				t.Fatalf("unexpected mean: %v", got)
+			}
+		}
+		checkShard()
+
+		// The rollup is kept after a restart.
+		if err := s.Reopen(); err != nil {
+			t.Fatal(err)
+		}
+		checkShard()
+
+		// A shard is only rolled up again to a multiple of its interval.
+		if err := s.RollupShard(context.Background(), 1, 90*time.Second); err != tsdb.ErrShardRolledUp {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := s.RollupShard(context.Background(), 2, time.Minute); err != tsdb.ErrShardNotFound {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(t, index) })
+	}
+}
+
 func TestShards_CreateIterator(t *testing.T) {
 	t.Parallel()
 
@@ -834,6 +1207,164 @@ func TestStore_BackupRestoreShard(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_RebuildShardIndex(t *testing.T) {
+	test := func(index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=a value=1 0`,
+			`cpu,host=b value=2 10`,
+			`mem value=3 20`,
+		)
+
+		if err := s.RebuildShardIndex(context.Background(), 2); err != tsdb.ErrShardNotFound {
+			t.Fatalf("unexpected error: %v", err)
+		} else if err := s.RebuildShardIndex(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+
+		sh := s.Shard(1)
+		if got, exp := sh.IndexType(), tsdb.TSI1IndexName; got != exp {
+			t.Fatalf("got index type %q, expected %q", got, exp)
+		} else if n := sh.SeriesN(); n != 3 {
+			t.Fatalf("unexpected series count: %d", n)
+		}
+
+		names, err := s.MeasurementNames(context.Background(), query.OpenAuthorizer, "db0", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		} else if got, exp := names, [][]byte{[]byte("cpu"), []byte("mem")}; !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected measurements: %s", got)
+		}
+
+		// The database no longer mixes index types, so deletes are allowed.
+		if err := s.DeleteSeries("db0", []influxql.Source{&influxql.Measurement{Name: "mem"}}, nil); err != nil {
+			t.Fatal(err)
+		} else if n := sh.SeriesN(); n != 2 {
+			t.Fatalf("unexpected series count after delete: %d", n)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(index) })
+	}
+}
+
+func TestStore_CollectSeries(t *testing.T) {
+	test := func(index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu,host=a value=1 0`,
+			`cpu,host=b value=2 10`,
+		)
+		sfile, err := s.Shard(1).SeriesFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A series in the series file that no shard references.
+		ids, err := sfile.CreateSeriesListIfNotExists([][]byte{[]byte("mem")}, []models.Tags{nil})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stats, err := s.CollectSeries(context.Background(), "db0")
+		if err != nil {
+			t.Fatal(err)
+		} else if stats.SeriesN != 1 {
+			t.Fatalf("unexpected collected series: %d", stats.SeriesN)
+		} else if !sfile.IsDeleted(ids[0]) {
+			t.Fatal("expected unreferenced series to be deleted")
+		}
+
+		// Referenced series are left alone.
+		if stats, err := s.CollectSeries(context.Background(), "db0"); err != nil {
+			t.Fatal(err)
+		} else if stats.SeriesN != 0 {
+			t.Fatalf("unexpected collected series on second run: %d", stats.SeriesN)
+		}
+		s.MustWriteToShardString(1, "cpu,host=a value=3 20")
+		if n := s.Shard(1).SeriesN(); n != 2 {
+			t.Fatalf("unexpected shard series count: %d", n)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) { test(index) })
+	}
+}
+
+func TestStore_MergeShard(t *testing.T) {
+	test := func(index string) {
+		s := MustOpenStore(index)
+		defer s.Close()
+
+		s.MustCreateShardWithData("db0", "rp0", 1,
+			`cpu value=1 0`,
+			`cpu value=2 10`,
+		)
+		s.MustCreateShardWithData("db0", "rp0", 2,
+			`cpu value=3 20`,
+			`mem value=4 30`,
+		)
+		if err := s.CreateShard("db0", "rp0", 3, true); err != nil {
+			t.Fatal(err)
+		}
+
+		// Merge both shards into the third one.
+		for _, id := range []uint64{1, 2} {
+			var buf bytes.Buffer
+			if err := s.BackupShard(id, time.Time{}, &buf); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.MergeShard(3, filepath.Join("db0", "rp0", strconv.FormatUint(id, 10)), &buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		m := &influxql.Measurement{Name: "cpu"}
+		itr, err := s.Shard(3).CreateIterator(context.Background(), m, query.IteratorOptions{
+			Expr:      influxql.MustParseExpr(`value`),
+			Ascending: true,
+			StartTime: influxql.MinTime,
+			EndTime:   influxql.MaxTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer itr.Close()
+		fitr := itr.(query.FloatIterator)
+
+		for i, exp := range []float64{1, 2, 3} {
+			p, err := fitr.Next()
+			if err != nil {
+				t.Fatal(err)
+			} else if p == nil || p.Value != exp {
+				t.Fatalf("unexpected point(%d): %s", i, spew.Sdump(p))
+			}
+		}
+		if p, err := fitr.Next(); err != nil {
+			t.Fatal(err)
+		} else if p != nil {
+			t.Fatalf("unexpected point: %s", spew.Sdump(p))
+		}
+
+		if n := s.Shard(3).SeriesN(); n != 2 {
+			t.Fatalf("unexpected series count: %d", n)
+		}
+	}
+
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			test(index)
+		})
+	}
+}
+
 func TestStore_Shard_SeriesN(t *testing.T) {
 	t.Parallel()
 
@@ -1412,6 +1943,179 @@ func TestStore_Cardinality_Limit_On_InMem_Index(t *testing.T) {
 	}
 }
 
+func TestStore_CardinalityLimits(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			s := NewStore(index)
+			s.EngineOptions.Config.MaxSeriesPerMeasurement = 2
+			s.EngineOptions.CardinalityLimits = func(database string) *tsdb.CardinalityLimits {
+				if database != "db0" {
+					return nil
+				}
+				return &tsdb.CardinalityLimits{
+					Measurements: map[string]int{"mem": -1},
+					TagKeys:      map[string]int{"pod": 2},
+				}
+			}
+			if err := s.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+
+			s.MustCreateShardWithData("db0", "rp0", 0,
+				`cpu,host=a value=1 0`,
+				`cpu,host=b value=1 0`,
+			)
+
+			write := func(data string) error {
+				points, err := models.ParsePointsString(data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return s.WriteToShard(0, points)
+			}
+
+			// Existing series can still be written, new ones are dropped.
+			err := write("cpu,host=a value=2 10\ncpu,host=c value=2 10\ncpu,host=d value=2 10")
+			if exp := `partial write: max-series-per-measurement limit exceeded (2/2): measurement="cpu" dropped=2`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// A disabled measurement limit.
+			if err := write("mem,host=a value=1 0\nmem,host=b value=1 0\nmem,host=c value=1 0"); err != nil {
+				t.Fatal(err)
+			}
+
+			// Tag key limits, counting the values created by the write.
+			err = write("mem,pod=a value=1 0\nmem,pod=b value=1 0\nmem,pod=b,host=a value=1 0\nmem,pod=c value=1 0")
+			if exp := `partial write: max-values-per-tag limit exceeded (2/2): measurement="mem" tag="pod" value="c" dropped=1`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if n, err := s.SeriesCardinality(context.Background(), "db0"); err != nil {
+				t.Fatal(err)
+			} else if n != 8 {
+				t.Fatalf("unexpected series cardinality: %d", n)
+			}
+
+			// Deleted series no longer count.
+			if err := s.DeleteSeries("db0", []influxql.Source{&influxql.Measurement{Name: "cpu"}}, influxql.MustParseExpr(`host = 'b'`)); err != nil {
+				t.Fatal(err)
+			}
+			if err := write("cpu,host=c value=3 20"); err != nil {
+				t.Fatal(err)
+			}
+			err = write("cpu,host=d value=3 20")
+			if exp := `partial write: max-series-per-measurement limit exceeded (2/2): measurement="cpu" dropped=1`; err == nil || err.Error() != exp {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_CardinalityReport(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			s := MustOpenStore(index)
+			defer s.Close()
+
+			s.MustCreateShardWithData("db0", "rp0", 0,
+				`cpu,host=a,region=west value=1 0`,
+				`cpu,host=b,region=west value=1 0`,
+				`cpu,host=c,region=east value=1 0`,
+				`mem,host=a value=1 0`,
+			)
+			s.MustCreateShardWithData("db0", "rp0", 1,
+				`cpu,host=a,region=west value=1 0`,
+				`cpu,host=d,region=west value=1 0`,
+				`disk,path=/ value=1 0`,
+			)
+
+			report, err := s.CardinalityReport(context.Background(), "db0", 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if exp := []tsdb.MeasurementCardinality{
+				{Measurement: "cpu", SeriesN: 4},
+				{Measurement: "disk", SeriesN: 1},
+			}; !reflect.DeepEqual(report.Measurements, exp) {
+				t.Fatalf("unexpected measurements: %+v", report.Measurements)
+			}
+			if exp := []tsdb.TagKeyCardinality{
+				{Measurement: "cpu", Key: "host", ValuesN: 4},
+				{Measurement: "cpu", Key: "region", ValuesN: 2},
+			}; !reflect.DeepEqual(report.TagKeys, exp) {
+				t.Fatalf("unexpected tag keys: %+v", report.TagKeys)
+			}
+			if report.SeriesN != 6 || report.MeasurementsN != 3 {
+				t.Fatalf("unexpected cardinality: series=%d measurements=%d", report.SeriesN, report.MeasurementsN)
+			}
+
+			// The next report has the growth since the previous one.
+			s.MustWriteToShardString(1, `cpu,host=e,region=west value=1 0`)
+			report, err = s.CardinalityReport(context.Background(), "db0", 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m := report.Measurements; len(m) != 1 || m[0].SeriesN != 5 || m[0].Growth <= 0 {
+				t.Fatalf("unexpected measurements: %+v", m)
+			}
+			if k := report.TagKeys; len(k) != 1 || k[0].ValuesN != 5 || k[0].Growth <= 0 {
+				t.Fatalf("unexpected tag keys: %+v", k)
+			}
+		})
+	}
+}
+
+func TestStore_DeleteFields(t *testing.T) {
+	for _, index := range tsdb.RegisteredIndexes() {
+		t.Run(index, func(t *testing.T) {
+			s := MustOpenStore(index)
+			defer s.Close()
+
+			s.MustCreateShardWithData("db0", "rp0", 0,
+				`cpu,host=a value=1,debug=1 0`,
+				`cpu,host=b debug=2 0`,
+				`mem,host=a debug=1 0`,
+				`disk,host=a debug=1 0`,
+				`disk,host=a debug=2 100`,
+			)
+
+			// Only time conditions are supported.
+			if err := s.DeleteFields("db0", nil, []string{"debug"}, influxql.MustParseExpr(`host = 'a'`)); err == nil {
+				t.Fatal("expected error")
+			}
+
+			if err := s.DeleteFields("db0", []influxql.Source{&influxql.Measurement{Name: "cpu"}, &influxql.Measurement{Name: "mem"}}, []string{"debug"}, nil); err != nil {
+				t.Fatal(err)
+			}
+			sh := s.Shard(0)
+			if mf := sh.MeasurementFields([]byte("cpu")); mf.HasField("debug") || !mf.HasField("value") {
+				t.Fatalf("unexpected cpu fields: %v", mf.FieldKeys())
+			}
+			// The measurement had no other field, so it is dropped.
+			if ok, err := sh.MeasurementExists([]byte("mem")); err != nil {
+				t.Fatal(err)
+			} else if ok {
+				t.Fatal("expected mem to be dropped")
+			}
+
+			// The field is kept while values remain outside the time range.
+			if err := s.DeleteFields("db0", []influxql.Source{&influxql.Measurement{Name: "disk"}}, []string{"debug"}, influxql.MustParseExpr(`time < 50`)); err != nil {
+				t.Fatal(err)
+			} else if !sh.MeasurementFields([]byte("disk")).HasField("debug") {
+				t.Fatal("expected disk debug field")
+			}
+			if err := s.DeleteFields("db0", []influxql.Source{&influxql.Measurement{Name: "disk"}}, []string{"debug"}, influxql.MustParseExpr(`time >= 50`)); err != nil {
+				t.Fatal(err)
+			} else if sh.MeasurementFields([]byte("disk")).HasField("debug") {
+				t.Fatal("unexpected disk debug field")
+			}
+		})
+	}
+}
+
 func TestStore_Sketches(t *testing.T) {
 	t.Parallel()
 
@@ -2563,9 +3267,14 @@ func (s *Store) Reopen() error {
 		return err
 	}
 
+	config, objectStore := s.EngineOptions.Config, s.ObjectStore
 	s.Store = tsdb.NewStore(s.Path())
+	s.ObjectStore = objectStore
 	s.EngineOptions.IndexVersion = s.index
 	s.EngineOptions.Config.WALDir = filepath.Join(s.Path(), "wal")
+	s.EngineOptions.Config.ColdDir = config.ColdDir
+	s.EngineOptions.Config.OffloadCacheDir = config.OffloadCacheDir
+	s.EngineOptions.Config.OffloadCacheMaxSize = config.OffloadCacheMaxSize
 	s.EngineOptions.Config.TraceLoggingEnabled = true
 
 	if testing.Verbose() {
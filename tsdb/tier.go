@@ -0,0 +1,403 @@
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/pkg/file"
+	"github.com/influxdata/influxdb/pkg/limiter"
+	"go.uber.org/zap"
+)
+
+// Storage tiers that a shard's data directory can be placed on.
+const (
+	// TierHot is the primary data directory.
+	TierHot = "hot"
+
+	// TierCold is the secondary data directory set by Config.ColdDir.
+	TierCold = "cold"
+
+	// TierRemote is object storage. Shards on this tier are accessed from a
+	// local copy in Config.OffloadCacheDir.
+	TierRemote = "remote"
+)
+
+// tierStagingExt is the extension of the directory a shard is copied into
+// before it is moved to another tier.
+const tierStagingExt = ".tiering"
+
+// TierMoveFile is the name of the file written in a shard directory once the
+// shard's copy on another tier replaces it. It holds the path of the copy, so
+// that a move interrupted before the directory is removed is completed when
+// the store is opened.
+const TierMoveFile = "moved"
+
+var (
+	// ErrColdDirNotConfigured is returned when moving a shard to the cold tier
+	// without a cold data directory.
+	ErrColdDirNotConfigured = errors.New("cold data directory not configured")
+
+	// ErrShardModified is returned when a shard is written to while it is
+	// being moved to another tier. The move can be retried later.
+	ErrShardModified = errors.New("shard modified during move")
+)
+
+// Tier returns the storage tier the shard's data directory is on.
+func (s *Shard) Tier() string {
+	if cacheDir := s.options.Config.OffloadCacheDir; cacheDir != "" {
+		if strings.HasPrefix(filepath.Clean(s.path), filepath.Clean(cacheDir)+string(filepath.Separator)) {
+			return TierRemote
+		}
+	}
+	if coldDir := s.options.Config.ColdDir; coldDir != "" {
+		if strings.HasPrefix(filepath.Clean(s.path), filepath.Clean(coldDir)+string(filepath.Separator)) {
+			return TierCold
+		}
+	}
+	return TierHot
+}
+
+// tierRoots returns the data directories that shards are loaded from.
+func (s *Store) tierRoots() []string {
+	roots := []string{s.path}
+	if coldDir := s.EngineOptions.Config.ColdDir; coldDir != "" {
+		roots = append(roots, coldDir)
+	}
+	return roots
+}
+
+// tierRoot returns the data directory for the given tier.
+func (s *Store) tierRoot(tier string) (string, error) {
+	switch tier {
+	case TierHot:
+		return s.path, nil
+	case TierCold:
+		if s.EngineOptions.Config.ColdDir == "" {
+			return "", ErrColdDirNotConfigured
+		}
+		return s.EngineOptions.Config.ColdDir, nil
+	case TierRemote:
+		return s.EngineOptions.Config.OffloadCacheDir, nil
+	default:
+		return "", fmt.Errorf("unknown storage tier %q", tier)
+	}
+}
+
+// shardRoot returns the data directory that holds the shard.
+func (s *Store) shardRoot(sh *Shard) string {
+	if root, err := s.tierRoot(sh.Tier()); err == nil {
+		return root
+	}
+	return s.path
+}
+
+// MoveShard moves a shard's data directory to the given storage tier. WAL
+// files and the database's series file are not moved.
+//
+// The shard's TSM files are copied from a snapshot, then its index and other
+// files, while the shard stays online, throttled to rate if it is non-nil.
+// The shard is then closed, the files changed since are copied again, and the
+// shard is reopened from its new location. Queries and writes against the
+// shard fail while it is closed. The store lock is only taken to swap in the
+// reopened shard. If the shard is written to during the copy,
+// ErrShardModified is returned and the shard is left where it was.
+func (s *Store) MoveShard(ctx context.Context, id uint64, tier string, rate limiter.Rate) error {
+	if tier == TierRemote {
+		return fmt.Errorf("cannot move shard to %s tier, offload it instead", tier)
+	}
+	root, err := s.tierRoot(tier)
+	if err != nil {
+		return err
+	}
+
+	sh := s.Shard(id)
+	if sh == nil {
+		return ErrShardNotFound
+	} else if sh.Tier() == tier {
+		return nil
+	} else if sh.Tier() == TierRemote {
+		return ErrShardOffloaded
+	}
+
+	path := filepath.Join(root, sh.database, sh.retentionPolicy, strconv.FormatUint(id, 10))
+	staging := path + tierStagingExt
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	} else if err := os.MkdirAll(staging, 0700); err != nil {
+		return err
+	}
+	moved := false
+	defer func() {
+		if !moved {
+			os.RemoveAll(staging)
+		}
+	}()
+
+	// Copy a point-in-time snapshot of the TSM files while the shard is online,
+	// then the index and the other files of the shard.
+	snapshot, err := sh.CreateSnapshot(false)
+	if err != nil {
+		return err
+	}
+	modified := sh.LastModified()
+	err = syncDir(ctx, snapshot, staging, rate)
+	if rmErr := os.RemoveAll(snapshot); err == nil {
+		err = rmErr
+	}
+	if err == nil {
+		err = syncDir(ctx, sh.path, staging, rate)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !sh.LastModified().Equal(modified) {
+		return ErrShardModified
+	}
+
+	sh.mu.RLock()
+	enabled := sh.enabled
+	sh.mu.RUnlock()
+
+	if err := sh.Close(); err != nil {
+		return err
+	}
+
+	// Bring over the files changed since the copy. This runs unthrottled since
+	// the shard is offline. The marker then commits the move.
+	err = syncDir(context.Background(), sh.path, staging, nil)
+	if err == nil {
+		err = writeTierMoveFile(sh.path, path)
+	}
+	if err == nil {
+		err = file.RenameFile(staging, path)
+	}
+	var other *Shard
+	if err == nil {
+		moved = true
+		other = NewShard(id, path, sh.walPath, sh.sfile, sh.options)
+		other.WithLogger(s.baseLogger)
+		other.EnableOnOpen = enabled
+		other.CompactionDisabled = sh.CompactionDisabled
+		if err = s.OpenShard(other, true); err != nil {
+			other = nil
+		}
+	}
+
+	// Swap in the moved shard, unless the shard was deleted or replaced while
+	// it was closed.
+	s.mu.Lock()
+	current := s.shards[id] == sh
+	if current && other != nil {
+		s.shards[id] = other
+	}
+	s.mu.Unlock()
+
+	if other != nil {
+		if !current {
+			other.Close()
+			os.RemoveAll(path)
+			return ErrShardNotFound
+		}
+		if rmErr := os.RemoveAll(sh.path); rmErr != nil {
+			s.Logger.Warn("Unable to remove moved shard", logger.Shard(id), zap.String("path", sh.path), zap.Error(rmErr))
+		}
+		return nil
+	}
+
+	// Put the data back so the shard can be reopened from where it was.
+	if moved {
+		os.RemoveAll(path)
+	}
+	if rmErr := os.Remove(filepath.Join(sh.path, TierMoveFile)); rmErr != nil && !os.IsNotExist(rmErr) {
+		s.Logger.Error("Unable to remove shard move marker", logger.Shard(id), zap.Error(rmErr))
+	}
+	if !current {
+		return ErrShardNotFound
+	}
+	sh.EnableOnOpen = enabled
+	if openErr := s.OpenShard(sh, true); openErr != nil {
+		s.Logger.Error("Unable to reopen shard after failed move", logger.Shard(id), zap.Error(openErr))
+	}
+	return err
+}
+
+// writeTierMoveFile records in the shard directory dir that it is replaced by
+// its copy at path.
+func writeTierMoveFile(dir, path string) error {
+	f, err := os.Create(filepath.Join(dir, TierMoveFile))
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(path); err != nil {
+		f.Close()
+		return err
+	} else if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	} else if err := f.Close(); err != nil {
+		return err
+	}
+	return file.SyncDir(dir)
+}
+
+// finishShardMoves completes the tier moves interrupted after their commit,
+// before the shards are loaded. The copy of the shard is renamed into place if
+// needed and the directory it replaces is removed. If the copy is missing, the
+// marker is removed and the shard is loaded from where it is.
+func (s *Store) finishShardMoves() error {
+	for _, root := range s.tierRoots() {
+		markers, err := filepath.Glob(filepath.Join(root, "*", "*", "*", TierMoveFile))
+		if err != nil {
+			return err
+		}
+		for _, marker := range markers {
+			dir := filepath.Dir(marker)
+			b, err := os.ReadFile(marker)
+			if err != nil {
+				return err
+			}
+			path := string(b)
+
+			if _, err := os.Stat(path + tierStagingExt); err == nil {
+				if err := file.RenameFile(path+tierStagingExt, path); err != nil {
+					return err
+				}
+			}
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				s.Logger.Warn("Moved shard copy not found, keeping shard", zap.String("path", dir), zap.String("copy_path", path))
+				if err := os.Remove(marker); err != nil {
+					return err
+				}
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			s.Logger.Info("Completing shard move", zap.String("path", dir), zap.String("copy_path", path))
+			if err := os.RemoveAll(dir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncDir copies the files under src to dst, skipping temporary files and
+// files that already exist in dst with the same size and modification time,
+// and removes files in dst that are no longer in src. Copies keep the
+// modification time of their source. Files removed from src while they are
+// synced are skipped.
+func syncDir(ctx context.Context, src, dst string, rate limiter.Rate) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Skip snapshots, partially written files and staging directories.
+		name := e.Name()
+		if strings.HasSuffix(name, ".tmp") || strings.HasSuffix(name, tierStagingExt) {
+			continue
+		}
+		keep[name] = struct{}{}
+
+		srcPath, dstPath := filepath.Join(src, name), filepath.Join(dst, name)
+		if e.IsDir() {
+			if err := os.MkdirAll(dstPath, 0700); err != nil {
+				return err
+			} else if err := syncDir(ctx, srcPath, dstPath, rate); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fi, err := e.Info()
+		if os.IsNotExist(err) {
+			delete(keep, name)
+			continue
+		} else if err != nil {
+			return err
+		}
+		if dfi, err := os.Stat(dstPath); err == nil && dfi.Size() == fi.Size() && dfi.ModTime().Equal(fi.ModTime()) {
+			continue
+		}
+		if err := copyFileRate(ctx, srcPath, dstPath, rate); os.IsNotExist(err) {
+			delete(keep, name)
+			continue
+		} else if err != nil {
+			return err
+		} else if err := os.Chtimes(dstPath, fi.ModTime(), fi.ModTime()); err != nil {
+			return err
+		}
+	}
+
+	existing, err := os.ReadDir(dst)
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if _, ok := keep[e.Name()]; !ok {
+			if err := os.RemoveAll(filepath.Join(dst, e.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return file.SyncDir(dst)
+}
+
+// copyFileRate copies src to dst. A hard link is used where possible,
+// otherwise the data is copied and throttled to rate if it is non-nil.
+func copyFileRate(ctx context.Context, src, dst string, rate limiter.Rate) error {
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	w := limiter.NewWriterWithRate(out, rate)
+	if _, err := io.Copy(w, ctxReader{ctx: ctx, r: in}); err != nil {
+		w.Close()
+		return err
+	} else if err := w.Sync(); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// ctxReader is an io.Reader that stops reading once its context is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
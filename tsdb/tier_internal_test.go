@@ -0,0 +1,56 @@
+package tsdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSyncDir(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	write := func(dir, name, data string, mtime time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		} else if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(name string) string {
+		t.Helper()
+		b, err := os.ReadFile(filepath.Join(dst, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	// A file of the same size is copied again if it was modified since.
+	now := time.Now().Truncate(time.Second)
+	write(src, "MANIFEST", "new", now)
+	write(dst, "MANIFEST", "old", now.Add(-time.Minute))
+	write(dst, "L0-00000001.tsl", "stale", now)
+	write(src, "data.tmp", "partial", now)
+	if err := syncDir(context.Background(), src, dst, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := read("MANIFEST"); got != "new" {
+		t.Fatalf("unexpected MANIFEST: %s", got)
+	}
+	for _, name := range []string{"L0-00000001.tsl", "data.tmp"} {
+		if _, err := os.Stat(filepath.Join(dst, name)); !os.IsNotExist(err) {
+			t.Fatalf("unexpected file %s: %v", name, err)
+		}
+	}
+
+	// Files copied keep their modification time, so they are not copied
+	// again.
+	if fi, err := os.Stat(filepath.Join(dst, "MANIFEST")); err != nil {
+		t.Fatal(err)
+	} else if !fi.ModTime().Equal(now) {
+		t.Fatalf("unexpected modification time: %s", fi.ModTime())
+	}
+}
@@ -0,0 +1,59 @@
+package tsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CorruptionError is returned when a verification pass finds data on disk
+// that fails an integrity check. Other errors returned from Verify indicate
+// the check itself could not be completed.
+type CorruptionError struct {
+	// Path of the file that failed verification.
+	Path string
+
+	// Reason describes the failed check.
+	Reason string
+}
+
+func (e *CorruptionError) Error() string {
+	return fmt.Sprintf("corrupt %s: %s", e.Path, e.Reason)
+}
+
+// IsCorruptionError returns true if err, or any error it wraps, is a CorruptionError.
+func IsCorruptionError(err error) bool {
+	var e *CorruptionError
+	return errors.As(err, &e)
+}
+
+// VerifyStats summarizes the work done by a verification pass.
+type VerifyStats struct {
+	Files  int64 // Number of files checked.
+	Blocks int64 // Number of blocks or entries checked.
+	Bytes  int64 // Number of bytes read.
+}
+
+// Add accumulates the stats in other into s.
+func (s *VerifyStats) Add(other VerifyStats) {
+	s.Files += other.Files
+	s.Blocks += other.Blocks
+	s.Bytes += other.Bytes
+}
+
+// VerifyStatus is the result of the most recent verification of a shard.
+type VerifyStatus struct {
+	// Time the last verification pass completed. Zero if the shard has
+	// never been verified.
+	VerifiedAt time.Time
+
+	// Err holds the error returned by the last verification, if any.
+	Err error
+}
+
+// IndexVerifier is implemented by indexes that can check their on-disk
+// structures for consistency while they are online.
+type IndexVerifier interface {
+	Verify(ctx context.Context) error
+}